@@ -0,0 +1,195 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/cmd/kuscia/modules"
+	"github.com/secretflow/kuscia/pkg/backup"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+)
+
+type options struct {
+	configFile string
+	target     string
+}
+
+func (o *options) addFlags(command *cobra.Command) {
+	command.PersistentFlags().StringVarP(&o.configFile, "config", "c", filepath.Join(common.DefaultKusciaHomePath, "etc/conf/kuscia.yaml"),
+		"Kuscia config file of master")
+	command.PersistentFlags().StringVar(&o.target, "target", "", "Backup target, a local directory or s3://bucket/prefix, overrides backup.target of config file")
+}
+
+// manager builds the backup manager from the backup config of master.
+func (o *options) manager() (*backup.Manager, error) {
+	commonConfig := confloader.LoadCommonConfig(o.configFile)
+	mode := strings.ToLower(commonConfig.Mode)
+	if mode == common.RunModeLite {
+		return nil, fmt.Errorf("backup can only be used on master or autonomy")
+	}
+	kusciaConf := confloader.ReadConfig(o.configFile, mode)
+	config := kusciaConf.Backup
+	if o.target != "" {
+		config.Target = o.target
+	}
+	if !config.Enabled() {
+		return nil, fmt.Errorf("backup target is not configured, set backup.target in %s or --target", o.configFile)
+	}
+	clients, err := kubeconfig.CreateClientSetsFromKubeconfig(kusciaConf.Master.APIServer.KubeConfig, kusciaConf.Master.APIServer.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("create kube clients failed, %v", err)
+	}
+	return modules.NewBackupManager(&config, kusciaConf.DomainID, kusciaConf.RootDir, clients)
+}
+
+func NewBackupCommand(ctx context.Context) *cobra.Command {
+	opts := &options{}
+	command := &cobra.Command{
+		Use:          "backup",
+		Short:        "Back up kuscia resources and stores of master, list and verify backups",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		Example: `
+# back up to the target in config file
+kuscia backup
+
+# back up to a s3 bucket, the s3 endpoint and credential are read from config file
+kuscia backup --target s3://kuscia-backup/alice
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := opts.manager()
+			if err != nil {
+				return err
+			}
+			result, err := manager.Backup(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("backup %s created, resources: %d, stores: %s, size: %d, sha256: %s\n", result.Name, result.Resources,
+				strings.Join(result.Stores, ","), result.Size, result.SHA256)
+			return nil
+		},
+	}
+	opts.addFlags(command)
+	command.AddCommand(newListCommand(ctx, opts))
+	command.AddCommand(newVerifyCommand(ctx, opts))
+	return command
+}
+
+func newListCommand(ctx context.Context, opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List backups in target, the latest is the last",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := opts.manager()
+			if err != nil {
+				return err
+			}
+			infos, err := manager.List(ctx)
+			if err != nil {
+				return err
+			}
+			for _, info := range infos {
+				fmt.Printf("%s\t%s\n", info.Name, info.CreatedAt.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+}
+
+func newVerifyCommand(ctx context.Context, opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify NAME",
+		Short: "Verify the checksums of every file in a backup",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager, err := opts.manager()
+			if err != nil {
+				return err
+			}
+			manifest, err := manager.Verify(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("backup %s is intact, domain: %s, kuscia version: %s, files: %d\n", args[0], manifest.DomainID,
+				manifest.KusciaVersion, len(manifest.Files))
+			return nil
+		},
+	}
+}
+
+func NewRestoreCommand(ctx context.Context) *cobra.Command {
+	opts := &options{}
+	restoreOpts := &backup.RestoreOptions{}
+	at := ""
+	command := &cobra.Command{
+		Use:          "restore [NAME]",
+		Short:        "Restore kuscia resources and stores of master from a backup",
+		SilenceUsage: true,
+		Args:         cobra.MaximumNArgs(1),
+		Example: `
+# restore the latest backup
+kuscia restore
+
+# restore the state at a point in time, the latest backup created at or before it is used
+kuscia restore --at 2024-05-01T08:00:00Z --prune
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				if at != "" {
+					return fmt.Errorf("backup name and --at can't be set together")
+				}
+				restoreOpts.Name = args[0]
+			}
+			if at != "" {
+				t, err := time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("invalid --at %s, it should be in RFC3339 format, %v", at, err)
+				}
+				restoreOpts.At = t
+			}
+			manager, err := opts.manager()
+			if err != nil {
+				return err
+			}
+			result, err := manager.Restore(ctx, restoreOpts)
+			if err != nil {
+				return err
+			}
+			prefix := ""
+			if restoreOpts.DryRun {
+				prefix = "(dry run) "
+			}
+			fmt.Printf("%sbackup %s restored, resources: %d, pruned: %d, store rows: %d\n", prefix, result.Name, result.Resources,
+				result.Pruned, result.StoreRows)
+			return nil
+		},
+	}
+	opts.addFlags(command)
+	command.Flags().StringVar(&at, "at", "", "Point in time to restore in RFC3339 format, the latest backup created at or before it is used")
+	command.Flags().BoolVar(&restoreOpts.Prune, "prune", false, "Delete resources and store rows created after the backup")
+	command.Flags().BoolVar(&restoreOpts.DryRun, "dry-run", false, "Verify the backup and count the changes without applying them")
+	return command
+}
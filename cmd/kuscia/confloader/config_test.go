@@ -15,10 +15,14 @@
 package confloader
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 
+	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/web/asserts"
 )
 
@@ -48,3 +52,40 @@ func Test_defaultMasterOverwrite(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadKusciaConfig(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "kuscia.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: Autonomy\ndomainID: alice\nlogLevel: DEBUG\n"), 0600))
+
+	conf, err := LoadKusciaConfig(configFile)
+	assert.NoError(t, err)
+	assert.Equal(t, common.RunModeAutonomy, conf.RunMode)
+	assert.Equal(t, "alice", conf.DomainID)
+	assert.Equal(t, "DEBUG", conf.LogLevel)
+
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: Unknown\ndomainID: alice\n"), 0600))
+	_, err = LoadKusciaConfig(configFile)
+	assert.Error(t, err)
+
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: Autonomy\n"), 0600))
+	_, err = LoadKusciaConfig(configFile)
+	assert.Error(t, err)
+
+	_, err = LoadKusciaConfig(filepath.Join(dir, "not-exist.yaml"))
+	assert.Error(t, err)
+}
+
+func TestDefaultConfig(t *testing.T) {
+	conf, err := DefaultConfig(common.RunModeLite, "")
+	assert.NoError(t, err)
+	assert.Equal(t, common.RunModeLite, conf.RunMode)
+	assert.Equal(t, common.DefaultKusciaHomePath, conf.RootDir)
+
+	conf, err = DefaultConfig(common.RunModeMaster, overwriteRootDir)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(overwriteRootDir, "etc/kubeconfig"), conf.Master.APIServer.KubeConfig)
+
+	_, err = DefaultConfig("unknown", "")
+	assert.Error(t, err)
+}
@@ -26,17 +26,31 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/secretflow/kuscia/pkg/agent/config"
+	"github.com/secretflow/kuscia/pkg/agent/netpolicy"
+	"github.com/secretflow/kuscia/pkg/agent/sandboxruntime"
+	"github.com/secretflow/kuscia/pkg/backup"
 	"github.com/secretflow/kuscia/pkg/common"
 	cmconfig "github.com/secretflow/kuscia/pkg/confmanager/config"
 	dmconfig "github.com/secretflow/kuscia/pkg/datamesh/config"
 	kaconfig "github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	"github.com/secretflow/kuscia/pkg/metricexporter/alerting"
+	"github.com/secretflow/kuscia/pkg/migration"
+	"github.com/secretflow/kuscia/pkg/utils/admin"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/election"
+	"github.com/secretflow/kuscia/pkg/utils/imagepolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobpolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 type LiteKusciaConfig struct {
@@ -48,7 +62,17 @@ type LiteKusciaConfig struct {
 	Capacity          config.CapacityCfg          `yaml:"capacity"`
 	ReservedResources config.ReservedResourcesCfg `yaml:"reservedResources"`
 	Image             ImageConfig                 `yaml:"image"`
-	AdvancedConfig    `yaml:",inline"`
+	// LocalStoreEndpoint is the database of local state, SQLite under rootDir is used if empty.
+	LocalStoreEndpoint string `yaml:"localStoreEndpoint,omitempty"`
+	// MasterSync tunes how resources are synced from master over poor links.
+	MasterSync kusciaconfig.MasterSyncConfig `yaml:"masterSync,omitempty"`
+	// MasterStandbyEndpoints are the standby masters of the same organization, the lite fails over to them in order.
+	MasterStandbyEndpoints []string `yaml:"masterStandbyEndpoints,omitempty"`
+	// NetworkPolicy restricts the egress of task pods.
+	NetworkPolicy netpolicy.Config `yaml:"networkPolicy,omitempty"`
+	// SandboxRuntime runs pods in gVisor or Kata Containers.
+	SandboxRuntime sandboxruntime.Config `yaml:"sandboxRuntime,omitempty"`
+	AdvancedConfig `yaml:",inline"`
 }
 
 type MasterKusciaConfig struct {
@@ -66,7 +90,11 @@ type AutonomyKusciaConfig struct {
 	ReservedResources config.ReservedResourcesCfg `yaml:"reservedResources"`
 	Image             ImageConfig                 `yaml:"image"`
 	DatastoreEndpoint string                      `yaml:"datastoreEndpoint"`
-	AdvancedConfig    `yaml:",inline"`
+	// NetworkPolicy restricts the egress of task pods.
+	NetworkPolicy netpolicy.Config `yaml:"networkPolicy,omitempty"`
+	// SandboxRuntime runs pods in gVisor or Kata Containers.
+	SandboxRuntime sandboxruntime.Config `yaml:"sandboxRuntime,omitempty"`
+	AdvancedConfig `yaml:",inline"`
 }
 
 type RunkConfig struct {
@@ -104,11 +132,15 @@ type ImageRegistry struct {
 }
 
 type CommonConfig struct {
-	Mode          string          `yaml:"mode"`
-	DomainID      string          `yaml:"domainID"`
-	DomainKeyData string          `yaml:"domainKeyData"`
-	LogLevel      string          `yaml:"logLevel"`
-	Protocol      common.Protocol `yaml:"protocol,omitempty"`
+	Mode          string `yaml:"mode"`
+	DomainID      string `yaml:"domainID"`
+	DomainKeyData string `yaml:"domainKeyData"`
+	LogLevel      string `yaml:"logLevel"`
+	// LogFormat is text or json.
+	LogFormat string `yaml:"logFormat,omitempty"`
+	// ModuleLogLevels overrides logLevel of modules, e.g. gateway: DEBUG.
+	ModuleLogLevels map[string]string `yaml:"moduleLogLevels,omitempty"`
+	Protocol        common.Protocol   `yaml:"protocol,omitempty"`
 }
 
 type LogrotateConfig struct {
@@ -126,6 +158,33 @@ type AdvancedConfig struct {
 	DebugPort             int                         `yaml:"debugPort,omitempty"`
 	EnableWorkloadApprove bool                        `yaml:"enableWorkloadApprove,omitempty"`
 	Logrotate             LogrotateConfig             `yaml:"logrotate,omitempty"`
+	MetricUpdatePeriod    uint                        `yaml:"metricUpdatePeriod,omitempty"` // Unit: second
+	DrainTimeout          uint                        `yaml:"drainTimeout,omitempty"`       // Unit: second
+	LeaderElection        election.Config             `yaml:"leaderElection,omitempty"`
+	// Heartbeat is the liveness protocol between lite domains and master.
+	Heartbeat kusciaconfig.HeartbeatConfig `yaml:"heartbeat,omitempty"`
+	// Tracing exports spans of jobs to an OTLP collector.
+	Tracing tracing.Config `yaml:"tracing,omitempty"`
+	// WorkloadApprovePolicies approve jobs of partners automatically when workload approval is enabled.
+	WorkloadApprovePolicies []approval.Policy `yaml:"workloadApprovePolicies,omitempty"`
+	// JobValidationPolicies deny jobs violating org-specific policies before tasks are created.
+	JobValidationPolicies []jobpolicy.Config `yaml:"jobValidationPolicies,omitempty"`
+	// ImagePolicy pins images of tasks to digests and verifies their signatures before tasks are admitted.
+	ImagePolicy *imagepolicy.Config `yaml:"imagePolicy,omitempty"`
+	// JobQueue limits the running jobs initiated by this cluster.
+	JobQueue jobqueue.Config `yaml:"jobQueue,omitempty"`
+	// JobTTLAfterFinished is the default lifetime of finished jobs before they are garbage collected.
+	JobTTLAfterFinished time.Duration `yaml:"jobTTLAfterFinished,omitempty"`
+	// Controllers tunes workers and resync periods of controllers and interconn.
+	Controllers kusciaconfig.ControllersConfig `yaml:"controllers,omitempty"`
+	// Backup snapshots kuscia resources and stores of master into a local directory or S3.
+	Backup backup.Config `yaml:"backup,omitempty"`
+	// Admin is the authenticated admin server of kuscia process, listening on 127.0.0.1:9094 by default.
+	Admin admin.Config `yaml:"admin,omitempty"`
+	// Alerting evaluates rules on metrics and sends notifications without a Prometheus stack.
+	Alerting alerting.Config `yaml:"alerting,omitempty"`
+	// Supervisor restarts modules which crash after they are ready.
+	Supervisor SupervisorConfig `yaml:"supervisor,omitempty"`
 }
 
 func LoadCommonConfig(configFile string) *CommonConfig {
@@ -154,6 +213,16 @@ func LoadAutonomyConfig(configFile string) *AutonomyKusciaConfig {
 
 func (lite *LiteKusciaConfig) OverwriteKusciaConfig(kusciaConfig *KusciaConfig) {
 	kusciaConfig.LogLevel = lite.LogLevel
+	kusciaConfig.LogFormat = lite.LogFormat
+	kusciaConfig.ModuleLogLevels = lite.ModuleLogLevels
+	kusciaConfig.Tracing = lite.Tracing
+	kusciaConfig.Heartbeat = lite.Heartbeat
+	if lite.MetricUpdatePeriod > 0 {
+		kusciaConfig.MetricUpdatePeriod = lite.MetricUpdatePeriod
+	}
+	if lite.DrainTimeout > 0 {
+		kusciaConfig.DrainTimeout = lite.DrainTimeout
+	}
 	kusciaConfig.DomainID = lite.DomainID
 	kusciaConfig.CAKeyData = lite.DomainKeyData
 	kusciaConfig.DomainKeyData = lite.DomainKeyData
@@ -182,6 +251,8 @@ func (lite *LiteKusciaConfig) OverwriteKusciaConfig(kusciaConfig *KusciaConfig)
 		}
 	}
 	kusciaConfig.Agent.Capacity = lite.Capacity
+	kusciaConfig.Agent.NetworkPolicy = lite.NetworkPolicy
+	kusciaConfig.Agent.SandboxRuntime = lite.SandboxRuntime
 	if kusciaConfig.Agent.Provider.Runtime == config.K8sRuntime && kusciaConfig.Agent.Provider.K8s.LogDirectory != "" {
 		kusciaConfig.Agent.StdoutPath = kusciaConfig.Agent.Provider.K8s.LogDirectory
 	}
@@ -203,10 +274,22 @@ func (lite *LiteKusciaConfig) OverwriteKusciaConfig(kusciaConfig *KusciaConfig)
 	}
 
 	kusciaConfig.Master.Endpoint = lite.MasterEndpoint
+	kusciaConfig.Master.Sync = lite.MasterSync
+	kusciaConfig.Master.StandbyEndpoints = lite.MasterStandbyEndpoints
 	kusciaConfig.DomainRoute.DomainCsrData = GenerateCsrData(lite.DomainID, lite.DomainKeyData, lite.LiteDeployToken)
 	kusciaConfig.Debug = lite.Debug
 	kusciaConfig.DebugPort = lite.DebugPort
+	kusciaConfig.Admin = lite.Admin
+	kusciaConfig.Alerting = lite.Alerting
+	kusciaConfig.Supervisor = lite.Supervisor
 	kusciaConfig.Image = lite.Image
+	kusciaConfig.LocalStoreEndpoint = lite.LocalStoreEndpoint
+	kusciaConfig.DomainRoute.ResponseCache = lite.DomainRoute.ResponseCache
+	kusciaConfig.DomainRoute.HTTP3 = lite.DomainRoute.HTTP3
+	kusciaConfig.DomainRoute.CertIssuance = lite.DomainRoute.CertIssuance
+	kusciaConfig.DomainRoute.Egress = lite.DomainRoute.Egress
+	kusciaConfig.DomainRoute.PeerRequest = lite.DomainRoute.PeerRequest
+	kusciaConfig.DomainRoute.RequestTimeout = lite.DomainRoute.RequestTimeout
 
 	overwriteKusciaConfigLogrotate(&kusciaConfig.Logrotate, &lite.AdvancedConfig.Logrotate)
 	overwriteKusciaConfigAgentLogrotate(&kusciaConfig.Agent.Provider.CRI, &lite.Agent.Provider.CRI, &kusciaConfig.Logrotate)
@@ -215,6 +298,17 @@ func (lite *LiteKusciaConfig) OverwriteKusciaConfig(kusciaConfig *KusciaConfig)
 func (master *MasterKusciaConfig) OverwriteKusciaConfig(kusciaConfig *KusciaConfig) {
 	kusciaConfig.DomainID = master.DomainID
 	kusciaConfig.LogLevel = master.LogLevel
+	kusciaConfig.LogFormat = master.LogFormat
+	kusciaConfig.ModuleLogLevels = master.ModuleLogLevels
+	kusciaConfig.Tracing = master.Tracing
+	kusciaConfig.Heartbeat = master.Heartbeat
+	if master.MetricUpdatePeriod > 0 {
+		kusciaConfig.MetricUpdatePeriod = master.MetricUpdatePeriod
+	}
+	if master.DrainTimeout > 0 {
+		kusciaConfig.DrainTimeout = master.DrainTimeout
+	}
+	kusciaConfig.LeaderElection = master.LeaderElection
 	kusciaConfig.CAKeyData = master.DomainKeyData
 	kusciaConfig.DomainKeyData = master.DomainKeyData
 	if master.KusciaAPI != nil {
@@ -224,17 +318,44 @@ func (master *MasterKusciaConfig) OverwriteKusciaConfig(kusciaConfig *KusciaConf
 	if master.DomainRoute.ExternalTLS != nil {
 		kusciaConfig.DomainRoute.ExternalTLS = master.DomainRoute.ExternalTLS
 	}
+	kusciaConfig.DomainRoute.ResponseCache = master.DomainRoute.ResponseCache
+	kusciaConfig.DomainRoute.HTTP3 = master.DomainRoute.HTTP3
+	kusciaConfig.DomainRoute.CertIssuance = master.DomainRoute.CertIssuance
+	kusciaConfig.DomainRoute.Egress = master.DomainRoute.Egress
+	kusciaConfig.DomainRoute.PeerRequest = master.DomainRoute.PeerRequest
+	kusciaConfig.DomainRoute.RequestTimeout = master.DomainRoute.RequestTimeout
 	kusciaConfig.Master.DatastoreEndpoint = master.DatastoreEndpoint
 	kusciaConfig.Master.ClusterToken = master.ClusterToken
 	kusciaConfig.Debug = master.Debug
 	kusciaConfig.DebugPort = master.DebugPort
+	kusciaConfig.Admin = master.Admin
+	kusciaConfig.Alerting = master.Alerting
+	kusciaConfig.Supervisor = master.Supervisor
 	kusciaConfig.EnableWorkloadApprove = master.AdvancedConfig.EnableWorkloadApprove
+	kusciaConfig.WorkloadApprovePolicies = master.AdvancedConfig.WorkloadApprovePolicies
+	kusciaConfig.JobValidationPolicies = master.AdvancedConfig.JobValidationPolicies
+	kusciaConfig.ImagePolicy = master.AdvancedConfig.ImagePolicy
+	kusciaConfig.JobQueue = master.AdvancedConfig.JobQueue
+	kusciaConfig.Controllers = master.AdvancedConfig.Controllers
+	kusciaConfig.JobTTLAfterFinished = master.AdvancedConfig.JobTTLAfterFinished
+	kusciaConfig.Backup = master.AdvancedConfig.Backup
 
 	overwriteKusciaConfigLogrotate(&kusciaConfig.Logrotate, &master.AdvancedConfig.Logrotate)
 }
 
 func (autonomy *AutonomyKusciaConfig) OverwriteKusciaConfig(kusciaConfig *KusciaConfig) {
 	kusciaConfig.LogLevel = autonomy.LogLevel
+	kusciaConfig.LogFormat = autonomy.LogFormat
+	kusciaConfig.ModuleLogLevels = autonomy.ModuleLogLevels
+	kusciaConfig.Tracing = autonomy.Tracing
+	kusciaConfig.Heartbeat = autonomy.Heartbeat
+	if autonomy.MetricUpdatePeriod > 0 {
+		kusciaConfig.MetricUpdatePeriod = autonomy.MetricUpdatePeriod
+	}
+	if autonomy.DrainTimeout > 0 {
+		kusciaConfig.DrainTimeout = autonomy.DrainTimeout
+	}
+	kusciaConfig.LeaderElection = autonomy.LeaderElection
 	kusciaConfig.DomainID = autonomy.DomainID
 	kusciaConfig.CAKeyData = autonomy.DomainKeyData
 	kusciaConfig.DomainKeyData = autonomy.DomainKeyData
@@ -260,6 +381,8 @@ func (autonomy *AutonomyKusciaConfig) OverwriteKusciaConfig(kusciaConfig *Kuscia
 		}
 	}
 	kusciaConfig.Agent.Capacity = autonomy.Capacity
+	kusciaConfig.Agent.NetworkPolicy = autonomy.NetworkPolicy
+	kusciaConfig.Agent.SandboxRuntime = autonomy.SandboxRuntime
 	if kusciaConfig.Agent.Provider.Runtime == config.K8sRuntime && kusciaConfig.Agent.Provider.K8s.LogDirectory != "" {
 		kusciaConfig.Agent.StdoutPath = kusciaConfig.Agent.Provider.K8s.LogDirectory
 	}
@@ -288,10 +411,26 @@ func (autonomy *AutonomyKusciaConfig) OverwriteKusciaConfig(kusciaConfig *Kuscia
 	if autonomy.DomainRoute.ExternalTLS != nil {
 		kusciaConfig.DomainRoute.ExternalTLS = autonomy.DomainRoute.ExternalTLS
 	}
+	kusciaConfig.DomainRoute.ResponseCache = autonomy.DomainRoute.ResponseCache
+	kusciaConfig.DomainRoute.HTTP3 = autonomy.DomainRoute.HTTP3
+	kusciaConfig.DomainRoute.CertIssuance = autonomy.DomainRoute.CertIssuance
+	kusciaConfig.DomainRoute.Egress = autonomy.DomainRoute.Egress
+	kusciaConfig.DomainRoute.PeerRequest = autonomy.DomainRoute.PeerRequest
+	kusciaConfig.DomainRoute.RequestTimeout = autonomy.DomainRoute.RequestTimeout
 	kusciaConfig.Master.DatastoreEndpoint = autonomy.DatastoreEndpoint
 	kusciaConfig.Debug = autonomy.Debug
 	kusciaConfig.DebugPort = autonomy.DebugPort
+	kusciaConfig.Admin = autonomy.Admin
+	kusciaConfig.Alerting = autonomy.Alerting
+	kusciaConfig.Supervisor = autonomy.Supervisor
 	kusciaConfig.EnableWorkloadApprove = autonomy.AdvancedConfig.EnableWorkloadApprove
+	kusciaConfig.WorkloadApprovePolicies = autonomy.AdvancedConfig.WorkloadApprovePolicies
+	kusciaConfig.JobValidationPolicies = autonomy.AdvancedConfig.JobValidationPolicies
+	kusciaConfig.ImagePolicy = autonomy.AdvancedConfig.ImagePolicy
+	kusciaConfig.JobQueue = autonomy.AdvancedConfig.JobQueue
+	kusciaConfig.Controllers = autonomy.AdvancedConfig.Controllers
+	kusciaConfig.JobTTLAfterFinished = autonomy.AdvancedConfig.JobTTLAfterFinished
+	kusciaConfig.Backup = autonomy.AdvancedConfig.Backup
 	kusciaConfig.Image = autonomy.Image
 
 	overwriteKusciaConfigLogrotate(&kusciaConfig.Logrotate, &autonomy.AdvancedConfig.Logrotate)
@@ -330,14 +469,38 @@ func overwriteKusciaConfigLogrotate(kusciaConfig, overwriteLogrotate *LogrotateC
 	}
 }
 
+// loadConfig reads config file with config migrations applied, so that config files of former versions keep working.
 func loadConfig(configFile string, conf interface{}) {
+	if err := decodeConfigFile(configFile, conf); err != nil {
+		nlog.Fatal(err)
+	}
+}
+
+func decodeConfigFile(configFile string, conf interface{}) error {
 	content, err := os.ReadFile(configFile)
 	if err != nil {
-		nlog.Fatal(err)
+		return err
 	}
-	if err = yaml.Unmarshal(content, conf); err != nil {
-		nlog.Fatal(err)
+	if content, _, err = migration.MigrateConfig(content); err != nil {
+		return err
+	}
+	return yaml.Unmarshal(content, conf)
+}
+
+// warnConfigMigrations reports the stale keys of config file, which are migrated in memory every time it's loaded.
+func warnConfigMigrations(configFile string) {
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return
+	}
+	_, changes, err := migration.MigrateConfig(content)
+	if err != nil || len(changes) == 0 {
+		return
+	}
+	for _, change := range changes {
+		nlog.Warnf("Config file %s is migrated: %s", configFile, change)
 	}
+	nlog.Warnf("Please run 'kuscia migrate -c %s --write-config' to update config file", configFile)
 }
 
 func GenerateCsrData(domainID, domainKeyData, deployToken string) string {
@@ -0,0 +1,120 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confloader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/secretflow/kuscia/pkg/utils/confbus"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const DefaultReloadInterval = 10 * time.Second
+
+// hotReloadableFields are top level fields of kuscia.yaml applied without restart, changes of other fields are
+// reported as restart required.
+var hotReloadableFields = map[string]bool{
+	confbus.FieldLogLevel:           true,
+	confbus.FieldMetricUpdatePeriod: true,
+}
+
+// ConfigSnapshot is the content of config file used to detect changes.
+type ConfigSnapshot struct {
+	Hash   string
+	fields map[string]interface{}
+}
+
+// LoadConfigSnapshot reads config file without failing fatally, so that a broken file doesn't stop a running kuscia.
+func LoadConfigSnapshot(configFile string) (*ConfigSnapshot, error) {
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &fields); err != nil {
+		return nil, fmt.Errorf("parse config file %s failed, %v", configFile, err)
+	}
+	sum := sha256.Sum256(content)
+	return &ConfigSnapshot{Hash: hex.EncodeToString(sum[:]), fields: fields}, nil
+}
+
+// HotValues returns values of hot-reloadable fields, default values are used for absent fields.
+func (s *ConfigSnapshot) HotValues() map[string]string {
+	values := map[string]string{
+		confbus.FieldLogLevel:           "",
+		confbus.FieldMetricUpdatePeriod: strconv.FormatUint(uint64(defaultMetricUpdatePeriod), 10),
+	}
+	for field := range hotReloadableFields {
+		if v, ok := s.fields[field]; ok && v != nil {
+			values[field] = fmt.Sprint(v)
+		}
+	}
+	return values
+}
+
+// RestartRequired returns fields which are changed from old but can't be hot reloaded.
+func (s *ConfigSnapshot) RestartRequired(old *ConfigSnapshot) []string {
+	var fields []string
+	for field, v := range s.fields {
+		if !hotReloadableFields[field] && !reflect.DeepEqual(v, old.fields[field]) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range old.fields {
+		if _, ok := s.fields[field]; !ok && !hotReloadableFields[field] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// WatchConfig checks config file every interval, and calls onChange with the new snapshot and fields requiring
+// restart when its content is changed. Restart required fields are compared with the snapshot loaded at startup.
+func WatchConfig(ctx context.Context, configFile string, interval time.Duration, initial *ConfigSnapshot,
+	onChange func(snapshot *ConfigSnapshot, restartRequired []string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := initial
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snapshot, err := LoadConfigSnapshot(configFile)
+			if err != nil {
+				nlog.Warnf("Reload config failed, keep the current config, %v", err)
+				continue
+			}
+			if snapshot.Hash == last.Hash {
+				continue
+			}
+			last = snapshot
+			restartRequired := snapshot.RestartRequired(initial)
+			if len(restartRequired) > 0 {
+				nlog.Warnf("Config fields %v are changed, they take effect after restart", restartRequired)
+			}
+			onChange(snapshot, restartRequired)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confloader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/utils/confbus"
+)
+
+func TestConfigSnapshot(t *testing.T) {
+	t.Parallel()
+	configFile := filepath.Join(t.TempDir(), "kuscia.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: lite\ndomainID: alice\nprotocol: NOTLS\n"), 0644))
+	initial, err := LoadConfigSnapshot(configFile)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{confbus.FieldLogLevel: "", confbus.FieldMetricUpdatePeriod: "5"}, initial.HotValues())
+
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: lite\ndomainID: alice\nlogLevel: DEBUG\nmetricUpdatePeriod: 10\n"), 0644))
+	snapshot, err := LoadConfigSnapshot(configFile)
+	assert.NoError(t, err)
+	assert.NotEqual(t, initial.Hash, snapshot.Hash)
+	assert.Equal(t, map[string]string{confbus.FieldLogLevel: "DEBUG", confbus.FieldMetricUpdatePeriod: "10"}, snapshot.HotValues())
+	assert.Equal(t, []string{"protocol"}, snapshot.RestartRequired(initial))
+
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: [lite\n"), 0644))
+	_, err = LoadConfigSnapshot(configFile)
+	assert.Error(t, err)
+}
+
+func TestWatchConfig(t *testing.T) {
+	t.Parallel()
+	configFile := filepath.Join(t.TempDir(), "kuscia.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: lite\nlogLevel: INFO\n"), 0644))
+	initial, err := LoadConfigSnapshot(configFile)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changed := make(chan map[string]string, 1)
+	go WatchConfig(ctx, configFile, 10*time.Millisecond, initial, func(snapshot *ConfigSnapshot, restartRequired []string) {
+		changed <- snapshot.HotValues()
+	})
+
+	assert.NoError(t, os.WriteFile(configFile, []byte("mode: lite\nlogLevel: WARN\n"), 0644))
+	select {
+	case values := <-changed:
+		assert.Equal(t, "WARN", values[confbus.FieldLogLevel])
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change isn't detected")
+	}
+}
@@ -37,6 +37,7 @@ func NewDiagnoseCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(NewCDRCommand(ctx))
 	cmd.AddCommand(NewNeworkCommand(ctx))
 	cmd.AddCommand(NewAppCommand(ctx))
+	cmd.AddCommand(NewJobCommand(ctx))
 	return cmd
 }
 
@@ -94,6 +95,7 @@ func NewNeworkCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().IntVar(&param.SizeThres, "request-size-threshold", netstat.DefaultRequestBodySizeThreshold, "Request size threshold, unit MB")
 
 	cmd.Flags().BoolVarP(&param.Bidirection, "bidirection", "b", true, "Execute bidirection test")
+	cmd.Flags().BoolVar(&param.ConnectivityOnly, "connectivity-only", false, "Only check connectivity layer by layer, skip network statistics job")
 	cmd.Flags().BoolVarP(&param.Manual, "manual", "m", false, "Initialize server/client manually")
 	cmd.Flags().StringVarP(&param.PeerEndpoint, "endpoint", "e", "", "Peer Endpoint, only effective in manual mode")
 	return cmd
@@ -0,0 +1,98 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/cmd/kuscia/modules"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/diagnose/bundle"
+	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+)
+
+const defaultLiteAPIServerEndpoint = "http://apiserver.master.svc"
+
+func NewJobCommand(ctx context.Context) *cobra.Command {
+	configFile := filepath.Join(common.DefaultKusciaHomePath, "etc/conf/kuscia.yaml")
+	output := ""
+	maxLogSizeMB := 0
+	cmd := &cobra.Command{
+		Use:          modules.SubCMDJob + " <job-id>",
+		Short:        "Collect resources and logs of a job of local party into a bundle for post-mortem",
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobID := args[0]
+			if output == "" {
+				output = fmt.Sprintf("%s-diagnose.tar.gz", jobID)
+			}
+			return RunJobCommand(ctx, configFile, jobID, output, int64(maxLogSizeMB)<<20)
+		},
+	}
+	cmd.Flags().StringVarP(&configFile, "config", "c", configFile, "Kuscia config file")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output bundle file, default <job-id>-diagnose.tar.gz")
+	cmd.Flags().IntVar(&maxLogSizeMB, "max-log-size", bundle.DefaultMaxLogBytes>>20, "Max size of each log in bundle, the tail is kept, unit MB")
+	return cmd
+}
+
+func RunJobCommand(ctx context.Context, configFile, jobID, output string, maxLogBytes int64) error {
+	commonConfig := confloader.LoadCommonConfig(configFile)
+	mode := strings.ToLower(commonConfig.Mode)
+	kusciaConf := confloader.ReadConfig(configFile, mode)
+
+	kubeconfigFile, endpoint := "", defaultLiteAPIServerEndpoint
+	if mode != common.RunModeLite {
+		kubeconfigFile, endpoint = kusciaConf.Master.APIServer.KubeConfig, kusciaConf.Master.APIServer.Endpoint
+	}
+	clients, err := kubeconfig.CreateClientSetsFromKubeconfig(kubeconfigFile, endpoint)
+	if err != nil {
+		return fmt.Errorf("create kube clients failed, %v", err)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	collector := bundle.NewJobCollector(&bundle.Config{
+		JobID:        jobID,
+		DomainID:     kusciaConf.DomainID,
+		KusciaClient: clients.KusciaClient,
+		KubeClient:   clients.KubeClient,
+		StdoutDir:    kusciaConf.Agent.StdoutPath,
+		LogDir:       filepath.Join(kusciaConf.RootDir, common.LogPrefix),
+		MaxLogBytes:  maxLogBytes,
+	})
+	if err := collector.Collect(ctx, file); err != nil {
+		return fmt.Errorf("write bundle %s failed, %v", output, err)
+	}
+	fmt.Printf("Bundle of job %s is written to %s\n", jobID, output)
+	if failures := collector.Failures(); len(failures) > 0 {
+		fmt.Printf("Some items are not collected, see summary.txt in bundle for details:\n")
+		for _, failure := range failures {
+			fmt.Printf("  %s\n", failure)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func NewDomainCommand(ctx context.Context) *cobra.Command {
+	command := &cobra.Command{
+		Use:          "domain",
+		Short:        "Manage join tokens on master and join a new domain to master",
+		SilenceUsage: true,
+	}
+	command.AddCommand(newTokenCommand(ctx))
+	command.AddCommand(newJoinCommand(ctx))
+	return command
+}
+
+func defaultConfigFile() string {
+	return filepath.Join(common.DefaultKusciaHomePath, "etc/conf/kuscia.yaml")
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/pkg/gateway/controller"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+const joinTimeout = 30 * time.Second
+
+type joinOptions struct {
+	configFile         string
+	token              string
+	masterEndpoint     string
+	caFile             string
+	insecureSkipVerify bool
+	certOutput         string
+}
+
+func newJoinCommand(ctx context.Context) *cobra.Command {
+	opts := &joinOptions{configFile: defaultConfigFile()}
+	command := &cobra.Command{
+		Use:   "join",
+		Short: "Join the lite domain to master with a join token, master signs the domain cert and creates the domain",
+		Args:  cobra.NoArgs,
+		Example: `
+# generate config of lite domain bob, then join it to master
+kuscia init --mode lite --domain bob --master-endpoint https://172.18.0.2:1080 > kuscia.yaml
+kuscia domain join -c kuscia.yaml --token <join-token>
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJoin(opts)
+		},
+	}
+	command.Flags().StringVarP(&opts.configFile, "config", "c", opts.configFile, "Kuscia config file of lite domain, domainKeyData is generated and written back if empty")
+	command.Flags().StringVarP(&opts.token, "token", "t", "", "Join token created on master")
+	command.Flags().StringVarP(&opts.masterEndpoint, "master-endpoint", "m", "", "The master endpoint, overrides masterEndpoint of config file")
+	command.Flags().StringVar(&opts.caFile, "ca-file", "", "CA file to verify the certificate of master endpoint")
+	command.Flags().BoolVar(&opts.insecureSkipVerify, "insecure-skip-verify", false, "Skip verifying the certificate of master endpoint")
+	command.Flags().StringVar(&opts.certOutput, "cert-output", "", "File to write the domain cert signed by master")
+	_ = command.MarkFlagRequired("token")
+	return command
+}
+
+func runJoin(opts *joinOptions) error {
+	conf := confloader.LoadLiteConfig(opts.configFile)
+	if conf.DomainID == "" {
+		return fmt.Errorf("domainID is empty in %s", opts.configFile)
+	}
+	masterEndpoint := conf.MasterEndpoint
+	if opts.masterEndpoint != "" {
+		masterEndpoint = opts.masterEndpoint
+	}
+	if masterEndpoint == "" {
+		return fmt.Errorf("master endpoint is empty, set masterEndpoint in %s or --master-endpoint", opts.configFile)
+	}
+
+	keyData := conf.DomainKeyData
+	if keyData == "" {
+		var err error
+		if keyData, err = tlsutils.GenerateKeyData(); err != nil {
+			return fmt.Errorf("generate domain key failed, %v", err)
+		}
+		if err := setConfigValue(opts.configFile, "domainKeyData", keyData); err != nil {
+			return fmt.Errorf("write domain key to %s failed, %v", opts.configFile, err)
+		}
+		fmt.Printf("Domain key is generated and written to %s\n", opts.configFile)
+	}
+	rawKey, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		return fmt.Errorf("decode domainKeyData failed, %v", err)
+	}
+	key, err := tlsutils.ParseKey(rawKey, "")
+	if err != nil {
+		return fmt.Errorf("parse domainKeyData failed, %v", err)
+	}
+
+	client, err := newJoinHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+	csrData := confloader.GenerateCsrData(conf.DomainID, keyData, opts.token)
+	resp, err := controller.JoinDomain(client, masterEndpoint, conf.DomainID, csrData, key)
+	if err != nil {
+		return fmt.Errorf("domain %s join master %s failed, %v", conf.DomainID, masterEndpoint, err)
+	}
+	if opts.certOutput != "" {
+		certPem, err := base64.StdEncoding.DecodeString(resp.Cert)
+		if err != nil {
+			return fmt.Errorf("decode domain cert failed, %v", err)
+		}
+		if err := os.WriteFile(opts.certOutput, certPem, 0644); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Domain %s joined master %s, start kuscia with %s\n", conf.DomainID, masterEndpoint, opts.configFile)
+	return nil
+}
+
+func newJoinHTTPClient(opts *joinOptions) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.insecureSkipVerify}
+	if opts.caFile != "" {
+		caPem, err := os.ReadFile(opts.caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPem) {
+			return nil, fmt.Errorf("no certificate found in %s", opts.caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &http.Client{
+		Timeout:   joinTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+// setConfigValue sets a top level key of yaml config file, comments and orders of other keys are kept.
+func setConfigValue(configFile, key, value string) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("config file isn't a yaml mapping")
+	}
+	mapping := doc.Content[0]
+	found := false
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1].SetString(value)
+			mapping.Content[i+1].Style = 0
+			found = true
+			break
+		}
+	}
+	if !found {
+		valueNode := &yaml.Node{}
+		valueNode.SetString(value)
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode)
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFile, out, 0600)
+}
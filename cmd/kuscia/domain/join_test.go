@@ -0,0 +1,48 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetConfigValue(t *testing.T) {
+	t.Parallel()
+	configFile := filepath.Join(t.TempDir(), "kuscia.yaml")
+	assert.NoError(t, os.WriteFile(configFile, []byte(`# lite config
+mode: lite
+domainID: bob
+# filled by kuscia domain join
+domainKeyData: ""
+masterEndpoint: https://172.18.0.2:1080
+`), 0600))
+
+	assert.NoError(t, setConfigValue(configFile, "domainKeyData", "a2V5"))
+	assert.NoError(t, setConfigValue(configFile, "logLevel", "INFO"))
+	data, err := os.ReadFile(configFile)
+	assert.NoError(t, err)
+	assert.Equal(t, `# lite config
+mode: lite
+domainID: bob
+# filled by kuscia domain join
+domainKeyData: a2V5
+masterEndpoint: https://172.18.0.2:1080
+logLevel: INFO
+`, string(data))
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+)
+
+const defaultJoinTokenTTL = 24 * time.Hour
+
+func newTokenCommand(ctx context.Context) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "token",
+		Short: "Manage one-time join tokens on master",
+	}
+	command.AddCommand(newTokenCreateCommand(ctx))
+	return command
+}
+
+func newTokenCreateCommand(ctx context.Context) *cobra.Command {
+	configFile := defaultConfigFile()
+	domainID := ""
+	ttl := defaultJoinTokenTTL
+	command := &cobra.Command{
+		Use:   "create",
+		Short: "Create a one-time join token, a new domain joins the master with it",
+		Args:  cobra.NoArgs,
+		Example: `
+# create a join token which can only be used by domain bob in 1 hour
+kuscia domain token create --domain bob --ttl 1h
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := CreateJoinToken(ctx, configFile, domainID, ttl)
+			if err != nil {
+				return err
+			}
+			fmt.Println(token)
+			return nil
+		},
+	}
+	command.Flags().StringVarP(&configFile, "config", "c", configFile, "Kuscia config file of master")
+	command.Flags().StringVarP(&domainID, "domain", "d", "", "Domain ID which the token is issued for, any domain can use the token if empty")
+	command.Flags().DurationVar(&ttl, "ttl", ttl, "Time to live of the token, the token never expires if 0")
+	return command
+}
+
+// CreateJoinToken stores a new join token in the namespace of master and returns it.
+func CreateJoinToken(ctx context.Context, configFile, domainID string, ttl time.Duration) (string, error) {
+	commonConfig := confloader.LoadCommonConfig(configFile)
+	mode := strings.ToLower(commonConfig.Mode)
+	if mode == common.RunModeLite {
+		return "", fmt.Errorf("join token can only be created on master or autonomy")
+	}
+	kusciaConf := confloader.ReadConfig(configFile, mode)
+	clients, err := kubeconfig.CreateClientSetsFromKubeconfig(kusciaConf.Master.APIServer.KubeConfig, kusciaConf.Master.APIServer.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("create kube clients failed, %v", err)
+	}
+
+	token, err := utils.GenerateJoinToken()
+	if err != nil {
+		return "", err
+	}
+	secret := utils.NewJoinTokenSecret(kusciaConf.DomainID, token, domainID, ttl)
+	if _, err := clients.KubeClient.CoreV1().Secrets(kusciaConf.DomainID).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		return "", fmt.Errorf("store join token failed, %v", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func newCreateCommand(ctx context.Context, opts *options) *cobra.Command {
+	file := ""
+	watch := false
+	command := &cobra.Command{
+		Use:   "create -f FILE",
+		Short: "Create a job from a json or yaml file of CreateJobRequest",
+		Args:  cobra.NoArgs,
+		Example: `
+# create a job and watch it until finished
+kuscia job create -f job.yaml --watch
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request, err := LoadCreateJobRequest(file)
+			if err != nil {
+				return err
+			}
+			jobClient, conn, err := opts.jobClient()
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			resp, err := jobClient.CreateJob(ctx, request)
+			if err != nil {
+				return fmt.Errorf("create job failed, %v", err)
+			}
+			if err := checkStatus(resp.Status); err != nil {
+				return fmt.Errorf("create job failed, %v", err)
+			}
+			if opts.output == OutputJSON {
+				if err := printJSON(os.Stdout, resp.Data, true); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("job %s created\n", resp.Data.GetJobId())
+			}
+			if !watch {
+				return nil
+			}
+			return watchJobs(ctx, jobClient, opts.output, []string{resp.Data.GetJobId()}, true)
+		},
+	}
+	command.Flags().StringVarP(&file, "file", "f", "", "Json or yaml file of CreateJobRequest")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "Watch phase changes of the job after created until it's finished")
+	_ = command.MarkFlagRequired("file")
+	return command
+}
+
+// LoadCreateJobRequest reads CreateJobRequest from file, field names are the same as the KusciaAPI http body.
+func LoadCreateJobRequest(file string) (*kusciaapi.CreateJobRequest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	// json is a subset of yaml
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse job file %s failed, %v", file, err)
+	}
+	request := &kusciaapi.CreateJobRequest{}
+	if err := protojson.Unmarshal(jsonData, request); err != nil {
+		return nil, fmt.Errorf("parse job file %s failed, %v", file, err)
+	}
+	return request, nil
+}
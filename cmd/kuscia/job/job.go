@@ -0,0 +1,121 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/secretflow/kuscia/pkg/diagnose/app/client"
+	"github.com/secretflow/kuscia/pkg/web/framework/config"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+type options struct {
+	profileFile string
+	profile     string
+	endpoint    string
+	token       string
+	tokenFile   string
+	output      string
+}
+
+func NewJobCommand(ctx context.Context) *cobra.Command {
+	opts := &options{}
+	command := &cobra.Command{
+		Use:          "job",
+		Short:        "Create, stop, list and watch jobs through KusciaAPI",
+		SilenceUsage: true,
+		Aliases:      []string{"jobs"},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output != OutputTable && opts.output != OutputJSON {
+				return fmt.Errorf("invalid output format %q, only %s and %s are supported", opts.output, OutputTable, OutputJSON)
+			}
+			return nil
+		},
+	}
+
+	flags := command.PersistentFlags()
+	flags.StringVar(&opts.profileFile, "profile-file", defaultProfileFile(), "File of KusciaAPI connection profiles")
+	flags.StringVar(&opts.profile, "profile", "", "Profile to use, default is the current profile of profile file, or local KusciaAPI")
+	flags.StringVar(&opts.endpoint, "endpoint", "", "KusciaAPI grpc endpoint, overrides endpoint of profile")
+	flags.StringVar(&opts.token, "token", "", "KusciaAPI token, overrides token of profile")
+	flags.StringVar(&opts.tokenFile, "token-file", "", "KusciaAPI token file, overrides token file of profile")
+	flags.StringVarP(&opts.output, "output", "o", OutputTable, "Output format: table or json")
+
+	command.AddCommand(newCreateCommand(ctx, opts))
+	command.AddCommand(newStopCommand(ctx, opts))
+	command.AddCommand(newListCommand(ctx, opts))
+	command.AddCommand(newWatchCommand(ctx, opts))
+	return command
+}
+
+func (o *options) resolveProfile() (*Profile, error) {
+	profile, err := LoadProfile(o.profileFile, o.profile)
+	if err != nil {
+		return nil, err
+	}
+	if o.endpoint != "" {
+		profile.Endpoint = o.endpoint
+	}
+	if o.token != "" {
+		profile.Token, profile.TokenFile = o.token, ""
+	} else if o.tokenFile != "" {
+		profile.Token, profile.TokenFile = "", o.tokenFile
+	}
+	return profile, nil
+}
+
+func (o *options) jobClient() (kusciaapi.JobServiceClient, *grpc.ClientConn, error) {
+	profile, err := o.resolveProfile()
+	if err != nil {
+		return nil, nil, err
+	}
+	token := profile.Token
+	if token == "" && profile.TokenFile != "" {
+		data, err := os.ReadFile(profile.TokenFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read token file of profile %s failed, %v", profile.Name, err)
+		}
+		token = string(data)
+	}
+	conn, err := client.NewGrpcConnWithToken(profile.Endpoint, &config.TLSConfig{
+		CAPath:         profile.CAFile,
+		ServerCertPath: profile.CertFile,
+		ServerKeyPath:  profile.KeyFile,
+	}, token)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect to KusciaAPI %s failed, %v", profile.Endpoint, err)
+	}
+	return kusciaapi.NewJobServiceClient(conn), conn, nil
+}
+
+// checkStatus converts a non-zero status of KusciaAPI response to an error.
+func checkStatus(status *v1alpha1.Status) error {
+	if status.GetCode() != 0 {
+		return fmt.Errorf("code: %d, message: %s", status.GetCode(), status.GetMessage())
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+const defaultListIdleTimeout = 2 * time.Second
+
+func newListCommand(ctx context.Context, opts *options) *cobra.Command {
+	idleTimeout := defaultListIdleTimeout
+	command := &cobra.Command{
+		Use:     "ls [JOB_ID...]",
+		Short:   "List status of jobs, all visible jobs are listed if no job id is specified",
+		Aliases: []string{"list"},
+		Example: `
+# list all visible jobs
+kuscia job list
+
+# list specified jobs in json
+kuscia job list job-1 job-2 -o json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobClient, conn, err := opts.jobClient()
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			var jobs []*kusciaapi.JobStatus
+			if len(args) > 0 {
+				resp, err := jobClient.BatchQueryJobStatus(ctx, &kusciaapi.BatchQueryJobStatusRequest{JobIds: args})
+				if err != nil {
+					return fmt.Errorf("query job status failed, %v", err)
+				}
+				if err := checkStatus(resp.Status); err != nil {
+					return fmt.Errorf("query job status failed, %v", err)
+				}
+				jobs = resp.Data.GetJobs()
+			} else if jobs, err = listAllJobs(ctx, jobClient, idleTimeout); err != nil {
+				return err
+			}
+			return PrintJobs(os.Stdout, opts.output, jobs)
+		},
+	}
+	command.Flags().DurationVar(&idleTimeout, "idle-timeout", idleTimeout,
+		"When listing all jobs, stop collecting after no job is received in this duration")
+	return command
+}
+
+// listAllJobs collects jobs from the initial events of WatchJob, because KusciaAPI has no api to list jobs. The watch
+// begins with an ADDED event for each existing job, so collecting stops once the stream is idle for idleTimeout.
+func listAllJobs(ctx context.Context, jobClient kusciaapi.JobServiceClient, idleTimeout time.Duration) ([]*kusciaapi.JobStatus, error) {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := jobClient.WatchJob(watchCtx, &kusciaapi.WatchJobRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("watch job failed, %v", err)
+	}
+
+	type result struct {
+		event *kusciaapi.WatchJobEventResponse
+		err   error
+	}
+	results := make(chan result)
+	go func() {
+		defer close(results)
+		for {
+			event, err := stream.Recv()
+			select {
+			case results <- result{event: event, err: err}:
+			case <-watchCtx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	jobs := map[string]*kusciaapi.JobStatus{}
+	var order []string
+	timer := time.NewTimer(idleTimeout)
+	defer timer.Stop()
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			break loop
+		case r := <-results:
+			if errors.Is(r.err, io.EOF) {
+				break loop
+			}
+			if r.err != nil {
+				return nil, fmt.Errorf("watch job failed, %v", r.err)
+			}
+			job := r.event.GetObject()
+			if r.event.Type == kusciaapi.EventType_HEARTBEAT || job.GetJobId() == "" {
+				continue
+			}
+			if r.event.Type == kusciaapi.EventType_DELETED {
+				delete(jobs, job.JobId)
+				continue
+			}
+			if _, ok := jobs[job.JobId]; !ok {
+				order = append(order, job.JobId)
+			}
+			jobs[job.JobId] = job
+			timer.Reset(idleTimeout)
+		}
+	}
+
+	list := make([]*kusciaapi.JobStatus, 0, len(jobs))
+	for _, jobID := range order {
+		if job, ok := jobs[jobID]; ok {
+			list = append(list, job)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func newTable(w io.Writer, header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetBorder(false)
+	table.SetHeaderLine(false)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetTablePadding("\t")
+	table.SetNoWhiteSpace(true)
+	return table
+}
+
+func printJSON(w io.Writer, message proto.Message, multiline bool) error {
+	data, err := protojson.MarshalOptions{Multiline: multiline}.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// taskSummary returns the count of succeeded tasks and total tasks like 1/3.
+func taskSummary(tasks []*kusciaapi.TaskStatus) string {
+	succeeded := 0
+	for _, task := range tasks {
+		if task.State == kusciaapi.JobState_Succeeded.String() {
+			succeeded++
+		}
+	}
+	return fmt.Sprintf("%d/%d", succeeded, len(tasks))
+}
+
+// PrintJobs prints status of jobs in table or json.
+func PrintJobs(w io.Writer, output string, jobs []*kusciaapi.JobStatus) error {
+	if output == OutputJSON {
+		return printJSON(w, &kusciaapi.BatchQueryJobStatusResponseData{Jobs: jobs}, true)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].GetStatus().GetCreateTime() < jobs[j].GetStatus().GetCreateTime()
+	})
+	table := newTable(w, []string{"JOB ID", "STATE", "TASKS", "CREATE TIME", "END TIME", "ERROR"})
+	for _, job := range jobs {
+		status := job.GetStatus()
+		table.Append([]string{
+			job.JobId,
+			status.GetState(),
+			taskSummary(status.GetTasks()),
+			status.GetCreateTime(),
+			status.GetEndTime(),
+			status.GetErrMsg(),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+// phaseTracker remembers the last states of jobs and tasks, so that watch only prints phase changes.
+type phaseTracker struct {
+	jobStates  map[string]string
+	taskStates map[string]string
+	now        func() time.Time
+}
+
+func newPhaseTracker() *phaseTracker {
+	return &phaseTracker{
+		jobStates:  map[string]string{},
+		taskStates: map[string]string{},
+		now:        time.Now,
+	}
+}
+
+// Changes returns human-readable lines of phase changes carried by the event.
+func (t *phaseTracker) Changes(event *kusciaapi.WatchJobEventResponse) []string {
+	job := event.GetObject()
+	jobID := job.GetJobId()
+	status := job.GetStatus()
+	timestamp := t.now().Format(time.RFC3339)
+
+	var lines []string
+	if event.Type == kusciaapi.EventType_DELETED {
+		delete(t.jobStates, jobID)
+		for _, task := range status.GetTasks() {
+			delete(t.taskStates, task.TaskId)
+		}
+		return append(lines, fmt.Sprintf("%s\tjob %s deleted", timestamp, jobID))
+	}
+
+	if last, ok := t.jobStates[jobID]; !ok || last != status.GetState() {
+		t.jobStates[jobID] = status.GetState()
+		line := fmt.Sprintf("%s\tjob %s: %s", timestamp, jobID, stateTransition(last, status.GetState()))
+		if status.GetErrMsg() != "" {
+			line += ", " + status.GetErrMsg()
+		}
+		lines = append(lines, line)
+	}
+	for _, task := range status.GetTasks() {
+		if last, ok := t.taskStates[task.TaskId]; !ok || last != task.State {
+			t.taskStates[task.TaskId] = task.State
+			line := fmt.Sprintf("%s\t  task %s: %s", timestamp, task.TaskId, stateTransition(last, task.State))
+			if task.ErrMsg != "" {
+				line += ", " + task.ErrMsg
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func stateTransition(from, to string) string {
+	if from == "" {
+		return to
+	}
+	return fmt.Sprintf("%s -> %s", from, to)
+}
+
+// isJobFinished returns whether the job reaches a state it won't leave without user operation.
+func isJobFinished(state string) bool {
+	switch state {
+	case kusciaapi.JobState_Succeeded.String(), kusciaapi.JobState_Failed.String(),
+		kusciaapi.JobState_ApprovalReject.String(), kusciaapi.JobState_Cancelled.String():
+		return true
+	}
+	return false
+}
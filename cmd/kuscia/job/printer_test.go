@@ -0,0 +1,105 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func newJobStatus(jobID, state string, taskStates ...string) *kusciaapi.JobStatus {
+	status := &kusciaapi.JobStatusDetail{State: state, CreateTime: "2024-01-01T00:00:00Z"}
+	for i, taskState := range taskStates {
+		status.Tasks = append(status.Tasks, &kusciaapi.TaskStatus{TaskId: jobID + "-task-" + string(rune('a'+i)), State: taskState})
+	}
+	return &kusciaapi.JobStatus{JobId: jobID, Status: status}
+}
+
+func TestPrintJobs(t *testing.T) {
+	t.Parallel()
+	jobs := []*kusciaapi.JobStatus{newJobStatus("job-1", "Running", "Succeeded", "Running")}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, PrintJobs(buf, OutputTable, jobs))
+	assert.Contains(t, buf.String(), "JOB ID")
+	assert.Contains(t, buf.String(), "job-1")
+	assert.Contains(t, buf.String(), "1/2")
+
+	buf.Reset()
+	assert.NoError(t, PrintJobs(buf, OutputJSON, jobs))
+	list := &kusciaapi.BatchQueryJobStatusResponseData{}
+	assert.NoError(t, protojson.Unmarshal(buf.Bytes(), list))
+	assert.Equal(t, "job-1", list.Jobs[0].JobId)
+}
+
+func TestPhaseTrackerChanges(t *testing.T) {
+	t.Parallel()
+	tracker := newPhaseTracker()
+	tracker.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	event := func(eventType kusciaapi.EventType, job *kusciaapi.JobStatus) *kusciaapi.WatchJobEventResponse {
+		return &kusciaapi.WatchJobEventResponse{Type: eventType, Object: job}
+	}
+
+	lines := tracker.Changes(event(kusciaapi.EventType_ADDED, newJobStatus("job-1", "Pending", "Pending")))
+	assert.Equal(t, []string{
+		"2024-01-01T00:00:00Z\tjob job-1: Pending",
+		"2024-01-01T00:00:00Z\t  task job-1-task-a: Pending",
+	}, lines)
+
+	// progress updates without phase change print nothing
+	assert.Empty(t, tracker.Changes(event(kusciaapi.EventType_MODIFIED, newJobStatus("job-1", "Pending", "Pending"))))
+
+	lines = tracker.Changes(event(kusciaapi.EventType_MODIFIED, newJobStatus("job-1", "Running", "Running")))
+	assert.Equal(t, []string{
+		"2024-01-01T00:00:00Z\tjob job-1: Pending -> Running",
+		"2024-01-01T00:00:00Z\t  task job-1-task-a: Pending -> Running",
+	}, lines)
+
+	lines = tracker.Changes(event(kusciaapi.EventType_DELETED, newJobStatus("job-1", "Running", "Running")))
+	assert.Equal(t, []string{"2024-01-01T00:00:00Z\tjob job-1 deleted"}, lines)
+}
+
+func TestLoadCreateJobRequest(t *testing.T) {
+	t.Parallel()
+	file := filepath.Join(t.TempDir(), "job.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(`
+job_id: job-1
+initiator: alice
+max_parallelism: 2
+tasks:
+- app_image: secretflow-image
+  task_id: task-1
+  parties:
+  - domain_id: alice
+  - domain_id: bob
+`), 0644))
+	request, err := LoadCreateJobRequest(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "job-1", request.JobId)
+	assert.Equal(t, int32(2), request.MaxParallelism)
+	assert.Len(t, request.Tasks[0].Parties, 2)
+
+	assert.NoError(t, os.WriteFile(file, []byte(`{"job_id": "job-1", "unknown": 1}`), 0644))
+	_, err = LoadCreateJobRequest(file)
+	assert.Error(t, err)
+}
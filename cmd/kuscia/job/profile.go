@@ -0,0 +1,103 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+const localProfileName = "local"
+
+// Profile describes how to connect to a KusciaAPI grpc endpoint.
+type Profile struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	Token     string `json:"token,omitempty"`
+	TokenFile string `json:"tokenFile,omitempty"`
+	CAFile    string `json:"caFile,omitempty"`
+	CertFile  string `json:"certFile,omitempty"`
+	KeyFile   string `json:"keyFile,omitempty"`
+}
+
+// ProfileConfig is the content of profile file, current is used when no profile is specified.
+type ProfileConfig struct {
+	Current  string    `json:"current,omitempty"`
+	Profiles []Profile `json:"profiles"`
+}
+
+func defaultProfileFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kuscia", "profiles.yaml")
+}
+
+// localProfile connects to KusciaAPI of the kuscia instance running on this host.
+func localProfile() *Profile {
+	kusciaConfig := confloader.DefaultKusciaConfig(common.DefaultKusciaHomePath)
+	return &Profile{
+		Name:      localProfileName,
+		Endpoint:  fmt.Sprintf("%s:%d", constants.LocalhostIP, kusciaConfig.KusciaAPI.GRPCPort),
+		TokenFile: kusciaConfig.KusciaAPI.Token.TokenFile,
+		CAFile:    kusciaConfig.CACertFile,
+		CertFile:  kusciaConfig.KusciaAPI.TLS.ServerCertFile,
+		KeyFile:   kusciaConfig.KusciaAPI.TLS.ServerKeyFile,
+	}
+}
+
+// LoadProfile returns the profile named name in profile file. If name is empty, the current profile is used, and
+// the local profile is used when the profile file doesn't exist or has no current profile.
+func LoadProfile(profileFile, name string) (*Profile, error) {
+	config := &ProfileConfig{}
+	if profileFile != "" {
+		data, err := os.ReadFile(profileFile)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, config); err != nil {
+				return nil, fmt.Errorf("parse profile file %s failed, %v", profileFile, err)
+			}
+		case errors.Is(err, os.ErrNotExist):
+		default:
+			return nil, fmt.Errorf("read profile file %s failed, %v", profileFile, err)
+		}
+	}
+
+	if name == "" {
+		name = config.Current
+	}
+	if name == "" || name == localProfileName {
+		return localProfile(), nil
+	}
+	for i := range config.Profiles {
+		if config.Profiles[i].Name == name {
+			profile := config.Profiles[i]
+			if profile.Endpoint == "" {
+				return nil, fmt.Errorf("endpoint of profile %s is empty", name)
+			}
+			return &profile, nil
+		}
+	}
+	return nil, fmt.Errorf("profile %s not found in %s", name, profileFile)
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProfile(t *testing.T) {
+	t.Parallel()
+	profileFile := filepath.Join(t.TempDir(), "profiles.yaml")
+	assert.NoError(t, os.WriteFile(profileFile, []byte(`
+current: alice
+profiles:
+- name: alice
+  endpoint: 10.0.0.1:8083
+  tokenFile: /tmp/alice/token
+- name: bob
+  endpoint: 10.0.0.2:8083
+  token: abc
+- name: broken
+`), 0644))
+
+	profile, err := LoadProfile(profileFile, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8083", profile.Endpoint)
+	assert.Equal(t, "/tmp/alice/token", profile.TokenFile)
+
+	profile, err = LoadProfile(profileFile, "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", profile.Token)
+
+	profile, err = LoadProfile(profileFile, localProfileName)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8083", profile.Endpoint)
+
+	_, err = LoadProfile(profileFile, "broken")
+	assert.Error(t, err)
+	_, err = LoadProfile(profileFile, "carol")
+	assert.Error(t, err)
+
+	// local profile is used when profile file doesn't exist
+	profile, err = LoadProfile(filepath.Join(t.TempDir(), "not-exist.yaml"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, localProfileName, profile.Name)
+}
+
+func TestResolveProfile(t *testing.T) {
+	t.Parallel()
+	profileFile := filepath.Join(t.TempDir(), "profiles.yaml")
+	assert.NoError(t, os.WriteFile(profileFile, []byte(`
+profiles:
+- name: alice
+  endpoint: 10.0.0.1:8083
+  tokenFile: /tmp/alice/token
+`), 0644))
+
+	opts := &options{profileFile: profileFile, profile: "alice", endpoint: "10.0.0.3:8083", token: "xyz"}
+	profile, err := opts.resolveProfile()
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.3:8083", profile.Endpoint)
+	assert.Equal(t, "xyz", profile.Token)
+	assert.Empty(t, profile.TokenFile)
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func newStopCommand(ctx context.Context, opts *options) *cobra.Command {
+	reason := ""
+	command := &cobra.Command{
+		Use:   "stop JOB_ID",
+		Short: "Stop a job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobClient, conn, err := opts.jobClient()
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			resp, err := jobClient.StopJob(ctx, &kusciaapi.StopJobRequest{JobId: args[0], Reason: reason})
+			if err != nil {
+				return fmt.Errorf("stop job %s failed, %v", args[0], err)
+			}
+			if err := checkStatus(resp.Status); err != nil {
+				return fmt.Errorf("stop job %s failed, %v", args[0], err)
+			}
+			if opts.output == OutputJSON {
+				return printJSON(os.Stdout, resp.Data, true)
+			}
+			fmt.Printf("job %s stopped\n", args[0])
+			return nil
+		},
+	}
+	command.Flags().StringVar(&reason, "reason", "", "Reason of stopping the job")
+	return command
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func newWatchCommand(ctx context.Context, opts *options) *cobra.Command {
+	exitOnFinish := true
+	command := &cobra.Command{
+		Use:   "watch [JOB_ID...]",
+		Short: "Watch phase changes of jobs and their tasks, all visible jobs are watched if no job id is specified",
+		Example: `
+# watch a job until it's finished
+kuscia job watch job-1
+
+# watch all jobs and print events in json lines
+kuscia job watch -o json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobClient, conn, err := opts.jobClient()
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			return watchJobs(ctx, jobClient, opts.output, args, exitOnFinish && len(args) > 0)
+		},
+	}
+	command.Flags().BoolVar(&exitOnFinish, "exit-on-finish", exitOnFinish,
+		"Exit when all specified jobs are finished, only takes effect when job ids are specified")
+	return command
+}
+
+// watchJobs prints phase changes of jobs in jobIDs, or all jobs if jobIDs is empty. In json output, every event is
+// printed as one line.
+func watchJobs(ctx context.Context, jobClient kusciaapi.JobServiceClient, output string, jobIDs []string, exitOnFinish bool) error {
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := jobClient.WatchJob(watchCtx, &kusciaapi.WatchJobRequest{})
+	if err != nil {
+		return fmt.Errorf("watch job failed, %v", err)
+	}
+
+	watched := map[string]bool{}
+	for _, jobID := range jobIDs {
+		watched[jobID] = false
+	}
+	tracker := newPhaseTracker()
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("watch job failed, %v", err)
+		}
+		jobID := event.GetObject().GetJobId()
+		if event.Type == kusciaapi.EventType_HEARTBEAT || jobID == "" {
+			continue
+		}
+		if _, ok := watched[jobID]; len(watched) > 0 && !ok {
+			continue
+		}
+
+		if output == OutputJSON {
+			if err := printJSON(os.Stdout, event, false); err != nil {
+				return err
+			}
+		} else {
+			for _, line := range tracker.Changes(event) {
+				fmt.Println(line)
+			}
+		}
+
+		if !exitOnFinish {
+			continue
+		}
+		watched[jobID] = event.Type == kusciaapi.EventType_DELETED || isJobFinished(event.GetObject().GetStatus().GetState())
+		if allFinished(watched) {
+			return nil
+		}
+	}
+}
+
+func allFinished(watched map[string]bool) bool {
+	for _, finished := range watched {
+		if !finished {
+			return false
+		}
+	}
+	return true
+}
@@ -59,10 +59,14 @@ import (
 	"github.com/spf13/pflag"
 	kubectlcmd "k8s.io/kubectl/pkg/cmd"
 
+	"github.com/secretflow/kuscia/cmd/kuscia/backup"
 	"github.com/secretflow/kuscia/cmd/kuscia/container"
 	"github.com/secretflow/kuscia/cmd/kuscia/diagnose"
+	"github.com/secretflow/kuscia/cmd/kuscia/domain"
 	"github.com/secretflow/kuscia/cmd/kuscia/image"
+	"github.com/secretflow/kuscia/cmd/kuscia/job"
 	"github.com/secretflow/kuscia/cmd/kuscia/kusciainit"
+	"github.com/secretflow/kuscia/cmd/kuscia/migrate"
 	"github.com/secretflow/kuscia/cmd/kuscia/start"
 	_ "github.com/secretflow/kuscia/pkg/agent/middleware/plugins"
 	"github.com/secretflow/kuscia/pkg/utils/meta"
@@ -86,7 +90,12 @@ func main() {
 	rootCmd.AddCommand(container.NewContainerCommand(ctx))
 	rootCmd.AddCommand(start.NewStartCommand(ctx))
 	rootCmd.AddCommand(diagnose.NewDiagnoseCommand(ctx))
+	rootCmd.AddCommand(job.NewJobCommand(ctx))
+	rootCmd.AddCommand(domain.NewDomainCommand(ctx))
 	rootCmd.AddCommand(kusciainit.NewInitCommand(ctx))
+	rootCmd.AddCommand(backup.NewBackupCommand(ctx))
+	rootCmd.AddCommand(backup.NewRestoreCommand(ctx))
+	rootCmd.AddCommand(migrate.NewMigrateCommand(ctx))
 	rootCmd.AddCommand(kubectlcmd.NewDefaultKubectlCommand())
 	rootCmd.AddCommand(NewKernelCheckCommand(ctx))
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,150 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/migration"
+	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+)
+
+type options struct {
+	configFile  string
+	dryRun      bool
+	writeConfig bool
+}
+
+func NewMigrateCommand(ctx context.Context) *cobra.Command {
+	opts := &options{}
+	command := &cobra.Command{
+		Use:          "migrate",
+		Short:        "Migrate config file and kuscia resources written by former versions",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		Example: `
+# report the migrations to be applied
+kuscia migrate --dry-run
+
+# migrate kuscia resources and rewrite config file, the original config file is kept with .bak suffix
+kuscia migrate -c /home/kuscia/etc/conf/kuscia.yaml --write-config
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := migrateConfigFile(opts); err != nil {
+				return err
+			}
+			return migrateResources(ctx, opts)
+		},
+	}
+	command.Flags().StringVarP(&opts.configFile, "config", "c", filepath.Join(common.DefaultKusciaHomePath, "etc/conf/kuscia.yaml"),
+		"Kuscia config file")
+	command.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Report the migrations to be applied without applying them")
+	command.Flags().BoolVar(&opts.writeConfig, "write-config", false,
+		"Write the migrated config file, otherwise config migrations are only applied in memory when kuscia starts")
+	command.AddCommand(newListCommand())
+	return command
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List all migration steps",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, step := range migration.Steps() {
+				fmt.Printf("%s\t%s\n", step.ID, step.Description)
+			}
+			return nil
+		},
+	}
+}
+
+func migrateConfigFile(opts *options) error {
+	content, err := os.ReadFile(opts.configFile)
+	if err != nil {
+		return err
+	}
+	migrated, changes, err := migration.MigrateConfig(content)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Printf("config file %s is up to date\n", opts.configFile)
+		return nil
+	}
+	for _, change := range changes {
+		fmt.Printf("%sconfig: %s\n", dryRunPrefix(opts.dryRun), change)
+	}
+	if opts.dryRun || !opts.writeConfig {
+		return nil
+	}
+	info, err := os.Stat(opts.configFile)
+	if err != nil {
+		return err
+	}
+	backupFile := opts.configFile + ".bak"
+	if err := os.WriteFile(backupFile, content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("back up config file failed, %v", err)
+	}
+	if err := os.WriteFile(opts.configFile, migrated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("write config file failed, %v", err)
+	}
+	fmt.Printf("config file %s is migrated, the original file is kept in %s\n", opts.configFile, backupFile)
+	return nil
+}
+
+// migrateResources applies resource migrations on master and autonomy, which are also applied when kuscia starts.
+func migrateResources(ctx context.Context, opts *options) error {
+	commonConfig := confloader.LoadCommonConfig(opts.configFile)
+	mode := strings.ToLower(commonConfig.Mode)
+	if mode == common.RunModeLite {
+		return nil
+	}
+	kusciaConf := confloader.ReadConfig(opts.configFile, mode)
+	clients, err := kubeconfig.CreateClientSetsFromKubeconfig(kusciaConf.Master.APIServer.KubeConfig, kusciaConf.Master.APIServer.Endpoint)
+	if err != nil {
+		return fmt.Errorf("create kube clients failed, %v", err)
+	}
+	runner, err := migration.NewRunner(clients)
+	if err != nil {
+		return err
+	}
+	report, err := runner.Run(ctx, opts.dryRun)
+	if report != nil {
+		for _, change := range report.Changes {
+			fmt.Printf("%sresource: %s\n", dryRunPrefix(opts.dryRun), change)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sresource migrations applied: %d, skipped: %d\n", dryRunPrefix(opts.dryRun), len(report.Applied), len(report.Skipped))
+	return nil
+}
+
+func dryRunPrefix(dryRun bool) string {
+	if dryRun {
+		return "(dry run) "
+	}
+	return ""
+}
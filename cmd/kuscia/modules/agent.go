@@ -99,6 +99,7 @@ func NewAgent(i *ModuleRuntimeConfigs) (Module, error) {
 		conf.KusciaAPIToken = token
 	}
 	conf.DomainKeyData = i.DomainKeyData
+	conf.LocalStore = i.LocalStore
 
 	nlog.Debugf("Agent config is %+v", conf)
 
@@ -141,6 +142,10 @@ func (agent *agentModule) Run(ctx context.Context) error {
 	return commands.RunRootCommand(ctx, agent.conf, agent.clients.KubeClient)
 }
 
+func (agent *agentModule) Drain(ctx context.Context) error {
+	return commands.TerminatePods(ctx)
+}
+
 func (agent *agentModule) WaitReady(ctx context.Context) error {
 	return WaitChannelReady(ctx, commands.ReadyChan, 60*time.Second)
 }
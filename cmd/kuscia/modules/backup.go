@@ -0,0 +1,80 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/backup"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/election"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
+	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+	"github.com/secretflow/kuscia/pkg/utils/readyz"
+)
+
+const backupElectionName = "kuscia-backup"
+
+type backupModule struct {
+	moduleRuntimeBase
+	manager        *backup.Manager
+	clients        *kubeconfig.KubeClients
+	leaderElection election.Config
+}
+
+// BackupStores returns the SQLite stores of master backed up with kuscia resources, indexed by store name.
+func BackupStores(rootDir string) map[string]string {
+	return map[string]string{
+		"jobhistory": filepath.Join(rootDir, common.JobHistoryPrefix, jobhistory.DBFileName),
+	}
+}
+
+// NewBackupManager returns the backup manager of config, or nil if backup isn't configured.
+func NewBackupManager(config *backup.Config, domainID, rootDir string, clients *kubeconfig.KubeClients) (*backup.Manager, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+	return backup.NewManager(config, domainID, clients, BackupStores(rootDir))
+}
+
+func NewBackup(d *ModuleRuntimeConfigs) (Module, error) {
+	manager, err := NewBackupManager(&d.Backup, d.DomainID, d.RootDir, d.Clients)
+	if err != nil {
+		return nil, err
+	}
+	return &backupModule{
+		moduleRuntimeBase: moduleRuntimeBase{
+			name:         "backup",
+			readyTimeout: 60 * time.Second,
+			rdz: readyz.NewFuncReadyZ(func(ctx context.Context) error {
+				return nil
+			}),
+		},
+		manager:        manager,
+		clients:        d.Clients,
+		leaderElection: d.LeaderElection,
+	}, nil
+}
+
+// Run creates scheduled backups in the leader of master replicas.
+func (m *backupModule) Run(ctx context.Context) error {
+	elector := election.NewElector(m.clients.KubeClient, backupElectionName,
+		election.WithConfig(m.leaderElection),
+		election.WithOnStartedLeading(m.manager.Run))
+	elector.Run(ctx)
+	return nil
+}
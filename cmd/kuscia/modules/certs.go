@@ -0,0 +1,150 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"sync/atomic"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/certmanager"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+	frameworkconfig "github.com/secretflow/kuscia/pkg/web/framework/config"
+)
+
+// Names of the certs registered to cert manager.
+const (
+	certNameCA              = "ca"
+	certNameDomain          = "domain"
+	certNameKusciaAPIServer = "kusciaapi-server"
+	certNameEnvoyExternal   = "envoy-external"
+)
+
+// registerCerts registers the internal CA and the domain cert, the certs of modules are registered by the modules.
+func (d *ModuleRuntimeConfigs) registerCerts() {
+	d.CertManager.Register(certmanager.Cert{
+		Name:    certNameCA,
+		Kind:    certmanager.KindCA,
+		Load:    func() (*x509.Certificate, error) { return d.CACert, nil },
+		Rotator: certmanager.NewFileRotator(d.CAKey, nil, nil, d.CACertFile),
+	})
+
+	domainCert := certmanager.Cert{
+		Name: certNameDomain,
+		Kind: certmanager.KindDomain,
+		Load: func() (*x509.Certificate, error) { return d.DomainCert, nil },
+	}
+	if d.RunMode == common.RunModeLite {
+		// the cert of lite domain is issued by master, and renewed by cert issuance of master.
+		domainCert.Load = func() (*x509.Certificate, error) {
+			if cert, ok := d.DomainCertByMasterValue.Load().(*x509.Certificate); ok && cert != nil {
+				return cert, nil
+			}
+			return d.DomainCert, nil
+		}
+	} else {
+		domainCert.Rotator = certmanager.NewFileRotator(d.DomainKey, nil, nil, d.DomainCertFile)
+	}
+	d.CertManager.Register(domainCert)
+}
+
+// envoyCertRotator issues a new key pair of the external listener of envoy signed by the internal CA, and pushes it to
+// envoy by xds.
+type envoyCertRotator struct {
+	tlsConfig *kusciaconfig.TLSConfig
+	caCert    *x509.Certificate
+	caKey     *rsa.PrivateKey
+	current   atomic.Pointer[x509.Certificate]
+}
+
+func (r *envoyCertRotator) Issue(ctx context.Context, current *x509.Certificate) (*certmanager.KeyPair, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := certmanager.Reissue(current, key, r.caCert, r.caKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &certmanager.KeyPair{Cert: cert, Key: key}, nil
+}
+
+func (r *envoyCertRotator) Apply(ctx context.Context, issued *certmanager.KeyPair) (bool, error) {
+	tlsCert, err := config.LoadTLSCertByTLSConfig(r.tlsConfig)
+	if err != nil {
+		return false, err
+	}
+	if tlsCert.CertData, err = tlsutils.EncodeCert(issued.Cert); err != nil {
+		return false, err
+	}
+	tlsCert.KeyData = string(tlsutils.EncodePKCS1PrivateKey(issued.Key))
+	if err := xds.SetExternalCert(tlsCert); err != nil {
+		return false, err
+	}
+	r.current.Store(issued.Cert)
+	return true, nil
+}
+
+// registerEnvoyExternalCert registers the cert of the external listener of envoy, it's only rotatable if it's
+// generated by kuscia.
+func registerEnvoyExternalCert(d *ModuleRuntimeConfigs, tlsConfig *kusciaconfig.TLSConfig, generated bool) error {
+	tlsCert, err := config.LoadTLSCertByTLSConfig(tlsConfig)
+	if err != nil {
+		return err
+	}
+	cert, err := tlsutils.ParseCertData([]byte(tlsCert.CertData))
+	if err != nil {
+		return err
+	}
+	if !generated {
+		d.CertManager.Register(certmanager.Cert{
+			Name: certNameEnvoyExternal,
+			Kind: certmanager.KindEnvoy,
+			Load: func() (*x509.Certificate, error) { return cert, nil },
+		})
+		return nil
+	}
+
+	rotator := &envoyCertRotator{tlsConfig: tlsConfig, caCert: d.CACert, caKey: d.CAKey}
+	rotator.current.Store(cert)
+	d.CertManager.Register(certmanager.Cert{
+		Name:    certNameEnvoyExternal,
+		Kind:    certmanager.KindEnvoy,
+		Load:    func() (*x509.Certificate, error) { return rotator.current.Load(), nil },
+		Rotator: rotator,
+	})
+	return nil
+}
+
+// registerKusciaAPIServerCert registers the server cert of KusciaAPI, it's only rotatable if it's saved in file.
+func registerKusciaAPIServerCert(d *ModuleRuntimeConfigs, tlsConfig *frameworkconfig.TLSServerConfig) {
+	cert := certmanager.Cert{
+		Name: certNameKusciaAPIServer,
+		Kind: certmanager.KindAPIServer,
+		Load: func() (*x509.Certificate, error) { return tlsConfig.ServerCert, nil },
+	}
+	if tlsConfig.ServerCertData == "" && tlsConfig.ServerCertFile != "" {
+		cert.Rotator = certmanager.NewFileRotator(tlsConfig.ServerKey, tlsConfig.RootCA, tlsConfig.RootCAKey,
+			tlsConfig.ServerCertFile)
+	}
+	d.CertManager.Register(cert)
+}
@@ -66,6 +66,9 @@ func NewConfManager(d *ModuleRuntimeConfigs) (Module, error) {
 	if d.ConfManager != nil && len(d.ConfManager.Params) != 0 {
 		conf.Params = d.ConfManager.Params
 	}
+	if d.ConfManager != nil && len(d.ConfManager.AccessPolicies) != 0 {
+		conf.AccessPolicies = d.ConfManager.AccessPolicies
+	}
 	conf.DomainID = d.DomainID
 	conf.DomainKey = d.DomainKey
 	conf.TLS.RootCA = d.CACert
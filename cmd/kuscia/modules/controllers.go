@@ -16,6 +16,8 @@
 package modules
 
 import (
+	"fmt"
+
 	"github.com/secretflow/kuscia/pkg/controllers"
 	"github.com/secretflow/kuscia/pkg/controllers/clusterdomainroute"
 	"github.com/secretflow/kuscia/pkg/controllers/domain"
@@ -27,9 +29,34 @@ import (
 	"github.com/secretflow/kuscia/pkg/controllers/kusciatask"
 	"github.com/secretflow/kuscia/pkg/controllers/portflake"
 	"github.com/secretflow/kuscia/pkg/controllers/taskresourcegroup"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/imagepolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobpolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 )
 
 func NewControllersModule(i *ModuleRuntimeConfigs) (Module, error) {
+	if err := approval.CheckPolicies(i.WorkloadApprovePolicies); err != nil {
+		return nil, err
+	}
+	jobValidator, err := jobpolicy.Build(i.JobValidationPolicies)
+	if err != nil {
+		return nil, err
+	}
+	imageVerifier, err := imagepolicy.Build(i.ImagePolicy)
+	if err != nil {
+		return nil, err
+	}
+	if err := kusciaconfig.CheckHeartbeatConfig(&i.Heartbeat); err != nil {
+		return nil, err
+	}
+	if err := jobqueue.CheckConfig(&i.JobQueue); err != nil {
+		return nil, err
+	}
+	if i.JobTTLAfterFinished < 0 {
+		return nil, fmt.Errorf("jobTTLAfterFinished can't be negative")
+	}
 	opt := &controllers.Options{
 		ControllerName:        "kuscia-controller-manager",
 		HealthCheckPort:       8090,
@@ -38,50 +65,78 @@ func NewControllersModule(i *ModuleRuntimeConfigs) (Module, error) {
 		Namespace:             i.DomainID,
 		RootDir:               i.RootDir,
 		EnableWorkloadApprove: i.EnableWorkloadApprove,
+		ApprovePolicies:       i.WorkloadApprovePolicies,
+		JobValidator:          jobValidator,
+		ImageVerifier:         imageVerifier,
+		LeaderElection:        i.LeaderElection,
+		Heartbeat:             i.Heartbeat,
+		JobQueue:              i.JobQueue,
+		JobTTLAfterFinished:   i.JobTTLAfterFinished,
+		JobHistory:            i.JobHistory,
+		Controllers:           i.Controllers,
 	}
 
-	return controllers.NewServer(
-		opt, i.Clients,
-		[]controllers.ControllerConstruction{
-			{
-				NewControler: taskresourcegroup.NewController,
-				CRDNames:     []string{controllers.CRDTaskResourcesGroupsName, controllers.CRDTaskResourcesName},
-			},
-			{
-				NewControler: domain.NewController,
-				CRDNames:     []string{controllers.CRDDomainsName},
-			},
-			{
-				NewControler: kusciatask.NewController,
-				CRDNames:     []string{controllers.CRDKusciaTasksName, controllers.CRDAppImagesName},
-			},
-			{
-				NewControler: domainroute.NewController,
-				CRDNames:     []string{controllers.CRDDomainsName, controllers.CRDDomainRoutesName, controllers.CRDGatewaysName},
-			},
-			{
-				NewControler: clusterdomainroute.NewController,
-				CRDNames:     []string{controllers.CRDDomainsName, controllers.CRDClusterDomainRoutesName, controllers.CRDDomainRoutesName, controllers.CRDGatewaysName},
-			},
-			{
-				NewControler: kusciajob.NewController,
-				CRDNames:     []string{controllers.CRDKusciaJobsName},
-			},
-			{
-				NewControler: kusciadeployment.NewController,
-				CRDNames:     []string{controllers.CRDKusciaDeploymentsName},
-			},
-			{
-				NewControler: domaindata.NewController,
-				CRDNames:     []string{controllers.CRDDomainsName, controllers.CRDDomainDataGrantsName},
-			},
-			{
-				NewControler: portflake.NewController,
-			},
-
-			{
-				NewControler: garbagecollection.NewKusciaJobGCController,
-			},
+	constructions := []controllers.ControllerConstruction{
+		{
+			Name:         "taskresourcegroup",
+			NewControler: taskresourcegroup.NewController,
+			CRDNames:     []string{controllers.CRDTaskResourcesGroupsName, controllers.CRDTaskResourcesName},
+		},
+		{
+			Name:         "domain",
+			NewControler: domain.NewController,
+			CRDNames:     []string{controllers.CRDDomainsName},
+		},
+		{
+			Name:         "kusciatask",
+			NewControler: kusciatask.NewController,
+			CRDNames:     []string{controllers.CRDKusciaTasksName, controllers.CRDAppImagesName},
+		},
+		{
+			Name:         "domainroute",
+			NewControler: domainroute.NewController,
+			CRDNames:     []string{controllers.CRDDomainsName, controllers.CRDDomainRoutesName, controllers.CRDGatewaysName},
 		},
-	), nil
+		{
+			Name:         "clusterdomainroute",
+			NewControler: clusterdomainroute.NewController,
+			CRDNames:     []string{controllers.CRDDomainsName, controllers.CRDClusterDomainRoutesName, controllers.CRDDomainRoutesName, controllers.CRDGatewaysName},
+		},
+		{
+			Name:         "kusciajob",
+			NewControler: kusciajob.NewController,
+			CRDNames:     []string{controllers.CRDKusciaJobsName},
+		},
+		{
+			Name:         "kusciadeployment",
+			NewControler: kusciadeployment.NewController,
+			CRDNames:     []string{controllers.CRDKusciaDeploymentsName},
+		},
+		{
+			Name:         "domaindata",
+			NewControler: domaindata.NewController,
+			CRDNames:     []string{controllers.CRDDomainsName, controllers.CRDDomainDataGrantsName},
+		},
+		{
+			Name:         "portflake",
+			NewControler: portflake.NewController,
+		},
+		{
+			Name:         "jobgc",
+			NewControler: garbagecollection.NewKusciaJobGCController,
+		},
+	}
+	// interconn controllers run in their own module but share the overrides of controllers
+	if err := kusciaconfig.CheckControllersConfig(&i.Controllers, append(controllerNames(constructions), "interconn")); err != nil {
+		return nil, err
+	}
+	return controllers.NewServer(opt, i.Clients, constructions), nil
+}
+
+func controllerNames(constructions []controllers.ControllerConstruction) []string {
+	names := make([]string, 0, len(constructions))
+	for _, cc := range constructions {
+		names = append(names, cc.Name)
+	}
+	return names
 }
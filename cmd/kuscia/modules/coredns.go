@@ -32,6 +32,7 @@ import (
 
 	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/coredns"
+	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	"github.com/secretflow/kuscia/pkg/utils/lock"
 	"github.com/secretflow/kuscia/pkg/utils/network"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
@@ -167,8 +168,8 @@ func (s *CorednsModule) Name() string {
 	return "coredns"
 }
 
-func (s *CorednsModule) StartControllers(ctx context.Context, kubeclient kubernetes.Interface) {
-	s.coreDNSInstance.StartControllers(ctx, kubeclient)
+func (s *CorednsModule) StartControllers(ctx context.Context, kubeclient kubernetes.Interface, kusciaClient kusciaclientset.Interface) {
+	s.coreDNSInstance.StartControllers(ctx, kubeclient, kusciaClient)
 }
 
 func prepareResolvConf(rootDir string) error {
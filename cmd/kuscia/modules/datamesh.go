@@ -16,6 +16,7 @@ package modules
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
@@ -36,10 +37,15 @@ func NewDataMesh(d *ModuleRuntimeConfigs) (Module, error) {
 	conf.RootDir = d.RootDir
 	conf.DomainKey = d.DomainKey
 	conf.KubeClient = d.Clients.KubeClient
+	conf.CopyPort = int32(d.DataMeshCopyPort)
 	// override data proxy config
 	if d.DataMesh != nil {
 		conf.DisableTLS = d.DataMesh.DisableTLS
 		conf.DataProxyList = d.DataMesh.DataProxyList
+		conf.FlightTokenAuth = d.DataMesh.FlightTokenAuth
+	}
+	if conf.FlightTokenAuth && conf.DisableTLS {
+		return nil, fmt.Errorf("dataMesh.flightTokenAuth requires tls, dataMesh.disableTLS should be false")
 	}
 
 	conf.TLS.RootCA = d.CACert
@@ -28,6 +28,7 @@ const (
 	SubCMDClusterDomainRoute        = "cdr"
 	SubCMDNetwork                   = "network"
 	SubCMDAPP                       = "app"
+	SubCMDJob                       = "job"
 )
 
 type diagnoseModule struct {
@@ -48,6 +48,35 @@ func NewDomainRoute(i *ModuleRuntimeConfigs) (Module, error) {
 	conf.CsrData = i.DomainRoute.DomainCsrData
 	conf.CACert = i.CACert
 	conf.CAKey = i.CAKey
+	conf.ResponseCache = i.DomainRoute.ResponseCache
+	conf.HTTP3 = i.DomainRoute.HTTP3
+	conf.CertIssuance = i.DomainRoute.CertIssuance
+	conf.Egress = i.DomainRoute.Egress
+	conf.PeerRequest = i.DomainRoute.PeerRequest
+	conf.RequestTimeout = i.DomainRoute.RequestTimeout
+	conf.Tracing = i.Tracing
+	if i.Master.Sync.ResyncPeriod > 0 {
+		conf.ResyncPeriod = int(i.Master.Sync.ResyncPeriod / time.Second)
+	}
+	if err := kusciaconfig.CheckHeartbeatConfig(&i.Heartbeat); err != nil {
+		return nil, err
+	}
+	conf.HeartbeatInterval = i.Heartbeat.GetInterval()
+	if err := conf.ResponseCache.Check(); err != nil {
+		return nil, err
+	}
+	if err := conf.CertIssuance.Check(); err != nil {
+		return nil, err
+	}
+	if err := conf.Egress.Check(); err != nil {
+		return nil, err
+	}
+	if err := conf.PeerRequest.Check(); err != nil {
+		return nil, err
+	}
+	if err := conf.RequestTimeout.Check(); err != nil {
+		return nil, err
+	}
 
 	externalTLS := conf.ExternalTLS
 	if i.DomainRoute.ExternalTLS != nil {
@@ -65,15 +94,24 @@ func NewDomainRoute(i *ModuleRuntimeConfigs) (Module, error) {
 	}
 
 	if externalTLS != nil && externalTLS.EnableTLS {
+		generated := false
 		if externalTLS.KeyData == "" && externalTLS.CertData == "" {
 			var err error
 			externalTLS.KeyData, externalTLS.CertData, err = tlsutils.GenerateKeyCertPairData(i.CAKey, i.CACert, fmt.Sprintf("%s_ENVOY_EXTERNAL", conf.DomainID))
 			if err != nil {
 				nlog.Fatalf("Generate external keyCert pair error:%v", err.Error())
 			}
+			generated = true
+		}
+		if err := registerEnvoyExternalCert(i, externalTLS, generated); err != nil {
+			return nil, err
 		}
 	}
 	conf.ExternalTLS = externalTLS
+	if conf.HTTP3.Enabled() && (externalTLS == nil || !externalTLS.EnableTLS) {
+		nlog.Warnf("HTTP/3 requires TLS of external listener, it's disabled under protocol %s", protocol)
+		conf.HTTP3 = nil
+	}
 
 	if i.TransportPort > 0 {
 		conf.TransportConfig = &kusciaconfig.ServiceConfig{
@@ -85,6 +123,11 @@ func NewDomainRoute(i *ModuleRuntimeConfigs) (Module, error) {
 			Endpoint: fmt.Sprintf("http://127.0.0.1:%d", i.InterConnSchedulerPort),
 		}
 	}
+	if i.DataMeshCopyPort > 0 {
+		conf.DataMeshCopyConfig = &kusciaconfig.ServiceConfig{
+			Endpoint: fmt.Sprintf("http://127.0.0.1:%d", i.DataMeshCopyPort),
+		}
+	}
 
 	return &domainRouteModule{
 		conf:    conf,
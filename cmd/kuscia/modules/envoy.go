@@ -18,9 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -34,6 +39,8 @@ import (
 	"github.com/secretflow/kuscia/pkg/utils/supervisor"
 )
 
+const envoyAdminEndpoint = "http://127.0.0.1:10000"
+
 type envoyModule struct {
 	moduleRuntimeBase
 	rootDir               string
@@ -56,7 +63,7 @@ func NewEnvoy(i *ModuleRuntimeConfigs) (Module, error) {
 		moduleRuntimeBase: moduleRuntimeBase{
 			name:         "envoy",
 			readyTimeout: 60 * time.Second,
-			rdz: readyz.NewHTTPReadyZ("http://127.0.0.1:10000/ready", 200, func(body []byte) error {
+			rdz: readyz.NewHTTPReadyZ(envoyAdminEndpoint+"/ready", 200, func(body []byte) error {
 				res := string(body[:len(body)-1])
 				if res != "LIVE" {
 					return errors.New("response is not live")
@@ -112,6 +119,72 @@ func (s *envoyModule) Run(ctx context.Context) error {
 	})
 }
 
+// Drain makes listeners close connections gracefully, and waits until all downstream connections are closed. Envoy
+// still accepts new connections until drain time of command line is passed.
+func (s *envoyModule) Drain(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, envoyAdminEndpoint+"/drain_listeners?graceful", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("drain listeners failed, status_code: %d", resp.StatusCode)
+	}
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		active, err := envoyActiveConnections(ctx)
+		if err != nil {
+			nlog.Warnf("Get active connections of envoy failed, %v", err)
+		} else if active == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d connections are still active, %v", active, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func envoyActiveConnections(ctx context.Context) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, envoyAdminEndpoint+"/stats?filter="+
+		url.QueryEscape(`^listener\..*\.downstream_cx_active$`), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	return parseActiveConnections(string(body)), nil
+}
+
+// parseActiveConnections sums downstream_cx_active of listeners except the admin listener, which is used by us.
+func parseActiveConnections(stats string) int {
+	active := 0
+	for _, line := range strings.Split(stats, "\n") {
+		name, value, found := strings.Cut(line, ":")
+		if !found || strings.HasPrefix(name, "listener.admin.") || !strings.HasSuffix(name, ".downstream_cx_active") {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			active += n
+		}
+	}
+	return active
+}
+
 func (s *envoyModule) renderLogRotateConfig() (configPath string, err error) {
 
 	filePath := filepath.Join(s.rootDir, common.ConfPrefix, "logrotate.conf")
@@ -0,0 +1,32 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseActiveConnections(t *testing.T) {
+	t.Parallel()
+	stats := `listener.0.0.0.0_1080.downstream_cx_active: 2
+listener.0.0.0.0_80.downstream_cx_active: 1
+listener.admin.downstream_cx_active: 1
+listener.0.0.0.0_80.downstream_cx_total: 10
+`
+	assert.Equal(t, 3, parseActiveConnections(stats))
+	assert.Equal(t, 0, parseActiveConnections("listener.admin.downstream_cx_active: 1\n"))
+}
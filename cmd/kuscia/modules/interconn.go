@@ -21,5 +21,5 @@ import (
 )
 
 func NewInterConn(deps *ModuleRuntimeConfigs) (Module, error) {
-	return interconn.NewServer(context.Background(), deps.Clients)
+	return interconn.NewServer(context.Background(), deps.Clients, deps.LeaderElection, deps.Controllers)
 }
@@ -59,6 +59,7 @@ type kusciaAPIModule struct {
 	conf         *config.KusciaAPIConfig
 	kusciaClient kusciaclientset.Interface
 	kubeClient   kubernetes.Interface
+	server       *commands.Server
 }
 
 func NewKusciaAPI(d *ModuleRuntimeConfigs) (Module, error) {
@@ -78,6 +79,14 @@ func NewKusciaAPI(d *ModuleRuntimeConfigs) (Module, error) {
 	kusciaAPIConfig.Protocol = d.Protocol
 	kusciaAPIConfig.StdoutPath = d.Agent.StdoutPath
 	kusciaAPIConfig.NodeName = d.Agent.Node.NodeName
+	kusciaAPIConfig.JobHistory = d.JobHistory
+	if d.RunMode != common.RunModeLite {
+		manager, err := NewBackupManager(&d.Backup, d.DomainID, d.RootDir, d.Clients)
+		if err != nil {
+			return nil, err
+		}
+		kusciaAPIConfig.Backup = manager
+	}
 
 	protocol := kusciaAPIConfig.Protocol
 	if protocol == "" {
@@ -92,7 +101,9 @@ func NewKusciaAPI(d *ModuleRuntimeConfigs) (Module, error) {
 		if err := kusciaAPIConfig.TLS.LoadFromDataOrFile(nil, []string{kusciaAPISanDNSName}); err != nil {
 			return nil, err
 		}
+		registerKusciaAPIServerCert(d, kusciaAPIConfig.TLS)
 	}
+	kusciaAPIConfig.CertManager = d.CertManager
 
 	if kusciaAPIConfig.Token != nil {
 		tokenFile := kusciaAPIConfig.Token.TokenFile
@@ -131,6 +142,7 @@ func NewKusciaAPI(d *ModuleRuntimeConfigs) (Module, error) {
 		conf:         kusciaAPIConfig,
 		kusciaClient: d.Clients.KusciaClient,
 		kubeClient:   d.Clients.KubeClient,
+		server:       commands.NewServer(),
 	}, nil
 }
 
@@ -144,7 +156,11 @@ func initInterceptorLogger(kusciaConf confloader.KusciaConfig, logPath string) (
 }
 
 func (m *kusciaAPIModule) Run(ctx context.Context) error {
-	return commands.Run(ctx, m.conf, m.kusciaClient, m.kubeClient)
+	return m.server.Run(ctx, m.conf, m.kusciaClient, m.kubeClient)
+}
+
+func (m *kusciaAPIModule) Drain(ctx context.Context) error {
+	return m.server.Drain(ctx)
 }
 
 func KusciaAPIReadyZ(tlsConfig *webconfig.TLSServerConfig, httpPort, grpcPort int32, protocol common.Protocol, tokenConfig *config.TokenConfig) bool {
@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/secretflow/kuscia/pkg/agent/pod"
 	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/metricexporter"
+	"github.com/secretflow/kuscia/pkg/metricexporter/alerting"
 	"github.com/secretflow/kuscia/pkg/metricexporter/envoyexporter"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/readyz"
@@ -35,6 +38,7 @@ type metricExporterModule struct {
 	ssExportPort     string
 	metricExportPort string
 	podManager       pod.Manager
+	alertEngine      *alerting.Engine
 }
 
 func NewMetricExporter(i *ModuleRuntimeConfigs) (Module, error) {
@@ -71,10 +75,26 @@ func NewMetricExporter(i *ModuleRuntimeConfigs) (Module, error) {
 			"ss":            "http://localhost:" + i.SsExportPort + "/ssmetrics",
 		},
 	}
+
+	if i.Alerting.Enabled() {
+		// metrics of modules in the kuscia process are gathered directly, others are scraped
+		sources := []alerting.Source{alerting.NewGathererSource("kuscia", prometheus.DefaultGatherer)}
+		for name, url := range exporter.metricURLs {
+			sources = append(sources, alerting.NewURLSource(name, url))
+		}
+		engine, err := alerting.NewEngine(&i.Alerting, i.DomainID, sources)
+		if err != nil {
+			return nil, err
+		}
+		exporter.alertEngine = engine
+	}
 	return exporter, nil
 }
 
 func (exporter *metricExporterModule) Run(ctx context.Context) error {
+	if exporter.alertEngine != nil {
+		go exporter.alertEngine.Run(ctx)
+	}
 	metricexporter.MetricExporter(ctx, exporter.metricURLs, exporter.metricExportPort)
 	return nil
 }
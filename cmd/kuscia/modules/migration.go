@@ -0,0 +1,64 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modules
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/migration"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/readyz"
+)
+
+type migrationModule struct {
+	moduleRuntimeBase
+	runner *migration.Runner
+	done   atomic.Bool
+}
+
+func NewMigration(d *ModuleRuntimeConfigs) (Module, error) {
+	runner, err := migration.NewRunner(d.Clients)
+	if err != nil {
+		return nil, err
+	}
+	m := &migrationModule{runner: runner}
+	m.moduleRuntimeBase = moduleRuntimeBase{
+		name:         "migration",
+		readyTimeout: 300 * time.Second,
+		rdz: readyz.NewFuncReadyZ(func(ctx context.Context) error {
+			if !m.done.Load() {
+				return fmt.Errorf("resource migrations are not finished")
+			}
+			return nil
+		}),
+	}
+	return m, nil
+}
+
+// Run applies resource migrations before the modules using kuscia resources start, then exits.
+func (m *migrationModule) Run(ctx context.Context) error {
+	report, err := m.runner.Run(ctx, false)
+	if err != nil {
+		return err
+	}
+	for _, change := range report.Changes {
+		nlog.Infof("Resource migration: %s", change)
+	}
+	m.done.Store(true)
+	return nil
+}
@@ -34,6 +34,7 @@ var (
 	k3sDataDirPrefix              = "var/k3s/"
 	kusciaLogPath                 = "var/logs/kuscia.log"
 	defaultInterConnSchedulerPort = 8084
+	defaultDataMeshCopyPort       = 8072
 	defaultEndpointForLite        = "http://apiserver.master.svc"
 )
 
@@ -81,6 +82,11 @@ func (mr *moduleRuntimeBase) WaitReady(ctx context.Context) error {
 	}
 }
 
+// IsReady checks the module once, it's used by health checks after the module started.
+func (mr *moduleRuntimeBase) IsReady(ctx context.Context) error {
+	return mr.rdz.IsReady(ctx)
+}
+
 func (mr *moduleRuntimeBase) Name() string {
 	return mr.name
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
@@ -28,12 +29,17 @@ import (
 
 	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
 	"github.com/secretflow/kuscia/pkg/agent/config"
+	"github.com/secretflow/kuscia/pkg/certmanager"
 	"github.com/secretflow/kuscia/pkg/common"
 	trconfig "github.com/secretflow/kuscia/pkg/transport/config"
+	"github.com/secretflow/kuscia/pkg/utils/confbus"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
 	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+	"github.com/secretflow/kuscia/pkg/utils/localstore"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/nlog/zlogwriter"
 	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 	"github.com/secretflow/kuscia/pkg/web/logs"
 )
 
@@ -50,18 +56,45 @@ type ModuleRuntimeConfigs struct {
 	TransportConfigFile     string
 	TransportPort           int
 	InterConnSchedulerPort  int
+	DataMeshCopyPort        int
 	SsExportPort            string
 	NodeExportPort          string
 	MetricExportPort        string
+	HealthPort              string
 	KusciaKubeConfig        string
 	EnableContainerd        bool
 	Image                   *confloader.ImageConfig
 	DomainCertByMasterValue atomic.Value // the value is <*x509.Certificate>
 	LogConfig               *nlog.LogConfig
 	Logrorate               confloader.LogrotateConfig
+	// LocalStore persists local state of lite nodes, it's nil in other modes.
+	LocalStore localstore.Store
+	// JobHistory archives finished jobs on master and autonomy, it's nil in lite mode.
+	JobHistory jobhistory.Store
+	// CertManager keeps the certs used by modules, which are inspected and rotated by KusciaAPI.
+	CertManager *certmanager.Manager
+	// shutdownTracing flushes the pending spans.
+	shutdownTracing func(context.Context) error
 }
 
 func (d *ModuleRuntimeConfigs) Close() {
+	if d.LocalStore != nil {
+		if err := d.LocalStore.Close(); err != nil {
+			nlog.Warnf("Close local store failed, %v", err)
+		}
+	}
+	if d.JobHistory != nil {
+		if err := d.JobHistory.Close(); err != nil {
+			nlog.Warnf("Close job history store failed, %v", err)
+		}
+	}
+	if d.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), tracing.ShutdownTimeout)
+		defer cancel()
+		if err := d.shutdownTracing(ctx); err != nil {
+			nlog.Warnf("Flush spans failed, %v", err)
+		}
+	}
 }
 
 func (d *ModuleRuntimeConfigs) LoadCaDomainKeyAndCert() error {
@@ -126,6 +159,9 @@ func InitLogs(logConfig *nlog.LogConfig) error {
 	logs.Setup(nlog.SetWriter(zlog))
 	klog.SetOutput(nlog.DefaultLogger())
 	klog.LogToStderr(false)
+	if w, ok := zlog.(*zlogwriter.Writer); ok {
+		confbus.Subscribe(confbus.FieldLogLevel, "log", w.ChangeLogLevel)
+	}
 	return nil
 }
 
@@ -133,6 +169,9 @@ func initLoggerConfig(kusciaConf confloader.KusciaConfig, logPath string) *nlog.
 	return &nlog.LogConfig{
 		LogPath:       path.Join(kusciaConf.RootDir, logPath),
 		LogLevel:      kusciaConf.LogLevel,
+		LogFormat:     kusciaConf.LogFormat,
+		ModuleLevels:  kusciaConf.ModuleLogLevels,
+		Fields:        map[string]string{nlog.FieldDomain: kusciaConf.DomainID},
 		MaxFileSizeMB: kusciaConf.Logrotate.MaxFileSizeMB,
 		MaxFiles:      kusciaConf.Logrotate.MaxFiles,
 		Compress:      true,
@@ -140,6 +179,17 @@ func initLoggerConfig(kusciaConf confloader.KusciaConfig, logPath string) *nlog.
 }
 
 func NewModuleRuntimeConfigs(ctx context.Context, kusciaConf confloader.KusciaConfig) *ModuleRuntimeConfigs {
+	dependencies, err := BuildModuleRuntimeConfigs(ctx, kusciaConf)
+	if err != nil {
+		nlog.Fatal(err)
+	}
+	return dependencies
+}
+
+// BuildModuleRuntimeConfigs prepares the logs, certs, clients and stores shared by modules. Unlike
+// NewModuleRuntimeConfigs, it returns errors instead of exiting the process, so it's safe for applications embedding
+// kuscia. The returned configs should be closed after modules exit.
+func BuildModuleRuntimeConfigs(ctx context.Context, kusciaConf confloader.KusciaConfig) (*ModuleRuntimeConfigs, error) {
 	dependencies := &ModuleRuntimeConfigs{
 		KusciaConfig: kusciaConf,
 	}
@@ -147,17 +197,22 @@ func NewModuleRuntimeConfigs(ctx context.Context, kusciaConf confloader.KusciaCo
 	// init log
 	logConfig := initLoggerConfig(kusciaConf, kusciaLogPath)
 	if err := InitLogs(logConfig); err != nil {
-		nlog.Fatal(err)
+		return nil, err
 	}
 
 	nlog.Debugf("Read kuscia config: %+v", kusciaConf)
+	shutdownTracing, err := tracing.Init(ctx, "kuscia-"+strings.ToLower(kusciaConf.RunMode), kusciaConf.DomainID, kusciaConf.Tracing)
+	if err != nil {
+		return nil, err
+	}
+	dependencies.shutdownTracing = shutdownTracing
 	dependencies.LogConfig = logConfig
 	dependencies.Logrorate = kusciaConf.Logrotate
 	dependencies.Image = &kusciaConf.Image
 	// make runtime dir
-	err := dependencies.EnsureDir()
-	if err != nil {
-		nlog.Fatal(err)
+	if err = dependencies.EnsureDir(); err != nil {
+		dependencies.Close()
+		return nil, err
 	}
 
 	// for master and autonomy, get k3s backend config.
@@ -173,8 +228,10 @@ func NewModuleRuntimeConfigs(ctx context.Context, kusciaConf confloader.KusciaCo
 		dependencies.TransportConfigFile = filepath.Join(dependencies.RootDir, "etc/conf/transport/transport.yaml")
 		dependencies.TransportPort, err = GetTransportPort(dependencies.TransportConfigFile)
 		if err != nil {
-			nlog.Fatal(err)
+			dependencies.Close()
+			return nil, err
 		}
+		dependencies.DataMeshCopyPort = defaultDataMeshCopyPort
 		dependencies.EnableContainerd = false
 		if dependencies.Agent.Provider.Runtime == config.ContainerRuntime {
 			dependencies.EnableContainerd = true
@@ -186,23 +243,42 @@ func NewModuleRuntimeConfigs(ctx context.Context, kusciaConf confloader.KusciaCo
 
 	// init certs
 	if err = dependencies.LoadCaDomainKeyAndCert(); err != nil {
-		nlog.Fatal(err)
+		dependencies.Close()
+		return nil, err
 	}
+	dependencies.CertManager = certmanager.NewManager()
+	dependencies.registerCerts()
 
 	// init exporter ports
 	dependencies.SsExportPort = "9092"
 	dependencies.NodeExportPort = "9100"
 	dependencies.MetricExportPort = "9091"
+	dependencies.HealthPort = "9093"
 
 	if strings.ToLower(dependencies.RunMode) == common.RunModeLite {
 		clients, err := kubeconfig.CreateClientSetsFromKubeconfig(dependencies.KubeconfigFile, dependencies.ApiserverEndpoint)
 		if err != nil {
-			nlog.Fatalf("init k3s client failed with err: %s", err.Error())
+			dependencies.Close()
+			return nil, fmt.Errorf("init k3s client failed with err: %s", err.Error())
 		}
 
 		dependencies.Clients = clients
+
+		store, err := localstore.New(ctx, dependencies.LocalStoreEndpoint, filepath.Join(dependencies.RootDir, common.LocalStorePrefix))
+		if err != nil {
+			dependencies.Close()
+			return nil, fmt.Errorf("init local store failed with err: %s", err.Error())
+		}
+		dependencies.LocalStore = store
+	} else {
+		store, err := jobhistory.New(ctx, filepath.Join(dependencies.RootDir, common.JobHistoryPrefix))
+		if err != nil {
+			dependencies.Close()
+			return nil, fmt.Errorf("init job history store failed with err: %s", err.Error())
+		}
+		dependencies.JobHistory = store
 	}
-	return dependencies
+	return dependencies, nil
 }
 
 func GetTransportPort(configPath string) (int, error) {
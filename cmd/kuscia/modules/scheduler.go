@@ -37,6 +37,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/scheduler/kusciascheduling"
 	"github.com/secretflow/kuscia/pkg/scheduler/queuesort"
 	"github.com/secretflow/kuscia/pkg/utils/common"
+	"github.com/secretflow/kuscia/pkg/utils/election"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
@@ -67,6 +68,18 @@ func NewScheduler(i *ModuleRuntimeConfigs) (Module, error) {
 		Logs:    logs.NewOptions(),
 	}
 
+	if le := i.LeaderElection; le.LeaseDuration > 0 || le.RenewDeadline > 0 || le.RetryPeriod > 0 {
+		if le.LeaseDuration > 0 {
+			o.LeaderElection.LeaseDuration = metav1.Duration{Duration: le.LeaseDuration}
+		}
+		if le.RenewDeadline > 0 {
+			o.LeaderElection.RenewDeadline = metav1.Duration{Duration: le.RenewDeadline}
+		}
+		if le.RetryPeriod > 0 {
+			o.LeaderElection.RetryPeriod = metav1.Duration{Duration: le.RetryPeriod}
+		}
+	}
+
 	o.Authentication.TolerateInClusterLookupFailure = true
 	o.Authentication.RemoteKubeConfigFileOptional = true
 	o.Authorization.RemoteKubeConfigFileOptional = true
@@ -170,12 +183,18 @@ func (s *schedulerModule) startScheduler(ctx context.Context, cc *schedulerserve
 
 	// If leader election is enabled, runCommand via LeaderElector until done and exit.
 	if cc.LeaderElection != nil {
+		leaseName := cc.LeaderElection.Name
 		cc.LeaderElection.Callbacks = leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) {
+				election.RecordLeading(leaseName, true)
 				close(waitingForLeader)
 				sched.Run(ctx)
 			},
+			OnNewLeader: func(identity string) {
+				election.RecordNewLeader(leaseName, identity)
+			},
 			OnStoppedLeading: func() {
+				election.RecordLeading(leaseName, false)
 				select {
 				case <-ctx.Done():
 					// We were asked to terminate.
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"time"
 
+	"k8s.io/client-go/kubernetes"
+
 	pkgcom "github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/ssexporter"
 	"github.com/secretflow/kuscia/pkg/utils/readyz"
@@ -31,10 +33,15 @@ type ssExporterModule struct {
 	rootDir            string
 	metricUpdatePeriod uint
 	ssExportPort       string
+	kubeClient         kubernetes.Interface
 }
 
 func NewSsExporter(i *ModuleRuntimeConfigs) (Module, error) {
 	readyURI := fmt.Sprintf("http://127.0.0.1:%s", i.SsExportPort)
+	var kubeClient kubernetes.Interface
+	if i.Clients != nil {
+		kubeClient = i.Clients.KubeClient
+	}
 	return &ssExporterModule{
 		moduleRuntimeBase: moduleRuntimeBase{
 			name:         "ssexporter",
@@ -48,9 +55,11 @@ func NewSsExporter(i *ModuleRuntimeConfigs) (Module, error) {
 		rootDir:            i.RootDir,
 		metricUpdatePeriod: i.MetricUpdatePeriod,
 		ssExportPort:       i.SsExportPort,
+		kubeClient:         kubeClient,
 	}, nil
 }
 
 func (exporter *ssExporterModule) Run(ctx context.Context) error {
-	return ssexporter.SsExporter(ctx, exporter.runMode, exporter.domainID, exporter.metricUpdatePeriod, exporter.ssExportPort)
+	return ssexporter.SsExporter(ctx, exporter.runMode, exporter.domainID, exporter.metricUpdatePeriod, exporter.ssExportPort,
+		exporter.kubeClient)
 }
@@ -0,0 +1,245 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package start
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/cmd/kuscia/modules"
+	"github.com/secretflow/kuscia/cmd/kuscia/utils"
+	"github.com/secretflow/kuscia/pkg/agent/config"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/admin"
+	"github.com/secretflow/kuscia/pkg/utils/confbus"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/runtime"
+)
+
+// Options configures a kuscia instance, which runs in its own process by `kuscia start`, or in the process of a host
+// application embedding kuscia as a library.
+type Options struct {
+	// ConfigFile is the path of kuscia.yaml, changes of hot-reloadable fields are applied while kuscia is running.
+	// It's ignored if Config is set.
+	ConfigFile string
+	// Config is injected by the host application instead of loading ConfigFile, it's usually built from
+	// confloader.DefaultConfig. RunMode and DomainID are required.
+	Config *confloader.KusciaConfig
+	// Modules selects the modules to run, the modules they depend on are run too. All modules of the run mode are
+	// run if it's empty.
+	Modules []string
+	// Embedded leaves the process-wide servers and settings to the host application, i.e. pprof, health server,
+	// admin server and OOM score of the process.
+	Embedded bool
+}
+
+// Kuscia is an instance of kuscia. It's created by New, runs modules after Start and stops them by Stop.
+type Kuscia struct {
+	opts     Options
+	conf     *modules.ModuleRuntimeConfigs
+	mm       ModuleManager
+	snapshot *confloader.ConfigSnapshot
+
+	mu        sync.Mutex
+	started   bool
+	cancel    context.CancelFunc
+	ready     chan struct{}
+	readyOnce sync.Once
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+// New loads the config and prepares the modules of kuscia, no module runs until Start is called. The instance must
+// be stopped by Stop even if it's never started, so the resources shared by modules are released.
+func New(ctx context.Context, opts Options) (*Kuscia, error) {
+	k := &Kuscia{
+		opts:  opts,
+		ready: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	kusciaConf, err := k.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if kusciaConf.DomainID == common.UnSupportedDomainID {
+		return nil, fmt.Errorf("domain id can't be '%s', please check the config", common.UnSupportedDomainID)
+	}
+
+	if k.snapshot != nil {
+		confbus.Init(k.snapshot.Hash, k.snapshot.HotValues())
+	} else {
+		confbus.Init("", map[string]string{
+			confbus.FieldLogLevel:           kusciaConf.LogLevel,
+			confbus.FieldMetricUpdatePeriod: strconv.FormatUint(uint64(kusciaConf.MetricUpdatePeriod), 10),
+		})
+	}
+
+	if k.conf, err = modules.BuildModuleRuntimeConfigs(ctx, kusciaConf); err != nil {
+		return nil, err
+	}
+	if err = k.check(); err != nil {
+		k.conf.Close()
+		return nil, err
+	}
+
+	k.mm = NewModuleManager()
+	names := registerModules(k.mm, k.conf)
+	if err = k.mm.Select(opts.Modules...); err != nil {
+		k.conf.Close()
+		return nil, err
+	}
+	if err = k.mm.AddReadyHook(func(ctx context.Context, _ map[string]modules.Module) error {
+		k.readyOnce.Do(func() { close(k.ready) })
+		return nil
+	}, names...); err != nil {
+		k.conf.Close()
+		return nil, err
+	}
+	return k, nil
+}
+
+func (k *Kuscia) loadConfig() (confloader.KusciaConfig, error) {
+	if k.opts.Config != nil {
+		conf := *k.opts.Config
+		conf.RunMode = strings.ToLower(conf.RunMode)
+		if conf.RunMode == "" {
+			return conf, errors.New("run mode of kuscia config should not be empty")
+		}
+		if conf.DomainID == "" {
+			return conf, errors.New("kuscia config domain should not be empty")
+		}
+		return conf, nil
+	}
+
+	if k.opts.ConfigFile == "" {
+		return confloader.KusciaConfig{}, errors.New("either config file or config of kuscia should be set")
+	}
+	conf, err := confloader.LoadKusciaConfig(k.opts.ConfigFile)
+	if err != nil {
+		return conf, err
+	}
+	if k.snapshot, err = confloader.LoadConfigSnapshot(k.opts.ConfigFile); err != nil {
+		return conf, err
+	}
+	return conf, nil
+}
+
+func (k *Kuscia) check() error {
+	if k.conf.Agent.Provider.Runtime == config.ContainerRuntime && !runtime.Permission.HasPrivileged() {
+		nlog.Errorf("Runc must run with privileged mode")
+		nlog.Errorf("Please run kuscia like: docker run --privileged secretflow/kuscia")
+		return errors.New("permission is error")
+	}
+	return k.conf.Admin.Check()
+}
+
+// Start runs the modules in background, it returns once they are launched. Ready is closed when all modules are
+// ready, and Done is closed when they exit, either by Stop, the cancellation of ctx or the failure of any module.
+func (k *Kuscia) Start(ctx context.Context) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.started {
+		return errors.New("kuscia is already started")
+	}
+
+	if !k.opts.Embedded {
+		utils.SetupPprof(k.conf.Debug, k.conf.DebugPort)
+		utils.SetupHealthServer(ctx, k.conf.HealthPort)
+		if err := admin.Start(ctx, k.conf.Admin, k.conf.RootDir); err != nil {
+			return err
+		}
+		if runtime.Permission.HasSetOOMScorePermission() {
+			modules.SetKusciaOOMScore()
+		}
+	}
+	registerHealthChecks(k.conf)
+	if k.snapshot != nil {
+		go confloader.WatchConfig(ctx, k.opts.ConfigFile, confloader.DefaultReloadInterval, k.snapshot,
+			func(snapshot *confloader.ConfigSnapshot, restartRequired []string) {
+				confbus.Publish(snapshot.Hash, snapshot.HotValues(), restartRequired)
+			})
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	k.cancel = cancel
+	k.started = true
+	go func() {
+		defer close(k.done)
+		k.err = k.mm.Start(runCtx, k.conf.RunMode, k.conf)
+		nlog.Infof("Kuscia Instance [%s] shut down", k.conf.DomainID)
+	}()
+	return nil
+}
+
+// Ready is closed when all modules are ready.
+func (k *Kuscia) Ready() <-chan struct{} {
+	return k.ready
+}
+
+// Done is closed when all modules exit.
+func (k *Kuscia) Done() <-chan struct{} {
+	return k.done
+}
+
+// Err returns why modules exit, it's valid after Done is closed.
+func (k *Kuscia) Err() error {
+	select {
+	case <-k.done:
+		return k.err
+	default:
+		return nil
+	}
+}
+
+// Config returns the configs shared by modules, e.g. the kube clients and certs of domain.
+func (k *Kuscia) Config() *modules.ModuleRuntimeConfigs {
+	return k.conf
+}
+
+// Stop stops the modules gracefully and releases the resources shared by them, it waits until all modules exit.
+func (k *Kuscia) Stop() error {
+	k.mu.Lock()
+	started := k.started
+	k.mu.Unlock()
+
+	var err error
+	if started {
+		k.cancel()
+		<-k.done
+		err = k.err
+	}
+	k.closeOnce.Do(k.conf.Close)
+	return err
+}
+
+// Run starts kuscia and blocks until ctx is canceled or modules exit.
+func (k *Kuscia) Run(ctx context.Context) error {
+	if err := k.Start(ctx); err != nil {
+		k.closeOnce.Do(k.conf.Close)
+		return err
+	}
+	select {
+	case <-ctx.Done():
+	case <-k.done:
+	}
+	return k.Stop()
+}
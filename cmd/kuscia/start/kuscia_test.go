@@ -0,0 +1,44 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package start
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func TestNew_InvalidConfig(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := New(ctx, Options{})
+	assert.Error(t, err)
+
+	_, err = New(ctx, Options{ConfigFile: "/not-exist/kuscia.yaml"})
+	assert.Error(t, err)
+
+	_, err = New(ctx, Options{Config: &confloader.KusciaConfig{DomainID: "alice"}})
+	assert.Error(t, err)
+
+	_, err = New(ctx, Options{Config: &confloader.KusciaConfig{RunMode: common.RunModeLite}})
+	assert.Error(t, err)
+
+	_, err = New(ctx, Options{Config: &confloader.KusciaConfig{RunMode: common.RunModeLite, DomainID: common.UnSupportedDomainID}})
+	assert.Error(t, err)
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/secretflow/kuscia/cmd/kuscia/modules"
 	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/admin"
+	"github.com/secretflow/kuscia/pkg/utils/healthz"
 	"github.com/secretflow/kuscia/pkg/utils/lock"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
@@ -39,6 +41,9 @@ type ModuleManager interface {
 	// when [modules] are all ready, hook will called
 	AddReadyHook(hook ModuleReadyHook, modules ...string) error
 
+	// select modules to run with the modules they depend on, all modules of run mode are run if none is selected
+	Select(names ...string) error
+
 	// start to run all modules
 	Start(ctx context.Context, mode common.RunModeType, conf *modules.ModuleRuntimeConfigs) error
 }
@@ -76,6 +81,15 @@ type kusciaModuleManager struct {
 
 	// run failed module use this chan to notify
 	runFailedModuleCh chan *moduleInfo
+
+	// modules are drained in this time before they are stopped, zero means no draining
+	drainTimeout time.Duration
+
+	// selected modules, nil means all modules
+	selected map[string]bool
+
+	// supervisor restarts crashed modules
+	supervisor *supervisor
 }
 
 func NewModuleManager() ModuleManager {
@@ -144,8 +158,38 @@ func (kmm *kusciaModuleManager) AddReadyHook(hook ModuleReadyHook, modules ...st
 	return nil
 }
 
+func (kmm *kusciaModuleManager) Select(names ...string) error {
+	for _, name := range names {
+		if _, ok := kmm.modules[name]; !ok {
+			return fmt.Errorf("invalidate module %s", name)
+		}
+	}
+	if len(names) == 0 {
+		kmm.selected = nil
+		return nil
+	}
+	kmm.selected = map[string]bool{}
+	for _, name := range names {
+		kmm.selected[name] = true
+	}
+	return nil
+}
+
 func (kmm *kusciaModuleManager) Start(ctx context.Context, mode common.RunModeType, conf *modules.ModuleRuntimeConfigs) error {
 	modules, moduleReverseDeps := kmm.initNeedStartModules(mode)
+	if conf != nil {
+		kmm.drainTimeout = time.Duration(conf.DrainTimeout) * time.Second
+	}
+	kmm.supervisor = newSupervisor(conf)
+	for _, mc := range modules {
+		mc.setState(moduleStatePending, nil)
+	}
+	admin.RegisterStatus("modules", func(ctx context.Context) (interface{}, error) {
+		return kmm.supervisor.status(modules), nil
+	})
+	healthz.AddLivenessCheck("kuscia", "modules", func(ctx context.Context) error {
+		return kmm.supervisor.checkCritical(modules)
+	})
 
 	// parent context do not use `ctx`; otherwise parent context(`ctx`) canceled, every module's context will cancel at once
 	kmm.ctx, kmm.cancel = context.WithCancel(context.Background())
@@ -167,7 +211,11 @@ func (kmm *kusciaModuleManager) Start(ctx context.Context, mode common.RunModeTy
 		case newCreateModule := <-kmm.newlyModuleCh:
 			go func() {
 				nlog.Infof("new created module: %v", newCreateModule.name)
-				newCreateModule.readyError = newCreateModule.instance.WaitReady(ctx)
+				newCreateModule.readyError = newCreateModule.getInstance().WaitReady(ctx)
+				if newCreateModule.readyError == nil {
+					newCreateModule.ready.Store(true)
+					newCreateModule.setState(moduleStateRunning, nil)
+				}
 				kmm.readyModuleCh <- newCreateModule
 			}()
 		case readyModule = <-kmm.readyModuleCh:
@@ -196,7 +244,7 @@ func (kmm *kusciaModuleManager) Start(ctx context.Context, mode common.RunModeTy
 
 		for _, name := range moduleReverseDeps[readyModule.name] {
 			mc := modules[name]
-			if mc.instance == nil { // module is not started
+			if mc.getInstance() == nil { // module is not started
 				if mc.isModuleDepReady(modules) {
 					if err := kmm.runModule(kmm.ctx, mc, conf); err != nil {
 						return err
@@ -238,7 +286,7 @@ func (kmm *kusciaModuleManager) callbackHookFunctions(ms map[string]*moduleInfo)
 		depModules := map[string]modules.Module{}
 		for _, dep := range hf.modules {
 			if m, ok := ms[dep]; ok {
-				depModules[dep] = m.instance
+				depModules[dep] = m.getInstance()
 				if !m.isReadyWaitDone {
 					isAllReady = false
 					break
@@ -289,6 +337,9 @@ func (kmm *kusciaModuleManager) initNeedStartModules(mode common.RunModeType) (m
 			startModules[mc.name] = mc
 		}
 	}
+	if kmm.selected != nil {
+		startModules = kmm.selectModules(startModules)
+	}
 
 	reverseDep := map[string][]string{}
 	for _, mc := range startModules {
@@ -305,10 +356,37 @@ func (kmm *kusciaModuleManager) initNeedStartModules(mode common.RunModeType) (m
 	return startModules, reverseDep
 }
 
+// selectModules keeps the selected modules and the modules they depend on directly or indirectly.
+func (kmm *kusciaModuleManager) selectModules(modeModules map[string]*moduleInfo) map[string]*moduleInfo {
+	selected := map[string]*moduleInfo{}
+	var visit func(name string)
+	visit = func(name string) {
+		mc, ok := modeModules[name]
+		if !ok {
+			return
+		}
+		if _, ok := selected[name]; ok {
+			return
+		}
+		selected[name] = mc
+		for _, dep := range mc.dependencies {
+			visit(dep)
+		}
+	}
+	for name := range kmm.selected {
+		if _, ok := modeModules[name]; !ok {
+			nlog.Warnf("Module %s doesn't run in current mode, skip it", name)
+			continue
+		}
+		visit(name)
+	}
+	return selected
+}
+
 func (kmm *kusciaModuleManager) isAllModulesStarted(modules map[string]*moduleInfo) bool {
 	allModuleStarted := true
 	for _, mc := range modules {
-		if mc.instance == nil || !mc.isReadyWaitDone {
+		if mc.getInstance() == nil || !mc.isReadyWaitDone {
 			allModuleStarted = false
 		}
 	}
@@ -317,35 +395,86 @@ func (kmm *kusciaModuleManager) isAllModulesStarted(modules map[string]*moduleIn
 
 func (kmm *kusciaModuleManager) runModule(ctx context.Context, mc *moduleInfo, conf *modules.ModuleRuntimeConfigs) error {
 	nlog.Infof("Try to start module %s", mc.name)
-	var err error
-	mc.instance, err = mc.creator(conf)
+	instance, err := mc.creator(conf)
 	if err != nil {
 		nlog.Errorf("[Module] %s init failed with error: %s", mc.name, err.Error())
+		mc.setState(moduleStateFailed, err)
 		return err
 	}
 	nlog.Infof("[Module] %s is created", mc.name)
+	mc.setInstance(instance)
+	mc.setState(moduleStateStarting, nil)
 
 	mc.ctx, mc.cancel = context.WithCancel(ctx)
+	registerModuleHealthChecks(mc)
 
 	kmm.newlyModuleCh <- mc
 
 	kmm.wg.Add(1)
 	mc.finishWG.Add(1)
+	mc.running.Store(true)
 	go func() {
 		defer kmm.wg.Done()
 		defer mc.finishWG.Done()
-		err := mc.instance.Run(mc.ctx)
-		if err != nil {
-			nlog.Infof("[Module] %s is finished with err=%s", mc.name, err.Error())
-			kmm.runFailedModuleCh <- mc
-		} else {
-			nlog.Infof("[Module] %s is successful finished", mc.name)
-		}
+		kmm.superviseModule(mc, conf)
 	}()
 
 	return nil
 }
 
+// drainer is implemented by modules which stop accepting new work and finish the in-flight work before exit.
+type drainer interface {
+	Drain(ctx context.Context) error
+}
+
+// drainModules drains all running modules at the same time, all modules are still running while draining, so the
+// in-flight work can use the modules they depend on.
+func (kmm *kusciaModuleManager) drainModules(modules map[string]*moduleInfo) {
+	if kmm.drainTimeout <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), kmm.drainTimeout)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	for _, mc := range modules {
+		d, ok := mc.getInstance().(drainer)
+		if !ok || !mc.running.Load() {
+			continue
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			nlog.Infof("[Module] %s start draining...", name)
+			if err := d.Drain(ctx); err != nil {
+				nlog.Warnf("[Module] %s drain failed, %v", name, err)
+				return
+			}
+			nlog.Infof("[Module] %s is drained", name)
+		}(mc.name)
+	}
+	wg.Wait()
+}
+
+// readyChecker is implemented by modules which can be checked after they are ready.
+type readyChecker interface {
+	IsReady(ctx context.Context) error
+}
+
+// registerModuleHealthChecks registers checks of the module, they are registered again with the new instance when the
+// module is restarted. A module waiting to be restarted is regarded as alive.
+func registerModuleHealthChecks(mc *moduleInfo) {
+	healthz.AddLivenessCheck(mc.name, "running", func(ctx context.Context) error {
+		if !mc.running.Load() && mc.getState() != moduleStateRestarting {
+			return fmt.Errorf("module %s is not running", mc.name)
+		}
+		return nil
+	})
+	if rc, ok := mc.getInstance().(readyChecker); ok {
+		healthz.AddReadinessCheck(mc.name, "ready", rc.IsReady)
+	}
+}
+
 func (kmm *kusciaModuleManager) stepExit(canExitModules map[string]bool) error {
 	wg := sync.WaitGroup{}
 	for name, exited := range canExitModules {
@@ -376,6 +505,8 @@ func (kmm *kusciaModuleManager) stepExit(canExitModules map[string]bool) error {
 
 func (kmm *kusciaModuleManager) gracefulExit(modules map[string]*moduleInfo, reverseDep map[string][]string) error {
 	nlog.Infof("GracefulExit started...")
+	kmm.drainModules(modules)
+
 	// true: module exited, false module not exited
 	canExitModules := map[string]bool{}
 
@@ -388,7 +519,7 @@ func (kmm *kusciaModuleManager) gracefulExit(modules map[string]*moduleInfo, rev
 
 	// mark all not started module as exited
 	for _, mc := range modules {
-		if mc.instance == nil {
+		if mc.getInstance() == nil {
 			canExitModules[mc.name] = true
 		}
 	}
@@ -90,14 +90,45 @@ func TestModuleManager_AddReadyHook(t *testing.T) {
 	assert.NoError(t, m.AddReadyHook(hook, "m1"))
 }
 
+func TestModuleManager_Select(t *testing.T) {
+	t.Parallel()
+	m := NewModuleManager()
+	assert.NotNil(t, m)
+
+	creator := func(*modules.ModuleRuntimeConfigs) (modules.Module, error) {
+		return &mockModule{}, nil
+	}
+	assert.NoError(t, m.Regist("m1", creator, common.RunModeAutonomy, common.RunModeLite))
+	assert.NoError(t, m.Regist("m2", creator, common.RunModeAutonomy, common.RunModeLite))
+	assert.NoError(t, m.Regist("m3", creator, common.RunModeAutonomy))
+	assert.NoError(t, m.Regist("m4", creator, common.RunModeAutonomy, common.RunModeLite))
+	assert.NoError(t, m.SetDependencies("m2", "m1"))
+	assert.NoError(t, m.SetDependencies("m3", "m2"))
+
+	assert.Error(t, m.Select("no-exists"))
+
+	kmm := m.(*kusciaModuleManager)
+	assert.NoError(t, m.Select("m3"))
+	ms, reverseDeps := kmm.initNeedStartModules(common.RunModeAutonomy)
+	assert.Len(t, ms, 3)
+	assert.NotContains(t, ms, "m4")
+	assert.Equal(t, []string{"m3"}, reverseDeps["m2"])
+
+	// m3 doesn't run in lite mode
+	ms, _ = kmm.initNeedStartModules(common.RunModeLite)
+	assert.Empty(t, ms)
+
+	assert.NoError(t, m.Select())
+	ms, _ = kmm.initNeedStartModules(common.RunModeAutonomy)
+	assert.Len(t, ms, 4)
+}
+
 func getModule(t *testing.T, m ModuleManager, name string) *mockModule {
 	kmm, _ := m.(*kusciaModuleManager)
 	assert.NotNil(t, kmm)
 	assert.NotNil(t, kmm.modules[name])
-	if kmm.modules[name].instance != nil {
-		if mm, ok := kmm.modules[name].instance.(*mockModule); ok {
-			return mm
-		}
+	if mm, ok := kmm.modules[name].getInstance().(*mockModule); ok {
+		return mm
 	}
 
 	return nil
@@ -307,3 +338,49 @@ func TestModuleManager_Start_runFailed(t *testing.T) {
 	assert.Equal(t, 0, m2.runOrder)
 	assert.Equal(t, 0, m2.stopOrder)
 }
+
+type drainModule struct {
+	mockModule
+	drained chan struct{}
+	block   bool
+}
+
+func (d *drainModule) Drain(ctx context.Context) error {
+	defer close(d.drained)
+	if d.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func TestModuleManager_drainModules(t *testing.T) {
+	t.Parallel()
+	kmm := NewModuleManager().(*kusciaModuleManager)
+	kmm.drainTimeout = 200 * time.Millisecond
+
+	fast := &drainModule{drained: make(chan struct{})}
+	slow := &drainModule{drained: make(chan struct{}), block: true}
+	stopped := &drainModule{drained: make(chan struct{})}
+	ms := map[string]*moduleInfo{
+		"fast":    {name: "fast", instance: fast},
+		"slow":    {name: "slow", instance: slow},
+		"stopped": {name: "stopped", instance: stopped},
+		"plain":   {name: "plain", instance: &mockModule{}},
+	}
+	ms["fast"].running.Store(true)
+	ms["slow"].running.Store(true)
+	ms["plain"].running.Store(true)
+
+	start := time.Now()
+	kmm.drainModules(ms)
+	assert.Less(t, time.Since(start), 2*time.Second)
+
+	<-fast.drained
+	<-slow.drained
+	select {
+	case <-stopped.drained:
+		t.Fatal("module not running shouldn't be drained")
+	default:
+	}
+}
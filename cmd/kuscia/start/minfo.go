@@ -17,6 +17,8 @@ package start
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/secretflow/kuscia/cmd/kuscia/modules"
 	"github.com/secretflow/kuscia/pkg/common"
@@ -32,7 +34,8 @@ type moduleInfo struct {
 	// dependency modules(I depend on others)
 	dependencies []string
 
-	// runtime
+	// runtime, instance is replaced when the module is restarted by supervisor
+	mtx      sync.RWMutex
 	instance modules.Module
 	ctx      context.Context
 	cancel   context.CancelFunc
@@ -42,6 +45,43 @@ type moduleInfo struct {
 
 	// run finished?
 	finishWG sync.WaitGroup
+	// module is running, read by health checks
+	running atomic.Bool
+	// module has been ready once, only ready modules are restarted by supervisor
+	ready atomic.Bool
+
+	// supervision state, guarded by mtx
+	state     string
+	restarts  int
+	crashes   []time.Time
+	lastError string
+}
+
+func (mc *moduleInfo) getInstance() modules.Module {
+	mc.mtx.RLock()
+	defer mc.mtx.RUnlock()
+	return mc.instance
+}
+
+func (mc *moduleInfo) setInstance(instance modules.Module) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	mc.instance = instance
+}
+
+func (mc *moduleInfo) setState(state string, err error) {
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+	mc.state = state
+	if err != nil {
+		mc.lastError = err.Error()
+	}
+}
+
+func (mc *moduleInfo) getState() string {
+	mc.mtx.RLock()
+	defer mc.mtx.RUnlock()
+	return mc.state
 }
 
 // all dependencies are started and ready now
@@ -49,7 +89,7 @@ func (mc *moduleInfo) isModuleDepReady(modules map[string]*moduleInfo) bool {
 	startModule := true
 	for _, dp := range mc.dependencies {
 		if m, ok := modules[dp]; ok {
-			if m.instance == nil || !m.isReadyWaitDone {
+			if m.getInstance() == nil || !m.isReadyWaitDone {
 				startModule = false
 				break
 			}
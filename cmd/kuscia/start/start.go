@@ -17,17 +17,13 @@ package start
 import (
 	"context"
 	"errors"
-	"strings"
 
 	"github.com/spf13/cobra"
 
-	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
 	"github.com/secretflow/kuscia/cmd/kuscia/modules"
-	"github.com/secretflow/kuscia/cmd/kuscia/utils"
-	"github.com/secretflow/kuscia/pkg/agent/config"
 	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/healthz"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
-	"github.com/secretflow/kuscia/pkg/utils/runtime"
 )
 
 func NewStartCommand(ctx context.Context) *cobra.Command {
@@ -48,80 +44,100 @@ func NewStartCommand(ctx context.Context) *cobra.Command {
 }
 
 func Start(ctx context.Context, configFile string) error {
-	commonConfig := confloader.LoadCommonConfig(configFile)
-	mode := strings.ToLower(commonConfig.Mode)
-
-	if commonConfig.DomainID == common.UnSupportedDomainID {
-		nlog.Fatalf("Domain id can't be 'master', please check input config file(%s)", configFile)
-	}
-
-	kusciaConf := confloader.ReadConfig(configFile, mode)
-	conf := modules.NewModuleRuntimeConfigs(ctx, kusciaConf)
-	defer conf.Close()
-
-	if conf.Agent.Provider.Runtime == config.ContainerRuntime && !runtime.Permission.HasPrivileged() {
-		nlog.Errorf("Runc must run with privileged mode")
-		nlog.Errorf("Please run kuscia like: docker run --privileged secretflow/kuscia")
-		return errors.New("permission is error")
+	k, err := New(ctx, Options{ConfigFile: configFile})
+	if err != nil {
+		return err
 	}
+	return k.Run(ctx)
+}
 
-	utils.SetupPprof(conf.Debug, conf.DebugPort)
-	if runtime.Permission.HasSetOOMScorePermission() {
-		modules.SetKusciaOOMScore()
+// registerModules registers all modules of kuscia with their dependencies, and returns the names of modules.
+func registerModules(mm ModuleManager, conf *modules.ModuleRuntimeConfigs) []string {
+	var names []string
+	regist := func(name string, creator NewModuleFunc, modes ...common.RunModeType) {
+		mm.Regist(name, creator, modes...)
+		names = append(names, name)
 	}
 
 	master, lite, autonomy := common.RunModeMaster, common.RunModeLite, common.RunModeAutonomy
 
-	mm := NewModuleManager()
-	mm.Regist("coredns", modules.NewCoreDNS, autonomy, lite, master)
-	mm.Regist("k3s", modules.NewK3s, autonomy, master)
-	mm.Regist("agent", modules.NewAgent, autonomy, lite)
-	mm.Regist("envoy", modules.NewEnvoy, autonomy, lite, master)
+	regist("coredns", modules.NewCoreDNS, autonomy, lite, master)
+	regist("k3s", modules.NewK3s, autonomy, master)
+	regist("migration", modules.NewMigration, autonomy, master)
+	regist("agent", modules.NewAgent, autonomy, lite)
+	regist("envoy", modules.NewEnvoy, autonomy, lite, master)
 	if conf.EnableContainerd {
-		mm.Regist("containerd", modules.NewContainerd, autonomy, lite)
+		regist("containerd", modules.NewContainerd, autonomy, lite)
 	}
 
-	mm.Regist("config", modules.NewConfManager, autonomy, lite, master)
-	mm.Regist("controllers", modules.NewControllersModule, autonomy, master)
-	mm.Regist("datamesh", modules.NewDataMesh, autonomy, lite)
-	mm.Regist("domainroute", modules.NewDomainRoute, autonomy, master, lite)
-	mm.Regist("interconn", modules.NewInterConn, autonomy, master)
-	mm.Regist("kusciaapi", modules.NewKusciaAPI, autonomy, lite, master)
-	mm.Regist("metricexporter", modules.NewMetricExporter, autonomy, lite, master)
-	mm.Regist("nodeexporter", modules.NewNodeExporter, autonomy, lite, master)
-	mm.Regist("ssexporter", modules.NewSsExporter, autonomy, lite, master)
-	mm.Regist("scheduler", modules.NewScheduler, autonomy, master)
-	mm.Regist("transport", modules.NewTransport, autonomy, lite)
-	mm.Regist("reporter", modules.NewReporter, autonomy, master)
+	regist("config", modules.NewConfManager, autonomy, lite, master)
+	regist("controllers", modules.NewControllersModule, autonomy, master)
+	regist("datamesh", modules.NewDataMesh, autonomy, lite)
+	regist("domainroute", modules.NewDomainRoute, autonomy, master, lite)
+	regist("interconn", modules.NewInterConn, autonomy, master)
+	regist("kusciaapi", modules.NewKusciaAPI, autonomy, lite, master)
+	regist("metricexporter", modules.NewMetricExporter, autonomy, lite, master)
+	regist("nodeexporter", modules.NewNodeExporter, autonomy, lite, master)
+	regist("ssexporter", modules.NewSsExporter, autonomy, lite, master)
+	regist("scheduler", modules.NewScheduler, autonomy, master)
+	regist("transport", modules.NewTransport, autonomy, lite)
+	regist("reporter", modules.NewReporter, autonomy, master)
+	if conf.Backup.Enabled() && conf.Backup.Interval > 0 {
+		regist("backup", modules.NewBackup, autonomy, master)
+	}
 
 	mm.SetDependencies("agent", "envoy", "k3s", "kusciaapi")
 	mm.SetDependencies("envoy", "k3s")
+	mm.SetDependencies("migration", "k3s")
 
-	mm.SetDependencies("controllers", "k3s")
+	mm.SetDependencies("controllers", "k3s", "migration")
 	mm.SetDependencies("config", "k3s", "envoy", "domainroute", "controllers")
 	mm.SetDependencies("datamesh", "k3s", "config", "envoy", "domainroute")
-	mm.SetDependencies("domainroute", "k3s")
-	mm.SetDependencies("interconn", "k3s")
-	mm.SetDependencies("kusciaapi", "k3s", "config", "domainroute")
+	mm.SetDependencies("domainroute", "k3s", "migration")
+	mm.SetDependencies("interconn", "k3s", "migration")
+	mm.SetDependencies("kusciaapi", "k3s", "migration", "config", "domainroute")
 	mm.SetDependencies("scheduler", "k3s")
 	mm.SetDependencies("ssexporter", "envoy")
 	mm.SetDependencies("metricexporter", "agent", "envoy", "ssexporter", "nodeexporter")
 	mm.SetDependencies("transport", "envoy")
 	mm.SetDependencies("k3s", "coredns")
 	mm.SetDependencies("reporter", "k3s", "kusciaapi")
+	if conf.Backup.Enabled() && conf.Backup.Interval > 0 {
+		mm.SetDependencies("backup", "k3s", "controllers")
+	}
 
 	mm.AddReadyHook(func(ctx context.Context, mdls map[string]modules.Module) error {
+		m, ok := mdls["coredns"]
+		if !ok {
+			// coredns isn't selected to run
+			return nil
+		}
 		nlog.Info("Start... coredns controllers")
-		cdsModule, ok := mdls["coredns"].(*modules.CorednsModule)
+		cdsModule, ok := m.(*modules.CorednsModule)
 		if ok && cdsModule != nil {
-			cdsModule.StartControllers(ctx, conf.Clients.KubeClient)
+			cdsModule.StartControllers(ctx, conf.Clients.KubeClient, conf.Clients.KusciaClient)
 			return nil
 
 		}
 		return errors.New("coredns module type is invalid")
 	}, "k3s", "coredns", "envoy", "domainroute")
 
-	err := mm.Start(ctx, mode, conf)
-	nlog.Infof("Kuscia Instance [%s] shut down", commonConfig.DomainID)
-	return err
+	return names
+}
+
+// registerHealthChecks registers checks of dependencies shared by modules, clients are created after k3s started
+// in master and autonomy, so they are got at check time.
+func registerHealthChecks(conf *modules.ModuleRuntimeConfigs) {
+	kubeAPICheck := func(path string) healthz.CheckFunc {
+		return func(ctx context.Context) error {
+			if conf.Clients == nil {
+				return errors.New("kube clients are not initialized")
+			}
+			return healthz.KubeAPICheck(conf.Clients.KubeClient, path)(ctx)
+		}
+	}
+	healthz.AddReadinessCheck("kuscia", "k8s-api", kubeAPICheck("/readyz"))
+	if conf.RunMode != common.RunModeLite {
+		healthz.AddReadinessCheck("k3s", "datastore", kubeAPICheck("/readyz/etcd"))
+	}
 }
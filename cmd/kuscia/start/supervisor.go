@@ -0,0 +1,249 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package start
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/cmd/kuscia/modules"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// States of modules shown on the admin endpoint.
+const (
+	moduleStatePending    = "Pending"
+	moduleStateStarting   = "Starting"
+	moduleStateRunning    = "Running"
+	moduleStateRestarting = "Restarting"
+	moduleStateFailed     = "Failed"
+	moduleStateStopped    = "Stopped"
+)
+
+// ModuleStatus is the state of a module served by /status/modules of admin endpoint.
+type ModuleStatus struct {
+	Name         string   `json:"name"`
+	State        string   `json:"state"`
+	Critical     bool     `json:"critical,omitempty"`
+	Restarts     int      `json:"restarts"`
+	Flapping     bool     `json:"flapping,omitempty"`
+	LastError    string   `json:"lastError,omitempty"`
+	LastCrash    string   `json:"lastCrash,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// supervisor decides whether and when crashed modules are restarted.
+type supervisor struct {
+	conf     confloader.SupervisorConfig
+	critical map[string]bool
+	now      func() time.Time
+}
+
+func newSupervisor(conf *modules.ModuleRuntimeConfigs) *supervisor {
+	s := &supervisor{now: time.Now, critical: map[string]bool{}}
+	if conf == nil {
+		return s
+	}
+	s.conf = conf.Supervisor.WithDefaults()
+	for _, name := range s.conf.CriticalModules {
+		s.critical[name] = true
+	}
+	return s
+}
+
+// restartable returns whether the crashed module is restarted, modules which have never been ready stop kuscia.
+func (s *supervisor) restartable(mc *moduleInfo) bool {
+	return s.conf.Enable && mc.ready.Load()
+}
+
+// recordCrash marks the module as restarting and returns the backoff before it's restarted, the backoff doubles for
+// each crash in flap window.
+func (s *supervisor) recordCrash(mc *moduleInfo, err error) time.Duration {
+	now := s.now()
+	mc.mtx.Lock()
+	defer mc.mtx.Unlock()
+
+	mc.crashes = append(s.crashesInWindow(mc, now), now)
+	mc.restarts++
+	mc.lastError = err.Error()
+	mc.state = moduleStateRestarting
+
+	backoff := s.conf.InitialBackoff
+	for i := 1; i < len(mc.crashes) && backoff < s.conf.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.conf.MaxBackoff {
+		backoff = s.conf.MaxBackoff
+	}
+	return backoff
+}
+
+// crashesInWindow must be called with mc.mtx held.
+func (s *supervisor) crashesInWindow(mc *moduleInfo, now time.Time) []time.Time {
+	var crashes []time.Time
+	for _, t := range mc.crashes {
+		if now.Sub(t) < s.conf.FlapWindow {
+			crashes = append(crashes, t)
+		}
+	}
+	return crashes
+}
+
+func (s *supervisor) isFlapping(mc *moduleInfo) bool {
+	if !s.conf.Enable {
+		return false
+	}
+	mc.mtx.RLock()
+	defer mc.mtx.RUnlock()
+	return len(s.crashesInWindow(mc, s.now())) > s.conf.MaxRestarts
+}
+
+// checkCritical fails if any critical module is flapping, so kuscia is restarted by the orchestrator.
+func (s *supervisor) checkCritical(ms map[string]*moduleInfo) error {
+	for _, name := range sortedModuleNames(ms) {
+		if s.critical[name] && s.isFlapping(ms[name]) {
+			return fmt.Errorf("critical module %s crashed more than %d times in %v", name, s.conf.MaxRestarts, s.conf.FlapWindow)
+		}
+	}
+	return nil
+}
+
+func (s *supervisor) status(ms map[string]*moduleInfo) []ModuleStatus {
+	statuses := make([]ModuleStatus, 0, len(ms))
+	for _, name := range sortedModuleNames(ms) {
+		mc := ms[name]
+		flapping := s.isFlapping(mc)
+		mc.mtx.RLock()
+		st := ModuleStatus{
+			Name:      name,
+			State:     mc.state,
+			Critical:  s.critical[name],
+			Restarts:  mc.restarts,
+			Flapping:  flapping,
+			LastError: mc.lastError,
+		}
+		if st.State == "" {
+			st.State = moduleStatePending
+		}
+		if len(mc.crashes) > 0 {
+			st.LastCrash = mc.crashes[len(mc.crashes)-1].Format(time.RFC3339)
+		}
+		mc.mtx.RUnlock()
+		for _, dep := range mc.dependencies {
+			if _, ok := ms[dep]; ok {
+				st.Dependencies = append(st.Dependencies, dep)
+			}
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
+
+func sortedModuleNames(ms map[string]*moduleInfo) []string {
+	names := make([]string, 0, len(ms))
+	for name := range ms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runSafely runs the module and turns its panic into error, so a crashed module doesn't bring down the process.
+func runSafely(ctx context.Context, name string, instance modules.Module) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			nlog.Errorf("[Module] %s panic: %v\n%s", name, r, debug.Stack())
+			err = fmt.Errorf("module %s panic: %v", name, r)
+		}
+	}()
+	return instance.Run(ctx)
+}
+
+// superviseModule runs the module until it's stopped. A crashed module is restarted with backoff if supervisor is
+// enabled and the module has been ready, otherwise the failure is notified to stop kuscia.
+func (kmm *kusciaModuleManager) superviseModule(mc *moduleInfo, conf *modules.ModuleRuntimeConfigs) {
+	instance := mc.getInstance()
+	for restarted := false; ; restarted = true {
+		mc.running.Store(true)
+		var err error
+		if restarted {
+			err = kmm.runRestarted(mc, instance)
+		} else {
+			err = runSafely(mc.ctx, mc.name, instance)
+		}
+		mc.running.Store(false)
+
+		if err == nil {
+			nlog.Infof("[Module] %s is successful finished", mc.name)
+			mc.setState(moduleStateStopped, nil)
+			return
+		}
+		nlog.Infof("[Module] %s is finished with err=%s", mc.name, err.Error())
+		if mc.ctx.Err() != nil || !kmm.supervisor.restartable(mc) {
+			mc.setState(moduleStateFailed, err)
+			kmm.runFailedModuleCh <- mc
+			return
+		}
+
+		for instance = nil; instance == nil; {
+			backoff := kmm.supervisor.recordCrash(mc, err)
+			nlog.Warnf("[Module] %s crashed, restart it in %v", mc.name, backoff)
+			select {
+			case <-mc.ctx.Done():
+				mc.setState(moduleStateStopped, nil)
+				return
+			case <-time.After(backoff):
+			}
+			if instance, err = mc.creator(conf); err != nil {
+				nlog.Warnf("[Module] %s recreate failed with error: %s", mc.name, err.Error())
+			}
+		}
+		nlog.Infof("[Module] %s is recreated", mc.name)
+		mc.setInstance(instance)
+		mc.setState(moduleStateStarting, nil)
+		registerModuleHealthChecks(mc)
+	}
+}
+
+// runRestarted runs the restarted module, which is regarded as crashed again if it fails to be ready.
+func (kmm *kusciaModuleManager) runRestarted(mc *moduleInfo, instance modules.Module) error {
+	ctx, cancel := context.WithCancel(mc.ctx)
+	defer cancel()
+
+	readyErrCh := make(chan error, 1)
+	go func() {
+		if err := instance.WaitReady(ctx); err != nil {
+			if ctx.Err() == nil {
+				readyErrCh <- err
+				cancel()
+			}
+			return
+		}
+		nlog.Infof("[Module] %s is ready again", mc.name)
+		mc.setState(moduleStateRunning, nil)
+	}()
+
+	err := runSafely(ctx, mc.name, instance)
+	select {
+	case readyErr := <-readyErrCh:
+		return fmt.Errorf("wait ready failed with %s", readyErr.Error())
+	default:
+		return err
+	}
+}
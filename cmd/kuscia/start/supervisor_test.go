@@ -0,0 +1,136 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package start
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/cmd/kuscia/modules"
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+// crashModule panics after it's ready for the first crashes runs.
+type crashModule struct {
+	runs    *atomic.Int32
+	crashes int32
+}
+
+func (c *crashModule) Run(ctx context.Context) error {
+	if c.runs.Add(1) <= c.crashes {
+		panic("boom")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (c *crashModule) WaitReady(ctx context.Context) error {
+	return nil
+}
+
+func (c *crashModule) Name() string {
+	return "crash"
+}
+
+func supervisedConf(enable bool) *modules.ModuleRuntimeConfigs {
+	return &modules.ModuleRuntimeConfigs{KusciaConfig: confloader.KusciaConfig{
+		Supervisor: confloader.SupervisorConfig{
+			Enable:         enable,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+		},
+	}}
+}
+
+func TestModuleManager_Start_restartCrashed(t *testing.T) {
+	t.Parallel()
+	m := NewModuleManager()
+	runs := &atomic.Int32{}
+	assert.NoError(t, m.Regist("m1", func(*modules.ModuleRuntimeConfigs) (modules.Module, error) {
+		return &crashModule{runs: runs, crashes: 2}, nil
+	}, common.RunModeAutonomy))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kmm := m.(*kusciaModuleManager)
+	go func() {
+		assert.NoError(t, wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			return runs.Load() == 3 && kmm.modules["m1"].getState() == moduleStateRunning, nil
+		}))
+		cancel()
+	}()
+
+	assert.NoError(t, m.Start(ctx, common.RunModeAutonomy, supervisedConf(true)))
+	status := kmm.supervisor.status(kmm.modules)
+	assert.Len(t, status, 1)
+	assert.Equal(t, 2, status[0].Restarts)
+	assert.Contains(t, status[0].LastError, "panic")
+	assert.Equal(t, moduleStateStopped, status[0].State)
+}
+
+func TestModuleManager_Start_crashWithoutSupervisor(t *testing.T) {
+	t.Parallel()
+	m := NewModuleManager()
+	runs := &atomic.Int32{}
+	assert.NoError(t, m.Regist("m1", func(*modules.ModuleRuntimeConfigs) (modules.Module, error) {
+		return &crashModule{runs: runs, crashes: 1}, nil
+	}, common.RunModeAutonomy))
+
+	// the panic is recovered and kuscia exits gracefully
+	assert.NoError(t, m.Start(context.Background(), common.RunModeAutonomy, supervisedConf(false)))
+	assert.Equal(t, int32(1), runs.Load())
+	assert.Equal(t, moduleStateFailed, m.(*kusciaModuleManager).modules["m1"].getState())
+}
+
+func TestSupervisor_flapping(t *testing.T) {
+	now := time.Now()
+	s := newSupervisor(&modules.ModuleRuntimeConfigs{KusciaConfig: confloader.KusciaConfig{
+		Supervisor: confloader.SupervisorConfig{Enable: true, MaxRestarts: 2, FlapWindow: time.Minute},
+	}})
+	s.now = func() time.Time { return now }
+
+	ms := map[string]*moduleInfo{"envoy": {name: "envoy"}, "reporter": {name: "reporter"}}
+	crash := errors.New("crash")
+	assert.Equal(t, time.Second, s.recordCrash(ms["envoy"], crash))
+	assert.Equal(t, 2*time.Second, s.recordCrash(ms["envoy"], crash))
+	assert.NoError(t, s.checkCritical(ms))
+	assert.Equal(t, 4*time.Second, s.recordCrash(ms["envoy"], crash))
+	assert.Error(t, s.checkCritical(ms))
+
+	// flapping of non-critical module doesn't fail the check
+	for i := 0; i < 3; i++ {
+		s.recordCrash(ms["reporter"], crash)
+	}
+	assert.True(t, s.isFlapping(ms["reporter"]))
+
+	// crashes out of window are forgotten
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, s.checkCritical(ms))
+	assert.Equal(t, time.Second, s.recordCrash(ms["envoy"], crash))
+	assert.Equal(t, 4, s.status(ms)[0].Restarts)
+}
+
+func TestSupervisorConfig_Check(t *testing.T) {
+	assert.NoError(t, confloader.SupervisorConfig{}.WithDefaults().Check())
+	assert.Error(t, confloader.SupervisorConfig{InitialBackoff: time.Minute, MaxBackoff: time.Second}.Check())
+	assert.Error(t, confloader.SupervisorConfig{MaxRestarts: -1}.Check())
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/secretflow/kuscia/pkg/utils/confbus"
+	"github.com/secretflow/kuscia/pkg/utils/healthz"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/queue"
+)
+
+// SetupHealthServer serves /healthz and /readyz aggregated from checks registered by modules, /config reporting
+// the applied config version, /loglevel changing log levels at runtime, and /metrics exposing the metrics of the
+// default prometheus registry and workqueues.
+func SetupHealthServer(ctx context.Context, port string) {
+	mux := http.NewServeMux()
+	healthz.InstallHandler(mux)
+	confbus.InstallHandler(mux)
+	nlog.InstallLevelHandler(mux)
+	mux.Handle("/metrics", queue.MetricsHandler())
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf("0.0.0.0:%s", port),
+		Handler: mux,
+	}
+	nlog.Infof("Health check port is %s", port)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			nlog.Errorf("Start health check server fail: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = httpServer.Close()
+	}()
+}
@@ -51,7 +51,7 @@ OUTPUT_MD:
 OPTIONS:
     -l i18n language. default is zh-CN
 For example:
-    bash hack/errorcode/gen_error_code_doc.sh doc proto/api/v1alpha1/errorcode/errorcode.proto hack/errorcode/i18n/errorcode.zh-CN.toml docs/reference/apis/error_code_cn.md
+    bash hack/errorcode/gen_error_code_doc.sh doc proto/api/v1alpha1/errorcode/errorcode.proto pkg/kusciaapi/errorcode/i18n/errorcode.zh-CN.toml docs/reference/apis/error_code_cn.md
     "
 }
 
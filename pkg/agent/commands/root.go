@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -41,8 +42,30 @@ import (
 
 var (
 	ReadyChan = make(chan struct{})
+
+	// localPodsController is set when agent is ready and pods run in local runtime.
+	podsControllerMtx   sync.Mutex
+	localPodsController *framework.PodsController
 )
 
+// TerminatePods gives pods running in local runtime their termination grace period before agent exits, pods of runk
+// aren't stopped since they don't exit with kuscia.
+func TerminatePods(ctx context.Context) error {
+	podsControllerMtx.Lock()
+	pc := localPodsController
+	podsControllerMtx.Unlock()
+	if pc == nil {
+		return nil
+	}
+	return pc.TerminatePods(ctx)
+}
+
+func setPodsController(pc *framework.PodsController) {
+	podsControllerMtx.Lock()
+	defer podsControllerMtx.Unlock()
+	localPodsController = pc
+}
+
 func RunRootCommand(ctx context.Context, agentConfig *config.AgentConfig, kubeClient kubernetes.Interface) error {
 	nlog.Infof("Run root command, Namespace=%v", agentConfig.Namespace)
 	if agentConfig.Namespace == "" {
@@ -167,6 +190,7 @@ func RunRootCommand(ctx context.Context, agentConfig *config.AgentConfig, kubeCl
 		NodeGetter:    nodeController,
 		EventRecorder: eventRecorder,
 		SourcesReady:  sourceManager,
+		StatusStore:   agentConfig.LocalStore,
 	}
 
 	podsController, err := framework.NewPodsController(podsControllerConfig)
@@ -197,6 +221,10 @@ func RunRootCommand(ctx context.Context, agentConfig *config.AgentConfig, kubeCl
 		}
 	}()
 	<-podsController.Ready()
+	if agentConfig.Provider.Runtime != config.K8sRuntime {
+		setPodsController(podsController)
+		defer setPodsController(nil)
+	}
 
 	nlog.Info("Agent started")
 	nodeController.NotifyAgentReady()
@@ -25,7 +25,10 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/secretflow/kuscia/pkg/agent/netpolicy"
+	"github.com/secretflow/kuscia/pkg/agent/sandboxruntime"
 	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/localstore"
 	"github.com/secretflow/kuscia/pkg/utils/network"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/paths"
@@ -260,6 +263,9 @@ type AgentConfig struct {
 	DomainCAKey      *rsa.PrivateKey
 	DomainCACert     *x509.Certificate
 
+	// LocalStore persists pod statuses while master is unreachable, it's nil if not running as lite.
+	LocalStore localstore.Store `yaml:"-"`
+
 	// AllowPrivileged if true, securityContext.Privileged will work for container.
 	AllowPrivileged bool `yaml:"allowPrivileged,omitempty"`
 
@@ -273,6 +279,10 @@ type AgentConfig struct {
 	Registry          RegistryCfg          `yaml:"registry,omitempty"`
 	Cert              CertCfg              `yaml:"cert,omitempty"`
 	Plugins           []PluginCfg          `yaml:"plugins,omitempty"`
+	// NetworkPolicy restricts the egress of task pods.
+	NetworkPolicy netpolicy.Config `yaml:"networkPolicy,omitempty"`
+	// SandboxRuntime runs pods in gVisor or Kata Containers.
+	SandboxRuntime sandboxruntime.Config `yaml:"sandboxRuntime,omitempty"`
 }
 
 func DefaultStaticAgentConfig() *AgentConfig {
@@ -344,6 +354,9 @@ func DefaultStaticAgentConfig() *AgentConfig {
 			{
 				Name: common.PluginNameConfigRender,
 			},
+			{
+				Name: common.PluginNameDataMeshToken,
+			},
 		},
 	}
 }
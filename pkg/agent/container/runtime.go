@@ -396,6 +396,9 @@ type RunContainerOptions struct {
 	// This should only be enabled when the container runtime is performing user remapping AND if the
 	// experimental behavior is desired.
 	EnableHostUserNamespace bool
+	// SecurityContext overrides the security context of the container if it's not nil, e.g. it's hardened by the pod
+	// security plugin. The container spec is kept unchanged, so the hash of container doesn't change.
+	SecurityContext *v1.SecurityContext
 }
 
 // VolumeInfo contains information about the volume.
@@ -0,0 +1,63 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// adminStatusName is the status page of agent on the admin server of kuscia process.
+const adminStatusName = "agent"
+
+// PodStatusSummary is a pod managed by agent on the status page.
+type PodStatusSummary struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	StartTime string `json:"startTime,omitempty"`
+}
+
+// AgentStatus is the status page of agent, the status of pod is the one cached by status manager.
+type AgentStatus struct {
+	Pods []*PodStatusSummary `json:"pods"`
+}
+
+func (pc *PodsController) adminStatus(ctx context.Context) (interface{}, error) {
+	pods := pc.podManager.GetPods()
+	status := &AgentStatus{Pods: make([]*PodStatusSummary, 0, len(pods))}
+	for _, pod := range pods {
+		summary := &PodStatusSummary{Namespace: pod.Namespace, Name: pod.Name}
+		podStatus, ok := pc.statusManager.GetPodStatus(pod.UID)
+		if !ok {
+			podStatus = pod.Status
+		}
+		summary.Phase = string(podStatus.Phase)
+		summary.Reason = podStatus.Reason
+		if podStatus.StartTime != nil {
+			summary.StartTime = podStatus.StartTime.UTC().Format(time.RFC3339)
+		}
+		status.Pods = append(status.Pods, summary)
+	}
+	sort.Slice(status.Pods, func(i, j int) bool {
+		if status.Pods[i].Namespace != status.Pods[j].Namespace {
+			return status.Pods[i].Namespace < status.Pods[j].Namespace
+		}
+		return status.Pods[i].Name < status.Pods[j].Name
+	})
+	return status, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestAdminStatus(t *testing.T) {
+	pc, _ := newTestStoragePodsController(t, "")
+	running := newEphemeralStoragePod("1", "pod2", "", "")
+	running.Status.Phase = v1.PodRunning
+	pc.podManager.AddPod(running)
+	failed := newEphemeralStoragePod("2", "pod1", "", "")
+	failed.Status = v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"}
+	pc.podManager.AddPod(failed)
+
+	status, err := pc.adminStatus(context.Background())
+	assert.NoError(t, err)
+	pods := status.(*AgentStatus).Pods
+	assert.Len(t, pods, 2)
+	assert.Equal(t, &PodStatusSummary{Namespace: "test", Name: "pod1", Phase: "Failed", Reason: "Evicted"}, pods[0])
+	assert.Equal(t, "pod2", pods[1].Name)
+	assert.Equal(t, "Running", pods[1].Phase)
+}
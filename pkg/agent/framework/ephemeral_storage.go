@@ -0,0 +1,160 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	resourcehelper "k8s.io/kubernetes/pkg/api/v1/resource"
+
+	"github.com/secretflow/kuscia/pkg/agent/kri"
+	"github.com/secretflow/kuscia/pkg/agent/utils/format"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// ReasonOutOfEphemeralStorage is the pod status reason when the node does not have
+	// enough allocatable ephemeral storage to admit the pod.
+	ReasonOutOfEphemeralStorage = "OutOfEphemeralStorage"
+	// ReasonEvicted is the pod status reason when the pod is evicted by agent.
+	ReasonEvicted = "Evicted"
+)
+
+var (
+	podEphemeralStorageUsage = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kuscia_agent_pod_ephemeral_storage_usage_bytes",
+		Help: "Ephemeral storage used by the pod, including container writable layers, logs and volumes",
+	}, []string{"namespace", "pod"})
+
+	podEphemeralStorageLimit = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kuscia_agent_pod_ephemeral_storage_limit_bytes",
+		Help: "Ephemeral storage limit of the pod, only reported for pods with a limit",
+	}, []string{"namespace", "pod"})
+
+	podEphemeralStorageEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuscia_agent_pod_ephemeral_storage_evictions_total",
+		Help: "Counts number of pods evicted for exceeding the ephemeral storage limit",
+	}, []string{"namespace"})
+)
+
+// admitEphemeralStorage checks whether the node has enough allocatable ephemeral storage
+// for the pod's request, taking the requests of the other active pods into account.
+func (pc *PodsController) admitEphemeralStorage(pod *corev1.Pod) (bool, string) {
+	request := resourcehelper.GetResourceRequestQuantity(pod, corev1.ResourceEphemeralStorage)
+	if request.IsZero() {
+		return true, ""
+	}
+
+	node, err := pc.nodeGetter.GetNode()
+	if err != nil || node == nil {
+		nlog.Warnf("Failed to get node, skip ephemeral storage admission for pod %q: %v", format.Pod(pod), err)
+		return true, ""
+	}
+	allocatable, ok := node.Status.Allocatable[corev1.ResourceEphemeralStorage]
+	if !ok {
+		return true, ""
+	}
+
+	used := resource.NewQuantity(0, resource.BinarySI)
+	for _, p := range pc.podManager.GetPods() {
+		if p.UID == pod.UID || !pc.isPodActive(p) {
+			continue
+		}
+		used.Add(resourcehelper.GetResourceRequestQuantity(p, corev1.ResourceEphemeralStorage))
+	}
+
+	total := used.DeepCopy()
+	total.Add(request)
+	if total.Cmp(allocatable) > 0 {
+		return false, fmt.Sprintf("Node didn't have enough ephemeral storage, requested: %s, used: %s, allocatable: %s",
+			request.String(), used.String(), allocatable.String())
+	}
+	return true, ""
+}
+
+// isPodActive returns true if the pod is admitted and has not been requested to terminate.
+func (pc *PodsController) isPodActive(pod *corev1.Pod) bool {
+	return !pc.isAdmittedPodTerminal(pod) && !pc.podWorkers.IsPodTerminationRequested(pod.UID)
+}
+
+// monitorEphemeralStorage measures the ephemeral storage usage of all active pods, exports
+// the usage metrics and evicts the pods whose usage exceeds their ephemeral storage limit.
+func (pc *PodsController) monitorEphemeralStorage(ctx context.Context) {
+	storageProvider, ok := pc.provider.(kri.PodStorageProvider)
+	if !ok {
+		return
+	}
+
+	measured := map[types.UID]struct{}{}
+	for _, pod := range pc.podManager.GetPods() {
+		if !pc.isPodActive(pod) {
+			continue
+		}
+
+		usage, err := storageProvider.GetPodEphemeralStorageUsage(ctx, pod)
+		if err != nil {
+			nlog.Warnf("Failed to get ephemeral storage usage of pod %q: %v", format.Pod(pod), err)
+			continue
+		}
+		measured[pod.UID] = struct{}{}
+		pc.storageMetricPods[pod.UID] = []string{pod.Namespace, pod.Name}
+		podEphemeralStorageUsage.WithLabelValues(pod.Namespace, pod.Name).Set(float64(usage))
+
+		_, limits := resourcehelper.PodRequestsAndLimits(pod)
+		limit, ok := limits[corev1.ResourceEphemeralStorage]
+		if !ok {
+			continue
+		}
+		podEphemeralStorageLimit.WithLabelValues(pod.Namespace, pod.Name).Set(float64(limit.Value()))
+		if usage > limit.Value() {
+			message := fmt.Sprintf("Pod ephemeral local storage usage exceeds the total limit of containers %s, used: %s",
+				limit.String(), resource.NewQuantity(usage, resource.BinarySI).String())
+			pc.evictPod(pod, message)
+		}
+	}
+
+	for uid, labels := range pc.storageMetricPods {
+		if _, ok := measured[uid]; ok {
+			continue
+		}
+		podEphemeralStorageUsage.DeleteLabelValues(labels...)
+		podEphemeralStorageLimit.DeleteLabelValues(labels...)
+		delete(pc.storageMetricPods, uid)
+	}
+}
+
+// evictPod kills the pod asynchronously and marks it as failed with the evicted reason.
+func (pc *PodsController) evictPod(pod *corev1.Pod, message string) {
+	nlog.Warnf("Evict pod %q: %s", format.Pod(pod), message)
+	pc.recorder.Eventf(pod, corev1.EventTypeWarning, ReasonEvicted, message)
+	podEphemeralStorageEvictions.WithLabelValues(pod.Namespace).Inc()
+
+	killPodFunc := killPodNow(pc.podWorkers, pc.recorder)
+	go func() {
+		if err := killPodFunc(pod, true, nil, func(status *corev1.PodStatus) {
+			status.Phase = corev1.PodFailed
+			status.Reason = ReasonEvicted
+			status.Message = message
+		}); err != nil {
+			nlog.Warnf("Failed to evict pod %q: %v", format.Pod(pod), err)
+		}
+	}()
+}
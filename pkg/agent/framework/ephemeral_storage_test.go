@@ -0,0 +1,122 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	pkgcontainer "github.com/secretflow/kuscia/pkg/agent/container"
+	"github.com/secretflow/kuscia/pkg/agent/kri"
+	pkgpod "github.com/secretflow/kuscia/pkg/agent/pod"
+	"github.com/secretflow/kuscia/pkg/agent/status"
+)
+
+type fakeNodeGetter struct {
+	node *v1.Node
+}
+
+func (f *fakeNodeGetter) GetNode() (*v1.Node, error) {
+	return f.node, nil
+}
+
+type fakeStorageProvider struct {
+	kri.PodProvider
+	usage map[types.UID]int64
+}
+
+func (f *fakeStorageProvider) GetPodEphemeralStorageUsage(ctx context.Context, pod *v1.Pod) (int64, error) {
+	return f.usage[pod.UID], nil
+}
+
+func newEphemeralStoragePod(uid, name, request, limit string) *v1.Pod {
+	container := v1.Container{Name: "c"}
+	if request != "" {
+		container.Resources.Requests = v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse(request)}
+	}
+	if limit != "" {
+		container.Resources.Limits = v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse(limit)}
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Name: name, Namespace: "test"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{container}},
+	}
+}
+
+func newTestStoragePodsController(t *testing.T, allocatable string) (*PodsController, *fakePodWorkers) {
+	node := &v1.Node{}
+	if allocatable != "" {
+		node.Status.Allocatable = v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse(allocatable)}
+	}
+	podWorkers := &fakePodWorkers{cache: pkgcontainer.NewCache(), t: t}
+	pc := &PodsController{
+		nodeGetter:        &fakeNodeGetter{node: node},
+		podManager:        pkgpod.NewBasicPodManager(nil),
+		podWorkers:        podWorkers,
+		recorder:          &record.FakeRecorder{},
+		storageMetricPods: map[types.UID][]string{},
+	}
+	pc.statusManager = status.NewManager(fake.NewSimpleClientset(), pc.podManager, pc)
+	return pc, podWorkers
+}
+
+func TestAdmitEphemeralStorage(t *testing.T) {
+	pc, _ := newTestStoragePodsController(t, "10Gi")
+	pc.podManager.AddPod(newEphemeralStoragePod("1", "pod1", "6Gi", ""))
+	finished := newEphemeralStoragePod("2", "pod2", "6Gi", "")
+	finished.Status.Phase = v1.PodSucceeded
+	pc.podManager.AddPod(finished)
+
+	ok, _ := pc.admitEphemeralStorage(newEphemeralStoragePod("3", "pod3", "", ""))
+	assert.True(t, ok)
+	ok, _ = pc.admitEphemeralStorage(newEphemeralStoragePod("4", "pod4", "4Gi", ""))
+	assert.True(t, ok)
+	ok, message := pc.admitEphemeralStorage(newEphemeralStoragePod("5", "pod5", "5Gi", ""))
+	assert.False(t, ok)
+	assert.Contains(t, message, "requested: 5Gi, used: 6Gi, allocatable: 10Gi")
+
+	noCapacity, _ := newTestStoragePodsController(t, "")
+	ok, _ = noCapacity.admitEphemeralStorage(newEphemeralStoragePod("5", "pod5", "5Gi", ""))
+	assert.True(t, ok)
+}
+
+func TestMonitorEphemeralStorage(t *testing.T) {
+	pc, podWorkers := newTestStoragePodsController(t, "")
+	pc.provider = &fakeStorageProvider{usage: map[types.UID]int64{"1": 2 << 30, "2": 2 << 30}}
+	pc.podManager.AddPod(newEphemeralStoragePod("1", "pod1", "", "1Gi"))
+	pc.podManager.AddPod(newEphemeralStoragePod("2", "pod2", "", "3Gi"))
+
+	pc.monitorEphemeralStorage(context.Background())
+	assert.Eventually(t, func() bool {
+		podWorkers.lock.Lock()
+		defer podWorkers.lock.Unlock()
+		return len(podWorkers.triggeredDeletion) == 1 && podWorkers.triggeredDeletion[0] == "1"
+	}, time.Second, 10*time.Millisecond)
+	assert.Len(t, pc.storageMetricPods, 2)
+
+	pod2, _ := pc.podManager.GetPodByUID("2")
+	pc.podManager.DeletePod(pod2)
+	pc.monitorEphemeralStorage(context.Background())
+	assert.Len(t, pc.storageMetricPods, 1)
+}
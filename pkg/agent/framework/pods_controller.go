@@ -26,11 +26,16 @@ import (
 	"net"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/kubernetes/pkg/kubelet/events"
@@ -46,7 +51,10 @@ import (
 	pkgpod "github.com/secretflow/kuscia/pkg/agent/pod"
 	"github.com/secretflow/kuscia/pkg/agent/status"
 	"github.com/secretflow/kuscia/pkg/agent/utils/format"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/admin"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 const (
@@ -63,6 +71,9 @@ const (
 	// error. It is also used as the base period for the exponential backoff
 	// container restarts and image pulls.
 	backOffPeriod = time.Second * 10
+
+	// Period for measuring the ephemeral storage usage of pods.
+	ephemeralStorageMonitorPeriod = time.Second * 10
 )
 
 // SourcesReady tracks the set of configured sources seen by the agent.
@@ -126,6 +137,10 @@ type PodsController struct {
 	// reasonCache caches the failure reason of the last creation of all containers, which is
 	// used for generating ContainerStatus.
 	reasonCache *kri.ReasonCache
+
+	// storageMetricPods records the label values of the exported pod ephemeral storage metrics,
+	// so the metrics can be removed once the pod is gone.
+	storageMetricPods map[types.UID][]string
 }
 
 // PodsControllerConfig is used to configure a new PodsController.
@@ -145,6 +160,9 @@ type PodsControllerConfig struct {
 	EventRecorder record.EventRecorder
 
 	SourcesReady SourcesReady
+
+	// StatusStore persists pod statuses which failed to sync, it's optional.
+	StatusStore status.StatusStore
 }
 
 // NewPodsController creates a new pod controller with the provided config.
@@ -163,12 +181,14 @@ func NewPodsController(cfg *PodsControllerConfig) (*PodsController, error) {
 		sourcesReady: cfg.SourcesReady,
 		clock:        clock.RealClock{},
 		reasonCache:  kri.NewReasonCache(),
+
+		storageMetricPods: map[types.UID][]string{},
 	}
 
 	mirrorPodClient := pkgpod.NewBasicMirrorClient(cfg.KubeClient, cfg.NodeGetter)
 	podManager := pkgpod.NewBasicPodManager(mirrorPodClient)
 
-	statusManager := status.NewManager(cfg.KubeClient, podManager, pc)
+	statusManager := status.NewManagerWithStore(cfg.KubeClient, podManager, pc, cfg.StatusStore)
 
 	pc.podManager = podManager
 	pc.statusManager = statusManager
@@ -197,6 +217,7 @@ func NewPodsController(cfg *PodsControllerConfig) (*PodsController, error) {
 func (pc *PodsController) Run(ctx context.Context) error {
 	// Shutdowns are idempotent, so we can call it multiple times. This is in case we have to bail out early for some reason.
 	defer func() {
+		admin.UnregisterStatus(adminStatusName)
 		close(pc.chStopped)
 		nlog.Info("Pods controller exited")
 	}()
@@ -209,6 +230,11 @@ func (pc *PodsController) Run(ctx context.Context) error {
 		}
 	}()
 	pc.statusManager.Start()
+	admin.RegisterStatus(adminStatusName, pc.adminStatus)
+
+	if _, ok := pc.provider.(kri.PodStorageProvider); ok {
+		go wait.UntilWithContext(ctx, pc.monitorEphemeralStorage, ephemeralStorageMonitorPeriod)
+	}
 
 	close(pc.chReady)
 
@@ -232,6 +258,31 @@ func (pc *PodsController) Stop() chan struct{} {
 	return pc.chStopped
 }
 
+// TerminatePods kills all running pods with their termination grace period, so containers can exit gracefully before
+// the runtime is stopped with kuscia.
+func (pc *PodsController) TerminatePods(ctx context.Context) error {
+	runningPods, err := pc.provider.GetPods(ctx, false)
+	if err != nil {
+		return err
+	}
+	wg := sync.WaitGroup{}
+	errs := make([]error, len(runningPods))
+	for i, runningPod := range runningPods {
+		pod, found := pc.podManager.GetPodByUID(runningPod.ID)
+		if !found {
+			pod = runningPod.ToAPIPod()
+		}
+		wg.Add(1)
+		go func(i int, pod *corev1.Pod, runningPod *pkgcontainer.Pod) {
+			defer wg.Done()
+			nlog.Infof("Terminating pod %q before exit", format.Pod(pod))
+			errs[i] = pc.provider.KillPod(ctx, pod, *runningPod, pod.Spec.TerminationGracePeriodSeconds)
+		}(i, pod, runningPod)
+	}
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
+}
+
 func (pc *PodsController) GetPodStateProvider() PodStateProvider {
 	return pc.podWorkers
 }
@@ -382,6 +433,11 @@ func (pc *PodsController) HandlePodAdditions(pods []*corev1.Pod) {
 				continue
 			}
 
+			if ok, message := pc.admitEphemeralStorage(pod); !ok {
+				pc.rejectPod(pod, ReasonOutOfEphemeralStorage, message)
+				nlog.Warnf("Reject pod %q: %s", format.Pod(pod), message)
+				continue
+			}
 		}
 
 		mirrorPod, _ := pc.podManager.GetMirrorPodByPod(pod)
@@ -513,12 +569,16 @@ func (pc *PodsController) constructPodImage(pod *corev1.Pod) {
 // desired state of the spec.
 func (pc *PodsController) syncPod(ctx context.Context, updateType kubetypes.SyncPodType, pod, mirrorPod *corev1.Pod, podStatus *pkgcontainer.PodStatus) (isTerminal bool, err error) {
 	nlog.Infof("Sync pod %q enter", format.Pod(pod))
+	ctx, span := startPodSpan(ctx, "Pod.Sync", pod, attribute.String("kuscia.sync_type", updateType.String()))
 	defer func() {
+		span.SetAttributes(attribute.Bool("kuscia.terminal", isTerminal))
+		tracing.End(span, err)
 		nlog.Infof("Sync pod %q exit, isTerminal=%v", format.Pod(pod), isTerminal)
 	}()
 
 	// Generate final API pod status with pod and status manager status
 	apiPodStatus := pc.generateAPIPodStatus(pod, podStatus)
+	span.SetAttributes(tracing.AttrPhase.String(string(apiPodStatus.Phase)))
 	// The pod IP may be changed in generateAPIPodStatus if the pod is using host network. (See #24576)
 	// TODO(random-liu): After writing pod spec into container labels, check whether pod is using host network, and
 	// set pod IP to hostIP directly in runtime.GetPodStatus
@@ -585,9 +645,13 @@ func (pc *PodsController) syncPod(ctx context.Context, updateType kubetypes.Sync
 // syncTerminatingPod is expected to terminate all running containers in a pod. Once this method
 // returns without error, the pod's local state can be safely cleaned up. If runningPod is passed,
 // we perform no status updates.
-func (pc *PodsController) syncTerminatingPod(ctx context.Context, pod *corev1.Pod, podStatus *pkgcontainer.PodStatus, runningPod *pkgcontainer.Pod, gracePeriod *int64, podStatusFn func(*corev1.PodStatus)) error {
+func (pc *PodsController) syncTerminatingPod(ctx context.Context, pod *corev1.Pod, podStatus *pkgcontainer.PodStatus, runningPod *pkgcontainer.Pod, gracePeriod *int64, podStatusFn func(*corev1.PodStatus)) (retErr error) {
 	nlog.Infof("Sync terminating pod %q enter", format.Pod(pod))
-	defer nlog.Infof("Sync terminating pod %q exit", format.Pod(pod))
+	ctx, span := startPodSpan(ctx, "Pod.Terminating", pod)
+	defer func() {
+		tracing.End(span, retErr)
+		nlog.Infof("Sync terminating pod %q exit", format.Pod(pod))
+	}()
 
 	// when we receive a runtime only pod (runningPod != nil) we don't need to update the status
 	// manager or refresh the status of the cache, because a successful killPod will ensure we do
@@ -667,11 +731,16 @@ func (pc *PodsController) syncTerminatingPod(ctx context.Context, pod *corev1.Po
 // TODO: make this method take a context and exit early
 func (pc *PodsController) syncTerminatedPod(ctx context.Context, pod *corev1.Pod, podStatus *pkgcontainer.PodStatus) error {
 	nlog.Infof("Sync terminated pod %q enter", format.Pod(pod))
-	defer nlog.Infof("Sync terminated pod %q exit", format.Pod(pod))
+	ctx, span := startPodSpan(ctx, "Pod.Terminated", pod)
+	defer func() {
+		span.End()
+		nlog.Infof("Sync terminated pod %q exit", format.Pod(pod))
+	}()
 
 	// generate the final status of the pod
 	// TODO: should we simply fold this into TerminatePod? that would give a single pod update
 	apiPodStatus := pc.generateAPIPodStatus(pod, podStatus)
+	span.SetAttributes(tracing.AttrPhase.String(string(apiPodStatus.Phase)))
 	pc.statusManager.SetPodStatus(pod, apiPodStatus)
 
 	if err := pc.provider.DeletePod(ctx, pod); err != nil {
@@ -685,6 +754,15 @@ func (pc *PodsController) syncTerminatedPod(ctx context.Context, pod *corev1.Pod
 	return nil
 }
 
+// startPodSpan starts a span of the pod lifecycle in the trace of the job the pod belongs to.
+func startPodSpan(ctx context.Context, name string, pod *corev1.Pod, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		tracing.AttrPod.String(pod.Namespace+"/"+pod.Name),
+		tracing.AttrJobID.String(pod.Annotations[common.JobIDAnnotationKey]),
+		tracing.AttrTaskID.String(pod.Annotations[common.TaskIDAnnotationKey]))
+	return tracing.StartWithAnnotations(ctx, name, pod.Annotations, attrs...)
+}
+
 func (pc *PodsController) getPodStatus(ctx context.Context, pod *corev1.Pod, minTime time.Time) (*pkgcontainer.PodStatus, error) {
 	return pc.provider.GetPodStatus(ctx, pod)
 }
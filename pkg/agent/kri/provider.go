@@ -81,3 +81,9 @@ type PodLifecycleHandler interface {
 type PodProvider interface {
 	PodLifecycleHandler
 }
+
+// PodStorageProvider is implemented by the providers which are able to measure the ephemeral storage
+// used by pods, i.e. the writable layers, logs and the local data directories of pod.
+type PodStorageProvider interface {
+	GetPodEphemeralStorageUsage(ctx context.Context, pod *v1.Pod) (int64, error)
+}
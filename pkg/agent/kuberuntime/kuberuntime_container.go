@@ -185,19 +185,6 @@ func (m *kubeGenericRuntimeManager) makeMounts(opts *pkgcontainer.RunContainerOp
 	return volumeMounts
 }
 
-func (m *kubeGenericRuntimeManager) calculateContainerSecurityContext(context *v1.SecurityContext) *runtimeapi.LinuxContainerSecurityContext {
-	if context == nil {
-		return nil
-	}
-	privileged := m.calculatePrivileged(context.Privileged)
-	if privileged == nil {
-		return nil
-	}
-	return &runtimeapi.LinuxContainerSecurityContext{
-		Privileged: *privileged,
-	}
-}
-
 // generateLinuxContainerConfig generates linux container config for kubelet runtime v1.
 func (m *kubeGenericRuntimeManager) generateLinuxContainerConfig(container *v1.Container, pod *v1.Pod) *runtimeapi.LinuxContainerConfig {
 	lc := &runtimeapi.LinuxContainerConfig{
@@ -207,7 +194,7 @@ func (m *kubeGenericRuntimeManager) generateLinuxContainerConfig(container *v1.C
 	// set linux container resources
 	lc.Resources = m.calculateLinuxResources(container.Resources.Requests.Cpu(), container.Resources.Limits.Cpu(), container.Resources.Limits.Memory())
 	// set security context
-	lc.SecurityContext = m.calculateContainerSecurityContext(container.SecurityContext)
+	lc.SecurityContext = m.determineEffectiveSecurityContext(pod, container)
 
 	// TODO calculate OomScoreAdj ...
 
@@ -221,6 +208,16 @@ func (m *kubeGenericRuntimeManager) generateContainerConfig(container *v1.Contai
 		return nil, nil, err
 	}
 
+	securedContainer := container
+	if opts.SecurityContext != nil {
+		securedContainer = container.DeepCopy()
+		securedContainer.SecurityContext = opts.SecurityContext
+	}
+	// Verify RunAsNonRoot before the container is created, the runtime doesn't check it.
+	if err := m.verifyRunAsNonRoot(context.Background(), pod, securedContainer, imageRef); err != nil {
+		return nil, cleanupAction, err
+	}
+
 	logDir := BuildContainerLogsDirectory(m.podStdoutRootDirectory, pod.Namespace, pod.Name, pod.UID, container.Name)
 	err = m.osInterface.MkdirAll(logDir, 0755)
 	if err != nil {
@@ -247,7 +244,7 @@ func (m *kubeGenericRuntimeManager) generateContainerConfig(container *v1.Contai
 	}
 
 	// set platform specific configurations.
-	config.Linux = m.generateLinuxContainerConfig(container, pod)
+	config.Linux = m.generateLinuxContainerConfig(securedContainer, pod)
 
 	// set environment variables
 	envs := make([]*runtimeapi.KeyValue, len(opts.Envs))
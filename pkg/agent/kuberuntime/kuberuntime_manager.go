@@ -135,6 +135,15 @@ type kubeGenericRuntimeManager struct {
 
 	// allowPrivileged if true, securityContext.Privileged will work for container.
 	allowPrivileged bool
+
+	// runtimeHandlerSelector selects the runtime handler of pod sandbox, the default handler is used if it's nil.
+	runtimeHandlerSelector RuntimeHandlerSelector
+}
+
+// RuntimeHandlerSelector selects the runtime handler of pod sandbox, e.g. runsc of gVisor.
+type RuntimeHandlerSelector interface {
+	// RuntimeHandler returns the handler of pod, the empty handler means the default one of runtime.
+	RuntimeHandler(pod *v1.Pod) (string, error)
 }
 
 func NewManager(recorder record.EventRecorder,
@@ -154,7 +163,8 @@ func NewManager(recorder record.EventRecorder,
 	cpuCFSQuota bool,
 	podStdoutRootDirectory string,
 	allowPrivileged bool,
-	agentRuntime string) (pkgcontainer.Runtime, error) {
+	agentRuntime string,
+	runtimeHandlerSelector RuntimeHandlerSelector) (pkgcontainer.Runtime, error) {
 	ctx := context.Background()
 	m := &kubeGenericRuntimeManager{
 		recorder:               recorder,
@@ -172,6 +182,7 @@ func NewManager(recorder record.EventRecorder,
 		podStdoutRootDirectory: podStdoutRootDirectory,
 		allowPrivileged:        allowPrivileged,
 		agentRuntime:           agentRuntime,
+		runtimeHandlerSelector: runtimeHandlerSelector,
 	}
 
 	typedVersion, err := m.getTypedVersion(ctx)
@@ -233,6 +233,14 @@ func (m *kubeGenericRuntimeManager) createPodSandbox(ctx context.Context, pod *v
 	}
 
 	runtimeHandler := ""
+	if m.runtimeHandlerSelector != nil {
+		runtimeHandler, err = m.runtimeHandlerSelector.RuntimeHandler(pod)
+		if err != nil {
+			message := fmt.Sprintf("Failed to select runtime handler for pod %q: %v", format.Pod(pod), err)
+			nlog.Errorf("Failed to select runtime handler for pod %q: %v", format.Pod(pod), err)
+			return "", message, err
+		}
+	}
 	podSandBoxID, err := m.runtimeService.RunPodSandbox(ctx, podSandboxConfig, runtimeHandler)
 	if err != nil {
 		message := fmt.Sprintf("Failed to create sandbox for pod %q: %v", format.Pod(pod), err)
@@ -20,6 +20,7 @@ package kuberuntime
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -57,6 +58,32 @@ func TestCreatePodSandbox(t *testing.T) {
 	// TODO Check pod sandbox configuration
 }
 
+type fakeRuntimeHandlerSelector struct {
+	handler string
+	err     error
+}
+
+func (s *fakeRuntimeHandlerSelector) RuntimeHandler(pod *v1.Pod) (string, error) {
+	return s.handler, s.err
+}
+
+func TestCreatePodSandboxWithRuntimeHandler(t *testing.T) {
+	ctx := context.Background()
+	fakeRuntime, _, m, err := createTestRuntimeManager()
+	require.NoError(t, err)
+	pod := newTestPod()
+
+	m.runtimeHandlerSelector = &fakeRuntimeHandlerSelector{handler: "runsc"}
+	id, _, err := m.createPodSandbox(ctx, pod, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "runsc", fakeRuntime.Sandboxes[id].RuntimeHandler)
+
+	m.runtimeHandlerSelector = &fakeRuntimeHandlerSelector{err: errors.New("runsc is not found in PATH")}
+	_, message, err := m.createPodSandbox(ctx, pod, 2)
+	assert.Error(t, err)
+	assert.Contains(t, message, "runsc is not found in PATH")
+}
+
 func newTestPod() *v1.Pod {
 	return &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,153 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Modified by Ant Group in 2024.
+
+package kuberuntime
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	"k8s.io/kubernetes/pkg/securitycontext"
+
+	"github.com/secretflow/kuscia/pkg/agent/utils/format"
+)
+
+// determineEffectiveSecurityContext gets container's security context from v1.Pod and v1.Container. It returns nil if
+// neither the pod nor the container sets the security context.
+func (m *kubeGenericRuntimeManager) determineEffectiveSecurityContext(pod *v1.Pod, container *v1.Container) *runtimeapi.LinuxContainerSecurityContext {
+	if pod.Spec.SecurityContext == nil && container.SecurityContext == nil {
+		return nil
+	}
+	effectiveSc := securitycontext.DetermineEffectiveSecurityContext(pod, container)
+
+	synthesized := &runtimeapi.LinuxContainerSecurityContext{
+		Capabilities: convertToRuntimeCapabilities(effectiveSc.Capabilities),
+		Seccomp:      convertToRuntimeSeccompProfile(determineEffectiveSeccompProfile(pod, container)),
+		NoNewPrivs:   securitycontext.AddNoNewPrivileges(effectiveSc),
+	}
+	if privileged := m.calculatePrivileged(effectiveSc.Privileged); privileged != nil {
+		synthesized.Privileged = *privileged
+	}
+	if effectiveSc.RunAsUser != nil {
+		synthesized.RunAsUser = &runtimeapi.Int64Value{Value: *effectiveSc.RunAsUser}
+	}
+	if effectiveSc.RunAsGroup != nil {
+		synthesized.RunAsGroup = &runtimeapi.Int64Value{Value: *effectiveSc.RunAsGroup}
+	}
+	if effectiveSc.ReadOnlyRootFilesystem != nil {
+		synthesized.ReadonlyRootfs = *effectiveSc.ReadOnlyRootFilesystem
+	}
+
+	if podSc := pod.Spec.SecurityContext; podSc != nil {
+		if podSc.FSGroup != nil {
+			synthesized.SupplementalGroups = append(synthesized.SupplementalGroups, *podSc.FSGroup)
+		}
+		synthesized.SupplementalGroups = append(synthesized.SupplementalGroups, podSc.SupplementalGroups...)
+	}
+
+	return synthesized
+}
+
+// verifyRunAsNonRoot verifies RunAsNonRoot. Non-root verification only supports numeric user, the user of image is
+// only looked up if the container doesn't set RunAsUser.
+func (m *kubeGenericRuntimeManager) verifyRunAsNonRoot(ctx context.Context, pod *v1.Pod, container *v1.Container, image string) error {
+	if pod.Spec.SecurityContext == nil && container.SecurityContext == nil {
+		return nil
+	}
+	effectiveSc := securitycontext.DetermineEffectiveSecurityContext(pod, container)
+	// If the option is not set, or if running as root is allowed, return nil.
+	if effectiveSc.RunAsNonRoot == nil || !*effectiveSc.RunAsNonRoot {
+		return nil
+	}
+
+	if effectiveSc.RunAsUser != nil {
+		if *effectiveSc.RunAsUser == 0 {
+			return fmt.Errorf("container's runAsUser breaks non-root policy (pod: %q, container: %s)", format.Pod(pod), container.Name)
+		}
+		return nil
+	}
+
+	uid, username, err := m.getImageUser(ctx, image)
+	if err != nil {
+		return err
+	}
+	switch {
+	case uid != nil && *uid == 0:
+		return fmt.Errorf("container has runAsNonRoot and image will run as root (pod: %q, container: %s)", format.Pod(pod), container.Name)
+	case uid == nil && len(username) > 0:
+		return fmt.Errorf("container has runAsNonRoot and image has non-numeric user (%s), cannot verify user is non-root (pod: %q, container: %s)", username, format.Pod(pod), container.Name)
+	default:
+		return nil
+	}
+}
+
+// determineEffectiveSeccompProfile returns the seccomp profile of container, which overrides the one of pod.
+func determineEffectiveSeccompProfile(pod *v1.Pod, container *v1.Container) *v1.SeccompProfile {
+	if container.SecurityContext != nil && container.SecurityContext.SeccompProfile != nil {
+		return container.SecurityContext.SeccompProfile
+	}
+	if pod.Spec.SecurityContext != nil {
+		return pod.Spec.SecurityContext.SeccompProfile
+	}
+	return nil
+}
+
+// convertToRuntimeSeccompProfile converts v1.SeccompProfile to runtimeapi.SecurityProfile, the localhost profile is
+// the path of the profile on the node.
+func convertToRuntimeSeccompProfile(profile *v1.SeccompProfile) *runtimeapi.SecurityProfile {
+	if profile == nil {
+		return nil
+	}
+
+	switch profile.Type {
+	case v1.SeccompProfileTypeRuntimeDefault:
+		return &runtimeapi.SecurityProfile{ProfileType: runtimeapi.SecurityProfile_RuntimeDefault}
+	case v1.SeccompProfileTypeLocalhost:
+		if profile.LocalhostProfile == nil {
+			return nil
+		}
+		return &runtimeapi.SecurityProfile{
+			ProfileType:  runtimeapi.SecurityProfile_Localhost,
+			LocalhostRef: *profile.LocalhostProfile,
+		}
+	default:
+		return &runtimeapi.SecurityProfile{ProfileType: runtimeapi.SecurityProfile_Unconfined}
+	}
+}
+
+// convertToRuntimeCapabilities converts v1.Capabilities to runtimeapi.Capability.
+func convertToRuntimeCapabilities(opts *v1.Capabilities) *runtimeapi.Capability {
+	if opts == nil {
+		return nil
+	}
+
+	capabilities := &runtimeapi.Capability{
+		AddCapabilities:  make([]string, len(opts.Add)),
+		DropCapabilities: make([]string, len(opts.Drop)),
+	}
+	for index, value := range opts.Add {
+		capabilities.AddCapabilities[index] = string(value)
+	}
+	for index, value := range opts.Drop {
+		capabilities.DropCapabilities[index] = string(value)
+	}
+
+	return capabilities
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Modified by Ant Group in 2024.
+
+package kuberuntime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestDetermineEffectiveSecurityContext(t *testing.T) {
+	_, _, m, err := createTestRuntimeManager()
+	assert.NoError(t, err)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{UID: "12345678", Name: "bar", Namespace: "new"},
+		Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "foo", Image: "busybox"}}},
+	}
+	assert.Nil(t, m.determineEffectiveSecurityContext(pod, &pod.Spec.Containers[0]))
+
+	runAsUser, fsGroup := int64(1000), int64(2000)
+	readOnly, allowPrivilegeEscalation := true, false
+	pod.Spec.SecurityContext = &v1.PodSecurityContext{
+		RunAsUser:      &runAsUser,
+		FSGroup:        &fsGroup,
+		SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+	}
+	pod.Spec.Containers[0].SecurityContext = &v1.SecurityContext{
+		ReadOnlyRootFilesystem:   &readOnly,
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+	}
+	sc := m.determineEffectiveSecurityContext(pod, &pod.Spec.Containers[0])
+	assert.Equal(t, &runtimeapi.LinuxContainerSecurityContext{
+		Capabilities:       &runtimeapi.Capability{AddCapabilities: []string{}, DropCapabilities: []string{"ALL"}},
+		RunAsUser:          &runtimeapi.Int64Value{Value: 1000},
+		ReadonlyRootfs:     true,
+		SupplementalGroups: []int64{2000},
+		NoNewPrivs:         true,
+		Seccomp:            &runtimeapi.SecurityProfile{ProfileType: runtimeapi.SecurityProfile_RuntimeDefault},
+	}, sc)
+
+	// the seccomp profile of container overrides the one of pod
+	profile := "/etc/kuscia/seccomp.json"
+	pod.Spec.Containers[0].SecurityContext.SeccompProfile = &v1.SeccompProfile{
+		Type:             v1.SeccompProfileTypeLocalhost,
+		LocalhostProfile: &profile,
+	}
+	sc = m.determineEffectiveSecurityContext(pod, &pod.Spec.Containers[0])
+	assert.Equal(t, &runtimeapi.SecurityProfile{
+		ProfileType:  runtimeapi.SecurityProfile_Localhost,
+		LocalhostRef: profile,
+	}, sc.Seccomp)
+}
+
+func TestVerifyRunAsNonRoot(t *testing.T) {
+	_, i, m, err := createTestRuntimeManager()
+	assert.NoError(t, err)
+	i.SetFakeImages([]string{"root-image", "user-image", "named-image"})
+	i.Images["user-image"].Uid = &runtimeapi.Int64Value{Value: 1000}
+	i.Images["named-image"].Username = "engine"
+
+	runAsNonRoot := true
+	rootUser, nonRootUser := int64(0), int64(1000)
+	tests := []struct {
+		name    string
+		image   string
+		sc      *v1.SecurityContext
+		wantErr bool
+	}{
+		{name: "no security context", image: "root-image"},
+		{name: "root image", image: "root-image", sc: &v1.SecurityContext{RunAsNonRoot: &runAsNonRoot}, wantErr: true},
+		{name: "non-root image", image: "user-image", sc: &v1.SecurityContext{RunAsNonRoot: &runAsNonRoot}},
+		{name: "non-numeric user", image: "named-image", sc: &v1.SecurityContext{RunAsNonRoot: &runAsNonRoot}, wantErr: true},
+		{name: "run as root", image: "user-image", sc: &v1.SecurityContext{RunAsNonRoot: &runAsNonRoot, RunAsUser: &rootUser}, wantErr: true},
+		{name: "run as non-root", image: "root-image", sc: &v1.SecurityContext{RunAsNonRoot: &runAsNonRoot, RunAsUser: &nonRootUser}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{UID: "12345678", Name: "bar", Namespace: "new"},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "foo", Image: tt.image, SecurityContext: tt.sc}}},
+			}
+			err := m.verifyRunAsNonRoot(context.Background(), pod, &pod.Spec.Containers[0], tt.image)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
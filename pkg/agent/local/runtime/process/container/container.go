@@ -90,6 +90,9 @@ type Container struct {
 	imageStore store.Store
 	imageName  *kii.ImageName
 	starter    st.Starter
+	// rootfsBaseSize is the size of rootfs right after the image is unpacked, the growth
+	// beyond it is the writable layer used by the container.
+	rootfsBaseSize int64
 }
 
 // Opts sets specific information to newly created Container.
@@ -162,6 +165,12 @@ func (c *Container) Create() error {
 		return fmt.Errorf("failed to copy resolv.conf, detail-> %v", err)
 	}
 
+	baseSize, err := paths.DirSize(c.bundle.GetOciRootfsPath())
+	if err != nil {
+		nlog.Warnf("Failed to stat rootfs size of container %q, detail-> %v", c.ID, err)
+	}
+	c.rootfsBaseSize = baseSize
+
 	c.status.CreatedAt = time.Now().UnixNano()
 
 	return nil
@@ -483,6 +492,22 @@ func (c *Container) GetCRIStatus() *runtime.ContainerStatus {
 	return status
 }
 
+// GetWritableLayerUsage returns the bytes written to rootfs by the container.
+func (c *Container) GetWritableLayerUsage() (int64, error) {
+	c.RLock()
+	baseSize := c.rootfsBaseSize
+	c.RUnlock()
+
+	size, err := paths.DirSize(c.bundle.GetOciRootfsPath())
+	if err != nil {
+		return 0, err
+	}
+	if size < baseSize {
+		return 0, nil
+	}
+	return size - baseSize, nil
+}
+
 func (c *Container) GetStatus() Status {
 	c.RLock()
 	defer c.RUnlock()
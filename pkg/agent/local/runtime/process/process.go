@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 
@@ -235,6 +236,61 @@ func (r *Runtime) ContainerStatus(ctx context.Context, containerID string, verbo
 	return &runtimeapi.ContainerStatusResponse{Status: status}, nil
 }
 
+func (r *Runtime) ContainerStats(ctx context.Context, containerID string) (*runtimeapi.ContainerStats, error) {
+	container, err := r.containerStore.Get(containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find container %q, detail-> %v", containerID, err)
+	}
+
+	return toCRIContainerStats(container)
+}
+
+func (r *Runtime) ListContainerStats(ctx context.Context, filter *runtimeapi.ContainerStatsFilter) ([]*runtimeapi.ContainerStats, error) {
+	var containerFilter *runtimeapi.ContainerFilter
+	if filter != nil {
+		containerFilter = &runtimeapi.ContainerFilter{
+			Id:            filter.GetId(),
+			PodSandboxId:  filter.GetPodSandboxId(),
+			LabelSelector: filter.GetLabelSelector(),
+		}
+	}
+
+	var stats []*runtimeapi.ContainerStats
+	for _, container := range r.containerStore.List() {
+		if len(r.filterCRIContainers([]*runtimeapi.Container{toCRIContainer(container)}, containerFilter)) == 0 {
+			continue
+		}
+		stat, err := toCRIContainerStats(container)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// toCRIContainerStats reports the writable layer usage of container, cpu and memory are not collected.
+func toCRIContainerStats(container *ctr.Container) (*runtimeapi.ContainerStats, error) {
+	usage, err := container.GetWritableLayerUsage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get writable layer usage of container %q, detail-> %v", container.ID, err)
+	}
+
+	return &runtimeapi.ContainerStats{
+		Attributes: &runtimeapi.ContainerAttributes{
+			Id:          container.ID,
+			Metadata:    container.Config.GetMetadata(),
+			Labels:      container.Config.GetLabels(),
+			Annotations: container.Config.GetAnnotations(),
+		},
+		WritableLayer: &runtimeapi.FilesystemUsage{
+			Timestamp: time.Now().UnixNano(),
+			UsedBytes: &runtimeapi.UInt64Value{Value: uint64(usage)},
+		},
+	}, nil
+}
+
 func (r *Runtime) RunPodSandbox(ctx context.Context, config *runtimeapi.PodSandboxConfig, runtimeHandler string) (id string, retErr error) {
 	podSandbox, err := sandbox.NewSandbox(config, r.hostIP, r.sandboxRootDir)
 	if err != nil {
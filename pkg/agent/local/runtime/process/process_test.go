@@ -118,6 +118,15 @@ func Test_RuntimeSandboxAndContainers(t *testing.T) {
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(containerList))
+
+	containerStats, err := runtime.ListContainerStats(ctx, &runtimeapi.ContainerStatsFilter{PodSandboxId: sandboxID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(containerStats))
+	assert.Equal(t, containerID, containerStats[0].Attributes.Id)
+	assert.NotNil(t, containerStats[0].WritableLayer.UsedBytes)
+	containerStats, err = runtime.ListContainerStats(ctx, &runtimeapi.ContainerStatsFilter{PodSandboxId: "not-exist"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(containerStats))
 	assert.Equal(t, containerID, containerList[0].Id)
 
 	containerStatus, err := runtime.ContainerStatus(ctx, containerID, false)
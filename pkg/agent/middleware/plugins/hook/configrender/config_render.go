@@ -15,19 +15,13 @@
 package configrender
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
-	"reflect"
-	"regexp"
-	"strconv"
 	"strings"
-	"text/template"
 
 	v1 "k8s.io/api/core/v1"
 
@@ -58,6 +52,9 @@ func Register() {
 }
 
 type configRenderConfig struct {
+	// Strict makes rendering fail on undefined variables of config templates.
+	Strict bool `yaml:"strict,omitempty"`
+
 	kusciaAPIProtocol common.Protocol
 	// Todo: temporary solution for scql
 	kusciaAPIToken string
@@ -385,31 +382,8 @@ func (cr *configRender) renderConfigFile(templateFile, configFile string, data m
 
 func (cr *configRender) renderConfig(templateContent string, data map[string]string) (string, error) {
 	// parse kuscia configs
-	configKeys := make(map[string]struct{})
-	configReg := regexp.MustCompile(`\{\{?\{\.([^{}]+)\}\}?\}`)
-	configResult := configReg.ReplaceAllStringFunc(templateContent, func(match string) string {
-		subMatch := configReg.FindStringSubmatch(match)
-		if len(subMatch) > 1 {
-			key := strings.Split(subMatch[1], ".")[0]
-			configKeys[key] = struct{}{}
-			// replace {{{pattern}}} --> {{kuscia "pattern"}}
-			if strings.HasPrefix(subMatch[0], "{{{") {
-				reg := regexp.MustCompile(`\[(.*?)\]`)
-				subMatch[1] = reg.ReplaceAllStringFunc(subMatch[1], func(match string) string {
-					result := reg.FindStringSubmatch(match)
-					if len(result) > 1 {
-						// replace [v1.v2] to [v1#v2]
-						return strings.ReplaceAll(match, ".", "#")
-					}
-					return match
-				})
-				return "{{kuscia " + "\"" + subMatch[1] + "\"" + "}}"
-			}
-		}
-		return match
-	})
 	keysFromCM := make(map[string]struct{})
-	for key := range configKeys {
+	for _, key := range uc.ConfigTemplateKeys(templateContent) {
 		if _, ok := data[key]; !ok {
 			keysFromCM[key] = struct{}{}
 		}
@@ -429,40 +403,23 @@ func (cr *configRender) renderConfig(templateContent string, data map[string]str
 				data[d.Key] = d.Value
 			}
 		} else {
-			return "", fmt.Errorf("failed to get config keys %v from cm, %v", configKeys, resp.Status.Message)
-		}
-	}
-
-	// use to replace values
-	kusciaQueryValue := func(query string) string {
-		value := uc.QueryByFields(buildStructMap(data), query)
-		if value == nil {
-			return ""
-		}
-
-		if _, ok := value.(string); ok {
-			return value.(string)
+			return "", fmt.Errorf("failed to get config keys %v from cm, %v", keys, resp.Status.Message)
 		}
-		output, _ := json.Marshal(value)
-		return string(output)
-	}
-
-	tmpl, err := template.New("config-template").Option(defaultTemplateRenderOption).Funcs(template.FuncMap{"kuscia": kusciaQueryValue}).Parse(configResult)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse config template, detail-> %v", err)
 	}
 
-	quoteData := make(map[string]string)
-	for k, v := range data {
-		quoteData[k] = strings.Trim(strconv.Quote(v), "\"")
-	}
+	return uc.RenderConfigTemplate(templateContent, data, uc.ConfigTemplateOptions{
+		Strict:       cr.config.Strict,
+		LookupSecret: cr.lookupSecret,
+	})
+}
 
-	var buf bytes.Buffer
-	if err = tmpl.Execute(&buf, quoteData); err != nil {
-		return "", fmt.Errorf("failed to execute config template, detail-> %v", err)
+// lookupSecret returns the value of key stored in confmanager of the domain.
+func (cr *configRender) lookupSecret(key string) (string, error) {
+	resp := cr.cmConfigService.QueryConfig(cr.ctx, &confmanager.QueryConfigRequest{Key: key})
+	if resp.Status.Code != int32(errorcode.ErrorCode_SUCCESS) {
+		return "", fmt.Errorf("failed to get secret %q from cm, %v", key, resp.Status.Message)
 	}
-
-	return buf.String(), nil
+	return resp.Value, nil
 }
 
 func (cr *configRender) makeDataMap(annotations, envs map[string]string) (map[string]string, error) {
@@ -513,32 +470,3 @@ func mergeDataMap(dst map[string]string, src map[string]string) {
 		dst[strings.ToUpper(k)] = v
 	}
 }
-
-func buildStructMap(value map[string]string) map[string]interface{} {
-	result := make(map[string]interface{})
-	for k, v := range value {
-		result[k] = v
-
-		// try json
-		var payload interface{}
-		if err := json.Unmarshal([]byte(v), &payload); err == nil {
-			switch reflect.TypeOf(payload).Kind() {
-			case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
-				result[k] = payload
-			}
-
-		}
-
-		// TODO: try yaml
-		//yamlPayload := make(map[string]interface{})
-		//if err := yaml.Unmarshal([]byte(v), &yamlPayload); err == nil {
-		//	switch reflect.TypeOf(yamlPayload).Kind() {
-		//	case reflect.Array, reflect.Map, reflect.Slice:
-		//		result[k] = yamlPayload
-		//	default:
-		//	}
-		//}
-	}
-
-	return result
-}
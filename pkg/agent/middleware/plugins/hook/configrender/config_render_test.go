@@ -282,59 +282,6 @@ task_cluster_define: "{{.TASK_CLUSTER_DEFINE}}"
 	}
 }
 
-func TestMergeDataMaps_WithJsonMap(t *testing.T) {
-	t.Parallel()
-	dst := buildStructMap(map[string]string{
-		"KEY1": "{\"xyz\":1}",
-		"KEY2": "124",
-	})
-
-	assert.Len(t, dst, 2)
-	assert.Contains(t, dst, "KEY1")
-	assert.Contains(t, dst, "KEY2")
-
-	assert.Contains(t, dst["KEY1"], "xyz")
-	assert.Equal(t, dst["KEY1"].(map[string]interface{})["xyz"], float64(1))
-}
-
-func TestBuildStructMap_WithJsonArray(t *testing.T) {
-	t.Parallel()
-	dst := buildStructMap(map[string]string{
-		"KEY1": "[1,2,3]",
-		"KEY2": "[\"1\"]",
-	})
-
-	assert.Len(t, dst, 2)
-	assert.Contains(t, dst, "KEY1")
-	assert.Contains(t, dst, "KEY2")
-
-	assert.Len(t, dst["KEY1"], 3)
-	assert.Len(t, dst["KEY2"], 1)
-	assert.Len(t, dst["KEY2"], 1)
-	assert.Equal(t, dst["KEY1"], []interface{}{float64(1), float64(2), float64(3)})
-	assert.Equal(t, dst["KEY2"], []interface{}{"1"})
-}
-
-func TestBuildStructMap_WithJsonMapArray(t *testing.T) {
-	t.Parallel()
-	dst := buildStructMap(map[string]string{
-		"KEY3": "[{\"xyz\":\"1\"}]",
-		"KEY4": "{\"xyz\":[\"2\"]}",
-	})
-
-	assert.Len(t, dst, 2)
-	assert.Contains(t, dst, "KEY3")
-	assert.Contains(t, dst, "KEY4")
-
-	assert.Len(t, dst["KEY3"], 1)
-	assert.NotNil(t, dst["KEY3"].([]interface{})[0])
-	assert.Contains(t, dst["KEY3"].([]interface{})[0], "xyz")
-	assert.Contains(t, dst["KEY3"].([]interface{})[0].(map[string]interface{})["xyz"], "1")
-
-	assert.Contains(t, dst["KEY4"], "xyz")
-	assert.Equal(t, dst["KEY4"].(map[string]interface{})["xyz"], []interface{}{"2"})
-}
-
 func TestBuildStructMap_WithStruct(t *testing.T) {
 	t.Parallel()
 	cr := setupTestConfigRender(t)
@@ -0,0 +1,141 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datameshtoken
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/secretflow/kuscia/pkg/agent/config"
+	"github.com/secretflow/kuscia/pkg/agent/container"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/hook"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/plugin"
+	"github.com/secretflow/kuscia/pkg/agent/utils/format"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/datameshtoken"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+func Register() {
+	plugin.Register(common.PluginNameDataMeshToken, &dataMeshToken{})
+}
+
+// dataMeshToken injects the token which authenticates the task pod to the DataMesh Flight server.
+type dataMeshToken struct {
+	domainKey   *rsa.PrivateKey
+	initialized bool
+}
+
+// Type implements the plugin.Plugin interface.
+func (dt *dataMeshToken) Type() string {
+	return hook.PluginType
+}
+
+// Init implements the plugin.Plugin interface.
+func (dt *dataMeshToken) Init(ctx context.Context, dependencies *plugin.Dependencies, cfg *config.PluginCfg) error {
+	if dependencies.AgentConfig.DomainKey == nil {
+		nlog.Infof("Plugin datamesh-token will not be registered, domain key is empty")
+		return nil
+	}
+
+	dt.domainKey = dependencies.AgentConfig.DomainKey
+	dt.initialized = true
+
+	hook.Register(common.PluginNameDataMeshToken, dt)
+
+	return nil
+}
+
+// CanExec implements the hook.Handler interface.
+func (dt *dataMeshToken) CanExec(ctx hook.Context) bool {
+	if !dt.initialized {
+		return false
+	}
+
+	var pod *corev1.Pod
+	switch ctx.Point() {
+	case hook.PointGenerateContainerOptions:
+		gCtx, ok := ctx.(*hook.GenerateContainerOptionContext)
+		if !ok {
+			return false
+		}
+		pod = gCtx.Pod
+	case hook.PointK8sProviderSyncPod:
+		syncPodCtx, ok := ctx.(*hook.K8sProviderSyncPodContext)
+		if !ok {
+			return false
+		}
+		pod = syncPodCtx.Pod
+	default:
+		return false
+	}
+
+	return pod != nil && pod.Annotations[common.TaskIDAnnotationKey] != ""
+}
+
+// ExecHook implements the hook.Handler interface.
+// It signs a token for the task pod and exposes it to the containers by env KUSCIA_DATAMESH_TOKEN.
+func (dt *dataMeshToken) ExecHook(ctx hook.Context) (*hook.Result, error) {
+	if !dt.initialized {
+		return nil, fmt.Errorf("plugin datamesh-token is not initialized")
+	}
+
+	switch ctx.Point() {
+	case hook.PointGenerateContainerOptions:
+		gCtx, ok := ctx.(*hook.GenerateContainerOptionContext)
+		if !ok {
+			return nil, fmt.Errorf("invalid context type %T", ctx)
+		}
+
+		token, err := dt.signToken(gCtx.Pod)
+		if err != nil {
+			return nil, err
+		}
+		gCtx.Opts.Envs = append(gCtx.Opts.Envs, container.EnvVar{Name: common.EnvDataMeshToken, Value: token})
+	case hook.PointK8sProviderSyncPod:
+		syncPodCtx, ok := ctx.(*hook.K8sProviderSyncPodContext)
+		if !ok {
+			return nil, fmt.Errorf("invalid context type %T", ctx)
+		}
+
+		token, err := dt.signToken(syncPodCtx.Pod)
+		if err != nil {
+			return nil, err
+		}
+		for i := range syncPodCtx.BkPod.Spec.Containers {
+			c := &syncPodCtx.BkPod.Spec.Containers[i]
+			c.Env = append(c.Env, corev1.EnvVar{Name: common.EnvDataMeshToken, Value: token})
+		}
+	default:
+		return nil, fmt.Errorf("invalid point %v", ctx.Point())
+	}
+
+	return &hook.Result{}, nil
+}
+
+func (dt *dataMeshToken) signToken(pod *corev1.Pod) (string, error) {
+	claims, err := datameshtoken.NewClaims(pod)
+	if err != nil {
+		return "", err
+	}
+	token, err := datameshtoken.Sign(dt.domainKey, claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign datamesh token for pod %q, detail-> %v", format.Pod(pod), err)
+	}
+	return token, nil
+}
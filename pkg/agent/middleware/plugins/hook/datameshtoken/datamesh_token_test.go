@@ -0,0 +1,78 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datameshtoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/agent/config"
+	pkgcontainer "github.com/secretflow/kuscia/pkg/agent/container"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/hook"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/plugin"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/datameshtoken"
+)
+
+func TestDataMeshToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	dt := &dataMeshToken{}
+	assert.NoError(t, dt.Init(context.Background(), &plugin.Dependencies{AgentConfig: &config.AgentConfig{DomainKey: key}}, nil))
+
+	taskPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "task-0",
+			Namespace:   "alice",
+			UID:         "uid-0",
+			Annotations: map[string]string{common.TaskIDAnnotationKey: "task"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "c0"}, {Name: "c1"}}},
+	}
+	otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "alice"}}
+
+	assert.False(t, dt.CanExec(&hook.GenerateContainerOptionContext{Pod: otherPod}))
+
+	gCtx := &hook.GenerateContainerOptionContext{
+		Pod:       taskPod,
+		Container: &taskPod.Spec.Containers[0],
+		Opts:      &pkgcontainer.RunContainerOptions{},
+	}
+	assert.True(t, dt.CanExec(gCtx))
+	_, err = dt.ExecHook(gCtx)
+	assert.NoError(t, err)
+	assert.Len(t, gCtx.Opts.Envs, 1)
+	assert.Equal(t, common.EnvDataMeshToken, gCtx.Opts.Envs[0].Name)
+	claims, err := datameshtoken.Parse(&key.PublicKey, gCtx.Opts.Envs[0].Value)
+	assert.NoError(t, err)
+	assert.Equal(t, "uid-0", claims.PodUID)
+	assert.Equal(t, "task", claims.TaskID)
+
+	syncCtx := &hook.K8sProviderSyncPodContext{Pod: taskPod, BkPod: taskPod.DeepCopy()}
+	assert.True(t, dt.CanExec(syncCtx))
+	_, err = dt.ExecHook(syncCtx)
+	assert.NoError(t, err)
+	for _, c := range syncCtx.BkPod.Spec.Containers {
+		assert.Len(t, c.Env, 1)
+		assert.Equal(t, common.EnvDataMeshToken, c.Env[0].Name)
+	}
+}
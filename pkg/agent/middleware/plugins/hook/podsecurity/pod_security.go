@@ -0,0 +1,231 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecurity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/securitycontext"
+
+	"github.com/secretflow/kuscia/pkg/agent/config"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/hook"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/plugin"
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const localhostSeccompPrefix = "localhost/"
+
+func Register() {
+	plugin.Register(common.PluginNamePodSecurity, &podSecurity{})
+}
+
+type podSecurityConfig struct {
+	// RunAsNonRoot rejects containers which run as root.
+	RunAsNonRoot bool `yaml:"runAsNonRoot"`
+	// RunAsUser is the uid of containers which don't specify one, the user of image is used if it's 0.
+	RunAsUser int64 `yaml:"runAsUser"`
+	// ReadOnlyRootFilesystem mounts the root filesystem of containers as read-only.
+	ReadOnlyRootFilesystem bool `yaml:"readOnlyRootFilesystem"`
+	// DropCapabilities are dropped from all containers.
+	DropCapabilities []string `yaml:"dropCapabilities"`
+	// AllowedCapabilities are the capabilities that containers could add, the others are removed.
+	AllowedCapabilities []string `yaml:"allowedCapabilities"`
+	// SeccompProfile is RuntimeDefault, or localhost/<path of profile on node>. It's applied to the containers
+	// without a seccomp profile or with an Unconfined one.
+	SeccompProfile string `yaml:"seccompProfile"`
+	// ExemptAppImages are the names of AppImages whose pods are not hardened.
+	ExemptAppImages []string `yaml:"exemptAppImages"`
+}
+
+type podSecurity struct {
+	config         podSecurityConfig
+	seccompProfile *v1.SeccompProfile
+	exempted       map[string]bool
+	allowed        map[v1.Capability]bool
+	initialized    bool
+}
+
+// Type implements the plugin.Plugin interface.
+func (ps *podSecurity) Type() string {
+	return hook.PluginType
+}
+
+// Init implements the plugin.Plugin interface.
+func (ps *podSecurity) Init(ctx context.Context, dependencies *plugin.Dependencies, cfg *config.PluginCfg) error {
+	ps.config = podSecurityConfig{
+		RunAsNonRoot:           true,
+		ReadOnlyRootFilesystem: true,
+		DropCapabilities:       []string{"ALL"},
+		SeccompProfile:         string(v1.SeccompProfileTypeRuntimeDefault),
+	}
+	if err := cfg.Config.Decode(&ps.config); err != nil {
+		return err
+	}
+	if ps.config.RunAsUser < 0 {
+		return fmt.Errorf("runAsUser %d is invalid", ps.config.RunAsUser)
+	}
+
+	switch profile := ps.config.SeccompProfile; {
+	case profile == "":
+	case profile == string(v1.SeccompProfileTypeRuntimeDefault):
+		ps.seccompProfile = &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}
+	case strings.HasPrefix(profile, localhostSeccompPrefix) && len(profile) > len(localhostSeccompPrefix):
+		path := strings.TrimPrefix(profile, localhostSeccompPrefix)
+		ps.seccompProfile = &v1.SeccompProfile{Type: v1.SeccompProfileTypeLocalhost, LocalhostProfile: &path}
+	default:
+		return fmt.Errorf("seccomp profile %q is invalid, it should be RuntimeDefault or localhost/<path>", profile)
+	}
+
+	ps.exempted = map[string]bool{}
+	for _, name := range ps.config.ExemptAppImages {
+		ps.exempted[name] = true
+	}
+	ps.allowed = map[v1.Capability]bool{}
+	for _, c := range ps.config.AllowedCapabilities {
+		ps.allowed[v1.Capability(c)] = true
+	}
+
+	ps.initialized = true
+	hook.Register(common.PluginNamePodSecurity, ps)
+	return nil
+}
+
+// CanExec implements the hook.Handler interface.
+func (ps *podSecurity) CanExec(ctx hook.Context) bool {
+	if !ps.initialized {
+		return false
+	}
+
+	switch ctx.Point() {
+	case hook.PointGenerateContainerOptions:
+		gCtx, ok := ctx.(*hook.GenerateContainerOptionContext)
+		return ok && !ps.isExempted(gCtx.Pod)
+	case hook.PointK8sProviderSyncPod:
+		syncPodCtx, ok := ctx.(*hook.K8sProviderSyncPodContext)
+		return ok && !ps.isExempted(syncPodCtx.Pod)
+	default:
+		return false
+	}
+}
+
+// ExecHook implements the hook.Handler interface.
+// In CRI runtime, the hardened security context is passed by container options, so the spec of pod is not changed.
+// In K8s runtime, the containers of the backend pod are hardened.
+func (ps *podSecurity) ExecHook(ctx hook.Context) (*hook.Result, error) {
+	if !ps.initialized {
+		return nil, fmt.Errorf("plugin pod-security is not initialized")
+	}
+
+	switch ctx.Point() {
+	case hook.PointGenerateContainerOptions:
+		gCtx, ok := ctx.(*hook.GenerateContainerOptionContext)
+		if !ok {
+			return nil, fmt.Errorf("invalid context type %T", ctx)
+		}
+		gCtx.Opts.SecurityContext = ps.harden(gCtx.Pod, gCtx.Container)
+	case hook.PointK8sProviderSyncPod:
+		syncPodCtx, ok := ctx.(*hook.K8sProviderSyncPodContext)
+		if !ok {
+			return nil, fmt.Errorf("invalid context type %T", ctx)
+		}
+		bkPod := syncPodCtx.BkPod
+		for i := range bkPod.Spec.InitContainers {
+			bkPod.Spec.InitContainers[i].SecurityContext = ps.harden(bkPod, &bkPod.Spec.InitContainers[i])
+		}
+		for i := range bkPod.Spec.Containers {
+			bkPod.Spec.Containers[i].SecurityContext = ps.harden(bkPod, &bkPod.Spec.Containers[i])
+		}
+	}
+
+	return &hook.Result{}, nil
+}
+
+func (ps *podSecurity) isExempted(pod *v1.Pod) bool {
+	appImage := pod.Annotations[common.AppImageAnnotationKey]
+	if appImage != "" && ps.exempted[appImage] {
+		nlog.Debugf("Pod %s/%s of AppImage %q is exempted from pod security policy", pod.Namespace, pod.Name, appImage)
+		return true
+	}
+	return false
+}
+
+// harden returns the security context of container enforced by the policy, the settings of container are kept if
+// they are stricter.
+func (ps *podSecurity) harden(pod *v1.Pod, container *v1.Container) *v1.SecurityContext {
+	sc := &v1.SecurityContext{}
+	if container.SecurityContext != nil {
+		sc = container.SecurityContext.DeepCopy()
+	}
+
+	privileged, allowPrivilegeEscalation := false, false
+	sc.Privileged = &privileged
+	sc.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+
+	if ps.config.RunAsNonRoot {
+		runAsNonRoot := true
+		sc.RunAsNonRoot = &runAsNonRoot
+		if _, ok := securitycontext.DetermineEffectiveRunAsUser(pod, container); !ok && ps.config.RunAsUser > 0 {
+			runAsUser := ps.config.RunAsUser
+			sc.RunAsUser = &runAsUser
+		}
+	}
+	if ps.config.ReadOnlyRootFilesystem {
+		readOnlyRootFilesystem := true
+		sc.ReadOnlyRootFilesystem = &readOnlyRootFilesystem
+	}
+
+	if sc.Capabilities != nil || len(ps.config.DropCapabilities) > 0 {
+		capabilities := &v1.Capabilities{}
+		if sc.Capabilities != nil {
+			for _, c := range sc.Capabilities.Add {
+				if ps.allowed[c] {
+					capabilities.Add = append(capabilities.Add, c)
+				}
+			}
+			capabilities.Drop = sc.Capabilities.Drop
+		}
+		for _, c := range ps.config.DropCapabilities {
+			if !containsCapability(capabilities.Drop, v1.Capability(c)) {
+				capabilities.Drop = append(capabilities.Drop, v1.Capability(c))
+			}
+		}
+		sc.Capabilities = capabilities
+	}
+
+	if ps.seccompProfile != nil {
+		profile := sc.SeccompProfile
+		if profile == nil && pod.Spec.SecurityContext != nil {
+			profile = pod.Spec.SecurityContext.SeccompProfile
+		}
+		if profile == nil || profile.Type == v1.SeccompProfileTypeUnconfined {
+			sc.SeccompProfile = ps.seccompProfile.DeepCopy()
+		}
+	}
+
+	return sc
+}
+
+func containsCapability(capabilities []v1.Capability, c v1.Capability) bool {
+	for _, capability := range capabilities {
+		if capability == c {
+			return true
+		}
+	}
+	return false
+}
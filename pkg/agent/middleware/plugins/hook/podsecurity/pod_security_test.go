@@ -0,0 +1,147 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podsecurity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/agent/config"
+	pkgcontainer "github.com/secretflow/kuscia/pkg/agent/container"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/hook"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/plugin"
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func setupTestPodSecurity(t *testing.T, configYaml string) (*podSecurity, error) {
+	cfg := &config.PluginCfg{}
+	assert.NoError(t, yaml.Unmarshal([]byte(configYaml), cfg))
+
+	dep := &plugin.Dependencies{
+		AgentConfig: config.DefaultAgentConfig(common.DefaultKusciaHomePath),
+	}
+
+	ps := &podSecurity{}
+	assert.Equal(t, hook.PluginType, ps.Type())
+	return ps, ps.Init(context.Background(), dep, cfg)
+}
+
+func newTestPod(appImage string, sc *v1.SecurityContext) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "alice-task-0",
+			Namespace:   "alice",
+			Annotations: map[string]string{common.AppImageAnnotationKey: appImage},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "engine", Image: "secretflow:latest", SecurityContext: sc}},
+		},
+	}
+}
+
+func TestPodSecurity_Default(t *testing.T) {
+	ps, err := setupTestPodSecurity(t, `
+name: "pod-security"
+`)
+	assert.NoError(t, err)
+
+	privileged := true
+	pod := newTestPod("secretflow-image", &v1.SecurityContext{
+		Privileged: &privileged,
+		Capabilities: &v1.Capabilities{
+			Add: []v1.Capability{"NET_ADMIN"},
+		},
+		SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeUnconfined},
+	})
+	ctx := &hook.GenerateContainerOptionContext{
+		Pod:       pod,
+		Container: &pod.Spec.Containers[0],
+		Opts:      &pkgcontainer.RunContainerOptions{},
+	}
+	assert.True(t, ps.CanExec(ctx))
+	_, err = ps.ExecHook(ctx)
+	assert.NoError(t, err)
+
+	sc := ctx.Opts.SecurityContext
+	assert.False(t, *sc.Privileged)
+	assert.False(t, *sc.AllowPrivilegeEscalation)
+	assert.True(t, *sc.RunAsNonRoot)
+	assert.Nil(t, sc.RunAsUser)
+	assert.True(t, *sc.ReadOnlyRootFilesystem)
+	assert.Empty(t, sc.Capabilities.Add)
+	assert.Equal(t, []v1.Capability{"ALL"}, sc.Capabilities.Drop)
+	assert.Equal(t, v1.SeccompProfileTypeRuntimeDefault, sc.SeccompProfile.Type)
+	// the spec of pod is not changed
+	assert.True(t, *pod.Spec.Containers[0].SecurityContext.Privileged)
+}
+
+func TestPodSecurity_Config(t *testing.T) {
+	ps, err := setupTestPodSecurity(t, `
+name: "pod-security"
+config:
+  runAsUser: 1000
+  readOnlyRootFilesystem: false
+  allowedCapabilities: ["NET_BIND_SERVICE"]
+  seccompProfile: localhost/profiles/engine.json
+  exemptAppImages: ["legacy-image"]
+`)
+	assert.NoError(t, err)
+
+	pod := newTestPod("legacy-image", nil)
+	ctx := &hook.GenerateContainerOptionContext{Pod: pod, Container: &pod.Spec.Containers[0], Opts: &pkgcontainer.RunContainerOptions{}}
+	assert.False(t, ps.CanExec(ctx))
+
+	pod = newTestPod("secretflow-image", &v1.SecurityContext{
+		Capabilities: &v1.Capabilities{
+			Add: []v1.Capability{"NET_ADMIN", "NET_BIND_SERVICE"},
+		},
+	})
+	bkPod := pod.DeepCopy()
+	bkPod.Spec.InitContainers = []v1.Container{{Name: "init"}}
+	syncPodCtx := &hook.K8sProviderSyncPodContext{Pod: pod, BkPod: bkPod}
+	assert.True(t, ps.CanExec(syncPodCtx))
+	_, err = ps.ExecHook(syncPodCtx)
+	assert.NoError(t, err)
+
+	sc := bkPod.Spec.Containers[0].SecurityContext
+	assert.Equal(t, int64(1000), *sc.RunAsUser)
+	assert.Nil(t, sc.ReadOnlyRootFilesystem)
+	assert.Equal(t, []v1.Capability{"NET_BIND_SERVICE"}, sc.Capabilities.Add)
+	assert.Equal(t, v1.SeccompProfileTypeLocalhost, sc.SeccompProfile.Type)
+	assert.Equal(t, "profiles/engine.json", *sc.SeccompProfile.LocalhostProfile)
+	assert.True(t, *bkPod.Spec.InitContainers[0].SecurityContext.RunAsNonRoot)
+
+	// runAsUser of pod is kept
+	runAsUser := int64(2000)
+	bkPod.Spec.SecurityContext = &v1.PodSecurityContext{RunAsUser: &runAsUser}
+	bkPod.Spec.Containers[0].SecurityContext = nil
+	_, err = ps.ExecHook(syncPodCtx)
+	assert.NoError(t, err)
+	assert.Nil(t, bkPod.Spec.Containers[0].SecurityContext.RunAsUser)
+}
+
+func TestPodSecurity_InvalidConfig(t *testing.T) {
+	_, err := setupTestPodSecurity(t, `
+name: "pod-security"
+config:
+  seccompProfile: Unconfined
+`)
+	assert.Error(t, err)
+}
@@ -17,8 +17,10 @@ package plugins
 import (
 	"github.com/secretflow/kuscia/pkg/agent/middleware/plugins/hook/certissuance"
 	"github.com/secretflow/kuscia/pkg/agent/middleware/plugins/hook/configrender"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/plugins/hook/datameshtoken"
 	"github.com/secretflow/kuscia/pkg/agent/middleware/plugins/hook/envimport"
 	"github.com/secretflow/kuscia/pkg/agent/middleware/plugins/hook/imagesecurity"
+	"github.com/secretflow/kuscia/pkg/agent/middleware/plugins/hook/podsecurity"
 )
 
 func init() {
@@ -26,4 +28,6 @@ func init() {
 	certissuance.Register()
 	envimport.Register()
 	imagesecurity.Register()
+	datameshtoken.Register()
+	podsecurity.Register()
 }
@@ -0,0 +1,252 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// egressChain holds the egress rules of all task pods on the node.
+	egressChain = "KUSCIA-TASK-EGRESS"
+	// dropLogPrefix is the prefix of kernel logs of dropped packets.
+	dropLogPrefix = "kuscia-egress-drop: "
+)
+
+// parentChains are the chains traffic from pods passes through, to other hosts and to the node itself.
+var parentChains = []string{"FORWARD", "INPUT"}
+
+type podEntry struct {
+	name      string
+	namespace string
+	taskUID   string
+	ips       []string
+	// restricted is false if the pod is only a peer of task pods, e.g. a pod of kuscia deployment.
+	restricted bool
+}
+
+// Enforcer restricts the egress of task pods on the node by iptables, the pods must have their own network namespace
+// and addresses, e.g. pods of runc runtime.
+type Enforcer struct {
+	config    *Config
+	gatewayIP string
+	// run executes the command with stdin, it's replaced in test.
+	run func(stdin []byte, name string, args ...string) error
+
+	mtx     sync.Mutex
+	pods    map[types.UID]*podEntry
+	applied []byte
+	jumped  bool
+}
+
+// NewEnforcer returns an enforcer, gatewayIP is the address of the gateway and DNS of domain.
+func NewEnforcer(config *Config, gatewayIP string) *Enforcer {
+	return &Enforcer{
+		config:    config,
+		gatewayIP: gatewayIP,
+		run:       runCommand,
+		pods:      map[types.UID]*podEntry{},
+	}
+}
+
+func runCommand(stdin []byte, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s %v failed, %v, output: %s", name, args, err, output)
+	}
+	return nil
+}
+
+// AddPod restricts the egress of pod with the addresses if it's a task pod, and allows its peers to access it. It
+// must be called before any container of pod is started.
+func (e *Enforcer) AddPod(pod *v1.Pod, ips []string) error {
+	restricted := e.config.Selected(pod)
+	if pod.Spec.HostNetwork {
+		if restricted {
+			nlog.Warnf("Pod %s/%s uses host network, it's not restricted by network policy", pod.Namespace, pod.Name)
+		}
+		return nil
+	}
+	var ipv4s []string
+	for _, ip := range ips {
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() != nil {
+			ipv4s = append(ipv4s, ip)
+		} else if restricted {
+			nlog.Warnf("Address %q of pod %s/%s is not restricted by network policy, only IPv4 is supported", ip, pod.Namespace, pod.Name)
+		}
+	}
+	if len(ipv4s) == 0 {
+		if !restricted {
+			return nil
+		}
+		return fmt.Errorf("pod %s/%s has no IPv4 address to apply network policy", pod.Namespace, pod.Name)
+	}
+
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.pods[pod.UID] = e.newEntry(pod, ipv4s)
+	return e.sync()
+}
+
+// Resync removes the rules of pods not in pods, and restores the rules of pods which are not added since the agent
+// restarted by their addresses in status.
+func (e *Enforcer) Resync(pods []*v1.Pod) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	existing := sets.NewString()
+	for _, pod := range pods {
+		existing.Insert(string(pod.UID))
+		if _, ok := e.pods[pod.UID]; ok || pod.Spec.HostNetwork || len(pod.Status.PodIPs) == 0 {
+			continue
+		}
+		var ips []string
+		for _, ip := range pod.Status.PodIPs {
+			if parsed := net.ParseIP(ip.IP); parsed != nil && parsed.To4() != nil {
+				ips = append(ips, ip.IP)
+			}
+		}
+		if len(ips) > 0 {
+			e.pods[pod.UID] = e.newEntry(pod, ips)
+		}
+	}
+	for uid := range e.pods {
+		if !existing.Has(string(uid)) {
+			delete(e.pods, uid)
+		}
+	}
+	return e.sync()
+}
+
+func (e *Enforcer) newEntry(pod *v1.Pod, ips []string) *podEntry {
+	return &podEntry{
+		name:       pod.Name,
+		namespace:  pod.Namespace,
+		taskUID:    pod.Labels[common.LabelTaskUID],
+		ips:        ips,
+		restricted: e.config.Selected(pod),
+	}
+}
+
+func (e *Enforcer) sync() error {
+	if !e.jumped {
+		// the chain may exist already
+		if err := e.run(nil, "iptables", "-w", "-N", egressChain); err != nil {
+			nlog.Debugf("Create chain %s: %v", egressChain, err)
+		}
+		for _, parent := range parentChains {
+			if e.run(nil, "iptables", "-w", "-C", parent, "-j", egressChain) == nil {
+				continue
+			}
+			if err := e.run(nil, "iptables", "-w", "-I", parent, "1", "-j", egressChain); err != nil {
+				return err
+			}
+		}
+		e.jumped = true
+	}
+
+	rules := e.rules()
+	if bytes.Equal(rules, e.applied) {
+		return nil
+	}
+	if err := e.run(rules, "iptables-restore", "-w", "--noflush"); err != nil {
+		return err
+	}
+	e.applied = rules
+	nlog.Infof("Network policy of pods is applied, %d pods on node", len(e.pods))
+	return nil
+}
+
+// rules returns the input of iptables-restore which rebuilds the egress chain.
+func (e *Enforcer) rules() []byte {
+	uids := make([]string, 0, len(e.pods))
+	for uid := range e.pods {
+		uids = append(uids, string(uid))
+	}
+	sort.Strings(uids)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "*filter\n:%s - [0:0]\n", egressChain)
+	fmt.Fprintf(buf, "-A %s -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN\n", egressChain)
+	for _, uid := range uids {
+		pod := e.pods[types.UID(uid)]
+		if !pod.restricted {
+			continue
+		}
+		comment := fmt.Sprintf("%s/%s", pod.namespace, pod.name)
+		for _, ip := range pod.ips {
+			rule := func(format string, args ...interface{}) {
+				fmt.Fprintf(buf, "-A %s -s %s/32 -m comment --comment %q %s\n", egressChain, ip, comment, fmt.Sprintf(format, args...))
+			}
+			if e.gatewayIP != "" {
+				for _, p := range gatewayPorts {
+					rule("-d %s -p %s --dport %d -j RETURN", hostCIDR(e.gatewayIP), protocolArg(p), p.Port)
+				}
+			}
+			for _, peer := range e.peerIPs(pod) {
+				rule("-d %s/32 -j RETURN", peer)
+			}
+			for _, p := range e.config.AllowedPorts {
+				rule("-p %s --dport %d -j RETURN", protocolArg(p), p.Port)
+			}
+			for _, cidr := range e.config.AllowedCIDRs {
+				rule("-d %s -j RETURN", cidr)
+			}
+			if e.config.LogDropped {
+				rule("-m limit --limit 10/min -j LOG --log-prefix %q", dropLogPrefix)
+			}
+			rule("-j DROP")
+		}
+	}
+	buf.WriteString("COMMIT\n")
+	return buf.Bytes()
+}
+
+// peerIPs returns the sorted addresses of pods the pod is allowed to access.
+func (e *Enforcer) peerIPs(pod *podEntry) []string {
+	var ips []string
+	for _, other := range e.pods {
+		if other.namespace != pod.namespace {
+			continue
+		}
+		if e.config.Level == LevelTask && other.taskUID != pod.taskUID {
+			continue
+		}
+		ips = append(ips, other.ips...)
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+func protocolArg(p Port) string {
+	if protocolOf(p) == v1.ProtocolUDP {
+		return "udp"
+	}
+	return "tcp"
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netpolicy restricts the egress of task pods, so a task pod can only access its peers, the gateway and DNS of
+// domain, and the addresses and ports allowed in config.
+package netpolicy
+
+import (
+	"fmt"
+	"net"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+const (
+	// LevelNone doesn't restrict the egress of task pods.
+	LevelNone = "none"
+	// LevelDomain allows task pods to access any pod in the same domain.
+	LevelDomain = "domain"
+	// LevelTask only allows task pods to access pods of the same task.
+	LevelTask = "task"
+)
+
+// Config is the network policy of task pods in config file.
+type Config struct {
+	// Level is none, domain or task, default none.
+	Level string `yaml:"level,omitempty"`
+	// AllowedPorts are the ports task pods can access on any address, e.g. the port of an external database.
+	AllowedPorts []Port `yaml:"allowedPorts,omitempty"`
+	// AllowedCIDRs are the networks task pods can access on any port.
+	AllowedCIDRs []string `yaml:"allowedCIDRs,omitempty"`
+	// LogDropped logs the dropped packets to the kernel log with prefix "kuscia-egress-drop: ", rate limited.
+	LogDropped bool `yaml:"logDropped,omitempty"`
+}
+
+// Port is a port allowed to access.
+type Port struct {
+	Port int32 `yaml:"port"`
+	// Protocol is TCP or UDP, default TCP.
+	Protocol v1.Protocol `yaml:"protocol,omitempty"`
+}
+
+// gatewayPorts are the ports of domain infrastructure on the gateway address, i.e. the internal listener of gateway,
+// through which task pods access the peers in other domains and DataMesh, and DNS.
+var gatewayPorts = []Port{
+	{Port: 80, Protocol: v1.ProtocolTCP},
+	{Port: 53, Protocol: v1.ProtocolUDP},
+	{Port: 53, Protocol: v1.ProtocolTCP},
+}
+
+// Enabled returns whether the egress of task pods is restricted.
+func (c *Config) Enabled() bool {
+	return c != nil && c.Level != "" && c.Level != LevelNone
+}
+
+// Check checks the config.
+func (c *Config) Check() error {
+	switch c.Level {
+	case "", LevelNone, LevelDomain, LevelTask:
+	default:
+		return fmt.Errorf("level of network policy must be %s, %s or %s", LevelNone, LevelDomain, LevelTask)
+	}
+	for _, p := range c.AllowedPorts {
+		if p.Port <= 0 || p.Port > 65535 {
+			return fmt.Errorf("allowed port %d of network policy is invalid", p.Port)
+		}
+		if p.Protocol != "" && p.Protocol != v1.ProtocolTCP && p.Protocol != v1.ProtocolUDP {
+			return fmt.Errorf("protocol of allowed port %d must be TCP or UDP", p.Port)
+		}
+	}
+	for _, cidr := range c.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("allowed CIDR %q of network policy is invalid, %v", cidr, err)
+		}
+	}
+	return nil
+}
+
+// Selected returns whether the egress of pod is restricted, only pods of tasks are restricted.
+func (c *Config) Selected(pod *v1.Pod) bool {
+	return c.Enabled() && pod.Labels[common.LabelTaskUID] != ""
+}
+
+func protocolOf(p Port) v1.Protocol {
+	if p.Protocol == "" {
+		return v1.ProtocolTCP
+	}
+	return p.Protocol
+}
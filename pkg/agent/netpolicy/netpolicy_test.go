@@ -0,0 +1,148 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netpolicy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func makeTestPod(name, namespace, taskUID string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		UID:       types.UID(name + "-uid"),
+		Labels:    map[string]string{},
+	}}
+	if taskUID != "" {
+		pod.Labels[common.LabelTaskUID] = taskUID
+	}
+	return pod
+}
+
+func TestCheck(t *testing.T) {
+	assert.NoError(t, (&Config{}).Check())
+	assert.NoError(t, (&Config{Level: LevelTask, AllowedPorts: []Port{{Port: 3306}}, AllowedCIDRs: []string{"10.0.0.0/8"}}).Check())
+	assert.Error(t, (&Config{Level: "strict"}).Check())
+	assert.Error(t, (&Config{AllowedPorts: []Port{{Port: 0}}}).Check())
+	assert.Error(t, (&Config{AllowedPorts: []Port{{Port: 80, Protocol: v1.ProtocolSCTP}}}).Check())
+	assert.Error(t, (&Config{AllowedCIDRs: []string{"10.0.0.1"}}).Check())
+
+	assert.False(t, (&Config{Level: LevelNone}).Selected(makeTestPod("a", "alice", "task-1")))
+	assert.True(t, (&Config{Level: LevelDomain}).Selected(makeTestPod("a", "alice", "task-1")))
+	assert.False(t, (&Config{Level: LevelDomain}).Selected(makeTestPod("a", "alice", "")))
+}
+
+func TestBuildNetworkPolicy(t *testing.T) {
+	config := &Config{Level: LevelTask, AllowedPorts: []Port{{Port: 3306}}, AllowedCIDRs: []string{"10.0.0.0/8"}}
+	pod := makeTestPod("task-1-server-0", "alice", "task-1")
+	np := BuildNetworkPolicy(config, pod, "alice", "172.18.0.2")
+
+	assert.Equal(t, "task-1-server-0", np.Name)
+	assert.Equal(t, map[string]string{common.LabelPodUID: "task-1-server-0-uid"}, np.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, np.Spec.PolicyTypes)
+	require.Len(t, np.Spec.Egress, 4)
+	assert.Equal(t, map[string]string{common.LabelNodeNamespace: "alice", common.LabelTaskUID: "task-1"},
+		np.Spec.Egress[0].To[0].PodSelector.MatchLabels)
+	assert.Equal(t, "172.18.0.2/32", np.Spec.Egress[1].To[0].IPBlock.CIDR)
+	assert.Len(t, np.Spec.Egress[1].Ports, 3)
+	assert.Equal(t, int32(3306), np.Spec.Egress[2].Ports[0].Port.IntVal)
+	assert.Equal(t, "10.0.0.0/8", np.Spec.Egress[3].To[0].IPBlock.CIDR)
+
+	config.Level = LevelDomain
+	np = BuildNetworkPolicy(config, pod, "alice", "172.18.0.2")
+	assert.Equal(t, map[string]string{common.LabelNodeNamespace: "alice"}, np.Spec.Egress[0].To[0].PodSelector.MatchLabels)
+}
+
+type fakeRunner struct {
+	commands []string
+	restored string
+}
+
+func (r *fakeRunner) run(stdin []byte, name string, args ...string) error {
+	r.commands = append(r.commands, name+" "+strings.Join(args, " "))
+	if name == "iptables-restore" {
+		r.restored = string(stdin)
+	}
+	if len(args) > 1 && args[1] == "-C" {
+		return fmt.Errorf("rule doesn't exist")
+	}
+	return nil
+}
+
+func TestEnforcer(t *testing.T) {
+	runner := &fakeRunner{}
+	e := NewEnforcer(&Config{Level: LevelTask, AllowedPorts: []Port{{Port: 3306}}}, "172.18.0.2")
+	e.run = runner.run
+
+	server := makeTestPod("task-1-server-0", "alice", "task-1")
+	client := makeTestPod("task-1-client-0", "alice", "task-1")
+	other := makeTestPod("task-2-server-0", "alice", "task-2")
+	serving := makeTestPod("serving-0", "alice", "")
+	require.NoError(t, e.AddPod(server, []string{"10.88.0.2"}))
+	require.NoError(t, e.AddPod(client, []string{"10.88.0.3", "fd00::3"}))
+	require.NoError(t, e.AddPod(other, []string{"10.88.0.4"}))
+	require.NoError(t, e.AddPod(serving, []string{"10.88.0.5"}))
+
+	assert.Contains(t, runner.commands, "iptables -w -I FORWARD 1 -j KUSCIA-TASK-EGRESS")
+	assert.Contains(t, runner.commands, "iptables -w -I INPUT 1 -j KUSCIA-TASK-EGRESS")
+	rules := runner.restored
+	assert.Contains(t, rules, ":KUSCIA-TASK-EGRESS - [0:0]")
+	assert.Contains(t, rules, `-s 10.88.0.2/32 -m comment --comment "alice/task-1-server-0" -d 10.88.0.3/32 -j RETURN`)
+	assert.NotContains(t, rules, `-s 10.88.0.2/32 -m comment --comment "alice/task-1-server-0" -d 10.88.0.4/32 -j RETURN`)
+	assert.Contains(t, rules, `-s 10.88.0.2/32 -m comment --comment "alice/task-1-server-0" -d 172.18.0.2/32 -p tcp --dport 80 -j RETURN`)
+	assert.Contains(t, rules, `-s 10.88.0.2/32 -m comment --comment "alice/task-1-server-0" -p tcp --dport 3306 -j RETURN`)
+	assert.Contains(t, rules, `-s 10.88.0.2/32 -m comment --comment "alice/task-1-server-0" -j DROP`)
+	assert.NotContains(t, rules, "-j LOG")
+	assert.NotContains(t, rules, "-s 10.88.0.5/32")
+	assert.True(t, strings.HasSuffix(rules, "COMMIT\n"))
+
+	// rules are not restored again if nothing changes
+	commands := len(runner.commands)
+	require.NoError(t, e.AddPod(server, []string{"10.88.0.2"}))
+	assert.Len(t, runner.commands, commands)
+
+	// rules of deleted pods are removed, and pods running before restart are restored from status
+	restarted := makeTestPod("task-3-server-0", "alice", "task-3")
+	restarted.Status.PodIPs = []v1.PodIP{{IP: "10.88.0.6"}}
+	require.NoError(t, e.Resync([]*v1.Pod{server, restarted}))
+	assert.NotContains(t, runner.restored, "10.88.0.3")
+	assert.Contains(t, runner.restored, `-s 10.88.0.6/32 -m comment --comment "alice/task-3-server-0" -j DROP`)
+
+	// the domain level allows pods of domain
+	e.config.Level = LevelDomain
+	require.NoError(t, e.AddPod(client, []string{"10.88.0.3"}))
+	assert.Contains(t, runner.restored, `-s 10.88.0.3/32 -m comment --comment "alice/task-1-client-0" -d 10.88.0.6/32 -j RETURN`)
+}
+
+func TestEnforcerLogDropped(t *testing.T) {
+	runner := &fakeRunner{}
+	e := NewEnforcer(&Config{Level: LevelTask, LogDropped: true}, "172.18.0.2")
+	e.run = runner.run
+
+	require.NoError(t, e.AddPod(makeTestPod("task-1-server-0", "alice", "task-1"), []string{"10.88.0.2"}))
+	assert.Contains(t, runner.restored, `-s 10.88.0.2/32 -m comment --comment "alice/task-1-server-0" -m limit --limit 10/min -j LOG --log-prefix "kuscia-egress-drop: "
+-A KUSCIA-TASK-EGRESS -s 10.88.0.2/32 -m comment --comment "alice/task-1-server-0" -j DROP`)
+}
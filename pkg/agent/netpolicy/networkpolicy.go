@@ -0,0 +1,83 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netpolicy
+
+import (
+	"net"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+// BuildNetworkPolicy builds the NetworkPolicy restricting the egress of pod in a k8s cluster, pods of domain are
+// selected by their labels in the cluster, i.e. LabelNodeNamespace is the domain and LabelPodUID is the UID of source
+// pod. gatewayIP is the address of the gateway and DNS of domain.
+func BuildNetworkPolicy(c *Config, pod *v1.Pod, domain, gatewayIP string) *networkingv1.NetworkPolicy {
+	peers := map[string]string{common.LabelNodeNamespace: domain}
+	if c.Level == LevelTask {
+		peers[common.LabelTaskUID] = pod.Labels[common.LabelTaskUID]
+	}
+
+	egress := []networkingv1.NetworkPolicyEgressRule{
+		{To: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: peers}}}},
+		{
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(gatewayIP)}}},
+			Ports: policyPorts(gatewayPorts),
+		},
+	}
+	if len(c.AllowedPorts) > 0 {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{Ports: policyPorts(c.AllowedPorts)})
+	}
+	if len(c.AllowedCIDRs) > 0 {
+		rule := networkingv1.NetworkPolicyEgressRule{}
+		for _, cidr := range c.AllowedCIDRs {
+			rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+		}
+		egress = append(egress, rule)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   pod.Name,
+			Labels: map[string]string{common.LabelTaskUID: pod.Labels[common.LabelTaskUID]},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{common.LabelPodUID: string(pod.UID)}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egress,
+		},
+	}
+}
+
+func policyPorts(ports []Port) []networkingv1.NetworkPolicyPort {
+	var result []networkingv1.NetworkPolicyPort
+	for _, p := range ports {
+		protocol := protocolOf(p)
+		port := intstr.FromInt(int(p.Port))
+		result = append(result, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port})
+	}
+	return result
+}
+
+func hostCIDR(ip string) string {
+	if net.ParseIP(ip).To4() == nil {
+		return ip + "/128"
+	}
+	return ip + "/32"
+}
@@ -23,8 +23,10 @@ import (
 	"github.com/shirou/gopsutil/v3/disk"
 	"github.com/shirou/gopsutil/v3/mem"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/secretflow/kuscia/pkg/agent/utils/nodeutils"
+	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/utils/math"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/runtime"
@@ -229,8 +231,28 @@ func (gnp *GenericNodeProvider) refreshNodeConditions(ctx context.Context, st *v
 	return memChanged || diskPressureChanged || diskOutChanged || kernelParamsChanged
 }
 
+// refreshDataDiskResource reports the space of data volume as an extended resource, so the scheduler is able to skip
+// nodes whose data disk is nearly full.
+func (gnp *GenericNodeProvider) refreshDataDiskResource(st *v1.NodeStatus) {
+	du, err := disk.Usage(gnp.diskPressurePath)
+	if err != nil {
+		nlog.Warnf("Get disk usage info fail, path=%v, err=%v", gnp.diskPressurePath, err)
+		return
+	}
+
+	if st.Capacity == nil {
+		st.Capacity = v1.ResourceList{}
+	}
+	if st.Allocatable == nil {
+		st.Allocatable = v1.ResourceList{}
+	}
+	st.Capacity[common.ResourceDataDisk] = *resource.NewQuantity(int64(du.Used+du.Free), resource.BinarySI)
+	st.Allocatable[common.ResourceDataDisk] = *resource.NewQuantity(int64(du.Free), resource.BinarySI)
+}
+
 func (gnp *GenericNodeProvider) RefreshNodeStatus(ctx context.Context, nodeStatus *v1.NodeStatus) bool {
 	condChange := gnp.refreshNodeConditions(ctx, nodeStatus)
+	gnp.refreshDataDiskResource(nodeStatus)
 
 	nlog.Debugf("Refresh node status finish, condition_changed=%v", condChange)
 	return condChange
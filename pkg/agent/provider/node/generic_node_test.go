@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/secretflow/kuscia/pkg/agent/config"
+	"github.com/secretflow/kuscia/pkg/common"
 )
 
 func TestGenericNode_ConfigureNode(t *testing.T) {
@@ -35,7 +36,7 @@ func TestGenericNode_ConfigureNode(t *testing.T) {
 			Address:         "1.1.1.1",
 			CapacityManager: capacityManager,
 		},
-		DiskPressurePath: agentConfig.DiskPressurePath,
+		DiskPressurePath: agentConfig.RootDir,
 	}
 
 	n := NewGenericNodeProvider(dep)
@@ -43,5 +44,8 @@ func TestGenericNode_ConfigureNode(t *testing.T) {
 	assert.Equal(t, "test-name", node.Name)
 	assert.Equal(t, n.runtime, node.Labels[labelRuntime])
 	assert.Equal(t, 6, len(node.Status.Conditions))
-
+	dataDiskFree := node.Status.Allocatable[common.ResourceDataDisk]
+	dataDiskTotal := node.Status.Capacity[common.ResourceDataDisk]
+	assert.True(t, dataDiskFree.Value() > 0)
+	assert.True(t, dataDiskTotal.Cmp(dataDiskFree) >= 0)
 }
@@ -38,6 +38,7 @@ import (
 	"k8s.io/kubernetes/pkg/kubelet/cri/remote"
 	"k8s.io/kubernetes/pkg/kubelet/logs"
 	"k8s.io/kubernetes/pkg/kubelet/network/dns"
+	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/kubelet/util"
 	"k8s.io/kubernetes/pkg/volume/validation"
 	"k8s.io/utils/clock"
@@ -52,10 +53,12 @@ import (
 	"github.com/secretflow/kuscia/pkg/agent/kuberuntime"
 	"github.com/secretflow/kuscia/pkg/agent/local/runtime/process"
 	"github.com/secretflow/kuscia/pkg/agent/middleware/hook"
+	"github.com/secretflow/kuscia/pkg/agent/netpolicy"
 	"github.com/secretflow/kuscia/pkg/agent/pleg"
 	"github.com/secretflow/kuscia/pkg/agent/prober"
 	proberesults "github.com/secretflow/kuscia/pkg/agent/prober/results"
 	"github.com/secretflow/kuscia/pkg/agent/resource"
+	"github.com/secretflow/kuscia/pkg/agent/sandboxruntime"
 	"github.com/secretflow/kuscia/pkg/agent/status"
 	"github.com/secretflow/kuscia/pkg/agent/utils/format"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
@@ -108,6 +111,8 @@ type CRIProviderDependence struct {
 	Runtime        string
 	CRIProviderCfg *config.CRIProviderCfg
 	RegistryCfg    *config.RegistryCfg
+	NetworkPolicy  *netpolicy.Config
+	SandboxRuntime *sandboxruntime.Config
 }
 
 // CRIProvider implements the kubelet interface and stores pods in memory.
@@ -149,10 +154,16 @@ type CRIProvider struct {
 	// Manager for container logs.
 	containerLogManager logs.ContainerLogManager
 
+	runtimeService      internalapi.RuntimeService
+	podsStdoutDirectory string
+
 	podStateProvider framework.PodStateProvider
 
 	podSyncHandler framework.SyncHandler
 
+	// netPolicy restricts the egress of task pods, it's nil if disabled.
+	netPolicy *netpolicy.Enforcer
+
 	chStopping chan struct{}
 	chStopped  chan struct{}
 }
@@ -183,6 +194,30 @@ func NewCRIProvider(dep *CRIProviderDependence) (kri.PodProvider, error) {
 
 	cp.rootDirectory = dep.RootDirectory
 
+	if dep.NetworkPolicy.Enabled() {
+		if err := dep.NetworkPolicy.Check(); err != nil {
+			return nil, err
+		}
+		if dep.Runtime == config.ProcessRuntime {
+			nlog.Warnf("Network policy %q is not enforced, pods of runtime %s share the network of node", dep.NetworkPolicy.Level, dep.Runtime)
+		} else {
+			cp.netPolicy = netpolicy.NewEnforcer(dep.NetworkPolicy, dep.NodeIP)
+		}
+	}
+
+	var runtimeHandlerSelector kuberuntime.RuntimeHandlerSelector
+	if dep.SandboxRuntime.Enabled() {
+		if dep.Runtime != config.ContainerRuntime {
+			nlog.Warnf("Sandbox runtime is ignored, it's only supported by runtime %s", config.ContainerRuntime)
+		} else {
+			selector, err := sandboxruntime.NewSelector(dep.SandboxRuntime, sandboxruntime.Detect)
+			if err != nil {
+				return nil, err
+			}
+			runtimeHandlerSelector = selector
+		}
+	}
+
 	imageBackOff := flowcontrol.NewBackOff(backOffPeriod, maxContainerBackOff)
 
 	var (
@@ -225,6 +260,7 @@ func NewCRIProvider(dep *CRIProviderDependence) (kri.PodProvider, error) {
 	}
 
 	cp.ImageManagerService = remoteImageService
+	cp.runtimeService = remoteRuntimeService
 
 	// setup containerLogManager for CRI container runtime
 	containerLogManager, err := logs.NewContainerLogManager(
@@ -244,6 +280,7 @@ func NewCRIProvider(dep *CRIProviderDependence) (kri.PodProvider, error) {
 	cp.probeManager = prober.NewManager(cp.statusManager, cp.livenessManager, cp.readinessManager, cp.startupManager, cp.eventRecorder)
 
 	podsStdoutDirectory := filepath.Join(dep.StdoutDirectory, defaultPodsDirName)
+	cp.podsStdoutDirectory = podsStdoutDirectory
 	cp.containerRuntime, err = kuberuntime.NewManager(
 		dep.EventRecorder,
 		cp.livenessManager,
@@ -263,6 +300,7 @@ func NewCRIProvider(dep *CRIProviderDependence) (kri.PodProvider, error) {
 		podsStdoutDirectory,
 		dep.AllowPrivileged,
 		dep.Runtime,
+		runtimeHandlerSelector,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize kuberuntime manager")
@@ -529,6 +567,11 @@ func (cp *CRIProvider) makeMounts(pod *v1.Pod, container *v1.Container, podVolum
 // the container runtime to set parameters for launching a container.
 func (cp *CRIProvider) GenerateRunContainerOptions(pod *v1.Pod, container *v1.Container, podIP string, podIPs []string) (*pkgcontainer.RunContainerOptions, func(), error) {
 	opts := &pkgcontainer.RunContainerOptions{}
+	if cp.netPolicy != nil {
+		if err := cp.netPolicy.AddPod(pod, podIPs); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply network policy, detail-> %v", err)
+		}
+	}
 	if err := hook.Execute(&hook.GenerateContainerOptionContext{
 		Pod:          pod,
 		Container:    container,
@@ -589,6 +632,37 @@ func (cp *CRIProvider) GenerateRunContainerOptions(pod *v1.Pod, container *v1.Co
 	return opts, nil, nil
 }
 
+// GetPodEphemeralStorageUsage implements the kri.PodStorageProvider interface. It sums up the writable layers of
+// containers reported by runtime, the logs and the data directory of pod.
+func (cp *CRIProvider) GetPodEphemeralStorageUsage(ctx context.Context, pod *v1.Pod) (int64, error) {
+	stats, err := cp.runtimeService.ListContainerStats(ctx, &runtimeapi.ContainerStatsFilter{
+		LabelSelector: map[string]string{kubetypes.KubernetesPodUIDLabel: string(pod.UID)},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list container stats, detail-> %v", err)
+	}
+
+	var usage int64
+	for _, stat := range stats {
+		if stat.GetWritableLayer().GetUsedBytes() != nil {
+			usage += int64(stat.GetWritableLayer().GetUsedBytes().GetValue())
+		}
+	}
+
+	for _, dir := range []string{
+		cp.getPodDir(pod.UID),
+		kuberuntime.BuildPodLogsDirectory(cp.podsStdoutDirectory, pod.Namespace, pod.Name, pod.UID),
+	} {
+		size, err := paths.DirSize(dir)
+		if err != nil {
+			return 0, fmt.Errorf("failed to stat size of %q, detail-> %v", dir, err)
+		}
+		usage += size
+	}
+
+	return usage, nil
+}
+
 // GetPodDNS returns DNS settings for the pod.
 // This function is defined in pkgcontainer.RuntimeHelper interface so we
 // have to implement it.
@@ -836,6 +910,12 @@ func (cp *CRIProvider) CleanupPods(ctx context.Context, pods []*v1.Pod, runningP
 
 	cp.backOff.GC()
 
+	if cp.netPolicy != nil {
+		if err := cp.netPolicy.Resync(pods); err != nil {
+			nlog.Warnf("Failed to resync network policy: %v", err)
+		}
+	}
+
 	allPods := sets.NewString()
 	for _, pod := range pods {
 		allPods.Insert(string(pod.UID))
@@ -43,6 +43,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/agent/framework"
 	"github.com/secretflow/kuscia/pkg/agent/kri"
 	"github.com/secretflow/kuscia/pkg/agent/middleware/hook"
+	"github.com/secretflow/kuscia/pkg/agent/netpolicy"
 	"github.com/secretflow/kuscia/pkg/agent/provider/pod/kubebackend"
 	"github.com/secretflow/kuscia/pkg/agent/resource"
 	"github.com/secretflow/kuscia/pkg/agent/utils/format"
@@ -72,6 +73,7 @@ type K8sProviderDependence struct {
 	ResourceManager *resource.KubeResourceManager
 	K8sProviderCfg  *config.K8sProviderCfg
 	Recorder        record.EventRecorder
+	NetworkPolicy   *netpolicy.Config
 }
 
 type K8sProvider struct {
@@ -80,6 +82,7 @@ type K8sProvider struct {
 	namespace         string
 	bkNamespace       string
 	nodeName          string
+	nodeIP            string
 	podDNSConfig      *v1.PodDNSConfig
 	podDNSPolicy      string
 	resolveConfigData string
@@ -103,6 +106,8 @@ type K8sProvider struct {
 	leaderElector election.Elector
 	recorder      record.EventRecorder
 	logManager    *K8sLogManager
+	// networkPolicy restricts the egress of task pods by NetworkPolicy of backend cluster.
+	networkPolicy *netpolicy.Config
 }
 
 func NewK8sProvider(dep *K8sProviderDependence) (*K8sProvider, error) {
@@ -112,6 +117,7 @@ func NewK8sProvider(dep *K8sProviderDependence) (*K8sProvider, error) {
 		namespace:        dep.Namespace,
 		bkNamespace:      dep.K8sProviderCfg.Namespace,
 		nodeName:         dep.NodeName,
+		nodeIP:           dep.NodeIP,
 		podDNSPolicy:     dep.K8sProviderCfg.DNS.Policy,
 		podSyncHandler:   dep.PodSyncHandler,
 		resourceManager:  dep.ResourceManager,
@@ -120,6 +126,12 @@ func NewK8sProvider(dep *K8sProviderDependence) (*K8sProvider, error) {
 		affinitiesToAdd:  &v1.Affinity{},
 		runtimeClassName: dep.K8sProviderCfg.RuntimeClassName,
 		recorder:         dep.Recorder,
+		networkPolicy:    dep.NetworkPolicy,
+	}
+	if kp.networkPolicy.Enabled() {
+		if err := kp.networkPolicy.Check(); err != nil {
+			return nil, err
+		}
 	}
 
 	if kp.podDNSPolicy == "" {
@@ -371,6 +383,13 @@ func (kp *K8sProvider) SyncPod(ctx context.Context, pod *v1.Pod, podStatus *pkgc
 	// allow backend plugin to customize setting
 	kp.backendPlugin.PreSyncPod(newPod)
 
+	// the egress of pod must be restricted before it runs
+	if kp.networkPolicy.Selected(pod) {
+		if err := kp.applyNetworkPolicy(ctx, pod, newPod.Name); err != nil {
+			return err
+		}
+	}
+
 	_, err := kp.applyPod(ctx, newPod)
 	if err != nil {
 		return fmt.Errorf("failed to apply pod %v, detail-> %v", format.Pod(newPod), err)
@@ -379,6 +398,18 @@ func (kp *K8sProvider) SyncPod(ctx context.Context, pod *v1.Pod, podStatus *pkgc
 	return nil
 }
 
+func (kp *K8sProvider) applyNetworkPolicy(ctx context.Context, pod *v1.Pod, bkPodName string) error {
+	np := netpolicy.BuildNetworkPolicy(kp.networkPolicy, pod, kp.namespace, kp.nodeIP)
+	np.ObjectMeta = *kp.normalizeMeta(pod.UID, &np.ObjectMeta)
+	normalizeSubResourceMeta(&np.ObjectMeta, bkPodName)
+
+	_, err := kp.bkClient.NetworkingV1().NetworkPolicies(kp.bkNamespace).Create(ctx, np, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create network policy %v, detail-> %v", np.Name, err)
+	}
+	return nil
+}
+
 func (kp *K8sProvider) normalizeMeta(sourcePodUID types.UID, meta *metav1.ObjectMeta) *metav1.ObjectMeta {
 	newMeta := &metav1.ObjectMeta{
 		Labels:      map[string]string{},
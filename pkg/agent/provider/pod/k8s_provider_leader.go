@@ -20,7 +20,9 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/secretflow/kuscia/pkg/agent/resource"
@@ -176,6 +178,21 @@ func (kp *K8sProvider) cleanupSubResources(ctx context.Context) error {
 		}
 	}
 
+	if kp.networkPolicy.Enabled() {
+		networkPolicies, err := kp.bkClient.NetworkingV1().NetworkPolicies(kp.bkNamespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(labels.Set{common.LabelNodeNamespace: kp.namespace}).String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list network policy, detail-> %v", err)
+		}
+		for i := range networkPolicies.Items {
+			np := &networkPolicies.Items[i]
+			if err := cleanupSubResource[*networkingv1.NetworkPolicy](ctx, np, kp.bkClient.CoreV1().Pods(kp.bkNamespace), kp.bkClient.NetworkingV1().NetworkPolicies(kp.bkNamespace)); err != nil {
+				nlog.Warnf("Failed to cleanup network policy %q: %v", np.Name, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -33,6 +33,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/agent/config"
 	pkgcontainer "github.com/secretflow/kuscia/pkg/agent/container"
 	frameworktest "github.com/secretflow/kuscia/pkg/agent/framework/testing"
+	"github.com/secretflow/kuscia/pkg/agent/netpolicy"
 	"github.com/secretflow/kuscia/pkg/agent/resource"
 	resourcetest "github.com/secretflow/kuscia/pkg/agent/resource/testing"
 	"github.com/secretflow/kuscia/pkg/common"
@@ -208,6 +209,32 @@ func TestK8sProvider_SyncAndKillPod(t *testing.T) {
 	cancel()
 }
 
+func TestK8sProvider_SyncPodNetworkPolicy(t *testing.T) {
+	rm := resourcetest.FakeResourceManager("test-namespace")
+	kp := createTestK8sProvider(t, &config.K8sProviderCfg{Namespace: "bk-namespace"}, rm)
+	kp.nodeIP = "172.18.0.2"
+	kp.networkPolicy = &netpolicy.Config{Level: netpolicy.LevelTask}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       "abc",
+			Name:      "pod01",
+			Namespace: "test-namespace",
+			Labels:    map[string]string{common.LabelTaskUID: "task-uid"},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "ctr01", Image: "aa/bb:001"}}},
+	}
+	assert.NoError(t, kp.SyncPod(context.Background(), pod, nil, nil))
+
+	np, err := kp.bkClient.NetworkingV1().NetworkPolicies(kp.bkNamespace).Get(context.Background(), "pod01-pod01", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", np.Spec.PodSelector.MatchLabels[common.LabelPodUID])
+	assert.Equal(t, "test-namespace", np.Labels[common.LabelNodeNamespace])
+	assert.Equal(t, "pod01", np.Annotations[labelOwnerPodName])
+	assert.Equal(t, "task-uid", np.Spec.Egress[0].To[0].PodSelector.MatchLabels[common.LabelTaskUID])
+	assert.Equal(t, "172.18.0.2/32", np.Spec.Egress[1].To[0].IPBlock.CIDR)
+}
+
 func TestNormalizeSubResourceMeta(t *testing.T) {
 	resourceNameLimit = 10
 	tests := []struct {
@@ -193,6 +193,8 @@ func (f *containerRuntimeFactory) BuildPodProvider(nodeName string, eventRecorde
 		Runtime:          f.agentConfig.Provider.Runtime,
 		CRIProviderCfg:   &f.agentConfig.Provider.CRI,
 		RegistryCfg:      &f.agentConfig.Registry,
+		NetworkPolicy:    &f.agentConfig.NetworkPolicy,
+		SandboxRuntime:   &f.agentConfig.SandboxRuntime,
 	}
 
 	return pod.NewCRIProvider(podProviderDep)
@@ -246,6 +248,7 @@ func (f *k8sRuntimeFactory) BuildPodProvider(nodeName string, eventRecorder reco
 		ResourceManager: resourceManager,
 		K8sProviderCfg:  bkCfg,
 		Recorder:        eventRecorder,
+		NetworkPolicy:   &f.agentConfig.NetworkPolicy,
 	}
 
 	return pod.NewK8sProvider(podProviderDep)
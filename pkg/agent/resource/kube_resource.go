@@ -64,7 +64,7 @@ func (rm *KubeResourceManager) GetSecret(name string) (*v1.Secret, error) {
 		return rm.kubeClient.CoreV1().Secrets(rm.namespace).Get(context.Background(), name, metav1.GetOptions{})
 	}
 
-	return secret, nil
+	return secret, err
 }
 
 func (rm *KubeResourceManager) GetPod(name string) (*v1.Pod, error) {
@@ -15,15 +15,20 @@
 package resource
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	utilstrings "k8s.io/utils/strings"
 
+	"github.com/secretflow/kuscia/pkg/agent/local/runtime/process/mount"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/paths"
 )
@@ -32,6 +37,11 @@ const (
 	hostPathPluginName  = "kubernetes.io/hostpath"
 	configMapPluginName = "kubernetes.io/configmap"
 	secretPluginName    = "kubernetes.io/secret"
+	projectedPluginName = "kubernetes.io/projected"
+
+	// tmpfsOptions keeps the volume directory accessible to the non-root users in container,
+	// the mode of each file is set separately.
+	tmpfsOptions = "mode=0755"
 )
 
 type VolumeHelper interface {
@@ -57,6 +67,10 @@ type VolumeManager struct {
 
 	podVolumeMap     map[types.UID]VolumeMap
 	podVolumeMapLock sync.RWMutex
+
+	// newMounter creates the mounter of tmpfs under the volumes dir of pod, the files of configmap and secret
+	// volumes are written to disk if it's nil or tmpfs is unable to mount.
+	newMounter func(root string) (mount.Mounter, error)
 }
 
 func NewVolumeManager(rm *KubeResourceManager, volumeHelper VolumeHelper) *VolumeManager {
@@ -65,6 +79,9 @@ func NewVolumeManager(rm *KubeResourceManager, volumeHelper VolumeHelper) *Volum
 		podVolumeMap:    make(map[types.UID]VolumeMap),
 		volumeHelper:    volumeHelper,
 	}
+	if runtime.GOOS == "linux" {
+		vs.newMounter = mount.NewSysMounter
+	}
 
 	return vs
 }
@@ -86,9 +103,11 @@ func (vm *VolumeManager) MountVolumesForPod(pod *v1.Pod) error {
 		case volume.HostPath != nil:
 			volumeInfo, err = vm.mountHostPath(volume.HostPath)
 		case volume.ConfigMap != nil:
-			volumeInfo, err = vm.mountConfigMap(pod, volume.ConfigMap)
+			volumeInfo, err = vm.mountConfigMap(pod, volume.Name, volume.ConfigMap)
 		case volume.Secret != nil:
-			volumeInfo, err = vm.mountSecret(pod, volume.Secret)
+			volumeInfo, err = vm.mountSecret(pod, volume.Name, volume.Secret)
+		case volume.Projected != nil:
+			volumeInfo, err = vm.mountProjected(pod, volume.Name, volume.Projected)
 		default:
 			err = fmt.Errorf("volume source %s not supported", volume.Name)
 		}
@@ -113,6 +132,8 @@ func (vm *VolumeManager) UnmountVolumesForPod(podUID types.UID) {
 	vm.podVolumeMapLock.Lock()
 	defer vm.podVolumeMapLock.Unlock()
 	delete(vm.podVolumeMap, podUID)
+
+	vm.unmountTmpfs(podUID)
 }
 
 func (vm *VolumeManager) GetMountedVolumesForPod(podUID types.UID) VolumeMap {
@@ -163,6 +184,49 @@ func (vm *VolumeManager) mountHostPath(hostPath *v1.HostPathVolumeSource) (*Volu
 	}, nil
 }
 
+// mountTmpfs mounts tmpfs on the volume dir so that the content of configmaps and secrets never hits the disk.
+func (vm *VolumeManager) mountTmpfs(podUID types.UID, volumeDir string) {
+	if err := paths.EnsureDirectory(volumeDir, true); err != nil {
+		nlog.Warnf("Create volume dir %q fail, detail-> %v", volumeDir, err)
+		return
+	}
+	if vm.newMounter == nil {
+		return
+	}
+
+	root := vm.volumeHelper.GetPodVolumesDir(podUID)
+	mounter, err := vm.newMounter(root)
+	if err == nil {
+		var target string
+		if target, err = filepath.Rel(root, volumeDir); err == nil {
+			err = mounter.MountIfNotMounted("tmpfs", target, "tmpfs", tmpfsOptions)
+		}
+	}
+	if err != nil {
+		nlog.Warnf("Mount tmpfs on %q fail, the volume is written to disk, detail-> %v", volumeDir, err)
+	}
+}
+
+func (vm *VolumeManager) unmountTmpfs(podUID types.UID) {
+	if vm.newMounter == nil {
+		return
+	}
+
+	root := vm.volumeHelper.GetPodVolumesDir(podUID)
+	if exists, err := paths.CheckExists(paths.CheckSymlinkOnly, root); err != nil || !exists {
+		return
+	}
+	mounter, err := vm.newMounter(root)
+	if err == nil {
+		err = mounter.UmountRoot()
+	}
+	if err != nil {
+		nlog.Warnf("Umount tmpfs under %q fail, detail-> %v", root, err)
+	}
+}
+
+// writeFile writes the data atomically and sets the mode regardless of umask. The file is left untouched
+// if neither the data nor the mode changes, so that frequent pod syncs don't churn the volume.
 func (vm *VolumeManager) writeFile(path string, data []byte, mode int32) error {
 	dirName := filepath.Dir(path)
 	if err := paths.EnsureDirectory(dirName, true); err != nil {
@@ -170,7 +234,31 @@ func (vm *VolumeManager) writeFile(path string, data []byte, mode int32) error {
 		return err
 	}
 
-	if err := os.WriteFile(path, data, os.FileMode(mode)); err != nil {
+	fileMode := os.FileMode(mode) & os.ModePerm
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() == fileMode {
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, data) {
+			return nil
+		}
+	}
+
+	tmpFile, err := os.CreateTemp(dirName, ".tmp-"+filepath.Base(path))
+	if err != nil {
+		nlog.Errorf("Create temp file fail, dir=%s, detail-> %s", dirName, err.Error())
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpFile.Name(), fileMode)
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), path)
+	}
+	if err != nil {
 		nlog.Errorf("Write file fail, path=%s, mode=%v, detail-> %s", path, mode, err.Error())
 		return err
 	}
@@ -179,45 +267,77 @@ func (vm *VolumeManager) writeFile(path string, data []byte, mode int32) error {
 	return nil
 }
 
+// removeStaleFiles removes the files which are no longer projected into the volume, e.g. the key is
+// deleted from the configmap.
+func (vm *VolumeManager) removeStaleFiles(targetPath string, written map[string]bool) error {
+	return filepath.WalkDir(targetPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || written[path] {
+			return nil
+		}
+		nlog.Infof("Remove stale file %q from volume", path)
+		return os.Remove(path)
+	})
+}
+
+// literalSource is the content projected from a configmap or secret.
+type literalSource struct {
+	items      []v1.KeyToPath
+	optional   *bool
+	literalMap map[string][]byte
+}
+
 // mountLiteralVolume dumps content in literalMap to file
-func (vm *VolumeManager) mountLiteralVolume(targetPath string,
-	defaultMode *int32, items []v1.KeyToPath, optional *bool, literalMap map[string][]byte) error {
+func (vm *VolumeManager) mountLiteralVolume(targetPath string, defaultMode *int32, sources ...literalSource) error {
 	// config maps are always mounted readonly, keep same with k8s
 	fileMode := int32(0644) // k8s default
 	if defaultMode != nil {
 		fileMode = *defaultMode
 	}
 
+	files := map[string]bool{}
+	write := func(relPath string, data []byte, mode int32) error {
+		if err := validateProjectedPath(relPath); err != nil {
+			return err
+		}
+		path := filepath.Join(targetPath, relPath)
+		files[path] = true
+		return vm.writeFile(path, data, mode)
+	}
+
 	// dump config map data to file
-	if len(items) == 0 {
-		// If Items unspecified, each key-value pair in the Data field of the referenced
-		// ConfigMap will be projected into the volume as a file whose name is the
-		// key and content is the value.
-		for k, v := range literalMap {
-			err := vm.writeFile(filepath.Join(targetPath, k), v, fileMode)
-			if err != nil {
-				return fmt.Errorf("write data to file fail, key=%s, detail-> %s", k, err.Error())
+	for _, source := range sources {
+		if len(source.items) == 0 {
+			// If Items unspecified, each key-value pair in the Data field of the referenced
+			// ConfigMap will be projected into the volume as a file whose name is the
+			// key and content is the value.
+			for k, v := range source.literalMap {
+				if err := write(k, v, fileMode); err != nil {
+					return fmt.Errorf("write data to file fail, key=%s, detail-> %s", k, err.Error())
+				}
 			}
+			continue
 		}
-	} else {
+
 		// If specified, the listed keys will be
 		// projected into the specified paths, and unlisted keys will not be
 		// present. If a key is specified which is not present in the ConfigMap,
 		// the volume setup will error unless it is marked optional.
-		for _, item := range items {
-			if data, exist := literalMap[item.Key]; exist {
+		for _, item := range source.items {
+			if data, exist := source.literalMap[item.Key]; exist {
 				mode := fileMode
 				if item.Mode != nil {
 					mode = *item.Mode
 				}
 
-				err := vm.writeFile(filepath.Join(targetPath, item.Path), data, mode)
-				if err != nil {
+				if err := write(item.Path, data, mode); err != nil {
 					return fmt.Errorf("write data to file failed, key=%s, path=%s, detail-> %s", item.Key, item.Path, err.Error())
 				}
 			} else {
 				// ref to a nonexistent key
-				if optional != nil && *optional {
+				if source.optional != nil && *source.optional {
 					continue
 				} else {
 					return fmt.Errorf("volumeMount ref to a nonexistent key %s", item.Key)
@@ -226,17 +346,29 @@ func (vm *VolumeManager) mountLiteralVolume(targetPath string,
 		}
 	}
 
-	return nil
+	return vm.removeStaleFiles(targetPath, files)
 }
 
-func (vm *VolumeManager) mountConfigMap(pod *v1.Pod, volume *v1.ConfigMapVolumeSource) (*VolumeInfo, error) {
-	var (
-		cmap *v1.ConfigMap
-		err  error
-	)
+func validateProjectedPath(relPath string) error {
+	if relPath == "" || filepath.IsAbs(relPath) {
+		return fmt.Errorf("invalid path %q, must be a non-empty relative path", relPath)
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if elem == ".." {
+			return fmt.Errorf("invalid path %q, must not contain '..'", relPath)
+		}
+	}
+	return nil
+}
 
-	cmap, err = vm.ResourceManager.GetConfigMap(volume.Name)
+// configMapSource builds the content projected from the configmap, the configmap not found is
+// treated as empty if it's optional.
+func (vm *VolumeManager) configMapSource(name string, items []v1.KeyToPath, optional *bool) (*literalSource, error) {
+	cmap, err := vm.ResourceManager.GetConfigMap(name)
 	if err != nil {
+		if k8serrors.IsNotFound(err) && optional != nil && *optional {
+			return &literalSource{items: items, optional: optional}, nil
+		}
 		return nil, err
 	}
 
@@ -244,21 +376,56 @@ func (vm *VolumeManager) mountConfigMap(pod *v1.Pod, volume *v1.ConfigMapVolumeS
 	dataMap := make(map[string][]byte)
 	for k, v := range cmap.Data {
 		if _, exist := dataMap[k]; exist {
-			return nil, fmt.Errorf("duplicate data key %s in config map %s", k, volume.Name)
+			return nil, fmt.Errorf("duplicate data key %s in config map %s", k, name)
 		}
 		dataMap[k] = []byte(v)
 	}
 	// The keys stored in BinaryData must not overlap with the ones in the Data field
 	for k, v := range cmap.BinaryData {
 		if _, exist := dataMap[k]; exist {
-			return nil, fmt.Errorf("duplicate binary data key %s in config map %s", k, volume.Name)
+			return nil, fmt.Errorf("duplicate binary data key %s in config map %s", k, name)
 		}
 		dataMap[k] = v
 	}
 
-	hostPath := vm.getPath(pod.UID, configMapPluginName, volume.Name)
+	return &literalSource{items: items, optional: optional, literalMap: dataMap}, nil
+}
+
+// secretSource builds the content projected from the secret, the secret not found is
+// treated as empty if it's optional.
+func (vm *VolumeManager) secretSource(name string, items []v1.KeyToPath, optional *bool) (*literalSource, error) {
+	secret, err := vm.ResourceManager.GetSecret(name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) && optional != nil && *optional {
+			return &literalSource{items: items, optional: optional}, nil
+		}
+		return nil, err
+	}
+
+	// build source map
+	dataMap := make(map[string][]byte)
+	for k, v := range secret.Data {
+		if _, exist := dataMap[k]; exist {
+			return nil, fmt.Errorf("duplicate data key %s in secret %s", k, name)
+		}
+		dataMap[k] = v // the value is auto decoded by master
+	}
+	// Ignore secret.StringData, it is provided as a write-only convenience method.
+	// and will never output when reading from the API.
+
+	return &literalSource{items: items, optional: optional, literalMap: dataMap}, nil
+}
+
+func (vm *VolumeManager) mountConfigMap(pod *v1.Pod, volumeName string, volume *v1.ConfigMapVolumeSource) (*VolumeInfo, error) {
+	source, err := vm.configMapSource(volume.Name, volume.Items, volume.Optional)
+	if err != nil {
+		return nil, err
+	}
+
+	hostPath := vm.getPath(pod.UID, configMapPluginName, volumeName)
+	vm.mountTmpfs(pod.UID, hostPath)
 
-	if err = vm.mountLiteralVolume(hostPath, volume.DefaultMode, volume.Items, volume.Optional, dataMap); err != nil {
+	if err = vm.mountLiteralVolume(hostPath, volume.DefaultMode, *source); err != nil {
 		return nil, fmt.Errorf("mount configmap volume %q failed, detail-> %v", volume.Name, err)
 	}
 
@@ -270,27 +437,54 @@ func (vm *VolumeManager) mountConfigMap(pod *v1.Pod, volume *v1.ConfigMapVolumeS
 	}, nil
 }
 
-func (vm *VolumeManager) mountSecret(pod *v1.Pod, volume *v1.SecretVolumeSource) (*VolumeInfo, error) {
-	secret, err := vm.ResourceManager.GetSecret(volume.SecretName)
+func (vm *VolumeManager) mountSecret(pod *v1.Pod, volumeName string, volume *v1.SecretVolumeSource) (*VolumeInfo, error) {
+	source, err := vm.secretSource(volume.SecretName, volume.Items, volume.Optional)
 	if err != nil {
 		return nil, err
 	}
 
-	// build source map
-	dataMap := make(map[string][]byte)
-	for k, v := range secret.Data {
-		if _, exist := dataMap[k]; exist {
-			return nil, fmt.Errorf("duplicate data key %s in secret %s", k, volume.SecretName)
+	hostPath := vm.getPath(pod.UID, secretPluginName, volumeName)
+	vm.mountTmpfs(pod.UID, hostPath)
+
+	if err = vm.mountLiteralVolume(hostPath, volume.DefaultMode, *source); err != nil {
+		return nil, fmt.Errorf("mount secret volume %q failed, detail-> %v", volume.SecretName, err)
+	}
+
+	return &VolumeInfo{
+		HostPath:       hostPath,
+		ReadOnly:       true,
+		Managed:        true,
+		SELinuxRelabel: true,
+	}, nil
+}
+
+// mountProjected projects the configmaps and secrets into the same volume dir, other sources are not supported.
+func (vm *VolumeManager) mountProjected(pod *v1.Pod, volumeName string, volume *v1.ProjectedVolumeSource) (*VolumeInfo, error) {
+	var sources []literalSource
+	for _, projection := range volume.Sources {
+		var (
+			source *literalSource
+			err    error
+		)
+		switch {
+		case projection.ConfigMap != nil:
+			source, err = vm.configMapSource(projection.ConfigMap.Name, projection.ConfigMap.Items, projection.ConfigMap.Optional)
+		case projection.Secret != nil:
+			source, err = vm.secretSource(projection.Secret.Name, projection.Secret.Items, projection.Secret.Optional)
+		default:
+			err = fmt.Errorf("only configmap and secret projections are supported")
 		}
-		dataMap[k] = v // the value is auto decoded by master
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, *source)
 	}
-	// Ignore secret.StringData, it is provided as a write-only convenience method.
-	// and will never output when reading from the API.
 
-	hostPath := vm.getPath(pod.UID, secretPluginName, volume.SecretName)
+	hostPath := vm.getPath(pod.UID, projectedPluginName, volumeName)
+	vm.mountTmpfs(pod.UID, hostPath)
 
-	if err = vm.mountLiteralVolume(hostPath, volume.DefaultMode, volume.Items, volume.Optional, dataMap); err != nil {
-		return nil, fmt.Errorf("mount secret volume %q failed, detail-> %v", volume.SecretName, err)
+	if err := vm.mountLiteralVolume(hostPath, volume.DefaultMode, sources...); err != nil {
+		return nil, fmt.Errorf("mount projected volume %q failed, detail-> %v", volumeName, err)
 	}
 
 	return &VolumeInfo{
@@ -0,0 +1,235 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/secretflow/kuscia/pkg/agent/local/runtime/process/mount"
+)
+
+type fakeVolumeHelper struct {
+	rootDir string
+}
+
+func (h *fakeVolumeHelper) GetPodVolumesDir(podUID types.UID) string {
+	return filepath.Join(h.rootDir, string(podUID), "volumes")
+}
+
+type fakeMounter struct {
+	mounted   []string
+	unmounted bool
+}
+
+func (m *fakeMounter) MountIfNotMounted(device, target, mType, options string) error {
+	m.mounted = append(m.mounted, target)
+	return nil
+}
+
+func (m *fakeMounter) Mount(device, target, mType, options string) error {
+	return nil
+}
+
+func (m *fakeMounter) UmountRoot() error {
+	m.unmounted = true
+	return nil
+}
+
+func newTestVolumeManager(t *testing.T, objects ...runtime.Object) (*VolumeManager, *fakeMounter) {
+	kubeClient := fake.NewSimpleClientset(objects...)
+	factory := informers.NewSharedInformerFactory(kubeClient, 0)
+	secretInformer := factory.Core().V1().Secrets()
+	configMapInformer := factory.Core().V1().ConfigMaps()
+	podInformer := factory.Core().V1().Pods()
+	go secretInformer.Informer().Run(wait.NeverStop)
+	go configMapInformer.Informer().Run(wait.NeverStop)
+	go podInformer.Informer().Run(wait.NeverStop)
+	assert.True(t, cache.WaitForCacheSync(wait.NeverStop, secretInformer.Informer().HasSynced,
+		configMapInformer.Informer().HasSynced, podInformer.Informer().HasSynced))
+
+	rm := NewResourceManager(kubeClient, "alice", podInformer.Lister().Pods("alice"),
+		secretInformer.Lister().Secrets("alice"), configMapInformer.Lister().ConfigMaps("alice"))
+	vm := NewVolumeManager(rm, &fakeVolumeHelper{rootDir: t.TempDir()})
+	mounter := &fakeMounter{}
+	vm.newMounter = func(root string) (mount.Mounter, error) { return mounter, nil }
+	return vm, mounter
+}
+
+func assertFile(t *testing.T, path, content string, mode os.FileMode) {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, mode, info.Mode().Perm())
+}
+
+func TestMountVolumesForPod(t *testing.T) {
+	optional := true
+	secretMode := int32(0400)
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "conf", Namespace: "alice"},
+		Data:       map[string]string{"app.yaml": "a: 1", "log.yaml": "level: info"},
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "alice"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}
+	vm, mounter := newTestVolumeManager(t, cm, secret)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "alice", UID: "uid"},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "conf", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: "conf"},
+				}}},
+				{Name: "conf-app", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: "conf"},
+					Items:                []v1.KeyToPath{{Key: "app.yaml", Path: "etc/app.yaml"}},
+				}}},
+				{Name: "token", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{
+					SecretName:  "token",
+					DefaultMode: &secretMode,
+				}}},
+				{Name: "missing", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{
+					SecretName: "missing",
+					Optional:   &optional,
+				}}},
+				{Name: "projected", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{ConfigMap: &v1.ConfigMapProjection{
+							LocalObjectReference: v1.LocalObjectReference{Name: "conf"},
+							Items:                []v1.KeyToPath{{Key: "log.yaml", Path: "log.yaml"}},
+						}},
+						{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "token"}}},
+					},
+				}}},
+			},
+		},
+	}
+
+	assert.NoError(t, vm.MountVolumesForPod(pod))
+	volumes := vm.GetMountedVolumesForPod(pod.UID)
+	assert.Len(t, volumes, 5)
+	assert.Len(t, mounter.mounted, 5)
+	for _, volume := range volumes {
+		assert.True(t, volume.ReadOnly)
+	}
+
+	assertFile(t, filepath.Join(volumes["conf"].HostPath, "app.yaml"), "a: 1", 0644)
+	assertFile(t, filepath.Join(volumes["conf"].HostPath, "log.yaml"), "level: info", 0644)
+	assertFile(t, filepath.Join(volumes["conf-app"].HostPath, "etc/app.yaml"), "a: 1", 0644)
+	assert.NoFileExists(t, filepath.Join(volumes["conf-app"].HostPath, "log.yaml"))
+	assertFile(t, filepath.Join(volumes["token"].HostPath, "token"), "secret-token", 0400)
+	assert.DirExists(t, volumes["missing"].HostPath)
+	assertFile(t, filepath.Join(volumes["projected"].HostPath, "log.yaml"), "level: info", 0644)
+	assertFile(t, filepath.Join(volumes["projected"].HostPath, "token"), "secret-token", 0644)
+
+	vm.UnmountVolumesForPod(pod.UID)
+	assert.True(t, mounter.unmounted)
+	assert.Nil(t, vm.GetMountedVolumesForPod(pod.UID))
+}
+
+func TestMountVolumesForPod_Error(t *testing.T) {
+	vm, _ := newTestVolumeManager(t, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "conf", Namespace: "alice"},
+		Data:       map[string]string{"app.yaml": "a: 1"},
+	})
+
+	tests := []struct {
+		name   string
+		volume v1.VolumeSource
+	}{
+		{
+			name:   "missing secret",
+			volume: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "missing"}},
+		},
+		{
+			name: "missing key",
+			volume: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "conf"},
+				Items:                []v1.KeyToPath{{Key: "missing", Path: "missing"}},
+			}},
+		},
+		{
+			name: "path escapes volume",
+			volume: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "conf"},
+				Items:                []v1.KeyToPath{{Key: "app.yaml", Path: "../app.yaml"}},
+			}},
+		},
+		{
+			name: "unsupported projection",
+			volume: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{{DownwardAPI: &v1.DownwardAPIProjection{}}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "alice", UID: "uid"},
+				Spec:       v1.PodSpec{Volumes: []v1.Volume{{Name: "v", VolumeSource: tt.volume}}},
+			}
+			assert.Error(t, vm.MountVolumesForPod(pod))
+		})
+	}
+}
+
+func TestMountVolumesForPod_Update(t *testing.T) {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "conf", Namespace: "alice"},
+		Data:       map[string]string{"app.yaml": "a: 1", "log.yaml": "level: info"},
+	}
+	vm, _ := newTestVolumeManager(t, cm)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "alice", UID: "uid"},
+		Spec: v1.PodSpec{Volumes: []v1.Volume{{Name: "conf", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+			LocalObjectReference: v1.LocalObjectReference{Name: "conf"},
+		}}}}},
+	}
+	assert.NoError(t, vm.MountVolumesForPod(pod))
+	hostPath := vm.GetMountedVolumesForPod(pod.UID)["conf"].HostPath
+
+	// key removed from configmap is removed from volume
+	cm = cm.DeepCopy()
+	delete(cm.Data, "log.yaml")
+	cm.Data["app.yaml"] = "a: 2"
+	_, err := vm.ResourceManager.kubeClient.CoreV1().ConfigMaps("alice").Update(context.Background(), cm, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		got, err := vm.ResourceManager.GetConfigMap("conf")
+		return err == nil && got.Data["app.yaml"] == "a: 2"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, vm.MountVolumesForPod(pod))
+	assertFile(t, filepath.Join(hostPath, "app.yaml"), "a: 2", 0644)
+	assert.NoFileExists(t, filepath.Join(hostPath, "log.yaml"))
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sandboxruntime selects the sandboxed runtime, i.e. gVisor or Kata Containers, to run the pods of domain or
+// of AppImages, which provides stronger isolation than runc for partner-provided engine images.
+package sandboxruntime
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// Runtime handlers of containerd, they are configured in containerd.toml.
+const (
+	// HandlerRunc is the default runtime.
+	HandlerRunc = "runc"
+	// HandlerGVisor runs pods in the user-space kernel of gVisor.
+	HandlerGVisor = "runsc"
+	// HandlerKata runs pods in lightweight virtual machines of Kata Containers.
+	HandlerKata = "kata"
+)
+
+// requirement is what the host needs to run a handler.
+type requirement struct {
+	name     string
+	binaries []string
+	devices  []string
+}
+
+var requirements = map[string]requirement{
+	HandlerGVisor: {name: "gVisor", binaries: []string{"containerd-shim-runsc-v1", "runsc"}},
+	HandlerKata:   {name: "Kata Containers", binaries: []string{"containerd-shim-kata-v2"}, devices: []string{"/dev/kvm"}},
+}
+
+// Config is the sandboxed runtime of pods in config file.
+type Config struct {
+	// Handler is the runtime handler of all pods of domain, runc, runsc or kata, default runc.
+	Handler string `yaml:"handler,omitempty"`
+	// AppImageHandlers overrides the handler of pods launched from the AppImages, the key is the name of AppImage.
+	AppImageHandlers map[string]string `yaml:"appImageHandlers,omitempty"`
+}
+
+// Enabled returns whether any pod runs in a sandboxed runtime.
+func (c *Config) Enabled() bool {
+	if c == nil {
+		return false
+	}
+	if c.Handler != "" && c.Handler != HandlerRunc {
+		return true
+	}
+	for _, handler := range c.AppImageHandlers {
+		if handler != HandlerRunc {
+			return true
+		}
+	}
+	return false
+}
+
+// Check checks the config.
+func (c *Config) Check() error {
+	if err := checkHandler(c.Handler); err != nil {
+		return err
+	}
+	for appImage, handler := range c.AppImageHandlers {
+		if handler == "" {
+			return fmt.Errorf("runtime handler of AppImage %s is empty", appImage)
+		}
+		if err := checkHandler(handler); err != nil {
+			return fmt.Errorf("%v, AppImage=%s", err, appImage)
+		}
+	}
+	return nil
+}
+
+func checkHandler(handler string) error {
+	switch handler {
+	case "", HandlerRunc, HandlerGVisor, HandlerKata:
+		return nil
+	default:
+		return fmt.Errorf("runtime handler %q is unknown, it must be %s, %s or %s", handler, HandlerRunc, HandlerGVisor, HandlerKata)
+	}
+}
+
+// Detect returns why the host can't run the handler, it returns nil if the host supports the handler.
+func Detect(handler string) error {
+	req, ok := requirements[handler]
+	if !ok {
+		return nil
+	}
+	for _, binary := range req.binaries {
+		if _, err := exec.LookPath(binary); err != nil {
+			return fmt.Errorf("runtime handler %s (%s) isn't supported by the host, %s is not found in PATH", handler, req.name, binary)
+		}
+	}
+	for _, device := range req.devices {
+		if _, err := os.Stat(device); err != nil {
+			return fmt.Errorf("runtime handler %s (%s) isn't supported by the host, %s is not available, hardware virtualization may be disabled", handler, req.name, device)
+		}
+	}
+	return nil
+}
+
+// Selector selects the runtime handler of pods.
+type Selector struct {
+	config *Config
+	// unsupported are the handlers which the host can't run.
+	unsupported map[string]error
+}
+
+// NewSelector detects the handlers in config. It fails if the handler of domain isn't supported, because no pod could
+// run, the pods of AppImages whose handlers aren't supported fail to start.
+func NewSelector(config *Config, detect func(handler string) error) (*Selector, error) {
+	if err := config.Check(); err != nil {
+		return nil, err
+	}
+	s := &Selector{config: config, unsupported: map[string]error{}}
+
+	handlers := map[string][]string{}
+	if config.Handler != "" {
+		handlers[config.Handler] = nil
+	}
+	for appImage, handler := range config.AppImageHandlers {
+		handlers[handler] = append(handlers[handler], appImage)
+	}
+	for handler, appImages := range handlers {
+		err := detect(handler)
+		if err == nil {
+			nlog.Infof("Runtime handler %s is available", handler)
+			continue
+		}
+		if handler == config.Handler {
+			return nil, err
+		}
+		sort.Strings(appImages)
+		nlog.Warnf("%v, pods of AppImages %v can't start", err, appImages)
+		s.unsupported[handler] = err
+	}
+	return s, nil
+}
+
+// RuntimeHandler returns the runtime handler of pod, the empty handler means the default runtime of containerd.
+func (s *Selector) RuntimeHandler(pod *v1.Pod) (string, error) {
+	handler := s.config.Handler
+	if appImage := pod.Annotations[common.AppImageAnnotationKey]; appImage != "" {
+		if h, ok := s.config.AppImageHandlers[appImage]; ok {
+			handler = h
+		}
+	}
+	if err, ok := s.unsupported[handler]; ok {
+		return "", err
+	}
+	if handler == HandlerRunc {
+		return "", nil
+	}
+	return handler, nil
+}
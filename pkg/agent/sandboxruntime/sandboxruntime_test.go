@@ -0,0 +1,87 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandboxruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func makeTestPod(appImage string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "task-1-0", Namespace: "alice"}}
+	if appImage != "" {
+		pod.Annotations = map[string]string{common.AppImageAnnotationKey: appImage}
+	}
+	return pod
+}
+
+func TestCheck(t *testing.T) {
+	assert.False(t, (&Config{}).Enabled())
+	assert.False(t, (&Config{Handler: HandlerRunc, AppImageHandlers: map[string]string{"sf": HandlerRunc}}).Enabled())
+	assert.True(t, (&Config{AppImageHandlers: map[string]string{"partner": HandlerKata}}).Enabled())
+
+	assert.NoError(t, (&Config{Handler: HandlerGVisor, AppImageHandlers: map[string]string{"sf": HandlerRunc}}).Check())
+	assert.Error(t, (&Config{Handler: "crun"}).Check())
+	assert.Error(t, (&Config{AppImageHandlers: map[string]string{"sf": ""}}).Check())
+}
+
+func TestSelector(t *testing.T) {
+	detect := func(handler string) error {
+		if handler == HandlerKata {
+			return fmt.Errorf("/dev/kvm is not available")
+		}
+		return nil
+	}
+
+	config := &Config{
+		Handler:          HandlerGVisor,
+		AppImageHandlers: map[string]string{"secretflow": HandlerRunc, "partner": HandlerKata},
+	}
+	s, err := NewSelector(config, detect)
+	assert.NoError(t, err)
+
+	handler, err := s.RuntimeHandler(makeTestPod(""))
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerGVisor, handler)
+	handler, err = s.RuntimeHandler(makeTestPod("secretflow"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", handler)
+	_, err = s.RuntimeHandler(makeTestPod("partner"))
+	assert.EqualError(t, err, "/dev/kvm is not available")
+
+	// no pod could run if the handler of domain isn't supported
+	_, err = NewSelector(&Config{Handler: HandlerKata}, detect)
+	assert.Error(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	assert.NoError(t, Detect(HandlerRunc))
+	assert.ErrorContains(t, Detect(HandlerGVisor), "containerd-shim-runsc-v1 is not found in PATH")
+
+	for _, binary := range []string{"containerd-shim-runsc-v1", "runsc"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, binary), []byte("#!/bin/sh\n"), 0755))
+	}
+	assert.NoError(t, Detect(HandlerGVisor))
+}
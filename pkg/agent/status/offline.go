@@ -0,0 +1,135 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/secretflow/kuscia/pkg/utils/localstore"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// StatusStore persists pod statuses which failed to sync to apiserver, so that they survive agent
+// restarts while the link to master is down. localstore.Store implements it.
+type StatusStore interface {
+	Put(ctx context.Context, kind, key string, value []byte) error
+	Delete(ctx context.Context, kind, key string) error
+	List(ctx context.Context, kind string) (map[string][]byte, error)
+}
+
+// offlineStatus is the persisted record of a pod status pending to be synced.
+type offlineStatus struct {
+	PodName      string       `json:"podName"`
+	PodNamespace string       `json:"podNamespace"`
+	Version      uint64       `json:"version"`
+	Status       v1.PodStatus `json:"status"`
+}
+
+// loadOfflineStatuses restores the pod statuses which were not synced before the agent exited.
+func (m *manager) loadOfflineStatuses() {
+	if m.store == nil {
+		return
+	}
+
+	records, err := m.store.List(context.Background(), localstore.KindPodStatus)
+	if err != nil {
+		nlog.Warnf("Failed to load offline pod statuses: %v", err)
+		return
+	}
+
+	for key, value := range records {
+		record := offlineStatus{}
+		if err := json.Unmarshal(value, &record); err != nil {
+			nlog.Warnf("Failed to parse offline status of pod %q, drop it: %v", key, err)
+			m.forgetOfflineStatus(types.UID(key))
+			continue
+		}
+		m.offlineStatuses[types.UID(key)] = versionedPodStatus{
+			status:       record.Status,
+			version:      record.Version,
+			podName:      record.PodName,
+			podNamespace: record.PodNamespace,
+		}
+	}
+	if len(m.offlineStatuses) > 0 {
+		nlog.Infof("Loaded %d offline pod statuses, they will be synced once apiserver is reachable", len(m.offlineStatuses))
+	}
+}
+
+// saveOfflineStatus persists the status which failed to sync. It must only be called from the sync thread.
+func (m *manager) saveOfflineStatus(uid types.UID, status versionedPodStatus) {
+	if m.store == nil {
+		return
+	}
+	if saved, ok := m.offlineStatuses[uid]; ok && saved.version == status.version {
+		return
+	}
+
+	value, err := json.Marshal(offlineStatus{
+		PodName:      status.podName,
+		PodNamespace: status.podNamespace,
+		Version:      status.version,
+		Status:       status.status,
+	})
+	if err != nil {
+		nlog.Warnf("Failed to marshal offline status of pod %q: %v", uid, err)
+		return
+	}
+	if err := m.store.Put(context.Background(), localstore.KindPodStatus, string(uid), value); err != nil {
+		nlog.Warnf("Failed to save offline status of pod %q: %v", uid, err)
+		return
+	}
+	m.offlineStatuses[uid] = status
+}
+
+// forgetOfflineStatus removes the persisted status once it's synced or obsolete. It must only be called from the sync thread.
+func (m *manager) forgetOfflineStatus(uid types.UID) {
+	if m.store == nil {
+		return
+	}
+	if _, ok := m.offlineStatuses[uid]; !ok {
+		return
+	}
+	if err := m.store.Delete(context.Background(), localstore.KindPodStatus, string(uid)); err != nil {
+		nlog.Warnf("Failed to delete offline status of pod %q: %v", uid, err)
+		return
+	}
+	delete(m.offlineStatuses, uid)
+}
+
+// syncOfflineStatuses syncs the restored statuses of pods which have no live status. The live status
+// of a pod is always newer than the restored one, so the restored one is only used when the pod is
+// unknown to the agent, e.g. it finished while the agent was restarting offline.
+func (m *manager) syncOfflineStatuses() {
+	var pending []podStatusSyncRequest
+	func() {
+		m.podStatusesLock.RLock()
+		defer m.podStatusesLock.RUnlock()
+		for uid, status := range m.offlineStatuses {
+			if _, live := m.podStatuses[uid]; !live {
+				pending = append(pending, podStatusSyncRequest{uid, status})
+			}
+		}
+	}()
+
+	for _, req := range pending {
+		nlog.Debugf("Status Manager: sync offline status of pod %q, statusVersion=%v", req.podUID, req.status.version)
+		m.syncPod(req.podUID, req.status)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	statustest "k8s.io/kubernetes/pkg/kubelet/status/testing"
+
+	pkgpod "github.com/secretflow/kuscia/pkg/agent/pod"
+	podtest "github.com/secretflow/kuscia/pkg/agent/pod/testing"
+	"github.com/secretflow/kuscia/pkg/utils/localstore"
+)
+
+type memStatusStore struct {
+	values map[string]map[string][]byte
+}
+
+func newMemStatusStore() *memStatusStore {
+	return &memStatusStore{values: map[string]map[string][]byte{}}
+}
+
+func (s *memStatusStore) Put(ctx context.Context, kind, key string, value []byte) error {
+	if s.values[kind] == nil {
+		s.values[kind] = map[string][]byte{}
+	}
+	s.values[kind][key] = value
+	return nil
+}
+
+func (s *memStatusStore) Delete(ctx context.Context, kind, key string) error {
+	delete(s.values[kind], key)
+	return nil
+}
+
+func (s *memStatusStore) List(ctx context.Context, kind string) (map[string][]byte, error) {
+	return s.values[kind], nil
+}
+
+func newTestManagerWithStore(client *fake.Clientset, store StatusStore) *manager {
+	podManager := pkgpod.NewBasicPodManager(podtest.NewFakeMirrorClient())
+	podManager.AddPod(getTestPod())
+	m := NewManagerWithStore(client, podManager, &statustest.FakePodDeletionSafetyProvider{}, store).(*manager)
+	m.loadOfflineStatuses()
+	return m
+}
+
+func TestOfflineStatusSyncedAfterRestart(t *testing.T) {
+	pod := getTestPod()
+	store := newMemStatusStore()
+
+	offline := true
+	client := fake.NewSimpleClientset(pod)
+	client.PrependReactor("*", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if offline {
+			return true, nil, fmt.Errorf("master is unreachable")
+		}
+		return false, nil, nil
+	})
+
+	m := newTestManagerWithStore(client, store)
+	status := getRandomPodStatus()
+	status.Phase = v1.PodSucceeded
+	m.SetPodStatus(pod, status)
+	verifyActions(t, m, []core.Action{getAction()})
+	assert.Len(t, store.values[localstore.KindPodStatus], 1)
+
+	// The agent restarts and the pod is gone locally, the persisted status must still be synced.
+	offline = false
+	client.ClearActions()
+	m = newTestManagerWithStore(client, store)
+	m.podManager.DeletePod(pod)
+	assert.Len(t, m.offlineStatuses, 1)
+	m.syncBatch()
+	verifyActions(t, m, []core.Action{getAction(), patchAction()})
+	assert.Empty(t, store.values[localstore.KindPodStatus])
+	assert.Empty(t, m.offlineStatuses)
+
+	updated, err := client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, v1.PodSucceeded, updated.Status.Phase)
+}
+
+func TestStaleStatusNotOverrideTerminatedPod(t *testing.T) {
+	pod := getTestPod()
+	pod.Status.Phase = v1.PodFailed
+	store := newMemStatusStore()
+	client := fake.NewSimpleClientset(pod)
+
+	m := newTestManagerWithStore(client, store)
+	status := getRandomPodStatus()
+	status.Phase = v1.PodRunning
+	m.offlineStatuses[pod.UID] = versionedPodStatus{status: status, version: 1, podName: pod.Name, podNamespace: pod.Namespace}
+	assert.NoError(t, store.Put(context.Background(), localstore.KindPodStatus, string(pod.UID), []byte("{}")))
+
+	m.syncPod(pod.UID, m.offlineStatuses[pod.UID])
+	verifyActions(t, m, []core.Action{getAction()})
+	assert.Empty(t, store.values[localstore.KindPodStatus])
+	assert.Empty(t, m.offlineStatuses)
+}
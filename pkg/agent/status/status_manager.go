@@ -74,6 +74,10 @@ type manager struct {
 	// apiStatusVersions must only be accessed from the sync thread.
 	apiStatusVersions map[kubetypes.MirrorPodUID]uint64
 	podDeletionSafety PodDeletionSafetyProvider
+	// store persists statuses which failed to sync, it's nil if offline tolerance isn't enabled.
+	store StatusStore
+	// offlineStatuses are the statuses persisted in store, they must only be accessed from the sync thread.
+	offlineStatuses map[types.UID]versionedPodStatus
 }
 
 // PodStatusProvider knows how to provide status for a pod. It's intended to be used by other components
@@ -124,6 +128,12 @@ const syncPeriod = 10 * time.Second
 
 // NewManager returns a functional Manager.
 func NewManager(kubeClient clientset.Interface, podManager pkgpod.Manager, podDeletionSafety PodDeletionSafetyProvider) Manager {
+	return NewManagerWithStore(kubeClient, podManager, podDeletionSafety, nil)
+}
+
+// NewManagerWithStore returns a Manager which persists the statuses failed to sync into store, and
+// syncs them once the apiserver is reachable again, even across agent restarts.
+func NewManagerWithStore(kubeClient clientset.Interface, podManager pkgpod.Manager, podDeletionSafety PodDeletionSafetyProvider, store StatusStore) Manager {
 	return &manager{
 		kubeClient:        kubeClient,
 		podManager:        podManager,
@@ -131,6 +141,8 @@ func NewManager(kubeClient clientset.Interface, podManager pkgpod.Manager, podDe
 		podStatusChannel:  make(chan podStatusSyncRequest, 1000), // Buffer up to 1000 statuses
 		apiStatusVersions: make(map[kubetypes.MirrorPodUID]uint64),
 		podDeletionSafety: podDeletionSafety,
+		store:             store,
+		offlineStatuses:   make(map[types.UID]versionedPodStatus),
 	}
 }
 
@@ -162,6 +174,7 @@ func (m *manager) Start() {
 	}
 
 	nlog.Infof("Starting to sync pod status with apiserver")
+	m.loadOfflineStatuses()
 
 	//nolint:staticcheck // SA1015 Ticker can leak since this is only called once and doesn't handle termination.
 	syncTicker := time.NewTicker(syncPeriod).C
@@ -589,6 +602,8 @@ func (m *manager) syncBatch() {
 		nlog.Debugf("Status Manager: sync pod %q in syncbatch", update.podUID)
 		m.syncPod(update.podUID, update.status)
 	}
+
+	m.syncOfflineStatuses()
 }
 
 // syncPod syncs the given status with the API server. The caller must not hold the lock.
@@ -604,10 +619,12 @@ func (m *manager) syncPod(uid types.UID, status versionedPodStatus) {
 		nlog.Infof("Pod %q does not exist on the server", format.PodDesc(status.podName, status.podNamespace, uid))
 		// If the Pod is deleted the status will be cleared in
 		// RemoveOrphanedStatuses, so we just ignore the update here.
+		m.forgetOfflineStatus(uid)
 		return
 	}
 	if err != nil {
 		nlog.Errorf("Failed to get status for pod %q: %v", format.PodDesc(status.podName, status.podNamespace, uid), err)
+		m.saveOfflineStatus(uid, status)
 		return
 	}
 
@@ -616,6 +633,20 @@ func (m *manager) syncPod(uid types.UID, status versionedPodStatus) {
 	if len(translatedUID) > 0 && translatedUID != kubetypes.ResolvedPodUID(uid) {
 		nlog.Infof("Pod %q was deleted and then recreated, skipping status update, oldPodUID=%v, podUID=%v", format.Pod(pod), uid, translatedUID)
 		m.deletePodStatus(uid)
+		m.forgetOfflineStatus(uid)
+		return
+	}
+	if _, offline := m.offlineStatuses[uid]; offline && pod.UID != uid && len(translatedUID) == 0 {
+		nlog.Infof("Pod %q was recreated while the status was pending, skipping status update, oldPodUID=%v, podUID=%v", format.Pod(pod), uid, pod.UID)
+		m.forgetOfflineStatus(uid)
+		return
+	}
+
+	// A status generated before reconnecting must not move a terminated pod back.
+	if isPodPhaseTerminal(pod.Status.Phase) && !isPodPhaseTerminal(status.status.Phase) {
+		nlog.Infof("Pod %q is %v on the server, skipping stale status, statusVersion=%v", format.Pod(pod), pod.Status.Phase, status.version)
+		m.apiStatusVersions[kubetypes.MirrorPodUID(pod.UID)] = status.version
+		m.forgetOfflineStatus(uid)
 		return
 	}
 
@@ -626,8 +657,10 @@ func (m *manager) syncPod(uid types.UID, status versionedPodStatus) {
 
 	if err != nil {
 		nlog.Errorf("Failed to update status for pod %q: %v", format.Pod(pod), err)
+		m.saveOfflineStatus(uid, status)
 		return
 	}
+	m.forgetOfflineStatus(uid)
 	if unchanged {
 		nlog.Debugf("Status for pod %q is up-to-date, statusVersion=%v", format.Pod(pod), status.version)
 	} else {
@@ -655,6 +688,10 @@ func (m *manager) syncPod(uid types.UID, status versionedPodStatus) {
 	}
 }
 
+func isPodPhaseTerminal(phase v1.PodPhase) bool {
+	return phase == v1.PodFailed || phase == v1.PodSucceeded
+}
+
 // needsUpdate returns whether the status is stale for the given pod UID.
 // This method is not thread safe, and must only be accessed by the sync thread.
 func (m *manager) needsUpdate(uid types.UID, status versionedPodStatus) bool {
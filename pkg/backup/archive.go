@@ -0,0 +1,213 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	archivePrefix     = "kuscia-backup-"
+	archiveSuffix     = ".tar.gz"
+	archiveTimeLayout = "20060102T150405Z"
+
+	manifestName    = "manifest.json"
+	manifestVersion = 1
+)
+
+// Manifest describes the content of an archive, it's the first entry of the archive.
+type Manifest struct {
+	Version       int         `json:"version"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	DomainID      string      `json:"domainID,omitempty"`
+	KusciaVersion string      `json:"kusciaVersion,omitempty"`
+	Files         []FileEntry `json:"files"`
+}
+
+// FileEntry is a file in the archive with its checksum.
+type FileEntry struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+func archiveName(t time.Time) string {
+	return archivePrefix + t.UTC().Format(archiveTimeLayout) + archiveSuffix
+}
+
+func isArchiveName(name string) bool {
+	_, err := archiveTime(name)
+	return err == nil
+}
+
+// archiveTime returns the time when the archive was created.
+func archiveTime(name string) (time.Time, error) {
+	if !strings.HasPrefix(name, archivePrefix) || !strings.HasSuffix(name, archiveSuffix) {
+		return time.Time{}, fmt.Errorf("%s is not a backup archive", name)
+	}
+	return time.Parse(archiveTimeLayout, strings.TrimSuffix(strings.TrimPrefix(name, archivePrefix), archiveSuffix))
+}
+
+// writeArchive writes the files of manifest under dir into a gzipped tarball, the manifest is written first so
+// the archive can be verified while it's read.
+func writeArchive(w io.Writer, dir string, manifest *Manifest) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0600, Size: int64(len(data)), ModTime: manifest.CreatedAt}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+
+	for _, file := range manifest.Files {
+		if err := writeArchiveFile(tw, filepath.Join(dir, file.Name), file, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeArchiveFile(tw *tar.Writer, filePath string, file FileEntry, modTime time.Time) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := tw.WriteHeader(&tar.Header{Name: file.Name, Mode: 0600, Size: file.Size, ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// fileEntry computes the size and checksum of the file name under dir.
+func fileEntry(dir, name string) (FileEntry, error) {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return FileEntry{}, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return FileEntry{}, err
+	}
+	return FileEntry{Name: name, Size: size, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// readArchive extracts the archive into dir and verifies every file against the manifest. If dir is empty, the
+// files are only verified.
+func readArchive(r io.Reader, dir string) (*Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive, %v", err)
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	header, err := tr.Next()
+	if err != nil || header.Name != manifestName {
+		return nil, errors.New("invalid archive, manifest is missing")
+	}
+	manifest := &Manifest{}
+	if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("invalid archive manifest, %v", err)
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", manifest.Version)
+	}
+
+	expected := map[string]FileEntry{}
+	for _, file := range manifest.Files {
+		if err := validateEntryName(file.Name); err != nil {
+			return nil, err
+		}
+		expected[file.Name] = file
+	}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive, %v", err)
+		}
+		file, ok := expected[header.Name]
+		if !ok {
+			return nil, fmt.Errorf("invalid archive, file %s isn't in manifest", header.Name)
+		}
+		delete(expected, header.Name)
+		if err := extractArchiveFile(tr, dir, file); err != nil {
+			return nil, err
+		}
+	}
+	for name := range expected {
+		return nil, fmt.Errorf("invalid archive, file %s is missing", name)
+	}
+	return manifest, nil
+}
+
+func extractArchiveFile(r io.Reader, dir string, file FileEntry) error {
+	w := io.Discard
+	if dir != "" {
+		filePath := filepath.Join(dir, file.Name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(w, h), r)
+	if err != nil {
+		return fmt.Errorf("read archive file %s failed, %v", file.Name, err)
+	}
+	if size != file.Size || hex.EncodeToString(h.Sum(nil)) != file.SHA256 {
+		return fmt.Errorf("archive file %s is corrupted, checksum mismatch", file.Name)
+	}
+	return nil
+}
+
+func validateEntryName(name string) error {
+	if name == "" || path.IsAbs(name) || path.Clean(name) != name || strings.HasPrefix(name, "../") || name == ".." {
+		return fmt.Errorf("invalid archive, illegal file name %q", name)
+	}
+	return nil
+}
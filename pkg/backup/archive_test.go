@@ -0,0 +1,83 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestArchive(t *testing.T, files map[string]string) (*Manifest, *bytes.Buffer) {
+	dir := t.TempDir()
+	manifest := &Manifest{Version: manifestVersion, CreatedAt: time.Now()}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+		entry, err := fileEntry(dir, name)
+		assert.NoError(t, err)
+		manifest.Files = append(manifest.Files, entry)
+	}
+	buf := &bytes.Buffer{}
+	assert.NoError(t, writeArchive(buf, dir, manifest))
+	return manifest, buf
+}
+
+func TestArchive(t *testing.T) {
+	_, buf := writeTestArchive(t, map[string]string{"resources/domains.json": "{}", "stores/jobhistory.db": "db"})
+
+	dir := t.TempDir()
+	manifest, err := readArchive(bytes.NewReader(buf.Bytes()), dir)
+	assert.NoError(t, err)
+	assert.Len(t, manifest.Files, 2)
+	content, err := os.ReadFile(filepath.Join(dir, "stores/jobhistory.db"))
+	assert.NoError(t, err)
+	assert.Equal(t, "db", string(content))
+
+	// verify only
+	_, err = readArchive(bytes.NewReader(buf.Bytes()), "")
+	assert.NoError(t, err)
+}
+
+func TestArchiveCorrupted(t *testing.T) {
+	manifest, _ := writeTestArchive(t, map[string]string{"resources/domains.json": "{}"})
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "resources"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "resources/domains.json"), []byte("[]"), 0600))
+	buf := &bytes.Buffer{}
+	assert.NoError(t, writeArchive(buf, dir, manifest))
+	_, err := readArchive(buf, "")
+	assert.ErrorContains(t, err, "checksum mismatch")
+
+	_, err = readArchive(bytes.NewReader([]byte("not an archive")), "")
+	assert.Error(t, err)
+	assert.Error(t, validateEntryName("../domains.json"))
+	assert.Error(t, validateEntryName("/etc/passwd"))
+}
+
+func TestArchiveName(t *testing.T) {
+	now := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	name := archiveName(now)
+	assert.Equal(t, "kuscia-backup-20240501T083000Z.tar.gz", name)
+	createdAt, err := archiveTime(name)
+	assert.NoError(t, err)
+	assert.True(t, now.Equal(createdAt))
+	assert.False(t, isArchiveName("kuscia-backup-latest.tar.gz"))
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup snapshots the state of master, the kuscia custom resources and the SQLite stores, into verifiable
+// archives in a local directory or an S3 bucket, and restores the state from them.
+package backup
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultRetention is the number of backups kept in the target if retention isn't set.
+	defaultRetention = 7
+
+	schemeFile = "file"
+	schemeS3   = "s3"
+)
+
+// Config is the backup section in config file.
+type Config struct {
+	// Target is where archives are stored, a local directory like /home/kuscia/var/backup or s3://bucket/prefix.
+	// Backup is disabled if it's empty.
+	Target string `yaml:"target,omitempty"`
+	// S3 is the endpoint and credential of the S3 target.
+	S3 S3Config `yaml:"s3,omitempty"`
+	// Interval is the period of scheduled backups, scheduled backups are disabled if it's 0.
+	Interval time.Duration `yaml:"interval,omitempty"`
+	// Retention is the number of archives kept in target, older archives are deleted after a backup, default 7.
+	Retention int `yaml:"retention,omitempty"`
+}
+
+// S3Config is the endpoint and credential of S3 compatible object storage.
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint,omitempty"`
+	Region          string `yaml:"region,omitempty"`
+	AccessKeyID     string `yaml:"accessKeyID,omitempty"`
+	AccessKeySecret string `yaml:"accessKeySecret,omitempty"`
+	// Virtualhost uses virtual hosted style urls instead of path style.
+	Virtualhost bool `yaml:"virtualhost,omitempty"`
+}
+
+// Enabled returns whether backup target is set.
+func (c *Config) Enabled() bool {
+	return c != nil && c.Target != ""
+}
+
+func CheckConfig(c *Config) error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Interval < 0 || c.Retention < 0 {
+		return fmt.Errorf("backup interval and retention can't be negative")
+	}
+	scheme, _, _, err := parseTarget(c.Target)
+	if err != nil {
+		return err
+	}
+	if scheme == schemeS3 && c.S3.Endpoint == "" {
+		return fmt.Errorf("backup s3 endpoint is required for target %s", c.Target)
+	}
+	return nil
+}
+
+func (c *Config) retention() int {
+	if c.Retention == 0 {
+		return defaultRetention
+	}
+	return c.Retention
+}
+
+// parseTarget splits target into scheme, bucket and path, bucket is empty for local directory.
+func parseTarget(target string) (scheme, bucket, path string, err error) {
+	if !strings.Contains(target, "://") {
+		return schemeFile, "", target, nil
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid backup target %s, %v", target, err)
+	}
+	switch u.Scheme {
+	case schemeFile:
+		return schemeFile, "", u.Path, nil
+	case schemeS3:
+		if u.Host == "" {
+			return "", "", "", fmt.Errorf("invalid backup target %s, bucket is empty", target)
+		}
+		return schemeS3, u.Host, strings.Trim(u.Path, "/"), nil
+	default:
+		return "", "", "", fmt.Errorf("invalid backup target %s, only local directory and s3 are supported", target)
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+	"github.com/secretflow/kuscia/pkg/utils/meta"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// Info is an archive in the target.
+type Info struct {
+	Name      string
+	CreatedAt time.Time
+}
+
+// Result is the archive created by a backup.
+type Result struct {
+	Info
+	Size   int64
+	SHA256 string
+	// Resources is the number of kuscia objects in the archive.
+	Resources int
+	// Stores are the names of SQLite stores in the archive.
+	Stores []string
+}
+
+// RestoreOptions selects the archive to restore.
+type RestoreOptions struct {
+	// Name of the archive, if it's empty, the latest archive created at or before At is restored.
+	Name string
+	// At is the point in time to restore, zero means the latest archive.
+	At time.Time
+	// Prune deletes the objects and store rows created after the archive, so the state is exactly the archived one.
+	Prune bool
+	// DryRun verifies the archive and counts the changes without applying them.
+	DryRun bool
+}
+
+// RestoreResult is the changes applied by a restore.
+type RestoreResult struct {
+	Name string
+	// Resources is the number of kuscia objects created or updated.
+	Resources int
+	// Pruned is the number of kuscia objects deleted.
+	Pruned int
+	// StoreRows is the number of store rows restored.
+	StoreRows int64
+}
+
+// Manager backs up and restores the state of master. It's safe for concurrent use.
+type Manager struct {
+	config        Config
+	domainID      string
+	target        Target
+	dynamicClient dynamic.Interface
+	discovery     discovery.DiscoveryInterface
+	kubeClient    kubernetes.Interface
+	// stores are the SQLite files to back up, indexed by store name.
+	stores map[string]string
+
+	lock sync.Mutex
+	now  func() time.Time
+}
+
+// NewManager returns a manager of the target in config, stores are the SQLite files indexed by store name.
+func NewManager(config *Config, domainID string, clients *kubeconfig.KubeClients, stores map[string]string) (*Manager, error) {
+	if err := CheckConfig(config); err != nil {
+		return nil, err
+	}
+	if !config.Enabled() {
+		return nil, fmt.Errorf("backup target is not configured")
+	}
+	target, err := NewTarget(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(clients.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("create dynamic client failed, %v", err)
+	}
+	return newManager(config, domainID, target, dynamicClient, clients.KubeClient.Discovery(), clients.KubeClient, stores), nil
+}
+
+func newManager(config *Config, domainID string, target Target, dynamicClient dynamic.Interface, disc discovery.DiscoveryInterface,
+	kubeClient kubernetes.Interface, stores map[string]string) *Manager {
+	return &Manager{
+		config:        *config,
+		domainID:      domainID,
+		target:        target,
+		dynamicClient: dynamicClient,
+		discovery:     disc,
+		kubeClient:    kubeClient,
+		stores:        stores,
+		now:           time.Now,
+	}
+}
+
+// Backup snapshots the state into a new archive in target, and deletes the archives exceeding the retention.
+func (m *Manager) Backup(ctx context.Context) (*Result, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dir, err := os.MkdirTemp("", "kuscia-backup-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	now := m.now().UTC().Truncate(time.Second)
+	result := &Result{Info: Info{Name: archiveName(now), CreatedAt: now}}
+
+	stagingDir := filepath.Join(dir, "staging")
+	names, count, err := snapshotResources(ctx, m.dynamicClient, m.discovery, stagingDir)
+	if err != nil {
+		return nil, err
+	}
+	result.Resources = count
+	storeNames := make([]string, 0, len(m.stores))
+	for name := range m.stores {
+		storeNames = append(storeNames, name)
+	}
+	sort.Strings(storeNames)
+	for _, name := range storeNames {
+		fileName := path.Join(storesDir, name+".db")
+		exists, err := snapshotSQLite(ctx, m.stores[name], filepath.Join(stagingDir, fileName))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			names = append(names, fileName)
+			result.Stores = append(result.Stores, name)
+		}
+	}
+
+	manifest := &Manifest{Version: manifestVersion, CreatedAt: now, DomainID: m.domainID, KusciaVersion: meta.KusciaVersionString()}
+	for _, name := range names {
+		entry, err := fileEntry(stagingDir, name)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+
+	archivePath := filepath.Join(dir, result.Name)
+	if err := m.writeArchiveFile(archivePath, stagingDir, manifest, result); err != nil {
+		return nil, err
+	}
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+	if err := m.target.Put(ctx, result.Name, archive); err != nil {
+		return nil, fmt.Errorf("upload backup %s failed, %v", result.Name, err)
+	}
+	nlog.Infof("Backup %s created, resources=%d, stores=%v, size=%d", result.Name, result.Resources, result.Stores, result.Size)
+
+	m.prune(ctx)
+	return result, nil
+}
+
+func (m *Manager) writeArchiveFile(archivePath, stagingDir string, manifest *Manifest, result *Result) error {
+	f, err := os.OpenFile(archivePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	counter := &countWriter{}
+	if err := writeArchive(io.MultiWriter(f, h, counter), stagingDir, manifest); err != nil {
+		return fmt.Errorf("write backup archive failed, %v", err)
+	}
+	result.Size = counter.n
+	result.SHA256 = hex.EncodeToString(h.Sum(nil))
+	return f.Close()
+}
+
+// prune deletes the oldest archives exceeding the retention.
+func (m *Manager) prune(ctx context.Context) {
+	names, err := m.target.List(ctx)
+	if err != nil {
+		nlog.Warnf("List backups failed, skip pruning, %v", err)
+		return
+	}
+	for i := 0; i < len(names)-m.config.retention(); i++ {
+		if err := m.target.Delete(ctx, names[i]); err != nil {
+			nlog.Warnf("Delete backup %s failed, %v", names[i], err)
+			continue
+		}
+		nlog.Infof("Backup %s deleted by retention", names[i])
+	}
+}
+
+// List returns the archives in target, the latest is the last.
+func (m *Manager) List(ctx context.Context) ([]Info, error) {
+	names, err := m.target.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]Info, 0, len(names))
+	for _, name := range names {
+		createdAt, _ := archiveTime(name)
+		infos = append(infos, Info{Name: name, CreatedAt: createdAt})
+	}
+	return infos, nil
+}
+
+// Verify reads the archive and checks every file against the checksums in manifest.
+func (m *Manager) Verify(ctx context.Context, name string) (*Manifest, error) {
+	return m.fetch(ctx, name, "")
+}
+
+// Select returns the latest archive created at or before at, zero at selects the latest archive.
+func (m *Manager) Select(ctx context.Context, at time.Time) (string, error) {
+	infos, err := m.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	for i := len(infos) - 1; i >= 0; i-- {
+		if at.IsZero() || !infos[i].CreatedAt.After(at) {
+			return infos[i].Name, nil
+		}
+	}
+	if at.IsZero() {
+		return "", fmt.Errorf("no backup found in %s", m.config.Target)
+	}
+	return "", fmt.Errorf("no backup created at or before %s found in %s", at.UTC().Format(time.RFC3339), m.config.Target)
+}
+
+// Restore verifies the selected archive and applies the archived state. Objects which exist are updated, their
+// status is restored as well.
+func (m *Manager) Restore(ctx context.Context, opts *RestoreOptions) (*RestoreResult, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := opts.Name
+	if name == "" {
+		var err error
+		if name, err = m.Select(ctx, opts.At); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "kuscia-restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	manifest, err := m.fetch(ctx, name, dir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := loadResourceFiles(dir, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestoreResult{Name: name}
+	restorer := &resourceRestorer{
+		dynamicClient: m.dynamicClient,
+		kubeClient:    m.kubeClient,
+		dryRun:        opts.DryRun,
+		uids:          map[types.UID]types.UID{},
+		namespaces:    map[string]bool{},
+		result:        result,
+	}
+	if opts.Prune {
+		if err := restorer.prune(ctx, files); err != nil {
+			return nil, err
+		}
+	}
+	if err := restorer.restore(ctx, files); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range manifest.Files {
+		if path.Dir(entry.Name) != storesDir {
+			continue
+		}
+		storeName := strings.TrimSuffix(path.Base(entry.Name), ".db")
+		dst, ok := m.stores[storeName]
+		if !ok {
+			nlog.Warnf("Store %s in backup %s is unknown, skip it", storeName, name)
+			continue
+		}
+		if opts.DryRun {
+			continue
+		}
+		rows, err := restoreSQLite(ctx, filepath.Join(dir, entry.Name), dst, opts.Prune)
+		if err != nil {
+			return nil, err
+		}
+		result.StoreRows += rows
+	}
+	nlog.Infof("Backup %s restored, resources=%d, pruned=%d, storeRows=%d, dryRun=%v", name, result.Resources, result.Pruned,
+		result.StoreRows, opts.DryRun)
+	return result, nil
+}
+
+// fetch downloads the archive and verifies it, files are extracted into dir if it's not empty.
+func (m *Manager) fetch(ctx context.Context, name, dir string) (*Manifest, error) {
+	if !isArchiveName(name) {
+		return nil, fmt.Errorf("invalid backup name %s", name)
+	}
+	r, err := m.target.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("get backup %s failed, %v", name, err)
+	}
+	defer r.Close()
+	manifest, err := readArchive(r, dir)
+	if err != nil {
+		return nil, fmt.Errorf("verify backup %s failed, %v", name, err)
+	}
+	return manifest, nil
+}
+
+// Run creates backups periodically until ctx is done, it returns at once if the interval isn't set.
+func (m *Manager) Run(ctx context.Context) {
+	if m.config.Interval <= 0 {
+		return
+	}
+	nlog.Infof("Scheduled backup to %s every %v", m.config.Target, m.config.Interval)
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.Backup(ctx); err != nil {
+				nlog.Errorf("Scheduled backup failed, %v", err)
+			}
+		}
+	}
+}
+
+type countWriter struct {
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+var (
+	domainGVR = v1alpha1.SchemeGroupVersion.WithResource("domains")
+	jobGVR    = v1alpha1.SchemeGroupVersion.WithResource("kusciajobs")
+)
+
+func newTestObject(kind, namespace, name, uid string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(v1alpha1.SchemeGroupVersion.String())
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID(types.UID(uid))
+	return obj
+}
+
+func newTestManager(t *testing.T, config *Config, store string, objects ...runtime.Object) (*Manager, *dynamicfake.FakeDynamicClient) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{domainGVR: "DomainList", jobGVR: "KusciaJobList"}, objects...)
+	// the api server assigns a new uid to created object.
+	dynamicClient.PrependReactor("create", "*", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		obj := action.(clienttesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		obj.SetUID(types.UID("restored-" + obj.GetName()))
+		return false, nil, nil
+	})
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.Discovery().(*discoveryfake.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: v1alpha1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{
+			{Name: "kusciajobs", Namespaced: true, Verbs: metav1.Verbs{"list", "create", "update", "delete"}},
+			{Name: "kusciajobs/status", Namespaced: true, Verbs: metav1.Verbs{"update"}},
+			{Name: "domains", Verbs: metav1.Verbs{"list", "create", "update", "delete"}},
+			{Name: "domains/status", Verbs: metav1.Verbs{"update"}},
+			{Name: "readonly", Verbs: metav1.Verbs{"list"}},
+		},
+	}}
+	target, err := NewTarget(config)
+	assert.NoError(t, err)
+	return newManager(config, "alice", target, dynamicClient, kubeClient.Discovery(), kubeClient,
+		map[string]string{"jobhistory": store, "missing": filepath.Join(t.TempDir(), "missing.db")}), dynamicClient
+}
+
+func openTestStore(t *testing.T, file string) *sql.DB {
+	db, err := sql.Open("sqlite3", "file:"+file)
+	assert.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE IF NOT EXISTS history (job_id TEXT PRIMARY KEY, phase TEXT)")
+	assert.NoError(t, err)
+	return db
+}
+
+func queryTestStore(t *testing.T, db *sql.DB) map[string]string {
+	rows, err := db.Query("SELECT job_id, phase FROM history")
+	assert.NoError(t, err)
+	defer rows.Close()
+	result := map[string]string{}
+	for rows.Next() {
+		var id, phase string
+		assert.NoError(t, rows.Scan(&id, &phase))
+		result[id] = phase
+	}
+	return result
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	store := filepath.Join(t.TempDir(), "jobhistory.db")
+	db := openTestStore(t, store)
+	defer db.Close()
+	_, err := db.Exec("INSERT INTO history VALUES ('job-0', 'Succeeded')")
+	assert.NoError(t, err)
+
+	domain := newTestObject("Domain", "", "alice", "uid-alice")
+	job := newTestObject("KusciaJob", "cross-domain", "job-1", "uid-job-1")
+	job.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "kuscia.secretflow/v1alpha1", Kind: "Domain", Name: "alice", UID: "uid-alice"}})
+	assert.NoError(t, unstructured.SetNestedField(job.Object, "Running", "status", "phase"))
+	m, dynamicClient := newTestManager(t, &Config{Target: t.TempDir()}, store, job, domain)
+
+	result, err := m.Backup(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Resources)
+	assert.Equal(t, []string{"jobhistory"}, result.Stores)
+	assert.NotEmpty(t, result.SHA256)
+	manifest, err := m.Verify(ctx, result.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", manifest.DomainID)
+	assert.Len(t, manifest.Files, 3)
+
+	// lose the state after backup
+	assert.NoError(t, dynamicClient.Resource(domainGVR).Delete(ctx, "alice", metav1.DeleteOptions{}))
+	assert.NoError(t, dynamicClient.Resource(jobGVR).Namespace("cross-domain").Delete(ctx, "job-1", metav1.DeleteOptions{}))
+	_, err = dynamicClient.Resource(jobGVR).Namespace("cross-domain").Create(ctx, newTestObject("KusciaJob", "cross-domain", "job-2", "uid-job-2"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = db.Exec("UPDATE history SET phase = 'Failed' WHERE job_id = 'job-0'")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO history VALUES ('job-2', 'Succeeded')")
+	assert.NoError(t, err)
+
+	dryRun, err := m.Restore(ctx, &RestoreOptions{Prune: true, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, dryRun.Resources)
+	assert.Equal(t, 1, dryRun.Pruned)
+	_, err = dynamicClient.Resource(domainGVR).Get(ctx, "alice", metav1.GetOptions{})
+	assert.Error(t, err)
+
+	restored, err := m.Restore(ctx, &RestoreOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, result.Name, restored.Name)
+	assert.Equal(t, 2, restored.Resources)
+	assert.Equal(t, 1, restored.Pruned)
+
+	restoredJob, err := dynamicClient.Resource(jobGVR).Namespace("cross-domain").Get(ctx, "job-1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, types.UID("restored-alice"), restoredJob.GetOwnerReferences()[0].UID)
+	phase, _, _ := unstructured.NestedString(restoredJob.Object, "status", "phase")
+	assert.Equal(t, "Running", phase)
+	_, err = dynamicClient.Resource(jobGVR).Namespace("cross-domain").Get(ctx, "job-2", metav1.GetOptions{})
+	assert.Error(t, err)
+	assert.Equal(t, map[string]string{"job-0": "Succeeded"}, queryTestStore(t, db))
+}
+
+func TestSelectAndRetention(t *testing.T) {
+	ctx := context.Background()
+	store := filepath.Join(t.TempDir(), "jobhistory.db")
+	m, _ := newTestManager(t, &Config{Target: t.TempDir(), Retention: 2}, store)
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		createdAt := start.Add(time.Duration(i) * time.Hour)
+		m.now = func() time.Time { return createdAt }
+		_, err := m.Backup(ctx)
+		assert.NoError(t, err)
+	}
+	infos, err := m.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, infos, 2)
+	assert.True(t, infos[0].CreatedAt.Equal(start.Add(time.Hour)))
+
+	name, err := m.Select(ctx, start.Add(90*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, infos[0].Name, name)
+	name, err = m.Select(ctx, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, infos[1].Name, name)
+	_, err = m.Select(ctx, start)
+	assert.Error(t, err)
+}
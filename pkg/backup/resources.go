@@ -0,0 +1,331 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const resourcesDir = "resources"
+
+// restorePriority orders the resources which others depend on, the rest are restored in name order.
+var restorePriority = map[string]int{
+	"domains":             1,
+	"appimages":           2,
+	"clusterdomainroutes": 3,
+	"domainroutes":        4,
+}
+
+// resourceFile is the content of a resource file in the archive.
+type resourceFile struct {
+	Resource   string                      `json:"resource"`
+	Namespaced bool                        `json:"namespaced"`
+	HasStatus  bool                        `json:"hasStatus"`
+	Items      []unstructured.Unstructured `json:"items"`
+}
+
+func (f *resourceFile) gvr() schema.GroupVersionResource {
+	return v1alpha1.SchemeGroupVersion.WithResource(f.Resource)
+}
+
+// listResourceFiles discovers the kuscia resources which can be listed and created, items are not filled.
+func listResourceFiles(disc discovery.DiscoveryInterface) ([]*resourceFile, error) {
+	resourceList, err := disc.ServerResourcesForGroupVersion(v1alpha1.SchemeGroupVersion.String())
+	if err != nil {
+		return nil, fmt.Errorf("discover kuscia resources failed, %v", err)
+	}
+	hasStatus := map[string]bool{}
+	for _, r := range resourceList.APIResources {
+		if name, sub, ok := strings.Cut(r.Name, "/"); ok && sub == "status" {
+			hasStatus[name] = true
+		}
+	}
+	var files []*resourceFile
+	for _, r := range resourceList.APIResources {
+		if strings.Contains(r.Name, "/") || !hasVerbs(r.Verbs, "list", "create", "update") {
+			continue
+		}
+		files = append(files, &resourceFile{Resource: r.Name, Namespaced: r.Namespaced, HasStatus: hasStatus[r.Name]})
+	}
+	sortResourceFiles(files)
+	return files, nil
+}
+
+func hasVerbs(verbs metav1.Verbs, expected ...string) bool {
+	for _, e := range expected {
+		found := false
+		for _, v := range verbs {
+			if v == e {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func sortResourceFiles(files []*resourceFile) {
+	sort.SliceStable(files, func(i, j int) bool {
+		pi, pj := restorePriority[files[i].Resource], restorePriority[files[j].Resource]
+		if pi == 0 || pj == 0 {
+			if pi != pj {
+				return pj == 0
+			}
+			return files[i].Resource < files[j].Resource
+		}
+		return pi < pj
+	})
+}
+
+// snapshotResources writes every kuscia resource into a file under dir, and returns the names of files.
+func snapshotResources(ctx context.Context, dynamicClient dynamic.Interface, disc discovery.DiscoveryInterface, dir string) ([]string, int, error) {
+	files, err := listResourceFiles(disc)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, resourcesDir), 0700); err != nil {
+		return nil, 0, err
+	}
+
+	var names []string
+	count := 0
+	for _, file := range files {
+		list, err := dynamicClient.Resource(file.gvr()).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("list %s failed, %v", file.Resource, err)
+		}
+		for i := range list.Items {
+			unstructured.RemoveNestedField(list.Items[i].Object, "metadata", "managedFields")
+		}
+		file.Items = list.Items
+		count += len(list.Items)
+
+		data, err := json.Marshal(file)
+		if err != nil {
+			return nil, 0, err
+		}
+		name := path.Join(resourcesDir, file.Resource+".json")
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			return nil, 0, err
+		}
+		names = append(names, name)
+	}
+	return names, count, nil
+}
+
+func loadResourceFiles(dir string, manifest *Manifest) ([]*resourceFile, error) {
+	var files []*resourceFile
+	for _, entry := range manifest.Files {
+		if path.Dir(entry.Name) != resourcesDir {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name))
+		if err != nil {
+			return nil, err
+		}
+		file := &resourceFile{}
+		if err := json.Unmarshal(data, file); err != nil {
+			return nil, fmt.Errorf("invalid resource file %s, %v", entry.Name, err)
+		}
+		files = append(files, file)
+	}
+	sortResourceFiles(files)
+	return files, nil
+}
+
+// resourceRestorer creates or updates the resources in archive. The uid of restored object differs from the one
+// in archive, owner references are rewritten with the new uid, otherwise the garbage collector deletes the object.
+type resourceRestorer struct {
+	dynamicClient dynamic.Interface
+	kubeClient    kubernetes.Interface
+	dryRun        bool
+
+	// uids maps the uid in archive to the uid of restored object.
+	uids       map[types.UID]types.UID
+	namespaces map[string]bool
+	result     *RestoreResult
+}
+
+type pendingObject struct {
+	file *resourceFile
+	obj  *unstructured.Unstructured
+}
+
+func (r *resourceRestorer) restore(ctx context.Context, files []*resourceFile) error {
+	archived := map[types.UID]bool{}
+	var pending []pendingObject
+	for _, file := range files {
+		for i := range file.Items {
+			archived[file.Items[i].GetUID()] = true
+			pending = append(pending, pendingObject{file: file, obj: &file.Items[i]})
+		}
+	}
+
+	// objects are restored after their owners, objects in an ownership cycle are restored at last.
+	for len(pending) > 0 {
+		var blocked []pendingObject
+		for _, p := range pending {
+			if r.ownersPending(p.obj, archived) {
+				blocked = append(blocked, p)
+				continue
+			}
+			if err := r.restoreObject(ctx, p.file, p.obj); err != nil {
+				return err
+			}
+		}
+		if len(blocked) == len(pending) {
+			for _, p := range blocked {
+				if err := r.restoreObject(ctx, p.file, p.obj); err != nil {
+					return err
+				}
+			}
+			break
+		}
+		pending = blocked
+	}
+	return nil
+}
+
+func (r *resourceRestorer) ownersPending(obj *unstructured.Unstructured, archived map[types.UID]bool) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if _, restored := r.uids[owner.UID]; archived[owner.UID] && !restored {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resourceRestorer) restoreObject(ctx context.Context, file *resourceFile, archivedObj *unstructured.Unstructured) error {
+	obj := archivedObj.DeepCopy()
+	key := file.Resource + "/" + obj.GetName()
+	if obj.GetNamespace() != "" {
+		key = file.Resource + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	}
+	status, hasStatus := obj.Object["status"]
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "deletionTimestamp",
+		"deletionGracePeriodSeconds", "selfLink", "managedFields"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	owners := obj.GetOwnerReferences()
+	for i := range owners {
+		if uid, ok := r.uids[owners[i].UID]; ok {
+			owners[i].UID = uid
+		}
+	}
+	obj.SetOwnerReferences(owners)
+
+	if r.dryRun {
+		r.uids[archivedObj.GetUID()] = archivedObj.GetUID()
+		r.result.Resources++
+		return nil
+	}
+
+	if file.Namespaced {
+		if err := r.ensureNamespace(ctx, obj.GetNamespace()); err != nil {
+			return err
+		}
+	}
+	client := r.dynamicClient.Resource(file.gvr()).Namespace(obj.GetNamespace())
+	current, err := client.Create(ctx, obj, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		var existing *unstructured.Unstructured
+		existing, err = client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get %s failed, %v", key, err)
+		}
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		current, err = client.Update(ctx, obj, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("restore %s failed, %v", key, err)
+	}
+	if file.HasStatus && hasStatus {
+		current.Object["status"] = status
+		if current, err = client.UpdateStatus(ctx, current, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("restore status of %s failed, %v", key, err)
+		}
+	}
+	r.uids[archivedObj.GetUID()] = current.GetUID()
+	r.result.Resources++
+	nlog.Debugf("Restored %s", key)
+	return nil
+}
+
+// ensureNamespace creates the namespace of domain if it's missing, domain controller completes its labels.
+func (r *resourceRestorer) ensureNamespace(ctx context.Context, namespace string) error {
+	if r.namespaces[namespace] {
+		return nil
+	}
+	_, err := r.kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace},
+	}, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("create namespace %s failed, %v", namespace, err)
+	}
+	r.namespaces[namespace] = true
+	return nil
+}
+
+// prune deletes the objects of archived resources which don't exist in archive.
+func (r *resourceRestorer) prune(ctx context.Context, files []*resourceFile) error {
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		archived := map[string]bool{}
+		for _, item := range file.Items {
+			archived[item.GetNamespace()+"/"+item.GetName()] = true
+		}
+		list, err := r.dynamicClient.Resource(file.gvr()).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("list %s failed, %v", file.Resource, err)
+		}
+		for _, item := range list.Items {
+			if archived[item.GetNamespace()+"/"+item.GetName()] {
+				continue
+			}
+			r.result.Pruned++
+			if r.dryRun {
+				continue
+			}
+			err := r.dynamicClient.Resource(file.gvr()).Namespace(item.GetNamespace()).Delete(ctx, item.GetName(), metav1.DeleteOptions{})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return fmt.Errorf("prune %s %s/%s failed, %v", file.Resource, item.GetNamespace(), item.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// register sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const storesDir = "stores"
+
+// snapshotSQLite writes a consistent copy of the SQLite database src into dst, it's safe while src is being written.
+// It returns false if src doesn't exist.
+func snapshotSQLite(ctx context.Context, src, dst string) (bool, error) {
+	if _, err := os.Stat(src); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return false, err
+	}
+	db, err := sql.Open("sqlite3", "file:"+src+"?mode=ro&_busy_timeout=5000")
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", dst); err != nil {
+		return false, fmt.Errorf("snapshot sqlite %s failed, %v", src, err)
+	}
+	return true, nil
+}
+
+// restoreSQLite copies the rows of every table in the snapshot src into the database dst, rows with the same
+// primary key are replaced. If prune is true, rows not in the snapshot are deleted. It's safe while dst is open
+// by the running store.
+func restoreSQLite(ctx context.Context, src, dst string, prune bool) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return 0, err
+	}
+	db, err := sql.Open("sqlite3", "file:"+dst+"?_busy_timeout=5000&_journal_mode=WAL")
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	// ATTACH applies to a single connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS snapshot", "file:"+src+"?mode=ro"); err != nil {
+		return 0, fmt.Errorf("open sqlite snapshot %s failed, %v", src, err)
+	}
+	defer conn.ExecContext(context.Background(), "DETACH DATABASE snapshot") //nolint:errcheck
+
+	tables, err := snapshotTables(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var rows int64
+	for name, createSQL := range tables {
+		if _, err := tx.ExecContext(ctx, strings.Replace(createSQL, "CREATE TABLE", "CREATE TABLE IF NOT EXISTS", 1)); err != nil {
+			return 0, fmt.Errorf("create table %s failed, %v", name, err)
+		}
+		columns, err := tableColumns(ctx, tx, name)
+		if err != nil {
+			return 0, err
+		}
+		if prune {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM main.%s", quoteIdent(name))); err != nil {
+				return 0, fmt.Errorf("prune table %s failed, %v", name, err)
+			}
+		}
+		result, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT OR REPLACE INTO main.%s (%s) SELECT %s FROM snapshot.%s",
+			quoteIdent(name), columns, columns, quoteIdent(name)))
+		if err != nil {
+			return 0, fmt.Errorf("restore table %s failed, %v", name, err)
+		}
+		n, _ := result.RowsAffected()
+		rows += n
+	}
+	return rows, tx.Commit()
+}
+
+// snapshotTables returns the create statements of tables in snapshot, indexed by table name.
+func snapshotTables(ctx context.Context, conn *sql.Conn) (map[string]string, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT name, sql FROM snapshot.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tables := map[string]string{}
+	for rows.Next() {
+		var name, createSQL string
+		if err := rows.Scan(&name, &createSQL); err != nil {
+			return nil, err
+		}
+		tables[name] = createSQL
+	}
+	return tables, rows.Err()
+}
+
+// tableColumns returns the quoted columns of table in snapshot, which also exist in the table of main database.
+func tableColumns(ctx context.Context, tx *sql.Tx, table string) (string, error) {
+	columnsOf := func(schema string) ([]string, error) {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT name FROM pragma_table_info(%s, %s)", quoteString(table), quoteString(schema)))
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var columns []string
+		for rows.Next() {
+			var column string
+			if err := rows.Scan(&column); err != nil {
+				return nil, err
+			}
+			columns = append(columns, column)
+		}
+		return columns, rows.Err()
+	}
+	snapshotColumns, err := columnsOf("snapshot")
+	if err != nil {
+		return "", err
+	}
+	mainColumns, err := columnsOf("main")
+	if err != nil {
+		return "", err
+	}
+	existing := map[string]bool{}
+	for _, column := range mainColumns {
+		existing[column] = true
+	}
+	var columns []string
+	for _, column := range snapshotColumns {
+		if existing[column] {
+			columns = append(columns, quoteIdent(column))
+		}
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("table %s has no column to restore", table)
+	}
+	return strings.Join(columns, ", "), nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteString(s string) string {
+	return `'` + strings.ReplaceAll(s, `'`, `''`) + `'`
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// Target stores archives by name.
+type Target interface {
+	Put(ctx context.Context, name string, r io.Reader) error
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// List returns the names of archives in the target in ascending order.
+	List(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// NewTarget returns the target of config.
+func NewTarget(c *Config) (Target, error) {
+	scheme, bucket, dir, err := parseTarget(c.Target)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == schemeFile {
+		return &localTarget{dir: dir}, nil
+	}
+
+	region := c.S3.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	awsConfig := &aws.Config{
+		Endpoint:         aws.String(c.S3.Endpoint),
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(!c.S3.Virtualhost),
+	}
+	if c.S3.AccessKeyID != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(c.S3.AccessKeyID, c.S3.AccessKeySecret, "")
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create s3 session of %s failed, %v", c.S3.Endpoint, err)
+	}
+	return &s3Target{client: s3.New(sess), bucket: bucket, prefix: dir}, nil
+}
+
+type localTarget struct {
+	dir string
+}
+
+func (t *localTarget) Put(ctx context.Context, name string, r io.Reader) error {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return err
+	}
+	// write to a temporary file first, so a partial archive is never listed.
+	tmp, err := os.CreateTemp(t.dir, "."+name+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(t.dir, name))
+}
+
+func (t *localTarget) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(t.dir, name))
+}
+
+func (t *localTarget) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(t.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && isArchiveName(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (t *localTarget) Delete(ctx context.Context, name string) error {
+	return os.Remove(filepath.Join(t.dir, name))
+}
+
+type s3Target struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+func (t *s3Target) key(name string) string {
+	return path.Join(t.prefix, name)
+}
+
+func (t *s3Target) Put(ctx context.Context, name string, r io.Reader) error {
+	uploader := s3manager.NewUploaderWithClient(t.client)
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(t.bucket),
+		Key:    aws.String(t.key(name)),
+		Body:   r,
+	})
+	return err
+}
+
+func (t *s3Target) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	output, err := t.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(t.bucket),
+		Key:    aws.String(t.key(name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+func (t *s3Target) List(ctx context.Context) ([]string, error) {
+	prefix := ""
+	if t.prefix != "" {
+		prefix = t.prefix + "/"
+	}
+	var names []string
+	err := t.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(t.bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			name := path.Base(aws.StringValue(object.Key))
+			if isArchiveName(name) {
+				names = append(names, name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (t *s3Target) Delete(ctx context.Context, name string) error {
+	_, err := t.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(t.bucket),
+		Key:    aws.String(t.key(name)),
+	})
+	return err
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTarget(t *testing.T, target Target) {
+	ctx := context.Background()
+	names, err := target.List(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	second, first := "kuscia-backup-20240502T000000Z.tar.gz", "kuscia-backup-20240501T000000Z.tar.gz"
+	assert.NoError(t, target.Put(ctx, second, bytes.NewReader([]byte("2"))))
+	assert.NoError(t, target.Put(ctx, first, bytes.NewReader([]byte("1"))))
+	names, err = target.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{first, second}, names)
+
+	r, err := target.Get(ctx, second)
+	assert.NoError(t, err)
+	content, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, "2", string(content))
+
+	assert.NoError(t, target.Delete(ctx, first))
+	names, err = target.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{second}, names)
+}
+
+func TestLocalTarget(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backup")
+	target, err := NewTarget(&Config{Target: dir})
+	assert.NoError(t, err)
+	testTarget(t, target)
+
+	// files which aren't archives are ignored
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0600))
+	names, err := target.List(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, names, 1)
+}
+
+func TestS3Target(t *testing.T) {
+	backend := s3mem.New()
+	assert.NoError(t, backend.CreateBucket("backup"))
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	defer server.Close()
+
+	target, err := NewTarget(&Config{
+		Target: "s3://backup/kuscia",
+		S3:     S3Config{Endpoint: server.URL, AccessKeyID: "ak", AccessKeySecret: "sk"},
+	})
+	assert.NoError(t, err)
+	testTarget(t, target)
+}
+
+func TestCheckConfig(t *testing.T) {
+	assert.NoError(t, CheckConfig(nil))
+	assert.NoError(t, CheckConfig(&Config{Target: "/home/kuscia/var/backup"}))
+	assert.Error(t, CheckConfig(&Config{Target: "s3://backup"}))
+	assert.Error(t, CheckConfig(&Config{Target: "s3:///prefix", S3: S3Config{Endpoint: "http://minio:9000"}}))
+	assert.Error(t, CheckConfig(&Config{Target: "oss://backup", S3: S3Config{Endpoint: "http://minio:9000"}}))
+	assert.Error(t, CheckConfig(&Config{Target: "/backup", Retention: -1}))
+}
@@ -0,0 +1,289 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certmanager keeps track of the certificates used by kuscia modules, so that they could be inspected and
+// rotated by KusciaAPI. Modules register their certs on start, the manager rotates a cert in background and reports
+// the progress by conditions.
+package certmanager
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// Kinds of certs.
+const (
+	KindDomain    = "Domain"
+	KindCA        = "CA"
+	KindAPIServer = "APIServer"
+	KindEnvoy     = "Envoy"
+)
+
+// Condition types of rotation, Rotating is True until the rotation finishes, Issued and Applied are the steps.
+const (
+	ConditionRotating = "Rotating"
+	ConditionIssued   = "Issued"
+	ConditionApplied  = "Applied"
+)
+
+// Condition statuses.
+const (
+	ConditionTrue    = "True"
+	ConditionFalse   = "False"
+	ConditionUnknown = "Unknown"
+)
+
+// Condition reasons.
+const (
+	ReasonStarted         = "Started"
+	ReasonSucceeded       = "Succeeded"
+	ReasonFailed          = "Failed"
+	ReasonRestartRequired = "RestartRequired"
+)
+
+const rotateTimeout = time.Minute
+
+// KeyPair is a cert and its private key.
+type KeyPair struct {
+	Cert *x509.Certificate
+	Key  *rsa.PrivateKey
+}
+
+// Rotator replaces a cert in use by a new one.
+type Rotator interface {
+	// Issue creates the cert to replace current.
+	Issue(ctx context.Context, current *x509.Certificate) (*KeyPair, error)
+	// Apply puts the issued cert in use, it returns false if the cert takes effect after kuscia restarts.
+	Apply(ctx context.Context, issued *KeyPair) (bool, error)
+}
+
+// Cert is a cert managed by kuscia.
+type Cert struct {
+	Name string
+	Kind string
+	// Load returns the cert in use.
+	Load func() (*x509.Certificate, error)
+	// Rotator is nil if the cert can't be rotated by kuscia, e.g. the cert of lite domain issued by master.
+	Rotator Rotator
+}
+
+// Condition is the state of a step of rotation.
+type Condition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
+
+// Status is a cert and the progress of its latest rotation.
+type Status struct {
+	Name      string
+	Kind      string
+	Rotatable bool
+	// Cert is nil if it fails to load, the reason is Error.
+	Cert       *x509.Certificate
+	Error      string
+	Conditions []Condition
+}
+
+// Fingerprint returns the hex encoded sha256 of the DER encoded cert.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+type entry struct {
+	cert       Cert
+	conditions []Condition
+	rotating   bool
+}
+
+// Manager is safe for concurrent use.
+type Manager struct {
+	mtx   sync.Mutex
+	certs map[string]*entry
+
+	// now is replaced in test.
+	now func() time.Time
+}
+
+func NewManager() *Manager {
+	return &Manager{
+		certs: make(map[string]*entry),
+		now:   time.Now,
+	}
+}
+
+// Register adds the cert or replaces the cert with the same name.
+func (m *Manager) Register(cert Cert) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if e, ok := m.certs[cert.Name]; ok {
+		e.cert = cert
+		return
+	}
+	m.certs[cert.Name] = &entry{cert: cert}
+}
+
+// List returns the status of all certs ordered by name.
+func (m *Manager) List() []Status {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	names := make([]string, 0, len(m.certs))
+	for name := range m.certs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]Status, 0, len(names))
+	for _, name := range names {
+		result = append(result, m.status(m.certs[name]))
+	}
+	return result
+}
+
+// Get returns the status of the cert named name.
+func (m *Manager) Get(name string) (Status, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e, ok := m.certs[name]
+	if !ok {
+		return Status{}, false
+	}
+	return m.status(e), true
+}
+
+func (m *Manager) status(e *entry) Status {
+	status := Status{
+		Name:       e.cert.Name,
+		Kind:       e.cert.Kind,
+		Rotatable:  e.cert.Rotator != nil,
+		Conditions: append([]Condition(nil), e.conditions...),
+	}
+	cert, err := e.cert.Load()
+	if err != nil {
+		status.Error = err.Error()
+	} else if cert == nil {
+		status.Error = "cert is not loaded"
+	} else {
+		status.Cert = cert
+	}
+	return status
+}
+
+// Rotate starts to rotate the cert named name in background, the progress is reported by conditions of its status.
+func (m *Manager) Rotate(name string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e, ok := m.certs[name]
+	if !ok {
+		return fmt.Errorf("cert %s is not found", name)
+	}
+	if e.cert.Rotator == nil {
+		return fmt.Errorf("cert %s can't be rotated by kuscia", name)
+	}
+	if e.rotating {
+		return fmt.Errorf("cert %s is being rotated", name)
+	}
+	current, err := e.cert.Load()
+	if err != nil {
+		return fmt.Errorf("load cert %s failed, %v", name, err)
+	}
+
+	e.rotating = true
+	e.conditions = nil
+	m.setCondition(e, ConditionRotating, ConditionTrue, ReasonStarted, "")
+	m.setCondition(e, ConditionIssued, ConditionUnknown, "", "")
+	m.setCondition(e, ConditionApplied, ConditionUnknown, "", "")
+	go m.rotate(e, e.cert, current)
+	return nil
+}
+
+func (m *Manager) rotate(e *entry, cert Cert, current *x509.Certificate) {
+	ctx, cancel := context.WithTimeout(context.Background(), rotateTimeout)
+	defer cancel()
+
+	issued, err := cert.Rotator.Issue(ctx, current)
+	if err != nil {
+		m.finish(e, ConditionIssued, fmt.Sprintf("issue cert failed, %v", err))
+		return
+	}
+	m.update(e, ConditionIssued, ConditionTrue, ReasonSucceeded, fmt.Sprintf("new cert %s expires at %s",
+		Fingerprint(issued.Cert), issued.Cert.NotAfter.UTC().Format(time.RFC3339)))
+
+	applied, err := cert.Rotator.Apply(ctx, issued)
+	if err != nil {
+		m.finish(e, ConditionApplied, fmt.Sprintf("apply cert failed, %v", err))
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e.rotating = false
+	if applied {
+		m.setCondition(e, ConditionApplied, ConditionTrue, ReasonSucceeded, "")
+	} else {
+		m.setCondition(e, ConditionApplied, ConditionFalse, ReasonRestartRequired,
+			"new cert is saved, it takes effect after kuscia restarts")
+	}
+	m.setCondition(e, ConditionRotating, ConditionFalse, ReasonSucceeded, "")
+	nlog.Infof("Cert %s is rotated, new cert expires at %s", cert.Name, issued.Cert.NotAfter.UTC().Format(time.RFC3339))
+}
+
+// finish marks the rotation failed at step conditionType.
+func (m *Manager) finish(e *entry, conditionType, message string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e.rotating = false
+	m.setCondition(e, conditionType, ConditionFalse, ReasonFailed, message)
+	m.setCondition(e, ConditionRotating, ConditionFalse, ReasonFailed, message)
+	nlog.Warnf("Rotate cert %s failed, %s", e.cert.Name, message)
+}
+
+func (m *Manager) update(e *entry, conditionType, status, reason, message string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.setCondition(e, conditionType, status, reason, message)
+}
+
+func (m *Manager) setCondition(e *entry, conditionType, status, reason, message string) {
+	for i := range e.conditions {
+		cond := &e.conditions[i]
+		if cond.Type != conditionType {
+			continue
+		}
+		if cond.Status != status {
+			cond.LastTransitionTime = m.now()
+		}
+		cond.Status = status
+		cond.Reason = reason
+		cond.Message = message
+		return
+	}
+	e.conditions = append(e.conditions, Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: m.now(),
+	})
+}
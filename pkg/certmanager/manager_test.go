@@ -0,0 +1,170 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+func newTestCA(t *testing.T) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "alice"},
+		NotBefore:             now,
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	cert, err := Reissue(template, key, nil, nil, now)
+	assert.NoError(t, err)
+	return cert, key
+}
+
+type fakeRotator struct {
+	issued   *KeyPair
+	applyErr error
+	applied  bool
+	// release blocks Apply until it's closed.
+	release chan struct{}
+}
+
+func (r *fakeRotator) Issue(ctx context.Context, current *x509.Certificate) (*KeyPair, error) {
+	return r.issued, nil
+}
+
+func (r *fakeRotator) Apply(ctx context.Context, issued *KeyPair) (bool, error) {
+	<-r.release
+	return r.applied, r.applyErr
+}
+
+func conditionsOf(m *Manager, name string) map[string]Condition {
+	status, _ := m.Get(name)
+	result := make(map[string]Condition)
+	for _, cond := range status.Conditions {
+		result[cond.Type] = cond
+	}
+	return result
+}
+
+func TestManagerRotate(t *testing.T) {
+	ca, _ := newTestCA(t)
+	issued, _ := newTestCA(t)
+	var current atomic.Pointer[x509.Certificate]
+	current.Store(ca)
+	rotator := &fakeRotator{issued: &KeyPair{Cert: issued}, applied: true, release: make(chan struct{})}
+
+	m := NewManager()
+	m.Register(Cert{Name: "ca", Kind: KindCA, Load: func() (*x509.Certificate, error) { return current.Load(), nil }, Rotator: rotator})
+	m.Register(Cert{Name: "domain", Kind: KindDomain, Load: func() (*x509.Certificate, error) {
+		return nil, errors.New("file not found")
+	}})
+
+	statuses := m.List()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "ca", statuses[0].Name)
+	assert.True(t, statuses[0].Rotatable)
+	assert.Equal(t, ca, statuses[0].Cert)
+	assert.Equal(t, "domain", statuses[1].Name)
+	assert.False(t, statuses[1].Rotatable)
+	assert.Equal(t, "file not found", statuses[1].Error)
+
+	assert.EqualError(t, m.Rotate("domain"), "cert domain can't be rotated by kuscia")
+	assert.EqualError(t, m.Rotate("unknown"), "cert unknown is not found")
+
+	assert.NoError(t, m.Rotate("ca"))
+	assert.EqualError(t, m.Rotate("ca"), "cert ca is being rotated")
+	assert.Eventually(t, func() bool {
+		return conditionsOf(m, "ca")[ConditionIssued].Status == ConditionTrue
+	}, time.Second, 10*time.Millisecond)
+	conditions := conditionsOf(m, "ca")
+	assert.Equal(t, ConditionTrue, conditions[ConditionRotating].Status)
+	assert.Equal(t, ConditionUnknown, conditions[ConditionApplied].Status)
+
+	close(rotator.release)
+	assert.Eventually(t, func() bool {
+		return conditionsOf(m, "ca")[ConditionRotating].Status == ConditionFalse
+	}, time.Second, 10*time.Millisecond)
+	conditions = conditionsOf(m, "ca")
+	assert.Equal(t, ReasonSucceeded, conditions[ConditionRotating].Reason)
+	assert.Equal(t, ConditionTrue, conditions[ConditionApplied].Status)
+
+	// failure of a step fails the rotation, and the cert could be rotated again
+	rotator.applyErr = errors.New("listener not found")
+	assert.NoError(t, m.Rotate("ca"))
+	assert.Eventually(t, func() bool {
+		return conditionsOf(m, "ca")[ConditionRotating].Status == ConditionFalse
+	}, time.Second, 10*time.Millisecond)
+	conditions = conditionsOf(m, "ca")
+	assert.Equal(t, ReasonFailed, conditions[ConditionRotating].Reason)
+	assert.Equal(t, ConditionFalse, conditions[ConditionApplied].Status)
+	assert.Equal(t, "apply cert failed, listener not found", conditions[ConditionApplied].Message)
+	assert.Equal(t, ConditionTrue, conditions[ConditionIssued].Status)
+}
+
+func TestFileRotator(t *testing.T) {
+	ca, caKey := newTestCA(t)
+	serverKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	server, err := Reissue(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: "KusciaAPI"},
+		NotBefore:   time.Now(),
+		NotAfter:    time.Now().Add(time.Hour),
+		DNSNames:    []string{"kusciaapi"},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, serverKey, ca, caKey, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "kusciaapi-server.crt")
+	rotator := NewFileRotator(serverKey, ca, caKey, file)
+	issued, err := rotator.Issue(context.Background(), server)
+	assert.NoError(t, err)
+	assert.NotEqual(t, server.SerialNumber, issued.Cert.SerialNumber)
+	assert.Equal(t, server.NotAfter.Sub(server.NotBefore), issued.Cert.NotAfter.Sub(issued.Cert.NotBefore))
+	assert.Equal(t, []string{"kusciaapi"}, issued.Cert.DNSNames)
+	assert.NoError(t, issued.Cert.CheckSignatureFrom(ca))
+
+	applied, err := rotator.Apply(context.Background(), issued)
+	assert.NoError(t, err)
+	assert.False(t, applied)
+	saved, err := tlsutils.ParseCert(nil, file)
+	assert.NoError(t, err)
+	assert.Equal(t, Fingerprint(issued.Cert), Fingerprint(saved))
+
+	// certs signed by the old CA are still trusted by the reissued CA
+	rotated, err := NewFileRotator(caKey, nil, nil, "").Issue(context.Background(), ca)
+	assert.NoError(t, err)
+	assert.NoError(t, server.CheckSignatureFrom(rotated.Cert))
+	_, err = NewFileRotator(caKey, nil, nil, "").Apply(context.Background(), rotated)
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certmanager
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var serialNumberLimit = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// Reissue signs a cert for key with the subject, names, usages and validity period of cert, it's valid from now.
+// The cert is self-signed if parent is nil.
+func Reissue(cert *x509.Certificate, key *rsa.PrivateKey, parent *x509.Certificate, parentKey *rsa.PrivateKey,
+	now time.Time) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               cert.Subject,
+		NotBefore:             now,
+		NotAfter:              now.Add(cert.NotAfter.Sub(cert.NotBefore)),
+		KeyUsage:              cert.KeyUsage,
+		ExtKeyUsage:           cert.ExtKeyUsage,
+		IsCA:                  cert.IsCA,
+		BasicConstraintsValid: cert.BasicConstraintsValid,
+		SubjectKeyId:          cert.SubjectKeyId,
+		DNSNames:              cert.DNSNames,
+		IPAddresses:           cert.IPAddresses,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// fileRotator reissues the cert with the same key, so the certs signed by it and the peers trusting it keep working.
+// The new cert is written to the file loaded by kuscia on start.
+type fileRotator struct {
+	key       *rsa.PrivateKey
+	parent    *x509.Certificate
+	parentKey *rsa.PrivateKey
+	file      string
+}
+
+// NewFileRotator returns the rotator of the cert of key saved in file, the cert is signed by parent, or self-signed if
+// parent is nil.
+func NewFileRotator(key *rsa.PrivateKey, parent *x509.Certificate, parentKey *rsa.PrivateKey, file string) Rotator {
+	return &fileRotator{key: key, parent: parent, parentKey: parentKey, file: file}
+}
+
+func (r *fileRotator) Issue(ctx context.Context, current *x509.Certificate) (*KeyPair, error) {
+	cert, err := Reissue(current, r.key, r.parent, r.parentKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{Cert: cert, Key: r.key}, nil
+}
+
+func (r *fileRotator) Apply(ctx context.Context, issued *KeyPair) (bool, error) {
+	if r.file == "" {
+		return false, fmt.Errorf("cert file is not configured")
+	}
+	// the file is replaced by rename, so it's never partially written
+	tmp, err := os.CreateTemp(filepath.Dir(r.file), filepath.Base(r.file)+".*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	if err := pem.Encode(tmp, &pem.Block{Type: "CERTIFICATE", Bytes: issued.Cert.Raw}); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return false, err
+	}
+	return false, os.Rename(tmp.Name(), r.file)
+}
@@ -17,6 +17,13 @@ package common
 
 import "time"
 
+// extended resources of node
+const (
+	// ResourceDataDisk is the space of the data volume of node in bytes, the capacity is the total space and the
+	// allocatable is the free space.
+	ResourceDataDisk = "kuscia.secretflow/data-disk"
+)
+
 // labels
 const (
 	// LabelPortScope represents port usage scope. Its values may be Local, Domain, Cluster. Refer to PortScope for more details.
@@ -64,12 +71,18 @@ const (
 	LabelKusciaDeploymentName     = "kuscia.secretflow/kd-name"
 	LabelKubernetesDeploymentName = "kuscia.secretflow/deployment-name"
 	LabelKusciaOwnerNamespace     = "kuscia.secretflow/owner_namespace"
+	// LabelServingID is the serving which a serving revision belongs to.
+	LabelServingID = "kuscia.secretflow/serving-id"
 
 	LabelNodeName        = "kuscia.secretflow/node"
 	LabelPodUID          = "kuscia.secretflow/pod-uid"
 	LabelOwnerReferences = "kuscia.secretflow/owner-references"
 
 	LabelDomainRoutePartner = "kuscia.secertflow/domainroute-partner"
+	// LabelAppImagePartnerVisible marks the AppImage which is advertised to partners by metadata exchange.
+	LabelAppImagePartnerVisible = "kuscia.secretflow/partner-visible"
+	// LabelTenant is the tenant which owns the KusciaJob or DomainData inside a domain.
+	LabelTenant = "kuscia.secretflow/tenant"
 )
 
 const (
@@ -77,6 +90,8 @@ const (
 	PluginNameConfigRender  = "config-render"
 	PluginNameImageSecurity = "image-security"
 	PluginNameEnvImport     = "env-import"
+	PluginNameDataMeshToken = "datamesh-token"
+	PluginNamePodSecurity   = "pod-security"
 )
 
 type LoadBalancerType string
@@ -129,10 +144,22 @@ const (
 
 	TaskBandwidthLimitAnnotationPrefix = "kuscia.secretflow/bandwidth-limit-"
 
+	// AppImageVisiblePartnersAnnotationKey limits the partners which a partner visible AppImage is advertised to,
+	// the value is a series of domain id join with ','. All partners can see the AppImage if it's empty.
+	AppImageVisiblePartnersAnnotationKey = "kuscia.secretflow/visible-partners"
+	// AppImageCapabilitiesAnnotationKey is the capabilities advertised with the AppImage, join with ','.
+	AppImageCapabilitiesAnnotationKey = "kuscia.secretflow/app-capabilities"
+
 	AccessDomainAnnotationKey = "kuscia.secretflow/access-domain"
 	ProtocolAnnotationKey     = "kuscia.secretflow/protocol"
 	ReadyTimeAnnotationKey    = "kuscia.secretflow/ready-time"
 
+	// ServingRevisionAnnotationKey is the revision name of a serving revision.
+	ServingRevisionAnnotationKey = "kuscia.secretflow/serving-revision"
+	// ServingPreviousRevisionAnnotationKey is the revision receiving traffic before the latest switch, it's used to
+	// roll back the switch.
+	ServingPreviousRevisionAnnotationKey = "kuscia.secretflow/serving-previous-revision"
+
 	ConfigTemplateVolumesAnnotationKey         = "kuscia.secretflow/config-template-volumes"
 	ConfigTemplateValueAnnotationKey           = "kuscia.secretflow/config-template-value-cm-name"
 	ConfigValueCompressFieldsNameAnnotationKey = "kuscia.secretflow/config-value-compress-fields-name"
@@ -142,6 +169,12 @@ const (
 	ComponentSpecAnnotationKey  = "kuscia.secretflow/component-spec"
 	AllocatedPortsAnnotationKey = "kuscia.secretflow/allocated-ports"
 	ImageIDAnnotationKey        = "kuscia.secretflow/image-id"
+	// AppImageAnnotationKey is the name of the AppImage which the pod is launched from.
+	AppImageAnnotationKey = "kuscia.secretflow/app-image"
+
+	// TraceParentAnnotationKey and TraceStateAnnotationKey carry the W3C trace context of a job to its tasks and pods.
+	TraceParentAnnotationKey = "kuscia.secretflow/traceparent"
+	TraceStateAnnotationKey  = "kuscia.secretflow/tracestate"
 )
 
 // Environment variables issued to the pod.
@@ -163,6 +196,7 @@ const (
 	EnvKusciaAPIProtocol   = "KUSCIA_API_PROTOCOL"
 	EnvKusciaAPIToken      = "KUSCIA_API_TOKEN"
 	EnvKusciaDomainKeyData = "KUSCIA_DOMAIN_KEY_DATA"
+	EnvDataMeshToken       = "KUSCIA_DATAMESH_TOKEN"
 )
 
 const (
@@ -233,11 +267,13 @@ const (
 const DomainCsrExtensionID = "1.2.3.4"
 
 const (
-	CertPrefix   = "var/certs/"
-	LogPrefix    = "var/logs/"
-	StdoutPrefix = "var/stdout/"
-	TmpPrefix    = "var/tmp/"
-	ConfPrefix   = "etc/conf/"
+	CertPrefix       = "var/certs/"
+	LogPrefix        = "var/logs/"
+	StdoutPrefix     = "var/stdout/"
+	TmpPrefix        = "var/tmp/"
+	LocalStorePrefix = "var/localstore/"
+	JobHistoryPrefix = "var/job-history/"
+	ConfPrefix       = "etc/conf/"
 )
 
 const (
@@ -54,6 +54,7 @@ func NewGrpcServerBean(config *config.ConfManagerConfig,
 
 func (s *grpcServerBean) Validate(errs *errorcode.Errs) {
 	s.config.MustTLSEnables(errs)
+	s.config.ValidateAccessPolicies(errs)
 }
 
 func (s *grpcServerBean) Init(e framework.ConfBeanRegistry) error {
@@ -66,6 +67,7 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 	opts := []grpc.ServerOption{
 		grpc.ConnectionTimeout(time.Duration(s.config.ConnectTimeout) * time.Second),
 		grpc.UnaryInterceptor(interceptor.GRPCTLSCertInfoInterceptor),
+		grpc.StreamInterceptor(interceptor.GRPCTLSCertInfoStreamInterceptor),
 	}
 	// tls must enabled
 	serverTLSConfig, err := tls.BuildServerTLSConfig(s.config.TLS.RootCA,
@@ -90,6 +92,7 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 	// register grpc server
 	server := grpc.NewServer(opts...)
 	confmanager.RegisterCertificateServiceServer(server, grpchandler.NewCertificateHandler(s.certService))
+	confmanager.RegisterConfigServiceServer(server, grpchandler.NewConfigHandler(s.configService))
 	reflection.Register(server)
 	nlog.Infof("Grpc server listening on %s", addr)
 
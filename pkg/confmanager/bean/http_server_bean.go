@@ -67,7 +67,8 @@ func (s *httpServerBean) Init(e framework.ConfBeanRegistry) error {
 	if err := s.ginBean.Init(e); err != nil {
 		return err
 	}
-	s.ginBean.Use(gin.Recovery(), webinterceptor.HTTPServerLoggingInterceptor(*s.ginBean.Logger))
+	webinterceptor.UseHTTPStandardInterceptors(s.ginBean.Engine, webinterceptor.HTTPMiddlewareConfig{})
+	s.ginBean.Use(webinterceptor.HTTPServerLoggingInterceptor(*s.ginBean.Logger))
 	if err := s.registerGroupRoutes(e); err != nil {
 		return err
 	}
@@ -45,10 +45,11 @@ func injectBean(ctx context.Context, conf *config.ConfManagerConfig, appEngine *
 		DomainKey:       conf.DomainKey,
 	})
 	configService, err := service.NewConfigService(ctx, &service.ConfigServiceConfig{
-		DomainID:   conf.DomainID,
-		DomainKey:  conf.DomainKey,
-		Driver:     conf.Driver,
-		KubeClient: conf.KubeClient,
+		DomainID:       conf.DomainID,
+		DomainKey:      conf.DomainKey,
+		Driver:         conf.Driver,
+		KubeClient:     conf.KubeClient,
+		AccessPolicies: conf.AccessPolicies,
 	})
 	if err != nil {
 		return err
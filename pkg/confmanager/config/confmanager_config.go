@@ -35,6 +35,9 @@ type ConfManagerConfig struct {
 	IdleTimeout    int            `yaml:"idleTimeout,omitempty"`
 	Driver         string         `yaml:"driver,omitempty"`
 	Params         map[string]any `yaml:"params,omitempty"`
+	// AccessPolicies restrict which client can access configs of which namespace,
+	// access isn't restricted if no policy is configured.
+	AccessPolicies []AccessPolicy `yaml:"accessPolicies,omitempty"`
 
 	DomainID        string                 `yaml:"-"`
 	DomainKey       *rsa.PrivateKey        `yaml:"-"`
@@ -44,6 +47,17 @@ type ConfManagerConfig struct {
 	KubeClient      kubernetes.Interface   `yaml:"-"`
 }
 
+// AccessPolicy grants subjects the actions on configs of namespaces.
+type AccessPolicy struct {
+	// Subjects match the common name or organizational unit of client tls cert,
+	// which is the app or service account name, "*" matches all subjects.
+	Subjects []string `yaml:"subjects"`
+	// Namespaces of configs, "default" is the default namespace, "*" matches all namespaces.
+	Namespaces []string `yaml:"namespaces"`
+	// Actions are "read" or "write", "*" matches all actions.
+	Actions []string `yaml:"actions"`
+}
+
 type SAN struct {
 	DNSNames []string `yaml:"dnsNames"`
 	IPs      []string `yaml:"ips"`
@@ -54,6 +68,13 @@ const (
 	DomainPublicKeyName = "domain-public-key"
 )
 
+const (
+	DefaultConfigNamespace = "default"
+	AccessActionRead       = "read"
+	AccessActionWrite      = "write"
+	AccessMatchAll         = "*"
+)
+
 func NewDefaultConfManagerConfig() *ConfManagerConfig {
 	return &ConfManagerConfig{
 		HTTPPort:       8060,
@@ -78,3 +99,16 @@ func (c *ConfManagerConfig) MustTLSEnables(errs *errorcode.Errs) {
 		errs.AppendErr(fmt.Errorf("for confmanager, tls server key should not be empty"))
 	}
 }
+
+func (c *ConfManagerConfig) ValidateAccessPolicies(errs *errorcode.Errs) {
+	for i, p := range c.AccessPolicies {
+		if len(p.Subjects) == 0 || len(p.Namespaces) == 0 || len(p.Actions) == 0 {
+			errs.AppendErr(fmt.Errorf("for confmanager, accessPolicies[%d] subjects, namespaces and actions should not be empty", i))
+		}
+		for _, action := range p.Actions {
+			if action != AccessActionRead && action != AccessActionWrite && action != AccessMatchAll {
+				errs.AppendErr(fmt.Errorf("for confmanager, accessPolicies[%d] action %q is invalid", i, action))
+			}
+		}
+	}
+}
@@ -18,12 +18,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/resources"
@@ -43,6 +46,14 @@ type CRDDriver struct {
 	Ctx context.Context
 	*Config
 	ConfigMapLister listers.ConfigMapLister
+
+	// namespace and root are set for the driver of a config namespace,
+	// root is the driver of default namespace which owns the informer.
+	namespace string
+	root      *CRDDriver
+
+	handlerLock    sync.RWMutex
+	changeHandlers map[string][]ConfigChangeHandler
 }
 
 func NewCRDDriver(ctx context.Context, conf *Config) (Driver, error) {
@@ -56,8 +67,9 @@ func NewCRDDriver(ctx context.Context, conf *Config) (Driver, error) {
 func newCRDDriver(ctx context.Context, conf *Config) (*CRDDriver, error) {
 	nlog.Info("Start initializing cm crd driver")
 	driver := &CRDDriver{
-		Ctx:    ctx,
-		Config: conf,
+		Ctx:            ctx,
+		Config:         conf,
+		changeHandlers: make(map[string][]ConfigChangeHandler),
 	}
 
 	if conf.KubeClient == nil {
@@ -69,6 +81,17 @@ func newCRDDriver(ctx context.Context, conf *Config) (*CRDDriver, error) {
 		kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(conf.KubeClient, 0, informers.WithNamespace(conf.DomainID))
 		configMapInformer := kubeInformerFactory.Core().V1().ConfigMaps()
 		driver.ConfigMapLister = configMapInformer.Lister()
+		configMapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: driver.isDomainConfigMap,
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+					driver.onConfigMapChanged(nil, obj.(*v1.ConfigMap))
+				},
+				UpdateFunc: func(oldObj, newObj interface{}) {
+					driver.onConfigMapChanged(oldObj.(*v1.ConfigMap), newObj.(*v1.ConfigMap))
+				},
+			},
+		})
 		kubeInformerFactory.Start(ctx.Done())
 		kubeInformerFactory.WaitForCacheSync(ctx.Done())
 		nlog.Info("Finish initializing cm configmap informer and syncing cache")
@@ -104,13 +127,19 @@ func (d *CRDDriver) GetConfig(ctx context.Context, key string) (string, bool, er
 }
 
 func (d *CRDDriver) SetConfig(ctx context.Context, data map[string]string) error {
+	_, err := d.setConfig(ctx, data)
+	return err
+}
+
+// setConfig writes data and returns the new versions of keys.
+func (d *CRDDriver) setConfig(ctx context.Context, data map[string]string) (map[string]int64, error) {
 	if len(data) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	cm, err := d.getConfigMap(ctx)
+	cm, exist, err := d.getOrInitConfigMap(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	newCM := cm.DeepCopy()
@@ -119,21 +148,36 @@ func (d *CRDDriver) SetConfig(ctx context.Context, data map[string]string) error
 	}
 
 	if err = checkDataSize(newCM.Data); err != nil {
-		return err
+		return nil, err
 	}
 
+	encValues := make(map[string]string, len(data))
 	for key, value := range data {
 		if len(key) > maxKeyLen {
-			return fmt.Errorf("key[%v] length is %v bytes and exceed the max length %v bytes", key, len(key), maxKeyLen)
+			return nil, fmt.Errorf("key[%v] length is %v bytes and exceed the max length %v bytes", key, len(key), maxKeyLen)
 		}
 		encValue, err := tls.EncryptOAEP(&d.DomainKey.PublicKey, []byte(value))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		newCM.Data[key] = encValue
+		encValues[key] = encValue
 	}
 
-	return resources.PatchConfigMap(ctx, d.KubeClient, cm, newCM)
+	versions, err := d.recordVersions(ctx, encValues, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record config versions, %v", err)
+	}
+	if !exist {
+		_, err = d.KubeClient.CoreV1().ConfigMaps(d.DomainID).Create(ctx, newCM, metav1.CreateOptions{})
+	} else {
+		err = resources.PatchConfigMap(ctx, d.KubeClient, cm, newCM)
+	}
+	if err != nil {
+		d.discardVersions(ctx, versions)
+		return nil, err
+	}
+	return versions, nil
 }
 
 func (d *CRDDriver) ListConfig(ctx context.Context, keys []string) (map[string]string, error) {
@@ -167,32 +211,170 @@ func (d *CRDDriver) ListConfig(ctx context.Context, keys []string) (map[string]s
 }
 
 func (d *CRDDriver) DeleteConfig(ctx context.Context, keys []string) error {
+	_, err := d.deleteConfig(ctx, keys)
+	return err
+}
+
+// deleteConfig deletes keys and returns the new versions of the deleted keys.
+func (d *CRDDriver) deleteConfig(ctx context.Context, keys []string) (map[string]int64, error) {
 	if len(keys) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	cm, err := d.getConfigMap(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(cm.Data) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	newCM := cm.DeepCopy()
+	var deletedKeys []string
 	for _, key := range keys {
-		delete(newCM.Data, key)
+		if _, ok := newCM.Data[key]; ok {
+			delete(newCM.Data, key)
+			deletedKeys = append(deletedKeys, key)
+		}
+	}
+	if len(deletedKeys) == 0 {
+		return nil, nil
+	}
+
+	versions, err := d.recordVersions(ctx, nil, deletedKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record config versions, %v", err)
+	}
+	if err = resources.PatchConfigMap(ctx, d.KubeClient, cm, newCM); err != nil {
+		d.discardVersions(ctx, versions)
+		return nil, err
+	}
+	return versions, nil
+}
+
+// Namespace returns the driver of configs in namespace, configs of a namespace are stored
+// in a separate configmap which is created on first write.
+func (d *CRDDriver) Namespace(namespace string) Driver {
+	root := d
+	if d.root != nil {
+		root = d.root
 	}
+	if namespace == "" {
+		return root
+	}
+	conf := *root.Config
+	conf.ConfigName = fmt.Sprintf("%s-%s", root.ConfigName, namespace)
+	return &CRDDriver{
+		Ctx:             root.Ctx,
+		Config:          &conf,
+		ConfigMapLister: root.ConfigMapLister,
+		namespace:       namespace,
+		root:            root,
+	}
+}
 
-	return resources.PatchConfigMap(ctx, d.KubeClient, cm, newCM)
+// AddChangeHandler registers handler to be notified of config changes in the namespace of driver.
+// Changes are observed by the configmap informer, so the cache must be enabled.
+func (d *CRDDriver) AddChangeHandler(handler ConfigChangeHandler) error {
+	if d.DisableCache {
+		return fmt.Errorf("cm crd driver can't watch config changes when cache is disabled")
+	}
+	root := d
+	if d.root != nil {
+		root = d.root
+	}
+	root.handlerLock.Lock()
+	defer root.handlerLock.Unlock()
+	root.changeHandlers[d.ConfigName] = append(root.changeHandlers[d.ConfigName], handler)
+	return nil
+}
+
+func (d *CRDDriver) isDomainConfigMap(obj interface{}) bool {
+	cm, ok := obj.(*v1.ConfigMap)
+	return ok && (cm.Name == d.ConfigName || strings.HasPrefix(cm.Name, d.ConfigName+"-")) &&
+		!strings.HasSuffix(cm.Name, configVersionsSuffix)
+}
+
+func (d *CRDDriver) onConfigMapChanged(oldCM, newCM *v1.ConfigMap) {
+	d.handlerLock.RLock()
+	handlers := d.changeHandlers[newCM.Name]
+	d.handlerLock.RUnlock()
+	if len(handlers) == 0 {
+		return
+	}
+
+	var oldData map[string]string
+	if oldCM != nil {
+		oldData = oldCM.Data
+	}
+	namespace := strings.TrimPrefix(strings.TrimPrefix(newCM.Name, d.ConfigName), "-")
+	// versions are recorded before configs are written, so they are already in cache
+	latest := d.latestVersions(newCM.Name)
+	changes := make([]ConfigChange, 0)
+	for key, encValue := range newCM.Data {
+		if oldEncValue, ok := oldData[key]; ok && oldEncValue == encValue {
+			continue
+		}
+		value := ""
+		if encValue != "" {
+			plain, err := tls.DecryptOAEP(d.DomainKey, encValue)
+			if err != nil {
+				nlog.Warnf("Failed to decrypt changed config key[%v], skip notifying it, %v", key, err.Error())
+				continue
+			}
+			value = string(plain)
+		}
+		changes = append(changes, ConfigChange{Namespace: namespace, Key: key, Value: value, Version: changeVersion(latest, key, false)})
+	}
+	for key := range oldData {
+		if _, ok := newCM.Data[key]; !ok {
+			changes = append(changes, ConfigChange{Namespace: namespace, Key: key, Deleted: true, Version: changeVersion(latest, key, true)})
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	for _, handler := range handlers {
+		handler(changes)
+	}
+}
+
+// changeVersion returns the latest version of key if it matches the change, otherwise the change isn't versioned.
+func changeVersion(latest map[string]configVersionRecord, key string, deleted bool) int64 {
+	if r, ok := latest[key]; ok && r.Deleted == deleted {
+		return r.Version
+	}
+	return 0
 }
 
 func (d *CRDDriver) getConfigMap(ctx context.Context) (*v1.ConfigMap, error) {
+	cm, _, err := d.getOrInitConfigMap(ctx)
+	return cm, err
+}
+
+// getOrInitConfigMap returns an empty configmap if the configmap of namespace doesn't exist yet.
+func (d *CRDDriver) getOrInitConfigMap(ctx context.Context) (*v1.ConfigMap, bool, error) {
+	var cm *v1.ConfigMap
+	var err error
 	if !d.Config.DisableCache {
-		return d.ConfigMapLister.ConfigMaps(d.DomainID).Get(d.ConfigName)
+		cm, err = d.ConfigMapLister.ConfigMaps(d.DomainID).Get(d.ConfigName)
+	} else {
+		cm, err = d.KubeClient.CoreV1().ConfigMaps(d.DomainID).Get(ctx, d.ConfigName, metav1.GetOptions{})
+	}
+	if err != nil {
+		if d.namespace != "" && k8serrors.IsNotFound(err) {
+			return &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      d.ConfigName,
+					Namespace: d.DomainID,
+				},
+			}, false, nil
+		}
+		return nil, false, err
 	}
-	return d.KubeClient.CoreV1().ConfigMaps(d.DomainID).Get(ctx, d.ConfigName, metav1.GetOptions{})
+	return cm, true, nil
 }
 
 func checkDataSize(data map[string]string) error {
@@ -20,6 +20,7 @@ import (
 	"crypto/rsa"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
@@ -154,3 +155,119 @@ func TestCheckDataSize(t *testing.T) {
 	got := checkDataSize(data)
 	assert.NoError(t, got)
 }
+
+func TestCRDDriver_AddChangeHandler(t *testing.T) {
+	d, err := makeNewCRDDriver(true)
+	assert.NoError(t, err)
+	assert.Error(t, d.AddChangeHandler(func(changes []ConfigChange) {}))
+
+	d, err = makeNewCRDDriver(false)
+	assert.NoError(t, err)
+	var got []ConfigChange
+	assert.NoError(t, d.AddChangeHandler(func(changes []ConfigChange) {
+		got = append(got, changes...)
+	}))
+
+	oldCM, err := d.getConfigMap(context.Background())
+	assert.NoError(t, err)
+	newCM := oldCM.DeepCopy()
+	encValue, err := tls.EncryptOAEP(&d.DomainKey.PublicKey, []byte("new-value"))
+	assert.NoError(t, err)
+	newCM.Data[testKey] = encValue
+	delete(newCM.Data, testKey1)
+
+	d.onConfigMapChanged(oldCM, newCM)
+	assert.ElementsMatch(t, []ConfigChange{
+		{Key: testKey, Value: "new-value"},
+		{Key: testKey1, Deleted: true},
+	}, got)
+}
+
+func TestCRDDriver_Namespace(t *testing.T) {
+	d, err := makeNewCRDDriver(true)
+	assert.NoError(t, err)
+	assert.Equal(t, d, d.Namespace(""))
+
+	nsDriver := d.Namespace("app")
+	value, exist, err := nsDriver.GetConfig(context.Background(), testKey)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	assert.Equal(t, "", value)
+
+	assert.NoError(t, nsDriver.SetConfig(context.Background(), map[string]string{testKey: "ns-value"}))
+	assert.NoError(t, nsDriver.SetConfig(context.Background(), map[string]string{testKey2: "ns-value2"}))
+	value, _, err = nsDriver.GetConfig(context.Background(), testKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns-value", value)
+
+	value, _, err = d.GetConfig(context.Background(), testKey)
+	assert.NoError(t, err)
+	assert.Equal(t, testValue, value)
+}
+
+func TestCRDDriver_Versions(t *testing.T) {
+	d, err := makeNewCRDDriver(true)
+	assert.NoError(t, err)
+	ctx := WithAuthor(context.Background(), "alice-app")
+
+	assert.NoError(t, d.SetConfig(ctx, map[string]string{testKey: "v1"}))
+	assert.NoError(t, d.SetConfig(ctx, map[string]string{testKey: "v2"}))
+	assert.NoError(t, d.DeleteConfig(context.Background(), []string{testKey, "key3"}))
+
+	versions, err := d.ListConfigVersions(ctx, testKey)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 3)
+	assert.Equal(t, int64(1), versions[0].Version)
+	assert.Equal(t, "alice-app", versions[0].Author)
+	assert.True(t, versions[2].Deleted)
+	assert.Equal(t, "", versions[2].Author)
+
+	v, exist, err := d.GetConfigVersion(ctx, testKey, 1)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "v1", v.Value)
+	_, exist, err = d.GetConfigVersion(ctx, testKey, 4)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	// rollback writes the old value as a new version
+	version, err := d.RollbackConfig(ctx, testKey, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), version)
+	value, _, err := d.GetConfig(ctx, testKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", value)
+	version, err = d.RollbackConfig(ctx, testKey, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), version)
+	_, exist, err = d.GetConfig(ctx, testKey)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	_, err = d.RollbackConfig(ctx, testKey, 10)
+	assert.Error(t, err)
+
+	for i := 0; i < maxConfigVersions; i++ {
+		assert.NoError(t, d.SetConfig(ctx, map[string]string{testKey: "value"}))
+	}
+	versions, err = d.ListConfigVersions(ctx, testKey)
+	assert.NoError(t, err)
+	assert.Len(t, versions, maxConfigVersions)
+	assert.Equal(t, int64(6), versions[0].Version)
+}
+
+func TestCRDDriver_ChangeVersion(t *testing.T) {
+	d, err := makeNewCRDDriver(false)
+	assert.NoError(t, err)
+	assert.False(t, d.isDomainConfigMap(&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: d.versionsConfigMapName()}}))
+
+	assert.NoError(t, d.SetConfig(context.Background(), map[string]string{testKey: "new-value"}))
+	assert.Eventually(t, func() bool {
+		_, err := d.ConfigMapLister.ConfigMaps(d.DomainID).Get(d.versionsConfigMapName())
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	latest := d.latestVersions(d.ConfigName)
+	assert.Equal(t, int64(1), changeVersion(latest, testKey, false))
+	assert.Equal(t, int64(0), changeVersion(latest, testKey, true))
+	assert.Equal(t, int64(0), changeVersion(latest, testKey1, false))
+}
@@ -0,0 +1,317 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+const (
+	// configVersionsSuffix is appended to the name of config configmap to get the configmap of versions,
+	// the dot keeps it from being regarded as the configmap of a config namespace.
+	configVersionsSuffix = ".versions"
+	// maxConfigVersions is the number of versions retained for each key.
+	maxConfigVersions = 10
+)
+
+// configVersionRecord is a version of key in the configmap of versions, the value is encrypted as the config.
+type configVersionRecord struct {
+	Version   int64  `json:"version"`
+	Value     string `json:"value,omitempty"`
+	Deleted   bool   `json:"deleted,omitempty"`
+	Author    string `json:"author,omitempty"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// ListConfigVersions implements the Versioner interface, values of versions are not included.
+func (d *CRDDriver) ListConfigVersions(ctx context.Context, key string) ([]ConfigVersion, error) {
+	records, err := d.getVersionRecords(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]ConfigVersion, 0, len(records))
+	for _, r := range records {
+		versions = append(versions, ConfigVersion{
+			Version:   r.Version,
+			Deleted:   r.Deleted,
+			Author:    r.Author,
+			Timestamp: time.UnixMilli(r.Timestamp),
+		})
+	}
+	return versions, nil
+}
+
+// GetConfigVersion implements the Versioner interface.
+func (d *CRDDriver) GetConfigVersion(ctx context.Context, key string, version int64) (*ConfigVersion, bool, error) {
+	records, err := d.getVersionRecords(ctx, key)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, r := range records {
+		if r.Version != version {
+			continue
+		}
+		v := &ConfigVersion{
+			Version:   r.Version,
+			Deleted:   r.Deleted,
+			Author:    r.Author,
+			Timestamp: time.UnixMilli(r.Timestamp),
+		}
+		if r.Value != "" {
+			value, err := tls.DecryptOAEP(d.DomainKey, r.Value)
+			if err != nil {
+				return nil, true, err
+			}
+			v.Value = string(value)
+		}
+		return v, true, nil
+	}
+	return nil, false, nil
+}
+
+// RollbackConfig implements the Versioner interface.
+func (d *CRDDriver) RollbackConfig(ctx context.Context, key string, version int64) (int64, error) {
+	target, exist, err := d.GetConfigVersion(ctx, key, version)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return 0, fmt.Errorf("version %d of key[%v] doesn't exist or has been discarded", version, key)
+	}
+
+	var versions map[string]int64
+	if target.Deleted {
+		versions, err = d.deleteConfig(ctx, []string{key})
+	} else {
+		versions, err = d.setConfig(ctx, map[string]string{key: target.Value})
+	}
+	if err != nil {
+		return 0, err
+	}
+	return versions[key], nil
+}
+
+func (d *CRDDriver) versionsConfigMapName() string {
+	return d.ConfigName + configVersionsSuffix
+}
+
+func (d *CRDDriver) getVersionRecords(ctx context.Context, key string) ([]configVersionRecord, error) {
+	var cm *v1.ConfigMap
+	var err error
+	if !d.Config.DisableCache {
+		cm, err = d.ConfigMapLister.ConfigMaps(d.DomainID).Get(d.versionsConfigMapName())
+	} else {
+		cm, err = d.KubeClient.CoreV1().ConfigMaps(d.DomainID).Get(ctx, d.versionsConfigMapName(), metav1.GetOptions{})
+	}
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decodeVersionRecords(cm.Data, key)
+}
+
+// latestVersions returns the latest version of keys in the configmap of versions from cache, it's used to tell
+// watchers the version of changes.
+func (d *CRDDriver) latestVersions(configName string) map[string]configVersionRecord {
+	latest := map[string]configVersionRecord{}
+	if d.ConfigMapLister == nil {
+		return latest
+	}
+	cm, err := d.ConfigMapLister.ConfigMaps(d.DomainID).Get(configName + configVersionsSuffix)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			nlog.Warnf("Failed to get versions of configmap %s, %v", configName, err)
+		}
+		return latest
+	}
+	for key := range cm.Data {
+		records, err := decodeVersionRecords(cm.Data, key)
+		if err != nil {
+			nlog.Warnf("Failed to decode versions of config key[%v], %v", key, err)
+			continue
+		}
+		if len(records) > 0 {
+			latest[key] = records[len(records)-1]
+		}
+	}
+	return latest
+}
+
+// recordVersions appends a new version for each key of encValues and deletedKeys, it's called before the configs are
+// written, so a watcher is able to find the version of a change. It returns the new versions of keys.
+func (d *CRDDriver) recordVersions(ctx context.Context, encValues map[string]string, deletedKeys []string) (map[string]int64, error) {
+	author := AuthorFromContext(ctx)
+	var versions map[string]int64
+	err := d.updateVersionRecords(ctx, func(data map[string]string) error {
+		versions = make(map[string]int64, len(encValues)+len(deletedKeys))
+		now := time.Now().UnixMilli()
+		appendRecord := func(key string, record configVersionRecord) error {
+			records, err := decodeVersionRecords(data, key)
+			if err != nil {
+				return err
+			}
+			record.Version = 1
+			if len(records) > 0 {
+				record.Version = records[len(records)-1].Version + 1
+			}
+			records = append(records, record)
+			if len(records) > maxConfigVersions {
+				records = records[len(records)-maxConfigVersions:]
+			}
+			versions[key] = record.Version
+			return encodeVersionRecords(data, key, records)
+		}
+		for key, encValue := range encValues {
+			if err := appendRecord(key, configVersionRecord{Value: encValue, Author: author, Timestamp: now}); err != nil {
+				return err
+			}
+		}
+		for _, key := range deletedKeys {
+			if err := appendRecord(key, configVersionRecord{Deleted: true, Author: author, Timestamp: now}); err != nil {
+				return err
+			}
+		}
+		return shrinkVersionRecords(data, versions)
+	})
+	return versions, err
+}
+
+// discardVersions removes the versions recorded for a write which failed.
+func (d *CRDDriver) discardVersions(ctx context.Context, versions map[string]int64) {
+	err := d.updateVersionRecords(ctx, func(data map[string]string) error {
+		for key, version := range versions {
+			records, err := decodeVersionRecords(data, key)
+			if err != nil {
+				return err
+			}
+			kept := records[:0]
+			for _, r := range records {
+				if r.Version != version {
+					kept = append(kept, r)
+				}
+			}
+			if err := encodeVersionRecords(data, key, kept); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		nlog.Warnf("Failed to discard config versions %v of failed write, %v", versions, err)
+	}
+}
+
+// updateVersionRecords updates the configmap of versions with the latest data from api server, so concurrent
+// writes never get the same version.
+func (d *CRDDriver) updateVersionRecords(ctx context.Context, mutate func(data map[string]string) error) error {
+	name := d.versionsConfigMapName()
+	retriable := func(err error) bool {
+		return k8serrors.IsConflict(err) || k8serrors.IsAlreadyExists(err)
+	}
+	return retry.OnError(retry.DefaultRetry, retriable, func() error {
+		cm, err := d.KubeClient.CoreV1().ConfigMaps(d.DomainID).Get(ctx, name, metav1.GetOptions{})
+		exist := true
+		if k8serrors.IsNotFound(err) {
+			cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: d.DomainID}}
+			exist = false
+		} else if err != nil {
+			return err
+		}
+
+		newCM := cm.DeepCopy()
+		if newCM.Data == nil {
+			newCM.Data = make(map[string]string)
+		}
+		if err = mutate(newCM.Data); err != nil {
+			return err
+		}
+		if !exist {
+			_, err = d.KubeClient.CoreV1().ConfigMaps(d.DomainID).Create(ctx, newCM, metav1.CreateOptions{})
+			return err
+		}
+		_, err = d.KubeClient.CoreV1().ConfigMaps(d.DomainID).Update(ctx, newCM, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// shrinkVersionRecords discards the oldest versions of the written keys until the data fits in the size threshold,
+// the latest version of each key is always kept.
+func shrinkVersionRecords(data map[string]string, versions map[string]int64) error {
+	for {
+		v, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if len(v) <= dataSizeThreshold {
+			return nil
+		}
+
+		shrunk := false
+		for key := range versions {
+			records, err := decodeVersionRecords(data, key)
+			if err != nil {
+				return err
+			}
+			if len(records) <= 1 {
+				continue
+			}
+			if err = encodeVersionRecords(data, key, records[1:]); err != nil {
+				return err
+			}
+			shrunk = true
+		}
+		if !shrunk {
+			return fmt.Errorf("config versions data size is %v bytes and exceed the threshold %v bytes", len(v), dataSizeThreshold)
+		}
+	}
+}
+
+func decodeVersionRecords(data map[string]string, key string) ([]configVersionRecord, error) {
+	value, ok := data[key]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var records []configVersionRecord
+	if err := json.Unmarshal([]byte(value), &records); err != nil {
+		return nil, fmt.Errorf("versions of key[%v] are corrupted, %v", key, err)
+	}
+	return records, nil
+}
+
+func encodeVersionRecords(data map[string]string, key string, records []configVersionRecord) error {
+	if len(records) == 0 {
+		delete(data, key)
+		return nil
+	}
+	value, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	data[key] = string(value)
+	return nil
+}
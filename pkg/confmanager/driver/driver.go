@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 )
@@ -29,6 +30,62 @@ type Driver interface {
 	DeleteConfig(ctx context.Context, keys []string) error
 }
 
+// ConfigChange describes a change of one config key.
+type ConfigChange struct {
+	Namespace string
+	Key       string
+	Value     string
+	Deleted   bool
+	// Version is the version of the key after the change, 0 if the driver doesn't keep versions.
+	Version int64
+}
+
+// ConfigChangeHandler is called with the changes observed by the driver.
+type ConfigChangeHandler func(changes []ConfigChange)
+
+// NamespacedDriver is implemented by drivers which are able to isolate configs by namespace.
+type NamespacedDriver interface {
+	Namespace(namespace string) Driver
+}
+
+// ConfigVersion is an immutable version of one config key, every write of the key creates a new version.
+type ConfigVersion struct {
+	Version   int64
+	Value     string
+	Deleted   bool
+	Author    string
+	Timestamp time.Time
+}
+
+// Versioner is implemented by drivers which keep the versions of config writes.
+type Versioner interface {
+	// ListConfigVersions returns the retained versions of key in ascending order.
+	ListConfigVersions(ctx context.Context, key string) ([]ConfigVersion, error)
+	// GetConfigVersion returns the version of key, it returns false if the version doesn't exist or is discarded.
+	GetConfigVersion(ctx context.Context, key string, version int64) (*ConfigVersion, bool, error)
+	// RollbackConfig writes the value of version as a new version of key, or deletes key if it was deleted in the
+	// version. It returns the new version, which is 0 if key is already deleted.
+	RollbackConfig(ctx context.Context, key string, version int64) (int64, error)
+}
+
+type authorKey struct{}
+
+// WithAuthor returns a context whose config writes are recorded as written by author.
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// AuthorFromContext returns the author of config writes in ctx.
+func AuthorFromContext(ctx context.Context) string {
+	author, _ := ctx.Value(authorKey{}).(string)
+	return author
+}
+
+// Watcher is implemented by drivers which are able to notify config changes.
+type Watcher interface {
+	AddChangeHandler(handler ConfigChangeHandler) error
+}
+
 const (
 	CRDDriverType = "crd"
 )
@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"github.com/secretflow/kuscia/pkg/confmanager/service"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/confmanager"
 )
 
@@ -53,3 +54,28 @@ func (h *configHandler) DeleteConfig(ctx context.Context, request *confmanager.D
 func (h *configHandler) BatchQueryConfig(ctx context.Context, request *confmanager.BatchQueryConfigRequest) (*confmanager.BatchQueryConfigResponse, error) {
 	return h.configService.BatchQueryConfig(ctx, request), nil
 }
+
+func (h *configHandler) ListConfigVersions(ctx context.Context, request *confmanager.ListConfigVersionsRequest) (*confmanager.ListConfigVersionsResponse, error) {
+	return h.configService.ListConfigVersions(ctx, request), nil
+}
+
+func (h *configHandler) RollbackConfig(ctx context.Context, request *confmanager.RollbackConfigRequest) (*confmanager.RollbackConfigResponse, error) {
+	return h.configService.RollbackConfig(ctx, request), nil
+}
+
+func (h *configHandler) WatchConfig(request *confmanager.WatchConfigRequest, stream confmanager.ConfigService_WatchConfigServer) error {
+	eventCh := make(chan *confmanager.WatchConfigEventResponse, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for e := range eventCh {
+			if err := stream.Send(e); err != nil {
+				nlog.Errorf("Send config event error: %v", err)
+			}
+		}
+	}()
+	err := h.configService.WatchConfig(stream.Context(), request, eventCh)
+	close(eventCh)
+	<-done
+	return err
+}
@@ -35,7 +35,8 @@ func TLSCertFromGRPCContext(ctx context.Context) (p *pkix.Name) {
 	return p
 }
 
-func newTLSCertContext(ctx context.Context, tlsCert *pkix.Name) context.Context {
+// NewTLSCertContext returns a context carrying tls cert info, which can be got by TLSCertFromGRPCContext.
+func NewTLSCertContext(ctx context.Context, tlsCert *pkix.Name) context.Context {
 	if tlsCert != nil {
 		return context.WithValue(ctx, grpcTLSCertKey, tlsCert)
 	}
@@ -44,18 +45,35 @@ func newTLSCertContext(ctx context.Context, tlsCert *pkix.Name) context.Context
 
 // GRPCTLSCertInfoInterceptor is an interceptor for grpc.It catches tls cert info from grpc context.Context, and you can get tls cert info by TLSCertFromGRPCContext.
 func GRPCTLSCertInfoInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	certInfoFromContext := func(ctx context.Context) *pkix.Name {
-		if p, ok := peer.FromContext(ctx); ok {
-			if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
-				if len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
-					return &tlsInfo.State.VerifiedChains[0][0].Subject
-				}
+	return handler(NewTLSCertContext(ctx, certInfoFromPeer(ctx)), req)
+}
+
+// GRPCTLSCertInfoStreamInterceptor is the stream version of GRPCTLSCertInfoInterceptor.
+func GRPCTLSCertInfoStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &tlsCertServerStream{
+		ServerStream: ss,
+		ctx:          NewTLSCertContext(ss.Context(), certInfoFromPeer(ss.Context())),
+	})
+}
+
+type tlsCertServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tlsCertServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func certInfoFromPeer(ctx context.Context) *pkix.Name {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
+				return &tlsInfo.State.VerifiedChains[0][0].Subject
 			}
 		}
-		return nil
 	}
-
-	return handler(newTLSCertContext(ctx, certInfoFromContext(ctx)), req)
+	return nil
 }
 
 const (
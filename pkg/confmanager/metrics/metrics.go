@@ -0,0 +1,40 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// WatchSubscribers record the number of active config watch subscribers.
+	WatchSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "kuscia_confmanager_watch_subscribers",
+		Help: "Number of active config watch subscribers",
+	})
+
+	// WatchEventsSent record the count of config events pushed to a subscriber.
+	WatchEventsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuscia_confmanager_watch_events_sent_count",
+		Help: "Counts number of config events pushed to subscriber",
+	}, []string{"subscriber"})
+
+	// WatchOverflows record the count of subscribers disconnected because they can't keep up.
+	WatchOverflows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuscia_confmanager_watch_overflow_count",
+		Help: "Counts number of config watch disconnected because of slow subscriber",
+	}, []string{"subscriber"})
+)
@@ -18,15 +18,23 @@ import (
 	"context"
 	"crypto/rsa"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"math"
+	"sync"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/secretflow/kuscia/pkg/confmanager/config"
 	"github.com/secretflow/kuscia/pkg/confmanager/driver"
+	"github.com/secretflow/kuscia/pkg/confmanager/interceptor"
+	"github.com/secretflow/kuscia/pkg/confmanager/metrics"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/tls"
 	"github.com/secretflow/kuscia/pkg/web/utils"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/confmanager"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
 )
@@ -38,18 +46,29 @@ type IConfigService interface {
 	UpdateConfig(context.Context, *confmanager.UpdateConfigRequest) *confmanager.UpdateConfigResponse
 	DeleteConfig(context.Context, *confmanager.DeleteConfigRequest) *confmanager.DeleteConfigResponse
 	BatchQueryConfig(context.Context, *confmanager.BatchQueryConfigRequest) *confmanager.BatchQueryConfigResponse
+	WatchConfig(context.Context, *confmanager.WatchConfigRequest, chan<- *confmanager.WatchConfigEventResponse) error
+	ListConfigVersions(context.Context, *confmanager.ListConfigVersionsRequest) *confmanager.ListConfigVersionsResponse
+	RollbackConfig(context.Context, *confmanager.RollbackConfigRequest) *confmanager.RollbackConfigResponse
 }
 
+// defaultConfigAuthor is the author of configs written by kuscia modules in the same process.
+const defaultConfigAuthor = "kuscia"
+
 type configService struct {
-	driver driver.Driver
+	driver        driver.Driver
+	accessControl *configAccessController
+
+	watchLock sync.Mutex
+	watchHubs map[string]*configWatchHub
 }
 
 type ConfigServiceConfig struct {
-	DomainID     string
-	DomainKey    *rsa.PrivateKey
-	Driver       string
-	DisableCache bool
-	KubeClient   kubernetes.Interface
+	DomainID       string
+	DomainKey      *rsa.PrivateKey
+	Driver         string
+	DisableCache   bool
+	KubeClient     kubernetes.Interface
+	AccessPolicies []config.AccessPolicy
 }
 
 func NewConfigService(ctx context.Context, conf *ConfigServiceConfig) (IConfigService, error) {
@@ -81,7 +100,9 @@ func NewConfigService(ctx context.Context, conf *ConfigServiceConfig) (IConfigSe
 	}
 
 	return &configService{
-		driver: cmDriver,
+		driver:        cmDriver,
+		accessControl: newConfigAccessController(conf.AccessPolicies),
+		watchHubs:     make(map[string]*configWatchHub),
 	}, nil
 }
 
@@ -98,6 +119,12 @@ func generateBase64PublicKeyStr(priKey *rsa.PrivateKey) (string, error) {
 }
 
 func (s *configService) CreateConfig(ctx context.Context, request *confmanager.CreateConfigRequest) *confmanager.CreateConfigResponse {
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionWrite, "CreateConfig")
+	if status != nil {
+		return &confmanager.CreateConfigResponse{Status: status}
+	}
+	ctx = withConfigAuthor(ctx)
+
 	if len(request.Data) == 0 {
 		return &confmanager.CreateConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request data can't be empty"),
@@ -111,7 +138,7 @@ func (s *configService) CreateConfig(ctx context.Context, request *confmanager.C
 				Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, fmt.Sprintf("request data[%v].key can't be empty", i)),
 			}
 		}
-		_, exist, err := s.driver.GetConfig(ctx, d.Key)
+		_, exist, err := cmDriver.GetConfig(ctx, d.Key)
 		if err != nil {
 			return &confmanager.CreateConfigResponse{
 				Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrCreateConfig, fmt.Sprintf("get key[%v] value failed, %v", d.Key, err.Error())),
@@ -125,7 +152,7 @@ func (s *configService) CreateConfig(ctx context.Context, request *confmanager.C
 		data[d.Key] = d.Value
 	}
 
-	if err := s.driver.SetConfig(ctx, data); err != nil {
+	if err := cmDriver.SetConfig(ctx, data); err != nil {
 		return &confmanager.CreateConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrCreateConfig, err.Error()),
 		}
@@ -137,27 +164,94 @@ func (s *configService) CreateConfig(ctx context.Context, request *confmanager.C
 }
 
 func (s *configService) QueryConfig(ctx context.Context, request *confmanager.QueryConfigRequest) *confmanager.QueryConfigResponse {
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionRead, "QueryConfig")
+	if status != nil {
+		return &confmanager.QueryConfigResponse{Status: status}
+	}
+
 	if request.Key == "" {
 		return &confmanager.QueryConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request key can't be empty"),
 		}
 	}
 
-	value, _, err := s.driver.GetConfig(ctx, request.Key)
+	if request.Version < 0 {
+		return &confmanager.QueryConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request version can't be negative"),
+		}
+	}
+	if request.Version > 0 {
+		return s.queryConfigVersion(ctx, cmDriver, request)
+	}
+
+	value, exist, err := cmDriver.GetConfig(ctx, request.Key)
 	if err != nil {
 		return &confmanager.QueryConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrQueryConfig, err.Error()),
 		}
 	}
 
-	return &confmanager.QueryConfigResponse{
+	resp := &confmanager.QueryConfigResponse{
 		Status: utils.BuildSuccessResponseStatus(),
 		Key:    request.Key,
 		Value:  value,
 	}
+	if versioner, ok := cmDriver.(driver.Versioner); ok && exist {
+		versions, err := versioner.ListConfigVersions(ctx, request.Key)
+		if err != nil {
+			nlog.Warnf("Failed to list versions of config key[%v], %v", request.Key, err)
+		} else if len(versions) > 0 && !versions[len(versions)-1].Deleted {
+			latest := versions[len(versions)-1]
+			resp.Version = latest.Version
+			resp.Author = latest.Author
+			resp.Timestamp = latest.Timestamp.Format(time.RFC3339)
+		}
+	}
+	return resp
+}
+
+// queryConfigVersion returns the value of key in the version of request.
+func (s *configService) queryConfigVersion(ctx context.Context, cmDriver driver.Driver, request *confmanager.QueryConfigRequest) *confmanager.QueryConfigResponse {
+	versioner, ok := cmDriver.(driver.Versioner)
+	if !ok {
+		return &confmanager.QueryConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "config driver doesn't support versions"),
+		}
+	}
+	version, exist, err := versioner.GetConfigVersion(ctx, request.Key, request.Version)
+	if err != nil {
+		return &confmanager.QueryConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrQueryConfig, err.Error()),
+		}
+	}
+	if !exist {
+		return &confmanager.QueryConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrQueryConfig, fmt.Sprintf("version %d of key[%v] doesn't exist or has been discarded", request.Version, request.Key)),
+		}
+	}
+	if version.Deleted {
+		return &confmanager.QueryConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrQueryConfig, fmt.Sprintf("key[%v] was deleted in version %d", request.Key, request.Version)),
+		}
+	}
+
+	return &confmanager.QueryConfigResponse{
+		Status:    utils.BuildSuccessResponseStatus(),
+		Key:       request.Key,
+		Value:     version.Value,
+		Version:   version.Version,
+		Author:    version.Author,
+		Timestamp: version.Timestamp.Format(time.RFC3339),
+	}
 }
 
 func (s *configService) UpdateConfig(ctx context.Context, request *confmanager.UpdateConfigRequest) *confmanager.UpdateConfigResponse {
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionWrite, "UpdateConfig")
+	if status != nil {
+		return &confmanager.UpdateConfigResponse{Status: status}
+	}
+	ctx = withConfigAuthor(ctx)
+
 	if len(request.Data) == 0 {
 		return &confmanager.UpdateConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request data can't be empty"),
@@ -174,7 +268,7 @@ func (s *configService) UpdateConfig(ctx context.Context, request *confmanager.U
 		data[d.Key] = d.Value
 	}
 
-	if err := s.driver.SetConfig(ctx, data); err != nil {
+	if err := cmDriver.SetConfig(ctx, data); err != nil {
 		return &confmanager.UpdateConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrUpdateConfig, err.Error()),
 		}
@@ -186,13 +280,19 @@ func (s *configService) UpdateConfig(ctx context.Context, request *confmanager.U
 }
 
 func (s *configService) DeleteConfig(ctx context.Context, request *confmanager.DeleteConfigRequest) *confmanager.DeleteConfigResponse {
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionWrite, "DeleteConfig")
+	if status != nil {
+		return &confmanager.DeleteConfigResponse{Status: status}
+	}
+	ctx = withConfigAuthor(ctx)
+
 	if len(request.Keys) == 0 {
 		return &confmanager.DeleteConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request keys can't be empty"),
 		}
 	}
 
-	if err := s.driver.DeleteConfig(ctx, request.Keys); err != nil {
+	if err := cmDriver.DeleteConfig(ctx, request.Keys); err != nil {
 		return &confmanager.DeleteConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrDeleteConfig, err.Error()),
 		}
@@ -204,13 +304,18 @@ func (s *configService) DeleteConfig(ctx context.Context, request *confmanager.D
 }
 
 func (s *configService) BatchQueryConfig(ctx context.Context, request *confmanager.BatchQueryConfigRequest) *confmanager.BatchQueryConfigResponse {
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionRead, "BatchQueryConfig")
+	if status != nil {
+		return &confmanager.BatchQueryConfigResponse{Status: status}
+	}
+
 	if len(request.Keys) == 0 {
 		return &confmanager.BatchQueryConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request keys can't be empty"),
 		}
 	}
 
-	result, err := s.driver.ListConfig(ctx, request.Keys)
+	result, err := cmDriver.ListConfig(ctx, request.Keys)
 	if err != nil {
 		return &confmanager.BatchQueryConfigResponse{
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrBatchQueryConfig, err.Error()),
@@ -227,3 +332,209 @@ func (s *configService) BatchQueryConfig(ctx context.Context, request *confmanag
 		Data:   data,
 	}
 }
+
+func (s *configService) ListConfigVersions(ctx context.Context, request *confmanager.ListConfigVersionsRequest) *confmanager.ListConfigVersionsResponse {
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionRead, "ListConfigVersions")
+	if status != nil {
+		return &confmanager.ListConfigVersionsResponse{Status: status}
+	}
+
+	if request.Key == "" {
+		return &confmanager.ListConfigVersionsResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request key can't be empty"),
+		}
+	}
+	versioner, ok := cmDriver.(driver.Versioner)
+	if !ok {
+		return &confmanager.ListConfigVersionsResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "config driver doesn't support versions"),
+		}
+	}
+
+	versions, err := versioner.ListConfigVersions(ctx, request.Key)
+	if err != nil {
+		return &confmanager.ListConfigVersionsResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrListConfigVersions, err.Error()),
+		}
+	}
+
+	data := make([]*confmanager.ConfigVersion, 0, len(versions))
+	for _, v := range versions {
+		data = append(data, &confmanager.ConfigVersion{
+			Version:   v.Version,
+			Deleted:   v.Deleted,
+			Author:    v.Author,
+			Timestamp: v.Timestamp.Format(time.RFC3339),
+		})
+	}
+	return &confmanager.ListConfigVersionsResponse{
+		Status:   utils.BuildSuccessResponseStatus(),
+		Key:      request.Key,
+		Versions: data,
+	}
+}
+
+// RollbackConfig writes the value of an old version as a new version, so the rollback itself can be rolled back.
+func (s *configService) RollbackConfig(ctx context.Context, request *confmanager.RollbackConfigRequest) *confmanager.RollbackConfigResponse {
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionWrite, "RollbackConfig")
+	if status != nil {
+		return &confmanager.RollbackConfigResponse{Status: status}
+	}
+	ctx = withConfigAuthor(ctx)
+
+	if request.Key == "" {
+		return &confmanager.RollbackConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request key can't be empty"),
+		}
+	}
+	if request.Version <= 0 {
+		return &confmanager.RollbackConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "request version must be positive"),
+		}
+	}
+	versioner, ok := cmDriver.(driver.Versioner)
+	if !ok {
+		return &confmanager.RollbackConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "config driver doesn't support versions"),
+		}
+	}
+
+	version, err := versioner.RollbackConfig(ctx, request.Key, request.Version)
+	if err != nil {
+		return &confmanager.RollbackConfigResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRollbackConfig, err.Error()),
+		}
+	}
+	nlog.Infof("[Audit] Config key[%v] in namespace %q is rolled back to version %d by %s, new version is %d",
+		request.Key, request.Namespace, request.Version, driver.AuthorFromContext(ctx), version)
+
+	return &confmanager.RollbackConfigResponse{
+		Status:  utils.BuildSuccessResponseStatus(),
+		Key:     request.Key,
+		Version: version,
+	}
+}
+
+// WatchConfig pushes changes of the subscribed keys into eventCh until ctx is done.
+// It returns an error if the subscriber can't keep up, the caller should resume with the last received version.
+func (s *configService) WatchConfig(ctx context.Context, request *confmanager.WatchConfigRequest, eventCh chan<- *confmanager.WatchConfigEventResponse) error {
+	if request.Version < 0 {
+		return errors.New("request version can't be negative")
+	}
+	if request.HeartbeatSeconds < 0 || request.HeartbeatSeconds > math.MaxInt32 {
+		return fmt.Errorf("heartbeat seconds must be in [0,2^31-1]")
+	}
+	for i, key := range request.Keys {
+		if key == "" {
+			return fmt.Errorf("request keys[%v] can't be empty", i)
+		}
+	}
+
+	cmDriver, status := s.namespaceDriver(ctx, request.Namespace, config.AccessActionRead, "WatchConfig")
+	if status != nil {
+		return errors.New(status.Message)
+	}
+	hub, err := s.getWatchHub(request.Namespace, cmDriver)
+	if err != nil {
+		return err
+	}
+
+	sub := newConfigSubscriber(request)
+	replay, err := hub.subscribe(sub, request.Version)
+	if err != nil {
+		return err
+	}
+	defer hub.unsubscribe(sub)
+
+	lastVersion := request.Version
+	for _, event := range replay {
+		eventCh <- event
+		lastVersion = event.Version
+		metrics.WatchEventsSent.WithLabelValues(sub.name).Inc()
+	}
+
+	heartbeat := defaultWatchHeartbeat
+	if request.HeartbeatSeconds > 0 {
+		heartbeat = time.Duration(request.HeartbeatSeconds) * time.Second
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.events:
+			if !ok {
+				if sub.overflow {
+					return fmt.Errorf("subscriber %s is too slow to receive config events, please resume from version %d", sub.name, lastVersion)
+				}
+				return nil
+			}
+			// events may already be replayed from history
+			if event.Version <= lastVersion {
+				continue
+			}
+			eventCh <- event
+			lastVersion = event.Version
+			metrics.WatchEventsSent.WithLabelValues(sub.name).Inc()
+		case <-ticker.C:
+			version := hub.syncedVersion(sub)
+			if version < lastVersion {
+				version = lastVersion
+			}
+			eventCh <- &confmanager.WatchConfigEventResponse{
+				Type:    confmanager.ConfigEventType_CONFIG_HEARTBEAT,
+				Version: version,
+			}
+		}
+	}
+}
+
+func (s *configService) getWatchHub(namespace string, cmDriver driver.Driver) (*configWatchHub, error) {
+	s.watchLock.Lock()
+	defer s.watchLock.Unlock()
+	if hub, ok := s.watchHubs[namespace]; ok {
+		return hub, nil
+	}
+
+	watcher, ok := cmDriver.(driver.Watcher)
+	if !ok {
+		return nil, errors.New("config driver doesn't support watching config changes")
+	}
+	hub := newConfigWatchHub(defaultWatchHistorySize)
+	if err := watcher.AddChangeHandler(hub.publish); err != nil {
+		return nil, err
+	}
+	s.watchHubs[namespace] = hub
+	return hub, nil
+}
+
+// namespaceDriver checks whether the caller can access configs of namespace and returns the driver of namespace.
+func (s *configService) namespaceDriver(ctx context.Context, namespace, action, method string) (driver.Driver, *v1alpha1.Status) {
+	if namespace != "" {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return nil, utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, fmt.Sprintf("request namespace is invalid, %v", errs))
+		}
+	}
+	if !s.accessControl.allow(ctx, namespace, action, method) {
+		return nil, utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrPermissionDenied, fmt.Sprintf("no permission to %s configs in namespace %q", action, namespace))
+	}
+	if namespace == "" {
+		return s.driver, nil
+	}
+	nsDriver, ok := s.driver.(driver.NamespacedDriver)
+	if !ok {
+		return nil, utils.BuildErrorResponseStatus(errorcode.ErrorCode_ConfManagerErrRequestInvalidate, "config driver doesn't support namespace")
+	}
+	return nsDriver.Namespace(namespace), nil
+}
+
+// withConfigAuthor records the common name of client tls cert as the author of config writes.
+func withConfigAuthor(ctx context.Context) context.Context {
+	author := defaultConfigAuthor
+	if subject := interceptor.TLSCertFromGRPCContext(ctx); subject != nil && subject.CommonName != "" {
+		author = subject.CommonName
+	}
+	return driver.WithAuthor(ctx, author)
+}
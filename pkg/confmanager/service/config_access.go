@@ -0,0 +1,80 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/x509/pkix"
+
+	"github.com/secretflow/kuscia/pkg/confmanager/config"
+	"github.com/secretflow/kuscia/pkg/confmanager/interceptor"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// configAccessController decides whether a client can access configs of a namespace.
+// Client is identified by the subject of its tls cert. Calls without client tls cert come from
+// kuscia modules in the same process, they are always allowed.
+type configAccessController struct {
+	policies []config.AccessPolicy
+}
+
+func newConfigAccessController(policies []config.AccessPolicy) *configAccessController {
+	return &configAccessController{policies: policies}
+}
+
+func (c *configAccessController) allow(ctx context.Context, namespace, action, method string) bool {
+	if len(c.policies) == 0 {
+		return true
+	}
+	subject := interceptor.TLSCertFromGRPCContext(ctx)
+	if subject == nil {
+		return true
+	}
+	if namespace == "" {
+		namespace = config.DefaultConfigNamespace
+	}
+
+	for _, p := range c.policies {
+		if matchSubject(p.Subjects, subject) && matchAny(p.Namespaces, namespace) && matchAny(p.Actions, action) {
+			return true
+		}
+	}
+
+	nlog.Warnf("[Audit] Deny %s of subject[cn=%q, ou=%v], action=%s, namespace=%s", method, subject.CommonName, subject.OrganizationalUnit, action, namespace)
+	return false
+}
+
+func matchSubject(subjects []string, name *pkix.Name) bool {
+	for _, s := range subjects {
+		if s == config.AccessMatchAll || s == name.CommonName {
+			return true
+		}
+		for _, ou := range name.OrganizationalUnit {
+			if s == ou {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, p := range patterns {
+		if p == config.AccessMatchAll || p == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/confmanager/config"
+	"github.com/secretflow/kuscia/pkg/confmanager/interceptor"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/confmanager"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+)
+
+func Test_ConfigAccessController_Allow(t *testing.T) {
+	ac := newConfigAccessController([]config.AccessPolicy{
+		{Subjects: []string{"app-a"}, Namespaces: []string{"ns-a"}, Actions: []string{config.AccessActionRead, config.AccessActionWrite}},
+		{Subjects: []string{"*"}, Namespaces: []string{config.DefaultConfigNamespace}, Actions: []string{config.AccessActionRead}},
+	})
+
+	tests := []struct {
+		name      string
+		subject   *pkix.Name
+		namespace string
+		action    string
+		want      bool
+	}{
+		{"in-process call", nil, "ns-a", config.AccessActionWrite, true},
+		{"app-a writes own namespace", &pkix.Name{CommonName: "app-a"}, "ns-a", config.AccessActionWrite, true},
+		{"app-a matched by ou", &pkix.Name{OrganizationalUnit: []string{"app-a"}}, "ns-a", config.AccessActionRead, true},
+		{"app-b reads ns-a", &pkix.Name{CommonName: "app-b"}, "ns-a", config.AccessActionRead, false},
+		{"app-b reads default namespace", &pkix.Name{CommonName: "app-b"}, "", config.AccessActionRead, true},
+		{"app-b writes default namespace", &pkix.Name{CommonName: "app-b"}, "", config.AccessActionWrite, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ac.allow(contextWithSubject(tt.subject), tt.namespace, tt.action, "test"))
+		})
+	}
+
+	assert.True(t, newConfigAccessController(nil).allow(contextWithSubject(&pkix.Name{CommonName: "app-b"}), "ns-a", config.AccessActionWrite, "test"))
+}
+
+func Test_ConfigService_Namespace(t *testing.T) {
+	service, err := makeConfigService()
+	assert.NoError(t, err)
+	cs := service.(*configService)
+	cs.accessControl = newConfigAccessController([]config.AccessPolicy{
+		{Subjects: []string{"app-a"}, Namespaces: []string{"ns-a"}, Actions: []string{"*"}},
+	})
+	ctx := contextWithSubject(&pkix.Name{CommonName: "app-a"})
+
+	createResp := cs.CreateConfig(ctx, &confmanager.CreateConfigRequest{
+		Namespace: "ns-a",
+		Data:      []*confmanager.ConfigData{{Key: "test-key", Value: "ns-value"}},
+	})
+	assert.Equal(t, int32(errorcode.ErrorCode_SUCCESS), createResp.Status.Code)
+
+	queryResp := cs.QueryConfig(ctx, &confmanager.QueryConfigRequest{Namespace: "ns-a", Key: "test-key"})
+	assert.Equal(t, "ns-value", queryResp.Value)
+
+	// default namespace isn't affected and can't be accessed by app-a
+	queryResp = cs.QueryConfig(context.Background(), &confmanager.QueryConfigRequest{Key: "test-key"})
+	assert.Equal(t, "test-value", queryResp.Value)
+	queryResp = cs.QueryConfig(ctx, &confmanager.QueryConfigRequest{Key: "test-key"})
+	assert.Equal(t, int32(errorcode.ErrorCode_ConfManagerErrPermissionDenied), queryResp.Status.Code)
+
+	queryResp = cs.QueryConfig(ctx, &confmanager.QueryConfigRequest{Namespace: "Invalid_NS", Key: "test-key"})
+	assert.Equal(t, int32(errorcode.ErrorCode_ConfManagerErrRequestInvalidate), queryResp.Status.Code)
+}
+
+func contextWithSubject(subject *pkix.Name) context.Context {
+	return interceptor.NewTLSCertContext(context.Background(), subject)
+}
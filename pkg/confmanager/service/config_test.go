@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509/pkix"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/secretflow/kuscia/pkg/confmanager/config"
 	"github.com/secretflow/kuscia/pkg/confmanager/driver"
+	"github.com/secretflow/kuscia/pkg/confmanager/interceptor"
 	"github.com/secretflow/kuscia/pkg/utils/tls"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/confmanager"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
@@ -305,3 +307,41 @@ func Test_ConfigService_BatchQueryConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_ConfigService_Versions(t *testing.T) {
+	s, err := makeConfigService()
+	assert.Nil(t, err)
+	ctx := interceptor.NewTLSCertContext(context.Background(), &pkix.Name{CommonName: "secretflow"})
+	key := "versioned-key"
+
+	got := s.UpdateConfig(ctx, &confmanager.UpdateConfigRequest{Data: []*confmanager.ConfigData{{Key: key, Value: "v1"}}})
+	assert.Equal(t, int32(errorcode.ErrorCode_SUCCESS), got.Status.Code)
+	got = s.UpdateConfig(context.Background(), &confmanager.UpdateConfigRequest{Data: []*confmanager.ConfigData{{Key: key, Value: "v2"}}})
+	assert.Equal(t, int32(errorcode.ErrorCode_SUCCESS), got.Status.Code)
+
+	listResp := s.ListConfigVersions(ctx, &confmanager.ListConfigVersionsRequest{Key: key})
+	assert.Equal(t, int32(errorcode.ErrorCode_SUCCESS), listResp.Status.Code)
+	assert.Len(t, listResp.Versions, 2)
+	assert.Equal(t, "secretflow", listResp.Versions[0].Author)
+	assert.Equal(t, defaultConfigAuthor, listResp.Versions[1].Author)
+
+	queryResp := s.QueryConfig(ctx, &confmanager.QueryConfigRequest{Key: key})
+	assert.Equal(t, "v2", queryResp.Value)
+	assert.Equal(t, int64(2), queryResp.Version)
+	queryResp = s.QueryConfig(ctx, &confmanager.QueryConfigRequest{Key: key, Version: 1})
+	assert.Equal(t, "v1", queryResp.Value)
+	assert.Equal(t, "secretflow", queryResp.Author)
+	queryResp = s.QueryConfig(ctx, &confmanager.QueryConfigRequest{Key: key, Version: 3})
+	assert.Equal(t, int32(errorcode.ErrorCode_ConfManagerErrQueryConfig), queryResp.Status.Code)
+
+	rollbackResp := s.RollbackConfig(ctx, &confmanager.RollbackConfigRequest{Key: key})
+	assert.Equal(t, int32(errorcode.ErrorCode_ConfManagerErrRequestInvalidate), rollbackResp.Status.Code)
+	rollbackResp = s.RollbackConfig(ctx, &confmanager.RollbackConfigRequest{Key: key, Version: 5})
+	assert.Equal(t, int32(errorcode.ErrorCode_ConfManagerErrRollbackConfig), rollbackResp.Status.Code)
+	rollbackResp = s.RollbackConfig(ctx, &confmanager.RollbackConfigRequest{Key: key, Version: 1})
+	assert.Equal(t, int32(errorcode.ErrorCode_SUCCESS), rollbackResp.Status.Code)
+	assert.Equal(t, int64(3), rollbackResp.Version)
+	queryResp = s.QueryConfig(ctx, &confmanager.QueryConfigRequest{Key: key})
+	assert.Equal(t, "v1", queryResp.Value)
+	assert.Equal(t, int64(3), queryResp.Version)
+}
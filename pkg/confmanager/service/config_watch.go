@@ -0,0 +1,178 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/confmanager/driver"
+	"github.com/secretflow/kuscia/pkg/confmanager/metrics"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/confmanager"
+)
+
+const (
+	defaultWatchHistorySize      = 1024
+	defaultWatchSubscriberBuffer = 256
+	defaultWatchHeartbeat        = 30 * time.Second
+	unknownWatchSubscriber       = "unknown"
+)
+
+// configWatchHub fans out config changes to subscribers and keeps a bounded
+// history of recent events, so a subscriber can resume from the last version it received.
+type configWatchHub struct {
+	mu          sync.Mutex
+	version     int64
+	history     []*confmanager.WatchConfigEventResponse
+	historySize int
+	subscribers map[*configSubscriber]struct{}
+}
+
+type configSubscriber struct {
+	name     string
+	keys     map[string]struct{}
+	prefixes []string
+	events   chan *confmanager.WatchConfigEventResponse
+	overflow bool
+}
+
+func newConfigWatchHub(historySize int) *configWatchHub {
+	return &configWatchHub{
+		// seed version with current time, so versions keep increasing across restarts
+		// and a version of previous process is regarded as compacted.
+		version:     time.Now().UnixMilli(),
+		historySize: historySize,
+		subscribers: make(map[*configSubscriber]struct{}),
+	}
+}
+
+func newConfigSubscriber(request *confmanager.WatchConfigRequest) *configSubscriber {
+	sub := &configSubscriber{
+		name:     request.Subscriber,
+		keys:     make(map[string]struct{}, len(request.Keys)),
+		prefixes: request.Prefixes,
+		events:   make(chan *confmanager.WatchConfigEventResponse, defaultWatchSubscriberBuffer),
+	}
+	if sub.name == "" {
+		sub.name = unknownWatchSubscriber
+	}
+	for _, key := range request.Keys {
+		sub.keys[key] = struct{}{}
+	}
+	return sub
+}
+
+func (s *configSubscriber) match(key string) bool {
+	if len(s.keys) == 0 && len(s.prefixes) == 0 {
+		return true
+	}
+	if _, ok := s.keys[key]; ok {
+		return true
+	}
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// subscribe registers the subscriber and returns history events newer than fromVersion.
+func (h *configWatchHub) subscribe(sub *configSubscriber, fromVersion int64) ([]*confmanager.WatchConfigEventResponse, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var replay []*confmanager.WatchConfigEventResponse
+	if fromVersion > 0 {
+		oldest := h.version + 1
+		if len(h.history) > 0 {
+			oldest = h.history[0].Version
+		}
+		if fromVersion > h.version || fromVersion < oldest-1 {
+			return nil, fmt.Errorf("version %d is unavailable, current version is %d, please reload configs and watch from version 0", fromVersion, h.version)
+		}
+		for _, event := range h.history {
+			if event.Version > fromVersion && sub.match(event.Data.Key) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	h.subscribers[sub] = struct{}{}
+	metrics.WatchSubscribers.Inc()
+	return replay, nil
+}
+
+func (h *configWatchHub) unsubscribe(sub *configSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+		metrics.WatchSubscribers.Dec()
+	}
+}
+
+// syncedVersion returns the hub version if the subscriber has consumed all events, otherwise 0.
+func (h *configWatchHub) syncedVersion(sub *configSubscriber) int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if sub.overflow || len(sub.events) > 0 {
+		return 0
+	}
+	return h.version
+}
+
+// publish is registered as driver change handler.
+func (h *configWatchHub) publish(changes []driver.ConfigChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, change := range changes {
+		h.version++
+		event := &confmanager.WatchConfigEventResponse{
+			Type:    confmanager.ConfigEventType_CONFIG_UPDATED,
+			Data:    &confmanager.ConfigData{Key: change.Key, Value: change.Value, Version: change.Version},
+			Version: h.version,
+		}
+		if change.Deleted {
+			event.Type = confmanager.ConfigEventType_CONFIG_DELETED
+			event.Data.Value = ""
+		}
+
+		h.history = append(h.history, event)
+		if len(h.history) > h.historySize {
+			h.history = h.history[len(h.history)-h.historySize:]
+		}
+
+		for sub := range h.subscribers {
+			if !sub.match(change.Key) {
+				continue
+			}
+			select {
+			case sub.events <- event:
+			default:
+				// subscriber can't keep up, disconnect it and let it resume from the last received version
+				sub.overflow = true
+				delete(h.subscribers, sub)
+				close(sub.events)
+				metrics.WatchSubscribers.Dec()
+				metrics.WatchOverflows.WithLabelValues(sub.name).Inc()
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/confmanager/driver"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/confmanager"
+)
+
+func Test_ConfigWatchHub_Publish(t *testing.T) {
+	hub := newConfigWatchHub(2)
+	startVersion := hub.version
+
+	sub := newConfigSubscriber(&confmanager.WatchConfigRequest{Keys: []string{"a"}, Prefixes: []string{"app."}})
+	_, err := hub.subscribe(sub, 0)
+	assert.NoError(t, err)
+
+	hub.publish([]driver.ConfigChange{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}, {Key: "app.x", Deleted: true}})
+	assert.Equal(t, startVersion+3, hub.version)
+	assert.Len(t, hub.history, 2)
+
+	e := <-sub.events
+	assert.Equal(t, "a", e.Data.Key)
+	assert.Equal(t, startVersion+1, e.Version)
+	e = <-sub.events
+	assert.Equal(t, "app.x", e.Data.Key)
+	assert.Equal(t, confmanager.ConfigEventType_CONFIG_DELETED, e.Type)
+	assert.Equal(t, hub.version, hub.syncedVersion(sub))
+
+	// resume from a version still in history
+	resumed := newConfigSubscriber(&confmanager.WatchConfigRequest{})
+	replay, err := hub.subscribe(resumed, startVersion+1)
+	assert.NoError(t, err)
+	assert.Len(t, replay, 2)
+
+	// resume from a compacted version
+	_, err = hub.subscribe(newConfigSubscriber(&confmanager.WatchConfigRequest{}), startVersion)
+	assert.Error(t, err)
+	hub.unsubscribe(sub)
+	hub.unsubscribe(resumed)
+	assert.Len(t, hub.subscribers, 0)
+}
+
+func Test_ConfigWatchHub_Overflow(t *testing.T) {
+	hub := newConfigWatchHub(defaultWatchHistorySize)
+	sub := newConfigSubscriber(&confmanager.WatchConfigRequest{})
+	_, err := hub.subscribe(sub, 0)
+	assert.NoError(t, err)
+
+	changes := make([]driver.ConfigChange, defaultWatchSubscriberBuffer+1)
+	for i := range changes {
+		changes[i] = driver.ConfigChange{Key: "k"}
+	}
+	hub.publish(changes)
+	assert.True(t, sub.overflow)
+	assert.Len(t, hub.subscribers, 0)
+}
+
+func Test_ConfigService_WatchConfig(t *testing.T) {
+	configService, err := makeConfigService()
+	assert.NoError(t, err)
+
+	eventCh := make(chan *confmanager.WatchConfigEventResponse, 1)
+	err = configService.WatchConfig(context.Background(), &confmanager.WatchConfigRequest{Version: -1}, eventCh)
+	assert.Error(t, err)
+
+	// cache is disabled in test service, driver can't watch config changes
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err = configService.WatchConfig(ctx, &confmanager.WatchConfigRequest{}, eventCh)
+	assert.Error(t, err)
+}
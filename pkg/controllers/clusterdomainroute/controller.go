@@ -43,6 +43,7 @@ import (
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
+	"github.com/secretflow/kuscia/pkg/utils/resources"
 )
 
 const (
@@ -79,8 +80,9 @@ type controller struct {
 }
 
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
+	resyncPeriod := config.ResyncPeriodOr(clusterDomainRouteSyncPeriod)
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(config.KubeClient, 0)
-	kusciaInformerFactory := informers.NewSharedInformerFactory(config.KusciaClient, clusterDomainRouteSyncPeriod)
+	kusciaInformerFactory := informers.NewSharedInformerFactory(config.KusciaClient, resyncPeriod)
 	domainInformer := kusciaInformerFactory.Kuscia().V1alpha1().Domains()
 	clusterDomainRouteInformer := kusciaInformerFactory.Kuscia().V1alpha1().ClusterDomainRoutes()
 	domainRouteInformer := kusciaInformerFactory.Kuscia().V1alpha1().DomainRoutes()
@@ -137,7 +139,7 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 				nlog.Debugf("Found clusterdomain(%s) delete", newCdr.Name)
 			},
 		},
-		clusterDomainRouteSyncPeriod,
+		resyncPeriod,
 	)
 	domainInformer.Informer().AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
@@ -159,7 +161,8 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 				if !ok {
 					return
 				}
-				if oldOne.Spec.Cert == newOne.Spec.Cert {
+				if oldOne.Spec.Cert == newOne.Spec.Cert &&
+					reflect.DeepEqual(resources.DomainKeyRotation(oldOne), resources.DomainKeyRotation(newOne)) {
 					return
 				}
 				nlog.Debugf("Sync clusterdomainroute because found domain(%s) update", newOne.Name)
@@ -210,7 +213,10 @@ func (c *controller) runWorker(ctx context.Context) {
 	}
 }
 
-func needDeleteDr(cdr *kusciaapisv1alpha1.ClusterDomainRoute, dr *kusciaapisv1alpha1.DomainRoute) bool {
+// needDeleteDr returns whether the DomainRoute should be recreated, which drops the tokens. The public key changed by
+// key rotation is updated in place, isRotated may be nil if there is no rotation.
+func needDeleteDr(cdr *kusciaapisv1alpha1.ClusterDomainRoute, dr *kusciaapisv1alpha1.DomainRoute,
+	isRotated func(namespace, oldKey, newKey string) bool) bool {
 	if !reflect.DeepEqual(cdr.Spec.Endpoint, dr.Spec.Endpoint) {
 		return true
 	}
@@ -218,10 +224,13 @@ func needDeleteDr(cdr *kusciaapisv1alpha1.ClusterDomainRoute, dr *kusciaapisv1al
 	if cdr.Spec.TokenConfig == nil || dr.Spec.TokenConfig == nil {
 		return cdr.Spec.TokenConfig != dr.Spec.TokenConfig
 	}
-	if cdr.Spec.TokenConfig.DestinationPublicKey != dr.Spec.TokenConfig.DestinationPublicKey {
+	keyChanged := func(namespace, oldKey, newKey string) bool {
+		return oldKey != newKey && (isRotated == nil || !isRotated(namespace, oldKey, newKey))
+	}
+	if keyChanged(cdr.Spec.Destination, dr.Spec.TokenConfig.DestinationPublicKey, cdr.Spec.TokenConfig.DestinationPublicKey) {
 		return true
 	}
-	if cdr.Spec.TokenConfig.SourcePublicKey != dr.Spec.TokenConfig.SourcePublicKey {
+	if keyChanged(cdr.Spec.Source, dr.Spec.TokenConfig.SourcePublicKey, cdr.Spec.TokenConfig.SourcePublicKey) {
 		return true
 	}
 
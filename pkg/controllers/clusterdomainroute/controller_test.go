@@ -76,6 +76,40 @@ func Test_compareSpec(t *testing.T) {
 	assert.True(t, compareSpec(testcdr, testdr))
 }
 
+func Test_needDeleteDr(t *testing.T) {
+	cdr := &kusciaapisv1alpha1.ClusterDomainRoute{
+		Spec: kusciaapisv1alpha1.ClusterDomainRouteSpec{
+			DomainRouteSpec: kusciaapisv1alpha1.DomainRouteSpec{
+				Source:      "alice",
+				Destination: "bob",
+				TokenConfig: &kusciaapisv1alpha1.TokenConfig{
+					SourcePublicKey:      "alice-key-2",
+					DestinationPublicKey: "bob-key",
+				},
+			},
+		},
+	}
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			TokenConfig: &kusciaapisv1alpha1.TokenConfig{
+				SourcePublicKey:      "alice-key-1",
+				DestinationPublicKey: "bob-key",
+			},
+		},
+	}
+	assert.True(t, needDeleteDr(cdr, dr, nil))
+
+	isRotated := func(namespace, oldKey, newKey string) bool {
+		return namespace == "alice" && oldKey == "alice-key-1" && newKey == "alice-key-2"
+	}
+	assert.False(t, needDeleteDr(cdr, dr, isRotated))
+
+	cdr.Spec.TokenConfig.DestinationPublicKey = "bob-key-2"
+	assert.True(t, needDeleteDr(cdr, dr, isRotated))
+}
+
 func createCrtString(t *testing.T) string {
 	rootDir := t.TempDir()
 	caCertFile := filepath.Join(rootDir, "ca.crt")
@@ -17,10 +17,6 @@ package clusterdomainroute
 
 import (
 	"context"
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/base64"
-	"encoding/pem"
 	"fmt"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -68,40 +64,27 @@ func (c *controller) getPublicKeyFromDomain(namespace string) string {
 		nlog.Errorf("Get domain %s error, %s ", namespace, err.Error())
 		return ""
 	}
-	if domain.Spec.Cert != "" {
-		rsaPubData, err := getPublickeyFromCert(domain.Spec.Cert)
-		if err != nil {
-			nlog.Errorf("Domain %s cert format error", namespace)
-			return ""
-		}
-		return base64.StdEncoding.EncodeToString(rsaPubData)
+	if domain.Spec.Cert == "" && resources.DomainKeyRotation(domain) == nil {
+		nlog.Warnf("Domain %s cert is nil", namespace)
+		return ""
 	}
-	nlog.Warnf("Domain %s cert is nil", namespace)
-	return ""
-}
-
-func getPublickeyFromCert(certString string) ([]byte, error) {
-	certPem, err := base64.StdEncoding.DecodeString(certString)
+	rsaPub, err := resources.DomainPublicKey(domain)
 	if err != nil {
-		return nil, err
-	}
-	certData, _ := pem.Decode(certPem)
-	if certData == nil {
-		return nil, fmt.Errorf("%s", "pem Decode fail")
+		nlog.Errorf("Domain %s cert format error", namespace)
+		return ""
 	}
-	cert, err := x509.ParseCertificate(certData.Bytes)
+	return rsaPub
+}
+
+// isPublicKeyRotated returns whether the public key of domain is changed by key rotation, the tokens encrypted by the
+// previous key are still valid during rotation.
+func (c *controller) isPublicKeyRotated(namespace, oldKey, newKey string) bool {
+	domain, err := c.domainLister.Get(namespace)
 	if err != nil {
-		return nil, err
-	}
-	rsaPub, ok := cert.PublicKey.(*rsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("%s", "Cant get publickey from src domain")
-	}
-	block := &pem.Block{
-		Type:  "RSA PUBLIC KEY",
-		Bytes: x509.MarshalPKCS1PublicKey(rsaPub),
+		return false
 	}
-	return pem.EncodeToMemory(block), nil
+	rotation := resources.DomainKeyRotation(domain)
+	return rotation != nil && rotation.PublicKey == newKey && rotation.PreviousPublicKey == oldKey
 }
 
 func (c *controller) syncServiceToken(ctx context.Context, cdr *kusciaapisv1alpha1.ClusterDomainRoute) (bool, error) {
@@ -48,7 +48,7 @@ func (c *controller) checkDomainRoute(ctx context.Context, cdr *kusciaapisv1alph
 	if !metav1.IsControlledBy(dr, cdr) {
 		return false, fmt.Errorf("DomainRoute %s already exists in namespace %s and is not managed by ClusterDomainRoute", drName, namespace)
 	}
-	if needDeleteDr(cdr, dr) {
+	if needDeleteDr(cdr, dr, c.isPublicKeyRotated) {
 		nlog.Infof("Delete domainroute %s/%s", namespace, drName)
 		return true, c.kusciaClient.KusciaV1alpha1().DomainRoutes(namespace).Delete(ctx, dr.Name, metav1.DeleteOptions{})
 	}
@@ -128,6 +128,25 @@ func (c *controller) syncStatusFromDomainroute(cdr *kusciaapisv1alpha1.ClusterDo
 		}
 	}
 
+	if srcdr != nil && cdr.Status.BodyEncryption != srcdr.Status.BodyEncryption {
+		cdr.Status.BodyEncryption = srcdr.Status.BodyEncryption
+		needUpdate = true
+	}
+
+	if srcdr != nil && cdr.Status.HTTP3 != srcdr.Status.HTTP3 {
+		cdr.Status.HTTP3 = srcdr.Status.HTTP3
+		needUpdate = true
+	}
+
+	if srcdr != nil && !reflect.DeepEqual(cdr.Status.HeaderPolicy, srcdr.Status.HeaderPolicy) {
+		cdr.Status.HeaderPolicy = srcdr.Status.HeaderPolicy.DeepCopy()
+		needUpdate = true
+	}
+
+	if srcdr != nil && syncGatewayConditions(&cdr.Status, &srcdr.Status) {
+		needUpdate = true
+	}
+
 	if IsReady(&cdr.Status) && IsTokenHeartBeatTimeout(cdr.Status.TokenStatus.DestinationTokens) {
 		setCondition(&cdr.Status, newCondition(kusciaapisv1alpha1.ClusterDomainRouteReady, corev1.ConditionFalse, "HeartBeatTimeout", "HeartBeatTimeout"))
 		needUpdate = true
@@ -196,6 +215,46 @@ func newCondition(condType kusciaapisv1alpha1.ClusterDomainRouteConditionType, s
 	}
 }
 
+// gatewayConditions are the conditions reported by the gateway of source domain.
+var gatewayConditions = []kusciaapisv1alpha1.ClusterDomainRouteConditionType{
+	kusciaapisv1alpha1.ClusterDomainRouteHandshakeSucceeded,
+	kusciaapisv1alpha1.ClusterDomainRouteTokenValid,
+	kusciaapisv1alpha1.ClusterDomainRouteEndpointReachable,
+	kusciaapisv1alpha1.ClusterDomainRouteTLSVerified,
+}
+
+// syncGatewayConditions copies the conditions and the last error reported by the source gateway, the timestamps and
+// messages are kept as they are so operators see when and why the state changed.
+func syncGatewayConditions(status *kusciaapisv1alpha1.ClusterDomainRouteStatus, srcStatus *kusciaapisv1alpha1.DomainRouteStatus) bool {
+	changed := false
+	for _, condType := range gatewayConditions {
+		for _, src := range srcStatus.Conditions {
+			if src.Type != condType {
+				continue
+			}
+			found := false
+			for i := range status.Conditions {
+				if status.Conditions[i].Type == condType {
+					found = true
+					if status.Conditions[i] != src {
+						status.Conditions[i] = src
+						changed = true
+					}
+				}
+			}
+			if !found {
+				status.Conditions = append(status.Conditions, src)
+				changed = true
+			}
+		}
+	}
+	if !reflect.DeepEqual(status.LastError, srcStatus.LastError) {
+		status.LastError = srcStatus.LastError.DeepCopy()
+		changed = true
+	}
+	return changed
+}
+
 func setCondition(status *kusciaapisv1alpha1.ClusterDomainRouteStatus, condition *kusciaapisv1alpha1.ClusterDomainRouteCondition) bool {
 	for i, v := range status.Conditions {
 		if v.Type == condition.Type {
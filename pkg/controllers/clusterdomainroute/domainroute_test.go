@@ -148,3 +148,35 @@ func TestTokenHeartBeatTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncGatewayConditions(t *testing.T) {
+	now := metav1.Now()
+	status := &kusciaapisv1alpha1.ClusterDomainRouteStatus{
+		Conditions: []kusciaapisv1alpha1.ClusterDomainRouteCondition{
+			{Type: kusciaapisv1alpha1.ClusterDomainRouteReady, Status: "True"},
+		},
+	}
+	srcStatus := &kusciaapisv1alpha1.DomainRouteStatus{
+		Conditions: []kusciaapisv1alpha1.ClusterDomainRouteCondition{
+			{Type: kusciaapisv1alpha1.ClusterDomainRouteHandshakeSucceeded, Status: "False", Reason: "HandshakeFailed",
+				Message: "connection refused", LastTransitionTime: now},
+		},
+		LastError: &kusciaapisv1alpha1.DomainRouteLastError{
+			Condition: kusciaapisv1alpha1.ClusterDomainRouteHandshakeSucceeded,
+			Message:   "connection refused",
+			Time:      now,
+		},
+	}
+
+	assert.Equal(t, true, syncGatewayConditions(status, srcStatus))
+	assert.Equal(t, 2, len(status.Conditions))
+	assert.Equal(t, "HandshakeFailed", status.Conditions[1].Reason)
+	assert.Equal(t, "connection refused", status.LastError.Message)
+	assert.Equal(t, false, syncGatewayConditions(status, srcStatus))
+
+	srcStatus.Conditions[0].Status = "True"
+	srcStatus.Conditions[0].Reason = "HandshakeSucceeded"
+	assert.Equal(t, true, syncGatewayConditions(status, srcStatus))
+	assert.Equal(t, 2, len(status.Conditions))
+	assert.Equal(t, "HandshakeSucceeded", status.Conditions[1].Reason)
+}
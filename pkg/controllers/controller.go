@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,6 +25,12 @@ import (
 
 	"github.com/secretflow/kuscia/pkg/common"
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/imagepolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
+	"github.com/secretflow/kuscia/pkg/utils/jobpolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 )
 
 type IController interface {
@@ -33,6 +40,8 @@ type IController interface {
 }
 
 type ControllerConstruction struct {
+	// Name is the key of controller in controllers overrides of kuscia config, e.g. kusciajob.
+	Name         string
 	NewControler NewControllerFunc
 	CRDNames     []string
 }
@@ -72,4 +81,21 @@ type ControllerConfig struct {
 	KusciaClient          kusciaclientset.Interface
 	EventRecorder         record.EventRecorder
 	EnableWorkloadApprove bool
+	ApprovePolicies       []approval.Policy
+	JobValidator          *jobpolicy.Chain
+	ImageVerifier         *imagepolicy.Verifier
+	Heartbeat             kusciaconfig.HeartbeatConfig
+	JobQueue              jobqueue.Config
+	JobTTLAfterFinished   time.Duration
+	JobHistory            jobhistory.Store
+	// ResyncPeriod is the resync period of informers of the controller, 0 means the default of the controller.
+	ResyncPeriod time.Duration
+}
+
+// ResyncPeriodOr returns the configured resync period, or defaultPeriod if it's not set.
+func (c ControllerConfig) ResyncPeriodOr(defaultPeriod time.Duration) time.Duration {
+	if c.ResyncPeriod > 0 {
+		return c.ResyncPeriod
+	}
+	return defaultPeriod
 }
@@ -28,6 +28,7 @@ import (
 	informerscorev1 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	listerscoordinationv1 "k8s.io/client-go/listers/coordination/v1"
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
 	rbaclisters "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
@@ -42,6 +43,7 @@ import (
 	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
 	kusciaextv1alpha1 "github.com/secretflow/kuscia/pkg/crd/informers/externalversions/kuscia/v1alpha1"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	"github.com/secretflow/kuscia/pkg/utils/resources"
@@ -83,27 +85,33 @@ type Controller struct {
 	domainLister          kuscialistersv1alpha1.DomainLister
 	namespaceLister       listerscorev1.NamespaceLister
 	nodeLister            listerscorev1.NodeLister
+	leaseLister           listerscoordinationv1.LeaseLister
+	gatewayLister         kuscialistersv1alpha1.GatewayLister
 	configmapLister       listerscorev1.ConfigMapLister
 	roleLister            rbaclisters.RoleLister
 	workqueue             workqueue.RateLimitingInterface
 	recorder              record.EventRecorder
 	cacheSyncs            []cache.InformerSynced
+	heartbeat             kusciaconfig.HeartbeatConfig
 }
 
 // NewController returns a controller instance.
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
+	resyncPeriod := config.ResyncPeriodOr(5 * time.Minute)
 	kubeClient := config.KubeClient
 	kusciaClient := config.KusciaClient
 	eventRecorder := config.EventRecorder
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 5*time.Minute)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
 	resourceQuotaInformer := kubeInformerFactory.Core().V1().ResourceQuotas()
 	namespaceInformer := kubeInformerFactory.Core().V1().Namespaces()
 	nodeInformer := kubeInformerFactory.Core().V1().Nodes()
 	configmapInformer := kubeInformerFactory.Core().V1().ConfigMaps()
 	roleInformer := kubeInformerFactory.Rbac().V1().Roles()
+	leaseInformer := kubeInformerFactory.Coordination().V1().Leases()
 
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, 5*time.Minute)
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, resyncPeriod)
 	domainInformer := kusciaInformerFactory.Kuscia().V1alpha1().Domains()
+	gatewayInformer := kusciaInformerFactory.Kuscia().V1alpha1().Gateways()
 
 	cacheSyncs := []cache.InformerSynced{
 		resourceQuotaInformer.Informer().HasSynced,
@@ -112,6 +120,8 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		nodeInformer.Informer().HasSynced,
 		configmapInformer.Informer().HasSynced,
 		roleInformer.Informer().HasSynced,
+		leaseInformer.Informer().HasSynced,
+		gatewayInformer.Informer().HasSynced,
 	}
 	controller := &Controller{
 		RunMode:               config.RunMode,
@@ -125,11 +135,14 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		domainLister:          domainInformer.Lister(),
 		namespaceLister:       namespaceInformer.Lister(),
 		nodeLister:            nodeInformer.Lister(),
+		leaseLister:           leaseInformer.Lister(),
+		gatewayLister:         gatewayInformer.Lister(),
 		configmapLister:       configmapInformer.Lister(),
 		roleLister:            roleInformer.Lister(),
 		workqueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "domain"),
 		recorder:              eventRecorder,
 		cacheSyncs:            cacheSyncs,
+		heartbeat:             config.Heartbeat,
 	}
 
 	controller.ctx, controller.cancel = context.WithCancel(ctx)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"reflect"
 	"sort"
+	"time"
 
 	apicorev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -57,10 +58,18 @@ func (c *Controller) syncDomainStatus(dm *kusciaapisv1alpha1.Domain) error {
 	newStatus := &kusciaapisv1alpha1.DomainStatus{}
 	newStatus.NodeStatuses = c.newDomainNodeStatus(deepCopy)
 	newStatus.DeployTokenStatuses = c.newDomainTokenStatus(deepCopy)
+	// key rotation is recorded by gateway
+	if oldStatus != nil {
+		newStatus.KeyRotation = oldStatus.KeyRotation
+	}
+	newStatus.Conditions = c.newDomainConditions(deepCopy, time.Now())
 	if !c.isDomainStatusEqual(oldStatus, newStatus) {
 		nlog.Infof("Update domain %v status", deepCopy.Name)
 		deepCopy.Status = newStatus
-		return c.updateDomainStatus(deepCopy)
+		if err := c.updateDomainStatus(deepCopy); err != nil {
+			return err
+		}
+		c.recordReadyTransition(dm, oldStatus, newStatus)
 	}
 	return nil
 }
@@ -0,0 +1,166 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	apicorev1 "k8s.io/api/core/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// heartbeatRecordPeriod is the minimal period of refreshing LastHeartbeatTime of a domain condition.
+const heartbeatRecordPeriod = time.Minute
+
+// domainHeartbeat is the latest heartbeat of a domain seen by master.
+type domainHeartbeat struct {
+	lastSeen time.Time
+	// interval is the longest heartbeat interval announced by gateways of the domain.
+	interval time.Duration
+}
+
+// getDomainHeartbeat collects heartbeats of domain from its gateways and the leases of its nodes.
+func (c *Controller) getDomainHeartbeat(domainName string) domainHeartbeat {
+	hb := domainHeartbeat{}
+	if c.gatewayLister != nil {
+		gateways, err := c.gatewayLister.Gateways(domainName).List(labels.Everything())
+		if err != nil {
+			nlog.Warnf("List gateways for domain %v failed, %v", domainName, err)
+		}
+		for _, gw := range gateways {
+			if gw.Status.HeartbeatTime.After(hb.lastSeen) {
+				hb.lastSeen = gw.Status.HeartbeatTime.Time
+			}
+			if interval := time.Duration(gw.Status.HeartbeatIntervalSeconds) * time.Second; interval > hb.interval {
+				hb.interval = interval
+			}
+		}
+	}
+
+	if c.nodeLister != nil && c.leaseLister != nil {
+		nodeReq, _ := labels.NewRequirement(common.LabelNodeNamespace, selection.Equals, []string{domainName})
+		nodes, err := c.nodeLister.List(labels.NewSelector().Add(*nodeReq))
+		if err != nil {
+			nlog.Warnf("List nodes for domain %v failed, %v", domainName, err)
+		}
+		for _, node := range nodes {
+			lease, err := c.leaseLister.Leases(apicorev1.NamespaceNodeLease).Get(node.Name)
+			if err != nil || lease.Spec.RenewTime == nil {
+				continue
+			}
+			if lease.Spec.RenewTime.After(hb.lastSeen) {
+				hb.lastSeen = lease.Spec.RenewTime.Time
+			}
+		}
+	}
+	return hb
+}
+
+// heartbeatTimeout returns how long master waits for heartbeats before the domain is unreachable.
+func (c *Controller) heartbeatTimeout(hb domainHeartbeat) time.Duration {
+	interval := hb.interval
+	if interval <= 0 {
+		interval = c.heartbeat.GetInterval()
+	}
+	return interval * time.Duration(c.heartbeat.GetMissThreshold())
+}
+
+// newDomainConditions judges the liveness of domain by its heartbeats.
+func (c *Controller) newDomainConditions(dm *kusciaapisv1alpha1.Domain, now time.Time) []kusciaapisv1alpha1.DomainCondition {
+	hb := c.getDomainHeartbeat(dm.Name)
+	var oldConditions []kusciaapisv1alpha1.DomainCondition
+	if dm.Status != nil {
+		oldConditions = dm.Status.Conditions
+	}
+	return []kusciaapisv1alpha1.DomainCondition{
+		newReadyCondition(getReadyCondition(oldConditions), hb.lastSeen, c.heartbeatTimeout(hb), now),
+	}
+}
+
+// newReadyCondition builds the Ready condition, LastTransitionTime is kept unless the status changes.
+func newReadyCondition(old *kusciaapisv1alpha1.DomainCondition, lastSeen time.Time, timeout time.Duration, now time.Time) kusciaapisv1alpha1.DomainCondition {
+	cond := kusciaapisv1alpha1.DomainCondition{
+		Type: kusciaapisv1alpha1.DomainReady,
+	}
+	switch {
+	case lastSeen.IsZero():
+		cond.Status = apicorev1.ConditionUnknown
+		cond.Reason = kusciaapisv1alpha1.DomainNeverSeen
+		cond.Message = "No heartbeat has been received from the domain"
+	case now.Sub(lastSeen) > timeout:
+		cond.Status = apicorev1.ConditionFalse
+		cond.Reason = kusciaapisv1alpha1.DomainUnreachable
+		cond.Message = fmt.Sprintf("No heartbeat has been received since %s, timeout is %v", lastSeen.Format(time.RFC3339), timeout)
+		cond.LastHeartbeatTime = apismetav1.NewTime(lastSeen)
+	default:
+		cond.Status = apicorev1.ConditionTrue
+		cond.Reason = kusciaapisv1alpha1.DomainHeartbeatReceived
+		cond.LastHeartbeatTime = apismetav1.NewTime(lastSeen)
+	}
+
+	if old != nil && old.Status == cond.Status {
+		cond.LastTransitionTime = old.LastTransitionTime
+		// avoid updating domain status on every heartbeat
+		if cond.LastHeartbeatTime.Sub(old.LastHeartbeatTime.Time) < heartbeatRecordPeriod {
+			cond.LastHeartbeatTime = old.LastHeartbeatTime
+		}
+	} else {
+		cond.LastTransitionTime = apismetav1.NewTime(now)
+	}
+	return cond
+}
+
+func getReadyCondition(conditions []kusciaapisv1alpha1.DomainCondition) *kusciaapisv1alpha1.DomainCondition {
+	for i := range conditions {
+		if conditions[i].Type == kusciaapisv1alpha1.DomainReady {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// recordReadyTransition emits an event when the domain becomes reachable or unreachable.
+func (c *Controller) recordReadyTransition(dm *kusciaapisv1alpha1.Domain, oldStatus, newStatus *kusciaapisv1alpha1.DomainStatus) {
+	if c.recorder == nil || newStatus == nil {
+		return
+	}
+	newCond := getReadyCondition(newStatus.Conditions)
+	if newCond == nil {
+		return
+	}
+	var oldCond *kusciaapisv1alpha1.DomainCondition
+	if oldStatus != nil {
+		oldCond = getReadyCondition(oldStatus.Conditions)
+	}
+	if oldCond != nil && oldCond.Status == newCond.Status {
+		return
+	}
+
+	switch newCond.Status {
+	case apicorev1.ConditionTrue:
+		c.recorder.Eventf(dm, apicorev1.EventTypeNormal, kusciaapisv1alpha1.DomainHeartbeatReceived,
+			"Domain %s is ready, last heartbeat at %s", dm.Name, newCond.LastHeartbeatTime.Format(time.RFC3339))
+	case apicorev1.ConditionFalse:
+		c.recorder.Eventf(dm, apicorev1.EventTypeWarning, kusciaapisv1alpha1.DomainUnreachable,
+			"Domain %s is unreachable, %s", dm.Name, newCond.Message)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apicorev1 "k8s.io/api/core/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerscoordinationv1 "k8s.io/client-go/listers/coordination/v1"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
+)
+
+func TestNewReadyCondition(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	timeout := time.Minute
+	old := &kusciaapisv1alpha1.DomainCondition{
+		Type:               kusciaapisv1alpha1.DomainReady,
+		Status:             apicorev1.ConditionTrue,
+		LastHeartbeatTime:  apismetav1.NewTime(now.Add(-20 * time.Second)),
+		LastTransitionTime: apismetav1.NewTime(now.Add(-time.Hour)),
+	}
+
+	testCases := []struct {
+		name               string
+		old                *kusciaapisv1alpha1.DomainCondition
+		lastSeen           time.Time
+		wantStatus         apicorev1.ConditionStatus
+		wantReason         string
+		wantHeartbeat      time.Time
+		wantTransitionTime time.Time
+	}{
+		{
+			name:               "never seen",
+			wantStatus:         apicorev1.ConditionUnknown,
+			wantReason:         kusciaapisv1alpha1.DomainNeverSeen,
+			wantTransitionTime: now,
+		},
+		{
+			name:               "heartbeat received",
+			lastSeen:           now.Add(-10 * time.Second),
+			wantStatus:         apicorev1.ConditionTrue,
+			wantReason:         kusciaapisv1alpha1.DomainHeartbeatReceived,
+			wantHeartbeat:      now.Add(-10 * time.Second),
+			wantTransitionTime: now,
+		},
+		{
+			name:               "recent heartbeat time is kept",
+			old:                old,
+			lastSeen:           now.Add(-5 * time.Second),
+			wantStatus:         apicorev1.ConditionTrue,
+			wantReason:         kusciaapisv1alpha1.DomainHeartbeatReceived,
+			wantHeartbeat:      now.Add(-20 * time.Second),
+			wantTransitionTime: now.Add(-time.Hour),
+		},
+		{
+			name:               "heartbeats missed",
+			old:                old,
+			lastSeen:           now.Add(-2 * time.Minute),
+			wantStatus:         apicorev1.ConditionFalse,
+			wantReason:         kusciaapisv1alpha1.DomainUnreachable,
+			wantHeartbeat:      now.Add(-2 * time.Minute),
+			wantTransitionTime: now,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cond := newReadyCondition(tc.old, tc.lastSeen, timeout, now)
+			assert.Equal(t, kusciaapisv1alpha1.DomainReady, cond.Type)
+			assert.Equal(t, tc.wantStatus, cond.Status)
+			assert.Equal(t, tc.wantReason, cond.Reason)
+			assert.True(t, tc.wantHeartbeat.Equal(cond.LastHeartbeatTime.Time))
+			assert.True(t, tc.wantTransitionTime.Equal(cond.LastTransitionTime.Time))
+		})
+	}
+}
+
+func TestGetDomainHeartbeat(t *testing.T) {
+	now := time.Now()
+	gatewayIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	leaseIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	assert.NoError(t, gatewayIndexer.Add(&kusciaapisv1alpha1.Gateway{
+		ObjectMeta: apismetav1.ObjectMeta{Name: "gw-1", Namespace: "alice"},
+		Status: kusciaapisv1alpha1.GatewayStatus{
+			HeartbeatTime:            apismetav1.NewTime(now.Add(-30 * time.Second)),
+			HeartbeatIntervalSeconds: 20,
+		},
+	}))
+	assert.NoError(t, gatewayIndexer.Add(&kusciaapisv1alpha1.Gateway{
+		ObjectMeta: apismetav1.ObjectMeta{Name: "gw-2", Namespace: "bob"},
+		Status: kusciaapisv1alpha1.GatewayStatus{
+			HeartbeatTime: apismetav1.NewTime(now),
+		},
+	}))
+	assert.NoError(t, nodeIndexer.Add(&apicorev1.Node{
+		ObjectMeta: apismetav1.ObjectMeta{Name: "node-1", Labels: map[string]string{common.LabelNodeNamespace: "alice"}},
+	}))
+	renewTime := apismetav1.NewMicroTime(now.Add(-5 * time.Second))
+	assert.NoError(t, leaseIndexer.Add(&coordinationv1.Lease{
+		ObjectMeta: apismetav1.ObjectMeta{Name: "node-1", Namespace: apicorev1.NamespaceNodeLease},
+		Spec:       coordinationv1.LeaseSpec{RenewTime: &renewTime},
+	}))
+
+	c := &Controller{
+		gatewayLister: kuscialistersv1alpha1.NewGatewayLister(gatewayIndexer),
+		nodeLister:    listerscorev1.NewNodeLister(nodeIndexer),
+		leaseLister:   listerscoordinationv1.NewLeaseLister(leaseIndexer),
+		heartbeat:     kusciaconfig.HeartbeatConfig{MissThreshold: 3},
+	}
+
+	hb := c.getDomainHeartbeat("alice")
+	assert.True(t, renewTime.Time.Equal(hb.lastSeen))
+	assert.Equal(t, 20*time.Second, hb.interval)
+	assert.Equal(t, time.Minute, c.heartbeatTimeout(hb))
+
+	hb = c.getDomainHeartbeat("carol")
+	assert.True(t, hb.lastSeen.IsZero())
+	assert.Equal(t, 45*time.Second, c.heartbeatTimeout(hb))
+}
+
+func TestRecordReadyTransition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	c := &Controller{recorder: recorder}
+	dm := makeTestDomain("alice")
+	statusOf := func(status apicorev1.ConditionStatus) *kusciaapisv1alpha1.DomainStatus {
+		return &kusciaapisv1alpha1.DomainStatus{
+			Conditions: []kusciaapisv1alpha1.DomainCondition{{Type: kusciaapisv1alpha1.DomainReady, Status: status}},
+		}
+	}
+
+	c.recordReadyTransition(dm, statusOf(apicorev1.ConditionTrue), statusOf(apicorev1.ConditionTrue))
+	assert.Len(t, recorder.Events, 0)
+
+	c.recordReadyTransition(dm, statusOf(apicorev1.ConditionTrue), statusOf(apicorev1.ConditionFalse))
+	assert.Contains(t, <-recorder.Events, "Warning Unreachable")
+
+	c.recordReadyTransition(dm, nil, statusOf(apicorev1.ConditionTrue))
+	assert.Contains(t, <-recorder.Events, "Normal HeartbeatReceived")
+}
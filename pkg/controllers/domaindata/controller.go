@@ -74,10 +74,11 @@ type Controller struct {
 }
 
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
+	resyncPeriod := config.ResyncPeriodOr(1 * time.Minute)
 	kubeClient := config.KubeClient
 	kusciaClient := config.KusciaClient
 
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, 1*time.Minute)
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, resyncPeriod)
 	domainInformer := kusciaInformerFactory.Kuscia().V1alpha1().Domains()
 	domaindataGrantInformer := kusciaInformerFactory.Kuscia().V1alpha1().DomainDataGrants()
 	domaindataInformer := kusciaInformerFactory.Kuscia().V1alpha1().DomainDatas()
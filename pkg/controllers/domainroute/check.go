@@ -19,8 +19,10 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
+	"golang.org/x/net/http/httpguts"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/secretflow/kuscia/pkg/common"
@@ -84,6 +86,19 @@ func DoValidate(spec *kusciaapisv1alpha1.DomainRouteSpec) error {
 			return fmt.Errorf("field TokenConfig is null")
 		}
 	}
+	if spec.ServerTLS != nil {
+		if len(spec.ServerTLS.ServerNames) == 0 {
+			return fmt.Errorf("field ServerTLS.ServerNames is empty")
+		}
+		if spec.ServerTLS.SecretName == "" {
+			return fmt.Errorf("field ServerTLS.SecretName is empty")
+		}
+	}
+	// request signing derives the signing key from the token
+	if spec.SecurityLevel == kusciaapisv1alpha1.DomainRouteSecurityLevelSigned &&
+		spec.AuthenticationType != kusciaapisv1alpha1.DomainAuthenticationToken {
+		return fmt.Errorf("securityLevel %s requires authenticationType %s", spec.SecurityLevel, kusciaapisv1alpha1.DomainAuthenticationToken)
+	}
 
 	switch spec.AuthenticationType {
 	case kusciaapisv1alpha1.DomainAuthenticationToken:
@@ -128,6 +143,127 @@ func DoValidate(spec *kusciaapisv1alpha1.DomainRouteSpec) error {
 		}
 	}
 
+	if spec.MTUWorkaround != nil {
+		if mss := spec.MTUWorkaround.MaxSegmentSize; mss != 0 && (mss < 536 || mss > 1460) {
+			return fmt.Errorf("maxSegmentSize of mtuWorkaround must be in range [536, 1460]")
+		}
+	}
+
+	if err := validateRetryPolicy(spec.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := validateHeaderPolicy(spec.HeaderPolicy); err != nil {
+		return err
+	}
+
+	return validateRules(spec)
+}
+
+// retryConditions are the retry conditions supported by envoy.
+var retryConditions = map[string]bool{
+	"5xx": true, "gateway-error": true, "reset": true, "reset-before-request": true, "connect-failure": true,
+	"envoy-ratelimited": true, "retriable-4xx": true, "refused-stream": true, "retriable-status-codes": true,
+	"retriable-headers": true, "http3-post-connect-failure": true,
+	"cancelled": true, "deadline-exceeded": true, "internal": true, "resource-exhausted": true, "unavailable": true,
+}
+
+func validateRetryPolicy(policy *kusciaapisv1alpha1.DomainRouteRetryPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	for _, cond := range policy.RetryOn {
+		if !retryConditions[cond] {
+			return fmt.Errorf("retryOn %s of retryPolicy isn't supported", cond)
+		}
+	}
+	if policy.NumRetries != nil && (*policy.NumRetries < 0 || *policy.NumRetries > 10) {
+		return fmt.Errorf("numRetries of retryPolicy must be in range [0, 10]")
+	}
+	if policy.PerTryTimeoutSeconds < 0 {
+		return fmt.Errorf("perTryTimeoutSeconds of retryPolicy must not be negative")
+	}
+	return nil
+}
+
+func validateHeaderPolicy(policy *kusciaapisv1alpha1.DomainRouteHeaderPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if err := validateHeaderRules("request", policy.Request); err != nil {
+		return err
+	}
+	return validateHeaderRules("response", policy.Response)
+}
+
+func validateHeaderRules(direction string, rules *kusciaapisv1alpha1.DomainRouteHeaderRules) error {
+	if rules == nil {
+		return nil
+	}
+	var names []string
+	names = append(names, rules.Allow...)
+	names = append(names, rules.Deny...)
+	for from, to := range rules.Rename {
+		names = append(names, from, to)
+	}
+	for name, value := range rules.Add {
+		names = append(names, name)
+		if !isPrintableHeaderValue(value) {
+			return fmt.Errorf("value of header %s added to %s must be printable ascii", name, direction)
+		}
+	}
+	for _, name := range names {
+		// pseudo headers, e.g. :path, are rejected as they aren't valid tokens
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("header %q in %s rules of headerPolicy is invalid", name, direction)
+		}
+	}
+	return nil
+}
+
+func isPrintableHeaderValue(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; (c < 0x20 || c > 0x7e) && c != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+func validateRules(spec *kusciaapisv1alpha1.DomainRouteSpec) error {
+	names := map[string]bool{}
+	for _, rule := range spec.Rules {
+		if rule.Name == "" || rule.Service == "" {
+			return fmt.Errorf("name and service of rule are required")
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("rule %s is duplicated", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.PathPrefix != "" && !strings.HasPrefix(rule.PathPrefix, "/") {
+			return fmt.Errorf("pathPrefix of rule %s must start with /", rule.Name)
+		}
+		if rule.Port != "" {
+			found := false
+			for _, dp := range spec.Endpoint.Ports {
+				if dp.Name == rule.Port {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("port %s of rule %s isn't found in endpoint ports", rule.Port, rule.Name)
+			}
+		}
+		for _, header := range rule.Headers {
+			if header.Name == "" {
+				return fmt.Errorf("header name of rule %s is empty", rule.Name)
+			}
+			if header.Exact != "" && header.Prefix != "" {
+				return fmt.Errorf("header %s of rule %s can't set both exact and prefix", header.Name, rule.Name)
+			}
+		}
+	}
 	return nil
 }
 
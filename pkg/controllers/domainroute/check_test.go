@@ -172,4 +172,72 @@ func Test_doValidate(t *testing.T) {
 		TokenGenMethod: kusciaapisv1alpha1.TokenGenMethodRSA,
 	}
 	assert.NoError(t, DoValidate(&testcdr.Spec.DomainRouteSpec))
+
+	testcdr.Spec.MTUWorkaround = &kusciaapisv1alpha1.DomainRouteMTUWorkaround{}
+	assert.NoError(t, DoValidate(&testcdr.Spec.DomainRouteSpec))
+	testcdr.Spec.MTUWorkaround.MaxSegmentSize = 1500
+	assert.Error(t, DoValidate(&testcdr.Spec.DomainRouteSpec))
+	testcdr.Spec.MTUWorkaround = nil
+
+	numRetries := int32(3)
+	testcdr.Spec.RetryPolicy = &kusciaapisv1alpha1.DomainRouteRetryPolicy{
+		RetryOn:    []string{"reset", "5xx"},
+		NumRetries: &numRetries,
+	}
+	assert.NoError(t, DoValidate(&testcdr.Spec.DomainRouteSpec))
+	testcdr.Spec.RetryPolicy.RetryOn = append(testcdr.Spec.RetryPolicy.RetryOn, "always")
+	assert.Equal(t, "retryOn always of retryPolicy isn't supported", DoValidate(&testcdr.Spec.DomainRouteSpec).Error())
+	testcdr.Spec.RetryPolicy = nil
+
+	testcdr.Spec.HeaderPolicy = &kusciaapisv1alpha1.DomainRouteHeaderPolicy{
+		Request: &kusciaapisv1alpha1.DomainRouteHeaderRules{
+			Allow:  []string{"X-Trace"},
+			Rename: map[string]string{"x-user": "x-party-user"},
+			Add:    map[string]string{"x-party": "alice"},
+		},
+	}
+	assert.NoError(t, DoValidate(&testcdr.Spec.DomainRouteSpec))
+	testcdr.Spec.HeaderPolicy.Response = &kusciaapisv1alpha1.DomainRouteHeaderRules{Deny: []string{":status"}}
+	assert.Equal(t, `header ":status" in response rules of headerPolicy is invalid`, DoValidate(&testcdr.Spec.DomainRouteSpec).Error())
+	testcdr.Spec.HeaderPolicy.Response = nil
+	testcdr.Spec.HeaderPolicy.Request.Add["x-party"] = "alice\n"
+	assert.Equal(t, "value of header x-party added to request must be printable ascii", DoValidate(&testcdr.Spec.DomainRouteSpec).Error())
+	testcdr.Spec.HeaderPolicy = nil
+
+	testcdr.Spec.SecurityLevel = kusciaapisv1alpha1.DomainRouteSecurityLevelSigned
+	assert.Equal(t, "securityLevel Signed requires authenticationType Token", DoValidate(&testcdr.Spec.DomainRouteSpec).Error())
+	testcdr.Spec.AuthenticationType = kusciaapisv1alpha1.DomainAuthenticationToken
+	assert.NoError(t, DoValidate(&testcdr.Spec.DomainRouteSpec))
+}
+
+func Test_validateRules(t *testing.T) {
+	spec := &kusciaapisv1alpha1.DomainRouteSpec{
+		Source:             "alice",
+		Destination:        "bob",
+		AuthenticationType: kusciaapisv1alpha1.DomainAuthenticationNone,
+		Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+			Host:  "bob.example.com",
+			Ports: []kusciaapisv1alpha1.DomainPort{{Name: "http", Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, Port: 1080}},
+		},
+		Rules: []kusciaapisv1alpha1.DomainRouteRule{
+			{Name: "serving", PathPrefix: "/serving/", Service: "serving", Port: "http"},
+			{Name: "dataproxy", Service: "dataproxy", Headers: []kusciaapisv1alpha1.DomainRouteHeaderMatch{{Name: "x-app", Exact: "dp"}}},
+		},
+	}
+	assert.NoError(t, DoValidate(spec))
+
+	spec.Rules[1].Name = "serving"
+	assert.Equal(t, "rule serving is duplicated", DoValidate(spec).Error())
+	spec.Rules[1].Name = "dataproxy"
+
+	spec.Rules[0].PathPrefix = "serving"
+	assert.Equal(t, "pathPrefix of rule serving must start with /", DoValidate(spec).Error())
+	spec.Rules[0].PathPrefix = "/serving/"
+
+	spec.Rules[0].Port = "grpc"
+	assert.Equal(t, "port grpc of rule serving isn't found in endpoint ports", DoValidate(spec).Error())
+	spec.Rules[0].Port = ""
+
+	spec.Rules[1].Headers[0].Prefix = "d"
+	assert.Error(t, DoValidate(spec))
 }
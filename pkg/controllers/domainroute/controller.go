@@ -63,7 +63,7 @@ type controller struct {
 // NewController returns a new sample controller
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
 	kusciaClient := config.KusciaClient
-	kusciaInformerFactory := informers.NewSharedInformerFactory(kusciaClient, domainRouteSyncPeriod)
+	kusciaInformerFactory := informers.NewSharedInformerFactory(kusciaClient, config.ResyncPeriodOr(domainRouteSyncPeriod))
 	gatewayInformer := kusciaInformerFactory.Kuscia().V1alpha1().Gateways()
 	domainRouteInformer := kusciaInformerFactory.Kuscia().V1alpha1().DomainRoutes()
 	c := &controller{
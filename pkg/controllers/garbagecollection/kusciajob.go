@@ -19,16 +19,21 @@ import (
 	"fmt"
 	"time"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/controllers"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
@@ -41,14 +46,20 @@ const (
 type KusciaJobGCController struct {
 	ctx                   context.Context
 	cancel                context.CancelFunc
+	kubeClient            kubernetes.Interface
 	kusciaClient          kusciaclientset.Interface
 	kusciaInformerFactory kusciainformers.SharedInformerFactory
 	kubeInformerFactory   kubeinformers.SharedInformerFactory
 	kusciaJobLister       kuscialistersv1alpha1.KusciaJobLister
+	kusciaTaskLister      kuscialistersv1alpha1.KusciaTaskLister
 	kusciaTaskSynced      cache.InformerSynced
 	kusciaJobSynced       cache.InformerSynced
 	namespaceSynced       cache.InformerSynced
 	kusciaJobGCDuration   time.Duration
+	// historyStore archives the summary of finished jobs, nil means no history is kept.
+	historyStore jobhistory.Store
+	// archived are the finished jobs archived already, keyed by job uid.
+	archived map[string]bool
 }
 
 func NewKusciaJobGCController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
@@ -61,15 +72,24 @@ func NewKusciaJobGCController(ctx context.Context, config controllers.Controller
 	kusciaTaskInformer := kusciaInformerFactory.Kuscia().V1alpha1().KusciaTasks()
 	namespaceInformer := kubeInformerFactory.Core().V1().Namespaces()
 
+	gcDuration := defaultGCDuration
+	if config.JobTTLAfterFinished > 0 {
+		gcDuration = config.JobTTLAfterFinished
+	}
+
 	gcController := &KusciaJobGCController{
+		kubeClient:            kubeClient,
 		kusciaClient:          kusciaClient,
 		kusciaInformerFactory: kusciaInformerFactory,
 		kubeInformerFactory:   kubeInformerFactory,
 		kusciaJobLister:       kusciaJobInformer.Lister(),
+		kusciaTaskLister:      kusciaTaskInformer.Lister(),
 		kusciaTaskSynced:      kusciaTaskInformer.Informer().HasSynced,
 		kusciaJobSynced:       kusciaJobInformer.Informer().HasSynced,
 		namespaceSynced:       namespaceInformer.Informer().HasSynced,
-		kusciaJobGCDuration:   defaultGCDuration,
+		kusciaJobGCDuration:   gcDuration,
+		historyStore:          config.JobHistory,
+		archived:              map[string]bool{},
 	}
 	gcController.ctx, gcController.cancel = context.WithCancel(ctx)
 	return gcController
@@ -117,19 +137,16 @@ func (kgc *KusciaJobGCController) GarbageCollectKusciaJob(ctx context.Context, t
 			return
 		case <-ticker.C:
 			kusciaJobs, _ := kgc.kusciaJobLister.KusciaJobs(common.KusciaCrossDomain).List(labels.Everything())
-			kusciaJobClient := kgc.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain)
 			for i, kusciaJob := range kusciaJobs {
 				if kusciaJob.Status.CompletionTime != nil {
-					kusciaJobTime := kusciaJob.Status.CompletionTime.Time
-					durationTime := time.Since(kusciaJobTime)
-					if durationTime >= kgc.kusciaJobGCDuration {
-						err := kusciaJobClient.Delete(ctx, kusciaJob.Name, metav1.DeleteOptions{})
-						if err != nil {
+					kgc.archiveKusciaJob(ctx, kusciaJob)
+					durationTime := time.Since(kusciaJob.Status.CompletionTime.Time)
+					if durationTime >= kgc.jobTTL(kusciaJob) {
+						if err := kgc.deleteKusciaJob(ctx, kusciaJob); err != nil {
 							nlog.Errorf("Delete outdated kusciaJob `%s` error: %v", kusciaJob.Name, err)
 							continue
 						}
 						nlog.Infof("Delete outdated kusciaJob `%s` (Outdated duration %v)", kusciaJob.Name, durationTime)
-
 					}
 				}
 				if (i+1)%batchSize == 0 {
@@ -140,3 +157,131 @@ func (kgc *KusciaJobGCController) GarbageCollectKusciaJob(ctx context.Context, t
 		}
 	}
 }
+
+// jobTTL returns the lifetime of the finished job, the ttl of job spec overrides the default one.
+func (kgc *KusciaJobGCController) jobTTL(kusciaJob *v1alpha1.KusciaJob) time.Duration {
+	if kusciaJob.Spec.TTLSecondsAfterFinished != nil {
+		return time.Duration(*kusciaJob.Spec.TTLSecondsAfterFinished) * time.Second
+	}
+	return kgc.kusciaJobGCDuration
+}
+
+// deleteKusciaJob deletes the resources of tasks, the tasks and then the job, so that nothing of the job is left
+// behind if the deletion is interrupted. The summary of job is kept before the job is deleted.
+func (kgc *KusciaJobGCController) deleteKusciaJob(ctx context.Context, kusciaJob *v1alpha1.KusciaJob) error {
+	tasks, err := kgc.kusciaTaskLister.KusciaTasks(common.KusciaCrossDomain).List(
+		labels.SelectorFromSet(labels.Set{common.LabelJobUID: string(kusciaJob.UID)}))
+	if err != nil {
+		return fmt.Errorf("failed to list tasks, %v", err)
+	}
+	for _, task := range tasks {
+		if err := kgc.deleteKusciaTask(ctx, task); err != nil {
+			return err
+		}
+	}
+
+	if kgc.historyStore != nil {
+		record := kgc.newHistoryRecord(ctx, kusciaJob, tasks)
+		now := time.Now()
+		record.DeletionTime = &now
+		if err := kgc.historyStore.Put(ctx, record); err != nil {
+			return fmt.Errorf("failed to archive job, %v", err)
+		}
+	}
+
+	err = kgc.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Delete(ctx, kusciaJob.Name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	delete(kgc.archived, string(kusciaJob.UID))
+	return nil
+}
+
+// archiveKusciaJob archives the finished job once, so that it's in history before it's garbage collected.
+func (kgc *KusciaJobGCController) archiveKusciaJob(ctx context.Context, kusciaJob *v1alpha1.KusciaJob) {
+	if kgc.historyStore == nil || kgc.archived[string(kusciaJob.UID)] {
+		return
+	}
+	tasks, err := kgc.kusciaTaskLister.KusciaTasks(common.KusciaCrossDomain).List(
+		labels.SelectorFromSet(labels.Set{common.LabelJobUID: string(kusciaJob.UID)}))
+	if err != nil {
+		nlog.Warnf("Failed to list tasks of kusciaJob `%s`, %v", kusciaJob.Name, err)
+		return
+	}
+	if err := kgc.historyStore.Put(ctx, kgc.newHistoryRecord(ctx, kusciaJob, tasks)); err != nil {
+		nlog.Warnf("Failed to archive kusciaJob `%s`, %v", kusciaJob.Name, err)
+		return
+	}
+	kgc.archived[string(kusciaJob.UID)] = true
+}
+
+// newHistoryRecord summarizes the job with the resource usage of parties accounted in this cluster.
+func (kgc *KusciaJobGCController) newHistoryRecord(ctx context.Context, kusciaJob *v1alpha1.KusciaJob, tasks []*v1alpha1.KusciaTask) *jobhistory.Record {
+	record := jobhistory.NewRecord(kusciaJob, tasks)
+	for _, party := range record.Parties {
+		jobUsage, err := accounting.LoadJobUsage(ctx, kgc.kubeClient, party, kusciaJob.Name)
+		if err != nil {
+			nlog.Warnf("Failed to load resource usage of kusciaJob `%s` in %s, %v", kusciaJob.Name, party, err)
+			continue
+		}
+		if jobUsage == nil {
+			continue
+		}
+		if record.ResourceUsage == nil {
+			record.ResourceUsage = &accounting.Usage{}
+		}
+		record.ResourceUsage.Add(jobUsage.Total())
+	}
+	return record
+}
+
+func (kgc *KusciaJobGCController) deleteKusciaTask(ctx context.Context, task *v1alpha1.KusciaTask) error {
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{common.LabelTaskUID: string(task.UID)}).String(),
+	}
+	deleteOptions := metav1.DeleteOptions{}
+	coreClient := kgc.kubeClient.CoreV1()
+
+	pods, err := coreClient.Pods(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("failed to list pods of task %s, %v", task.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if err := coreClient.Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOptions); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	services, err := coreClient.Services(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("failed to list services of task %s, %v", task.Name, err)
+	}
+	for _, service := range services.Items {
+		if err := coreClient.Services(service.Namespace).Delete(ctx, service.Name, deleteOptions); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete service %s/%s, %v", service.Namespace, service.Name, err)
+		}
+	}
+
+	configMaps, err := coreClient.ConfigMaps(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("failed to list configmaps of task %s, %v", task.Name, err)
+	}
+	for _, configMap := range configMaps.Items {
+		if err := coreClient.ConfigMaps(configMap.Namespace).Delete(ctx, configMap.Name, deleteOptions); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete configmap %s/%s, %v", configMap.Namespace, configMap.Name, err)
+		}
+	}
+
+	// the task resource group is named after the task
+	err = kgc.kusciaClient.KusciaV1alpha1().TaskResourceGroups().Delete(ctx, task.Name, deleteOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete taskResourceGroup %s, %v", task.Name, err)
+	}
+
+	err = kgc.kusciaClient.KusciaV1alpha1().KusciaTasks(task.Namespace).Delete(ctx, task.Name, deleteOptions)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete task %s, %v", task.Name, err)
+	}
+	nlog.Infof("Delete task `%s` of outdated kusciaJob and its resources", task.Name)
+	return nil
+}
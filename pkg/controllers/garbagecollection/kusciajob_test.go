@@ -28,12 +28,15 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 
 	constants "github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/controllers"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
 )
 
 func makeKusciaJob() *kusciaapisv1alpha1.KusciaJob {
@@ -131,3 +134,88 @@ func Test_GarbageCollectKusciaJob(t *testing.T) {
 		assert.Emptyf(t, kusciaJobs, "Error getting %d KusciaJobs", len(kusciaJobs))
 	}
 }
+
+func Test_jobTTL(t *testing.T) {
+	kgc := &KusciaJobGCController{kusciaJobGCDuration: defaultGCDuration}
+	job := makeKusciaJob()
+	assert.Equal(t, defaultGCDuration, kgc.jobTTL(job))
+
+	ttl := int32(0)
+	job.Spec.TTLSecondsAfterFinished = &ttl
+	assert.Equal(t, time.Duration(0), kgc.jobTTL(job))
+
+	ttl = 3600
+	assert.Equal(t, time.Hour, kgc.jobTTL(job))
+}
+
+func Test_deleteKusciaJob(t *testing.T) {
+	job := makeKusciaJob()
+	job.UID = "job-uid"
+	job.Status.Phase = kusciaapisv1alpha1.KusciaJobSucceeded
+	job.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	task := &kusciaapisv1alpha1.KusciaTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "h",
+			Namespace: constants.KusciaCrossDomain,
+			UID:       "task-uid",
+			Labels:    map[string]string{constants.LabelJobUID: "job-uid"},
+		},
+		Status: kusciaapisv1alpha1.KusciaTaskStatus{Phase: kusciaapisv1alpha1.TaskSucceeded},
+	}
+	trg := &kusciaapisv1alpha1.TaskResourceGroup{ObjectMeta: metav1.ObjectMeta{Name: "h"}}
+	taskLabels := map[string]string{constants.LabelTaskUID: "task-uid"}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "h-0", Namespace: "hello", Labels: taskLabels}}
+	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "h-0-svc", Namespace: "hello", Labels: taskLabels}}
+	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "h-0-cm", Namespace: "hello", Labels: taskLabels}}
+	otherPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "hello"}}
+
+	kubeClient := fake.NewSimpleClientset(pod, service, configMap, otherPod)
+	kusciaClient := kusciafake.NewSimpleClientset(job, task, trg)
+	ctx := context.Background()
+	historyStore, err := jobhistory.New(ctx, t.TempDir())
+	assert.NoError(t, err)
+	defer historyStore.Close()
+	c := NewKusciaJobGCController(ctx, controllers.ControllerConfig{
+		KubeClient:   kubeClient,
+		KusciaClient: kusciaClient,
+		JobHistory:   historyStore,
+	})
+	kgc := c.(*KusciaJobGCController)
+	taskIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, taskIndexer.Add(task))
+	kgc.kusciaTaskLister = kuscialistersv1alpha1.NewKusciaTaskLister(taskIndexer)
+
+	// the finished job is archived before it's deleted
+	kgc.archiveKusciaJob(ctx, job)
+	records, err := historyStore.Query(ctx, &jobhistory.Query{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Nil(t, records[0].DeletionTime)
+
+	assert.NoError(t, kgc.deleteKusciaJob(ctx, job))
+
+	pods, _ := kubeClient.CoreV1().Pods("hello").List(ctx, metav1.ListOptions{})
+	assert.Len(t, pods.Items, 1)
+	assert.Equal(t, "other", pods.Items[0].Name)
+	services, _ := kubeClient.CoreV1().Services("hello").List(ctx, metav1.ListOptions{})
+	assert.Empty(t, services.Items)
+	configMaps, _ := kubeClient.CoreV1().ConfigMaps("hello").List(ctx, metav1.ListOptions{})
+	assert.Empty(t, configMaps.Items)
+	trgs, _ := kusciaClient.KusciaV1alpha1().TaskResourceGroups().List(ctx, metav1.ListOptions{})
+	assert.Empty(t, trgs.Items)
+	tasks, _ := kusciaClient.KusciaV1alpha1().KusciaTasks(constants.KusciaCrossDomain).List(ctx, metav1.ListOptions{})
+	assert.Empty(t, tasks.Items)
+	jobs, _ := kusciaClient.KusciaV1alpha1().KusciaJobs(constants.KusciaCrossDomain).List(ctx, metav1.ListOptions{})
+	assert.Empty(t, jobs.Items)
+
+	records, err = historyStore.Query(ctx, &jobhistory.Query{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	record := records[0]
+	assert.NotNil(t, record.DeletionTime)
+	assert.Equal(t, job.Name, record.JobID)
+	assert.Equal(t, string(kusciaapisv1alpha1.KusciaJobSucceeded), record.Phase)
+	assert.Equal(t, []string{"hello", "world"}, record.Parties)
+	assert.Len(t, record.Tasks, 2)
+	assert.Equal(t, string(kusciaapisv1alpha1.TaskSucceeded), record.Tasks[0].Phase)
+}
@@ -0,0 +1,447 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kusciadeployment
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciav1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	autoscalePeriod                      = 15 * time.Second
+	defaultScaleDownStabilizationSeconds = 300
+	// autoscaleTolerance skips scaling when the ratio of metric to target is within 1±autoscaleTolerance.
+	autoscaleTolerance = 0.1
+
+	reasonScaledUp         = "ScaledUp"
+	reasonScaledDown       = "ScaledDown"
+	reasonFailedGetMetrics = "FailedGetMetrics"
+	reasonFailedScale      = "FailedScale"
+)
+
+type recommendation struct {
+	replicas  int32
+	timestamp time.Time
+}
+
+// autoscaleState is the history of a party deployment kept between autoscaling rounds.
+type autoscaleState struct {
+	recommendations []recommendation
+	// requests is the cumulative upstream requests of envoy clusters at sampleTime.
+	requests   map[string]float64
+	sampleTime time.Time
+}
+
+// autoscaler keeps the states of autoscaled deployments, it's only used by the autoscaling goroutine.
+type autoscaler struct {
+	metrics metricsSource
+	now     func() time.Time
+	states  map[string]*autoscaleState
+}
+
+func newAutoscaler(metrics metricsSource) *autoscaler {
+	return &autoscaler{
+		metrics: metrics,
+		now:     time.Now,
+		states:  make(map[string]*autoscaleState),
+	}
+}
+
+// metricValue is the observed value of a metric and the replicas it asks for.
+type metricValue struct {
+	metric   kusciav1alpha1.AutoscalingMetric
+	value    float64
+	replicas int32
+}
+
+func (m *metricValue) String() string {
+	return fmt.Sprintf("%s %.2f, target %d", m.metric.Type, m.value, m.metric.Target)
+}
+
+// autoscale adjusts replicas of party deployments with autoscaling enabled.
+func (c *Controller) autoscale(ctx context.Context) {
+	kds, err := c.kdLister.KusciaDeployments(common.KusciaCrossDomain).List(labels.Everything())
+	if err != nil {
+		nlog.Warnf("List kuscia deployments for autoscaling failed, %v", err)
+		return
+	}
+
+	round := &autoscaleRound{source: c.autoscaler.metrics}
+	active := make(map[string]bool)
+	for _, kd := range kds {
+		if kd.DeletionTimestamp != nil || !hasAutoscaling(kd) {
+			continue
+		}
+		parties, err := c.selfParties(kd)
+		if err != nil {
+			nlog.Warnf("Get self parties of kuscia deployment %s failed, %v", kd.Name, err)
+			continue
+		}
+		for i := range parties {
+			party := &parties[i]
+			if party.Template.Autoscaling == nil {
+				continue
+			}
+			key := party.DomainID + "/" + generateDeploymentName(kd.Name, party.Role)
+			active[key] = true
+			if err := c.autoscaleParty(ctx, round, kd, party); err != nil {
+				nlog.Warnf("Autoscale deployment %s of kuscia deployment %s failed, %v", key, kd.Name, err)
+			}
+		}
+	}
+
+	for key := range c.autoscaler.states {
+		if !active[key] {
+			delete(c.autoscaler.states, key)
+		}
+	}
+}
+
+func hasAutoscaling(kd *kusciav1alpha1.KusciaDeployment) bool {
+	for _, party := range kd.Spec.Parties {
+		if party.Template.Autoscaling != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// autoscaleRound fetches envoy stats at most once in an autoscaling round.
+type autoscaleRound struct {
+	source  metricsSource
+	fetched bool
+	stats   map[string]*envoyClusterStats
+	err     error
+}
+
+func (r *autoscaleRound) clusterStats(ctx context.Context) (map[string]*envoyClusterStats, error) {
+	if !r.fetched {
+		r.stats, r.err = r.source.clusterStats(ctx)
+		r.fetched = true
+	}
+	return r.stats, r.err
+}
+
+func (c *Controller) autoscaleParty(ctx context.Context, round *autoscaleRound, kd *kusciav1alpha1.KusciaDeployment,
+	party *kusciav1alpha1.KusciaDeploymentParty) error {
+	deployment, err := c.deploymentLister.Deployments(party.DomainID).Get(generateDeploymentName(kd.Name, party.Role))
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if deployment.DeletionTimestamp != nil || deployment.Spec.Replicas == nil {
+		return nil
+	}
+
+	key := deployment.Namespace + "/" + deployment.Name
+	state, ok := c.autoscaler.states[key]
+	if !ok {
+		state = &autoscaleState{}
+		c.autoscaler.states[key] = state
+	}
+
+	as := party.Template.Autoscaling
+	now := c.autoscaler.now()
+	current := *deployment.Spec.Replicas
+	values, err := c.computeMetricValues(ctx, round, deployment, as.Metrics, state, now)
+	if err != nil {
+		c.recorder.Eventf(kd, corev1.EventTypeWarning, reasonFailedGetMetrics,
+			"Get metrics of deployment %s failed, %v", key, err)
+		return err
+	}
+
+	var picked *metricValue
+	for i := range values {
+		if picked == nil || values[i].replicas > picked.replicas {
+			picked = &values[i]
+		}
+	}
+	minReplicas, maxReplicas := autoscalingLimits(as)
+	if picked == nil {
+		// no metric is available yet, only keep replicas in limits
+		if desired := clampReplicas(current, minReplicas, maxReplicas); desired != current {
+			return c.scaleDeployment(ctx, kd, deployment, desired, fmt.Sprintf("replicas limits are [%d, %d]", minReplicas, maxReplicas))
+		}
+		return nil
+	}
+
+	recommended := clampReplicas(picked.replicas, minReplicas, maxReplicas)
+	desired := stabilizeReplicas(state, recommended, current, now, as)
+	desired = clampReplicas(desired, minReplicas, maxReplicas)
+	if desired == current {
+		if recommended != current {
+			nlog.Debugf("Deployment %s recommends %d replicas by %s, hold %d replicas in stabilization window",
+				key, recommended, picked, current)
+		}
+		return nil
+	}
+	return c.scaleDeployment(ctx, kd, deployment, desired, picked.String())
+}
+
+func (c *Controller) scaleDeployment(ctx context.Context, kd *kusciav1alpha1.KusciaDeployment, deployment *appsv1.Deployment,
+	replicas int32, reason string) error {
+	current := *deployment.Spec.Replicas
+	deploymentCopy := deployment.DeepCopy()
+	deploymentCopy.Spec.Replicas = &replicas
+	if _, err := c.kubeClient.AppsV1().Deployments(deployment.Namespace).Update(ctx, deploymentCopy, metav1.UpdateOptions{}); err != nil {
+		c.recorder.Eventf(kd, corev1.EventTypeWarning, reasonFailedScale,
+			"Scale deployment %s/%s from %d to %d failed, %v", deployment.Namespace, deployment.Name, current, replicas, err)
+		return err
+	}
+
+	eventReason := reasonScaledUp
+	if replicas < current {
+		eventReason = reasonScaledDown
+	}
+	c.recorder.Eventf(kd, corev1.EventTypeNormal, eventReason, "Scaled deployment %s/%s from %d to %d, %s",
+		deployment.Namespace, deployment.Name, current, replicas, reason)
+	nlog.Infof("Scaled deployment %s/%s from %d to %d, %s", deployment.Namespace, deployment.Name, current, replicas, reason)
+	return nil
+}
+
+// computeMetricValues returns the replicas asked by each metric, metrics without enough samples are skipped.
+func (c *Controller) computeMetricValues(ctx context.Context, round *autoscaleRound, deployment *appsv1.Deployment,
+	metrics []kusciav1alpha1.AutoscalingMetric, state *autoscaleState, now time.Time) ([]metricValue, error) {
+	current := *deployment.Spec.Replicas
+	var values []metricValue
+	var requests map[string]float64
+	for _, metric := range metrics {
+		var value, ratio float64
+		switch metric.Type {
+		case kusciav1alpha1.AutoscalingMetricRequestsPerSecond:
+			stats, clusters, err := c.serviceClusterStats(ctx, round, deployment, metric.PortName)
+			if err != nil {
+				return nil, err
+			}
+			if requests == nil {
+				requests = make(map[string]float64)
+			}
+			total, found := sumRequests(stats, clusters, requests)
+			if !found {
+				continue
+			}
+			last, elapsed := state.requestsOf(clusters), now.Sub(state.sampleTime).Seconds()
+			if last < 0 || elapsed <= 0 || current == 0 {
+				continue
+			}
+			value = math.Max(total-last, 0) / elapsed
+			ratio = value / float64(current) / float64(metric.Target)
+		case kusciav1alpha1.AutoscalingMetricLatency:
+			stats, clusters, err := c.serviceClusterStats(ctx, round, deployment, metric.PortName)
+			if err != nil {
+				return nil, err
+			}
+			var found bool
+			if value, found = maxLatency(stats, clusters); !found {
+				continue
+			}
+			ratio = value / float64(metric.Target)
+		case kusciav1alpha1.AutoscalingMetricCPU:
+			selector := labels.SelectorFromSet(labels.Set{common.LabelKubernetesDeploymentName: deployment.Name})
+			utilization, found, err := round.source.podCPUUtilization(ctx, deployment.Namespace, selector)
+			if err != nil {
+				return nil, fmt.Errorf("get cpu utilization failed, %v", err)
+			}
+			if !found {
+				continue
+			}
+			value, ratio = utilization, utilization/float64(metric.Target)
+		default:
+			continue
+		}
+
+		replicas := current
+		if math.Abs(ratio-1) > autoscaleTolerance {
+			replicas = int32(math.Ceil(ratio * float64(current)))
+		}
+		values = append(values, metricValue{metric: metric, value: value, replicas: replicas})
+	}
+
+	if requests != nil {
+		state.requests, state.sampleTime = requests, now
+	}
+	return values, nil
+}
+
+// requestsOf returns the cumulative requests of clusters in the last sample, or -1 if any cluster wasn't sampled.
+func (s *autoscaleState) requestsOf(clusters []string) float64 {
+	var total float64
+	for _, cluster := range clusters {
+		v, ok := s.requests[cluster]
+		if !ok {
+			return -1
+		}
+		total += v
+	}
+	return total
+}
+
+// serviceClusterStats returns envoy stats and the envoy clusters of services selecting the deployment.
+func (c *Controller) serviceClusterStats(ctx context.Context, round *autoscaleRound, deployment *appsv1.Deployment,
+	portName string) (map[string]*envoyClusterStats, []string, error) {
+	stats, err := round.clusterStats(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get envoy stats failed, %v", err)
+	}
+	selector := labels.SelectorFromSet(labels.Set{common.LabelKubernetesDeploymentName: deployment.Name})
+	services, err := c.serviceLister.Services(deployment.Namespace).List(selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	var clusters []string
+	for _, svc := range services {
+		if portName != "" && (len(svc.Spec.Ports) == 0 || svc.Spec.Ports[0].Name != portName) {
+			continue
+		}
+		clusters = append(clusters, envoyServiceClusterPrefix+svc.Name)
+	}
+	if len(clusters) == 0 {
+		return nil, nil, fmt.Errorf("no service of port %q found for deployment %s/%s", portName, deployment.Namespace, deployment.Name)
+	}
+	return stats, clusters, nil
+}
+
+func sumRequests(stats map[string]*envoyClusterStats, clusters []string, requests map[string]float64) (float64, bool) {
+	var total float64
+	found := false
+	for _, cluster := range clusters {
+		if s, ok := stats[cluster]; ok && s.hasRequests {
+			total += s.requests
+			requests[cluster] = s.requests
+			found = true
+		}
+	}
+	return total, found
+}
+
+func maxLatency(stats map[string]*envoyClusterStats, clusters []string) (float64, bool) {
+	var latency float64
+	found := false
+	for _, cluster := range clusters {
+		if s, ok := stats[cluster]; ok && s.hasLatency {
+			latency = math.Max(latency, s.latencyMillis)
+			found = true
+		}
+	}
+	return latency, found
+}
+
+func autoscalingLimits(as *kusciav1alpha1.KusciaDeploymentAutoscaling) (int32, int32) {
+	minReplicas := int32(1)
+	if as.MinReplicas != nil {
+		minReplicas = *as.MinReplicas
+	}
+	return minReplicas, as.MaxReplicas
+}
+
+func clampReplicas(replicas, minReplicas, maxReplicas int32) int32 {
+	if replicas < minReplicas {
+		return minReplicas
+	}
+	if replicas > maxReplicas {
+		return maxReplicas
+	}
+	return replicas
+}
+
+// stabilizeReplicas records the recommendation, and returns the replicas allowed by stabilization windows: scaling up
+// uses the lowest recommendation in the scale up window, scaling down uses the highest one in the scale down window.
+func stabilizeReplicas(state *autoscaleState, recommended, current int32, now time.Time,
+	as *kusciav1alpha1.KusciaDeploymentAutoscaling) int32 {
+	upWindow := time.Duration(0)
+	if as.ScaleUpStabilizationSeconds != nil {
+		upWindow = time.Duration(*as.ScaleUpStabilizationSeconds) * time.Second
+	}
+	downWindow := time.Duration(defaultScaleDownStabilizationSeconds) * time.Second
+	if as.ScaleDownStabilizationSeconds != nil {
+		downWindow = time.Duration(*as.ScaleDownStabilizationSeconds) * time.Second
+	}
+
+	longest := upWindow
+	if downWindow > longest {
+		longest = downWindow
+	}
+	kept := state.recommendations[:0]
+	for _, r := range state.recommendations {
+		if now.Sub(r.timestamp) <= longest {
+			kept = append(kept, r)
+		}
+	}
+	state.recommendations = append(kept, recommendation{replicas: recommended, timestamp: now})
+
+	upReplicas, downReplicas := recommended, recommended
+	for _, r := range state.recommendations {
+		age := now.Sub(r.timestamp)
+		if age <= upWindow && r.replicas < upReplicas {
+			upReplicas = r.replicas
+		}
+		if age <= downWindow && r.replicas > downReplicas {
+			downReplicas = r.replicas
+		}
+	}
+
+	switch {
+	case upReplicas > current:
+		return upReplicas
+	case downReplicas < current:
+		return downReplicas
+	default:
+		return current
+	}
+}
+
+// validateAutoscaling checks the autoscaling of party template.
+func validateAutoscaling(as *kusciav1alpha1.KusciaDeploymentAutoscaling) error {
+	minReplicas, maxReplicas := autoscalingLimits(as)
+	if minReplicas < 1 {
+		return fmt.Errorf("autoscaling minReplicas %d should be at least 1", minReplicas)
+	}
+	if maxReplicas < minReplicas {
+		return fmt.Errorf("autoscaling maxReplicas %d should not be less than minReplicas %d", maxReplicas, minReplicas)
+	}
+	if len(as.Metrics) == 0 {
+		return fmt.Errorf("autoscaling metrics can't be empty")
+	}
+	for _, metric := range as.Metrics {
+		switch metric.Type {
+		case kusciav1alpha1.AutoscalingMetricRequestsPerSecond, kusciav1alpha1.AutoscalingMetricLatency,
+			kusciav1alpha1.AutoscalingMetricCPU:
+		default:
+			return fmt.Errorf("unsupported autoscaling metric type %q", metric.Type)
+		}
+		if metric.Target <= 0 {
+			return fmt.Errorf("target of autoscaling metric %s should be positive", metric.Type)
+		}
+	}
+	if (as.ScaleUpStabilizationSeconds != nil && *as.ScaleUpStabilizationSeconds < 0) ||
+		(as.ScaleDownStabilizationSeconds != nil && *as.ScaleDownStabilizationSeconds < 0) {
+		return fmt.Errorf("autoscaling stabilization seconds can't be negative")
+	}
+	return nil
+}
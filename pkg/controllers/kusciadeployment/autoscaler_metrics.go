@@ -0,0 +1,212 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kusciadeployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	envoyStatsURL             = "http://127.0.0.1:10000/stats"
+	envoyStatsRequestTimeout  = 2 * time.Second
+	envoyServiceClusterPrefix = "service-"
+	envoyUpstreamRequests     = "upstream_rq_total"
+	envoyUpstreamLatency      = "upstream_rq_time"
+	// envoyLatencyQuantile is the quantile of upstream latency used by autoscaling.
+	envoyLatencyQuantile = "P90"
+
+	podMetricsPath = "/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods"
+)
+
+// envoyClusterStats is the stats of an envoy cluster used by autoscaling.
+type envoyClusterStats struct {
+	requests      float64
+	hasRequests   bool
+	latencyMillis float64
+	hasLatency    bool
+}
+
+// metricsSource provides metrics for autoscaling, it's replaced in test.
+type metricsSource interface {
+	// clusterStats returns stats of envoy clusters of services, keyed by cluster name.
+	clusterStats(ctx context.Context) (map[string]*envoyClusterStats, error)
+	// podCPUUtilization returns the average cpu utilization of running pods in percentage of cpu requests, found is
+	// false if no pod has both metrics and cpu requests.
+	podCPUUtilization(ctx context.Context, namespace string, selector labels.Selector) (utilization float64, found bool, err error)
+}
+
+// kubeMetricsSource reads request metrics from the local envoy, and cpu usage from the metrics.k8s.io api.
+type kubeMetricsSource struct {
+	kubeClient kubernetes.Interface
+	httpClient *http.Client
+}
+
+func newKubeMetricsSource(kubeClient kubernetes.Interface) *kubeMetricsSource {
+	return &kubeMetricsSource{
+		kubeClient: kubeClient,
+		httpClient: &http.Client{Timeout: envoyStatsRequestTimeout},
+	}
+}
+
+func (s *kubeMetricsSource) clusterStats(ctx context.Context) (map[string]*envoyClusterStats, error) {
+	filter := fmt.Sprintf(`^cluster\.%s.*\.(%s|%s)$`, envoyServiceClusterPrefix, envoyUpstreamRequests, envoyUpstreamLatency)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, envoyStatsURL+"?filter="+url.QueryEscape(filter), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status_code: %d, msg: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return parseEnvoyClusterStats(string(body)), nil
+}
+
+// parseEnvoyClusterStats parses envoy stats in text format. A counter is as "cluster.{name}.upstream_rq_total: 10",
+// a histogram is as "cluster.{name}.upstream_rq_time: P0(1,1) ... P90(interval,cumulative) ...", the interval value
+// is used for latency, and it's nan if there is no request in the latest interval, which is regarded as zero latency.
+func parseEnvoyClusterStats(text string) map[string]*envoyClusterStats {
+	result := make(map[string]*envoyClusterStats)
+	for _, line := range strings.Split(text, "\n") {
+		name, value, found := strings.Cut(line, ":")
+		if !found || !strings.HasPrefix(name, "cluster.") {
+			continue
+		}
+		rest := strings.TrimPrefix(name, "cluster.")
+		idx := strings.Index(rest, ".")
+		if idx <= 0 {
+			continue
+		}
+		cluster, stat := rest[:idx], rest[idx+1:]
+		value = strings.TrimSpace(value)
+
+		switch stat {
+		case envoyUpstreamRequests:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			s := getOrCreateClusterStats(result, cluster)
+			s.requests, s.hasRequests = n, true
+		case envoyUpstreamLatency:
+			if value == "No recorded values" {
+				s := getOrCreateClusterStats(result, cluster)
+				s.latencyMillis, s.hasLatency = 0, true
+				continue
+			}
+			for _, q := range strings.Fields(value) {
+				if !strings.HasPrefix(q, envoyLatencyQuantile+"(") || !strings.HasSuffix(q, ")") {
+					continue
+				}
+				interval, _, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(q, envoyLatencyQuantile+"("), ")"), ",")
+				latency, err := strconv.ParseFloat(interval, 64)
+				if err != nil {
+					continue
+				}
+				if latency != latency {
+					// nan
+					latency = 0
+				}
+				s := getOrCreateClusterStats(result, cluster)
+				s.latencyMillis, s.hasLatency = latency, true
+			}
+		}
+	}
+	return result
+}
+
+func getOrCreateClusterStats(stats map[string]*envoyClusterStats, cluster string) *envoyClusterStats {
+	s, ok := stats[cluster]
+	if !ok {
+		s = &envoyClusterStats{}
+		stats[cluster] = s
+	}
+	return s
+}
+
+func (s *kubeMetricsSource) podCPUUtilization(ctx context.Context, namespace string, selector labels.Selector) (float64, bool, error) {
+	pods, err := s.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return 0, false, err
+	}
+	body, err := s.kubeClient.CoreV1().RESTClient().Get().AbsPath(fmt.Sprintf(podMetricsPath, namespace)).
+		Param("labelSelector", selector.String()).DoRaw(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	podMetrics := &metricsv1beta1.PodMetricsList{}
+	if err := json.Unmarshal(body, podMetrics); err != nil {
+		return 0, false, err
+	}
+	utilization, found := cpuUtilization(pods.Items, podMetrics.Items)
+	return utilization, found, nil
+}
+
+// cpuUtilization returns the cpu usage of running pods in percentage of their cpu requests.
+func cpuUtilization(pods []corev1.Pod, podMetrics []metricsv1beta1.PodMetrics) (float64, bool) {
+	usages := make(map[string]int64, len(podMetrics))
+	for _, m := range podMetrics {
+		var usage int64
+		for _, c := range m.Containers {
+			usage += c.Usage.Cpu().MilliValue()
+		}
+		usages[m.Name] = usage
+	}
+
+	var totalUsage, totalRequests int64
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		usage, ok := usages[pod.Name]
+		if !ok {
+			continue
+		}
+		var requests int64
+		for _, c := range pod.Spec.Containers {
+			requests += c.Resources.Requests.Cpu().MilliValue()
+		}
+		if requests == 0 {
+			continue
+		}
+		totalUsage += usage
+		totalRequests += requests
+	}
+	if totalRequests == 0 {
+		return 0, false
+	}
+	return float64(totalUsage) * 100 / float64(totalRequests), true
+}
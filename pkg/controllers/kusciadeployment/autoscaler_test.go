@@ -0,0 +1,230 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kusciadeployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciav1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+type fakeMetricsSource struct {
+	stats          map[string]*envoyClusterStats
+	cpu            float64
+	cpuFound       bool
+	clusterFetches int
+}
+
+func (f *fakeMetricsSource) clusterStats(ctx context.Context) (map[string]*envoyClusterStats, error) {
+	f.clusterFetches++
+	return f.stats, nil
+}
+
+func (f *fakeMetricsSource) podCPUUtilization(ctx context.Context, namespace string, selector labels.Selector) (float64, bool, error) {
+	return f.cpu, f.cpuFound, nil
+}
+
+func TestParseEnvoyClusterStats(t *testing.T) {
+	text := `cluster.service-kd-grpc.upstream_rq_total: 120
+cluster.service-kd-grpc.upstream_rq_time: P0(1,1) P25(2,2) P50(5,5) P75(8,8) P90(12,20) P95(15,25) P99(30,40) P99.5(31,41) P99.9(32,42) P100(33,43)
+cluster.service-kd-http.upstream_rq_total: 7
+cluster.service-kd-http.upstream_rq_time: P0(nan,1) P25(nan,2) P50(nan,3) P75(nan,4) P90(nan,5) P95(nan,6) P99(nan,7) P99.5(nan,8) P99.9(nan,9) P100(nan,10)
+cluster.service-kd-idle.upstream_rq_time: No recorded values
+`
+	stats := parseEnvoyClusterStats(text)
+	assert.Equal(t, &envoyClusterStats{requests: 120, hasRequests: true, latencyMillis: 12, hasLatency: true}, stats["service-kd-grpc"])
+	assert.Equal(t, &envoyClusterStats{requests: 7, hasRequests: true, latencyMillis: 0, hasLatency: true}, stats["service-kd-http"])
+	assert.Equal(t, &envoyClusterStats{hasLatency: true}, stats["service-kd-idle"])
+}
+
+func TestCPUUtilization(t *testing.T) {
+	newPod := func(name string, phase corev1.PodPhase, cpu string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+			}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	newMetrics := func(name, cpu string) metricsv1beta1.PodMetrics {
+		return metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Containers: []metricsv1beta1.ContainerMetrics{{Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}},
+		}
+	}
+
+	pods := []corev1.Pod{
+		newPod("a", corev1.PodRunning, "500m"),
+		newPod("b", corev1.PodRunning, "500m"),
+		newPod("c", corev1.PodPending, "500m"),
+	}
+	metrics := []metricsv1beta1.PodMetrics{newMetrics("a", "400m"), newMetrics("b", "800m"), newMetrics("c", "1")}
+	utilization, found := cpuUtilization(pods, metrics)
+	assert.True(t, found)
+	assert.InDelta(t, 120, utilization, 0.001)
+
+	_, found = cpuUtilization(pods, nil)
+	assert.False(t, found)
+}
+
+func TestStabilizeReplicas(t *testing.T) {
+	upSeconds, downSeconds := int32(30), int32(60)
+	as := &kusciav1alpha1.KusciaDeploymentAutoscaling{
+		MaxReplicas:                   10,
+		ScaleUpStabilizationSeconds:   &upSeconds,
+		ScaleDownStabilizationSeconds: &downSeconds,
+	}
+	state := &autoscaleState{}
+	now := time.Now()
+
+	// scaling up waits until the recommendations in the window are all higher
+	assert.Equal(t, int32(2), stabilizeReplicas(state, 2, 2, now, as))
+	assert.Equal(t, int32(2), stabilizeReplicas(state, 5, 2, now.Add(15*time.Second), as))
+	assert.Equal(t, int32(4), stabilizeReplicas(state, 4, 2, now.Add(31*time.Second), as))
+	assert.Equal(t, int32(4), stabilizeReplicas(state, 5, 2, now.Add(46*time.Second), as))
+
+	// scaling down uses the highest recommendation in the window
+	assert.Equal(t, int32(5), stabilizeReplicas(state, 1, 5, now.Add(60*time.Second), as))
+	assert.Equal(t, int32(1), stabilizeReplicas(state, 1, 5, now.Add(107*time.Second), as))
+}
+
+func TestValidateAutoscaling(t *testing.T) {
+	minReplicas, negative := int32(3), int32(-1)
+	tests := []struct {
+		name    string
+		as      *kusciav1alpha1.KusciaDeploymentAutoscaling
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			as: &kusciav1alpha1.KusciaDeploymentAutoscaling{MaxReplicas: 3, MinReplicas: &minReplicas,
+				Metrics: []kusciav1alpha1.AutoscalingMetric{{Type: kusciav1alpha1.AutoscalingMetricCPU, Target: 80}}},
+		},
+		{
+			name: "max less than min",
+			as: &kusciav1alpha1.KusciaDeploymentAutoscaling{MaxReplicas: 2, MinReplicas: &minReplicas,
+				Metrics: []kusciav1alpha1.AutoscalingMetric{{Type: kusciav1alpha1.AutoscalingMetricCPU, Target: 80}}},
+			wantErr: true,
+		},
+		{
+			name:    "no metric",
+			as:      &kusciav1alpha1.KusciaDeploymentAutoscaling{MaxReplicas: 2},
+			wantErr: true,
+		},
+		{
+			name: "unknown metric",
+			as: &kusciav1alpha1.KusciaDeploymentAutoscaling{MaxReplicas: 2,
+				Metrics: []kusciav1alpha1.AutoscalingMetric{{Type: "Memory", Target: 80}}},
+			wantErr: true,
+		},
+		{
+			name: "zero target",
+			as: &kusciav1alpha1.KusciaDeploymentAutoscaling{MaxReplicas: 2,
+				Metrics: []kusciav1alpha1.AutoscalingMetric{{Type: kusciav1alpha1.AutoscalingMetricLatency}}},
+			wantErr: true,
+		},
+		{
+			name: "negative window",
+			as: &kusciav1alpha1.KusciaDeploymentAutoscaling{MaxReplicas: 2, ScaleUpStabilizationSeconds: &negative,
+				Metrics: []kusciav1alpha1.AutoscalingMetric{{Type: kusciav1alpha1.AutoscalingMetricCPU, Target: 80}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, validateAutoscaling(tt.as) != nil)
+		})
+	}
+}
+
+func TestAutoscaleParty(t *testing.T) {
+	kd := makeTestKusciaDeployment("kd", 2, 1, 1)
+	zero := int32(0)
+	kd.Spec.Parties[0].Template.Autoscaling = &kusciav1alpha1.KusciaDeploymentAutoscaling{
+		MaxReplicas:                   6,
+		ScaleDownStabilizationSeconds: &zero,
+		Metrics: []kusciav1alpha1.AutoscalingMetric{
+			{Type: kusciav1alpha1.AutoscalingMetricRequestsPerSecond, Target: 10},
+			{Type: kusciav1alpha1.AutoscalingMetricCPU, Target: 50},
+		},
+	}
+	deployment := makeTestDeployment("kd", "alice", "sf-1", 2, 1, 1)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      "kd-grpc",
+		Namespace: "alice",
+		Labels:    map[string]string{common.LabelKubernetesDeploymentName: "kd"},
+	}}
+
+	kubeClient := clientsetfake.NewSimpleClientset(deployment, svc)
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, 0)
+	deployInformer := informerFactory.Apps().V1().Deployments()
+	serviceInformer := informerFactory.Core().V1().Services()
+	assert.NoError(t, deployInformer.Informer().GetStore().Add(deployment))
+	assert.NoError(t, serviceInformer.Informer().GetStore().Add(svc))
+
+	source := &fakeMetricsSource{stats: map[string]*envoyClusterStats{
+		"service-kd-grpc": {requests: 100, hasRequests: true},
+	}}
+	recorder := record.NewFakeRecorder(10)
+	now := time.Now()
+	c := &Controller{
+		kubeClient:       kubeClient,
+		recorder:         recorder,
+		deploymentLister: deployInformer.Lister(),
+		serviceLister:    serviceInformer.Lister(),
+		autoscaler:       newAutoscaler(source),
+	}
+	c.autoscaler.now = func() time.Time { return now }
+	party := &kd.Spec.Parties[0]
+
+	// the first round only samples requests
+	assert.NoError(t, c.autoscaleParty(context.Background(), &autoscaleRound{source: source}, kd, party))
+	assert.Len(t, recorder.Events, 0)
+
+	// 900 requests in 15s are 60 rps, which asks for 6 replicas
+	now = now.Add(15 * time.Second)
+	source.stats["service-kd-grpc"].requests = 1000
+	round := &autoscaleRound{source: source}
+	assert.NoError(t, c.autoscaleParty(context.Background(), round, kd, party))
+	got, err := kubeClient.AppsV1().Deployments("alice").Get(context.Background(), "kd", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(6), *got.Spec.Replicas)
+	assert.Contains(t, <-recorder.Events, reasonScaledUp)
+
+	// no request and idle cpu scale down to min replicas
+	assert.NoError(t, deployInformer.Informer().GetStore().Update(got))
+	now = now.Add(15 * time.Second)
+	source.cpu, source.cpuFound = 5, true
+	assert.NoError(t, c.autoscaleParty(context.Background(), &autoscaleRound{source: source}, kd, party))
+	got, err = kubeClient.AppsV1().Deployments("alice").Get(context.Background(), "kd", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), *got.Spec.Replicas)
+	assert.Contains(t, <-recorder.Events, reasonScaledDown)
+	assert.Equal(t, 3, source.clusterFetches)
+}
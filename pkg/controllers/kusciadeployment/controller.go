@@ -25,11 +25,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/wait"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	applisters "k8s.io/client-go/listers/apps/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/secretflow/kuscia/pkg/common"
@@ -57,6 +59,7 @@ type Controller struct {
 	kubeClient kubernetes.Interface
 	// kusciaClient is a clientset for kuscia API group
 	kusciaClient kusciaclientset.Interface
+	recorder     record.EventRecorder
 
 	// kusciaDeployment queue
 	kdQueue workqueue.RateLimitingInterface
@@ -80,12 +83,15 @@ type Controller struct {
 	appImageSynced cache.InformerSynced
 	domainLister   kuscialistersv1alpha1.DomainLister
 	domainSynced   cache.InformerSynced
+
+	autoscaler *autoscaler
 }
 
 // NewController returns a controller instance.
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(config.KubeClient, 5*time.Minute)
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(config.KusciaClient, 5*time.Minute)
+	resyncPeriod := config.ResyncPeriodOr(5 * time.Minute)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(config.KubeClient, resyncPeriod)
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(config.KusciaClient, resyncPeriod)
 
 	deploymentInformer := kubeInformerFactory.Apps().V1().Deployments()
 	nsInformer := kubeInformerFactory.Core().V1().Namespaces()
@@ -99,6 +105,7 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		config:                config,
 		kubeClient:            config.KubeClient,
 		kusciaClient:          config.KusciaClient,
+		recorder:              config.EventRecorder,
 		kubeInformerFactory:   kubeInformerFactory,
 		kusciaInformerFactory: kusciaInformerFactory,
 		deploymentLister:      deploymentInformer.Lister(),
@@ -116,6 +123,7 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		domainLister:          domainInformer.Lister(),
 		domainSynced:          domainInformer.Informer().HasSynced,
 		kdQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "kusciaDeployment"),
+		autoscaler:            newAutoscaler(newKubeMetricsSource(config.KubeClient)),
 	}
 
 	controller.ctx, controller.cancel = context.WithCancel(ctx)
@@ -495,6 +503,7 @@ func (c *Controller) Run(workers int) error {
 	for i := 0; i < workers; i++ {
 		go c.runWorker(c.ctx)
 	}
+	go wait.UntilWithContext(c.ctx, c.autoscale, autoscalePeriod)
 
 	<-c.ctx.Done()
 	nlog.Infof("Shutting down %v workers", c.Name())
@@ -573,6 +582,11 @@ func (c *Controller) validate(kd *kusciav1alpha1.KusciaDeployment) error {
 				return err
 			}
 		}
+		if party.Template.Autoscaling != nil {
+			if err := validateAutoscaling(party.Template.Autoscaling); err != nil {
+				return fmt.Errorf("party %s of kusciaDeployment %s is invalid, %v", party.DomainID, kd.Name, err)
+			}
+		}
 		if kd.Spec.Initiator == party.DomainID {
 			found = true
 		}
@@ -314,11 +314,36 @@ func (c *Controller) syncService(ctx context.Context, partyKitInfos map[string]*
 				partyKitInfo.kd.Status.Message = err.Error()
 				return err
 			}
+
+			if err = c.updateServiceSelector(ctx, partyKitInfo, svc); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// updateServiceSelector switches the service to pods of the traffic target.
+func (c *Controller) updateServiceSelector(ctx context.Context, partyKitInfo *PartyKitInfo, svc *corev1.Service) error {
+	target := trafficDeploymentName(partyKitInfo)
+	if svc.Spec.Selector[common.LabelKubernetesDeploymentName] == target {
+		return nil
+	}
+	if target != partyKitInfo.dkInfo.deploymentName {
+		if _, err := c.deploymentLister.Deployments(partyKitInfo.domainID).Get(target); err != nil {
+			return fmt.Errorf("failed to get traffic target deployment %v/%v of service %v, %v", partyKitInfo.domainID, target, svc.Name, err)
+		}
+	}
+
+	svcCopy := svc.DeepCopy()
+	svcCopy.Spec.Selector = map[string]string{common.LabelKubernetesDeploymentName: target}
+	if _, err := c.kubeClient.CoreV1().Services(svc.Namespace).Update(ctx, svcCopy, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to switch service %v/%v to deployment %v, %v", svc.Namespace, svc.Name, target, err)
+	}
+	nlog.Infof("Switched traffic of service %v/%v to deployment %v", svc.Namespace, svc.Name, target)
+	return nil
+}
+
 func (c *Controller) createService(ctx context.Context, partyKitInfo *PartyKitInfo, portName, serviceName string) error {
 	ctrPort, ok := partyKitInfo.dkInfo.ports[portName]
 	if !ok {
@@ -371,7 +396,7 @@ func generateService(partyKitInfo *PartyKitInfo, serviceName string, port kuscia
 			Type:      corev1.ServiceTypeClusterIP,
 			ClusterIP: "None",
 			Selector: map[string]string{
-				common.LabelKubernetesDeploymentName: partyKitInfo.dkInfo.deploymentName,
+				common.LabelKubernetesDeploymentName: trafficDeploymentName(partyKitInfo),
 			},
 			Ports: []corev1.ServicePort{
 				{
@@ -570,6 +595,13 @@ func (c *Controller) generateDeployment(partyKitInfo *PartyKitInfo) (*appsv1.Dep
 		buildAffinity(affinity, partyKitInfo.dkInfo.deploymentName)
 	}
 
+	replicas := partyKitInfo.deployTemplate.Replicas
+	if as := partyKitInfo.deployTemplate.Autoscaling; as != nil && replicas != nil {
+		minReplicas, maxReplicas := autoscalingLimits(as)
+		initial := clampReplicas(*replicas, minReplicas, maxReplicas)
+		replicas = &initial
+	}
+
 	automountServiceAccountToken := false
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -579,7 +611,7 @@ func (c *Controller) generateDeployment(partyKitInfo *PartyKitInfo) (*appsv1.Dep
 			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
-			Replicas: partyKitInfo.deployTemplate.Replicas,
+			Replicas: replicas,
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
@@ -612,6 +644,9 @@ func (c *Controller) generateDeployment(partyKitInfo *PartyKitInfo) (*appsv1.Dep
 	if partyKitInfo.dkInfo.imageID != "" {
 		deployment.Spec.Template.Annotations[common.ImageIDAnnotationKey] = partyKitInfo.dkInfo.imageID
 	}
+	if partyKitInfo.dkInfo.appImage != "" {
+		deployment.Spec.Template.Annotations[common.AppImageAnnotationKey] = partyKitInfo.dkInfo.appImage
+	}
 
 	renderConfigTemplateVolume := false
 	for _, ctr := range partyKitInfo.deployTemplate.Spec.Containers {
@@ -751,8 +786,8 @@ func (c *Controller) updateDeployment(ctx context.Context, partyKitInfo *PartyKi
 	needUpdate := false
 	for _, kdParty := range partyKitInfo.kd.Spec.Parties {
 		if kdParty.DomainID == partyKitInfo.domainID && kdParty.Role == partyKitInfo.role {
-			// check replicas
-			if kdParty.Template.Replicas != nil && deploymentCopy.Spec.Replicas != nil && *kdParty.Template.Replicas != *deploymentCopy.Spec.Replicas {
+			// check replicas, they are managed by autoscaler if autoscaling is enabled
+			if kdParty.Template.Autoscaling == nil && kdParty.Template.Replicas != nil && deploymentCopy.Spec.Replicas != nil && *kdParty.Template.Replicas != *deploymentCopy.Spec.Replicas {
 				nlog.Debugf("Deployment %v/%v replicas changed from %v to %v", deploymentCopy.Namespace, deploymentCopy.Name, *deploymentCopy.Spec.Replicas, *kdParty.Template.Replicas)
 				needUpdate = true
 				deploymentCopy.Spec.Replicas = kdParty.Template.Replicas
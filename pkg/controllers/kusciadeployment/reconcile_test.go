@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/informers"
 	clientsetfake "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/secretflow/kuscia/pkg/common"
 	kusciav1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 )
 
@@ -364,6 +365,46 @@ func TestSyncService(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUpdateServiceSelector(t *testing.T) {
+	kd := makeTestKusciaDeployment("kd", 1, 1, 1)
+	kd.Spec.TrafficTarget = "kd-v2"
+	partyKitInfo := &PartyKitInfo{
+		kd:       kd,
+		domainID: "alice",
+		dkInfo:   &DeploymentKitInfo{deploymentName: "kd"},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "kd-domain", Namespace: "alice"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{common.LabelKubernetesDeploymentName: "kd"},
+		},
+	}
+
+	kubeFakeClient := clientsetfake.NewSimpleClientset(svc)
+	informerFactory := informers.NewSharedInformerFactory(kubeFakeClient, 0)
+	deployInformer := informerFactory.Apps().V1().Deployments()
+	c := &Controller{
+		kubeClient:       kubeFakeClient,
+		deploymentLister: deployInformer.Lister(),
+	}
+
+	// the target deployment doesn't exist
+	assert.Error(t, c.updateServiceSelector(context.Background(), partyKitInfo, svc))
+
+	assert.NoError(t, deployInformer.Informer().GetStore().Add(makeTestDeployment("kd-v2", "alice", "sf-2", 1, 1, 1)))
+	assert.NoError(t, c.updateServiceSelector(context.Background(), partyKitInfo, svc))
+	got, err := kubeFakeClient.CoreV1().Services("alice").Get(context.Background(), "kd-domain", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "kd-v2", got.Spec.Selector[common.LabelKubernetesDeploymentName])
+
+	// roll back to itself
+	kd.Spec.TrafficTarget = ""
+	assert.NoError(t, c.updateServiceSelector(context.Background(), partyKitInfo, got))
+	got, err = kubeFakeClient.CoreV1().Services("alice").Get(context.Background(), "kd-domain", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "kd", got.Spec.Selector[common.LabelKubernetesDeploymentName])
+}
+
 func TestCreateService(t *testing.T) {
 	kd := makeTestKusciaDeployment("kd", 1, 1, 1)
 	partyKitInfo := &PartyKitInfo{
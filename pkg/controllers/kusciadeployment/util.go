@@ -66,6 +66,7 @@ type PortService map[string]string
 // DeploymentKitInfo defines kit for deployment.
 type DeploymentKitInfo struct {
 	deploymentName string
+	appImage       string
 	image          string
 	imageID        string
 	ports          NamedPorts
@@ -153,6 +154,7 @@ func (c *Controller) buildPartyKitInfo(kd *kusciav1alpha1.KusciaDeployment, part
 
 	dkInfo := &DeploymentKitInfo{
 		deploymentName: deployName,
+		appImage:       appImage.Name,
 		image:          fmt.Sprintf("%s:%s", appImage.Spec.Image.Name, appImage.Spec.Image.Tag),
 		imageID:        appImage.Spec.Image.ID,
 		ports:          ports,
@@ -535,6 +537,14 @@ func generateDeploymentName(kdName, role string) string {
 	return fmt.Sprintf("%s-%s", kdName, role)
 }
 
+// trafficDeploymentName returns the name of deployment whose pods serve the services of party.
+func trafficDeploymentName(partyKitInfo *PartyKitInfo) string {
+	if target := partyKitInfo.kd.Spec.TrafficTarget; target != "" {
+		return generateDeploymentName(target, partyKitInfo.role)
+	}
+	return partyKitInfo.dkInfo.deploymentName
+}
+
 func (c *Controller) isPartnerDomain(domainID string) (bool, error) {
 	partyDomain, err := c.domainLister.Get(domainID)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	kubeinformers "k8s.io/client-go/informers"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -35,9 +36,11 @@ import (
 	clientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 const (
@@ -81,15 +84,18 @@ type Controller struct {
 
 	namespaceLister listers.NamespaceLister
 	namespaceSynced cache.InformerSynced
+
+	queueEnabled bool
 }
 
 // NewController is used to new kuscia job controller.
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
+	resyncPeriod := config.ResyncPeriodOr(5 * time.Minute)
 	kubeClient := config.KubeClient
 	kusciaClient := config.KusciaClient
 	eventRecorder := config.EventRecorder
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, 5*time.Minute)
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 5*time.Minute)
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, resyncPeriod)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, resyncPeriod)
 
 	kusciaTaskInformer := kusciaInformerFactory.Kuscia().V1alpha1().KusciaTasks()
 	kusciaJobInformer := kusciaInformerFactory.Kuscia().V1alpha1().KusciaJobs()
@@ -111,6 +117,7 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		namespaceSynced:       namespaceInformer.Informer().HasSynced,
 		workqueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "kusciajob"),
 		recorder:              eventRecorder,
+		queueEnabled:          config.JobQueue.Enabled(),
 	}
 	controller.ctx, controller.cancel = context.WithCancel(ctx)
 
@@ -121,6 +128,10 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		NamespaceLister:       namespaceInformer.Lister(),
 		DomainLister:          kusciaDomainInformer.Lister(),
 		EnableWorkloadApprove: config.EnableWorkloadApprove,
+		ApprovePolicies:       config.ApprovePolicies,
+		JobValidator:          config.JobValidator,
+		KusciaJobLister:       kusciaJobInformer.Lister(),
+		JobQueue:              jobqueue.New(&config.JobQueue),
 	})
 
 	// kuscia job event handler
@@ -128,8 +139,13 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		AddFunc: controller.enqueueKusciaJob,
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			controller.enqueueKusciaJob(newObj)
+			controller.enqueueQueuedJobs(oldObj, newObj)
+			controller.enqueueDependentJobs(oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			controller.enqueueKusciaJob(obj)
+			controller.enqueueQueuedJobs(obj, nil)
 		},
-		DeleteFunc: controller.enqueueKusciaJob,
 	})
 
 	// kuscia task event handler
@@ -197,6 +213,71 @@ func (c *Controller) enqueueKusciaJob(obj interface{}) {
 }
 
 // handleTaskObject enqueue the KusciaJob which the task belongs.
+// enqueueQueuedJobs re-dispatches the jobs waiting in the job queue when a job leaves the running slots, e.g. a
+// running job finishes or a queued job is dispatched, so the positions of queued jobs are refreshed.
+func (c *Controller) enqueueQueuedJobs(oldObj, newObj interface{}) {
+	if !c.queueEnabled {
+		return
+	}
+	oldJob, ok := oldObj.(*kusciaapisv1alpha1.KusciaJob)
+	if !ok {
+		tombstone, ok := oldObj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if oldJob, ok = tombstone.Obj.(*kusciaapisv1alpha1.KusciaJob); !ok {
+			return
+		}
+	}
+	if newJob, ok := newObj.(*kusciaapisv1alpha1.KusciaJob); ok && newJob.Status.Phase == oldJob.Status.Phase {
+		return
+	}
+	if oldJob.Status.Phase != kusciaapisv1alpha1.KusciaJobRunning && oldJob.Status.Phase != kusciaapisv1alpha1.KusciaJobPending {
+		return
+	}
+
+	jobs, err := c.kusciaJobLister.List(labels.Everything())
+	if err != nil {
+		nlog.Warnf("List kuscia jobs failed, %v", err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Name != oldJob.Name && job.Status.QueuePosition > 0 {
+			c.enqueueKusciaJob(job)
+		}
+	}
+}
+
+// enqueueDependentJobs re-checks the pending jobs depending on a job when the phase of the job changes, so they start
+// once the job reaches the required phase.
+func (c *Controller) enqueueDependentJobs(oldObj, newObj interface{}) {
+	oldJob, ok := oldObj.(*kusciaapisv1alpha1.KusciaJob)
+	if !ok {
+		return
+	}
+	newJob, ok := newObj.(*kusciaapisv1alpha1.KusciaJob)
+	if !ok || newJob.Status.Phase == oldJob.Status.Phase {
+		return
+	}
+
+	jobs, err := c.kusciaJobLister.KusciaJobs(newJob.Namespace).List(labels.Everything())
+	if err != nil {
+		nlog.Warnf("List kuscia jobs failed, %v", err)
+		return
+	}
+	for _, job := range jobs {
+		if job.Status.Phase != kusciaapisv1alpha1.KusciaJobPending {
+			continue
+		}
+		for _, dep := range job.Spec.DependsOnJobs {
+			if dep.JobID == newJob.Name {
+				c.enqueueKusciaJob(job)
+				break
+			}
+		}
+	}
+}
+
 func (c *Controller) handleTaskObject(obj interface{}) {
 	var object metav1.Object
 	var ok bool
@@ -278,6 +359,9 @@ func (c *Controller) syncHandler(ctx context.Context, key string) (retErr error)
 
 	// For kusciaJob, we set default value to field.
 	phase := curJob.Status.Phase
+	_, span := tracing.Start(tracing.ContextFromAnnotations(ctx, curJob.Annotations, curJob.Name), "KusciaJob.Reconcile",
+		tracing.AttrJobID.String(curJob.Name), tracing.AttrPhase.String(string(phase)))
+	defer func() { tracing.End(span, retErr) }()
 
 	// Internal state machine flow.
 	needUpdate, err := c.handlerFactory.KusciaJobPhaseHandlerFor(phase).HandlePhase(curJob)
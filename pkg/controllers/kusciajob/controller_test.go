@@ -198,7 +198,7 @@ func Test_KusciaTaskControllerHandlerTaskFailed(t *testing.T) {
 func waitAndCheckKusciaJobStatus(t *testing.T, c *Controller, kusciaJobName string, statusSet []kusciaapisv1alpha1.KusciaJobPhase) {
 	err := wait.Poll(1*time.Second, 60*time.Second, func() (done bool, err error) {
 		kusciaJob, err := c.kusciaJobLister.KusciaJobs(constants.KusciaCrossDomain).Get(kusciaJobName)
-		nlog.Infof("kusciaJob: %s, err: %s", kusciaJob.Status, err)
+		nlog.Infof("kusciaJob: %+v, err: %v", kusciaJob.Status, err)
 		return kusciaJob != nil && statusContains(statusSet, kusciaJob.Status.Phase), err
 	})
 	assert.NoError(t, err)
@@ -21,6 +21,7 @@ import (
 
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/interconn/kuscia/common"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
 )
 
 // AwaitingApprovalHandler will handle kuscia job in AwaitingApproval phase.
@@ -62,6 +63,16 @@ func (h *JobScheduler) handleAwaitingApproval(job *kusciaapisv1alpha1.KusciaJob)
 				job.Status.ApproveStatus[p] = kusciaapisv1alpha1.JobAccepted
 				needUpdateStatus = true
 			}
+		} else if policy := approval.Match(h.approvePolicies, job); policy != nil {
+			ownP, _, _ := h.getAllParties(job)
+			for p := range ownP {
+				if approval.Decided(job, p) {
+					continue
+				}
+				_ = approval.Record(job, p, kusciaapisv1alpha1.JobAccepted,
+					fmt.Sprintf("Matched workload approve policy %s.", policy.Name), approval.PolicyOperator(policy.Name))
+				needUpdateStatus = true
+			}
 		}
 	}
 
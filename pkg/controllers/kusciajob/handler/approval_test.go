@@ -30,6 +30,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
 )
 
 // all party accept
@@ -212,3 +213,63 @@ func TestAwaitingApprovalHandler_HandlePhase(t *testing.T) {
 		})
 	}
 }
+
+func TestAwaitingApprovalHandler_ApprovePolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		policies     []approval.Policy
+		wantJobPhase kusciaapisv1alpha1.KusciaJobPhase
+		wantRecords  int
+	}{
+		{
+			name:         "policy matched",
+			policies:     []approval.Policy{{Name: "trusted", Initiators: []string{"alice"}}},
+			wantJobPhase: kusciaapisv1alpha1.KusciaJobPending,
+			wantRecords:  1,
+		},
+		{
+			name:         "policy of other initiator",
+			policies:     []approval.Policy{{Name: "trusted", Initiators: []string{"carol"}}},
+			wantJobPhase: kusciaapisv1alpha1.KusciaJobAwaitingApproval,
+		},
+		{
+			name:         "policy of other app image",
+			policies:     []approval.Policy{{Name: "trusted", AppImages: []string{"test-image-1"}}},
+			wantJobPhase: kusciaapisv1alpha1.KusciaJobAwaitingApproval,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kusciaClient := kusciafake.NewSimpleClientset()
+			kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, 5*time.Minute)
+			domainInformer := kusciaInformerFactory.Kuscia().V1alpha1().Domains()
+			domainInformer.Informer().GetStore().Add(&kusciaapisv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+				Spec:       kusciaapisv1alpha1.DomainSpec{Role: kusciaapisv1alpha1.Partner},
+			})
+			domainInformer.Informer().GetStore().Add(&kusciaapisv1alpha1.Domain{
+				ObjectMeta: metav1.ObjectMeta{Name: "bob"},
+			})
+			job := makeKusciaJob(KusciaJobForShapeIndependent, kusciaapisv1alpha1.KusciaJobScheduleModeBestEffort, 2, nil)
+			setJobAllPartyNil(job)
+
+			h := &AwaitingApprovalHandler{
+				JobScheduler: NewJobScheduler(&Dependencies{
+					KusciaClient:          kusciaClient,
+					DomainLister:          domainInformer.Lister(),
+					EnableWorkloadApprove: true,
+					ApprovePolicies:       tt.policies,
+				}),
+			}
+			_, err := h.HandlePhase(job)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantJobPhase, job.Status.Phase)
+			assert.Len(t, job.Status.ApprovalRecords, tt.wantRecords)
+			if tt.wantRecords > 0 {
+				assert.Equal(t, "bob", job.Status.ApprovalRecords[0].DomainID)
+				assert.Equal(t, approval.PolicyOperator("trusted"), job.Status.ApprovalRecords[0].Operator)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+)
+
+const (
+	jobWaitingDependenciesReason = "WaitingDependencies"
+	jobDependenciesReadyReason   = "DependenciesReady"
+)
+
+// waitDependencies holds the job initiated by this cluster in Pending until the jobs it depends on reach the required
+// phases. It returns whether the job has to wait, and whether the status of job is changed. The job is set to Failed if
+// the dependencies constitute a cycle, can't be satisfied any more or time out.
+func (h *JobScheduler) waitDependencies(now metav1.Time, job *kusciaapisv1alpha1.KusciaJob) (waiting bool, changed bool) {
+	if len(job.Spec.DependsOnJobs) == 0 || h.kusciaJobLister == nil || !h.selfInitiated(job) {
+		return false, false
+	}
+
+	cond, exist := utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobWaitingDependencies, true)
+	fail := func(reason kusciaapisv1alpha1.KusciaJobReason, message string) (bool, bool) {
+		nlog.Warnf("Job %s failed, %s", job.Name, message)
+		utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionFalse, string(reason), message)
+		setKusciaJobStatus(now, &job.Status, kusciaapisv1alpha1.KusciaJobFailed, string(reason), message)
+		return true, true
+	}
+
+	if cycle := h.findDependencyCycle(job); len(cycle) > 0 {
+		return fail(kusciaapisv1alpha1.DependencyCycle,
+			fmt.Sprintf("Dependencies of jobs constitute a cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
+	var pending []string
+	for _, dep := range job.Spec.DependsOnJobs {
+		phase := dependencyPhase(dep)
+		depJob, err := h.kusciaJobLister.KusciaJobs(job.Namespace).Get(dep.JobID)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				nlog.Warnf("Get job %s depended on by job %s failed, %v", dep.JobID, job.Name, err)
+			}
+			pending = append(pending, dep.JobID)
+			continue
+		}
+		if depJob.Status.Phase == phase {
+			continue
+		}
+		if isJobFinished(depJob.Status.Phase) {
+			return fail(kusciaapisv1alpha1.DependencyUnsatisfiable,
+				fmt.Sprintf("Job %s depended on is %s, but %s is required", dep.JobID, depJob.Status.Phase, phase))
+		}
+		pending = append(pending, dep.JobID)
+	}
+
+	if len(pending) == 0 {
+		if exist && cond.Status == corev1.ConditionTrue {
+			nlog.Infof("Jobs depended on by job %s are ready", job.Name)
+			utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionFalse, jobDependenciesReadyReason, "")
+			return false, true
+		}
+		return false, false
+	}
+
+	if timeout := job.Spec.DependencyTimeoutSeconds; timeout != nil &&
+		now.Time.After(job.CreationTimestamp.Add(time.Duration(*timeout)*time.Second)) {
+		return fail(kusciaapisv1alpha1.DependencyTimeout,
+			fmt.Sprintf("Jobs %s depended on are not ready in %d seconds", strings.Join(pending, ", "), *timeout))
+	}
+
+	message := fmt.Sprintf("Waiting for jobs %s", strings.Join(pending, ", "))
+	if cond.Status == corev1.ConditionTrue && cond.Message == message {
+		return true, false
+	}
+	nlog.Infof("Job %s is waiting for jobs %s", job.Name, strings.Join(pending, ", "))
+	utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionTrue, jobWaitingDependenciesReason, message)
+	return true, true
+}
+
+// findDependencyCycle returns the jobs in a cycle of dependencies reachable from job, the first job is repeated at the
+// end of it. It returns nil if there is no cycle.
+func (h *JobScheduler) findDependencyCycle(job *kusciaapisv1alpha1.KusciaJob) []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := map[string]int{}
+	var path []string
+	var visit func(name string, deps []kusciaapisv1alpha1.JobDependency) []string
+	visit = func(name string, deps []kusciaapisv1alpha1.JobDependency) []string {
+		states[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps {
+			switch states[dep.JobID] {
+			case visiting:
+				for i := range path {
+					if path[i] == dep.JobID {
+						return append(append([]string{}, path[i:]...), dep.JobID)
+					}
+				}
+			case visited:
+				continue
+			}
+			depJob, err := h.kusciaJobLister.KusciaJobs(job.Namespace).Get(dep.JobID)
+			if err != nil {
+				states[dep.JobID] = visited
+				continue
+			}
+			if cycle := visit(dep.JobID, depJob.Spec.DependsOnJobs); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		states[name] = visited
+		return nil
+	}
+	return visit(job.Name, job.Spec.DependsOnJobs)
+}
+
+func dependencyPhase(dep kusciaapisv1alpha1.JobDependency) kusciaapisv1alpha1.KusciaJobPhase {
+	if dep.Phase == "" {
+		return kusciaapisv1alpha1.KusciaJobSucceeded
+	}
+	return dep.Phase
+}
+
+// isJobFinished tells whether the job of phase won't run any more.
+func isJobFinished(phase kusciaapisv1alpha1.KusciaJobPhase) bool {
+	switch phase {
+	case kusciaapisv1alpha1.KusciaJobSucceeded, kusciaapisv1alpha1.KusciaJobFailed,
+		kusciaapisv1alpha1.KusciaJobCancelled, kusciaapisv1alpha1.KusciaJobApprovalReject:
+		return true
+	}
+	return false
+}
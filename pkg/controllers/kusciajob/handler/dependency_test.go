@@ -0,0 +1,136 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+)
+
+func makeDependencyScheduler(t *testing.T, jobs ...*kusciaapisv1alpha1.KusciaJob) *JobScheduler {
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}))
+	jobIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, job := range jobs {
+		assert.NoError(t, jobIndexer.Add(job))
+	}
+	return &JobScheduler{
+		namespaceLister: corelisters.NewNamespaceLister(nsIndexer),
+		kusciaJobLister: kuscialistersv1alpha1.NewKusciaJobLister(jobIndexer),
+	}
+}
+
+func makeDependentJob(name string, phase kusciaapisv1alpha1.KusciaJobPhase, deps ...string) *kusciaapisv1alpha1.KusciaJob {
+	job := makeKusciaJob(KusciaJobForShapeIndependent, kusciaapisv1alpha1.KusciaJobScheduleModeBestEffort, 1, nil)
+	job.Name = name
+	job.Status.Phase = phase
+	for _, dep := range deps {
+		job.Spec.DependsOnJobs = append(job.Spec.DependsOnJobs, kusciaapisv1alpha1.JobDependency{JobID: dep})
+	}
+	return job
+}
+
+func TestWaitDependencies(t *testing.T) {
+	upstream := makeDependentJob("upstream", kusciaapisv1alpha1.KusciaJobRunning)
+	job := makeDependentJob("downstream", kusciaapisv1alpha1.KusciaJobPending, "upstream")
+	h := makeDependencyScheduler(t, upstream, job)
+	now := metav1.Now()
+
+	waiting, changed := h.waitDependencies(now, job)
+	assert.True(t, waiting)
+	assert.True(t, changed)
+	cond, ok := utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobWaitingDependencies, false)
+	assert.True(t, ok)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+	assert.Equal(t, "Waiting for jobs upstream", cond.Message)
+
+	// unchanged dependencies don't update the job
+	waiting, changed = h.waitDependencies(now, job)
+	assert.True(t, waiting)
+	assert.False(t, changed)
+
+	upstream.Status.Phase = kusciaapisv1alpha1.KusciaJobSucceeded
+	waiting, changed = h.waitDependencies(now, job)
+	assert.False(t, waiting)
+	assert.True(t, changed)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+	assert.Equal(t, kusciaapisv1alpha1.KusciaJobPending, job.Status.Phase)
+
+	// jobs initiated by partners are not held
+	partnerJob := makeDependentJob("partner-job", kusciaapisv1alpha1.KusciaJobPending, "missing")
+	partnerJob.Spec.Initiator = "bob"
+	waiting, _ = h.waitDependencies(now, partnerJob)
+	assert.False(t, waiting)
+}
+
+func TestWaitDependenciesFailed(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    []*kusciaapisv1alpha1.KusciaJob
+		timeout *int64
+		reason  kusciaapisv1alpha1.KusciaJobReason
+		message string
+	}{
+		{
+			name: "cycle",
+			jobs: []*kusciaapisv1alpha1.KusciaJob{
+				makeDependentJob("job-b", kusciaapisv1alpha1.KusciaJobPending, "job-c"),
+				makeDependentJob("job-c", kusciaapisv1alpha1.KusciaJobPending, "job-a"),
+			},
+			reason:  kusciaapisv1alpha1.DependencyCycle,
+			message: "Dependencies of jobs constitute a cycle: job-a -> job-b -> job-c -> job-a",
+		},
+		{
+			name: "unsatisfiable",
+			jobs: []*kusciaapisv1alpha1.KusciaJob{
+				makeDependentJob("job-b", kusciaapisv1alpha1.KusciaJobFailed),
+			},
+			reason:  kusciaapisv1alpha1.DependencyUnsatisfiable,
+			message: "Job job-b depended on is Failed, but Succeeded is required",
+		},
+		{
+			name:    "timeout",
+			timeout: func(v int64) *int64 { return &v }(60),
+			reason:  kusciaapisv1alpha1.DependencyTimeout,
+			message: "Jobs job-b depended on are not ready in 60 seconds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := makeDependentJob("job-a", kusciaapisv1alpha1.KusciaJobPending, "job-b")
+			job.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			job.Spec.DependencyTimeoutSeconds = tt.timeout
+			h := makeDependencyScheduler(t, append(tt.jobs, job)...)
+
+			waiting, changed := h.waitDependencies(metav1.Now(), job)
+			assert.True(t, waiting)
+			assert.True(t, changed)
+			assert.Equal(t, kusciaapisv1alpha1.KusciaJobFailed, job.Status.Phase)
+			assert.Equal(t, string(tt.reason), job.Status.Reason)
+			assert.Equal(t, tt.message, job.Status.Message)
+		})
+	}
+}
@@ -21,6 +21,9 @@ import (
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/jobpolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
 )
 
 // Dependencies defines KusciaJobPhaseHandlerFactory's dependencies.
@@ -28,9 +31,14 @@ type Dependencies struct {
 	Recorder              record.EventRecorder
 	KusciaClient          versioned.Interface
 	KusciaTaskLister      kuscialistersv1alpha1.KusciaTaskLister
+	KusciaJobLister       kuscialistersv1alpha1.KusciaJobLister
 	NamespaceLister       corelisters.NamespaceLister
 	DomainLister          kuscialistersv1alpha1.DomainLister
 	EnableWorkloadApprove bool
+	ApprovePolicies       []approval.Policy
+	JobValidator          *jobpolicy.Chain
+	// JobQueue limits the running jobs initiated by this cluster, nil means unlimited.
+	JobQueue *jobqueue.Queue
 }
 
 // KusciaJobPhaseHandler defines that how to handle the kuscia job in each phase.
@@ -43,6 +51,7 @@ func NewKusciaJobPhaseHandlerFactory(deps *Dependencies) *KusciaJobPhaseHandlerF
 
 	KusciaJobStateHandlerMap := map[kusciaapisv1alpha1.KusciaJobPhase]KusciaJobPhaseHandler{
 		kusciaapisv1alpha1.KusciaJobInitialized:      NewInitializedHandler(deps),
+		kusciaapisv1alpha1.KusciaJobPreflightCheck:   NewPreflightCheckHandler(deps),
 		kusciaapisv1alpha1.KusciaJobAwaitingApproval: NewAwaitingApprovalHandler(deps),
 		kusciaapisv1alpha1.KusciaJobPending:          NewPendingHandler(deps),
 		kusciaapisv1alpha1.KusciaJobRunning:          NewRunningHandler(deps),
@@ -89,13 +89,19 @@ func (h *JobScheduler) handleInitialized(job *kusciaapisv1alpha1.KusciaJob) (nee
 			job.Status.StageStatus[p] = kusciaapisv1alpha1.JobCreateStageSucceeded
 		}
 	}
-	// inter connection job
-	if isInterConnJob(job) {
-		job.Status.Phase = kusciaapisv1alpha1.KusciaJobAwaitingApproval
+	// the initiator checks the dependencies of job before any task is created
+	if utilsres.SelfClusterAsInitiator(h.namespaceLister, job.Spec.Initiator, job.Annotations) {
+		job.Status.Phase = kusciaapisv1alpha1.KusciaJobPreflightCheck
 		return true, nil
 	}
-	// inner job
-	// set initialized --> pending
-	job.Status.Phase = kusciaapisv1alpha1.KusciaJobPending
+	job.Status.Phase = phaseAfterInitialized(job)
 	return true, nil
 }
+
+// phaseAfterInitialized returns AwaitingApproval for inter connection job, and Pending for inner job.
+func phaseAfterInitialized(job *kusciaapisv1alpha1.KusciaJob) kusciaapisv1alpha1.KusciaJobPhase {
+	if isInterConnJob(job) {
+		return kusciaapisv1alpha1.KusciaJobAwaitingApproval
+	}
+	return kusciaapisv1alpha1.KusciaJobPending
+}
@@ -68,6 +68,14 @@ func (h *PendingHandler) handlePending(job *kusciaapisv1alpha1.KusciaJob) (needU
 	}
 	// normal logic
 	if ok, _ := h.allPartyCreateSuccess(job); ok {
+		// wait for the jobs it depends on
+		if waiting, changed := h.waitDependencies(now, job); waiting {
+			return changed, nil
+		}
+		// wait in the job queue if too many jobs are running
+		if queued, changed := h.queueJob(now, job); queued {
+			return changed, nil
+		}
 		// set Pending --> Running
 		job.Status.Phase = kusciaapisv1alpha1.KusciaJobRunning
 		return true, nil
@@ -0,0 +1,205 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+)
+
+// PreflightCheckHandler checks the dependencies of the job initiated by this cluster before any task is created, so
+// the job fails fast instead of failing minutes later when tasks are scheduled.
+type PreflightCheckHandler struct {
+	*JobScheduler
+}
+
+func NewPreflightCheckHandler(deps *Dependencies) *PreflightCheckHandler {
+	return &PreflightCheckHandler{
+		JobScheduler: NewJobScheduler(deps),
+	}
+}
+
+func (h *PreflightCheckHandler) HandlePhase(kusciaJob *kusciaapisv1alpha1.KusciaJob) (needUpdate bool, err error) {
+	return h.handlePreflightCheck(kusciaJob)
+}
+
+func (h *PreflightCheckHandler) handlePreflightCheck(job *kusciaapisv1alpha1.KusciaJob) (needUpdateStatus bool, err error) {
+	now := metav1.Now().Rfc3339Copy()
+	defer updateJobTime(now, job)
+	// handle stage command, check if the stage command matches the phase of job
+	if hasReconciled, err := h.handleStageCommand(now, job); err != nil || hasReconciled {
+		return hasReconciled, err
+	}
+
+	cond, _ := utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobPreflightChecked, true)
+	problems, err := h.preflightCheck(job)
+	if err != nil {
+		return false, err
+	}
+	if len(problems) > 0 {
+		message := fmt.Sprintf("Preflight check failed: %s", strings.Join(problems, "; "))
+		nlog.Warnf("Job %s %s", job.Name, message)
+		utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionFalse, string(kusciaapisv1alpha1.PreflightCheckFailed), message)
+		setKusciaJobStatus(now, &job.Status, kusciaapisv1alpha1.KusciaJobFailed, string(kusciaapisv1alpha1.PreflightCheckFailed), message)
+		return true, nil
+	}
+
+	utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionTrue, "", "")
+	// set PreflightCheck --> AwaitingApproval or Pending
+	job.Status.Phase = phaseAfterInitialized(job)
+	return true, nil
+}
+
+// preflightCheck returns the problems found in the dependencies of job, each problem tells what to fix. Only the
+// resources visible to this cluster are checked, partners check their own resources when they run tasks.
+func (h *JobScheduler) preflightCheck(job *kusciaapisv1alpha1.KusciaJob) (problems []string, err error) {
+	ownP, _, err := h.getAllParties(job)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, h.preflightCheckRoutes(job, ownP)...)
+	problems = append(problems, h.preflightCheckAppImages(job, ownP)...)
+	problems = append(problems, h.preflightCheckDomainDataGrants(job, ownP)...)
+	return problems, nil
+}
+
+// preflightCheckRoutes checks the routes between parties of each task are ready, a route to a partner isn't ready
+// if the partner can't be reached by handshake or heartbeat.
+func (h *JobScheduler) preflightCheckRoutes(job *kusciaapisv1alpha1.KusciaJob, ownP map[string]kusciaapisv1alpha1.Party) (problems []string) {
+	checked := map[string]bool{}
+	for _, task := range job.Spec.Tasks {
+		for _, src := range task.Parties {
+			for _, dst := range task.Parties {
+				_, srcOwn := ownP[src.DomainID]
+				_, dstOwn := ownP[dst.DomainID]
+				name := fmt.Sprintf("%s-%s", src.DomainID, dst.DomainID)
+				// the routes between partners are invisible to this cluster
+				if src.DomainID == dst.DomainID || (!srcOwn && !dstOwn) || checked[name] {
+					continue
+				}
+				checked[name] = true
+
+				cdr, err := h.kusciaClient.KusciaV1alpha1().ClusterDomainRoutes().Get(context.Background(), name, metav1.GetOptions{})
+				if err != nil {
+					if k8serrors.IsNotFound(err) {
+						problems = append(problems, fmt.Sprintf("route %s from %s to %s does not exist, create it before running the job", name, src.DomainID, dst.DomainID))
+					} else {
+						problems = append(problems, fmt.Sprintf("get route %s failed, %v", name, err))
+					}
+					continue
+				}
+				if ready, reason := clusterDomainRouteReady(cdr); !ready {
+					if !dstOwn {
+						problems = append(problems, fmt.Sprintf("partner %s is unreachable from %s, route %s is not ready (%s), check the network and the gateway of the partner", dst.DomainID, src.DomainID, name, reason))
+					} else {
+						problems = append(problems, fmt.Sprintf("route %s is not ready (%s)", name, reason))
+					}
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func clusterDomainRouteReady(cdr *kusciaapisv1alpha1.ClusterDomainRoute) (ready bool, reason string) {
+	for _, cond := range cdr.Status.Conditions {
+		if cond.Type == kusciaapisv1alpha1.ClusterDomainRouteReady {
+			return cond.Status == corev1.ConditionTrue, cond.Reason
+		}
+	}
+	return false, "no ready condition"
+}
+
+// preflightCheckAppImages checks the app images of tasks exist and render for the parties in this cluster.
+func (h *JobScheduler) preflightCheckAppImages(job *kusciaapisv1alpha1.KusciaJob, ownP map[string]kusciaapisv1alpha1.Party) (problems []string) {
+	for _, task := range job.Spec.Tasks {
+		appImage, err := h.kusciaClient.KusciaV1alpha1().AppImages().Get(context.Background(), task.AppImage, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				problems = append(problems, fmt.Sprintf("app image %s of task %s does not exist, create it before running the job", task.AppImage, task.Alias))
+			} else {
+				problems = append(problems, fmt.Sprintf("get app image %s of task %s failed, %v", task.AppImage, task.Alias, err))
+			}
+			continue
+		}
+		for _, party := range task.Parties {
+			if _, ok := ownP[party.DomainID]; !ok {
+				continue
+			}
+			dryRun := utilsres.DryRunAppImage(appImage, party.Role, task.AppImageChannel, party.DomainID, task.TaskInputConfig)
+			for _, e := range dryRun.Errors {
+				problems = append(problems, fmt.Sprintf("app image %s of task %s can't run for party %s, %s", task.AppImage, task.Alias, party.DomainID, e))
+			}
+		}
+	}
+	return problems
+}
+
+// preflightCheckDomainDataGrants checks the domaindata read by tasks from sf_input_ids of task input config is granted
+// to the initiator if it's owned by another party in this cluster. Domaindata produced by former tasks or owned by
+// partners are skipped.
+func (h *JobScheduler) preflightCheckDomainDataGrants(job *kusciaapisv1alpha1.KusciaJob, ownP map[string]kusciaapisv1alpha1.Party) (problems []string) {
+	outputs := map[string]bool{}
+	for _, task := range job.Spec.Tasks {
+		inputIDs, outputIDs, err := utilsres.TaskInputDomainDataIDs(task.TaskInputConfig)
+		if err != nil {
+			// task input config isn't always json, it's left to the app
+			continue
+		}
+		for _, dataID := range inputIDs {
+			if outputs[dataID] {
+				continue
+			}
+			owner := h.findDomainDataOwner(dataID, task.Parties, ownP)
+			if owner == "" || owner == job.Spec.Initiator {
+				continue
+			}
+			grants, err := h.kusciaClient.KusciaV1alpha1().DomainDataGrants(owner).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("list domaindatagrants of %s failed, %v", owner, err))
+				continue
+			}
+			if err := utilsres.CheckDomainDataGranted(grants.Items, dataID, job.Spec.Initiator, metav1.Now().Time); err != nil {
+				problems = append(problems, fmt.Sprintf("task %s reads domaindata of %s, but %v", task.Alias, owner, err))
+			}
+		}
+		for _, dataID := range outputIDs {
+			outputs[dataID] = true
+		}
+	}
+	return problems
+}
+
+func (h *JobScheduler) findDomainDataOwner(dataID string, parties []kusciaapisv1alpha1.Party, ownP map[string]kusciaapisv1alpha1.Party) string {
+	for _, party := range parties {
+		if _, ok := ownP[party.DomainID]; !ok {
+			continue
+		}
+		_, err := h.kusciaClient.KusciaV1alpha1().DomainDatas(party.DomainID).Get(context.Background(), dataID, metav1.GetOptions{})
+		if err == nil {
+			return party.DomainID
+		}
+	}
+	return ""
+}
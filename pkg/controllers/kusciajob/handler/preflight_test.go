@@ -0,0 +1,137 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+)
+
+func TestPreflightCheckHandler(t *testing.T) {
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	domainIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, name := range []string{"alice", "carol"} {
+		assert.NoError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}))
+		assert.NoError(t, domainIndexer.Add(&kusciaapisv1alpha1.Domain{ObjectMeta: metav1.ObjectMeta{Name: name}}))
+	}
+	assert.NoError(t, domainIndexer.Add(&kusciaapisv1alpha1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob"},
+		Spec:       kusciaapisv1alpha1.DomainSpec{Role: kusciaapisv1alpha1.Partner},
+	}))
+
+	route := func(name string, status corev1.ConditionStatus) *kusciaapisv1alpha1.ClusterDomainRoute {
+		return &kusciaapisv1alpha1.ClusterDomainRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: kusciaapisv1alpha1.ClusterDomainRouteStatus{Conditions: []kusciaapisv1alpha1.ClusterDomainRouteCondition{{
+				Type:   kusciaapisv1alpha1.ClusterDomainRouteReady,
+				Status: status,
+				Reason: "HeartBeatTimeout",
+			}}},
+		}
+	}
+	kusciaClient := kusciafake.NewSimpleClientset(
+		route("alice-bob", corev1.ConditionFalse),
+		route("bob-alice", corev1.ConditionTrue),
+		route("alice-carol", corev1.ConditionTrue),
+		route("carol-alice", corev1.ConditionTrue),
+		&kusciaapisv1alpha1.DomainData{ObjectMeta: metav1.ObjectMeta{Name: "carol-table", Namespace: "carol"}},
+		&kusciaapisv1alpha1.AppImage{
+			ObjectMeta: metav1.ObjectMeta{Name: "secretflow"},
+			Spec: kusciaapisv1alpha1.AppImageSpec{
+				Image: kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "latest"},
+				DeployTemplates: []kusciaapisv1alpha1.DeployTemplate{{
+					Name: "secretflow",
+					Spec: kusciaapisv1alpha1.PodSpec{Containers: []kusciaapisv1alpha1.Container{{Name: "secretflow"}}},
+				}},
+			},
+		},
+	)
+	h := NewPreflightCheckHandler(&Dependencies{
+		KusciaClient:    kusciaClient,
+		NamespaceLister: corelisters.NewNamespaceLister(nsIndexer),
+		DomainLister:    kuscialistersv1alpha1.NewDomainLister(domainIndexer),
+	})
+	makeJob := func() *kusciaapisv1alpha1.KusciaJob {
+		return &kusciaapisv1alpha1.KusciaJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: common.KusciaCrossDomain},
+			Spec: kusciaapisv1alpha1.KusciaJobSpec{
+				Initiator: "alice",
+				Tasks: []kusciaapisv1alpha1.KusciaTaskTemplate{{
+					Alias:           "psi",
+					TaskID:          "psi",
+					AppImage:        "secretflow",
+					TaskInputConfig: `{"sf_input_ids":["carol-table"],"sf_output_ids":["psi-output"]}`,
+					Parties:         []kusciaapisv1alpha1.Party{{DomainID: "alice"}, {DomainID: "bob"}, {DomainID: "carol"}},
+				}, {
+					Alias:           "train",
+					TaskID:          "train",
+					AppImage:        "unknown",
+					TaskInputConfig: `{"sf_input_ids":["psi-output"]}`,
+					Parties:         []kusciaapisv1alpha1.Party{{DomainID: "alice"}, {DomainID: "carol"}},
+				}},
+			},
+			Status: kusciaapisv1alpha1.KusciaJobStatus{Phase: kusciaapisv1alpha1.KusciaJobPreflightCheck},
+		}
+	}
+
+	job := makeJob()
+	needUpdate, err := h.HandlePhase(job)
+	assert.NoError(t, err)
+	assert.True(t, needUpdate)
+	assert.Equal(t, kusciaapisv1alpha1.KusciaJobFailed, job.Status.Phase)
+	assert.Equal(t, string(kusciaapisv1alpha1.PreflightCheckFailed), job.Status.Reason)
+	assert.Contains(t, job.Status.Message, "partner bob is unreachable from alice, route alice-bob is not ready (HeartBeatTimeout)")
+	assert.Contains(t, job.Status.Message, "route bob-carol from bob to carol does not exist")
+	assert.Contains(t, job.Status.Message, "app image unknown of task train does not exist")
+	assert.Contains(t, job.Status.Message, "task psi reads domaindata of carol, but domaindata carol-table is not granted to alice, no grant")
+	assert.NotContains(t, job.Status.Message, "psi-output")
+	cond, ok := utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobPreflightChecked, false)
+	assert.True(t, ok)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+
+	ctx := context.Background()
+	_, err = kusciaClient.KusciaV1alpha1().ClusterDomainRoutes().Update(ctx, route("alice-bob", corev1.ConditionTrue), metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	for _, name := range []string{"bob-carol", "carol-bob"} {
+		_, err = kusciaClient.KusciaV1alpha1().ClusterDomainRoutes().Create(ctx, route(name, corev1.ConditionTrue), metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	_, err = kusciaClient.KusciaV1alpha1().DomainDataGrants("carol").Create(ctx, &kusciaapisv1alpha1.DomainDataGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "carol-table-alice", Namespace: "carol"},
+		Spec:       kusciaapisv1alpha1.DomainDataGrantSpec{Author: "carol", DomainDataID: "carol-table", GrantDomain: "alice"},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	job = makeJob()
+	job.Spec.Tasks[1].AppImage = "secretflow"
+	needUpdate, err = h.HandlePhase(job)
+	assert.NoError(t, err)
+	assert.True(t, needUpdate)
+	assert.Equal(t, kusciaapisv1alpha1.KusciaJobPending, job.Status.Phase)
+	cond, _ = utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobPreflightChecked, false)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+}
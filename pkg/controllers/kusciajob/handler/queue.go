@@ -0,0 +1,70 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+)
+
+const (
+	jobQueuedReason     = "Queued"
+	jobDispatchedReason = "Dispatched"
+)
+
+// queueJob puts the job initiated by this cluster into the job queue. It returns whether the job has to wait,
+// and whether the queue position of job is changed.
+func (h *JobScheduler) queueJob(now metav1.Time, job *kusciaapisv1alpha1.KusciaJob) (queued bool, changed bool) {
+	if h.jobQueue == nil || h.kusciaJobLister == nil || !h.selfInitiated(job) {
+		return false, false
+	}
+
+	jobs, err := h.kusciaJobLister.List(labels.Everything())
+	if err != nil {
+		nlog.Warnf("List kuscia jobs failed, skip queueing job %s, %v", job.Name, err)
+		return false, false
+	}
+
+	dispatched, position := h.jobQueue.Dispatch(job, jobs, h.selfInitiated)
+	cond, exist := utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobQueued, !dispatched)
+	if dispatched {
+		job.Status.QueuePosition = 0
+		if exist && cond.Status == corev1.ConditionTrue {
+			nlog.Infof("Job %s is dispatched from the job queue", job.Name)
+			utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionFalse, jobDispatchedReason, "")
+		}
+		return false, false
+	}
+
+	if job.Status.QueuePosition == int32(position) && cond.Status == corev1.ConditionTrue {
+		return true, false
+	}
+	nlog.Infof("Job %s is waiting in the job queue, position %d", job.Name, position)
+	job.Status.QueuePosition = int32(position)
+	utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionTrue, jobQueuedReason,
+		fmt.Sprintf("Waiting for running jobs to finish, position %d in the job queue", position))
+	return true, true
+}
+
+func (h *JobScheduler) selfInitiated(job *kusciaapisv1alpha1.KusciaJob) bool {
+	return utilsres.SelfClusterAsInitiator(h.namespaceLister, job.Spec.Initiator, job.Annotations)
+}
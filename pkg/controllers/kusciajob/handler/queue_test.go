@@ -0,0 +1,78 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
+	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+)
+
+func TestQueueJob(t *testing.T) {
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}))
+	jobIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	running := makeKusciaJob(KusciaJobForShapeIndependent, kusciaapisv1alpha1.KusciaJobScheduleModeBestEffort, 1, nil)
+	running.Name = "running-job"
+	running.Status.Phase = kusciaapisv1alpha1.KusciaJobRunning
+	assert.NoError(t, jobIndexer.Add(running))
+	job := makeKusciaJob(KusciaJobForShapeIndependent, kusciaapisv1alpha1.KusciaJobScheduleModeBestEffort, 1, nil)
+	setJobAllPartyCreateSuccess(job)
+	assert.NoError(t, jobIndexer.Add(job))
+
+	h := &JobScheduler{
+		namespaceLister: corelisters.NewNamespaceLister(nsIndexer),
+		kusciaJobLister: kuscialistersv1alpha1.NewKusciaJobLister(jobIndexer),
+		jobQueue:        jobqueue.New(&jobqueue.Config{MaxRunningJobsPerDomain: 1}),
+	}
+	now := metav1.Now()
+
+	queued, changed := h.queueJob(now, job)
+	assert.True(t, queued)
+	assert.True(t, changed)
+	assert.Equal(t, int32(1), job.Status.QueuePosition)
+	cond, ok := utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobQueued, false)
+	assert.True(t, ok)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+
+	// unchanged position doesn't update the job
+	queued, changed = h.queueJob(now, job)
+	assert.True(t, queued)
+	assert.False(t, changed)
+
+	running.Status.Phase = kusciaapisv1alpha1.KusciaJobSucceeded
+	queued, _ = h.queueJob(now, job)
+	assert.False(t, queued)
+	assert.Equal(t, int32(0), job.Status.QueuePosition)
+	assert.Equal(t, corev1.ConditionFalse, cond.Status)
+	assert.Equal(t, jobDispatchedReason, cond.Reason)
+
+	// jobs initiated by partners are not queued
+	partnerJob := job.DeepCopy()
+	partnerJob.Name = "partner-job"
+	partnerJob.Spec.Initiator = "bob"
+	queued, _ = h.queueJob(now, partnerJob)
+	assert.False(t, queued)
+}
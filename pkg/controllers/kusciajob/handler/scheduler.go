@@ -38,8 +38,12 @@ import (
 	"github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
 	intercommon "github.com/secretflow/kuscia/pkg/interconn/kuscia/common"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/jobpolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 const (
@@ -53,6 +57,10 @@ type JobScheduler struct {
 	domainLister          kuscialistersv1alpha1.DomainLister
 	namespaceLister       corelisters.NamespaceLister
 	enableWorkloadApprove bool
+	approvePolicies       []approval.Policy
+	jobValidator          *jobpolicy.Chain
+	kusciaJobLister       kuscialistersv1alpha1.KusciaJobLister
+	jobQueue              *jobqueue.Queue
 }
 
 // NewJobScheduler return kuscia job scheduler.
@@ -63,6 +71,10 @@ func NewJobScheduler(deps *Dependencies) *JobScheduler {
 		namespaceLister:       deps.NamespaceLister,
 		domainLister:          deps.DomainLister,
 		enableWorkloadApprove: deps.EnableWorkloadApprove,
+		approvePolicies:       deps.ApprovePolicies,
+		jobValidator:          deps.JobValidator,
+		kusciaJobLister:       deps.KusciaJobLister,
+		jobQueue:              deps.JobQueue,
 	}
 }
 
@@ -289,15 +301,22 @@ func (h *JobScheduler) validateJob(now metav1.Time, job *kusciaapisv1alpha1.Kusc
 		return false, true
 	}
 	// validate job
+	reason := v1alpha1.ValidateFailed
 	err := h.kusciaJobValidate(job)
+	if err == nil {
+		// org-specific policies run after the built-in validation, before any task is created
+		if err = h.jobValidator.Validate(context.Background(), job); err != nil {
+			reason = v1alpha1.PolicyDenied
+		}
+	}
 	if err == nil {
 		// validate pass
 		utilsres.SetKusciaJobCondition(now, jobValidatedCond, corev1.ConditionTrue, "", "")
 		return true, true
 	}
 	// validate failed
-	utilsres.SetKusciaJobCondition(now, jobValidatedCond, corev1.ConditionFalse, string(v1alpha1.ValidateFailed), fmt.Sprintf("Validate job failed, %v", err.Error()))
-	setKusciaJobStatus(now, &job.Status, kusciaapisv1alpha1.KusciaJobFailed, string(v1alpha1.ValidateFailed), "")
+	utilsres.SetKusciaJobCondition(now, jobValidatedCond, corev1.ConditionFalse, string(reason), fmt.Sprintf("Validate job failed, %v", err.Error()))
+	setKusciaJobStatus(now, &job.Status, kusciaapisv1alpha1.KusciaJobFailed, string(reason), "")
 	return true, false
 }
 
@@ -1053,6 +1072,10 @@ func (h *RunningHandler) buildWillStartKusciaTask(kusciaJob *kusciaapisv1alpha1.
 			},
 			Spec: h.createTaskSpec(kusciaJob.Spec.Initiator, t),
 		}
+		tracing.CopyAnnotations(taskObject.Annotations, kusciaJob.Annotations)
+		if tenant, ok := kusciaJob.Labels[common.LabelTenant]; ok {
+			taskObject.Labels[common.LabelTenant] = tenant
+		}
 
 		if isIcJob {
 			// todo delete LabelInterConnProtocolType label
@@ -1111,11 +1134,12 @@ func (h *RunningHandler) buildPartiesFromTaskInputConfig(template kusciaapisv1al
 		tpl := h.buildPartyTemplate(p, template.AppImage)
 
 		taskPartyInfos[i] = kusciaapisv1alpha1.PartyInfo{
-			DomainID:       p.DomainID,
-			AppImageRef:    template.AppImage,
-			Role:           p.Role,
-			Template:       tpl,
-			BandwidthLimit: p.BandwidthLimit,
+			DomainID:        p.DomainID,
+			AppImageRef:     template.AppImage,
+			AppImageChannel: template.AppImageChannel,
+			Role:            p.Role,
+			Template:        tpl,
+			BandwidthLimit:  p.BandwidthLimit,
 		}
 	}
 	return taskPartyInfos
@@ -1128,12 +1152,15 @@ func (h *RunningHandler) buildPartyTemplate(p kusciaapisv1alpha1.Party, appImage
 	ptrDT, err := h.findMatchedDeployTemplate(p, appImageName)
 	if err != nil {
 		nlog.Warnf("Can not get suitable deployTemplate. err: %s", err.Error())
-		return v1alpha1.PartyTemplate{}
+		return v1alpha1.PartyTemplate{Replicas: p.Replicas}
 	}
 
 	deployTemplate = *ptrDT
 	ctrNumber = len(deployTemplate.Spec.Containers)
 	rplNumber = int(*(deployTemplate.Replicas))
+	if p.Replicas != nil {
+		rplNumber = int(*p.Replicas)
+	}
 
 	var everyCPU, everyMemory k8sresource.Quantity
 	var ptr *k8sresource.Quantity
@@ -1187,6 +1214,7 @@ func (h *RunningHandler) buildPartyTemplate(p kusciaapisv1alpha1.Party, appImage
 	if utilsres.IsEmpty(p.Resources) {
 		resources = v1alpha1.PartyTemplate{}
 	}
+	resources.Replicas = p.Replicas
 	return resources
 }
 
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +33,8 @@ import (
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
+	"github.com/secretflow/kuscia/pkg/utils/jobpolicy"
+	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
 )
 
 const (
@@ -862,6 +865,7 @@ func Test_willStartTasksOf(t *testing.T) {
 
 func TestRunningHandler_buildPartyTemplate(t *testing.T) {
 	t.Parallel()
+	replicas := int32(2)
 	type args struct {
 		party        kusciaapisv1alpha1.Party
 		appImageName string
@@ -936,6 +940,50 @@ func TestRunningHandler_buildPartyTemplate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Replicas of party should override deploy template and split resources",
+			args: args{
+				party: kusciaapisv1alpha1.Party{
+					DomainID: "Alice",
+					Role:     "server",
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU: k8sresource.MustParse("2"),
+						},
+					},
+					Replicas: &replicas,
+				},
+				appImageName: "mockImage",
+			},
+			want: kusciaapisv1alpha1.PartyTemplate{
+				Replicas: &replicas,
+				Spec: kusciaapisv1alpha1.PodSpec{
+					Containers: []kusciaapisv1alpha1.Container{
+						{
+							Name: "mock-Container",
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU: k8sresource.MustParse("1"),
+								},
+								Requests: corev1.ResourceList{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Replicas of party should be kept without resources",
+			args: args{
+				party: kusciaapisv1alpha1.Party{
+					DomainID: "Alice",
+					Role:     "server",
+					Replicas: &replicas,
+				},
+				appImageName: "mockImage",
+			},
+			want: kusciaapisv1alpha1.PartyTemplate{Replicas: &replicas},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1035,3 +1083,36 @@ func makeMockAppImage(name string) *kusciaapisv1alpha1.AppImage {
 		},
 	}
 }
+
+func Test_validateJobWithPolicies(t *testing.T) {
+	kubeFakeClient := clientsetfake.NewSimpleClientset()
+	kubeInformerFactory := informers.NewSharedInformerFactory(kubeFakeClient, 0)
+	nsInformer := kubeInformerFactory.Core().V1().Namespaces()
+	nsInformer.Informer().GetStore().Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+	})
+
+	var configs []jobpolicy.Config
+	assert.NoError(t, yaml.Unmarshal([]byte(`
+- name: approved-images
+  type: rules
+  config:
+    appImages: [approved-image]
+`), &configs))
+	jobValidator, err := jobpolicy.Build(configs)
+	assert.NoError(t, err)
+	js := &JobScheduler{
+		namespaceLister: nsInformer.Lister(),
+		jobValidator:    jobValidator,
+	}
+
+	job := makeKusciaJob(KusciaJobForShapeIndependent, kusciaapisv1alpha1.KusciaJobScheduleModeBestEffort, 2, nil)
+	needUpdate, pass := js.validateJob(metav1.Now(), job)
+	assert.True(t, needUpdate)
+	assert.False(t, pass)
+	assert.Equal(t, kusciaapisv1alpha1.KusciaJobFailed, job.Status.Phase)
+	assert.Equal(t, string(kusciaapisv1alpha1.PolicyDenied), job.Status.Reason)
+	cond, _ := utilsres.GetKusciaJobCondition(&job.Status, kusciaapisv1alpha1.JobValidated, false)
+	assert.Equal(t, string(kusciaapisv1alpha1.PolicyDenied), cond.Reason)
+	assert.Contains(t, cond.Message, "denied by policy approved-images")
+}
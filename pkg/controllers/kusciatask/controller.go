@@ -44,6 +44,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 const (
@@ -87,6 +88,7 @@ type Controller struct {
 	namespaceSynced  cache.InformerSynced
 	podsLister       corelisters.PodLister
 	podsSynced       cache.InformerSynced
+	nodesSynced      cache.InformerSynced
 	servicesSynced   cache.InformerSynced
 	servicesLister   corelisters.ServiceLister
 	configMapSynced  cache.InformerSynced
@@ -94,24 +96,28 @@ type Controller struct {
 	kusciaTaskLister kuscialistersv1alpha1.KusciaTaskLister
 	kusciaTaskSynced cache.InformerSynced
 	appImageSynced   cache.InformerSynced
+	domainSynced     cache.InformerSynced
 	trgSynced        cache.InformerSynced
 	trgLister        kuscialistersv1alpha1.TaskResourceGroupLister
 }
 
 // NewController returns a controller instance.
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
+	resyncPeriod := config.ResyncPeriodOr(5 * time.Minute)
 	kubeClient := config.KubeClient
 	kusciaClient := config.KusciaClient
 	eventRecorder := config.EventRecorder
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 5*time.Minute)
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, 5*time.Minute)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, resyncPeriod)
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, resyncPeriod)
 
 	namespaceInformer := kubeInformerFactory.Core().V1().Namespaces()
 	podInformer := kubeInformerFactory.Core().V1().Pods()
+	nodeInformer := kubeInformerFactory.Core().V1().Nodes()
 	serviceInformer := kubeInformerFactory.Core().V1().Services()
 	configMapInformer := kubeInformerFactory.Core().V1().ConfigMaps()
 	kusciaTaskInformer := kusciaInformerFactory.Kuscia().V1alpha1().KusciaTasks()
 	appImageInformer := kusciaInformerFactory.Kuscia().V1alpha1().AppImages()
+	domainInformer := kusciaInformerFactory.Kuscia().V1alpha1().Domains()
 	trgInformer := kusciaInformerFactory.Kuscia().V1alpha1().TaskResourceGroups()
 
 	controller := &Controller{
@@ -123,6 +129,7 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		namespaceSynced:       namespaceInformer.Informer().HasSynced,
 		podsLister:            podInformer.Lister(),
 		podsSynced:            podInformer.Informer().HasSynced,
+		nodesSynced:           nodeInformer.Informer().HasSynced,
 		servicesSynced:        serviceInformer.Informer().HasSynced,
 		servicesLister:        serviceInformer.Lister(),
 		configMapSynced:       configMapInformer.Informer().HasSynced,
@@ -130,6 +137,7 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		kusciaTaskLister:      kusciaTaskInformer.Lister(),
 		kusciaTaskSynced:      kusciaTaskInformer.Informer().HasSynced,
 		appImageSynced:        appImageInformer.Informer().HasSynced,
+		domainSynced:          domainInformer.Informer().HasSynced,
 		trgLister:             trgInformer.Lister(),
 		trgSynced:             trgInformer.Informer().HasSynced,
 		taskQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), taskQueue),
@@ -143,10 +151,13 @@ func NewController(ctx context.Context, config controllers.ControllerConfig) con
 		TrgLister:        trgInformer.Lister(),
 		NamespacesLister: namespaceInformer.Lister(),
 		PodsLister:       controller.podsLister,
+		NodesLister:      nodeInformer.Lister(),
 		ServicesLister:   serviceInformer.Lister(),
 		ConfigMapLister:  configMapInformer.Lister(),
 		AppImagesLister:  appImageInformer.Lister(),
+		DomainLister:     domainInformer.Lister(),
 		Recorder:         eventRecorder,
+		ImageVerifier:    config.ImageVerifier,
 	})
 
 	// kuscia task event handler
@@ -238,8 +249,8 @@ func (c *Controller) Run(workers int) error {
 
 	// Wait for the caches to be synced before starting workers
 	nlog.Infof("Waiting for informer cache to sync for %v", c.Name())
-	if !cache.WaitForCacheSync(c.ctx.Done(), c.namespaceSynced, c.podsSynced, c.servicesSynced, c.configMapSynced,
-		c.kusciaTaskSynced, c.appImageSynced, c.trgSynced) {
+	if !cache.WaitForCacheSync(c.ctx.Done(), c.namespaceSynced, c.podsSynced, c.nodesSynced, c.servicesSynced, c.configMapSynced,
+		c.kusciaTaskSynced, c.appImageSynced, c.domainSynced, c.trgSynced) {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
@@ -558,6 +569,12 @@ func (c *Controller) syncHandler(key string) (retErr error) {
 		phase = kusciaapisv1alpha1.TaskPending
 	}
 
+	jobID := kusciaTask.Annotations[common.JobIDAnnotationKey]
+	_, span := tracing.Start(tracing.ContextFromAnnotations(c.ctx, kusciaTask.Annotations, jobID),
+		"KusciaTask.Reconcile", tracing.AttrJobID.String(jobID), tracing.AttrTaskID.String(kusciaTask.Name),
+		tracing.AttrPhase.String(string(phase)))
+	defer func() { tracing.End(span, retErr) }()
+
 	// Internal state machine flow.
 	needUpdate, err := c.handlerFactory.GetKusciaTaskPhaseHandler(phase).Handle(kusciaTask)
 	if err != nil {
@@ -572,6 +589,10 @@ func (c *Controller) syncHandler(key string) (retErr error) {
 		metrics.SyncDurations.WithLabelValues(string(phase), metrics.Succeeded).Observe(time.Since(startTime).Seconds())
 	}
 
+	if after := handler.RequeueAfter(kusciaTask); after > 0 {
+		c.taskQueue.AddAfter(key, after)
+	}
+
 	if !needUpdate {
 		return nil
 	}
@@ -17,6 +17,7 @@ package handler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -42,6 +43,12 @@ const (
 	defaultResourceReservedSeconds = 30
 	defaultLifecycleSeconds        = 300
 	defaultRetryIntervalSeconds    = 30
+
+	resourceReserveFailedReason  = "ResourceReserveFailed"
+	resourceReserveRetryInterval = 5 * time.Second
+
+	imageVerificationFailedReason = "ImageVerificationFailed"
+	partyLimitsExceededReason     = "PartyLimitsExceeded"
 )
 
 func selfClusterAsParticipant(namespacesLister corelisters.NamespaceLister, kusciaTask *kusciaapisv1alpha1.KusciaTask) (bool, error) {
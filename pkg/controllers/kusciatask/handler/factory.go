@@ -22,6 +22,7 @@ import (
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/imagepolicy"
 )
 
 // Dependencies defines some parameter dependencies of functions.
@@ -31,10 +32,13 @@ type Dependencies struct {
 	TrgLister        kuscialistersv1alpha1.TaskResourceGroupLister
 	NamespacesLister corelisters.NamespaceLister
 	PodsLister       corelisters.PodLister
+	NodesLister      corelisters.NodeLister
 	ServicesLister   corelisters.ServiceLister
 	ConfigMapLister  corelisters.ConfigMapLister
 	AppImagesLister  kuscialistersv1alpha1.AppImageLister
+	DomainLister     kuscialistersv1alpha1.DomainLister
 	Recorder         record.EventRecorder
+	ImageVerifier    *imagepolicy.Verifier
 }
 
 // KusciaTaskPhaseHandler is an interface to handle kuscia task.
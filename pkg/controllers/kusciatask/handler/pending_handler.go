@@ -19,25 +19,33 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/secretflow/kuscia/pkg/common"
 	pkgport "github.com/secretflow/kuscia/pkg/controllers/portflake/port"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/scheduler/reservation"
 	utilcom "github.com/secretflow/kuscia/pkg/utils/common"
+	"github.com/secretflow/kuscia/pkg/utils/imagepolicy"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 	proto "github.com/secretflow/kuscia/proto/api/v1alpha1/appconfig"
 )
 
@@ -48,9 +56,14 @@ type PendingHandler struct {
 	trgLister        kuscialistersv1alpha1.TaskResourceGroupLister
 	namespacesLister corelisters.NamespaceLister
 	podsLister       corelisters.PodLister
+	nodesLister      corelisters.NodeLister
 	servicesLister   corelisters.ServiceLister
 	configMapLister  corelisters.ConfigMapLister
 	appImagesLister  kuscialistersv1alpha1.AppImageLister
+	domainLister     kuscialistersv1alpha1.DomainLister
+	reservations     *reservation.Ledger
+	recorder         record.EventRecorder
+	imageVerifier    *imagepolicy.Verifier
 }
 
 type NamedPorts map[string]kusciaapisv1alpha1.ContainerPort
@@ -70,6 +83,7 @@ type PartyKitInfo struct {
 	kusciaTask            *kusciaapisv1alpha1.KusciaTask
 	domainID              string
 	role                  string
+	appImage              string
 	image                 string
 	imageID               string
 	deployTemplate        *kusciaapisv1alpha1.DeployTemplate
@@ -93,12 +107,23 @@ func NewPendingHandler(deps *Dependencies) *PendingHandler {
 		servicesLister:   deps.ServicesLister,
 		configMapLister:  deps.ConfigMapLister,
 		appImagesLister:  deps.AppImagesLister,
+		domainLister:     deps.DomainLister,
+		nodesLister:      deps.NodesLister,
+		reservations:     reservation.Default(),
+		recorder:         deps.Recorder,
+		imageVerifier:    deps.ImageVerifier,
 	}
 }
 
 // Handle is used to perform the real logic.
 func (h *PendingHandler) Handle(kusciaTask *kusciaapisv1alpha1.KusciaTask) (needUpdate bool, err error) {
 	now := metav1.Now().Rfc3339Copy()
+	defer func() {
+		if kusciaTask.Status.Phase != kusciaapisv1alpha1.TaskPending {
+			h.reservations.Release(kusciaTask.Name)
+		}
+	}()
+
 	if needUpdate, err = h.prepareTaskResources(now, kusciaTask); needUpdate || err != nil {
 		return needUpdate, err
 	}
@@ -135,6 +160,14 @@ func (h *PendingHandler) prepareTaskResources(now metav1.Time, kusciaTask *kusci
 		kusciaTask.Status.Phase = kusciaapisv1alpha1.TaskPending
 	}
 
+	if !h.checkPartyLimits(now, kusciaTask) {
+		return true, nil
+	}
+
+	if verified, changed, err := h.verifyImages(now, kusciaTask); !verified || changed || err != nil {
+		return changed, err
+	}
+
 	cond, found := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondPortsAllocated, true)
 	if !found {
 		needUpdate, err = h.allocatePorts(kusciaTask)
@@ -148,6 +181,11 @@ func (h *PendingHandler) prepareTaskResources(now metav1.Time, kusciaTask *kusci
 		}
 	}
 
+	// stop here until resources are reserved, status won't be updated if nothing is changed
+	if reserved, changed := h.reserveResources(now, kusciaTask); !reserved || changed {
+		return true, nil
+	}
+
 	cond, found = utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondResourceCreated, true)
 	if !found {
 		latestKt, err := h.kusciaClient.KusciaV1alpha1().KusciaTasks(common.KusciaCrossDomain).Get(context.Background(),
@@ -174,6 +212,225 @@ func (h *PendingHandler) prepareTaskResources(now metav1.Time, kusciaTask *kusci
 	return false, nil
 }
 
+// verifyImages resolves and verifies the images of local parties before any resource of the task is allocated. The
+// task fails if any image is rejected by the image policy, and is retried if the registry can't be reached.
+func (h *PendingHandler) verifyImages(now metav1.Time, kusciaTask *kusciaapisv1alpha1.KusciaTask) (verified bool, changed bool, err error) {
+	if h.imageVerifier == nil {
+		return true, false, nil
+	}
+	cond, _ := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondImageVerified, true)
+	if cond.Status == v1.ConditionTrue {
+		return true, false, nil
+	}
+
+	_, selfPartyKitInfos, err := h.buildPartyKitInfos(kusciaTask)
+	if err != nil {
+		return false, false, err
+	}
+	for _, partyKit := range selfPartyKitInfos {
+		if partyKit.image == "" {
+			continue
+		}
+		if _, err = h.imageVerifier.Admit(context.Background(), partyKit.image); err == nil {
+			continue
+		}
+		if !imagepolicy.IsRejected(err) {
+			nlog.Warnf("Verify image %v of kuscia task %v failed, %v, wait for next round", partyKit.image, kusciaTask.Name, err)
+			return false, false, err
+		}
+
+		message := fmt.Sprintf("party %v/%v: %v", partyKit.domainID, partyKit.role, err)
+		utilsres.SetKusciaTaskCondition(now, cond, v1.ConditionFalse, imageVerificationFailedReason, message)
+		kusciaTask.Status.Phase = kusciaapisv1alpha1.TaskFailed
+		kusciaTask.Status.Message = fmt.Sprintf("The task image is not allowed to run, %v", message)
+		kusciaTask.Status.LastReconcileTime = &now
+		if h.recorder != nil {
+			h.recorder.Event(kusciaTask, v1.EventTypeWarning, imageVerificationFailedReason, message)
+		}
+		return false, true, nil
+	}
+
+	utilsres.SetKusciaTaskCondition(now, cond, v1.ConditionTrue, "", "")
+	kusciaTask.Status.LastReconcileTime = &now
+	return true, true, nil
+}
+
+// checkPartyLimits fails the task if any local party requests more resources than the task party limits of its
+// domain. Resources and replicas of parties are set by the initiator, so the caps are enforced by the receiving side.
+func (h *PendingHandler) checkPartyLimits(now metav1.Time, kusciaTask *kusciaapisv1alpha1.KusciaTask) bool {
+	if h.domainLister == nil {
+		return true
+	}
+	if cond, _ := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondResourceCreated, false); cond != nil {
+		return true
+	}
+	_, selfPartyKitInfos, err := h.buildPartyKitInfos(kusciaTask)
+	if err != nil {
+		// the error is reported by the following steps
+		return true
+	}
+
+	keys := make([]string, 0, len(selfPartyKitInfos))
+	for key := range selfPartyKitInfos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		partyKit := selfPartyKitInfos[key]
+		if partyKit.deployTemplate == nil {
+			continue
+		}
+		domain, err := h.domainLister.Get(partyKit.domainID)
+		if err != nil || domain.Spec.ResourceQuota == nil || domain.Spec.ResourceQuota.TaskPartyLimits == nil {
+			continue
+		}
+		if err = checkPartyKitLimits(partyKit, domain.Spec.ResourceQuota.TaskPartyLimits); err == nil {
+			continue
+		}
+
+		message := fmt.Sprintf("party %v/%v: %v", partyKit.domainID, partyKit.role, err)
+		kusciaTask.Status.Phase = kusciaapisv1alpha1.TaskFailed
+		kusciaTask.Status.Reason = partyLimitsExceededReason
+		kusciaTask.Status.Message = fmt.Sprintf("The task exceeds the resource limits of domain, %v", message)
+		kusciaTask.Status.LastReconcileTime = &now
+		if h.recorder != nil {
+			h.recorder.Event(kusciaTask, v1.EventTypeWarning, partyLimitsExceededReason, message)
+		}
+		return false
+	}
+	return true
+}
+
+// checkPartyKitLimits checks the total resources of the party's pods. The limit of a container is counted, or the
+// request if the container has no limit.
+func checkPartyKitLimits(partyKit *PartyKitInfo, limits *kusciaapisv1alpha1.TaskPartyLimits) error {
+	replicas := len(partyKit.pods)
+	if limits.MaxReplicas != nil && replicas > int(*limits.MaxReplicas) {
+		return fmt.Errorf("replicas %d exceed the limit %d", replicas, *limits.MaxReplicas)
+	}
+
+	caps := map[v1.ResourceName]*resource.Quantity{v1.ResourceCPU: limits.MaxCPU, v1.ResourceMemory: limits.MaxMemory}
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		if caps[name] == nil {
+			continue
+		}
+		perPod := resource.Quantity{}
+		for _, ctr := range partyKit.deployTemplate.Spec.Containers {
+			quantity, ok := ctr.Resources.Limits[name]
+			if !ok {
+				quantity = ctr.Resources.Requests[name]
+			}
+			perPod.Add(quantity)
+		}
+		total := resource.Quantity{}
+		for i := 0; i < replicas; i++ {
+			total.Add(perPod)
+		}
+		if total.Cmp(*caps[name]) > 0 {
+			return fmt.Errorf("%v %s of %d pods exceeds the limit %s", name, total.String(), replicas, caps[name].String())
+		}
+	}
+	return nil
+}
+
+// pinImages replaces the images of local parties with the images by digest which were verified.
+func (h *PendingHandler) pinImages(selfPartyKitInfos map[string]*PartyKitInfo) error {
+	if h.imageVerifier == nil {
+		return nil
+	}
+	for _, partyKit := range selfPartyKitInfos {
+		if partyKit.image == "" {
+			continue
+		}
+		image, err := h.imageVerifier.Admit(context.Background(), partyKit.image)
+		if err != nil {
+			return err
+		}
+		partyKit.image = image
+	}
+	return nil
+}
+
+// reserveResources reserves resources of all local parties at once before creating pods, so no pod of the task holds
+// resources unless every local party has enough resources. The task waits for resources until its lifecycle is over.
+func (h *PendingHandler) reserveResources(now metav1.Time, kusciaTask *kusciaapisv1alpha1.KusciaTask) (reserved bool, changed bool) {
+	if h.nodesLister == nil {
+		return true, false
+	}
+	if cond, _ := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondResourceCreated, false); cond != nil {
+		return true, false
+	}
+	cond, _ := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondResourceReserved, true)
+	if cond.Status == v1.ConditionTrue {
+		return true, false
+	}
+
+	err := h.reserveResourcesForParties(kusciaTask)
+	if err == nil {
+		utilsres.SetKusciaTaskCondition(now, cond, v1.ConditionTrue, "", "")
+		kusciaTask.Status.LastReconcileTime = &now
+		return true, true
+	}
+
+	lifecycleSeconds := defaultLifecycleSeconds
+	if kusciaTask.Spec.ScheduleConfig.LifecycleSeconds > 0 {
+		lifecycleSeconds = kusciaTask.Spec.ScheduleConfig.LifecycleSeconds
+	}
+	if kusciaTask.Status.StartTime != nil && now.Sub(kusciaTask.Status.StartTime.Time) > time.Duration(lifecycleSeconds)*time.Second {
+		kusciaTask.Status.Phase = kusciaapisv1alpha1.TaskFailed
+		kusciaTask.Status.Message = fmt.Sprintf("The task resources were not reserved within %v seconds of its entire lifecycle, %v", lifecycleSeconds, err)
+		kusciaTask.Status.LastReconcileTime = &now
+		return false, true
+	}
+
+	nlog.Infof("Reserve resources for kuscia task %v failed, %v, wait for next round", kusciaTask.Name, err)
+	return false, utilsres.SetKusciaTaskCondition(now, cond, v1.ConditionFalse, resourceReserveFailedReason, err.Error())
+}
+
+func (h *PendingHandler) reserveResourcesForParties(kusciaTask *kusciaapisv1alpha1.KusciaTask) error {
+	_, selfPartyKitInfos, err := h.buildPartyKitInfos(kusciaTask)
+	if err != nil {
+		return err
+	}
+
+	requests := map[string]v1.ResourceList{}
+	for _, partyKit := range selfPartyKitInfos {
+		if requests[partyKit.domainID] == nil {
+			requests[partyKit.domainID] = v1.ResourceList{}
+		}
+		for range partyKit.pods {
+			for _, c := range partyKit.deployTemplate.Spec.Containers {
+				reservation.Add(requests[partyKit.domainID], reservation.ContainerRequests(c.Resources))
+			}
+		}
+	}
+
+	nodes, err := h.nodesLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	pods, err := h.podsLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	return h.reservations.Reserve(kusciaTask.Name, requests, func(namespace string) (v1.ResourceList, bool) {
+		return reservation.FreeResources(namespace, nodes, pods)
+	}, reservation.DefaultWindow)
+}
+
+// RequeueAfter returns the duration after which the task should be handled again, zero means no need to requeue.
+// A pending task waiting for resources isn't triggered by any event, so it's requeued periodically.
+func RequeueAfter(kusciaTask *kusciaapisv1alpha1.KusciaTask) time.Duration {
+	if kusciaTask.Status.Phase != kusciaapisv1alpha1.TaskPending {
+		return 0
+	}
+	cond, _ := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondResourceReserved, false)
+	if cond != nil && cond.Status == v1.ConditionFalse {
+		return resourceReserveRetryInterval
+	}
+	return 0
+}
+
 func (h *PendingHandler) buildPartyKitInfos(kusciaTask *kusciaapisv1alpha1.KusciaTask) (map[string]*PartyKitInfo, map[string]*PartyKitInfo, error) {
 	partyKitInfos := map[string]*PartyKitInfo{}
 	selfPartyKitInfos := map[string]*PartyKitInfo{}
@@ -212,6 +469,10 @@ func (h *PendingHandler) createTaskResources(kusciaTask *kusciaapisv1alpha1.Kusc
 		return err
 	}
 
+	if err = h.pinImages(selfPartyKitInfos); err != nil {
+		return err
+	}
+
 	parties := generateParties(partyKitInfos)
 
 	for _, partyKitInfo := range partyKitInfos {
@@ -402,8 +663,13 @@ func (h *PendingHandler) buildPartyKitInfo(kusciaTask *kusciaapisv1alpha1.Kuscia
 		minReservedPods = replicas
 	}
 
-	kit.image = fmt.Sprintf("%s:%s", appImage.Spec.Image.Name, appImage.Spec.Image.Tag)
-	kit.imageID = appImage.Spec.Image.ID
+	imageInfo, err := utilsres.SelectAppImageInfo(appImage, party.AppImageChannel)
+	if err != nil {
+		return nil, err
+	}
+	kit.appImage = appImage.Name
+	kit.image = fmt.Sprintf("%s:%s", imageInfo.Name, imageInfo.Tag)
+	kit.imageID = imageInfo.ID
 	kit.deployTemplate = deployTemplate
 	kit.configTemplates = appImage.Spec.ConfigTemplates
 	kit.servicedPorts = servicedPorts
@@ -1043,6 +1309,13 @@ func (h *PendingHandler) generatePod(partyKit *PartyKitInfo, podKit *PodKitInfo)
 		common.TaskResourceGroupAnnotationKey: partyKit.kusciaTask.Name,
 		kusciaapisv1alpha1.TaskResourceKey:    "",
 	}
+	// job id is used to account resource usage of the job
+	jobID := partyKit.kusciaTask.Annotations[common.JobIDAnnotationKey]
+	if jobID != "" {
+		annotations[common.JobIDAnnotationKey] = jobID
+	}
+	// the pod and its engine join the trace of the job
+	tracing.InjectAnnotations(tracing.ContextFromAnnotations(context.Background(), partyKit.kusciaTask.Annotations, jobID), annotations)
 
 	var protocolType string
 	if partyKit.kusciaTask.Labels != nil {
@@ -1084,6 +1357,13 @@ func (h *PendingHandler) generatePod(partyKit *PartyKitInfo, podKit *PodKitInfo)
 					Operator: v1.TolerationOpExists,
 					Effect:   v1.TaintEffectNoSchedule,
 				},
+				// Lite nodes keep running tasks while the link to master is down, and sync the
+				// statuses after reconnecting, so the pod must not be evicted from an unreachable node.
+				{
+					Key:      v1.TaintNodeUnreachable,
+					Operator: v1.TolerationOpExists,
+					Effect:   v1.TaintEffectNoExecute,
+				},
 			},
 			NodeSelector: map[string]string{
 				common.LabelNodeNamespace: partyKit.domainID,
@@ -1098,6 +1378,9 @@ func (h *PendingHandler) generatePod(partyKit *PartyKitInfo, podKit *PodKitInfo)
 	if partyKit.imageID != "" {
 		pod.Annotations[common.ImageIDAnnotationKey] = partyKit.imageID
 	}
+	if partyKit.appImage != "" {
+		pod.Annotations[common.AppImageAnnotationKey] = partyKit.appImage
+	}
 
 	needConfigTemplateVolume := false
 	for _, ctr := range partyKit.deployTemplate.Spec.Containers {
@@ -1150,6 +1433,10 @@ func (h *PendingHandler) generatePod(partyKit *PartyKitInfo, podKit *PodKitInfo)
 			resCtr.Ports = append(resCtr.Ports, resPort)
 		}
 
+		if traceParent := annotations[common.TraceParentAnnotationKey]; traceParent != "" {
+			resCtr.Env = append(resCtr.Env, v1.EnvVar{Name: tracing.EnvTraceParent, Value: traceParent})
+		}
+
 		portNumberEnvs := buildPortNumberEnvs(podKit.allocatedPorts) // todo : remove it , now scql use it ,20240829
 		if len(portNumberEnvs) > 0 {
 			resCtr.Env = append(resCtr.Env, portNumberEnvs...)
@@ -1285,6 +1572,9 @@ func generateServices(partyKit *PartyKitInfo, pod *v1.Pod, serviceName string, p
 		common.AccessDomainAnnotationKey: partyKit.portAccessDomains[port.Name],
 		common.TaskIDAnnotationKey:       partyKit.kusciaTask.Name,
 	}
+	if jobID := partyKit.kusciaTask.Annotations[common.JobIDAnnotationKey]; jobID != "" {
+		svc.Annotations[common.JobIDAnnotationKey] = jobID
+	}
 
 	for _, limit := range partyKit.bandwidthLimit {
 		key := fmt.Sprintf("%s%s", common.TaskBandwidthLimitAnnotationPrefix, limit.DestinationID)
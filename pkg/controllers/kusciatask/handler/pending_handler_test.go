@@ -16,24 +16,40 @@ package handler
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/secretflow/kuscia/pkg/common"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/yaml"
 
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
+	"github.com/secretflow/kuscia/pkg/scheduler/reservation"
+	"github.com/secretflow/kuscia/pkg/utils/imagepolicy"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
 	proto "github.com/secretflow/kuscia/proto/api/v1alpha1/appconfig"
 )
@@ -147,6 +163,154 @@ func TestPendingHandler_Handle(t *testing.T) {
 	assert.Equal(t, v1.ConditionFalse, createdCondition.Status)
 }
 
+func TestPendingHandler_reserveResources(t *testing.T) {
+	t.Parallel()
+	handler := makeTestPendingHandler()
+	handler.reservations = reservation.NewLedger()
+	nodeInformer := kubeinformers.NewSharedInformerFactory(handler.kubeClient, 0).Core().V1().Nodes()
+	handler.nodesLister = nodeInformer.Lister()
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{common.LabelNodeNamespace: "domain-a"}},
+		Status:     v1.NodeStatus{Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}},
+	}
+	assert.NoError(t, nodeInformer.Informer().GetStore().Add(node))
+
+	kusciaTask := makeTestKusciaTaskCase1()
+	kusciaTask.Status.Phase = kusciaapisv1alpha1.TaskPending
+	now := metav1.Now()
+	kusciaTask.Status.StartTime = &now
+	reserved, changed := handler.reserveResources(now, kusciaTask)
+	assert.False(t, reserved)
+	assert.True(t, changed)
+	cond, _ := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondResourceReserved, false)
+	assert.Equal(t, v1.ConditionFalse, cond.Status)
+	assert.Contains(t, cond.Message, "domain domain-a has insufficient cpu")
+	assert.Equal(t, resourceReserveRetryInterval, RequeueAfter(kusciaTask))
+
+	node = node.DeepCopy()
+	node.Status.Allocatable[v1.ResourceCPU] = resource.MustParse("3")
+	assert.NoError(t, nodeInformer.Informer().GetStore().Update(node))
+	reserved, changed = handler.reserveResources(now, kusciaTask)
+	assert.True(t, reserved)
+	assert.True(t, changed)
+	assert.Equal(t, v1.ConditionTrue, cond.Status)
+	assert.Equal(t, time.Duration(0), RequeueAfter(kusciaTask))
+	reservedCPU := handler.reservations.ReservedByOthers("domain-a", "other-task")[v1.ResourceCPU]
+	assert.Equal(t, "2", reservedCPU.String())
+
+	// the task fails if resources are not reserved within its lifecycle
+	otherTask := makeTestKusciaTaskCase1()
+	otherTask.Name = "kusciatask-002"
+	otherTask.Status.Phase = kusciaapisv1alpha1.TaskPending
+	startTime := metav1.NewTime(now.Add(-time.Hour))
+	otherTask.Status.StartTime = &startTime
+	reserved, changed = handler.reserveResources(now, otherTask)
+	assert.False(t, reserved)
+	assert.True(t, changed)
+	assert.Equal(t, kusciaapisv1alpha1.TaskFailed, otherTask.Status.Phase)
+}
+
+func TestPendingHandler_verifyImages(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	imageName := strings.TrimPrefix(server.URL, "http://") + "/secretflow/test-image"
+	ref, err := name.ParseReference(imageName+":1.0.0", name.Insecure)
+	assert.NoError(t, err)
+	img, err := random.Image(64, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	assert.NoError(t, err)
+
+	handler := makeTestPendingHandler()
+	appImage := makeTestAppImageCase1()
+	appImage.Spec.Image.Name = imageName
+	appImageInformer := kusciainformers.NewSharedInformerFactory(handler.kusciaClient, 0).Kuscia().V1alpha1().AppImages()
+	assert.NoError(t, appImageInformer.Informer().GetStore().Add(appImage))
+	handler.appImagesLister = appImageInformer.Lister()
+
+	// images are pinned to digests
+	handler.imageVerifier, err = imagepolicy.Build(&imagepolicy.Config{PinDigest: true, Insecure: true})
+	assert.NoError(t, err)
+	kusciaTask := makeTestKusciaTaskCase1()
+	now := metav1.Now()
+	verified, changed, err := handler.verifyImages(now, kusciaTask)
+	assert.NoError(t, err)
+	assert.True(t, verified)
+	assert.True(t, changed)
+	cond, _ := utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondImageVerified, false)
+	assert.Equal(t, v1.ConditionTrue, cond.Status)
+
+	_, selfPartyKitInfos, err := handler.buildPartyKitInfos(kusciaTask)
+	assert.NoError(t, err)
+	assert.NoError(t, handler.pinImages(selfPartyKitInfos))
+	for _, kit := range selfPartyKitInfos {
+		assert.Equal(t, imageName+"@"+digest.String(), kit.image)
+	}
+
+	// the task fails if its image isn't signed
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "cosign.pub")
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	handler.imageVerifier, err = imagepolicy.Build(&imagepolicy.Config{
+		Cosign:   &imagepolicy.CosignConfig{PublicKeys: []string{keyFile}},
+		Insecure: true,
+	})
+	assert.NoError(t, err)
+	recorder := record.NewFakeRecorder(10)
+	handler.recorder = recorder
+	kusciaTask = makeTestKusciaTaskCase1()
+	verified, changed, err = handler.verifyImages(now, kusciaTask)
+	assert.NoError(t, err)
+	assert.False(t, verified)
+	assert.True(t, changed)
+	assert.Equal(t, kusciaapisv1alpha1.TaskFailed, kusciaTask.Status.Phase)
+	cond, _ = utilsres.GetKusciaTaskCondition(&kusciaTask.Status, kusciaapisv1alpha1.KusciaTaskCondImageVerified, false)
+	assert.Equal(t, v1.ConditionFalse, cond.Status)
+	assert.Equal(t, imageVerificationFailedReason, cond.Reason)
+	assert.Contains(t, <-recorder.Events, "no cosign signature found")
+}
+
+func TestPendingHandler_checkPartyLimits(t *testing.T) {
+	t.Parallel()
+	handler := makeTestPendingHandler()
+	maxCPU, maxReplicas := resource.MustParse("3"), int32(2)
+	domainInformer := kusciainformers.NewSharedInformerFactory(handler.kusciaClient, 0).Kuscia().V1alpha1().Domains()
+	assert.NoError(t, domainInformer.Informer().GetStore().Add(&kusciaapisv1alpha1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "domain-a"},
+		Spec: kusciaapisv1alpha1.DomainSpec{ResourceQuota: &kusciaapisv1alpha1.DomainResourceQuota{
+			TaskPartyLimits: &kusciaapisv1alpha1.TaskPartyLimits{MaxCPU: &maxCPU, MaxReplicas: &maxReplicas},
+		}},
+	}))
+	handler.domainLister = domainInformer.Lister()
+
+	makeTask := func(replicas int32, cpu string) *kusciaapisv1alpha1.KusciaTask {
+		kt := makeTestKusciaTaskCase1()
+		kt.Spec.Parties[0].Template.Replicas = &replicas
+		kt.Spec.Parties[0].Template.Spec.Containers[0].Resources.Limits = v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)}
+		return kt
+	}
+	now := metav1.Now()
+
+	kusciaTask := makeTask(2, "1")
+	assert.True(t, handler.checkPartyLimits(now, kusciaTask))
+	assert.Equal(t, kusciaapisv1alpha1.KusciaTaskPhase(""), kusciaTask.Status.Phase)
+
+	kusciaTask = makeTask(2, "2")
+	assert.False(t, handler.checkPartyLimits(now, kusciaTask))
+	assert.Equal(t, kusciaapisv1alpha1.TaskFailed, kusciaTask.Status.Phase)
+	assert.Equal(t, partyLimitsExceededReason, kusciaTask.Status.Reason)
+	assert.Contains(t, kusciaTask.Status.Message, "cpu 4 of 2 pods exceeds the limit 3")
+
+	kusciaTask = makeTask(3, "500m")
+	assert.False(t, handler.checkPartyLimits(now, kusciaTask))
+	assert.Contains(t, kusciaTask.Status.Message, "replicas 3 exceed the limit 2")
+}
+
 func Test_mergeDeployTemplate(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -383,6 +547,9 @@ spec:
   - effect: NoSchedule
     key: kuscia.secretflow/agent
     operator: Exists
+  - effect: NoExecute
+    key: node.kubernetes.io/unreachable
+    operator: Exists
   volumes:
   - configMap:
       name: kusciatask-001-configtemplate
@@ -36,6 +36,7 @@ import (
 
 const (
 	defaultPullImageRetryCount = 3
+	maxPartyPodEventLength     = 256
 
 	errImagePull        = "ErrImagePull"
 	errImagePullBackOff = "ErrImagePullBackOff"
@@ -199,6 +200,7 @@ func (h *RunningHandler) buildPartyTaskStatus(taskStatus *kusciaapisv1alpha1.Kus
 		default:
 			partyTaskStatus.Phase = kusciaapisv1alpha1.TaskPending
 		}
+		partyTaskStatus.Pods = h.buildPartyPodStatuses(party)
 
 		partyTaskStatuses = append(partyTaskStatuses, partyTaskStatus)
 	}
@@ -359,6 +361,89 @@ func buildTaskStatusMessage(trg *kusciaapisv1alpha1.TaskResourceGroup) string {
 	return strings.TrimSuffix(condReason, ",")
 }
 
+// buildPartyPodStatuses builds the pod-level progress of a local party, which is synced to other parties with the
+// party task status.
+func (h *RunningHandler) buildPartyPodStatuses(party kusciaapisv1alpha1.TaskResourceGroupParty) []kusciaapisv1alpha1.PartyPodStatus {
+	if len(party.Pods) == 0 {
+		return nil
+	}
+	statuses := make([]kusciaapisv1alpha1.PartyPodStatus, 0, len(party.Pods))
+	for _, pp := range party.Pods {
+		pod, err := h.podsLister.Pods(party.DomainID).Get(pp.Name)
+		if err != nil {
+			statuses = append(statuses, kusciaapisv1alpha1.PartyPodStatus{
+				Name:      pp.Name,
+				Phase:     v1.PodPending,
+				LastEvent: "PodNotFound",
+			})
+			continue
+		}
+		statuses = append(statuses, newPartyPodStatus(pod))
+	}
+	return statuses
+}
+
+// newPartyPodStatus summarizes the pod. The last event is picked from the most relevant state instead of the
+// event list, so it doesn't change unless the pod changes: a waiting container, a terminated container, the last
+// termination, a false pod condition and then the pod reason.
+func newPartyPodStatus(pod *v1.Pod) kusciaapisv1alpha1.PartyPodStatus {
+	st := kusciaapisv1alpha1.PartyPodStatus{
+		Name:  pod.Name,
+		Phase: pod.Status.Phase,
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			st.Ready = cond.Status == v1.ConditionTrue
+		}
+	}
+
+	setEvent := func(reason, message string, t *metav1.Time) {
+		if st.LastEvent != "" || reason == "" {
+			return
+		}
+		st.LastEvent = reason
+		if message != "" {
+			st.LastEvent = fmt.Sprintf("%s: %s", reason, message)
+		}
+		if len(st.LastEvent) > maxPartyPodEventLength {
+			st.LastEvent = st.LastEvent[:maxPartyPodEventLength]
+		}
+		if t != nil && !t.IsZero() {
+			st.LastEventTime = t.DeepCopy()
+		}
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		st.RestartCount += cs.RestartCount
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil {
+			var t *metav1.Time
+			if cs.LastTerminationState.Terminated != nil {
+				t = &cs.LastTerminationState.Terminated.FinishedAt
+			}
+			setEvent(cs.State.Waiting.Reason, cs.State.Waiting.Message, t)
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated != nil {
+			setEvent(cs.State.Terminated.Reason, cs.State.Terminated.Message, &cs.State.Terminated.FinishedAt)
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if last := cs.LastTerminationState.Terminated; last != nil {
+			setEvent(last.Reason, fmt.Sprintf("container %s exited with code %d", cs.Name, last.ExitCode), &last.FinishedAt)
+		}
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Status != v1.ConditionTrue {
+			setEvent(cond.Reason, cond.Message, &cond.LastTransitionTime)
+		}
+	}
+	setEvent(pod.Status.Reason, pod.Status.Message, nil)
+	return st
+}
+
 func buildOuterPartyTaskStatus(taskStatus *kusciaapisv1alpha1.KusciaTaskStatus, trgParty *kusciaapisv1alpha1.TaskResourceGroupParty) *kusciaapisv1alpha1.PartyTaskStatus {
 	outerPartyTaskStatus := &kusciaapisv1alpha1.PartyTaskStatus{
 		DomainID: trgParty.DomainID,
@@ -370,6 +455,7 @@ func buildOuterPartyTaskStatus(taskStatus *kusciaapisv1alpha1.KusciaTaskStatus,
 		if s.DomainID == outerPartyTaskStatus.DomainID && s.Role == outerPartyTaskStatus.Role {
 			outerPartyTaskStatus.Phase = s.Phase
 			outerPartyTaskStatus.Message = s.Message
+			outerPartyTaskStatus.Pods = s.Pods
 			break
 		}
 	}
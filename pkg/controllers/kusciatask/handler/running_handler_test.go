@@ -678,3 +678,43 @@ func TestReconcileTaskStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPartyPodStatus(t *testing.T) {
+	finishedAt := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-1-0", Namespace: "alice"},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: v1.ConditionFalse, Reason: "ContainersNotReady"},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "app",
+					RestartCount: 3,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 40s restarting failed container"},
+					},
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1, FinishedAt: finishedAt},
+					},
+				},
+				{Name: "sidecar", RestartCount: 1, Ready: true},
+			},
+		},
+	}
+
+	st := newPartyPodStatus(pod)
+	assert.Equal(t, "task-1-0", st.Name)
+	assert.Equal(t, v1.PodRunning, st.Phase)
+	assert.False(t, st.Ready)
+	assert.Equal(t, int32(4), st.RestartCount)
+	assert.Equal(t, "CrashLoopBackOff: back-off 40s restarting failed container", st.LastEvent)
+	assert.True(t, finishedAt.Equal(st.LastEventTime))
+
+	pod.Status.ContainerStatuses[0].State = v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	pod.Status.Conditions[0].Status = v1.ConditionTrue
+	st = newPartyPodStatus(pod)
+	assert.True(t, st.Ready)
+	assert.Equal(t, "Error: container app exited with code 1", st.LastEvent)
+}
@@ -16,10 +16,18 @@ package controllers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/pflag"
 
 	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/election"
+	"github.com/secretflow/kuscia/pkg/utils/imagepolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
+	"github.com/secretflow/kuscia/pkg/utils/jobpolicy"
+	"github.com/secretflow/kuscia/pkg/utils/jobqueue"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 )
 
 // Options is the main context object for the domain controller.
@@ -42,6 +50,30 @@ type Options struct {
 	ControllerName string
 
 	EnableWorkloadApprove bool
+	// ApprovePolicies approve jobs of partners automatically when EnableWorkloadApprove is true.
+	ApprovePolicies []approval.Policy
+	// JobValidator denies jobs violating org-specific policies before tasks are created.
+	JobValidator *jobpolicy.Chain
+	// ImageVerifier pins images of tasks to digests and rejects tasks running unsigned images, nil means disabled.
+	ImageVerifier *imagepolicy.Verifier
+
+	// LeaderElection is the lease timing of controllers leader election.
+	LeaderElection election.Config
+
+	// Heartbeat defines when domains are unreachable without heartbeats.
+	Heartbeat kusciaconfig.HeartbeatConfig
+
+	// JobQueue limits the running jobs initiated by this cluster.
+	JobQueue jobqueue.Config
+
+	// JobTTLAfterFinished is the default lifetime of finished jobs, 0 means the default of garbage collection.
+	JobTTLAfterFinished time.Duration
+
+	// JobHistory archives the summary of finished jobs, nil means no history is kept.
+	JobHistory jobhistory.Store
+
+	// Controllers overrides workers and resync periods of controllers, Workers is used if it's not set.
+	Controllers kusciaconfig.ControllersConfig
 }
 
 // NewOptions creates a new options with a default config.
@@ -31,13 +31,13 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/record"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kusciascheme "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/scheme"
+	"github.com/secretflow/kuscia/pkg/utils/admin"
 	"github.com/secretflow/kuscia/pkg/utils/election"
 	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/queue"
 )
 
 var (
@@ -47,6 +47,8 @@ var (
 	leaderHealthzAdaptorTimeout = time.Second * 20
 )
 
+const adminStatusName = "controllers"
+
 // Server defines detailed info which used to run server.
 type Server struct {
 	ctx                     context.Context
@@ -60,6 +62,8 @@ type Server struct {
 	electionChecker         *leaderelection.HealthzAdaptor
 	controllers             []IController
 	controllerConstructions []ControllerConstruction
+	// lastLeader is the leader observed before, used to detect failover between masters.
+	lastLeader string
 }
 
 func buildEventRecorder(kubeClient kubernetes.Interface, name string) record.EventRecorder {
@@ -84,6 +88,8 @@ func NewServer(opts *Options, clients *kubeconfig.KubeClients, controllerConstru
 		s.kubeClient,
 		s.options.ControllerName,
 		election.WithHealthChecker(s.electionChecker),
+		election.WithConfig(s.options.LeaderElection),
+		election.WithEventRecorder(s.eventRecorder),
 		election.WithOnNewLeader(s.onNewLeader),
 		election.WithOnStartedLeading(s.onStartedLeading),
 		election.WithOnStoppedLeading(s.onStoppedLeading))
@@ -117,10 +123,32 @@ func (s *Server) Run(ctx context.Context) error {
 	nlog.Infof("Start running with %q identity", s.leaderElector.MyIdentity())
 
 	s.runHealthCheckServer()
+	admin.RegisterStatus(adminStatusName, s.status)
 	s.leaderElector.Run(ctx)
 	return nil
 }
 
+// ServerStatus is the status page of controllers on the admin server, depths of their queues are in workqueues.
+type ServerStatus struct {
+	Identity    string   `json:"identity"`
+	Leader      string   `json:"leader"`
+	Controllers []string `json:"controllers"`
+}
+
+func (s *Server) status(ctx context.Context) (interface{}, error) {
+	status := &ServerStatus{
+		Identity:    s.leaderElector.MyIdentity(),
+		Leader:      s.leaderElector.GetLeader(),
+		Controllers: []string{},
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, controller := range s.controllers {
+		status.Controllers = append(status.Controllers, controller.Name())
+	}
+	return status, nil
+}
+
 // onNewLeader is executed when leader is changed.
 func (s *Server) onNewLeader(identity string) {
 	nlog.Info("On new leader")
@@ -128,6 +156,16 @@ func (s *Server) onNewLeader(identity string) {
 		return
 	}
 
+	s.mutex.Lock()
+	previous := s.lastLeader
+	s.lastLeader = identity
+	s.mutex.Unlock()
+	if identity == s.leaderElector.MyIdentity() && previous != "" && previous != identity {
+		// Masters of a federation share the datastore and the lease, the controllers started below list
+		// all resources and converge the jobs left by the previous leader.
+		nlog.Warnf("Take over controllers from previous leader %s, all resources will be reconciled", previous)
+	}
+
 	if identity == s.leaderElector.MyIdentity() {
 		if s.controllersIsEmpty() {
 			s.onStartedLeading(s.ctx)
@@ -154,12 +192,21 @@ func (s *Server) onStartedLeading(ctx context.Context) {
 		KusciaClient:          s.kusciaClient,
 		EventRecorder:         s.eventRecorder,
 		EnableWorkloadApprove: s.options.EnableWorkloadApprove,
+		ApprovePolicies:       s.options.ApprovePolicies,
+		JobValidator:          s.options.JobValidator,
+		ImageVerifier:         s.options.ImageVerifier,
+		Heartbeat:             s.options.Heartbeat,
+		JobQueue:              s.options.JobQueue,
+		JobTTLAfterFinished:   s.options.JobTTLAfterFinished,
+		JobHistory:            s.options.JobHistory,
 	}
 	for _, cc := range s.controllerConstructions {
+		config.ResyncPeriod = s.options.Controllers.GetResyncPeriod(cc.Name)
+		workers := s.options.Controllers.GetWorkers(cc.Name, s.options.Workers)
 		controller := cc.NewControler(ctx, config)
-		nlog.Infof("Run controller %v", controller.Name())
+		nlog.Infof("Run controller %v with %d workers", controller.Name(), workers)
 		go func(controller IController) {
-			if err := controller.Run(s.options.Workers); err != nil {
+			if err := controller.Run(workers); err != nil {
 				nlog.Fatalf("Error running controller %v, %v", controller.Name(), err)
 			} else {
 				nlog.Infof("Controller %v exit", controller.Name())
@@ -194,7 +241,7 @@ func (s *Server) runHealthCheckServer() {
 
 	mux := http.NewServeMux()
 	healthz.InstallPathHandler(mux, "/healthz", checks...)
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", queue.MetricsHandler())
 
 	httpServer := &http.Server{
 		Addr:    fmt.Sprintf("127.0.0.1:%d", s.options.HealthCheckPort),
@@ -30,6 +30,7 @@ import (
 
 	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	"github.com/secretflow/kuscia/pkg/utils/election"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 )
 
 type testcontroller struct {
@@ -75,7 +76,7 @@ func Test_server_run(t *testing.T) {
 	extensionClient := extensionfake.NewSimpleClientset()
 	stopCh := make(chan struct{})
 	ctx := signals.NewKusciaContextWithStopCh(stopCh)
-	s := NewServer(opts, &kubeconfig.KubeClients{KubeClient: kubeClient, KusciaClient: kusciaClient, ExtensionsClient: extensionClient}, []ControllerConstruction{{testNewControllerFunc, nil}})
+	s := NewServer(opts, &kubeconfig.KubeClients{KubeClient: kubeClient, KusciaClient: kusciaClient, ExtensionsClient: extensionClient}, []ControllerConstruction{{Name: "test", NewControler: testNewControllerFunc}})
 
 	stoppedChan := make(chan struct{})
 
@@ -120,7 +121,7 @@ func Test_server_restartLeading(t *testing.T) {
 		kubeClient:              kubeClient,
 		kusciaClient:            kusciaClient,
 		options:                 opts,
-		controllerConstructions: []ControllerConstruction{{testNewControllerFunc, nil}},
+		controllerConstructions: []ControllerConstruction{{Name: "test", NewControler: testNewControllerFunc}},
 	}
 
 	s.leaderElector = election.NewElector(
@@ -136,6 +137,9 @@ func Test_server_restartLeading(t *testing.T) {
 	time.Sleep(time.Second)
 	goroutineNum := runtime.NumGoroutine()
 	assert.True(t, goroutineNumBegin < goroutineNum)
+	status, err := s.status(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testcontroller"}, status.(*ServerStatus).Controllers)
 	s.onStoppedLeading()
 	time.Sleep(time.Second)
 	assert.Equal(t, goroutineNumBegin, runtime.NumGoroutine())
@@ -163,7 +167,7 @@ func Test_server_NewLeading(t *testing.T) {
 		kubeClient:              kubeClient,
 		kusciaClient:            kusciaClient,
 		options:                 opts,
-		controllerConstructions: []ControllerConstruction{{testNewControllerFunc, nil}},
+		controllerConstructions: []ControllerConstruction{{Name: "test", NewControler: testNewControllerFunc}},
 	}
 
 	s.onNewLeader("test")
@@ -198,7 +202,46 @@ func Test_server_runserver(t *testing.T) {
 			KusciaClient:     kusciaClient,
 			ExtensionsClient: extensionClient,
 		},
-		[]ControllerConstruction{{testNewControllerFunc, nil}})
+		[]ControllerConstruction{{Name: "test", NewControler: testNewControllerFunc}})
 	assert.NoError(t, err)
 }
 */
+
+func Test_server_controllerOverrides(t *testing.T) {
+	opts := &Options{Workers: 3, ControllerName: "test", Controllers: kusciaconfig.ControllersConfig{
+		Overrides: map[string]kusciaconfig.ControllerTuning{"test": {Workers: 5, ResyncPeriod: time.Minute}},
+	}}
+	kubeClient := kubefake.NewSimpleClientset()
+
+	var resyncPeriod time.Duration
+	workersCh := make(chan int, 1)
+	s := &Server{
+		eventRecorder: record.NewFakeRecorder(1),
+		kubeClient:    kubeClient,
+		kusciaClient:  kusciafake.NewSimpleClientset(),
+		options:       opts,
+		controllerConstructions: []ControllerConstruction{{
+			Name: "test",
+			NewControler: func(ctx context.Context, config ControllerConfig) IController {
+				resyncPeriod = config.ResyncPeriodOr(5 * time.Minute)
+				return &workersController{testcontroller: testNewControllerFunc(ctx, config).(*testcontroller), workersCh: workersCh}
+			},
+		}},
+	}
+	s.leaderElector = election.NewElector(kubeClient, s.options.ControllerName)
+
+	s.onStartedLeading(context.Background())
+	assert.Equal(t, time.Minute, resyncPeriod)
+	assert.Equal(t, 5, <-workersCh)
+	s.onStoppedLeading()
+}
+
+type workersController struct {
+	*testcontroller
+	workersCh chan int
+}
+
+func (w *workersController) Run(num int) error {
+	w.workersCh <- num
+	return w.testcontroller.Run(num)
+}
@@ -90,14 +90,15 @@ type Controller struct {
 
 // NewController returns a controller instance.
 func NewController(ctx context.Context, config controllers.ControllerConfig) controllers.IController {
+	resyncPeriod := config.ResyncPeriodOr(5 * time.Minute)
 	kubeClient := config.KubeClient
 	kusciaClient := config.KusciaClient
 	eventRecorder := config.EventRecorder
-	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 5*time.Minute)
+	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
 	podInformer := kubeInformerFactory.Core().V1().Pods()
 	nsInformer := kubeInformerFactory.Core().V1().Namespaces()
 
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, 5*time.Minute)
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, resyncPeriod)
 	trgInformer := kusciaInformerFactory.Kuscia().V1alpha1().TaskResourceGroups()
 	trInformer := kusciaInformerFactory.Kuscia().V1alpha1().TaskResources()
 	controller := &Controller{
@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 
+	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
@@ -29,10 +30,14 @@ const (
 	heartbeatPeriod = 30 * time.Second
 )
 
-// Start create and run controllers to list-watch resources.
-func (e *KusciaCoreDNS) Start(ctx context.Context, kubeClient kubernetes.Interface) error {
+// Start create and run controllers to list-watch resources. DomainRoutes aren't watched if kusciaClient is nil, and
+// services of all other domains are resolved to the gateway.
+func (e *KusciaCoreDNS) Start(ctx context.Context, kubeClient kubernetes.Interface, kusciaClient kusciaclientset.Interface) error {
 	go startEndpointsController(ctx, kubeClient, e, e.Namespace)
 	go startPodController(ctx, kubeClient, e, e.Namespace)
+	if kusciaClient != nil {
+		go startDomainRouteInformer(ctx, kusciaClient, e)
+	}
 	return nil
 }
 
@@ -35,6 +35,8 @@ import (
 	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/secretflow/kuscia/pkg/common"
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	"github.com/secretflow/kuscia/pkg/utils/signals"
 )
 
@@ -309,3 +311,37 @@ func Test_Records(t *testing.T) {
 	_, err = n.Reverse(context.Background(), r, true, plugin.Options{})
 	assert.NoError(t, err)
 }
+
+func Test_Records_crossDomain(t *testing.T) {
+	kusciaClient := kusciafake.NewSimpleClientset(&kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob", Namespace: "alice"},
+		Spec:       kusciaapisv1alpha1.DomainRouteSpec{Source: "alice", Destination: "bob"},
+	})
+	n := &KusciaCoreDNS{Cache: cache.New(defaultExpiration, 0), EnvoyIP: "127.0.0.1", Upstream: upstream.New(), Namespace: "alice"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go startDomainRouteInformer(ctx, kusciaClient, n)
+	assert.Eventually(t, func() bool {
+		routes, _ := n.routes.Load().(*domainRoutes)
+		return routes != nil && routes.hasSynced()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	lookup := func(name string) error {
+		m := &dns.Msg{Question: []dns.Question{{Name: name}}}
+		_, err := n.Records(context.Background(), request.Request{W: dnstest.NewRecorder(&test.ResponseWriter{}), Req: m}, true)
+		return err
+	}
+	assert.NoError(t, lookup("party-svc.bob.svc."))
+	assert.Equal(t, errKeyNotFound, lookup("party-svc.carol.svc."))
+	_, found := n.Cache.Get(negativeCachePrefix + "carol")
+	assert.True(t, found)
+
+	_, err := kusciaClient.KusciaV1alpha1().DomainRoutes("alice").Create(context.Background(), &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-carol", Namespace: "alice"},
+		Spec:       kusciaapisv1alpha1.DomainRouteSpec{Source: "alice", Destination: "carol"},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return lookup("party-svc.carol.svc.") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coredns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	routeDestinationIndex = "destination"
+	negativeCachePrefix   = "nxdomain/"
+	negativeCacheTTL      = 10 * time.Second
+)
+
+// domainRoutes looks up the DomainRoutes from the namespace by destination. Services of a domain in the form of
+// svc.domain.svc are resolved to the gateway only if the domain is reachable by a DomainRoute.
+type domainRoutes struct {
+	indexer   cache.Indexer
+	hasSynced cache.InformerSynced
+}
+
+func indexByDestination(obj interface{}) ([]string, error) {
+	dr, ok := obj.(*kusciaapisv1alpha1.DomainRoute)
+	if !ok {
+		return nil, fmt.Errorf("object %T is not a DomainRoute", obj)
+	}
+	return []string{dr.Spec.Destination}, nil
+}
+
+// startDomainRouteInformer watches DomainRoutes from the namespace of instance. The negative cache of a destination
+// is dropped once a route to it shows up, so pods don't wait for the negative TTL to resolve it.
+func startDomainRouteInformer(ctx context.Context, kusciaClient kusciaclientset.Interface, n *KusciaCoreDNS) {
+	defer runtime.HandleCrash()
+	informerFactory := kusciainformers.NewSharedInformerFactoryWithOptions(kusciaClient, defaultSyncPeriod,
+		kusciainformers.WithNamespace(n.Namespace))
+	informer := informerFactory.Kuscia().V1alpha1().DomainRoutes().Informer()
+	if err := informer.AddIndexers(cache.Indexers{routeDestinationIndex: indexByDestination}); err != nil {
+		nlog.Errorf("Add domain route indexer failed, %v", err)
+		return
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: n.onDomainRouteChanged,
+		UpdateFunc: func(_, cur interface{}) {
+			n.onDomainRouteChanged(cur)
+		},
+	})
+	n.routes.Store(&domainRoutes{indexer: informer.GetIndexer(), hasSynced: informer.HasSynced})
+
+	nlog.Infof("Starting domain route informer, namespace: %s", n.Namespace)
+	informerFactory.Start(ctx.Done())
+	<-ctx.Done()
+	nlog.Infof("Shutting down domain route informer, namespace: %s", n.Namespace)
+}
+
+func (e *KusciaCoreDNS) onDomainRouteChanged(obj interface{}) {
+	if dr, ok := obj.(*kusciaapisv1alpha1.DomainRoute); ok && dr.Spec.Source == e.Namespace {
+		e.Cache.Delete(negativeCachePrefix + dr.Spec.Destination)
+	}
+}
+
+// crossDomainReachable tells whether services of domain are resolved to the gateway. Unreachable domains are cached
+// for negativeCacheTTL. All domains are reachable if the routes are unknown, which keeps the behavior before routes
+// are watched.
+func (e *KusciaCoreDNS) crossDomainReachable(domain string) bool {
+	routes, _ := e.routes.Load().(*domainRoutes)
+	if routes == nil || !routes.hasSynced() {
+		crossDomainLookups.WithLabelValues("unchecked").Inc()
+		return true
+	}
+
+	if _, found := e.Cache.Get(negativeCachePrefix + domain); found {
+		crossDomainLookups.WithLabelValues("negative_cached").Inc()
+		return false
+	}
+
+	objs, err := routes.indexer.ByIndex(routeDestinationIndex, domain)
+	if err != nil {
+		nlog.Warnf("Lookup domain routes to %s failed, %v", domain, err)
+		crossDomainLookups.WithLabelValues("unchecked").Inc()
+		return true
+	}
+	for _, obj := range objs {
+		if dr, ok := obj.(*kusciaapisv1alpha1.DomainRoute); ok && dr.Spec.Source == e.Namespace {
+			crossDomainLookups.WithLabelValues("hit").Inc()
+			return true
+		}
+	}
+
+	e.Cache.Set(negativeCachePrefix+domain, struct{}{}, negativeCacheTTL)
+	crossDomainLookups.WithLabelValues("miss").Inc()
+	return false
+}
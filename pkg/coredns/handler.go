@@ -10,7 +10,7 @@ import (
 )
 
 // ServeDNS implements the plugin.Handler interface.
-func (e *KusciaCoreDNS) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dns.Msg) (int, error) {
+func (e *KusciaCoreDNS) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *dns.Msg) (rcode int, err error) {
 	opt := plugin.Options{}
 	state := request.Request{W: w, Req: r}
 
@@ -18,11 +18,11 @@ func (e *KusciaCoreDNS) ServeDNS(ctx context.Context, w dns.ResponseWriter, r *d
 	if zone == "" {
 		return plugin.NextOrFailure(e.Name(), e.Next, ctx, w, r)
 	}
+	defer func() {
+		recordQuery(state.QType(), rcode)
+	}()
 
-	var (
-		records, extra []dns.RR
-		err            error
-	)
+	var records, extra []dns.RR
 
 	switch state.QType() {
 	case dns.TypeA:
@@ -53,6 +53,8 @@ type KusciaCoreDNS struct {
 	Cache    *cache.Cache
 
 	counter uint64
+	// routes holds *domainRoutes once DomainRoutes are watched
+	routes atomic.Value
 }
 
 // Services implements the ServiceBackend interface.
@@ -93,7 +95,7 @@ func (e *KusciaCoreDNS) Records(ctx context.Context, state request.Request, exac
 	var item interface{}
 	found := false
 
-	// FQDN: foo.bar.ns.svc
+	// FQDN: foo.bar.ns.svc, services of other domains are resolved to the gateway
 	if strings.HasSuffix(name, ".svc") {
 		fields := strings.Split(name, ".")
 
@@ -104,7 +106,7 @@ func (e *KusciaCoreDNS) Records(ctx context.Context, state request.Request, exac
 
 		if fields[n-2] == e.Namespace {
 			item, found = e.Cache.Get(strings.TrimSuffix(name, ".svc"))
-		} else {
+		} else if e.crossDomainReachable(fields[n-2]) {
 			item = []string{e.EnvoyIP}
 			found = true
 		}
@@ -0,0 +1,47 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coredns
+
+import (
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// queries records the DNS queries served by the kuscia plugin.
+	queries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuscia_coredns_queries_total",
+		Help: "Counts number of DNS queries served by kuscia coredns plugin by query type and response code",
+	}, []string{"type", "rcode"})
+
+	// crossDomainLookups records the lookups of services in other domains.
+	crossDomainLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuscia_coredns_cross_domain_lookups_total",
+		Help: "Counts number of lookups of cross-domain service names by result, hit, miss, negative_cached or unchecked",
+	}, []string{"result"})
+)
+
+func recordQuery(qtype uint16, rcode int) {
+	typ, ok := dns.TypeToString[qtype]
+	if !ok {
+		typ = "other"
+	}
+	rc, ok := dns.RcodeToString[rcode]
+	if !ok {
+		rc = "other"
+	}
+	queries.WithLabelValues(typ, rc).Inc()
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/patrickmn/go-cache"
 	"k8s.io/client-go/kubernetes"
 
+	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	"github.com/secretflow/kuscia/pkg/utils/network"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
@@ -82,8 +83,8 @@ func KusciaParse(c *caddy.Controller, namespace, envoyIP string) (*KusciaCoreDNS
 	return &KusciaCoreDNS{}, nil
 }
 
-func (e *KusciaCoreDNS) StartControllers(ctx context.Context, kubeclient kubernetes.Interface) error {
-	err := e.Start(ctx, kubeclient)
+func (e *KusciaCoreDNS) StartControllers(ctx context.Context, kubeclient kubernetes.Interface, kusciaClient kusciaclientset.Interface) error {
+	err := e.Start(ctx, kubeclient, kusciaClient)
 	if err != nil {
 		return fmt.Errorf("start coredns controller failed, %v", err)
 	}
@@ -47,11 +47,39 @@ type AppImageList struct {
 // AppImageSpec defines the details of app image.
 type AppImageSpec struct {
 	Image AppImageInfo `json:"image"`
+	// Version is the app version of Image, e.g. 1.7.0.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Channels are the release channels of the app, e.g. stable and beta. Tasks pinning a channel use the image of the
+	// channel instead of Image, so the image can be upgraded by updating the channel.
+	// +optional
+	Channels []AppImageChannel `json:"channels,omitempty"`
+	// TaskConfigSchema is the JSON Schema of the task input config of Image, the task input config of jobs using the
+	// app image is validated against it when jobs are created.
+	// +optional
+	TaskConfigSchema string `json:"taskConfigSchema,omitempty"`
 	// +optional
 	ConfigTemplates map[string]string `json:"configTemplates,omitempty"`
 	DeployTemplates []DeployTemplate  `json:"deployTemplates"`
 }
 
+const (
+	AppImageChannelStable = "stable"
+	AppImageChannelBeta   = "beta"
+)
+
+// AppImageChannel defines the image of a release channel.
+type AppImageChannel struct {
+	Name string `json:"name"`
+	// +optional
+	Version string       `json:"version,omitempty"`
+	Image   AppImageInfo `json:"image"`
+	// TaskConfigSchema is the JSON Schema of the task input config of the image of channel, TaskConfigSchema of the
+	// app image is used if it's empty.
+	// +optional
+	TaskConfigSchema string `json:"taskConfigSchema,omitempty"`
+}
+
 // AppImageInfo defines the basic app image info.
 type AppImageInfo struct {
 	Name string `json:"name"`
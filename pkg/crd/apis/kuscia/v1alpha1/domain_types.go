@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -95,6 +97,21 @@ type DomainResourceQuota struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	PodMaxCount *int `json:"podMaxCount,omitempty"`
+	// TaskPartyLimits caps the resources requested by parties of this domain in a task, it's enforced by the
+	// controller rendering the party's pods. Tasks exceeding the caps fail.
+	// +optional
+	TaskPartyLimits *TaskPartyLimits `json:"taskPartyLimits,omitempty"`
+}
+
+// TaskPartyLimits is the max resources of a party in a task, in total of all its pods.
+type TaskPartyLimits struct {
+	// +optional
+	MaxCPU *resource.Quantity `json:"maxCPU,omitempty"`
+	// +optional
+	MaxMemory *resource.Quantity `json:"maxMemory,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 }
 
 // DomainStatus defines domain status.
@@ -103,6 +120,47 @@ type DomainStatus struct {
 	NodeStatuses []NodeStatus `json:"nodeStatuses,omitempty"`
 	// +optional
 	DeployTokenStatuses []DeployTokenStatus `json:"deployTokenStatuses"`
+	// KeyRotation is the latest rotation of the key which authenticates the routes of domain.
+	// +optional
+	KeyRotation *DomainKeyRotationStatus `json:"keyRotation,omitempty"`
+	// Conditions are the liveness of domain judged by the heartbeats of its gateways and nodes.
+	// +optional
+	Conditions []DomainCondition `json:"conditions,omitempty"`
+}
+
+// DomainConditionType is the type of domain condition.
+type DomainConditionType string
+
+const (
+	// DomainReady means the domain reports heartbeats to master in time.
+	DomainReady DomainConditionType = "Ready"
+)
+
+// Reasons of domain conditions.
+const (
+	DomainHeartbeatReceived = "HeartbeatReceived"
+	DomainUnreachable       = "Unreachable"
+	DomainNeverSeen         = "NeverSeen"
+)
+
+// DomainCondition describes the state of a domain at a certain point.
+type DomainCondition struct {
+	// Type of domain condition.
+	Type DomainConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// The reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human-readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastHeartbeatTime is the last time a heartbeat of the domain is seen by master.
+	// +optional
+	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // NodeStatus defines node status under domain.
@@ -116,6 +174,38 @@ type NodeStatus struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
+// DomainKeyRotationPhase is the phase of domain key rotation.
+type DomainKeyRotationPhase string
+
+const (
+	// DomainKeyRotationRotating means the new key is advertised and the routes are re-handshaked, both the new and the
+	// previous keys are accepted.
+	DomainKeyRotationRotating DomainKeyRotationPhase = "Rotating"
+	// DomainKeyRotationCompleted means all routes confirmed the new key and the previous key is retired.
+	DomainKeyRotationCompleted DomainKeyRotationPhase = "Completed"
+)
+
+// DomainKeyRotationStatus defines the key rotation of domain. The public key replaces the one in cert of domain for
+// routes, it's recorded by the domain itself when the rotation starts, and by partners when they learn it in handshake.
+type DomainKeyRotationStatus struct {
+	Phase DomainKeyRotationPhase `json:"phase"`
+	// PublicKey is the RSA public key in use, base64 encoded.
+	PublicKey string `json:"publicKey"`
+	// PreviousPublicKey is the public key replaced by this rotation, base64 encoded.
+	// +optional
+	PreviousPublicKey string `json:"previousPublicKey,omitempty"`
+	// Signature of PublicKey by the previous private key, base64 encoded.
+	Signature string `json:"signature"`
+	// CertHash is the sha256 of cert when the rotation is recorded, the rotation is discarded once the cert is replaced.
+	CertHash  string      `json:"certHash"`
+	StartTime metav1.Time `json:"startTime"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// PendingRoutes are the DomainRoutes not re-handshaked with the new key yet.
+	// +optional
+	PendingRoutes []string `json:"pendingRoutes,omitempty"`
+}
+
 // DeployTokenStatus defines csr token status under domain.
 type DeployTokenStatus struct {
 	Token string `json:"token"`
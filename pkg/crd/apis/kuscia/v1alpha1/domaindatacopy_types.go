@@ -0,0 +1,96 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=domaindatacopies
+// +kubebuilder:resource:singular=domaindatacopy
+// +kubebuilder:resource:shortName=ddc
+
+// DomainDataCopy copies a domaindata granted by another domain into the local domain.
+type DomainDataCopy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              DomainDataCopySpec `json:"spec"`
+	// +optional
+	Status DomainDataCopyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DomainDataCopyList contains a list of domain data copy.
+type DomainDataCopyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DomainDataCopy `json:"items"`
+}
+
+// DomainDataCopySpec defines the source and the destination of the copy.
+type DomainDataCopySpec struct {
+	// SourceDomain is the domain which owns the source domaindata.
+	SourceDomain string `json:"sourceDomain"`
+	// SourceDomainDataID is the id of the source domaindata, it must be granted to the local domain.
+	SourceDomainDataID string `json:"sourceDomainDataID"`
+	// DomainDataID is the id of the destination domaindata, which is registered when the copy succeeds.
+	DomainDataID string `json:"domainDataID"`
+	// DataSource is the local datasource which the data is copied to.
+	DataSource string `json:"dataSource"`
+	// RelativeURI is the uri of the copied data, relative to the datasource.
+	RelativeURI string `json:"relativeURI"`
+	// ChunkSize is the bytes fetched from the source domain in one request.
+	// +optional
+	ChunkSize int64 `json:"chunkSize,omitempty"`
+}
+
+// DomainDataCopyStatus defines the progress of the copy.
+type DomainDataCopyStatus struct {
+	// +optional
+	Phase DomainDataCopyPhase `json:"phase,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// CopiedBytes is the size of the data already copied, an interrupted copy resumes from it.
+	// +optional
+	CopiedBytes int64 `json:"copiedBytes,omitempty"`
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// Checksum is the checksum of the source data, the copied data is verified against it.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// DomainDataCopyPhase is phase of domain data copy at the current time.
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type DomainDataCopyPhase string
+
+const (
+	DomainDataCopyPending   DomainDataCopyPhase = "Pending"
+	DomainDataCopyRunning   DomainDataCopyPhase = "Running"
+	DomainDataCopySucceeded DomainDataCopyPhase = "Succeeded"
+	DomainDataCopyFailed    DomainDataCopyPhase = "Failed"
+)
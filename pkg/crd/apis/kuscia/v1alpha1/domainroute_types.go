@@ -71,6 +71,18 @@ type ClusterDomainRouteStatus struct {
 	// EndpointStatuses shows the health status from all gateway instance of the source domain to the endpoint.
 	// +optional
 	EndpointStatuses map[string]ClusterDomainRouteEndpointStatus `json:"endpointStatuses,omitempty"`
+	// BodyEncryption is the body encryption negotiated by the source domain.
+	// +optional
+	BodyEncryption DomainRouteBodyEncryptionStatus `json:"bodyEncryption,omitempty"`
+	// HTTP3 is true if the source domain negotiated HTTP/3 with the destination.
+	// +optional
+	HTTP3 bool `json:"http3,omitempty"`
+	// HeaderPolicy is the header policy enforced by the source domain.
+	// +optional
+	HeaderPolicy *DomainRouteHeaderPolicy `json:"headerPolicy,omitempty"`
+	// LastError is the last error reported by the gateway of the source domain.
+	// +optional
+	LastError *DomainRouteLastError `json:"lastError,omitempty"`
 }
 
 // ClusterDomainRouteTokenStatus represents the status information related to token authentication.
@@ -127,8 +139,27 @@ const (
 	ClusterDomainRouteFailure ClusterDomainRouteConditionType = "Failure"
 	// ClusterDomainRouteReady means at least one token has been generated.
 	ClusterDomainRouteReady ClusterDomainRouteConditionType = "Ready"
+	// ClusterDomainRouteHandshakeSucceeded means the last handshake between source and destination succeeded.
+	ClusterDomainRouteHandshakeSucceeded ClusterDomainRouteConditionType = "HandshakeSucceeded"
+	// ClusterDomainRouteTokenValid means the token in use is accepted by the destination.
+	ClusterDomainRouteTokenValid ClusterDomainRouteConditionType = "TokenValid"
+	// ClusterDomainRouteEndpointReachable means the source gateway can reach the endpoint of destination.
+	ClusterDomainRouteEndpointReachable ClusterDomainRouteConditionType = "EndpointReachable"
+	// ClusterDomainRouteTLSVerified means the certificate of destination is verified by the source gateway,
+	// it's only reported if the endpoint uses TLS.
+	ClusterDomainRouteTLSVerified ClusterDomainRouteConditionType = "TLSVerified"
 )
 
+// DomainRouteLastError describes the last error the gateway met on the route.
+type DomainRouteLastError struct {
+	// Condition is the type of condition that turned false because of the error.
+	Condition ClusterDomainRouteConditionType `json:"condition"`
+	// Message of the error.
+	Message string `json:"message"`
+	// Time when the error happened.
+	Time metav1.Time `json:"time"`
+}
+
 // ClusterDomainRouteCondition describes the state of a ClusterDomainRoute at a certain point.
 type ClusterDomainRouteCondition struct {
 	// Type of ClusterDomainRoute condition.
@@ -210,14 +241,177 @@ type DomainRouteSpec struct {
 	TokenConfig *TokenConfig `json:"tokenConfig,omitempty"`
 	// +optional
 	BodyEncryption *BodyEncryption `json:"bodyEncryption,omitempty"`
+	// SecurityLevel of requests from source, Standard by default. Requests of Signed level are signed by the source
+	// gateway and verified by the destination gateway one by one, authenticationType must be Token.
+	// +kubebuilder:validation:Enum=Standard;Signed
+	// +optional
+	SecurityLevel DomainRouteSecurityLevel `json:"securityLevel,omitempty"`
 	// +optional
 	MTLSConfig *DomainRouteMTLSConfig `json:"mTLSConfig,omitempty"`
+	// ServerTLS is the certificate presented by the destination to connections from the source, it's selected by SNI
+	// instead of the global certificate of external listener.
+	// +optional
+	ServerTLS *DomainRouteServerTLS `json:"serverTLS,omitempty"`
 	// Whitelist of source IP address or CIDR. If it is empty, the source ip will not be checked.
 	// +optional
 	SourceWhiteIPList []string `json:"sourceWhiteIPList,omitempty"`
 	// add specified headers to requests from source.
 	// +optional
 	RequestHeadersToAdd map[string]string `json:"requestHeadersToAdd,omitempty"`
+	// Rules route requests matching path prefix and headers to services of destination, they are matched in order
+	// before the default route which sends requests to the service in host.
+	// +optional
+	Rules []DomainRouteRule `json:"rules,omitempty"`
+	// MTUWorkaround limits the size of packets to the endpoint, for links dropping large packets silently, e.g. path
+	// MTU discovery is broken as icmp is blocked.
+	// +optional
+	MTUWorkaround *DomainRouteMTUWorkaround `json:"mtuWorkaround,omitempty"`
+	// ConnectionPolicy tunes the idle timeout, max age and TCP keepalive of connections to the endpoint, defaults are
+	// tuned for WAN links if it's nil.
+	// +optional
+	ConnectionPolicy *DomainRouteConnectionPolicy `json:"connectionPolicy,omitempty"`
+	// RetryPolicy retries requests from source on transient failures, idempotent requests are retried once by default.
+	// +optional
+	RetryPolicy *DomainRouteRetryPolicy `json:"retryPolicy,omitempty"`
+	// TimeoutPolicy bounds the deadlines which applications set by the header X-Kuscia-Timeout on requests to
+	// destination.
+	// +optional
+	TimeoutPolicy *DomainRouteTimeoutPolicy `json:"timeoutPolicy,omitempty"`
+	// HeaderPolicy controls the headers of requests to destination and responses from destination, it's enforced by
+	// the source gateway. All headers pass if it's nil.
+	// +optional
+	HeaderPolicy *DomainRouteHeaderPolicy `json:"headerPolicy,omitempty"`
+}
+
+// DomainRouteHeaderPolicy is the header policy of both directions.
+type DomainRouteHeaderPolicy struct {
+	// Request rules are applied to requests from source before they are sent to destination.
+	// +optional
+	Request *DomainRouteHeaderRules `json:"request,omitempty"`
+	// Response rules are applied to responses from destination before they are returned to source.
+	// +optional
+	Response *DomainRouteHeaderRules `json:"response,omitempty"`
+}
+
+// DomainRouteHeaderRules are applied in order of rename, allow, deny and add. Header names are case-insensitive.
+type DomainRouteHeaderRules struct {
+	// Allow lists the headers passed, other headers are removed except pseudo headers and the headers required by
+	// protocols, tracing and kuscia. All headers pass if it's empty.
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+	// Deny lists the headers removed.
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+	// Rename maps header names to new names, the value of an existing header with the new name is replaced.
+	// +optional
+	Rename map[string]string `json:"rename,omitempty"`
+	// Add sets headers, the values of existing headers are replaced.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+}
+
+// DomainRouteRetryPolicy is the retry policy of envoy routes to destination. Requests of idempotent methods (GET, HEAD,
+// OPTIONS, PUT and DELETE) are retried, other requests are retried only if their header Kuscia-Idempotent is true.
+// A request is never retried after the response has been sent to the client.
+type DomainRouteRetryPolicy struct {
+	// RetryOn are the retry conditions of envoy, including the ones of x-envoy-retry-on and x-envoy-retry-grpc-on,
+	// connect-failure, refused-stream, reset and unavailable by default.
+	// +optional
+	RetryOn []string `json:"retryOn,omitempty"`
+	// NumRetries is the max retries of a request, 1 by default, 0 disables retries.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	NumRetries *int32 `json:"numRetries,omitempty"`
+	// PerTryTimeoutSeconds limits the time of each try, only the timeout of the whole request applies if it's zero.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PerTryTimeoutSeconds int32 `json:"perTryTimeoutSeconds,omitempty"`
+}
+
+// DomainRouteTimeoutPolicy is the timeout policy of envoy routes to destination. Requests without the header
+// X-Kuscia-Timeout are not limited, the deadline of the header is applied as the timeout of the request and sent to
+// the gateway of destination.
+type DomainRouteTimeoutPolicy struct {
+	// MaxTimeoutSeconds clamps the deadlines in the header, the max request timeout of gateway applies if it's zero
+	// or larger.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxTimeoutSeconds int32 `json:"maxTimeoutSeconds,omitempty"`
+}
+
+// DomainRouteMTUWorkaround clamps the TCP MSS of connections to the endpoint, large TLS records are split into packets
+// no larger than the MSS plus headers in both directions, as the peer follows the MSS announced in handshake.
+type DomainRouteMTUWorkaround struct {
+	// MaxSegmentSize of TCP connections, 1200 by default.
+	// +kubebuilder:validation:Minimum=536
+	// +kubebuilder:validation:Maximum=1460
+	// +optional
+	MaxSegmentSize int32 `json:"maxSegmentSize,omitempty"`
+}
+
+// DomainRouteConnectionPolicy tunes the lifetime of connections to the endpoint, for links through firewalls or NAT
+// devices dropping long-lived connections silently, which fails the first request on the stale connection.
+type DomainRouteConnectionPolicy struct {
+	// IdleTimeoutSeconds closes connections without active requests, 300 by default. It should be shorter than the
+	// idle timeout of firewalls on the link.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	IdleTimeoutSeconds int32 `json:"idleTimeoutSeconds,omitempty"`
+	// MaxConnectionAgeSeconds drains connections older than it, new requests are sent through new connections. Zero
+	// means connections are never drained for age.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConnectionAgeSeconds int32 `json:"maxConnectionAgeSeconds,omitempty"`
+	// +optional
+	TCPKeepalive *DomainRouteTCPKeepalive `json:"tcpKeepalive,omitempty"`
+}
+
+// DomainRouteTCPKeepalive probes idle TCP connections to the endpoint, keeping the state of firewalls on the link and
+// detecting dead connections before requests are sent through them.
+type DomainRouteTCPKeepalive struct {
+	// IdleSeconds before the first probe, 60 by default.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	IdleSeconds int32 `json:"idleSeconds,omitempty"`
+	// IntervalSeconds between probes, 15 by default.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+	// Probes without response before the connection is closed, 3 by default.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Probes int32 `json:"probes,omitempty"`
+}
+
+// DomainRouteRule routes requests matching path prefix and all headers to a service of destination.
+type DomainRouteRule struct {
+	// Name of rule, it's used as the name and stat prefix of route in envoy.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+	// PathPrefix of requests, "/" by default.
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// +optional
+	Headers []DomainRouteHeaderMatch `json:"headers,omitempty"`
+	// Service of destination that requests are sent to, the host of requests becomes <service>.<destination>.svc.
+	Service string `json:"service"`
+	// Port is the name of endpoint port to send requests through, the port of default route by default.
+	// +optional
+	Port string `json:"port,omitempty"`
+	// PrefixRewrite replaces the matched path prefix if it's set.
+	// +optional
+	PrefixRewrite string `json:"prefixRewrite,omitempty"`
+}
+
+// DomainRouteHeaderMatch matches a request header. The header only needs to be present if both exact and prefix are
+// empty.
+type DomainRouteHeaderMatch struct {
+	Name string `json:"name"`
+	// +optional
+	Exact string `json:"exact,omitempty"`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // DomainEndpoint defines destination access address.
@@ -229,6 +423,18 @@ type DomainEndpoint struct {
 }
 
 // DomainRouteProtocolType defines protocol type supported by the port.
+// DomainRouteSecurityLevel defines how requests from source are authenticated.
+type DomainRouteSecurityLevel string
+
+const (
+	// DomainRouteSecurityLevelStandard authenticates the channel by authenticationType only.
+	DomainRouteSecurityLevelStandard DomainRouteSecurityLevel = "Standard"
+	// DomainRouteSecurityLevelSigned signs every request with HMAC of timestamp, nonce and body digest by the key
+	// derived from the token, the destination rejects requests with invalid signature, expired timestamp or replayed
+	// nonce.
+	DomainRouteSecurityLevelSigned DomainRouteSecurityLevel = "Signed"
+)
+
 type DomainRouteProtocolType string
 
 const (
@@ -256,6 +462,10 @@ type Transit struct {
 	// DomainTransit defines the information of the third domain.
 	// +optional
 	Domain *DomainTransit `json:"domain,omitempty"`
+	// CandidateDomains are the backup third domains in order. The first healthy one of domain and candidate domains
+	// is selected to forward the request, and the gateway fails over to the next one if it becomes unreachable.
+	// +optional
+	CandidateDomains []DomainTransit `json:"candidateDomains,omitempty"`
 }
 
 type TransitMethodType string
@@ -345,12 +555,52 @@ type DomainRouteMTLSConfig struct {
 	SourceClientCert string `json:"sourceClientCert,omitempty"`
 }
 
+// DomainRouteServerTLS defines the server certificate of destination for a DomainRoute, e.g. the source requires a
+// certificate issued by its approved CA.
+type DomainRouteServerTLS struct {
+	// ServerNames are the SNI of connections from the source, the source gateway connects with the first one.
+	// +kubebuilder:validation:MinItems=1
+	ServerNames []string `json:"serverNames"`
+	// SecretName is the name of kubernetes.io/tls secret in destination namespace storing tls.crt and tls.key. If ca.crt
+	// is present, the client certificate of the source is required and verified by it.
+	SecretName string `json:"secretName"`
+}
+
 // DomainRouteStatus represents information about the status of DomainRoute.
 type DomainRouteStatus struct {
 	IsDestinationAuthorized  bool `json:"isDestinationAuthorized"`
 	IsDestinationUnreachable bool `json:"isDestinationUnreachable"`
 	// +optional
 	TokenStatus DomainRouteTokenStatus `json:"tokenStatus,omitempty"`
+	// +optional
+	BodyEncryption DomainRouteBodyEncryptionStatus `json:"bodyEncryption,omitempty"`
+	// HTTP3 is true if source and destination negotiated HTTP/3 in the last handshake, the source gateway connects
+	// by QUIC first and falls back to TCP if it fails.
+	// +optional
+	HTTP3 bool `json:"http3,omitempty"`
+	// HeaderPolicy is the header policy enforced by the source gateway, header names are lower-cased and sorted.
+	// +optional
+	HeaderPolicy *DomainRouteHeaderPolicy `json:"headerPolicy,omitempty"`
+	// Conditions are the granular conditions reported by the source gateway, e.g. HandshakeSucceeded.
+	// +optional
+	Conditions []ClusterDomainRouteCondition `json:"conditions,omitempty"`
+	// LastError is the last error the source gateway met on the route.
+	// +optional
+	LastError *DomainRouteLastError `json:"lastError,omitempty"`
+}
+
+// DomainRouteBodyEncryptionStatus represents the body encryption negotiated by source and destination in handshake.
+type DomainRouteBodyEncryptionStatus struct {
+	// Algorithm accepted by both source and destination, empty means the body isn't encrypted.
+	// +optional
+	Algorithm BodyEncryptionAlgorithmType `json:"algorithm,omitempty"`
+	// EndToEnd is true if requests are transited through a third domain, the body is encrypted by the source gateway
+	// and decrypted by the destination gateway, so the third domain only sees ciphertext.
+	// +optional
+	EndToEnd bool `json:"endToEnd,omitempty"`
+	// KeyRevision is the revision of the token used as the encryption key.
+	// +optional
+	KeyRevision int64 `json:"keyRevision,omitempty"`
 }
 
 // DomainRouteTokenStatus represents information about the token in DomainRoute.
@@ -41,6 +41,10 @@ type GatewayStatus struct {
 	Address       string      `json:"address"`
 	UpTime        metav1.Time `json:"uptime"`
 	HeartbeatTime metav1.Time `json:"heartbeatTime"`
+	// HeartbeatIntervalSeconds is the period of heartbeats reported by the gateway, master judges the liveness of
+	// domain by it.
+	// +optional
+	HeartbeatIntervalSeconds int32 `json:"heartbeatIntervalSeconds,omitempty"`
 	// PublicKey is RSA public key used by domain, base64 encoded.
 	PublicKey     string                  `json:"publicKey"`
 	Version       string                  `json:"version"`
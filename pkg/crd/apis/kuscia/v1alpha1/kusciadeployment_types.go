@@ -56,6 +56,11 @@ type KusciaDeploymentSpec struct {
 	Initiator   string                  `json:"initiator"`
 	InputConfig string                  `json:"inputConfig"`
 	Parties     []KusciaDeploymentParty `json:"parties"`
+	// TrafficTarget is the name of kuscia deployment in the same namespace whose pods serve the services of this
+	// kuscia deployment, it's used to switch traffic between revisions in blue-green release. The target must have
+	// the same parties. Defaults to this kuscia deployment.
+	// +optional
+	TrafficTarget string `json:"trafficTarget,omitempty"`
 }
 
 // KusciaDeploymentParty defines the kuscia deployment party info.
@@ -81,6 +86,52 @@ type KusciaDeploymentPartyTemplate struct {
 	Strategy *v1.DeploymentStrategy `json:"strategy,omitempty"`
 	// +optional
 	Spec PodSpec `json:"spec,omitempty"`
+	// Autoscaling adjusts replicas by metrics, replicas is only used as the initial number of pods if it's set.
+	// +optional
+	Autoscaling *KusciaDeploymentAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// KusciaDeploymentAutoscaling defines the horizontal autoscaling of party deployment.
+type KusciaDeploymentAutoscaling struct {
+	// Lower limit of replicas. Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// Upper limit of replicas.
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Metrics used to compute desired replicas, the largest desired replicas of all metrics is used.
+	Metrics []AutoscalingMetric `json:"metrics"`
+	// Seconds of recommendations considered when scaling up, the lowest recommendation in the window is used.
+	// Defaults to 0, which means scaling up at once.
+	// +optional
+	ScaleUpStabilizationSeconds *int32 `json:"scaleUpStabilizationSeconds,omitempty"`
+	// Seconds of recommendations considered when scaling down, the highest recommendation in the window is used.
+	// Defaults to 300.
+	// +optional
+	ScaleDownStabilizationSeconds *int32 `json:"scaleDownStabilizationSeconds,omitempty"`
+}
+
+// AutoscalingMetricType defines the metric type used by autoscaling.
+type AutoscalingMetricType string
+
+const (
+	// AutoscalingMetricRequestsPerSecond is the upstream requests per second of envoy to the services of deployment,
+	// the target is requests per second of each replica.
+	AutoscalingMetricRequestsPerSecond AutoscalingMetricType = "RequestsPerSecond"
+	// AutoscalingMetricLatency is the P90 upstream latency of envoy to the services of deployment, the target is in
+	// milliseconds.
+	AutoscalingMetricLatency AutoscalingMetricType = "Latency"
+	// AutoscalingMetricCPU is the average cpu utilization of pods, the target is the percentage of cpu requests.
+	AutoscalingMetricCPU AutoscalingMetricType = "CPU"
+)
+
+// AutoscalingMetric defines a metric and its target value.
+type AutoscalingMetric struct {
+	Type AutoscalingMetricType `json:"type"`
+	// Target value of the metric.
+	Target int64 `json:"target"`
+	// Port name of the service measured by RequestsPerSecond and Latency. Defaults to all services of deployment.
+	// +optional
+	PortName string `json:"portName,omitempty"`
 }
 
 // KusciaDeploymentPartyStatus defines party status of kuscia deployment.
@@ -85,6 +85,26 @@ type KusciaJobSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=128
 	MaxParallelism *int `json:"maxParallelism,omitempty"`
+	// Priority of job in the job queue of initiator. When the running jobs reach the limits of queue,
+	// the job with larger priority is started first, jobs with the same priority are started in creation order.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// TTLSecondsAfterFinished limits the lifetime of a finished job, the job and its tasks are deleted by garbage
+	// collection after the TTL. The default TTL of controllers is used if it's not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// DependsOnJobs defines the jobs this job depends on. The job is held in Pending until all the jobs
+	// reach the required phases, and it fails if any of them finishes in another phase.
+	// The jobs should be in the same namespace and the dependencies should not constitute a cycle.
+	// +optional
+	// +kubebuilder:validation:MaxItems=128
+	DependsOnJobs []JobDependency `json:"dependsOnJobs,omitempty"`
+	// DependencyTimeoutSeconds limits how long the job waits for DependsOnJobs since created,
+	// the job fails after the timeout. The job waits forever if it's not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DependencyTimeoutSeconds *int64 `json:"dependencyTimeoutSeconds,omitempty"`
 	// Tasks defines the subtasks participating in scheduling and their dependencies,
 	// and the subtasks and dependencies should constitute a directed acyclic graph.
 	// During runtime, each subtask will be created as a KusciaTask.
@@ -93,6 +113,17 @@ type KusciaJobSpec struct {
 	Tasks []KusciaTaskTemplate `json:"tasks"`
 }
 
+// JobDependency describes a job that must reach the phase before the dependent job starts.
+type JobDependency struct {
+	// JobID is the name of the job depended on.
+	JobID string `json:"jobID"`
+	// Phase is the phase the job depended on must reach, default Succeeded.
+	// +optional
+	// +kubebuilder:validation:Enum=Succeeded;Failed;Cancelled
+	// +kubebuilder:default=Succeeded
+	Phase KusciaJobPhase `json:"phase,omitempty"`
+}
+
 type KusciaTaskTemplate struct {
 	// Alias represents KusciaTask alias.
 	Alias string `json:"alias"`
@@ -114,6 +145,9 @@ type KusciaTaskTemplate struct {
 	Tolerable *bool `json:"tolerable,omitempty"`
 	// AppImage defines image be used in KusciaTask
 	AppImage string `json:"appImage"`
+	// AppImageChannel pins the release channel of AppImage, e.g. stable. The image of AppImage is used if it's empty.
+	// +optional
+	AppImageChannel string `json:"appImageChannel,omitempty"`
 	// TaskInputConfig defines input config for KusciaTask.
 	TaskInputConfig string `json:"taskInputConfig"`
 	// ScheduleConfig defines the schedule config for KusciaTask.
@@ -132,8 +166,13 @@ type Party struct {
 	DomainID string `json:"domainID"`
 	// +optional
 	Role string `json:"role,omitempty"`
+	// Resources is the total resources of the party's pods, they are split evenly among containers of the pods.
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Replicas overrides the replicas of the party's deploy template in AppImage.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
 	// +optional
 	BandwidthLimit []BandwidthLimit `json:"bandwidthLimits,omitempty"`
 }
@@ -158,6 +197,10 @@ type KusciaJobStatus struct {
 	// +optional
 	ApproveStatus map[string]JobApprovePhase `json:"approveStatus,omitempty"`
 
+	// ApprovalRecords are the approval decisions made by local parties, appended in order for audit.
+	// +optional
+	ApprovalRecords []JobApprovalRecord `json:"approvalRecords,omitempty"`
+
 	// job stage status of each party,
 	// +optional
 	StageStatus map[string]JobStagePhase `json:"stageStatus,omitempty"`
@@ -183,6 +226,11 @@ type KusciaJobStatus struct {
 	// +optional
 	TaskStatus map[string]KusciaTaskPhase `json:"taskStatus,omitempty"`
 
+	// QueuePosition is the position of job in the job queue of initiator, starting from 1.
+	// It's 0 if the job is not waiting in the queue.
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
 	// Represents time when the job was acknowledged by the job controller.
 	// It is not guaranteed to be set in happens-before order across separate operations.
 	// It is represented in RFC3339 form and is in UTC.
@@ -256,6 +304,12 @@ const (
 	TaskStopped KusciaJobConditionType = "TaskStopped"
 	// JobStatusSynced represents condition of syncing job status.
 	JobStatusSynced KusciaJobConditionType = "JobStatusSynced"
+	// JobQueued represents job is waiting in the job queue for running jobs to finish.
+	JobQueued KusciaJobConditionType = "JobQueued"
+	// JobPreflightChecked represents the dependencies of job are checked before any task is created.
+	JobPreflightChecked KusciaJobConditionType = "JobPreflightChecked"
+	// JobWaitingDependencies represents job is held in Pending until the jobs it depends on reach the required phases.
+	JobWaitingDependencies KusciaJobConditionType = "JobWaitingDependencies"
 )
 
 // KusciaJobCondition describes current state of a kuscia job.
@@ -280,6 +334,16 @@ type KusciaJobReason string
 const (
 	ValidateFailed   KusciaJobReason = "ValidateFailed"
 	CreateTaskFailed KusciaJobReason = "CreateTaskFailed"
+	// PolicyDenied means the job is denied by job validation policies.
+	PolicyDenied KusciaJobReason = "PolicyDenied"
+	// PreflightCheckFailed means the dependencies of job, e.g. routes, domaindata grants and app images, aren't ready.
+	PreflightCheckFailed KusciaJobReason = "PreflightCheckFailed"
+	// DependencyCycle means the jobs the job depends on constitute a cycle.
+	DependencyCycle KusciaJobReason = "DependencyCycle"
+	// DependencyUnsatisfiable means a job the job depends on finished in a phase other than required.
+	DependencyUnsatisfiable KusciaJobReason = "DependencyUnsatisfiable"
+	// DependencyTimeout means the jobs the job depends on don't reach the required phases before the timeout.
+	DependencyTimeout KusciaJobReason = "DependencyTimeout"
 )
 
 // KusciaJobPhase defines current status of this kuscia job.
@@ -291,6 +355,10 @@ const (
 	// KusciaJobInitialized means the job is initialized, just be created soon.
 	KusciaJobInitialized KusciaJobPhase = "initialized"
 
+	// KusciaJobPreflightCheck means the initiator is checking the routes, partners, domaindata grants and app images
+	// the job depends on, before any task is created.
+	KusciaJobPreflightCheck KusciaJobPhase = "PreflightCheck"
+
 	// KusciaJobAwaitingApproval means the job is waiting for approval by some parties
 	KusciaJobAwaitingApproval KusciaJobPhase = "AwaitingApproval"
 
@@ -346,6 +414,18 @@ const (
 	JobAccepted JobApprovePhase = "JobAccepted"
 )
 
+// JobApprovalRecord is an approval decision of a party.
+type JobApprovalRecord struct {
+	DomainID string          `json:"domainID"`
+	Result   JobApprovePhase `json:"result"`
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Operator is who made the decision, e.g. kusciaapi/alice or policy/trusted.
+	// +optional
+	Operator string      `json:"operator,omitempty"`
+	Time     metav1.Time `json:"time"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:printcolumn:name="StartTime",type=date,JSONPath=`.status.startTime`
@@ -77,6 +77,9 @@ type ScheduleConfig struct {
 type PartyInfo struct {
 	DomainID    string `json:"domainID"`
 	AppImageRef string `json:"appImageRef"`
+	// AppImageChannel pins the release channel of app image.
+	// +optional
+	AppImageChannel string `json:"appImageChannel,omitempty"`
 	// +optional
 	Role string `json:"role,omitempty"`
 	// +optional
@@ -104,6 +107,31 @@ type PartyTaskStatus struct {
 	Phase KusciaTaskPhase `json:"phase,omitempty"`
 	// +optional
 	Message string `json:"message,omitempty"`
+	// Pods are the pod-level progress of the party, reported by the cluster where the party runs and synced
+	// to the other parties.
+	// +optional
+	Pods []PartyPodStatus `json:"pods,omitempty"`
+}
+
+// PartyPodStatus describes the progress of a pod of the party.
+type PartyPodStatus struct {
+	// Pod name.
+	Name string `json:"name"`
+	// The phase of the pod.
+	// +optional
+	Phase corev1.PodPhase `json:"phase,omitempty"`
+	// Ready is true if all containers of the pod are ready.
+	Ready bool `json:"ready"`
+	// RestartCount is the total restart count of the containers of the pod.
+	// +optional
+	RestartCount int32 `json:"restartCount,omitempty"`
+	// LastEvent is a summary of the latest state change of the pod, e.g. "CrashLoopBackOff: back-off 10s restarting
+	// failed container".
+	// +optional
+	LastEvent string `json:"lastEvent,omitempty"`
+	// Represents time of the last event.
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
 }
 
 // KusciaTaskStatus defines the observed state of kuscia task.
@@ -118,6 +146,11 @@ type KusciaTaskStatus struct {
 	// +optional
 	Progress float32 `json:"progress,omitempty"`
 
+	// Metrics are the key metrics reported by the task engine together with the progress,
+	// e.g. the current epoch or the number of processed rows.
+	// +optional
+	Metrics map[string]string `json:"metrics,omitempty"`
+
 	// PartyTaskStatus defines task status for all party.
 	// +optional
 	PartyTaskStatus []PartyTaskStatus `json:"partyTaskStatus,omitempty"`
@@ -194,8 +227,12 @@ type KusciaTaskConditionType string
 
 // These are built-in conditions of kuscia task.
 const (
+	// KusciaTaskCondImageVerified means images of local parties have been resolved and their signatures verified.
+	KusciaTaskCondImageVerified KusciaTaskConditionType = "ImageVerified"
 	// KusciaTaskCondPortsAllocated means pods have beed allocated.
 	KusciaTaskCondPortsAllocated KusciaTaskConditionType = "PortsAllocated"
+	// KusciaTaskCondResourceReserved means resources of local parties have been reserved before creating pods.
+	KusciaTaskCondResourceReserved KusciaTaskConditionType = "ResourceReserved"
 	// KusciaTaskCondResourceCreated means all sub-resources (e.g. services/pods) of the task has been created.
 	KusciaTaskCondResourceCreated KusciaTaskConditionType = "ResourceCreated"
 	// KusciaTaskCondRunning means task is running.
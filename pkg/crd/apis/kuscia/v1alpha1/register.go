@@ -54,6 +54,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DomainDataSourceList{},
 		&DomainData{},
 		&DomainDataList{},
+		&DomainDataCopy{},
+		&DomainDataCopyList{},
 		&DomainDataGrant{},
 		&DomainDataGrantList{},
 		&Domain{},
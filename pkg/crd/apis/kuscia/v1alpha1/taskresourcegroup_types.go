@@ -159,4 +159,11 @@ type SchedulerPluginArgs struct {
 	// ResourceReservedSeconds is the waiting timeout in seconds.
 	// +optional
 	ResourceReservedSeconds int `json:"resourceReservedSeconds,omitempty"`
+	// GangSchedulingTimeoutSeconds is the timeout in seconds for all pods of a task resource to be scheduled since it
+	// began reserving. After timeout, the task resource is failed and reserved resources are released.
+	// +optional
+	GangSchedulingTimeoutSeconds int `json:"gangSchedulingTimeoutSeconds,omitempty"`
+	// DataDiskFreeThreshold is the minimum free space of the data disk of nodes to schedule pods to, e.g. 5Gi.
+	// +optional
+	DataDiskFreeThreshold string `json:"dataDiskFreeThreshold,omitempty"`
 }
@@ -52,6 +52,23 @@ func (in *AppImage) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppImageChannel) DeepCopyInto(out *AppImageChannel) {
+	*out = *in
+	out.Image = in.Image
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppImageChannel.
+func (in *AppImageChannel) DeepCopy() *AppImageChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(AppImageChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppImageInfo) DeepCopyInto(out *AppImageInfo) {
 	*out = *in
@@ -105,6 +122,11 @@ func (in *AppImageList) DeepCopyObject() runtime.Object {
 func (in *AppImageSpec) DeepCopyInto(out *AppImageSpec) {
 	*out = *in
 	out.Image = in.Image
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]AppImageChannel, len(*in))
+		copy(*out, *in)
+	}
 	if in.ConfigTemplates != nil {
 		in, out := &in.ConfigTemplates, &out.ConfigTemplates
 		*out = make(map[string]string, len(*in))
@@ -148,6 +170,22 @@ func (in *AuthCenter) DeepCopy() *AuthCenter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingMetric) DeepCopyInto(out *AutoscalingMetric) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingMetric.
+func (in *AutoscalingMetric) DeepCopy() *AutoscalingMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BandwidthLimit) DeepCopyInto(out *BandwidthLimit) {
 	*out = *in
@@ -310,6 +348,17 @@ func (in *ClusterDomainRouteStatus) DeepCopyInto(out *ClusterDomainRouteStatus)
 			(*out)[key] = val
 		}
 	}
+	out.BodyEncryption = in.BodyEncryption
+	if in.HeaderPolicy != nil {
+		in, out := &in.HeaderPolicy, &out.HeaderPolicy
+		*out = new(DomainRouteHeaderPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(DomainRouteLastError)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -719,6 +768,24 @@ func (in *DomainAppImageSpec) DeepCopy() *DomainAppImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainCondition) DeepCopyInto(out *DomainCondition) {
+	*out = *in
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainCondition.
+func (in *DomainCondition) DeepCopy() *DomainCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainData) DeepCopyInto(out *DomainData) {
 	*out = *in
@@ -747,6 +814,111 @@ func (in *DomainData) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainDataCopy) DeepCopyInto(out *DomainDataCopy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainDataCopy.
+func (in *DomainDataCopy) DeepCopy() *DomainDataCopy {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainDataCopy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DomainDataCopy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainDataCopyList) DeepCopyInto(out *DomainDataCopyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DomainDataCopy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainDataCopyList.
+func (in *DomainDataCopyList) DeepCopy() *DomainDataCopyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainDataCopyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DomainDataCopyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainDataCopySpec) DeepCopyInto(out *DomainDataCopySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainDataCopySpec.
+func (in *DomainDataCopySpec) DeepCopy() *DomainDataCopySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainDataCopySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainDataCopyStatus) DeepCopyInto(out *DomainDataCopyStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainDataCopyStatus.
+func (in *DomainDataCopyStatus) DeepCopy() *DomainDataCopyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainDataCopyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainDataGrant) DeepCopyInto(out *DomainDataGrant) {
 	*out = *in
@@ -1030,6 +1202,32 @@ func (in *DomainEndpoint) DeepCopy() *DomainEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainKeyRotationStatus) DeepCopyInto(out *DomainKeyRotationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingRoutes != nil {
+		in, out := &in.PendingRoutes, &out.PendingRoutes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainKeyRotationStatus.
+func (in *DomainKeyRotationStatus) DeepCopy() *DomainKeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainKeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainList) DeepCopyInto(out *DomainList) {
 	*out = *in
@@ -1087,6 +1285,11 @@ func (in *DomainResourceQuota) DeepCopyInto(out *DomainResourceQuota) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.TaskPartyLimits != nil {
+		in, out := &in.TaskPartyLimits, &out.TaskPartyLimits
+		*out = new(TaskPartyLimits)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1128,6 +1331,55 @@ func (in *DomainRoute) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteHeaderMatch) DeepCopyInto(out *DomainRouteHeaderMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteHeaderMatch.
+func (in *DomainRouteHeaderMatch) DeepCopy() *DomainRouteHeaderMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteHeaderMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteBodyEncryptionStatus) DeepCopyInto(out *DomainRouteBodyEncryptionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteBodyEncryptionStatus.
+func (in *DomainRouteBodyEncryptionStatus) DeepCopy() *DomainRouteBodyEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteBodyEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteLastError) DeepCopyInto(out *DomainRouteLastError) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteLastError.
+func (in *DomainRouteLastError) DeepCopy() *DomainRouteLastError {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteLastError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainRouteList) DeepCopyInto(out *DomainRouteList) {
 	*out = *in
@@ -1161,6 +1413,72 @@ func (in *DomainRouteList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteHeaderPolicy) DeepCopyInto(out *DomainRouteHeaderPolicy) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(DomainRouteHeaderRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(DomainRouteHeaderRules)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteHeaderPolicy.
+func (in *DomainRouteHeaderPolicy) DeepCopy() *DomainRouteHeaderPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteHeaderPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteHeaderRules) DeepCopyInto(out *DomainRouteHeaderRules) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteHeaderRules.
+func (in *DomainRouteHeaderRules) DeepCopy() *DomainRouteHeaderRules {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteHeaderRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainRouteMTLSConfig) DeepCopyInto(out *DomainRouteMTLSConfig) {
 	*out = *in
@@ -1177,6 +1495,111 @@ func (in *DomainRouteMTLSConfig) DeepCopy() *DomainRouteMTLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteConnectionPolicy) DeepCopyInto(out *DomainRouteConnectionPolicy) {
+	*out = *in
+	if in.TCPKeepalive != nil {
+		in, out := &in.TCPKeepalive, &out.TCPKeepalive
+		*out = new(DomainRouteTCPKeepalive)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteConnectionPolicy.
+func (in *DomainRouteConnectionPolicy) DeepCopy() *DomainRouteConnectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteConnectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteMTUWorkaround) DeepCopyInto(out *DomainRouteMTUWorkaround) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteMTUWorkaround.
+func (in *DomainRouteMTUWorkaround) DeepCopy() *DomainRouteMTUWorkaround {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteMTUWorkaround)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteRetryPolicy) DeepCopyInto(out *DomainRouteRetryPolicy) {
+	*out = *in
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NumRetries != nil {
+		in, out := &in.NumRetries, &out.NumRetries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteRetryPolicy.
+func (in *DomainRouteRetryPolicy) DeepCopy() *DomainRouteRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteRule) DeepCopyInto(out *DomainRouteRule) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]DomainRouteHeaderMatch, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteRule.
+func (in *DomainRouteRule) DeepCopy() *DomainRouteRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteServerTLS) DeepCopyInto(out *DomainRouteServerTLS) {
+	*out = *in
+	if in.ServerNames != nil {
+		in, out := &in.ServerNames, &out.ServerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteServerTLS.
+func (in *DomainRouteServerTLS) DeepCopy() *DomainRouteServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainRouteSpec) DeepCopyInto(out *DomainRouteSpec) {
 	*out = *in
@@ -1201,6 +1624,11 @@ func (in *DomainRouteSpec) DeepCopyInto(out *DomainRouteSpec) {
 		*out = new(DomainRouteMTLSConfig)
 		**out = **in
 	}
+	if in.ServerTLS != nil {
+		in, out := &in.ServerTLS, &out.ServerTLS
+		*out = new(DomainRouteServerTLS)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SourceWhiteIPList != nil {
 		in, out := &in.SourceWhiteIPList, &out.SourceWhiteIPList
 		*out = make([]string, len(*in))
@@ -1213,6 +1641,38 @@ func (in *DomainRouteSpec) DeepCopyInto(out *DomainRouteSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]DomainRouteRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MTUWorkaround != nil {
+		in, out := &in.MTUWorkaround, &out.MTUWorkaround
+		*out = new(DomainRouteMTUWorkaround)
+		**out = **in
+	}
+	if in.ConnectionPolicy != nil {
+		in, out := &in.ConnectionPolicy, &out.ConnectionPolicy
+		*out = new(DomainRouteConnectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(DomainRouteRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutPolicy != nil {
+		in, out := &in.TimeoutPolicy, &out.TimeoutPolicy
+		*out = new(DomainRouteTimeoutPolicy)
+		**out = **in
+	}
+	if in.HeaderPolicy != nil {
+		in, out := &in.HeaderPolicy, &out.HeaderPolicy
+		*out = new(DomainRouteHeaderPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1230,6 +1690,24 @@ func (in *DomainRouteSpec) DeepCopy() *DomainRouteSpec {
 func (in *DomainRouteStatus) DeepCopyInto(out *DomainRouteStatus) {
 	*out = *in
 	in.TokenStatus.DeepCopyInto(&out.TokenStatus)
+	out.BodyEncryption = in.BodyEncryption
+	if in.HeaderPolicy != nil {
+		in, out := &in.HeaderPolicy, &out.HeaderPolicy
+		*out = new(DomainRouteHeaderPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterDomainRouteCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(DomainRouteLastError)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1267,6 +1745,38 @@ func (in *DomainRouteToken) DeepCopy() *DomainRouteToken {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteTCPKeepalive) DeepCopyInto(out *DomainRouteTCPKeepalive) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteTCPKeepalive.
+func (in *DomainRouteTCPKeepalive) DeepCopy() *DomainRouteTCPKeepalive {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteTCPKeepalive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainRouteTimeoutPolicy) DeepCopyInto(out *DomainRouteTimeoutPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainRouteTimeoutPolicy.
+func (in *DomainRouteTimeoutPolicy) DeepCopy() *DomainRouteTimeoutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainRouteTimeoutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainRouteTokenStatus) DeepCopyInto(out *DomainRouteTokenStatus) {
 	*out = *in
@@ -1339,6 +1849,18 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(DomainKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DomainCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1637,6 +2159,39 @@ func (in *InteropConfigSpec) DeepCopy() *InteropConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobApprovalRecord) DeepCopyInto(out *JobApprovalRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobApprovalRecord.
+func (in *JobApprovalRecord) DeepCopy() *JobApprovalRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(JobApprovalRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobDependency) DeepCopyInto(out *JobDependency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobDependency.
+func (in *JobDependency) DeepCopy() *JobDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(JobDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KusciaDeployment) DeepCopyInto(out *KusciaDeployment) {
 	*out = *in
@@ -1665,6 +2220,42 @@ func (in *KusciaDeployment) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KusciaDeploymentAutoscaling) DeepCopyInto(out *KusciaDeploymentAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]AutoscalingMetric, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleUpStabilizationSeconds != nil {
+		in, out := &in.ScaleUpStabilizationSeconds, &out.ScaleUpStabilizationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDownStabilizationSeconds != nil {
+		in, out := &in.ScaleDownStabilizationSeconds, &out.ScaleDownStabilizationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KusciaDeploymentAutoscaling.
+func (in *KusciaDeploymentAutoscaling) DeepCopy() *KusciaDeploymentAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(KusciaDeploymentAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KusciaDeploymentList) DeepCopyInto(out *KusciaDeploymentList) {
 	*out = *in
@@ -1756,6 +2347,11 @@ func (in *KusciaDeploymentPartyTemplate) DeepCopyInto(out *KusciaDeploymentParty
 		(*in).DeepCopyInto(*out)
 	}
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(KusciaDeploymentAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1986,6 +2582,21 @@ func (in *KusciaJobSpec) DeepCopyInto(out *KusciaJobSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DependsOnJobs != nil {
+		in, out := &in.DependsOnJobs, &out.DependsOnJobs
+		*out = make([]JobDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependencyTimeoutSeconds != nil {
+		in, out := &in.DependencyTimeoutSeconds, &out.DependencyTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
 	if in.Tasks != nil {
 		in, out := &in.Tasks, &out.Tasks
 		*out = make([]KusciaTaskTemplate, len(*in))
@@ -2016,6 +2627,13 @@ func (in *KusciaJobStatus) DeepCopyInto(out *KusciaJobStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ApprovalRecords != nil {
+		in, out := &in.ApprovalRecords, &out.ApprovalRecords
+		*out = make([]JobApprovalRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.StageStatus != nil {
 		in, out := &in.StageStatus, &out.StageStatus
 		*out = make(map[string]JobStagePhase, len(*in))
@@ -2262,10 +2880,19 @@ func (in *KusciaTaskSpec) DeepCopy() *KusciaTaskSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KusciaTaskStatus) DeepCopyInto(out *KusciaTaskStatus) {
 	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.PartyTaskStatus != nil {
 		in, out := &in.PartyTaskStatus, &out.PartyTaskStatus
 		*out = make([]PartyTaskStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -2559,6 +3186,11 @@ func (in *Party) DeepCopyInto(out *Party) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	if in.BandwidthLimit != nil {
 		in, out := &in.BandwidthLimit, &out.BandwidthLimit
 		*out = make([]BandwidthLimit, len(*in))
@@ -2622,6 +3254,26 @@ func (in *PartyInfo) DeepCopy() *PartyInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartyPodStatus) DeepCopyInto(out *PartyPodStatus) {
+	*out = *in
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartyPodStatus.
+func (in *PartyPodStatus) DeepCopy() *PartyPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PartyPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PartyTaskCreateStatus) DeepCopyInto(out *PartyTaskCreateStatus) {
 	*out = *in
@@ -2641,6 +3293,13 @@ func (in *PartyTaskCreateStatus) DeepCopy() *PartyTaskCreateStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PartyTaskStatus) DeepCopyInto(out *PartyTaskStatus) {
 	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]PartyPodStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2792,6 +3451,37 @@ func (in *ServiceStatus) DeepCopy() *ServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskPartyLimits) DeepCopyInto(out *TaskPartyLimits) {
+	*out = *in
+	if in.MaxCPU != nil {
+		in, out := &in.MaxCPU, &out.MaxCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskPartyLimits.
+func (in *TaskPartyLimits) DeepCopy() *TaskPartyLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskPartyLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskResource) DeepCopyInto(out *TaskResource) {
 	*out = *in
@@ -3172,6 +3862,11 @@ func (in *Transit) DeepCopyInto(out *Transit) {
 		*out = new(DomainTransit)
 		**out = **in
 	}
+	if in.CandidateDomains != nil {
+		in, out := &in.CandidateDomains, &out.CandidateDomains
+		*out = make([]DomainTransit, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,193 @@
+// Copyright 2023 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	scheme "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// DomainDataCopiesGetter has a method to return a DomainDataCopyInterface.
+// A group's client should implement this interface.
+type DomainDataCopiesGetter interface {
+	DomainDataCopies(namespace string) DomainDataCopyInterface
+}
+
+// DomainDataCopyInterface has methods to work with DomainDataCopy resources.
+type DomainDataCopyInterface interface {
+	Create(ctx context.Context, domainDataCopy *v1alpha1.DomainDataCopy, opts v1.CreateOptions) (*v1alpha1.DomainDataCopy, error)
+	Update(ctx context.Context, domainDataCopy *v1alpha1.DomainDataCopy, opts v1.UpdateOptions) (*v1alpha1.DomainDataCopy, error)
+	UpdateStatus(ctx context.Context, domainDataCopy *v1alpha1.DomainDataCopy, opts v1.UpdateOptions) (*v1alpha1.DomainDataCopy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.DomainDataCopy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.DomainDataCopyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DomainDataCopy, err error)
+	DomainDataCopyExpansion
+}
+
+// domainDataCopies implements DomainDataCopyInterface
+type domainDataCopies struct {
+	client rest.Interface
+	ns     string
+}
+
+// newDomainDataCopies returns a DomainDataCopies
+func newDomainDataCopies(c *KusciaV1alpha1Client, namespace string) *domainDataCopies {
+	return &domainDataCopies{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the domainDataCopy, and returns the corresponding domainDataCopy object, and an error if there is any.
+func (c *domainDataCopies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DomainDataCopy, err error) {
+	result = &v1alpha1.DomainDataCopy{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of DomainDataCopies that match those selectors.
+func (c *domainDataCopies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DomainDataCopyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.DomainDataCopyList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested domainDataCopies.
+func (c *domainDataCopies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a domainDataCopy and creates it.  Returns the server's representation of the domainDataCopy, and an error, if there is any.
+func (c *domainDataCopies) Create(ctx context.Context, domainDataCopy *v1alpha1.DomainDataCopy, opts v1.CreateOptions) (result *v1alpha1.DomainDataCopy, err error) {
+	result = &v1alpha1.DomainDataCopy{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(domainDataCopy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a domainDataCopy and updates it. Returns the server's representation of the domainDataCopy, and an error, if there is any.
+func (c *domainDataCopies) Update(ctx context.Context, domainDataCopy *v1alpha1.DomainDataCopy, opts v1.UpdateOptions) (result *v1alpha1.DomainDataCopy, err error) {
+	result = &v1alpha1.DomainDataCopy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		Name(domainDataCopy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(domainDataCopy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *domainDataCopies) UpdateStatus(ctx context.Context, domainDataCopy *v1alpha1.DomainDataCopy, opts v1.UpdateOptions) (result *v1alpha1.DomainDataCopy, err error) {
+	result = &v1alpha1.DomainDataCopy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		Name(domainDataCopy.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(domainDataCopy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the domainDataCopy and deletes it. Returns an error if one occurs.
+func (c *domainDataCopies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *domainDataCopies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched domainDataCopy.
+func (c *domainDataCopies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DomainDataCopy, err error) {
+	result = &v1alpha1.DomainDataCopy{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("domaindatacopies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
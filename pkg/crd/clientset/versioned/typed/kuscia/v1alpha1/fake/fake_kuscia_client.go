@@ -46,6 +46,10 @@ func (c *FakeKusciaV1alpha1) DomainDatas(namespace string) v1alpha1.DomainDataIn
 	return &FakeDomainDatas{c, namespace}
 }
 
+func (c *FakeKusciaV1alpha1) DomainDataCopies(namespace string) v1alpha1.DomainDataCopyInterface {
+	return &FakeDomainDataCopies{c, namespace}
+}
+
 func (c *FakeKusciaV1alpha1) DomainDataGrants(namespace string) v1alpha1.DomainDataGrantInterface {
 	return &FakeDomainDataGrants{c, namespace}
 }
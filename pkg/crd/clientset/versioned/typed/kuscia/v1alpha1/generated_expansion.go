@@ -26,6 +26,8 @@ type DomainAppImageExpansion interface{}
 
 type DomainDataExpansion interface{}
 
+type DomainDataCopyExpansion interface{}
+
 type DomainDataGrantExpansion interface{}
 
 type DomainDataSourceExpansion interface{}
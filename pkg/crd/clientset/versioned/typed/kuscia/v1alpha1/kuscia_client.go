@@ -31,6 +31,7 @@ type KusciaV1alpha1Interface interface {
 	DomainsGetter
 	DomainAppImagesGetter
 	DomainDatasGetter
+	DomainDataCopiesGetter
 	DomainDataGrantsGetter
 	DomainDataSourcesGetter
 	DomainRoutesGetter
@@ -71,6 +72,10 @@ func (c *KusciaV1alpha1Client) DomainDatas(namespace string) DomainDataInterface
 	return newDomainDatas(c, namespace)
 }
 
+func (c *KusciaV1alpha1Client) DomainDataCopies(namespace string) DomainDataCopyInterface {
+	return newDomainDataCopies(c, namespace)
+}
+
 func (c *KusciaV1alpha1Client) DomainDataGrants(namespace string) DomainDataGrantInterface {
 	return newDomainDataGrants(c, namespace)
 }
@@ -61,6 +61,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kuscia().V1alpha1().DomainAppImages().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("domaindatas"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kuscia().V1alpha1().DomainDatas().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("domaindatacopies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kuscia().V1alpha1().DomainDataCopies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("domaindatagrants"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kuscia().V1alpha1().DomainDataGrants().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("domaindatasources"):
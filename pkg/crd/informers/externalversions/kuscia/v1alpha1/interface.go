@@ -32,6 +32,8 @@ type Interface interface {
 	DomainAppImages() DomainAppImageInformer
 	// DomainDatas returns a DomainDataInformer.
 	DomainDatas() DomainDataInformer
+	// DomainDataCopies returns a DomainDataCopyInformer.
+	DomainDataCopies() DomainDataCopyInformer
 	// DomainDataGrants returns a DomainDataGrantInformer.
 	DomainDataGrants() DomainDataGrantInformer
 	// DomainDataSources returns a DomainDataSourceInformer.
@@ -96,6 +98,11 @@ func (v *version) DomainDatas() DomainDataInformer {
 	return &domainDataInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DomainDataCopies returns a DomainDataCopyInformer.
+func (v *version) DomainDataCopies() DomainDataCopyInformer {
+	return &domainDataCopyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DomainDataGrants returns a DomainDataGrantInformer.
 func (v *version) DomainDataGrants() DomainDataGrantInformer {
 	return &domainDataGrantInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
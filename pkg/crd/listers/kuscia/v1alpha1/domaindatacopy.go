@@ -0,0 +1,97 @@
+// Copyright 2023 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DomainDataCopyLister helps list DomainDataCopies.
+// All objects returned here must be treated as read-only.
+type DomainDataCopyLister interface {
+	// List lists all DomainDataCopies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DomainDataCopy, err error)
+	// DomainDataCopies returns an object that can list and get DomainDataCopies.
+	DomainDataCopies(namespace string) DomainDataCopyNamespaceLister
+	DomainDataCopyListerExpansion
+}
+
+// domainDataCopyLister implements the DomainDataCopyLister interface.
+type domainDataCopyLister struct {
+	indexer cache.Indexer
+}
+
+// NewDomainDataCopyLister returns a new DomainDataCopyLister.
+func NewDomainDataCopyLister(indexer cache.Indexer) DomainDataCopyLister {
+	return &domainDataCopyLister{indexer: indexer}
+}
+
+// List lists all DomainDataCopies in the indexer.
+func (s *domainDataCopyLister) List(selector labels.Selector) (ret []*v1alpha1.DomainDataCopy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DomainDataCopy))
+	})
+	return ret, err
+}
+
+// DomainDataCopies returns an object that can list and get DomainDataCopies.
+func (s *domainDataCopyLister) DomainDataCopies(namespace string) DomainDataCopyNamespaceLister {
+	return domainDataCopyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DomainDataCopyNamespaceLister helps list and get DomainDataCopies.
+// All objects returned here must be treated as read-only.
+type DomainDataCopyNamespaceLister interface {
+	// List lists all DomainDataCopies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DomainDataCopy, err error)
+	// Get retrieves the DomainDataCopy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.DomainDataCopy, error)
+	DomainDataCopyNamespaceListerExpansion
+}
+
+// domainDataCopyNamespaceLister implements the DomainDataCopyNamespaceLister
+// interface.
+type domainDataCopyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DomainDataCopies in the indexer for a given namespace.
+func (s domainDataCopyNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.DomainDataCopy, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DomainDataCopy))
+	})
+	return ret, err
+}
+
+// Get retrieves the DomainDataCopy from the indexer for a given namespace and name.
+func (s domainDataCopyNamespaceLister) Get(name string) (*v1alpha1.DomainDataCopy, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("domaindatacopy"), name)
+	}
+	return obj.(*v1alpha1.DomainDataCopy), nil
+}
@@ -44,6 +44,14 @@ type DomainDataListerExpansion interface{}
 // DomainDataNamespaceLister.
 type DomainDataNamespaceListerExpansion interface{}
 
+// DomainDataCopyListerExpansion allows custom methods to be added to
+// DomainDataCopyLister.
+type DomainDataCopyListerExpansion interface{}
+
+// DomainDataCopyNamespaceListerExpansion allows custom methods to be added to
+// DomainDataCopyNamespaceLister.
+type DomainDataCopyNamespaceListerExpansion interface{}
+
 // DomainDataGrantListerExpansion allows custom methods to be added to
 // DomainDataGrantLister.
 type DomainDataGrantListerExpansion interface{}
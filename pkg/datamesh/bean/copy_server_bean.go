@@ -0,0 +1,83 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bean
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	cmservice "github.com/secretflow/kuscia/pkg/confmanager/service"
+	"github.com/secretflow/kuscia/pkg/datamesh/config"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/datacopy"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/io/builtin"
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/web/errorcode"
+	"github.com/secretflow/kuscia/pkg/web/framework"
+)
+
+// copyServerBean
+// 1 serves the domaindata copied by other domains, the server listens on localhost and is exposed by the gateway
+// 2 copies the domaindata of other domains declared by domaindatacopies
+type copyServerBean struct {
+	config *config.DataMeshConfig
+	server *datacopy.Server
+	runner *datacopy.Runner
+}
+
+func NewCopyServerBean(config *config.DataMeshConfig, cmConfigService cmservice.IConfigService) *copyServerBean { // nolint: golint
+	ioServer := builtin.NewIOServer()
+	datasourceService := service.NewDomainDataSourceService(config, cmConfigService)
+	return &copyServerBean{
+		config: config,
+		server: datacopy.NewServer(config, ioServer, datasourceService),
+		runner: datacopy.NewRunner(config, ioServer, datasourceService),
+	}
+}
+
+func (b *copyServerBean) Validate(errs *errorcode.Errs) {
+
+}
+
+func (b *copyServerBean) Init(e framework.ConfBeanRegistry) error {
+	return nil
+}
+
+// Start copyServerBean
+func (b *copyServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry) error {
+	go b.runner.Start(ctx)
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf("127.0.0.1:%d", b.config.CopyPort),
+		Handler:           b.server.Handler(),
+		ReadHeaderTimeout: time.Duration(b.config.ReadTimeout) * time.Second,
+		IdleTimeout:       time.Duration(b.config.IdleTimeout) * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	nlog.Infof("Copy server listening on %s", server.Addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (b *copyServerBean) ServerName() string {
+	return "DataMeshCopyServer"
+}
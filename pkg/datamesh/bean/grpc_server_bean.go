@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/flight"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
@@ -67,6 +68,7 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 		grpc.ChainUnaryInterceptor(interceptor.UnaryRecoverInterceptor(pberrorcode.ErrorCode_DataMeshErrForUnexpected)),
 		grpc.StreamInterceptor(interceptor.StreamRecoverInterceptor(pberrorcode.ErrorCode_DataMeshErrForUnexpected)),
 		grpc.MaxRecvMsgSize(256 * 1024 * 1024), // 256MB
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	}
 
 	if !s.config.DisableTLS {
@@ -82,6 +84,13 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 	if s.config.InterceptorLog != nil {
 		opts = append(opts, grpc.ChainUnaryInterceptor(interceptor.GrpcServerLoggingInterceptor(*s.config.InterceptorLog)))
 	}
+	// only the running task pods holding a token are able to call the flight service
+	if s.config.FlightTokenAuth {
+		auth := handler.NewFlightTokenAuthenticator(&s.config.DomainKey.PublicKey, s.config.KubeClient, s.config.KubeNamespace)
+		opts = append(opts, grpc.ChainUnaryInterceptor(auth.UnaryInterceptor()), grpc.ChainStreamInterceptor(auth.StreamInterceptor()))
+	}
+	// scope requests to the tenant in Kuscia-Tenant header
+	opts = append(opts, grpc.ChainUnaryInterceptor(interceptor.GrpcServerTenantHeaderInterceptor()))
 	// listen on grpc port
 	addr := fmt.Sprintf("%s:%d", s.config.ListenAddr, s.config.GRPCPort)
 	lis, err := net.Listen("tcp", addr)
@@ -97,6 +106,7 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 	datamesh.RegisterDomainDataServiceServer(server, grpchandler.NewDomainDataHandler(domainDataService))
 	datamesh.RegisterDomainDataSourceServiceServer(server, grpchandler.NewDomainDataSourceHandler(datasourceService))
 	datamesh.RegisterDomainDataGrantServiceServer(server, grpchandler.NewDomainDataGrantHandler(service.NewDomainDataGrantService(s.config)))
+	datamesh.RegisterDomainDataCopyServiceServer(server, grpchandler.NewDomainDataCopyHandler(service.NewDomainDataCopyService(s.config)))
 
 	flight.RegisterFlightServiceServer(server, handler.NewDataMeshFlightHandler(domainDataService, datasourceService, s.config.DataProxyList))
 
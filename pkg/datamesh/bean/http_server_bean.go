@@ -24,6 +24,7 @@ import (
 	dmconfig "github.com/secretflow/kuscia/pkg/datamesh/config"
 	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
 	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/v1handler/httphandler/domaindata"
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/v1handler/httphandler/domaindatacopy"
 	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/v1handler/httphandler/domaindatagrant"
 	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/v1handler/httphandler/domaindatasource"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/health"
@@ -37,6 +38,7 @@ import (
 	frameworkconfig "github.com/secretflow/kuscia/pkg/web/framework/config"
 	"github.com/secretflow/kuscia/pkg/web/framework/router"
 	"github.com/secretflow/kuscia/pkg/web/interceptor"
+	"github.com/secretflow/kuscia/pkg/web/openapi"
 	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
 )
 
@@ -73,6 +75,8 @@ func (s *httpServerBean) Init(e framework.ConfBeanRegistry) error {
 	if s.config.InterceptorLog != nil {
 		s.ginBean.Use(interceptor.HTTPServerLoggingInterceptor(*s.config.InterceptorLog))
 	}
+	// scope requests to the tenant in Kuscia-Tenant header
+	s.ginBean.Use(interceptor.HTTPTenantHeaderInterceptor())
 	s.registerGroupRoutes(e)
 	return nil
 }
@@ -90,6 +94,7 @@ func (s *httpServerBean) registerGroupRoutes(e framework.ConfBeanRegistry) {
 	domainDataService := service.NewDomainDataService(s.config)
 	domainDataSourceService := service.NewDomainDataSourceService(s.config, s.cmConfigService)
 	domainDataGrantService := service.NewDomainDataGrantService(s.config)
+	domainDataCopyService := service.NewDomainDataCopyService(s.config)
 	healthService := apisvc.NewHealthService()
 	// define router groups
 	groupsRouters := []*router.GroupRouters{
@@ -97,73 +102,40 @@ func (s *httpServerBean) registerGroupRoutes(e framework.ConfBeanRegistry) {
 		{
 			Group: "api/v1/datamesh/domaindata",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewCreateDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewDeleteDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewQueryDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewUpdateDomainHandler(domainDataService))},
-				},
+				protoRouter(e, http.MethodPost, "create", domaindata.NewCreateDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "delete", domaindata.NewDeleteDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "query", domaindata.NewQueryDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "update", domaindata.NewUpdateDomainHandler(domainDataService)),
 			},
 		},
 		// domainData group routes
 		{
 			Group: "api/v1/datamesh/domaindatasource",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatasource.NewQueryDomainDataSourceHandler(domainDataSourceService))},
-				},
+				protoRouter(e, http.MethodPost, "query", domaindatasource.NewQueryDomainDataSourceHandler(domainDataSourceService)),
 			},
 		},
 		{
 			Group: "api/v1/datamesh/domaindatagrant",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewCreateDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewDeleteDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewQueryDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewUpdateDomainSourceHandler(domainDataGrantService))},
-				},
+				protoRouter(e, http.MethodPost, "create", domaindatagrant.NewCreateDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "delete", domaindatagrant.NewDeleteDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "query", domaindatagrant.NewQueryDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "update", domaindatagrant.NewUpdateDomainSourceHandler(domainDataGrantService)),
+			},
+		},
+		{
+			Group: "api/v1/datamesh/domaindatacopy",
+			Routes: []*router.Router{
+				protoRouter(e, http.MethodPost, "create", domaindatacopy.NewCreateDomainDataCopyHandler(domainDataCopyService)),
+				protoRouter(e, http.MethodPost, "query", domaindatacopy.NewQueryDomainDataCopyHandler(domainDataCopyService)),
 			},
 		},
 		// health group routes
 		{
 			Group: "",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: constants.HealthAPI,
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, health.NewReadyHandler(healthService))},
-				},
+				protoRouter(e, http.MethodPost, constants.HealthAPI, health.NewReadyHandler(healthService)),
 			},
 		},
 	}
@@ -171,6 +143,22 @@ func (s *httpServerBean) registerGroupRoutes(e framework.ConfBeanRegistry) {
 	for _, gr := range groupsRouters {
 		s.ginBean.RegisterGroup(gr)
 	}
+	s.ginBean.GET(openapi.Path, openapi.Handler(openapi.Options{
+		Info:          openapi.Info{Title: "Kuscia DataMesh API", Version: "v1"},
+		UseProtoNames: true,
+	}, groupsRouters))
+}
+
+// protoRouter returns the route served by a proto handler.
+func protoRouter(e framework.ConfBeanRegistry, method, path string, handler api.ProtoHandler) *router.Router {
+	reqType, respType := handler.GetType()
+	return &router.Router{
+		HTTPMethod:   method,
+		RelativePath: path,
+		Handlers:     []gin.HandlerFunc{protoDecorator(e, handler)},
+		RequestType:  reqType,
+		ResponseType: respType,
+	}
 }
 
 // protoDecorator is used to wrap handler.
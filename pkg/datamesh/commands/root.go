@@ -74,6 +74,15 @@ func injectBean(ctx context.Context, conf *config.DataMeshConfig, appEngine *eng
 	if err != nil {
 		return fmt.Errorf("inject bean %s failed: %v", serverName, err.Error())
 	}
+	// inject copy server bean
+	if conf.CopyPort > 0 {
+		copyServer := bean.NewCopyServerBean(conf, cmConfigService)
+		serverName = copyServer.ServerName()
+		err = appEngine.UseBeanWithConfig(serverName, copyServer)
+		if err != nil {
+			return fmt.Errorf("inject bean %s failed: %v", serverName, err.Error())
+		}
+	}
 	// inject operator bean
 	opServer := bean.NewOperatorBean(conf, cmConfigService)
 	serverName = opServer.ServerName()
@@ -38,6 +38,7 @@ type DataMeshConfig struct {
 	ListenAddr     string // default is empty
 	HTTPPort       int32
 	GRPCPort       int32
+	CopyPort       int32 // serves the domaindata copied by other domains on localhost, 0 is disabled
 	Debug          bool
 	ConnectTimeOut int
 	ReadTimeout    int
@@ -53,6 +54,8 @@ type DataMeshConfig struct {
 	DisableTLS     bool              `yaml:"disableTLS,omitempty"`
 	DataProxyList  []DataProxyConfig `yaml:"dataProxyList,omitempty"`
 	InterceptorLog *nlog.NLog        `yaml:"-"`
+	// FlightTokenAuth requires the calls to the Flight service to carry the token issued to the task pod by agent
+	FlightTokenAuth bool `yaml:"flightTokenAuth,omitempty"`
 }
 
 type DataProxyConfig struct {
@@ -62,6 +65,13 @@ type DataProxyConfig struct {
 	DataSourceTypes []string `yaml:"dataSourceTypes,omitempty"`
 	// io mode type: proxy(app --> datamesh --> datasource) or direct(app --> datasource)
 	Mode string `yaml:"mode,omitempty"`
+	// PassthroughHeaders are the request headers forwarded to the dataProxy, default is authorization
+	PassthroughHeaders []string `yaml:"passthroughHeaders,omitempty"`
+	// HealthCheckIntervalSeconds is the min interval between two health checks of the dataProxy, default is 10s
+	HealthCheckIntervalSeconds int `yaml:"healthCheckIntervalSeconds,omitempty"`
+	// Fallback to the builtin dataProxy when the dataProxy is unhealthy or unavailable,
+	// only works for the dataSource types supported by builtin dataProxy
+	Fallback bool `yaml:"fallback,omitempty"`
 }
 
 type DbConfig struct {
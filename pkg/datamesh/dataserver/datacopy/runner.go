@@ -0,0 +1,337 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacopy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/datamesh/config"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/io/builtin"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+const (
+	// LineageSourceDomainAttribute and LineageSourceDomainDataAttribute are the attributes of the copied domaindata
+	// which record where it's copied from.
+	LineageSourceDomainAttribute     = "copied-from-domain"
+	LineageSourceDomainDataAttribute = "copied-from-domaindata"
+
+	DefaultChunkSize = 4 * 1024 * 1024
+
+	defaultGatewayEndpoint = "http://127.0.0.1:80"
+	defaultSyncInterval    = 5 * time.Second
+)
+
+// permanentError fails the copy, other errors leave the copy running and it's resumed in the next sync.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func permanentErrorf(format string, args ...interface{}) error {
+	return &permanentError{err: fmt.Errorf(format, args...)}
+}
+
+// Runner copies domaindata of other domains to the local domain as the domaindatacopies in the domain namespace
+// declare, the data is fetched in chunks through the gateway and the progress is recorded in the status of
+// domaindatacopy, so an interrupted copy resumes from the last copied chunk.
+type Runner struct {
+	conf            *config.DataMeshConfig
+	ioServer        *builtin.IOServer
+	datasourceSvc   service.IDomainDataSourceService
+	gatewayEndpoint string
+	syncInterval    time.Duration
+	client          *http.Client
+	running         sync.Map
+}
+
+func NewRunner(conf *config.DataMeshConfig, ioServer *builtin.IOServer, datasourceSvc service.IDomainDataSourceService) *Runner {
+	return &Runner{
+		conf:            conf,
+		ioServer:        ioServer,
+		datasourceSvc:   datasourceSvc,
+		gatewayEndpoint: defaultGatewayEndpoint,
+		syncInterval:    defaultSyncInterval,
+		client:          &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (r *Runner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.syncInterval)
+	defer ticker.Stop()
+	for {
+		r.sync(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync starts the copies which are not finished and not running.
+func (r *Runner) sync(ctx context.Context) {
+	copies, err := r.conf.KusciaClient.KusciaV1alpha1().DomainDataCopies(r.conf.KubeNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		nlog.Warnf("List domaindatacopies failed, %v", err)
+		return
+	}
+	for i := range copies.Items {
+		ddc := &copies.Items[i]
+		if ddc.Status.Phase == v1alpha1.DomainDataCopySucceeded || ddc.Status.Phase == v1alpha1.DomainDataCopyFailed {
+			continue
+		}
+		if _, loaded := r.running.LoadOrStore(ddc.Name, struct{}{}); loaded {
+			continue
+		}
+		go func(ddc *v1alpha1.DomainDataCopy) {
+			defer r.running.Delete(ddc.Name)
+			r.run(ctx, ddc)
+		}(ddc.DeepCopy())
+	}
+}
+
+func (r *Runner) run(ctx context.Context, ddc *v1alpha1.DomainDataCopy) {
+	err := r.copy(ctx, ddc)
+	if err == nil {
+		return
+	}
+	var pe *permanentError
+	if errors.As(err, &pe) {
+		nlog.Errorf("DomainDataCopy %s failed, %v", ddc.Name, err)
+		ddc.Status.Phase = v1alpha1.DomainDataCopyFailed
+		now := metav1.Now()
+		ddc.Status.CompletionTime = &now
+	} else {
+		nlog.Warnf("DomainDataCopy %s interrupted and will be resumed, %v", ddc.Name, err)
+	}
+	ddc.Status.Message = err.Error()
+	if _, updateErr := r.updateStatus(ctx, ddc); updateErr != nil {
+		nlog.Warnf("Update status of domaindatacopy %s failed, %v", ddc.Name, updateErr)
+	}
+}
+
+func (r *Runner) copy(ctx context.Context, ddc *v1alpha1.DomainDataCopy) error {
+	resp := r.datasourceSvc.QueryDomainDataSource(ctx, &datamesh.QueryDomainDataSourceRequest{DatasourceId: ddc.Spec.DataSource})
+	if resp.GetStatus().GetCode() != 0 {
+		return permanentErrorf("query datasource %s failed, %s", ddc.Spec.DataSource, resp.GetStatus().GetMessage())
+	}
+	ds := resp.GetData()
+	rio, ok := r.ioServer.RangeIO(ds.GetType())
+	if !ok {
+		return permanentErrorf("copy to datasource type %s is not supported", ds.GetType())
+	}
+
+	meta, err := r.fetchMeta(ctx, ddc)
+	if err != nil {
+		return err
+	}
+	if ddc.Status.Checksum != "" && ddc.Status.Checksum != meta.Checksum {
+		nlog.Infof("Source domaindata of domaindatacopy %s changed, copy from the beginning", ddc.Name)
+		ddc.Status.CopiedBytes = 0
+	}
+	if ddc.Status.StartTime == nil {
+		now := metav1.Now()
+		ddc.Status.StartTime = &now
+	}
+	ddc.Status.Phase = v1alpha1.DomainDataCopyRunning
+	ddc.Status.TotalBytes = meta.Size
+	ddc.Status.Checksum = meta.Checksum
+	ddc.Status.Message = ""
+	if ddc, err = r.updateStatus(ctx, ddc); err != nil {
+		return err
+	}
+
+	chunkSize := ddc.Spec.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	offset := ddc.Status.CopiedBytes
+	if meta.Size == 0 {
+		if _, err := rio.WriteAt(ctx, ds, ddc.Spec.RelativeURI, 0, http.NoBody); err != nil {
+			return err
+		}
+	}
+	for offset < meta.Size {
+		n, err := r.copyChunk(ctx, ddc, rio, ds, offset, chunkSize)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("source domaindata ends at %d, expect size %d", offset, meta.Size)
+		}
+		offset += n
+		ddc.Status.CopiedBytes = offset
+		if ddc, err = r.updateStatus(ctx, ddc); err != nil {
+			return err
+		}
+	}
+
+	checksum, err := r.checksum(ctx, rio, ds, ddc.Spec.RelativeURI)
+	if err != nil {
+		return err
+	}
+	if checksum != meta.Checksum {
+		return permanentErrorf("checksum of copied data %s mismatches the source %s", checksum, meta.Checksum)
+	}
+	if err := r.registerDomainData(ctx, ddc, meta, checksum); err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	ddc.Status.Phase = v1alpha1.DomainDataCopySucceeded
+	ddc.Status.CompletionTime = &now
+	if _, err := r.updateStatus(ctx, ddc); err != nil {
+		return err
+	}
+	nlog.Infof("DomainDataCopy %s succeeded, copied %d bytes from %s/%s", ddc.Name, meta.Size, ddc.Spec.SourceDomain, ddc.Spec.SourceDomainDataID)
+	return nil
+}
+
+func (r *Runner) copyChunk(ctx context.Context, ddc *v1alpha1.DomainDataCopy, rio builtin.DataMeshRangeIOInterface,
+	ds *datamesh.DomainDataSource, offset, length int64) (int64, error) {
+	query := url.Values{}
+	query.Set("domaindata_id", ddc.Spec.SourceDomainDataID)
+	query.Set("offset", strconv.FormatInt(offset, 10))
+	query.Set("length", strconv.FormatInt(length, 10))
+	body, err := r.get(ctx, ddc.Spec.SourceDomain, ChunkPath, query)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	return rio.WriteAt(ctx, ds, ddc.Spec.RelativeURI, offset, io.LimitReader(body, length))
+}
+
+func (r *Runner) fetchMeta(ctx context.Context, ddc *v1alpha1.DomainDataCopy) (*SourceMeta, error) {
+	query := url.Values{}
+	query.Set("domaindata_id", ddc.Spec.SourceDomainDataID)
+	body, err := r.get(ctx, ddc.Spec.SourceDomain, MetaPath, query)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	meta := &SourceMeta{}
+	if err := json.NewDecoder(body).Decode(meta); err != nil {
+		return nil, fmt.Errorf("decode meta of source domaindata failed, %s", err.Error())
+	}
+	return meta, nil
+}
+
+// get sends the request to the copy server of the source domain through the gateway.
+func (r *Runner) get(ctx context.Context, sourceDomain, path string, query url.Values) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.gatewayEndpoint+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	host := fmt.Sprintf("%s.%s.svc", ServiceName, sourceDomain)
+	req.Host = host
+	req.Header.Set("Kuscia-Host", host)
+	req.Header.Set(headerSource, r.conf.KubeNamespace)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s of %s failed, %s", path, sourceDomain, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		err := fmt.Errorf("request %s of %s failed, status code %d, %s", path, sourceDomain, resp.StatusCode, string(msg))
+		switch resp.StatusCode {
+		case http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusNotImplemented:
+			return nil, &permanentError{err: err}
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (r *Runner) checksum(ctx context.Context, rio builtin.DataMeshRangeIOInterface, ds *datamesh.DomainDataSource, uri string) (string, error) {
+	rc, err := rio.ReadRange(ctx, ds, uri, 0, -1)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	return utils.ComputeChecksum(rc)
+}
+
+// registerDomainData registers the copied data as domaindata, which records the lineage and the checksum.
+func (r *Runner) registerDomainData(ctx context.Context, ddc *v1alpha1.DomainDataCopy, meta *SourceMeta, checksum string) error {
+	attributes := make(map[string]string, len(meta.DomainData.Attributes)+3)
+	for k, v := range meta.DomainData.Attributes {
+		attributes[k] = v
+	}
+	attributes[LineageSourceDomainAttribute] = ddc.Spec.SourceDomain
+	attributes[LineageSourceDomainDataAttribute] = ddc.Spec.SourceDomainDataID
+	attributes[utils.DomainDataChecksumAttribute] = checksum
+
+	spec := meta.DomainData
+	spec.Author = r.conf.KubeNamespace
+	spec.DataSource = ddc.Spec.DataSource
+	spec.RelativeURI = ddc.Spec.RelativeURI
+	spec.Attributes = attributes
+	dd := &v1alpha1.DomainData{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ddc.Spec.DomainDataID,
+			Namespace: r.conf.KubeNamespace,
+			Labels: map[string]string{
+				common.LabelDomainDataType:        spec.Type,
+				common.LabelDomainDataVendor:      spec.Vendor,
+				common.LabelInterConnProtocolType: "kuscia",
+			},
+		},
+		Spec: spec,
+	}
+	_, err := r.conf.KusciaClient.KusciaV1alpha1().DomainDatas(r.conf.KubeNamespace).Create(ctx, dd, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		existing, getErr := r.conf.KusciaClient.KusciaV1alpha1().DomainDatas(r.conf.KubeNamespace).Get(ctx, dd.Name, metav1.GetOptions{})
+		if getErr != nil {
+			return getErr
+		}
+		if existing.Spec.Attributes[LineageSourceDomainDataAttribute] != ddc.Spec.SourceDomainDataID {
+			return permanentErrorf("domaindata %s already exists", dd.Name)
+		}
+		return nil
+	}
+	return err
+}
+
+func (r *Runner) updateStatus(ctx context.Context, ddc *v1alpha1.DomainDataCopy) (*v1alpha1.DomainDataCopy, error) {
+	now := metav1.Now()
+	ddc.Status.LastUpdateTime = &now
+	updated, err := r.conf.KusciaClient.KusciaV1alpha1().DomainDataCopies(ddc.Namespace).UpdateStatus(ctx, ddc, metav1.UpdateOptions{})
+	if err != nil {
+		return ddc, err
+	}
+	return updated, nil
+}
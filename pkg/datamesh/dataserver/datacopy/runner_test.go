@@ -0,0 +1,188 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacopy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/pkg/datamesh/config"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/io/builtin"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
+	"github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+const testContent = "id,age\nalice,18\nbob,20\n"
+
+type testEnv struct {
+	source  *config.DataMeshConfig
+	dest    *config.DataMeshConfig
+	destDir string
+	runner  *Runner
+}
+
+func newTestEnv(t *testing.T, granted bool) *testEnv {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	client := kusciafake.NewSimpleClientset()
+	env := &testEnv{
+		source:  &config.DataMeshConfig{KusciaClient: client, KubeNamespace: "alice", DomainKey: key},
+		dest:    &config.DataMeshConfig{KusciaClient: client, KubeNamespace: "bob", DomainKey: key},
+		destDir: t.TempDir(),
+	}
+	sourceDir := t.TempDir()
+	registerLocalFS(t, env.source, sourceDir)
+	registerLocalFS(t, env.dest, env.destDir)
+	assert.NoError(t, os.WriteFile(path.Join(sourceDir, "alice.csv"), []byte(testContent), 0644))
+
+	ctx := context.Background()
+	_, err = client.KusciaV1alpha1().DomainDatas("alice").Create(ctx, &v1alpha1.DomainData{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-table"},
+		Spec: v1alpha1.DomainDataSpec{
+			RelativeURI: "alice.csv",
+			Author:      "alice",
+			Name:        "alice-table",
+			Type:        "table",
+			DataSource:  common.DefaultDataSourceID,
+			Columns:     []v1alpha1.DataColumn{{Name: "id", Type: "str"}, {Name: "age", Type: "int"}},
+		},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	if granted {
+		_, err = client.KusciaV1alpha1().DomainDataGrants("alice").Create(ctx, &v1alpha1.DomainDataGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Labels: map[string]string{common.LabelDomainDataID: "alice-table"}},
+			Spec:       v1alpha1.DomainDataGrantSpec{Author: "alice", DomainDataID: "alice-table", GrantDomain: "bob"},
+			Status:     v1alpha1.DomainDataGrantStatus{Phase: v1alpha1.GrantReady},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	ioServer := builtin.NewIOServer()
+	server := httptest.NewServer(NewServer(env.source, ioServer, service.NewDomainDataSourceService(env.source, nil)).Handler())
+	t.Cleanup(server.Close)
+	env.runner = NewRunner(env.dest, ioServer, service.NewDomainDataSourceService(env.dest, nil))
+	env.runner.gatewayEndpoint = server.URL
+	return env
+}
+
+func registerLocalFS(t *testing.T, conf *config.DataMeshConfig, dir string) {
+	info, err := json.Marshal(&datamesh.DataSourceInfo{Localfs: &datamesh.LocalDataSourceInfo{Path: dir}})
+	assert.NoError(t, err)
+	encrypted, err := tls.EncryptOAEP(&conf.DomainKey.PublicKey, info)
+	assert.NoError(t, err)
+	_, err = conf.KusciaClient.KusciaV1alpha1().DomainDataSources(conf.KubeNamespace).Create(context.Background(), &v1alpha1.DomainDataSource{
+		ObjectMeta: metav1.ObjectMeta{Name: common.DefaultDataSourceID},
+		Spec: v1alpha1.DomainDataSourceSpec{
+			Name: common.DefaultDataSourceID,
+			Type: common.DomainDataSourceTypeLocalFS,
+			Data: map[string]string{"encryptedInfo": encrypted},
+		},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+}
+
+func (env *testEnv) createCopy(t *testing.T) *v1alpha1.DomainDataCopy {
+	resp := service.NewDomainDataCopyService(env.dest).CreateDomainDataCopy(context.Background(), &datamesh.CreateDomainDataCopyRequest{
+		DomaindatacopyId:   "copy",
+		SourceDomain:       "alice",
+		SourceDomaindataId: "alice-table",
+		DomaindataId:       "alice-table-copy",
+		RelativeUri:        "copy/alice.csv",
+		ChunkSize:          5,
+	})
+	assert.Equal(t, int32(0), resp.Status.Code)
+	return env.getCopy(t)
+}
+
+func (env *testEnv) getCopy(t *testing.T) *v1alpha1.DomainDataCopy {
+	ddc, err := env.dest.KusciaClient.KusciaV1alpha1().DomainDataCopies("bob").Get(context.Background(), "copy", metav1.GetOptions{})
+	assert.NoError(t, err)
+	return ddc
+}
+
+func TestRunner_Copy(t *testing.T) {
+	env := newTestEnv(t, true)
+	ddc := env.createCopy(t)
+	assert.Equal(t, v1alpha1.DomainDataCopyPending, ddc.Status.Phase)
+
+	env.runner.run(context.Background(), ddc)
+
+	ddc = env.getCopy(t)
+	assert.Equal(t, v1alpha1.DomainDataCopySucceeded, ddc.Status.Phase)
+	assert.Equal(t, int64(len(testContent)), ddc.Status.CopiedBytes)
+	assert.Equal(t, int64(len(testContent)), ddc.Status.TotalBytes)
+	assert.NotNil(t, ddc.Status.CompletionTime)
+
+	content, err := os.ReadFile(path.Join(env.destDir, "copy/alice.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, testContent, string(content))
+
+	dd, err := env.dest.KusciaClient.KusciaV1alpha1().DomainDatas("bob").Get(context.Background(), "alice-table-copy", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", dd.Spec.Author)
+	assert.Equal(t, "copy/alice.csv", dd.Spec.RelativeURI)
+	assert.Len(t, dd.Spec.Columns, 2)
+	assert.Equal(t, "alice", dd.Spec.Attributes[LineageSourceDomainAttribute])
+	assert.Equal(t, "alice-table", dd.Spec.Attributes[LineageSourceDomainDataAttribute])
+	assert.Equal(t, ddc.Status.Checksum, dd.Spec.Attributes[utils.DomainDataChecksumAttribute])
+}
+
+func TestRunner_Resume(t *testing.T) {
+	env := newTestEnv(t, true)
+	ddc := env.createCopy(t)
+
+	// the copy was interrupted after the first 10 bytes, the bytes written after them are discarded
+	assert.NoError(t, os.MkdirAll(path.Join(env.destDir, "copy"), 0755))
+	assert.NoError(t, os.WriteFile(path.Join(env.destDir, "copy/alice.csv"), []byte(testContent[:10]+"garbage"), 0644))
+	ddc.Status.Phase = v1alpha1.DomainDataCopyRunning
+	ddc.Status.CopiedBytes = 10
+	ddc, err := env.dest.KusciaClient.KusciaV1alpha1().DomainDataCopies("bob").UpdateStatus(context.Background(), ddc, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+
+	env.runner.run(context.Background(), ddc)
+
+	ddc = env.getCopy(t)
+	assert.Equal(t, v1alpha1.DomainDataCopySucceeded, ddc.Status.Phase)
+	content, err := os.ReadFile(path.Join(env.destDir, "copy/alice.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, testContent, string(content))
+}
+
+func TestRunner_NotGranted(t *testing.T) {
+	env := newTestEnv(t, false)
+	ddc := env.createCopy(t)
+
+	env.runner.run(context.Background(), ddc)
+
+	ddc = env.getCopy(t)
+	assert.Equal(t, v1alpha1.DomainDataCopyFailed, ddc.Status.Phase)
+	assert.Contains(t, ddc.Status.Message, "not granted to bob")
+	_, err := env.dest.KusciaClient.KusciaV1alpha1().DomainDatas("bob").Get(context.Background(), "alice-table-copy", metav1.GetOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacopy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/datamesh/config"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/io/builtin"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+const (
+	// ServiceName is the service name which the copy server is exposed to other domains by the gateway.
+	ServiceName = "datamesh"
+
+	MetaPath  = "/api/v1/datamesh/copy/meta"
+	ChunkPath = "/api/v1/datamesh/copy/chunk"
+
+	headerSource = "Kuscia-Source"
+)
+
+// SourceMeta is the metadata of the source domaindata sent to the destination domain.
+type SourceMeta struct {
+	DomainData v1alpha1.DomainDataSpec `json:"domainData"`
+	Size       int64                   `json:"size"`
+	Checksum   string                  `json:"checksum"`
+}
+
+// Server serves the domaindata granted to other domains, the data is fetched by the destination domain in chunks.
+type Server struct {
+	conf          *config.DataMeshConfig
+	ioServer      *builtin.IOServer
+	datasourceSvc service.IDomainDataSourceService
+}
+
+func NewServer(conf *config.DataMeshConfig, ioServer *builtin.IOServer, datasourceSvc service.IDomainDataSourceService) *Server {
+	return &Server{
+		conf:          conf,
+		ioServer:      ioServer,
+		datasourceSvc: datasourceSvc,
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(MetaPath, s.handleMeta)
+	mux.HandleFunc(ChunkPath, s.handleChunk)
+	return mux
+}
+
+func (s *Server) handleMeta(w http.ResponseWriter, r *http.Request) {
+	dd, ds, rio, code, err := s.prepare(r)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	size, err := rio.Stat(r.Context(), ds, dd.Spec.RelativeURI)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("stat domaindata failed, %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	checksum := dd.Spec.Attributes[utils.DomainDataChecksumAttribute]
+	if checksum == "" {
+		if checksum, err = s.computeChecksum(r.Context(), rio, ds, dd.Spec.RelativeURI); err != nil {
+			http.Error(w, fmt.Sprintf("compute checksum failed, %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&SourceMeta{DomainData: dd.Spec, Size: size, Checksum: checksum}); err != nil {
+		nlog.Warnf("Write meta of domaindata %s failed, %v", dd.Name, err)
+	}
+}
+
+func (s *Server) handleChunk(w http.ResponseWriter, r *http.Request) {
+	dd, ds, rio, code, err := s.prepare(r)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		http.Error(w, "invalid offset", http.StatusBadRequest)
+		return
+	}
+	length, err := strconv.ParseInt(r.URL.Query().Get("length"), 10, 64)
+	if err != nil || length <= 0 {
+		http.Error(w, "invalid length", http.StatusBadRequest)
+		return
+	}
+	rc, err := rio.ReadRange(r.Context(), ds, dd.Spec.RelativeURI, offset, length)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("read domaindata failed, %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err := io.Copy(w, rc); err != nil {
+		nlog.Warnf("Send chunk of domaindata %s at offset %d failed, %v", dd.Name, offset, err)
+	}
+}
+
+// prepare checks the domaindata is granted to the requesting domain and finds the io channel to read it.
+func (s *Server) prepare(r *http.Request) (*v1alpha1.DomainData, *datamesh.DomainDataSource, builtin.DataMeshRangeIOInterface, int, error) {
+	if r.Method != http.MethodGet {
+		return nil, nil, nil, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method)
+	}
+	source := r.Header.Get(headerSource)
+	domainDataID := r.URL.Query().Get("domaindata_id")
+	if source == "" || domainDataID == "" {
+		return nil, nil, nil, http.StatusBadRequest, fmt.Errorf("%s header and domaindata_id are required", headerSource)
+	}
+	if err := s.checkGrant(r.Context(), domainDataID, source); err != nil {
+		return nil, nil, nil, http.StatusForbidden, err
+	}
+	dd, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(s.conf.KubeNamespace).Get(r.Context(), domainDataID, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, nil, http.StatusNotFound, fmt.Errorf("domaindata %s not found", domainDataID)
+	}
+	resp := s.datasourceSvc.QueryDomainDataSource(r.Context(), &datamesh.QueryDomainDataSourceRequest{DatasourceId: dd.Spec.DataSource})
+	if resp.GetStatus().GetCode() != 0 {
+		return nil, nil, nil, http.StatusInternalServerError, fmt.Errorf("query datasource %s failed, %s", dd.Spec.DataSource, resp.GetStatus().GetMessage())
+	}
+	rio, ok := s.ioServer.RangeIO(resp.GetData().GetType())
+	if !ok {
+		return nil, nil, nil, http.StatusNotImplemented, fmt.Errorf("copy of datasource type %s is not supported", resp.GetData().GetType())
+	}
+	return dd, resp.GetData(), rio, http.StatusOK, nil
+}
+
+// checkGrant checks there is a ready domaindatagrant of the domaindata to the domain.
+func (s *Server) checkGrant(ctx context.Context, domainDataID, domain string) error {
+	selector := labels.SelectorFromSet(labels.Set{common.LabelDomainDataID: domainDataID})
+	grants, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDataGrants(s.conf.KubeNamespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("list domaindatagrants failed, %s", err.Error())
+	}
+	for _, dg := range grants.Items {
+		if dg.Spec.DomainDataID != domainDataID || dg.Spec.GrantDomain != domain || dg.Status.Phase != v1alpha1.GrantReady {
+			continue
+		}
+		if limit := dg.Spec.Limit; limit != nil && limit.ExpirationTime != nil && time.Now().After(limit.ExpirationTime.Time) {
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("domaindata %s is not granted to %s", domainDataID, domain)
+}
+
+func (s *Server) computeChecksum(ctx context.Context, rio builtin.DataMeshRangeIOInterface, ds *datamesh.DomainDataSource, uri string) (string, error) {
+	rc, err := rio.ReadRange(ctx, ds, uri, 0, -1)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	return utils.ComputeChecksum(rc)
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"crypto/rsa"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/datameshtoken"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+const flightServicePrefix = "/arrow.flight.protocol.FlightService/"
+
+// FlightTokenAuthenticator authenticates the calls to the Flight service by the token which the agent
+// issued to the task pod. Only a running task pod of the domain is able to call the Flight service.
+type FlightTokenAuthenticator struct {
+	key        *rsa.PublicKey
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func NewFlightTokenAuthenticator(key *rsa.PublicKey, kubeClient kubernetes.Interface, namespace string) *FlightTokenAuthenticator {
+	return &FlightTokenAuthenticator{
+		key:        key,
+		kubeClient: kubeClient,
+		namespace:  namespace,
+	}
+}
+
+// UnaryInterceptor authenticates the unary calls to the Flight service, other services are not affected.
+func (a *FlightTokenAuthenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, flightServicePrefix) {
+			if err := a.authenticate(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates the stream calls to the Flight service, other services are not affected.
+func (a *FlightTokenAuthenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, flightServicePrefix) {
+			if err := a.authenticate(ss.Context(), info.FullMethod); err != nil {
+				return err
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *FlightTokenAuthenticator) authenticate(ctx context.Context, method string) error {
+	tokens := metadata.ValueFromIncomingContext(ctx, strings.ToLower(constants.DataMeshTokenHeader))
+	if len(tokens) == 0 || tokens[0] == "" {
+		return status.Errorf(codes.Unauthenticated, "missing %s header", constants.DataMeshTokenHeader)
+	}
+
+	claims, err := datameshtoken.Parse(a.key, tokens[0])
+	if err != nil {
+		nlog.Warnf("Reject flight call %s, invalid token: %v", method, err)
+		return status.Error(codes.Unauthenticated, "invalid datamesh token")
+	}
+	if claims.Namespace != a.namespace {
+		return status.Errorf(codes.PermissionDenied, "token is issued to domain %s", claims.Namespace)
+	}
+
+	pod, err := a.kubeClient.CoreV1().Pods(claims.Namespace).Get(ctx, claims.Pod, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return status.Errorf(codes.PermissionDenied, "pod %s of task %s does not exist", claims.Pod, claims.TaskID)
+		}
+		return status.Errorf(codes.Unavailable, "failed to get pod %s, detail-> %v", claims.Pod, err)
+	}
+	if string(pod.UID) != claims.PodUID || pod.Annotations[common.TaskIDAnnotationKey] != claims.TaskID {
+		return status.Errorf(codes.PermissionDenied, "token is not issued to pod %s", claims.Pod)
+	}
+	if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return status.Errorf(codes.PermissionDenied, "pod %s of task %s is finished", claims.Pod, claims.TaskID)
+	}
+	return nil
+}
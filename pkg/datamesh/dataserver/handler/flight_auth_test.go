@@ -0,0 +1,99 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/datameshtoken"
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+func TestFlightTokenAuthenticator(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	newPod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "alice",
+				UID:         types.UID("uid-" + name),
+				Annotations: map[string]string{common.TaskIDAnnotationKey: "task"},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	signToken := func(pod *corev1.Pod) string {
+		claims, err := datameshtoken.NewClaims(pod)
+		assert.NoError(t, err)
+		token, err := datameshtoken.Sign(key, claims)
+		assert.NoError(t, err)
+		return token
+	}
+
+	running := newPod("running", corev1.PodRunning)
+	finished := newPod("finished", corev1.PodSucceeded)
+	deleted := newPod("deleted", corev1.PodRunning)
+	recreated := newPod("running", corev1.PodRunning)
+	recreated.UID = "uid-old"
+	otherDomain := newPod("running", corev1.PodRunning)
+	otherDomain.Namespace = "bob"
+
+	kubeClient := fake.NewSimpleClientset(running, finished)
+	auth := NewFlightTokenAuthenticator(&key.PublicKey, kubeClient, "alice")
+	interceptor := auth.UnaryInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		code   codes.Code
+	}{
+		{name: "running pod", method: "/arrow.flight.protocol.FlightService/DoAction", token: signToken(running), code: codes.OK},
+		{name: "missing token", method: "/arrow.flight.protocol.FlightService/DoAction", code: codes.Unauthenticated},
+		{name: "invalid token", method: "/arrow.flight.protocol.FlightService/DoAction", token: "invalid", code: codes.Unauthenticated},
+		{name: "finished pod", method: "/arrow.flight.protocol.FlightService/DoAction", token: signToken(finished), code: codes.PermissionDenied},
+		{name: "deleted pod", method: "/arrow.flight.protocol.FlightService/DoAction", token: signToken(deleted), code: codes.PermissionDenied},
+		{name: "recreated pod", method: "/arrow.flight.protocol.FlightService/DoAction", token: signToken(recreated), code: codes.PermissionDenied},
+		{name: "other domain", method: "/arrow.flight.protocol.FlightService/DoAction", token: signToken(otherDomain), code: codes.PermissionDenied},
+		{name: "not flight service", method: "/kuscia.proto.api.v1alpha1.datamesh.DomainDataService/QueryDomainData", code: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(constants.DataMeshTokenHeader, tt.token))
+			}
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
@@ -58,6 +58,8 @@ func NewDataMeshFlightHandler(dds service.IDomainDataService, dss service.IDomai
 	handler.customHandles["ActionUpdateDomainDataRequest"] = chs.DoActionUpdateDomainDataRequest
 	handler.customHandles["ActionDeleteDomainDataRequest"] = chs.DoActionDeleteDomainDataRequest
 	handler.customHandles["ActionQueryDomainDataSourceRequest"] = chs.DoActionQueryDomainDataSourceRequest
+	handler.customHandles["ActionVerifyDomainDataRequest"] = handler.flightService.DoActionVerifyDomainDataRequest
+	handler.customHandles["ActionSampleDomainDataRequest"] = handler.flightService.DoActionSampleDomainDataRequest
 	return handler
 }
 
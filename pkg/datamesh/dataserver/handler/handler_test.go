@@ -275,7 +275,7 @@ func TestGetFlightInf_DataSource_Invalidate(t *testing.T) {
 		},
 		Spec: v1alpha1.DomainDataSourceSpec{
 			Name: "test-datasouce",
-			Type: "odps",
+			Type: "postgresql",
 			Data: map[string]string{
 				"encryptedInfo": strConfig,
 			},
@@ -23,7 +23,9 @@ import (
 	"github.com/apache/arrow/go/v13/arrow/ipc"
 	"github.com/google/uuid"
 	gocache "github.com/patrickmn/go-cache"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/secretflow/kuscia/pkg/common"
@@ -44,6 +46,7 @@ func NewIOServer() *IOServer {
 			common.DomainDataSourceTypeLocalFS: NewBuiltinLocalFileIOChannel(),
 			common.DomainDataSourceTypeOSS:     NewBuiltinOssIOChannel(),
 			common.DomainDataSourceTypeMysql:   NewBuiltinMySQLIOChannel(),
+			common.DomainDataSourceTypeODPS:    NewBuiltinODPSIOChannel(),
 		},
 	}
 }
@@ -113,6 +116,7 @@ func (d *IOServer) DoGet(tkt *flight.Ticket, fs flight.FlightService_DoGetServer
 			nlog.Errorf("Read domaindata failed with %s", ioReadErr.Error())
 			return status.Error(codes.Internal, fmt.Sprintf("Read domaindata failed with %s", ioReadErr.Error()))
 		}
+		setChecksumTrailer(fs, reqCtx)
 		return nil
 	}
 	nlog.Errorf("The datasource type (%s) not found in io channels ", reqCtx.DataSourceType)
@@ -153,8 +157,46 @@ func (d *IOServer) DoPut(stream flight.FlightService_DoPutServer) (err error) {
 			nlog.Errorf("Write domaindata failed with %s", ioWriteErr.Error())
 			return status.Error(codes.Internal, fmt.Sprintf("Write domaindata failed with %s", ioWriteErr.Error()))
 		}
+		setChecksumTrailer(stream, reqCtx)
 		return nil
 	}
 	nlog.Errorf("The datasource type (%s) not found in io channels ", reqCtx.DataSourceType)
 	return status.Errorf(codes.Internal, "The datasource type (%s) not found in io channels ", reqCtx.DataSourceType)
 }
+
+func (d *IOServer) Checksum(ctx context.Context, reqCtx *utils.DataMeshRequestContext) (string, error) {
+	channel, ok := d.ioChannels[reqCtx.DataSourceType]
+	if !ok {
+		return "", status.Errorf(codes.InvalidArgument, "datasource type (%s) not supported", reqCtx.DataSourceType)
+	}
+	cs, ok := channel.(DataMeshChecksumInterface)
+	if !ok {
+		return "", status.Errorf(codes.Unimplemented, "checksum of datasource type (%s) not supported", reqCtx.DataSourceType)
+	}
+	return cs.Checksum(ctx, reqCtx)
+}
+
+// RangeIO returns the io channel which accesses the files of datasource type by byte ranges.
+func (d *IOServer) RangeIO(dataSourceType string) (DataMeshRangeIOInterface, bool) {
+	channel, ok := d.ioChannels[dataSourceType]
+	if !ok {
+		return nil, false
+	}
+	rio, ok := channel.(DataMeshRangeIOInterface)
+	return rio, ok
+}
+
+// setChecksumTrailer returns the recorded checksum of domaindata to client, so that client could verify the
+// transferred raw data.
+func setChecksumTrailer(stream grpc.ServerStream, reqCtx *utils.DataMeshRequestContext) {
+	if reqCtx.SqlQuery != nil {
+		return
+	}
+	data, err := reqCtx.GetDomainData(stream.Context())
+	if err != nil {
+		return
+	}
+	if checksum := data.GetAttributes()[utils.DomainDataChecksumAttribute]; checksum != "" {
+		stream.SetTrailer(metadata.Pairs(utils.ChecksumMetadataKey, checksum))
+	}
+}
@@ -44,6 +44,36 @@ type DataMeshDataIOInterface interface {
 	GetEndpointURI() string
 }
 
+// DataMeshChecksumInterface is implemented by io channels which store domaindata as files and record their checksums.
+type DataMeshChecksumInterface interface {
+	// Checksum computes the checksum of the stored domaindata.
+	Checksum(ctx context.Context, rc *utils.DataMeshRequestContext) (string, error)
+}
+
+// DataMeshRangeIOInterface is implemented by io channels which store domaindata as files and access them by byte
+// ranges, domaindata copied between domains is transferred in resumable chunks through it.
+type DataMeshRangeIOInterface interface {
+	// Stat returns the size of the file.
+	Stat(ctx context.Context, ds *datamesh.DomainDataSource, uri string) (int64, error)
+	// ReadRange reads the file from offset, a negative length reads to the end of the file.
+	ReadRange(ctx context.Context, ds *datamesh.DomainDataSource, uri string, offset, length int64) (io.ReadCloser, error)
+	// WriteAt truncates the file to offset and appends the content of r, it returns the bytes written.
+	WriteAt(ctx context.Context, ds *datamesh.DomainDataSource, uri string, offset int64, r io.Reader) (int64, error)
+}
+
+// verifyReadChecksum checks the checksum of the data read from storage, a partially read data is not checked.
+func verifyReadChecksum(data *datamesh.DomainData, cr *utils.ChecksumReader) error {
+	checksum, ok := cr.Checksum()
+	if !ok {
+		return nil
+	}
+	if err := utils.VerifyChecksum(data, checksum); err != nil {
+		nlog.Errorf("Verify checksum failed, %s", err.Error())
+		return err
+	}
+	return nil
+}
+
 // DataFlow(Table): RemoteStorage(FileSystem/OSS/...)  --> DataProxy --> Client
 func DataProxyContentToFlightStreamCSV(data *datamesh.DomainData, r io.Reader, w utils.RecordWriter) error {
 	//generate arrow schema
@@ -16,6 +16,7 @@ package builtin
 
 import (
 	"context"
+	"io"
 	"os"
 	"path"
 
@@ -60,14 +61,19 @@ func (fio *BuiltinLocalFileIO) Read(ctx context.Context, rc *utils.DataMeshReque
 	}
 	defer file.Close()
 
+	cr := utils.NewChecksumReader(file)
 	switch rc.GetTransferContentType() {
 	case datamesh.ContentType_RAW:
-		return DataProxyContentToFlightStreamBinary(data, file, w, fio.batchReadSize)
+		err = DataProxyContentToFlightStreamBinary(data, cr, w, fio.batchReadSize)
 	case datamesh.ContentType_CSV, datamesh.ContentType_Table:
-		return DataProxyContentToFlightStreamCSV(data, file, w)
+		err = DataProxyContentToFlightStreamCSV(data, cr, w)
 	default:
 		return errors.Errorf("invalidate content-type: %s", rc.GetTransferContentType().String())
 	}
+	if err != nil {
+		return err
+	}
+	return verifyReadChecksum(data, cr)
 }
 
 // DataFlow: Client --> DataProxy --> RemoteStorage(FileSystem/OSS/...)
@@ -99,14 +105,84 @@ func (fio *BuiltinLocalFileIO) Write(ctx context.Context, rc *utils.DataMeshRequ
 	}
 	defer file.Close()
 
+	cw := utils.NewChecksumWriter(file)
 	switch rc.GetTransferContentType() {
 	case datamesh.ContentType_RAW:
-		return FlightStreamToDataProxyContentBinary(data, file, reader)
+		err = FlightStreamToDataProxyContentBinary(data, cw, reader)
 	case datamesh.ContentType_CSV, datamesh.ContentType_Table:
-		return FlightStreamToDataProxyContentCSV(data, file, reader)
+		err = FlightStreamToDataProxyContentCSV(data, cw, reader)
 	default:
 		return errors.Errorf("invalidate content-type: %s", rc.GetTransferContentType().String())
 	}
+	if err != nil {
+		return err
+	}
+	return rc.SetDomainDataChecksum(ctx, data, cw.Checksum())
+}
+
+func (fio *BuiltinLocalFileIO) Checksum(ctx context.Context, rc *utils.DataMeshRequestContext) (string, error) {
+	data, ds, err := rc.GetDomainDataAndSource(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(path.Join(ds.Info.Localfs.Path, data.RelativeUri))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return utils.ComputeChecksum(file)
+}
+
+func (fio *BuiltinLocalFileIO) Stat(ctx context.Context, ds *datamesh.DomainDataSource, uri string) (int64, error) {
+	info, err := os.Stat(path.Join(ds.Info.Localfs.Path, uri))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (fio *BuiltinLocalFileIO) ReadRange(ctx context.Context, ds *datamesh.DomainDataSource, uri string, offset, length int64) (io.ReadCloser, error) {
+	file, err := os.Open(path.Join(ds.Info.Localfs.Path, uri))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if length < 0 {
+		return file, nil
+	}
+	return &limitedReadCloser{Reader: io.LimitReader(file, length), Closer: file}, nil
+}
+
+func (fio *BuiltinLocalFileIO) WriteAt(ctx context.Context, ds *datamesh.DomainDataSource, uri string, offset int64, r io.Reader) (int64, error) {
+	filePath := path.Join(ds.Info.Localfs.Path, uri)
+	if err := paths.EnsurePath(path.Dir(filePath), true); err != nil {
+		return 0, err
+	}
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	if err := file.Truncate(offset); err != nil {
+		return 0, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(file, r)
+	if err != nil {
+		return n, err
+	}
+	return n, file.Sync()
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
 }
 
 func (fio *BuiltinLocalFileIO) GetEndpointURI() string {
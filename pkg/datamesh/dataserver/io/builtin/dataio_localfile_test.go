@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/apache/arrow/go/v13/arrow/flight"
@@ -280,6 +281,51 @@ func TestLocalFileIOChannel_Write_Success(t *testing.T) {
 	assert.NoError(t, os.Remove(filepath))
 }
 
+func TestLocalFileIOChannel_Checksum(t *testing.T) {
+	t.Parallel()
+	filename := fmt.Sprintf("localtest-%s.txt", uuid.New().String())
+
+	ctx := initLocalFileDataIOTestRequestContext(t, filename, false)
+	ctx.Update.ContentType = datamesh.ContentType_RAW
+	channel := NewBuiltinLocalFileIOChannel()
+
+	reader, err := flight.NewRecordReader(&mockDoPutServer{
+		ServerStream: &mockGrpcServerStream{},
+		nextDataList: getFlightData(t, [][]byte{[]byte("hello world!")}),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, channel.Write(context.Background(), ctx, reader))
+
+	// checksum is recorded on write
+	dd, ds, err := ctx.GetDomainDataAndSource(context.Background())
+	assert.NoError(t, err)
+	filepath := path.Join(ds.Info.Localfs.Path, dd.RelativeUri)
+	defer os.Remove(filepath)
+	expected, err := utils.ComputeChecksum(strings.NewReader("hello world!"))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, dd.Attributes[utils.DomainDataChecksumAttribute])
+	checksum, err := channel.(DataMeshChecksumInterface).Checksum(context.Background(), ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, checksum)
+
+	// checksum is verified on read
+	ctx.Query = &datamesh.CommandDomainDataQuery{
+		DomaindataId: dd.DomaindataId,
+		ContentType:  datamesh.ContentType_RAW,
+	}
+	ctx.Update = nil
+	newWriter := func() utils.RecordWriter {
+		return flight.NewRecordWriter(&mockDoGetServer{
+			ServerStream: &mockGrpcServerStream{},
+		}, ipc.WithSchema(utils.GenerateBinaryDataArrowSchema()))
+	}
+	assert.NoError(t, channel.Read(context.Background(), ctx, newWriter()))
+
+	assert.NoError(t, os.WriteFile(filepath, []byte("hello world?"), 0644))
+	err = channel.Read(context.Background(), ctx, newWriter())
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
 func TestLocalFileIOChannel_Endpoint(t *testing.T) {
 	t.Parallel()
 	channel := NewBuiltinLocalFileIOChannel()
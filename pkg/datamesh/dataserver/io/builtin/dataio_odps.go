@@ -0,0 +1,345 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/flight"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/pkg/errors"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+// partitionSpecAttribute is the attribute of domaindata which specifies the partition of table.
+const partitionSpecAttribute = "partition_spec"
+
+// BuiltinODPSIO defined the ODPS(MaxCompute) read & write method, tables are transferred by the tunnel service.
+type BuiltinODPSIO struct {
+	client *http.Client
+}
+
+func NewBuiltinODPSIOChannel() DataMeshDataIOInterface {
+	return &BuiltinODPSIO{
+		client: http.DefaultClient,
+	}
+}
+
+// odpsPartitionSpec returns the partition spec of request, or the one in domaindata attributes.
+func odpsPartitionSpec(dd *datamesh.DomainData, requestSpec string) string {
+	if requestSpec != "" {
+		return requestSpec
+	}
+	return dd.GetAttributes()[partitionSpecAttribute]
+}
+
+// DataFlow: RemoteStorage(ODPS)  --> DataProxy --> Client
+func (o *BuiltinODPSIO) Read(ctx context.Context, rc *utils.DataMeshRequestContext, w utils.RecordWriter) error {
+	dd, ds, err := rc.GetDomainDataAndSource(ctx)
+	if err != nil {
+		nlog.Errorf("Get domaindata domaindatasource failed(%s)", err)
+		return err
+	}
+	switch rc.GetTransferContentType() {
+	case datamesh.ContentType_CSV, datamesh.ContentType_Table:
+	default:
+		return errors.Errorf("Invalidate content-type: %s", rc.GetTransferContentType().String())
+	}
+
+	table, err := parseODPSTable(dd.RelativeUri, odpsPartitionSpec(dd, rc.Query.GetPartitionSpec()))
+	if err != nil {
+		return err
+	}
+	schema, err := odpsQuerySchema(dd, rc.Query.GetColumns())
+	if err != nil {
+		return err
+	}
+	client, err := NewODPSClient(ds.Info.Odps, o.client)
+	if err != nil {
+		return err
+	}
+	tunnel, err := client.tunnelEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+	session, err := client.CreateDownloadSession(ctx, tunnel, table)
+	if err != nil {
+		return err
+	}
+	nlog.Infof("DomainData(%s) read from odps table %s/%s partition(%s), records=%d", dd.DomaindataId, client.project,
+		table.name, table.partition, session.RecordCount)
+
+	columns := make([]string, 0, len(schema.Fields()))
+	for _, f := range schema.Fields() {
+		columns = append(columns, f.Name)
+	}
+	body, err := client.OpenArrowReader(ctx, tunnel, table, session, columns)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	reader, err := ipc.NewReader(body, ipc.WithAllocator(memory.DefaultAllocator))
+	if err != nil {
+		return errors.Errorf("read arrow stream of odps table %s failed, %s", table.name, err.Error())
+	}
+	defer reader.Release()
+
+	defer w.Close()
+	var count int64
+	for reader.Next() {
+		record, err := convertArrowRecord(reader.Record(), schema)
+		if err != nil {
+			nlog.Errorf("Convert domaindata(%s) odps record failed, %s", dd.DomaindataId, err.Error())
+			return err
+		}
+		err = w.Write(record)
+		count += record.NumRows()
+		record.Release()
+		if err != nil {
+			nlog.Errorf("Domaindata(%s) to flight stream failed with error %s", dd.DomaindataId, err.Error())
+			return err
+		}
+	}
+	if err := reader.Err(); err != nil && err != io.EOF {
+		return errors.Errorf("read arrow stream of odps table %s failed, %s", table.name, err.Error())
+	}
+	nlog.Infof("Domaindata(%s) send rows=%d", dd.DomaindataId, count)
+	return nil
+}
+
+// DataFlow: Client --> DataProxy --> RemoteStorage(ODPS)
+func (o *BuiltinODPSIO) Write(ctx context.Context, rc *utils.DataMeshRequestContext, stream *flight.Reader) error {
+	dd, ds, err := rc.GetDomainDataAndSource(ctx)
+	if err != nil {
+		nlog.Errorf("Get domaindata domaindatasource failed(%s)", err)
+		return err
+	}
+	switch rc.GetTransferContentType() {
+	case datamesh.ContentType_CSV, datamesh.ContentType_Table:
+	default:
+		return errors.Errorf("Invalidate content-type: %s", rc.GetTransferContentType().String())
+	}
+
+	table, err := parseODPSTable(dd.RelativeUri, odpsPartitionSpec(dd, rc.Update.GetPartitionSpec()))
+	if err != nil {
+		return err
+	}
+	client, err := NewODPSClient(ds.Info.Odps, o.client)
+	if err != nil {
+		return err
+	}
+	nlog.Infof("DomainData(%s) try save to odps table %s/%s partition(%s)", dd.DomaindataId, client.project, table.name,
+		table.partition)
+
+	tunnel, err := client.tunnelEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+	session, err := client.CreateUploadSession(ctx, tunnel, table)
+	if err != nil {
+		return err
+	}
+	schema, err := odpsTableArrowSchema(session.Schema.Columns)
+	if err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	writeErr := make(chan error, 1)
+	go func() {
+		err := writeODPSArrowStream(pw, stream, schema)
+		pw.CloseWithError(err)
+		writeErr <- err
+	}()
+	err = client.WriteArrowBlock(ctx, tunnel, table, session, 0, pr)
+	// unblock the writer if the upload failed before the stream is consumed
+	pr.CloseWithError(io.ErrClosedPipe)
+	if werr := <-writeErr; werr != nil && !errors.Is(werr, io.ErrClosedPipe) {
+		nlog.Warnf("Convert domaindata(%s) to odps table failed with %s", dd.DomaindataId, werr.Error())
+		return werr
+	}
+	if err != nil {
+		nlog.Warnf("Upload domaindata(%s) to odps failed with %s", dd.DomaindataId, err.Error())
+		return err
+	}
+	return client.CommitUpload(ctx, tunnel, table, session)
+}
+
+func (o *BuiltinODPSIO) GetEndpointURI() string {
+	return utils.BuiltinFlightServerEndpointURI
+}
+
+// writeODPSArrowStream converts the records from client to the schema of odps table and writes them as arrow stream.
+func writeODPSArrowStream(w io.Writer, stream *flight.Reader, schema *arrow.Schema) error {
+	writer := ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(memory.DefaultAllocator))
+	for stream.Next() {
+		record, err := convertArrowRecord(stream.Record(), schema)
+		if err != nil {
+			return err
+		}
+		err = writer.Write(record)
+		record.Release()
+		if err != nil {
+			return err
+		}
+	}
+	if err := stream.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return writer.Close()
+}
+
+// odpsQuerySchema returns the arrow schema of the queried columns of domaindata.
+func odpsQuerySchema(dd *datamesh.DomainData, queryColumns []string) (*arrow.Schema, error) {
+	columns := make(map[string]int, len(dd.Columns))
+	for i, column := range dd.Columns {
+		columns[column.Name] = i
+	}
+	if len(queryColumns) == 0 {
+		for _, column := range dd.Columns {
+			queryColumns = append(queryColumns, column.Name)
+		}
+	}
+	if len(queryColumns) == 0 {
+		return nil, errors.Errorf("No data column available, terminate reading")
+	}
+
+	fields := make([]arrow.Field, 0, len(queryColumns))
+	for _, name := range queryColumns {
+		idx, ok := columns[name]
+		if !ok {
+			return nil, errors.Errorf("Query column(%s) is not defined in domaindata(%s)", name, dd.DomaindataId)
+		}
+		column := dd.Columns[idx]
+		colType := common.Convert2ArrowColumnType(column.Type)
+		if colType == nil {
+			return nil, errors.Errorf("invalid column(%s) with type(%s)", column.Name, column.Type)
+		}
+		fields = append(fields, arrow.Field{Name: column.Name, Type: colType, Nullable: !column.NotNullable})
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// odpsTableArrowSchema returns the arrow schema of the columns of odps table.
+func odpsTableArrowSchema(columns []odpsColumn) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, 0, len(columns))
+	for _, column := range columns {
+		var colType arrow.DataType
+		switch t := strings.ToLower(column.Type); {
+		case t == "boolean":
+			colType = arrow.FixedWidthTypes.Boolean
+		case t == "tinyint":
+			colType = arrow.PrimitiveTypes.Int8
+		case t == "smallint":
+			colType = arrow.PrimitiveTypes.Int16
+		case t == "int":
+			colType = arrow.PrimitiveTypes.Int32
+		case t == "bigint":
+			colType = arrow.PrimitiveTypes.Int64
+		case t == "float":
+			colType = arrow.PrimitiveTypes.Float32
+		case t == "double":
+			colType = arrow.PrimitiveTypes.Float64
+		case t == "string", strings.HasPrefix(t, "varchar"), strings.HasPrefix(t, "char"):
+			colType = arrow.BinaryTypes.String
+		case t == "binary":
+			colType = arrow.BinaryTypes.Binary
+		default:
+			return nil, errors.Errorf("type(%s) of odps column(%s) is not supported now", column.Type, column.Name)
+		}
+		fields = append(fields, arrow.Field{Name: column.Name, Type: colType, Nullable: column.Nullable})
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// convertArrowRecord selects the columns of schema from record by name and converts them to the types of schema.
+// Column names of odps are case-insensitive, the columns absent in record are filled with nulls.
+func convertArrowRecord(record arrow.Record, schema *arrow.Schema) (arrow.Record, error) {
+	columns := make(map[string]int, record.NumCols())
+	for i, f := range record.Schema().Fields() {
+		columns[strings.ToLower(f.Name)] = i
+	}
+	arrays := make([]arrow.Array, 0, len(schema.Fields()))
+	defer func() {
+		for _, arr := range arrays {
+			arr.Release()
+		}
+	}()
+	for _, f := range schema.Fields() {
+		idx, ok := columns[strings.ToLower(f.Name)]
+		if !ok {
+			if !f.Nullable {
+				return nil, errors.Errorf("data column %s not allowed null value", f.Name)
+			}
+			arrays = append(arrays, array.MakeArrayOfNull(memory.DefaultAllocator, f.Type, int(record.NumRows())))
+			continue
+		}
+		arr, err := convertArrowColumn(record.Column(idx), f)
+		if err != nil {
+			return nil, err
+		}
+		arrays = append(arrays, arr)
+	}
+	return array.NewRecord(schema, arrays, record.NumRows()), nil
+}
+
+func convertArrowColumn(src arrow.Array, field arrow.Field) (arrow.Array, error) {
+	if !field.Nullable && src.NullN() > 0 {
+		return nil, errors.Errorf("data column %s not allowed null value", field.Name)
+	}
+	if arrow.TypeEqual(src.DataType(), field.Type) {
+		src.Retain()
+		return src, nil
+	}
+
+	builder := array.NewBuilder(memory.DefaultAllocator, field.Type)
+	defer builder.Release()
+	builder.Reserve(src.Len())
+	for i := 0; i < src.Len(); i++ {
+		if src.IsNull(i) {
+			builder.AppendNull()
+			continue
+		}
+		var value string
+		switch arr := src.(type) {
+		case *array.String:
+			value = arr.Value(i)
+		case *array.Binary:
+			value = string(arr.Value(i))
+		default:
+			value = arr.ValueStr(i)
+		}
+		if _, ok := builder.(*array.BinaryBuilder); ok {
+			builder.(*array.BinaryBuilder).AppendString(value)
+			continue
+		}
+		if err := builder.AppendValueFromString(value); err != nil {
+			return nil, fmt.Errorf("convert value %q of column %s to %s failed, %s", value, field.Name, field.Type, err.Error())
+		}
+	}
+	return builder.NewArray(), nil
+}
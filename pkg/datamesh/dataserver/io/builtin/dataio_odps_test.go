@@ -0,0 +1,269 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/flight"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
+	"github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+// fakeODPS serves the rest api and tunnel service of an odps project with table "t1" partitioned by "dt".
+type fakeODPS struct {
+	*httptest.Server
+	mu         sync.Mutex
+	requests   []string
+	uploaded   []arrow.Record
+	committed  bool
+	partitions []string
+}
+
+func newFakeODPS(t *testing.T) *fakeODPS {
+	f := &fakeODPS{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "ODPS ak:") {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"Code":"Unauthorized","Message":"invalid signature"}`))
+			return
+		}
+		query := r.URL.Query()
+		schema := map[string]interface{}{
+			"columns": []map[string]interface{}{
+				{"name": "id", "type": "BIGINT", "nullable": true},
+				{"name": "name", "type": "STRING", "nullable": true},
+			},
+			"partitionKeys": []map[string]interface{}{{"name": "dt", "type": "STRING"}},
+		}
+		switch {
+		case r.URL.Path == "/api/projects/p1/tunnel" && query.Has("service"):
+			w.Write([]byte(f.Listener.Addr().String()))
+		case r.URL.Path != "/projects/p1/tables/t1":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Code":"NoSuchObject","Message":"table not found"}`))
+		case r.Method == http.MethodPost && query.Has("downloads"):
+			f.partitions = append(f.partitions, query.Get("partition"))
+			json.NewEncoder(w).Encode(map[string]interface{}{"DownloadID": "d1", "RecordCount": 3, "Schema": schema, "Status": "normal"})
+		case r.Method == http.MethodGet && query.Has("data"):
+			arrowSchema := arrow.NewSchema([]arrow.Field{
+				{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+				{Name: "name", Type: arrow.BinaryTypes.Binary, Nullable: true},
+			}, nil)
+			b := array.NewRecordBuilder(memory.DefaultAllocator, arrowSchema)
+			defer b.Release()
+			b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+			b.Field(1).(*array.BinaryBuilder).AppendStringValues([]string{"alice", "bob", ""}, []bool{true, true, false})
+			writer := ipc.NewWriter(w, ipc.WithSchema(arrowSchema))
+			writer.Write(b.NewRecord())
+			writer.Close()
+		case r.Method == http.MethodPost && query.Has("uploads"):
+			f.partitions = append(f.partitions, query.Get("partition"))
+			json.NewEncoder(w).Encode(map[string]interface{}{"UploadID": "u1", "Schema": schema, "Status": "normal"})
+		case r.Method == http.MethodPut && query.Get("uploadid") == "u1":
+			reader, err := ipc.NewReader(r.Body)
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for reader.Next() {
+				rec := reader.Record()
+				rec.Retain()
+				f.uploaded = append(f.uploaded, rec)
+			}
+		case r.Method == http.MethodPost && query.Get("uploadid") == "u1":
+			f.committed = true
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func initODPSIOTestRequestContext(t *testing.T, endpoint string, isQuery bool, partitionSpec string) *utils.DataMeshRequestContext {
+	conf := initMySQLContextTestEnv(t, nil)
+	dsID := "odps-data-source"
+	info, err := json.Marshal(&datamesh.DataSourceInfo{Odps: &datamesh.OdpsDataSourceInfo{
+		Endpoint:        endpoint + "/api",
+		Project:         "p1",
+		AccessKeyId:     "ak",
+		AccessKeySecret: "sk",
+	}})
+	require.NoError(t, err)
+	encrypted, err := tls.EncryptOAEP(&conf.DomainKey.PublicKey, info)
+	require.NoError(t, err)
+	_, err = conf.KusciaClient.KusciaV1alpha1().DomainDataSources(conf.KubeNamespace).Create(context.Background(), &v1alpha1.DomainDataSource{
+		ObjectMeta: v1.ObjectMeta{Name: dsID},
+		Spec: v1alpha1.DomainDataSourceSpec{
+			Name: dsID,
+			Type: common.DomainDataSourceTypeODPS,
+			Data: map[string]string{"encryptedInfo": encrypted},
+		},
+	}, v1.CreateOptions{})
+	require.NoError(t, err)
+	registerDomainData(t, conf, &v1alpha1.DomainDataSpec{
+		RelativeURI: "t1/dt=20240101",
+		Name:        "odps-data",
+		Type:        "TABLE",
+		DataSource:  dsID,
+		Author:      conf.KubeNamespace,
+		Columns: []v1alpha1.DataColumn{
+			{Name: "name", Type: "str"},
+			{Name: "id", Type: "int32"},
+		},
+	})
+
+	var rc *utils.DataMeshRequestContext
+	if isQuery {
+		rc, err = utils.NewDataMeshRequestContext(service.NewDomainDataService(conf), service.NewDomainDataSourceService(conf, nil),
+			&datamesh.CommandDomainDataQuery{DomaindataId: "odps-data", PartitionSpec: partitionSpec}, common.DomainDataSourceTypeODPS)
+	} else {
+		rc, err = utils.NewDataMeshRequestContext(service.NewDomainDataService(conf), service.NewDomainDataSourceService(conf, nil),
+			&datamesh.CommandDomainDataUpdate{DomaindataId: "odps-data", PartitionSpec: partitionSpec}, common.DomainDataSourceTypeODPS)
+	}
+	require.NoError(t, err)
+	return rc
+}
+
+type recordCollector struct {
+	records []arrow.Record
+}
+
+func (c *recordCollector) Write(rec arrow.Record) error {
+	rec.Retain()
+	c.records = append(c.records, rec)
+	return nil
+}
+
+func (c *recordCollector) Close() error { return nil }
+
+func TestODPSIOChannel_Read(t *testing.T) {
+	t.Parallel()
+	server := newFakeODPS(t)
+	rc := initODPSIOTestRequestContext(t, server.URL, true, "dt='20240102'")
+
+	w := &recordCollector{}
+	require.NoError(t, NewBuiltinODPSIOChannel().Read(context.Background(), rc, w))
+	require.Len(t, w.records, 1)
+	rec := w.records[0]
+	assert.Equal(t, "name", rec.ColumnName(0))
+	assert.Equal(t, arrow.BinaryTypes.String, rec.Column(0).DataType())
+	assert.Equal(t, "bob", rec.Column(0).(*array.String).Value(1))
+	assert.True(t, rec.Column(0).IsNull(2))
+	assert.Equal(t, []int32{1, 2, 3}, rec.Column(1).(*array.Int32).Int32Values())
+
+	assert.Equal(t, []string{"dt=20240102"}, server.partitions)
+	assert.Contains(t, server.requests[2], "columns=name%2Cid")
+}
+
+func TestODPSIOChannel_Write(t *testing.T) {
+	t.Parallel()
+	server := newFakeODPS(t)
+	rc := initODPSIOTestRequestContext(t, server.URL, false, "")
+
+	inputs := getTableFlightData(t, rc, []arrow.DataType{arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32},
+		[][]any{{"alice", int32(1)}, {"bob", int32(2)}})
+	reader, err := flight.NewRecordReader(&mockDoPutServer{ServerStream: &mockGrpcServerStream{}, nextDataList: inputs})
+	require.NoError(t, err)
+
+	require.NoError(t, NewBuiltinODPSIOChannel().Write(context.Background(), rc, reader))
+	assert.True(t, server.committed)
+	assert.Equal(t, []string{"dt=20240101"}, server.partitions)
+	assert.Contains(t, server.requests[1], "create_partition&overwrite=true")
+	require.Len(t, server.uploaded, 1)
+	rec := server.uploaded[0]
+	assert.Equal(t, "id", rec.ColumnName(0))
+	assert.Equal(t, []int64{1, 2}, rec.Column(0).(*array.Int64).Int64Values())
+	assert.Equal(t, "alice", rec.Column(1).(*array.String).Value(0))
+}
+
+func TestODPSIOChannel_TableNotFound(t *testing.T) {
+	t.Parallel()
+	server := newFakeODPS(t)
+	client, err := NewODPSClient(&datamesh.OdpsDataSourceInfo{Endpoint: server.URL + "/api", Project: "p1",
+		AccessKeyId: "ak", AccessKeySecret: "sk"}, nil)
+	require.NoError(t, err)
+	tunnel, err := client.tunnelEndpoint(context.Background())
+	require.NoError(t, err)
+	_, err = client.CreateDownloadSession(context.Background(), tunnel, &odpsTable{name: "t2"})
+	assert.ErrorContains(t, err, "code=NoSuchObject")
+}
+
+func TestODPSClient_Sign(t *testing.T) {
+	t.Parallel()
+	client, err := NewODPSClient(&datamesh.OdpsDataSourceInfo{Endpoint: "https://service.odps.example.com/api",
+		Project: "p1", AccessKeyId: "ak", AccessKeySecret: "secret"}, nil)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "https://tunnel.example.com/projects/p1/tables/t1", nil)
+	require.NoError(t, err)
+	req.Header.Set("Date", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+	req.Header.Set(odpsHeaderTunnelVersion, odpsTunnelVersion)
+	client.sign(req, "/projects/p1/tables/t1", url.Values{"downloads": {""}, "partition": {"dt=20240101"}})
+	assert.Equal(t, "ODPS ak:xeUYPGg22AJogMmggU4T40cZ9xI=", req.Header.Get("Authorization"))
+}
+
+func TestParseODPSTable(t *testing.T) {
+	t.Parallel()
+	table, err := parseODPSTable("t1/dt='20240101'/region=cn", "")
+	require.NoError(t, err)
+	assert.Equal(t, &odpsTable{name: "t1", partition: "dt=20240101,region=cn"}, table)
+
+	table, err = parseODPSTable("t1", "dt=20240102")
+	require.NoError(t, err)
+	assert.Equal(t, "dt=20240102", table.partition)
+
+	_, err = parseODPSTable("t1;drop", "")
+	assert.Error(t, err)
+	_, err = parseODPSTable("t1", "dt")
+	assert.Error(t, err)
+}
+
+func TestConvertArrowRecord_NotNullable(t *testing.T) {
+	t.Parallel()
+	b := array.NewStringBuilder(memory.DefaultAllocator)
+	b.AppendNull()
+	arr := b.NewArray()
+	src := array.NewRecord(arrow.NewSchema([]arrow.Field{{Name: "a", Type: arrow.BinaryTypes.String, Nullable: true}}, nil),
+		[]arrow.Array{arr}, 1)
+	_, err := convertArrowRecord(src, arrow.NewSchema([]arrow.Field{{Name: "A", Type: arrow.BinaryTypes.String}}, nil))
+	assert.ErrorContains(t, err, "not allowed null value")
+	_, err = convertArrowRecord(src, arrow.NewSchema([]arrow.Field{{Name: "b", Type: arrow.PrimitiveTypes.Int64}}, nil))
+	assert.ErrorContains(t, err, "not allowed null value")
+}
@@ -85,14 +85,19 @@ func (o *BuiltinOssIO) Read(ctx context.Context, rc *utils.DataMeshRequestContex
 	}
 	defer obj.Body.Close()
 
+	cr := utils.NewChecksumReader(obj.Body)
 	switch rc.GetTransferContentType() {
 	case datamesh.ContentType_RAW:
-		return DataProxyContentToFlightStreamBinary(dd, obj.Body, w, o.batchReadSize)
+		err = DataProxyContentToFlightStreamBinary(dd, cr, w, o.batchReadSize)
 	case datamesh.ContentType_CSV, datamesh.ContentType_Table:
-		return DataProxyContentToFlightStreamCSV(dd, obj.Body, w)
+		err = DataProxyContentToFlightStreamCSV(dd, cr, w)
 	default:
 		return errors.Errorf("invalidate content-type: %s", rc.GetTransferContentType().String())
 	}
+	if err != nil {
+		return err
+	}
+	return verifyReadChecksum(dd, cr)
 }
 
 // DataFlow: Client --> DataProxy --> RemoteStorage(FileSystem/OSS/...)
@@ -122,11 +127,12 @@ func (o *BuiltinOssIO) Write(ctx context.Context, rc *utils.DataMeshRequestConte
 	exchanger := NewOSSUploader(ctx, client, ds.Info.Oss.Bucket, objectKey, 5*1024*1024)
 	defer exchanger.Close()
 
+	cw := utils.NewChecksumWriter(exchanger)
 	switch rc.GetTransferContentType() {
 	case datamesh.ContentType_RAW:
-		err = FlightStreamToDataProxyContentBinary(dd, exchanger, reader)
+		err = FlightStreamToDataProxyContentBinary(dd, cw, reader)
 	case datamesh.ContentType_CSV, datamesh.ContentType_Table:
-		err = FlightStreamToDataProxyContentCSV(dd, exchanger, reader)
+		err = FlightStreamToDataProxyContentCSV(dd, cw, reader)
 	default:
 		return errors.Errorf("invalidate content-type: %s", rc.GetTransferContentType().String())
 	}
@@ -136,10 +142,33 @@ func (o *BuiltinOssIO) Write(ctx context.Context, rc *utils.DataMeshRequestConte
 			nlog.Warnf("Upload to oss failed with %s", err.Error())
 		}
 	}
+	if err == nil {
+		err = rc.SetDomainDataChecksum(ctx, dd, cw.Checksum())
+	}
 
 	return err
 }
 
+func (o *BuiltinOssIO) Checksum(ctx context.Context, rc *utils.DataMeshRequestContext) (string, error) {
+	dd, ds, err := rc.GetDomainDataAndSource(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := o.newOssSession(ds.Info.Oss)
+	if err != nil {
+		return "", err
+	}
+	obj, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(ds.Info.Oss.Bucket),
+		Key:    aws.String(path.Join(ds.Info.Oss.Prefix, dd.RelativeUri))})
+	if err != nil {
+		return "", err
+	}
+	defer obj.Body.Close()
+	return utils.ComputeChecksum(obj.Body)
+}
+
 func (o *BuiltinOssIO) GetEndpointURI() string {
 	return utils.BuiltinFlightServerEndpointURI
 }
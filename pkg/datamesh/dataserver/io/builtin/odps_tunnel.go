@@ -0,0 +1,350 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+const (
+	odpsTunnelVersion       = "5"
+	odpsHeaderTunnelVersion = "x-odps-tunnel-version"
+	odpsHeaderPrefix        = "x-odps-"
+)
+
+var odpsNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ODPSClient accesses the tables of a MaxCompute(ODPS) project by the rest api and the tunnel service, table data is
+// transferred in arrow stream format.
+type ODPSClient struct {
+	endpoint        *url.URL
+	project         string
+	accessKeyID     string
+	accessKeySecret string
+	client          *http.Client
+	// now returns the time of Date header, replaced in tests
+	now func() time.Time
+}
+
+type odpsColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+}
+
+type odpsTableSchema struct {
+	Columns       []odpsColumn `json:"columns"`
+	PartitionKeys []odpsColumn `json:"partitionKeys"`
+}
+
+type odpsDownloadSession struct {
+	ID          string          `json:"DownloadID"`
+	RecordCount int64           `json:"RecordCount"`
+	Schema      odpsTableSchema `json:"Schema"`
+	Status      string          `json:"Status"`
+}
+
+type odpsUploadSession struct {
+	ID     string          `json:"UploadID"`
+	Schema odpsTableSchema `json:"Schema"`
+	Status string          `json:"Status"`
+}
+
+type odpsError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+// odpsTable is the table and partition of domaindata, the relative uri of domaindata is "table" or
+// "table/partition_spec".
+type odpsTable struct {
+	name      string
+	partition string
+}
+
+func NewODPSClient(config *datamesh.OdpsDataSourceInfo, client *http.Client) (*ODPSClient, error) {
+	if config == nil {
+		return nil, errors.New("odps datasource info is empty")
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, errors.Errorf("invalid odps endpoint %q", config.Endpoint)
+	}
+	if !odpsNameRegexp.MatchString(config.Project) {
+		return nil, errors.Errorf("invalid odps project %q", config.Project)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &ODPSClient{
+		endpoint:        endpoint,
+		project:         config.Project,
+		accessKeyID:     config.AccessKeyId,
+		accessKeySecret: config.AccessKeySecret,
+		client:          client,
+		now:             time.Now,
+	}, nil
+}
+
+// parseODPSTable parses the relative uri of domaindata, the partition spec overrides the one in uri if not empty.
+func parseODPSTable(uri, partitionSpec string) (*odpsTable, error) {
+	name, partition, _ := strings.Cut(strings.Trim(uri, "/"), "/")
+	if !odpsNameRegexp.MatchString(name) {
+		return nil, errors.Errorf("invalid odps table name %q", name)
+	}
+	if partitionSpec != "" {
+		partition = partitionSpec
+	}
+	partition, err := normalizeODPSPartition(partition)
+	if err != nil {
+		return nil, err
+	}
+	return &odpsTable{name: name, partition: partition}, nil
+}
+
+// normalizeODPSPartition converts the partition spec such as "dt='20240101'/region=cn" to "dt=20240101,region=cn".
+func normalizeODPSPartition(spec string) (string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return "", nil
+	}
+	parts := strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == '/' })
+	for i, part := range parts {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `'"`)
+		if !ok || !odpsNameRegexp.MatchString(key) || value == "" {
+			return "", errors.Errorf("invalid odps partition spec %q", spec)
+		}
+		parts[i] = key + "=" + value
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// encodeODPSParams encodes the query params in key order, the params without value such as "downloads" are encoded
+// without "=". The canonical resource of signature uses the unescaped params.
+func encodeODPSParams(params url.Values, escape bool) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v := params.Get(k)
+		if escape {
+			k, v = url.QueryEscape(k), url.QueryEscape(v)
+		}
+		if v == "" {
+			items = append(items, k)
+		} else {
+			items = append(items, k+"="+v)
+		}
+	}
+	return strings.Join(items, "&")
+}
+
+// sign signs the request by the access key, see the signature of MaxCompute rest api.
+func (c *ODPSClient) sign(req *http.Request, resource string, params url.Values) {
+	var headerKeys []string
+	for k := range req.Header {
+		if lk := strings.ToLower(k); strings.HasPrefix(lk, odpsHeaderPrefix) {
+			headerKeys = append(headerKeys, lk)
+		}
+	}
+	sort.Strings(headerKeys)
+
+	var msg strings.Builder
+	msg.WriteString(req.Method + "\n")
+	msg.WriteString(req.Header.Get("Content-MD5") + "\n")
+	msg.WriteString(req.Header.Get("Content-Type") + "\n")
+	msg.WriteString(req.Header.Get("Date") + "\n")
+	for _, k := range headerKeys {
+		msg.WriteString(k + ":" + req.Header.Get(k) + "\n")
+	}
+	msg.WriteString(resource)
+	if len(params) > 0 {
+		msg.WriteString("?" + encodeODPSParams(params, false))
+	}
+
+	mac := hmac.New(sha1.New, []byte(c.accessKeySecret))
+	mac.Write([]byte(msg.String()))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	req.Header.Set("Authorization", fmt.Sprintf("ODPS %s:%s", c.accessKeyID, signature))
+}
+
+// do sends the signed request to the resource of base url, the response is returned only if it succeeds.
+func (c *ODPSClient) do(ctx context.Context, method string, base *url.URL, resource string, params url.Values,
+	body io.Reader) (*http.Response, error) {
+	u := *base
+	u.Path = base.Path + resource
+	u.RawQuery = encodeODPSParams(params, true)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Date", c.now().UTC().Format(http.TimeFormat))
+	req.Header.Set(odpsHeaderTunnelVersion, odpsTunnelVersion)
+	if body == nil {
+		req.ContentLength = 0
+	}
+	c.sign(req, resource, params)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	odpsErr := &odpsError{}
+	if json.Unmarshal(content, odpsErr) == nil && odpsErr.Code != "" {
+		return nil, errors.Errorf("odps request %s %s failed, status=%d, code=%s, message=%s", method, resource,
+			resp.StatusCode, odpsErr.Code, odpsErr.Message)
+	}
+	return nil, errors.Errorf("odps request %s %s failed, status=%d, %s", method, resource, resp.StatusCode,
+		strings.TrimSpace(string(content)))
+}
+
+func (c *ODPSClient) doJSON(ctx context.Context, method string, base *url.URL, resource string, params url.Values,
+	out interface{}) error {
+	resp, err := c.do(ctx, method, base, resource, params, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// tunnelEndpoint returns the endpoint of tunnel service of the project.
+func (c *ODPSClient) tunnelEndpoint(ctx context.Context) (*url.URL, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.endpoint, "/projects/"+c.project+"/tunnel", url.Values{"service": {""}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return nil, err
+	}
+	host := strings.TrimSpace(string(content))
+	if host == "" {
+		return nil, errors.Errorf("tunnel endpoint of odps project %s is empty", c.project)
+	}
+	return &url.URL{Scheme: c.endpoint.Scheme, Host: host}, nil
+}
+
+func (c *ODPSClient) tableResource(table *odpsTable) string {
+	return "/projects/" + c.project + "/tables/" + table.name
+}
+
+func (c *ODPSClient) tableParams(table *odpsTable, params url.Values) url.Values {
+	if table.partition != "" {
+		params.Set("partition", table.partition)
+	}
+	return params
+}
+
+// CreateDownloadSession creates the session to download the table or partition.
+func (c *ODPSClient) CreateDownloadSession(ctx context.Context, tunnel *url.URL, table *odpsTable) (*odpsDownloadSession, error) {
+	session := &odpsDownloadSession{}
+	params := c.tableParams(table, url.Values{"downloads": {""}})
+	if err := c.doJSON(ctx, http.MethodPost, tunnel, c.tableResource(table), params, session); err != nil {
+		return nil, err
+	}
+	if session.ID == "" {
+		return nil, errors.Errorf("create download session of odps table %s failed, status=%s", table.name, session.Status)
+	}
+	return session, nil
+}
+
+// OpenArrowReader reads the columns of all records in the download session as an arrow stream.
+func (c *ODPSClient) OpenArrowReader(ctx context.Context, tunnel *url.URL, table *odpsTable, session *odpsDownloadSession,
+	columns []string) (io.ReadCloser, error) {
+	params := c.tableParams(table, url.Values{
+		"data":       {""},
+		"arrow":      {""},
+		"downloadid": {session.ID},
+		"rowrange":   {fmt.Sprintf("(0,%d)", session.RecordCount)},
+	})
+	if len(columns) > 0 {
+		params.Set("columns", strings.Join(columns, ","))
+	}
+	resp, err := c.do(ctx, http.MethodGet, tunnel, c.tableResource(table), params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CreateUploadSession creates the session to overwrite the table or partition, the partition is created if not exists.
+func (c *ODPSClient) CreateUploadSession(ctx context.Context, tunnel *url.URL, table *odpsTable) (*odpsUploadSession, error) {
+	session := &odpsUploadSession{}
+	params := c.tableParams(table, url.Values{"uploads": {""}, "overwrite": {"true"}})
+	if table.partition != "" {
+		params.Set("create_partition", "")
+	}
+	if err := c.doJSON(ctx, http.MethodPost, tunnel, c.tableResource(table), params, session); err != nil {
+		return nil, err
+	}
+	if session.ID == "" {
+		return nil, errors.Errorf("create upload session of odps table %s failed, status=%s", table.name, session.Status)
+	}
+	return session, nil
+}
+
+// WriteArrowBlock uploads a block of records in arrow stream format.
+func (c *ODPSClient) WriteArrowBlock(ctx context.Context, tunnel *url.URL, table *odpsTable, session *odpsUploadSession,
+	blockID int, body io.Reader) error {
+	params := c.tableParams(table, url.Values{
+		"arrow":    {""},
+		"uploadid": {session.ID},
+		"blockid":  {fmt.Sprint(blockID)},
+	})
+	resp, err := c.do(ctx, http.MethodPut, tunnel, c.tableResource(table), params, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// CommitUpload commits the uploaded blocks, the records are visible after commit.
+func (c *ODPSClient) CommitUpload(ctx context.Context, tunnel *url.URL, table *odpsTable, session *odpsUploadSession) error {
+	params := c.tableParams(table, url.Values{"uploadid": {session.ID}})
+	resp, err := c.do(ctx, http.MethodPost, tunnel, c.tableResource(table), params, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
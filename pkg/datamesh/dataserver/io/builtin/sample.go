@@ -0,0 +1,344 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/flight"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+// nullStratum is the stratum of rows whose stratification column is null.
+const nullStratum = "\x00null"
+
+// Sample reads the source domaindata twice by its io channel and writes the sampled rows to the output domaindata.
+// The first pass counts the rows of each stratum, the second pass selects exactly the planned number of rows of each
+// stratum with equal probability, so the same seed samples the same rows from the same data.
+func (d *IOServer) Sample(ctx context.Context, src, dst *utils.DataMeshRequestContext,
+	req *datamesh.SampleDomainDataRequest) (*datamesh.SampleDomainDataResult, error) {
+	reader, ok := d.ioChannels[src.DataSourceType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "datasource type (%s) not supported", src.DataSourceType)
+	}
+	writer, ok := d.ioChannels[dst.DataSourceType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "datasource type (%s) not supported", dst.DataSourceType)
+	}
+	data, err := src.GetDomainData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := utils.GenerateArrowSchema(data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generate arrow schema failed with %s", err.Error())
+	}
+	column := -1
+	if req.StratifyColumn != "" {
+		if indices := schema.FieldIndices(req.StratifyColumn); len(indices) > 0 {
+			column = indices[0]
+		} else {
+			return nil, status.Errorf(codes.InvalidArgument, "stratify column(%s) is not defined in domaindata(%s)",
+				req.StratifyColumn, data.DomaindataId)
+		}
+	}
+
+	counter := &strataCounter{column: column, counts: map[string]int64{}}
+	if err := reader.Read(ctx, src, counter); err != nil {
+		return nil, err
+	}
+	s := newStrataSampler(counter.counts, req.Fraction, req.Rows, req.Seed)
+	nlog.Infof("Sample domaindata(%s) to (%s), total rows=%d, sample rows=%d, strata=%d", data.DomaindataId,
+		dst.Update.DomaindataId, counter.total, s.planned, len(counter.counts))
+
+	pipe := newFlightDataPipe()
+	sw := &samplingWriter{
+		column:  column,
+		sampler: s,
+		writer:  flight.NewRecordWriter(pipe, ipc.WithSchema(schema), ipc.WithAllocator(memory.DefaultAllocator)),
+	}
+	readErr := make(chan error, 1)
+	go func() {
+		err := reader.Read(ctx, src, sw)
+		if err == nil {
+			err = sw.Close()
+		}
+		pipe.CloseWithError(err)
+		readErr <- err
+	}()
+
+	stream, err := flight.NewRecordReader(pipe)
+	if err == nil {
+		err = writer.Write(ctx, dst, stream)
+		stream.Release()
+	}
+	// unblock the reader if the writer exits before the stream is consumed
+	pipe.CloseReader()
+	rerr := <-readErr
+	if err != nil {
+		return nil, err
+	}
+	if rerr != nil {
+		return nil, rerr
+	}
+	if sw.sampled != s.planned {
+		return nil, status.Errorf(codes.Aborted, "domaindata(%s) changed during sampling, sampled %d rows but planned %d",
+			data.DomaindataId, sw.sampled, s.planned)
+	}
+	return &datamesh.SampleDomainDataResult{
+		DomaindataId: dst.Update.DomaindataId,
+		TotalRows:    counter.total,
+		SampledRows:  sw.sampled,
+	}, nil
+}
+
+func stratumOf(record arrow.Record, column, row int) string {
+	if column < 0 {
+		return ""
+	}
+	arr := record.Column(column)
+	if arr.IsNull(row) {
+		return nullStratum
+	}
+	return arr.ValueStr(row)
+}
+
+// strataCounter counts the rows of each stratum.
+type strataCounter struct {
+	column int
+	total  int64
+	counts map[string]int64
+}
+
+func (c *strataCounter) Write(record arrow.Record) error {
+	for i := 0; i < int(record.NumRows()); i++ {
+		c.counts[stratumOf(record, c.column, i)]++
+	}
+	c.total += record.NumRows()
+	return nil
+}
+
+func (c *strataCounter) Close() error { return nil }
+
+// strataSampler selects rows of each stratum by selection sampling, each row is selected with probability
+// (planned - selected) / (total - seen) of its stratum.
+type strataSampler struct {
+	rng     *rand.Rand
+	planned int64
+	strata  map[string]*stratum
+}
+
+type stratum struct {
+	total, planned, seen, selected int64
+}
+
+// newStrataSampler plans the sample size of each stratum in proportion to its rows, the sizes of strata are rounded
+// by the largest remainders so that they sum up to the sample size.
+func newStrataSampler(counts map[string]int64, fraction float64, rows, seed int64) *strataSampler {
+	var total int64
+	keys := make([]string, 0, len(counts))
+	for k, n := range counts {
+		keys = append(keys, k)
+		total += n
+	}
+	sort.Strings(keys)
+
+	size := rows
+	if fraction > 0 {
+		size = int64(math.Round(fraction * float64(total)))
+	}
+	if size > total {
+		size = total
+	}
+
+	s := &strataSampler{rng: rand.New(rand.NewSource(seed)), planned: size, strata: make(map[string]*stratum, len(keys))}
+	if total == 0 {
+		return s
+	}
+	remainders := make([]float64, len(keys))
+	var allocated int64
+	for i, k := range keys {
+		quota := float64(size) * float64(counts[k]) / float64(total)
+		planned := int64(math.Floor(quota))
+		remainders[i] = quota - float64(planned)
+		s.strata[k] = &stratum{total: counts[k], planned: planned}
+		allocated += planned
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for _, i := range order[:size-allocated] {
+		s.strata[keys[i]].planned++
+	}
+	return s
+}
+
+func (s *strataSampler) selects(key string) bool {
+	st, ok := s.strata[key]
+	if !ok || st.seen >= st.total {
+		return false
+	}
+	selected := s.rng.Int63n(st.total-st.seen) < st.planned-st.selected
+	st.seen++
+	if selected {
+		st.selected++
+	}
+	return selected
+}
+
+// samplingWriter writes the selected rows of records to the flight stream.
+type samplingWriter struct {
+	column  int
+	sampler *strataSampler
+	writer  *flight.Writer
+	sampled int64
+	closed  bool
+}
+
+func (w *samplingWriter) Write(record arrow.Record) error {
+	var slices []arrow.Record
+	defer func() {
+		for _, r := range slices {
+			r.Release()
+		}
+	}()
+	start := -1
+	rows := int(record.NumRows())
+	for i := 0; i <= rows; i++ {
+		if i < rows && w.sampler.selects(stratumOf(record, w.column, i)) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			slices = append(slices, record.NewSlice(int64(start), int64(i)))
+			start = -1
+		}
+	}
+	if len(slices) == 0 {
+		return nil
+	}
+
+	columns := make([]arrow.Array, record.NumCols())
+	defer func() {
+		for _, c := range columns {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+	var n int64
+	for _, r := range slices {
+		n += r.NumRows()
+	}
+	for i := range columns {
+		arrs := make([]arrow.Array, len(slices))
+		for j, r := range slices {
+			arrs[j] = r.Column(i)
+		}
+		arr, err := array.Concatenate(arrs, memory.DefaultAllocator)
+		if err != nil {
+			return err
+		}
+		columns[i] = arr
+	}
+	sampled := array.NewRecord(record.Schema(), columns, n)
+	defer sampled.Release()
+	if err := w.writer.Write(sampled); err != nil {
+		return err
+	}
+	w.sampled += n
+	return nil
+}
+
+func (w *samplingWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.writer.Close()
+}
+
+// flightDataPipe connects a flight record writer to a flight record reader in memory.
+type flightDataPipe struct {
+	data     chan *flight.FlightData
+	done     chan struct{}
+	doneOnce sync.Once
+	mu       sync.Mutex
+	err      error
+	closed   bool
+}
+
+func newFlightDataPipe() *flightDataPipe {
+	return &flightDataPipe{data: make(chan *flight.FlightData, 4), done: make(chan struct{})}
+}
+
+// Send sends a copy of data, the buffers of data are reused by the ipc writer after Send returns.
+func (p *flightDataPipe) Send(data *flight.FlightData) error {
+	select {
+	case p.data <- proto.Clone(data).(*flight.FlightData):
+		return nil
+	case <-p.done:
+		return io.ErrClosedPipe
+	}
+}
+
+func (p *flightDataPipe) Recv() (*flight.FlightData, error) {
+	data, ok := <-p.data
+	if ok {
+		return data, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	return nil, io.EOF
+}
+
+// CloseWithError closes the writing side, the reader gets err after the sent data, or io.EOF if err is nil.
+func (p *flightDataPipe) CloseWithError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	p.err = err
+	close(p.data)
+}
+
+// CloseReader stops the reading side, the following Send fails.
+func (p *flightDataPipe) CloseReader() {
+	p.doneOnce.Do(func() { close(p.done) })
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrataSampler(t *testing.T) {
+	t.Parallel()
+	// largest remainders decide the strata of the rounded rows
+	s := newStrataSampler(map[string]int64{"a": 5, "b": 3, "c": 2}, 0, 5, 1)
+	assert.Equal(t, int64(5), s.planned)
+	assert.Equal(t, int64(3), s.strata["a"].planned)
+	assert.Equal(t, int64(1), s.strata["b"].planned)
+	assert.Equal(t, int64(1), s.strata["c"].planned)
+
+	// rows more than total selects all rows
+	s = newStrataSampler(map[string]int64{"": 3}, 0, 10, 1)
+	assert.Equal(t, int64(3), s.planned)
+	for i := 0; i < 3; i++ {
+		assert.True(t, s.selects(""))
+	}
+	assert.False(t, s.selects(""))
+
+	s = newStrataSampler(map[string]int64{}, 0.5, 0, 1)
+	assert.Equal(t, int64(0), s.planned)
+}
@@ -25,7 +25,9 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/datamesh/config"
@@ -44,6 +46,9 @@ type IDataProxyClient interface {
 	GetFlightInfoDataMeshQuery(context.Context, *datamesh.CommandDataMeshQuery) (*flight.FlightInfo, error)
 	GetFlightInfoDataMeshUpdate(context.Context, *datamesh.CommandDataMeshUpdate) (*flight.FlightInfo, error)
 	GetFlightInfoDataMeshSqlQuery(context.Context, *datamesh.CommandDataMeshSqlQuery) (*flight.FlightInfo, error)
+	DoGet(context.Context, *flight.Ticket) (flight.FlightService_DoGetClient, error)
+	DoPut(context.Context) (flight.FlightService_DoPutClient, error)
+	HealthCheck(context.Context) error
 }
 
 type DataProxyConfig struct {
@@ -174,6 +179,24 @@ func (dp *DataProxyClient) GetFlightInfoDataMeshSqlQuery(ctx context.Context, qu
 	return flightInfo, nil
 }
 
+func (dp *DataProxyClient) DoGet(ctx context.Context, ticket *flight.Ticket) (flight.FlightService_DoGetClient, error) {
+	return dp.flightClient.DoGet(ctx, ticket)
+}
+
+func (dp *DataProxyClient) DoPut(ctx context.Context) (flight.FlightService_DoPutClient, error) {
+	return dp.flightClient.DoPut(ctx)
+}
+
+// HealthCheck checks the dataProxy via grpc health checking protocol, a dataProxy which does not implement
+// the protocol is considered healthy as long as it is reachable.
+func (dp *DataProxyClient) HealthCheck(ctx context.Context) error {
+	_, err := healthpb.NewHealthClient(dp.conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	return err
+}
+
 func (dp *DataProxyClient) complementFlightInfo(flightInfo *flight.FlightInfo) error {
 	if len(flightInfo.Endpoint) == 0 {
 		nlog.Errorf("FlightInfo's endpoints is nil, flightInfo detail: %+v", flightInfo)
@@ -17,10 +17,18 @@ package external
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/flight"
+	"github.com/google/uuid"
+	gocache "github.com/patrickmn/go-cache"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/secretflow/kuscia/pkg/datamesh/config"
 	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
@@ -30,8 +38,27 @@ import (
 
 var partitionSpecKey = "partition_spec"
 
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	healthCheckTimeout         = 3 * time.Second
+	proxyTicketExpiration      = 10 * time.Minute
+)
+
+var defaultPassthroughHeaders = []string{"authorization"}
+
 type IOServer struct {
 	exDpClient IDataProxyClient
+
+	mode                config.DataProxyMode
+	passthroughHeaders  []string
+	fallback            bool
+	healthCheckInterval time.Duration
+	// tickets maps the tickets issued by datamesh to the tickets of dataProxy in proxy mode
+	tickets *gocache.Cache
+
+	healthMu        sync.Mutex
+	healthy         bool
+	lastHealthCheck time.Time
 }
 
 func NewIOServer(conf *config.DataProxyConfig) *IOServer {
@@ -39,10 +66,36 @@ func NewIOServer(conf *config.DataProxyConfig) *IOServer {
 	if err != nil {
 		nlog.Fatalf("New external data proxy endpoints:%s failed, error: %s.", conf.Endpoint, err.Error())
 	}
-	return &IOServer{exDpClient: cli}
+	return newIOServer(conf, cli)
+}
+
+func newIOServer(conf *config.DataProxyConfig, cli IDataProxyClient) *IOServer {
+	d := &IOServer{
+		exDpClient:          cli,
+		mode:                config.DataProxyMode(conf.Mode),
+		passthroughHeaders:  conf.PassthroughHeaders,
+		fallback:            conf.Fallback,
+		healthCheckInterval: time.Duration(conf.HealthCheckIntervalSeconds) * time.Second,
+		tickets:             gocache.New(proxyTicketExpiration, time.Minute),
+	}
+	if len(d.passthroughHeaders) == 0 {
+		d.passthroughHeaders = defaultPassthroughHeaders
+	}
+	if d.healthCheckInterval <= 0 {
+		d.healthCheckInterval = defaultHealthCheckInterval
+	}
+	return d
 }
 
 func (d *IOServer) GetFlightInfo(ctx context.Context, reqCtx *utils.DataMeshRequestContext) (flightInfo *flight.FlightInfo, err error) {
+	flightInfo, err = d.getFlightInfo(passthroughContext(ctx, d.passthroughHeaders), reqCtx)
+	if err != nil || d.mode != config.ModeProxy {
+		return flightInfo, err
+	}
+	return d.proxyFlightInfo(flightInfo)
+}
+
+func (d *IOServer) getFlightInfo(ctx context.Context, reqCtx *utils.DataMeshRequestContext) (flightInfo *flight.FlightInfo, err error) {
 	dd, ds, err := reqCtx.GetDomainDataAndSource(ctx)
 	if err != nil {
 		nlog.Errorf("GetFlightInfo get DomainData and Source failed, error: %s.", err.Error())
@@ -82,12 +135,181 @@ func (d *IOServer) GetFlightInfo(ctx context.Context, reqCtx *utils.DataMeshRequ
 	return nil, status.Errorf(codes.InvalidArgument, "Request is not query or update.")
 }
 
+// proxyFlightInfo replaces the ticket of dataProxy with a ticket issued by datamesh, so that the following
+// DoGet/DoPut calls are sent to datamesh and forwarded to dataProxy.
+func (d *IOServer) proxyFlightInfo(flightInfo *flight.FlightInfo) (*flight.FlightInfo, error) {
+	if len(flightInfo.Endpoint) == 0 || flightInfo.Endpoint[0].Ticket == nil {
+		return nil, status.Error(codes.Internal, "FlightInfo from dataproxy has no ticket")
+	}
+	tickUUID := uuid.New().String()
+	if err := d.tickets.Add(tickUUID, flightInfo.Endpoint[0].Ticket.Ticket, proxyTicketExpiration); err != nil {
+		return nil, status.Error(codes.Internal,
+			fmt.Sprintf("cache ticket failed, please try call GetFlightInfo again. raw message=(%s)", err.Error()))
+	}
+	nlog.Infof("[DataMesh] [GetFlightInfo] proxy ticket=%s", tickUUID)
+
+	info := utils.CreateDateMeshFlightInfo([]byte(tickUUID), utils.BuiltinFlightServerEndpointURI)
+	info.Schema = flightInfo.Schema
+	return info, nil
+}
+
+// OwnsTicket returns whether the ticket is issued by the server in proxy mode.
+func (d *IOServer) OwnsTicket(ticket []byte) bool {
+	_, ok := d.tickets.Get(string(ticket))
+	return ok
+}
+
+func (d *IOServer) FallbackEnabled() bool {
+	return d.fallback
+}
+
+// Healthy returns the result of the latest health check of dataProxy, the check is performed again if it is
+// older than health check interval.
+func (d *IOServer) Healthy(ctx context.Context) bool {
+	d.healthMu.Lock()
+	defer d.healthMu.Unlock()
+	if !d.lastHealthCheck.IsZero() && time.Since(d.lastHealthCheck) < d.healthCheckInterval {
+		return d.healthy
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	err := d.exDpClient.HealthCheck(checkCtx)
+	if err != nil {
+		nlog.Warnf("Health check of external dataproxy failed, error: %s", err.Error())
+	}
+	d.healthy = err == nil
+	d.lastHealthCheck = time.Now()
+	return d.healthy
+}
+
 func (d *IOServer) DoGet(tkt *flight.Ticket, fs flight.FlightService_DoGetServer) (err error) {
-	// no need implement
-	return errors.New("external DoGet not implement")
+	if d.mode != config.ModeProxy {
+		// no need implement
+		return errors.New("external DoGet not implement")
+	}
+	ticket, ok := d.tickets.Get(string(tkt.GetTicket()))
+	if !ok {
+		nlog.Warnf("[DataMesh] [DoGet] invalidate input proxy ticket=%s", string(tkt.GetTicket()))
+		return status.Errorf(codes.InvalidArgument, "invalid ticket:%s", string(tkt.GetTicket()))
+	}
+	nlog.Infof("[DataMesh] [DoGet] proxy ticket=%s", string(tkt.GetTicket()))
+
+	client, err := d.exDpClient.DoGet(passthroughContext(fs.Context(), d.passthroughHeaders), &flight.Ticket{Ticket: ticket.([]byte)})
+	if err != nil {
+		nlog.Errorf("DoGet from dataproxy failed, error: %s", err.Error())
+		return err
+	}
+	for {
+		data, err := client.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			nlog.Errorf("Receive data from dataproxy failed, error: %s", err.Error())
+			return err
+		}
+		if err := fs.Send(data); err != nil {
+			return err
+		}
+	}
 }
 
 func (d *IOServer) DoPut(stream flight.FlightService_DoPutServer) (err error) {
-	// no need implement
-	return errors.New("external DoPut not implement")
+	if d.mode != config.ModeProxy {
+		// no need implement
+		return errors.New("external DoPut not implement")
+	}
+	data, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if data.FlightDescriptor == nil {
+		nlog.Warnf("[DataMesh] [DoPut] not found Descriptor")
+		return status.Error(codes.InvalidArgument, "not found Descriptor")
+	}
+	ticketID := string(data.FlightDescriptor.Cmd)
+	ticket, ok := d.tickets.Get(ticketID)
+	if !ok {
+		nlog.Warnf("[DataMesh] [DoPut] invalidate input proxy ticket=%s", ticketID)
+		return status.Errorf(codes.InvalidArgument, "invalid ticket:%s", ticketID)
+	}
+	nlog.Infof("[DataMesh] [DoPut] proxy ticket=%s", ticketID)
+
+	ctx, cancel := context.WithCancel(passthroughContext(stream.Context(), d.passthroughHeaders))
+	defer cancel()
+	client, err := d.exDpClient.DoPut(ctx)
+	if err != nil {
+		nlog.Errorf("DoPut to dataproxy failed, error: %s", err.Error())
+		return err
+	}
+
+	desc := proto.Clone(data.FlightDescriptor).(*flight.FlightDescriptor)
+	desc.Cmd = ticket.([]byte)
+	data.FlightDescriptor = desc
+
+	// forward put results of dataProxy to client
+	resultCh := make(chan error, 1)
+	go func() {
+		for {
+			result, err := client.Recv()
+			if err == io.EOF {
+				resultCh <- nil
+				return
+			}
+			if err != nil {
+				resultCh <- err
+				return
+			}
+			if err := stream.Send(result); err != nil {
+				resultCh <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		if err := client.Send(data); err != nil {
+			if err == io.EOF {
+				// the real error is returned by Recv
+				return <-resultCh
+			}
+			return err
+		}
+		if data, err = stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	if err := client.CloseSend(); err != nil {
+		return err
+	}
+	return <-resultCh
+}
+
+func (d *IOServer) Checksum(ctx context.Context, reqCtx *utils.DataMeshRequestContext) (string, error) {
+	return "", status.Error(codes.Unimplemented, "checksum of external dataproxy not supported")
+}
+
+func (d *IOServer) Sample(ctx context.Context, src, dst *utils.DataMeshRequestContext, req *datamesh.SampleDomainDataRequest) (*datamesh.SampleDomainDataResult, error) {
+	return nil, status.Error(codes.Unimplemented, "sampling of external dataproxy not supported")
+}
+
+// passthroughContext forwards the headers of incoming request to dataProxy.
+func passthroughContext(ctx context.Context, headers []string) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	var kv []string
+	for _, header := range headers {
+		for _, value := range md.Get(header) {
+			kv = append(kv, header, value)
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
 }
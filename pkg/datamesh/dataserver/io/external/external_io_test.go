@@ -19,11 +19,16 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
+	"io"
 	"testing"
 
 	"github.com/apache/arrow/go/v13/arrow/flight"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -197,3 +202,140 @@ func TestGetFlightInfoWithSql(t *testing.T) {
 	err = ioServer.DoPut(nil)
 	assert.NotNil(t, err)
 }
+
+type mockProxyFlightServer struct {
+	MockFlightServer
+	putCmd        []byte
+	authorization []string
+}
+
+func (m *mockProxyFlightServer) DoGet(tkt *flight.Ticket, fs flight.FlightService_DoGetServer) error {
+	md, _ := metadata.FromIncomingContext(fs.Context())
+	m.authorization = md.Get("authorization")
+	return fs.Send(&flight.FlightData{DataBody: tkt.Ticket})
+}
+
+func (m *mockProxyFlightServer) DoPut(stream flight.FlightService_DoPutServer) error {
+	data, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	m.putCmd = data.FlightDescriptor.Cmd
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	return stream.Send(&flight.PutResult{AppMetadata: []byte("done")})
+}
+
+type mockDoGetServer struct {
+	grpc.ServerStream
+	ctx      context.Context
+	dataList []*flight.FlightData
+}
+
+func (m *mockDoGetServer) Context() context.Context { return m.ctx }
+
+func (m *mockDoGetServer) Send(data *flight.FlightData) error {
+	m.dataList = append(m.dataList, data)
+	return nil
+}
+
+type mockDoPutServer struct {
+	grpc.ServerStream
+	dataList []*flight.FlightData
+	results  []*flight.PutResult
+}
+
+func (m *mockDoPutServer) Context() context.Context { return context.Background() }
+
+func (m *mockDoPutServer) Recv() (*flight.FlightData, error) {
+	if len(m.dataList) == 0 {
+		return nil, io.EOF
+	}
+	data := m.dataList[0]
+	m.dataList = m.dataList[1:]
+	return data, nil
+}
+
+func (m *mockDoPutServer) Send(result *flight.PutResult) error {
+	m.results = append(m.results, result)
+	return nil
+}
+
+func TestProxyMode(t *testing.T) {
+	server := flight.NewServerWithMiddleware(nil)
+	server.Init("localhost:0")
+	srv := &mockProxyFlightServer{}
+	server.RegisterFlightService(srv)
+
+	go server.Serve()
+	defer server.Shutdown()
+
+	conf := config.DataProxyConfig{
+		Endpoint:        server.Addr().String(),
+		DataSourceTypes: []string{"localfs"},
+		Mode:            string(config.ModeProxy),
+	}
+	ioServer := NewIOServer(&conf)
+
+	confi := initContextTestEnv(t)
+	domainDataService := service.NewDomainDataService(confi)
+	datasourceService := service.NewDomainDataSourceService(confi, nil)
+	registLocalFileDomainDataSource(t, confi, common.DefaultDataSourceID)
+	domainDataID := registDomainData(t, confi, common.DefaultDataSourceID, "filename")
+
+	reqCtx, err := utils.NewDataMeshRequestContext(domainDataService, datasourceService, &datamesh.CommandDomainDataQuery{
+		DomaindataId: domainDataID,
+	})
+	assert.NoError(t, err)
+
+	// ticket of dataproxy is replaced with a ticket issued by datamesh
+	fl, err := ioServer.GetFlightInfo(context.Background(), reqCtx)
+	assert.NoError(t, err)
+	ticket := fl.Endpoint[0].Ticket
+	assert.NotEqual(t, "test-ticket", string(ticket.Ticket))
+	assert.Equal(t, utils.BuiltinFlightServerEndpointURI, fl.Endpoint[0].Location[0].Uri)
+	assert.True(t, ioServer.OwnsTicket(ticket.Ticket))
+	assert.False(t, ioServer.OwnsTicket([]byte("test-ticket")))
+
+	// DoGet is forwarded with the credential of client
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc", "x-other", "1"))
+	getServer := &mockDoGetServer{ctx: ctx}
+	assert.NoError(t, ioServer.DoGet(ticket, getServer))
+	assert.Equal(t, 1, len(getServer.dataList))
+	assert.Equal(t, "test-ticket", string(getServer.dataList[0].DataBody))
+	assert.Equal(t, []string{"Bearer abc"}, srv.authorization)
+
+	// DoPut is forwarded with the ticket of dataproxy
+	putServer := &mockDoPutServer{dataList: []*flight.FlightData{
+		{FlightDescriptor: &flight.FlightDescriptor{Type: flight.DescriptorCMD, Cmd: ticket.Ticket}},
+		{DataBody: []byte("data")},
+	}}
+	assert.NoError(t, ioServer.DoPut(putServer))
+	assert.Equal(t, "test-ticket", string(srv.putCmd))
+	assert.Equal(t, 1, len(putServer.results))
+
+	err = ioServer.DoGet(&flight.Ticket{Ticket: []byte("unknown")}, getServer)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHealthy(t *testing.T) {
+	server := flight.NewServerWithMiddleware(nil)
+	server.Init("localhost:0")
+	server.RegisterFlightService(&MockFlightServer{})
+
+	go server.Serve()
+	defer server.Shutdown()
+
+	ioServer := NewIOServer(&config.DataProxyConfig{Endpoint: server.Addr().String(), Fallback: true})
+	assert.True(t, ioServer.Healthy(context.Background()))
+	assert.True(t, ioServer.FallbackEnabled())
+
+	ioServer = NewIOServer(&config.DataProxyConfig{Endpoint: "127.0.0.1:1"})
+	assert.False(t, ioServer.Healthy(context.Background()))
+	assert.False(t, ioServer.FallbackEnabled())
+}
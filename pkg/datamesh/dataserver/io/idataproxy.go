@@ -23,15 +23,31 @@ import (
 	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/io/builtin"
 	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/io/external"
 	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
 )
 
 type Server interface {
 	GetFlightInfo(ctx context.Context, reqCtx *utils.DataMeshRequestContext) (flightInfo *flight.FlightInfo, err error)
 	DoGet(tkt *flight.Ticket, fs flight.FlightService_DoGetServer) (err error)
 	DoPut(stream flight.FlightService_DoPutServer) (err error)
+	// Checksum computes the checksum of the stored domaindata.
+	Checksum(ctx context.Context, reqCtx *utils.DataMeshRequestContext) (string, error)
+	// Sample writes the sampled rows of the source domaindata to the output domaindata.
+	Sample(ctx context.Context, src, dst *utils.DataMeshRequestContext, req *datamesh.SampleDomainDataRequest) (*datamesh.SampleDomainDataResult, error)
 }
 
-func NewExternalIO(conf *config.DataProxyConfig) Server {
+// ExternalServer is a Server backed by an external dataProxy.
+type ExternalServer interface {
+	Server
+	// OwnsTicket returns whether the ticket is issued by the server, which happens in proxy mode.
+	OwnsTicket(ticket []byte) bool
+	// Healthy returns whether the external dataProxy is healthy.
+	Healthy(ctx context.Context) bool
+	// FallbackEnabled returns whether calls can fall back to the builtin dataProxy.
+	FallbackEnabled() bool
+}
+
+func NewExternalIO(conf *config.DataProxyConfig) ExternalServer {
 	return external.NewIOServer(conf)
 }
 
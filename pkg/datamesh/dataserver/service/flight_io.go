@@ -28,6 +28,8 @@ import (
 	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
 	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	webutils "github.com/secretflow/kuscia/pkg/web/utils"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
 )
 
 type FlightIO struct {
@@ -35,6 +37,9 @@ type FlightIO struct {
 	ds    service.IDomainDataSourceService
 	ioMap map[string]io.Server
 	inIO  io.Server
+	exIOs []io.ExternalServer
+	// builtinTypes are the datasource types supported by builtin dataproxy
+	builtinTypes map[string]bool
 }
 
 func NewFlightIO(dd service.IDomainDataService, ds service.IDomainDataSourceService, configs []config.DataProxyConfig) *FlightIO {
@@ -46,11 +51,17 @@ func NewFlightIO(dd service.IDomainDataService, ds service.IDomainDataSourceServ
 			common.DomainDataSourceTypeLocalFS: inIO,
 			common.DomainDataSourceTypeOSS:     inIO,
 			common.DomainDataSourceTypeMysql:   inIO,
+			common.DomainDataSourceTypeODPS:    inIO,
 		},
-		inIO: inIO,
+		inIO:         inIO,
+		builtinTypes: map[string]bool{},
+	}
+	for typ := range fs.ioMap {
+		fs.builtinTypes[typ] = true
 	}
 	for _, conf := range configs {
 		exDp := io.NewExternalIO(&conf)
+		fs.exIOs = append(fs.exIOs, exDp)
 		for _, typ := range conf.DataSourceTypes {
 			nlog.Infof("External dataproxy type[%s] mode(%s) %s", typ, conf.Mode, conf.Endpoint)
 			fs.ioMap[typ] = exDp
@@ -66,16 +77,116 @@ func (dp *FlightIO) GetFlightInfo(ctx context.Context, msg proto.Message) (fligh
 		return nil, err
 	}
 
-	if dpX, ok := dp.ioMap[reqCtx.DataSourceType]; ok {
+	dpX, ok := dp.ioMap[reqCtx.DataSourceType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "datasource type (%s) without data proxy", reqCtx.DataSourceType)
+	}
+	exDp, ok := dpX.(io.ExternalServer)
+	if !ok || !exDp.FallbackEnabled() || !dp.builtinTypes[reqCtx.DataSourceType] {
 		return dpX.GetFlightInfo(ctx, reqCtx)
 	}
-	return nil, status.Errorf(codes.InvalidArgument, "datasource type (%s) without data proxy", reqCtx.DataSourceType)
+
+	// fall back to builtin dataproxy when external dataproxy is unhealthy or unavailable
+	if !exDp.Healthy(ctx) {
+		nlog.Warnf("External dataproxy of type[%s] is unhealthy, fall back to builtin dataproxy", reqCtx.DataSourceType)
+		return dp.inIO.GetFlightInfo(ctx, reqCtx)
+	}
+	flightInfo, err = exDp.GetFlightInfo(ctx, reqCtx)
+	if code := status.Code(err); code == codes.Unavailable || code == codes.DeadlineExceeded {
+		nlog.Warnf("External dataproxy of type[%s] is unavailable, fall back to builtin dataproxy, error: %s", reqCtx.DataSourceType, err.Error())
+		return dp.inIO.GetFlightInfo(ctx, reqCtx)
+	}
+	return flightInfo, err
 }
 
 func (dp *FlightIO) DoGet(tkt *flight.Ticket, fs flight.FlightService_DoGetServer) (err error) {
+	for _, exDp := range dp.exIOs {
+		if exDp.OwnsTicket(tkt.GetTicket()) {
+			return exDp.DoGet(tkt, fs)
+		}
+	}
 	return dp.inIO.DoGet(tkt, fs)
 }
 
 func (dp *FlightIO) DoPut(stream flight.FlightService_DoPutServer) (err error) {
-	return dp.inIO.DoPut(stream)
+	if len(dp.exIOs) == 0 {
+		return dp.inIO.DoPut(stream)
+	}
+
+	// peek the descriptor to find out which server issued the ticket
+	data, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	peeked := &peekedPutStream{FlightService_DoPutServer: stream, first: data}
+	if data.FlightDescriptor != nil {
+		for _, exDp := range dp.exIOs {
+			if exDp.OwnsTicket(data.FlightDescriptor.Cmd) {
+				return exDp.DoPut(peeked)
+			}
+		}
+	}
+	return dp.inIO.DoPut(peeked)
+}
+
+// VerifyDomainData re-computes the checksum of stored domaindata and compares it with the recorded one.
+func (dp *FlightIO) VerifyDomainData(ctx context.Context, domainDataID string) (*datamesh.VerifyDomainDataResult, error) {
+	reqCtx, err := utils.NewDataMeshRequestContext(dp.dd, dp.ds, &datamesh.CommandDomainDataQuery{
+		DomaindataId: domainDataID,
+		ContentType:  datamesh.ContentType_RAW,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := reqCtx.GetDomainData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dpX, ok := dp.ioMap[reqCtx.DataSourceType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "datasource type (%s) without data proxy", reqCtx.DataSourceType)
+	}
+	checksum, err := dpX.Checksum(ctx, reqCtx)
+	if err != nil {
+		nlog.Warnf("Compute checksum of domaindata(%s) failed, %s", domainDataID, err.Error())
+		return nil, err
+	}
+	expected := data.GetAttributes()[utils.DomainDataChecksumAttribute]
+	return &datamesh.VerifyDomainDataResult{
+		ExpectedChecksum: expected,
+		ActualChecksum:   checksum,
+		Verified:         expected != "" && expected == checksum,
+	}, nil
+}
+
+func (dp *FlightIO) DoActionVerifyDomainDataRequest(ctx context.Context, body []byte) (*flight.Result, error) {
+	request := &datamesh.VerifyDomainDataRequest{}
+	if err := proto.Unmarshal(body, request); err != nil {
+		return nil, err
+	}
+
+	result, err := dp.VerifyDomainData(ctx, request.DomaindataId)
+	if err != nil {
+		return nil, err
+	}
+	return utils.PackActionResult(&datamesh.VerifyDomainDataResponse{
+		Status: webutils.BuildSuccessResponseStatus(),
+		Data:   result,
+	})
+}
+
+// peekedPutStream returns the peeked data before reading the rest of the stream.
+type peekedPutStream struct {
+	flight.FlightService_DoPutServer
+	first *flight.FlightData
+}
+
+func (s *peekedPutStream) Recv() (*flight.FlightData, error) {
+	if s.first != nil {
+		data := s.first
+		s.first = nil
+		return data, nil
+	}
+	return s.FlightService_DoPutServer.Recv()
 }
@@ -215,6 +215,67 @@ func TestGetFlightInfo(t *testing.T) {
 	assert.NotNil(t, fl)
 }
 
+func TestGetFlightInfo_Fallback(t *testing.T) {
+	t.Parallel()
+	conf := initContextTestEnv(t)
+	domainDataService := service.NewDomainDataService(conf)
+	datasourceService := service.NewDomainDataSourceService(conf, nil)
+
+	// the unreachable external dataproxy claims localfs which is supported by builtin dataproxy
+	fs := NewFlightIO(domainDataService, datasourceService, []config.DataProxyConfig{{
+		Endpoint:        "127.0.0.1:1",
+		DataSourceTypes: []string{common.DomainDataSourceTypeLocalFS},
+		Mode:            string(config.ModeProxy),
+		Fallback:        true,
+	}})
+
+	registLocalFileDomainDataSource(t, conf, common.DefaultDataSourceID)
+	domainDataID := registDomainData(t, conf, common.DefaultDataSourceID, "filename")
+	fl, err := fs.GetFlightInfo(context.Background(), &datamesh.CommandDomainDataQuery{
+		DomaindataId: domainDataID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, utils.BuiltinFlightServerEndpointURI, fl.Endpoint[0].Location[0].Uri)
+	assert.False(t, fs.exIOs[0].OwnsTicket(fl.Endpoint[0].Ticket.Ticket))
+}
+
+func TestVerifyDomainData(t *testing.T) {
+	t.Parallel()
+	conf := initContextTestEnv(t)
+	domainDataService := service.NewDomainDataService(conf)
+	datasourceService := service.NewDomainDataSourceService(conf, nil)
+	fs := NewFlightIO(domainDataService, datasourceService, nil)
+
+	registLocalFileDomainDataSource(t, conf, common.DefaultDataSourceID)
+	fileName := "TestVerifyDomainData.file"
+	domainDataID := registDomainData(t, conf, common.DefaultDataSourceID, fileName)
+	filepath := path.Join(defaultLocalFSPath, fileName)
+	assert.NoError(t, os.WriteFile(filepath, []byte("hello world!"), 0644))
+	defer os.Remove(filepath)
+
+	// no checksum recorded
+	result, err := fs.VerifyDomainData(context.Background(), domainDataID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", result.ExpectedChecksum)
+	assert.False(t, result.Verified)
+
+	resp := domainDataService.UpdateDomainData(context.Background(), &datamesh.UpdateDomainDataRequest{
+		DomaindataId: domainDataID,
+		Attributes:   map[string]string{utils.DomainDataChecksumAttribute: result.ActualChecksum},
+	})
+	assert.Equal(t, int32(0), resp.Status.Code)
+	result, err = fs.VerifyDomainData(context.Background(), domainDataID)
+	assert.NoError(t, err)
+	assert.True(t, result.Verified)
+
+	// data is corrupted
+	assert.NoError(t, os.WriteFile(filepath, []byte("hello world?"), 0644))
+	result, err = fs.VerifyDomainData(context.Background(), domainDataID)
+	assert.NoError(t, err)
+	assert.False(t, result.Verified)
+	assert.NotEqual(t, result.ExpectedChecksum, result.ActualChecksum)
+}
+
 func TestFlightDoGet_Success(t *testing.T) {
 	t.Parallel()
 	// Construct Flight Service
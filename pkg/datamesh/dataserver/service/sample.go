@@ -0,0 +1,167 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow/flight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/datamesh/dataserver/utils"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	webutils "github.com/secretflow/kuscia/pkg/web/utils"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+const (
+	// LineageSampledFromAttribute is the attribute of the sampled domaindata which records the source domaindata.
+	LineageSampledFromAttribute = "sampled-from-domaindata"
+	// SampleSpecAttribute is the attribute of the sampled domaindata which records how it is sampled.
+	SampleSpecAttribute = "sample-spec"
+)
+
+func validateSampleRequest(req *datamesh.SampleDomainDataRequest) error {
+	if req.DomaindataId == "" {
+		return status.Error(codes.InvalidArgument, "domaindata_id is required")
+	}
+	if (req.Fraction != 0) == (req.Rows != 0) {
+		return status.Error(codes.InvalidArgument, "one of fraction and rows must be set")
+	}
+	if req.Fraction < 0 || req.Fraction > 1 {
+		return status.Errorf(codes.InvalidArgument, "fraction %v must be in (0, 1]", req.Fraction)
+	}
+	if req.Rows < 0 {
+		return status.Errorf(codes.InvalidArgument, "rows %d must be positive", req.Rows)
+	}
+	if req.OutputRelativeUri == "" {
+		return status.Error(codes.InvalidArgument, "output_relative_uri is required")
+	}
+	return nil
+}
+
+func sampleSpec(req *datamesh.SampleDomainDataRequest) string {
+	spec := fmt.Sprintf("rows=%d,seed=%d", req.Rows, req.Seed)
+	if req.Fraction > 0 {
+		spec = fmt.Sprintf("fraction=%v,seed=%d", req.Fraction, req.Seed)
+	}
+	if req.StratifyColumn != "" {
+		spec += ",stratify=" + req.StratifyColumn
+	}
+	return spec
+}
+
+// SampleDomainData samples the rows of a table domaindata into a new domaindata, which records the source domaindata
+// and the sample spec as lineage. The output domaindata is deleted if sampling fails.
+func (dp *FlightIO) SampleDomainData(ctx context.Context, req *datamesh.SampleDomainDataRequest) (*datamesh.SampleDomainDataResult, error) {
+	if err := validateSampleRequest(req); err != nil {
+		return nil, err
+	}
+	src, err := utils.NewDataMeshRequestContext(dp.dd, dp.ds, &datamesh.CommandDomainDataQuery{
+		DomaindataId: req.DomaindataId,
+		ContentType:  datamesh.ContentType_Table,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := src.GetDomainData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(data.Type, "table") {
+		return nil, status.Errorf(codes.InvalidArgument, "domaindata(%s) of type %s can't be sampled, only table is supported",
+			data.DomaindataId, data.Type)
+	}
+
+	datasourceID := req.OutputDatasourceId
+	if datasourceID == "" {
+		datasourceID = data.DatasourceId
+	}
+	name := req.OutputName
+	if name == "" {
+		name = data.Name + "-sample"
+	}
+	resp := dp.dd.CreateDomainData(ctx, &datamesh.CreateDomainDataRequest{
+		DomaindataId: req.OutputDomaindataId,
+		Name:         name,
+		Type:         data.Type,
+		RelativeUri:  req.OutputRelativeUri,
+		DatasourceId: datasourceID,
+		Attributes: map[string]string{
+			LineageSampledFromAttribute: data.DomaindataId,
+			SampleSpecAttribute:         sampleSpec(req),
+		},
+		Columns:    data.Columns,
+		Vendor:     data.Vendor,
+		FileFormat: data.FileFormat,
+	})
+	if resp == nil || resp.GetStatus() == nil || resp.GetStatus().GetCode() != 0 {
+		return nil, common.BuildGrpcErrorf(resp.GetStatus(), codes.Internal, "create sampled domaindata of (%s) fail", data.DomaindataId)
+	}
+	outputID := resp.GetData().GetDomaindataId()
+
+	result, err := dp.sample(ctx, src, outputID, req)
+	if err != nil {
+		nlog.Warnf("Sample domaindata(%s) to (%s) failed, %s", data.DomaindataId, outputID, err.Error())
+		if resp := dp.dd.DeleteDomainData(ctx, &datamesh.DeleteDomainDataRequest{DomaindataId: outputID}); resp.GetStatus().GetCode() != 0 {
+			nlog.Warnf("Delete sampled domaindata(%s) failed, %s", outputID, resp.GetStatus().GetMessage())
+		}
+		return nil, err
+	}
+	nlog.Infof("Sample domaindata(%s) to (%s) succeed, %d of %d rows", data.DomaindataId, outputID, result.SampledRows,
+		result.TotalRows)
+	return result, nil
+}
+
+func (dp *FlightIO) sample(ctx context.Context, src *utils.DataMeshRequestContext, outputID string,
+	req *datamesh.SampleDomainDataRequest) (*datamesh.SampleDomainDataResult, error) {
+	dst, err := utils.NewDataMeshRequestContext(dp.dd, dp.ds, &datamesh.CommandDomainDataUpdate{
+		DomaindataId: outputID,
+		ContentType:  datamesh.ContentType_Table,
+	})
+	if err != nil {
+		return nil, err
+	}
+	srcIO, ok := dp.ioMap[src.DataSourceType]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "datasource type (%s) without data proxy", src.DataSourceType)
+	}
+	if dstIO, ok := dp.ioMap[dst.DataSourceType]; !ok || dstIO != srcIO {
+		return nil, status.Errorf(codes.Unimplemented, "sampling from datasource type (%s) to (%s) not supported",
+			src.DataSourceType, dst.DataSourceType)
+	}
+	return srcIO.Sample(ctx, src, dst, req)
+}
+
+func (dp *FlightIO) DoActionSampleDomainDataRequest(ctx context.Context, body []byte) (*flight.Result, error) {
+	request := &datamesh.SampleDomainDataRequest{}
+	if err := proto.Unmarshal(body, request); err != nil {
+		return nil, err
+	}
+
+	result, err := dp.SampleDomainData(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return utils.PackActionResult(&datamesh.SampleDomainDataResponse{
+		Status: webutils.BuildSuccessResponseStatus(),
+		Data:   result,
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+func TestSampleDomainData(t *testing.T) {
+	t.Parallel()
+	conf := initContextTestEnv(t)
+	domainDataService := service.NewDomainDataService(conf)
+	datasourceService := service.NewDomainDataSourceService(conf, nil)
+	fs := NewFlightIO(domainDataService, datasourceService, nil)
+	registLocalFileDomainDataSource(t, conf, common.DefaultDataSourceID)
+
+	// 80 rows of label a and 20 rows of label b
+	prefix := "sample-" + uuid.NewString()
+	content := strings.Builder{}
+	content.WriteString("id,label\n")
+	for i := 0; i < 100; i++ {
+		label := "a"
+		if i%5 == 0 {
+			label = "b"
+		}
+		content.WriteString(fmt.Sprintf("%d,%s\n", i, label))
+	}
+	require.NoError(t, os.WriteFile(path.Join(defaultLocalFSPath, prefix+".csv"), []byte(content.String()), 0644))
+	resp := domainDataService.CreateDomainData(context.Background(), &datamesh.CreateDomainDataRequest{
+		DomaindataId: prefix,
+		Name:         "train",
+		Type:         "table",
+		RelativeUri:  prefix + ".csv",
+		DatasourceId: common.DefaultDataSourceID,
+		Columns: []*v1alpha1.DataColumn{
+			{Name: "id", Type: "int64"},
+			{Name: "label", Type: "str"},
+		},
+	})
+	require.Equal(t, int32(0), resp.Status.Code, resp.Status.Message)
+	t.Cleanup(func() {
+		files, _ := os.ReadDir(defaultLocalFSPath)
+		for _, f := range files {
+			if strings.HasPrefix(f.Name(), prefix) {
+				os.Remove(path.Join(defaultLocalFSPath, f.Name()))
+			}
+		}
+	})
+
+	readRows := func(uri string) []string {
+		content, err := os.ReadFile(path.Join(defaultLocalFSPath, uri))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		return lines[1:]
+	}
+
+	// stratified sample keeps the proportion of labels
+	result, err := fs.SampleDomainData(context.Background(), &datamesh.SampleDomainDataRequest{
+		DomaindataId:      prefix,
+		Fraction:          0.1,
+		Seed:              7,
+		StratifyColumn:    "label",
+		OutputRelativeUri: prefix + "-s1.csv",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), result.TotalRows)
+	assert.Equal(t, int64(10), result.SampledRows)
+	rows := readRows(prefix + "-s1.csv")
+	assert.Len(t, rows, 10)
+	labels := map[string]int{}
+	for _, row := range rows {
+		labels[strings.Split(row, ",")[1]]++
+	}
+	assert.Equal(t, map[string]int{"a": 8, "b": 2}, labels)
+
+	output := domainDataService.QueryDomainData(context.Background(), &datamesh.QueryDomainDataRequest{DomaindataId: result.DomaindataId})
+	require.Equal(t, int32(0), output.Status.Code)
+	assert.Equal(t, prefix, output.Data.Attributes[LineageSampledFromAttribute])
+	assert.Equal(t, "fraction=0.1,seed=7,stratify=label", output.Data.Attributes[SampleSpecAttribute])
+	assert.Equal(t, "train-sample", output.Data.Name)
+
+	// the same seed samples the same rows
+	result, err = fs.SampleDomainData(context.Background(), &datamesh.SampleDomainDataRequest{
+		DomaindataId:       prefix,
+		Fraction:           0.1,
+		Seed:               7,
+		StratifyColumn:     "label",
+		OutputDomaindataId: prefix + "-s2",
+		OutputRelativeUri:  prefix + "-s2.csv",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prefix+"-s2", result.DomaindataId)
+	assert.Equal(t, rows, readRows(prefix+"-s2.csv"))
+
+	// sample n rows
+	result, err = fs.SampleDomainData(context.Background(), &datamesh.SampleDomainDataRequest{
+		DomaindataId:      prefix,
+		Rows:              5,
+		Seed:              1,
+		OutputRelativeUri: prefix + "-s3.csv",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), result.SampledRows)
+	assert.Len(t, readRows(prefix+"-s3.csv"), 5)
+
+	// the output domaindata is deleted if sampling fails
+	_, err = fs.SampleDomainData(context.Background(), &datamesh.SampleDomainDataRequest{
+		DomaindataId:       prefix,
+		Rows:               5,
+		StratifyColumn:     "unknown",
+		OutputDomaindataId: prefix + "-s4",
+		OutputRelativeUri:  prefix + "-s4.csv",
+	})
+	assert.ErrorContains(t, err, "stratify column(unknown)")
+	output = domainDataService.QueryDomainData(context.Background(), &datamesh.QueryDomainDataRequest{DomaindataId: prefix + "-s4"})
+	assert.NotEqual(t, int32(0), output.Status.Code)
+}
+
+func TestValidateSampleRequest(t *testing.T) {
+	t.Parallel()
+	valid := &datamesh.SampleDomainDataRequest{DomaindataId: "d", Fraction: 0.5, OutputRelativeUri: "d.csv"}
+	assert.NoError(t, validateSampleRequest(valid))
+
+	for _, req := range []*datamesh.SampleDomainDataRequest{
+		{Fraction: 0.5, OutputRelativeUri: "d.csv"},
+		{DomaindataId: "d", OutputRelativeUri: "d.csv"},
+		{DomaindataId: "d", Fraction: 0.5, Rows: 10, OutputRelativeUri: "d.csv"},
+		{DomaindataId: "d", Fraction: 1.5, OutputRelativeUri: "d.csv"},
+		{DomaindataId: "d", Rows: -1, OutputRelativeUri: "d.csv"},
+		{DomaindataId: "d", Fraction: 0.5},
+	} {
+		assert.Error(t, validateSampleRequest(req))
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+const (
+	// DomainDataChecksumAttribute is the attribute of domaindata which records the checksum of stored data.
+	DomainDataChecksumAttribute = "checksum"
+	// ChecksumMetadataKey is the grpc trailer key which carries the checksum of transferred data.
+	ChecksumMetadataKey = "x-kuscia-checksum"
+
+	checksumAlgorithm = "sha256"
+)
+
+// ChecksumWriter computes the checksum of the data written to the underlying writer.
+type ChecksumWriter struct {
+	w io.Writer
+	h hash.Hash
+}
+
+func NewChecksumWriter(w io.Writer) *ChecksumWriter {
+	return &ChecksumWriter{w: w, h: sha256.New()}
+}
+
+func (cw *ChecksumWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.h.Write(p[:n])
+	return n, err
+}
+
+func (cw *ChecksumWriter) Checksum() string {
+	return formatChecksum(cw.h)
+}
+
+// ChecksumReader computes the checksum of the data read from the underlying reader.
+type ChecksumReader struct {
+	r   io.Reader
+	h   hash.Hash
+	eof bool
+}
+
+func NewChecksumReader(r io.Reader) *ChecksumReader {
+	return &ChecksumReader{r: r, h: sha256.New()}
+}
+
+func (cr *ChecksumReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.h.Write(p[:n])
+	if err == io.EOF {
+		cr.eof = true
+	}
+	return n, err
+}
+
+// Checksum returns the checksum of the data read so far, ok is false if the reader has not reached EOF.
+func (cr *ChecksumReader) Checksum() (checksum string, ok bool) {
+	return formatChecksum(cr.h), cr.eof
+}
+
+// ComputeChecksum reads all the data of r and returns the checksum.
+func ComputeChecksum(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return formatChecksum(h), nil
+}
+
+// VerifyChecksum checks the checksum against the one recorded in domaindata, it passes if no checksum is recorded.
+func VerifyChecksum(data *datamesh.DomainData, checksum string) error {
+	expected := data.GetAttributes()[DomainDataChecksumAttribute]
+	if expected == "" || expected == checksum {
+		return nil
+	}
+	return fmt.Errorf("domaindata(%s) checksum mismatch, expected %s but got %s", data.GetDomaindataId(), expected, checksum)
+}
+
+func formatChecksum(h hash.Hash) string {
+	return checksumAlgorithm + ":" + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+	expected, err := ComputeChecksum(strings.NewReader("hello world!"))
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:7509e5bda0c762d2bac7f90d758b5b2263fa01ccbc542ab5e3df163be08e6ca9", expected)
+
+	buf := &bytes.Buffer{}
+	cw := NewChecksumWriter(buf)
+	_, err = cw.Write([]byte("hello "))
+	assert.NoError(t, err)
+	_, err = cw.Write([]byte("world!"))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, cw.Checksum())
+	assert.Equal(t, "hello world!", buf.String())
+
+	cr := NewChecksumReader(strings.NewReader("hello world!"))
+	_, err = cr.Read(make([]byte, 5))
+	assert.NoError(t, err)
+	_, ok := cr.Checksum()
+	assert.False(t, ok)
+	_, err = io.ReadAll(cr)
+	assert.NoError(t, err)
+	checksum, ok := cr.Checksum()
+	assert.True(t, ok)
+	assert.Equal(t, expected, checksum)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	t.Parallel()
+	data := &datamesh.DomainData{DomaindataId: "test"}
+	assert.NoError(t, VerifyChecksum(data, "sha256:abc"))
+
+	data.Attributes = map[string]string{DomainDataChecksumAttribute: "sha256:abc"}
+	assert.NoError(t, VerifyChecksum(data, "sha256:abc"))
+	assert.Error(t, VerifyChecksum(data, "sha256:def"))
+}
@@ -85,6 +85,28 @@ func (rc *DataMeshRequestContext) GetDomainData(ctx context.Context) (*datamesh.
 	return domainDataResp.Data, nil
 }
 
+// SetDomainDataChecksum records the checksum of the stored data in the attributes of domaindata.
+func (rc *DataMeshRequestContext) SetDomainDataChecksum(ctx context.Context, data *datamesh.DomainData, checksum string) error {
+	attributes := make(map[string]string, len(data.GetAttributes())+1)
+	for k, v := range data.GetAttributes() {
+		attributes[k] = v
+	}
+	attributes[DomainDataChecksumAttribute] = checksum
+
+	resp := rc.domainDataService.UpdateDomainData(ctx, &datamesh.UpdateDomainDataRequest{
+		DomaindataId: data.GetDomaindataId(),
+		Attributes:   attributes,
+	})
+	if resp == nil || resp.GetStatus() == nil || resp.GetStatus().GetCode() != 0 {
+		return common.BuildGrpcErrorf(resp.GetStatus(), codes.Internal, "Record checksum of domain data(%s) fail", data.GetDomaindataId())
+	}
+	if data.Attributes == nil {
+		data.Attributes = map[string]string{}
+	}
+	data.Attributes[DomainDataChecksumAttribute] = checksum
+	return nil
+}
+
 func (rc *DataMeshRequestContext) GetDomainDataAndSource(ctx context.Context) (*datamesh.DomainData, *datamesh.DomainDataSource, error) {
 
 	var data *datamesh.DomainData
@@ -28,6 +28,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/datamesh/config"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tenant"
 	"github.com/secretflow/kuscia/pkg/web/utils"
 	pbv1alpha1 "github.com/secretflow/kuscia/proto/api/v1alpha1"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
@@ -62,6 +63,7 @@ func (s domainDataService) CreateDomainData(ctx context.Context, request *datame
 		}
 		domainData, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(s.conf.KubeNamespace).Get(ctx, request.DomaindataId, metav1.GetOptions{})
 		if err == nil && domainData != nil {
+			// UpdateDomainData checks the tenant of domainData
 			// update domainData
 			resp := s.UpdateDomainData(ctx, convert2UpdateReq(request))
 			return convert2CreateResp(resp, request.DomaindataId)
@@ -111,6 +113,8 @@ func (s domainDataService) CreateDomainData(ctx context.Context, request *datame
 		},
 	}
 
+	// the domainData belongs to the tenant of request
+	tenant.SetLabel(ctx, kusciaDomainData)
 	// create kuscia domain
 	_, err = s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(s.conf.KubeNamespace).Create(ctx, kusciaDomainData, metav1.CreateOptions{})
 	if err != nil {
@@ -136,6 +140,11 @@ func (s domainDataService) QueryDomainData(ctx context.Context, request *datames
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_DataMeshErrQueryDomainData, err.Error()),
 		}
 	}
+	if err := tenant.Check(ctx, kusciaDomainData); err != nil {
+		return &datamesh.QueryDomainDataResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_DataMeshErrQueryDomainData, err.Error()),
+		}
+	}
 	// build domain response
 	return &datamesh.QueryDomainDataResponse{
 		Status: utils.BuildSuccessResponseStatus(),
@@ -164,6 +173,11 @@ func (s domainDataService) UpdateDomainData(ctx context.Context, request *datame
 			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_DataMeshErrGetDomainDataFromKubeFailed, err.Error()),
 		}
 	}
+	if err := tenant.Check(ctx, originalDomainData); err != nil {
+		return &datamesh.UpdateDomainDataResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_DataMeshErrPatchDomainDataFailed, err.Error()),
+		}
+	}
 
 	// normalize request
 	s.normalizationUpdateRequest(request, originalDomainData.Spec)
@@ -240,6 +254,17 @@ func (s domainDataService) UpdateDomainData(ctx context.Context, request *datame
 func (s domainDataService) DeleteDomainData(ctx context.Context, request *datamesh.DeleteDomainDataRequest) *datamesh.DeleteDomainDataResponse {
 	// record the delete operation
 	nlog.Warnf("Delete domainDataID %s", request.DomaindataId)
+	if tenant.FromContext(ctx) != "" {
+		domainData, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(s.conf.KubeNamespace).Get(ctx, request.DomaindataId, metav1.GetOptions{})
+		if err == nil {
+			err = tenant.Check(ctx, domainData)
+		}
+		if err != nil {
+			return &datamesh.DeleteDomainDataResponse{
+				Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_DataMeshErrDeleteDomainDataFailed, err.Error()),
+			}
+		}
+	}
 	// delete kuscia domainData
 	err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(s.conf.KubeNamespace).Delete(ctx, request.DomaindataId, metav1.DeleteOptions{})
 	if err != nil {
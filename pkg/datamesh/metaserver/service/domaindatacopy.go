@@ -0,0 +1,152 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/datamesh/config"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/web/utils"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+)
+
+type IDomainDataCopyService interface {
+	CreateDomainDataCopy(ctx context.Context, request *datamesh.CreateDomainDataCopyRequest) *datamesh.CreateDomainDataCopyResponse
+	QueryDomainDataCopy(ctx context.Context, request *datamesh.QueryDomainDataCopyRequest) *datamesh.QueryDomainDataCopyResponse
+}
+
+type domainDataCopyService struct {
+	conf *config.DataMeshConfig
+}
+
+func NewDomainDataCopyService(config *config.DataMeshConfig) IDomainDataCopyService {
+	return &domainDataCopyService{
+		conf: config,
+	}
+}
+
+func (s *domainDataCopyService) CreateDomainDataCopy(ctx context.Context, request *datamesh.CreateDomainDataCopyRequest) *datamesh.CreateDomainDataCopyResponse {
+	if request.SourceDomain == "" || request.SourceDomaindataId == "" {
+		return &datamesh.CreateDomainDataCopyResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_DataMeshErrRequestInvalidate, "source domain and source domaindata id cant be null"),
+		}
+	}
+	if request.SourceDomain == s.conf.KubeNamespace {
+		return &datamesh.CreateDomainDataCopyResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_DataMeshErrRequestInvalidate, "source domain cant be self"),
+		}
+	}
+
+	ddc := &v1alpha1.DomainDataCopy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: request.DomaindatacopyId,
+		},
+		Spec: v1alpha1.DomainDataCopySpec{
+			SourceDomain:       request.SourceDomain,
+			SourceDomainDataID: request.SourceDomaindataId,
+			DomainDataID:       request.DomaindataId,
+			DataSource:         request.DatasourceId,
+			RelativeURI:        request.RelativeUri,
+			ChunkSize:          request.ChunkSize,
+		},
+	}
+	if ddc.Name == "" {
+		ddc.Name = common.GenDomainDataID("domaindatacopy")
+	}
+	if ddc.Spec.DomainDataID == "" {
+		ddc.Spec.DomainDataID = common.GenDomainDataID(request.SourceDomaindataId)
+	}
+	if ddc.Spec.DataSource == "" {
+		ddc.Spec.DataSource = common.DefaultDataSourceID
+	}
+	if ddc.Spec.RelativeURI == "" {
+		ddc.Spec.RelativeURI = ddc.Spec.DomainDataID
+	}
+	if _, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(s.conf.KubeNamespace).Get(ctx, ddc.Spec.DomainDataID, metav1.GetOptions{}); err == nil {
+		return &datamesh.CreateDomainDataCopyResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_DataMeshErrRequestInvalidate, fmt.Sprintf("domaindata [%s] already exists", ddc.Spec.DomainDataID)),
+		}
+	}
+
+	created, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDataCopies(s.conf.KubeNamespace).Create(ctx, ddc, metav1.CreateOptions{})
+	if err != nil {
+		nlog.Errorf("CreateDomainDataCopy failed, error:%s", err.Error())
+		return &datamesh.CreateDomainDataCopyResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_DataMeshErrCreateDomainDataCopy, err.Error()),
+		}
+	}
+	created.Status.Phase = v1alpha1.DomainDataCopyPending
+	if _, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDataCopies(s.conf.KubeNamespace).UpdateStatus(ctx, created, metav1.UpdateOptions{}); err != nil {
+		nlog.Warnf("Update status of DomainDataCopy %s failed, error:%s", created.Name, err.Error())
+	}
+	nlog.Infof("Create DomainDataCopy %s/%s", s.conf.KubeNamespace, created.Name)
+	return &datamesh.CreateDomainDataCopyResponse{
+		Status: utils.BuildSuccessResponseStatus(),
+		Data: &datamesh.CreateDomainDataCopyResponseData{
+			DomaindatacopyId: created.Name,
+			DomaindataId:     created.Spec.DomainDataID,
+		},
+	}
+}
+
+func (s *domainDataCopyService) QueryDomainDataCopy(ctx context.Context, request *datamesh.QueryDomainDataCopyRequest) *datamesh.QueryDomainDataCopyResponse {
+	if request.DomaindatacopyId == "" {
+		return &datamesh.QueryDomainDataCopyResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_DataMeshErrRequestInvalidate, "domaindatacopyid cant be null"),
+		}
+	}
+	ddc, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDataCopies(s.conf.KubeNamespace).Get(ctx, request.DomaindatacopyId, metav1.GetOptions{})
+	if err != nil {
+		nlog.Errorf("Query DomainDataCopy failed, error:%s", err.Error())
+		return &datamesh.QueryDomainDataCopyResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_DataMeshErrQueryDomainDataCopy, err.Error()),
+		}
+	}
+	return &datamesh.QueryDomainDataCopyResponse{
+		Status: utils.BuildSuccessResponseStatus(),
+		Data: &datamesh.DomainDataCopyData{
+			DomaindatacopyId:   ddc.Name,
+			SourceDomain:       ddc.Spec.SourceDomain,
+			SourceDomaindataId: ddc.Spec.SourceDomainDataID,
+			DomaindataId:       ddc.Spec.DomainDataID,
+			DatasourceId:       ddc.Spec.DataSource,
+			RelativeUri:        ddc.Spec.RelativeURI,
+			Status: &datamesh.DomainDataCopyStatus{
+				Phase:          string(ddc.Status.Phase),
+				Message:        ddc.Status.Message,
+				CopiedBytes:    ddc.Status.CopiedBytes,
+				TotalBytes:     ddc.Status.TotalBytes,
+				Checksum:       ddc.Status.Checksum,
+				StartTime:      formatTime(ddc.Status.StartTime),
+				CompletionTime: formatTime(ddc.Status.CompletionTime),
+			},
+		},
+	}
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/pkg/datamesh/config"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+func TestCreateDomainDataCopy(t *testing.T) {
+	conf := &config.DataMeshConfig{
+		KusciaClient:  kusciafake.NewSimpleClientset(),
+		KubeNamespace: "bob",
+	}
+	svc := NewDomainDataCopyService(conf)
+
+	res := svc.CreateDomainDataCopy(context.Background(), &datamesh.CreateDomainDataCopyRequest{
+		SourceDomain:       "bob",
+		SourceDomaindataId: "table",
+	})
+	assert.NotEqual(t, int32(0), res.Status.Code)
+
+	res = svc.CreateDomainDataCopy(context.Background(), &datamesh.CreateDomainDataCopyRequest{
+		SourceDomain:       "alice",
+		SourceDomaindataId: "table",
+	})
+	assert.Equal(t, int32(0), res.Status.Code)
+	assert.NotEmpty(t, res.Data.DomaindatacopyId)
+	assert.NotEmpty(t, res.Data.DomaindataId)
+
+	queryRes := svc.QueryDomainDataCopy(context.Background(), &datamesh.QueryDomainDataCopyRequest{
+		DomaindatacopyId: res.Data.DomaindatacopyId,
+	})
+	assert.Equal(t, int32(0), queryRes.Status.Code)
+	assert.Equal(t, "alice", queryRes.Data.SourceDomain)
+	assert.Equal(t, res.Data.DomaindataId, queryRes.Data.DomaindataId)
+	assert.Equal(t, res.Data.DomaindataId, queryRes.Data.RelativeUri)
+	assert.Equal(t, common.DefaultDataSourceID, queryRes.Data.DatasourceId)
+	assert.Equal(t, "Pending", queryRes.Data.Status.Phase)
+
+	queryRes = svc.QueryDomainDataCopy(context.Background(), &datamesh.QueryDomainDataCopyRequest{
+		DomaindatacopyId: "not-exists",
+	})
+	assert.NotEqual(t, int32(0), queryRes.Status.Code)
+}
@@ -91,3 +91,22 @@ func (h *domainDataGrantHandler) UpdateDomainDataGrant(ctx context.Context, requ
 func (h *domainDataGrantHandler) DeleteDomainDataGrant(ctx context.Context, request *datamesh.DeleteDomainDataGrantRequest) (*datamesh.DeleteDomainDataGrantResponse, error) {
 	return h.domainDataGrantService.DeleteDomainDataGrant(ctx, request), nil
 }
+
+type domainDataCopyHandler struct {
+	domainDataCopyService service.IDomainDataCopyService
+	datamesh.UnimplementedDomainDataCopyServiceServer
+}
+
+func NewDomainDataCopyHandler(domainDataCopyService service.IDomainDataCopyService) datamesh.DomainDataCopyServiceServer {
+	return &domainDataCopyHandler{
+		domainDataCopyService: domainDataCopyService,
+	}
+}
+
+func (h *domainDataCopyHandler) CreateDomainDataCopy(ctx context.Context, request *datamesh.CreateDomainDataCopyRequest) (*datamesh.CreateDomainDataCopyResponse, error) {
+	return h.domainDataCopyService.CreateDomainDataCopy(ctx, request), nil
+}
+
+func (h *domainDataCopyHandler) QueryDomainDataCopy(ctx context.Context, request *datamesh.QueryDomainDataCopyRequest) (*datamesh.QueryDomainDataCopyResponse, error) {
+	return h.domainDataCopyService.QueryDomainDataCopy(ctx, request), nil
+}
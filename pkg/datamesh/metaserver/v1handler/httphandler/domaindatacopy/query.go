@@ -0,0 +1,52 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//nolint:dupl
+package domaindatacopy
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/secretflow/kuscia/pkg/datamesh/metaserver/service"
+	"github.com/secretflow/kuscia/pkg/web/api"
+	"github.com/secretflow/kuscia/pkg/web/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+)
+
+type queryDomainDataCopyHandler struct {
+	domainDataCopyService service.IDomainDataCopyService
+}
+
+func NewQueryDomainDataCopyHandler(domainDataCopyService service.IDomainDataCopyService) api.ProtoHandler {
+	return &queryDomainDataCopyHandler{
+		domainDataCopyService: domainDataCopyService,
+	}
+}
+
+func (h *queryDomainDataCopyHandler) Validate(context *api.BizContext, request api.ProtoRequest, errs *errorcode.Errs) {
+	queryReq, _ := request.(*datamesh.QueryDomainDataCopyRequest)
+	if queryReq.DomaindatacopyId == "" {
+		errs.AppendErr(errors.New("domaindatacopyid should not be empty"))
+	}
+}
+
+func (h *queryDomainDataCopyHandler) Handle(context *api.BizContext, request api.ProtoRequest) api.ProtoResponse {
+	queryRequest, _ := request.(*datamesh.QueryDomainDataCopyRequest)
+	return h.domainDataCopyService.QueryDomainDataCopy(context.Context, queryRequest)
+}
+
+func (h *queryDomainDataCopyHandler) GetType() (reqType, respType reflect.Type) {
+	return reflect.TypeOf(datamesh.QueryDomainDataCopyRequest{}), reflect.TypeOf(datamesh.QueryDomainDataCopyResponse{})
+}
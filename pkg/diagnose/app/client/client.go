@@ -203,13 +203,24 @@ func NewDatameshConn() (*grpc.ClientConn, error) {
 }
 
 func NewGrpcConn(address string, tlsconfig *config.TLSConfig, tokenFile string) (*grpc.ClientConn, error) {
-	dialOpts := make([]grpc.DialOption, 0)
+	token := ""
 	if tokenFile != "" {
-		token, err := os.ReadFile(tokenFile)
+		data, err := os.ReadFile(tokenFile)
 		if err != nil {
 			return nil, err
 		}
-		dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(interceptor.GrpcClientTokenInterceptor(string(token))))
+		token = string(data)
+	}
+	return NewGrpcConnWithToken(address, tlsconfig, token)
+}
+
+// NewGrpcConnWithToken creates a grpc connection which carries the token in both unary and stream calls.
+func NewGrpcConnWithToken(address string, tlsconfig *config.TLSConfig, token string) (*grpc.ClientConn, error) {
+	dialOpts := make([]grpc.DialOption, 0)
+	if token != "" {
+		dialOpts = append(dialOpts,
+			grpc.WithUnaryInterceptor(interceptor.GrpcClientTokenInterceptor(token)),
+			grpc.WithStreamInterceptor(interceptor.GrpcClientStreamTokenInterceptor(token)))
 	}
 
 	clientTLSConfig, err := tlsutils.BuildClientTLSConfigViaPath(tlsconfig.CAPath, tlsconfig.ServerCertPath, tlsconfig.ServerKeyPath)
@@ -0,0 +1,381 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle collects the resources and logs related to a job of local party into a tar bundle for post-mortem.
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	DefaultMaxLogBytes = 10 << 20 // 10MB
+
+	summaryFile = "summary.txt"
+	// maxLineSize bounds the line scanned in kuscia and gateway logs.
+	maxLineSize = 1 << 20
+)
+
+// Config is the config of JobCollector. Logs are skipped if the corresponding directory is empty.
+type Config struct {
+	JobID        string
+	DomainID     string
+	KusciaClient kusciaclientset.Interface
+	KubeClient   kubernetes.Interface
+	// StdoutDir is the directory of pod stdout logs, e.g. /home/kuscia/var/stdout.
+	StdoutDir string
+	// LogDir is the directory of kuscia and gateway logs, e.g. /home/kuscia/var/logs.
+	LogDir string
+	// MaxLogBytes bounds each log file in bundle, the tail is kept.
+	MaxLogBytes int64
+}
+
+// JobCollector collects the resources and logs of a job visible to local party. Collecting is best-effort, failures
+// are recorded in the summary of bundle instead of aborting.
+type JobCollector struct {
+	conf *Config
+
+	tw       *tar.Writer
+	now      time.Time
+	entries  []string
+	failures []string
+	// keywords identify the lines of kuscia and gateway logs related to job.
+	keywords []string
+}
+
+func NewJobCollector(conf *Config) *JobCollector {
+	if conf.MaxLogBytes <= 0 {
+		conf.MaxLogBytes = DefaultMaxLogBytes
+	}
+	return &JobCollector{conf: conf}
+}
+
+// Collect writes the gzipped tar bundle to w.
+func (c *JobCollector) Collect(ctx context.Context, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	c.tw = tar.NewWriter(gw)
+	c.now = time.Now()
+	c.keywords = []string{c.conf.JobID}
+
+	taskIDs := c.collectJob(ctx)
+	pods := c.collectTasks(ctx, taskIDs)
+	c.collectPodLogs(pods)
+	c.collectLogs()
+
+	if err := c.addFile(summaryFile, c.summary()); err != nil {
+		return err
+	}
+	if err := c.tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func (c *JobCollector) collectJob(ctx context.Context) []string {
+	job, err := c.conf.KusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(ctx, c.conf.JobID, metav1.GetOptions{})
+	if err != nil {
+		c.fail("get kuscia job %s failed, %v", c.conf.JobID, err)
+		return nil
+	}
+	job.ManagedFields = nil
+	c.addObject("resources/kusciajob.yaml", job)
+
+	var taskIDs []string
+	for _, task := range job.Spec.Tasks {
+		taskIDs = append(taskIDs, task.TaskID)
+	}
+	return taskIDs
+}
+
+func (c *JobCollector) collectTasks(ctx context.Context, taskIDs []string) []*corev1.Pod {
+	tasks := make(map[string]bool, len(taskIDs))
+	for _, taskID := range taskIDs {
+		tasks[taskID] = true
+		c.keywords = append(c.keywords, taskID)
+
+		task, err := c.conf.KusciaClient.KusciaV1alpha1().KusciaTasks(common.KusciaCrossDomain).Get(ctx, taskID, metav1.GetOptions{})
+		if err != nil {
+			// task isn't created if job failed before it's scheduled
+			c.fail("get kuscia task %s failed, %v", taskID, err)
+			continue
+		}
+		task.ManagedFields = nil
+		c.addObject(fmt.Sprintf("resources/kusciatasks/%s.yaml", taskID), task)
+
+		trg, err := c.conf.KusciaClient.KusciaV1alpha1().TaskResourceGroups().Get(ctx, taskID, metav1.GetOptions{})
+		if err != nil {
+			c.fail("get task resource group %s failed, %v", taskID, err)
+			continue
+		}
+		trg.ManagedFields = nil
+		c.addObject(fmt.Sprintf("resources/taskresourcegroups/%s.yaml", taskID), trg)
+	}
+	if len(tasks) == 0 || c.conf.KubeClient == nil {
+		return nil
+	}
+
+	// pods and services of local party only
+	namespace := c.conf.DomainID
+	listOptions := metav1.ListOptions{LabelSelector: common.LabelTaskUID}
+	podList, err := c.conf.KubeClient.CoreV1().Pods(namespace).List(ctx, listOptions)
+	if err != nil {
+		c.fail("list pods in %s failed, %v", namespace, err)
+		return nil
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !tasks[pod.Annotations[common.TaskIDAnnotationKey]] {
+			continue
+		}
+		pods = append(pods, pod)
+		c.keywords = append(c.keywords, pod.Name)
+
+		pod.ManagedFields = nil
+		redactPod(pod)
+		c.addObject(fmt.Sprintf("resources/pods/%s.yaml", pod.Name), pod)
+		c.collectEvents(ctx, namespace, pod.Name)
+	}
+
+	if svcList, err := c.conf.KubeClient.CoreV1().Services(namespace).List(ctx, listOptions); err != nil {
+		c.fail("list services in %s failed, %v", namespace, err)
+	} else {
+		for i := range svcList.Items {
+			svc := &svcList.Items[i]
+			if !tasks[svc.Annotations[common.TaskIDAnnotationKey]] {
+				continue
+			}
+			c.keywords = append(c.keywords, svc.Name)
+			svc.ManagedFields = nil
+			c.addObject(fmt.Sprintf("resources/services/%s.yaml", svc.Name), svc)
+		}
+	}
+	return pods
+}
+
+func (c *JobCollector) collectEvents(ctx context.Context, namespace, podName string) {
+	events, err := c.conf.KubeClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", podName).String(),
+	})
+	if err != nil {
+		c.fail("list events of pod %s failed, %v", podName, err)
+		return
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+	})
+	buf := &bytes.Buffer{}
+	for _, event := range events.Items {
+		fmt.Fprintf(buf, "%s\t%s\t%s\t%s\tx%d\t%s\n", event.LastTimestamp.Format(time.RFC3339), event.Type,
+			event.Reason, event.Source.Component, event.Count, event.Message)
+	}
+	c.addRedactedFile(fmt.Sprintf("events/%s.log", podName), buf.Bytes())
+}
+
+// collectPodLogs collects stdout of containers, which are stored in <StdoutDir>/<namespace>_<pod>_<uid>/<container>/<restart>.log.
+func (c *JobCollector) collectPodLogs(pods []*corev1.Pod) {
+	if c.conf.StdoutDir == "" || len(pods) == 0 {
+		return
+	}
+	dirs, err := os.ReadDir(c.conf.StdoutDir)
+	if err != nil {
+		c.fail("read stdout directory %s failed, %v", c.conf.StdoutDir, err)
+		return
+	}
+	for _, pod := range pods {
+		prefix := fmt.Sprintf("%s_%s_", pod.Namespace, pod.Name)
+		found := false
+		for _, dir := range dirs {
+			if !dir.IsDir() || !strings.HasPrefix(dir.Name(), prefix) {
+				continue
+			}
+			found = true
+			podDir := filepath.Join(c.conf.StdoutDir, dir.Name())
+			err := filepath.Walk(podDir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() || !strings.HasSuffix(info.Name(), ".log") {
+					return err
+				}
+				rel, _ := filepath.Rel(c.conf.StdoutDir, path)
+				data, err := readTail(path, c.conf.MaxLogBytes)
+				if err != nil {
+					c.fail("read log %s failed, %v", path, err)
+					return nil
+				}
+				c.addRedactedFile(filepath.ToSlash(filepath.Join("logs/pods", rel)), data)
+				return nil
+			})
+			if err != nil {
+				c.fail("walk log directory %s failed, %v", podDir, err)
+			}
+		}
+		if !found {
+			c.fail("stdout of pod %s isn't found in %s, it may run on other node", pod.Name, c.conf.StdoutDir)
+		}
+	}
+}
+
+// collectLogs collects lines related to job from kuscia and gateway logs.
+func (c *JobCollector) collectLogs() {
+	if c.conf.LogDir == "" {
+		return
+	}
+	files := []string{filepath.Join(c.conf.LogDir, "kuscia.log")}
+	envoyLogs, _ := filepath.Glob(filepath.Join(c.conf.LogDir, "envoy", "*.log"))
+	files = append(files, envoyLogs...)
+	for _, file := range files {
+		data, err := grepFile(file, c.keywords, c.conf.MaxLogBytes)
+		if err != nil {
+			c.fail("read log %s failed, %v", file, err)
+			continue
+		}
+		rel, _ := filepath.Rel(c.conf.LogDir, file)
+		c.addRedactedFile(filepath.ToSlash(filepath.Join("logs", rel)), data)
+	}
+}
+
+func (c *JobCollector) addObject(name string, obj interface{}) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		c.fail("marshal %s failed, %v", name, err)
+		return
+	}
+	c.addRedactedFile(name, data)
+}
+
+func (c *JobCollector) addRedactedFile(name string, data []byte) {
+	if err := c.addFile(name, Redact(data)); err != nil {
+		c.fail("write %s to bundle failed, %v", name, err)
+	}
+}
+
+func (c *JobCollector) addFile(name string, data []byte) error {
+	name = filepath.ToSlash(filepath.Join(c.conf.JobID, name))
+	if err := c.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: c.now,
+	}); err != nil {
+		return err
+	}
+	if _, err := c.tw.Write(data); err != nil {
+		return err
+	}
+	c.entries = append(c.entries, name)
+	return nil
+}
+
+func (c *JobCollector) fail(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	nlog.Warn(msg)
+	c.failures = append(c.failures, msg)
+}
+
+func (c *JobCollector) summary() []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "job: %s\ndomain: %s\ncollected at: %s\n\nfiles:\n", c.conf.JobID, c.conf.DomainID,
+		c.now.Format(time.RFC3339))
+	for _, entry := range c.entries {
+		fmt.Fprintf(buf, "  %s\n", entry)
+	}
+	if len(c.failures) > 0 {
+		fmt.Fprintf(buf, "\nfailures:\n")
+		for _, failure := range c.failures {
+			fmt.Fprintf(buf, "  %s\n", failure)
+		}
+	}
+	return buf.Bytes()
+}
+
+// Failures returns the failures of last collecting.
+func (c *JobCollector) Failures() []string {
+	return c.failures
+}
+
+func readTail(path string, maxBytes int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxBytes {
+		if _, err := file.Seek(info.Size()-maxBytes, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(file)
+}
+
+// grepFile returns lines containing any of keywords, only the last maxBytes are kept.
+func grepFile(path string, keywords []string, maxBytes int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines [][]byte
+	var size int64
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		matched := false
+		for _, keyword := range keywords {
+			if bytes.Contains(line, []byte(keyword)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		line = append(append([]byte{}, line...), '\n')
+		lines = append(lines, line)
+		size += int64(len(line))
+		for size > maxBytes && len(lines) > 0 {
+			size -= int64(len(lines[0]))
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return bytes.Join(lines, nil), nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const Redacted = "<redacted>"
+
+var (
+	sensitiveName = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private[_-]?key|access[_-]?key)`)
+
+	// sensitiveKeyValue matches values of sensitive keys in json, yaml, and key=value text, quotes of json embedded in
+	// string are escaped.
+	sensitiveKeyValue = regexp.MustCompile(`(?i)((?:password|passwd|secret|token|credential|private[_-]?key|access[_-]?key)[\w-]*\\?["']?\s*[:=]\s*\\?["']?)([^"'\s,;}&\\]+)`)
+
+	pemPrivateKey = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// Redact replaces values of sensitive keys and private keys in text.
+func Redact(text []byte) []byte {
+	text = pemPrivateKey.ReplaceAll(text, []byte(Redacted))
+	return sensitiveKeyValue.ReplaceAll(text, []byte("${1}"+Redacted))
+}
+
+// redactPod replaces values of sensitive env of containers, which can't be matched by Redact after serialized.
+func redactPod(pod *corev1.Pod) {
+	redactContainers := func(containers []corev1.Container) {
+		for i := range containers {
+			for j := range containers[i].Env {
+				env := &containers[i].Env[j]
+				if env.Value != "" && sensitiveName.MatchString(env.Name) {
+					env.Value = Redacted
+				}
+			}
+		}
+	}
+	redactContainers(pod.Spec.InitContainers)
+	redactContainers(pod.Spec.Containers)
+}
@@ -29,6 +29,7 @@ const (
 	Pass    = "[PASS]"
 	Fail    = "[FAIL]"
 	Warning = "[WARNING]"
+	Skip    = "[SKIP]"
 )
 
 const (
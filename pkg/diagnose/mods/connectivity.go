@@ -0,0 +1,381 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mods
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/diagnose/app/netstat"
+	"github.com/secretflow/kuscia/pkg/diagnose/common"
+	util "github.com/secretflow/kuscia/pkg/diagnose/utils"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/constants"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+	"google.golang.org/grpc"
+)
+
+const (
+	StageDomainRoute = "DOMAINROUTE"
+	StageDNS         = "DNS"
+	StageTCP         = "TCP"
+	StageTLS         = "TLS"
+	StageTokenAuth   = "TOKEN AUTH"
+	StageEcho        = "ECHO"
+	StageBandwidth   = "BANDWIDTH"
+	StageMTU         = "MTU"
+
+	dialTimeout        = 5 * time.Second
+	requestTimeout     = 30 * time.Second
+	echoPayloadSize    = 4 << 10 // 4KB
+	bandwidthProbeSize = 8 << 20 // 8MB
+	maxErrorBodySize   = 200
+)
+
+// mtuProbeSizes are payload sizes echoed in order, a payload larger than path mtu is split into full-sized packets,
+// which are dropped silently if the path mtu is misconfigured and icmp is blocked.
+var mtuProbeSizes = []int{512, 1400, 4096, 16 << 10, 64 << 10}
+
+type connectivityStage struct {
+	name string
+	run  func(ctx context.Context) (result string, information string)
+}
+
+// ConnectivityMod checks the connectivity from source to destination layer by layer through the gateway, stages after
+// the first failed one are skipped, so the failed stage pinpoints the layer to fix.
+type ConnectivityMod struct {
+	source         string
+	destination    string
+	speed          bool
+	speedThreshold int
+	kusciaAPIConn  *grpc.ClientConn
+	table          *util.Table
+	// internalServer is the internal listener of local gateway.
+	internalServer string
+	httpClient     *http.Client
+
+	route    *kusciaapi.QueryDomainRouteResponseData
+	hostPort string
+}
+
+func NewConnectivityMod(reporter *util.Reporter, kusciaAPIConn *grpc.ClientConn, config *DiagnoseConfig) Mod {
+	threshold := config.SpeedThres
+	if threshold == 0 {
+		threshold = netstat.DefaultBandWidthThreshold
+	}
+	return &ConnectivityMod{
+		source:         config.Source,
+		destination:    config.Destination,
+		speed:          config.Speed,
+		speedThreshold: threshold,
+		kusciaAPIConn:  kusciaAPIConn,
+		table:          reporter.NewTableWriter(),
+		internalServer: utils.InternalServer,
+		httpClient: &http.Client{
+			Timeout: requestTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (m *ConnectivityMod) Run(ctx context.Context) error {
+	PrintToConsole("diagnose <%s-%s> connectivity layer by layer\n", m.source, m.destination)
+	m.table.SetTitle("CONNECTIVITY CHECK:")
+	m.table.AddHeader([]string{"STAGE", "RESULT", "ELAPSED", "INFORMATION"})
+
+	stages := []connectivityStage{
+		{StageDomainRoute, m.checkDomainRoute},
+		{StageDNS, m.checkDNS},
+		{StageTCP, m.checkTCP},
+		{StageTLS, m.checkTLS},
+		{StageTokenAuth, m.checkTokenAuth},
+		{StageEcho, m.checkEcho},
+		{StageBandwidth, m.checkBandwidth},
+		{StageMTU, m.checkMTU},
+	}
+	var failed, failedInfo string
+	for _, stage := range stages {
+		if failed != "" {
+			m.table.AddRow([]string{stage.name, common.Skip, "", fmt.Sprintf("skipped as stage %s failed", failed)})
+			continue
+		}
+		PrintToConsole("check %s\n", stage.name)
+		start := time.Now()
+		result, info := stage.run(ctx)
+		nlog.Infof("Connectivity stage %s of %s-%s: %s %s", stage.name, m.source, m.destination, result, info)
+		m.table.AddRow([]string{stage.name, result, time.Since(start).Round(time.Millisecond).String(), info})
+		if result == common.Fail {
+			failed, failedInfo = stage.name, info
+		}
+	}
+
+	if failed != "" {
+		m.table.AddRow([]string{"CONCLUSION", common.Fail, "", fmt.Sprintf("connectivity is broken at layer %s", failed)})
+		return fmt.Errorf("connectivity of %s-%s is broken at layer %s, %s", m.source, m.destination, failed, failedInfo)
+	}
+	m.table.AddRow([]string{"CONCLUSION", common.Pass, "", "all layers are reachable"})
+	return nil
+}
+
+func (m *ConnectivityMod) checkDomainRoute(ctx context.Context) (string, string) {
+	resp, err := m.QueryDomainRoute(ctx, &kusciaapi.QueryDomainRouteRequest{
+		Source:      m.source,
+		Destination: m.destination,
+	})
+	if err != nil {
+		return common.Fail, fmt.Sprintf("invoke query api failed, %v", err)
+	}
+	if resp.Status.Code != 0 {
+		return common.Fail, fmt.Sprintf("query cdr failed, code:%v, message:%v", resp.Status.Code, resp.Status.Message)
+	}
+	m.route = resp.Data
+	if m.route.Endpoint != nil && len(m.route.Endpoint.Ports) > 0 {
+		m.hostPort = net.JoinHostPort(m.route.Endpoint.Host, strconv.Itoa(int(m.route.Endpoint.Ports[0].Port)))
+	}
+	// lower layers are still checked to find out why the route isn't ready
+	if m.route.Status == nil || m.route.Status.Status != constants.RouteSucceeded {
+		var reason string
+		if m.route.Status != nil {
+			reason = m.route.Status.Reason
+		}
+		return common.Warning, fmt.Sprintf("cdr status not succeeded, reason: %v", reason)
+	}
+	return common.Pass, fmt.Sprintf("authentication type: %s", m.route.AuthenticationType)
+}
+
+func (m *ConnectivityMod) checkDNS(ctx context.Context) (string, string) {
+	if m.hostPort == "" {
+		return common.Skip, "endpoint isn't configured in cdr, it may be a transit route or synced from peer"
+	}
+	host := m.route.Endpoint.Host
+	if net.ParseIP(host) != nil {
+		return common.Pass, fmt.Sprintf("%s is an ip address", host)
+	}
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return common.Fail, fmt.Sprintf("resolve %s failed, %v", host, err)
+	}
+	return common.Pass, fmt.Sprintf("%s resolved to %v", host, addrs)
+}
+
+func (m *ConnectivityMod) checkTCP(ctx context.Context) (string, string) {
+	if m.hostPort == "" {
+		return common.Skip, "endpoint isn't configured in cdr"
+	}
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.hostPort)
+	if err != nil {
+		return common.Fail, fmt.Sprintf("connect to %s failed, %v, check firewall and port mapping of peer", m.hostPort, err)
+	}
+	defer conn.Close()
+	return common.Pass, fmt.Sprintf("connected to %s", conn.RemoteAddr())
+}
+
+func (m *ConnectivityMod) checkTLS(ctx context.Context) (string, string) {
+	if m.hostPort == "" {
+		return common.Skip, "endpoint isn't configured in cdr"
+	}
+	if !m.route.Endpoint.Ports[0].IsTLS {
+		return common.Skip, "endpoint isn't tls enabled"
+	}
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: dialTimeout},
+		Config: &tls.Config{
+			ServerName:         m.route.Endpoint.Host,
+			InsecureSkipVerify: true, // only the handshake is checked, certificate is verified by gateway
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", m.hostPort)
+	if err != nil {
+		info := fmt.Sprintf("tls handshake with %s failed, %v", m.hostPort, err)
+		if m.route.MtlsConfig != nil && m.route.MtlsConfig.SourceClientCert != "" {
+			info += ", peer may reject the handshake without the client certificate of mtls config"
+		}
+		// tcp is connected, a handshake hanging is the typical symptom of large certificate records being dropped
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			info += ", large tls records may be dropped due to mtu mismatch, " + m.mtuWorkaroundHint()
+		}
+		return common.Fail, info
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return common.Pass, tls.VersionName(state.Version)
+	}
+	cert := state.PeerCertificates[0]
+	if time.Now().After(cert.NotAfter) {
+		return common.Fail, fmt.Sprintf("certificate of %s expired at %s", cert.Subject, cert.NotAfter)
+	}
+	return common.Pass, fmt.Sprintf("%s, certificate %s expires at %s", tls.VersionName(state.Version), cert.Subject,
+		cert.NotAfter.Format(time.RFC3339))
+}
+
+func (m *ConnectivityMod) checkTokenAuth(ctx context.Context) (string, string) {
+	resp, body, err := m.doDiagnoseRequest(ctx, utils.DiagnoseEchoPath, nil)
+	if err != nil {
+		return common.Fail, err.Error()
+	}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		if domain := resp.Header.Get(utils.DiagnoseDomainHeader); domain != m.destination {
+			return common.Fail, fmt.Sprintf("response is served by domain %q instead of %s", domain, m.destination)
+		}
+		return common.Pass, fmt.Sprintf("authenticated by %s", m.destination)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return common.Fail, fmt.Sprintf("rejected by token authentication, status code: %d, kuscia error message: %s",
+			resp.StatusCode, resp.Header.Get(utils.KusciaEnvoyMsgHeaderKey))
+	default:
+		return common.Fail, fmt.Sprintf("status code: %d, kuscia error message: %s, body: %s", resp.StatusCode,
+			resp.Header.Get(utils.KusciaEnvoyMsgHeaderKey), body)
+	}
+}
+
+func (m *ConnectivityMod) checkEcho(ctx context.Context) (string, string) {
+	if err := m.echo(ctx, echoPayloadSize); err != nil {
+		// the link only drops large packets if a small payload passes
+		if small := mtuProbeSizes[0]; m.echo(ctx, small) == nil {
+			return common.Fail, fmt.Sprintf("%v, while %d bytes echoed, large packets may be dropped due to mtu mismatch, %s",
+				err, small, m.mtuWorkaroundHint())
+		}
+		return common.Fail, err.Error()
+	}
+	return common.Pass, fmt.Sprintf("%d bytes echoed by %s", echoPayloadSize, m.destination)
+}
+
+func (m *ConnectivityMod) checkBandwidth(ctx context.Context) (string, string) {
+	if !m.speed {
+		return common.Skip, "bandwidth test is disabled"
+	}
+	start := time.Now()
+	resp, body, err := m.doDiagnoseRequest(ctx, utils.DiagnoseSinkPath, make([]byte, bandwidthProbeSize))
+	if err != nil {
+		return common.Fail, err.Error()
+	}
+	elapsed := time.Since(start)
+	if resp.StatusCode != http.StatusOK {
+		return common.Fail, fmt.Sprintf("status code: %d, body: %s", resp.StatusCode, body)
+	}
+	out := &utils.DiagnoseSinkResponse{}
+	if err := json.Unmarshal(body, out); err != nil || out.Bytes != bandwidthProbeSize {
+		return common.Fail, fmt.Sprintf("peer received %d bytes of %d bytes sent", out.Bytes, bandwidthProbeSize)
+	}
+
+	bandwidth := netstat.ToMbps(int(float64(bandwidthProbeSize) / elapsed.Seconds()))
+	info := fmt.Sprintf("%v%s", bandwidth, netstat.BandWidthUnit)
+	if bandwidth < float64(m.speedThreshold) {
+		return common.Warning, fmt.Sprintf("%s, not satisfy threshold %v%s", info, m.speedThreshold, netstat.BandWidthUnit)
+	}
+	return common.Pass, info
+}
+
+func (m *ConnectivityMod) checkMTU(ctx context.Context) (string, string) {
+	var info string
+	if m.hostPort != "" {
+		if mtu, err := pathMTU(ctx, m.hostPort); err != nil {
+			nlog.Warnf("Get path mtu of %s failed, %v", m.hostPort, err)
+		} else {
+			info = fmt.Sprintf("path mtu to %s: %d, ", m.hostPort, mtu)
+		}
+	}
+
+	passed := 0
+	for _, size := range mtuProbeSizes {
+		if err := m.echo(ctx, size); err != nil {
+			if passed == 0 {
+				return common.Fail, fmt.Sprintf("%secho %d bytes failed, %v", info, size, err)
+			}
+			return common.Fail, fmt.Sprintf("%secho %d bytes failed while %d bytes passed, large packets may be dropped "+
+				"silently due to mtu mismatch of the links between domains, %s, %v", info, size, passed,
+				m.mtuWorkaroundHint(), err)
+		}
+		passed = size
+	}
+	return common.Pass, fmt.Sprintf("%spayload up to %d bytes echoed", info, passed)
+}
+
+func (m *ConnectivityMod) mtuWorkaroundHint() string {
+	return fmt.Sprintf("set spec.mtuWorkaround of cdr %s-%s to clamp the tcp mss", m.source, m.destination)
+}
+
+func (m *ConnectivityMod) echo(ctx context.Context, size int) error {
+	payload := make([]byte, size)
+	if _, err := rand.Read(payload); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	resp, body, err := m.doDiagnoseRequest(ctx, utils.DiagnoseEchoPath, payload)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code: %d, kuscia error message: %s, body: %s", resp.StatusCode,
+			resp.Header.Get(utils.KusciaEnvoyMsgHeaderKey), body)
+	}
+	if !bytes.Equal(payload, body) {
+		return fmt.Errorf("echoed %d bytes mismatch with %d bytes sent", len(body), size)
+	}
+	return nil
+}
+
+// doDiagnoseRequest sends request to diagnose api of destination through the internal listener of local gateway, the
+// response body is truncated if status code isn't 200.
+func (m *ConnectivityMod) doDiagnoseRequest(ctx context.Context, path string, payload []byte) (*http.Response, []byte, error) {
+	method := http.MethodGet
+	var reader io.Reader
+	if payload != nil {
+		method = http.MethodPost
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, m.internalServer+path, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Host = utils.GetDiagnoseHost(m.destination)
+	req.Header.Set("Kuscia-Source", m.source)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("send request through local gateway failed, %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response failed, %v", err)
+	}
+	if resp.StatusCode != http.StatusOK && len(body) > maxErrorBodySize {
+		body = body[:maxErrorBodySize]
+	}
+	return resp, body, nil
+}
+
+func (m *ConnectivityMod) QueryDomainRoute(ctx context.Context, req *kusciaapi.QueryDomainRouteRequest) (*kusciaapi.QueryDomainRouteResponse, error) {
+	return kusciaapi.NewDomainRouteServiceClient(m.kusciaAPIConn).QueryDomainRoute(ctx, req)
+}
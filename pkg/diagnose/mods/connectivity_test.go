@@ -0,0 +1,128 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mods
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/agiledragon/gomonkey"
+	"github.com/secretflow/kuscia/pkg/diagnose/utils"
+	gatewayutils "github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/constants"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockGateway mocks the local gateway which forwards diagnose requests to peer, unauthorized requests are rejected.
+func newMockGateway(authorized bool) *httptest.Server {
+	return newMockGatewayWithLimit(authorized, 0)
+}
+
+// newMockGatewayWithLimit mocks a gateway whose link to peer times out on requests larger than limit.
+func newMockGatewayWithLimit(authorized bool, limit int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized {
+			w.Header().Set(gatewayutils.KusciaEnvoyMsgHeaderKey, "token is invalid")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set(gatewayutils.DiagnoseDomainHeader, "bob")
+		body, _ := io.ReadAll(r.Body)
+		if limit > 0 && len(body) > limit {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		if r.URL.Path == gatewayutils.DiagnoseSinkPath {
+			body, _ = json.Marshal(&gatewayutils.DiagnoseSinkResponse{Bytes: int64(len(body))})
+		}
+		_, _ = w.Write(body)
+	}))
+}
+
+func newTestConnectivityMod(t *testing.T, gateway *httptest.Server) *ConnectivityMod {
+	conf := &DiagnoseConfig{
+		Source:      "alice",
+		Destination: "bob",
+	}
+	conf.Speed = true
+	mod := NewConnectivityMod(utils.NewReporter(""), nil, conf).(*ConnectivityMod)
+	mod.internalServer = gateway.URL
+
+	host, port, err := net.SplitHostPort(gateway.Listener.Addr().String())
+	assert.NoError(t, err)
+	portNum, _ := strconv.Atoi(port)
+	route := &kusciaapi.QueryDomainRouteResponseData{
+		AuthenticationType: "Token",
+		Endpoint: &kusciaapi.RouteEndpoint{
+			Host:  host,
+			Ports: []*kusciaapi.EndpointPort{{Port: int32(portNum), Protocol: "HTTP"}},
+		},
+		Status: &kusciaapi.RouteStatus{Status: constants.RouteSucceeded},
+	}
+	patch := gomonkey.ApplyMethod(reflect.TypeOf(mod), "QueryDomainRoute", func(_ *ConnectivityMod, ctx context.Context, req *kusciaapi.QueryDomainRouteRequest) (*kusciaapi.QueryDomainRouteResponse, error) {
+		return &kusciaapi.QueryDomainRouteResponse{
+			Status: &v1alpha1.Status{},
+			Data:   route,
+		}, nil
+	})
+	t.Cleanup(patch.Reset)
+	return mod
+}
+
+func TestConnectivityModSuccess(t *testing.T) {
+	gateway := newMockGateway(true)
+	defer gateway.Close()
+
+	mod := newTestConnectivityMod(t, gateway)
+	assert.NoError(t, mod.Run(context.Background()))
+}
+
+func TestConnectivityModTokenAuthFailed(t *testing.T) {
+	gateway := newMockGateway(false)
+	defer gateway.Close()
+
+	mod := newTestConnectivityMod(t, gateway)
+	err := mod.Run(context.Background())
+	assert.ErrorContains(t, err, "broken at layer "+StageTokenAuth)
+	assert.ErrorContains(t, err, "token is invalid")
+}
+
+func TestConnectivityModMTUMismatch(t *testing.T) {
+	tests := []struct {
+		limit int
+		stage string
+	}{
+		{1400, StageEcho},
+		{8 << 10, StageMTU},
+	}
+	for _, tt := range tests {
+		gateway := newMockGatewayWithLimit(true, tt.limit)
+		mod := newTestConnectivityMod(t, gateway)
+		mod.speed = false
+		err := mod.Run(context.Background())
+		assert.ErrorContains(t, err, "broken at layer "+tt.stage)
+		assert.ErrorContains(t, err, "spec.mtuWorkaround of cdr alice-bob")
+		gateway.Close()
+	}
+}
@@ -36,6 +36,8 @@ type DiagnoseConfig struct {
 	CRDType      string
 	ReportFile   string
 	Manual       bool
+	// ConnectivityOnly skips the network statistics job after connectivity check.
+	ConnectivityOnly bool
 	netstat.NetworkParam
 }
 
@@ -46,10 +48,11 @@ func (c *DiagnoseConfig) String() string {
 --CRD: %s
 --ReportFile: %s
 --Manual: %v
+--ConnectivityOnly: %v
 --TestSpeed: %v, Threshold: %v
 --TestRTT: %v, Threshold: %v
 --TestProxyTimeout: %v, Threshold: %v
 --TestProxyBuffer: %v
 --TestRequestBodySize: %v, Threshold: %v
---BidrectionMode: %v`, c.Command, c.Source, c.Destination, c.CRDType, c.ReportFile, c.Manual, c.Speed, c.SpeedThres, c.RTT, c.RTTTres, c.ProxyTimeout, c.ProxyTimeoutThres, c.ProxyBuffer, c.Size, c.SizeThres, c.Bidirection)
+--BidrectionMode: %v`, c.Command, c.Source, c.Destination, c.CRDType, c.ReportFile, c.Manual, c.ConnectivityOnly, c.Speed, c.SpeedThres, c.RTT, c.RTTTres, c.ProxyTimeout, c.ProxyTimeoutThres, c.ProxyBuffer, c.Size, c.SizeThres, c.Bidirection)
 }
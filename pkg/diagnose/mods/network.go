@@ -23,16 +23,35 @@ import (
 type NetworkMod struct {
 	reporter *util.Reporter
 	// NodeMod Mod
-	cdrMod Mod
+	connectivityMod  Mod
+	cdrMod           Mod
+	manual           bool
+	connectivityOnly bool
 }
 
 func NewNetworkMod(reporter *util.Reporter, kusciaAPIConn *grpc.ClientConn, config *DiagnoseConfig) Mod {
-	return &NetworkMod{
-		reporter: reporter,
-		cdrMod:   NewDomainRouteMod(reporter, kusciaAPIConn, config),
+	m := &NetworkMod{
+		reporter:         reporter,
+		manual:           config.Manual,
+		connectivityOnly: config.ConnectivityOnly,
 	}
+	// peer is unreachable through domain route in manual mode
+	if !config.Manual {
+		m.connectivityMod = NewConnectivityMod(reporter, kusciaAPIConn, config)
+	}
+	m.cdrMod = NewDomainRouteMod(reporter, kusciaAPIConn, config)
+	return m
 }
 
 func (m *NetworkMod) Run(ctx context.Context) error {
+	if m.connectivityMod != nil {
+		// network statistics job can't run if any layer of connectivity is broken
+		if err := m.connectivityMod.Run(ctx); err != nil {
+			return err
+		}
+		if m.connectivityOnly {
+			return nil
+		}
+	}
 	return m.cdrMod.Run(ctx)
 }
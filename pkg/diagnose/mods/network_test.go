@@ -46,6 +46,10 @@ func TestNetworkMod(t *testing.T) {
 		return nil
 	})
 	defer patch1.Reset()
+	patch2 := gomonkey.ApplyMethod(reflect.TypeOf(netMod.connectivityMod), "Run", func(_ *ConnectivityMod, ctx context.Context) error {
+		return nil
+	})
+	defer patch2.Reset()
 	err := mod.Run(context.Background())
 	assert.Nil(t, err)
 }
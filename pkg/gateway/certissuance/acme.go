@@ -0,0 +1,175 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certissuance
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/acme"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/paths"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+// HTTP01ChallengePrefix is the path prefix of http-01 challenges.
+const HTTP01ChallengePrefix = "/.well-known/acme-challenge/"
+
+// ACMEIssuer requests certs from the ACME server of an enterprise CA.
+type ACMEIssuer struct {
+	conf   *config.ACMEConfig
+	client *acme.Client
+
+	// registerMtx serializes the registration of account, which is done on the first issuance.
+	registerMtx sync.Mutex
+	registered  bool
+
+	// challenges maps the tokens of pending http-01 challenges to their key authorizations.
+	challenges sync.Map
+}
+
+// NewACMEIssuer returns the issuer of the ACME account, the account key is generated into accountKeyFile if not
+// exists, so the account is kept across restarts.
+func NewACMEIssuer(conf *config.ACMEConfig, accountKeyFile string) (*ACMEIssuer, error) {
+	if !paths.CheckFileExist(accountKeyFile) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		if err = tlsutils.WritePrivateKeyToFile(key, accountKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	key, err := tlsutils.ParseKey(nil, accountKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load acme account key %s fail, %v", accountKeyFile, err)
+	}
+	return &ACMEIssuer{
+		conf: conf,
+		client: &acme.Client{
+			Key:          key,
+			DirectoryURL: conf.DirectoryURL,
+			UserAgent:    "kuscia",
+		},
+	}, nil
+}
+
+func (i *ACMEIssuer) register(ctx context.Context) error {
+	i.registerMtx.Lock()
+	defer i.registerMtx.Unlock()
+	if i.registered {
+		return nil
+	}
+	account := &acme.Account{}
+	if i.conf.Email != "" {
+		account.Contact = []string{"mailto:" + i.conf.Email}
+	}
+	if i.conf.EABKeyID != "" {
+		hmacKey, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(i.conf.EABHMACKey, "="))
+		if err != nil {
+			return fmt.Errorf("decode eabHMACKey fail, %v", err)
+		}
+		account.ExternalAccountBinding = &acme.ExternalAccountBinding{KID: i.conf.EABKeyID, Key: hmacKey}
+	}
+	if _, err := i.client.Register(ctx, account, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("register acme account fail, %v", err)
+	}
+	i.registered = true
+	return nil
+}
+
+// Issue requests the cert for the DNS names in csr, or the common name if there is none.
+func (i *ACMEIssuer) Issue(ctx context.Context, csr *x509.CertificateRequest) (*x509.Certificate, string, error) {
+	if err := i.register(ctx); err != nil {
+		return nil, "", err
+	}
+	names := csr.DNSNames
+	if len(names) == 0 {
+		names = []string{csr.Subject.CommonName}
+	}
+	order, err := i.client.AuthorizeOrder(ctx, acme.DomainIDs(names...))
+	if err != nil {
+		return nil, "", fmt.Errorf("create acme order for %v fail, %v", names, err)
+	}
+	for _, authzURL := range order.AuthzURLs {
+		if err = i.authorize(ctx, authzURL); err != nil {
+			return nil, "", err
+		}
+	}
+	if order, err = i.client.WaitOrder(ctx, order.URI); err != nil {
+		return nil, "", fmt.Errorf("wait acme order for %v fail, %v", names, err)
+	}
+	chain, _, err := i.client.CreateOrderCert(ctx, order.FinalizeURL, csr.Raw, true)
+	if err != nil {
+		return nil, "", fmt.Errorf("finalize acme order for %v fail, %v", names, err)
+	}
+	nlog.Infof("Issued cert for %v by acme server %s", names, i.conf.DirectoryURL)
+	return encodeCerts(chain)
+}
+
+func (i *ACMEIssuer) authorize(ctx context.Context, authzURL string) error {
+	authz, err := i.client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return err
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+	var challenge *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "http-01" {
+			challenge = c
+			break
+		}
+	}
+	if challenge == nil {
+		return fmt.Errorf("acme authorization of %s offers no http-01 challenge", authz.Identifier.Value)
+	}
+	keyAuth, err := i.client.HTTP01ChallengeResponse(challenge.Token)
+	if err != nil {
+		return err
+	}
+	i.challenges.Store(challenge.Token, keyAuth)
+	defer i.challenges.Delete(challenge.Token)
+
+	if _, err = i.client.Accept(ctx, challenge); err != nil {
+		return fmt.Errorf("accept acme challenge of %s fail, %v", authz.Identifier.Value, err)
+	}
+	if _, err = i.client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("acme authorization of %s fail, %v", authz.Identifier.Value, err)
+	}
+	return nil
+}
+
+// ServeHTTP responds http-01 challenges of pending authorizations.
+func (i *ACMEIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	keyAuth, ok := i.challenges.Load(strings.TrimPrefix(r.URL.Path, HTTP01ChallengePrefix))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(keyAuth.(string)))
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certissuance
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+func newTestCertRequest(t *testing.T, domainID string) *x509.CertificateRequest {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: domainID}}, key)
+	assert.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(der)
+	assert.NoError(t, err)
+	return csr
+}
+
+func TestCAIssuer(t *testing.T) {
+	caKey, caBytes, err := tlsutils.CreateCA("kuscia-system")
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caBytes)
+	assert.NoError(t, err)
+
+	issuer, err := New(&config.CertIssuanceConfig{Validity: 24 * time.Hour}, caCert, caKey, "")
+	assert.NoError(t, err)
+	cert, certStr, err := issuer.Issue(context.Background(), newTestCertRequest(t, "alice"))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", cert.Subject.CommonName)
+	assert.NoError(t, cert.CheckSignatureFrom(caCert))
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), cert.NotAfter, time.Minute)
+
+	parsed, err := ParseCert(certStr)
+	assert.NoError(t, err)
+	assert.Equal(t, cert.Raw, parsed.Raw)
+	_, err = ParseCert("invalid")
+	assert.Error(t, err)
+}
+
+func TestNeedRenew(t *testing.T) {
+	now := time.Now()
+	cert := &x509.Certificate{NotBefore: now.Add(-60 * time.Hour), NotAfter: now.Add(31 * time.Hour)}
+	// renewed within 1/3 of lifetime by default
+	assert.False(t, NeedRenew(nil, cert, now))
+	assert.True(t, NeedRenew(nil, cert, now.Add(time.Hour)))
+	assert.True(t, NeedRenew(&config.CertIssuanceConfig{RenewBefore: 48 * time.Hour}, cert, now))
+}
+
+func TestRenewer(t *testing.T) {
+	caKey, caBytes, err := tlsutils.CreateCA("kuscia-system")
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caBytes)
+	assert.NoError(t, err)
+	csr := newTestCertRequest(t, "alice")
+	_, dueCert, err := NewCAIssuer(caCert, caKey, time.Nanosecond).Issue(context.Background(), csr)
+	assert.NoError(t, err)
+
+	renewed := 0
+	var r *Renewer
+	r = NewRenewer(nil, "alice", func() error {
+		renewed++
+		_, certStr, err := NewCAIssuer(caCert, caKey, 0).Issue(context.Background(), csr)
+		assert.NoError(t, err)
+		r.SetCert(certStr)
+		return nil
+	})
+	// nothing to renew before registered
+	r.check(time.Now())
+	assert.Equal(t, 0, renewed)
+
+	r.SetCert(dueCert)
+	r.check(time.Now())
+	assert.Equal(t, 1, renewed)
+	r.check(time.Now())
+	assert.Equal(t, 1, renewed)
+}
+
+func TestACMEIssuerChallenge(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "acme-account.key")
+	issuer, err := NewACMEIssuer(&config.ACMEConfig{DirectoryURL: "https://ca.example.com/acme/directory"}, keyFile)
+	assert.NoError(t, err)
+	// the account key is kept across restarts
+	reloaded, err := NewACMEIssuer(&config.ACMEConfig{DirectoryURL: "https://ca.example.com/acme/directory"}, keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, issuer.client.Key, reloaded.client.Key)
+
+	issuer.challenges.Store("token", "token.thumbprint")
+	w := httptest.NewRecorder()
+	issuer.ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTP01ChallengePrefix+"token", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "token.thumbprint", w.Body.String())
+
+	w = httptest.NewRecorder()
+	issuer.ServeHTTP(w, httptest.NewRequest(http.MethodGet, HTTP01ChallengePrefix+"unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
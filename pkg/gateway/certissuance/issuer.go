@@ -0,0 +1,122 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certissuance issues domain certs for lite domains registered to master and renews them before they expire.
+package certissuance
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+)
+
+const (
+	defaultValidity   = 10 * 365 * 24 * time.Hour
+	defaultWarnBefore = 30 * 24 * time.Hour
+	// notBeforeSkew tolerates the clock skew between master and lite.
+	notBeforeSkew = 5 * time.Minute
+)
+
+// Issuer issues the domain cert of csr, it returns the cert and its base64 encoded pem.
+type Issuer interface {
+	Issue(ctx context.Context, csr *x509.CertificateRequest) (*x509.Certificate, string, error)
+}
+
+// New returns the issuer configured, the CA of master is used by default.
+func New(conf *config.CertIssuanceConfig, caCert *x509.Certificate, caKey *rsa.PrivateKey, accountKeyFile string) (Issuer, error) {
+	if conf != nil && conf.ACME != nil {
+		return NewACMEIssuer(conf.ACME, accountKeyFile)
+	}
+	var validity time.Duration
+	if conf != nil {
+		validity = conf.Validity
+	}
+	return NewCAIssuer(caCert, caKey, validity), nil
+}
+
+type caIssuer struct {
+	caCert   *x509.Certificate
+	caKey    *rsa.PrivateKey
+	validity time.Duration
+}
+
+// NewCAIssuer returns the issuer signing certs by the CA, the validity is 10 years if zero.
+func NewCAIssuer(caCert *x509.Certificate, caKey *rsa.PrivateKey, validity time.Duration) Issuer {
+	if validity <= 0 {
+		validity = defaultValidity
+	}
+	return &caIssuer{caCert: caCert, caKey: caKey, validity: validity}
+}
+
+func (i *caIssuer) Issue(_ context.Context, csr *x509.CertificateRequest) (*x509.Certificate, string, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               csr.Subject,
+		DNSNames:              csr.DNSNames,
+		PublicKeyAlgorithm:    csr.PublicKeyAlgorithm,
+		PublicKey:             csr.PublicKey,
+		NotBefore:             now.Add(-notBeforeSkew),
+		NotAfter:              now.Add(i.validity),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, template, i.caCert, csr.PublicKey, i.caKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return encodeCerts([][]byte{certRaw})
+}
+
+// encodeCerts parses the leaf cert of chain, and encodes the chain to base64 encoded pem.
+func encodeCerts(chain [][]byte) (*x509.Certificate, string, error) {
+	if len(chain) == 0 {
+		return nil, "", fmt.Errorf("cert chain is empty")
+	}
+	cert, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return nil, "", err
+	}
+	var certPem []byte
+	for _, der := range chain {
+		certPem = append(certPem, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	return cert, base64.StdEncoding.EncodeToString(certPem), nil
+}
+
+// ParseCert parses the leaf cert of base64 encoded pem.
+func ParseCert(certStr string) (*x509.Certificate, error) {
+	certPem, err := base64.StdEncoding.DecodeString(certStr)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(certPem)
+	if block == nil {
+		return nil, fmt.Errorf("cert should be PEM block format")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
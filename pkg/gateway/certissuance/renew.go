@@ -0,0 +1,161 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certissuance
+
+import (
+	"context"
+	"crypto/x509"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const certCheckPeriod = time.Hour
+
+var (
+	domainCertExpiration = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "domain_cert_expiration_timestamp_seconds",
+			Help: "unix time the domain cert expires at",
+		},
+		[]string{"domain"},
+	)
+	domainCertExpiring = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "domain_cert_expiring",
+			Help: "1 if the domain cert expires within the warning duration of cert issuance, otherwise 0",
+		},
+		[]string{"domain"},
+	)
+)
+
+// NeedRenew returns whether the cert expires within the renewal duration.
+func NeedRenew(conf *config.CertIssuanceConfig, cert *x509.Certificate, now time.Time) bool {
+	var renewBefore time.Duration
+	if conf != nil {
+		renewBefore = conf.RenewBefore
+	}
+	if renewBefore <= 0 {
+		renewBefore = cert.NotAfter.Sub(cert.NotBefore) / 3
+	}
+	return !now.Before(cert.NotAfter.Add(-renewBefore))
+}
+
+// RecordExpiration records the expiration of the domain cert in metrics, and warns if it expires soon.
+func RecordExpiration(conf *config.CertIssuanceConfig, domainID string, cert *x509.Certificate, now time.Time) {
+	warnBefore := defaultWarnBefore
+	if conf != nil && conf.WarnBefore > 0 {
+		warnBefore = conf.WarnBefore
+	}
+	domainCertExpiration.WithLabelValues(domainID).Set(float64(cert.NotAfter.Unix()))
+	if now.Before(cert.NotAfter.Add(-warnBefore)) {
+		domainCertExpiring.WithLabelValues(domainID).Set(0)
+		return
+	}
+	domainCertExpiring.WithLabelValues(domainID).Set(1)
+	if now.After(cert.NotAfter) {
+		nlog.Warnf("Cert of domain %s expired at %s", domainID, cert.NotAfter.Format(time.RFC3339))
+	} else {
+		nlog.Warnf("Cert of domain %s expires at %s, renew it before then", domainID, cert.NotAfter.Format(time.RFC3339))
+	}
+}
+
+// Renewer renews the cert of lite domain from master before it expires.
+type Renewer struct {
+	conf     *config.CertIssuanceConfig
+	domainID string
+	// renew registers to master again, master issues a new cert if the cert is due, the cert is set by SetCert.
+	renew func() error
+	cert  atomic.Pointer[x509.Certificate]
+}
+
+func NewRenewer(conf *config.CertIssuanceConfig, domainID string, renew func() error) *Renewer {
+	return &Renewer{conf: conf, domainID: domainID, renew: renew}
+}
+
+// SetCert sets the cert issued by master.
+func (r *Renewer) SetCert(certStr string) {
+	cert, err := ParseCert(certStr)
+	if err != nil {
+		nlog.Warnf("Parse cert of domain %s fail, %v", r.domainID, err)
+		return
+	}
+	r.cert.Store(cert)
+}
+
+func (r *Renewer) Run(ctx context.Context) {
+	t := time.NewTicker(certCheckPeriod)
+	defer t.Stop()
+	for {
+		r.check(time.Now())
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Renewer) check(now time.Time) {
+	cert := r.cert.Load()
+	if cert == nil {
+		return
+	}
+	if NeedRenew(r.conf, cert, now) {
+		if err := r.renew(); err != nil {
+			nlog.Warnf("Renew cert of domain %s fail, %v", r.domainID, err)
+		} else if renewed := r.cert.Load(); renewed.NotAfter.After(cert.NotAfter) {
+			nlog.Infof("Cert of domain %s is renewed, it expires at %s", r.domainID, renewed.NotAfter.Format(time.RFC3339))
+		}
+	}
+	RecordExpiration(r.conf, r.domainID, r.cert.Load(), now)
+}
+
+// MonitorDomainCerts records the expiration of the certs of all domains periodically.
+func MonitorDomainCerts(ctx context.Context, conf *config.CertIssuanceConfig, kusciaClient versioned.Interface) {
+	t := time.NewTicker(certCheckPeriod)
+	defer t.Stop()
+	for {
+		domains, err := kusciaClient.KusciaV1alpha1().Domains().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			nlog.Warnf("List domains to check certs fail, %v", err)
+		} else {
+			now := time.Now()
+			for _, domain := range domains.Items {
+				if domain.Spec.Cert == "" {
+					continue
+				}
+				cert, err := ParseCert(domain.Spec.Cert)
+				if err != nil {
+					nlog.Warnf("Parse cert of domain %s fail, %v", domain.Name, err)
+					continue
+				}
+				RecordExpiration(conf, domain.Name, cert, now)
+			}
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
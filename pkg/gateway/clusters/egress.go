@@ -0,0 +1,129 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusters
+
+import (
+	"fmt"
+	"strings"
+
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	grpc_http1_reverse_bridge "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_http1_reverse_bridge/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// egressRemovedHeaders are added by the internal listener for other domains, they shouldn't leak to external hosts.
+var egressRemovedHeaders = []string{"Kuscia-Source", "Kuscia-Origin-Source", "Kuscia-Host"}
+
+// GetEgressClusterName returns the cluster of the egress destination.
+func GetEgressClusterName(name string) string {
+	return fmt.Sprintf("egress-%s", name)
+}
+
+// AddEgressClusters renders a dedicated cluster for each egress destination, and routes the requests of task pods on
+// the internal listener to it. Requests to other hosts get 404 from the default virtual host, and are recorded in the
+// internal access log.
+func AddEgressClusters(conf *config.EgressConfig) error {
+	if conf == nil {
+		return nil
+	}
+	for i := range conf.Destinations {
+		dest := &conf.Destinations[i]
+		cluster, err := generateEgressCluster(dest)
+		if err != nil {
+			return fmt.Errorf("generate egress cluster %s err: %v", dest.Name, err)
+		}
+		if err := xds.AddOrUpdateCluster(cluster); err != nil {
+			return err
+		}
+		if err := xds.AddOrUpdateVirtualHost(generateEgressVirtualHost(dest), xds.InternalRoute); err != nil {
+			return err
+		}
+		nlog.Infof("Add egress destination %s to %s:%d", dest.Name, dest.Host, dest.Port)
+	}
+	return nil
+}
+
+func generateEgressCluster(dest *config.EgressDestination) (*envoycluster.Cluster, error) {
+	protocol := strings.ToUpper(dest.Protocol)
+	if protocol == "" {
+		protocol = xds.ProtocolHTTP
+	}
+	cluster, err := generateDefaultCluster(GetEgressClusterName(dest.Name), &config.ClusterConfig{
+		Host:     dest.Host,
+		Port:     dest.Port,
+		Protocol: protocol,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cluster.Name = GetEgressClusterName(dest.Name)
+	cluster.LoadAssignment.ClusterName = cluster.Name
+	// the host may serve many sites by SNI
+	if err := xds.SetUpstreamServerName(cluster, dest.Host); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+func generateEgressVirtualHost(dest *config.EgressDestination) *route.VirtualHost {
+	clusterName := GetEgressClusterName(dest.Name)
+	// task pods don't speak grpc-web to external hosts
+	bridge, _ := proto.Marshal(&grpc_http1_reverse_bridge.FilterConfigPerRoute{
+		Disabled: true,
+	})
+	return &route.VirtualHost{
+		Name: fmt.Sprintf("%s-internal", clusterName),
+		Domains: []string{
+			dest.Name + config.EgressServiceSuffix,
+			dest.Host,
+			fmt.Sprintf("%s:%d", dest.Host, dest.Port),
+		},
+		RequestHeadersToRemove: egressRemovedHeaders,
+		Routes: []*route.Route{
+			{
+				Match: &route.RouteMatch{
+					PathSpecifier: &route.RouteMatch_Prefix{
+						Prefix: "/",
+					},
+				},
+				Action: &route.Route_Route{
+					Route: xds.AddDefaultTimeout(
+						&route.RouteAction{
+							ClusterSpecifier: &route.RouteAction_Cluster{
+								Cluster: clusterName,
+							},
+							HostRewriteSpecifier: &route.RouteAction_AutoHostRewrite{
+								AutoHostRewrite: wrapperspb.Bool(true),
+							},
+						},
+					),
+				},
+				TypedPerFilterConfig: map[string]*anypb.Any{
+					"envoy.filters.http.grpc_http1_reverse_bridge": {
+						TypeUrl: "type.googleapis.com/envoy.extensions.filters.http.grpc_http1_reverse_bridge.v3.FilterConfigPerRoute",
+						Value:   bridge,
+					},
+				},
+			},
+		},
+	}
+}
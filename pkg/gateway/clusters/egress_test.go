@@ -0,0 +1,71 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusters
+
+import (
+	"testing"
+
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestAddEgressClusters(t *testing.T) {
+	err := AddEgressClusters(&config.EgressConfig{
+		Destinations: []config.EgressDestination{
+			{Name: "model-registry", Host: "registry.example.com", Port: 443, Protocol: "https"},
+			{Name: "weather", Host: "10.0.0.1", Port: 8080},
+		},
+	})
+	assert.NoError(t, err)
+
+	cluster, err := xds.QueryCluster("egress-model-registry")
+	assert.NoError(t, err)
+	ep := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint()
+	assert.Equal(t, "registry.example.com", ep.Address.GetSocketAddress().Address)
+	assert.Equal(t, uint32(443), ep.Address.GetSocketAddress().GetPortValue())
+	tlsContext := &tls.UpstreamTlsContext{}
+	assert.NoError(t, cluster.TransportSocket.GetTypedConfig().UnmarshalTo(tlsContext))
+	assert.Equal(t, "registry.example.com", tlsContext.Sni)
+
+	cluster, err = xds.QueryCluster("egress-weather")
+	assert.NoError(t, err)
+	assert.Nil(t, cluster.TransportSocket)
+
+	vh, err := xds.QueryVirtualHost("egress-model-registry-internal", xds.InternalRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"model-registry.kuscia-egress.svc", "registry.example.com", "registry.example.com:443"}, vh.Domains)
+	assert.Equal(t, "egress-model-registry", vh.Routes[0].GetRoute().GetCluster())
+	assert.Contains(t, vh.RequestHeadersToRemove, "Kuscia-Source")
+}
+
+func TestEgressConfigCheck(t *testing.T) {
+	valid := config.EgressDestination{Name: "registry", Host: "registry.example.com", Port: 443, Protocol: "HTTPS"}
+	assert.NoError(t, (&config.EgressConfig{Destinations: []config.EgressDestination{valid}}).Check())
+	assert.NoError(t, (*config.EgressConfig)(nil).Check())
+
+	invalid := []config.EgressDestination{
+		{Name: "Registry", Host: "registry.example.com", Port: 443},
+		{Name: "registry", Port: 443},
+		{Name: "registry", Host: "registry.example.com"},
+		{Name: "registry", Host: "registry.example.com", Port: 443, Protocol: "TCP"},
+	}
+	for _, d := range invalid {
+		assert.Error(t, (&config.EgressConfig{Destinations: []config.EgressDestination{d}}).Check())
+	}
+	assert.Error(t, (&config.EgressConfig{Destinations: []config.EgressDestination{valid, valid}}).Check())
+}
@@ -29,6 +29,7 @@ import (
 const (
 	transportService = "transport"
 	schedulerService = "interconn-scheduler"
+	dataMeshService  = "datamesh"
 )
 
 func AddInterConnClusters(namespace string, config *config.InterConnClusterConfig) error {
@@ -42,6 +43,11 @@ func AddInterConnClusters(namespace string, config *config.InterConnClusterConfi
 			return err
 		}
 	}
+	if config.DataMeshConfig != nil {
+		if err := addDataMeshCluster(namespace, config.DataMeshConfig); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -88,6 +94,24 @@ func addSchedulerCluster(namespace string, clusterConfig *config.ClusterConfig)
 	return nil
 }
 
+// addDataMeshCluster exposes the copy server of datamesh to other domains, which fetch the granted domaindata from it.
+func addDataMeshCluster(namespace string, clusterConfig *config.ClusterConfig) error {
+	cluster, err := generateDefaultCluster(dataMeshService, clusterConfig)
+	if err != nil {
+		return fmt.Errorf("generate %s cluster err: %v", dataMeshService, err)
+	}
+	if err := xds.AddOrUpdateCluster(cluster); err != nil {
+		return err
+	}
+	externalVh := generateInterConnInternalVirtualHost(dataMeshService, cluster.Name, namespace)
+	externalVh.Name = fmt.Sprintf("%s-external", dataMeshService)
+	if err := xds.AddOrUpdateVirtualHost(externalVh, xds.ExternalRoute); err != nil {
+		return err
+	}
+	nlog.Infof("Add datamesh Cluster success")
+	return nil
+}
+
 func generateInterConnInternalVirtualHost(service, cluster, namespace string) *route.VirtualHost {
 	virtualHost := &route.VirtualHost{
 		Name: fmt.Sprintf("%s-internal", service),
@@ -27,6 +27,7 @@ import (
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/secretflow/kuscia/pkg/gateway/config"
@@ -49,6 +50,7 @@ func AddMasterProxyClusters(ctx context.Context, namespace string, config *confi
 		nlog.Fatalf("Generate masterProxy Cluster fail, %v", err)
 		return err
 	}
+	addMasterStandbyEndpoints(masterProxyCluster, config, namespace)
 
 	if err := xds.SetKeepAliveForDstCluster(masterProxyCluster, false); err != nil {
 		nlog.Error(err)
@@ -150,6 +152,9 @@ func AddMasterProxyVirtualHost(cluster, pathPrefix, service, namespace, token st
 			AppendAction: core.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
 		},
 	}
+	if err := xds.DecorateMasterSyncRoute(internalVh.Routes[0]); err != nil {
+		return err
+	}
 
 	return xds.AddOrUpdateVirtualHost(internalVh, xds.InternalRoute)
 }
@@ -186,6 +191,8 @@ func generateMasterInternalVirtualHost(cluster, pathPrefix, service string, doma
 		},
 	}
 	if service == utils.ServiceAPIServer {
+		// keep watch streams of lite nodes alive until they end normally.
+		virtualHost.Routes[0].GetRoute().IdleTimeout = durationpb.New(xds.MasterWatchIdleTimeout())
 		regex := getMasterAPIWhitelistRegex(apiWhitelist)
 		if len(regex) > 0 {
 			virtualHost.Routes[0].Match.PathSpecifier = &route.RouteMatch_SafeRegex{
@@ -212,25 +219,7 @@ func generateDefaultCluster(name string, config *config.ClusterConfig) (*envoycl
 			ClusterName: name,
 			Endpoints: []*endpoint.LocalityLbEndpoints{
 				{
-					LbEndpoints: []*endpoint.LbEndpoint{
-						{
-							HostIdentifier: &endpoint.LbEndpoint_Endpoint{
-								Endpoint: &endpoint.Endpoint{
-									Address: &core.Address{
-										Address: &core.Address_SocketAddress{
-											SocketAddress: &core.SocketAddress{
-												Address: config.Host,
-												PortSpecifier: &core.SocketAddress_PortValue{
-													PortValue: config.Port,
-												},
-											},
-										},
-									},
-									Hostname: config.Host,
-								},
-							},
-						},
-					},
+					LbEndpoints: []*endpoint.LbEndpoint{generateMasterLbEndpoint(config)},
 				},
 			},
 		},
@@ -249,6 +238,78 @@ func generateDefaultCluster(name string, config *config.ClusterConfig) (*envoycl
 	return cluster, nil
 }
 
+// addMasterStandbyEndpoints adds the standby masters to the cluster with lower priorities, envoy only sends
+// requests to the highest priority master which passes health checks, so only one master is active at a time.
+func addMasterStandbyEndpoints(cluster *envoycluster.Cluster, config *config.MasterConfig, namespace string) {
+	if len(config.MasterProxyStandbys) == 0 {
+		return
+	}
+
+	for i, standby := range config.MasterProxyStandbys {
+		cluster.LoadAssignment.Endpoints = append(cluster.LoadAssignment.Endpoints, &endpoint.LocalityLbEndpoints{
+			Priority:    uint32(i + 1),
+			LbEndpoints: []*endpoint.LbEndpoint{generateMasterLbEndpoint(standby)},
+		})
+	}
+	for _, lbEndpoints := range cluster.LoadAssignment.Endpoints {
+		for _, lbEndpoint := range lbEndpoints.LbEndpoints {
+			ep := lbEndpoint.GetEndpoint()
+			ep.HealthCheckConfig = &endpoint.Endpoint_HealthCheckConfig{Hostname: ep.Hostname}
+		}
+	}
+
+	cluster.HealthChecks = []*core.HealthCheck{
+		{
+			Timeout:            durationpb.New(time.Second),
+			Interval:           durationpb.New(15 * time.Second),
+			UnhealthyInterval:  durationpb.New(3 * time.Second),
+			UnhealthyThreshold: wrapperspb.UInt32(2),
+			HealthyThreshold:   wrapperspb.UInt32(2),
+			HealthChecker: &core.HealthCheck_HttpHealthCheck_{
+				HttpHealthCheck: &core.HealthCheck_HttpHealthCheck{
+					Path: utils.GetHandshakePathOfPrefix(config.MasterProxy.Path),
+					RequestHeadersToAdd: []*core.HeaderValueOption{
+						{
+							Header: &core.HeaderValue{
+								Key:   "Kuscia-Host",
+								Value: fmt.Sprintf("%s.master.svc", utils.ServiceHandshake),
+							},
+						},
+						{
+							Header: &core.HeaderValue{
+								Key:   "Kuscia-Source",
+								Value: namespace,
+							},
+							AppendAction: core.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+						},
+					},
+				},
+			},
+		},
+	}
+	nlog.Infof("Add %d standby masters to cluster %s", len(config.MasterProxyStandbys), cluster.Name)
+}
+
+func generateMasterLbEndpoint(config *config.ClusterConfig) *endpoint.LbEndpoint {
+	return &endpoint.LbEndpoint{
+		HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+			Endpoint: &endpoint.Endpoint{
+				Address: &core.Address{
+					Address: &core.Address_SocketAddress{
+						SocketAddress: &core.SocketAddress{
+							Address: config.Host,
+							PortSpecifier: &core.SocketAddress_PortValue{
+								PortValue: config.Port,
+							},
+						},
+					},
+				},
+				Hostname: config.Host,
+			},
+		},
+	}
+}
+
 func getMasterNamespace(soure string, pathPrefix string) (string, error) {
 	kusciaStatus := map[string]interface{}{}
 	handshakePath := utils.GetHandshakePathOfPrefix(pathPrefix)
@@ -107,3 +107,27 @@ func TestAddMasterProxyClusters(t *testing.T) {
 		}})
 	assert.NoError(t, err)
 }
+
+func TestAddMasterStandbyEndpoints(t *testing.T) {
+	masterConfig := &config.MasterConfig{
+		MasterProxy: &config.ClusterConfig{Host: "master-a.example.com", Port: 1080, Protocol: "http"},
+		MasterProxyStandbys: []*config.ClusterConfig{
+			{Host: "master-b.example.com", Port: 1080, Protocol: "http"},
+			{Host: "master-c.example.com", Port: 1080, Protocol: "http"},
+		},
+	}
+	cluster, err := generateDefaultCluster("master-test", masterConfig.MasterProxy)
+	assert.NoError(t, err)
+	addMasterStandbyEndpoints(cluster, masterConfig, "alice")
+
+	endpoints := cluster.LoadAssignment.Endpoints
+	assert.Len(t, endpoints, 3)
+	for i, host := range []string{"master-a.example.com", "master-b.example.com", "master-c.example.com"} {
+		assert.Equal(t, uint32(i), endpoints[i].Priority)
+		ep := endpoints[i].LbEndpoints[0].GetEndpoint()
+		assert.Equal(t, host, ep.Hostname)
+		assert.Equal(t, host, ep.HealthCheckConfig.Hostname)
+	}
+	assert.Len(t, cluster.HealthChecks, 1)
+	assert.Equal(t, "/handshake", cluster.HealthChecks[0].GetHttpHealthCheck().Path)
+}
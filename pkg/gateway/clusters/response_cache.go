@@ -0,0 +1,47 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusters
+
+import (
+	"fmt"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const responseCacheService = "response-cache"
+
+// GetResponseCacheClusterName returns the cluster of the response cache proxy of gateway.
+func GetResponseCacheClusterName() string {
+	return fmt.Sprintf("service-%s", responseCacheService)
+}
+
+// AddResponseCacheCluster adds the cluster of the response cache proxy listening on port of loopback address.
+func AddResponseCacheCluster(port uint32) error {
+	cluster, err := generateDefaultCluster(responseCacheService, &config.ClusterConfig{
+		Host:     "127.0.0.1",
+		Port:     port,
+		Protocol: xds.ProtocolHTTP,
+	})
+	if err != nil {
+		return fmt.Errorf("generate %s cluster err: %v", responseCacheService, err)
+	}
+	if err := xds.AddOrUpdateCluster(cluster); err != nil {
+		return err
+	}
+	nlog.Infof("Add response cache cluster success")
+	return nil
+}
@@ -27,16 +27,20 @@ import (
 
 	"github.com/secretflow/kuscia/pkg/common"
 	informers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
+	"github.com/secretflow/kuscia/pkg/gateway/certissuance"
 	"github.com/secretflow/kuscia/pkg/gateway/clusters"
 	"github.com/secretflow/kuscia/pkg/gateway/config"
 	"github.com/secretflow/kuscia/pkg/gateway/controller"
 	"github.com/secretflow/kuscia/pkg/gateway/controller/poller"
 	"github.com/secretflow/kuscia/pkg/gateway/metrics"
+	"github.com/secretflow/kuscia/pkg/gateway/responsecache"
+	"github.com/secretflow/kuscia/pkg/gateway/signing"
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/gateway/xds"
 	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/handshake"
 )
 
 const (
@@ -45,6 +49,8 @@ const (
 
 	defaultHandshakeRetryCount    = 10
 	defaultHandshakeRetryInterval = 100 * time.Millisecond
+
+	acmeAccountKeyFile = "acme-account.key"
 )
 
 var (
@@ -54,6 +60,7 @@ var (
 func Run(ctx context.Context, gwConfig *config.GatewayConfig, clients *kubeconfig.KubeClients, afterRegisterHook controller.AfterRegisterDomainHook) error {
 	prikey := gwConfig.DomainKey
 	priKeyData := tls.EncodePKCS1PublicKey(gwConfig.DomainKey)
+	gwConfig.PeerRequest.Apply()
 
 	// start xds server and envoy
 	if err := StartXds(gwConfig); err != nil {
@@ -79,11 +86,17 @@ func Run(ctx context.Context, gwConfig *config.GatewayConfig, clients *kubeconfi
 			return fmt.Errorf("add master clusters fail, detail-> %v", err)
 		}
 		nlog.Infof("Add master clusters successfully")
+		if err = xds.SetMasterSyncCompressors(); err != nil {
+			return fmt.Errorf("set master sync compressors fail, detail-> %v", err)
+		}
 	} else {
 		if err := utils.ProbePeerEndpoint(gwConfig.MasterConfig.Endpoint); err != nil {
 			return fmt.Errorf("[PROBE] failed to probe master endpoint %s, detail-> %v", gwConfig.MasterConfig.Endpoint, err)
 		}
 		nlog.Infof("[PROBE] success to probe master endpoint %s", gwConfig.MasterConfig.Endpoint)
+		if err := xds.SetMasterSyncFilters(); err != nil {
+			return fmt.Errorf("set master sync filters fail, detail-> %v", err)
+		}
 		var err error
 		if masterConfig, err = ConnectToMaster(ctx, gwConfig, clients, afterRegisterHook); err != nil {
 			return err
@@ -93,7 +106,7 @@ func Run(ctx context.Context, gwConfig *config.GatewayConfig, clients *kubeconfi
 
 	// add interconn cluster
 	interConnClusterConfig, err := config.LoadInterConnClusterConfig(gwConfig.TransportConfig,
-		gwConfig.InterConnSchedulerConfig)
+		gwConfig.InterConnSchedulerConfig, gwConfig.DataMeshCopyConfig)
 	if err != nil {
 		return fmt.Errorf("failed to load interConnClusterConfig, detail-> %v", err)
 	}
@@ -103,6 +116,14 @@ func Run(ctx context.Context, gwConfig *config.GatewayConfig, clients *kubeconfi
 	}
 	nlog.Infof("Add interconn clusters success")
 
+	// add egress clusters of external destinations
+	if gwConfig.Egress != nil {
+		if err := clusters.AddEgressClusters(gwConfig.Egress); err != nil {
+			return fmt.Errorf("add egress clusters fail, detail-> %v", err)
+		}
+		nlog.Infof("Add %d egress clusters success", len(gwConfig.Egress.Destinations))
+	}
+
 	// create informer factory
 	defaultResync := time.Duration(gwConfig.ResyncPeriod) * time.Second
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(clients.KubeClient, defaultResync,
@@ -117,6 +138,7 @@ func Run(ctx context.Context, gwConfig *config.GatewayConfig, clients *kubeconfi
 	if err != nil {
 		return fmt.Errorf("failed to new gateway controller, detail-> %v", err)
 	}
+	gwc.SetHeartbeatPeriod(gwConfig.HeartbeatInterval)
 	go gwc.Run(concurrentSyncs, ctx.Done())
 
 	// start endpoints controller
@@ -136,19 +158,54 @@ func Run(ctx context.Context, gwConfig *config.GatewayConfig, clients *kubeconfi
 	}
 	go ec.Run(concurrentSyncs, ctx.Done())
 
+	// start response cache proxy
+	var responseCache *responsecache.Cache
+	if gwConfig.ResponseCache != nil && gwConfig.ResponseCache.Enable {
+		responseCache = responsecache.New(gwConfig.ResponseCache, utils.InternalServer)
+		if err := clusters.AddResponseCacheCluster(responseCache.Port()); err != nil {
+			return fmt.Errorf("add response cache cluster fail, detail-> %v", err)
+		}
+		go responseCache.Run(ctx.Done())
+	}
+
+	// start request signing server
+	requestSigning := signing.New(gwConfig.DomainID, signing.DefaultPort)
+	if err := clusters.AddRequestSigningCluster(requestSigning.Port()); err != nil {
+		return fmt.Errorf("add request signing cluster fail, detail-> %v", err)
+	}
+	go requestSigning.Run(ctx.Done())
+
 	// start DomainRoute controller
 	drInformer := kusciaInformerFactory.Kuscia().V1alpha1().DomainRoutes()
 	drConfig := &controller.DomainRouteConfig{
-		Namespace:     gwConfig.DomainID,
-		MasterConfig:  masterConfig,
-		IsMaster:      isMaster,
-		CAKey:         gwConfig.CAKey,
-		CACert:        gwConfig.CACert,
-		Prikey:        prikey,
-		PrikeyData:    priKeyData,
-		HandshakePort: gwConfig.HandshakePort,
+		Namespace:      gwConfig.DomainID,
+		MasterConfig:   masterConfig,
+		IsMaster:       isMaster,
+		CAKey:          gwConfig.CAKey,
+		CACert:         gwConfig.CACert,
+		Prikey:         prikey,
+		PrikeyData:     priKeyData,
+		HandshakePort:  gwConfig.HandshakePort,
+		AdminPort:      gwConfig.AdminPort,
+		ResponseCache:  responseCache,
+		RequestSigning: requestSigning,
+		HTTP3:          gwConfig.HTTP3.Enabled(),
+	}
+	// lite handshakes to master with the domain key, so its key is not rotated
+	if isMaster {
+		drConfig.KeyDir = filepath.Join(gwConfig.RootDir, common.CertPrefix)
+		drConfig.CertIssuance = gwConfig.CertIssuance
+		drConfig.CertIssuer, err = certissuance.New(gwConfig.CertIssuance, gwConfig.CACert, gwConfig.CAKey,
+			filepath.Join(gwConfig.RootDir, common.CertPrefix, acmeAccountKeyFile))
+		if err != nil {
+			return fmt.Errorf("failed to new cert issuer, detail-> %v", err)
+		}
+		go certissuance.MonitorDomainCerts(ctx, gwConfig.CertIssuance, clients.KusciaClient)
+	}
+	drc, err := controller.NewDomainRouteController(drConfig, clients.KubeClient, clients.KusciaClient, drInformer)
+	if err != nil {
+		return fmt.Errorf("failed to new domain route controller, detail-> %v", err)
 	}
-	drc := controller.NewDomainRouteController(drConfig, clients.KubeClient, clients.KusciaClient, drInformer)
 	go drc.Run(ctx, concurrentSyncs*2, ctx.Done())
 
 	pm, err := poller.NewPollManager(isMaster, gwConfig.DomainID, gwc.GatewayName(), serviceInformer, drInformer, gatewayInformer)
@@ -189,11 +246,22 @@ func ConnectToMaster(ctx context.Context, gwConfig *config.GatewayConfig, client
 		return nil, fmt.Errorf("add master clusters failed, detail-> %v", err)
 	}
 	masterNamespace := masterProxyConfig.Namespace
-	// register domain cert to master
-	err = controller.RegisterDomain(domainID, pathPrefix, gwConfig.CsrData, prikey, afterRegisterHook)
+	// register domain cert to master, and register again to renew it before it expires
+	var renewer *certissuance.Renewer
+	registerHook := func(response *handshake.RegisterResponse) {
+		if afterRegisterHook != nil {
+			afterRegisterHook(response)
+		}
+		renewer.SetCert(response.Cert)
+	}
+	renewer = certissuance.NewRenewer(gwConfig.CertIssuance, domainID, func() error {
+		return controller.RegisterDomain(domainID, pathPrefix, gwConfig.CsrData, prikey, registerHook)
+	})
+	err = controller.RegisterDomain(domainID, pathPrefix, gwConfig.CsrData, prikey, registerHook)
 	if err != nil {
 		return nil, fmt.Errorf("register self domain [%s] cert to master failed, detail -> %v", domainID, err)
 	}
+	go renewer.Run(ctx)
 	// handshake to master
 	revisionToken, err := handshakeToMasterWithRetry(domainID, pathPrefix, prikey)
 	if err != nil {
@@ -242,6 +310,10 @@ func handshakeToMasterWithRetry(domainID, pathPrefix string, prikey *rsa.Private
 func StartXds(gwConfig *config.GatewayConfig) error {
 	// set route idle timeout
 	xds.IdleTimeout = gwConfig.IdleTimeout
+	if maxTimeout := gwConfig.RequestTimeout.GetMaxTimeout(); maxTimeout > 0 {
+		xds.MaxRequestTimeout = maxTimeout
+	}
+	xds.MasterSync = gwConfig.MasterConfig.Sync
 
 	xds.NewXdsServer(gwConfig.XDSPort, gwConfig.GetEnvoyNodeID())
 
@@ -260,7 +332,9 @@ func StartXds(gwConfig *config.GatewayConfig) error {
 		ExternalPort: gwConfig.ExternalPort,
 		ExternalCert: externalCert,
 		InternalCert: internalCert,
+		HTTP3:        gwConfig.HTTP3.Enabled() && externalCert != nil,
 		Logdir:       filepath.Join(gwConfig.RootDir, "var/logs/envoy/"),
+		Tracing:      &gwConfig.Tracing,
 	}
 
 	xds.InitSnapshot(gwConfig.DomainID, utils.GetHostname(), xdsConfig)
@@ -30,15 +30,18 @@ type ClusterConfig struct {
 type InterConnClusterConfig struct {
 	TransportConfig *ClusterConfig
 	SchedulerConfig *ClusterConfig
+	DataMeshConfig  *ClusterConfig
 }
 
 type MasterConfig struct {
-	Master        bool
-	Namespace     string
-	MasterProxy   *ClusterConfig
-	APIServer     *ClusterConfig
-	KusciaStorage *ClusterConfig
-	KusciaAPI     *ClusterConfig
-	Reporter      *ClusterConfig
-	APIWhitelist  []string
+	Master      bool
+	Namespace   string
+	MasterProxy *ClusterConfig
+	// MasterProxyStandbys are the standby masters which lite nodes fail over to in order.
+	MasterProxyStandbys []*ClusterConfig
+	APIServer           *ClusterConfig
+	KusciaStorage       *ClusterConfig
+	KusciaAPI           *ClusterConfig
+	Reporter            *ClusterConfig
+	APIWhitelist        []string
 }
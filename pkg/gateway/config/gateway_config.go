@@ -18,9 +18,14 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 type GatewayConfig struct {
@@ -37,9 +42,12 @@ type GatewayConfig struct {
 	HandshakePort  uint32 `yaml:"handshakePort,omitempty"`
 	XDSPort        uint32 `yaml:"xdsPort,omitempty"`
 	EnvoyAdminPort uint32 `yaml:"envoyAdminPort,omitempty"`
+	AdminPort      uint32 `yaml:"adminPort,omitempty"`
 
 	IdleTimeout  int `yaml:"idleTimeout,omitempty"`
 	ResyncPeriod int `yaml:"resyncPeriod,omitempty"`
+	// HeartbeatInterval is the period of heartbeats reported to master.
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval,omitempty"`
 
 	MasterConfig   *kusciaconfig.MasterConfig `yaml:"master,omitempty"`
 	ExternalTLS    *kusciaconfig.TLSConfig    `yaml:"externalTLS,omitempty"`
@@ -48,6 +56,96 @@ type GatewayConfig struct {
 
 	TransportConfig          *kusciaconfig.ServiceConfig `yaml:"transport,omitempty"`
 	InterConnSchedulerConfig *kusciaconfig.ServiceConfig `yaml:"interConnScheduler,omitempty"`
+	// DataMeshCopyConfig is the endpoint which serves the domaindata copied by other domains
+	DataMeshCopyConfig *kusciaconfig.ServiceConfig `yaml:"dataMeshCopy,omitempty"`
+
+	ResponseCache  *ResponseCacheConfig  `yaml:"responseCache,omitempty"`
+	HTTP3          *HTTP3Config          `yaml:"http3,omitempty"`
+	CertIssuance   *CertIssuanceConfig   `yaml:"certIssuance,omitempty"`
+	Egress         *EgressConfig         `yaml:"egress,omitempty"`
+	PeerRequest    *PeerRequestConfig    `yaml:"peerRequest,omitempty"`
+	RequestTimeout *RequestTimeoutConfig `yaml:"requestTimeout,omitempty"`
+
+	Tracing tracing.Config `yaml:"tracing,omitempty"`
+}
+
+// ResponseCacheConfig caches responses of GET requests sent to other domains, so repeated reads of the same resource
+// don't cross the network again.
+type ResponseCacheConfig struct {
+	Enable bool `yaml:"enable"`
+	// Port of the cache proxy, which listens on loopback address, default 10003.
+	Port uint32 `yaml:"port,omitempty"`
+	// MaxSizeMB limits the total size of cached bodies, the least recently used entries are evicted, default 64.
+	MaxSizeMB int `yaml:"maxSizeMB,omitempty"`
+	// MaxEntrySizeKB limits the size of one body, larger responses are not cached, default 1024.
+	MaxEntrySizeKB int                 `yaml:"maxEntrySizeKB,omitempty"`
+	Rules          []ResponseCacheRule `yaml:"rules,omitempty"`
+}
+
+// ResponseCacheRule selects the requests to cache, only GET requests whose path has the prefix are cached.
+type ResponseCacheRule struct {
+	// Destination is the domain requested, empty matches all domains.
+	Destination string `yaml:"destination,omitempty"`
+	PathPrefix  string `yaml:"pathPrefix"`
+	// TTL is the seconds a response is cached, default 60.
+	TTL int `yaml:"ttl,omitempty"`
+}
+
+// HTTP3Config serves the external listener by HTTP/3 on the UDP port of the same number, and connects to other domains
+// by HTTP/3 if they accept it in handshake. HTTP/3 requires TLS of the external listener.
+type HTTP3Config struct {
+	Enable bool `yaml:"enable"`
+}
+
+// Enabled returns whether HTTP/3 is enabled, nil means disabled.
+func (c *HTTP3Config) Enabled() bool {
+	return c != nil && c.Enable
+}
+
+// CertIssuanceConfig issues and renews domain certs. Master signs the certs of lite domains by its CA, or requests them
+// from an enterprise CA by ACME if configured, and lite renews its cert from master before it expires.
+type CertIssuanceConfig struct {
+	// Validity of the certs signed by the CA of master, default 87600h.
+	Validity time.Duration `yaml:"validity,omitempty"`
+	// RenewBefore renews the cert when it expires within the duration, default 1/3 of the lifetime of the cert.
+	RenewBefore time.Duration `yaml:"renewBefore,omitempty"`
+	// WarnBefore warns of the certs which expire within the duration, default 720h.
+	WarnBefore time.Duration `yaml:"warnBefore,omitempty"`
+	// ACME requests the certs from an enterprise CA instead of signing them by the CA of master.
+	ACME *ACMEConfig `yaml:"acme,omitempty"`
+}
+
+// ACMEConfig is the account of master in the ACME server of an enterprise CA. The certs are requested for the DNS
+// names in csr, or the domain id if there is none, and the names are validated by http-01 challenges served on the
+// handshake port of master.
+type ACMEConfig struct {
+	DirectoryURL string `yaml:"directoryURL"`
+	Email        string `yaml:"email,omitempty"`
+	// EABKeyID and EABHMACKey bind the account to an external account of the CA, EABHMACKey is base64url encoded.
+	EABKeyID   string `yaml:"eabKeyID,omitempty"`
+	EABHMACKey string `yaml:"eabHMACKey,omitempty"`
+}
+
+// Check validates the durations and the ACME account.
+func (c *CertIssuanceConfig) Check() error {
+	if c == nil {
+		return nil
+	}
+	if c.Validity < 0 || c.RenewBefore < 0 || c.WarnBefore < 0 {
+		return fmt.Errorf("certIssuance durations should not be negative")
+	}
+	if c.Validity > 0 && c.RenewBefore >= c.Validity {
+		return fmt.Errorf("certIssuance.renewBefore should be less than certIssuance.validity")
+	}
+	if c.ACME != nil {
+		if c.ACME.DirectoryURL == "" {
+			return fmt.Errorf("certIssuance.acme.directoryURL is required")
+		}
+		if (c.ACME.EABKeyID == "") != (c.ACME.EABHMACKey == "") {
+			return fmt.Errorf("certIssuance.acme.eabKeyID and certIssuance.acme.eabHMACKey should be set together")
+		}
+	}
+	return nil
 }
 
 func DefaultStaticGatewayConfig() *GatewayConfig {
@@ -60,6 +158,7 @@ func DefaultStaticGatewayConfig() *GatewayConfig {
 		HandshakePort:  1054,
 		XDSPort:        10001,
 		EnvoyAdminPort: 10000,
+		AdminPort:      10002,
 		IdleTimeout:    60,
 		ResyncPeriod:   600,
 		MasterConfig:   &kusciaconfig.MasterConfig{},
@@ -96,9 +195,160 @@ func (config *GatewayConfig) CheckConfig() error {
 		}
 	}
 
+	if config.DataMeshCopyConfig != nil {
+		if err := kusciaconfig.CheckServiceConfig(config.DataMeshCopyConfig, "dataMeshCopy"); err != nil {
+			return err
+		}
+	}
+
+	if err := config.ResponseCache.Check(); err != nil {
+		return err
+	}
+
+	if err := config.CertIssuance.Check(); err != nil {
+		return err
+	}
+
+	if err := config.Egress.Check(); err != nil {
+		return err
+	}
+
+	if err := config.PeerRequest.Check(); err != nil {
+		return err
+	}
+
+	if config.HTTP3.Enabled() && (config.ExternalTLS == nil || !config.ExternalTLS.EnableTLS) {
+		return fmt.Errorf("http3 requires externalTLS to be enabled")
+	}
+
 	return kusciaconfig.CheckMasterConfig(config.MasterConfig)
 }
 
+// Check validates the rules of response cache.
+func (c *ResponseCacheConfig) Check() error {
+	if c == nil || !c.Enable {
+		return nil
+	}
+	for i, rule := range c.Rules {
+		if !strings.HasPrefix(rule.PathPrefix, "/") {
+			return fmt.Errorf("responseCache.rules[%d].pathPrefix %q should start with /", i, rule.PathPrefix)
+		}
+		if rule.TTL < 0 {
+			return fmt.Errorf("responseCache.rules[%d].ttl should not be negative", i)
+		}
+	}
+	return nil
+}
+
+// EgressServiceSuffix is the suffix of the names task pods access the egress destinations by, these names are resolved
+// to the gateway like the services of other domains.
+const EgressServiceSuffix = ".kuscia-egress.svc"
+
+// EgressConfig allows task pods to access the external (non-kuscia) destinations through the gateway. The egress of
+// task pods to other external addresses should be blocked by the network policy of task pods.
+type EgressConfig struct {
+	Destinations []EgressDestination `yaml:"destinations,omitempty"`
+}
+
+// EgressDestination is an external host task pods access by http://<name>.kuscia-egress.svc, or by the host itself if
+// the internal port of gateway is used as their http proxy.
+type EgressDestination struct {
+	// Name is a DNS-1123 label unique in destinations.
+	Name string `yaml:"name"`
+	Host string `yaml:"host"`
+	Port uint32 `yaml:"port"`
+	// Protocol of the host is HTTP, HTTPS, GRPC or GRPCS, default HTTP. Task pods always send plaintext requests to
+	// the gateway, and the gateway originates TLS to the host for HTTPS and GRPCS.
+	Protocol string `yaml:"protocol,omitempty"`
+}
+
+// Check validates the destinations.
+func (c *EgressConfig) Check() error {
+	if c == nil {
+		return nil
+	}
+	names := map[string]bool{}
+	for i, d := range c.Destinations {
+		if errs := validation.IsDNS1123Label(d.Name); len(errs) > 0 {
+			return fmt.Errorf("egress.destinations[%d].name %q is invalid, %s", i, d.Name, strings.Join(errs, ","))
+		}
+		if names[d.Name] {
+			return fmt.Errorf("egress.destinations[%d].name %q is duplicated", i, d.Name)
+		}
+		names[d.Name] = true
+		if d.Host == "" {
+			return fmt.Errorf("egress.destinations[%d].host is required", i)
+		}
+		if d.Port == 0 || d.Port > 65535 {
+			return fmt.Errorf("egress.destinations[%d].port %d is invalid", i, d.Port)
+		}
+		switch strings.ToUpper(d.Protocol) {
+		case "", "HTTP", "HTTPS", "GRPC", "GRPCS":
+		default:
+			return fmt.Errorf("egress.destinations[%d].protocol should be HTTP, HTTPS, GRPC or GRPCS", i)
+		}
+	}
+	return nil
+}
+
+// PeerRequestConfig limits the handshake, register and other requests the gateway sends to other domains and master,
+// so a broken or malicious peer can't exhaust the memory of gateway by a huge response, or hold it by a slow one.
+type PeerRequestConfig struct {
+	// MaxResponseBodySizeKB limits the size of response body, default 4096.
+	MaxResponseBodySizeKB int64 `yaml:"maxResponseBodySizeKB,omitempty"`
+	// Timeout is the deadline of one request including reading the response body, default 10s.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+func (c *PeerRequestConfig) Check() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxResponseBodySizeKB < 0 {
+		return fmt.Errorf("peerRequest.maxResponseBodySizeKB should not be negative")
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("peerRequest.timeout should not be negative")
+	}
+	return nil
+}
+
+// Apply sets the limits of requests to peers, nil or zero values keep the defaults.
+func (c *PeerRequestConfig) Apply() {
+	if c == nil {
+		utils.SetHTTPLimits(0, 0)
+		return
+	}
+	utils.SetHTTPLimits(c.MaxResponseBodySizeKB<<10, c.Timeout)
+}
+
+// RequestTimeoutConfig bounds the deadlines which applications set by the header X-Kuscia-Timeout.
+type RequestTimeoutConfig struct {
+	// MaxTimeout clamps the deadlines of requests through the gateway, default 1h.
+	MaxTimeout time.Duration `yaml:"maxTimeout,omitempty"`
+}
+
+func (c *RequestTimeoutConfig) Check() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxTimeout < 0 {
+		return fmt.Errorf("requestTimeout.maxTimeout should not be negative")
+	}
+	if c.MaxTimeout > 0 && c.MaxTimeout < time.Millisecond {
+		return fmt.Errorf("requestTimeout.maxTimeout should not be less than 1ms")
+	}
+	return nil
+}
+
+// GetMaxTimeout returns the max deadline of requests, zero means the default of xds.
+func (c *RequestTimeoutConfig) GetMaxTimeout() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.MaxTimeout
+}
+
 func (config *GatewayConfig) GetEnvoyNodeID() string {
 	hostname := utils.GetHostname()
 	envoyNodeCluster := fmt.Sprintf("kuscia-gateway-%s", config.DomainID)
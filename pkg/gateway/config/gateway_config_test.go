@@ -16,6 +16,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -43,4 +44,69 @@ func TestLoadConfig(t *testing.T) {
 	}
 	err = config.CheckConfig()
 	assert.NoError(t, err)
+
+	// HTTP/3 requires TLS of external listener
+	config.HTTP3 = &HTTP3Config{Enable: true}
+	assert.Error(t, config.CheckConfig())
+	config.ExternalTLS = &kusciaconfig.TLSConfig{EnableTLS: true, KeyFile: "external.key", CertFile: "external.crt"}
+	assert.NoError(t, config.CheckConfig())
+}
+
+func TestCheckResponseCacheConfig(t *testing.T) {
+	conf := &ResponseCacheConfig{
+		Enable: true,
+		Rules:  []ResponseCacheRule{{PathPrefix: "/api/v1/meta", TTL: 30}},
+	}
+	assert.NoError(t, conf.Check())
+
+	conf.Rules = append(conf.Rules, ResponseCacheRule{PathPrefix: "api"})
+	assert.Error(t, conf.Check())
+
+	conf.Enable = false
+	assert.NoError(t, conf.Check())
+}
+
+func TestCheckCertIssuanceConfig(t *testing.T) {
+	var conf *CertIssuanceConfig
+	assert.NoError(t, conf.Check())
+
+	conf = &CertIssuanceConfig{Validity: 24 * time.Hour, RenewBefore: 8 * time.Hour}
+	assert.NoError(t, conf.Check())
+
+	conf.RenewBefore = 24 * time.Hour
+	assert.Error(t, conf.Check())
+	conf.RenewBefore = 0
+
+	conf.ACME = &ACMEConfig{EABKeyID: "kid"}
+	assert.Error(t, conf.Check())
+	conf.ACME.DirectoryURL = "https://ca.example.com/acme/directory"
+	assert.Error(t, conf.Check())
+	conf.ACME.EABHMACKey = "aG1hYw"
+	assert.NoError(t, conf.Check())
+}
+
+func TestCheckPeerRequestConfig(t *testing.T) {
+	var conf *PeerRequestConfig
+	assert.NoError(t, conf.Check())
+
+	conf = &PeerRequestConfig{MaxResponseBodySizeKB: 1024, Timeout: 5 * time.Second}
+	assert.NoError(t, conf.Check())
+
+	conf.Timeout = -time.Second
+	assert.Error(t, conf.Check())
+}
+
+func TestCheckRequestTimeoutConfig(t *testing.T) {
+	var conf *RequestTimeoutConfig
+	assert.NoError(t, conf.Check())
+	assert.Equal(t, time.Duration(0), conf.GetMaxTimeout())
+
+	conf = &RequestTimeoutConfig{MaxTimeout: 10 * time.Minute}
+	assert.NoError(t, conf.Check())
+	assert.Equal(t, 10*time.Minute, conf.GetMaxTimeout())
+
+	conf.MaxTimeout = -time.Second
+	assert.Error(t, conf.Check())
+	conf.MaxTimeout = time.Microsecond
+	assert.Error(t, conf.Check())
 }
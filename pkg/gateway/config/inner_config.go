@@ -31,9 +31,21 @@ func LoadMasterProxyConfig(masterConfig *kusciaconfig.MasterConfig) (*MasterConf
 	if err != nil {
 		return nil, err
 	}
+	var standbys []*ClusterConfig
+	for _, endpoint := range masterConfig.StandbyEndpoints {
+		standby, err := LoadClusterConfig(masterConfig.TLSConfig, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if standby.Path != proxyCluster.Path {
+			return nil, fmt.Errorf("master standby endpoint %q must have the same path as %q", endpoint, masterConfig.Endpoint)
+		}
+		standbys = append(standbys, standby)
+	}
 	return &MasterConfig{
-		Master:      false,
-		MasterProxy: proxyCluster,
+		Master:              false,
+		MasterProxy:         proxyCluster,
+		MasterProxyStandbys: standbys,
 	}, nil
 }
 
@@ -89,9 +101,10 @@ func LoadMasterConfig(masterConfig *kusciaconfig.MasterConfig, kubeConfig *restc
 	}, nil
 }
 
-func LoadInterConnClusterConfig(transportConfig, schedulerConfig *kusciaconfig.ServiceConfig) (*InterConnClusterConfig, error) {
+func LoadInterConnClusterConfig(transportConfig, schedulerConfig, dataMeshConfig *kusciaconfig.ServiceConfig) (*InterConnClusterConfig, error) {
 	var transServiceConfig *ClusterConfig
 	var schedulerServiceConfig *ClusterConfig
+	var dataMeshServiceConfig *ClusterConfig
 	var err error
 
 	if transportConfig != nil {
@@ -108,9 +121,17 @@ func LoadInterConnClusterConfig(transportConfig, schedulerConfig *kusciaconfig.S
 		}
 	}
 
+	if dataMeshConfig != nil {
+		dataMeshServiceConfig, err = LoadServiceConfig(dataMeshConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &InterConnClusterConfig{
 		TransportConfig: transServiceConfig,
 		SchedulerConfig: schedulerServiceConfig,
+		DataMeshConfig:  dataMeshServiceConfig,
 	}, nil
 }
 
@@ -0,0 +1,246 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/admin"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/web/interceptor"
+)
+
+// Admin api of gateway, served on loopback address only.
+const (
+	adminConfigDumpPath   = "/config_dump"
+	adminDomainRoutesPath = "/domainroutes"
+	adminTestRoutePath    = "/test_route"
+
+	// adminStatusName is the status page of gateway on the admin server of kuscia process.
+	adminStatusName = "gateway"
+)
+
+// domainRouteDump is the xds resources generated for a DomainRoute.
+type domainRouteDump struct {
+	Name        string                     `json:"name"`
+	Source      string                     `json:"source"`
+	Destination string                     `json:"destination"`
+	VirtualHost json.RawMessage            `json:"virtualHost,omitempty"`
+	Clusters    map[string]json.RawMessage `json:"clusters,omitempty"`
+	// Missing are resources expected but not found in xds, the DomainRoute may not be synced yet.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// domainRouteStatus is the summary of a DomainRoute on the status page of gateway.
+type domainRouteStatus struct {
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	Destination string   `json:"destination"`
+	Authorized  bool     `json:"authorized"`
+	Unreachable bool     `json:"unreachable"`
+	Missing     []string `json:"missing,omitempty"`
+}
+
+// gatewayStatus is the status page of gateway, xds resources are omitted, see /domainroutes of gateway admin api.
+type gatewayStatus struct {
+	Namespace    string               `json:"namespace"`
+	DomainRoutes []*domainRouteStatus `json:"domainRoutes"`
+}
+
+func (c *DomainRouteController) status(ctx context.Context) (interface{}, error) {
+	drs, err := c.domainRouteLister.DomainRoutes(c.gateway.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	status := &gatewayStatus{
+		Namespace:    c.gateway.Namespace,
+		DomainRoutes: make([]*domainRouteStatus, 0, len(drs)),
+	}
+	for _, dr := range drs {
+		dump := c.dumpDomainRoute(dr)
+		status.DomainRoutes = append(status.DomainRoutes, &domainRouteStatus{
+			Name:        dump.Name,
+			Source:      dump.Source,
+			Destination: dump.Destination,
+			Authorized:  dr.Status.IsDestinationAuthorized,
+			Unreachable: dr.Status.IsDestinationUnreachable,
+			Missing:     dump.Missing,
+		})
+	}
+	sort.Slice(status.DomainRoutes, func(i, j int) bool {
+		return status.DomainRoutes[i].Name < status.DomainRoutes[j].Name
+	})
+	return status, nil
+}
+
+func (c *DomainRouteController) registerAdminStatus() {
+	admin.RegisterStatus(adminStatusName, c.status)
+}
+
+func (c *DomainRouteController) startAdminServer(port uint32) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(adminConfigDumpPath, c.configDumpHandle)
+	mux.HandleFunc(adminDomainRoutesPath, c.domainRoutesHandle)
+	mux.HandleFunc(adminTestRoutePath, c.testRouteHandle)
+	mux.HandleFunc(adminKeyRotationPath, c.keyRotationHandle)
+	if c.responseCache != nil {
+		mux.HandleFunc(adminResponseCachePath, c.responseCacheHandle)
+		mux.HandleFunc(adminResponseCacheInvalidatePath, c.responseCacheInvalidateHandle)
+	}
+
+	c.adminServer = &http.Server{
+		Addr:    fmt.Sprintf("127.0.0.1:%d", port),
+		Handler: interceptor.HTTPStandardHandler(mux, interceptor.HTTPMiddlewareConfig{}),
+	}
+	if err := c.adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		nlog.Errorf("Gateway admin server exit, %v", err)
+	}
+}
+
+// configDumpHandle returns listeners, clusters and routes of xds, resource=clusters,routes filters the types.
+func (c *DomainRouteController) configDumpHandle(w http.ResponseWriter, r *http.Request) {
+	var resourceTypes []string
+	if resource := r.URL.Query().Get("resource"); resource != "" {
+		resourceTypes = strings.Split(resource, ",")
+	}
+	dump, err := xds.DumpConfig(resourceTypes...)
+	if err != nil {
+		httpErrWrapped(w, err, http.StatusInternalServerError)
+		return
+	}
+	writeAdminResponse(w, dump)
+}
+
+// domainRoutesHandle returns the xds resources of all DomainRoutes in namespace, or the one of name=xxx.
+func (c *DomainRouteController) domainRoutesHandle(w http.ResponseWriter, r *http.Request) {
+	var drs []*kusciaapisv1alpha1.DomainRoute
+	if name := r.URL.Query().Get("name"); name != "" {
+		dr, err := c.domainRouteLister.DomainRoutes(c.gateway.Namespace).Get(name)
+		if err != nil {
+			httpErrWrapped(w, err, http.StatusNotFound)
+			return
+		}
+		drs = append(drs, dr)
+	} else {
+		var err error
+		if drs, err = c.domainRouteLister.DomainRoutes(c.gateway.Namespace).List(labels.Everything()); err != nil {
+			httpErrWrapped(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	dumps := make([]*domainRouteDump, 0, len(drs))
+	for _, dr := range drs {
+		dumps = append(dumps, c.dumpDomainRoute(dr))
+	}
+	writeAdminResponse(w, dumps)
+}
+
+func (c *DomainRouteController) dumpDomainRoute(dr *kusciaapisv1alpha1.DomainRoute) *domainRouteDump {
+	dump := &domainRouteDump{
+		Name:        dr.Name,
+		Source:      dr.Spec.Source,
+		Destination: dr.Spec.Destination,
+		Clusters:    map[string]json.RawMessage{},
+	}
+	// only the source gateway generates virtual host and clusters of a route
+	if dr.Spec.Source != c.gateway.Namespace {
+		return dump
+	}
+
+	vhName := fmt.Sprintf("%s-to-%s", dr.Spec.Source, dr.Spec.Destination)
+	if vh, err := xds.QueryVirtualHost(vhName, xds.InternalRoute); err == nil {
+		dump.VirtualHost, _ = xds.MarshalResource(vh)
+	} else {
+		dump.Missing = append(dump.Missing, fmt.Sprintf("virtual host %s", vhName))
+	}
+	for _, name := range c.getClusterNamesByDomainRoute(dr) {
+		if cluster, err := xds.QueryCluster(name); err == nil {
+			dump.Clusters[name], _ = xds.MarshalResource(cluster)
+		} else {
+			dump.Missing = append(dump.Missing, fmt.Sprintf("cluster %s", name))
+		}
+	}
+	return dump
+}
+
+// testRouteHandle returns the route matched by a hypothetical request. The request is given by json body of POST, or
+// by query parameters of GET: route, host, path, method and header=key:value which may be repeated.
+func (c *DomainRouteController) testRouteHandle(w http.ResponseWriter, r *http.Request) {
+	req := &xds.RouteRequest{}
+	switch r.Method {
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			httpErrWrapped(w, err, http.StatusBadRequest)
+			return
+		}
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.RouteConfig = query.Get("route")
+		req.Host = query.Get("host")
+		req.Path = query.Get("path")
+		req.Method = query.Get("method")
+		for _, header := range query["header"] {
+			key, value, found := strings.Cut(header, ":")
+			if !found {
+				httpErrWrapped(w, fmt.Errorf("header %q is invalid, expected format: key:value", header), http.StatusBadRequest)
+				return
+			}
+			if req.Headers == nil {
+				req.Headers = map[string]string{}
+			}
+			req.Headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if req.RouteConfig == "" {
+		req.RouteConfig = xds.InternalRoute
+	}
+	if req.Path == "" {
+		req.Path = "/"
+	}
+	if req.Host == "" {
+		httpErrWrapped(w, fmt.Errorf("host is required"), http.StatusBadRequest)
+		return
+	}
+	result, err := xds.MatchRoute(req)
+	if err != nil {
+		httpErrWrapped(w, err, http.StatusNotFound)
+		return
+	}
+	writeAdminResponse(w, result)
+}
+
+func writeAdminResponse(w http.ResponseWriter, v interface{}) {
+	body, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		httpErrWrapped(w, err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestAdminHandlers(t *testing.T) {
+	ns := "alice"
+	c := newDomainRouteTestInfo(ns, 1058)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go c.Run(context.Background(), 1, stopCh)
+	time.Sleep(200 * time.Millisecond)
+
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-carol", Namespace: ns},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:             ns,
+			Destination:        "carol",
+			InterConnProtocol:  kusciaapisv1alpha1.InterConnKuscia,
+			AuthenticationType: kusciaapisv1alpha1.DomainAuthenticationNone,
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host: EnvoyServerIP,
+				Ports: []kusciaapisv1alpha1.DomainPort{{
+					Name:     "http",
+					Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP,
+					Port:     ExternalServerPort,
+				}},
+			},
+		},
+	}
+	_, err := c.client.KusciaV1alpha1().DomainRoutes(ns).Create(context.Background(), dr, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	time.Sleep(200 * time.Millisecond)
+
+	w := httptest.NewRecorder()
+	c.domainRoutesHandle(w, httptest.NewRequest(http.MethodGet, adminDomainRoutesPath+"?name=alice-carol", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var dumps []*domainRouteDump
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &dumps))
+	assert.Len(t, dumps, 1)
+	assert.NotEmpty(t, dumps[0].VirtualHost)
+	assert.Contains(t, dumps[0].Clusters, "alice-to-carol-http")
+	assert.Empty(t, dumps[0].Missing)
+
+	w = httptest.NewRecorder()
+	c.testRouteHandle(w, httptest.NewRequest(http.MethodGet, adminTestRoutePath+"?host=secretflow.carol.svc&path=/v1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	result := &xds.RouteMatchResult{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), result))
+	assert.Equal(t, "alice-to-carol", result.VirtualHost)
+	assert.Equal(t, "alice-to-carol-http", result.Cluster)
+
+	w = httptest.NewRecorder()
+	c.testRouteHandle(w, httptest.NewRequest(http.MethodGet, adminTestRoutePath+"?host=a.b.svc&header=invalid", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	c.configDumpHandle(w, httptest.NewRequest(http.MethodGet, adminConfigDumpPath+"?resource=clusters", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	dump := &xds.ConfigDump{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), dump))
+	assert.Contains(t, dump.Clusters, "alice-to-carol-http")
+	assert.Empty(t, dump.Listeners)
+
+	status, err := c.status(context.Background())
+	assert.NoError(t, err)
+	gs := status.(*gatewayStatus)
+	assert.Equal(t, ns, gs.Namespace)
+	assert.Len(t, gs.DomainRoutes, 1)
+	assert.Equal(t, "alice-carol", gs.DomainRoutes[0].Name)
+	assert.Empty(t, gs.DomainRoutes[0].Missing)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	maxDiagnoseEchoBodySize = 1 << 20  // 1MB
+	maxDiagnoseSinkBodySize = 64 << 20 // 64MB
+)
+
+func registerDiagnoseHandlers(mux *http.ServeMux, namespace string) {
+	mux.HandleFunc(utils.DiagnoseEchoPath, diagnoseHandler(namespace, diagnoseEcho))
+	mux.HandleFunc(utils.DiagnoseSinkPath, diagnoseHandler(namespace, diagnoseSink))
+}
+
+// diagnoseHandler only accepts requests to diagnose host, handshake host shares the handshake server but skips token
+// authentication of peer.
+func diagnoseHandler(namespace string, handle http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if idx := strings.LastIndex(host, ":"); idx > 0 {
+			host = host[:idx]
+		}
+		if host != utils.GetDiagnoseHost(namespace) {
+			http.Error(w, fmt.Sprintf("diagnose api is only served for host %s", utils.GetDiagnoseHost(namespace)),
+				http.StatusForbidden)
+			return
+		}
+		w.Header().Set(utils.DiagnoseDomainHeader, namespace)
+		handle(w, r)
+	}
+}
+
+func diagnoseEcho(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDiagnoseEchoBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		nlog.Warnf("Write diagnose echo response from %s failed, %v", r.Header.Get("Kuscia-Source"), err)
+	}
+}
+
+func diagnoseSink(w http.ResponseWriter, r *http.Request) {
+	n, err := io.Copy(io.Discard, http.MaxBytesReader(w, r.Body, maxDiagnoseSinkBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	body, _ := json.Marshal(&utils.DiagnoseSinkResponse{Bytes: n})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
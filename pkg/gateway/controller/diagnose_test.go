@@ -0,0 +1,59 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+)
+
+func TestDiagnoseHandlers(t *testing.T) {
+	mux := http.NewServeMux()
+	registerDiagnoseHandlers(mux, "bob")
+
+	// echo
+	payload := bytes.Repeat([]byte("kuscia"), 100)
+	req := httptest.NewRequest(http.MethodPost, utils.DiagnoseEchoPath, bytes.NewReader(payload))
+	req.Host = utils.GetDiagnoseHost("bob")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, payload, w.Body.Bytes())
+	assert.Equal(t, "bob", w.Header().Get(utils.DiagnoseDomainHeader))
+
+	// sink
+	req = httptest.NewRequest(http.MethodPost, utils.DiagnoseSinkPath, bytes.NewReader(payload))
+	req.Host = utils.GetDiagnoseHost("bob") + ":80"
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	out := &utils.DiagnoseSinkResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), out))
+	assert.Equal(t, int64(len(payload)), out.Bytes)
+
+	// handshake host skips token authentication, so it's rejected
+	req = httptest.NewRequest(http.MethodPost, utils.DiagnoseEchoPath, bytes.NewReader(payload))
+	req.Host = "kuscia-handshake.bob.svc"
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
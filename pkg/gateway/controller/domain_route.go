@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -55,11 +56,15 @@ import (
 	clientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kusciaextv1alpha1 "github.com/secretflow/kuscia/pkg/crd/informers/externalversions/kuscia/v1alpha1"
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/certissuance"
 	"github.com/secretflow/kuscia/pkg/gateway/clusters"
 	"github.com/secretflow/kuscia/pkg/gateway/config"
 	"github.com/secretflow/kuscia/pkg/gateway/controller/interconn"
+	"github.com/secretflow/kuscia/pkg/gateway/responsecache"
+	"github.com/secretflow/kuscia/pkg/gateway/signing"
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/admin"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	"github.com/secretflow/kuscia/pkg/utils/tls"
@@ -71,6 +76,9 @@ const (
 	domainRouteSyncPeriod = 10 * time.Minute
 	domainRouteQueueName  = "domain-route-queue"
 	grpcDegradeLabel      = "kuscia.secretflow/grpc-degrade"
+	defaultMaxSegmentSize = 1200
+	// maxTransitHops limits the number of domains a request is transited through.
+	maxTransitHops = 8
 )
 
 type DomainRouteConfig struct {
@@ -82,6 +90,20 @@ type DomainRouteConfig struct {
 	Prikey        *rsa.PrivateKey
 	PrikeyData    []byte
 	HandshakePort uint32
+	// AdminPort serves xds introspection api on loopback address, it's disabled if zero.
+	AdminPort uint32
+	// ResponseCache caches responses of GET requests to other domains, it's disabled if nil.
+	ResponseCache *responsecache.Cache
+	// RequestSigning signs and verifies requests of DomainRoutes whose security level is Signed, it's disabled if nil.
+	RequestSigning *signing.Server
+	// HTTP3 proposes HTTP/3 to destinations in handshake and accepts it from sources.
+	HTTP3 bool
+	// KeyDir stores the keys generated by key rotation, key rotation is disabled if empty.
+	KeyDir string
+	// CertIssuer issues the certs of lite domains on master, the CA of master signs them if nil.
+	CertIssuer certissuance.Issuer
+	// CertIssuance decides when the certs of lite domains are renewed.
+	CertIssuance *config.CertIssuanceConfig
 }
 
 type DomainRouteController struct {
@@ -101,12 +123,33 @@ type DomainRouteController struct {
 	workqueue               workqueue.RateLimitingInterface
 
 	drCache sync.Map
+	// transitSelections records the transit domain selected for third party transit routes.
+	transitSelections sync.Map
 
 	handshakeCache  *gocache.Cache
 	handshakeServer *http.Server
 	handshakePort   uint32
 
+	adminServer *http.Server
+	adminPort   uint32
+
+	responseCache *responsecache.Cache
+
+	signing          *signing.Server
+	signingMtx       sync.Mutex
+	signingListeners map[string]bool
+
+	http3 bool
+
 	drHeartbeat map[string]time.Time
+
+	keys           *keyRing
+	keyDir         string
+	keyRotationMtx sync.RWMutex
+	keyRotation    *kusciaapisv1alpha1.DomainKeyRotationStatus
+
+	certIssuer   certissuance.Issuer
+	certIssuance *config.CertIssuanceConfig
 }
 
 // NewDomainRouteController create a new endpoints controller.
@@ -114,9 +157,13 @@ func NewDomainRouteController(
 	drConfig *DomainRouteConfig,
 	kubeClient kubernetes.Interface,
 	kusciaClient clientset.Interface,
-	DomainRouteInformer kusciaextv1alpha1.DomainRouteInformer) *DomainRouteController {
+	DomainRouteInformer kusciaextv1alpha1.DomainRouteInformer) (*DomainRouteController, error) {
 
 	hostname := utils.GetHostname()
+	keys, err := loadKeyRing(drConfig.Prikey, drConfig.KeyDir)
+	if err != nil {
+		return nil, err
+	}
 	pubPem := tls.EncodePKCS1PublicKey(drConfig.Prikey)
 
 	gateway := &kusciaapisv1alpha1.Gateway{
@@ -143,9 +190,18 @@ func NewDomainRouteController(
 		domainRouteListerSynced: DomainRouteInformer.Informer().HasSynced,
 		workqueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), domainRouteQueueName),
 		handshakePort:           drConfig.HandshakePort,
+		adminPort:               drConfig.AdminPort,
+		responseCache:           drConfig.ResponseCache,
+		signing:                 drConfig.RequestSigning,
+		http3:                   drConfig.HTTP3,
+		signingListeners:        make(map[string]bool),
 		drCache:                 sync.Map{},
 		handshakeCache:          gocache.New(5*time.Minute, 10*time.Minute),
 		drHeartbeat:             make(map[string]time.Time, 0),
+		keys:                    keys,
+		keyDir:                  drConfig.KeyDir,
+		certIssuer:              drConfig.CertIssuer,
+		certIssuance:            drConfig.CertIssuance,
 	}
 
 	DomainRouteInformer.Informer().AddEventHandlerWithResyncPeriod(
@@ -159,7 +215,7 @@ func NewDomainRouteController(
 		domainRouteSyncPeriod,
 	)
 
-	return c
+	return c, nil
 }
 
 // Run will set up the event handlers for types we are interested in, as well
@@ -187,7 +243,14 @@ func (c *DomainRouteController) Run(ctx context.Context, threadiness int, stopCh
 	}
 
 	go c.startHandShakeServer(c.handshakePort)
+	if c.adminPort > 0 {
+		go c.startAdminServer(c.adminPort)
+	}
+	c.registerAdminStatus()
 	go c.checkConnectionHealthy(stopCh)
+	if c.keyDir != "" {
+		go c.runKeyRotation(stopCh)
+	}
 	nlog.Info("Starting workers")
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
@@ -196,7 +259,11 @@ func (c *DomainRouteController) Run(ctx context.Context, threadiness int, stopCh
 	nlog.Info("Started workers")
 
 	<-stopCh
+	admin.UnregisterStatus(adminStatusName)
 	c.handshakeServer.Close()
+	if c.adminServer != nil {
+		c.adminServer.Close()
+	}
 	nlog.Info("Shutting down workers")
 }
 
@@ -293,6 +360,7 @@ func (c *DomainRouteController) syncHandler(ctx context.Context, key string) err
 						return c.sourceInitiateHandShake(dr, c.getDefaultClusterNameByDomainRoute(dr))
 					}(); err != nil {
 						nlog.Error(err)
+						c.updateRouteConditions(dr, handshakeConditions(dr, err)...)
 						return err
 					}
 				}
@@ -337,14 +405,22 @@ func (c *DomainRouteController) addDomainRoute(obj interface{}) {
 		return
 	}
 
-	if newDomainRoute.Spec.Source == c.gateway.Namespace && !utils.IsThirdPartyTransit(newDomainRoute.Spec.Transit) {
+	// the transit route may switch to another transit domain as the health of this route changes, and the route to a
+	// transit domain may be a transit route too.
+	if newDomainRoute.Spec.Source == c.gateway.Namespace {
 		drs, err := c.domainRouteLister.List(labels.Everything())
 		if err != nil {
 			utilruntime.HandleError(fmt.Errorf("list DomainRoute failed with: %s", err.Error()))
 		}
 		for _, dr := range drs {
-			if utils.IsThirdPartyTransit(dr.Spec.Transit) && dr.Spec.Transit.Domain.DomainID == newDomainRoute.Spec.Destination {
-				c.workqueue.Add(fmt.Sprintf("%s/%s", dr.Namespace, dr.Name))
+			if !utils.IsThirdPartyTransit(dr.Spec.Transit) || dr.Spec.Source != newDomainRoute.Spec.Source {
+				continue
+			}
+			for _, domain := range utils.TransitDomains(dr.Spec.Transit) {
+				if domain == newDomainRoute.Spec.Destination {
+					c.workqueue.Add(fmt.Sprintf("%s/%s", dr.Namespace, dr.Name))
+					break
+				}
 			}
 		}
 	}
@@ -360,6 +436,10 @@ func (c *DomainRouteController) updateDomainRoute(dr *kusciaapisv1alpha1.DomainR
 	// Swallow all errors to avoid requeuing
 	if err != nil {
 		nlog.Warn(err)
+		if dr.Spec.Source == c.gateway.Namespace {
+			c.updateRouteConditions(dr, routeCondition{condType: kusciaapisv1alpha1.ClusterDomainRouteTokenValid,
+				reason: "TokenInvalid", err: err})
+		}
 		return nil
 	}
 
@@ -395,6 +475,7 @@ func (c *DomainRouteController) deleteDomainRoute(key string) error {
 	}
 	delete(c.drHeartbeat, dr.Name)
 	c.drCache.Delete(key)
+	c.transitSelections.Delete(dr.Spec.Source + "/" + dr.Spec.Destination)
 	return nil
 }
 
@@ -428,7 +509,7 @@ func (c *DomainRouteController) addClusterWithEnvoy(dr *kusciaapisv1alpha1.Domai
 
 	for _, dp := range dr.Spec.Endpoint.Ports {
 		nlog.Infof("add cluster %s-to-%s name:%s protocol:%s port:%d", dr.Spec.Source, dr.Spec.Destination, dp.Name, dp.Protocol, dp.Port)
-		err := addClusterForDstGateway(dr, dp, transportSocket)
+		err := addClusterForDstGateway(dr, dp, transportSocket, c.http3 && dr.Status.HTTP3)
 		if err != nil {
 			return err
 		}
@@ -472,6 +553,10 @@ func (c *DomainRouteController) updatePollerReceiverXds(dr *kusciaapisv1alpha1.D
 }
 
 func (c *DomainRouteController) updateEnvoyRule(dr *kusciaapisv1alpha1.DomainRoute, tokens []*Token) error {
+	if err := c.updateRequestSigning(dr, tokens); err != nil {
+		return fmt.Errorf("update request signing failed with %v", err)
+	}
+
 	// TODO domain route from current ns to master can't be transit route, is it correct, or else why?
 	if dr.Spec.Destination == c.getMasterNamespace() && dr.Spec.Source == c.gateway.Namespace {
 		token := tokens[len(tokens)-1]
@@ -518,13 +603,23 @@ func (c *DomainRouteController) updateEnvoyRule(dr *kusciaapisv1alpha1.DomainRou
 			return nil
 		}
 		// case2: direct route, add virtualhost: source-to-dest-Protocol
-		if err := xds.AddOrUpdateVirtualHost(generateInternalVirtualHost(dr, token.Token, grpcDegrade),
-			xds.InternalRoute); err != nil {
+		vh := generateInternalVirtualHost(dr, token.Token, grpcDegrade)
+		if c.responseCache != nil {
+			decorateResponseCacheRoutes(vh, c.responseCache.RulesFor(dr.Spec.Destination))
+		}
+		if err := xds.SetHeaderPolicy(vh.Name, dr.Spec.HeaderPolicy != nil); err != nil {
+			return err
+		}
+		if err := xds.AddOrUpdateVirtualHost(vh, xds.InternalRoute); err != nil {
 			return err
 		}
+		c.updateHeaderPolicyStatus(dr)
 
 		return c.setKeepAliveForDstClusters(dr, true)
 	} else if dr.Spec.Destination == c.gateway.Namespace { // external
+		if err := c.updateServerCert(dr); err != nil {
+			return err
+		}
 		if !utils.IsThirdPartyTransit(dr.Spec.Transit) {
 			var tokenVals []string
 			for _, token := range tokens {
@@ -555,11 +650,17 @@ func (c *DomainRouteController) updateEnvoyRule(dr *kusciaapisv1alpha1.DomainRou
 }
 
 func (c *DomainRouteController) deleteEnvoyRule(dr *kusciaapisv1alpha1.DomainRoute) error {
+	if err := c.updateRequestSigning(dr, nil); err != nil {
+		return fmt.Errorf("delete request signing failed with %v", err)
+	}
 	name := fmt.Sprintf("%s-to-%s", dr.Spec.Source, dr.Spec.Destination)
 	if dr.Spec.Source == c.gateway.Namespace {
 		if err := xds.DeleteVirtualHost(name, xds.InternalRoute); err != nil {
 			return fmt.Errorf("delete virtual host %s failed with %v", name, err)
 		}
+		if err := xds.SetHeaderPolicy(name, false); err != nil {
+			return err
+		}
 		if utils.IsReverseTunnelTransit(dr.Spec.Transit) {
 			rule := kusciareceiver.ReceiverRule{
 				Source:      dr.Spec.Source,
@@ -581,6 +682,9 @@ func (c *DomainRouteController) deleteEnvoyRule(dr *kusciaapisv1alpha1.DomainRou
 			return xds.DeleteCluster(name)
 		}
 	} else if dr.Spec.Destination == c.gateway.Namespace {
+		if err := xds.SetServerCert(dr.Name, nil); err != nil {
+			return err
+		}
 		if dr.Spec.BodyEncryption != nil {
 			rule := &kusciacrypt.CryptRule{
 				Source:      dr.Spec.Source,
@@ -619,8 +723,96 @@ func (c *DomainRouteController) deleteEnvoyRule(dr *kusciaapisv1alpha1.DomainRou
 	return nil
 }
 
+// selectTransitDomain selects the first healthy transit domain whose path has no cycle. If none of them is healthy,
+// the first one without cycle is selected.
+func (c *DomainRouteController) selectTransitDomain(dr *kusciaapisv1alpha1.DomainRoute) (string, error) {
+	var fallback string
+	var errs []string
+	for _, domain := range utils.TransitDomains(dr.Spec.Transit) {
+		healthy, err := c.checkTransitPath(dr.Spec.Source, dr.Spec.Destination, domain)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if healthy {
+			return domain, nil
+		}
+		if fallback == "" {
+			fallback = domain
+		}
+	}
+	if fallback != "" {
+		return fallback, nil
+	}
+	return "", fmt.Errorf("no available transit domain for route %s->%s, %s", dr.Spec.Source, dr.Spec.Destination,
+		strings.Join(errs, "; "))
+}
+
+// checkTransitPath follows the routes from source to the transit domain hop by hop, and returns whether all hops are
+// reachable. It fails if the path comes back to a domain visited, so misconfigured hops can't make a routing cycle.
+func (c *DomainRouteController) checkTransitPath(source, destination, transit string) (bool, error) {
+	visited := map[string]bool{source: true, destination: true}
+	healthy := true
+	hop := transit
+	for i := 0; i < maxTransitHops; i++ {
+		if visited[hop] {
+			return false, fmt.Errorf("transit domain %s of route %s->%s makes a routing cycle", transit, source, destination)
+		}
+		visited[hop] = true
+		// the route to master is built in
+		if hop == c.getMasterNamespace() {
+			return healthy, nil
+		}
+
+		hopRoute, err := c.getDomainRoute(source, hop)
+		if err != nil {
+			return false, err
+		}
+		if hopRoute.Status.IsDestinationUnreachable {
+			healthy = false
+		}
+		if !utils.IsThirdPartyTransit(hopRoute.Spec.Transit) {
+			return healthy, nil
+		}
+
+		// follow the transit domain selected for the hop route, or the first one if it isn't selected yet
+		next, ok := c.transitSelections.Load(hopRoute.Spec.Source + "/" + hopRoute.Spec.Destination)
+		if !ok {
+			domains := utils.TransitDomains(hopRoute.Spec.Transit)
+			if len(domains) == 0 {
+				return false, fmt.Errorf("route %s->%s has no transit domain", source, hop)
+			}
+			next = domains[0]
+		}
+		hop = next.(string)
+	}
+	return false, fmt.Errorf("transit path of route %s->%s via %s exceeds %d hops", source, destination, transit, maxTransitHops)
+}
+
+func (c *DomainRouteController) getDomainRoute(source, destination string) (*kusciaapisv1alpha1.DomainRoute, error) {
+	drs, err := c.domainRouteLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, dr := range drs {
+		if dr.Spec.Source == source && dr.Spec.Destination == destination {
+			return dr, nil
+		}
+	}
+	return nil, fmt.Errorf("domain route %s->%s not found", source, destination)
+}
+
 func (c *DomainRouteController) updateTransitVh(dr *kusciaapisv1alpha1.DomainRoute) error {
-	ns := dr.Spec.Transit.Domain.DomainID
+	ns, err := c.selectTransitDomain(dr)
+	if err != nil {
+		return err
+	}
+	selectionKey := dr.Spec.Source + "/" + dr.Spec.Destination
+	if prev, ok := c.transitSelections.Load(selectionKey); !ok || prev.(string) != ns {
+		nlog.Infof("Route %s->%s is transited by domain %s", dr.Spec.Source, dr.Spec.Destination, ns)
+	}
+	c.transitSelections.Store(selectionKey, ns)
+
 	vhName := fmt.Sprintf("%s-to-%s", dr.Spec.Source, ns)
 	if ns == c.getMasterNamespace() && c.getMasterNamespace() != c.gateway.Namespace {
 		vhName = fmt.Sprintf("%s-internal", clusters.GetMasterClusterName())
@@ -797,7 +989,20 @@ func generateReceiverInternalVh(dr *kusciaapisv1alpha1.DomainRoute) (*route.Virt
 func generateInternalRoute(dr *kusciaapisv1alpha1.DomainRoute, dp kusciaapisv1alpha1.DomainPort, token string, isDefaultRoute bool,
 	grpcDegrade bool) []*route.Route {
 	httpRoutes := interconn.Decorator.GenerateInternalRoute(dr, dp, token)
+	retryPolicy := generateRetryPolicy(dr)
+	var headerScript string
+	if dr.Spec.HeaderPolicy != nil {
+		headerScript = generateHeaderPolicyScript(dr.Spec.HeaderPolicy)
+	}
+	// routes without timeout policy are bounded by the max request timeout of listeners
+	var maxTimeout time.Duration
+	if p := dr.Spec.TimeoutPolicy; p != nil && p.MaxTimeoutSeconds > 0 {
+		maxTimeout = time.Duration(p.MaxTimeoutSeconds) * time.Second
+	}
 	for _, httpRoute := range httpRoutes {
+		if action := httpRoute.GetRoute(); action != nil && retryPolicy != nil {
+			action.RetryPolicy = proto.Clone(retryPolicy).(*route.RetryPolicy)
+		}
 		if !isDefaultRoute && dp.Protocol == "GRPC" {
 			httpRoute.Match.Headers = []*route.HeaderMatcher{
 				{
@@ -829,6 +1034,16 @@ func generateInternalRoute(dr *kusciaapisv1alpha1.DomainRoute, dp kusciaapisv1al
 				}
 			}
 		}
+		if headerScript != "" {
+			if err := decorateHeaderPolicyRoute(httpRoute, headerScript); err != nil {
+				nlog.Errorf("Marshal header policy of DomainRoute %s failed with %v", dr.Name, err)
+			}
+		}
+		if maxTimeout > 0 {
+			if err := xds.DecorateRequestTimeoutRoute(httpRoute, maxTimeout); err != nil {
+				nlog.Errorf("Marshal timeout policy of DomainRoute %s failed with %v", dr.Name, err)
+			}
+		}
 	}
 	return httpRoutes
 }
@@ -848,7 +1063,7 @@ func generateInternalRoutes(dr *kusciaapisv1alpha1.DomainRoute, token string, gr
 }
 
 func generateInternalVirtualHost(dr *kusciaapisv1alpha1.DomainRoute, token string, grpcDegrade bool) *route.VirtualHost {
-	routes := generateInternalRoutes(dr, token, grpcDegrade)
+	routes := append(generateRuleRoutes(dr, token, grpcDegrade), generateInternalRoutes(dr, token, grpcDegrade)...)
 
 	connectRoute := &route.Route{
 		Match: &route.RouteMatch{
@@ -903,13 +1118,14 @@ func (c *DomainRouteController) setKeepAliveForDstClusters(dr *kusciaapisv1alpha
 }
 
 func addClusterForDstGateway(dr *kusciaapisv1alpha1.DomainRoute, dp kusciaapisv1alpha1.DomainPort,
-	transportSocket *core.TransportSocket) error {
+	transportSocket *core.TransportSocket, http3 bool) error {
 	var protocolOptions *envoyhttp.HttpProtocolOptions
 	var protocol string
 	if dr.Labels[grpcDegradeLabel] == "True" && dp.Protocol == kusciaapisv1alpha1.DomainRouteProtocolGRPC {
 		// use http1.1
 		protocolOptions = xds.GenerateHTTP2UpstreamHTTPOptions(true)
 		protocol = xds.GenerateProtocol(dp.IsTLS, true)
+		http3 = false
 	} else {
 		// use same protocol with downstream
 		protocolOptions = xds.GenerateSimpleUpstreamHTTPOptions(true)
@@ -932,6 +1148,7 @@ func addClusterForDstGateway(dr *kusciaapisv1alpha1.DomainRoute, dp kusciaapisv1
 			protocolOptions.CommonHttpProtocolOptions = preProtocolOptions.CommonHttpProtocolOptions
 		}
 	}
+	applyConnectionPolicy(dr, protocolOptions.CommonHttpProtocolOptions)
 
 	b, err := proto.Marshal(protocolOptions)
 	if err != nil {
@@ -979,6 +1196,25 @@ func addClusterForDstGateway(dr *kusciaapisv1alpha1.DomainRoute, dp kusciaapisv1
 	if err := xds.DecorateRemoteUpstreamCluster(cluster, protocol); err != nil {
 		return err
 	}
+	cluster.UpstreamConnectionOptions.TcpKeepalive = generateTCPKeepalive(dr)
+	if dr.Spec.ServerTLS != nil && len(dr.Spec.ServerTLS.ServerNames) > 0 {
+		if err := xds.SetUpstreamServerName(cluster, dr.Spec.ServerTLS.ServerNames[0]); err != nil {
+			return err
+		}
+	}
+	if http3 && dp.IsTLS {
+		// envoy falls back to tcp if the quic connection fails
+		if err := xds.SetUpstreamHTTP3(cluster, dr.Spec.Endpoint.Host, uint32(dp.Port)); err != nil {
+			return err
+		}
+	}
+	if mtu := dr.Spec.MTUWorkaround; mtu != nil {
+		mss := mtu.MaxSegmentSize
+		if mss == 0 {
+			mss = defaultMaxSegmentSize
+		}
+		xds.SetMaxSegmentSize(cluster, mss)
+	}
 
 	interconn.Decorator.UpdateDstCluster(dr, cluster)
 
@@ -0,0 +1,189 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// routeCondition is a condition observed by the source gateway, the condition is true if err is nil.
+type routeCondition struct {
+	condType kusciaapisv1alpha1.ClusterDomainRouteConditionType
+	reason   string
+	err      error
+}
+
+// setRouteCondition sets the condition in status and records its error as the last error, it returns false if
+// nothing changed. LastUpdateTime of a condition isn't refreshed if its state is the same, so health checks don't
+// update status every time.
+func setRouteCondition(status *kusciaapisv1alpha1.DomainRouteStatus, cond routeCondition) bool {
+	condStatus := corev1.ConditionTrue
+	message := ""
+	if cond.err != nil {
+		condStatus = corev1.ConditionFalse
+		message = cond.err.Error()
+	}
+
+	now := metav1.Now()
+	found := false
+	for i := range status.Conditions {
+		c := &status.Conditions[i]
+		if c.Type != cond.condType {
+			continue
+		}
+		if c.Status == condStatus && c.Reason == cond.reason && c.Message == message {
+			return false
+		}
+		if c.Status != condStatus {
+			c.LastTransitionTime = now
+		}
+		c.Status, c.Reason, c.Message, c.LastUpdateTime = condStatus, cond.reason, message, now
+		found = true
+		break
+	}
+	if !found {
+		status.Conditions = append(status.Conditions, kusciaapisv1alpha1.ClusterDomainRouteCondition{
+			Type:               cond.condType,
+			Status:             condStatus,
+			LastUpdateTime:     now,
+			LastTransitionTime: now,
+			Reason:             cond.reason,
+			Message:            message,
+		})
+	}
+	if cond.err != nil {
+		status.LastError = &kusciaapisv1alpha1.DomainRouteLastError{
+			Condition: cond.condType,
+			Message:   message,
+			Time:      now,
+		}
+	}
+	return true
+}
+
+func setRouteConditions(status *kusciaapisv1alpha1.DomainRouteStatus, conds []routeCondition) bool {
+	changed := false
+	for _, cond := range conds {
+		if setRouteCondition(status, cond) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// updateRouteConditions updates the conditions in status of the route as soon as they are observed, it's skipped
+// if the conditions are the same as the cached route.
+func (c *DomainRouteController) updateRouteConditions(dr *kusciaapisv1alpha1.DomainRoute, conds ...routeCondition) {
+	if !setRouteConditions(dr.Status.DeepCopy(), conds) {
+		return
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		drLatest, err := c.kusciaClient.KusciaV1alpha1().DomainRoutes(dr.Namespace).Get(context.Background(), dr.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		drUpdate := drLatest.DeepCopy()
+		if !setRouteConditions(&drUpdate.Status, conds) {
+			return nil
+		}
+		_, err = c.kusciaClient.KusciaV1alpha1().DomainRoutes(dr.Namespace).UpdateStatus(context.Background(), drUpdate, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		nlog.Warnf("Update conditions of DomainRoute %s/%s failed with %v", dr.Namespace, dr.Name, err)
+	}
+}
+
+// endpointConditions maps the result of a request sent to destination to conditions.
+func endpointConditions(dr *kusciaapisv1alpha1.DomainRoute, err error) []routeCondition {
+	if err == nil {
+		conds := []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteEndpointReachable, reason: "Reachable"}}
+		if isTLSEndpoint(dr) {
+			conds = append(conds, routeCondition{condType: kusciaapisv1alpha1.ClusterDomainRouteTLSVerified, reason: "Verified"})
+		}
+		return conds
+	}
+	if isTLSVerifyError(err) {
+		return []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteTLSVerified, reason: "CertificateVerifyFailed", err: err}}
+	}
+	return []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteEndpointReachable, reason: "DestinationUnreachable", err: err}}
+}
+
+// handshakeConditions maps the result of a handshake to conditions.
+func handshakeConditions(dr *kusciaapisv1alpha1.DomainRoute, err error) []routeCondition {
+	if err == nil {
+		return append(endpointConditions(dr, nil),
+			routeCondition{condType: kusciaapisv1alpha1.ClusterDomainRouteHandshakeSucceeded, reason: "HandshakeSucceeded"},
+			routeCondition{condType: kusciaapisv1alpha1.ClusterDomainRouteTokenValid, reason: "TokenReady"})
+	}
+	conds := []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteHandshakeSucceeded, reason: "HandshakeFailed", err: err}}
+	if isTLSVerifyError(err) {
+		conds = append(conds, routeCondition{condType: kusciaapisv1alpha1.ClusterDomainRouteTLSVerified, reason: "CertificateVerifyFailed", err: err})
+	}
+	return conds
+}
+
+// tokenConditions maps the token state replied by destination in health check to conditions.
+func tokenConditions(state DestinationStatus, err error) []routeCondition {
+	switch state {
+	case TokenReady:
+		return []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteTokenValid, reason: "TokenReady"}}
+	case TokenNotFound:
+		return []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteTokenValid, reason: "TokenNotFound",
+			err: fmt.Errorf("token isn't found in destination, handshake again")}}
+	case TokenNotReady:
+		return []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteTokenValid, reason: "TokenNotReady", err: err}}
+	case TokenRevisionInputInvalid:
+		return []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteTokenValid, reason: "TokenRevisionInvalid", err: err}}
+	case NoAuthentication:
+		return []routeCondition{{condType: kusciaapisv1alpha1.ClusterDomainRouteHandshakeSucceeded, reason: "DestinationNotAuthorized",
+			err: fmt.Errorf("destination authentication is disabled")}}
+	default:
+		return nil
+	}
+}
+
+func isTLSEndpoint(dr *kusciaapisv1alpha1.DomainRoute) bool {
+	for _, port := range dr.Spec.Endpoint.Ports {
+		if port.IsTLS {
+			return true
+		}
+	}
+	return false
+}
+
+// isTLSVerifyError checks the error of go tls client and the error body replied by envoy, e.g.
+// "TLS error: 268435581:SSL routines:OPENSSL_internal:CERTIFICATE_VERIFY_FAILED".
+func isTLSVerifyError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalidCert x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	if errors.As(err, &unknownAuthority) || errors.As(err, &invalidCert) || errors.As(err, &hostname) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "CERTIFICATE_VERIFY_FAILED") || strings.Contains(msg, "x509: ")
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+func TestSetRouteCondition(t *testing.T) {
+	status := &kusciaapisv1alpha1.DomainRouteStatus{}
+	cond := routeCondition{condType: kusciaapisv1alpha1.ClusterDomainRouteEndpointReachable, reason: "Reachable"}
+	assert.True(t, setRouteCondition(status, cond))
+	assert.False(t, setRouteCondition(status, cond))
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, corev1.ConditionTrue, status.Conditions[0].Status)
+	assert.Nil(t, status.LastError)
+	transition := status.Conditions[0].LastTransitionTime
+
+	cond = routeCondition{condType: kusciaapisv1alpha1.ClusterDomainRouteEndpointReachable, reason: "DestinationUnreachable",
+		err: fmt.Errorf("connection refused")}
+	assert.True(t, setRouteCondition(status, cond))
+	assert.Len(t, status.Conditions, 1)
+	assert.Equal(t, corev1.ConditionFalse, status.Conditions[0].Status)
+	assert.Equal(t, "connection refused", status.Conditions[0].Message)
+	assert.False(t, status.Conditions[0].LastTransitionTime.Before(&transition))
+	assert.Equal(t, kusciaapisv1alpha1.ClusterDomainRouteEndpointReachable, status.LastError.Condition)
+	assert.Equal(t, "connection refused", status.LastError.Message)
+}
+
+func TestEndpointConditions(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host:  "bob.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{{Name: "https", Port: 443, IsTLS: true}},
+			},
+		},
+	}
+	conds := endpointConditions(dr, nil)
+	assert.Len(t, conds, 2)
+	assert.Equal(t, kusciaapisv1alpha1.ClusterDomainRouteTLSVerified, conds[1].condType)
+
+	err := fmt.Errorf("response status code [503], detail -> upstream connect error or disconnect/reset before headers. " +
+		"reset reason: connection failure, transport failure reason: TLS error: 268435581:SSL routines:OPENSSL_internal:CERTIFICATE_VERIFY_FAILED")
+	conds = endpointConditions(dr, err)
+	assert.Len(t, conds, 1)
+	assert.Equal(t, kusciaapisv1alpha1.ClusterDomainRouteTLSVerified, conds[0].condType)
+	assert.Equal(t, "CertificateVerifyFailed", conds[0].reason)
+
+	conds = handshakeConditions(dr, fmt.Errorf("connection refused"))
+	assert.Len(t, conds, 1)
+	assert.Equal(t, kusciaapisv1alpha1.ClusterDomainRouteHandshakeSucceeded, conds[0].condType)
+
+	conds = tokenConditions(TokenNotFound, nil)
+	assert.Equal(t, kusciaapisv1alpha1.ClusterDomainRouteTokenValid, conds[0].condType)
+	assert.Error(t, conds[0].err)
+}
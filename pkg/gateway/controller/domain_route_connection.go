@@ -0,0 +1,61 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+// applyConnectionPolicy sets the idle timeout and max age of connections to destination in options, the max requests
+// per connection is kept as it's managed by handshake.
+func applyConnectionPolicy(dr *kusciaapisv1alpha1.DomainRoute, options *core.HttpProtocolOptions) {
+	idleTimeout := xds.DefaultUpstreamIdleTimeout
+	var maxAge time.Duration
+	if p := dr.Spec.ConnectionPolicy; p != nil {
+		if p.IdleTimeoutSeconds > 0 {
+			idleTimeout = time.Duration(p.IdleTimeoutSeconds) * time.Second
+		}
+		maxAge = time.Duration(p.MaxConnectionAgeSeconds) * time.Second
+	}
+
+	options.IdleTimeout = durationpb.New(idleTimeout)
+	options.MaxConnectionDuration = nil
+	if maxAge > 0 {
+		options.MaxConnectionDuration = durationpb.New(maxAge)
+	}
+}
+
+// generateTCPKeepalive generates the TCP keepalive options of connections to destination.
+func generateTCPKeepalive(dr *kusciaapisv1alpha1.DomainRoute) *core.TcpKeepalive {
+	idle, interval, probes := xds.DefaultKeepaliveTime, xds.DefaultKeepaliveInterval, uint32(xds.DefaultKeepaliveProbes)
+	if p := dr.Spec.ConnectionPolicy; p != nil && p.TCPKeepalive != nil {
+		if p.TCPKeepalive.IdleSeconds > 0 {
+			idle = time.Duration(p.TCPKeepalive.IdleSeconds) * time.Second
+		}
+		if p.TCPKeepalive.IntervalSeconds > 0 {
+			interval = time.Duration(p.TCPKeepalive.IntervalSeconds) * time.Second
+		}
+		if p.TCPKeepalive.Probes > 0 {
+			probes = uint32(p.TCPKeepalive.Probes)
+		}
+	}
+	return xds.NewTCPKeepalive(idle, interval, probes)
+}
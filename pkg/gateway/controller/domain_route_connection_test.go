@@ -0,0 +1,88 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestConnectionPolicy(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-carol", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:             "alice",
+			Destination:        "carol",
+			AuthenticationType: kusciaapisv1alpha1.DomainAuthenticationNone,
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host: "carol.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{
+					{Name: "http", Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, Port: 1080},
+				},
+			},
+		},
+	}
+	clusterName := "alice-to-carol-http"
+
+	assert.NoError(t, addClusterForDstGateway(dr, dr.Spec.Endpoint.Ports[0], nil, false))
+	cluster, err := xds.QueryCluster(clusterName)
+	assert.NoError(t, err)
+	keepalive := cluster.UpstreamConnectionOptions.TcpKeepalive
+	assert.Equal(t, uint32(60), keepalive.KeepaliveTime.GetValue())
+	assert.Equal(t, uint32(15), keepalive.KeepaliveInterval.GetValue())
+	assert.Equal(t, uint32(3), keepalive.KeepaliveProbes.GetValue())
+	options, _, err := xds.GetClusterHTTPProtocolOptions(clusterName)
+	assert.NoError(t, err)
+	assert.Equal(t, xds.DefaultUpstreamIdleTimeout, options.CommonHttpProtocolOptions.IdleTimeout.AsDuration())
+	assert.Nil(t, options.CommonHttpProtocolOptions.MaxConnectionDuration)
+
+	dr.Spec.ConnectionPolicy = &kusciaapisv1alpha1.DomainRouteConnectionPolicy{
+		IdleTimeoutSeconds:      120,
+		MaxConnectionAgeSeconds: 1800,
+		TCPKeepalive:            &kusciaapisv1alpha1.DomainRouteTCPKeepalive{IdleSeconds: 30, Probes: 5},
+	}
+	assert.NoError(t, addClusterForDstGateway(dr, dr.Spec.Endpoint.Ports[0], nil, false))
+	cluster, err = xds.QueryCluster(clusterName)
+	assert.NoError(t, err)
+	keepalive = cluster.UpstreamConnectionOptions.TcpKeepalive
+	assert.Equal(t, uint32(30), keepalive.KeepaliveTime.GetValue())
+	assert.Equal(t, uint32(15), keepalive.KeepaliveInterval.GetValue())
+	assert.Equal(t, uint32(5), keepalive.KeepaliveProbes.GetValue())
+	options, _, err = xds.GetClusterHTTPProtocolOptions(clusterName)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, options.CommonHttpProtocolOptions.IdleTimeout.AsDuration())
+	assert.Equal(t, 30*time.Minute, options.CommonHttpProtocolOptions.MaxConnectionDuration.AsDuration())
+}
+
+func TestApplyConnectionPolicy_KeepMaxRequests(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			ConnectionPolicy: &kusciaapisv1alpha1.DomainRouteConnectionPolicy{IdleTimeoutSeconds: 60},
+		},
+	}
+	options := xds.GenerateSimpleUpstreamHTTPOptions(true)
+	options.CommonHttpProtocolOptions.MaxRequestsPerConnection = wrapperspb.UInt32(1)
+
+	applyConnectionPolicy(dr, options.CommonHttpProtocolOptions)
+	assert.Equal(t, time.Minute, options.CommonHttpProtocolOptions.IdleTimeout.AsDuration())
+	assert.Equal(t, uint32(1), options.CommonHttpProtocolOptions.MaxRequestsPerConnection.GetValue())
+}
@@ -0,0 +1,232 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// headerPolicyLib is shared by the scripts of all routes. Pseudo headers and the headers kuscia relies on
+// are never removed or renamed, so a policy can't break routing, authentication or grpc.
+const headerPolicyLib = `local preserved = {
+  ["content-type"] = true, ["content-length"] = true, ["content-encoding"] = true,
+  ["transfer-encoding"] = true, ["te"] = true, ["host"] = true, ["x-request-id"] = true,
+  ["traceparent"] = true, ["tracestate"] = true, ["x-kuscia-timeout"] = true,
+}
+local preservedPrefixes = {"grpc-", "kuscia-", "x-b3-"}
+
+local function keep(name)
+  if name:sub(1, 1) == ":" or preserved[name] then
+    return true
+  end
+  for _, prefix in ipairs(preservedPrefixes) do
+    if name:sub(1, #prefix) == prefix then
+      return true
+    end
+  end
+  return false
+end
+
+local function apply(headers, rules)
+  for _, pair in ipairs(rules.rename) do
+    local value = headers:get(pair[1])
+    if value ~= nil and not keep(pair[1]) then
+      headers:remove(pair[1])
+      headers:replace(pair[2], value)
+    end
+  end
+  local drop = {}
+  for name, _ in pairs(headers) do
+    if not keep(name) and (rules.deny[name] or (rules.restricted and not rules.allow[name])) then
+      table.insert(drop, name)
+    end
+  end
+  for _, name in ipairs(drop) do
+    headers:remove(name)
+  end
+  for _, pair in ipairs(rules.add) do
+    headers:replace(pair[1], pair[2])
+  end
+end
+`
+
+// normalizeHeaderPolicy lowercases header names and sorts the lists, so the policy reported in status
+// and the generated script don't change with the order of the spec. Empty rules are dropped.
+func normalizeHeaderPolicy(policy *kusciaapisv1alpha1.DomainRouteHeaderPolicy) *kusciaapisv1alpha1.DomainRouteHeaderPolicy {
+	if policy == nil {
+		return nil
+	}
+	result := &kusciaapisv1alpha1.DomainRouteHeaderPolicy{
+		Request:  normalizeHeaderRules(policy.Request),
+		Response: normalizeHeaderRules(policy.Response),
+	}
+	if result.Request == nil && result.Response == nil {
+		return nil
+	}
+	return result
+}
+
+func normalizeHeaderRules(rules *kusciaapisv1alpha1.DomainRouteHeaderRules) *kusciaapisv1alpha1.DomainRouteHeaderRules {
+	if rules == nil {
+		return nil
+	}
+	result := &kusciaapisv1alpha1.DomainRouteHeaderRules{
+		Allow:  normalizeHeaderNames(rules.Allow),
+		Deny:   normalizeHeaderNames(rules.Deny),
+		Rename: normalizeHeaderMap(rules.Rename, true),
+		Add:    normalizeHeaderMap(rules.Add, false),
+	}
+	if result.Allow == nil && result.Deny == nil && result.Rename == nil && result.Add == nil {
+		return nil
+	}
+	return result
+}
+
+func normalizeHeaderNames(names []string) []string {
+	set := map[string]bool{}
+	for _, name := range names {
+		set[strings.ToLower(name)] = true
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(set))
+	for name := range set {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func normalizeHeaderMap(m map[string]string, lowerValue bool) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		if lowerValue {
+			v = strings.ToLower(v)
+		}
+		result[strings.ToLower(k)] = v
+	}
+	return result
+}
+
+// generateHeaderPolicyScript generates the lua script enforcing the policy, requests are filtered
+// before they are sent to destination and responses before they are returned to source.
+func generateHeaderPolicyScript(policy *kusciaapisv1alpha1.DomainRouteHeaderPolicy) string {
+	policy = normalizeHeaderPolicy(policy)
+	var request, response *kusciaapisv1alpha1.DomainRouteHeaderRules
+	if policy != nil {
+		request, response = policy.Request, policy.Response
+	}
+
+	var b strings.Builder
+	b.WriteString(headerPolicyLib)
+	fmt.Fprintf(&b, "\nlocal request = %s\nlocal response = %s\n", luaHeaderRules(request), luaHeaderRules(response))
+	b.WriteString(`
+function envoy_on_request(handle)
+  apply(handle:headers(), request)
+end
+
+function envoy_on_response(handle)
+  apply(handle:headers(), response)
+end
+`)
+	return b.String()
+}
+
+func luaHeaderRules(rules *kusciaapisv1alpha1.DomainRouteHeaderRules) string {
+	if rules == nil {
+		rules = &kusciaapisv1alpha1.DomainRouteHeaderRules{}
+	}
+	return fmt.Sprintf("{restricted = %t, allow = %s, deny = %s, rename = %s, add = %s}",
+		len(rules.Allow) > 0, luaSet(rules.Allow), luaSet(rules.Deny), luaPairs(rules.Rename), luaPairs(rules.Add))
+}
+
+func luaSet(names []string) string {
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, fmt.Sprintf("[%s] = true", strconv.Quote(name)))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+func luaPairs(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	items := make([]string, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, fmt.Sprintf("{%s, %s}", strconv.Quote(k), strconv.Quote(m[k])))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// decorateHeaderPolicyRoute attaches the script of the policy to the route, the lua filter itself is
+// installed on the internal listener by xds.SetHeaderPolicy.
+func decorateHeaderPolicyRoute(httpRoute *route.Route, script string) error {
+	perRoute, err := anypb.New(&lua.LuaPerRoute{
+		Override: &lua.LuaPerRoute_SourceCode{
+			SourceCode: &core.DataSource{
+				Specifier: &core.DataSource_InlineString{InlineString: script},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if httpRoute.TypedPerFilterConfig == nil {
+		httpRoute.TypedPerFilterConfig = map[string]*anypb.Any{}
+	}
+	httpRoute.TypedPerFilterConfig[xds.LuaFilterName] = perRoute
+	return nil
+}
+
+// updateHeaderPolicyStatus reports the policy applied by the source gateway in status.
+func (c *DomainRouteController) updateHeaderPolicyStatus(dr *kusciaapisv1alpha1.DomainRoute) {
+	policy := normalizeHeaderPolicy(dr.Spec.HeaderPolicy)
+	if reflect.DeepEqual(dr.Status.HeaderPolicy, policy) {
+		return
+	}
+	drLatest, err := c.kusciaClient.KusciaV1alpha1().DomainRoutes(dr.Namespace).Get(context.Background(), dr.Name, metav1.GetOptions{})
+	if err != nil {
+		nlog.Warnf("Get DomainRoute %s/%s failed with %v", dr.Namespace, dr.Name, err)
+		return
+	}
+	drUpdate := drLatest.DeepCopy()
+	drUpdate.Status.HeaderPolicy = policy
+	if _, err := c.kusciaClient.KusciaV1alpha1().DomainRoutes(dr.Namespace).UpdateStatus(context.Background(), drUpdate,
+		metav1.UpdateOptions{}); err != nil {
+		nlog.Warnf("Update header policy status of DomainRoute %s/%s failed with %v", dr.Namespace, dr.Name, err)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestNormalizeHeaderPolicy(t *testing.T) {
+	assert.Nil(t, normalizeHeaderPolicy(nil))
+	assert.Nil(t, normalizeHeaderPolicy(&kusciaapisv1alpha1.DomainRouteHeaderPolicy{
+		Request: &kusciaapisv1alpha1.DomainRouteHeaderRules{},
+	}))
+
+	policy := normalizeHeaderPolicy(&kusciaapisv1alpha1.DomainRouteHeaderPolicy{
+		Request: &kusciaapisv1alpha1.DomainRouteHeaderRules{
+			Allow:  []string{"X-Trace", "x-app", "x-trace"},
+			Rename: map[string]string{"X-User": "X-Party-User"},
+			Add:    map[string]string{"X-Party": "Alice"},
+		},
+	})
+	assert.Nil(t, policy.Response)
+	assert.Equal(t, []string{"x-app", "x-trace"}, policy.Request.Allow)
+	assert.Equal(t, map[string]string{"x-user": "x-party-user"}, policy.Request.Rename)
+	assert.Equal(t, map[string]string{"x-party": "Alice"}, policy.Request.Add)
+}
+
+func TestGenerateHeaderPolicyScript(t *testing.T) {
+	script := generateHeaderPolicyScript(&kusciaapisv1alpha1.DomainRouteHeaderPolicy{
+		Request: &kusciaapisv1alpha1.DomainRouteHeaderRules{
+			Allow: []string{"x-app"},
+			Add:   map[string]string{"x-party": `a"b`},
+		},
+		Response: &kusciaapisv1alpha1.DomainRouteHeaderRules{
+			Deny:   []string{"Server"},
+			Rename: map[string]string{"x-b": "x-c", "x-a": "x-b"},
+		},
+	})
+	assert.Contains(t, script, `local request = {restricted = true, allow = {["x-app"] = true}, deny = {}, rename = {}, add = {{"x-party", "a\"b"}}}`)
+	assert.Contains(t, script, `local response = {restricted = false, allow = {}, deny = {["server"] = true}, rename = {{"x-a", "x-b"}, {"x-b", "x-c"}}, add = {}}`)
+	assert.Contains(t, script, "function envoy_on_request(handle)")
+	assert.Contains(t, script, "function envoy_on_response(handle)")
+}
+
+func TestGenerateInternalRouteWithHeaderPolicy(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host: "bob.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{
+					{Name: "http", Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, Port: 1080},
+				},
+			},
+		},
+	}
+	for _, r := range generateInternalRoutes(dr, "token", false) {
+		assert.NotContains(t, r.TypedPerFilterConfig, xds.LuaFilterName)
+	}
+
+	dr.Spec.HeaderPolicy = &kusciaapisv1alpha1.DomainRouteHeaderPolicy{
+		Request: &kusciaapisv1alpha1.DomainRouteHeaderRules{Deny: []string{"x-secret"}},
+	}
+	routes := generateInternalRoutes(dr, "token", false)
+	assert.NotEmpty(t, routes)
+	for _, r := range routes {
+		config, ok := r.TypedPerFilterConfig[xds.LuaFilterName]
+		assert.True(t, ok)
+		perRoute := &lua.LuaPerRoute{}
+		assert.NoError(t, config.UnmarshalTo(perRoute))
+		assert.Contains(t, perRoute.GetSourceCode().GetInlineString(), `deny = {["x-secret"] = true}`)
+		// the grpc reverse bridge config is kept
+		assert.Contains(t, r.TypedPerFilterConfig, xds.GrpcHTTP1ReverseBridgeName)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strings"
+	"time"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+const (
+	// idempotentHeader marks a request of non-idempotent method, e.g. POST, safe to retry.
+	idempotentHeader = "Kuscia-Idempotent"
+
+	defaultRetryOn    = "connect-failure,refused-stream,reset,unavailable"
+	defaultNumRetries = 1
+)
+
+// generateRetryPolicy generates the retry policy of routes to destination, nil if retries are disabled. Only
+// idempotent requests are retried, as the destination may have processed the request before the reset.
+func generateRetryPolicy(dr *kusciaapisv1alpha1.DomainRoute) *route.RetryPolicy {
+	retryOn := defaultRetryOn
+	numRetries := int32(defaultNumRetries)
+	var perTryTimeout *durationpb.Duration
+	if p := dr.Spec.RetryPolicy; p != nil {
+		if len(p.RetryOn) > 0 {
+			retryOn = strings.Join(p.RetryOn, ",")
+		}
+		if p.NumRetries != nil {
+			numRetries = *p.NumRetries
+		}
+		if p.PerTryTimeoutSeconds > 0 {
+			perTryTimeout = durationpb.New(time.Duration(p.PerTryTimeoutSeconds) * time.Second)
+		}
+	}
+	if numRetries <= 0 {
+		return nil
+	}
+
+	return &route.RetryPolicy{
+		RetryOn:       retryOn,
+		NumRetries:    wrapperspb.UInt32(uint32(numRetries)),
+		PerTryTimeout: perTryTimeout,
+		// a request is retried if any of the headers matches
+		RetriableRequestHeaders: []*route.HeaderMatcher{
+			{
+				Name: ":method",
+				HeaderMatchSpecifier: &route.HeaderMatcher_StringMatch{
+					StringMatch: &matcher.StringMatcher{
+						MatchPattern: &matcher.StringMatcher_SafeRegex{
+							SafeRegex: &matcher.RegexMatcher{Regex: "GET|HEAD|OPTIONS|PUT|DELETE"},
+						},
+					},
+				},
+			},
+			{
+				Name: strings.ToLower(idempotentHeader),
+				HeaderMatchSpecifier: &route.HeaderMatcher_StringMatch{
+					StringMatch: &matcher.StringMatcher{
+						MatchPattern: &matcher.StringMatcher_Exact{Exact: "true"},
+						IgnoreCase:   true,
+					},
+				},
+			},
+		},
+	}
+}
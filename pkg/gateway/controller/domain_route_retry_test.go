@@ -0,0 +1,102 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestGenerateRetryPolicy(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host: "bob.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{
+					{Name: "http", Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, Port: 1080},
+				},
+			},
+		},
+	}
+
+	policy := generateRetryPolicy(dr)
+	assert.Equal(t, defaultRetryOn, policy.RetryOn)
+	assert.Equal(t, uint32(1), policy.NumRetries.GetValue())
+	assert.Nil(t, policy.PerTryTimeout)
+	assert.Len(t, policy.RetriableRequestHeaders, 2)
+	assert.Equal(t, ":method", policy.RetriableRequestHeaders[0].Name)
+	assert.Equal(t, "kuscia-idempotent", policy.RetriableRequestHeaders[1].Name)
+
+	routes := generateInternalRoutes(dr, "token", false)
+	assert.NotEmpty(t, routes)
+	for _, r := range routes {
+		assert.Equal(t, defaultRetryOn, r.GetRoute().GetRetryPolicy().GetRetryOn())
+	}
+
+	numRetries := int32(3)
+	dr.Spec.RetryPolicy = &kusciaapisv1alpha1.DomainRouteRetryPolicy{
+		RetryOn:              []string{"reset", "5xx"},
+		NumRetries:           &numRetries,
+		PerTryTimeoutSeconds: 10,
+	}
+	policy = generateRetryPolicy(dr)
+	assert.Equal(t, "reset,5xx", policy.RetryOn)
+	assert.Equal(t, uint32(3), policy.NumRetries.GetValue())
+	assert.Equal(t, 10*time.Second, policy.PerTryTimeout.AsDuration())
+
+	numRetries = 0
+	assert.Nil(t, generateRetryPolicy(dr))
+	for _, r := range generateInternalRoutes(dr, "token", false) {
+		assert.Nil(t, r.GetRoute().GetRetryPolicy())
+	}
+}
+
+func TestGenerateInternalRoutesTimeoutPolicy(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host: "bob.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{
+					{Name: "http", Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, Port: 1080},
+				},
+			},
+		},
+	}
+	for _, r := range generateInternalRoutes(dr, "token", false) {
+		assert.NotContains(t, r.TypedPerFilterConfig, xds.RequestTimeoutFilterName)
+	}
+
+	dr.Spec.TimeoutPolicy = &kusciaapisv1alpha1.DomainRouteTimeoutPolicy{MaxTimeoutSeconds: 60}
+	routes := generateInternalRoutes(dr, "token", false)
+	assert.NotEmpty(t, routes)
+	for _, r := range routes {
+		perRoute := &lua.LuaPerRoute{}
+		assert.NoError(t, r.TypedPerFilterConfig[xds.RequestTimeoutFilterName].UnmarshalTo(perRoute))
+		assert.Equal(t, xds.RequestTimeoutScript(time.Minute), perRoute.GetSourceCode().GetInlineString())
+	}
+}
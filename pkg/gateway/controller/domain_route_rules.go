@@ -0,0 +1,127 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// ruleRoutePrefix prefixes the name and stat prefix of routes generated by DomainRoute rules, the stats of a rule are
+// vhost.<source>-to-<destination>.route.rule-<name>.*
+const ruleRoutePrefix = "rule-"
+
+// generateRuleRoutes generates routes of DomainRoute rules, they are placed before the default routes. Each rule
+// route is the default route of its port with the match replaced and Kuscia-Host pointing to the service of rule.
+func generateRuleRoutes(dr *kusciaapisv1alpha1.DomainRoute, token string, grpcDegrade bool) []*route.Route {
+	if len(dr.Spec.Rules) == 0 || dr.Spec.InterConnProtocol == kusciaapisv1alpha1.InterConnBFIA {
+		return nil
+	}
+
+	var routes []*route.Route
+	for _, rule := range dr.Spec.Rules {
+		dp, ok := ruleDomainPort(dr, rule)
+		if !ok {
+			nlog.Warnf("Port %s of DomainRoute %s rule %s isn't found, skip it", rule.Port, dr.Name, rule.Name)
+			continue
+		}
+		for _, r := range generateInternalRoute(dr, dp, token, true, grpcDegrade) {
+			if r.Name != xds.DefaultRouteName {
+				continue
+			}
+			decorateRuleRoute(r, dr, dp, rule)
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+func decorateRuleRoute(r *route.Route, dr *kusciaapisv1alpha1.DomainRoute, dp kusciaapisv1alpha1.DomainPort,
+	rule kusciaapisv1alpha1.DomainRouteRule) {
+	r.Name = ruleRoutePrefix + rule.Name
+	r.StatPrefix = ruleRoutePrefix + rule.Name
+
+	pathPrefix := rule.PathPrefix
+	if pathPrefix == "" {
+		pathPrefix = "/"
+	}
+	r.Match = &route.RouteMatch{
+		PathSpecifier: &route.RouteMatch_Prefix{Prefix: pathPrefix},
+	}
+	for _, h := range rule.Headers {
+		hm := &route.HeaderMatcher{Name: h.Name}
+		switch {
+		case h.Exact != "":
+			hm.HeaderMatchSpecifier = &route.HeaderMatcher_StringMatch{StringMatch: &matcher.StringMatcher{
+				MatchPattern: &matcher.StringMatcher_Exact{Exact: h.Exact},
+			}}
+		case h.Prefix != "":
+			hm.HeaderMatchSpecifier = &route.HeaderMatcher_StringMatch{StringMatch: &matcher.StringMatcher{
+				MatchPattern: &matcher.StringMatcher_Prefix{Prefix: h.Prefix},
+			}}
+		default:
+			hm.HeaderMatchSpecifier = &route.HeaderMatcher_PresentMatch{PresentMatch: true}
+		}
+		r.Match.Headers = append(r.Match.Headers, hm)
+	}
+
+	// destination gateway rewrites host by Kuscia-Host
+	host := fmt.Sprintf("%s.%s.svc", rule.Service, dr.Spec.Destination)
+	for _, h := range r.RequestHeadersToAdd {
+		if h.Header != nil && h.Header.Key == "Kuscia-Host" {
+			h.Header.Value = host
+		}
+	}
+
+	if action := r.GetRoute(); action != nil {
+		rewrite := rule.PrefixRewrite
+		if rewrite == "" && dp.PathPrefix != "" {
+			rewrite = pathPrefix
+		}
+		action.PrefixRewrite = ""
+		if rewrite != "" {
+			action.PrefixRewrite = strings.TrimSuffix(dp.PathPrefix, "/") + rewrite
+		}
+	}
+}
+
+// ruleDomainPort returns the endpoint port of rule, the port of default route is used if rule doesn't specify one.
+func ruleDomainPort(dr *kusciaapisv1alpha1.DomainRoute, rule kusciaapisv1alpha1.DomainRouteRule) (kusciaapisv1alpha1.DomainPort, bool) {
+	dps := sortDomainPorts(dr.Spec.Endpoint.Ports)
+	if len(dps) == 0 {
+		return kusciaapisv1alpha1.DomainPort{}, false
+	}
+	if rule.Port == "" {
+		for _, dp := range dps {
+			if dp.Protocol == kusciaapisv1alpha1.DomainRouteProtocolHTTP {
+				return dp, true
+			}
+		}
+		return dps[0], true
+	}
+	for _, dp := range dps {
+		if dp.Name == rule.Port {
+			return dp, true
+		}
+	}
+	return kusciaapisv1alpha1.DomainPort{}, false
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestGenerateRuleRoutes(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host: "bob.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{
+					{Name: "grpc", Protocol: kusciaapisv1alpha1.DomainRouteProtocolGRPC, Port: 1081},
+					{Name: "http", Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, Port: 1080, PathPrefix: "/gateway"},
+				},
+			},
+			Rules: []kusciaapisv1alpha1.DomainRouteRule{
+				{
+					Name:       "serving",
+					PathPrefix: "/serving/",
+					Service:    "secretflow-serving",
+					Headers:    []kusciaapisv1alpha1.DomainRouteHeaderMatch{{Name: "x-app", Exact: "serving"}},
+				},
+				{Name: "dataproxy", Service: "dataproxy", Port: "grpc", PrefixRewrite: "/dp/"},
+				{Name: "unknown-port", Service: "x", Port: "not-exist"},
+			},
+		},
+	}
+
+	vh := generateInternalVirtualHost(dr, "token", false)
+	// 2 rule routes, 2 default routes and the connect route
+	assert.Len(t, vh.Routes, 5)
+
+	serving := vh.Routes[0]
+	assert.Equal(t, "rule-serving", serving.Name)
+	assert.Equal(t, "rule-serving", serving.StatPrefix)
+	assert.Equal(t, "/serving/", serving.Match.GetPrefix())
+	assert.Len(t, serving.Match.Headers, 1)
+	assert.Equal(t, "serving", serving.Match.Headers[0].GetStringMatch().GetExact())
+	assert.Equal(t, "alice-to-bob-http", serving.GetRoute().GetCluster())
+	assert.Equal(t, "/gateway/serving/", serving.GetRoute().PrefixRewrite)
+	assert.Equal(t, "secretflow-serving.bob.svc", kusciaHost(serving))
+
+	dataproxy := vh.Routes[1]
+	assert.Equal(t, "rule-dataproxy", dataproxy.Name)
+	assert.Equal(t, "/", dataproxy.Match.GetPrefix())
+	assert.Equal(t, "alice-to-bob-grpc", dataproxy.GetRoute().GetCluster())
+	assert.Equal(t, "/dp/", dataproxy.GetRoute().PrefixRewrite)
+	assert.Equal(t, "dataproxy.bob.svc", kusciaHost(dataproxy))
+
+	// default routes are kept untouched
+	assert.Equal(t, xds.DefaultRouteName, vh.Routes[2].Name)
+	assert.Equal(t, "%REQ(:authority)%", kusciaHost(vh.Routes[2]))
+
+	result, err := xds.MatchRouteConfig(&envoyroute.RouteConfiguration{
+		Name:         xds.InternalRoute,
+		VirtualHosts: []*envoyroute.VirtualHost{vh},
+	}, &xds.RouteRequest{Host: "a.bob.svc", Path: "/serving/predict", Headers: map[string]string{"x-app": "serving"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "rule-serving", result.RouteName)
+
+	dr.Spec.InterConnProtocol = kusciaapisv1alpha1.InterConnBFIA
+	assert.Empty(t, generateRuleRoutes(dr, "token", false))
+}
+
+func kusciaHost(r *envoyroute.Route) string {
+	for _, h := range r.RequestHeadersToAdd {
+		if h.Header.Key == "Kuscia-Host" {
+			return h.Header.Value
+		}
+	}
+	return ""
+}
@@ -34,12 +34,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/kubernetes/pkg/controller"
 
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kusciaFake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	informers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
+	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/gateway/config"
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/gateway/xds"
@@ -114,7 +116,10 @@ func newDomainRouteTestInfo(namespace string, port uint32) *DomainRouteTestInfo
 		CAKey:         caKey,
 		CACert:        caCert,
 	}
-	c := NewDomainRouteController(config, fake.NewSimpleClientset(), kusciaClient, domainRouteInformer)
+	c, err := NewDomainRouteController(config, fake.NewSimpleClientset(), kusciaClient, domainRouteInformer)
+	if err != nil {
+		nlog.Fatal(err)
+	}
 	kusciaInformerFactory.Start(wait.NeverStop)
 	block := &pem.Block{
 		Type:  "RSA PUBLIC KEY",
@@ -579,6 +584,59 @@ func TestTransit(t *testing.T) {
 	time.Sleep(time.Second)
 }
 
+func TestSelectTransitDomain(t *testing.T) {
+	newRoute := func(source, destination string, unreachable bool, transits ...string) *kusciaapisv1alpha1.DomainRoute {
+		dr := &kusciaapisv1alpha1.DomainRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: source + "-" + destination, Namespace: source},
+			Spec:       kusciaapisv1alpha1.DomainRouteSpec{Source: source, Destination: destination},
+			Status:     kusciaapisv1alpha1.DomainRouteStatus{IsDestinationUnreachable: unreachable},
+		}
+		if len(transits) > 0 {
+			dr.Spec.Transit = &kusciaapisv1alpha1.Transit{
+				TransitMethod: kusciaapisv1alpha1.TransitMethodThirdDomain,
+				Domain:        &kusciaapisv1alpha1.DomainTransit{DomainID: transits[0]},
+			}
+			for _, transit := range transits[1:] {
+				dr.Spec.Transit.CandidateDomains = append(dr.Spec.Transit.CandidateDomains, kusciaapisv1alpha1.DomainTransit{DomainID: transit})
+			}
+		}
+		return dr
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, dr := range []*kusciaapisv1alpha1.DomainRoute{
+		newRoute("alice", "t1", true),
+		newRoute("alice", "t2", false),
+		newRoute("alice", "t3", false, "t4"),
+		newRoute("alice", "t4", false, "t3"),
+	} {
+		assert.NoError(t, indexer.Add(dr))
+	}
+	c := &DomainRouteController{
+		masterConfig:      &config.MasterConfig{Namespace: "kuscia"},
+		domainRouteLister: kuscialistersv1alpha1.NewDomainRouteLister(indexer),
+	}
+
+	// t1 is unreachable, fail over to t2
+	domain, err := c.selectTransitDomain(newRoute("alice", "bob", false, "t1", "t2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "t2", domain)
+
+	// none is healthy, use the first one
+	domain, err = c.selectTransitDomain(newRoute("alice", "bob", false, "t1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", domain)
+
+	// t3 and t4 transit each other, t5 has no route
+	_, err = c.selectTransitDomain(newRoute("alice", "bob", false, "t3", "t5"))
+	assert.ErrorContains(t, err, "makes a routing cycle")
+	assert.ErrorContains(t, err, "alice->t5 not found")
+
+	// transit through the destination itself
+	_, err = c.selectTransitDomain(newRoute("alice", "t2", false, "t2"))
+	assert.ErrorContains(t, err, "makes a routing cycle")
+}
+
 func TestAppendHeaders(t *testing.T) {
 	ns := "defaultappend"
 	c := newDomainRouteTestInfo(ns, 1056)
@@ -1035,3 +1093,66 @@ func TestDeleteRoute(t *testing.T) {
 	}
 	assert.Nil(t, dvh)
 }
+
+func TestNegotiateBodyEncryption(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			Transit: &kusciaapisv1alpha1.Transit{
+				TransitMethod: kusciaapisv1alpha1.TransitMethodThirdDomain,
+				Domain:        &kusciaapisv1alpha1.DomainTransit{DomainID: "carol"},
+			},
+		},
+	}
+	assert.NoError(t, negotiateBodyEncryption(dr, ""))
+	assert.NoError(t, negotiateBodyEncryption(dr, bodyEncryptionNone))
+	assert.Error(t, negotiateBodyEncryption(dr, string(kusciaapisv1alpha1.BodyEncryptionAlgorithmAES)))
+	assert.Equal(t, kusciaapisv1alpha1.DomainRouteBodyEncryptionStatus{}, bodyEncryptionStatus(dr, 1))
+
+	dr.Spec.BodyEncryption = &kusciaapisv1alpha1.BodyEncryption{Algorithm: kusciaapisv1alpha1.BodyEncryptionAlgorithmAES}
+	assert.NoError(t, negotiateBodyEncryption(dr, ""))
+	assert.NoError(t, negotiateBodyEncryption(dr, string(kusciaapisv1alpha1.BodyEncryptionAlgorithmAES)))
+	assert.Error(t, negotiateBodyEncryption(dr, string(kusciaapisv1alpha1.BodyEncryptionAlgorithmSM4)))
+	assert.Error(t, negotiateBodyEncryption(dr, bodyEncryptionNone))
+	assert.Equal(t, kusciaapisv1alpha1.DomainRouteBodyEncryptionStatus{
+		Algorithm:   kusciaapisv1alpha1.BodyEncryptionAlgorithmAES,
+		EndToEnd:    true,
+		KeyRevision: 2,
+	}, bodyEncryptionStatus(dr, 2))
+}
+
+func TestProposeHTTP3(t *testing.T) {
+	c := &DomainRouteController{masterConfig: &config.MasterConfig{Namespace: "kuscia-system"}}
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host: "bob.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{
+					{Name: "http", Port: 1080, Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, IsTLS: true},
+				},
+			},
+		},
+	}
+	assert.False(t, c.proposeHTTP3(dr))
+
+	c.http3 = true
+	assert.True(t, c.proposeHTTP3(dr))
+
+	dr.Spec.Endpoint.Ports = append(dr.Spec.Endpoint.Ports, kusciaapisv1alpha1.DomainPort{
+		Name: "grpc", Port: 2080, Protocol: kusciaapisv1alpha1.DomainRouteProtocolGRPC,
+	})
+	assert.False(t, c.proposeHTTP3(dr))
+
+	dr.Spec.Endpoint.Ports = dr.Spec.Endpoint.Ports[:1]
+	dr.Spec.MTUWorkaround = &kusciaapisv1alpha1.DomainRouteMTUWorkaround{}
+	assert.False(t, c.proposeHTTP3(dr))
+
+	dr.Spec.MTUWorkaround = nil
+	dr.Spec.Destination = "kuscia-system"
+	assert.False(t, c.proposeHTTP3(dr))
+}
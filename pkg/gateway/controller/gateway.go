@@ -40,16 +40,13 @@ import (
 	kuscialistersv1alpha1 "github.com/secretflow/kuscia/pkg/crd/listers/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 	"github.com/secretflow/kuscia/pkg/utils/meta"
 	"github.com/secretflow/kuscia/pkg/utils/network"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
 )
 
-const (
-	heartbeatPeriod = 15 * time.Second
-)
-
 var (
 	gwAddrs []string
 )
@@ -61,6 +58,8 @@ type GatewayController struct {
 	hostname  string
 	address   string
 	uptime    time.Time
+	// heartbeatPeriod is the period of updating gateway status, which is the heartbeat of domain to master.
+	heartbeatPeriod time.Duration
 
 	lock sync.Mutex
 
@@ -85,6 +84,7 @@ func NewGatewayController(namespace string, prikey *rsa.PrivateKey, kusciaClient
 		hostname:            hostname,
 		address:             address,
 		uptime:              time.Now(),
+		heartbeatPeriod:     kusciaconfig.DefaultHeartbeatInterval,
 		kusciaClient:        kusciaClient,
 		gatewayLister:       informer.Lister(),
 		gatewayListerSynced: informer.Informer().HasSynced,
@@ -93,6 +93,13 @@ func NewGatewayController(namespace string, prikey *rsa.PrivateKey, kusciaClient
 	return controller, nil
 }
 
+// SetHeartbeatPeriod sets the period of heartbeats, it must be called before Run.
+func (c *GatewayController) SetHeartbeatPeriod(period time.Duration) {
+	if period > 0 {
+		c.heartbeatPeriod = period
+	}
+}
+
 func (c *GatewayController) GatewayName() string {
 	return c.hostname
 }
@@ -113,7 +120,7 @@ func (c *GatewayController) Run(threadiness int, stopCh <-chan struct{}) {
 	if err := c.syncHandler(); err != nil {
 		nlog.Errorf("sync gateway error: %v", err)
 	}
-	ticker := time.NewTicker(heartbeatPeriod)
+	ticker := time.NewTicker(c.heartbeatPeriod)
 	defer ticker.Stop()
 	for {
 		select {
@@ -158,8 +165,9 @@ func (c *GatewayController) syncHandler() error {
 		HeartbeatTime: metav1.Time{
 			Time: time.Now(),
 		},
-		PublicKey: base64.StdEncoding.EncodeToString(c.publicKey),
-		Version:   meta.KusciaVersionString(),
+		HeartbeatIntervalSeconds: int32(c.heartbeatPeriod / time.Second),
+		PublicKey:                base64.StdEncoding.EncodeToString(c.publicKey),
+		Version:                  meta.KusciaVersionString(),
 	}
 
 	{
@@ -182,7 +190,7 @@ func (c *GatewayController) syncHandler() error {
 		nlog.Errorf("get gateway list(namespace:%s) fail: %v", c.namespace, err)
 		return err
 	}
-	thresh := time.Now().Add(-2 * heartbeatPeriod)
+	thresh := time.Now().Add(-2 * c.heartbeatPeriod)
 	var ga []string
 	for _, gw := range gws {
 		if gw.Status.HeartbeatTime.After(thresh) {
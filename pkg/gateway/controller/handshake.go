@@ -34,11 +34,13 @@ import (
 	"github.com/secretflow/kuscia/pkg/common"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	clientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/pkg/gateway/certissuance"
 	"github.com/secretflow/kuscia/pkg/gateway/clusters"
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/gateway/xds"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/pkg/web/interceptor"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/handshake"
 )
@@ -53,6 +55,9 @@ const (
 	handShakeTypeRSA = "RSA"
 )
 
+// bodyEncryptionNone is sent in handshake if body encryption is disabled, sources of old versions send nothing.
+const bodyEncryptionNone = "None"
+
 const (
 	kusciaTokenRevision = "Kuscia-Token-Revision"
 )
@@ -71,6 +76,8 @@ type RevisionToken struct {
 	PublicKey      *rsa.PublicKey
 	ExpirationTime int64
 	Revision       int32
+	// HTTP3 is true if destination accepted HTTP/3 in handshake.
+	HTTP3 bool
 }
 
 type AfterRegisterDomainHook func(response *handshake.RegisterResponse)
@@ -78,20 +85,27 @@ type AfterRegisterDomainHook func(response *handshake.RegisterResponse)
 func (c *DomainRouteController) startHandShakeServer(port uint32) {
 	mux := http.NewServeMux()
 	mux.HandleFunc(utils.GetHandshakePathSuffix(), c.handShakeHandle)
+	registerDiagnoseHandlers(mux, c.gateway.Namespace)
+	registerMetadataHandlers(mux, c.gateway.Namespace, c.kusciaClient)
+	mux.HandleFunc(utils.InternalAuthRejectPath, internalAuthRejectHandle)
 	if c.isMaser {
 		mux.HandleFunc("/register", c.registerHandle)
+		mux.HandleFunc(utils.JoinPath, c.joinHandle)
+		if handler, ok := c.certIssuer.(http.Handler); ok {
+			mux.Handle(certissuance.HTTP01ChallengePrefix, handler)
+		}
 	}
 
 	c.handshakeServer = &http.Server{
 		Addr:    fmt.Sprintf("0.0.0.0:%d", port),
-		Handler: mux,
+		Handler: interceptor.HTTPStandardHandler(mux, interceptor.HTTPMiddlewareConfig{}),
 	}
 
 	nlog.Error(c.handshakeServer.ListenAndServe())
 }
 
 func doHTTPWithDefaultRetry(in interface{}, out interface{}, hp *utils.HTTPParam) error {
-	return utils.DoHTTPWithRetry(in, out, hp, time.Second, 5)
+	return utils.DoHTTPWithRetry(context.Background(), in, out, hp, time.Second, 5)
 }
 
 func (c *DomainRouteController) waitTokenReady(drName string) error {
@@ -140,12 +154,16 @@ func (c *DomainRouteController) checkConnectionStatus(dr *kusciaapisv1alpha1.Dom
 	err := utils.DoHTTP(nil, out, hp)
 	if err != nil {
 		c.markDestUnreachable(context.Background(), dr)
+		c.updateRouteConditions(dr, endpointConditions(dr, err)...)
 		return err
 	}
 
 	c.refreshHeartbeatTime(dr)
 	c.markDestReachable(context.Background(), dr)
-	return c.handleGetResponse(out, dr)
+	c.handleDestKeyRotation(dr, out.KeyRotation)
+	err = c.handleGetResponse(out, dr)
+	c.updateRouteConditions(dr, append(endpointConditions(dr, nil), tokenConditions(out.State, err)...)...)
+	return err
 }
 
 func (c *DomainRouteController) handleGetResponse(out *getResponse, dr *kusciaapisv1alpha1.DomainRoute) error {
@@ -212,14 +230,18 @@ func calcPublicKeyHash(pubStr string) ([]byte, error) {
 }
 
 func (c *DomainRouteController) sourceInitiateHandShake(dr *kusciaapisv1alpha1.DomainRoute, clusterName string) error {
-	if dr.Spec.TokenConfig.SourcePublicKey != c.gateway.Status.PublicKey {
+	routeKey := c.routeKey(dr)
+	if routeKey == nil {
 		nlog.Errorf("DomainRoute %s: mismatch source public key", dr.Name)
 		return nil
 	}
 
 	handshankeReq := &handshake.HandShakeRequest{
-		DomainId:    dr.Spec.Source,
-		RequestTime: time.Now().UnixNano(),
+		DomainId:                dr.Spec.Source,
+		RequestTime:             time.Now().UnixNano(),
+		BodyEncryptionAlgorithm: bodyEncryptionAlgorithm(dr),
+		Http3:                   c.proposeHTTP3(dr),
+		KeyRotation:             c.keyRotationAdvertisement(),
 	}
 
 	//1. In UID mode, the token is directly generated by the peer end and encrypted by the local public key
@@ -251,7 +273,7 @@ func (c *DomainRouteController) sourceInitiateHandShake(dr *kusciaapisv1alpha1.D
 			nlog.Error(err)
 			return err
 		}
-		token, err = decryptToken(c.prikey, resp.Token.Token, tokenByteSize)
+		token, err = c.keys.decrypt(resp.Token.Token, tokenByteSize)
 		if err != nil {
 			err = fmt.Errorf("DomainRoute %s: handshake fail, return error:%v", dr.Name, resp.Status.Message)
 			nlog.Error(err)
@@ -260,7 +282,7 @@ func (c *DomainRouteController) sourceInitiateHandShake(dr *kusciaapisv1alpha1.D
 	} else if dr.Spec.TokenConfig.TokenGenMethod == kusciaapisv1alpha1.TokenGenMethodRSA {
 		handshankeReq.Type = handShakeTypeRSA
 
-		msgHashSum, err := calcPublicKeyHash(c.gateway.Status.PublicKey)
+		msgHashSum, err := calcPublicKeyHash(dr.Spec.TokenConfig.SourcePublicKey)
 		if err != nil {
 			return err
 		}
@@ -319,7 +341,7 @@ func (c *DomainRouteController) sourceInitiateHandShake(dr *kusciaapisv1alpha1.D
 			nlog.Warn(err)
 			return err
 		}
-		destToken, err := decryptToken(c.prikey, resp.Token.Token, tokenByteSize/2)
+		destToken, err := c.keys.decrypt(resp.Token.Token, tokenByteSize/2)
 		if err != nil {
 			err = fmt.Errorf("DomainRoute %s: handshake fail, decryptToken  error:%v", dr.Name, resp.Status.Message)
 			nlog.Warn(err)
@@ -329,13 +351,19 @@ func (c *DomainRouteController) sourceInitiateHandShake(dr *kusciaapisv1alpha1.D
 	} else {
 		return fmt.Errorf("TokenGenMethod must be %s or %s", kusciaapisv1alpha1.TokenGenUIDRSA, kusciaapisv1alpha1.TokenGenMethodRSA)
 	}
+	if err := negotiateBodyEncryption(dr, resp.BodyEncryptionAlgorithm); err != nil {
+		err = fmt.Errorf("DomainRoute %s: handshake fail, %v", dr.Name, err)
+		nlog.Warn(err)
+		return err
+	}
 
 	// The final token is encrypted with the local private key and stored in the status of domainroute
 	revisionToken := &RevisionToken{
 		RawToken:       token,
-		PublicKey:      &c.prikey.PublicKey,
+		PublicKey:      &routeKey.PublicKey,
 		Revision:       resp.Token.Revision,
 		ExpirationTime: resp.Token.ExpirationTime,
+		HTTP3:          handshankeReq.Http3 && resp.Http3,
 	}
 
 	return UpdateDomainRouteRevisionToken(c.kusciaClient, dr.Namespace, dr.Name, revisionToken)
@@ -359,6 +387,9 @@ func UpdateDomainRouteRevisionToken(kusciaClient clientset.Interface, namespace,
 	drUpdateRevisionToken.Revision = int64(revisionToken.Revision)
 	drUpdateRevisionToken.IsReady = true
 	drUpdateRevisionToken.RevisionTime = tn
+	drUpdateStatus.BodyEncryption = bodyEncryptionStatus(drUpdate, drUpdateRevisionToken.Revision)
+	drUpdateStatus.HTTP3 = revisionToken.HTTP3
+	setRouteConditions(drUpdateStatus, handshakeConditions(drUpdate, nil))
 	if drUpdate.Spec.TokenConfig.RollingUpdatePeriod == 0 {
 		drUpdateRevisionToken.ExpirationTime = metav1.NewTime(tn.AddDate(100, 0, 0))
 	} else {
@@ -386,6 +417,8 @@ const (
 type getResponse struct {
 	Namespace string            `json:"namespace"`
 	State     DestinationStatus `json:"state"`
+	// KeyRotation advertises the new key of destination during key rotation.
+	KeyRotation *handshake.KeyRotation `json:"keyRotation,omitempty"`
 }
 
 func (c *DomainRouteController) handShakeHandle(w http.ResponseWriter, r *http.Request) {
@@ -402,6 +435,7 @@ func (c *DomainRouteController) handShakeHandle(w http.ResponseWriter, r *http.R
 		}
 		tokenRevision := r.Header.Get(kusciaTokenRevision)
 		resp.State = c.checkTokenStatus(domainID, tokenRevision)
+		resp.KeyRotation = c.keyRotationAdvertisement()
 
 		w.Header().Set("Content-Type", "application/json")
 		err := json.NewEncoder(w).Encode(resp)
@@ -508,6 +542,9 @@ func (c *DomainRouteController) DestReplyHandshake(req *handshake.HandShakeReque
 		!(req.Type == handShakeTypeRSA && dr.Spec.TokenConfig.TokenGenMethod == kusciaapisv1alpha1.TokenGenMethodRSA) {
 		return buildFailedHandshakeReply(500, fmt.Errorf("handshake type [%s] mismatch in domainroute [%s]", req.Type, dr.Spec.TokenConfig.TokenGenMethod))
 	}
+	if err := negotiateBodyEncryption(dr, req.BodyEncryptionAlgorithm); err != nil {
+		return buildFailedHandshakeReply(500, fmt.Errorf("domainroute [%s]: %v", drName, err))
+	}
 	srcPub, err := base64.StdEncoding.DecodeString(dr.Spec.TokenConfig.SourcePublicKey)
 	if err != nil {
 		return buildFailedHandshakeReply(500, fmt.Errorf("invalid source domain [%s] publickey in domainroute [%s], must be based64 encoded string", srcPub, drName))
@@ -516,6 +553,14 @@ func (c *DomainRouteController) DestReplyHandshake(req *handshake.HandShakeReque
 	if err != nil {
 		return buildFailedHandshakeReply(500, fmt.Errorf("invalid source domain [%s] publickey in domainroute [%s], error: %s", srcDomain, drName, err.Error()))
 	}
+	adopted, err := c.adoptKeyRotation(context.Background(), srcDomain, dr.Spec.TokenConfig.SourcePublicKey, req.KeyRotation)
+	if err != nil {
+		nlog.Warnf("DomainRoute %s adopt key rotation of source fail, %v", drName, err)
+	}
+	routeKey := c.routeKey(dr)
+	if routeKey == nil {
+		return buildFailedHandshakeReply(500, fmt.Errorf("dest domain [%s] publickey mismatch in domainroute [%s]", destDomain, drName))
+	}
 	dstRevisionToken := dr.Status.TokenStatus.RevisionToken
 
 	var token []byte
@@ -538,7 +583,7 @@ func (c *DomainRouteController) DestReplyHandshake(req *handshake.HandShakeReque
 				return buildFailedHandshakeReply(500, fmt.Errorf("generate auth token in dest domain [%s] error: %s", destDomain, err.Error()))
 			}
 		} else {
-			respToken, err = decryptToken(c.prikey, dstRevisionToken.Token, tokenByteSize)
+			respToken, err = c.keys.decrypt(dstRevisionToken.Token, tokenByteSize)
 			if err != nil {
 				nlog.Warnf("decrypt token with revision [%d] in dest domain [%s] error: %s", dstRevisionToken.Revision, destDomain, err.Error())
 				respToken, err = generateRandomToken(tokenByteSize)
@@ -554,10 +599,17 @@ func (c *DomainRouteController) DestReplyHandshake(req *handshake.HandShakeReque
 		if err != nil {
 			return buildFailedHandshakeReply(500, fmt.Errorf("caculate source domain [%s] publickey hash error: %s", srcDomain, err.Error()))
 		}
-		if req.TokenConfig.Pubhash != base64.StdEncoding.EncodeToString(msgHashSum) {
+		pubhash := base64.StdEncoding.EncodeToString(msgHashSum)
+		if req.TokenConfig.Pubhash != pubhash && adopted {
+			// source may handshake with its new key before the domainroute is updated
+			if msgHashSum, err = calcPublicKeyHash(req.KeyRotation.PublicKey); err == nil {
+				pubhash = base64.StdEncoding.EncodeToString(msgHashSum)
+			}
+		}
+		if req.TokenConfig.Pubhash != pubhash {
 			return buildFailedHandshakeReply(500, fmt.Errorf("source domain [%s] publickey hash mismatch in domainroute [%s]", srcDomain, dr.Name))
 		}
-		sourceToken, err := decryptToken(c.prikey, req.TokenConfig.Token, tokenByteSize/2)
+		sourceToken, err := c.keys.decrypt(req.TokenConfig.Token, tokenByteSize/2)
 		if err != nil {
 			nlog.Errorf("dest domain [%s] publickey in source domain [%s] may be not correct, error: %s", destDomain, srcDomain, err.Error())
 			return buildFailedHandshakeReply(500, fmt.Errorf("dest domain [%s] publickey in source domain [%s] may be not correct", destDomain, srcDomain))
@@ -571,7 +623,7 @@ func (c *DomainRouteController) DestReplyHandshake(req *handshake.HandShakeReque
 		token = append(sourceToken, respToken...)
 	}
 
-	tokenEncrypted, err := encryptToken(&c.prikey.PublicKey, token)
+	tokenEncrypted, err := encryptToken(&routeKey.PublicKey, token)
 	if err != nil {
 		return buildFailedHandshakeReply(500, fmt.Errorf("encrypt auth token with dest domain [%s] publickey error: %s", destDomain, err.Error()))
 	}
@@ -601,6 +653,7 @@ func (c *DomainRouteController) DestReplyHandshake(req *handshake.HandShakeReque
 			expirationTime = metav1.NewTime(revisionTime.Add(2 * time.Duration(drCopy.Spec.TokenConfig.RollingUpdatePeriod) * time.Second))
 		}
 		drCopy.Status.TokenStatus.RevisionToken.ExpirationTime = expirationTime
+		drCopy.Status.BodyEncryption = bodyEncryptionStatus(drCopy, revision)
 		_, err = c.kusciaClient.KusciaV1alpha1().DomainRoutes(drCopy.Namespace).UpdateStatus(context.Background(), drCopy, metav1.UpdateOptions{})
 		if err != nil {
 			return buildFailedHandshakeReply(500, fmt.Errorf("update domainRoute [%s] in dest domain [%s] error: %s", drName, destDomain, err.Error()))
@@ -625,6 +678,54 @@ func (c *DomainRouteController) DestReplyHandshake(req *handshake.HandShakeReque
 			ExpirationTime: expirationTime.UnixNano(),
 			Revision:       int32(revision),
 		},
+		BodyEncryptionAlgorithm: bodyEncryptionAlgorithm(dr),
+		Http3:                   req.Http3 && c.http3,
+	}
+}
+
+// proposeHTTP3 returns whether HTTP/3 is proposed to the destination of dr. QUIC needs TLS on all ports, and the
+// routes to master, through other domains or with MTU workaround stay on TCP.
+func (c *DomainRouteController) proposeHTTP3(dr *kusciaapisv1alpha1.DomainRoute) bool {
+	if !c.http3 || dr.Spec.Destination == c.getMasterNamespace() || dr.Spec.MTUWorkaround != nil ||
+		utils.IsTransit(dr.Spec.Transit) || len(dr.Spec.Endpoint.Ports) == 0 {
+		return false
+	}
+	for _, dp := range dr.Spec.Endpoint.Ports {
+		if !dp.IsTLS {
+			return false
+		}
+	}
+	return true
+}
+
+func bodyEncryptionAlgorithm(dr *kusciaapisv1alpha1.DomainRoute) string {
+	if dr.Spec.BodyEncryption == nil {
+		return bodyEncryptionNone
+	}
+	return string(dr.Spec.BodyEncryption.Algorithm)
+}
+
+// negotiateBodyEncryption checks the body encryption algorithm of peer is the same as the local one, otherwise the
+// body encrypted by one side can't be decrypted by the other. Peers of old versions don't send the algorithm, they are
+// trusted to follow the route.
+func negotiateBodyEncryption(dr *kusciaapisv1alpha1.DomainRoute, peerAlgorithm string) error {
+	if peerAlgorithm == "" {
+		return nil
+	}
+	if local := bodyEncryptionAlgorithm(dr); peerAlgorithm != local {
+		return fmt.Errorf("body encryption mismatch, local is [%s] but peer is [%s]", local, peerAlgorithm)
+	}
+	return nil
+}
+
+func bodyEncryptionStatus(dr *kusciaapisv1alpha1.DomainRoute, revision int64) kusciaapisv1alpha1.DomainRouteBodyEncryptionStatus {
+	if dr.Spec.BodyEncryption == nil {
+		return kusciaapisv1alpha1.DomainRouteBodyEncryptionStatus{}
+	}
+	return kusciaapisv1alpha1.DomainRouteBodyEncryptionStatus{
+		Algorithm:   dr.Spec.BodyEncryption.Algorithm,
+		EndToEnd:    utils.IsThirdPartyTransit(dr.Spec.Transit),
+		KeyRevision: revision,
 	}
 }
 
@@ -633,7 +734,8 @@ func (c *DomainRouteController) parseToken(dr *kusciaapisv1alpha1.DomainRoute, r
 	var err error
 
 	is3rdParty := utils.IsThirdPartyTransit(dr.Spec.Transit)
-	if (is3rdParty && dr.Spec.BodyEncryption == nil) ||
+	signed := dr.Spec.SecurityLevel == kusciaapisv1alpha1.DomainRouteSecurityLevelSigned
+	if (is3rdParty && dr.Spec.BodyEncryption == nil && !signed) ||
 		(!is3rdParty && dr.Spec.AuthenticationType == kusciaapisv1alpha1.DomainAuthenticationMTLS) ||
 		(!is3rdParty && dr.Spec.AuthenticationType == kusciaapisv1alpha1.DomainAuthenticationNone) {
 		tokens = append(tokens, &Token{Token: NoopToken})
@@ -665,14 +767,13 @@ func (c *DomainRouteController) parseTokenRSA(dr *kusciaapisv1alpha1.DomainRoute
 		return tokens, nil
 	}
 
-	if (c.gateway.Namespace == dr.Spec.Source && dr.Spec.TokenConfig.SourcePublicKey != c.gateway.Status.PublicKey) ||
-		(c.gateway.Namespace == dr.Spec.Destination && dr.Spec.TokenConfig.DestinationPublicKey != c.gateway.Status.PublicKey) {
+	if c.routeKey(dr) == nil {
 		err := fmt.Errorf("DomainRoute %s mismatch public key", key)
 		return tokens, err
 	}
 
 	for _, token := range dr.Status.TokenStatus.Tokens {
-		b, err := decryptToken(c.prikey, token.Token, tokenByteSize)
+		b, err := c.keys.decrypt(token.Token, tokenByteSize)
 		if err != nil {
 			if !drop {
 				return []*Token{}, fmt.Errorf("DomainRoute %s decrypt token error: %v", key, err)
@@ -705,6 +806,17 @@ func (c *DomainRouteController) checkAndUpdateTokenInstances(dr *kusciaapisv1alp
 	return nil
 }
 
+// routeKey returns the private key of the public key of this domain in route, nil if the public key is not in use.
+func (c *DomainRouteController) routeKey(dr *kusciaapisv1alpha1.DomainRoute) *rsa.PrivateKey {
+	switch c.gateway.Namespace {
+	case dr.Spec.Source:
+		return c.keys.get(dr.Spec.TokenConfig.SourcePublicKey)
+	case dr.Spec.Destination:
+		return c.keys.get(dr.Spec.TokenConfig.DestinationPublicKey)
+	}
+	return c.prikey
+}
+
 func generateRandomToken(size int) ([]byte, error) {
 	respToken := make([]byte, size)
 	if _, err := rand.Read(respToken); err != nil {
@@ -763,7 +875,12 @@ func HandshakeToMaster(domainID string, pathPrefix string, prikey *rsa.PrivateKe
 				nlog.Warn(resp.Status.Message)
 			}
 		}
-		time.Sleep(time.Second)
+		if i < maxRetryTimes-1 {
+			if err := utils.DefaultRetryBudget.Wait(context.Background(), clusters.GetMasterClusterName(), time.Second); err != nil {
+				nlog.Warnf("Stop retrying handshake to master, %v", err)
+				break
+			}
+		}
 	}
 
 	if resp.Status.Code != 0 {
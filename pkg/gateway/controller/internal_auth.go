@@ -0,0 +1,35 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// internalAuthRejectHandle serves the requests which the internal listener rejects for missing or invalid internal
+// token, it logs the attempt and returns 403.
+func internalAuthRejectHandle(w http.ResponseWriter, r *http.Request) {
+	path := "/" + strings.TrimPrefix(r.URL.Path, utils.InternalAuthRejectPath)
+	nlog.Warnf("Rejected internal request from %s to cluster(%s) path(%s), source(%s), invalid internal token",
+		r.Header.Get(utils.DownstreamAddressHeader), r.Header.Get(fmt.Sprintf("%s-Cluster", utils.ServiceHandshake)),
+		path, r.Header.Get("Kuscia-Source"))
+	http.Error(w, "internal token is invalid, only kuscia components can request other clusters by handshake route",
+		http.StatusForbidden)
+}
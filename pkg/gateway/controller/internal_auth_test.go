@@ -0,0 +1,53 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestInternalRouteRequiresToken(t *testing.T) {
+	vh, err := xds.QueryVirtualHost(xds.DefaultVirtualHost, xds.InternalRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, "handeshake-route", vh.Routes[0].Name)
+	headers := vh.Routes[0].Match.Headers
+	assert.Len(t, headers, 2)
+	assert.Equal(t, utils.InternalTokenHeader, headers[1].Name)
+	assert.Equal(t, utils.InternalToken(), headers[1].GetStringMatch().GetExact())
+	assert.Contains(t, vh.Routes[0].RequestHeadersToRemove, utils.InternalTokenHeader)
+
+	assert.Equal(t, "internal-auth-reject-route", vh.Routes[1].Name)
+	assert.Equal(t, utils.InternalAuthRejectPath, vh.Routes[1].GetRoute().GetPrefixRewrite())
+}
+
+func TestInternalAuthRejectHandle(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(utils.InternalAuthRejectPath, internalAuthRejectHandle)
+
+	req := httptest.NewRequest(http.MethodPost, utils.InternalAuthRejectPath+"register", nil)
+	req.Header.Set("Kuscia-Handshake-Cluster", "service-master")
+	req.Header.Set(utils.DownstreamAddressHeader, "10.88.0.5")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "internal token is invalid")
+}
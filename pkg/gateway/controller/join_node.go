@@ -0,0 +1,158 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/handshake"
+)
+
+// JoinDomain sends the csr of domain with a join token embedded to master, the master signs the domain certificate and
+// creates the domain. The request is sent to the external endpoint of master directly, because the gateway of the
+// joining domain isn't running yet.
+func JoinDomain(client *http.Client, masterEndpoint, domainID, csrData string, priKey *rsa.PrivateKey) (*handshake.RegisterResponse, error) {
+	req, token, err := generateJwtToken(domainID, csrData, priKey)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(masterEndpoint, "/")+utils.JoinPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Host = fmt.Sprintf("%s.master.svc", utils.ServiceHandshake)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("jwt-token", token)
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("join failed, status_code: %d, msg: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	joinResp := &handshake.RegisterResponse{}
+	if err := json.Unmarshal(respBody, joinResp); err != nil {
+		return nil, fmt.Errorf("decode join response failed, %v", err)
+	}
+	return joinResp, nil
+}
+
+// joinHandle signs the certificate of a new domain and creates the domain, the domain is authorized by a one-time join
+// token stored as a secret in master namespace.
+func (c *DomainRouteController) joinHandle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	req := handshake.RegisterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpErrWrapped(w, err, http.StatusBadRequest)
+		return
+	}
+	certRequest, err := parseCertRequest(req.Csr)
+	if err != nil {
+		httpErrWrapped(w, err, http.StatusBadRequest)
+		return
+	}
+	// verify jwt token and csr
+	if err = verifyRegisterRequest(&req, r.Header.Get("jwt-token")); err != nil {
+		httpErrWrapped(w, err, http.StatusBadRequest)
+		return
+	}
+	joinToken := getTokenFromCertRequest(certRequest)
+	if joinToken == "" {
+		httpErrWrapped(w, fmt.Errorf("join token not found in cert request"), http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.Background()
+	secrets := c.kubeClient.CoreV1().Secrets(c.gateway.Namespace)
+	secret, err := secrets.Get(ctx, utils.JoinTokenSecretName(joinToken), metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			httpErrWrapped(w, fmt.Errorf("domain [%s] join failed, join token is invalid or used", req.DomainId), http.StatusForbidden)
+		} else {
+			httpErrWrapped(w, fmt.Errorf("get join token failed, detail -> %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := utils.VerifyJoinTokenSecret(secret, joinToken, req.DomainId, time.Now()); err != nil {
+		httpErrWrapped(w, fmt.Errorf("domain [%s] join failed, %v", req.DomainId, err), http.StatusForbidden)
+		return
+	}
+
+	if _, err := c.kusciaClient.KusciaV1alpha1().Domains().Get(ctx, req.DomainId, metav1.GetOptions{}); err == nil {
+		httpErrWrapped(w, fmt.Errorf("domain [%s] already exists, use deploy token of the domain to register instead", req.DomainId), http.StatusConflict)
+		return
+	} else if !k8serrors.IsNotFound(err) {
+		httpErrWrapped(w, fmt.Errorf("get domain [%s] info failed, detail -> %v", req.DomainId, err), http.StatusInternalServerError)
+		return
+	}
+
+	// consume the token before creating domain, the uid precondition makes sure only one request wins
+	if err := secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &secret.UID}}); err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsConflict(err) {
+			httpErrWrapped(w, fmt.Errorf("domain [%s] join failed, join token is used", req.DomainId), http.StatusForbidden)
+		} else {
+			httpErrWrapped(w, fmt.Errorf("consume join token failed, detail -> %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	_, domainCrtStr, err := c.signDomainCert(certRequest)
+	if err != nil {
+		httpErrWrapped(w, err, http.StatusInternalServerError)
+		return
+	}
+	domain := &kusciaapisv1alpha1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: req.DomainId},
+		Spec:       kusciaapisv1alpha1.DomainSpec{Cert: domainCrtStr},
+	}
+	if _, err := c.kusciaClient.KusciaV1alpha1().Domains().Create(ctx, domain, metav1.CreateOptions{}); err != nil {
+		httpErrWrapped(w, fmt.Errorf("create domain [%s] failed, detail -> %v", req.DomainId, err), http.StatusInternalServerError)
+		return
+	}
+	nlog.Infof("Domain %s joined, domain is created with signed cert", req.DomainId)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&handshake.RegisterResponse{Cert: domainCrtStr}); err != nil {
+		nlog.Errorf("encode join response for(%s) fail, detail-> %v", req.DomainId, err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+func TestJoinDomain(t *testing.T) {
+	t.Parallel()
+	caKey, caBytes, err := tls.CreateCA("kuscia-system")
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caBytes)
+	assert.NoError(t, err)
+
+	masterNamespace := "kuscia-system"
+	expired := utils.NewJoinTokenSecret(masterNamespace, "token-expired", "", time.Hour)
+	expired.Annotations[utils.AnnotationJoinTokenExpiration] = time.Now().Add(-time.Hour).Format(time.RFC3339)
+	kubeClient := fake.NewSimpleClientset(
+		utils.NewJoinTokenSecret(masterNamespace, "token-alice", "", time.Hour),
+		utils.NewJoinTokenSecret(masterNamespace, "token-bob", utBob, 0),
+		expired,
+	)
+	kusciaClient := kusciafake.NewSimpleClientset()
+	c := &DomainRouteController{
+		gateway:      &kusciaapisv1alpha1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: masterNamespace}},
+		kubeClient:   kubeClient,
+		kusciaClient: kusciaClient,
+		CaCert:       caCert,
+		CaKey:        caKey,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(utils.JoinPath, c.joinHandle)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	join := func(domainID, joinToken string) error {
+		keyData, err := tls.GenerateKeyData()
+		assert.NoError(t, err)
+		rawKey, _ := base64.StdEncoding.DecodeString(keyData)
+		key, err := tls.ParseKey(rawKey, "")
+		assert.NoError(t, err)
+		resp, err := JoinDomain(server.Client(), server.URL, domainID, confloader.GenerateCsrData(domainID, keyData, joinToken), key)
+		if err == nil {
+			assert.NotEmpty(t, resp.Cert)
+		}
+		return err
+	}
+
+	assert.NoError(t, join(utAlice, "token-alice"))
+	domain, err := kusciaClient.KusciaV1alpha1().Domains().Get(context.Background(), utAlice, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, domain.Spec.Cert)
+	_, err = kubeClient.CoreV1().Secrets(masterNamespace).Get(context.Background(), utils.JoinTokenSecretName("token-alice"), metav1.GetOptions{})
+	assert.Error(t, err, "join token should be consumed")
+
+	// join token is one-time
+	assert.ErrorContains(t, join("carol", "token-alice"), "invalid or used")
+	// join token bound to another domain
+	assert.ErrorContains(t, join("carol", "token-bob"), "issued for domain bob")
+	assert.ErrorContains(t, join("carol", "token-expired"), "expired")
+	assert.ErrorContains(t, join("carol", "token-unknown"), "invalid or used")
+	// domain exists
+	_, err = kubeClient.CoreV1().Secrets(masterNamespace).Create(context.Background(),
+		utils.NewJoinTokenSecret(masterNamespace, "token-alice-2", "", 0), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.ErrorContains(t, join(utAlice, "token-alice-2"), "already exists")
+	assert.NoError(t, join(utBob, "token-bob"))
+}
@@ -0,0 +1,474 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/paths"
+	"github.com/secretflow/kuscia/pkg/utils/resources"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/handshake"
+)
+
+// Key rotation replaces the key which authenticates the routes of domain without interrupting them:
+//  1. A new key is generated and recorded in the status of domain with the signature by the previous key, the public
+//     keys of domain in routes are updated in place, and both keys are accepted from now on.
+//  2. The new key is advertised to partners in handshake requests and in replies of connection checks, they verify the
+//     signature by the key they trust and record it in the status of their copy of domain.
+//  3. The routes handshaked before the rotation are re-handshaked, by the domain itself for its source routes and by
+//     partners for their source routes.
+//  4. The previous key is retired once all routes are re-handshaked, the tokens encrypted by it are re-encrypted.
+const (
+	adminKeyRotationPath = "/keyrotation"
+
+	// nextKeyFile is the new key during rotation, it's renamed to rotatedKeyFile once the rotation completes.
+	nextKeyFile = "domain.key.next"
+	// rotatedKeyFile replaces the domain key for routes.
+	rotatedKeyFile = "domain.key.rotated"
+
+	keyRotationSyncPeriod = 30 * time.Second
+)
+
+// keyRing holds the private keys for routes, the key in use and the new key during rotation.
+type keyRing struct {
+	mtx  sync.RWMutex
+	keys []*rsa.PrivateKey
+}
+
+func newKeyRing(keys ...*rsa.PrivateKey) *keyRing {
+	return &keyRing{keys: keys}
+}
+
+// loadKeyRing loads the keys for routes, the rotated key replaces the domain key and the new key is added during
+// rotation.
+func loadKeyRing(domainKey *rsa.PrivateKey, keyDir string) (*keyRing, error) {
+	if keyDir == "" {
+		return newKeyRing(domainKey), nil
+	}
+	keys := []*rsa.PrivateKey{domainKey}
+	if file := filepath.Join(keyDir, rotatedKeyFile); paths.CheckFileExist(file) {
+		key, err := tlsutils.ParseKey(nil, file)
+		if err != nil {
+			return nil, fmt.Errorf("load rotated key %s fail, %v", file, err)
+		}
+		keys = []*rsa.PrivateKey{key}
+	}
+	if file := filepath.Join(keyDir, nextKeyFile); paths.CheckFileExist(file) {
+		key, err := tlsutils.ParseKey(nil, file)
+		if err != nil {
+			return nil, fmt.Errorf("load next key %s fail, %v", file, err)
+		}
+		keys = append(keys, key)
+	}
+	return newKeyRing(keys...), nil
+}
+
+func encodePublicKey(key *rsa.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(tlsutils.EncodePKCS1PublicKey(key))
+}
+
+// get returns the private key of the public key, nil if not found.
+func (k *keyRing) get(publicKey string) *rsa.PrivateKey {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	for _, key := range k.keys {
+		if encodePublicKey(key) == publicKey {
+			return key
+		}
+	}
+	return nil
+}
+
+func (k *keyRing) add(key *rsa.PrivateKey) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	k.keys = append(k.keys, key)
+}
+
+// retain retires the keys other than the one of publicKey.
+func (k *keyRing) retain(publicKey string) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	for _, key := range k.keys {
+		if encodePublicKey(key) == publicKey {
+			k.keys = []*rsa.PrivateKey{key}
+			return
+		}
+	}
+}
+
+// decrypt decrypts the token by the keys in turn, the peer may encrypt it by either key during rotation.
+func (k *keyRing) decrypt(ciphertext string, keysize int) ([]byte, error) {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	var err error
+	for _, key := range k.keys {
+		var token []byte
+		if token, err = decryptToken(key, ciphertext, keysize); err == nil {
+			return token, nil
+		}
+	}
+	return nil, err
+}
+
+// keyRotationAdvertisement returns the key rotation advertised to partners, nil if there is no rotation in progress.
+func (c *DomainRouteController) keyRotationAdvertisement() *handshake.KeyRotation {
+	c.keyRotationMtx.RLock()
+	defer c.keyRotationMtx.RUnlock()
+	rotation := c.keyRotation
+	if rotation == nil || rotation.Phase != kusciaapisv1alpha1.DomainKeyRotationRotating {
+		return nil
+	}
+	return &handshake.KeyRotation{
+		PublicKey: rotation.PublicKey,
+		Signature: rotation.Signature,
+		StartTime: rotation.StartTime.UnixNano(),
+	}
+}
+
+func (c *DomainRouteController) setKeyRotation(rotation *kusciaapisv1alpha1.DomainKeyRotationStatus) {
+	c.keyRotationMtx.Lock()
+	defer c.keyRotationMtx.Unlock()
+	c.keyRotation = rotation
+}
+
+// startKeyRotation generates a new key and records it in the status of domain.
+func (c *DomainRouteController) startKeyRotation(ctx context.Context) (*kusciaapisv1alpha1.DomainKeyRotationStatus, error) {
+	if c.keyDir == "" {
+		return nil, fmt.Errorf("key rotation is not supported by domain %s", c.gateway.Namespace)
+	}
+	domain, err := c.kusciaClient.KusciaV1alpha1().Domains().Get(ctx, c.gateway.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if rotation := resources.DomainKeyRotation(domain); rotation != nil && rotation.Phase == kusciaapisv1alpha1.DomainKeyRotationRotating {
+		return nil, fmt.Errorf("key rotation started at %s is in progress", rotation.StartTime)
+	}
+	publicKey, err := resources.DomainPublicKey(domain)
+	if err != nil {
+		return nil, err
+	}
+	key := c.keys.get(publicKey)
+	if key == nil {
+		return nil, fmt.Errorf("private key of domain %s is not found, the public key in use may be replaced", domain.Name)
+	}
+
+	newKey, err := rsa.GenerateKey(rand.Reader, key.N.BitLen())
+	if err != nil {
+		return nil, err
+	}
+	if err = tlsutils.WritePrivateKeyToFile(newKey, filepath.Join(c.keyDir, nextKeyFile)); err != nil {
+		return nil, err
+	}
+	// the start time is signed, so it's truncated to the precision of status
+	startTime := metav1.NewTime(time.Now().Truncate(time.Second))
+	newPublicKey := encodePublicKey(newKey)
+	signature, err := tlsutils.SignWithRSA(key, resources.KeyRotationSignData(domain.Name, newPublicKey, startTime.UnixNano()))
+	if err != nil {
+		return nil, err
+	}
+
+	domain = domain.DeepCopy()
+	if domain.Status == nil {
+		domain.Status = &kusciaapisv1alpha1.DomainStatus{}
+	}
+	domain.Status.KeyRotation = &kusciaapisv1alpha1.DomainKeyRotationStatus{
+		Phase:             kusciaapisv1alpha1.DomainKeyRotationRotating,
+		PublicKey:         newPublicKey,
+		PreviousPublicKey: publicKey,
+		Signature:         signature,
+		CertHash:          resources.DomainCertHash(domain.Spec.Cert),
+		StartTime:         startTime,
+	}
+	// the new key must be accepted before it's published
+	c.keys.add(newKey)
+	if _, err = c.kusciaClient.KusciaV1alpha1().Domains().UpdateStatus(ctx, domain, metav1.UpdateOptions{}); err != nil {
+		return nil, err
+	}
+	c.setKeyRotation(domain.Status.KeyRotation)
+	nlog.Infof("Domain %s starts key rotation", domain.Name)
+	return domain.Status.KeyRotation, nil
+}
+
+func (c *DomainRouteController) runKeyRotation(stopCh <-chan struct{}) {
+	t := time.NewTicker(keyRotationSyncPeriod)
+	defer t.Stop()
+	for {
+		if err := c.syncKeyRotation(context.Background()); err != nil {
+			nlog.Warnf("Sync key rotation fail, %v", err)
+		}
+		select {
+		case <-t.C:
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// syncKeyRotation re-handshakes the source routes handshaked before the rotation, and retires the previous key once
+// all routes are re-handshaked.
+func (c *DomainRouteController) syncKeyRotation(ctx context.Context) error {
+	domain, err := c.kusciaClient.KusciaV1alpha1().Domains().Get(ctx, c.gateway.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	rotation := resources.DomainKeyRotation(domain)
+	c.setKeyRotation(rotation)
+	if rotation == nil || rotation.Phase != kusciaapisv1alpha1.DomainKeyRotationRotating {
+		return nil
+	}
+	if c.keys.get(rotation.PublicKey) == nil {
+		return fmt.Errorf("new key of rotation started at %s is not found in this gateway", rotation.StartTime)
+	}
+
+	drs, err := c.domainRouteLister.DomainRoutes(c.gateway.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, dr := range drs {
+		if !isTokenRoute(dr) {
+			continue
+		}
+		publicKey := dr.Spec.TokenConfig.DestinationPublicKey
+		if dr.Spec.Source == c.gateway.Namespace {
+			publicKey = dr.Spec.TokenConfig.SourcePublicKey
+		}
+		revisionToken := dr.Status.TokenStatus.RevisionToken
+		if publicKey == rotation.PublicKey && revisionToken.IsReady && !revisionToken.RevisionTime.Before(&rotation.StartTime) {
+			continue
+		}
+		pending = append(pending, dr.Name)
+		if publicKey == rotation.PublicKey && dr.Spec.Source == c.gateway.Namespace {
+			c.rehandshake(ctx, dr, &rotation.StartTime)
+		}
+	}
+
+	domain = domain.DeepCopy()
+	if len(pending) > 0 {
+		if reflect.DeepEqual(pending, rotation.PendingRoutes) {
+			return nil
+		}
+		domain.Status.KeyRotation.PendingRoutes = pending
+		_, err = c.kusciaClient.KusciaV1alpha1().Domains().UpdateStatus(ctx, domain, metav1.UpdateOptions{})
+		return err
+	}
+
+	if err = c.retirePreviousKey(ctx, drs, rotation.PublicKey); err != nil {
+		return err
+	}
+	now := metav1.Now()
+	domain.Status.KeyRotation.Phase = kusciaapisv1alpha1.DomainKeyRotationCompleted
+	domain.Status.KeyRotation.PendingRoutes = nil
+	domain.Status.KeyRotation.CompletionTime = &now
+	if _, err = c.kusciaClient.KusciaV1alpha1().Domains().UpdateStatus(ctx, domain, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.setKeyRotation(domain.Status.KeyRotation)
+	nlog.Infof("Domain %s completes key rotation", domain.Name)
+	return nil
+}
+
+func isTokenRoute(dr *kusciaapisv1alpha1.DomainRoute) bool {
+	return (dr.Spec.BodyEncryption != nil || dr.Spec.AuthenticationType == kusciaapisv1alpha1.DomainAuthenticationToken) &&
+		dr.Spec.TokenConfig != nil && (dr.Spec.TokenConfig.TokenGenMethod == kusciaapisv1alpha1.TokenGenMethodRSA ||
+		dr.Spec.TokenConfig.TokenGenMethod == kusciaapisv1alpha1.TokenGenUIDRSA)
+}
+
+// rehandshake starts a new revision of the source route if it's handshaked before, the tokens in use are kept until
+// the new one is ready.
+func (c *DomainRouteController) rehandshake(ctx context.Context, dr *kusciaapisv1alpha1.DomainRoute, before *metav1.Time) {
+	revisionToken := dr.Status.TokenStatus.RevisionToken
+	if dr.Status.TokenStatus.RevisionInitializer != c.gateway.Name || revisionToken.Token == "" ||
+		!revisionToken.RevisionTime.Before(before) {
+		return
+	}
+	dr = dr.DeepCopy()
+	dr.Status.TokenStatus.RevisionToken = kusciaapisv1alpha1.DomainRouteToken{
+		RevisionTime: metav1.Now(),
+		Revision:     revisionToken.Revision,
+	}
+	if _, err := c.kusciaClient.KusciaV1alpha1().DomainRoutes(dr.Namespace).UpdateStatus(ctx, dr, metav1.UpdateOptions{}); err != nil {
+		nlog.Warnf("DomainRoute %s re-handshake for key rotation fail, %v", dr.Name, err)
+		return
+	}
+	nlog.Infof("DomainRoute %s re-handshakes for key rotation, the last revision is %d", dr.Name, revisionToken.Revision)
+}
+
+// retirePreviousKey re-encrypts the tokens by the new key, then replaces the previous key with it.
+func (c *DomainRouteController) retirePreviousKey(ctx context.Context, drs []*kusciaapisv1alpha1.DomainRoute, publicKey string) error {
+	key := c.keys.get(publicKey)
+	reencrypt := func(token *kusciaapisv1alpha1.DomainRouteToken) (bool, error) {
+		if token.Token == "" {
+			return false, nil
+		}
+		if _, err := decryptToken(key, token.Token, tokenByteSize); err == nil {
+			return false, nil
+		}
+		raw, err := c.keys.decrypt(token.Token, tokenByteSize)
+		if err != nil {
+			return false, err
+		}
+		if token.Token, err = encryptToken(&key.PublicKey, raw); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	for _, dr := range drs {
+		if !isTokenRoute(dr) {
+			continue
+		}
+		dr = dr.DeepCopy()
+		updated, err := reencrypt(&dr.Status.TokenStatus.RevisionToken)
+		if err != nil {
+			return fmt.Errorf("re-encrypt token of DomainRoute %s fail, %v", dr.Name, err)
+		}
+		for i := range dr.Status.TokenStatus.Tokens {
+			tokenUpdated, err := reencrypt(&dr.Status.TokenStatus.Tokens[i])
+			if err != nil {
+				return fmt.Errorf("re-encrypt token of DomainRoute %s fail, %v", dr.Name, err)
+			}
+			updated = updated || tokenUpdated
+		}
+		if !updated {
+			continue
+		}
+		if _, err = c.kusciaClient.KusciaV1alpha1().DomainRoutes(dr.Namespace).UpdateStatus(ctx, dr, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		nlog.Infof("DomainRoute %s re-encrypts tokens for key rotation", dr.Name)
+	}
+
+	if err := os.Rename(filepath.Join(c.keyDir, nextKeyFile), filepath.Join(c.keyDir, rotatedKeyFile)); err != nil {
+		return err
+	}
+	c.keys.retain(publicKey)
+	return nil
+}
+
+// adoptKeyRotation verifies the key rotation advertised by partner with the public key trusted, and records it in the
+// status of domain of partner. It returns whether the new key is adopted.
+func (c *DomainRouteController) adoptKeyRotation(ctx context.Context, domainID, trustedKey string, advertisement *handshake.KeyRotation) (bool, error) {
+	if advertisement == nil || domainID == c.gateway.Namespace {
+		return false, nil
+	}
+	if advertisement.PublicKey == trustedKey {
+		return true, nil
+	}
+	pubPem, err := base64.StdEncoding.DecodeString(trustedKey)
+	if err != nil {
+		return false, err
+	}
+	pub, err := tlsutils.ParseRSAPublicKey(pubPem)
+	if err != nil {
+		return false, err
+	}
+	data := resources.KeyRotationSignData(domainID, advertisement.PublicKey, advertisement.StartTime)
+	if err = tlsutils.VerifyWithRSA(pub, data, advertisement.Signature); err != nil {
+		return false, fmt.Errorf("verify key rotation of domain %s fail, %v", domainID, err)
+	}
+
+	domain, err := c.kusciaClient.KusciaV1alpha1().Domains().Get(ctx, domainID, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if rotation := resources.DomainKeyRotation(domain); rotation != nil && rotation.PublicKey == advertisement.PublicKey {
+		return true, nil
+	}
+	now := metav1.Now()
+	domain = domain.DeepCopy()
+	if domain.Status == nil {
+		domain.Status = &kusciaapisv1alpha1.DomainStatus{}
+	}
+	// partner has nothing to wait for once the new key is recorded
+	domain.Status.KeyRotation = &kusciaapisv1alpha1.DomainKeyRotationStatus{
+		Phase:             kusciaapisv1alpha1.DomainKeyRotationCompleted,
+		PublicKey:         advertisement.PublicKey,
+		PreviousPublicKey: trustedKey,
+		Signature:         advertisement.Signature,
+		CertHash:          resources.DomainCertHash(domain.Spec.Cert),
+		StartTime:         metav1.NewTime(time.Unix(0, advertisement.StartTime)),
+		CompletionTime:    &now,
+	}
+	if _, err = c.kusciaClient.KusciaV1alpha1().Domains().UpdateStatus(ctx, domain, metav1.UpdateOptions{}); err != nil {
+		return false, err
+	}
+	nlog.Infof("Domain %s adopts the new key of partner %s", c.gateway.Namespace, domainID)
+	return true, nil
+}
+
+// handleDestKeyRotation handles the key rotation advertised by destination in connection check, the route is
+// re-handshaked once the public key of destination in route is replaced.
+func (c *DomainRouteController) handleDestKeyRotation(dr *kusciaapisv1alpha1.DomainRoute, advertisement *handshake.KeyRotation) {
+	if advertisement == nil || dr.Spec.TokenConfig == nil {
+		return
+	}
+	if dr.Spec.TokenConfig.DestinationPublicKey != advertisement.PublicKey {
+		if _, err := c.adoptKeyRotation(context.Background(), dr.Spec.Destination, dr.Spec.TokenConfig.DestinationPublicKey, advertisement); err != nil {
+			nlog.Warnf("DomainRoute %s adopt key rotation of destination fail, %v", dr.Name, err)
+		}
+		return
+	}
+	startTime := metav1.NewTime(time.Unix(0, advertisement.StartTime))
+	c.rehandshake(context.Background(), dr, &startTime)
+}
+
+// keyRotationHandle returns the key rotation of domain by GET, and starts a new rotation by POST.
+func (c *DomainRouteController) keyRotationHandle(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		domain, err := c.kusciaClient.KusciaV1alpha1().Domains().Get(r.Context(), c.gateway.Namespace, metav1.GetOptions{})
+		if err != nil {
+			httpErrWrapped(w, err, http.StatusInternalServerError)
+			return
+		}
+		rotation := resources.DomainKeyRotation(domain)
+		if rotation == nil {
+			httpErrWrapped(w, fmt.Errorf("domain %s has no key rotation", domain.Name), http.StatusNotFound)
+			return
+		}
+		writeAdminResponse(w, rotation)
+	case http.MethodPost:
+		rotation, err := c.startKeyRotation(r.Context())
+		if err != nil {
+			statusCode := http.StatusBadRequest
+			if k8serrors.IsConflict(err) {
+				statusCode = http.StatusConflict
+			}
+			httpErrWrapped(w, err, statusCode)
+			return
+		}
+		writeAdminResponse(w, rotation)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	informers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
+	"github.com/secretflow/kuscia/pkg/utils/paths"
+	"github.com/secretflow/kuscia/pkg/utils/resources"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/handshake"
+)
+
+func newKeyRotationTestDomain(t *testing.T, name string) (*rsa.PrivateKey, *kusciaapisv1alpha1.Domain) {
+	key, certBytes, err := tlsutils.CreateCA(name)
+	assert.NoError(t, err)
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	return key, &kusciaapisv1alpha1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       kusciaapisv1alpha1.DomainSpec{Cert: base64.StdEncoding.EncodeToString(cert)},
+	}
+}
+
+func newKeyRotationTestController(key *rsa.PrivateKey, keyDir string, kusciaClient *kusciafake.Clientset) *DomainRouteController {
+	kusciaInformerFactory := informers.NewSharedInformerFactory(kusciaClient, 0)
+	return &DomainRouteController{
+		gateway: &kusciaapisv1alpha1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "alice"},
+		},
+		prikey:            key,
+		keys:              newKeyRing(key),
+		keyDir:            keyDir,
+		kusciaClient:      kusciaClient,
+		domainRouteLister: kusciaInformerFactory.Kuscia().V1alpha1().DomainRoutes().Lister(),
+	}
+}
+
+// syncKeyRotationTestRoute updates the domainroute in lister to the latest one.
+func syncKeyRotationTestRoute(t *testing.T, c *DomainRouteController, dr *kusciaapisv1alpha1.DomainRoute) *kusciaapisv1alpha1.DomainRoute {
+	dr, err := c.kusciaClient.KusciaV1alpha1().DomainRoutes(dr.Namespace).Get(context.Background(), dr.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	kusciaInformerFactory := informers.NewSharedInformerFactory(c.kusciaClient, 0)
+	informer := kusciaInformerFactory.Kuscia().V1alpha1().DomainRoutes()
+	assert.NoError(t, informer.Informer().GetIndexer().Add(dr))
+	c.domainRouteLister = informer.Lister()
+	return dr
+}
+
+func TestKeyRing(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	ring := newKeyRing(key1)
+	ring.add(key2)
+	assert.Equal(t, key2, ring.get(encodePublicKey(key2)))
+	assert.Nil(t, ring.get("unknown"))
+
+	raw, err := generateRandomToken(tokenByteSize)
+	assert.NoError(t, err)
+	token, err := encryptToken(&key2.PublicKey, raw)
+	assert.NoError(t, err)
+	decrypted, err := ring.decrypt(token, tokenByteSize)
+	assert.NoError(t, err)
+	assert.Equal(t, raw, decrypted)
+
+	ring.retain(encodePublicKey(key1))
+	assert.Nil(t, ring.get(encodePublicKey(key2)))
+	_, err = ring.decrypt(token, tokenByteSize)
+	assert.Error(t, err)
+}
+
+func TestKeyRotation(t *testing.T) {
+	key, domain := newKeyRotationTestDomain(t, "alice")
+	oldPub := encodePublicKey(key)
+	raw, err := generateRandomToken(tokenByteSize)
+	assert.NoError(t, err)
+	oldToken, err := encryptToken(&key.PublicKey, raw)
+	assert.NoError(t, err)
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:             "alice",
+			Destination:        "bob",
+			AuthenticationType: kusciaapisv1alpha1.DomainAuthenticationToken,
+			TokenConfig: &kusciaapisv1alpha1.TokenConfig{
+				SourcePublicKey: oldPub,
+				TokenGenMethod:  kusciaapisv1alpha1.TokenGenMethodRSA,
+			},
+		},
+		Status: kusciaapisv1alpha1.DomainRouteStatus{
+			TokenStatus: kusciaapisv1alpha1.DomainRouteTokenStatus{
+				RevisionInitializer: "gateway",
+				RevisionToken: kusciaapisv1alpha1.DomainRouteToken{
+					Token:        oldToken,
+					Revision:     1,
+					IsReady:      true,
+					RevisionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+				Tokens: []kusciaapisv1alpha1.DomainRouteToken{{Token: oldToken, Revision: 1}},
+			},
+		},
+	}
+	kusciaClient := kusciafake.NewSimpleClientset(domain, dr)
+	keyDir := t.TempDir()
+	c := newKeyRotationTestController(key, keyDir, kusciaClient)
+	ctx := context.Background()
+
+	// start rotation
+	w := httptest.NewRecorder()
+	c.keyRotationHandle(w, httptest.NewRequest(http.MethodPost, adminKeyRotationPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	rotation := &kusciaapisv1alpha1.DomainKeyRotationStatus{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), rotation))
+	assert.Equal(t, kusciaapisv1alpha1.DomainKeyRotationRotating, rotation.Phase)
+	assert.Equal(t, oldPub, rotation.PreviousPublicKey)
+	assert.True(t, paths.CheckFileExist(filepath.Join(keyDir, nextKeyFile)))
+	assert.NotNil(t, c.keys.get(rotation.PublicKey))
+	assert.Equal(t, rotation.PublicKey, c.keyRotationAdvertisement().PublicKey)
+	newKey := c.keys.get(rotation.PublicKey)
+
+	w = httptest.NewRecorder()
+	c.keyRotationHandle(w, httptest.NewRequest(http.MethodPost, adminKeyRotationPath, nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// the route is pending until its key is updated
+	syncKeyRotationTestRoute(t, c, dr)
+	assert.NoError(t, c.syncKeyRotation(ctx))
+	domain, err = kusciaClient.KusciaV1alpha1().Domains().Get(ctx, "alice", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice-bob"}, domain.Status.KeyRotation.PendingRoutes)
+	dr = syncKeyRotationTestRoute(t, c, dr)
+	assert.Equal(t, oldToken, dr.Status.TokenStatus.RevisionToken.Token)
+
+	// the route re-handshakes once its key is updated
+	dr.Spec.TokenConfig.SourcePublicKey = rotation.PublicKey
+	_, err = kusciaClient.KusciaV1alpha1().DomainRoutes("alice").Update(ctx, dr, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	syncKeyRotationTestRoute(t, c, dr)
+	assert.NoError(t, c.syncKeyRotation(ctx))
+	dr = syncKeyRotationTestRoute(t, c, dr)
+	assert.Empty(t, dr.Status.TokenStatus.RevisionToken.Token)
+	assert.Equal(t, oldToken, dr.Status.TokenStatus.Tokens[0].Token)
+
+	// the previous key is retired once the route is re-handshaked
+	newToken, err := encryptToken(&newKey.PublicKey, raw)
+	assert.NoError(t, err)
+	dr.Status.TokenStatus.RevisionToken = kusciaapisv1alpha1.DomainRouteToken{
+		Token:        newToken,
+		Revision:     2,
+		IsReady:      true,
+		RevisionTime: metav1.NewTime(time.Now().Add(time.Second)),
+	}
+	_, err = kusciaClient.KusciaV1alpha1().DomainRoutes("alice").UpdateStatus(ctx, dr, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	syncKeyRotationTestRoute(t, c, dr)
+	assert.NoError(t, c.syncKeyRotation(ctx))
+	domain, err = kusciaClient.KusciaV1alpha1().Domains().Get(ctx, "alice", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, kusciaapisv1alpha1.DomainKeyRotationCompleted, domain.Status.KeyRotation.Phase)
+	assert.Empty(t, domain.Status.KeyRotation.PendingRoutes)
+	assert.NotNil(t, domain.Status.KeyRotation.CompletionTime)
+	assert.Nil(t, c.keyRotationAdvertisement())
+	assert.Nil(t, c.keys.get(oldPub))
+
+	dr = syncKeyRotationTestRoute(t, c, dr)
+	decrypted, err := decryptToken(newKey, dr.Status.TokenStatus.Tokens[0].Token, tokenByteSize)
+	assert.NoError(t, err)
+	assert.Equal(t, raw, decrypted)
+
+	// the rotated key replaces the domain key after restart
+	keys, err := loadKeyRing(key, keyDir)
+	assert.NoError(t, err)
+	assert.NotNil(t, keys.get(rotation.PublicKey))
+	assert.Nil(t, keys.get(oldPub))
+
+	w = httptest.NewRecorder()
+	c.keyRotationHandle(w, httptest.NewRequest(http.MethodGet, adminKeyRotationPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestKeyRotationDisabled(t *testing.T) {
+	key, domain := newKeyRotationTestDomain(t, "alice")
+	c := newKeyRotationTestController(key, "", kusciafake.NewSimpleClientset(domain))
+
+	w := httptest.NewRecorder()
+	c.keyRotationHandle(w, httptest.NewRequest(http.MethodPost, adminKeyRotationPath, nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	c.keyRotationHandle(w, httptest.NewRequest(http.MethodGet, adminKeyRotationPath, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdoptKeyRotation(t *testing.T) {
+	key, domain := newKeyRotationTestDomain(t, "alice")
+	bobKey, bob := newKeyRotationTestDomain(t, "bob")
+	kusciaClient := kusciafake.NewSimpleClientset(domain, bob)
+	c := newKeyRotationTestController(key, "", kusciaClient)
+	ctx := context.Background()
+
+	bobPub := encodePublicKey(bobKey)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	advertisement := &handshake.KeyRotation{
+		PublicKey: encodePublicKey(newKey),
+		StartTime: time.Now().Truncate(time.Second).UnixNano(),
+	}
+
+	// the signature is not signed by the key trusted
+	advertisement.Signature, err = tlsutils.SignWithRSA(newKey, resources.KeyRotationSignData("bob", advertisement.PublicKey, advertisement.StartTime))
+	assert.NoError(t, err)
+	_, err = c.adoptKeyRotation(ctx, "bob", bobPub, advertisement)
+	assert.Error(t, err)
+
+	advertisement.Signature, err = tlsutils.SignWithRSA(bobKey, resources.KeyRotationSignData("bob", advertisement.PublicKey, advertisement.StartTime))
+	assert.NoError(t, err)
+	adopted, err := c.adoptKeyRotation(ctx, "bob", bobPub, advertisement)
+	assert.NoError(t, err)
+	assert.True(t, adopted)
+	bob, err = kusciaClient.KusciaV1alpha1().Domains().Get(ctx, "bob", metav1.GetOptions{})
+	assert.NoError(t, err)
+	pub, err := resources.DomainPublicKey(bob)
+	assert.NoError(t, err)
+	assert.Equal(t, advertisement.PublicKey, pub)
+	assert.Equal(t, kusciaapisv1alpha1.DomainKeyRotationCompleted, bob.Status.KeyRotation.Phase)
+	assert.Equal(t, bobPub, bob.Status.KeyRotation.PreviousPublicKey)
+
+	// the rotation of domain itself is not adopted
+	adopted, err = c.adoptKeyRotation(ctx, "alice", bobPub, advertisement)
+	assert.NoError(t, err)
+	assert.False(t, adopted)
+}
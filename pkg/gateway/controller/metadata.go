@@ -0,0 +1,83 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	clientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/interconn/kuscia/metadata"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+func registerMetadataHandlers(mux *http.ServeMux, namespace string, kusciaClient clientset.Interface) {
+	mux.HandleFunc(utils.MetadataAppsPath, metadataAppsHandler(namespace, kusciaClient))
+}
+
+// metadataAppsHandler advertises the partner visible AppImages to the partner in Kuscia-Source header, which has been
+// authenticated by the token of domain route.
+func metadataAppsHandler(namespace string, kusciaClient clientset.Interface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if idx := strings.LastIndex(host, ":"); idx > 0 {
+			host = host[:idx]
+		}
+		if host != utils.GetMetadataHost(namespace) {
+			http.Error(w, fmt.Sprintf("metadata api is only served for host %s", utils.GetMetadataHost(namespace)),
+				http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		partner := r.Header.Get("Kuscia-Source")
+		if partner == "" {
+			http.Error(w, "Kuscia-Source header is required", http.StatusBadRequest)
+			return
+		}
+
+		appImages, err := kusciaClient.KusciaV1alpha1().AppImages().List(r.Context(), metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=true", common.LabelAppImagePartnerVisible),
+		})
+		if err != nil {
+			nlog.Warnf("List partner visible app images for %s failed, %v", partner, err)
+			http.Error(w, "list app images failed", http.StatusInternalServerError)
+			return
+		}
+		items := make([]*v1alpha1.AppImage, 0, len(appImages.Items))
+		for i := range appImages.Items {
+			items = append(items, &appImages.Items[i])
+		}
+		result := metadata.BuildPartnerMetadata(namespace, partner, items)
+		if len(result.Apps) == 0 {
+			http.Error(w, fmt.Sprintf("no app is advertised to %s", partner), http.StatusNotFound)
+			return
+		}
+
+		body, _ := json.Marshal(result)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/interconn/kuscia/metadata"
+)
+
+func TestMetadataHandlers(t *testing.T) {
+	kusciaClient := kusciafake.NewSimpleClientset(&v1alpha1.AppImage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "secretflow",
+			Labels: map[string]string{common.LabelAppImagePartnerVisible: "true"},
+		},
+		Spec: v1alpha1.AppImageSpec{Image: v1alpha1.AppImageInfo{Name: "secretflow/secretflow", Tag: "1.0"}},
+	})
+	mux := http.NewServeMux()
+	registerMetadataHandlers(mux, "alice", kusciaClient)
+
+	req := httptest.NewRequest(http.MethodGet, utils.MetadataAppsPath, nil)
+	req.Host = utils.GetMetadataHost("alice")
+	req.Header.Set("Kuscia-Source", "bob")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	md := &metadata.PartnerMetadata{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), md))
+	assert.Equal(t, "alice", md.DomainID)
+	assert.NotNil(t, md.FindApp("secretflow"))
+
+	// handshake host skips token authentication, so it's rejected
+	req = httptest.NewRequest(http.MethodGet, utils.MetadataAppsPath, nil)
+	req.Host = "kuscia-handshake.alice.svc"
+	req.Header.Set("Kuscia-Source", "bob")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// nothing is advertised
+	mux = http.NewServeMux()
+	registerMetadataHandlers(mux, "alice", kusciafake.NewSimpleClientset())
+	req = httptest.NewRequest(http.MethodGet, utils.MetadataAppsPath, nil)
+	req.Host = utils.GetMetadataHost("alice")
+	req.Header.Set("Kuscia-Source", "bob")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
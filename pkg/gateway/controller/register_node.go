@@ -16,7 +16,6 @@ package controller
 
 import (
 	"context"
-	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
@@ -24,7 +23,6 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
-	"math/big"
 	"net/http"
 	"reflect"
 	"strings"
@@ -38,6 +36,7 @@ import (
 
 	"github.com/secretflow/kuscia/pkg/common"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/certissuance"
 	"github.com/secretflow/kuscia/pkg/gateway/clusters"
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
@@ -130,26 +129,6 @@ func (c *DomainRouteController) registerHandle(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// create domain certificate
-	t := time.Unix(req.RequestTime/int64(time.Second), req.RequestTime%int64(time.Second))
-	domainCrt := &x509.Certificate{
-		SerialNumber:          big.NewInt(1),
-		Subject:               certRequest.Subject,
-		PublicKeyAlgorithm:    certRequest.PublicKeyAlgorithm,
-		PublicKey:             certRequest.PublicKey,
-		NotBefore:             t,
-		NotAfter:              t.AddDate(10, 0, 0),
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
-		BasicConstraintsValid: true,
-	}
-	domainCrtRaw, err := x509.CreateCertificate(rand.Reader, domainCrt, c.CaCert, certRequest.PublicKey, c.CaKey)
-	if err != nil {
-		httpErrWrapped(w, err, http.StatusInternalServerError)
-		return
-	}
-	domainCrtStr := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: domainCrtRaw}))
-
 	domain, err := c.kusciaClient.KusciaV1alpha1().Domains().Get(context.Background(), req.DomainId, metav1.GetOptions{})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -164,8 +143,36 @@ func (c *DomainRouteController) registerHandle(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// If the tokens match and the cert in the domain does not match the cert in the request, the domain is updated
-	if !isCertMatch(domain.Spec.Cert, domainCrt) {
+	// The cert in the domain is returned if it matches the request and is not due for renewal
+	requestCrt := &x509.Certificate{
+		Subject:            certRequest.Subject,
+		PublicKeyAlgorithm: certRequest.PublicKeyAlgorithm,
+		PublicKey:          certRequest.PublicKey,
+	}
+	certMatched := isCertMatch(domain.Spec.Cert, requestCrt)
+	if certMatched {
+		if domainCrt, err := certissuance.ParseCert(domain.Spec.Cert); err == nil && !certissuance.NeedRenew(c.certIssuance, domainCrt, time.Now()) {
+			c.writeRegisterResponse(w, req.DomainId, domain.Spec.Cert)
+			return
+		}
+	}
+
+	// create domain certificate
+	_, domainCrtStr, err := c.signDomainCert(certRequest)
+	if err != nil {
+		httpErrWrapped(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if certMatched {
+		// the key is proved by the jwt token, so the cert is renewed without deploy token
+		if err = c.patchDomainCert(domain, domainCrtStr); err != nil {
+			httpErrWrapped(w, fmt.Errorf("renew cert of domain [%s] failed, detail -> %v", req.DomainId, err), http.StatusInternalServerError)
+			return
+		}
+		nlog.Infof("Domain %s register success, renew domain cert", domain.Name)
+	} else {
+		// If the tokens match and the cert in the domain does not match the cert in the request, the domain is updated
 		index, err := deployTokenMatched(certRequest, domain.Status.DeployTokenStatuses)
 		if err != nil {
 			httpErrWrapped(w, fmt.Errorf("source domain [%s] deploy token mismatch, detail -> %s", req.DomainId, err.Error()), http.StatusInternalServerError)
@@ -185,10 +192,7 @@ func (c *DomainRouteController) registerHandle(w http.ResponseWriter, r *http.Re
 			return
 		}
 		// update domain cert
-		oldData, _ := json.Marshal(kusciaapisv1alpha1.Domain{Spec: domain.Spec})
-		newData, _ := json.Marshal(kusciaapisv1alpha1.Domain{Spec: domainDeepCopy.Spec})
-		patchBytes, _ := strategicpatch.CreateTwoWayMergePatch(oldData, newData, &kusciaapisv1alpha1.Domain{})
-		if _, err = c.kusciaClient.KusciaV1alpha1().Domains().Patch(context.Background(), domainDeepCopy.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{}); err != nil {
+		if err = c.patchDomainCert(domain, domainCrtStr); err != nil {
 			if k8serrors.IsNotFound(err) {
 				httpErrWrapped(w, fmt.Errorf("source domain [%s] not found, may be deleted", req.DomainId), http.StatusInternalServerError)
 			} else {
@@ -199,17 +203,42 @@ func (c *DomainRouteController) registerHandle(w http.ResponseWriter, r *http.Re
 		nlog.Infof("Domain %s register success, set domain cert", domain.Name)
 	}
 
+	c.writeRegisterResponse(w, req.DomainId, domainCrtStr)
+}
+
+func (c *DomainRouteController) writeRegisterResponse(w http.ResponseWriter, domainID, cert string) {
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(&handshake.RegisterResponse{
-		Cert: domainCrtStr,
+	err := json.NewEncoder(w).Encode(&handshake.RegisterResponse{
+		Cert: cert,
 	})
 	if err != nil {
-		nlog.Errorf("encode register response for(%s) fail, detail-> %v", req.DomainId, err)
+		nlog.Errorf("encode register response for(%s) fail, detail-> %v", domainID, err)
 	} else {
-		nlog.Infof("Domain register %s handle success", req.DomainId)
+		nlog.Infof("Domain register %s handle success", domainID)
 	}
 }
 
+// patchDomainCert replaces the cert in the spec of domain.
+func (c *DomainRouteController) patchDomainCert(domain *kusciaapisv1alpha1.Domain, cert string) error {
+	domainDeepCopy := domain.DeepCopy()
+	domainDeepCopy.Spec.Cert = cert
+	oldData, _ := json.Marshal(kusciaapisv1alpha1.Domain{Spec: domain.Spec})
+	newData, _ := json.Marshal(kusciaapisv1alpha1.Domain{Spec: domainDeepCopy.Spec})
+	patchBytes, _ := strategicpatch.CreateTwoWayMergePatch(oldData, newData, &kusciaapisv1alpha1.Domain{})
+	_, err := c.kusciaClient.KusciaV1alpha1().Domains().Patch(context.Background(), domain.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
+// signDomainCert issues the domain certificate of csr by the cert issuer of master, returns the certificate and its
+// base64 encoded pem.
+func (c *DomainRouteController) signDomainCert(certRequest *x509.CertificateRequest) (*x509.Certificate, string, error) {
+	issuer := c.certIssuer
+	if issuer == nil {
+		issuer = certissuance.NewCAIssuer(c.CaCert, c.CaKey, 0)
+	}
+	return issuer.Issue(context.Background(), certRequest)
+}
+
 func deployTokenMatched(certRequest *x509.CertificateRequest, deployTokenStatuses []kusciaapisv1alpha1.DeployTokenStatus) (int, error) {
 	regToken := getTokenFromCertRequest(certRequest)
 	if regToken == "" {
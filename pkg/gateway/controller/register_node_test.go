@@ -15,20 +15,30 @@
 package controller
 
 import (
+	"bytes"
+	"context"
 	"crypto/rsa"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	gomonkeyv2 "github.com/agiledragon/gomonkey/v2"
 
 	"github.com/secretflow/kuscia/cmd/kuscia/confloader"
+	"github.com/secretflow/kuscia/pkg/common"
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/pkg/gateway/certissuance"
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/tls"
@@ -89,7 +99,7 @@ func TestRegisterDomain_ServerNotExists(t *testing.T) {
 	csr, key := generateTestKey(t, utAlice)
 
 	// try to mock http request
-	gomonkeyv2.ApplyFunc(utils.DoHTTPWithRetry, func(i interface{}, out interface{}, hp *utils.HTTPParam, d time.Duration, tm int) error {
+	gomonkeyv2.ApplyFunc(utils.DoHTTPWithRetry, func(ctx context.Context, i interface{}, out interface{}, hp *utils.HTTPParam, d time.Duration, tm int) error {
 		assert.Equal(t, http.MethodPost, hp.Method)
 		assert.Equal(t, utAlice, hp.KusciaSource)
 		return nil
@@ -117,6 +127,63 @@ func TestVerifyCSRcn(t *testing.T) {
 	assert.Error(t, err, "verifyRegisterRequest failed")
 }
 
+func TestRegisterHandleRenewal(t *testing.T) {
+	t.Parallel()
+	caKey, caBytes, err := tls.CreateCA("kuscia-system")
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caBytes)
+	assert.NoError(t, err)
+
+	csr, key := generateTestKey(t, utAlice)
+	certRequest, err := parseCertRequest(base64.StdEncoding.EncodeToString([]byte(csr)))
+	assert.NoError(t, err)
+	// the cert is due for renewal once issued
+	_, dueCert, err := certissuance.NewCAIssuer(caCert, caKey, time.Nanosecond).Issue(context.Background(), certRequest)
+	assert.NoError(t, err)
+	kusciaClient := kusciafake.NewSimpleClientset(&kusciaapisv1alpha1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: utAlice},
+		Spec:       kusciaapisv1alpha1.DomainSpec{Cert: dueCert},
+		Status: &kusciaapisv1alpha1.DomainStatus{
+			DeployTokenStatuses: []kusciaapisv1alpha1.DeployTokenStatus{{Token: unitTestDeployToken, State: common.DeployTokenUsedState}},
+		},
+	})
+	c := &DomainRouteController{
+		kusciaClient: kusciaClient,
+		CaCert:       caCert,
+		CaKey:        caKey,
+	}
+	register := func(csr string, key *rsa.PrivateKey) (int, string) {
+		req, token, err := generateJwtToken(utAlice, csr, key)
+		assert.NoError(t, err)
+		body, _ := json.Marshal(req)
+		r := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		r.Header.Set("jwt-token", token)
+		w := httptest.NewRecorder()
+		c.registerHandle(w, r)
+		resp := &handshake.RegisterResponse{}
+		_ = json.Unmarshal(w.Body.Bytes(), resp)
+		return w.Code, resp.Cert
+	}
+
+	// the cert of the same key is renewed without deploy token
+	code, renewedCert := register(csr, key)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotEqual(t, dueCert, renewedCert)
+	domain, err := kusciaClient.KusciaV1alpha1().Domains().Get(context.Background(), utAlice, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, renewedCert, domain.Spec.Cert)
+
+	// the cert is kept until it's due
+	code, cert := register(csr, key)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, renewedCert, cert)
+
+	// the cert of another key requires an unused deploy token
+	csr, key = generateTestKey(t, utAlice)
+	code, _ = register(csr, key)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
 func TestCompatibility(t *testing.T) {
 	t.Parallel()
 	csr, key := generateTestKey(t, utAlice)
@@ -0,0 +1,76 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/clusters"
+	"github.com/secretflow/kuscia/pkg/gateway/signing"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// updateRequestSigning updates the keys of signing server by the tokens of DomainRoute, the keys are removed if the
+// route isn't signed or tokens are empty. The ext_authz filter is only installed while some routes are signed.
+func (c *DomainRouteController) updateRequestSigning(dr *kusciaapisv1alpha1.DomainRoute, tokens []*Token) error {
+	if c.signing == nil {
+		if dr.Spec.SecurityLevel == kusciaapisv1alpha1.DomainRouteSecurityLevelSigned {
+			nlog.Warnf("DomainRoute %s requires request signing, but signing server of gateway is disabled", dr.Name)
+		}
+		return nil
+	}
+	signed := dr.Spec.SecurityLevel == kusciaapisv1alpha1.DomainRouteSecurityLevelSigned && len(tokens) > 0
+
+	if dr.Spec.Source == c.gateway.Namespace {
+		var key *signing.Key
+		if signed {
+			token := tokens[len(tokens)-1]
+			key = &signing.Key{Token: token.Token, Version: fmt.Sprint(token.Version)}
+		}
+		c.signing.SetSignKey(dr.Spec.Destination, key)
+		return c.updateRequestSigningFilter(xds.InternalListener, signing.SignPath, c.signing.SignEnabled())
+	}
+	if dr.Spec.Destination == c.gateway.Namespace {
+		var keys []signing.Key
+		if signed {
+			for i := len(tokens) - 1; i >= 0; i-- {
+				keys = append(keys, signing.Key{Token: tokens[i].Token, Version: fmt.Sprint(tokens[i].Version)})
+			}
+		}
+		c.signing.SetVerifyKeys(dr.Spec.Source, keys)
+		return c.updateRequestSigningFilter(xds.ExternalListener, signing.VerifyPath, c.signing.VerifyEnabled())
+	}
+	return nil
+}
+
+func (c *DomainRouteController) updateRequestSigningFilter(listenerName, pathPrefix string, enabled bool) error {
+	c.signingMtx.Lock()
+	defer c.signingMtx.Unlock()
+	if c.signingListeners[listenerName] == enabled {
+		return nil
+	}
+	cluster := ""
+	if enabled {
+		cluster = clusters.GetRequestSigningClusterName()
+	}
+	if err := xds.SetRequestSigning(listenerName, cluster, pathPrefix, signing.MaxBodyBytes); err != nil {
+		return err
+	}
+	c.signingListeners[listenerName] = enabled
+	nlog.Infof("Request signing of listener %s is set to %v", listenerName, enabled)
+	return nil
+}
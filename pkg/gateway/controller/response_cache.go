@@ -0,0 +1,95 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	"github.com/secretflow/kuscia/pkg/gateway/clusters"
+	"github.com/secretflow/kuscia/pkg/gateway/responsecache"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+const (
+	responseCacheRoutePrefix = "response-cache-"
+
+	adminResponseCachePath           = "/response_cache"
+	adminResponseCacheInvalidatePath = "/response_cache/invalidate"
+)
+
+// decorateResponseCacheRoutes prepends routes sending cacheable requests to the response cache proxy. Requests sent
+// back by the proxy carry the bypass header, they skip these routes and the header is removed before forwarding.
+func decorateResponseCacheRoutes(vh *route.VirtualHost, rules []responsecache.Rule) {
+	if len(rules) == 0 {
+		return
+	}
+	routes := make([]*route.Route, 0, len(rules)+len(vh.Routes))
+	for i, rule := range rules {
+		routes = append(routes, &route.Route{
+			Name: fmt.Sprintf("%s%d", responseCacheRoutePrefix, i),
+			Match: &route.RouteMatch{
+				PathSpecifier: &route.RouteMatch_Prefix{
+					Prefix: rule.PathPrefix,
+				},
+				Headers: []*route.HeaderMatcher{
+					{
+						Name: ":method",
+						HeaderMatchSpecifier: &route.HeaderMatcher_ExactMatch{
+							ExactMatch: http.MethodGet,
+						},
+					},
+					{
+						Name: responsecache.BypassHeader,
+						HeaderMatchSpecifier: &route.HeaderMatcher_PresentMatch{
+							PresentMatch: true,
+						},
+						InvertMatch: true,
+					},
+				},
+			},
+			Action: &route.Route_Route{
+				Route: xds.AddDefaultTimeout(
+					&route.RouteAction{
+						ClusterSpecifier: &route.RouteAction_Cluster{
+							Cluster: clusters.GetResponseCacheClusterName(),
+						},
+					},
+				),
+			},
+		})
+	}
+	vh.Routes = append(routes, vh.Routes...)
+	vh.RequestHeadersToRemove = append(vh.RequestHeadersToRemove, responsecache.BypassHeader)
+}
+
+// responseCacheHandle returns the usage of response cache.
+func (c *DomainRouteController) responseCacheHandle(w http.ResponseWriter, r *http.Request) {
+	writeAdminResponse(w, c.responseCache.GetStats())
+}
+
+// responseCacheInvalidateHandle removes cached responses selected by query parameters host, destination and prefix,
+// all responses are removed if none is given.
+func (c *DomainRouteController) responseCacheInvalidateHandle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	removed := c.responseCache.Invalidate(query.Get("host"), query.Get("destination"), query.Get("prefix"))
+	writeAdminResponse(w, map[string]int{"removed": removed})
+}
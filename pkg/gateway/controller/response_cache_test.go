@@ -0,0 +1,72 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	envoyroute "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/clusters"
+	"github.com/secretflow/kuscia/pkg/gateway/responsecache"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+func TestDecorateResponseCacheRoutes(t *testing.T) {
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-bob", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: "bob",
+			Endpoint: kusciaapisv1alpha1.DomainEndpoint{
+				Host:  "bob.example.com",
+				Ports: []kusciaapisv1alpha1.DomainPort{{Name: "http", Protocol: kusciaapisv1alpha1.DomainRouteProtocolHTTP, Port: 1080}},
+			},
+		},
+	}
+	vh := generateInternalVirtualHost(dr, "token", false)
+	n := len(vh.Routes)
+	decorateResponseCacheRoutes(vh, []responsecache.Rule{{PathPrefix: "/api/v1/meta", TTL: time.Minute}})
+	assert.Len(t, vh.Routes, n+1)
+	assert.Equal(t, []string{responsecache.BypassHeader}, vh.RequestHeadersToRemove)
+
+	rc := &envoyroute.RouteConfiguration{Name: xds.InternalRoute, VirtualHosts: []*envoyroute.VirtualHost{vh}}
+	tests := []struct {
+		method  string
+		path    string
+		headers map[string]string
+		cached  bool
+	}{
+		{http.MethodGet, "/api/v1/meta/1", nil, true},
+		{http.MethodGet, "/api/v1/meta/1", map[string]string{responsecache.BypassHeader: "true"}, false},
+		{http.MethodPost, "/api/v1/meta/1", nil, false},
+		{http.MethodGet, "/api/v1/data", nil, false},
+	}
+	for _, tt := range tests {
+		result, err := xds.MatchRouteConfig(rc, &xds.RouteRequest{Host: "svc.bob.svc", Path: tt.path, Method: tt.method, Headers: tt.headers})
+		assert.NoError(t, err)
+		assert.Equal(t, tt.cached, result.Cluster == clusters.GetResponseCacheClusterName(), "%s %s %v", tt.method, tt.path, tt.headers)
+	}
+
+	vh = generateInternalVirtualHost(dr, "token", false)
+	decorateResponseCacheRoutes(vh, nil)
+	assert.Len(t, vh.Routes, n)
+	assert.Empty(t, vh.RequestHeadersToRemove)
+}
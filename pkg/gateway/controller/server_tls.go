@@ -0,0 +1,48 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+)
+
+// caCertKey is the optional key of tls secret storing the ca verifying client certificates.
+const caCertKey = "ca.crt"
+
+// updateServerCert presents the server certificate of DomainRoute to connections from the source by SNI. The secret
+// is read on every sync, so a renewed certificate takes effect after the DomainRoute is resynced.
+func (c *DomainRouteController) updateServerCert(dr *kusciaapisv1alpha1.DomainRoute) error {
+	if dr.Spec.ServerTLS == nil {
+		return xds.SetServerCert(dr.Name, nil)
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(c.gateway.Namespace).Get(context.Background(),
+		dr.Spec.ServerTLS.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get server tls secret %s failed with %v", dr.Spec.ServerTLS.SecretName, err)
+	}
+	cert := &xds.TLSCert{
+		CertData: string(secret.Data[corev1.TLSCertKey]),
+		KeyData:  string(secret.Data[corev1.TLSPrivateKeyKey]),
+		CAData:   string(secret.Data[caCertKey]),
+	}
+	return xds.SetServerCert(dr.Name, &xds.ServerCert{ServerNames: dr.Spec.ServerTLS.ServerNames, Cert: cert})
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/xds"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+func TestUpdateServerCert(t *testing.T) {
+	ns := "default"
+	c := newDomainRouteTestInfo(ns, 1059)
+	keyData, certData, err := tlsutils.GenerateKeyCertPairData(c.CaKey, c.CaCert, "partner.example.com")
+	assert.NoError(t, err)
+	_, err = c.kubeClient.CoreV1().Secrets(ns).Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "partner-tls", Namespace: ns},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte(certData),
+			corev1.TLSPrivateKeyKey: []byte(keyData),
+		},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	dr := &kusciaapisv1alpha1.DomainRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-default", Namespace: ns},
+		Spec: kusciaapisv1alpha1.DomainRouteSpec{
+			Source:      "alice",
+			Destination: ns,
+			ServerTLS: &kusciaapisv1alpha1.DomainRouteServerTLS{
+				ServerNames: []string{"partner.example.com"},
+				SecretName:  "partner-tls",
+			},
+		},
+	}
+	assert.NoError(t, c.updateServerCert(dr))
+	lis, err := xds.QueryListener(xds.ExternalListener)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(lis.FilterChains))
+	assert.Equal(t, []string{"partner.example.com"}, lis.FilterChains[1].FilterChainMatch.ServerNames)
+	assert.NotNil(t, lis.FilterChains[1].TransportSocket)
+	assert.Equal(t, 1, len(lis.ListenerFilters))
+
+	// chains of server certs keep the http filters of default chain
+	assert.NoError(t, xds.SetRequestSigning(xds.ExternalListener, "", "/verify", 0))
+	lis, err = xds.QueryListener(xds.ExternalListener)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(lis.FilterChains))
+	assert.Equal(t, lis.FilterChains[0].Filters, lis.FilterChains[1].Filters)
+
+	// server name is used by another route
+	dr2 := dr.DeepCopy()
+	dr2.Name = "bob-default"
+	assert.Error(t, c.updateServerCert(dr2))
+
+	dr.Spec.ServerTLS = nil
+	assert.NoError(t, c.updateServerCert(dr))
+	lis, err = xds.QueryListener(xds.ExternalListener)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(lis.FilterChains))
+
+	// secret not found
+	dr2.Spec.ServerTLS.SecretName = "not-exist"
+	assert.Error(t, c.updateServerCert(dr2))
+}
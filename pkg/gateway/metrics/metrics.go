@@ -53,6 +53,27 @@ var (
 		},
 		[]string{"stat"},
 	)
+	domainRouteConnections = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "domainroute_upstream_connections",
+			Help: "total connections to the destination gateway by protocol",
+		},
+		[]string{"cluster", "protocol"},
+	)
+	domainRouteStreamResets = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "domainroute_upstream_stream_resets",
+			Help: "total streams reset on connections to the destination gateway by protocol",
+		},
+		[]string{"cluster", "protocol"},
+	)
+	domainRouteHTTP3Broken = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "domainroute_http3_broken",
+			Help: "times HTTP/3 to the destination gateway failed and fell back to TCP",
+		},
+		[]string{"cluster"},
+	)
 )
 
 func MonitorRuntimeMetrics(stopCh <-chan struct{}) {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -34,6 +35,9 @@ import (
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
+// statsFilter selects the envoy stats of cluster health and protocols.
+const statsFilter = `membership_total|membership_healthy|upstream_cx_http[123]_total|upstream_http3_broken|http[23]\.[rt]x_reset`
+
 type metrics struct {
 	Stats []metric `json:"stats"`
 }
@@ -140,6 +144,7 @@ func (c *ClusterMetricsCollector) collect() {
 					TotalEndpointsCount:   total,
 					HealthyEndpointsCount: healthy,
 				})
+				updateProtocolMetrics(metrics, clusterName)
 			}
 		}
 	}
@@ -149,10 +154,30 @@ func (c *ClusterMetricsCollector) collect() {
 	}
 }
 
+// updateProtocolMetrics exports the connections and stream resets of each protocol to the destination gateway, so
+// HTTP/3 can be compared with HTTP/2 and HTTP/1.1 over TCP.
+func updateProtocolMetrics(metrics map[string]int, clusterName string) {
+	for _, protocol := range []string{"http1", "http2", "http3"} {
+		if cx, ok := metrics[fmt.Sprintf("cluster.%s.upstream_cx_%s_total", clusterName, protocol)]; ok {
+			domainRouteConnections.WithLabelValues(clusterName, protocol).Set(float64(cx))
+		}
+		if protocol == "http1" {
+			continue
+		}
+		rx, rxOk := metrics[fmt.Sprintf("cluster.%s.%s.rx_reset", clusterName, protocol)]
+		tx, txOk := metrics[fmt.Sprintf("cluster.%s.%s.tx_reset", clusterName, protocol)]
+		if rxOk || txOk {
+			domainRouteStreamResets.WithLabelValues(clusterName, protocol).Set(float64(rx + tx))
+		}
+	}
+	if broken, ok := metrics[fmt.Sprintf("cluster.%s.upstream_http3_broken", clusterName)]; ok {
+		domainRouteHTTP3Broken.WithLabelValues(clusterName).Set(float64(broken))
+	}
+}
+
 func (c *ClusterMetricsCollector) getMetrics() (map[string]int, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s?filter=membership_total|membership_healthy&format=json",
-		c.metricsEndpoint),
-		nil)
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s?filter=%s&format=json", c.metricsEndpoint,
+		url.QueryEscape(statsFilter)), nil)
 	if err != nil {
 		return nil, err
 	}
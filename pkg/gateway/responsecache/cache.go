@@ -0,0 +1,379 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package responsecache is a caching proxy of the gateway for GET requests sent to other domains. Envoy routes
+// cacheable requests to the proxy, the proxy serves them from cache or sends them back to the internal listener of
+// envoy with BypassHeader, which routes them to the destination domain as usual.
+package responsecache
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// StatusHeader tells clients whether the response is served from cache.
+	StatusHeader = "Kuscia-Cache-Status"
+	// BypassHeader marks requests sent by the cache proxy, envoy doesn't route them to the cache again.
+	BypassHeader = "Kuscia-Cache-Bypass"
+
+	StatusHit    = "HIT"
+	StatusMiss   = "MISS"
+	StatusBypass = "BYPASS"
+
+	DefaultPort           = 10003
+	defaultMaxSizeMB      = 64
+	defaultMaxEntrySizeKB = 1024
+	defaultTTL            = 60 * time.Second
+)
+
+// hopHeaders are not forwarded by proxies, see RFC 7230 section 6.1.
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Rule selects GET requests of Destination whose path has PathPrefix.
+type Rule struct {
+	Destination string
+	PathPrefix  string
+	TTL         time.Duration
+}
+
+type entry struct {
+	key     string
+	host    string
+	path    string
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+	elem    *list.Element
+}
+
+// Stats is the usage of cache.
+type Stats struct {
+	Entries      int   `json:"entries"`
+	SizeBytes    int64 `json:"sizeBytes"`
+	MaxSizeBytes int64 `json:"maxSizeBytes"`
+	Hits         int64 `json:"hits"`
+	Misses       int64 `json:"misses"`
+	Evictions    int64 `json:"evictions"`
+}
+
+// Cache is a size limited LRU cache of responses, entries expire after the TTL of the rule matched.
+type Cache struct {
+	port         uint32
+	upstream     string
+	rules        []Rule
+	maxSize      int64
+	maxEntrySize int64
+	client       *http.Client
+	now          func() time.Time
+
+	mtx     sync.Mutex
+	entries map[string]*entry
+	lru     *list.List
+	stats   Stats
+}
+
+// New returns a cache proxy listening on the port of conf, misses are sent to upstream, the internal listener of envoy.
+func New(conf *config.ResponseCacheConfig, upstream string) *Cache {
+	port, maxSizeMB, maxEntrySizeKB := conf.Port, conf.MaxSizeMB, conf.MaxEntrySizeKB
+	if port == 0 {
+		port = DefaultPort
+	}
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultMaxSizeMB
+	}
+	if maxEntrySizeKB <= 0 {
+		maxEntrySizeKB = defaultMaxEntrySizeKB
+	}
+	c := &Cache{
+		port:         port,
+		upstream:     strings.TrimSuffix(upstream, "/"),
+		maxSize:      int64(maxSizeMB) << 20,
+		maxEntrySize: int64(maxEntrySizeKB) << 10,
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now:     time.Now,
+		entries: make(map[string]*entry),
+		lru:     list.New(),
+	}
+	for _, r := range conf.Rules {
+		ttl := time.Duration(r.TTL) * time.Second
+		if ttl == 0 {
+			ttl = defaultTTL
+		}
+		c.rules = append(c.rules, Rule{Destination: r.Destination, PathPrefix: r.PathPrefix, TTL: ttl})
+	}
+	c.stats.MaxSizeBytes = c.maxSize
+	return c
+}
+
+// Port returns the port the cache proxy listens on.
+func (c *Cache) Port() uint32 {
+	return c.port
+}
+
+// Run serves the cache proxy until stopCh is closed.
+func (c *Cache) Run(stopCh <-chan struct{}) {
+	addr := fmt.Sprintf("127.0.0.1:%d", c.port)
+	server := &http.Server{Addr: addr, Handler: c}
+	go func() {
+		<-stopCh
+		server.Close()
+	}()
+	nlog.Infof("Response cache listens on %s", addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		nlog.Errorf("Response cache exit, %v", err)
+	}
+}
+
+// RulesFor returns the rules matching requests sent to destination.
+func (c *Cache) RulesFor(destination string) []Rule {
+	var rules []Rule
+	for _, r := range c.rules {
+		if r.Destination == "" || r.Destination == destination {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// matchRule returns the rule of the longest path prefix matching the request.
+func (c *Cache) matchRule(host, path string) *Rule {
+	var matched *Rule
+	for i, r := range c.rules {
+		if r.Destination != "" && destinationOf(host) != r.Destination {
+			continue
+		}
+		if strings.HasPrefix(path, r.PathPrefix) && (matched == nil || len(r.PathPrefix) > len(matched.PathPrefix)) {
+			matched = &c.rules[i]
+		}
+	}
+	return matched
+}
+
+// destinationOf returns the domain of host service.domain.svc[:port].
+func destinationOf(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	parts := strings.Split(host, ".")
+	if len(parts) < 3 || parts[len(parts)-1] != "svc" {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
+
+func (c *Cache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rule := c.matchRule(r.Host, r.URL.Path)
+	if r.Method != http.MethodGet || rule == nil || hasCacheDirective(r.Header, "no-store") {
+		c.forward(w, r, StatusBypass, nil)
+		return
+	}
+
+	key := r.Host + r.URL.RequestURI()
+	if e := c.get(key); e != nil {
+		writeHeader(w.Header(), e.header)
+		w.Header().Set(StatusHeader, StatusHit)
+		w.WriteHeader(e.status)
+		_, _ = w.Write(e.body)
+		return
+	}
+	c.forward(w, r, StatusMiss, func(resp *http.Response, body []byte) {
+		c.put(&entry{
+			key:     key,
+			host:    r.Host,
+			path:    r.URL.RequestURI(),
+			status:  resp.StatusCode,
+			header:  resp.Header.Clone(),
+			body:    body,
+			expires: c.now().Add(rule.TTL),
+		})
+	})
+}
+
+// forward sends the request to upstream, store is called if the response is cacheable and not larger than the limit.
+func (c *Cache) forward(w http.ResponseWriter, r *http.Request, status string, store func(*http.Response, []byte)) {
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, c.upstream+r.URL.RequestURI(), r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.Host = r.Host
+	req.ContentLength = r.ContentLength
+	writeHeader(req.Header, r.Header)
+	req.Header.Set(BypassHeader, "true")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		nlog.Warnf("Response cache forward %s%s failed, %v", r.Host, r.URL.RequestURI(), err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	if store == nil || !cacheable(resp) {
+		store = nil
+		status = StatusBypass
+	}
+	writeHeader(w.Header(), resp.Header)
+	w.Header().Set(StatusHeader, status)
+	w.WriteHeader(resp.StatusCode)
+	if store == nil {
+		_, _ = io.Copy(w, resp.Body)
+		return
+	}
+
+	// buffer up to the entry limit, responses exceeding it are streamed without caching
+	buf := &bytes.Buffer{}
+	n, err := io.Copy(buf, io.LimitReader(resp.Body, c.maxEntrySize+1))
+	if _, werr := w.Write(buf.Bytes()); werr != nil {
+		return
+	}
+	if err != nil {
+		return
+	}
+	if n > c.maxEntrySize {
+		_, _ = io.Copy(w, resp.Body)
+		return
+	}
+	store(resp, buf.Bytes())
+}
+
+// cacheable returns whether a response can be cached, only 200 without no-store or private is cached.
+func cacheable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusOK && !hasCacheDirective(resp.Header, "no-store") &&
+		!hasCacheDirective(resp.Header, "private") && resp.Header.Get("Set-Cookie") == ""
+}
+
+func hasCacheDirective(header http.Header, directive string) bool {
+	for _, v := range header.Values("Cache-Control") {
+		for _, d := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(d), directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeHeader(dst, src http.Header) {
+	for k, vs := range src {
+		dst[k] = append([]string(nil), vs...)
+	}
+	for _, k := range hopHeaders {
+		dst.Del(k)
+	}
+}
+
+func (c *Cache) get(key string) *entry {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expires) {
+		if ok {
+			c.remove(e)
+		}
+		c.stats.Misses++
+		return nil
+	}
+	c.lru.MoveToFront(e.elem)
+	c.stats.Hits++
+	return e
+}
+
+func (c *Cache) put(e *entry) {
+	size := int64(len(e.body))
+	if size > c.maxSize {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if old, ok := c.entries[e.key]; ok {
+		c.remove(old)
+	}
+	for c.stats.SizeBytes+size > c.maxSize {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			break
+		}
+		c.remove(oldest.Value.(*entry))
+		c.stats.Evictions++
+	}
+	e.elem = c.lru.PushFront(e)
+	c.entries[e.key] = e
+	c.stats.SizeBytes += size
+	c.stats.Entries = len(c.entries)
+}
+
+func (c *Cache) remove(e *entry) {
+	c.lru.Remove(e.elem)
+	delete(c.entries, e.key)
+	c.stats.SizeBytes -= int64(len(e.body))
+	c.stats.Entries = len(c.entries)
+}
+
+// Invalidate removes cached responses whose path has prefix. If host isn't empty, only responses of the host are
+// removed; if destination isn't empty, only responses of the domain are removed. It returns the number removed.
+func (c *Cache) Invalidate(host, destination, prefix string) int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	removed := 0
+	for _, e := range c.entries {
+		if host != "" && e.host != host {
+			continue
+		}
+		if destination != "" && destinationOf(e.host) != destination {
+			continue
+		}
+		if !strings.HasPrefix(e.path, prefix) {
+			continue
+		}
+		c.remove(e)
+		removed++
+	}
+	if removed > 0 {
+		nlog.Infof("Response cache invalidated %d entries, host=%q, destination=%q, prefix=%q", removed, host, destination, prefix)
+	}
+	return removed
+}
+
+// GetStats returns the usage of cache.
+func (c *Cache) GetStats() Stats {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.stats
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsecache
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/gateway/config"
+)
+
+func newTestCache(t *testing.T, conf *config.ResponseCacheConfig) (*Cache, *int) {
+	calls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "true", r.Header.Get(BypassHeader))
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/large"):
+			_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+		case strings.HasPrefix(r.URL.Path, "/nostore"):
+			w.Header().Set("Cache-Control", "no-store")
+			_, _ = w.Write([]byte("nostore"))
+		case strings.HasPrefix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = fmt.Fprintf(w, "%s%s-%d", r.Host, r.URL.RequestURI(), calls)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+	return New(conf, upstream.URL), &calls
+}
+
+func doRequest(c *Cache, method, host, uri string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, uri, nil)
+	req.Host = host
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, req)
+	return w
+}
+
+func TestCacheServeHTTP(t *testing.T) {
+	c, calls := newTestCache(t, &config.ResponseCacheConfig{
+		Enable:         true,
+		MaxEntrySizeKB: 1,
+		Rules: []config.ResponseCacheRule{
+			{Destination: "bob", PathPrefix: "/", TTL: 10},
+		},
+	})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	w := doRequest(c, http.MethodGet, "svc.bob.svc", "/meta?id=1")
+	assert.Equal(t, StatusMiss, w.Header().Get(StatusHeader))
+	assert.Equal(t, "svc.bob.svc/meta?id=1-1", w.Body.String())
+
+	w = doRequest(c, http.MethodGet, "svc.bob.svc", "/meta?id=1")
+	assert.Equal(t, StatusHit, w.Header().Get(StatusHeader))
+	assert.Equal(t, "svc.bob.svc/meta?id=1-1", w.Body.String())
+	assert.Equal(t, 1, *calls)
+
+	// different query is a different entry
+	w = doRequest(c, http.MethodGet, "svc.bob.svc", "/meta?id=2")
+	assert.Equal(t, StatusMiss, w.Header().Get(StatusHeader))
+
+	// other destinations and methods are not cached
+	w = doRequest(c, http.MethodGet, "svc.carol.svc", "/meta?id=1")
+	assert.Equal(t, StatusBypass, w.Header().Get(StatusHeader))
+	w = doRequest(c, http.MethodPost, "svc.bob.svc", "/meta?id=1")
+	assert.Equal(t, StatusBypass, w.Header().Get(StatusHeader))
+
+	// uncacheable responses, the size of body is unknown until the header is sent, so large ones are still misses
+	for _, uri := range []string{"/nostore", "/missing"} {
+		doRequest(c, http.MethodGet, "svc.bob.svc", uri)
+		w = doRequest(c, http.MethodGet, "svc.bob.svc", uri)
+		assert.Equal(t, StatusBypass, w.Header().Get(StatusHeader), uri)
+	}
+	doRequest(c, http.MethodGet, "svc.bob.svc", "/large")
+	w = doRequest(c, http.MethodGet, "svc.bob.svc", "/large")
+	assert.Equal(t, StatusMiss, w.Header().Get(StatusHeader))
+	assert.Equal(t, 2048, w.Body.Len())
+
+	// expired
+	now = now.Add(11 * time.Second)
+	w = doRequest(c, http.MethodGet, "svc.bob.svc", "/meta?id=1")
+	assert.Equal(t, StatusMiss, w.Header().Get(StatusHeader))
+}
+
+func TestCacheEviction(t *testing.T) {
+	c, _ := newTestCache(t, &config.ResponseCacheConfig{
+		Enable: true,
+		Rules:  []config.ResponseCacheRule{{PathPrefix: "/"}},
+	})
+	c.maxSize = 30
+
+	doRequest(c, http.MethodGet, "a.bob.svc", "/1")
+	doRequest(c, http.MethodGet, "a.bob.svc", "/2")
+	// touch /1, so /2 is the least recently used
+	assert.Equal(t, StatusHit, doRequest(c, http.MethodGet, "a.bob.svc", "/1").Header().Get(StatusHeader))
+	doRequest(c, http.MethodGet, "a.bob.svc", "/3")
+
+	stats := c.GetStats()
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, int64(1), stats.Evictions)
+	assert.LessOrEqual(t, stats.SizeBytes, c.maxSize)
+	assert.Equal(t, StatusHit, doRequest(c, http.MethodGet, "a.bob.svc", "/1").Header().Get(StatusHeader))
+	assert.Equal(t, StatusMiss, doRequest(c, http.MethodGet, "a.bob.svc", "/2").Header().Get(StatusHeader))
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	c, _ := newTestCache(t, &config.ResponseCacheConfig{
+		Enable: true,
+		Rules:  []config.ResponseCacheRule{{PathPrefix: "/"}},
+	})
+	doRequest(c, http.MethodGet, "a.bob.svc", "/meta/1")
+	doRequest(c, http.MethodGet, "a.bob.svc", "/data/1")
+	doRequest(c, http.MethodGet, "b.bob.svc", "/meta/1")
+	doRequest(c, http.MethodGet, "a.carol.svc", "/meta/1")
+
+	assert.Equal(t, 1, c.Invalidate("a.bob.svc", "", "/meta"))
+	assert.Equal(t, 1, c.Invalidate("", "carol", ""))
+	assert.Equal(t, 2, c.Invalidate("", "", "/"))
+	assert.Equal(t, 0, c.GetStats().Entries)
+	assert.Equal(t, int64(0), c.GetStats().SizeBytes)
+}
+
+func TestRulesFor(t *testing.T) {
+	c := New(&config.ResponseCacheConfig{
+		Rules: []config.ResponseCacheRule{
+			{PathPrefix: "/a"},
+			{Destination: "bob", PathPrefix: "/b", TTL: 5},
+		},
+	}, "http://127.0.0.1:80")
+	assert.Len(t, c.RulesFor("carol"), 1)
+	rules := c.RulesFor("bob")
+	assert.Len(t, rules, 2)
+	assert.Equal(t, defaultTTL, rules[0].TTL)
+	assert.Equal(t, 5*time.Second, rules[1].TTL)
+	assert.Equal(t, uint32(DefaultPort), c.Port())
+}
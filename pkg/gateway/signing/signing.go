@@ -0,0 +1,348 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signing signs requests sent to other domains and verifies requests from other domains, so each request is
+// authenticated besides the channel. Envoy asks the signing server of gateway by ext_authz filter: the server of the
+// sending gateway returns signature headers which envoy adds to the request, and the server of the receiving gateway
+// checks the signature, the clock skew of timestamp and whether the nonce is replayed.
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	HeaderSignature  = "Kuscia-Signature"
+	HeaderTimestamp  = "Kuscia-Signature-Timestamp"
+	HeaderNonce      = "Kuscia-Signature-Nonce"
+	HeaderDigest     = "Kuscia-Signature-Digest"
+	HeaderKeyVersion = "Kuscia-Signature-Key-Version"
+
+	// SignPath and VerifyPath are the path prefixes of ext_authz check requests, envoy appends the original path.
+	SignPath   = "/sign"
+	VerifyPath = "/verify"
+
+	DefaultPort = 10004
+	// MaxBodyBytes is the size of body buffered by envoy for the digest, larger bodies are digested partially.
+	MaxBodyBytes        = 1 << 20
+	DefaultMaxClockSkew = 5 * time.Minute
+
+	headerOriginSource = "Kuscia-Origin-Source"
+	headerSource       = "Kuscia-Source"
+	headerHost         = "Kuscia-Host"
+
+	keyDerivationLabel = "kuscia-request-signing"
+	nonceBytes         = 16
+)
+
+// Key is the token negotiated by handshake of a DomainRoute, the signing key is derived from it.
+type Key struct {
+	Token   string
+	Version string
+}
+
+func (k Key) derive() []byte {
+	mac := hmac.New(sha256.New, []byte(k.Token))
+	mac.Write([]byte(keyDerivationLabel))
+	return mac.Sum(nil)
+}
+
+// Server serves the ext_authz check requests of envoy.
+type Server struct {
+	namespace    string
+	port         uint32
+	maxClockSkew time.Duration
+
+	mtx sync.RWMutex
+	// signKeys are the latest keys of destinations.
+	signKeys map[string]Key
+	// verifyKeys are the keys of sources, the latest one first, the previous one is kept during token rolling.
+	verifyKeys map[string][]Key
+
+	nonces *nonceCache
+	now    func() time.Time
+}
+
+func New(namespace string, port uint32) *Server {
+	if port == 0 {
+		port = DefaultPort
+	}
+	return &Server{
+		namespace:    namespace,
+		port:         port,
+		maxClockSkew: DefaultMaxClockSkew,
+		signKeys:     make(map[string]Key),
+		verifyKeys:   make(map[string][]Key),
+		nonces:       newNonceCache(),
+		now:          time.Now,
+	}
+}
+
+func (s *Server) Port() uint32 {
+	return s.port
+}
+
+// SetSignKey signs requests to destination with key, the signing is disabled if key is nil.
+func (s *Server) SetSignKey(destination string, key *Key) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if key == nil {
+		delete(s.signKeys, destination)
+	} else {
+		s.signKeys[destination] = *key
+	}
+}
+
+// SetVerifyKeys verifies requests from source with keys, the verification is disabled if keys are empty.
+func (s *Server) SetVerifyKeys(source string, keys []Key) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(keys) == 0 {
+		delete(s.verifyKeys, source)
+	} else {
+		s.verifyKeys[source] = append([]Key(nil), keys...)
+	}
+}
+
+// SignEnabled returns whether requests to any destination are signed.
+func (s *Server) SignEnabled() bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return len(s.signKeys) > 0
+}
+
+// VerifyEnabled returns whether requests from any source are verified.
+func (s *Server) VerifyEnabled() bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return len(s.verifyKeys) > 0
+}
+
+func (s *Server) Run(stopCh <-chan struct{}) {
+	server := &http.Server{
+		Addr:              net.JoinHostPort("127.0.0.1", strconv.Itoa(int(s.port))),
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-stopCh
+		server.Close()
+	}()
+	nlog.Infof("Request signing server listening on %s", server.Addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		nlog.Errorf("Request signing server exit with error: %v", err)
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodyBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("read body failed, %v", err), http.StatusBadRequest)
+		return
+	}
+	switch {
+	case strings.HasPrefix(r.URL.Path, SignPath):
+		headers, err := s.sign(r.Method, r.Header, hostOf(r), body)
+		if err != nil {
+			nlog.Warnf("Sign request failed, %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+	case strings.HasPrefix(r.URL.Path, VerifyPath):
+		if err := s.verify(r.Method, r.Header, hostOf(r), body); err != nil {
+			nlog.Warnf("Verify request from %s failed, %v", sourceOf(r.Header), err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// sign returns the signature headers of request to destination in host, nothing is returned if the destination
+// isn't signed, or the request is transited from other domains.
+func (s *Server) sign(method string, header http.Header, host string, body []byte) (map[string]string, error) {
+	if header.Get(HeaderSignature) != "" {
+		return nil, nil
+	}
+	if origin := header.Get(headerOriginSource); origin != "" && origin != s.namespace {
+		return nil, nil
+	}
+	destination := destinationOf(host)
+	s.mtx.RLock()
+	key, ok := s.signKeys[destination]
+	s.mtx.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+
+	nonce := make([]byte, nonceBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	headers := map[string]string{
+		HeaderTimestamp:  strconv.FormatInt(s.now().Unix(), 10),
+		HeaderNonce:      hex.EncodeToString(nonce),
+		HeaderDigest:     digest(body),
+		HeaderKeyVersion: key.Version,
+	}
+	headers[HeaderSignature] = signature(key, method, s.namespace, destination, headers)
+	return headers, nil
+}
+
+// verify checks the signature of request from source, requests from sources without keys and requests transited
+// to other domains are passed.
+func (s *Server) verify(method string, header http.Header, host string, body []byte) error {
+	destination := destinationOf(host)
+	if destination != s.namespace {
+		return nil
+	}
+	source := sourceOf(header)
+	s.mtx.RLock()
+	keys := s.verifyKeys[source]
+	s.mtx.RUnlock()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if header.Get(HeaderSignature) == "" {
+		return fmt.Errorf("request isn't signed")
+	}
+	timestamp, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", header.Get(HeaderTimestamp))
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if skew := s.now().Sub(signedAt); skew > s.maxClockSkew || skew < -s.maxClockSkew {
+		return fmt.Errorf("timestamp %d is out of the allowed clock skew %s", timestamp, s.maxClockSkew)
+	}
+	nonce := header.Get(HeaderNonce)
+	if nonce == "" {
+		return fmt.Errorf("nonce is empty")
+	}
+	if header.Get(HeaderDigest) != digest(body) {
+		return fmt.Errorf("body digest mismatch")
+	}
+
+	headers := map[string]string{
+		HeaderTimestamp:  header.Get(HeaderTimestamp),
+		HeaderNonce:      nonce,
+		HeaderDigest:     header.Get(HeaderDigest),
+		HeaderKeyVersion: header.Get(HeaderKeyVersion),
+	}
+	expected := []byte(header.Get(HeaderSignature))
+	for _, key := range keys {
+		if key.Version != headers[HeaderKeyVersion] {
+			continue
+		}
+		if !hmac.Equal(expected, []byte(signature(key, method, source, destination, headers))) {
+			return fmt.Errorf("signature mismatch")
+		}
+		if !s.nonces.add(source+"/"+nonce, s.now(), signedAt.Add(s.maxClockSkew)) {
+			return fmt.Errorf("nonce %s is replayed", nonce)
+		}
+		return nil
+	}
+	return fmt.Errorf("key version %q not found", headers[HeaderKeyVersion])
+}
+
+func signature(key Key, method, source, destination string, headers map[string]string) string {
+	mac := hmac.New(sha256.New, key.derive())
+	for _, v := range []string{method, source, destination, headers[HeaderTimestamp], headers[HeaderNonce],
+		headers[HeaderDigest], headers[HeaderKeyVersion]} {
+		mac.Write([]byte(v))
+		mac.Write([]byte{'\n'})
+	}
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func hostOf(r *http.Request) string {
+	if host := r.Header.Get(headerHost); host != "" {
+		return host
+	}
+	return r.Host
+}
+
+func sourceOf(header http.Header) string {
+	if origin := header.Get(headerOriginSource); origin != "" {
+		return origin
+	}
+	return header.Get(headerSource)
+}
+
+// destinationOf returns the domain of host like service.domain.svc.
+func destinationOf(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	fields := strings.Split(host, ".")
+	if len(fields) < 3 || fields[len(fields)-1] != "svc" {
+		return ""
+	}
+	return fields[len(fields)-2]
+}
+
+// nonceCache remembers nonces until they expire, a request with a remembered nonce is a replay.
+type nonceCache struct {
+	mtx       sync.Mutex
+	entries   map[string]time.Time
+	lastPurge time.Time
+}
+
+func newNonceCache() *nonceCache {
+	return &nonceCache{entries: make(map[string]time.Time)}
+}
+
+// add returns false if nonce exists.
+func (c *nonceCache) add(nonce string, now, expiration time.Time) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if now.Sub(c.lastPurge) > time.Minute {
+		for k, v := range c.entries {
+			if now.After(v) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastPurge = now
+	}
+	if v, ok := c.entries[nonce]; ok && !now.After(v) {
+		return false
+	}
+	c.entries[nonce] = expiration
+	return true
+}
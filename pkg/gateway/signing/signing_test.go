@@ -0,0 +1,126 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func doCheck(s *Server, path, host string, header http.Header, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path+"/api/v1/jobs", strings.NewReader(body))
+	req.Host = host
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	return w
+}
+
+func signedHeader(t *testing.T, alice *Server, body string) http.Header {
+	w := doCheck(alice, SignPath, "job.bob.svc", http.Header{}, body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	header := http.Header{}
+	for k, v := range w.Header() {
+		if strings.HasPrefix(k, HeaderSignature) {
+			header[k] = v
+		}
+	}
+	assert.NotEmpty(t, header.Get(HeaderSignature))
+	header.Set(headerSource, "alice")
+	return header
+}
+
+func TestSignAndVerify(t *testing.T) {
+	alice := New("alice", 0)
+	bob := New("bob", 0)
+	alice.SetSignKey("bob", &Key{Token: "token-2", Version: "2"})
+	bob.SetVerifyKeys("alice", []Key{{Token: "token-2", Version: "2"}, {Token: "token-1", Version: "1"}})
+	assert.True(t, alice.SignEnabled())
+	assert.True(t, bob.VerifyEnabled())
+
+	header := signedHeader(t, alice, "hello")
+	assert.Equal(t, http.StatusOK, doCheck(bob, VerifyPath, "job.bob.svc", header, "hello").Code)
+	// replayed
+	assert.Equal(t, http.StatusUnauthorized, doCheck(bob, VerifyPath, "job.bob.svc", header, "hello").Code)
+
+	// tampered body
+	header = signedHeader(t, alice, "hello")
+	assert.Equal(t, http.StatusUnauthorized, doCheck(bob, VerifyPath, "job.bob.svc", header, "hello!").Code)
+
+	// unsigned
+	header = http.Header{}
+	header.Set(headerSource, "alice")
+	assert.Equal(t, http.StatusUnauthorized, doCheck(bob, VerifyPath, "job.bob.svc", header, "hello").Code)
+
+	// expired
+	header = signedHeader(t, alice, "hello")
+	bob.now = func() time.Time { return time.Now().Add(DefaultMaxClockSkew + time.Minute) }
+	assert.Equal(t, http.StatusUnauthorized, doCheck(bob, VerifyPath, "job.bob.svc", header, "hello").Code)
+	bob.now = time.Now
+
+	// signed by the previous key during token rolling
+	alice.SetSignKey("bob", &Key{Token: "token-1", Version: "1"})
+	header = signedHeader(t, alice, "hello")
+	assert.Equal(t, http.StatusOK, doCheck(bob, VerifyPath, "job.bob.svc", header, "hello").Code)
+
+	// signed by a wrong key
+	alice.SetSignKey("bob", &Key{Token: "token-3", Version: "2"})
+	header = signedHeader(t, alice, "hello")
+	assert.Equal(t, http.StatusUnauthorized, doCheck(bob, VerifyPath, "job.bob.svc", header, "hello").Code)
+}
+
+func TestSignAndVerifySkipped(t *testing.T) {
+	alice := New("alice", 0)
+	bob := New("bob", 0)
+	alice.SetSignKey("bob", &Key{Token: "token", Version: "1"})
+	bob.SetVerifyKeys("alice", []Key{{Token: "token", Version: "1"}})
+
+	// destinations without keys are not signed
+	w := doCheck(alice, SignPath, "job.carol.svc", http.Header{}, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(HeaderSignature))
+
+	// requests transited from other domains are not signed again
+	header := http.Header{}
+	header.Set(headerOriginSource, "carol")
+	w = doCheck(alice, SignPath, "job.bob.svc", header, "")
+	assert.Empty(t, w.Header().Get(HeaderSignature))
+
+	// sources without keys and requests transited to other domains are not verified
+	header = http.Header{}
+	header.Set(headerSource, "carol")
+	assert.Equal(t, http.StatusOK, doCheck(bob, VerifyPath, "job.bob.svc", header, "").Code)
+	header.Set(headerSource, "alice")
+	assert.Equal(t, http.StatusOK, doCheck(bob, VerifyPath, "job.carol.svc", header, "").Code)
+
+	alice.SetSignKey("bob", nil)
+	bob.SetVerifyKeys("alice", nil)
+	assert.False(t, alice.SignEnabled())
+	assert.False(t, bob.VerifyEnabled())
+}
+
+func TestDestinationOf(t *testing.T) {
+	assert.Equal(t, "bob", destinationOf("job.bob.svc"))
+	assert.Equal(t, "bob", destinationOf("job.bob.svc:80"))
+	assert.Equal(t, "", destinationOf("127.0.0.1"))
+	assert.Equal(t, "", destinationOf("bob.svc"))
+}
@@ -24,6 +24,8 @@ const (
 	ServiceAPIServer     = "apiserver"
 	ServiceKusciaStorage = "kusciastorage"
 	ServiceHandshake     = "kuscia-handshake"
+	ServiceDiagnose      = "kuscia-diagnose"
+	ServiceMetadata      = "kuscia-metadata"
 	ServiceKusciaAPI     = "kusciaapi"
 	ServiceReporter      = "reporter"
 	EnvoyClusterName     = "envoy-cluster"
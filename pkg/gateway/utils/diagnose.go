@@ -0,0 +1,37 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "fmt"
+
+const (
+	// DiagnoseEchoPath returns the request body as response body.
+	DiagnoseEchoPath = "/diagnose/echo"
+	// DiagnoseSinkPath discards the request body and returns its size.
+	DiagnoseSinkPath = "/diagnose/sink"
+	// DiagnoseDomainHeader is set by the gateway serving diagnose requests to its domain id.
+	DiagnoseDomainHeader = "Kuscia-Diagnose-Domain"
+)
+
+// DiagnoseSinkResponse is the response of DiagnoseSinkPath.
+type DiagnoseSinkResponse struct {
+	Bytes int64 `json:"bytes"`
+}
+
+// GetDiagnoseHost returns the host of diagnose service of domain. Requests to this host pass through the token
+// authentication of domain routes, unlike the handshake host.
+func GetDiagnoseHost(domain string) string {
+	return fmt.Sprintf("%s.%s.svc", ServiceDiagnose, domain)
+}
@@ -16,8 +16,10 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,11 +27,54 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
 const KusciaEnvoyMsgHeaderKey = "Kuscia-Error-Message"
 
+const (
+	DefaultMaxResponseBodySize = 4 << 20 // 4MB
+	DefaultRequestTimeout      = 10 * time.Second
+)
+
+var (
+	maxResponseBodySize int64 = DefaultMaxResponseBodySize
+	requestTimeout            = DefaultRequestTimeout
+)
+
+// SetHTTPLimits sets the max size of response body and the deadline of one request of DoHTTP, the deadline covers
+// reading the response body, so a slow peer can't hold the request forever. Non-positive values restore the defaults.
+func SetHTTPLimits(maxBodySize int64, timeout time.Duration) {
+	maxResponseBodySize = DefaultMaxResponseBodySize
+	if maxBodySize > 0 {
+		maxResponseBodySize = maxBodySize
+	}
+	requestTimeout = DefaultRequestTimeout
+	if timeout > 0 {
+		requestTimeout = timeout
+	}
+}
+
+// ResponseTooLargeError is returned by DoHTTP if the response body exceeds the size limit.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds the limit of %d bytes", e.Limit)
+}
+
+// RequestTimeoutError is returned by DoHTTP if the response isn't read completely before the deadline.
+type RequestTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *RequestTimeoutError) Error() string {
+	return fmt.Sprintf("request isn't completed in %v", e.Timeout)
+}
+
 type HTTPParam struct {
 	Method       string
 	Path         string
@@ -77,18 +122,60 @@ func ParseURL(url string) (string, string, uint32, string, error) {
 	return protocol, host, uint32(port), path, nil
 }
 
-func DoHTTPWithRetry(in interface{}, out interface{}, hp *HTTPParam, waitTime time.Duration, maxRetryTimes int) error {
+// destination returns the key of the peer in retry budget and pacing, the host is used for transit requests.
+func (hp *HTTPParam) destination() string {
+	if hp.ClusterName != "" {
+		return hp.ClusterName
+	}
+	return hp.KusciaHost
+}
+
+// DoHTTPWithRetry retries the request to the cluster of hp after waitTime, the retries take tokens from the
+// DefaultRetryBudget. If the peer responds 429 or 503, all requests to it are paused by DefaultClusterPacer for the
+// delay asked by Retry-After instead of waitTime. It gives up before the deadline of ctx, or if the budget of cluster
+// is exhausted.
+func DoHTTPWithRetry(ctx context.Context, in interface{}, out interface{}, hp *HTTPParam, waitTime time.Duration, maxRetryTimes int) error {
+	destination := hp.destination()
 	var err error
 	for i := 0; i < maxRetryTimes; i++ {
+		var throttled *ThrottledError
+		if i > 0 {
+			backoff := waitTime
+			// the pause of a throttled peer is waited in pacing
+			if errors.As(err, &throttled) {
+				backoff = 0
+			}
+			if waitErr := DefaultRetryBudget.Wait(ctx, hp.ClusterName, backoff); waitErr != nil {
+				nlog.Warnf("[HTTP] stop retrying path(%s) of cluster(%s), %v", hp.Path, hp.ClusterName, waitErr)
+				return err
+			}
+		}
+		if waitErr := DefaultClusterPacer.Wait(ctx, destination); waitErr != nil {
+			nlog.Warnf("[HTTP] stop requesting path(%s) of cluster(%s) paused by throttling, %v", hp.Path, destination, waitErr)
+			if err == nil {
+				err = fmt.Errorf("cluster %s is paused by throttling, %w", destination, waitErr)
+			}
+			return err
+		}
 		err = DoHTTP(in, out, hp)
 		sin, _ := json.Marshal(in)
 		sou, _ := json.Marshal(out)
 		nlog.Infof("[HTTP] method(%s),uri(%s),path(%s),req(%s),res(%s),err(%v)",
 			hp.Method, hp.KusciaHost, hp.Path, sin, sou, err)
 		if err == nil {
+			DefaultClusterPacer.Succeeded(destination)
 			return nil
 		}
-		time.Sleep(waitTime)
+		if errors.As(err, &throttled) {
+			pausedUntil := DefaultClusterPacer.Throttled(destination, throttled)
+			nlog.Warnf("[HTTP] cluster(%s) is throttled, pause requests until %s", destination, pausedUntil.Format(time.RFC3339))
+			continue
+		}
+		// the peer returns the same huge body again, don't retry
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return err
+		}
 	}
 	return err
 }
@@ -98,12 +185,15 @@ func DoHTTP(in interface{}, out interface{}, hp *HTTPParam) error {
 	var req *http.Request
 	var err error
 
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
 	handshakeHost = InternalServer
 	if hp.Transit {
 		handshakeHost = "http://" + hp.KusciaHost
 	}
 	if hp.Method == http.MethodGet {
-		req, err = http.NewRequest(http.MethodGet, handshakeHost+hp.Path, nil)
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, handshakeHost+hp.Path, nil)
 		if err != nil {
 			return fmt.Errorf("invalid request, detail -> %s", err.Error())
 		}
@@ -112,13 +202,14 @@ func DoHTTP(in interface{}, out interface{}, hp *HTTPParam) error {
 		if err != nil {
 			return fmt.Errorf("invalid request, detail -> %s", err.Error())
 		}
-		req, err = http.NewRequest(hp.Method, handshakeHost+hp.Path, bytes.NewBuffer(inbody))
+		req, err = http.NewRequestWithContext(ctx, hp.Method, handshakeHost+hp.Path, bytes.NewBuffer(inbody))
 		if err != nil {
 			return fmt.Errorf("invalid request, detail -> %s", err.Error())
 		}
 	}
 	if !hp.Transit {
 		req.Header.Set(fmt.Sprintf("%s-Cluster", ServiceHandshake), hp.ClusterName)
+		req.Header.Set(InternalTokenHeader, internalToken)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Kuscia-Source", hp.KusciaSource)
@@ -128,26 +219,44 @@ func DoHTTP(in interface{}, out interface{}, hp *HTTPParam) error {
 	}
 
 	client := &http.Client{
-		Timeout: time.Second * 10,
+		// the traceparent header lets envoy of both sides join the span of this request
+		Transport: otelhttp.NewTransport(httpTransport()),
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
 	}
 	resp, err := client.Do(req)
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("send request error, detail -> %w", &RequestTimeoutError{Timeout: requestTimeout})
+		}
 		return fmt.Errorf("send request error, detail -> %s", err.Error())
 	}
 
 	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	limit := maxResponseBodySize
+	if resp.ContentLength > limit {
+		return fmt.Errorf("read response body error, detail -> %w", &ResponseTooLargeError{Limit: limit})
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("read response body error, detail -> %w", &RequestTimeoutError{Timeout: requestTimeout})
+		}
 		return fmt.Errorf("read response body error, detail -> %s", err.Error())
 	}
+	if int64(len(body)) > limit {
+		return fmt.Errorf("read response body error, detail -> %w", &ResponseTooLargeError{Limit: limit})
+	}
 
 	if resp.StatusCode != http.StatusOK {
 		if len(body) > 200 {
 			body = body[:200]
 		}
+		if isThrottledStatus(resp.StatusCode) {
+			retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			return fmt.Errorf("%w, detail -> %s", &ThrottledError{StatusCode: resp.StatusCode, RetryAfter: retryAfter}, string(body))
+		}
 		return fmt.Errorf("response status code [%d], detail -> %s", resp.StatusCode, string(body))
 	}
 
@@ -173,6 +282,8 @@ func ProbePeerEndpoint(endpointURL string) error {
 
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		// honor host overrides and resolver as DoHTTP
+		DialContext: dialContext,
 	}
 	client := &http.Client{Timeout: time.Second * 5, Transport: tr}
 
@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -133,6 +137,7 @@ func TestDoHTTPWithRetry(t *testing.T) {
 	gock.New("http://127.0.0.1:80").
 		Get("/handshake").
 		MatchType("json").
+		MatchHeader(InternalTokenHeader, InternalToken()).
 		Reply(200).
 		JSON(map[string]int{"value": 100})
 
@@ -161,9 +166,77 @@ func TestDoHTTPWithRetry(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := DoHTTPWithRetry(tt.args.in, tt.args.out, tt.args.hp, time.Second, 3); (err != nil) != tt.wantErr {
+			if err := DoHTTPWithRetry(context.Background(), tt.args.in, tt.args.out, tt.args.hp, time.Second, 3); (err != nil) != tt.wantErr {
 				t.Errorf("DoHTTP() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestDoHTTPLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			w.Write([]byte(`{"value":"` + strings.Repeat("a", 2048) + `"}`))
+		case "/slow":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			time.Sleep(500 * time.Millisecond)
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{"value":"ok"}`))
+		}
+	}))
+	defer server.Close()
+	defer SetHTTPLimits(0, 0)
+	SetHTTPLimits(1024, 200*time.Millisecond)
+
+	hp := func(path string) *HTTPParam {
+		return &HTTPParam{Method: http.MethodGet, Path: path, KusciaHost: strings.TrimPrefix(server.URL, "http://"), Transit: true}
+	}
+	out := map[string]string{}
+	assert.NilError(t, DoHTTP(nil, &out, hp("/ok")))
+	assert.Equal(t, "ok", out["value"])
+
+	err := DoHTTP(nil, &out, hp("/large"))
+	var tooLarge *ResponseTooLargeError
+	assert.Assert(t, errors.As(err, &tooLarge))
+	assert.Equal(t, int64(1024), tooLarge.Limit)
+	// a huge response isn't retried
+	assert.Assert(t, errors.As(DoHTTPWithRetry(context.Background(), nil, &out, hp("/large"), time.Millisecond, 3), &tooLarge))
+
+	err = DoHTTP(nil, &out, hp("/slow"))
+	var timeout *RequestTimeoutError
+	assert.Assert(t, errors.As(err, &timeout), err)
+}
+
+func TestDoHTTPWithRetryAfter(t *testing.T) {
+	var requests int
+	var lastRequest time.Time
+	var interval time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !lastRequest.IsZero() {
+			interval = time.Since(lastRequest)
+		}
+		lastRequest = time.Now()
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"value":"ok"}`))
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	hp := &HTTPParam{Method: http.MethodGet, Path: "/throttled", KusciaHost: host, Transit: true}
+	out := map[string]string{}
+	assert.NilError(t, DoHTTPWithRetry(context.Background(), nil, &out, hp, time.Millisecond, 3))
+	assert.Equal(t, "ok", out["value"])
+	assert.Equal(t, 2, requests)
+	// the retry waits for Retry-After instead of the wait time
+	assert.Assert(t, interval >= 900*time.Millisecond, interval)
+	assert.Equal(t, PacingState{}, DefaultClusterPacer.State(host))
+}
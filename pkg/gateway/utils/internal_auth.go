@@ -0,0 +1,46 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const (
+	// InternalTokenHeader authenticates the requests sent by kuscia to other clusters through the internal listener,
+	// the handshake route of internal listener rejects requests without the token, so other pods can't forge them.
+	InternalTokenHeader = "Kuscia-Internal-Token"
+	// InternalAuthRejectPath is the path prefix of handshake server which the rejected requests are rewritten to,
+	// followed by the original path.
+	InternalAuthRejectPath = "/internal-auth/reject/"
+	// DownstreamAddressHeader carries the address of the client whose request is rejected.
+	DownstreamAddressHeader = "Kuscia-Downstream-Address"
+)
+
+var internalToken = newInternalToken()
+
+func newInternalToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// InternalToken returns the token of internal requests, it's generated each time kuscia starts.
+func InternalToken() string {
+	return internalToken
+}
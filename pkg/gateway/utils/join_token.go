@@ -0,0 +1,102 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// JoinPath is served by the handshake server of master, a new domain joins with a csr and a join token.
+	JoinPath = "/join"
+
+	LabelJoinToken                = "kuscia.secretflow/join-token"
+	AnnotationJoinTokenDomain     = "kuscia.secretflow/join-token-domain"
+	AnnotationJoinTokenExpiration = "kuscia.secretflow/join-token-expiration"
+	JoinTokenHashKey              = "tokenHash"
+
+	joinTokenSecretPrefix = "join-token-"
+	joinTokenBytes        = 24
+)
+
+// GenerateJoinToken returns a random join token.
+func GenerateJoinToken() (string, error) {
+	b := make([]byte, joinTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashJoinToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return []byte(hex.EncodeToString(sum[:]))
+}
+
+// JoinTokenSecretName returns the name of secret storing the join token, the token itself isn't stored.
+func JoinTokenSecretName(token string) string {
+	return joinTokenSecretPrefix + string(hashJoinToken(token)[:16])
+}
+
+// NewJoinTokenSecret returns a secret of join token. If domainID isn't empty, only the domain can join with the token.
+// The token never expires if ttl is zero.
+func NewJoinTokenSecret(namespace, token, domainID string, ttl time.Duration) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        JoinTokenSecretName(token),
+			Namespace:   namespace,
+			Labels:      map[string]string{LabelJoinToken: "true"},
+			Annotations: map[string]string{},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{JoinTokenHashKey: hashJoinToken(token)},
+	}
+	if domainID != "" {
+		secret.Annotations[AnnotationJoinTokenDomain] = domainID
+	}
+	if ttl > 0 {
+		secret.Annotations[AnnotationJoinTokenExpiration] = time.Now().Add(ttl).UTC().Format(time.RFC3339)
+	}
+	return secret
+}
+
+// VerifyJoinTokenSecret checks whether domainID can join with token stored in secret.
+func VerifyJoinTokenSecret(secret *corev1.Secret, token, domainID string, now time.Time) error {
+	if secret.Labels[LabelJoinToken] != "true" ||
+		subtle.ConstantTimeCompare(secret.Data[JoinTokenHashKey], hashJoinToken(token)) != 1 {
+		return fmt.Errorf("join token is invalid")
+	}
+	if expiration, ok := secret.Annotations[AnnotationJoinTokenExpiration]; ok {
+		t, err := time.Parse(time.RFC3339, expiration)
+		if err != nil {
+			return fmt.Errorf("parse expiration of join token failed, %v", err)
+		}
+		if now.After(t) {
+			return fmt.Errorf("join token expired at %s", expiration)
+		}
+	}
+	if bound, ok := secret.Annotations[AnnotationJoinTokenDomain]; ok && bound != domainID {
+		return fmt.Errorf("join token is issued for domain %s, not %s", bound, domainID)
+	}
+	return nil
+}
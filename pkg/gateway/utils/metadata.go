@@ -0,0 +1,28 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "fmt"
+
+const (
+	// MetadataAppsPath returns the AppImages advertised to the requesting partner.
+	MetadataAppsPath = "/metadata/apps"
+)
+
+// GetMetadataHost returns the host of metadata service of domain. Requests to this host pass through the token
+// authentication of domain routes, so the Kuscia-Source header identifies the partner.
+func GetMetadataHost(domain string) string {
+	return fmt.Sprintf("%s.%s.svc", ServiceMetadata, domain)
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// DefaultPacingBaseDelay is the pause after the first throttled response without Retry-After, it doubles for
+	// each consecutive throttled response.
+	DefaultPacingBaseDelay = time.Second
+	// DefaultPacingMaxDelay bounds the pause of a cluster, including the one asked by Retry-After.
+	DefaultPacingMaxDelay = 5 * time.Minute
+)
+
+var (
+	throttledResponses = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "throttled_responses_total",
+			Help: "responses with status 429 or 503 from the destination cluster",
+		},
+		[]string{"cluster", "code"},
+	)
+	pacingDelayed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pacing_delayed_requests_total",
+			Help: "requests to the destination cluster delayed because the cluster asked callers to back off",
+		},
+		[]string{"cluster"},
+	)
+)
+
+// ThrottledError is returned by DoHTTP if the peer responds 429 Too Many Requests or 503 Service Unavailable.
+type ThrottledError struct {
+	StatusCode int
+	// RetryAfter is the delay asked by the Retry-After header, it's 0 if the header is absent or invalid.
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("response status code [%d], retry after %v", e.StatusCode, e.RetryAfter)
+	}
+	return fmt.Sprintf("response status code [%d]", e.StatusCode)
+}
+
+func isThrottledStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter parses the Retry-After header, which is either delay seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// DefaultClusterPacer is shared by the callers of DoHTTPWithRetry, so that all of them back off together once a peer
+// asks to slow down.
+var DefaultClusterPacer = NewClusterPacer(DefaultPacingBaseDelay, DefaultPacingMaxDelay)
+
+// PacingState is the pacing state of a destination cluster.
+type PacingState struct {
+	// PausedUntil is the time before which requests to the cluster are held, it's zero if the cluster isn't paused.
+	PausedUntil time.Time
+	// Throttled is the number of consecutive throttled responses from the cluster.
+	Throttled int
+}
+
+// ClusterPacer pauses the requests to a destination cluster after it responds 429 or 503, for the delay asked by
+// Retry-After or an exponential backoff if it doesn't ask. It's safe for concurrent use.
+type ClusterPacer struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+
+	lock   sync.Mutex
+	states map[string]*PacingState
+	// now is replaced in test.
+	now func() time.Time
+}
+
+// NewClusterPacer creates a pacer which backs off from baseDelay up to maxDelay.
+func NewClusterPacer(baseDelay, maxDelay time.Duration) *ClusterPacer {
+	return &ClusterPacer{
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		states:    map[string]*PacingState{},
+		now:       time.Now,
+	}
+}
+
+// State returns the pacing state of cluster.
+func (p *ClusterPacer) State(cluster string) PacingState {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if state, ok := p.states[cluster]; ok {
+		return *state
+	}
+	return PacingState{}
+}
+
+// Throttled records a throttled response from cluster and pauses the cluster. It returns the time until which the
+// cluster is paused, a pause asked by another caller is never shortened.
+func (p *ClusterPacer) Throttled(cluster string, err *ThrottledError) time.Time {
+	throttledResponses.WithLabelValues(cluster, strconv.Itoa(err.StatusCode)).Inc()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	state, ok := p.states[cluster]
+	if !ok {
+		state = &PacingState{}
+		p.states[cluster] = state
+	}
+	state.Throttled++
+
+	delay := err.RetryAfter
+	if delay <= 0 {
+		delay = p.baseDelay
+		for i := 1; i < state.Throttled && delay < p.maxDelay; i++ {
+			delay *= 2
+		}
+	}
+	if delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+	if until := p.now().Add(delay); until.After(state.PausedUntil) {
+		state.PausedUntil = until
+	}
+	return state.PausedUntil
+}
+
+// Succeeded resets the consecutive throttled responses of cluster.
+func (p *ClusterPacer) Succeeded(cluster string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if state, ok := p.states[cluster]; ok && !p.now().Before(state.PausedUntil) {
+		delete(p.states, cluster)
+	}
+}
+
+// Wait blocks until the pause of cluster ends. It returns ErrRetryDeadlineExceeded without waiting if the pause
+// doesn't end before the deadline of ctx.
+func (p *ClusterPacer) Wait(ctx context.Context, cluster string) error {
+	delay := p.State(cluster).PausedUntil.Sub(p.now())
+	if delay <= 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && p.now().Add(delay).After(deadline) {
+		return ErrRetryDeadlineExceeded
+	}
+
+	pacingDelayed.WithLabelValues(cluster).Inc()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		delay time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "3", delay: 3 * time.Second, ok: true},
+		{value: "-1", ok: false},
+		{value: now.Add(90 * time.Second).Format(http.TimeFormat), delay: 90 * time.Second, ok: true},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), delay: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		delay, ok := parseRetryAfter(tt.value, now)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, tt.delay, delay, tt.value)
+	}
+}
+
+func TestClusterPacerThrottled(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	pacer := NewClusterPacer(time.Second, 5*time.Second)
+	pacer.now = func() time.Time { return now }
+
+	// consecutive throttled responses without Retry-After back off exponentially up to the max delay
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		assert.Equal(t, now.Add(delay), pacer.Throttled("cluster-a", &ThrottledError{StatusCode: http.StatusServiceUnavailable}))
+	}
+	assert.Equal(t, 4, pacer.State("cluster-a").Throttled)
+
+	// a shorter Retry-After doesn't shorten the pause
+	assert.Equal(t, now.Add(5*time.Second), pacer.Throttled("cluster-a", &ThrottledError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second}))
+	// clusters are paced independently, Retry-After is bounded by the max delay
+	assert.Equal(t, now.Add(5*time.Second), pacer.Throttled("cluster-b", &ThrottledError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour}))
+
+	// success during the pause doesn't reset the state
+	pacer.Succeeded("cluster-a")
+	assert.Equal(t, 5, pacer.State("cluster-a").Throttled)
+	now = now.Add(5 * time.Second)
+	pacer.Succeeded("cluster-a")
+	assert.Equal(t, PacingState{}, pacer.State("cluster-a"))
+}
+
+func TestClusterPacerWait(t *testing.T) {
+	pacer := NewClusterPacer(20*time.Millisecond, time.Second)
+	assert.NoError(t, pacer.Wait(context.Background(), "cluster-a"))
+
+	pacer.Throttled("cluster-a", &ThrottledError{StatusCode: http.StatusTooManyRequests})
+	// the pause ends after the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, pacer.Wait(ctx, "cluster-a"), ErrRetryDeadlineExceeded)
+
+	start := time.Now()
+	assert.NoError(t, pacer.Wait(context.Background(), "cluster-a"))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
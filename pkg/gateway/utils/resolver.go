@@ -0,0 +1,140 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Resolver resolves the host of requests sent by DoHTTP, it returns the address to dial with or without port, or empty
+// to resolve the host by system DNS.
+type Resolver func(ctx context.Context, host string) (string, error)
+
+var (
+	resolveLock   sync.RWMutex
+	hostOverrides = map[string]string{}
+	hostResolver  Resolver
+	// resolveTransport is only used when host overrides or resolver are set, otherwise requests are sent by
+	// http.DefaultTransport.
+	resolveTransport *http.Transport
+)
+
+// SetHostOverride maps host to address for requests sent by DoHTTP, like an entry of /etc/hosts. host could be
+// "host" or "host:port", address could be "ip", "ip:port" or another host name; the port of request is kept if the
+// address has no port. Overrides take precedence over the resolver.
+func SetHostOverride(host, address string) {
+	resolveLock.Lock()
+	defer resolveLock.Unlock()
+	hostOverrides[host] = address
+	resetResolveTransport()
+}
+
+// DeleteHostOverride deletes the override of host set by SetHostOverride.
+func DeleteHostOverride(host string) {
+	resolveLock.Lock()
+	defer resolveLock.Unlock()
+	delete(hostOverrides, host)
+	resetResolveTransport()
+}
+
+// SetResolver sets the resolver of hosts without override, nil restores system DNS.
+func SetResolver(resolver Resolver) {
+	resolveLock.Lock()
+	defer resolveLock.Unlock()
+	hostResolver = resolver
+	resetResolveTransport()
+}
+
+// resetResolveTransport drops the connections dialed by the previous resolution, it's called with lock held.
+func resetResolveTransport() {
+	if resolveTransport != nil {
+		resolveTransport.CloseIdleConnections()
+		resolveTransport = nil
+	}
+	if len(hostOverrides) == 0 && hostResolver == nil {
+		return
+	}
+	resolveTransport = newTransport()
+	resolveTransport.DialContext = dialContext
+}
+
+func newTransport() *http.Transport {
+	// same as http.DefaultTransport
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// httpTransport returns the transport of DoHTTP.
+func httpTransport() http.RoundTripper {
+	resolveLock.RLock()
+	defer resolveLock.RUnlock()
+	if resolveTransport != nil {
+		return resolveTransport
+	}
+	return http.DefaultTransport
+}
+
+func dialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	target, err := resolveAddress(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	return dialer.DialContext(ctx, network, target)
+}
+
+// resolveAddress returns the address to dial for addr in form of host:port.
+func resolveAddress(ctx context.Context, addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+
+	resolveLock.RLock()
+	target, ok := hostOverrides[addr]
+	if !ok {
+		target, ok = hostOverrides[host]
+	}
+	resolver := hostResolver
+	resolveLock.RUnlock()
+
+	if !ok && resolver != nil {
+		if target, err = resolver(ctx, host); err != nil {
+			return "", err
+		}
+	}
+	if target == "" {
+		return addr, nil
+	}
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		// target has no port
+		return net.JoinHostPort(target, port), nil
+	}
+	return target, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAddress(t *testing.T) {
+	SetHostOverride("datamesh", "10.0.0.1")
+	SetHostOverride("datamesh:8071", "10.0.0.2:9071")
+	defer DeleteHostOverride("datamesh")
+	defer DeleteHostOverride("datamesh:8071")
+	SetResolver(func(ctx context.Context, host string) (string, error) {
+		switch host {
+		case "kusciaapi":
+			return "10.0.0.3", nil
+		case "broken":
+			return "", fmt.Errorf("no such host")
+		}
+		return "", nil
+	})
+	defer SetResolver(nil)
+
+	tests := []struct {
+		addr    string
+		want    string
+		wantErr bool
+	}{
+		{addr: "datamesh:8070", want: "10.0.0.1:8070"},
+		{addr: "datamesh:8071", want: "10.0.0.2:9071"},
+		{addr: "kusciaapi:8082", want: "10.0.0.3:8082"},
+		{addr: "example.com:443", want: "example.com:443"},
+		{addr: "broken:80", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			got, err := resolveAddress(context.Background(), tt.addr)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDoHTTPWithHostOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"host":"` + r.Host + `"}`))
+	}))
+	defer server.Close()
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	assert.Nil(t, resolveTransport)
+	SetHostOverride("kuscia-handshake.bob.svc", "127.0.0.1")
+	defer DeleteHostOverride("kuscia-handshake.bob.svc")
+	assert.NotNil(t, resolveTransport)
+
+	out := &struct {
+		Host string `json:"host"`
+	}{}
+	err = DoHTTP(nil, out, &HTTPParam{
+		Method:     http.MethodGet,
+		Path:       "/handshake",
+		KusciaHost: "kuscia-handshake.bob.svc:" + port,
+		Transit:    true,
+	})
+	assert.NoError(t, err)
+	// the request keeps the logical host
+	assert.Equal(t, "kuscia-handshake.bob.svc:"+port, out.Host)
+}
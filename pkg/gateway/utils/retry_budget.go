@@ -0,0 +1,144 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRetryRate is the retries per second allowed to a destination cluster by all callers.
+	DefaultRetryRate = 1.0
+	// DefaultRetryBurst is the retries allowed at once to a destination cluster after it has been idle.
+	DefaultRetryBurst = 10
+	// DefaultRetryMaxQueueDelay bounds how long a retry waits for the budget before it's rejected.
+	DefaultRetryMaxQueueDelay = 30 * time.Second
+)
+
+var (
+	// ErrRetryBudgetExhausted is returned if the budget of destination cluster can't afford a retry in time.
+	ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+	// ErrRetryDeadlineExceeded is returned if the retry couldn't start before the deadline of context.
+	ErrRetryDeadlineExceeded = errors.New("retry would exceed the deadline")
+)
+
+var (
+	retryBudgetRetries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retry_budget_retries_total",
+			Help: "retries to the destination cluster allowed by the retry budget",
+		},
+		[]string{"cluster"},
+	)
+	retryBudgetExhausted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retry_budget_exhausted_total",
+			Help: "retries to the destination cluster delayed or rejected because the retry budget was exhausted",
+		},
+		[]string{"cluster", "result"},
+	)
+	retryBudgetDeadlineExceeded = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "retry_budget_deadline_exceeded_total",
+			Help: "retries to the destination cluster given up because they couldn't start before the deadline",
+		},
+		[]string{"cluster"},
+	)
+)
+
+// DefaultRetryBudget is shared by the retry loops of gateway, so that the aggregate retry rate to a peer is bounded
+// no matter how many callers are retrying.
+var DefaultRetryBudget = NewRetryBudget(DefaultRetryRate, DefaultRetryBurst, DefaultRetryMaxQueueDelay)
+
+// RetryBudget is a token bucket per destination cluster, every retry takes a token from the bucket of its destination.
+// It's safe for concurrent use.
+type RetryBudget struct {
+	rate          rate.Limit
+	burst         int
+	maxQueueDelay time.Duration
+
+	lock    sync.Mutex
+	buckets map[string]*rate.Limiter
+}
+
+// NewRetryBudget creates a budget refilled by retryRate tokens per second up to burst tokens per cluster. Retries
+// waiting longer than maxQueueDelay for a token are rejected.
+func NewRetryBudget(retryRate float64, burst int, maxQueueDelay time.Duration) *RetryBudget {
+	return &RetryBudget{
+		rate:          rate.Limit(retryRate),
+		burst:         burst,
+		maxQueueDelay: maxQueueDelay,
+		buckets:       map[string]*rate.Limiter{},
+	}
+}
+
+func (b *RetryBudget) bucket(cluster string) *rate.Limiter {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	limiter, ok := b.buckets[cluster]
+	if !ok {
+		limiter = rate.NewLimiter(b.rate, b.burst)
+		b.buckets[cluster] = limiter
+	}
+	return limiter
+}
+
+// Wait blocks for the backoff of caller before a retry to cluster, and longer if the budget of cluster is exhausted.
+// It returns an error without waiting if the retry couldn't start before the deadline of ctx, or the budget couldn't
+// afford it within the max queue delay. The token is returned to the budget if the retry doesn't happen.
+func (b *RetryBudget) Wait(ctx context.Context, cluster string, backoff time.Duration) error {
+	now := time.Now()
+	reservation := b.bucket(cluster).ReserveN(now, 1)
+	if !reservation.OK() {
+		retryBudgetExhausted.WithLabelValues(cluster, "rejected").Inc()
+		return ErrRetryBudgetExhausted
+	}
+
+	delay := reservation.DelayFrom(now)
+	if delay > b.maxQueueDelay {
+		reservation.CancelAt(now)
+		retryBudgetExhausted.WithLabelValues(cluster, "rejected").Inc()
+		return ErrRetryBudgetExhausted
+	}
+	if delay > 0 {
+		retryBudgetExhausted.WithLabelValues(cluster, "delayed").Inc()
+	}
+	if backoff > delay {
+		delay = backoff
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		reservation.CancelAt(now)
+		retryBudgetDeadlineExceeded.WithLabelValues(cluster).Inc()
+		return ErrRetryDeadlineExceeded
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		retryBudgetRetries.WithLabelValues(cluster).Inc()
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudgetWait(t *testing.T) {
+	ctx := context.Background()
+	budget := NewRetryBudget(0.1, 2, time.Second)
+
+	// the burst is spent without waiting for the budget
+	assert.NoError(t, budget.Wait(ctx, "cluster-a", 0))
+	assert.NoError(t, budget.Wait(ctx, "cluster-a", 0))
+	exhausted := testutil.ToFloat64(retryBudgetExhausted.WithLabelValues("cluster-a", "rejected"))
+	assert.ErrorIs(t, budget.Wait(ctx, "cluster-a", 0), ErrRetryBudgetExhausted)
+	assert.Equal(t, exhausted+1, testutil.ToFloat64(retryBudgetExhausted.WithLabelValues("cluster-a", "rejected")))
+
+	// budgets of clusters are independent
+	assert.NoError(t, budget.Wait(ctx, "cluster-b", 0))
+}
+
+func TestRetryBudgetDeadline(t *testing.T) {
+	budget := NewRetryBudget(1, 1, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the backoff exceeds the deadline, the token isn't taken
+	assert.ErrorIs(t, budget.Wait(ctx, "cluster-a", time.Second), ErrRetryDeadlineExceeded)
+	start := time.Now()
+	assert.NoError(t, budget.Wait(ctx, "cluster-a", 10*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	// waiting for the budget exceeds the deadline
+	assert.ErrorIs(t, budget.Wait(ctx, "cluster-a", 0), ErrRetryDeadlineExceeded)
+}
+
+func TestRetryBudgetCanceled(t *testing.T) {
+	budget := NewRetryBudget(1, 1, time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	assert.ErrorIs(t, budget.Wait(ctx, "cluster-a", time.Minute), context.Canceled)
+
+	// the token of canceled retry is returned
+	assert.NoError(t, budget.Wait(context.Background(), "cluster-a", 0))
+}
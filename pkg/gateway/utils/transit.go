@@ -34,3 +34,23 @@ func IsThirdPartyTransit(transit *v1alpha1.Transit) bool {
 func IsReverseTunnelTransit(transit *v1alpha1.Transit) bool {
 	return transit != nil && transit.TransitMethod == v1alpha1.TransitMethodReverseTunnel
 }
+
+// TransitDomains returns the transit domain and candidate domains in order, duplicated domains are removed.
+func TransitDomains(transit *v1alpha1.Transit) []string {
+	if transit == nil {
+		return nil
+	}
+	var domains []string
+	seen := map[string]bool{}
+	add := func(dt *v1alpha1.DomainTransit) {
+		if dt != nil && dt.DomainID != "" && !seen[dt.DomainID] {
+			seen[dt.DomainID] = true
+			domains = append(domains, dt.DomainID)
+		}
+	}
+	add(transit.Domain)
+	for i := range transit.CandidateDomains {
+		add(&transit.CandidateDomains[i])
+	}
+	return domains
+}
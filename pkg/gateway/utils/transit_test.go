@@ -16,6 +16,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
@@ -176,3 +177,22 @@ func TestIsTransit(t *testing.T) {
 		})
 	}
 }
+
+func TestTransitDomains(t *testing.T) {
+	transit := &v1alpha1.Transit{
+		Domain: &v1alpha1.DomainTransit{DomainID: "t1"},
+		CandidateDomains: []v1alpha1.DomainTransit{
+			{DomainID: "t2"},
+			{DomainID: "t1"},
+			{DomainID: ""},
+			{DomainID: "t3"},
+		},
+	}
+	got := TransitDomains(transit)
+	if strings.Join(got, ",") != "t1,t2,t3" {
+		t.Errorf("TransitDomains() = %v, want [t1 t2 t3]", got)
+	}
+	if got := TransitDomains(nil); len(got) != 0 {
+		t.Errorf("TransitDomains(nil) = %v, want empty", got)
+	}
+}
@@ -39,6 +39,15 @@ const (
 	ProtocolGRPCS = "GRPCS"
 )
 
+// Defaults of connections to remote gateways, tuned for WAN links where firewalls and NAT devices drop idle
+// connections silently in minutes.
+const (
+	DefaultUpstreamIdleTimeout = 300 * time.Second
+	DefaultKeepaliveTime       = 60 * time.Second
+	DefaultKeepaliveInterval   = 15 * time.Second
+	DefaultKeepaliveProbes     = 3
+)
+
 func GenerateProtocol(isTLS, isGRPC bool) string {
 	if isTLS {
 		if isGRPC {
@@ -88,9 +97,9 @@ func DecorateCluster(cluster *envoycluster.Cluster) {
 func DecorateRemoteUpstreamCluster(cluster *envoycluster.Cluster, protocol string) error {
 	DecorateCluster(cluster)
 
-	// enable tcp keep alive as a client
+	// enable tcp keep alive as a client, the defaults of os are too long to keep the state of firewalls
 	cluster.UpstreamConnectionOptions = &envoycluster.UpstreamConnectionOptions{
-		TcpKeepalive: &core.TcpKeepalive{},
+		TcpKeepalive: NewTCPKeepalive(DefaultKeepaliveTime, DefaultKeepaliveInterval, DefaultKeepaliveProbes),
 	}
 
 	// set HTTPOptions
@@ -195,7 +204,17 @@ func GenerateHTTP2UpstreamHTTPOptions(isRemoteCluster bool) *envoyhttp.HttpProto
 func SetCommonHTTPProtocolOptions(options *envoyhttp.HttpProtocolOptions) {
 	// set connections idle timeout
 	options.CommonHttpProtocolOptions = &core.HttpProtocolOptions{
-		IdleTimeout: &durationpb.Duration{Seconds: int64(900)},
+		IdleTimeout: durationpb.New(DefaultUpstreamIdleTimeout),
+	}
+}
+
+// NewTCPKeepalive returns the keepalive options of upstream connections, probes are sent after the connection has been
+// idle for idle, every interval, and the connection is closed after probes unanswered ones.
+func NewTCPKeepalive(idle, interval time.Duration, probes uint32) *core.TcpKeepalive {
+	return &core.TcpKeepalive{
+		KeepaliveTime:     wrapperspb.UInt32(uint32(idle.Seconds())),
+		KeepaliveInterval: wrapperspb.UInt32(uint32(interval.Seconds())),
+		KeepaliveProbes:   wrapperspb.UInt32(probes),
 	}
 }
 
@@ -233,6 +252,21 @@ func GetClusterHTTPProtocolOptions(clusterName string) (*envoyhttp.HttpProtocolO
 	return &protocolOptions, cluster, nil
 }
 
+// SetMaxSegmentSize clamps the TCP MSS of upstream connections of cluster. The MSS is announced to the peer in
+// handshake, so packets of both directions are no larger than mss plus headers.
+func SetMaxSegmentSize(c *envoycluster.Cluster, mss int32) {
+	if c.UpstreamBindConfig == nil {
+		c.UpstreamBindConfig = &core.BindConfig{}
+	}
+	c.UpstreamBindConfig.SocketOptions = append(c.UpstreamBindConfig.SocketOptions, &core.SocketOption{
+		Description: "tcp max segment size",
+		Level:       6, // IPPROTO_TCP
+		Name:        2, // TCP_MAXSEG
+		Value:       &core.SocketOption_IntValue{IntValue: int64(mss)},
+		State:       core.SocketOption_STATE_PREBIND,
+	})
+}
+
 func SetKeepAliveForDstCluster(c *envoycluster.Cluster, enable bool) error {
 	optionName := "envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
 	option, ok := c.TypedExtensionProtocolOptions[optionName]
@@ -16,6 +16,10 @@ package xds
 
 import (
 	"testing"
+
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGenerateProtocol(t *testing.T) {
@@ -41,3 +45,13 @@ func TestGenerateProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestSetMaxSegmentSize(t *testing.T) {
+	c := &envoycluster.Cluster{Name: "alice-to-bob-http"}
+	SetMaxSegmentSize(c, 1200)
+	assert.NoError(t, c.Validate())
+	assert.Len(t, c.UpstreamBindConfig.SocketOptions, 1)
+	option := c.UpstreamBindConfig.SocketOptions[0]
+	assert.Equal(t, int64(1200), option.GetIntValue())
+	assert.Equal(t, core.SocketOption_STATE_PREBIND, option.State)
+}
@@ -16,15 +16,21 @@ package xds
 
 import (
 	"sort"
+	"time"
 
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	bandwidth_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/bandwidth_limit/v3"
+	extauthz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	kusciacrypt "github.com/secretflow/kuscia-envoy/kuscia/api/filters/http/kuscia_crypt/v3"
 	headerdecorator "github.com/secretflow/kuscia-envoy/kuscia/api/filters/http/kuscia_header_decorator/v3"
 	kusciapoller "github.com/secretflow/kuscia-envoy/kuscia/api/filters/http/kuscia_poller/v3"
 	kusciareceiver "github.com/secretflow/kuscia-envoy/kuscia/api/filters/http/kuscia_receiver/v3"
 	kusciatoken "github.com/secretflow/kuscia-envoy/kuscia/api/filters/http/kuscia_token_auth/v3"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
@@ -40,27 +46,42 @@ const (
 	ReceiverFilterName         = "envoy.filters.http.kuscia_receiver"
 	BandwidthLimitName         = "envoy.filters.http.bandwidth_limit"
 	PollerFilterName           = "envoy.filters.http.kuscia_poller"
+	ExtAuthzFilterName         = "envoy.filters.http.ext_authz"
+	DecompressorFilterName     = "envoy.filters.http.decompressor"
+	GzipCompressorFilterName   = "envoy.filters.http.compressor.gzip"
+	ZstdCompressorFilterName   = "envoy.filters.http.compressor.zstd"
+	LuaFilterName              = "envoy.filters.http.lua"
 )
 
+const signingTimeout = 2 * time.Second
+
 var (
 	internalFilterPriority = map[string]int{
 		GrpcHTTP1ReverseBridgeName: 0,
 		KusciaGressName:            1,
-		BandwidthLimitName:         2,
-		CryptFilterName:            3,
-		ReceiverFilterName:         4,
-		PollerFilterName:           5,
-		RouterName:                 6,
+		LuaFilterName:              2,
+		DecompressorFilterName:     3,
+		BandwidthLimitName:         4,
+		CryptFilterName:            5,
+		ExtAuthzFilterName:         6,
+		ReceiverFilterName:         7,
+		PollerFilterName:           8,
+		RequestTimeoutFilterName:   9,
+		RouterName:                 10,
 	}
 
 	externalFilterPriority = map[string]int{
 		GrpcHTTP1BridgeName:       0,
 		KusciaGressName:           1,
 		TokenAuthFilterName:       2,
-		HeaderDecoratorFilterName: 3,
-		CryptFilterName:           4,
-		ReceiverFilterName:        5,
-		RouterName:                6,
+		ExtAuthzFilterName:        3,
+		HeaderDecoratorFilterName: 4,
+		CryptFilterName:           5,
+		ReceiverFilterName:        6,
+		GzipCompressorFilterName:  7,
+		ZstdCompressorFilterName:  8,
+		RequestTimeoutFilterName:  9,
+		RouterName:                10,
 	}
 
 	mutableFilters = map[string]bool{
@@ -70,12 +91,18 @@ var (
 		ReceiverFilterName:        true,
 		BandwidthLimitName:        true,
 		PollerFilterName:          true,
+		ExtAuthzFilterName:        true,
+		DecompressorFilterName:    true,
+		GzipCompressorFilterName:  true,
+		ZstdCompressorFilterName:  true,
+		LuaFilterName:             true,
 	}
 
 	// internal only filters config
 	encryptRules      []*kusciacrypt.CryptRule // for outbound, on port 80
 	pollAppendHeaders []*kusciapoller.Poller_SourceHeader
 	virtualHostLimits map[string]map[string]*RouteLimitConfig
+	headerPolicies    = map[string]bool{} // virtual hosts with header policy
 
 	// external only filers config
 	decryptRules  []*kusciacrypt.CryptRule // for inbound, on port 1080
@@ -123,6 +150,58 @@ func UpdateDecryptRules(rule *kusciacrypt.CryptRule, selfNamespace string, add b
 	return updateHTTPFilters(externalFilterMap, ExternalListener)
 }
 
+// SetRequestSigning adds the ext_authz filter asking the signing server of cluster with pathPrefix, or removes it if
+// cluster is empty. The filter signs requests on internal listener and verifies requests on external listener, it runs
+// after encryption and before decryption, so the body digest covers the ciphertext.
+func SetRequestSigning(listenerName, cluster, pathPrefix string, maxBodyBytes uint32) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	filterMap := externalFilterMap
+	if listenerName == InternalListener {
+		filterMap = internalFilterMap
+	}
+	if cluster == "" {
+		delete(filterMap, ExtAuthzFilterName)
+	} else {
+		filterMap[ExtAuthzFilterName] = generateSigningExtAuthz(cluster, pathPrefix, maxBodyBytes)
+	}
+	return updateHTTPFilters(filterMap, listenerName)
+}
+
+func generateSigningExtAuthz(cluster, pathPrefix string, maxBodyBytes uint32) *extauthz.ExtAuthz {
+	kusciaHeaders := &matcher.ListStringMatcher{
+		Patterns: []*matcher.StringMatcher{
+			{MatchPattern: &matcher.StringMatcher_Prefix{Prefix: "kuscia-"}, IgnoreCase: true},
+		},
+	}
+	return &extauthz.ExtAuthz{
+		Services: &extauthz.ExtAuthz_HttpService{
+			HttpService: &extauthz.HttpService{
+				ServerUri: &core.HttpUri{
+					Uri:              "http://127.0.0.1" + pathPrefix,
+					HttpUpstreamType: &core.HttpUri_Cluster{Cluster: cluster},
+					Timeout:          durationpb.New(signingTimeout),
+				},
+				PathPrefix: pathPrefix,
+				AuthorizationResponse: &extauthz.AuthorizationResponse{
+					AllowedUpstreamHeaders: &matcher.ListStringMatcher{
+						Patterns: []*matcher.StringMatcher{
+							{MatchPattern: &matcher.StringMatcher_Prefix{Prefix: "kuscia-signature"}, IgnoreCase: true},
+						},
+					},
+				},
+			},
+		},
+		TransportApiVersion: core.ApiVersion_V3,
+		WithRequestBody: &extauthz.BufferSettings{
+			MaxRequestBytes:     maxBodyBytes,
+			AllowPartialMessage: true,
+		},
+		AllowedHeaders: kusciaHeaders,
+	}
+}
+
 func UpdateAppendHeaders(header *headerdecorator.HeaderDecorator_SourceHeader, add bool) error {
 	lock.Lock()
 	defer lock.Unlock()
@@ -192,6 +271,40 @@ func UpdatePollAppendHeaders(header *kusciapoller.Poller_SourceHeader, add bool)
 	return updateHTTPFilters(internalFilterMap, InternalListener)
 }
 
+// SetHeaderPolicy records whether routes of the virtual host carry a header policy. The lua filter is
+// only installed on the internal listener while at least one virtual host has a policy, the scripts
+// themselves are attached to routes by typed per filter config.
+func SetHeaderPolicy(vhName string, enabled bool) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if enabled {
+		headerPolicies[vhName] = true
+	} else {
+		if _, ok := headerPolicies[vhName]; !ok {
+			return nil
+		}
+		delete(headerPolicies, vhName)
+	}
+
+	_, installed := internalFilterMap[LuaFilterName]
+	if len(headerPolicies) > 0 == installed {
+		return nil
+	}
+	if len(headerPolicies) > 0 {
+		internalFilterMap[LuaFilterName] = &lua.Lua{
+			DefaultSourceCode: &core.DataSource{
+				Specifier: &core.DataSource_InlineString{
+					InlineString: "function envoy_on_request(handle) end\nfunction envoy_on_response(handle) end\n",
+				},
+			},
+		}
+	} else {
+		delete(internalFilterMap, LuaFilterName)
+	}
+	return updateHTTPFilters(internalFilterMap, InternalListener)
+}
+
 func UpdateBandwidthLimit(vhName string, taskID string, serviceName string, limitKbps *int64, add bool) error {
 	nlog.Infof("add or update virtual host limit, vhName: %s, taskId: %s, serviceName: %s, limitKbps: %+v", vhName, taskID, serviceName, limitKbps)
 	lock.Lock()
@@ -206,7 +319,7 @@ func UpdateBandwidthLimit(vhName string, taskID string, serviceName string, limi
 		}
 	} else {
 		deleteVirtualHostLimit(vhName, taskID, serviceName)
-		if len(virtualHostLimits) == 0 {
+		if len(virtualHostLimits) == 0 && MasterSync.BandwidthLimitKbps == 0 {
 			delete(internalFilterMap, BandwidthLimitName)
 		}
 	}
@@ -0,0 +1,159 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"fmt"
+
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	quic "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// ExternalQUICListener serves the routes of external listener by HTTP/3.
+	ExternalQUICListener = "external-listener-quic"
+
+	quicTransportSocketName = "envoy.transport_sockets.quic"
+	httpConnectionManager   = "envoy.filters.network.http_connection_manager"
+	httpProtocolOptionsName = "envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
+)
+
+// generateQUICListener clones the external listener to listen on the UDP port of the same number, the tls transport
+// sockets of filter chains are wrapped by QUIC, so certs selected by SNI work for HTTP/3 too.
+func generateQUICListener(lis *listener.Listener) (*listener.Listener, error) {
+	quicLis := proto.Clone(lis).(*listener.Listener)
+	quicLis.Name = ExternalQUICListener
+	quicLis.GetAddress().GetSocketAddress().Protocol = core.SocketAddress_UDP
+	// tcp keep alive and tls inspector don't work on udp, QUIC reads SNI itself
+	quicLis.SocketOptions = nil
+	quicLis.ListenerFilters = nil
+	quicLis.UdpListenerConfig = &listener.UdpListenerConfig{
+		QuicOptions: &listener.QuicProtocolOptions{},
+	}
+
+	for _, chain := range quicLis.FilterChains {
+		if chain.TransportSocket == nil {
+			return nil, fmt.Errorf("tls of %s is disabled, HTTP/3 can't be served", lis.Name)
+		}
+		tlsContext := &tls.DownstreamTlsContext{}
+		if err := chain.TransportSocket.GetTypedConfig().UnmarshalTo(tlsContext); err != nil {
+			return nil, fmt.Errorf("unmarshal DownstreamTlsContext of %s failed with %v", lis.Name, err)
+		}
+		conf, err := anypb.New(&quic.QuicDownstreamTransport{DownstreamTlsContext: tlsContext})
+		if err != nil {
+			return nil, err
+		}
+		chain.TransportSocket = &core.TransportSocket{
+			Name:       quicTransportSocketName,
+			ConfigType: &core.TransportSocket_TypedConfig{TypedConfig: conf},
+		}
+
+		for _, f := range chain.Filters {
+			if f.Name != httpConnectionManager {
+				continue
+			}
+			manager := &hcm.HttpConnectionManager{}
+			if err := f.GetTypedConfig().UnmarshalTo(manager); err != nil {
+				return nil, err
+			}
+			manager.CodecType = hcm.HttpConnectionManager_HTTP3
+			manager.Http3ProtocolOptions = &core.Http3ProtocolOptions{}
+			manager.StatPrefix = "external_http3"
+			conf, err := anypb.New(manager)
+			if err != nil {
+				return nil, err
+			}
+			f.ConfigType = &listener.Filter_TypedConfig{TypedConfig: conf}
+		}
+	}
+	return quicLis, nil
+}
+
+// syncQUICListener regenerates the QUIC listener from the external listener in items, so the filters updated on the
+// external listener take effect on HTTP/3 too.
+func syncQUICListener(items map[string]types.ResourceWithTTL) error {
+	if config == nil || !config.HTTP3 {
+		return nil
+	}
+	external, ok := items[ExternalListener]
+	if !ok {
+		return nil
+	}
+	quicLis, err := generateQUICListener(external.Resource.(*listener.Listener))
+	if err != nil {
+		return err
+	}
+	items[ExternalQUICListener] = types.ResourceWithTTL{Resource: quicLis}
+	return nil
+}
+
+// SetUpstreamHTTP3 lets cluster connect to host:port by HTTP/3 first, Envoy falls back to HTTP/2 or HTTP/1.1 over TCP
+// if the QUIC connection fails, and marks HTTP/3 broken for a while. The tls transport socket of cluster is wrapped
+// by QUIC, which is also used by TCP connections.
+func SetUpstreamHTTP3(cluster *envoycluster.Cluster, host string, port uint32) error {
+	if cluster.TransportSocket == nil {
+		return fmt.Errorf("tls of cluster %s is disabled, HTTP/3 can't be used", cluster.Name)
+	}
+	tlsContext := &tls.UpstreamTlsContext{}
+	if err := cluster.TransportSocket.GetTypedConfig().UnmarshalTo(tlsContext); err != nil {
+		return fmt.Errorf("unmarshal UpstreamTlsContext of cluster %s failed with %v", cluster.Name, err)
+	}
+	conf, err := anypb.New(&quic.QuicUpstreamTransport{UpstreamTlsContext: tlsContext})
+	if err != nil {
+		return err
+	}
+
+	protocolOptions := &envoyhttp.HttpProtocolOptions{}
+	if opts, ok := cluster.TypedExtensionProtocolOptions[httpProtocolOptionsName]; ok {
+		if err := opts.UnmarshalTo(protocolOptions); err != nil {
+			return err
+		}
+	}
+	protocolOptions.UpstreamProtocolOptions = &envoyhttp.HttpProtocolOptions_AutoConfig{
+		AutoConfig: &envoyhttp.HttpProtocolOptions_AutoHttpConfig{
+			HttpProtocolOptions:  &core.Http1ProtocolOptions{},
+			Http2ProtocolOptions: &core.Http2ProtocolOptions{},
+			Http3ProtocolOptions: &core.Http3ProtocolOptions{},
+			AlternateProtocolsCacheOptions: &core.AlternateProtocolsCacheOptions{
+				Name: cluster.Name,
+				// the peer accepted HTTP/3 in handshake, so it's tried without waiting for Alt-Svc
+				PrepopulatedEntries: []*core.AlternateProtocolsCacheOptions_AlternateProtocolsCacheEntry{
+					{Hostname: host, Port: port},
+				},
+			},
+		},
+	}
+	opts, err := anypb.New(protocolOptions)
+	if err != nil {
+		return err
+	}
+	if cluster.TypedExtensionProtocolOptions == nil {
+		cluster.TypedExtensionProtocolOptions = make(map[string]*anypb.Any)
+	}
+	cluster.TypedExtensionProtocolOptions[httpProtocolOptionsName] = opts
+	cluster.TransportSocket = &core.TransportSocket{
+		Name:       quicTransportSocketName,
+		ConfigType: &core.TransportSocket_TypedConfig{TypedConfig: conf},
+	}
+	return nil
+}
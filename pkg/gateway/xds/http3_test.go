@@ -0,0 +1,101 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"testing"
+
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	quic "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestSetUpstreamHTTP3(t *testing.T) {
+	cluster := &envoycluster.Cluster{Name: "alice-to-bob-http"}
+	assert.Error(t, SetUpstreamHTTP3(cluster, "bob.example.com", 1080))
+
+	assert.NoError(t, DecorateRemoteUpstreamCluster(cluster, ProtocolHTTPS))
+	assert.NoError(t, SetUpstreamServerName(cluster, "bob.example.com"))
+	assert.NoError(t, SetUpstreamHTTP3(cluster, "bob.example.com", 1080))
+
+	assert.Equal(t, quicTransportSocketName, cluster.TransportSocket.Name)
+	quicTransport := &quic.QuicUpstreamTransport{}
+	assert.NoError(t, cluster.TransportSocket.GetTypedConfig().UnmarshalTo(quicTransport))
+	assert.Equal(t, "bob.example.com", quicTransport.UpstreamTlsContext.Sni)
+
+	protocolOptions := &envoyhttp.HttpProtocolOptions{}
+	assert.NoError(t, cluster.TypedExtensionProtocolOptions[httpProtocolOptionsName].UnmarshalTo(protocolOptions))
+	autoConfig := protocolOptions.GetAutoConfig()
+	assert.NotNil(t, autoConfig)
+	assert.NotNil(t, autoConfig.Http2ProtocolOptions)
+	assert.NotNil(t, autoConfig.Http3ProtocolOptions)
+	entries := autoConfig.AlternateProtocolsCacheOptions.PrepopulatedEntries
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "bob.example.com", entries[0].Hostname)
+	assert.Equal(t, uint32(1080), entries[0].Port)
+}
+
+func TestGenerateQUICListener(t *testing.T) {
+	manager, err := anypb.New(&hcm.HttpConnectionManager{StatPrefix: "external_http"})
+	assert.NoError(t, err)
+	lis := &listener.Listener{
+		Name: ExternalListener,
+		Address: &core.Address{
+			Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{
+					Address:       "0.0.0.0",
+					PortSpecifier: &core.SocketAddress_PortValue{PortValue: 1080},
+				},
+			},
+		},
+		FilterChains: []*listener.FilterChain{
+			{
+				Filters: []*listener.Filter{
+					{
+						Name:       httpConnectionManager,
+						ConfigType: &listener.Filter_TypedConfig{TypedConfig: manager},
+					},
+				},
+			},
+		},
+	}
+	_, err = generateQUICListener(lis)
+	assert.Error(t, err)
+
+	tlsContext, err := anypb.New(&tls.DownstreamTlsContext{})
+	assert.NoError(t, err)
+	lis.FilterChains[0].TransportSocket = generateTransportSocket(tlsContext)
+	quicLis, err := generateQUICListener(lis)
+	assert.NoError(t, err)
+	assert.Equal(t, ExternalQUICListener, quicLis.Name)
+	assert.Equal(t, core.SocketAddress_UDP, quicLis.Address.GetSocketAddress().Protocol)
+	assert.Equal(t, uint32(1080), quicLis.Address.GetSocketAddress().GetPortValue())
+	assert.NotNil(t, quicLis.UdpListenerConfig.QuicOptions)
+	assert.Equal(t, quicTransportSocketName, quicLis.FilterChains[0].TransportSocket.Name)
+
+	quicManager := &hcm.HttpConnectionManager{}
+	assert.NoError(t, quicLis.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(quicManager))
+	assert.Equal(t, hcm.HttpConnectionManager_HTTP3, quicManager.CodecType)
+
+	// the external listener is unchanged
+	assert.Equal(t, core.SocketAddress_TCP, lis.Address.GetSocketAddress().Protocol)
+	assert.Equal(t, "envoy.transport_sockets.tls", lis.FilterChains[0].TransportSocket.Name)
+}
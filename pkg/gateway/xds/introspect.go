@@ -0,0 +1,353 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ConfigDump is the readable form of resources in current snapshot, resources are indexed by name.
+type ConfigDump struct {
+	Versions  map[string]string          `json:"versions"`
+	Listeners map[string]json.RawMessage `json:"listeners,omitempty"`
+	Clusters  map[string]json.RawMessage `json:"clusters,omitempty"`
+	Routes    map[string]json.RawMessage `json:"routes,omitempty"`
+}
+
+// RouteRequest is a hypothetical request to match against a route configuration.
+type RouteRequest struct {
+	RouteConfig string            `json:"routeConfig"`
+	Host        string            `json:"host"`
+	Path        string            `json:"path"`
+	Method      string            `json:"method,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
+
+// RouteMatchResult tells which virtual host and route a request matches, and where it would be sent.
+type RouteMatchResult struct {
+	RouteConfig      string   `json:"routeConfig"`
+	VirtualHost      string   `json:"virtualHost"`
+	RouteIndex       int      `json:"routeIndex"`
+	RouteName        string   `json:"routeName,omitempty"`
+	Match            string   `json:"match"`
+	Cluster          string   `json:"cluster,omitempty"`
+	WeightedClusters []string `json:"weightedClusters,omitempty"`
+	ClusterHeader    string   `json:"clusterHeader,omitempty"`
+	HostRewrite      string   `json:"hostRewrite,omitempty"`
+	DirectResponse   uint32   `json:"directResponse,omitempty"`
+	Redirect         bool     `json:"redirect,omitempty"`
+}
+
+var dumpMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// DumpConfig returns the resources of types in current snapshot, all types are returned if types is empty. Types are
+// "listeners", "clusters" and "routes".
+func DumpConfig(resourceTypes ...string) (*ConfigDump, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	wanted := func(ty string) bool {
+		if len(resourceTypes) == 0 {
+			return true
+		}
+		for _, t := range resourceTypes {
+			if t == ty {
+				return true
+			}
+		}
+		return false
+	}
+
+	dump := &ConfigDump{Versions: map[string]string{}}
+	for _, rt := range []struct {
+		name string
+		ty   types.ResponseType
+		out  *map[string]json.RawMessage
+	}{
+		{"listeners", types.Listener, &dump.Listeners},
+		{"clusters", types.Cluster, &dump.Clusters},
+		{"routes", types.Route, &dump.Routes},
+	} {
+		if !wanted(rt.name) {
+			continue
+		}
+		dump.Versions[rt.name] = snapshot.Resources[rt.ty].Version
+		*rt.out = map[string]json.RawMessage{}
+		for name, item := range snapshot.Resources[rt.ty].Items {
+			data, err := MarshalResource(item.Resource)
+			if err != nil {
+				return nil, fmt.Errorf("marshal %s %s failed, %v", rt.name, name, err)
+			}
+			(*rt.out)[name] = data
+		}
+	}
+	return dump, nil
+}
+
+// MarshalResource returns the readable form of a xds resource.
+func MarshalResource(res proto.Message) (json.RawMessage, error) {
+	data, err := dumpMarshaler.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
+
+// MatchRoute returns the route matched by req in the route configuration of current snapshot. It follows the matching
+// order of envoy, virtual host is selected by host, then the first route matching path, method and headers wins.
+func MatchRoute(req *RouteRequest) (*RouteMatchResult, error) {
+	lock.Lock()
+	rs, ok := snapshot.Resources[types.Route].Items[req.RouteConfig]
+	var rc *route.RouteConfiguration
+	if ok {
+		rc, ok = proto.Clone(rs.Resource).(*route.RouteConfiguration)
+	}
+	lock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown route config name: %s", req.RouteConfig)
+	}
+	return MatchRouteConfig(rc, req)
+}
+
+// MatchRouteConfig returns the route matched by req in rc, req.RouteConfig is ignored.
+func MatchRouteConfig(rc *route.RouteConfiguration, req *RouteRequest) (*RouteMatchResult, error) {
+	host := strings.ToLower(req.Host)
+	if h, _, found := strings.Cut(host, ":"); found {
+		host = h
+	}
+	vh := selectVirtualHost(rc.VirtualHosts, host)
+	if vh == nil {
+		return nil, fmt.Errorf("no virtual host in route config %s matches host %s", rc.Name, req.Host)
+	}
+
+	headers := map[string]string{":authority": req.Host, ":path": req.Path}
+	if req.Method != "" {
+		headers[":method"] = strings.ToUpper(req.Method)
+	}
+	for k, v := range req.Headers {
+		headers[strings.ToLower(k)] = v
+	}
+	path := req.Path
+	if idx := strings.IndexAny(path, "?#"); idx >= 0 {
+		path = path[:idx]
+	}
+
+	for i, r := range vh.Routes {
+		desc, matched, err := matchRoute(r.Match, path, headers)
+		if err != nil {
+			return nil, fmt.Errorf("route %d of virtual host %s is invalid, %v", i, vh.Name, err)
+		}
+		if !matched {
+			continue
+		}
+		result := &RouteMatchResult{
+			RouteConfig: rc.Name,
+			VirtualHost: vh.Name,
+			RouteIndex:  i,
+			RouteName:   r.Name,
+			Match:       desc,
+		}
+		switch action := r.Action.(type) {
+		case *route.Route_Route:
+			fillRouteAction(result, action.Route)
+		case *route.Route_DirectResponse:
+			result.DirectResponse = action.DirectResponse.Status
+		case *route.Route_Redirect:
+			result.Redirect = true
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("no route of virtual host %s matches path %s", vh.Name, req.Path)
+}
+
+func fillRouteAction(result *RouteMatchResult, action *route.RouteAction) {
+	switch spec := action.ClusterSpecifier.(type) {
+	case *route.RouteAction_Cluster:
+		result.Cluster = spec.Cluster
+	case *route.RouteAction_ClusterHeader:
+		result.ClusterHeader = spec.ClusterHeader
+	case *route.RouteAction_WeightedClusters:
+		for _, c := range spec.WeightedClusters.Clusters {
+			result.WeightedClusters = append(result.WeightedClusters, fmt.Sprintf("%s:%d", c.Name, c.Weight.GetValue()))
+		}
+	}
+	if rewrite, ok := action.HostRewriteSpecifier.(*route.RouteAction_HostRewriteLiteral); ok {
+		result.HostRewrite = rewrite.HostRewriteLiteral
+	}
+}
+
+// selectVirtualHost selects virtual host in the order of envoy: exact domain, longest suffix wildcard, longest prefix
+// wildcard, then "*".
+func selectVirtualHost(vhs []*route.VirtualHost, host string) *route.VirtualHost {
+	var suffix, prefix, any *route.VirtualHost
+	suffixLen, prefixLen := -1, -1
+	for _, vh := range vhs {
+		for _, domain := range vh.Domains {
+			domain = strings.ToLower(domain)
+			switch {
+			case domain == "*":
+				if any == nil {
+					any = vh
+				}
+			case strings.HasPrefix(domain, "*"):
+				if strings.HasSuffix(host, domain[1:]) && len(host) > len(domain)-1 && len(domain) > suffixLen {
+					suffix, suffixLen = vh, len(domain)
+				}
+			case strings.HasSuffix(domain, "*"):
+				if strings.HasPrefix(host, domain[:len(domain)-1]) && len(host) > len(domain)-1 && len(domain) > prefixLen {
+					prefix, prefixLen = vh, len(domain)
+				}
+			case domain == host:
+				return vh
+			}
+		}
+	}
+	for _, vh := range []*route.VirtualHost{suffix, prefix, any} {
+		if vh != nil {
+			return vh
+		}
+	}
+	return nil
+}
+
+// matchRoute returns the description of match and whether it's matched.
+func matchRoute(m *route.RouteMatch, path string, headers map[string]string) (string, bool, error) {
+	caseSensitive := m.CaseSensitive == nil || m.CaseSensitive.Value
+	var desc string
+	var matched bool
+	switch spec := m.PathSpecifier.(type) {
+	case *route.RouteMatch_Prefix:
+		desc = "prefix " + spec.Prefix
+		matched = hasPrefix(path, spec.Prefix, caseSensitive)
+	case *route.RouteMatch_Path:
+		desc = "path " + spec.Path
+		matched = path == spec.Path || (!caseSensitive && strings.EqualFold(path, spec.Path))
+	case *route.RouteMatch_PathSeparatedPrefix:
+		desc = "path_separated_prefix " + spec.PathSeparatedPrefix
+		matched = hasPrefix(path, spec.PathSeparatedPrefix, caseSensitive) &&
+			(len(path) == len(spec.PathSeparatedPrefix) || path[len(spec.PathSeparatedPrefix)] == '/')
+	case *route.RouteMatch_SafeRegex:
+		desc = "safe_regex " + spec.SafeRegex.GetRegex()
+		var err error
+		if matched, err = matchRegex(spec.SafeRegex.GetRegex(), path); err != nil {
+			return desc, false, err
+		}
+	case *route.RouteMatch_ConnectMatcher_:
+		desc = "connect"
+		matched = headers[":method"] == "CONNECT"
+	default:
+		return "", false, fmt.Errorf("path specifier %T isn't supported", spec)
+	}
+	if !matched {
+		return desc, false, nil
+	}
+
+	for _, hm := range m.Headers {
+		ok, err := matchHeader(hm, headers)
+		if err != nil {
+			return desc, false, err
+		}
+		if !ok {
+			return desc, false, nil
+		}
+		desc += ", header " + hm.Name
+	}
+	return desc, true, nil
+}
+
+func hasPrefix(s, prefix string, caseSensitive bool) bool {
+	if caseSensitive {
+		return strings.HasPrefix(s, prefix)
+	}
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func matchHeader(hm *route.HeaderMatcher, headers map[string]string) (bool, error) {
+	value, present := headers[strings.ToLower(hm.Name)]
+	var matched bool
+	switch spec := hm.HeaderMatchSpecifier.(type) {
+	case nil:
+		matched = present
+	case *route.HeaderMatcher_PresentMatch:
+		matched = present == spec.PresentMatch
+	case *route.HeaderMatcher_ExactMatch:
+		matched = present && value == spec.ExactMatch
+	case *route.HeaderMatcher_PrefixMatch:
+		matched = present && strings.HasPrefix(value, spec.PrefixMatch)
+	case *route.HeaderMatcher_SuffixMatch:
+		matched = present && strings.HasSuffix(value, spec.SuffixMatch)
+	case *route.HeaderMatcher_ContainsMatch:
+		matched = present && strings.Contains(value, spec.ContainsMatch)
+	case *route.HeaderMatcher_SafeRegexMatch:
+		ok, err := matchRegex(spec.SafeRegexMatch.GetRegex(), value)
+		if err != nil {
+			return false, err
+		}
+		matched = present && ok
+	case *route.HeaderMatcher_StringMatch:
+		ok, err := matchString(spec.StringMatch, value)
+		if err != nil {
+			return false, err
+		}
+		matched = present && ok
+	default:
+		return false, fmt.Errorf("header matcher %T isn't supported", spec)
+	}
+	return matched != hm.InvertMatch, nil
+}
+
+func matchString(m *matcherv3.StringMatcher, value string) (bool, error) {
+	if m.IgnoreCase {
+		value = strings.ToLower(value)
+	}
+	lower := func(s string) string {
+		if m.IgnoreCase {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+	switch spec := m.MatchPattern.(type) {
+	case *matcherv3.StringMatcher_Exact:
+		return value == lower(spec.Exact), nil
+	case *matcherv3.StringMatcher_Prefix:
+		return strings.HasPrefix(value, lower(spec.Prefix)), nil
+	case *matcherv3.StringMatcher_Suffix:
+		return strings.HasSuffix(value, lower(spec.Suffix)), nil
+	case *matcherv3.StringMatcher_Contains:
+		return strings.Contains(value, lower(spec.Contains)), nil
+	case *matcherv3.StringMatcher_SafeRegex:
+		return matchRegex(spec.SafeRegex.GetRegex(), value)
+	default:
+		return false, fmt.Errorf("string matcher %T isn't supported", spec)
+	}
+}
+
+// matchRegex matches the whole value like RE2 matcher of envoy.
+func matchRegex(pattern, value string) (bool, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(value), nil
+}
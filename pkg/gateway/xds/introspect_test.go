@@ -0,0 +1,128 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"testing"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func clusterRoute(name string, match *route.RouteMatch, cluster string) *route.Route {
+	return &route.Route{
+		Name:  name,
+		Match: match,
+		Action: &route.Route_Route{Route: &route.RouteAction{
+			ClusterSpecifier: &route.RouteAction_Cluster{Cluster: cluster},
+		}},
+	}
+}
+
+func TestMatchRouteConfig(t *testing.T) {
+	rc := &route.RouteConfiguration{
+		Name: InternalRoute,
+		VirtualHosts: []*route.VirtualHost{
+			{
+				Name:    "alice-to-bob",
+				Domains: []string{"*.bob.svc"},
+				Routes: []*route.Route{
+					clusterRoute("grpc", &route.RouteMatch{
+						PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"},
+						Headers: []*route.HeaderMatcher{{
+							Name: "content-type",
+							HeaderMatchSpecifier: &route.HeaderMatcher_StringMatch{StringMatch: &matcherv3.StringMatcher{
+								MatchPattern: &matcherv3.StringMatcher_Prefix{Prefix: "application/grpc"},
+							}},
+						}},
+					}, "alice-to-bob-grpc"),
+					clusterRoute("default", &route.RouteMatch{
+						PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"},
+					}, "alice-to-bob-http"),
+				},
+			},
+			{
+				Name:    "kuscia-handshake",
+				Domains: []string{"kuscia-handshake.alice.svc"},
+				Routes: []*route.Route{
+					clusterRoute("", &route.RouteMatch{
+						PathSpecifier: &route.RouteMatch_SafeRegex{SafeRegex: &matcherv3.RegexMatcher{Regex: "/handshake/.*"}},
+					}, "handshake-cluster"),
+				},
+			},
+			{
+				Name:    "default",
+				Domains: []string{"*"},
+				Routes: []*route.Route{{
+					Match:  &route.RouteMatch{PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"}},
+					Action: &route.Route_DirectResponse{DirectResponse: &route.DirectResponseAction{Status: 404}},
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		req         *RouteRequest
+		virtualHost string
+		cluster     string
+		direct      uint32
+		wantErr     bool
+	}{
+		{
+			name:        "suffix wildcard with header",
+			req:         &RouteRequest{Host: "fate.bob.svc:8080", Path: "/v1", Headers: map[string]string{"Content-Type": "application/grpc+proto"}},
+			virtualHost: "alice-to-bob",
+			cluster:     "alice-to-bob-grpc",
+		},
+		{
+			name:        "suffix wildcard fallback route",
+			req:         &RouteRequest{Host: "fate.bob.svc", Path: "/v1"},
+			virtualHost: "alice-to-bob",
+			cluster:     "alice-to-bob-http",
+		},
+		{
+			name:        "exact domain with regex path",
+			req:         &RouteRequest{Host: "kuscia-handshake.alice.svc", Path: "/handshake/token?x=1"},
+			virtualHost: "kuscia-handshake",
+			cluster:     "handshake-cluster",
+		},
+		{
+			name:    "regex path not matched",
+			req:     &RouteRequest{Host: "kuscia-handshake.alice.svc", Path: "/register"},
+			wantErr: true,
+		},
+		{
+			name:        "any domain",
+			req:         &RouteRequest{Host: "unknown.carol.svc", Path: "/"},
+			virtualHost: "default",
+			direct:      404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MatchRouteConfig(rc, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.virtualHost, result.VirtualHost)
+			assert.Equal(t, tt.cluster, result.Cluster)
+			assert.Equal(t, tt.direct, result.DirectResponse)
+		})
+	}
+}
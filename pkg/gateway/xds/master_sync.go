@@ -0,0 +1,188 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"fmt"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	gzipcompressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/compressor/v3"
+	gzipdecompressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/gzip/decompressor/v3"
+	zstdcompressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/zstd/compressor/v3"
+	zstddecompressor "github.com/envoyproxy/go-control-plane/envoy/extensions/compression/zstd/decompressor/v3"
+	bandwidth_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/bandwidth_limit/v3"
+	compressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/compressor/v3"
+	decompressorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/decompressor/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
+)
+
+// DefaultWatchIdleTimeout is longer than the maximum watch timeout of informers (10 minutes),
+// so that watch streams to master end normally instead of being reset, which makes informers re-list resources.
+const DefaultWatchIdleTimeout = 15 * time.Minute
+
+// minCompressLength is the minimum response length to compress, small responses don't benefit from compression.
+const minCompressLength = 1024
+
+// compressContentTypes are the content types of kubernetes apiserver responses.
+var compressContentTypes = []string{
+	"application/json",
+	"application/yaml",
+	"application/vnd.kubernetes.protobuf",
+}
+
+// MasterSync is the config of syncing resources from master on lite nodes, it's set before the gateway starts.
+var MasterSync kusciaconfig.MasterSyncConfig
+
+// MasterWatchIdleTimeout returns the idle timeout of streams between lite and master.
+func MasterWatchIdleTimeout() time.Duration {
+	if MasterSync.WatchIdleTimeout > 0 {
+		return MasterSync.WatchIdleTimeout
+	}
+	return DefaultWatchIdleTimeout
+}
+
+// DecorateMasterSyncRoute applies the watch idle timeout and the bandwidth budget of master sync to a route to master.
+func DecorateMasterSyncRoute(r *route.Route) error {
+	if action := r.GetRoute(); action != nil {
+		action.IdleTimeout = durationpb.New(MasterWatchIdleTimeout())
+	}
+
+	if MasterSync.BandwidthLimitKbps <= 0 {
+		return nil
+	}
+
+	bandwidthConfig, err := anypb.New(&bandwidth_limitv3.BandwidthLimit{
+		StatPrefix:   "kuscia_master_sync_bandwidth_limit",
+		FillInterval: &durationpb.Duration{Nanos: 1e8}, // 0.1s
+		EnableMode:   bandwidth_limitv3.BandwidthLimit_REQUEST_AND_RESPONSE,
+		LimitKbps:    &wrapperspb.UInt64Value{Value: uint64(MasterSync.BandwidthLimitKbps)},
+	})
+	if err != nil {
+		return err
+	}
+	if r.TypedPerFilterConfig == nil {
+		r.TypedPerFilterConfig = map[string]*anypb.Any{}
+	}
+	r.TypedPerFilterConfig[BandwidthLimitName] = bandwidthConfig
+	return nil
+}
+
+// SetMasterSyncFilters sets the filters of the internal listener on lite nodes, which request compressed
+// responses from master and decompress them, and enforce the bandwidth budget of master sync.
+func SetMasterSyncFilters() error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if MasterSync.Compression != "" {
+		decompressor, err := buildDecompressor(MasterSync.Compression)
+		if err != nil {
+			return err
+		}
+		internalFilterMap[DecompressorFilterName] = decompressor
+	} else {
+		delete(internalFilterMap, DecompressorFilterName)
+	}
+
+	if MasterSync.BandwidthLimitKbps > 0 {
+		if _, ok := internalFilterMap[BandwidthLimitName]; !ok {
+			internalFilterMap[BandwidthLimitName] = &bandwidth_limitv3.BandwidthLimit{
+				StatPrefix: "kuscia_bandwidth_limit",
+			}
+		}
+	}
+	return updateHTTPFilters(internalFilterMap, InternalListener)
+}
+
+// SetMasterSyncCompressors sets the filters of the external listener on master, which compress
+// responses to lite nodes when they are requested with gzip or zstd encoding.
+func SetMasterSyncCompressors() error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	gzip, err := buildCompressor(CompressorLibraryName(kusciaconfig.CompressionGzip), &gzipcompressor.Gzip{})
+	if err != nil {
+		return err
+	}
+	zstd, err := buildCompressor(CompressorLibraryName(kusciaconfig.CompressionZstd), &zstdcompressor.Zstd{})
+	if err != nil {
+		return err
+	}
+	externalFilterMap[GzipCompressorFilterName] = gzip
+	externalFilterMap[ZstdCompressorFilterName] = zstd
+	return updateHTTPFilters(externalFilterMap, ExternalListener)
+}
+
+// CompressorLibraryName returns the envoy extension name of the compression library.
+func CompressorLibraryName(encoding string) string {
+	return fmt.Sprintf("envoy.compression.%s", encoding)
+}
+
+func buildCompressor(name string, library proto.Message) (*compressorv3.Compressor, error) {
+	typedConfig, err := anypb.New(library)
+	if err != nil {
+		return nil, err
+	}
+	return &compressorv3.Compressor{
+		CompressorLibrary: &core.TypedExtensionConfig{
+			Name:        name,
+			TypedConfig: typedConfig,
+		},
+		ResponseDirectionConfig: &compressorv3.Compressor_ResponseDirectionConfig{
+			CommonConfig: &compressorv3.Compressor_CommonDirectionConfig{
+				MinContentLength: wrapperspb.UInt32(minCompressLength),
+				ContentType:      compressContentTypes,
+			},
+		},
+	}, nil
+}
+
+func buildDecompressor(encoding string) (*decompressorv3.Decompressor, error) {
+	var library proto.Message
+	switch encoding {
+	case kusciaconfig.CompressionGzip:
+		library = &gzipdecompressor.Gzip{}
+	case kusciaconfig.CompressionZstd:
+		library = &zstddecompressor.Zstd{}
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", encoding)
+	}
+
+	typedConfig, err := anypb.New(library)
+	if err != nil {
+		return nil, err
+	}
+	return &decompressorv3.Decompressor{
+		DecompressorLibrary: &core.TypedExtensionConfig{
+			Name:        CompressorLibraryName(encoding),
+			TypedConfig: typedConfig,
+		},
+		// only responses from master are compressed, requests are sent as they are.
+		RequestDirectionConfig: &decompressorv3.Decompressor_RequestDirectionConfig{
+			CommonConfig: &decompressorv3.Decompressor_CommonDirectionConfig{
+				Enabled: &core.RuntimeFeatureFlag{
+					DefaultValue: wrapperspb.Bool(false),
+					RuntimeKey:   "kuscia.master_sync.request_decompression",
+				},
+			},
+		},
+	}, nil
+}
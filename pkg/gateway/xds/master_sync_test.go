@@ -0,0 +1,63 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"testing"
+	"time"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	bandwidth_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/bandwidth_limit/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
+)
+
+func TestDecorateMasterSyncRoute(t *testing.T) {
+	defer func() { MasterSync = kusciaconfig.MasterSyncConfig{} }()
+
+	newRoute := func() *route.Route {
+		return &route.Route{Action: &route.Route_Route{Route: AddDefaultTimeout(&route.RouteAction{})}}
+	}
+
+	r := newRoute()
+	assert.NoError(t, DecorateMasterSyncRoute(r))
+	assert.Equal(t, DefaultWatchIdleTimeout, r.GetRoute().IdleTimeout.AsDuration())
+	assert.Nil(t, r.TypedPerFilterConfig)
+
+	MasterSync = kusciaconfig.MasterSyncConfig{BandwidthLimitKbps: 512, WatchIdleTimeout: 20 * time.Minute}
+	r = newRoute()
+	assert.NoError(t, DecorateMasterSyncRoute(r))
+	assert.Equal(t, 20*time.Minute, r.GetRoute().IdleTimeout.AsDuration())
+	var limit bandwidth_limitv3.BandwidthLimit
+	assert.NoError(t, r.TypedPerFilterConfig[BandwidthLimitName].UnmarshalTo(&limit))
+	assert.Equal(t, uint64(512), limit.LimitKbps.Value)
+}
+
+func TestBuildDecompressor(t *testing.T) {
+	d, err := buildDecompressor(kusciaconfig.CompressionZstd)
+	assert.NoError(t, err)
+	assert.Equal(t, "envoy.compression.zstd", d.DecompressorLibrary.Name)
+	assert.False(t, d.RequestDirectionConfig.CommonConfig.Enabled.DefaultValue.Value)
+
+	_, err = buildDecompressor("br")
+	assert.Error(t, err)
+}
+
+func TestSortFiltersWithCompression(t *testing.T) {
+	// responses pass filters in reverse order, the bandwidth limit must see compressed data.
+	assert.Less(t, internalFilterPriority[DecompressorFilterName], internalFilterPriority[BandwidthLimitName])
+	assert.Less(t, externalFilterPriority[ZstdCompressorFilterName], externalFilterPriority[RouterName])
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"fmt"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// RequestTimeoutHeader carries the deadline of a request set by the application, e.g. 30s, 1500ms or 2m. A
+	// number without unit is in milliseconds.
+	RequestTimeoutHeader = "X-Kuscia-Timeout"
+	// RequestTimeoutFilterName is the lua filter applying RequestTimeoutHeader, it has its own name so the scripts
+	// of header policies attached to routes don't override it.
+	RequestTimeoutFilterName = "kuscia.filters.http.request_timeout"
+
+	// DefaultMaxRequestTimeout is the max deadline of requests if the gateway doesn't configure one.
+	DefaultMaxRequestTimeout = time.Hour
+)
+
+// MaxRequestTimeout is the max deadline of requests of all routes, larger deadlines in RequestTimeoutHeader are
+// clamped to it.
+var MaxRequestTimeout = DefaultMaxRequestTimeout
+
+// requestTimeoutLib validates the header and sets the timeout of envoy router by x-envoy-upstream-rq-timeout-ms.
+// The header is rewritten with the clamped deadline and sent to the peer, whose gateway applies it again.
+const requestTimeoutLib = `
+local units = {[""] = 1, ms = 1, s = 1000, m = 60000, h = 3600000}
+
+local function parseTimeout(value)
+  local num, unit = string.match(value, "^%s*(%d+)(%a*)%s*$")
+  if num == nil or units[unit] == nil then
+    return nil
+  end
+  local ms = tonumber(num) * units[unit]
+  if ms <= 0 then
+    return nil
+  end
+  return ms
+end
+
+function envoy_on_request(handle)
+  local headers = handle:headers()
+  local value = headers:get("x-kuscia-timeout")
+  if value == nil then
+    return
+  end
+  local ms = parseTimeout(value)
+  if ms == nil then
+    handle:respond({[":status"] = "400", ["content-type"] = "text/plain"}, "invalid X-Kuscia-Timeout header: " .. value)
+    return
+  end
+  if ms > maxTimeoutMs then
+    ms = maxTimeoutMs
+  end
+  headers:replace("x-kuscia-timeout", string.format("%dms", ms))
+  headers:replace("x-envoy-upstream-rq-timeout-ms", string.format("%d", ms))
+end
+`
+
+// RequestTimeoutScript returns the lua script which clamps deadlines in RequestTimeoutHeader to maxTimeout, zero
+// maxTimeout means MaxRequestTimeout.
+func RequestTimeoutScript(maxTimeout time.Duration) string {
+	if maxTimeout <= 0 || maxTimeout > MaxRequestTimeout {
+		maxTimeout = MaxRequestTimeout
+	}
+	return fmt.Sprintf("local maxTimeoutMs = %d\n%s", maxTimeout.Milliseconds(), requestTimeoutLib)
+}
+
+// DecorateRequestTimeoutRoute clamps deadlines of requests matching the route to maxTimeout, which can't exceed
+// MaxRequestTimeout.
+func DecorateRequestTimeoutRoute(httpRoute *route.Route, maxTimeout time.Duration) error {
+	perRoute, err := anypb.New(&lua.LuaPerRoute{
+		Override: &lua.LuaPerRoute_SourceCode{
+			SourceCode: &core.DataSource{
+				Specifier: &core.DataSource_InlineString{InlineString: RequestTimeoutScript(maxTimeout)},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if httpRoute.TypedPerFilterConfig == nil {
+		httpRoute.TypedPerFilterConfig = map[string]*anypb.Any{}
+	}
+	httpRoute.TypedPerFilterConfig[RequestTimeoutFilterName] = perRoute
+	return nil
+}
+
+// setListenerRequestTimeout installs the request timeout filter right before the router of lis. Both internal and
+// external listeners apply the header, so requests from applications and from peers are bounded by the deadline.
+func setListenerRequestTimeout(lis *listener.Listener) error {
+	if len(lis.FilterChains) == 0 || len(lis.FilterChains[0].Filters) == 0 {
+		return nil
+	}
+	filter := lis.FilterChains[0].Filters[0]
+	var httpManager hcm.HttpConnectionManager
+	if !filter.GetTypedConfig().MessageIs(&httpManager) {
+		return nil
+	}
+	if err := filter.GetTypedConfig().UnmarshalTo(&httpManager); err != nil {
+		return fmt.Errorf("unmarshal hcm of %s failed with %s", lis.Name, err.Error())
+	}
+
+	luaConfig, err := anypb.New(&lua.Lua{
+		DefaultSourceCode: &core.DataSource{
+			Specifier: &core.DataSource_InlineString{InlineString: RequestTimeoutScript(0)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	timeoutFilter := &hcm.HttpFilter{
+		Name:       RequestTimeoutFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: luaConfig},
+	}
+	filters := make([]*hcm.HttpFilter, 0, len(httpManager.HttpFilters)+1)
+	for _, f := range httpManager.HttpFilters {
+		if f.Name == RequestTimeoutFilterName {
+			continue
+		}
+		if f.Name == RouterName {
+			filters = append(filters, timeoutFilter)
+		}
+		filters = append(filters, f)
+	}
+	httpManager.HttpFilters = filters
+
+	hcmConfig, err := anypb.New(&httpManager)
+	if err != nil {
+		return fmt.Errorf("marshal http connection manager failed with %s", err.Error())
+	}
+	filter.ConfigType = &listener.Filter_TypedConfig{TypedConfig: hcmConfig}
+	return nil
+}
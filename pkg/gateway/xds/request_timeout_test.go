@@ -0,0 +1,83 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	router "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestRequestTimeoutScript(t *testing.T) {
+	assert.True(t, strings.HasPrefix(RequestTimeoutScript(0), "local maxTimeoutMs = 3600000\n"))
+	assert.True(t, strings.HasPrefix(RequestTimeoutScript(30*time.Second), "local maxTimeoutMs = 30000\n"))
+	// route maximums can't exceed the one of gateway
+	assert.True(t, strings.HasPrefix(RequestTimeoutScript(2*time.Hour), "local maxTimeoutMs = 3600000\n"))
+	assert.Contains(t, RequestTimeoutScript(0), `headers:replace("x-envoy-upstream-rq-timeout-ms"`)
+}
+
+func TestDecorateRequestTimeoutRoute(t *testing.T) {
+	r := &route.Route{}
+	assert.NoError(t, DecorateRequestTimeoutRoute(r, time.Minute))
+	perRoute := &lua.LuaPerRoute{}
+	assert.NoError(t, r.TypedPerFilterConfig[RequestTimeoutFilterName].UnmarshalTo(perRoute))
+	assert.Equal(t, RequestTimeoutScript(time.Minute), perRoute.GetSourceCode().GetInlineString())
+}
+
+func TestSetListenerRequestTimeout(t *testing.T) {
+	routerConfig, err := anypb.New(&router.Router{})
+	assert.NoError(t, err)
+	hcmConfig, err := anypb.New(&hcm.HttpConnectionManager{
+		StatPrefix: "external",
+		HttpFilters: []*hcm.HttpFilter{
+			{Name: KusciaGressName},
+			{Name: RouterName, ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerConfig}},
+		},
+	})
+	assert.NoError(t, err)
+	lis := &listener.Listener{
+		Name: ExternalListener,
+		FilterChains: []*listener.FilterChain{{
+			Filters: []*listener.Filter{{
+				Name:       "envoy.filters.network.http_connection_manager",
+				ConfigType: &listener.Filter_TypedConfig{TypedConfig: hcmConfig},
+			}},
+		}},
+	}
+
+	// it's applied twice to make sure the filter isn't duplicated
+	assert.NoError(t, setListenerRequestTimeout(lis))
+	assert.NoError(t, setListenerRequestTimeout(lis))
+
+	httpManager := &hcm.HttpConnectionManager{}
+	assert.NoError(t, lis.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(httpManager))
+	assert.Len(t, httpManager.HttpFilters, 3)
+	assert.Equal(t, RequestTimeoutFilterName, httpManager.HttpFilters[1].Name)
+	assert.Equal(t, RouterName, httpManager.HttpFilters[2].Name)
+	luaConfig := &lua.Lua{}
+	assert.NoError(t, httpManager.HttpFilters[1].GetTypedConfig().UnmarshalTo(luaConfig))
+	assert.Equal(t, RequestTimeoutScript(0), luaConfig.GetDefaultSourceCode().GetInlineString())
+
+	sorted := sortExternalFilters(httpManager.HttpFilters)
+	assert.Equal(t, RequestTimeoutFilterName, sorted[len(sorted)-2].Name)
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"fmt"
+	"sort"
+
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	tlsinspector "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const tlsInspectorName = "envoy.filters.listener.tls_inspector"
+
+// ServerCert is presented by the external listener to connections whose SNI is one of ServerNames.
+type ServerCert struct {
+	ServerNames []string
+	Cert        *TLSCert
+}
+
+// serverCerts are the certs of filter chains selected by SNI, the default filter chain serves the other connections.
+var serverCerts = map[string]*ServerCert{}
+
+// SetServerCert adds or updates the filter chain of external listener serving cert named name, the chain is removed if
+// cert is nil. A server name can only be used by one cert.
+func SetServerCert(name string, cert *ServerCert) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if cert == nil {
+		if _, ok := serverCerts[name]; !ok {
+			return nil
+		}
+		delete(serverCerts, name)
+		return updateServerCertChains()
+	}
+
+	if len(cert.ServerNames) == 0 {
+		return fmt.Errorf("server names of cert %s are empty", name)
+	}
+	if _, err := GenerateDownstreamTLSConfigByCert(cert.Cert); err != nil {
+		return err
+	}
+	for other, c := range serverCerts {
+		if other == name {
+			continue
+		}
+		for _, sn := range c.ServerNames {
+			for _, n := range cert.ServerNames {
+				if sn == n {
+					return fmt.Errorf("server name %s of cert %s is used by %s", n, name, other)
+				}
+			}
+		}
+	}
+	if old, ok := serverCerts[name]; ok && equalServerCert(old, cert) {
+		return nil
+	}
+	serverCerts[name] = cert
+	return updateServerCertChains()
+}
+
+// SetExternalCert replaces the cert of the default filter chain of external listener, e.g. when the cert is rotated.
+// The filter chains of server certs aren't changed.
+func SetExternalCert(cert *TLSCert) error {
+	transportSocket, err := GenerateDownstreamTLSConfigByCert(cert)
+	if err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if snapshot == nil {
+		return fmt.Errorf("xds snapshot is not initialized")
+	}
+	listeners := snapshot.Resources[types.Listener].Items
+	external, ok := listeners[ExternalListener]
+	if !ok {
+		return fmt.Errorf("unknown listener name: %s", ExternalListener)
+	}
+	if external.Resource.(*listener.Listener).FilterChains[0].TransportSocket == nil {
+		return fmt.Errorf("tls of %s is disabled", ExternalListener)
+	}
+	items := make(map[string]types.ResourceWithTTL)
+	for k, v := range listeners {
+		items[k] = v
+	}
+	lis := proto.Clone(external.Resource).(*listener.Listener)
+	lis.FilterChains[0].TransportSocket = transportSocket
+	items[ExternalListener] = types.ResourceWithTTL{Resource: lis}
+	return resetSnapshot(types.Listener, items)
+}
+
+func equalServerCert(a, b *ServerCert) bool {
+	if len(a.ServerNames) != len(b.ServerNames) || *a.Cert != *b.Cert {
+		return false
+	}
+	for i := range a.ServerNames {
+		if a.ServerNames[i] != b.ServerNames[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func updateServerCertChains() error {
+	listeners := snapshot.Resources[types.Listener].Items
+	if _, ok := listeners[ExternalListener]; !ok {
+		return fmt.Errorf("unknown listener name: %s", ExternalListener)
+	}
+	items := make(map[string]types.ResourceWithTTL)
+	for k, v := range listeners {
+		items[k] = v
+	}
+	lis := proto.Clone(listeners[ExternalListener].Resource).(*listener.Listener)
+	if err := decorateServerCertChains(lis); err != nil {
+		return err
+	}
+	items[ExternalListener] = types.ResourceWithTTL{Resource: lis}
+	return resetSnapshot(types.Listener, items)
+}
+
+// decorateServerCertChains rebuilds the filter chains of server certs from the default filter chain, the tls inspector
+// is added to read SNI of connections.
+func decorateServerCertChains(lis *listener.Listener) error {
+	lis.FilterChains = lis.FilterChains[:1]
+	if len(serverCerts) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(serverCerts))
+	for name := range serverCerts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cert := serverCerts[name]
+		transportSocket, err := GenerateDownstreamTLSConfigByCert(cert.Cert)
+		if err != nil {
+			return fmt.Errorf("generate tls config of cert %s failed with %v", name, err)
+		}
+		chain := proto.Clone(lis.FilterChains[0]).(*listener.FilterChain)
+		chain.Name = fmt.Sprintf("server-cert-%s", name)
+		chain.FilterChainMatch = &listener.FilterChainMatch{ServerNames: cert.ServerNames}
+		chain.TransportSocket = transportSocket
+		lis.FilterChains = append(lis.FilterChains, chain)
+	}
+
+	for _, f := range lis.ListenerFilters {
+		if f.Name == tlsInspectorName {
+			return nil
+		}
+	}
+	conf, err := anypb.New(&tlsinspector.TlsInspector{})
+	if err != nil {
+		return err
+	}
+	lis.ListenerFilters = append(lis.ListenerFilters, &listener.ListenerFilter{
+		Name:       tlsInspectorName,
+		ConfigType: &listener.ListenerFilter_TypedConfig{TypedConfig: conf},
+	})
+	return nil
+}
+
+// SetUpstreamServerName sets the SNI of tls connections of cluster, clusters without tls are not changed.
+func SetUpstreamServerName(cluster *envoycluster.Cluster, serverName string) error {
+	if cluster.TransportSocket == nil || serverName == "" {
+		return nil
+	}
+	tlsContext := &tls.UpstreamTlsContext{}
+	if err := cluster.TransportSocket.GetTypedConfig().UnmarshalTo(tlsContext); err != nil {
+		return fmt.Errorf("unmarshal UpstreamTlsContext of cluster %s failed with %v", cluster.Name, err)
+	}
+	tlsContext.Sni = serverName
+	conf, err := anypb.New(tlsContext)
+	if err != nil {
+		return err
+	}
+	cluster.TransportSocket = generateTransportSocket(conf)
+	return nil
+}
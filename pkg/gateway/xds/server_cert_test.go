@@ -0,0 +1,35 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"testing"
+
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetUpstreamServerName(t *testing.T) {
+	cluster := &envoycluster.Cluster{Name: "alice-to-bob-http"}
+	assert.NoError(t, SetUpstreamServerName(cluster, "bob.example.com"))
+	assert.Nil(t, cluster.TransportSocket)
+
+	assert.NoError(t, DecorateClusterTransport(cluster, ProtocolHTTPS))
+	assert.NoError(t, SetUpstreamServerName(cluster, "bob.example.com"))
+	tlsContext := &tls.UpstreamTlsContext{}
+	assert.NoError(t, cluster.TransportSocket.GetTypedConfig().UnmarshalTo(tlsContext))
+	assert.Equal(t, "bob.example.com", tlsContext.Sni)
+}
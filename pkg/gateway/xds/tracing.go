@@ -0,0 +1,144 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	envoycluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	tracev3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoytype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
+)
+
+const (
+	// TracingCollectorCluster is the OTLP collector which spans of envoy are exported to.
+	TracingCollectorCluster = "tracing-collector"
+
+	openTelemetryTracer = "envoy.tracers.opentelemetry"
+	tracingServiceName  = "kuscia-gateway"
+)
+
+// generateTracingCluster builds the cluster of the OTLP gRPC collector of conf.
+func generateTracingCluster(conf *tracing.Config) (*envoycluster.Cluster, error) {
+	host, portStr, err := net.SplitHostPort(conf.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint %q, %v", conf.Endpoint, err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port of tracing endpoint %q, %v", conf.Endpoint, err)
+	}
+
+	cluster := &envoycluster.Cluster{
+		Name: TracingCollectorCluster,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: TracingCollectorCluster,
+			Endpoints: []*endpoint.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*endpoint.LbEndpoint{
+						{
+							HostIdentifier: &endpoint.LbEndpoint_Endpoint{
+								Endpoint: &endpoint.Endpoint{
+									Address: &core.Address{
+										Address: &core.Address_SocketAddress{
+											SocketAddress: &core.SocketAddress{
+												Address: host,
+												PortSpecifier: &core.SocketAddress_PortValue{
+													PortValue: uint32(port),
+												},
+											},
+										},
+									},
+									Hostname: host,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := DecorateLocalUpstreamCluster(cluster, GenerateProtocol(!conf.Insecure, true)); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// setListenerTracing replaces the tracer of lis with the OpenTelemetry tracer. Envoy then continues the W3C trace
+// context of requests, propagates it to other domains and exports its spans to TracingCollectorCluster.
+func setListenerTracing(lis *listener.Listener, conf *tracing.Config) error {
+	if len(lis.FilterChains) == 0 || len(lis.FilterChains[0].Filters) == 0 {
+		return nil
+	}
+	filter := lis.FilterChains[0].Filters[0]
+	var httpManager hcm.HttpConnectionManager
+	if !filter.GetTypedConfig().MessageIs(&httpManager) {
+		return nil
+	}
+	if err := filter.GetTypedConfig().UnmarshalTo(&httpManager); err != nil {
+		return fmt.Errorf("unmarshal hcm of %s failed with %s", lis.Name, err.Error())
+	}
+
+	grpcService := &core.GrpcService{
+		TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+			EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: TracingCollectorCluster},
+		},
+	}
+	keys := make([]string, 0, len(conf.Headers))
+	for k := range conf.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		grpcService.InitialMetadata = append(grpcService.InitialMetadata, &core.HeaderValue{Key: k, Value: conf.Headers[k]})
+	}
+	tracerConfig, err := anypb.New(&tracev3.OpenTelemetryConfig{
+		GrpcService: grpcService,
+		ServiceName: tracingServiceName,
+	})
+	if err != nil {
+		return err
+	}
+
+	ratio := conf.SampleRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+	if httpManager.Tracing == nil {
+		httpManager.Tracing = &hcm.HttpConnectionManager_Tracing{}
+	}
+	httpManager.Tracing.RandomSampling = &envoytype.Percent{Value: ratio * 100}
+	httpManager.Tracing.Provider = &tracev3.Tracing_Http{
+		Name:       openTelemetryTracer,
+		ConfigType: &tracev3.Tracing_Http_TypedConfig{TypedConfig: tracerConfig},
+	}
+
+	hcmConfig, err := anypb.New(&httpManager)
+	if err != nil {
+		return fmt.Errorf("marshal http connection manager failed with %s", err.Error())
+	}
+	filter.ConfigType = &listener.Filter_TypedConfig{TypedConfig: hcmConfig}
+	return nil
+}
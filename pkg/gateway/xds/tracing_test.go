@@ -0,0 +1,71 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"testing"
+
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	tracev3 "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
+)
+
+func TestGenerateTracingCluster(t *testing.T) {
+	cluster, err := generateTracingCluster(&tracing.Config{Endpoint: "otel-collector:4317", Insecure: true})
+	assert.NoError(t, err)
+	assert.Equal(t, TracingCollectorCluster, cluster.Name)
+	assert.Nil(t, cluster.TransportSocket)
+	addr := cluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address.GetSocketAddress()
+	assert.Equal(t, "otel-collector", addr.Address)
+	assert.Equal(t, uint32(4317), addr.GetPortValue())
+
+	cluster, err = generateTracingCluster(&tracing.Config{Endpoint: "otel-collector:4317"})
+	assert.NoError(t, err)
+	assert.NotNil(t, cluster.TransportSocket)
+
+	_, err = generateTracingCluster(&tracing.Config{Endpoint: "otel-collector"})
+	assert.Error(t, err)
+}
+
+func TestSetListenerTracing(t *testing.T) {
+	hcmConfig, err := anypb.New(&hcm.HttpConnectionManager{StatPrefix: "internal"})
+	assert.NoError(t, err)
+	lis := &listener.Listener{
+		Name: InternalListener,
+		FilterChains: []*listener.FilterChain{{
+			Filters: []*listener.Filter{{
+				Name:       "envoy.filters.network.http_connection_manager",
+				ConfigType: &listener.Filter_TypedConfig{TypedConfig: hcmConfig},
+			}},
+		}},
+	}
+
+	conf := &tracing.Config{Endpoint: "otel-collector:4317", SampleRatio: 0.5, Headers: map[string]string{"token": "abc"}}
+	assert.NoError(t, setListenerTracing(lis, conf))
+
+	var httpManager hcm.HttpConnectionManager
+	assert.NoError(t, lis.FilterChains[0].Filters[0].GetTypedConfig().UnmarshalTo(&httpManager))
+	assert.Equal(t, "internal", httpManager.StatPrefix)
+	assert.Equal(t, float64(50), httpManager.Tracing.RandomSampling.Value)
+	assert.Equal(t, openTelemetryTracer, httpManager.Tracing.Provider.Name)
+	var otelConfig tracev3.OpenTelemetryConfig
+	assert.NoError(t, httpManager.Tracing.Provider.GetTypedConfig().UnmarshalTo(&otelConfig))
+	assert.Equal(t, TracingCollectorCluster, otelConfig.GrpcService.GetEnvoyGrpc().ClusterName)
+	assert.Equal(t, "abc", otelConfig.GrpcService.InitialMetadata[0].Value)
+}
@@ -68,6 +68,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/gateway/utils"
 	"github.com/secretflow/kuscia/pkg/utils/meta"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 const (
@@ -106,6 +107,11 @@ type InitConfig struct {
 
 	ExternalCert *TLSCert
 	InternalCert *TLSCert
+	// HTTP3 serves the external listener by HTTP/3 too, it requires ExternalCert.
+	HTTP3 bool
+
+	// Tracing exports spans of envoy to the OTLP collector if its endpoint is set.
+	Tracing *tracing.Config
 }
 
 type ConfigTemplate struct {
@@ -114,6 +120,8 @@ type ConfigTemplate struct {
 	ExternalPort uint32
 	LogPrefix    string
 	Version      string // kuscia version
+	// InternalToken is required by the handshake route of internal listener.
+	InternalToken string
 }
 
 type RouteLimitConfig struct {
@@ -184,14 +192,23 @@ func InitSnapshot(ns, instance string, initConfig *InitConfig) {
 	// Add the snapshot to the cache
 	var err error
 	configTemplate := ConfigTemplate{
-		Namespace:    ns,
-		Instance:     instance,
-		ExternalPort: config.ExternalPort,
-		LogPrefix:    config.Logdir,
-		Version:      meta.KusciaVersionString(),
+		Namespace:     ns,
+		Instance:      instance,
+		ExternalPort:  config.ExternalPort,
+		LogPrefix:     config.Logdir,
+		Version:       meta.KusciaVersionString(),
+		InternalToken: utils.InternalToken(),
+	}
+	clusters := generateClusters(config.Basedir)
+	if config.Tracing != nil && config.Tracing.Endpoint != "" {
+		tracingCluster, err := generateTracingCluster(config.Tracing)
+		if err != nil {
+			nlog.Fatalf("generate tracing cluster failed with %v", err)
+		}
+		clusters = append(clusters, tracingCluster)
 	}
 	snapshot, err = cache.NewSnapshot("1", map[resource.Type][]types.Resource{
-		resource.ClusterType:  generateClusters(config.Basedir),
+		resource.ClusterType:  clusters,
 		resource.RouteType:    generateRoutes(configTemplate, config.Basedir),
 		resource.ListenerType: generateListeners(configTemplate, config),
 	})
@@ -251,8 +268,25 @@ func generateListeners(configTemplate ConfigTemplate, config *InitConfig) []type
 		if err := protojson.Unmarshal(data.Bytes(), &lis); err != nil {
 			nlog.Fatal(err)
 		}
+		if config.Tracing != nil && config.Tracing.Endpoint != "" {
+			if err := setListenerTracing(&lis, config.Tracing); err != nil {
+				nlog.Fatalf("set tracing of listener %s failed with %v", lis.Name, err)
+			}
+		}
+		if lis.Name == InternalListener || lis.Name == ExternalListener {
+			if err := setListenerRequestTimeout(&lis); err != nil {
+				nlog.Fatalf("set request timeout of listener %s failed with %v", lis.Name, err)
+			}
+		}
 		if lis.Name == ExternalListener && config.ExternalCert != nil {
 			generateTLSListener(&lis, config.ExternalCert)
+			if config.HTTP3 {
+				quicLis, err := generateQUICListener(&lis)
+				if err != nil {
+					nlog.Fatalf("generate %s failed with %v", ExternalQUICListener, err)
+				}
+				listeners = append(listeners, quicLis)
+			}
 		}
 		if lis.Name == InternalListener && config.InternalCert != nil {
 			tlsLis, err := copyTLSListener(&lis, config.InternalCert, InternalTLSPort)
@@ -495,6 +529,20 @@ func QueryCluster(name string) (*envoycluster.Cluster, error) {
 	return copiedCluster, nil
 }
 
+func QueryListener(name string) (*listener.Listener, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	rs, ok := snapshot.Resources[types.Listener].Items[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown listener: %s", name)
+	}
+	lis, ok := rs.Resource.(*listener.Listener)
+	if !ok {
+		return nil, fmt.Errorf("resource cannot cast to Listener")
+	}
+	return proto.Clone(lis).(*listener.Listener), nil
+}
+
 func QueryVirtualHost(name, routeName string) (*route.VirtualHost, error) {
 	lock.Lock()
 	defer lock.Unlock()
@@ -963,6 +1011,12 @@ func updateHTTPFilters(filterMap map[string]protoreflect.ProtoMessage, listenerN
 		},
 	}
 	lis.FilterChains[0].Filters = []*listener.Filter{hcmFilter}
+	if lis.Name == ExternalListener {
+		// filter chains of server certs share the http filters of default filter chain
+		if err := decorateServerCertChains(lis); err != nil {
+			return err
+		}
+	}
 
 	if lis.Name == InternalListener && config.InternalCert != nil {
 		tlsLis, err := copyTLSListener(lis, config.InternalCert, InternalTLSPort)
@@ -1006,6 +1060,9 @@ func resetSnapshot(ty types.ResponseType, items map[string]types.ResourceWithTTL
 	}
 
 	if ty == types.Listener {
+		if err := syncQUICListener(items); err != nil {
+			return err
+		}
 		listenerResources = buildResourceFromResourcesItems(items)
 	} else {
 		listenerResources = buildResourcesFromSnapshot(types.Listener)
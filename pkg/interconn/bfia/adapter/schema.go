@@ -0,0 +1,238 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/proto/api/v1/interconn"
+)
+
+const (
+	datasetNameKey = "name"
+	// joinKeyParam is the common task param naming the column which parties join on.
+	joinKeyParam = "id"
+	schemasKey   = "schemas"
+)
+
+// DatasetSchema defines the declared columns of the input dataset of one party in a component.
+type DatasetSchema struct {
+	Component string            `json:"component"`
+	Role      string            `json:"role"`
+	NodeID    string            `json:"node_id"`
+	Dataset   string            `json:"dataset"`
+	Columns   map[string]string `json:"columns"`
+}
+
+// datasetRef defines the dataset referred by task params of one party in a component.
+type datasetRef struct {
+	component string
+	role      string
+	nodeID    string
+	name      string
+}
+
+// LookupDatasetSchemas looks up the schemas of the input datasets which are declared as domain data in local domains.
+// Datasets whose domain data can't be found locally belong to other parties and are skipped.
+func LookupDatasetSchemas(ctx context.Context, kusciaClient kusciaclientset.Interface, config *interconn.Config) ([]DatasetSchema, error) {
+	var schemas []DatasetSchema
+	for _, ref := range listDatasetRefs(config) {
+		dd, err := kusciaClient.KusciaV1alpha1().DomainDatas(ref.nodeID).Get(ctx, ref.name, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("get domain data %s/%s failed, %v", ref.nodeID, ref.name, err)
+		}
+
+		columns := make(map[string]string, len(dd.Spec.Columns))
+		for _, col := range dd.Spec.Columns {
+			columns[col.Name] = col.Type
+		}
+		schemas = append(schemas, DatasetSchema{
+			Component: ref.component,
+			Role:      ref.role,
+			NodeID:    ref.nodeID,
+			Dataset:   ref.name,
+			Columns:   columns,
+		})
+	}
+	return schemas, nil
+}
+
+// BuildDatasetSchemasData builds the response data which carries dataset schemas.
+func BuildDatasetSchemasData(schemas []DatasetSchema) (*structpb.Struct, error) {
+	content, err := json.Marshal(schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []interface{}
+	if err = json.Unmarshal(content, &list); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(map[string]interface{}{schemasKey: list})
+}
+
+// ParseDatasetSchemasData parses dataset schemas from response data.
+func ParseDatasetSchemasData(data *structpb.Struct) ([]DatasetSchema, error) {
+	if data == nil || data.Fields[schemasKey] == nil {
+		return nil, nil
+	}
+
+	content, err := data.Fields[schemasKey].MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []DatasetSchema
+	if err = json.Unmarshal(content, &schemas); err != nil {
+		return nil, fmt.Errorf("parse dataset schemas failed, %v", err)
+	}
+	return schemas, nil
+}
+
+// CompareDatasetSchemas compares the dataset schemas of parties in each component.
+// It returns an error with a readable diff if the join key column is missing in
+// some datasets or if the same column is declared with different types.
+func CompareDatasetSchemas(config *interconn.Config, schemas []DatasetSchema) error {
+	byComponent := make(map[string][]DatasetSchema)
+	for _, s := range schemas {
+		byComponent[s.Component] = append(byComponent[s.Component], s)
+	}
+
+	components := make([]string, 0, len(byComponent))
+	for cpt := range byComponent {
+		components = append(components, cpt)
+	}
+	sort.Strings(components)
+
+	var diffs []string
+	for _, cpt := range components {
+		parties := byComponent[cpt]
+		sort.Slice(parties, func(i, j int) bool {
+			return partyName(parties[i]) < partyName(parties[j])
+		})
+
+		if key := joinKeyOf(config, cpt); key != "" {
+			for _, p := range parties {
+				if _, ok := p.Columns[key]; !ok {
+					diffs = append(diffs, fmt.Sprintf("component %s: join key column %q is missing in %s dataset %s", cpt, key, partyName(p), p.Dataset))
+				}
+			}
+		}
+
+		columnTypes := make(map[string][]string)
+		for _, p := range parties {
+			for col, typ := range p.Columns {
+				columnTypes[col] = append(columnTypes[col], fmt.Sprintf("%s=%s", partyName(p), typ))
+			}
+		}
+
+		columns := make([]string, 0, len(columnTypes))
+		for col := range columnTypes {
+			columns = append(columns, col)
+		}
+		sort.Strings(columns)
+		for _, col := range columns {
+			if !sameColumnType(columnTypes[col]) {
+				diffs = append(diffs, fmt.Sprintf("component %s: column %q type differs, %s", cpt, col, strings.Join(columnTypes[col], ", ")))
+			}
+		}
+	}
+
+	if len(diffs) > 0 {
+		return fmt.Errorf("data schema mismatch between parties:\n%s", strings.Join(diffs, "\n"))
+	}
+	return nil
+}
+
+// listDatasetRefs lists the datasets referred by the task params of all parties.
+func listDatasetRefs(config *interconn.Config) []datasetRef {
+	if config == nil || config.TaskParams == nil || config.Role == nil {
+		return nil
+	}
+
+	var refs []datasetRef
+	collect := func(roleParams *structpb.Struct, nodeIDs []string, role string) {
+		if roleParams == nil {
+			return
+		}
+		for index, f := range roleParams.Fields {
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 || i >= len(nodeIDs) {
+				continue
+			}
+			for cpt, params := range f.GetStructValue().AsMap() {
+				p, ok := params.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := p[datasetNameKey].(string)
+				if name == "" {
+					continue
+				}
+				refs = append(refs, datasetRef{component: cpt, role: role, nodeID: nodeIDs[i], name: name})
+			}
+		}
+	}
+
+	collect(config.TaskParams.Host, config.Role.Host, "host")
+	collect(config.TaskParams.Guest, config.Role.Guest, "guest")
+	collect(config.TaskParams.Arbiter, config.Role.Arbiter, "arbiter")
+	return refs
+}
+
+// joinKeyOf returns the join key column of the component from common task params.
+func joinKeyOf(config *interconn.Config, component string) string {
+	if config == nil || config.TaskParams == nil || config.TaskParams.Common == nil {
+		return ""
+	}
+
+	cpt := config.TaskParams.Common.Fields[component].GetStructValue()
+	if cpt == nil {
+		return ""
+	}
+	return cpt.Fields[joinKeyParam].GetStringValue()
+}
+
+func partyName(s DatasetSchema) string {
+	return fmt.Sprintf("%s(%s)", s.Role, s.NodeID)
+}
+
+func sameColumnType(types []string) bool {
+	var first string
+	for i, t := range types {
+		typ := strings.ToLower(t[strings.LastIndex(t, "=")+1:])
+		if i == 0 {
+			first = typ
+			continue
+		}
+		if typ != first {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciaclientsetfake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/proto/api/v1/interconn"
+)
+
+var schemaJobConfig = `{"role":{"arbiter":["alice"],"host":["bob"],"guest":["alice"]},"initiator":{"role":"guest","node_id":"alice"},"task_params":{"host":{"0":{"intersect_rsa_1":{"name":"test_host","namespace":"testspace"}}},"arbiter":{"0":{"intersect_rsa_1":{}}},"guest":{"0":{"intersect_rsa_1":{"name":"test_guest","namespace":"testspace"}}},"common":{"intersect_rsa_1":{"id":"id","intersect_method":"rsa"}}},"version":"2.0.0"}`
+
+func makeSchemaJobConfig(t *testing.T) *interconn.Config {
+	config := &interconn.Config{}
+	assert.NoError(t, json.Unmarshal([]byte(schemaJobConfig), config))
+	return config
+}
+
+func TestLookupDatasetSchemas(t *testing.T) {
+	dd := &kusciaapisv1alpha1.DomainData{
+		ObjectMeta: metav1.ObjectMeta{Name: "test_guest", Namespace: "alice"},
+		Spec: kusciaapisv1alpha1.DomainDataSpec{
+			Columns: []kusciaapisv1alpha1.DataColumn{{Name: "id", Type: "str"}, {Name: "y", Type: "int"}},
+		},
+	}
+	kusciaClient := kusciaclientsetfake.NewSimpleClientset(dd)
+
+	schemas, err := LookupDatasetSchemas(context.Background(), kusciaClient, makeSchemaJobConfig(t))
+	assert.NoError(t, err)
+	assert.Equal(t, []DatasetSchema{{
+		Component: "intersect_rsa_1",
+		Role:      "guest",
+		NodeID:    "alice",
+		Dataset:   "test_guest",
+		Columns:   map[string]string{"id": "str", "y": "int"},
+	}}, schemas)
+}
+
+func TestDatasetSchemasDataRoundTrip(t *testing.T) {
+	schemas := []DatasetSchema{{
+		Component: "intersect_rsa_1",
+		Role:      "host",
+		NodeID:    "bob",
+		Dataset:   "test_host",
+		Columns:   map[string]string{"id": "str"},
+	}}
+
+	data, err := BuildDatasetSchemasData(schemas)
+	assert.NoError(t, err)
+	got, err := ParseDatasetSchemasData(data)
+	assert.NoError(t, err)
+	assert.Equal(t, schemas, got)
+
+	got, err = ParseDatasetSchemasData(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestCompareDatasetSchemas(t *testing.T) {
+	config := makeSchemaJobConfig(t)
+	guest := DatasetSchema{Component: "intersect_rsa_1", Role: "guest", NodeID: "alice", Dataset: "test_guest", Columns: map[string]string{"id": "str", "y": "int"}}
+
+	tests := []struct {
+		name    string
+		host    DatasetSchema
+		wantErr []string
+	}{
+		{
+			name: "schemas match",
+			host: DatasetSchema{Component: "intersect_rsa_1", Role: "host", NodeID: "bob", Dataset: "test_host", Columns: map[string]string{"id": "STR", "x1": "float"}},
+		},
+		{
+			name: "join key is missing",
+			host: DatasetSchema{Component: "intersect_rsa_1", Role: "host", NodeID: "bob", Dataset: "test_host", Columns: map[string]string{"uid": "str"}},
+			wantErr: []string{
+				`component intersect_rsa_1: join key column "id" is missing in host(bob) dataset test_host`,
+			},
+		},
+		{
+			name: "column type differs",
+			host: DatasetSchema{Component: "intersect_rsa_1", Role: "host", NodeID: "bob", Dataset: "test_host", Columns: map[string]string{"id": "int"}},
+			wantErr: []string{
+				`component intersect_rsa_1: column "id" type differs, guest(alice)=str, host(bob)=int`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompareDatasetSchemas(config, []DatasetSchema{tt.host, guest})
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
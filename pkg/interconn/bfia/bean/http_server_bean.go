@@ -157,6 +157,11 @@ func (b *httpServerBean) buildGroupRouters(engine *engine.Engine) router.GroupsR
 					RelativePath: "status_all",
 					Handlers:     []gin.HandlerFunc{protoDecorator(engine, handler.NewQueryJobStatusAllHandler(b.ResourcesManager))},
 				},
+				{
+					HTTPMethod:   http.MethodPost,
+					RelativePath: "schema",
+					Handlers:     []gin.HandlerFunc{protoDecorator(engine, handler.NewQueryDataSchemaHandler(b.ResourcesManager))},
+				},
 			},
 		},
 		{
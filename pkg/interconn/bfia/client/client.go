@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/secretflow/kuscia/pkg/interconn/common"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/proto/api/v1/interconn"
 )
@@ -32,13 +33,14 @@ import (
 const (
 	httpPrefix = "http://"
 
-	createJobAPI      = "/v1/interconn/schedule/job/create"
-	stopJobAPI        = "/v1/interconn/schedule/job/stop"
-	startJobAPI       = "/v1/interconn/schedule/job/start"
-	queryJobStatusAPI = "/v1/interconn/schedule/job/status_all"
-	stopTaskAPI       = "/v1/interconn/schedule/task/stop"
-	startTaskAPI      = "/v1/interconn/schedule/task/start"
-	pollTaskStatusAPI = "/v1/interconn/schedule/task/poll"
+	createJobAPI       = "/v1/interconn/schedule/job/create"
+	stopJobAPI         = "/v1/interconn/schedule/job/stop"
+	startJobAPI        = "/v1/interconn/schedule/job/start"
+	queryJobStatusAPI  = "/v1/interconn/schedule/job/status_all"
+	queryDataSchemaAPI = "/v1/interconn/schedule/job/schema"
+	stopTaskAPI        = "/v1/interconn/schedule/task/stop"
+	startTaskAPI       = "/v1/interconn/schedule/task/start"
+	pollTaskStatusAPI  = "/v1/interconn/schedule/task/poll"
 )
 
 const (
@@ -127,6 +129,22 @@ func (c *Client) QueryJobStatusAll(ctx context.Context, requesterID, host, jobID
 	return c.do(ctx, requesterID, host, http.MethodGet, url, nil)
 }
 
+// QueryDataSchema is used to query the schemas of input datasets from other party before creating job.
+func (c *Client) QueryDataSchema(ctx context.Context, requesterID, host, jobID, flowID string, dag *interconn.DAG, config *interconn.Config) (*interconn.CommonResponse, error) {
+	req := &interconn.CreateJobRequest{
+		JobId:  jobID,
+		FlowId: flowID,
+		Dag:    dag,
+		Config: config,
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, requesterID, host, http.MethodPost, fmt.Sprintf("%s%s%s", httpPrefix, host, queryDataSchemaAPI), body)
+}
+
 // StopTask is used to send stop-task request to other party.
 func (c *Client) StopTask(ctx context.Context, requesterID, host, taskID string) error {
 	req := &interconn.StopTaskRequest{
@@ -197,7 +215,7 @@ func (c *Client) do(ctx context.Context, requesterID, host, method, url string,
 		time.Sleep(200 * time.Millisecond)
 	}
 	if err != nil {
-		return nil, err
+		return nil, common.BFIAErrorMapping.TranslateTransport(host, err)
 	}
 	defer resp.Body.Close()
 
@@ -208,7 +226,7 @@ func (c *Client) do(ctx context.Context, requesterID, host, method, url string,
 
 	nlog.Infof("response body: %v", string(respBody))
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response status code: %v, body: %v", resp.StatusCode, string(respBody))
+		return nil, common.BFIAErrorMapping.Translate(host, strconv.Itoa(resp.StatusCode), string(respBody))
 	}
 
 	commonResp := &interconn.CommonResponse{}
@@ -218,7 +236,7 @@ func (c *Client) do(ctx context.Context, requesterID, host, method, url string,
 	}
 
 	if commonResp.Code != http.StatusOK {
-		return nil, fmt.Errorf("status code: %v, message: %v", commonResp.Code, commonResp.Msg)
+		return nil, common.BFIAErrorMapping.Translate(host, strconv.Itoa(int(commonResp.Code)), commonResp.Msg)
 	}
 	return commonResp, nil
 }
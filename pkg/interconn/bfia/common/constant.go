@@ -46,10 +46,11 @@ var InterConnTaskPhaseToKusciaTaskPhase = map[string]kusciaapisv1alpha1.KusciaTa
 }
 
 var KusciaJobPhaseToInterConJobPhase = map[kusciaapisv1alpha1.KusciaJobPhase]string{
-	kusciaapisv1alpha1.KusciaJobPending:   InterConnPending,
-	kusciaapisv1alpha1.KusciaJobRunning:   InterConnRunning,
-	kusciaapisv1alpha1.KusciaJobSucceeded: InterConnSuccess,
-	kusciaapisv1alpha1.KusciaJobFailed:    InterConnFailed,
+	kusciaapisv1alpha1.KusciaJobPreflightCheck: InterConnPending,
+	kusciaapisv1alpha1.KusciaJobPending:        InterConnPending,
+	kusciaapisv1alpha1.KusciaJobRunning:        InterConnRunning,
+	kusciaapisv1alpha1.KusciaJobSucceeded:      InterConnSuccess,
+	kusciaapisv1alpha1.KusciaJobFailed:         InterConnFailed,
 }
 
 const (
@@ -52,6 +52,7 @@ const (
 
 const (
 	maxRetries                             = 5
+	defaultResync                          = 5 * time.Minute
 	inflightRequestCacheExpiration         = 600 * time.Second
 	finishedInflightRequestCacheExpiration = 15 * time.Second
 	taskStatusSyncInterval                 = 5 * time.Second
@@ -113,11 +114,14 @@ type Controller struct {
 }
 
 // NewController returns a controller instance.
-func NewController(ctx context.Context, kubeClient kubernetes.Interface, kusciaClient kusciaclientset.Interface, eventRecorder record.EventRecorder) iccommon.IController {
-	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 5*time.Minute)
+func NewController(ctx context.Context, kubeClient kubernetes.Interface, kusciaClient kusciaclientset.Interface, eventRecorder record.EventRecorder, resyncPeriod time.Duration) iccommon.IController {
+	if resyncPeriod <= 0 {
+		resyncPeriod = defaultResync
+	}
+	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
 	nsInformer := kubeInformerFactory.Core().V1().Namespaces()
 
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, 5*time.Minute)
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, resyncPeriod)
 	kjInformer := kusciaInformerFactory.Kuscia().V1alpha1().KusciaJobs()
 	ktInformer := kusciaInformerFactory.Kuscia().V1alpha1().KusciaTasks()
 	appImageInformer := kusciaInformerFactory.Kuscia().V1alpha1().AppImages()
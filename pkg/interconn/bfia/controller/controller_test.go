@@ -31,7 +31,7 @@ import (
 func TestNewController(t *testing.T) {
 	kubeFakeClient := kubefake.NewSimpleClientset()
 	kusciaFakeClient := kusciafake.NewSimpleClientset()
-	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil)
+	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil, 0)
 	assert.NotNil(t, c)
 }
 
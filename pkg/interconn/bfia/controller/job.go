@@ -29,6 +29,7 @@ import (
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/interconn/bfia/adapter"
 	bfiacommon "github.com/secretflow/kuscia/pkg/interconn/bfia/common"
+	iccommon "github.com/secretflow/kuscia/pkg/interconn/common"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
@@ -137,7 +138,7 @@ func (c *Controller) processJobStatusSyncNextWorkItem(ctx context.Context) bool
 
 	resp, err := c.bfiaClient.QueryJobStatusAll(ctx, c.getReqDomainIDFromKusciaJob(kj), buildHostFor(kj.Spec.Initiator), kj.Name)
 	if err != nil {
-		utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionFalse, "ErrorQueryJobStatus", err.Error())
+		utilsres.SetKusciaJobCondition(now, cond, corev1.ConditionFalse, iccommon.ErrorReason("ErrorQueryJobStatus", err), err.Error())
 		if err = c.updateJobStatus(kj, false, true); err != nil {
 			nlog.Errorf("Update kuscia job %v status condition failed, %v", kj.Name, err)
 		}
@@ -261,27 +262,41 @@ func (c *Controller) createJob(ctx context.Context, kj *kusciaapisv1alpha1.Kusci
 	cacheKeyName := getCacheKeyName(reqTypeCreateJob, resourceTypeKusciaJob, kj.Name)
 	c.inflightRequestCache.Add(cacheKeyName, "", inflightRequestCacheExpiration)
 
-	var wg sync.WaitGroup
-	var errs errorcode.Errs
-	for domainID := range c.getPartiesDomainInfo(kj) {
-		wg.Add(1)
-		go func(domainID string) {
-			createJobErr := c.bfiaClient.CreateJob(ctx, kj.Spec.Initiator, buildHostFor(domainID), interConnJobSpec.JodID, interConnJobSpec.FlowID, interConnJobSpec.DAG, interConnJobSpec.Config)
-			if createJobErr != nil {
-				errs.AppendErr(createJobErr)
-			}
-			defer wg.Done()
-		}(domainID)
-	}
-
 	go func(cacheKeyName string) {
 		defer c.inflightRequestCache.Set(cacheKeyName, "", finishedInflightRequestCacheExpiration)
+		if err = c.negotiateDataSchema(ctx, kj, interConnJobSpec); err != nil {
+			nlog.Errorf("Negotiate data schema of job %v failed, %v", kj.Name, err)
+			now := metav1.Now().Rfc3339Copy()
+			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionFalse, "ErrorDataSchemaMismatch", err.Error())
+			kj.Status.Phase = kusciaapisv1alpha1.KusciaJobFailed
+			kj.Status.Reason = "DataSchemaMismatch"
+			kj.Status.Message = err.Error()
+			kj.Status.LastReconcileTime = &now
+			if err = c.updateJobStatus(kj, false, true); err != nil {
+				nlog.Errorf("Update kuscia job %v status failed, %v", kj.Name, err)
+			}
+			return
+		}
+
+		var wg sync.WaitGroup
+		var errs errorcode.Errs
+		for domainID := range c.getPartiesDomainInfo(kj) {
+			wg.Add(1)
+			go func(domainID string) {
+				createJobErr := c.bfiaClient.CreateJob(ctx, kj.Spec.Initiator, buildHostFor(domainID), interConnJobSpec.JodID, interConnJobSpec.FlowID, interConnJobSpec.DAG, interConnJobSpec.Config)
+				if createJobErr != nil {
+					errs.AppendErr(createJobErr)
+				}
+				defer wg.Done()
+			}(domainID)
+		}
+
 		wg.Wait()
 		now := metav1.Now().Rfc3339Copy()
 		if len(errs) > 0 {
 			err = fmt.Errorf("create interconn job %v request failed, %v", kj.Name, errs.String())
 			nlog.Error(err)
-			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionFalse, "ErrorCreateJobRequest", err.Error())
+			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionFalse, iccommon.ErrorReason("ErrorCreateJobRequest", errs...), err.Error())
 		} else {
 			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionTrue, "", "")
 		}
@@ -292,6 +307,58 @@ func (c *Controller) createJob(ctx context.Context, kj *kusciaapisv1alpha1.Kusci
 	}(cacheKeyName)
 }
 
+// negotiateDataSchema exchanges the schemas of input datasets between parties before creating job,
+// so that disagreements on column names or types fail fast instead of deep inside the engine.
+// Parties which don't support the schema exchange are skipped.
+func (c *Controller) negotiateDataSchema(ctx context.Context, kj *kusciaapisv1alpha1.KusciaJob, jobInfo *adapter.InterConnJobInfo) error {
+	schemas, err := adapter.LookupDatasetSchemas(ctx, c.kusciaClient, jobInfo.Config)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for domainID := range c.getPartiesDomainInfo(kj) {
+		wg.Add(1)
+		go func(domainID string) {
+			defer wg.Done()
+			resp, queryErr := c.bfiaClient.QueryDataSchema(ctx, kj.Spec.Initiator, buildHostFor(domainID), jobInfo.JodID, jobInfo.FlowID, jobInfo.DAG, jobInfo.Config)
+			if queryErr != nil {
+				nlog.Warnf("Query data schema of job %v from party %v failed, skip checking its schema, %v", kj.Name, domainID, queryErr)
+				return
+			}
+
+			partySchemas, parseErr := adapter.ParseDatasetSchemasData(resp.Data)
+			if parseErr != nil {
+				nlog.Warnf("Parse data schema of job %v from party %v failed, skip checking its schema, %v", kj.Name, domainID, parseErr)
+				return
+			}
+
+			mu.Lock()
+			schemas = append(schemas, partySchemas...)
+			mu.Unlock()
+		}(domainID)
+	}
+	wg.Wait()
+
+	return adapter.CompareDatasetSchemas(jobInfo.Config, dedupDatasetSchemas(schemas))
+}
+
+// dedupDatasetSchemas removes duplicate schemas of the same party and component reported more than once.
+func dedupDatasetSchemas(schemas []adapter.DatasetSchema) []adapter.DatasetSchema {
+	seen := make(map[string]struct{}, len(schemas))
+	var ret []adapter.DatasetSchema
+	for _, s := range schemas {
+		key := fmt.Sprintf("%s/%s/%s", s.Component, s.Role, s.NodeID)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		ret = append(ret, s)
+	}
+	return ret
+}
+
 // handleJobCreateStage handles kuscia job with stop stage.
 func (c *Controller) handleJobStopStage(ctx context.Context, kj *kusciaapisv1alpha1.KusciaJob) {
 	initializedCond, found := utilsres.GetKusciaJobCondition(&kj.Status, kusciaapisv1alpha1.JobStopInitialized, false)
@@ -333,7 +400,7 @@ func (c *Controller) stopJob(ctx context.Context, kj *kusciaapisv1alpha1.KusciaJ
 		if len(errs) > 0 {
 			err := fmt.Errorf("stop interconn job %v request failed, %v", kj.Name, errs.String())
 			nlog.Error(err)
-			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionFalse, "ErrorStopJobRequest", err.Error())
+			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionFalse, iccommon.ErrorReason("ErrorStopJobRequest", errs...), err.Error())
 		} else {
 			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionTrue, "", "")
 		}
@@ -456,7 +523,7 @@ func (c *Controller) startJob(ctx context.Context, kj *kusciaapisv1alpha1.Kuscia
 		if len(errs) > 0 {
 			err := fmt.Errorf("start interconn job %v request failed, %v", kj.Name, errs.String())
 			nlog.Error(err)
-			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionFalse, "ErrorStartJobRequest", err.Error())
+			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionFalse, iccommon.ErrorReason("ErrorStartJobRequest", errs...), err.Error())
 		} else {
 			utilsres.SetKusciaJobCondition(now, succeededCond, corev1.ConditionTrue, "", "")
 		}
@@ -75,7 +75,7 @@ func makeKusciaJob(name string, labels, annotations map[string]string) *kusciaap
 func TestHandleAddedOrDeletedKusciaJob(t *testing.T) {
 	kubeFakeClient := clientsetfake.NewSimpleClientset()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil)
+	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -111,7 +111,7 @@ func TestHandleAddedOrDeletedKusciaJob(t *testing.T) {
 func TestHandleUpdatedKusciaJob(t *testing.T) {
 	kubeFakeClient := clientsetfake.NewSimpleClientset()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil)
+	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -307,7 +307,7 @@ func TestGetPartiesDomainInfo(t *testing.T) {
 func TestGetReqDomainIDFromKusciaJob(t *testing.T) {
 	kubeFakeClient := clientsetfake.NewSimpleClientset()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil)
+	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -32,6 +32,7 @@ import (
 	pkgcommon "github.com/secretflow/kuscia/pkg/common"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	"github.com/secretflow/kuscia/pkg/interconn/bfia/common"
+	iccommon "github.com/secretflow/kuscia/pkg/interconn/common"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
@@ -649,7 +650,7 @@ func (c *Controller) processTaskStatusSyncNextWorkItem(ctx context.Context) bool
 			if len(errs) > 0 {
 				err = fmt.Errorf("poll task status request failed, %v", errs.String())
 				nlog.Error(err)
-				utilsres.SetKusciaTaskCondition(now, cond, corev1.ConditionFalse, "ErrorPollTaskStatusRequest", err.Error())
+				utilsres.SetKusciaTaskCondition(now, cond, corev1.ConditionFalse, iccommon.ErrorReason("ErrorPollTaskStatusRequest", errs...), err.Error())
 			} else {
 				if cond.Status != corev1.ConditionTrue {
 					utilsres.SetKusciaTaskCondition(now, cond, corev1.ConditionTrue, "", "")
@@ -51,7 +51,7 @@ func makeKusciaTask(name string, labels map[string]string) *kusciaapisv1alpha1.K
 func TestHandleAddedOrDeletedKusciaTask(t *testing.T) {
 	kubeFakeClient := kubefake.NewSimpleClientset()
 	kusciaFakeClient := kusciafake.NewSimpleClientset()
-	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil)
+	c := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -359,7 +359,7 @@ func TestController_syncTaskHandler(t *testing.T) {
 	kubeClient := kubefake.NewSimpleClientset()
 	kusciaClient := kusciafake.NewSimpleClientset(job, appImage, task1, task2)
 
-	cc := NewController(context.Background(), kubeClient, kusciaClient, nil)
+	cc := NewController(context.Background(), kubeClient, kusciaClient, nil, 0)
 	c := cc.(*Controller)
 	c.kusciaInformerFactory.Start(c.ctx.Done())
 	if ok := cache.WaitForCacheSync(c.ctx.Done(), c.kjSynced, c.ktSynced, c.appImageSynced); !ok {
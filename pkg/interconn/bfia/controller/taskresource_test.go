@@ -34,7 +34,7 @@ import (
 func TestHandleAddedOrDeletedTaskResource(t *testing.T) {
 	kubeFakeClient := clientsetfake.NewSimpleClientset()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	cc := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil)
+	cc := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil, 0)
 	if cc == nil {
 		t.Error("new controller failed")
 	}
@@ -70,7 +70,7 @@ func TestHandleAddedOrDeletedTaskResource(t *testing.T) {
 func TestHandleUpdatedTaskResource(t *testing.T) {
 	kubeFakeClient := clientsetfake.NewSimpleClientset()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	cc := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil)
+	cc := NewController(context.Background(), kubeFakeClient, kusciaFakeClient, nil, 0)
 	if cc == nil {
 		t.Error("new controller failed")
 	}
@@ -0,0 +1,83 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/secretflow/kuscia/pkg/interconn/bfia/adapter"
+	bfiacommon "github.com/secretflow/kuscia/pkg/interconn/bfia/common"
+	"github.com/secretflow/kuscia/pkg/web/api"
+	"github.com/secretflow/kuscia/pkg/web/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1/interconn"
+)
+
+// queryDataSchemaHandler defines the handler info for querying the schemas of local input datasets before job creation.
+type queryDataSchemaHandler struct {
+	*ResourcesManager
+}
+
+// NewQueryDataSchemaHandler returns a queryDataSchemaHandler instance.
+func NewQueryDataSchemaHandler(rm *ResourcesManager) api.ProtoHandler {
+	return &queryDataSchemaHandler{
+		ResourcesManager: rm,
+	}
+}
+
+// Validate is used to validate request.
+func (h *queryDataSchemaHandler) Validate(ctx *api.BizContext, request api.ProtoRequest, errs *errorcode.Errs) {
+	req, ok := request.(*interconn.CreateJobRequest)
+	if !ok {
+		errs.AppendErr(fmt.Errorf("query data schema request type is invalid"))
+		return
+	}
+
+	if req.JobId == "" {
+		errs.AppendErr(fmt.Errorf("parameter job_id can't be empty"))
+	}
+
+	if req.Config == nil {
+		errs.AppendErr(fmt.Errorf("parameter config can't be empty"))
+	}
+}
+
+// Handle is used to handle request.
+func (h *queryDataSchemaHandler) Handle(ctx *api.BizContext, request api.ProtoRequest) api.ProtoResponse {
+	req := request.(*interconn.CreateJobRequest)
+	resp := &interconn.CommonResponse{
+		Code: http.StatusOK,
+	}
+
+	schemas, err := adapter.LookupDatasetSchemas(h.ctx, h.KusciaClient, req.Config)
+	if err != nil {
+		resp.Code = http.StatusInternalServerError
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	resp.Data, err = adapter.BuildDatasetSchemasData(schemas)
+	if err != nil {
+		resp.Code = http.StatusInternalServerError
+		resp.Msg = bfiacommon.ErrGenerateDataFailed
+	}
+	return resp
+}
+
+// GetType is used to get request and response type.
+func (h *queryDataSchemaHandler) GetType() (reqType, respType reflect.Type) {
+	return reflect.TypeOf(interconn.CreateJobRequest{}), reflect.TypeOf(interconn.CommonResponse{})
+}
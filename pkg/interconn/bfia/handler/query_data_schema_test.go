@@ -0,0 +1,71 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciaclientsetfake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/pkg/interconn/bfia/adapter"
+	"github.com/secretflow/kuscia/pkg/web/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1/interconn"
+)
+
+func Test_queryDataSchemaHandler_Validate(t *testing.T) {
+	ctx := context.Background()
+	rm, _ := NewResourcesManager(ctx, kusciaclientsetfake.NewSimpleClientset())
+	h := NewQueryDataSchemaHandler(rm)
+
+	errs := &errorcode.Errs{}
+	h.Validate(nil, &interconn.CreateJobRequest{}, errs)
+	assert.Equal(t, 2, len(*errs))
+
+	errs = &errorcode.Errs{}
+	icJobInfo := makeInterConnConfig()
+	h.Validate(nil, &interconn.CreateJobRequest{JobId: icJobInfo.JodID, Config: icJobInfo.Config}, errs)
+	assert.Equal(t, 0, len(*errs))
+}
+
+func Test_queryDataSchemaHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	dd := &kusciaapisv1alpha1.DomainData{
+		ObjectMeta: metav1.ObjectMeta{Name: "test_host", Namespace: "JG0100001100000000"},
+		Spec: kusciaapisv1alpha1.DomainDataSpec{
+			Columns: []kusciaapisv1alpha1.DataColumn{{Name: "id", Type: "str"}},
+		},
+	}
+	rm, _ := NewResourcesManager(ctx, kusciaclientsetfake.NewSimpleClientset(dd))
+	h := NewQueryDataSchemaHandler(rm)
+
+	icJobInfo := makeInterConnConfig()
+	resp := h.Handle(nil, &interconn.CreateJobRequest{JobId: icJobInfo.JodID, Config: icJobInfo.Config}).(*interconn.CommonResponse)
+	assert.Equal(t, int32(http.StatusOK), resp.Code)
+
+	schemas, err := adapter.ParseDatasetSchemasData(resp.Data)
+	assert.NoError(t, err)
+	assert.Equal(t, []adapter.DatasetSchema{{
+		Component: "intersect_rsa_1",
+		Role:      "host",
+		NodeID:    "JG0100001100000000",
+		Dataset:   "test_host",
+		Columns:   map[string]string{"id": "str"},
+	}}, schemas)
+}
@@ -16,6 +16,7 @@ package common
 
 import (
 	"context"
+	"time"
 
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,7 +31,8 @@ type ControllerConstruction struct {
 	CRDNames     []string
 }
 
-type NewControllerFunc func(ctx context.Context, kubeClient kubernetes.Interface, kusciaClient kusciaclientset.Interface, eventRecorder record.EventRecorder) IController
+// NewControllerFunc creates a controller, resyncPeriod is the resync period of its informers, 0 means the default.
+type NewControllerFunc func(ctx context.Context, kubeClient kubernetes.Interface, kusciaClient kusciaclientset.Interface, eventRecorder record.EventRecorder, resyncPeriod time.Duration) IController
 
 // CheckCRDExists is used to check if crd exist.
 func CheckCRDExists(ctx context.Context, extensionClient apiextensionsclientset.Interface, crdNames []string) error {
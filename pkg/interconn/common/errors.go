@@ -0,0 +1,150 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+)
+
+// PartyError is an error of a request to the platform of another party. The error code of the platform is translated
+// into a Kuscia error code, so the reasons of job and task status are the same whichever platform failed, and the
+// original code and message are kept for troubleshooting.
+type PartyError struct {
+	// Code is the Kuscia error code.
+	Code errorcode.ErrorCode
+	// Protocol of the platform, e.g. bfia.
+	Protocol string
+	// Party is the host of the platform.
+	Party string
+	// OriginalCode is the error code or http status returned by the platform, it's empty if no response is received.
+	OriginalCode string
+	// Message is the original error message.
+	Message string
+}
+
+func (e *PartyError) Error() string {
+	if e.OriginalCode == "" {
+		return fmt.Sprintf("%s: request to %s failed, %s", e.Code, e.Party, e.Message)
+	}
+	return fmt.Sprintf("%s: %s returned %s code %s, %s", e.Code, e.Party, e.Protocol, e.OriginalCode, e.Message)
+}
+
+// MessageRule maps errors whose message contains the keyword, case-insensitively.
+type MessageRule struct {
+	Keyword string
+	Code    errorcode.ErrorCode
+}
+
+// ErrorMapping translates the errors of a platform into Kuscia error codes.
+type ErrorMapping struct {
+	Protocol string
+	// Messages are matched in order before codes, as some platforms return the same code for different errors.
+	Messages []MessageRule
+	Codes    map[string]errorcode.ErrorCode
+	// Default is the code of errors matching no rule.
+	Default errorcode.ErrorCode
+}
+
+// Translate translates the error code and message returned by party.
+func (m *ErrorMapping) Translate(party, code, message string) *PartyError {
+	e := &PartyError{
+		Code:         m.Default,
+		Protocol:     m.Protocol,
+		Party:        party,
+		OriginalCode: code,
+		Message:      message,
+	}
+
+	lower := strings.ToLower(message)
+	for _, r := range m.Messages {
+		if strings.Contains(lower, r.Keyword) {
+			e.Code = r.Code
+			return e
+		}
+	}
+	if c, ok := m.Codes[code]; ok {
+		e.Code = c
+	}
+	return e
+}
+
+// TranslateTransport translates the error of a request to party without response, e.g. the connection is refused.
+func (m *ErrorMapping) TranslateTransport(party string, err error) *PartyError {
+	code := errorcode.ErrorCode_InterConnErrPartyUnreachable
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		code = errorcode.ErrorCode_InterConnErrPartyTimeout
+	}
+	return &PartyError{
+		Code:     code,
+		Protocol: m.Protocol,
+		Party:    party,
+		Message:  err.Error(),
+	}
+}
+
+// BFIAErrorMapping translates the errors of platforms of BFIA protocol, codes are http status codes returned in
+// either the status line or the body.
+var BFIAErrorMapping = &ErrorMapping{
+	Protocol: "bfia",
+	Messages: []MessageRule{
+		{Keyword: "already exist", Code: errorcode.ErrorCode_InterConnErrResourceExists},
+		{Keyword: "not exist", Code: errorcode.ErrorCode_InterConnErrResourceNotExists},
+		{Keyword: "not found", Code: errorcode.ErrorCode_InterConnErrResourceNotExists},
+		{Keyword: "failed to find", Code: errorcode.ErrorCode_InterConnErrResourceNotExists},
+		{Keyword: "unauthorized", Code: errorcode.ErrorCode_InterConnErrAuthFailed},
+		{Keyword: "permission denied", Code: errorcode.ErrorCode_InterConnErrAuthFailed},
+	},
+	Codes: map[string]errorcode.ErrorCode{
+		"400": errorcode.ErrorCode_InterConnErrRequestInvalidate,
+		"401": errorcode.ErrorCode_InterConnErrAuthFailed,
+		"403": errorcode.ErrorCode_InterConnErrAuthFailed,
+		"404": errorcode.ErrorCode_InterConnErrPartyNotSupport,
+		"405": errorcode.ErrorCode_InterConnErrPartyNotSupport,
+		"409": errorcode.ErrorCode_InterConnErrResourceExists,
+		"500": errorcode.ErrorCode_InterConnErrPartyInternal,
+		"501": errorcode.ErrorCode_InterConnErrPartyNotSupport,
+		"502": errorcode.ErrorCode_InterConnErrPartyUnreachable,
+		"503": errorcode.ErrorCode_InterConnErrPartyUnreachable,
+		"504": errorcode.ErrorCode_InterConnErrPartyTimeout,
+	},
+	Default: errorcode.ErrorCode_InterConnErrForUnexpected,
+}
+
+// ErrorReason returns the reason of status for errs. It's the name of the Kuscia error code if all errs are party
+// errors of the same code, otherwise defaultReason.
+func ErrorReason(defaultReason string, errs ...error) string {
+	reason := ""
+	for _, err := range errs {
+		var partyErr *PartyError
+		if !errors.As(err, &partyErr) {
+			return defaultReason
+		}
+		if reason != "" && reason != partyErr.Code.String() {
+			return defaultReason
+		}
+		reason = partyErr.Code.String()
+	}
+	if reason == "" {
+		return defaultReason
+	}
+	return reason
+}
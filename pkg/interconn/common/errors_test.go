@@ -0,0 +1,74 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+)
+
+func TestBFIAErrorMapping_Translate(t *testing.T) {
+	tests := []struct {
+		code    string
+		message string
+		want    errorcode.ErrorCode
+	}{
+		{"400", "Job already exists", errorcode.ErrorCode_InterConnErrResourceExists},
+		{"400", "job does not exist", errorcode.ErrorCode_InterConnErrResourceNotExists},
+		{"500", "failed to find the task", errorcode.ErrorCode_InterConnErrResourceNotExists},
+		{"400", "invalid job id", errorcode.ErrorCode_InterConnErrRequestInvalidate},
+		{"401", "", errorcode.ErrorCode_InterConnErrAuthFailed},
+		{"404", "404 page not implemented", errorcode.ErrorCode_InterConnErrPartyNotSupport},
+		{"500", "internal error", errorcode.ErrorCode_InterConnErrPartyInternal},
+		{"503", "upstream connect error", errorcode.ErrorCode_InterConnErrPartyUnreachable},
+		{"10001", "unknown", errorcode.ErrorCode_InterConnErrForUnexpected},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.code, tt.message), func(t *testing.T) {
+			err := BFIAErrorMapping.Translate("interconn-scheduler.bob.svc", tt.code, tt.message)
+			assert.Equal(t, tt.want, err.Code)
+			assert.Equal(t, tt.code, err.OriginalCode)
+			assert.Equal(t, tt.message, err.Message)
+			assert.Contains(t, err.Error(), tt.want.String())
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestBFIAErrorMapping_TranslateTransport(t *testing.T) {
+	err := BFIAErrorMapping.TranslateTransport("interconn-scheduler.bob.svc", errors.New("connection refused"))
+	assert.Equal(t, errorcode.ErrorCode_InterConnErrPartyUnreachable, err.Code)
+	assert.Empty(t, err.OriginalCode)
+
+	err = BFIAErrorMapping.TranslateTransport("interconn-scheduler.bob.svc", fmt.Errorf("post: %w", context.DeadlineExceeded))
+	assert.Equal(t, errorcode.ErrorCode_InterConnErrPartyTimeout, err.Code)
+}
+
+func TestErrorReason(t *testing.T) {
+	notExists := BFIAErrorMapping.Translate("bob", "400", "job does not exist")
+	internal := BFIAErrorMapping.Translate("carol", "500", "internal error")
+
+	assert.Equal(t, "Default", ErrorReason("Default"))
+	assert.Equal(t, "InterConnErrResourceNotExists", ErrorReason("Default", notExists))
+	assert.Equal(t, "InterConnErrResourceNotExists", ErrorReason("Default", notExists, fmt.Errorf("wrapped, %w", notExists)))
+	assert.Equal(t, "Default", ErrorReason("Default", notExists, internal))
+	assert.Equal(t, "Default", ErrorReason("Default", notExists, errors.New("local error")))
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -32,6 +33,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/interconn/kuscia"
 	"github.com/secretflow/kuscia/pkg/utils/election"
 	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+	"github.com/secretflow/kuscia/pkg/utils/kusciaconfig"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
@@ -39,6 +41,8 @@ var (
 	serverName = "interconn"
 )
 
+const defaultWorkers = 4
+
 // Server defines detailed info which used to run Server.
 type Server struct {
 	ctx                     context.Context
@@ -52,15 +56,20 @@ type Server struct {
 	leaderElector           election.Elector
 	controllers             []iccommon.IController
 	controllerConstructions []iccommon.ControllerConstruction
+	workers                 int
+	resyncPeriod            time.Duration
 }
 
-// NewServer returns a Server instance.
-func NewServer(ctx context.Context, clients *kubeconfig.KubeClients) (*Server, error) {
+// NewServer returns a Server instance, controllersConfig tunes interconn controllers with the name interconn.
+func NewServer(ctx context.Context, clients *kubeconfig.KubeClients, electionConfig election.Config,
+	controllersConfig kusciaconfig.ControllersConfig) (*Server, error) {
 	s := &Server{
 		ctx:             ctx,
 		kubeClient:      clients.KubeClient,
 		kusciaClient:    clients.KusciaClient,
 		extensionClient: clients.ExtensionsClient,
+		workers:         controllersConfig.GetWorkers(serverName, defaultWorkers),
+		resyncPeriod:    controllersConfig.GetResyncPeriod(serverName),
 	}
 
 	bfiaServer, err := bfia.NewServer(ctx, clients)
@@ -86,6 +95,8 @@ func NewServer(ctx context.Context, clients *kubeconfig.KubeClients) (*Server, e
 	leaderElector := election.NewElector(
 		s.kubeClient,
 		serverName,
+		election.WithConfig(electionConfig),
+		election.WithEventRecorder(s.eventRecorder),
 		election.WithOnNewLeader(s.onNewLeader),
 		election.WithOnStartedLeading(s.onStartedLeading),
 		election.WithOnStoppedLeading(s.onStoppedLeading))
@@ -123,10 +134,10 @@ func (s *Server) onStartedLeading(ctx context.Context) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	for _, cc := range s.controllerConstructions {
-		controller := cc.NewControler(ctx, s.kubeClient, s.kusciaClient, s.eventRecorder)
-		nlog.Infof("Run controller %v ", controller.Name())
+		controller := cc.NewControler(ctx, s.kubeClient, s.kusciaClient, s.eventRecorder, s.resyncPeriod)
+		nlog.Infof("Run controller %v with %d workers", controller.Name(), s.workers)
 		go func(controller iccommon.IController) {
-			if err := controller.Run(4); err != nil {
+			if err := controller.Run(s.workers); err != nil {
 				nlog.Fatalf("Error running controller %v: %v", controller.Name(), err)
 			} else {
 				nlog.Infof("Run controller %v successfully", controller.Name())
@@ -102,8 +102,11 @@ type interopConfigInfo struct {
 }
 
 // NewController returns a controller instance.
-func NewController(ctx context.Context, kubeClient kubernetes.Interface, kusciaClient kusciaclientset.Interface, eventRecorder record.EventRecorder) iccommon.IController {
-	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, defaultResync)
+func NewController(ctx context.Context, kubeClient kubernetes.Interface, kusciaClient kusciaclientset.Interface, eventRecorder record.EventRecorder, resyncPeriod time.Duration) iccommon.IController {
+	if resyncPeriod <= 0 {
+		resyncPeriod = defaultResync
+	}
+	kusciaInformerFactory := kusciainformers.NewSharedInformerFactory(kusciaClient, resyncPeriod)
 	interopConfigInformer := kusciaInformerFactory.Kuscia().V1alpha1().InteropConfigs()
 	domainInformer := kusciaInformerFactory.Kuscia().V1alpha1().Domains()
 	deploymentInformer := kusciaInformerFactory.Kuscia().V1alpha1().KusciaDeployments()
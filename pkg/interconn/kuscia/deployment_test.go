@@ -46,7 +46,7 @@ func makeMockDeployment(namespace, name string) *v1alpha1.KusciaDeployment {
 func TestHandleUpdatedDeployment(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -100,7 +100,7 @@ func TestHandleDeletedDeployment(t *testing.T) {
 	domainBob := makeMockDomain("bob")
 	domainBob.Spec.Role = v1alpha1.Partner
 	domainInformer.Informer().GetStore().Add(domainBob)
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
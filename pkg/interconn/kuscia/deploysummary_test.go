@@ -43,7 +43,7 @@ func makeMockDeploymentSummary(namespace, name string) *v1alpha1.KusciaDeploymen
 func TestHandleUpdatedDeploymentSummary(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
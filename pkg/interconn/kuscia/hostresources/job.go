@@ -30,6 +30,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 // runJobWorker is a long-running function that will continually call the
@@ -157,6 +158,7 @@ func (c *hostResourcesController) createJob(ctx context.Context, hostJob *kuscia
 		},
 		Spec: *hostJob.Spec.DeepCopy(),
 	}
+	tracing.CopyAnnotations(kj.Annotations, hostJob.Annotations)
 
 	for k, v := range hostJob.Labels {
 		if strings.Contains(k, common.JobCustomFieldsLabelPrefix) {
@@ -31,7 +31,7 @@ import (
 func TestHandleAddedorDeletedInteropConfig(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -47,7 +47,7 @@ func TestHandleAddedorDeletedInteropConfig(t *testing.T) {
 func TestHandleUpdatedInteropConfig(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -104,7 +104,7 @@ func TestRegisterInteropConfig(t *testing.T) {
 	}
 
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -173,7 +173,7 @@ func TestRegisterInteropConfig(t *testing.T) {
 func TestDeregisterInteropConfig(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -225,7 +225,7 @@ func TestDeregisterInteropConfig(t *testing.T) {
 func TestGetInteropConfigInfo(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -273,7 +273,7 @@ func TestGetInteropConfigInfo(t *testing.T) {
 func TestSetInteropConfigInfo(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -308,7 +308,7 @@ func TestSetInteropConfigInfo(t *testing.T) {
 func TestDeleteInteropConfigInfo(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
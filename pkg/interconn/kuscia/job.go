@@ -33,6 +33,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/queue"
 	utilsres "github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 )
 
 // runJobWorker is a long-running function that will continually call the
@@ -215,6 +216,7 @@ func (c *Controller) buildMirrorJobs(job *v1alpha1.KusciaJob) (map[string]*v1alp
 			common.InitiatorAnnotationKey:            ikcommon.GetObjectAnnotation(job, common.InitiatorAnnotationKey),
 			common.InterConnKusciaPartyAnnotationKey: strings.Join(partyDomainIDs, "_"),
 		}
+		tracing.CopyAnnotations(annotations, job.Annotations)
 
 		labels := make(map[string]string)
 		for k, v := range job.Labels {
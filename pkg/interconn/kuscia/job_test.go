@@ -46,7 +46,7 @@ func makeMockJob(namespace, name string) *v1alpha1.KusciaJob {
 func TestHandleUpdatedJob(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -101,7 +101,7 @@ func TestHandleDeletedJob(t *testing.T) {
 	domainBob.Spec.Role = v1alpha1.Partner
 	domainInformer.Informer().GetStore().Add(domainBob)
 
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
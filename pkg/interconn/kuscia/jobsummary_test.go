@@ -43,7 +43,7 @@ func makeMockJobSummary(namespace, name string) *v1alpha1.KusciaJobSummary {
 func TestHandleUpdatedJobSummary(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -0,0 +1,222 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata exchanges the AppImages and engine versions supported by partners before jobs are created.
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/utils/meta"
+)
+
+const (
+	// DefaultCacheTTL is how long the metadata of a partner is cached.
+	DefaultCacheTTL = 5 * time.Minute
+
+	maxMetadataBodySize = 4 << 20
+)
+
+// ErrNotAdvertised means the partner doesn't advertise any app to us, e.g. it runs an old version of kuscia.
+var ErrNotAdvertised = errors.New("partner doesn't advertise metadata")
+
+// AppInfo is an app advertised to partners.
+type AppInfo struct {
+	Name         string   `json:"name"`
+	Image        string   `json:"image"`
+	Tag          string   `json:"tag"`
+	Version      string   `json:"version,omitempty"`
+	Channels     []string `json:"channels,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// PartnerMetadata is the metadata advertised by a domain to a partner.
+type PartnerMetadata struct {
+	DomainID      string    `json:"domainId"`
+	KusciaVersion string    `json:"kusciaVersion"`
+	Apps          []AppInfo `json:"apps"`
+}
+
+// FindApp returns the advertised app of name, or nil if it isn't advertised.
+func (m *PartnerMetadata) FindApp(name string) *AppInfo {
+	for i := range m.Apps {
+		if m.Apps[i].Name == name {
+			return &m.Apps[i]
+		}
+	}
+	return nil
+}
+
+// BuildPartnerMetadata builds the metadata of the AppImages which are visible to partner.
+func BuildPartnerMetadata(domainID, partner string, appImages []*v1alpha1.AppImage) *PartnerMetadata {
+	metadata := &PartnerMetadata{
+		DomainID:      domainID,
+		KusciaVersion: meta.KusciaVersionString(),
+		Apps:          []AppInfo{},
+	}
+	for _, appImage := range appImages {
+		if !visibleTo(appImage, partner) {
+			continue
+		}
+		metadata.Apps = append(metadata.Apps, buildAppInfo(appImage))
+	}
+	sort.Slice(metadata.Apps, func(i, j int) bool { return metadata.Apps[i].Name < metadata.Apps[j].Name })
+	return metadata
+}
+
+func visibleTo(appImage *v1alpha1.AppImage, partner string) bool {
+	if appImage.Labels[common.LabelAppImagePartnerVisible] != "true" {
+		return false
+	}
+	partners := splitList(appImage.Annotations[common.AppImageVisiblePartnersAnnotationKey])
+	if len(partners) == 0 {
+		return true
+	}
+	for _, p := range partners {
+		if p == partner {
+			return true
+		}
+	}
+	return false
+}
+
+func buildAppInfo(appImage *v1alpha1.AppImage) AppInfo {
+	app := AppInfo{
+		Name:         appImage.Name,
+		Image:        appImage.Spec.Image.Name,
+		Tag:          appImage.Spec.Image.Tag,
+		Version:      appImage.Spec.Version,
+		Capabilities: splitList(appImage.Annotations[common.AppImageCapabilitiesAnnotationKey]),
+	}
+	for _, channel := range appImage.Spec.Channels {
+		app.Channels = append(app.Channels, channel.Name)
+	}
+	for _, tpl := range appImage.Spec.DeployTemplates {
+		if tpl.Role != "" {
+			app.Roles = append(app.Roles, tpl.Role)
+		}
+	}
+	return app
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+type cachedMetadata struct {
+	metadata  *PartnerMetadata
+	err       error
+	expiredAt time.Time
+}
+
+// Client queries the metadata of partners through the internal listener of local gateway, and caches them with TTL.
+type Client struct {
+	source     string
+	server     string
+	ttl        time.Duration
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedMetadata
+}
+
+// NewClient returns a client which queries metadata as domain source.
+func NewClient(source string, ttl time.Duration) *Client {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Client{
+		source:     source,
+		server:     utils.InternalServer,
+		ttl:        ttl,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+		cache:      map[string]cachedMetadata{},
+	}
+}
+
+// Get returns the metadata advertised by partner to us. ErrNotAdvertised is returned if partner doesn't advertise
+// any app. Both metadata and ErrNotAdvertised are cached, other errors aren't.
+func (c *Client) Get(ctx context.Context, partner string) (*PartnerMetadata, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[partner]
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expiredAt) {
+		return cached.metadata, cached.err
+	}
+
+	metadata, err := c.query(ctx, partner)
+	if err != nil && !errors.Is(err, ErrNotAdvertised) {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[partner] = cachedMetadata{metadata: metadata, err: err, expiredAt: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return metadata, err
+}
+
+func (c *Client) query(ctx context.Context, partner string) (*PartnerMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.server+utils.MetadataAppsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Host = utils.GetMetadataHost(partner)
+	req.Header.Set("Kuscia-Source", c.source)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query metadata of %s failed, %v", partner, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("read metadata of %s failed, %v", partner, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotAdvertised
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query metadata of %s failed, status code %d, %s", partner, resp.StatusCode, body)
+	}
+
+	metadata := &PartnerMetadata{}
+	if err := json.Unmarshal(body, metadata); err != nil {
+		return nil, fmt.Errorf("parse metadata of %s failed, %v", partner, err)
+	}
+	if len(metadata.Apps) == 0 {
+		return nil, ErrNotAdvertised
+	}
+	return metadata, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/gateway/utils"
+)
+
+func makeAppImage(name string, labels, annotations map[string]string) *v1alpha1.AppImage {
+	return &v1alpha1.AppImage{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+		Spec: v1alpha1.AppImageSpec{
+			Image:           v1alpha1.AppImageInfo{Name: "secretflow/" + name, Tag: "1.0"},
+			Version:         "1.0.0",
+			Channels:        []v1alpha1.AppImageChannel{{Name: v1alpha1.AppImageChannelStable}},
+			DeployTemplates: []v1alpha1.DeployTemplate{{Name: "default", Role: "server"}},
+		},
+	}
+}
+
+func TestBuildPartnerMetadata(t *testing.T) {
+	visible := map[string]string{common.LabelAppImagePartnerVisible: "true"}
+	appImages := []*v1alpha1.AppImage{
+		makeAppImage("secretflow", visible, map[string]string{common.AppImageCapabilitiesAnnotationKey: "psi, ml"}),
+		makeAppImage("scql", visible, map[string]string{common.AppImageVisiblePartnersAnnotationKey: "carol"}),
+		makeAppImage("internal", nil, nil),
+	}
+
+	md := BuildPartnerMetadata("alice", "bob", appImages)
+	assert.Equal(t, "alice", md.DomainID)
+	assert.Len(t, md.Apps, 1)
+	app := md.FindApp("secretflow")
+	assert.NotNil(t, app)
+	assert.Equal(t, "1.0.0", app.Version)
+	assert.Equal(t, []string{"psi", "ml"}, app.Capabilities)
+	assert.Equal(t, []string{"stable"}, app.Channels)
+	assert.Equal(t, []string{"server"}, app.Roles)
+	assert.Nil(t, md.FindApp("scql"))
+
+	md = BuildPartnerMetadata("alice", "carol", appImages)
+	assert.Len(t, md.Apps, 2)
+	assert.Equal(t, "scql", md.Apps[0].Name)
+}
+
+func TestClientGet(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, utils.MetadataAppsPath, r.URL.Path)
+		assert.Equal(t, "alice", r.Header.Get("Kuscia-Source"))
+		switch r.Host {
+		case utils.GetMetadataHost("bob"):
+			body, _ := json.Marshal(&PartnerMetadata{DomainID: "bob", Apps: []AppInfo{{Name: "secretflow"}}})
+			_, _ = w.Write(body)
+		case utils.GetMetadataHost("carol"):
+			http.Error(w, "not found", http.StatusNotFound)
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	c := NewClient("alice", time.Minute)
+	c.server = server.URL
+	c.now = func() time.Time { return now }
+
+	md, err := c.Get(context.Background(), "bob")
+	assert.NoError(t, err)
+	assert.NotNil(t, md.FindApp("secretflow"))
+	_, err = c.Get(context.Background(), "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	_, err = c.Get(context.Background(), "carol")
+	assert.ErrorIs(t, err, ErrNotAdvertised)
+	_, err = c.Get(context.Background(), "carol")
+	assert.ErrorIs(t, err, ErrNotAdvertised)
+	assert.Equal(t, 2, requests)
+
+	// errors aren't cached
+	_, err = c.Get(context.Background(), "dave")
+	assert.Error(t, err)
+	_, err = c.Get(context.Background(), "dave")
+	assert.Error(t, err)
+	assert.Equal(t, 4, requests)
+
+	// expired
+	now = now.Add(2 * time.Minute)
+	_, err = c.Get(context.Background(), "bob")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, requests)
+}
@@ -45,7 +45,7 @@ func makeMockTask(namespace, name string) *v1alpha1.KusciaTask {
 func TestHandleUpdatedTask(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -100,7 +100,7 @@ func TestHandleDeletedTask(t *testing.T) {
 	domainBob.Spec.Role = v1alpha1.Partner
 	domainInformer.Informer().GetStore().Add(domainBob)
 
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
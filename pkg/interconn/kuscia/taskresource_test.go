@@ -45,7 +45,7 @@ func makeMockTaskResource(namespace, name string) *v1alpha1.TaskResource {
 func TestHandleUpdatedTaskResource(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := kusciaclientsetfake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -18,6 +18,7 @@ package kuscia
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -174,6 +175,11 @@ func updateTaskPartyStatus(task *v1alpha1.KusciaTask, taskSummary *v1alpha1.Kusc
 					task.Status.PartyTaskStatus[j].Message = pts.Message
 					updated = true
 				}
+
+				if !reflect.DeepEqual(pts.Pods, taskPts.Pods) {
+					task.Status.PartyTaskStatus[j].Pods = pts.Pods
+					updated = true
+				}
 			}
 		}
 
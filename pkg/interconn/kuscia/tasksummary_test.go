@@ -56,7 +56,7 @@ func makeMockTaskSummary(namespace, name, rv string) *v1alpha1.KusciaTaskSummary
 func TestHandleUpdatedTaskSummary(t *testing.T) {
 	t.Parallel()
 	kusciaFakeClient := fake.NewSimpleClientset()
-	c := NewController(context.Background(), nil, kusciaFakeClient, nil)
+	c := NewController(context.Background(), nil, kusciaFakeClient, nil, 0)
 	if c == nil {
 		t.Error("new controller failed")
 	}
@@ -205,6 +205,18 @@ func TestUpdateTaskPartyStatus(t *testing.T) {
 	assert.Equal(t, true, got)
 	assert.Equal(t, true, reflect.DeepEqual(kt.Status.PartyTaskStatus, kts.Status.PartyTaskStatus))
 
+	// pod-level progress in taskSummary changed, should return true.
+	kts.Status.PartyTaskStatus[0].Pods = []v1alpha1.PartyPodStatus{
+		{
+			Name:         "task-1-0",
+			Phase:        "Running",
+			RestartCount: 2,
+			LastEvent:    "CrashLoopBackOff",
+		},
+	}
+	got = updateTaskPartyStatus(kt, kts, domainIDs)
+	assert.Equal(t, true, got)
+	assert.Equal(t, true, reflect.DeepEqual(kt.Status.PartyTaskStatus, kts.Status.PartyTaskStatus))
 }
 
 func TestUpdateTaskResource(t *testing.T) {
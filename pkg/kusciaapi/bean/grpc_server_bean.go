@@ -19,8 +19,10 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
@@ -28,7 +30,9 @@ import (
 	"github.com/secretflow/kuscia/pkg/common"
 	cmservice "github.com/secretflow/kuscia/pkg/confmanager/service"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	apierrorcode "github.com/secretflow/kuscia/pkg/kusciaapi/errorcode"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/handler/grpchandler"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/middleware"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/service"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/utils"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
@@ -44,6 +48,9 @@ import (
 type grpcServerBean struct {
 	config          *config.KusciaAPIConfig
 	cmConfigService cmservice.IConfigService
+
+	serverMtx sync.Mutex
+	server    *grpc.Server
 }
 
 func NewGrpcServerBean(config *config.KusciaAPIConfig, cmConfigService cmservice.IConfigService) *grpcServerBean { // nolint: golint
@@ -65,9 +72,11 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 	// init grpc server opts
 	opts := []grpc.ServerOption{
 		grpc.ConnectionTimeout(time.Duration(s.config.ConnectTimeout) * time.Second),
-		grpc.ChainUnaryInterceptor(interceptor.UnaryRecoverInterceptor(pberrorcode.ErrorCode_KusciaAPIErrForUnexpected)),
+		grpc.ChainUnaryInterceptor(interceptor.UnaryRecoverInterceptor(pberrorcode.ErrorCode_KusciaAPIErrForUnexpected),
+			apierrorcode.GrpcServerErrorDetailInterceptor()),
 		grpc.StreamInterceptor(interceptor.StreamRecoverInterceptor(pberrorcode.ErrorCode_KusciaAPIErrForUnexpected)),
 		grpc.MaxRecvMsgSize(256 * 1024 * 1024), // 256MB
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	}
 	if s.config.TLS != nil {
 		serverTLSConfig, err := buildServerTLSConfig(s.config.TLS, s.config.Protocol)
@@ -92,9 +101,15 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 		if err != nil {
 			return err
 		}
-		tokenInterceptor := grpc.ChainUnaryInterceptor(interceptor.GrpcServerTokenInterceptor(token))
+		tenantTokens, err := utils.ReadTenantTokens(s.config.Tenants, token)
+		if err != nil {
+			return err
+		}
+		tokenInterceptor := grpc.ChainUnaryInterceptor(interceptor.GrpcServerTenantTokenInterceptor(token, tenantTokens),
+			middleware.GrpcTenantPermissionInterceptor())
 		opts = append(opts, tokenInterceptor)
-		tokenStreamInterceptor := grpc.ChainStreamInterceptor(interceptor.GrpcStreamServerTokenInterceptor(token))
+		tokenStreamInterceptor := grpc.ChainStreamInterceptor(interceptor.GrpcStreamServerTenantTokenInterceptor(token, tenantTokens),
+			middleware.GrpcStreamTenantPermissionInterceptor())
 		opts = append(opts, tokenStreamInterceptor)
 	}
 	// set master role interceptor
@@ -103,6 +118,9 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 
 	// register grpc server
 	server := grpc.NewServer(opts...)
+	s.serverMtx.Lock()
+	s.server = server
+	s.serverMtx.Unlock()
 	kusciaapi.RegisterJobServiceServer(server, grpchandler.NewJobHandler(service.NewJobService(s.config)))
 	kusciaapi.RegisterDomainServiceServer(server, grpchandler.NewDomainHandler(service.NewDomainService(s.config)))
 	kusciaapi.RegisterDomainRouteServiceServer(server, grpchandler.NewDomainRouteHandler(service.NewDomainRouteService(s.config)))
@@ -111,10 +129,11 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 	kusciaapi.RegisterDomainDataSourceServiceServer(server, grpchandler.NewDomainDataSourceHandler(service.NewDomainDataSourceService(s.config, s.cmConfigService)))
 	kusciaapi.RegisterServingServiceServer(server, grpchandler.NewServingHandler(service.NewServingService(s.config)))
 	kusciaapi.RegisterDomainDataGrantServiceServer(server, grpchandler.NewDomainDataGrantHandler(service.NewDomainDataGrantService(s.config)))
-	kusciaapi.RegisterCertificateServiceServer(server, grpchandler.NewCertificateHandler(newCertService(s.config)))
+	kusciaapi.RegisterCertificateServiceServer(server, grpchandler.NewCertificateHandler(newCertService(s.config), service.NewCertificateService(s.config)))
 	kusciaapi.RegisterConfigServiceServer(server, grpchandler.NewConfigHandler(service.NewConfigService(s.config, s.cmConfigService)))
 	kusciaapi.RegisterAppImageServiceServer(server, grpchandler.NewAppImageHandler(service.NewAppImageService(s.config)))
 	kusciaapi.RegisterLogServiceServer(server, grpchandler.NewLogHandler(service.NewLogService(s.config)))
+	kusciaapi.RegisterBackupServiceServer(server, grpchandler.NewBackupHandler(service.NewBackupService(s.config)))
 
 	reflection.Register(server)
 	nlog.Infof("grpc server listening on %s", addr)
@@ -123,6 +142,28 @@ func (s *grpcServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 	return server.Serve(lis)
 }
 
+// Drain stops accepting new rpcs and waits for the pending ones, rpcs still running when ctx is done are canceled.
+func (s *grpcServerBean) Drain(ctx context.Context) error {
+	s.serverMtx.Lock()
+	server := s.server
+	s.serverMtx.Unlock()
+	if server == nil {
+		return nil
+	}
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return ctx.Err()
+	}
+}
+
 func (s *grpcServerBean) ServerName() string {
 	return "kusciaAPIGrpcServer"
 }
@@ -18,9 +18,12 @@ package bean
 import (
 	"context"
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -29,7 +32,10 @@ import (
 	"github.com/secretflow/kuscia/pkg/confmanager/handler/httphandler/certificate"
 	cmservice "github.com/secretflow/kuscia/pkg/confmanager/service"
 	apiconfig "github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	apierrorcode "github.com/secretflow/kuscia/pkg/kusciaapi/errorcode"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/appimage"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/backup"
+	kacertificate "github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/certificate"
 	handlerconfig "github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/config"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/domain"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/handler/httphandler/domaindata"
@@ -53,8 +59,10 @@ import (
 	frameworkconfig "github.com/secretflow/kuscia/pkg/web/framework/config"
 	"github.com/secretflow/kuscia/pkg/web/framework/router"
 	"github.com/secretflow/kuscia/pkg/web/interceptor"
+	"github.com/secretflow/kuscia/pkg/web/openapi"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1"
 	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
 )
 
 type httpServerBean struct {
@@ -113,8 +121,9 @@ func (s *httpServerBean) externalGinInit(e framework.ConfBeanRegistry) error {
 	if err := s.externalGinBean.Init(e); err != nil {
 		return err
 	}
-	// recover middleware
-	s.externalGinBean.Use(gin.Recovery(), interceptor.HTTPServerLoggingInterceptor(*s.config.InterceptorLog))
+	// recovery, request id and body limit
+	interceptor.UseHTTPStandardInterceptors(s.externalGinBean.Engine, interceptor.HTTPMiddlewareConfig{})
+	s.externalGinBean.Use(interceptor.HTTPServerLoggingInterceptor(*s.config.InterceptorLog))
 	// auth token
 	tokenConfig := s.config.Token
 	if tokenConfig != nil {
@@ -122,9 +131,17 @@ func (s *httpServerBean) externalGinInit(e framework.ConfBeanRegistry) error {
 		if err != nil {
 			return err
 		}
-		s.externalGinBean.Use(interceptor.HTTPTokenAuthInterceptor(token))
+		tenantTokens, err := utils.ReadTenantTokens(s.config.Tenants, token)
+		if err != nil {
+			return err
+		}
+		s.externalGinBean.Use(interceptor.HTTPTenantTokenAuthInterceptor(token, tenantTokens))
+	} else if len(s.config.Tenants) > 0 {
+		nlog.Warnf("Tenants are ignored because token auth of KusciaAPI is disabled")
 	}
 	s.externalGinBean.Use(interceptor.HTTPSetMasterRoleInterceptor())
+	// tenants could only access the apis granted to tenant role
+	s.externalGinBean.Use(middleware.TenantPermissionMiddleWare)
 	s.registerGroupRoutes(e, s.externalGinBean)
 	return nil
 }
@@ -133,8 +150,8 @@ func (s *httpServerBean) internalGinInit(e framework.ConfBeanRegistry) error {
 	if err := s.internalGinBean.Init(e); err != nil {
 		return err
 	}
-	// recover middleware
-	s.internalGinBean.Use(gin.Recovery())
+	// recovery, request id and body limit
+	interceptor.UseHTTPStandardInterceptors(s.internalGinBean.Engine, interceptor.HTTPMiddlewareConfig{})
 	// auth Kuscia-Source header
 	s.internalGinBean.Use(interceptor.HTTPSourceAuthInterceptor())
 	// casbin permission
@@ -155,10 +172,24 @@ func (s *httpServerBean) Start(ctx context.Context, e framework.ConfBeanRegistry
 		errChan <- err
 	}()
 	err := <-errChan
-	nlog.Errorf("httpServerBean start failed, error:%s", err.Error())
+	if err != nil {
+		nlog.Errorf("httpServerBean start failed, error:%s", err.Error())
+	}
 	return err
 }
 
+// Drain shuts down the external and internal http servers gracefully.
+func (s *httpServerBean) Drain(ctx context.Context) error {
+	errChan := make(chan error, 2)
+	go func() {
+		errChan <- s.externalGinBean.Drain(ctx)
+	}()
+	go func() {
+		errChan <- s.internalGinBean.Drain(ctx)
+	}()
+	return errors.Join(<-errChan, <-errChan)
+}
+
 func (s *httpServerBean) ServerName() string {
 	return "kusciaAPIHttpServer"
 }
@@ -174,329 +205,137 @@ func (s *httpServerBean) registerGroupRoutes(e framework.ConfBeanRegistry, bean
 	appImageService := service.NewAppImageService(s.config)
 	healthService := service.NewHealthService()
 	certService := newCertService(s.config)
+	certificateService := service.NewCertificateService(s.config)
 	configService := service.NewConfigService(s.config, s.cmConfigService)
 	logService := service.NewLogService(s.config)
+	backupService := service.NewBackupService(s.config)
 	// define router groups
 	groupsRouters := []*router.GroupRouters{
 		// job group routes
 		{
 			Group: "api/v1/job",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewCreateJobHandler(jobService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewDeleteJobHandler(jobService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewQueryJobHandler(jobService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "stop",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewStopJobHandler(jobService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "status/batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewBatchQueryJobStatusHandler(jobService))},
-				},
+				protoRouter(e, http.MethodPost, "create", job.NewCreateJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "delete", job.NewDeleteJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "query", job.NewQueryJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "stop", job.NewStopJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "status/batchQuery", job.NewBatchQueryJobStatusHandler(jobService)),
 				{
 					HTTPMethod:   http.MethodPost,
 					RelativePath: "watch",
 					Handlers:     []gin.HandlerFunc{job.NewWatchJobHandler(jobService).Handle},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "approve",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewApproveJobHandler(jobService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "suspend",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewSuspendJobHandler(jobService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "restart",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewRestartJobHandler(jobService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "cancel",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, job.NewCancelJobHandler(jobService))},
-				},
+					RequestType:  reflect.TypeOf(kusciaapi.WatchJobRequest{}),
+					ResponseType: reflect.TypeOf(kusciaapi.WatchJobEventResponse{}),
+				},
+				protoRouter(e, http.MethodPost, "approve", job.NewApproveJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "approval/pending", job.NewListPendingApprovalsHandler(jobService)),
+				protoRouter(e, http.MethodPost, "suspend", job.NewSuspendJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "restart", job.NewRestartJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "cancel", job.NewCancelJobHandler(jobService)),
+				protoRouter(e, http.MethodPost, "resourceUsage/query", job.NewQueryJobResourceUsageHandler(jobService)),
+				protoRouter(e, http.MethodPost, "history/query", job.NewQueryJobHistoryHandler(jobService)),
 			},
 		},
 		// domain group routes
 		{
 			Group: "api/v1/domain",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domain.NewCreateDomainHandler(domainService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domain.NewDeleteDomainHandler(domainService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domain.NewUpdateDomainHandler(domainService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domain.NewQueryDomainHandler(domainService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domain.NewBatchQueryDomainHandler(domainService))},
-				},
+				protoRouter(e, http.MethodPost, "create", domain.NewCreateDomainHandler(domainService)),
+				protoRouter(e, http.MethodPost, "delete", domain.NewDeleteDomainHandler(domainService)),
+				protoRouter(e, http.MethodPost, "update", domain.NewUpdateDomainHandler(domainService)),
+				protoRouter(e, http.MethodPost, "query", domain.NewQueryDomainHandler(domainService)),
+				protoRouter(e, http.MethodPost, "batchQuery", domain.NewBatchQueryDomainHandler(domainService)),
+				protoRouter(e, http.MethodPost, "status/query", domain.NewQueryDomainStatusDetailHandler(domainService)),
 			},
 		},
 		// domain route routes
 		{
 			Group: "api/v1/route",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domainroute.NewCreateDomainRouteHandler(routeService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domainroute.NewDeleteDomainHandler(routeService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domainroute.NewQueryDomainRouteHandler(routeService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "status/batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domainroute.NewBatchQueryDomainRouteStatusHandler(routeService))},
-				},
+				protoRouter(e, http.MethodPost, "create", domainroute.NewCreateDomainRouteHandler(routeService)),
+				protoRouter(e, http.MethodPost, "delete", domainroute.NewDeleteDomainHandler(routeService)),
+				protoRouter(e, http.MethodPost, "query", domainroute.NewQueryDomainRouteHandler(routeService)),
+				protoRouter(e, http.MethodPost, "status/batchQuery", domainroute.NewBatchQueryDomainRouteStatusHandler(routeService)),
+				protoRouter(e, http.MethodPost, "transfer/query", domainroute.NewQueryDomainRouteTransferHandler(routeService)),
 			},
 		},
 		// domainData group routes
 		{
 			Group: "api/v1/domaindata",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewCreateDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewUpdateDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewDeleteDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewQueryDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewBatchQueryDomainDataHandler(domainDataService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "list",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindata.NewListDomainDataHandler(domainDataService))},
-				},
+				protoRouter(e, http.MethodPost, "create", domaindata.NewCreateDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "update", domaindata.NewUpdateDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "delete", domaindata.NewDeleteDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "query", domaindata.NewQueryDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "batchQuery", domaindata.NewBatchQueryDomainDataHandler(domainDataService)),
+				protoRouter(e, http.MethodPost, "list", domaindata.NewListDomainDataHandler(domainDataService)),
 			},
 		},
 		// domainDataSource routes
 		{
 			Group: "api/v1/domaindatasource",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatasource.NewCreateDomainDataSourceHandler(domainDataSourceService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatasource.NewUpdateDomainDataSourceHandler(domainDataSourceService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatasource.NewDeleteDomainDataSourceHandler(domainDataSourceService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatasource.NewQueryDomainDataSourceHandler(domainDataSourceService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatasource.NewBatchQueryDomainDataSourceHandler(domainDataSourceService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "list",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatasource.NewListDomainDataSourceHandler(domainDataSourceService))},
-				},
+				protoRouter(e, http.MethodPost, "create", domaindatasource.NewCreateDomainDataSourceHandler(domainDataSourceService)),
+				protoRouter(e, http.MethodPost, "update", domaindatasource.NewUpdateDomainDataSourceHandler(domainDataSourceService)),
+				protoRouter(e, http.MethodPost, "delete", domaindatasource.NewDeleteDomainDataSourceHandler(domainDataSourceService)),
+				protoRouter(e, http.MethodPost, "query", domaindatasource.NewQueryDomainDataSourceHandler(domainDataSourceService)),
+				protoRouter(e, http.MethodPost, "batchQuery", domaindatasource.NewBatchQueryDomainDataSourceHandler(domainDataSourceService)),
+				protoRouter(e, http.MethodPost, "list", domaindatasource.NewListDomainDataSourceHandler(domainDataSourceService)),
+				protoRouter(e, http.MethodPost, "test", domaindatasource.NewTestDomainDataSourceHandler(domainDataSourceService)),
 			},
 		},
 		// serving group routes
 		{
 			Group: "api/v1/serving",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, serving.NewCreateServingHandler(servingService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, serving.NewUpdateServingHandler(servingService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, serving.NewDeleteServingHandler(servingService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, serving.NewQueryServingHandler(servingService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "status/batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, serving.NewBatchQueryServingStatusHandler(servingService))},
-				},
+				protoRouter(e, http.MethodPost, "create", serving.NewCreateServingHandler(servingService)),
+				protoRouter(e, http.MethodPost, "update", serving.NewUpdateServingHandler(servingService)),
+				protoRouter(e, http.MethodPost, "delete", serving.NewDeleteServingHandler(servingService)),
+				protoRouter(e, http.MethodPost, "query", serving.NewQueryServingHandler(servingService)),
+				protoRouter(e, http.MethodPost, "status/batchQuery", serving.NewBatchQueryServingStatusHandler(servingService)),
+				protoRouter(e, http.MethodPost, "revision/create", serving.NewCreateServingRevisionHandler(servingService)),
+				protoRouter(e, http.MethodPost, "traffic/switch", serving.NewSwitchServingTrafficHandler(servingService)),
+				protoRouter(e, http.MethodPost, "traffic/rollback", serving.NewRollbackServingTrafficHandler(servingService)),
 			},
 		},
 		{
 			Group: "api/v1/domaindatagrant",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewCreateDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewUpdateDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewDeleteDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewQueryDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewBatchQueryDomainDataGrantHandler(domainDataGrantService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "list",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, domaindatagrant.NewListDomainDataGrantHandler(domainDataGrantService))},
-				},
+				protoRouter(e, http.MethodPost, "create", domaindatagrant.NewCreateDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "update", domaindatagrant.NewUpdateDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "delete", domaindatagrant.NewDeleteDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "query", domaindatagrant.NewQueryDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "batchQuery", domaindatagrant.NewBatchQueryDomainDataGrantHandler(domainDataGrantService)),
+				protoRouter(e, http.MethodPost, "list", domaindatagrant.NewListDomainDataGrantHandler(domainDataGrantService)),
 			},
 		},
 		{
 			Group: "api/v1/certificate",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "generate",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, certificate.NewGenerateKeyCertsHandler(certService))},
-				},
+				protoRouter(e, http.MethodPost, "generate", certificate.NewGenerateKeyCertsHandler(certService)),
+				protoRouter(e, http.MethodPost, "list", kacertificate.NewListCertificatesHandler(certificateService)),
+				protoRouter(e, http.MethodPost, "rotate", kacertificate.NewRotateCertificateHandler(certificateService)),
 			},
 		},
 		{
 			Group: "api/v1/config",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, handlerconfig.NewCreateConfigHandler(configService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, handlerconfig.NewQueryConfigHandler(configService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, handlerconfig.NewUpdateConfigHandler(configService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, handlerconfig.NewDeleteConfigHandler(configService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, handlerconfig.NewBatchQueryConfigHandler(configService))},
-				},
+				protoRouter(e, http.MethodPost, "create", handlerconfig.NewCreateConfigHandler(configService)),
+				protoRouter(e, http.MethodPost, "query", handlerconfig.NewQueryConfigHandler(configService)),
+				protoRouter(e, http.MethodPost, "update", handlerconfig.NewUpdateConfigHandler(configService)),
+				protoRouter(e, http.MethodPost, "delete", handlerconfig.NewDeleteConfigHandler(configService)),
+				protoRouter(e, http.MethodPost, "batchQuery", handlerconfig.NewBatchQueryConfigHandler(configService)),
 			},
 		},
 		{
 			Group: "api/v1/appimage",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "create",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, appimage.NewCreateAppImageHandler(appImageService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "update",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, appimage.NewUpdateAppImageHandler(appImageService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "delete",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, appimage.NewDeleteAppImageHandler(appImageService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, appimage.NewQueryAppImageHandler(appImageService))},
-				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "batchQuery",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, appimage.NewBatchQueryAppImageHandler(appImageService))},
-				},
+				protoRouter(e, http.MethodPost, "create", appimage.NewCreateAppImageHandler(appImageService)),
+				protoRouter(e, http.MethodPost, "update", appimage.NewUpdateAppImageHandler(appImageService)),
+				protoRouter(e, http.MethodPost, "delete", appimage.NewDeleteAppImageHandler(appImageService)),
+				protoRouter(e, http.MethodPost, "query", appimage.NewQueryAppImageHandler(appImageService)),
+				protoRouter(e, http.MethodPost, "batchQuery", appimage.NewBatchQueryAppImageHandler(appImageService)),
+				protoRouter(e, http.MethodPost, "validate", appimage.NewValidateAppImageHandler(appImageService)),
 			},
 		},
 		{
@@ -506,23 +345,24 @@ func (s *httpServerBean) registerGroupRoutes(e framework.ConfBeanRegistry, bean
 					HTTPMethod:   http.MethodPost,
 					RelativePath: "task/query",
 					Handlers:     []gin.HandlerFunc{log.NewQueryHandler(logService).Handle},
+					RequestType:  reflect.TypeOf(kusciaapi.QueryLogRequest{}),
+					ResponseType: reflect.TypeOf(kusciaapi.QueryLogResponse{}),
 				},
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: "node/query",
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, log.NewQueryPodNodeHandler(logService))},
-				},
+				protoRouter(e, http.MethodPost, "node/query", log.NewQueryPodNodeHandler(logService)),
+			},
+		},
+		{
+			Group: "api/v1/backup",
+			Routes: []*router.Router{
+				protoRouter(e, http.MethodPost, "create", backup.NewCreateBackupHandler(backupService)),
+				protoRouter(e, http.MethodPost, "list", backup.NewListBackupHandler(backupService)),
 			},
 		},
 		// health group routes
 		{
 			Group: "",
 			Routes: []*router.Router{
-				{
-					HTTPMethod:   http.MethodPost,
-					RelativePath: constants.HealthAPI,
-					Handlers:     []gin.HandlerFunc{protoDecorator(e, health.NewReadyHandler(healthService))},
-				},
+				protoRouter(e, http.MethodPost, constants.HealthAPI, health.NewReadyHandler(healthService)),
 			},
 		},
 	}
@@ -534,6 +374,18 @@ func (s *httpServerBean) registerGroupRoutes(e framework.ConfBeanRegistry, bean
 			group.Handle(route.HTTPMethod, route.RelativePath, route.Handlers...)
 		}
 	}
+	bean.GET(openapi.Path, openapi.Handler(openapi.Options{
+		Info:          openapi.Info{Title: "Kuscia API", Version: "v1"},
+		TokenHeader:   s.tokenHeader(),
+		UseProtoNames: true,
+	}, groupsRouters))
+}
+
+func (s *httpServerBean) tokenHeader() string {
+	if s.config.Token == nil {
+		return ""
+	}
+	return constants.TokenHeader
 }
 
 func newCertService(config *apiconfig.KusciaAPIConfig) cmservice.ICertificateService {
@@ -557,9 +409,35 @@ func newCertService(config *apiconfig.KusciaAPIConfig) cmservice.ICertificateSer
 	return certService
 }
 
+// protoRouteTimeout is the timeout of routes served by proto handlers, streaming routes such as job/watch have no
+// timeout, that's why the write timeout of server is zero.
+const protoRouteTimeout = 60 * time.Second
+
+// protoRouter returns the route served by a proto handler.
+func protoRouter(e framework.ConfBeanRegistry, method, path string, handler api.ProtoHandler) *router.Router {
+	reqType, respType := handler.GetType()
+	return &router.Router{
+		HTTPMethod:   method,
+		RelativePath: path,
+		Handlers:     []gin.HandlerFunc{interceptor.HTTPTimeoutInterceptor(protoRouteTimeout), protoDecorator(e, handler)},
+		RequestType:  reqType,
+		ResponseType: respType,
+	}
+}
+
 // protoDecorator is used to wrap handler.
 func protoDecorator(e framework.ConfBeanRegistry, handler api.ProtoHandler) gin.HandlerFunc {
-	return decorator.CustomProtoDecoratorMaker(setKusciaAPIErrorResp(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate), setKusciaAPIErrorResp(pberrorcode.ErrorCode_KusciaAPIErrForUnexpected))(e, handler)
+	return decorator.ProtoDecorator(e, handler, &decorator.ProtoDecoratorOptions{
+		ValidateFailedHandler:   setKusciaAPIErrorResp(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate),
+		UnexpectedErrorHandler:  setKusciaAPIErrorResp(pberrorcode.ErrorCode_KusciaAPIErrForUnexpected),
+		PostProcessHandler:      fillErrorDetail,
+		RenderJSONUseProtoNames: true,
+	})
+}
+
+// fillErrorDetail attaches the error detail in the language of Accept-Language header to failed responses.
+func fillErrorDetail(response api.ProtoResponse, bizContext *api.BizContext) {
+	apierrorcode.FillResponseErrorDetail(response, bizContext.Context.GetHeader(apierrorcode.AcceptLanguageHeader))
 }
 
 func setKusciaAPIErrorResp(errCode pberrorcode.ErrorCode) func(flow *decorator.BizFlow, errs *errorcode.Errs) (response api.ProtoResponse) {
@@ -572,6 +450,7 @@ func setKusciaAPIErrorResp(errCode pberrorcode.ErrorCode) func(flow *decorator.B
 				Message: wrappedErr.Error(),
 			},
 		}
+		apierrorcode.FillErrorDetail(resp.Status, flow.BizContext.Context.GetHeader(apierrorcode.AcceptLanguageHeader))
 		bytes, _ := protojson.Marshal(&resp)
 		response = &api.AnyStringProto{
 			Content: string(bytes),
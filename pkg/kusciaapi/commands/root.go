@@ -32,13 +32,31 @@ import (
 )
 
 func Run(ctx context.Context, kusciaAPIConfig *config.KusciaAPIConfig, kusciaClient kusciaclientset.Interface, kubeClient kubernetes.Interface) error {
-	// new app engine
-	appEngine := engine.New(&framework.AppConfig{
-		Name:    "KusciaAPI",
-		Usage:   "KusciaAPI",
-		Version: meta.KusciaVersionString(),
-	})
+	return NewServer().Run(ctx, kusciaAPIConfig, kusciaClient, kubeClient)
+}
+
+// Server is the KusciaAPI app, it can be drained before exit.
+type Server struct {
+	appEngine *engine.Engine
+}
+
+func NewServer() *Server {
+	return &Server{
+		appEngine: engine.New(&framework.AppConfig{
+			Name:    "KusciaAPI",
+			Usage:   "KusciaAPI",
+			Version: meta.KusciaVersionString(),
+		}),
+	}
+}
+
+// Drain stops accepting new calls of http and grpc servers, and waits for the in-flight calls.
+func (s *Server) Drain(ctx context.Context) error {
+	return s.appEngine.Drain(ctx)
+}
 
+func (s *Server) Run(ctx context.Context, kusciaAPIConfig *config.KusciaAPIConfig, kusciaClient kusciaclientset.Interface, kubeClient kubernetes.Interface) error {
+	appEngine := s.appEngine
 	kusciaAPIConfig.KubeClient = kubeClient
 	kusciaAPIConfig.KusciaClient = kusciaClient
 
@@ -22,8 +22,11 @@ import (
 
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/secretflow/kuscia/pkg/backup"
+	"github.com/secretflow/kuscia/pkg/certmanager"
 	"github.com/secretflow/kuscia/pkg/common"
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/web/framework/config"
 )
@@ -39,6 +42,7 @@ type KusciaAPIConfig struct {
 	Initiator        string                    `yaml:"initiator,omitempty"`
 	Protocol         common.Protocol           `yaml:"protocol"`
 	Token            *TokenConfig              `yaml:"token"`
+	Tenants          []TenantConfig            `yaml:"tenants,omitempty"`
 	WriteTimeout     int                       `yaml:"-"`
 	TLS              *config.TLSServerConfig   `yaml:"-"`
 	DomainKey        *rsa.PrivateKey           `yaml:"-"`
@@ -53,12 +57,22 @@ type KusciaAPIConfig struct {
 	InterceptorLog   *nlog.NLog                `yaml:"-"`
 	StdoutPath       string                    `yaml:"-"`
 	NodeName         string                    `yaml:"-"`
+	JobHistory       jobhistory.Store          `yaml:"-"`
+	Backup           *backup.Manager           `yaml:"-"`
+	CertManager      *certmanager.Manager      `yaml:"-"`
 }
 
 type TokenConfig struct {
 	TokenFile string
 }
 
+// TenantConfig is a tenant inside the domain, requests with the token of tenant could only access the jobs and data
+// of the tenant.
+type TenantConfig struct {
+	Name      string `yaml:"name"`
+	TokenFile string `yaml:"tokenFile"`
+}
+
 func NewDefaultKusciaAPIConfig(rootDir string) *KusciaAPIConfig {
 	return &KusciaAPIConfig{
 		HTTPPort:         8082,
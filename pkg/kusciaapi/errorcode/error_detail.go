@@ -0,0 +1,172 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorcode
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+)
+
+const (
+	// LanguageEnUS is the default language of error details.
+	LanguageEnUS = "en-US"
+	LanguageZhCN = "zh-CN"
+
+	// AcceptLanguageHeader selects the language of error details, grpc clients set it as metadata.
+	AcceptLanguageHeader = "Accept-Language"
+)
+
+//go:embed i18n/errorcode.*.toml
+var i18nFS embed.FS
+
+var (
+	// languages and languageTags are in the same order, the first one is the default.
+	languages       = []string{LanguageEnUS, LanguageZhCN}
+	languageTags    = []language.Tag{language.AmericanEnglish, language.SimplifiedChinese}
+	languageMatcher = language.NewMatcher(languageTags)
+
+	catalogs = mustLoadCatalogs()
+)
+
+func mustLoadCatalogs() map[string]map[string]string {
+	result := make(map[string]map[string]string, len(languages))
+	for _, lang := range languages {
+		content, err := i18nFS.ReadFile(fmt.Sprintf("i18n/errorcode.%s.toml", lang))
+		if err != nil {
+			panic(fmt.Sprintf("read error code catalog of %s failed, %v", lang, err))
+		}
+		catalog := map[string]string{}
+		if err := toml.Unmarshal(content, &catalog); err != nil {
+			panic(fmt.Sprintf("parse error code catalog of %s failed, %v", lang, err))
+		}
+		result[lang] = catalog
+	}
+	return result
+}
+
+// MatchLanguage returns the supported language that best matches the Accept-Language value.
+func MatchLanguage(acceptLanguage string) string {
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return LanguageEnUS
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return LanguageEnUS
+	}
+	_, index, confidence := languageMatcher.Match(tags...)
+	if confidence == language.No {
+		return LanguageEnUS
+	}
+	return languages[index]
+}
+
+// GetErrorDetail returns the error detail of code in the language, messages missing in the language fall back
+// to the default language. It returns nil if the code is not a known error code.
+func GetErrorDetail(code int32, lang string) *errorcode.ErrorDetail {
+	reason, ok := errorcode.ErrorCode_name[code]
+	if !ok || code == int32(errorcode.ErrorCode_SUCCESS) {
+		return nil
+	}
+	detail := &errorcode.ErrorDetail{
+		Reason: reason,
+		Code:   code,
+	}
+	descriptionKey := fmt.Sprintf("error_code_%d_description", code)
+	solutionKey := fmt.Sprintf("error_code_%d_solution", code)
+	for _, l := range []string{lang, LanguageEnUS} {
+		if description := catalogs[l][descriptionKey]; description != "" {
+			detail.Description = description
+			detail.Solution = catalogs[l][solutionKey]
+			detail.Language = l
+			break
+		}
+	}
+	return detail
+}
+
+// FillErrorDetail attaches the error detail to the details of a failed status, an existing error detail is replaced.
+func FillErrorDetail(status *v1alpha1.Status, acceptLanguage string) {
+	if status == nil || status.Code == int32(errorcode.ErrorCode_SUCCESS) {
+		return
+	}
+	detail := GetErrorDetail(status.Code, MatchLanguage(acceptLanguage))
+	if detail == nil {
+		return
+	}
+	anyDetail, err := anypb.New(detail)
+	if err != nil {
+		return
+	}
+	details := make([]*anypb.Any, 0, len(status.Details)+1)
+	for _, d := range status.Details {
+		if !d.MessageIs(detail) {
+			details = append(details, d)
+		}
+	}
+	status.Details = append(details, anyDetail)
+}
+
+// FillResponseErrorDetail attaches the error detail to the status field of the response if it has one.
+func FillResponseErrorDetail(response proto.Message, acceptLanguage string) {
+	if response == nil {
+		return
+	}
+	m := response.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName("status")
+	if fd == nil || fd.Message() == nil || !m.Has(fd) {
+		return
+	}
+	if status, ok := m.Get(fd).Message().Interface().(*v1alpha1.Status); ok {
+		FillErrorDetail(status, acceptLanguage)
+	}
+}
+
+// GrpcServerErrorDetailInterceptor attaches the error detail to the status of failed responses, the language is
+// selected by the Accept-Language metadata.
+func GrpcServerErrorDetailInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if msg, ok := resp.(proto.Message); ok {
+			FillResponseErrorDetail(msg, acceptLanguageFromContext(ctx))
+		}
+		return resp, nil
+	}
+}
+
+func acceptLanguageFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(AcceptLanguageHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorcode
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func TestCatalogsComplete(t *testing.T) {
+	for code, name := range errorcode.ErrorCode_name {
+		if !strings.HasPrefix(name, "KusciaAPIErr") {
+			continue
+		}
+		for _, lang := range languages {
+			detail := GetErrorDetail(code, lang)
+			assert.Equal(t, lang, detail.Language, "%s of %s", name, lang)
+			assert.NotEmpty(t, detail.Solution, "%s of %s", name, lang)
+		}
+	}
+}
+
+func TestMatchLanguage(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", LanguageEnUS},
+		{"zh-CN", LanguageZhCN},
+		{"zh", LanguageZhCN},
+		{"zh-CN,zh;q=0.9,en;q=0.8", LanguageZhCN},
+		{"en-GB,zh;q=0.5", LanguageEnUS},
+		{"fr-FR", LanguageEnUS},
+		{"!invalid", LanguageEnUS},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchLanguage(tt.acceptLanguage), tt.acceptLanguage)
+	}
+}
+
+func TestGetErrorDetail(t *testing.T) {
+	detail := GetErrorDetail(int32(errorcode.ErrorCode_KusciaAPIErrDomainNotExists), LanguageZhCN)
+	assert.Equal(t, "KusciaAPIErrDomainNotExists", detail.Reason)
+	assert.Equal(t, int32(11305), detail.Code)
+	assert.Equal(t, "节点不存在异常", detail.Description)
+	assert.Equal(t, LanguageZhCN, detail.Language)
+
+	// codes without catalog entries only carry the reason
+	detail = GetErrorDetail(int32(errorcode.ErrorCode_DataMeshErrForUnexpected), LanguageZhCN)
+	assert.Equal(t, "DataMeshErrForUnexpected", detail.Reason)
+	assert.Empty(t, detail.Description)
+	assert.Empty(t, detail.Language)
+
+	assert.Nil(t, GetErrorDetail(0, LanguageEnUS))
+	assert.Nil(t, GetErrorDetail(99999, LanguageEnUS))
+}
+
+func TestFillErrorDetail(t *testing.T) {
+	other, _ := anypb.New(&v1alpha1.RequestHeader{})
+	status := &v1alpha1.Status{
+		Code:    int32(errorcode.ErrorCode_KusciaAPIErrRequestValidate),
+		Message: "domain id can not be empty",
+		Details: []*anypb.Any{other},
+	}
+	FillErrorDetail(status, "zh-CN")
+	FillErrorDetail(status, "en-US")
+	assert.Equal(t, "domain id can not be empty", status.Message)
+	assert.Len(t, status.Details, 2)
+	detail := &errorcode.ErrorDetail{}
+	assert.NoError(t, status.Details[1].UnmarshalTo(detail))
+	assert.Equal(t, "KusciaAPIErrRequestValidate", detail.Reason)
+	assert.Equal(t, LanguageEnUS, detail.Language)
+
+	success := &v1alpha1.Status{}
+	FillErrorDetail(success, "en-US")
+	assert.Empty(t, success.Details)
+}
+
+func TestGrpcServerErrorDetailInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "zh"))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &kusciaapi.QueryDomainResponse{Status: &v1alpha1.Status{Code: int32(errorcode.ErrorCode_KusciaAPIErrQueryDomain)}}, nil
+	}
+	resp, err := GrpcServerErrorDetailInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+	status := resp.(*kusciaapi.QueryDomainResponse).Status
+	assert.Len(t, status.Details, 1)
+	detail := &errorcode.ErrorDetail{}
+	assert.NoError(t, status.Details[0].UnmarshalTo(detail))
+	assert.Equal(t, "查询节点失败", detail.Description)
+
+	// responses without status are left untouched
+	resp, err = GrpcServerErrorDetailInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &v1alpha1.RequestHeader{}, nil
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+}
@@ -52,3 +52,7 @@ func (h appImageHandler) DeleteAppImage(ctx context.Context, request *kusciaapi.
 func (h appImageHandler) BatchQueryAppImage(ctx context.Context, request *kusciaapi.BatchQueryAppImageRequest) (*kusciaapi.BatchQueryAppImageResponse, error) {
 	return h.appImageService.BatchQueryAppImage(ctx, request), nil
 }
+
+func (h appImageHandler) ValidateAppImage(ctx context.Context, request *kusciaapi.ValidateAppImageRequest) (*kusciaapi.ValidateAppImageResponse, error) {
+	return h.appImageService.ValidateAppImage(ctx, request), nil
+}
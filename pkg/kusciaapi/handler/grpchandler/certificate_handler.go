@@ -18,7 +18,8 @@ import (
 	"context"
 	"encoding/json"
 
-	"github.com/secretflow/kuscia/pkg/confmanager/service"
+	cmservice "github.com/secretflow/kuscia/pkg/confmanager/service"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/service"
 	"github.com/secretflow/kuscia/pkg/web/utils"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/confmanager"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
@@ -27,13 +28,16 @@ import (
 
 // certificateHandler GRPC Handler
 type certificateHandler struct {
-	certificateService service.ICertificateService
+	certificateService cmservice.ICertificateService
+	// certManagementService lists and rotates the certificates used by kuscia.
+	certManagementService service.ICertificateService
 	kusciaapi.UnimplementedCertificateServiceServer
 }
 
-func NewCertificateHandler(certService service.ICertificateService) kusciaapi.CertificateServiceServer {
+func NewCertificateHandler(certService cmservice.ICertificateService, certManagementService service.ICertificateService) kusciaapi.CertificateServiceServer {
 	return &certificateHandler{
-		certificateService: certService,
+		certificateService:    certService,
+		certManagementService: certManagementService,
 	}
 }
 
@@ -54,6 +58,14 @@ func (h *certificateHandler) GenerateKeyCerts(ctx context.Context, request *kusc
 	return kapiResp, nil
 }
 
+func (h *certificateHandler) ListCertificates(ctx context.Context, request *kusciaapi.ListCertificatesRequest) (*kusciaapi.ListCertificatesResponse, error) {
+	return h.certManagementService.ListCertificates(ctx, request), nil
+}
+
+func (h *certificateHandler) RotateCertificate(ctx context.Context, request *kusciaapi.RotateCertificateRequest) (*kusciaapi.RotateCertificateResponse, error) {
+	return h.certManagementService.RotateCertificate(ctx, request), nil
+}
+
 func CopyValue(src interface{}, dst interface{}) error {
 	jsonBytes, _ := json.Marshal(src)
 	return json.Unmarshal(jsonBytes, dst)
@@ -52,3 +52,7 @@ func (h domainHandler) DeleteDomain(ctx context.Context, request *kusciaapi.Dele
 func (h domainHandler) BatchQueryDomain(ctx context.Context, request *kusciaapi.BatchQueryDomainRequest) (*kusciaapi.BatchQueryDomainResponse, error) {
 	return h.domainService.BatchQueryDomain(ctx, request), nil
 }
+
+func (h domainHandler) QueryDomainStatusDetail(ctx context.Context, request *kusciaapi.QueryDomainStatusDetailRequest) (*kusciaapi.QueryDomainStatusDetailResponse, error) {
+	return h.domainService.QueryDomainStatusDetail(ctx, request), nil
+}
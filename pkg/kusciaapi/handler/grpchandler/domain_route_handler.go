@@ -48,5 +48,9 @@ func (h domainRouteHandler) BatchQueryDomainRouteStatus(ctx context.Context, req
 	return h.domainRouteService.BatchQueryDomainRouteStatus(ctx, request), nil
 }
 
+func (h domainRouteHandler) QueryDomainRouteTransfer(ctx context.Context, request *kusciaapi.QueryDomainRouteTransferRequest) (*kusciaapi.QueryDomainRouteTransferResponse, error) {
+	return h.domainRouteService.QueryDomainRouteTransfer(ctx, request), nil
+}
+
 func (h domainRouteHandler) mustEmbedUnimplementedRouteServiceServer() {
 }
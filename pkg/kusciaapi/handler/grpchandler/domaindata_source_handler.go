@@ -56,3 +56,7 @@ func (h *domainDataSourceHandler) BatchQueryDomainDataSource(ctx context.Context
 func (h *domainDataSourceHandler) ListDomainDataSource(ctx context.Context, request *kusciaapi.ListDomainDataSourceRequest) (*kusciaapi.ListDomainDataSourceResponse, error) {
 	return h.domainDataSourceService.ListDomainDataSource(ctx, request), nil
 }
+
+func (h *domainDataSourceHandler) TestDomainDataSource(ctx context.Context, request *kusciaapi.TestDomainDataSourceRequest) (*kusciaapi.TestDomainDataSourceResponse, error) {
+	return h.domainDataSourceService.TestDomainDataSource(ctx, request), nil
+}
@@ -83,10 +83,23 @@ func (h jobHandler) WatchJob(request *kusciaapi.WatchJobRequest, stream kusciaap
 			}
 		}
 	}()
-	err := h.jobService.WatchJob(context.Background(), request, eventCh)
+	// stream context carries the tenant of request
+	err := h.jobService.WatchJob(stream.Context(), request, eventCh)
 	return err
 }
 
 func (h jobHandler) ApproveJob(ctx context.Context, request *kusciaapi.ApproveJobRequest) (*kusciaapi.ApproveJobResponse, error) {
 	return h.jobService.ApproveJob(ctx, request), nil
 }
+
+func (h jobHandler) ListPendingApprovals(ctx context.Context, request *kusciaapi.ListPendingApprovalsRequest) (*kusciaapi.ListPendingApprovalsResponse, error) {
+	return h.jobService.ListPendingApprovals(ctx, request), nil
+}
+
+func (h jobHandler) QueryJobResourceUsage(ctx context.Context, request *kusciaapi.QueryJobResourceUsageRequest) (*kusciaapi.QueryJobResourceUsageResponse, error) {
+	return h.jobService.QueryJobResourceUsage(ctx, request), nil
+}
+
+func (h jobHandler) QueryJobHistory(ctx context.Context, request *kusciaapi.QueryJobHistoryRequest) (*kusciaapi.QueryJobHistoryResponse, error) {
+	return h.jobService.QueryJobHistory(ctx, request), nil
+}
@@ -52,3 +52,15 @@ func (h servingHandler) DeleteServing(ctx context.Context, request *kusciaapi.De
 func (h servingHandler) BatchQueryServingStatus(ctx context.Context, request *kusciaapi.BatchQueryServingStatusRequest) (*kusciaapi.BatchQueryServingStatusResponse, error) {
 	return h.servingService.BatchQueryServingStatus(ctx, request), nil
 }
+
+func (h servingHandler) CreateServingRevision(ctx context.Context, request *kusciaapi.CreateServingRevisionRequest) (*kusciaapi.CreateServingRevisionResponse, error) {
+	return h.servingService.CreateServingRevision(ctx, request), nil
+}
+
+func (h servingHandler) SwitchServingTraffic(ctx context.Context, request *kusciaapi.SwitchServingTrafficRequest) (*kusciaapi.SwitchServingTrafficResponse, error) {
+	return h.servingService.SwitchServingTraffic(ctx, request), nil
+}
+
+func (h servingHandler) RollbackServingTraffic(ctx context.Context, request *kusciaapi.RollbackServingTrafficRequest) (*kusciaapi.RollbackServingTrafficResponse, error) {
+	return h.servingService.RollbackServingTraffic(ctx, request), nil
+}
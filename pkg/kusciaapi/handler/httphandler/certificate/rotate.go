@@ -0,0 +1,51 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificate
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/secretflow/kuscia/pkg/kusciaapi/service"
+	"github.com/secretflow/kuscia/pkg/web/api"
+	"github.com/secretflow/kuscia/pkg/web/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+type rotateCertificateHandler struct {
+	certificateService service.ICertificateService
+}
+
+func NewRotateCertificateHandler(certificateService service.ICertificateService) api.ProtoHandler {
+	return &rotateCertificateHandler{
+		certificateService: certificateService,
+	}
+}
+
+func (h rotateCertificateHandler) Validate(context *api.BizContext, request api.ProtoRequest, errs *errorcode.Errs) {
+	rotateRequest, _ := request.(*kusciaapi.RotateCertificateRequest)
+	if rotateRequest.Name == "" {
+		errs.AppendErr(errors.New("certificate name should not be empty"))
+	}
+}
+
+func (h rotateCertificateHandler) Handle(context *api.BizContext, request api.ProtoRequest) api.ProtoResponse {
+	rotateRequest, _ := request.(*kusciaapi.RotateCertificateRequest)
+	return h.certificateService.RotateCertificate(context.Context, rotateRequest)
+}
+
+func (h rotateCertificateHandler) GetType() (reqType, respType reflect.Type) {
+	return reflect.TypeOf(kusciaapi.RotateCertificateRequest{}), reflect.TypeOf(kusciaapi.RotateCertificateResponse{})
+}
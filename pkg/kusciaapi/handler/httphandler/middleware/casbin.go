@@ -16,6 +16,7 @@
 package middleware
 
 import (
+	"context"
 	_ "embed"
 	"fmt"
 	"net/http"
@@ -24,6 +25,9 @@ import (
 
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/secretflow/kuscia/pkg/web/constants"
 )
@@ -37,6 +41,8 @@ var casbinPolicy []byte
 const (
 	modelFile  = "casbin_model.conf"
 	policyFile = "casbin_policy.csv"
+	// grpcAction is the action in policy of grpc methods.
+	grpcAction = "GRPC"
 )
 
 var casbinEnforcer *casbin.CachedEnforcer
@@ -51,15 +57,57 @@ func InitConfig(confPath string) {
 
 func PermissionMiddleWare(ctx *gin.Context) {
 	role, _ := ctx.Get(constants.AuthRole)
-	isPass, err := casbinEnforcer.Enforce(role, ctx.Request.URL.Path, ctx.Request.Method)
-	if err != nil {
+	if err := enforce(role, ctx.Request.URL.Path, ctx.Request.Method); err != nil {
 		ctx.AbortWithError(http.StatusUnauthorized, err)
 		return
 	}
-	if !isPass {
-		err = fmt.Errorf("check role failed,role :%s,path:%s", role, ctx.Request.URL.Path)
+	ctx.Next()
+}
+
+// TenantPermissionMiddleWare restricts requests of tenants to the apis granted to the tenant role.
+func TenantPermissionMiddleWare(ctx *gin.Context) {
+	if _, ok := ctx.Get(constants.TenantKey); !ok {
+		ctx.Next()
+		return
+	}
+	if err := enforce(constants.AuthRoleTenant, ctx.Request.URL.Path, ctx.Request.Method); err != nil {
 		ctx.AbortWithError(http.StatusUnauthorized, err)
 		return
 	}
 	ctx.Next()
 }
+
+// GrpcTenantPermissionInterceptor is the grpc version of TenantPermissionMiddleWare.
+func GrpcTenantPermissionInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		if ctx.Value(constants.TenantKey) != nil {
+			if err := enforce(constants.AuthRoleTenant, info.FullMethod, grpcAction); err != nil {
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GrpcStreamTenantPermissionInterceptor is the grpc stream version of TenantPermissionMiddleWare.
+func GrpcStreamTenantPermissionInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if ss.Context().Value(constants.TenantKey) != nil {
+			if err := enforce(constants.AuthRoleTenant, info.FullMethod, grpcAction); err != nil {
+				return status.Error(codes.PermissionDenied, err.Error())
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+func enforce(role any, path, action string) error {
+	isPass, err := casbinEnforcer.Enforce(role, path, action)
+	if err != nil {
+		return err
+	}
+	if !isPass {
+		return fmt.Errorf("check role failed,role :%s,path:%s", role, path)
+	}
+	return nil
+}
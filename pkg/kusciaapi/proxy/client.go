@@ -32,14 +32,16 @@ import (
 
 const (
 	// Domain
-	UpdateDomainPath     = "/api/v1/domain/update"
-	QueryDomainPath      = "/api/v1/domain/query"
-	BatchQueryDomainPath = "/api/v1/domain/batchQuery"
+	UpdateDomainPath      = "/api/v1/domain/update"
+	QueryDomainPath       = "/api/v1/domain/query"
+	BatchQueryDomainPath  = "/api/v1/domain/batchQuery"
+	QueryDomainStatusPath = "/api/v1/domain/status/query"
 	// Domain Route
-	CreateDomainRoutePath     = "/api/v1/route/create"
-	DeleteDomainRoutePath     = "/api/v1/route/delete"
-	QueryDomainRoutePath      = "/api/v1/route/query"
-	BatchQueryDomainRoutePath = "/api/v1/route/status/batchQuery"
+	CreateDomainRoutePath        = "/api/v1/route/create"
+	DeleteDomainRoutePath        = "/api/v1/route/delete"
+	QueryDomainRoutePath         = "/api/v1/route/query"
+	BatchQueryDomainRoutePath    = "/api/v1/route/status/batchQuery"
+	QueryDomainRouteTransferPath = "/api/v1/route/transfer/query"
 	// Domain Data
 	CreateDomainDataPath     = "/api/v1/domaindata/create"
 	UpdateDomainDataPath     = "/api/v1/domaindata/update"
@@ -48,25 +50,31 @@ const (
 	BatchQueryDomainDataPath = "/api/v1/domaindata/batchQuery"
 	ListDomainDataPath       = "/api/v1/domaindata/list"
 	// Kuscia Job
-	CreateJobPath     = "/api/v1/job/create"
-	DeleteJobPath     = "/api/v1/job/delete"
-	QueryJobPath      = "/api/v1/job/query"
-	StopJobPath       = "/api/v1/job/stop"
-	SuspendJobPath    = "/api/v1/job/suspend"
-	RestartJobPath    = "/api/v1/job/restart"
-	CancelJobPath     = "/api/v1/job/cancel"
-	ApproveJobPath    = "/api/v1/job/approve"
-	BatchQueryJobPath = "/api/v1/job/status/batchQuery"
-	WatchJobPath      = "/api/v1/job/watch"
+	CreateJobPath       = "/api/v1/job/create"
+	DeleteJobPath       = "/api/v1/job/delete"
+	QueryJobPath        = "/api/v1/job/query"
+	StopJobPath         = "/api/v1/job/stop"
+	SuspendJobPath      = "/api/v1/job/suspend"
+	RestartJobPath      = "/api/v1/job/restart"
+	CancelJobPath       = "/api/v1/job/cancel"
+	ApproveJobPath      = "/api/v1/job/approve"
+	BatchQueryJobPath   = "/api/v1/job/status/batchQuery"
+	WatchJobPath        = "/api/v1/job/watch"
+	QueryJobUsagePath   = "/api/v1/job/resourceUsage/query"
+	QueryJobHistoryPath = "/api/v1/job/history/query"
+	PendingJobPath      = "/api/v1/job/approval/pending"
 	// Log
 	QueryPodNodePath = "/api/v1/log/node/query"
 
 	// Kuscia Serving
-	CreateServingPath     = "/api/v1/serving/create"
-	UpdateServingPath     = "/api/v1/serving/update"
-	DeleteServingPath     = "/api/v1/serving/delete"
-	QueryServingPath      = "/api/v1/serving/query"
-	BatchQueryServingPath = "/api/v1/serving/status/batchQuery"
+	CreateServingPath          = "/api/v1/serving/create"
+	UpdateServingPath          = "/api/v1/serving/update"
+	DeleteServingPath          = "/api/v1/serving/delete"
+	QueryServingPath           = "/api/v1/serving/query"
+	BatchQueryServingPath      = "/api/v1/serving/status/batchQuery"
+	CreateServingRevisionPath  = "/api/v1/serving/revision/create"
+	SwitchServingTrafficPath   = "/api/v1/serving/traffic/switch"
+	RollbackServingTrafficPath = "/api/v1/serving/traffic/rollback"
 	// AppImage
 	CreateAppImagePath = "/api/v1/appimage/create"
 	UpdateAppImagePath = "/api/v1/appimage/update"
@@ -84,6 +92,7 @@ type KusciaAPIClient interface {
 	QueryDomain(ctx context.Context, request *kusciaapi.QueryDomainRequest) (response *kusciaapi.QueryDomainResponse, err error)
 
 	BatchQueryDomain(ctx context.Context, request *kusciaapi.BatchQueryDomainRequest) (response *kusciaapi.BatchQueryDomainResponse, err error)
+	QueryDomainStatusDetail(ctx context.Context, request *kusciaapi.QueryDomainStatusDetailRequest) (response *kusciaapi.QueryDomainStatusDetailResponse, err error)
 
 	CreateDomainRoute(ctx context.Context, request *kusciaapi.CreateDomainRouteRequest) (response *kusciaapi.CreateDomainRouteResponse, err error)
 
@@ -93,6 +102,8 @@ type KusciaAPIClient interface {
 
 	BatchQueryDomainRoute(ctx context.Context, request *kusciaapi.BatchQueryDomainRouteStatusRequest) (response *kusciaapi.BatchQueryDomainRouteStatusResponse, err error)
 
+	QueryDomainRouteTransfer(ctx context.Context, request *kusciaapi.QueryDomainRouteTransferRequest) (response *kusciaapi.QueryDomainRouteTransferResponse, err error)
+
 	CreateDomainData(ctx context.Context, request *kusciaapi.CreateDomainDataRequest) (response *kusciaapi.CreateDomainDataResponse, err error)
 
 	UpdateDomainData(ctx context.Context, request *kusciaapi.UpdateDomainDataRequest) (response *kusciaapi.UpdateDomainDataResponse, err error)
@@ -133,12 +144,23 @@ type KusciaAPIClient interface {
 
 	BatchQueryServing(ctx context.Context, request *kusciaapi.BatchQueryServingStatusRequest) (response *kusciaapi.BatchQueryServingStatusResponse, err error)
 
+	CreateServingRevision(ctx context.Context, request *kusciaapi.CreateServingRevisionRequest) (response *kusciaapi.CreateServingRevisionResponse, err error)
+
+	SwitchServingTraffic(ctx context.Context, request *kusciaapi.SwitchServingTrafficRequest) (response *kusciaapi.SwitchServingTrafficResponse, err error)
+
+	RollbackServingTraffic(ctx context.Context, request *kusciaapi.RollbackServingTrafficRequest) (response *kusciaapi.RollbackServingTrafficResponse, err error)
+
 	UpdateAppImage(ctx context.Context, request *kusciaapi.UpdateAppImageRequest) (response *kusciaapi.UpdateAppImageResponse, err error)
 
 	QueryAppImage(ctx context.Context, request *kusciaapi.QueryAppImageRequest) (response *kusciaapi.QueryAppImageResponse, err error)
 
 	ApproveJob(ctx context.Context, request *kusciaapi.ApproveJobRequest) (response *kusciaapi.ApproveJobResponse, err error)
 
+	QueryJobResourceUsage(ctx context.Context, request *kusciaapi.QueryJobResourceUsageRequest) (response *kusciaapi.QueryJobResourceUsageResponse, err error)
+
+	QueryJobHistory(ctx context.Context, request *kusciaapi.QueryJobHistoryRequest) (response *kusciaapi.QueryJobHistoryResponse, err error)
+	ListPendingApprovals(ctx context.Context, request *kusciaapi.ListPendingApprovalsRequest) (response *kusciaapi.ListPendingApprovalsResponse, err error)
+
 	QueryPodNode(ctx context.Context, request *kusciaapi.QueryPodNodeRequest) (response *kusciaapi.QueryPodNodeResponse, err error)
 }
 
@@ -174,6 +196,12 @@ func (c *KusciaAPIHttpClient) BatchQueryDomain(ctx context.Context, request *kus
 	err = c.Send(ctx, request, response, BatchQueryDomainPath)
 	return
 }
+
+func (c *KusciaAPIHttpClient) QueryDomainStatusDetail(ctx context.Context, request *kusciaapi.QueryDomainStatusDetailRequest) (response *kusciaapi.QueryDomainStatusDetailResponse, err error) {
+	response = &kusciaapi.QueryDomainStatusDetailResponse{}
+	err = c.Send(ctx, request, response, QueryDomainStatusPath)
+	return
+}
 func (c *KusciaAPIHttpClient) CreateDomainRoute(ctx context.Context, request *kusciaapi.CreateDomainRouteRequest) (response *kusciaapi.CreateDomainRouteResponse, err error) {
 	response = &kusciaapi.CreateDomainRouteResponse{}
 	err = c.Send(ctx, request, response, CreateDomainRoutePath)
@@ -198,6 +226,12 @@ func (c *KusciaAPIHttpClient) BatchQueryDomainRoute(ctx context.Context, request
 	return
 }
 
+func (c *KusciaAPIHttpClient) QueryDomainRouteTransfer(ctx context.Context, request *kusciaapi.QueryDomainRouteTransferRequest) (response *kusciaapi.QueryDomainRouteTransferResponse, err error) {
+	response = &kusciaapi.QueryDomainRouteTransferResponse{}
+	err = c.Send(ctx, request, response, QueryDomainRouteTransferPath)
+	return
+}
+
 func (c *KusciaAPIHttpClient) CreateDomainData(ctx context.Context, request *kusciaapi.CreateDomainDataRequest) (response *kusciaapi.CreateDomainDataResponse, err error) {
 	response = &kusciaapi.CreateDomainDataResponse{}
 	err = c.Send(ctx, request, response, CreateDomainDataPath)
@@ -282,6 +316,24 @@ func (c *KusciaAPIHttpClient) ApproveJob(ctx context.Context, request *kusciaapi
 	return
 }
 
+func (c *KusciaAPIHttpClient) QueryJobResourceUsage(ctx context.Context, request *kusciaapi.QueryJobResourceUsageRequest) (response *kusciaapi.QueryJobResourceUsageResponse, err error) {
+	response = &kusciaapi.QueryJobResourceUsageResponse{}
+	err = c.Send(ctx, request, response, QueryJobUsagePath)
+	return
+}
+
+func (c *KusciaAPIHttpClient) ListPendingApprovals(ctx context.Context, request *kusciaapi.ListPendingApprovalsRequest) (response *kusciaapi.ListPendingApprovalsResponse, err error) {
+	response = &kusciaapi.ListPendingApprovalsResponse{}
+	err = c.Send(ctx, request, response, PendingJobPath)
+	return
+}
+
+func (c *KusciaAPIHttpClient) QueryJobHistory(ctx context.Context, request *kusciaapi.QueryJobHistoryRequest) (response *kusciaapi.QueryJobHistoryResponse, err error) {
+	response = &kusciaapi.QueryJobHistoryResponse{}
+	err = c.Send(ctx, request, response, QueryJobHistoryPath)
+	return
+}
+
 func (c *KusciaAPIHttpClient) BatchQueryJob(ctx context.Context, request *kusciaapi.BatchQueryJobStatusRequest) (response *kusciaapi.BatchQueryJobStatusResponse, err error) {
 	response = &kusciaapi.BatchQueryJobStatusResponse{}
 	err = c.Send(ctx, request, response, BatchQueryJobPath)
@@ -374,6 +426,24 @@ func (c *KusciaAPIHttpClient) BatchQueryServing(ctx context.Context, request *ku
 	return
 }
 
+func (c *KusciaAPIHttpClient) CreateServingRevision(ctx context.Context, request *kusciaapi.CreateServingRevisionRequest) (response *kusciaapi.CreateServingRevisionResponse, err error) {
+	response = &kusciaapi.CreateServingRevisionResponse{}
+	err = c.Send(ctx, request, response, CreateServingRevisionPath)
+	return
+}
+
+func (c *KusciaAPIHttpClient) SwitchServingTraffic(ctx context.Context, request *kusciaapi.SwitchServingTrafficRequest) (response *kusciaapi.SwitchServingTrafficResponse, err error) {
+	response = &kusciaapi.SwitchServingTrafficResponse{}
+	err = c.Send(ctx, request, response, SwitchServingTrafficPath)
+	return
+}
+
+func (c *KusciaAPIHttpClient) RollbackServingTraffic(ctx context.Context, request *kusciaapi.RollbackServingTrafficRequest) (response *kusciaapi.RollbackServingTrafficResponse, err error) {
+	response = &kusciaapi.RollbackServingTrafficResponse{}
+	err = c.Send(ctx, request, response, RollbackServingTrafficPath)
+	return
+}
+
 func (c *KusciaAPIHttpClient) UpdateAppImage(ctx context.Context, request *kusciaapi.UpdateAppImageRequest) (response *kusciaapi.UpdateAppImageResponse, err error) {
 	response = &kusciaapi.UpdateAppImageResponse{}
 	err = c.Send(ctx, request, response, UpdateAppImagePath)
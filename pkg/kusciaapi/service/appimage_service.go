@@ -16,6 +16,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/secretflow/kuscia/pkg/common"
@@ -24,6 +25,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/errorcode"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/proxy"
+	"github.com/secretflow/kuscia/pkg/utils/jsonschema"
 	"github.com/secretflow/kuscia/pkg/utils/resources"
 	"github.com/secretflow/kuscia/pkg/web/utils"
 	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
@@ -40,6 +42,7 @@ type IAppImageService interface {
 	UpdateAppImage(ctx context.Context, request *kusciaapi.UpdateAppImageRequest) *kusciaapi.UpdateAppImageResponse
 	DeleteAppImage(ctx context.Context, request *kusciaapi.DeleteAppImageRequest) *kusciaapi.DeleteAppImageResponse
 	BatchQueryAppImage(ctx context.Context, request *kusciaapi.BatchQueryAppImageRequest) *kusciaapi.BatchQueryAppImageResponse
+	ValidateAppImage(ctx context.Context, request *kusciaapi.ValidateAppImageRequest) *kusciaapi.ValidateAppImageResponse
 }
 
 type appImageService struct {
@@ -71,6 +74,11 @@ func (s appImageService) CreateAppImage(ctx context.Context, request *kusciaapi.
 	if err != nil {
 		return &kusciaapi.CreateAppImageResponse{Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrCreateAppImage, err.Error())}
 	}
+	k8sAppImage.Spec.Version = request.Version
+	if len(request.Channels) > 0 {
+		k8sAppImage.Spec.Channels = convertSlice(request.Channels, k8sAppImageChannel)
+	}
+	k8sAppImage.Spec.TaskConfigSchema = request.TaskConfigSchema
 
 	// create resources
 	if _, err := s.kusciaClient.KusciaV1alpha1().AppImages().Create(ctx, k8sAppImage, metav1.CreateOptions{}); err != nil {
@@ -150,6 +158,15 @@ func (s appImageService) UpdateAppImage(ctx context.Context, request *kusciaapi.
 	if err := overrideK8sAppImage(k8sAppImage, request.Image, request.ConfigTemplates, request.DeployTemplates); err != nil {
 		return &kusciaapi.UpdateAppImageResponse{Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrUpdateAppImage, err.Error())}
 	}
+	if request.Version != "" {
+		k8sAppImage.Spec.Version = request.Version
+	}
+	if request.Channels != nil {
+		k8sAppImage.Spec.Channels = convertSlice(request.Channels, k8sAppImageChannel)
+	}
+	if request.TaskConfigSchema != "" {
+		k8sAppImage.Spec.TaskConfigSchema = request.TaskConfigSchema
+	}
 
 	// update resources
 	if _, err := s.kusciaClient.KusciaV1alpha1().AppImages().Update(ctx, k8sAppImage, metav1.UpdateOptions{}); err != nil {
@@ -179,6 +196,52 @@ func (s appImageService) DeleteAppImage(ctx context.Context, request *kusciaapi.
 	}
 }
 
+func (s appImageService) ValidateAppImage(ctx context.Context, request *kusciaapi.ValidateAppImageRequest) *kusciaapi.ValidateAppImageResponse {
+	var k8sAppImage *v1alpha1.AppImage
+	if request.Name != "" {
+		appImage, err := s.kusciaClient.KusciaV1alpha1().AppImages().Get(ctx, request.Name, metav1.GetOptions{})
+		if err != nil {
+			return &kusciaapi.ValidateAppImageResponse{Status: utils.BuildErrorResponseStatus(errorcode.GetAppImageErrorCode(err, pberrorcode.ErrorCode_KusciaAPIErrValidateAppImage), err.Error())}
+		}
+		k8sAppImage = appImage
+	} else {
+		if request.Image == nil {
+			return &kusciaapi.ValidateAppImageResponse{Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, "appimage name and image can not be both empty")}
+		}
+		appImage, err := buildK8sAppImage("", request.Image, request.ConfigTemplates, request.DeployTemplates)
+		if err != nil {
+			return &kusciaapi.ValidateAppImageResponse{Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error())}
+		}
+		appImage.Spec.TaskConfigSchema = request.TaskConfigSchema
+		k8sAppImage = appImage
+	}
+
+	domainID := request.DomainId
+	if domainID == "" {
+		domainID = s.conf.DomainID
+	}
+	dryRun := resources.DryRunAppImage(k8sAppImage, request.Role, request.AppImageChannel, domainID, request.TaskInputConfig)
+	data := &kusciaapi.ValidateAppImageResponseData{
+		Valid:          len(dryRun.Errors) == 0,
+		Errors:         dryRun.Errors,
+		Warnings:       dryRun.Warnings,
+		DeployTemplate: dryRun.DeployTemplate,
+		Image:          dryRun.Image,
+		ConfigFiles:    dryRun.ConfigFiles,
+	}
+	if dryRun.PodSpec != nil {
+		podSpec, err := json.Marshal(dryRun.PodSpec)
+		if err != nil {
+			return &kusciaapi.ValidateAppImageResponse{Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrValidateAppImage, err.Error())}
+		}
+		data.PodSpec = string(podSpec)
+	}
+	return &kusciaapi.ValidateAppImageResponse{
+		Status: utils.BuildSuccessResponseStatus(),
+		Data:   data,
+	}
+}
+
 func validateCreateAppImageRequest(request *kusciaapi.CreateAppImageRequest) error {
 	// do validate
 	if request.Name == "" {
@@ -190,6 +253,12 @@ func validateCreateAppImageRequest(request *kusciaapi.CreateAppImageRequest) err
 	if err := validateDeployTemplates(request.DeployTemplates); err != nil {
 		return err
 	}
+	if err := validateAppImageChannels(request.Channels); err != nil {
+		return err
+	}
+	if err := validateTaskConfigSchema(request.TaskConfigSchema); err != nil {
+		return err
+	}
 	// do k8s validate
 	if err := resources.ValidateK8sName(request.Name, "appimage_id"); err != nil {
 		return err
@@ -209,6 +278,39 @@ func validateUpdateAppImageRequest(request *kusciaapi.UpdateAppImageRequest) err
 			return err
 		}
 	}
+	if err := validateTaskConfigSchema(request.TaskConfigSchema); err != nil {
+		return err
+	}
+	return validateAppImageChannels(request.Channels)
+}
+
+func validateAppImageChannels(channels []*kusciaapi.AppImageChannel) error {
+	names := map[string]bool{}
+	for _, channel := range channels {
+		if err := resources.ValidateK8sName(channel.Name, "channel name"); err != nil {
+			return err
+		}
+		if names[channel.Name] {
+			return fmt.Errorf("channel %s is duplicated", channel.Name)
+		}
+		names[channel.Name] = true
+		if channel.Image == nil || channel.Image.Name == "" || channel.Image.Tag == "" {
+			return fmt.Errorf("image name and tag of channel %s can not be empty", channel.Name)
+		}
+		if err := validateTaskConfigSchema(channel.TaskConfigSchema); err != nil {
+			return fmt.Errorf("channel %s: %v", channel.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateTaskConfigSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+	if _, err := jsonschema.Parse(schema); err != nil {
+		return fmt.Errorf("task config schema is malformed, %v", err)
+	}
 	return nil
 }
 
@@ -244,8 +346,39 @@ func buildAppImage(k8sAppImage *v1alpha1.AppImage) *kusciaapi.QueryAppImageRespo
 			Id:   k8sAppImage.Spec.Image.ID,
 			Sign: k8sAppImage.Spec.Image.Sign,
 		},
-		ConfigTemplates: k8sAppImage.Spec.ConfigTemplates,
-		DeployTemplates: convertSlice(k8sAppImage.Spec.DeployTemplates, apiDeployTemplate),
+		ConfigTemplates:  k8sAppImage.Spec.ConfigTemplates,
+		DeployTemplates:  convertSlice(k8sAppImage.Spec.DeployTemplates, apiDeployTemplate),
+		Version:          k8sAppImage.Spec.Version,
+		Channels:         convertSlice(k8sAppImage.Spec.Channels, apiAppImageChannel),
+		TaskConfigSchema: k8sAppImage.Spec.TaskConfigSchema,
+	}
+}
+
+func k8sAppImageChannel(channel *kusciaapi.AppImageChannel) v1alpha1.AppImageChannel {
+	return v1alpha1.AppImageChannel{
+		Name:    channel.Name,
+		Version: channel.Version,
+		Image: v1alpha1.AppImageInfo{
+			Name: channel.Image.Name,
+			Tag:  channel.Image.Tag,
+			ID:   channel.Image.Id,
+			Sign: channel.Image.Sign,
+		},
+		TaskConfigSchema: channel.TaskConfigSchema,
+	}
+}
+
+func apiAppImageChannel(channel v1alpha1.AppImageChannel) *kusciaapi.AppImageChannel {
+	return &kusciaapi.AppImageChannel{
+		Name:    channel.Name,
+		Version: channel.Version,
+		Image: &kusciaapi.AppImageInfo{
+			Name: channel.Image.Name,
+			Tag:  channel.Image.Tag,
+			Id:   channel.Image.ID,
+			Sign: channel.Image.Sign,
+		},
+		TaskConfigSchema: channel.TaskConfigSchema,
 	}
 }
 
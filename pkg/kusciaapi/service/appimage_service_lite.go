@@ -59,3 +59,10 @@ func (s appImageServiceLite) BatchQueryAppImage(ctx context.Context, request *ku
 		Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrLiteAPINotSupport, "kuscia lite api not support this interface now"),
 	}
 }
+
+func (s appImageServiceLite) ValidateAppImage(ctx context.Context, request *kusciaapi.ValidateAppImageRequest) *kusciaapi.ValidateAppImageResponse {
+	// kuscia lite api not support this interface
+	return &kusciaapi.ValidateAppImageResponse{
+		Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrLiteAPINotSupport, "kuscia lite api not support this interface now"),
+	}
+}
@@ -204,6 +204,113 @@ func TestUpdateAppImage(t *testing.T) {
 	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), true)
 }
 
+func TestUpdateAppImageChannels(t *testing.T) {
+	conf := &config.KusciaAPIConfig{
+		KusciaClient: client,
+		RunMode:      common.RunModeAutonomy,
+	}
+	s := NewAppImageService(conf)
+
+	req := new(kusciaapi.UpdateAppImageRequest)
+	if err := json.Unmarshal([]byte(createReqStr), req); err != nil {
+		t.Errorf("unmarshall failed")
+		return
+	}
+	req.Version = "1.0.0"
+	req.Channels = []*kusciaapi.AppImageChannel{
+		{Name: "stable", Version: "1.0.0", Image: &kusciaapi.AppImageInfo{Name: "app-image", Tag: "v1.0.0"}},
+		{Name: "beta", Version: "1.1.0b0", Image: &kusciaapi.AppImageInfo{Name: "app-image", Tag: "v1.1.0b0"}},
+	}
+	resp := s.UpdateAppImage(context.Background(), req)
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), true)
+
+	queryResp := s.QueryAppImage(context.Background(), &kusciaapi.QueryAppImageRequest{Name: req.Name})
+	assert.Equal(t, utils.IsSuccessCode(queryResp.Status.Code), true)
+	assert.Equal(t, queryResp.Data.Version, "1.0.0")
+	assert.Equal(t, len(queryResp.Data.Channels), 2)
+	assert.Equal(t, queryResp.Data.Channels[1].Image.Tag, "v1.1.0b0")
+
+	// channels are kept if they are not set
+	req.Channels = nil
+	resp = s.UpdateAppImage(context.Background(), req)
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), true)
+	queryResp = s.QueryAppImage(context.Background(), &kusciaapi.QueryAppImageRequest{Name: req.Name})
+	assert.Equal(t, len(queryResp.Data.Channels), 2)
+
+	req.Channels = []*kusciaapi.AppImageChannel{
+		{Name: "stable", Image: &kusciaapi.AppImageInfo{Name: "app-image", Tag: "v1.0.0"}},
+		{Name: "stable", Image: &kusciaapi.AppImageInfo{Name: "app-image", Tag: "v1.1.0"}},
+	}
+	resp = s.UpdateAppImage(context.Background(), req)
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), false)
+}
+
+func TestValidateAppImage(t *testing.T) {
+	conf := &config.KusciaAPIConfig{
+		KusciaClient: client,
+		RunMode:      common.RunModeAutonomy,
+		DomainID:     "alice",
+	}
+	s := NewAppImageService(conf)
+
+	resp := s.ValidateAppImage(context.Background(), &kusciaapi.ValidateAppImageRequest{Name: "appimage-template", Role: "server"})
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), true)
+	assert.Equal(t, resp.Data.Valid, true)
+	assert.Equal(t, resp.Data.DeployTemplate, "app")
+	assert.Equal(t, resp.Data.Image, "app-image:v1.0.0")
+	assert.Assert(t, resp.Data.PodSpec != "")
+	assert.Assert(t, resp.Data.ConfigFiles["task-config.conf"] != "")
+
+	// inline app image with a broken deploy template
+	req := new(kusciaapi.ValidateAppImageRequest)
+	if err := json.Unmarshal([]byte(createReqStr), req); err != nil {
+		t.Errorf("unmarshall failed")
+		return
+	}
+	req.Name = ""
+	req.DeployTemplates[0].Containers[0].ConfigVolumeMounts[0].SubPath = "missing.conf"
+	resp = s.ValidateAppImage(context.Background(), req)
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), true)
+	assert.Equal(t, resp.Data.Valid, false)
+	assert.Equal(t, len(resp.Data.Errors), 1)
+
+	resp = s.ValidateAppImage(context.Background(), &kusciaapi.ValidateAppImageRequest{Name: "not-exist"})
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), false)
+}
+
+func TestAppImageTaskConfigSchema(t *testing.T) {
+	conf := &config.KusciaAPIConfig{
+		KusciaClient: client,
+		RunMode:      common.RunModeAutonomy,
+		DomainID:     "alice",
+	}
+	s := NewAppImageService(conf)
+
+	req := new(kusciaapi.UpdateAppImageRequest)
+	if err := json.Unmarshal([]byte(createReqStr), req); err != nil {
+		t.Errorf("unmarshall failed")
+		return
+	}
+	req.TaskConfigSchema = `{"type":"obj"}`
+	resp := s.UpdateAppImage(context.Background(), req)
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), false)
+
+	req.TaskConfigSchema = `{"type":"object","properties":{"sf_input_ids":{"type":"array"}}}`
+	resp = s.UpdateAppImage(context.Background(), req)
+	assert.Equal(t, utils.IsSuccessCode(resp.Status.Code), true)
+	queryResp := s.QueryAppImage(context.Background(), &kusciaapi.QueryAppImageRequest{Name: req.Name})
+	assert.Equal(t, queryResp.Data.TaskConfigSchema, req.TaskConfigSchema)
+
+	validateResp := s.ValidateAppImage(context.Background(), &kusciaapi.ValidateAppImageRequest{
+		Name:            req.Name,
+		Role:            "server",
+		TaskInputConfig: `{"sf_input_ids":"alice-table"}`,
+	})
+	assert.Equal(t, utils.IsSuccessCode(validateResp.Status.Code), true)
+	assert.Equal(t, validateResp.Data.Valid, false)
+	assert.DeepEqual(t, validateResp.Data.Errors, []string{"invalid taskInputConfig, sf_input_ids: must be of type array, got string"})
+}
+
 func TestDeleteAppImage(t *testing.T) {
 	conf := &config.KusciaAPIConfig{
 		KusciaClient: client,
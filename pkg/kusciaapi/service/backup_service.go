@@ -0,0 +1,117 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/backup"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	consts "github.com/secretflow/kuscia/pkg/web/constants"
+	utils2 "github.com/secretflow/kuscia/pkg/web/utils"
+	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+type IBackupService interface {
+	CreateBackup(ctx context.Context, request *kusciaapi.CreateBackupRequest) *kusciaapi.CreateBackupResponse
+	ListBackup(ctx context.Context, request *kusciaapi.ListBackupRequest) *kusciaapi.ListBackupResponse
+}
+
+type backupService struct {
+	// manager is nil if backup isn't configured or in lite mode.
+	manager *backup.Manager
+}
+
+func NewBackupService(config *config.KusciaAPIConfig) IBackupService {
+	return &backupService{
+		manager: config.Backup,
+	}
+}
+
+func (s *backupService) CreateBackup(ctx context.Context, request *kusciaapi.CreateBackupRequest) *kusciaapi.CreateBackupResponse {
+	if err := s.authHandler(ctx); err != nil {
+		return &kusciaapi.CreateBackupResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
+	if s.manager == nil {
+		return &kusciaapi.CreateBackupResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrCreateBackup, "backup is not configured"),
+		}
+	}
+	result, err := s.manager.Backup(ctx)
+	if err != nil {
+		return &kusciaapi.CreateBackupResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrCreateBackup, err.Error()),
+		}
+	}
+	data := buildBackup(&result.Info)
+	data.Size = result.Size
+	data.Sha256 = result.SHA256
+	data.Resources = int32(result.Resources)
+	data.Stores = result.Stores
+	return &kusciaapi.CreateBackupResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data:   data,
+	}
+}
+
+func (s *backupService) ListBackup(ctx context.Context, request *kusciaapi.ListBackupRequest) *kusciaapi.ListBackupResponse {
+	if err := s.authHandler(ctx); err != nil {
+		return &kusciaapi.ListBackupResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
+	if s.manager == nil {
+		return &kusciaapi.ListBackupResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrListBackup, "backup is not configured"),
+		}
+	}
+	infos, err := s.manager.List(ctx)
+	if err != nil {
+		return &kusciaapi.ListBackupResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrListBackup, err.Error()),
+		}
+	}
+	backups := make([]*kusciaapi.Backup, 0, len(infos))
+	for i := range infos {
+		backups = append(backups, buildBackup(&infos[i]))
+	}
+	return &kusciaapi.ListBackupResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.ListBackupResponseData{
+			Backups: backups,
+		},
+	}
+}
+
+// authHandler only allows the kusciaAPI of master or autonomy itself to operate backups, which contain all the domains.
+func (s *backupService) authHandler(ctx context.Context) error {
+	role, domainID := GetRoleAndDomainFromCtx(ctx)
+	if role == consts.AuthRoleDomain || role == consts.AuthRoleTenant {
+		return fmt.Errorf("domain %s is not allowed to operate backups", domainID)
+	}
+	return nil
+}
+
+func buildBackup(info *backup.Info) *kusciaapi.Backup {
+	return &kusciaapi.Backup{
+		Name:       info.Name,
+		CreateTime: info.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	consts "github.com/secretflow/kuscia/pkg/web/constants"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func TestBackupService(t *testing.T) {
+	s := NewBackupService(&config.KusciaAPIConfig{})
+
+	ctx := context.WithValue(context.Background(), consts.AuthRole, consts.AuthRoleDomain)
+	ctx = context.WithValue(ctx, consts.SourceDomainKey, "alice")
+	createResp := s.CreateBackup(ctx, &kusciaapi.CreateBackupRequest{})
+	assert.Equal(t, createResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrAuthFailed))
+	listResp := s.ListBackup(ctx, &kusciaapi.ListBackupRequest{})
+	assert.Equal(t, listResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrAuthFailed))
+
+	ctx = context.WithValue(context.Background(), consts.AuthRole, consts.AuthRoleMaster)
+	createResp = s.CreateBackup(ctx, &kusciaapi.CreateBackupRequest{})
+	assert.Equal(t, createResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrCreateBackup))
+	listResp = s.ListBackup(ctx, &kusciaapi.ListBackupRequest{})
+	assert.Equal(t, listResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrListBackup))
+}
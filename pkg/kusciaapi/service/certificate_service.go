@@ -0,0 +1,132 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/certmanager"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	consts "github.com/secretflow/kuscia/pkg/web/constants"
+	utils2 "github.com/secretflow/kuscia/pkg/web/utils"
+	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+type ICertificateService interface {
+	ListCertificates(ctx context.Context, request *kusciaapi.ListCertificatesRequest) *kusciaapi.ListCertificatesResponse
+	RotateCertificate(ctx context.Context, request *kusciaapi.RotateCertificateRequest) *kusciaapi.RotateCertificateResponse
+}
+
+type certificateService struct {
+	// manager is nil if kusciaAPI runs without kuscia modules.
+	manager *certmanager.Manager
+}
+
+func NewCertificateService(config *config.KusciaAPIConfig) ICertificateService {
+	return &certificateService{
+		manager: config.CertManager,
+	}
+}
+
+func (s *certificateService) ListCertificates(ctx context.Context, request *kusciaapi.ListCertificatesRequest) *kusciaapi.ListCertificatesResponse {
+	if err := s.authHandler(ctx); err != nil {
+		return &kusciaapi.ListCertificatesResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
+	if s.manager == nil {
+		return &kusciaapi.ListCertificatesResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrListCertificate, "certificates are not managed"),
+		}
+	}
+	statuses := s.manager.List()
+	certificates := make([]*kusciaapi.Certificate, 0, len(statuses))
+	for i := range statuses {
+		certificates = append(certificates, buildCertificate(&statuses[i]))
+	}
+	return &kusciaapi.ListCertificatesResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.ListCertificatesResponseData{
+			Certificates: certificates,
+		},
+	}
+}
+
+func (s *certificateService) RotateCertificate(ctx context.Context, request *kusciaapi.RotateCertificateRequest) *kusciaapi.RotateCertificateResponse {
+	if request.Name == "" {
+		return &kusciaapi.RotateCertificateResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, "certificate name can not be empty"),
+		}
+	}
+	if err := s.authHandler(ctx); err != nil {
+		return &kusciaapi.RotateCertificateResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
+	if s.manager == nil {
+		return &kusciaapi.RotateCertificateResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRotateCertificate, "certificates are not managed"),
+		}
+	}
+	if err := s.manager.Rotate(request.Name); err != nil {
+		return &kusciaapi.RotateCertificateResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRotateCertificate, err.Error()),
+		}
+	}
+	status, _ := s.manager.Get(request.Name)
+	return &kusciaapi.RotateCertificateResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data:   buildCertificate(&status),
+	}
+}
+
+// authHandler only allows the kusciaAPI of the domain itself to operate certificates.
+func (s *certificateService) authHandler(ctx context.Context) error {
+	role, domainID := GetRoleAndDomainFromCtx(ctx)
+	if role == consts.AuthRoleDomain || role == consts.AuthRoleTenant {
+		return fmt.Errorf("domain %s is not allowed to operate certificates", domainID)
+	}
+	return nil
+}
+
+func buildCertificate(status *certmanager.Status) *kusciaapi.Certificate {
+	certificate := &kusciaapi.Certificate{
+		Name:      status.Name,
+		Kind:      status.Kind,
+		Rotatable: status.Rotatable,
+		Error:     status.Error,
+	}
+	if cert := status.Cert; cert != nil {
+		certificate.Subject = cert.Subject.String()
+		certificate.Issuer = cert.Issuer.String()
+		certificate.SerialNumber = cert.SerialNumber.Text(16)
+		certificate.NotBefore = cert.NotBefore.UTC().Format(time.RFC3339)
+		certificate.NotAfter = cert.NotAfter.UTC().Format(time.RFC3339)
+		certificate.Sha256Fingerprint = certmanager.Fingerprint(cert)
+	}
+	for _, cond := range status.Conditions {
+		certificate.Conditions = append(certificate.Conditions, &kusciaapi.CertificateCondition{
+			Type:               cond.Type,
+			Status:             cond.Status,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime.UTC().Format(time.RFC3339),
+		})
+	}
+	return certificate
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/secretflow/kuscia/pkg/certmanager"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	consts "github.com/secretflow/kuscia/pkg/web/constants"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func TestCertificateService(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	now := time.Now()
+	ca, err := certmanager.Reissue(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "alice"},
+		NotBefore:             now,
+		NotAfter:              now.Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}, key, nil, nil, now)
+	assert.NilError(t, err)
+	manager := certmanager.NewManager()
+	manager.Register(certmanager.Cert{
+		Name:    "ca",
+		Kind:    certmanager.KindCA,
+		Load:    func() (*x509.Certificate, error) { return ca, nil },
+		Rotator: certmanager.NewFileRotator(key, nil, nil, filepath.Join(t.TempDir(), "ca.crt")),
+	})
+	s := NewCertificateService(&config.KusciaAPIConfig{CertManager: manager})
+
+	ctx := context.WithValue(context.Background(), consts.AuthRole, consts.AuthRoleDomain)
+	ctx = context.WithValue(ctx, consts.SourceDomainKey, "bob")
+	listResp := s.ListCertificates(ctx, &kusciaapi.ListCertificatesRequest{})
+	assert.Equal(t, listResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrAuthFailed))
+
+	ctx = context.Background()
+	listResp = s.ListCertificates(ctx, &kusciaapi.ListCertificatesRequest{})
+	assert.Equal(t, listResp.Status.Code, int32(0))
+	assert.Equal(t, len(listResp.Data.Certificates), 1)
+	certificate := listResp.Data.Certificates[0]
+	assert.Equal(t, certificate.Name, "ca")
+	assert.Equal(t, certificate.Subject, "CN=alice")
+	assert.Equal(t, certificate.NotAfter, ca.NotAfter.UTC().Format(time.RFC3339))
+	assert.Equal(t, certificate.Sha256Fingerprint, certmanager.Fingerprint(ca))
+	assert.Assert(t, certificate.Rotatable)
+
+	rotateResp := s.RotateCertificate(ctx, &kusciaapi.RotateCertificateRequest{})
+	assert.Equal(t, rotateResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrRequestValidate))
+	rotateResp = s.RotateCertificate(ctx, &kusciaapi.RotateCertificateRequest{Name: "domain"})
+	assert.Equal(t, rotateResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrRotateCertificate))
+	rotateResp = s.RotateCertificate(ctx, &kusciaapi.RotateCertificateRequest{Name: "ca"})
+	assert.Equal(t, rotateResp.Status.Code, int32(0))
+	assert.Equal(t, rotateResp.Data.Conditions[0].Type, certmanager.ConditionRotating)
+	assert.Equal(t, rotateResp.Data.Conditions[0].Status, certmanager.ConditionTrue)
+	// waits for the rotation, the new CA is saved and takes effect after restart
+	for i := 0; i < 100; i++ {
+		listResp = s.ListCertificates(ctx, &kusciaapi.ListCertificatesRequest{})
+		if listResp.Data.Certificates[0].Conditions[0].Status == certmanager.ConditionFalse {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	conditions := listResp.Data.Certificates[0].Conditions
+	assert.Equal(t, conditions[0].Reason, certmanager.ReasonSucceeded)
+	assert.Equal(t, conditions[2].Type, certmanager.ConditionApplied)
+	assert.Equal(t, conditions[2].Reason, certmanager.ReasonRestartRequired)
+
+	s = NewCertificateService(&config.KusciaAPIConfig{})
+	listResp = s.ListCertificates(ctx, &kusciaapi.ListCertificatesRequest{})
+	assert.Equal(t, listResp.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrListCertificate))
+}
@@ -18,10 +18,13 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
@@ -30,6 +33,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/kusciaapi/constants"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/errorcode"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/proxy"
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	consts "github.com/secretflow/kuscia/pkg/web/constants"
 	"github.com/secretflow/kuscia/pkg/web/utils"
@@ -42,10 +46,12 @@ type IDomainRouteService interface {
 	DeleteDomainRoute(ctx context.Context, request *kusciaapi.DeleteDomainRouteRequest) *kusciaapi.DeleteDomainRouteResponse
 	QueryDomainRoute(ctx context.Context, request *kusciaapi.QueryDomainRouteRequest) *kusciaapi.QueryDomainRouteResponse
 	BatchQueryDomainRouteStatus(ctx context.Context, request *kusciaapi.BatchQueryDomainRouteStatusRequest) *kusciaapi.BatchQueryDomainRouteStatusResponse
+	QueryDomainRouteTransfer(ctx context.Context, request *kusciaapi.QueryDomainRouteTransferRequest) *kusciaapi.QueryDomainRouteTransferResponse
 }
 
 type domainRouteService struct {
 	kusciaClient kusciaclientset.Interface
+	kubeClient   kubernetes.Interface
 }
 
 func NewDomainRouteService(config *config.KusciaAPIConfig) IDomainRouteService {
@@ -57,6 +63,7 @@ func NewDomainRouteService(config *config.KusciaAPIConfig) IDomainRouteService {
 	default:
 		return &domainRouteService{
 			kusciaClient: config.KusciaClient,
+			kubeClient:   config.KubeClient,
 		}
 	}
 }
@@ -157,6 +164,9 @@ func (s domainRouteService) CreateDomainRoute(ctx context.Context, request *kusc
 				DomainID: request.Transit.Domain.DomainId,
 			}
 		}
+		for _, candidate := range request.Transit.CandidateDomains {
+			transit.CandidateDomains = append(transit.CandidateDomains, v1alpha1.DomainTransit{DomainID: candidate.DomainId})
+		}
 	}
 	// build body encryption
 	var bodyEncryption *v1alpha1.BodyEncryption
@@ -292,6 +302,9 @@ func (s domainRouteService) QueryDomainRoute(ctx context.Context, request *kusci
 				DomainId: cdrSpec.Transit.Domain.DomainID,
 			}
 		}
+		for _, candidate := range cdrSpec.Transit.CandidateDomains {
+			apiTransit.CandidateDomains = append(apiTransit.CandidateDomains, &kusciaapi.Transit_Domain{DomainId: candidate.DomainID})
+		}
 	}
 
 	// build BodyEncryption
@@ -368,6 +381,73 @@ func (s domainRouteService) BatchQueryDomainRouteStatus(ctx context.Context, req
 	}
 }
 
+func (s domainRouteService) QueryDomainRouteTransfer(ctx context.Context, request *kusciaapi.QueryDomainRouteTransferRequest) *kusciaapi.QueryDomainRouteTransferResponse {
+	// do validate
+	if err := validateQueryDomainRouteTransferRequest(request); err != nil {
+		return &kusciaapi.QueryDomainRouteTransferResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+	// auth pre handler, the transfer accounted by a domain is only visible to itself
+	role, domainID := GetRoleAndDomainFromCtx(ctx)
+	if role == consts.AuthRoleDomain && request.Source != domainID {
+		return &kusciaapi.QueryDomainRouteTransferResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed,
+				fmt.Sprintf("domain's kusciaAPI could only query transfer of DomainRoute from itself, request.Source must be %s not %s", domainID, request.Source)),
+		}
+	}
+
+	days, err := accounting.LoadDailyTransfers(ctx, s.kubeClient, request.Source)
+	if err != nil {
+		return &kusciaapi.QueryDomainRouteTransferResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrQueryDomainRouteTransfer, err.Error()),
+		}
+	}
+
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		// dates formatted as 2006-01-02 compare as time
+		if (request.StartDate != "" && date < request.StartDate) || (request.EndDate != "" && date > request.EndDate) {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	routes := map[string]*kusciaapi.DomainRouteTransfer{}
+	for _, date := range dates {
+		for partner, transfer := range days[date] {
+			if request.Destination != "" && partner != request.Destination {
+				continue
+			}
+			route, ok := routes[partner]
+			if !ok {
+				route = &kusciaapi.DomainRouteTransfer{Source: request.Source, Destination: partner}
+				routes[partner] = route
+			}
+			route.SentBytes += transfer.SentBytes
+			route.ReceivedBytes += transfer.ReceivedBytes
+			route.Daily = append(route.Daily, &kusciaapi.DailyTransfer{
+				Date:          date,
+				SentBytes:     transfer.SentBytes,
+				ReceivedBytes: transfer.ReceivedBytes,
+			})
+		}
+	}
+
+	data := &kusciaapi.QueryDomainRouteTransferResponseData{}
+	for _, route := range routes {
+		data.Routes = append(data.Routes, route)
+	}
+	sort.Slice(data.Routes, func(i, j int) bool {
+		return data.Routes[i].Destination < data.Routes[j].Destination
+	})
+	return &kusciaapi.QueryDomainRouteTransferResponse{
+		Status: utils.BuildSuccessResponseStatus(),
+		Data:   data,
+	}
+}
+
 func buildRouteStatus(cdr *v1alpha1.ClusterDomainRoute) *kusciaapi.RouteStatus {
 	status := constants.RouteFailed
 	reason := ""
@@ -450,6 +530,11 @@ func validateCreateDomainRouteRequest(request *kusciaapi.CreateDomainRouteReques
 			if request.Transit.Domain == nil || request.Transit.Domain.DomainId == "" {
 				return fmt.Errorf("domain is required when transit method is third domain")
 			}
+			for _, domain := range append([]*kusciaapi.Transit_Domain{request.Transit.Domain}, request.Transit.CandidateDomains...) {
+				if domain.GetDomainId() == request.Source || domain.GetDomainId() == request.Destination {
+					return fmt.Errorf("transit domain %s can not be the source or destination", domain.GetDomainId())
+				}
+			}
 		}
 	}
 
@@ -466,6 +551,24 @@ func validateDomainRouteRequest(request RequestWithDstAndSrc) error {
 	return nil
 }
 
+func validateQueryDomainRouteTransferRequest(request *kusciaapi.QueryDomainRouteTransferRequest) error {
+	if request.Source == "" {
+		return fmt.Errorf("source can not be empty")
+	}
+	for _, date := range []string{request.StartDate, request.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(accounting.TransferDateLayout, date); err != nil {
+			return fmt.Errorf("date %s is invalid, it should be formatted as %s", date, accounting.TransferDateLayout)
+		}
+	}
+	if request.StartDate != "" && request.EndDate != "" && request.StartDate > request.EndDate {
+		return fmt.Errorf("start date %s can not be after end date %s", request.StartDate, request.EndDate)
+	}
+	return nil
+}
+
 func convert2DomainRouteProtocol(protocol string) (drProtocol v1alpha1.DomainRouteProtocolType, isTLS bool, err error) {
 	protocol = strings.ToUpper(protocol)
 	isTLS = false
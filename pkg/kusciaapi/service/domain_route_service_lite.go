@@ -104,3 +104,20 @@ func (s domainRouteServiceLite) BatchQueryDomainRouteStatus(ctx context.Context,
 	}
 	return resp
 }
+
+func (s domainRouteServiceLite) QueryDomainRouteTransfer(ctx context.Context, request *kusciaapi.QueryDomainRouteTransferRequest) *kusciaapi.QueryDomainRouteTransferResponse {
+	// do validate
+	if err := validateQueryDomainRouteTransferRequest(request); err != nil {
+		return &kusciaapi.QueryDomainRouteTransferResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+	// request the master api
+	resp, err := s.kusciaAPIClient.QueryDomainRouteTransfer(ctx, request)
+	if err != nil {
+		return &kusciaapi.QueryDomainRouteTransferResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
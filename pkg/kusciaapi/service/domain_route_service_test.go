@@ -21,12 +21,62 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
+	consts "github.com/secretflow/kuscia/pkg/web/constants"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
 )
 
+func TestQueryDomainRouteTransfer(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := kubefake.NewSimpleClientset()
+	err := accounting.SaveDailyTransfers(ctx, kubeClient, "alice", map[string]accounting.DailyTransfer{
+		"2024-06-01": {"bob": {SentBytes: 100, ReceivedBytes: 200}},
+		"2024-06-02": {"bob": {SentBytes: 10, ReceivedBytes: 20}, "carol": {SentBytes: 1}},
+		"2024-06-03": {"bob": {SentBytes: 1000}},
+	})
+	assert.NoError(t, err)
+	s := domainRouteService{kubeClient: kubeClient}
+
+	res := s.QueryDomainRouteTransfer(ctx, &kusciaapi.QueryDomainRouteTransferRequest{
+		Source:    "alice",
+		StartDate: "2024-06-01",
+		EndDate:   "2024-06-02",
+	})
+	assert.Equal(t, kusciaAPISuccessStatusCode, res.Status.Code)
+	assert.Equal(t, 2, len(res.Data.Routes))
+	bob := res.Data.Routes[0]
+	assert.Equal(t, "bob", bob.Destination)
+	assert.Equal(t, int64(110), bob.SentBytes)
+	assert.Equal(t, int64(220), bob.ReceivedBytes)
+	assert.Equal(t, 2, len(bob.Daily))
+	assert.Equal(t, "2024-06-01", bob.Daily[0].Date)
+	assert.Equal(t, "carol", res.Data.Routes[1].Destination)
+
+	res = s.QueryDomainRouteTransfer(ctx, &kusciaapi.QueryDomainRouteTransferRequest{
+		Source:      "alice",
+		Destination: "carol",
+	})
+	assert.Equal(t, kusciaAPISuccessStatusCode, res.Status.Code)
+	assert.Equal(t, 1, len(res.Data.Routes))
+	assert.Equal(t, int64(1), res.Data.Routes[0].SentBytes)
+
+	res = s.QueryDomainRouteTransfer(ctx, &kusciaapi.QueryDomainRouteTransferRequest{
+		Source:    "alice",
+		StartDate: "2024/06/01",
+	})
+	assert.Equal(t, int32(errorcode.ErrorCode_KusciaAPIErrRequestValidate), res.Status.Code)
+
+	// the transfer accounted by other domains is invisible to a domain
+	ctx = context.WithValue(ctx, consts.AuthRole, consts.AuthRoleDomain)
+	ctx = context.WithValue(ctx, consts.SourceDomainKey, "bob")
+	res = s.QueryDomainRouteTransfer(ctx, &kusciaapi.QueryDomainRouteTransferRequest{Source: "alice"})
+	assert.Equal(t, int32(errorcode.ErrorCode_KusciaAPIErrAuthFailed), res.Status.Code)
+}
+
 func TestCreateDomainRouteWithAllDomainNotExists(t *testing.T) {
 	res := createDomainRoute()
 
@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
@@ -49,10 +50,12 @@ type IDomainService interface {
 	UpdateDomain(ctx context.Context, request *kusciaapi.UpdateDomainRequest) *kusciaapi.UpdateDomainResponse
 	DeleteDomain(ctx context.Context, request *kusciaapi.DeleteDomainRequest) *kusciaapi.DeleteDomainResponse
 	BatchQueryDomain(ctx context.Context, request *kusciaapi.BatchQueryDomainRequest) *kusciaapi.BatchQueryDomainResponse
+	QueryDomainStatusDetail(ctx context.Context, request *kusciaapi.QueryDomainStatusDetailRequest) *kusciaapi.QueryDomainStatusDetailResponse
 }
 
 type domainService struct {
 	kusciaClient kusciaclientset.Interface
+	kubeClient   kubernetes.Interface
 	conf         *config.KusciaAPIConfig
 }
 
@@ -65,6 +68,7 @@ func NewDomainService(config *config.KusciaAPIConfig) IDomainService {
 	default:
 		return &domainService{
 			kusciaClient: config.KusciaClient,
+			kubeClient:   config.KubeClient,
 			conf:         config,
 		}
 	}
@@ -110,3 +110,20 @@ func (s domainServiceLite) BatchQueryDomain(ctx context.Context, request *kuscia
 	}
 	return resp
 }
+
+func (s domainServiceLite) QueryDomainStatusDetail(ctx context.Context, request *kusciaapi.QueryDomainStatusDetailRequest) *kusciaapi.QueryDomainStatusDetailResponse {
+	// do validate
+	if request.DomainId == "" {
+		return &kusciaapi.QueryDomainStatusDetailResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, "domain id can not be empty"),
+		}
+	}
+	// request the master api
+	resp, err := s.kusciaAPIClient.QueryDomainStatusDetail(ctx, request)
+	if err != nil {
+		return &kusciaapi.QueryDomainStatusDetailResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
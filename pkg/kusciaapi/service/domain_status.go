@@ -0,0 +1,326 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/kusciaapi/errorcode"
+	apiutils "github.com/secretflow/kuscia/pkg/kusciaapi/utils"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/pkg/web/utils"
+	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+const (
+	domainHealthHealthy     = "Healthy"
+	domainHealthDegraded    = "Degraded"
+	domainHealthUnavailable = "Unavailable"
+	// domainHealthUnknown is the health of partner domains, whose components are not managed by this cluster.
+	domainHealthUnknown = "Unknown"
+
+	nodeStatusReady    = "Ready"
+	nodeStatusNotReady = "NotReady"
+
+	certSourceDomain = "Domain"
+	certSourceSecret = "Secret"
+)
+
+func (s domainService) QueryDomainStatusDetail(ctx context.Context, request *kusciaapi.QueryDomainStatusDetailRequest) *kusciaapi.QueryDomainStatusDetailResponse {
+	// do validate
+	domainID := request.DomainId
+	if domainID == "" {
+		return &kusciaapi.QueryDomainStatusDetailResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, "domain id can not be empty"),
+		}
+	}
+	// Auth Handler
+	if err := s.authHandler(ctx, request); err != nil {
+		return &kusciaapi.QueryDomainStatusDetailResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
+	kusciaDomain, err := s.kusciaClient.KusciaV1alpha1().Domains().Get(ctx, domainID, metav1.GetOptions{})
+	if err != nil {
+		return &kusciaapi.QueryDomainStatusDetailResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.GetDomainErrorCode(err, pberrorcode.ErrorCode_KusciaAPIErrQueryDomainStatusDetail), err.Error()),
+		}
+	}
+
+	detail, err := s.buildDomainStatusDetail(ctx, kusciaDomain, time.Now())
+	if err != nil {
+		nlog.Errorf("Query status detail of domain %s failed, %v", domainID, err)
+		return &kusciaapi.QueryDomainStatusDetailResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrQueryDomainStatusDetail, err.Error()),
+		}
+	}
+	return &kusciaapi.QueryDomainStatusDetailResponse{
+		Status: utils.BuildSuccessResponseStatus(),
+		Data:   detail,
+	}
+}
+
+// buildDomainStatusDetail aggregates the status of components of domain. Certs and pending approvals are reported
+// for partner domains too, while agents, gateways, apps and resources are only managed for local domains.
+func (s domainService) buildDomainStatusDetail(ctx context.Context, domain *v1alpha1.Domain, now time.Time) (*kusciaapi.DomainStatusDetail, error) {
+	detail := &kusciaapi.DomainStatusDetail{
+		DomainId: domain.Name,
+		Health:   domainHealthUnknown,
+	}
+
+	if cert := domainCertStatus(domain, now); cert != nil {
+		detail.Certs = append(detail.Certs, cert)
+	}
+	pending, err := s.countPendingApprovals(ctx, domain.Name)
+	if err != nil {
+		return nil, fmt.Errorf("count pending approvals failed, %v", err)
+	}
+	detail.PendingApprovals = pending
+
+	if domain.Spec.Role == v1alpha1.Partner {
+		return detail, nil
+	}
+
+	nodes, err := s.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{common.LabelNodeNamespace: domain.Name}).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list nodes failed, %v", err)
+	}
+	gateways, err := s.kusciaClient.KusciaV1alpha1().Gateways(domain.Name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list gateways failed, %v", err)
+	}
+	deployments, err := s.kubeClient.AppsV1().Deployments(domain.Name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list deployments failed, %v", err)
+	}
+	pods, err := s.kubeClient.CoreV1().Pods(domain.Name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list pods failed, %v", err)
+	}
+	secrets, err := s.kubeClient.CoreV1().Secrets(domain.Name).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tls secrets failed, %v", err)
+	}
+
+	healthyAgents := 0
+	for i := range nodes.Items {
+		agent := buildAgentStatus(&nodes.Items[i], now)
+		if agent.Status == nodeStatusReady {
+			healthyAgents++
+		}
+		detail.Agents = append(detail.Agents, agent)
+	}
+	healthyGateways := 0
+	for i := range gateways.Items {
+		gateway := buildGatewayStatus(&gateways.Items[i], now)
+		if gateway.Ready {
+			healthyGateways++
+		}
+		detail.Gateways = append(detail.Gateways, gateway)
+	}
+	for _, d := range deployments.Items {
+		replicas := int32(1)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		detail.Apps = append(detail.Apps, &kusciaapi.DomainAppStatus{
+			Name:              d.Name,
+			Replicas:          replicas,
+			AvailableReplicas: d.Status.AvailableReplicas,
+		})
+	}
+	detail.Resources = buildResourceStatus(nodes.Items, pods.Items)
+	for i := range secrets.Items {
+		if cert := secretCertStatus(&secrets.Items[i], now); cert != nil {
+			detail.Certs = append(detail.Certs, cert)
+		}
+	}
+
+	sort.Slice(detail.Agents, func(i, j int) bool { return detail.Agents[i].Name < detail.Agents[j].Name })
+	sort.Slice(detail.Gateways, func(i, j int) bool { return detail.Gateways[i].Name < detail.Gateways[j].Name })
+	sort.Slice(detail.Apps, func(i, j int) bool { return detail.Apps[i].Name < detail.Apps[j].Name })
+	detail.Health = domainHealth(len(detail.Agents), healthyAgents, len(detail.Gateways), healthyGateways)
+	return detail, nil
+}
+
+func domainHealth(agents, healthyAgents, gateways, healthyGateways int) string {
+	switch {
+	case healthyAgents == 0 || healthyGateways == 0:
+		return domainHealthUnavailable
+	case healthyAgents < agents || healthyGateways < gateways:
+		return domainHealthDegraded
+	default:
+		return domainHealthHealthy
+	}
+}
+
+func buildAgentStatus(node *corev1.Node, now time.Time) *kusciaapi.DomainAgentStatus {
+	agent := &kusciaapi.DomainAgentStatus{
+		Name:    node.Name,
+		Status:  nodeStatusNotReady,
+		Version: node.Status.NodeInfo.KubeletVersion,
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != corev1.NodeReady {
+			continue
+		}
+		if cond.Status == corev1.ConditionTrue {
+			agent.Status = nodeStatusReady
+		}
+		if !cond.LastHeartbeatTime.IsZero() {
+			agent.LastHeartbeatTime = apiutils.TimeRfc3339String(&cond.LastHeartbeatTime)
+			agent.HeartbeatAgeSeconds = int64(now.Sub(cond.LastHeartbeatTime.Time).Seconds())
+		}
+		break
+	}
+	return agent
+}
+
+func buildGatewayStatus(gateway *v1alpha1.Gateway, now time.Time) *kusciaapi.DomainGatewayStatus {
+	status := &kusciaapi.DomainGatewayStatus{
+		Name:    gateway.Name,
+		Version: gateway.Status.Version,
+		Address: gateway.Status.Address,
+	}
+	if heartbeat := gateway.Status.HeartbeatTime; !heartbeat.IsZero() {
+		age := now.Sub(heartbeat.Time)
+		status.LastHeartbeatTime = apiutils.TimeRfc3339String(&heartbeat)
+		status.HeartbeatAgeSeconds = int64(age.Seconds())
+		status.Ready = age < common.GatewayLiveTimeout
+	}
+	return status
+}
+
+// buildResourceStatus sums the resources of nodes, and the requests of pods which are not terminated.
+func buildResourceStatus(nodes []corev1.Node, pods []corev1.Pod) *kusciaapi.DomainResourceStatus {
+	capacity, allocatable, requested := corev1.ResourceList{}, corev1.ResourceList{}, corev1.ResourceList{}
+	for _, node := range nodes {
+		addResources(capacity, node.Status.Capacity)
+		addResources(allocatable, node.Status.Allocatable)
+	}
+	podCount := int32(0)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podCount++
+		for _, c := range pod.Spec.Containers {
+			addResources(requested, c.Resources.Requests)
+		}
+	}
+	return &kusciaapi.DomainResourceStatus{
+		CpuCapacity:       quantityString(capacity, corev1.ResourceCPU),
+		MemoryCapacity:    quantityString(capacity, corev1.ResourceMemory),
+		CpuAllocatable:    quantityString(allocatable, corev1.ResourceCPU),
+		MemoryAllocatable: quantityString(allocatable, corev1.ResourceMemory),
+		CpuRequested:      quantityString(requested, corev1.ResourceCPU),
+		MemoryRequested:   quantityString(requested, corev1.ResourceMemory),
+		Pods:              podCount,
+	}
+}
+
+func addResources(sum, list corev1.ResourceList) {
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		q, ok := list[name]
+		if !ok {
+			continue
+		}
+		total := sum[name]
+		total.Add(q)
+		sum[name] = total
+	}
+}
+
+func quantityString(list corev1.ResourceList, name corev1.ResourceName) string {
+	q, ok := list[name]
+	if !ok {
+		q = resource.Quantity{}
+	}
+	return q.String()
+}
+
+func domainCertStatus(domain *v1alpha1.Domain, now time.Time) *kusciaapi.DomainCertStatus {
+	if domain.Spec.Cert == "" {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(domain.Spec.Cert)
+	if err != nil {
+		nlog.Warnf("Decode cert of domain %s failed, %v", domain.Name, err)
+		return nil
+	}
+	return certStatus(domain.Name, certSourceDomain, data, now)
+}
+
+func secretCertStatus(secret *corev1.Secret, now time.Time) *kusciaapi.DomainCertStatus {
+	data := secret.Data[corev1.TLSCertKey]
+	if len(data) == 0 {
+		return nil
+	}
+	return certStatus(secret.Name, certSourceSecret, data, now)
+}
+
+func certStatus(name, source string, data []byte, now time.Time) *kusciaapi.DomainCertStatus {
+	cert, err := tls.ParseCertData(data)
+	if err != nil {
+		nlog.Warnf("Parse cert %s of %s failed, %v", name, source, err)
+		return nil
+	}
+	return &kusciaapi.DomainCertStatus{
+		Name:             name,
+		Source:           source,
+		Subject:          cert.Subject.String(),
+		NotAfter:         cert.NotAfter.UTC().Format(time.RFC3339),
+		ExpiresInSeconds: int64(cert.NotAfter.Sub(now).Seconds()),
+	}
+}
+
+// countPendingApprovals counts the jobs awaiting the approval of domain.
+func (s domainService) countPendingApprovals(ctx context.Context, domainID string) (int32, error) {
+	jobs, err := s.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+	count := int32(0)
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Status.Phase != v1alpha1.KusciaJobAwaitingApproval || !slices.Contains(jobPartyDomainIDs(job), domainID) {
+			continue
+		}
+		if len(approval.Pending(job, []string{domainID})) > 0 {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+	"github.com/secretflow/kuscia/test/util"
+)
+
+func TestQueryDomainStatusDetail(t *testing.T) {
+	now := time.Now()
+	domain := &v1alpha1.Domain{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec:       v1alpha1.DomainSpec{Cert: util.MakeBase64EncodeCert(t)},
+	}
+	gateways := []*v1alpha1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw-1", Namespace: "alice"},
+			Status:     v1alpha1.GatewayStatus{Version: "v1", HeartbeatTime: metav1.NewTime(now.Add(-10 * time.Second))},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw-2", Namespace: "alice"},
+			Status:     v1alpha1.GatewayStatus{Version: "v1", HeartbeatTime: metav1.NewTime(now.Add(-time.Hour))},
+		},
+	}
+	job := &v1alpha1.KusciaJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-1", Namespace: common.KusciaCrossDomain},
+		Spec: v1alpha1.KusciaJobSpec{
+			Initiator: "bob",
+			Tasks: []v1alpha1.KusciaTaskTemplate{
+				{Parties: []v1alpha1.Party{{DomainID: "alice"}, {DomainID: "bob"}}},
+			},
+		},
+		Status: v1alpha1.KusciaJobStatus{Phase: v1alpha1.KusciaJobAwaitingApproval},
+	}
+	kusciaClient := kusciafake.NewSimpleClientset(domain, job)
+	for _, gw := range gateways {
+		// the object tracker guesses the resource of Gateway as "gatewaies", so gateways are created by client
+		_, err := kusciaClient.KusciaV1alpha1().Gateways(gw.Namespace).Create(context.Background(), gw, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-node", Labels: map[string]string{common.LabelNodeNamespace: "alice"}},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("7"),
+				corev1.ResourceMemory: resource.MustParse("15Gi"),
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastHeartbeatTime: metav1.NewTime(now.Add(-20 * time.Second))},
+			},
+		},
+	}
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "serving", Namespace: "alice"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+	}
+	newPod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "alice"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "main",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}},
+			}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	kubeClient := kubefake.NewSimpleClientset(node, deployment, newPod("running", corev1.PodRunning),
+		newPod("pending", corev1.PodPending), newPod("succeeded", corev1.PodSucceeded))
+
+	s := &domainService{kusciaClient: kusciaClient, kubeClient: kubeClient}
+	res := s.QueryDomainStatusDetail(context.Background(), &kusciaapi.QueryDomainStatusDetailRequest{DomainId: "alice"})
+	assert.Equal(t, kusciaAPISuccessStatusCode, res.Status.Code)
+	detail := res.Data
+	assert.Equal(t, domainHealthDegraded, detail.Health)
+	assert.Len(t, detail.Agents, 1)
+	assert.Equal(t, nodeStatusReady, detail.Agents[0].Status)
+	assert.InDelta(t, 20, detail.Agents[0].HeartbeatAgeSeconds, 2)
+	assert.Len(t, detail.Gateways, 2)
+	assert.True(t, detail.Gateways[0].Ready)
+	assert.False(t, detail.Gateways[1].Ready)
+	assert.Equal(t, []*kusciaapi.DomainAppStatus{{Name: "serving", Replicas: 2, AvailableReplicas: 1}}, detail.Apps)
+	assert.Equal(t, "8", detail.Resources.CpuCapacity)
+	assert.Equal(t, "15Gi", detail.Resources.MemoryAllocatable)
+	assert.Equal(t, "1", detail.Resources.CpuRequested)
+	assert.Equal(t, "2Gi", detail.Resources.MemoryRequested)
+	assert.Equal(t, int32(2), detail.Resources.Pods)
+	assert.Len(t, detail.Certs, 1)
+	assert.Equal(t, certSourceDomain, detail.Certs[0].Source)
+	assert.Greater(t, detail.Certs[0].ExpiresInSeconds, int64(0))
+	assert.Equal(t, int32(1), detail.PendingApprovals)
+
+	res = s.QueryDomainStatusDetail(context.Background(), &kusciaapi.QueryDomainStatusDetailRequest{DomainId: "carol"})
+	assert.Equal(t, int32(errorcode.ErrorCode_KusciaAPIErrDomainNotExists), res.Status.Code)
+}
+
+func TestDomainHealth(t *testing.T) {
+	assert.Equal(t, domainHealthHealthy, domainHealth(1, 1, 2, 2))
+	assert.Equal(t, domainHealthDegraded, domainHealth(2, 1, 2, 2))
+	assert.Equal(t, domainHealthUnavailable, domainHealth(1, 1, 1, 0))
+	assert.Equal(t, domainHealthUnavailable, domainHealth(0, 0, 1, 1))
+}
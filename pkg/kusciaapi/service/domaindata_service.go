@@ -34,6 +34,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/kusciaapi/errorcode"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tenant"
 	consts "github.com/secretflow/kuscia/pkg/web/constants"
 	"github.com/secretflow/kuscia/pkg/web/utils"
 	pbv1alpha1 "github.com/secretflow/kuscia/proto/api/v1alpha1"
@@ -158,6 +159,8 @@ func (s domainDataService) CreateDomainData(ctx context.Context, request *kuscia
 			FileFormat:  common.Convert2KubeFileFormat(request.FileFormat),
 		},
 	}
+	// the domainData belongs to the tenant of request
+	tenant.SetLabel(ctx, kusciaDomainData)
 	// create kuscia domain
 	_, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(request.DomainId).Create(ctx, kusciaDomainData, metav1.CreateOptions{})
 	if err != nil {
@@ -201,6 +204,11 @@ func (s domainDataService) UpdateDomainData(ctx context.Context, request *kuscia
 			Status: utils.BuildErrorResponseStatus(errorcode.GetDomainDataErrorCode(err, pberrorcode.ErrorCode_KusciaAPIErrGetDomainDataFailed), err.Error()),
 		}
 	}
+	if err := tenant.Check(ctx, originalDomainData); err != nil {
+		return &kusciaapi.UpdateDomainDataResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
 
 	s.normalizationUpdateRequest(request, originalDomainData.Spec)
 	if len(request.DatasourceId) > 0 {
@@ -287,6 +295,19 @@ func (s domainDataService) DeleteDomainData(ctx context.Context, request *kuscia
 			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
 		}
 	}
+	if tenant.FromContext(ctx) != "" {
+		domainData, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(request.DomainId).Get(ctx, request.DomaindataId, metav1.GetOptions{})
+		if err != nil {
+			return &kusciaapi.DeleteDomainDataResponse{
+				Status: utils.BuildErrorResponseStatus(errorcode.GetDomainDataErrorCode(err, pberrorcode.ErrorCode_KusciaAPIErrDeleteDomainDataFailed), err.Error()),
+			}
+		}
+		if err := tenant.Check(ctx, domainData); err != nil {
+			return &kusciaapi.DeleteDomainDataResponse{
+				Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+			}
+		}
+	}
 	// record the delete operation
 	nlog.Warnf("Delete domainID: %s domainDataID: %s", request.DomainId, request.DomaindataId)
 	// delete kuscia domainData
@@ -328,6 +349,11 @@ func (s domainDataService) QueryDomainData(ctx context.Context, request *kusciaa
 			Status: utils.BuildErrorResponseStatus(errorcode.GetDomainDataErrorCode(err, pberrorcode.ErrorCode_KusciaAPIErrGetDomainDataFailed), err.Error()),
 		}
 	}
+	if err := tenant.Check(ctx, kusciaDomainData); err != nil {
+		return &kusciaapi.QueryDomainDataResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
 	// build domain response
 	return &kusciaapi.QueryDomainDataResponse{
 		Status: utils.BuildSuccessResponseStatus(),
@@ -383,6 +409,10 @@ func (s domainDataService) BatchQueryDomainData(ctx context.Context, request *ku
 				Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrGetDomainDataFailed, err.Error()),
 			}
 		}
+		// domainData of other tenants are invisible, same as not found
+		if tenant.Check(ctx, kusciaDomainData) != nil {
+			continue
+		}
 		domainData := kusciaapi.DomainData{
 			DomaindataId: kusciaDomainData.Name,
 			DomainId:     kusciaDomainData.Namespace,
@@ -446,6 +476,13 @@ func (s domainDataService) ListDomainData(ctx context.Context, request *kusciaap
 		}
 		selectorStr = selector.String()
 	}
+	// only list the domainData of the tenant of request
+	selectorStr, err := tenant.LabelSelector(ctx, selectorStr)
+	if err != nil {
+		return &kusciaapi.ListDomainDataResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
 	// get kuscia domain
 	// todo support limit and continue
 	dataList, err := s.conf.KusciaClient.KusciaV1alpha1().DomainDatas(request.Data.DomainId).List(ctx, metav1.ListOptions{
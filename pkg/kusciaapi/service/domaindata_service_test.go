@@ -26,6 +26,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/common"
 	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
+	consts "github.com/secretflow/kuscia/pkg/web/constants"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
@@ -375,3 +376,47 @@ func TestListDomainData(t *testing.T) {
 	)
 	assert.NotNil(t, res2)
 }
+
+func TestDomainDataTenant(t *testing.T) {
+	conf := makeDomainDataServiceConfig(t)
+	domainDataService := NewDomainDataService(conf)
+	mockCreateDomainDataSource(t, conf)
+
+	tenantA := context.WithValue(context.Background(), consts.TenantKey, "team-a")
+	tenantB := context.WithValue(context.Background(), consts.TenantKey, "team-b")
+	createData := func(ctx context.Context, id string) {
+		res := domainDataService.CreateDomainData(ctx, &kusciaapi.CreateDomainDataRequest{
+			DomaindataId: id,
+			DomainId:     domainID,
+			Type:         "table",
+			RelativeUri:  "a/b/c.csv",
+			DatasourceId: dsID,
+			Columns:      []*v1alpha1.DataColumn{{Name: "id", Type: "string"}},
+		})
+		assert.Equal(t, kusciaAPISuccessStatusCode, res.Status.Code)
+	}
+	createData(tenantA, "data-a")
+	createData(context.Background(), "data-shared")
+
+	queryRes := domainDataService.QueryDomainData(tenantA, &kusciaapi.QueryDomainDataRequest{
+		Data: &kusciaapi.QueryDomainDataRequestData{DomainId: domainID, DomaindataId: "data-a"},
+	})
+	assert.Equal(t, kusciaAPISuccessStatusCode, queryRes.Status.Code)
+	queryRes = domainDataService.QueryDomainData(tenantB, &kusciaapi.QueryDomainDataRequest{
+		Data: &kusciaapi.QueryDomainDataRequestData{DomainId: domainID, DomaindataId: "data-a"},
+	})
+	assert.Equal(t, int32(errorcode.ErrorCode_KusciaAPIErrAuthFailed), queryRes.Status.Code)
+
+	// tenant only lists its own domainData, the domain lists all
+	listReq := &kusciaapi.ListDomainDataRequest{Data: &kusciaapi.ListDomainDataRequestData{DomainId: domainID}}
+	listRes := domainDataService.ListDomainData(tenantA, listReq)
+	assert.Equal(t, 1, len(listRes.Data.DomaindataList))
+	assert.Equal(t, "data-a", listRes.Data.DomaindataList[0].DomaindataId)
+	assert.Equal(t, 0, len(domainDataService.ListDomainData(tenantB, listReq).Data.DomaindataList))
+	assert.Equal(t, 2, len(domainDataService.ListDomainData(context.Background(), listReq).Data.DomaindataList))
+
+	deleteRes := domainDataService.DeleteDomainData(tenantB, &kusciaapi.DeleteDomainDataRequest{DomainId: domainID, DomaindataId: "data-a"})
+	assert.Equal(t, int32(errorcode.ErrorCode_KusciaAPIErrAuthFailed), deleteRes.Status.Code)
+	deleteRes = domainDataService.DeleteDomainData(tenantA, &kusciaapi.DeleteDomainDataRequest{DomainId: domainID, DomaindataId: "data-a"})
+	assert.Equal(t, kusciaAPISuccessStatusCode, deleteRes.Status.Code)
+}
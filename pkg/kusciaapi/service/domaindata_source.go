@@ -50,6 +50,7 @@ var (
 	errQueryDomainDataSource      = "QueryDomainDataSource failed, %v"
 	errBatchQueryDomainDataSource = "BatchQueryDomainDataSource failed, %v"
 	errListDomainDataSource       = "ListDomainDataSource failed, %v"
+	errTestDomainDataSource       = "TestDomainDataSource failed, %v"
 )
 
 const (
@@ -63,17 +64,20 @@ type IDomainDataSourceService interface {
 	QueryDomainDataSource(ctx context.Context, request *kusciaapi.QueryDomainDataSourceRequest) *kusciaapi.QueryDomainDataSourceResponse
 	BatchQueryDomainDataSource(ctx context.Context, request *kusciaapi.BatchQueryDomainDataSourceRequest) *kusciaapi.BatchQueryDomainDataSourceResponse
 	ListDomainDataSource(ctx context.Context, request *kusciaapi.ListDomainDataSourceRequest) *kusciaapi.ListDomainDataSourceResponse
+	TestDomainDataSource(ctx context.Context, request *kusciaapi.TestDomainDataSourceRequest) *kusciaapi.TestDomainDataSourceResponse
 }
 
 type domainDataSourceService struct {
 	conf          *config.KusciaAPIConfig
 	configService cmservice.IConfigService
+	checker       *dataSourceChecker
 }
 
 func NewDomainDataSourceService(config *config.KusciaAPIConfig, configService cmservice.IConfigService) IDomainDataSourceService {
 	return &domainDataSourceService{
 		conf:          config,
 		configService: configService,
+		checker:       newDataSourceChecker(defaultDataSourceCheckTimeout),
 	}
 }
 
@@ -421,6 +425,38 @@ returnErr:
 	}
 }
 
+// TestDomainDataSource checks the datasource with its stored credentials, a failed check is reported in the
+// diagnosis rather than the status, the status is only failed if the datasource can't be checked.
+func (s domainDataSourceService) TestDomainDataSource(ctx context.Context, request *kusciaapi.TestDomainDataSourceRequest) *kusciaapi.TestDomainDataSourceResponse {
+	if err := s.validateRequestIdentity(request.DomainId); err != nil {
+		nlog.Errorf(errTestDomainDataSource, err.Error())
+		return &kusciaapi.TestDomainDataSourceResponse{
+			Status: utils.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	dataSource, err := s.getDomainDataSource(ctx, request.DomainId, request.DatasourceId)
+	if err != nil {
+		nlog.Errorf(errTestDomainDataSource, err.Error())
+		return &kusciaapi.TestDomainDataSourceResponse{
+			Status: utils.BuildErrorResponseStatus(errorcode.GetDomainDataSourceErrorCode(err, pberrorcode.ErrorCode_KusciaAPIErrTestDomainDataSource), err.Error()),
+		}
+	}
+
+	checks, passed := s.checker.check(ctx, dataSource.Type, dataSource.Info)
+	nlog.Infof("Test domain %s data source %s of type %s, passed=%v", request.DomainId, request.DatasourceId, dataSource.Type, passed)
+	return &kusciaapi.TestDomainDataSourceResponse{
+		Status: utils.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.TestDomainDataSourceResponseData{
+			DomainId:     dataSource.DomainId,
+			DatasourceId: dataSource.DatasourceId,
+			Type:         dataSource.Type,
+			Passed:       passed,
+			Checks:       checks,
+		},
+	}
+}
+
 func (s domainDataSourceService) decryptDatasourceInfo(ctx context.Context, ds *v1alpha1.DomainDataSource) (info *kusciaapi.DataSourceInfo, err error) {
 	if len(ds.Spec.InfoKey) != 0 {
 		info, err = s.getDsInfoByKey(ctx, ds.Spec.Type, ds.Spec.InfoKey)
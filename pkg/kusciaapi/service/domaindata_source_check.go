@@ -0,0 +1,375 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/web/constants"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+const (
+	dataSourceCheckConnectivity   = "Connectivity"
+	dataSourceCheckAuthentication = "Authentication"
+	dataSourceCheckPermission     = "Permission"
+
+	dataSourceCheckPassed  = "Passed"
+	dataSourceCheckFailed  = "Failed"
+	dataSourceCheckSkipped = "Skipped"
+
+	defaultDataSourceCheckTimeout = 5 * time.Second
+)
+
+// mysql error numbers, see https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	mysqlErrDBAccessDenied = 1044
+	mysqlErrAccessDenied   = 1045
+	mysqlErrBadDB          = 1049
+)
+
+// dataSourceCheckResult is the result of a check, it passes if suggestion is empty.
+type dataSourceCheckResult struct {
+	message    string
+	suggestion string
+}
+
+func checkPassed(format string, args ...interface{}) dataSourceCheckResult {
+	return dataSourceCheckResult{message: fmt.Sprintf(format, args...)}
+}
+
+func checkFailed(suggestion string, format string, args ...interface{}) dataSourceCheckResult {
+	return dataSourceCheckResult{message: fmt.Sprintf(format, args...), suggestion: suggestion}
+}
+
+// dataSourceDiagnosis collects the checks of a datasource, the checks after a failed one are skipped.
+type dataSourceDiagnosis struct {
+	ctx    context.Context
+	checks []*kusciaapi.DataSourceCheck
+	failed bool
+}
+
+func (d *dataSourceDiagnosis) run(name string, check func(ctx context.Context) dataSourceCheckResult) {
+	if d.failed {
+		d.skip(name, "skipped because the previous check failed")
+		return
+	}
+	start := time.Now()
+	result := check(d.ctx)
+	c := &kusciaapi.DataSourceCheck{
+		Name:       name,
+		Result:     dataSourceCheckPassed,
+		Message:    result.message,
+		Suggestion: result.suggestion,
+		ElapsedMs:  time.Since(start).Milliseconds(),
+	}
+	if result.suggestion != "" {
+		c.Result = dataSourceCheckFailed
+		d.failed = true
+	}
+	d.checks = append(d.checks, c)
+}
+
+func (d *dataSourceDiagnosis) skip(name, message string) {
+	d.checks = append(d.checks, &kusciaapi.DataSourceCheck{Name: name, Result: dataSourceCheckSkipped, Message: message})
+}
+
+// dataSourceChecker performs the live checks of a datasource with its credentials.
+type dataSourceChecker struct {
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+func newDataSourceChecker(timeout time.Duration) *dataSourceChecker {
+	return &dataSourceChecker{
+		timeout:    timeout,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// check checks whether the datasource is reachable, the credentials are accepted, and the data is accessible.
+func (c *dataSourceChecker) check(ctx context.Context, sourceType string, info *kusciaapi.DataSourceInfo) ([]*kusciaapi.DataSourceCheck, bool) {
+	d := &dataSourceDiagnosis{ctx: ctx}
+	if err := validateDataSourceInfo(sourceType, info); err != nil {
+		d.run(dataSourceCheckConnectivity, func(ctx context.Context) dataSourceCheckResult {
+			return checkFailed("Update the datasource with the complete info", "datasource info is invalid, %v", err)
+		})
+		d.skip(dataSourceCheckAuthentication, "skipped because the previous check failed")
+		d.skip(dataSourceCheckPermission, "skipped because the previous check failed")
+		return d.checks, false
+	}
+
+	switch sourceType {
+	case common.DomainDataSourceTypeLocalFS:
+		c.checkLocalFS(d, info.Localfs)
+	case common.DomainDataSourceTypeOSS:
+		c.checkOSS(d, info.Oss)
+	case common.DomainDataSourceTypeMysql:
+		c.checkMySQL(d, info.Database)
+	case common.DomainDataSourceTypePostgreSQL:
+		d.run(dataSourceCheckConnectivity, func(ctx context.Context) dataSourceCheckResult {
+			return c.dial(ctx, info.Database.Endpoint)
+		})
+		d.skip(dataSourceCheckAuthentication, "credentials of postgresql are not verified, they are verified when the data is read")
+		d.skip(dataSourceCheckPermission, "permissions of postgresql are not verified, they are verified when the data is read")
+	case common.DomainDataSourceTypeODPS:
+		c.checkODPS(d, info.Odps)
+	}
+	return d.checks, !d.failed
+}
+
+func (c *dataSourceChecker) checkLocalFS(d *dataSourceDiagnosis, info *kusciaapi.LocalDataSourceInfo) {
+	d.run(dataSourceCheckConnectivity, func(ctx context.Context) dataSourceCheckResult {
+		fi, err := os.Stat(info.Path)
+		if err != nil {
+			return checkFailed("Create the directory or fix the path of datasource, the path is in the container of kuscia",
+				"path %s is not accessible, %v", info.Path, err)
+		}
+		if !fi.IsDir() {
+			return checkFailed("Fix the path of datasource to a directory", "path %s is not a directory", info.Path)
+		}
+		return checkPassed("path %s exists", info.Path)
+	})
+	d.skip(dataSourceCheckAuthentication, "localfs doesn't require credentials")
+	d.run(dataSourceCheckPermission, func(ctx context.Context) dataSourceCheckResult {
+		suggestion := "Grant kuscia the read and write permission of the path"
+		if _, err := os.ReadDir(info.Path); err != nil {
+			return checkFailed(suggestion, "path %s is not readable, %v", info.Path, err)
+		}
+		f, err := os.CreateTemp(info.Path, ".kuscia-datasource-test-")
+		if err != nil {
+			return checkFailed(suggestion, "path %s is not writable, %v", info.Path, err)
+		}
+		f.Close()
+		os.Remove(f.Name())
+		return checkPassed("path %s is readable and writable", info.Path)
+	})
+}
+
+func (c *dataSourceChecker) checkOSS(d *dataSourceDiagnosis, info *kusciaapi.OssDataSourceInfo) {
+	d.run(dataSourceCheckConnectivity, func(ctx context.Context) dataSourceCheckResult {
+		return c.dial(ctx, info.Endpoint)
+	})
+
+	// listing objects verifies both credentials and permission, the error code tells which one fails
+	var listErr awserr.Error
+	d.run(dataSourceCheckAuthentication, func(ctx context.Context) dataSourceCheckResult {
+		sess, err := session.NewSession(&aws.Config{
+			Credentials:      credentials.NewStaticCredentials(info.AccessKeyId, info.AccessKeySecret, ""),
+			Endpoint:         aws.String(info.Endpoint),
+			Region:           aws.String("us-west-2"),
+			S3ForcePathStyle: aws.Bool(!info.Virtualhost),
+			HTTPClient:       c.httpClient,
+			MaxRetries:       aws.Int(0),
+		})
+		if err != nil {
+			return checkFailed("Fix the endpoint of datasource", "failed to create oss client, %v", err)
+		}
+		_, err = s3.New(sess).ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+			Bucket:  aws.String(info.Bucket),
+			Prefix:  aws.String(info.Prefix),
+			MaxKeys: aws.Int64(1),
+		})
+		if err == nil {
+			return checkPassed("access key %s is accepted", info.AccessKeyId)
+		}
+		if !errors.As(err, &listErr) {
+			return checkFailed("Check the endpoint and credentials of datasource", "failed to list objects, %v", err)
+		}
+		switch listErr.Code() {
+		case "AccessDenied", s3.ErrCodeNoSuchBucket:
+			return checkPassed("access key %s is accepted", info.AccessKeyId)
+		case "InvalidAccessKeyId", "SignatureDoesNotMatch":
+			return checkFailed("Fix the access_key_id and access_key_secret of datasource",
+				"access key %s is rejected, %s", info.AccessKeyId, listErr.Message())
+		default:
+			return checkFailed("Check the endpoint and credentials of datasource", "failed to list objects, %v", err)
+		}
+	})
+	d.run(dataSourceCheckPermission, func(ctx context.Context) dataSourceCheckResult {
+		if listErr == nil {
+			return checkPassed("objects in bucket %s with prefix %q are listable", info.Bucket, info.Prefix)
+		}
+		if listErr.Code() == s3.ErrCodeNoSuchBucket {
+			return checkFailed("Create the bucket or fix the bucket of datasource", "bucket %s doesn't exist", info.Bucket)
+		}
+		return checkFailed("Grant the access key the permission to list and read objects of the bucket",
+			"access key %s has no permission to list objects in bucket %s, %s", info.AccessKeyId, info.Bucket, listErr.Message())
+	})
+}
+
+func (c *dataSourceChecker) checkMySQL(d *dataSourceDiagnosis, info *kusciaapi.DatabaseDataSourceInfo) {
+	d.run(dataSourceCheckConnectivity, func(ctx context.Context) dataSourceCheckResult {
+		return c.dial(ctx, info.Endpoint)
+	})
+	ping := func(ctx context.Context, database string) error {
+		conf := mysql.NewConfig()
+		conf.User = info.User
+		conf.Passwd = info.Password
+		conf.Net = "tcp"
+		conf.Addr = info.Endpoint
+		conf.DBName = database
+		conf.Timeout = c.timeout
+		db, err := sql.Open("mysql", conf.FormatDSN())
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		return db.PingContext(ctx)
+	}
+	d.run(dataSourceCheckAuthentication, func(ctx context.Context) dataSourceCheckResult {
+		err := ping(ctx, "")
+		if err == nil {
+			return checkPassed("user %s is authenticated", info.User)
+		}
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrAccessDenied {
+			return checkFailed("Fix the user and password of datasource", "user %s is rejected, %v", info.User, err)
+		}
+		return checkFailed("Check the endpoint and credentials of datasource", "failed to connect to mysql, %v", err)
+	})
+	d.run(dataSourceCheckPermission, func(ctx context.Context) dataSourceCheckResult {
+		err := ping(ctx, info.Database)
+		if err == nil {
+			return checkPassed("database %s is accessible", info.Database)
+		}
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrBadDB {
+			return checkFailed("Create the database or fix the database of datasource", "database %s doesn't exist", info.Database)
+		}
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDBAccessDenied {
+			return checkFailed(fmt.Sprintf("Grant user %s the privileges on database %s", info.User, info.Database),
+				"user %s has no permission to access database %s", info.User, info.Database)
+		}
+		return checkFailed("Check the database of datasource", "failed to access database %s, %v", info.Database, err)
+	})
+}
+
+func (c *dataSourceChecker) checkODPS(d *dataSourceDiagnosis, info *kusciaapi.OdpsDataSourceInfo) {
+	d.run(dataSourceCheckConnectivity, func(ctx context.Context) dataSourceCheckResult {
+		return c.dial(ctx, info.Endpoint)
+	})
+
+	// getting the project verifies both credentials and permission, the status code tells which one fails
+	var statusCode int
+	d.run(dataSourceCheckAuthentication, func(ctx context.Context) dataSourceCheckResult {
+		resource := "/projects/" + info.Project
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(info.Endpoint, "/")+resource, nil)
+		if err != nil {
+			return checkFailed("Fix the endpoint of datasource", "failed to build odps request, %v", err)
+		}
+		signODPSRequest(req, info.AccessKeyId, info.AccessKeySecret, resource)
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return checkFailed("Check the endpoint of datasource", "failed to request odps, %v", err)
+		}
+		resp.Body.Close()
+		statusCode = resp.StatusCode
+		switch {
+		case statusCode == http.StatusUnauthorized:
+			return checkFailed("Fix the access_key_id and access_key_secret of datasource", "access key %s is rejected", info.AccessKeyId)
+		case statusCode < http.StatusBadRequest, statusCode == http.StatusForbidden, statusCode == http.StatusNotFound:
+			return checkPassed("access key %s is accepted", info.AccessKeyId)
+		default:
+			return checkFailed("Check the endpoint and credentials of datasource", "odps responds with status %s", resp.Status)
+		}
+	})
+	d.run(dataSourceCheckPermission, func(ctx context.Context) dataSourceCheckResult {
+		switch statusCode {
+		case http.StatusNotFound:
+			return checkFailed("Fix the project of datasource", "project %s doesn't exist", info.Project)
+		case http.StatusForbidden:
+			return checkFailed(fmt.Sprintf("Grant the access key the permission of project %s", info.Project),
+				"access key %s has no permission to access project %s", info.AccessKeyId, info.Project)
+		default:
+			return checkPassed("project %s is accessible", info.Project)
+		}
+	})
+}
+
+// dial checks whether the endpoint is reachable by tcp.
+func (c *dataSourceChecker) dial(ctx context.Context, endpoint string) dataSourceCheckResult {
+	addr, err := endpointAddress(endpoint)
+	if err != nil {
+		return checkFailed("Fix the endpoint of datasource", "endpoint %s is invalid, %v", endpoint, err)
+	}
+	dialer := &net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return checkFailed("Check the endpoint of datasource, and the network and firewall between kuscia and the datasource",
+			"endpoint %s is unreachable, %v", addr, err)
+	}
+	conn.Close()
+	return checkPassed("endpoint %s is reachable", addr)
+}
+
+// endpointAddress returns host:port of endpoint, which is a url like https://oss.xxx.com or an address like
+// localhost:3306. The scheme is https if it's not specified.
+func endpointAddress(endpoint string) (string, error) {
+	if !strings.Contains(endpoint, "://") {
+		if _, _, err := net.SplitHostPort(endpoint); err == nil {
+			return endpoint, nil
+		}
+		endpoint = constants.SchemaHTTPS + "://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", errors.New("host is empty")
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == constants.SchemaHTTP {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// signODPSRequest signs the request with the access key as the odps sdk does.
+func signODPSRequest(req *http.Request, accessKeyID, accessKeySecret, resource string) {
+	date := time.Now().UTC().Format(http.TimeFormat)
+	req.Header.Set("Date", date)
+	// method, content-md5, content-type, date and canonical resource
+	stringToSign := strings.Join([]string{req.Method, "", "", date, resource}, "\n")
+	mac := hmac.New(sha1.New, []byte(accessKeySecret))
+	mac.Write([]byte(stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("ODPS %s:%s", accessKeyID, base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func checkResults(checks []*kusciaapi.DataSourceCheck) []string {
+	var results []string
+	for _, c := range checks {
+		results = append(results, c.Name+":"+c.Result)
+	}
+	return results
+}
+
+func TestDataSourceChecker_LocalFS(t *testing.T) {
+	c := newDataSourceChecker(time.Second)
+	dir := t.TempDir()
+
+	checks, passed := c.check(context.Background(), common.DomainDataSourceTypeLocalFS, &kusciaapi.DataSourceInfo{
+		Localfs: &kusciaapi.LocalDataSourceInfo{Path: dir},
+	})
+	assert.True(t, passed)
+	assert.Equal(t, []string{"Connectivity:Passed", "Authentication:Skipped", "Permission:Passed"}, checkResults(checks))
+
+	checks, passed = c.check(context.Background(), common.DomainDataSourceTypeLocalFS, &kusciaapi.DataSourceInfo{
+		Localfs: &kusciaapi.LocalDataSourceInfo{Path: filepath.Join(dir, "not-exist")},
+	})
+	assert.False(t, passed)
+	assert.Equal(t, []string{"Connectivity:Failed", "Authentication:Skipped", "Permission:Skipped"}, checkResults(checks))
+	assert.NotEmpty(t, checks[0].Suggestion)
+
+	checks, passed = c.check(context.Background(), common.DomainDataSourceTypeLocalFS, &kusciaapi.DataSourceInfo{})
+	assert.False(t, passed)
+	assert.Contains(t, checks[0].Message, "invalid")
+}
+
+func TestDataSourceChecker_MySQLUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	endpoint := listener.Addr().String()
+	listener.Close()
+
+	c := newDataSourceChecker(time.Second)
+	checks, passed := c.check(context.Background(), common.DomainDataSourceTypeMysql, &kusciaapi.DataSourceInfo{
+		Database: &kusciaapi.DatabaseDataSourceInfo{Endpoint: endpoint, User: "root", Password: "passwd", Database: "kuscia"},
+	})
+	assert.False(t, passed)
+	assert.Equal(t, []string{"Connectivity:Failed", "Authentication:Skipped", "Permission:Skipped"}, checkResults(checks))
+	assert.Contains(t, checks[0].Message, "unreachable")
+}
+
+func TestDataSourceChecker_OSS(t *testing.T) {
+	code := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if code == "" {
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><ListBucketResult><Name>bucket</Name><KeyCount>0</KeyCount></ListBucketResult>`))
+			return
+		}
+		status := http.StatusForbidden
+		if code == "NoSuchBucket" {
+			status = http.StatusNotFound
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>` + code + `</Code><Message>denied</Message></Error>`))
+	}))
+	defer server.Close()
+
+	info := &kusciaapi.DataSourceInfo{Oss: &kusciaapi.OssDataSourceInfo{
+		Endpoint: server.URL, Bucket: "bucket", AccessKeyId: "ak", AccessKeySecret: "sk",
+	}}
+	c := newDataSourceChecker(time.Second)
+	tests := []struct {
+		code    string
+		results []string
+	}{
+		{code: "", results: []string{"Connectivity:Passed", "Authentication:Passed", "Permission:Passed"}},
+		{code: "InvalidAccessKeyId", results: []string{"Connectivity:Passed", "Authentication:Failed", "Permission:Skipped"}},
+		{code: "AccessDenied", results: []string{"Connectivity:Passed", "Authentication:Passed", "Permission:Failed"}},
+		{code: "NoSuchBucket", results: []string{"Connectivity:Passed", "Authentication:Passed", "Permission:Failed"}},
+	}
+	for _, tt := range tests {
+		code = tt.code
+		checks, passed := c.check(context.Background(), common.DomainDataSourceTypeOSS, info)
+		assert.Equal(t, tt.code == "", passed, tt.code)
+		assert.Equal(t, tt.results, checkResults(checks), tt.code)
+	}
+}
+
+func TestDataSourceChecker_ODPS(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/projects/kuscia", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "ODPS ak:"))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	info := &kusciaapi.DataSourceInfo{Odps: &kusciaapi.OdpsDataSourceInfo{
+		Endpoint: server.URL + "/api", Project: "kuscia", AccessKeyId: "ak", AccessKeySecret: "sk",
+	}}
+	c := newDataSourceChecker(time.Second)
+	tests := []struct {
+		status  int
+		results []string
+	}{
+		{status: http.StatusOK, results: []string{"Connectivity:Passed", "Authentication:Passed", "Permission:Passed"}},
+		{status: http.StatusUnauthorized, results: []string{"Connectivity:Passed", "Authentication:Failed", "Permission:Skipped"}},
+		{status: http.StatusForbidden, results: []string{"Connectivity:Passed", "Authentication:Passed", "Permission:Failed"}},
+		{status: http.StatusNotFound, results: []string{"Connectivity:Passed", "Authentication:Passed", "Permission:Failed"}},
+	}
+	for _, tt := range tests {
+		status = tt.status
+		checks, _ := c.check(context.Background(), common.DomainDataSourceTypeODPS, info)
+		assert.Equal(t, tt.results, checkResults(checks), tt.status)
+	}
+}
+
+func TestEndpointAddress(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{endpoint: "localhost:3306", want: "localhost:3306"},
+		{endpoint: "https://oss.xxx.cn-xxx.com", want: "oss.xxx.cn-xxx.com:443"},
+		{endpoint: "http://127.0.0.1:9000", want: "127.0.0.1:9000"},
+		{endpoint: "http://service.odps.com/api", want: "service.odps.com:80"},
+		{endpoint: "oss.xxx.cn-xxx.com", want: "oss.xxx.cn-xxx.com:443"},
+	}
+	for _, tt := range tests {
+		got, err := endpointAddress(tt.endpoint)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+}
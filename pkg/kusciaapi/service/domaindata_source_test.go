@@ -308,3 +308,34 @@ func makeConfigService(t *testing.T) cmservice.IConfigService {
 	assert.Nil(t, err)
 	return configService
 }
+
+func TestTestDomainDataSource(t *testing.T) {
+	dataSourceID := "ds-1"
+	conf := makeDomainDataSourceServiceConfig(t)
+	dsService := makeDomainDataSourceService(t, conf)
+	createRes := dsService.CreateDomainDataSource(context.Background(), &kusciaapi.CreateDomainDataSourceRequest{
+		DomainId:     mockDomainID,
+		DatasourceId: dataSourceID,
+		Type:         common.DomainDataSourceTypeLocalFS,
+		Info: &kusciaapi.DataSourceInfo{
+			Localfs: &kusciaapi.LocalDataSourceInfo{
+				Path: t.TempDir(),
+			},
+		},
+	})
+	assert.Equal(t, int32(0), createRes.Status.Code)
+
+	res := dsService.TestDomainDataSource(context.Background(), &kusciaapi.TestDomainDataSourceRequest{
+		DomainId:     mockDomainID,
+		DatasourceId: dataSourceID,
+	})
+	assert.Equal(t, int32(0), res.Status.Code)
+	assert.True(t, res.Data.Passed)
+	assert.Len(t, res.Data.Checks, 3)
+
+	res = dsService.TestDomainDataSource(context.Background(), &kusciaapi.TestDomainDataSourceRequest{
+		DomainId:     "bob",
+		DatasourceId: dataSourceID,
+	})
+	assert.Equal(t, int32(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate), res.Status.Code)
+}
@@ -0,0 +1,253 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/resources"
+	utils2 "github.com/secretflow/kuscia/pkg/web/utils"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+const (
+	dryRunCheckRequest          = "request"
+	dryRunCheckAuth             = "auth"
+	dryRunCheckJob              = "job"
+	dryRunCheckParties          = "parties"
+	dryRunCheckRoutes           = "routes"
+	dryRunCheckAppImages        = "app_images"
+	dryRunCheckPartnerApps      = "partner_apps"
+	dryRunCheckDomainDataGrants = "domaindata_grants"
+)
+
+// dryRunCreateJob runs the checks of creating the job and the checks the job would fail on when it's scheduled, and
+// reports all problems found instead of stopping at the first one. Nothing is created.
+func (h *jobService) dryRunCreateJob(ctx context.Context, request *kusciaapi.CreateJobRequest) *kusciaapi.CreateJobResponse {
+	report := &kusciaapi.JobDryRunReport{}
+	addCheck := func(name string, errs, warnings []string) {
+		report.Checks = append(report.Checks, &kusciaapi.JobDryRunCheck{
+			Name:     name,
+			Passed:   len(errs) == 0,
+			Errors:   errs,
+			Warnings: warnings,
+		})
+	}
+
+	// the other checks rely on a well-formed request
+	if err := validateCreateJobRequest(request, h.Initiator); err != nil {
+		addCheck(dryRunCheckRequest, []string{err.Error()}, nil)
+		return buildDryRunResponse(request.JobId, report)
+	}
+	addCheck(dryRunCheckRequest, nil, nil)
+
+	var errs []string
+	if err := h.authHandlerJobCreate(ctx, request); err != nil {
+		errs = append(errs, err.Error())
+	}
+	addCheck(dryRunCheckAuth, errs, nil)
+
+	addCheck(dryRunCheckJob, h.dryRunJob(ctx, request), nil)
+	addCheck(dryRunCheckParties, h.dryRunParties(ctx, request), nil)
+	addCheck(dryRunCheckRoutes, h.dryRunRoutes(ctx, request), nil)
+	errs, warnings := h.dryRunAppImages(ctx, request.Tasks)
+	addCheck(dryRunCheckAppImages, errs, warnings)
+
+	errs = nil
+	if err := h.validatePartnerApps(ctx, request.Tasks); err != nil {
+		errs = append(errs, err.Error())
+	}
+	addCheck(dryRunCheckPartnerApps, errs, nil)
+
+	errs, warnings = h.dryRunDomainDataGrants(ctx, request)
+	addCheck(dryRunCheckDomainDataGrants, errs, warnings)
+
+	return buildDryRunResponse(request.JobId, report)
+}
+
+func buildDryRunResponse(jobID string, report *kusciaapi.JobDryRunReport) *kusciaapi.CreateJobResponse {
+	report.Valid = true
+	for _, check := range report.Checks {
+		report.Valid = report.Valid && check.Passed
+	}
+	return &kusciaapi.CreateJobResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.CreateJobResponseData{
+			JobId:        jobID,
+			DryRunReport: report,
+		},
+	}
+}
+
+// dryRunJob checks the request can be converted to a kuscia job and the job id isn't taken.
+func (h *jobService) dryRunJob(ctx context.Context, request *kusciaapi.CreateJobRequest) (errs []string) {
+	if _, err := buildKusciaJob(ctx, request); err != nil {
+		errs = append(errs, err.Error())
+	}
+	_, err := h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(ctx, request.JobId, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		errs = append(errs, fmt.Sprintf("job %s already exists", request.JobId))
+	case !apierrors.IsNotFound(err):
+		errs = append(errs, fmt.Sprintf("get job %s failed, %v", request.JobId, err))
+	}
+	return errs
+}
+
+// dryRunParties checks the initiator and parties of tasks are registered domains.
+func (h *jobService) dryRunParties(ctx context.Context, request *kusciaapi.CreateJobRequest) (errs []string) {
+	for _, domainID := range dryRunDomainIDs(request) {
+		if _, err := h.kusciaClient.KusciaV1alpha1().Domains().Get(ctx, domainID, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				errs = append(errs, fmt.Sprintf("domain %s does not exist", domainID))
+			} else {
+				errs = append(errs, fmt.Sprintf("get domain %s failed, %v", domainID, err))
+			}
+		}
+	}
+	return errs
+}
+
+// dryRunRoutes checks the routes between parties of each task are ready, tasks can't start without them.
+func (h *jobService) dryRunRoutes(ctx context.Context, request *kusciaapi.CreateJobRequest) (errs []string) {
+	checked := map[string]bool{}
+	for _, task := range request.Tasks {
+		for _, src := range task.Parties {
+			for _, dst := range task.Parties {
+				name := buildRouteName(src.DomainId, dst.DomainId)
+				if src.DomainId == dst.DomainId || checked[name] {
+					continue
+				}
+				checked[name] = true
+
+				cdr, err := h.kusciaClient.KusciaV1alpha1().ClusterDomainRoutes().Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					if apierrors.IsNotFound(err) {
+						errs = append(errs, fmt.Sprintf("route from %s to %s does not exist", src.DomainId, dst.DomainId))
+					} else {
+						errs = append(errs, fmt.Sprintf("get route %s failed, %v", name, err))
+					}
+					continue
+				}
+				if !isClusterDomainRouteReady(cdr) {
+					errs = append(errs, fmt.Sprintf("route from %s to %s is not ready", src.DomainId, dst.DomainId))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func isClusterDomainRouteReady(cdr *v1alpha1.ClusterDomainRoute) bool {
+	for _, cond := range cdr.Status.Conditions {
+		if cond.Type == v1alpha1.ClusterDomainRouteReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// dryRunAppImages renders the deploy templates of app images for each party of tasks.
+func (h *jobService) dryRunAppImages(ctx context.Context, tasks []*kusciaapi.Task) (errs, warnings []string) {
+	for i, task := range tasks {
+		appImage, err := h.kusciaClient.KusciaV1alpha1().AppImages().Get(ctx, task.AppImage, metav1.GetOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("tasks[%d]: get app image %s failed, %v", i, task.AppImage, err))
+			continue
+		}
+		for _, party := range task.Parties {
+			dryRun := resources.DryRunAppImage(appImage, party.Role, task.AppImageChannel, party.DomainId, task.TaskInputConfig)
+			for _, e := range dryRun.Errors {
+				errs = append(errs, fmt.Sprintf("tasks[%d] party %s: %s", i, party.DomainId, e))
+			}
+			for _, w := range dryRun.Warnings {
+				warnings = append(warnings, fmt.Sprintf("tasks[%d] party %s: %s", i, party.DomainId, w))
+			}
+		}
+	}
+	return errs, warnings
+}
+
+// dryRunDomainDataGrants checks the domaindata read by tasks is granted to the initiator if it's owned by another
+// party. The input domaindata of a task is read from sf_input_ids of the task input config, domaindata produced by
+// former tasks and domaindata invisible to this domain are reported as warnings.
+func (h *jobService) dryRunDomainDataGrants(ctx context.Context, request *kusciaapi.CreateJobRequest) (errs, warnings []string) {
+	outputs := map[string]bool{}
+	for i, task := range request.Tasks {
+		inputIDs, outputIDs, err := resources.TaskInputDomainDataIDs(task.TaskInputConfig)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("tasks[%d]: skip checking input domaindata, task input config isn't json", i))
+			continue
+		}
+
+		for _, dataID := range inputIDs {
+			if outputs[dataID] {
+				continue
+			}
+			owner := h.findDomainDataOwner(ctx, dataID, task.Parties)
+			if owner == "" {
+				warnings = append(warnings, fmt.Sprintf("tasks[%d]: domaindata %s is not found in parties", i, dataID))
+				continue
+			}
+			if owner == request.Initiator {
+				continue
+			}
+			grants, err := h.kusciaClient.KusciaV1alpha1().DomainDataGrants(owner).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("tasks[%d]: list domaindatagrants of %s failed, %v", i, owner, err))
+				continue
+			}
+			if err := resources.CheckDomainDataGranted(grants.Items, dataID, request.Initiator, time.Now()); err != nil {
+				errs = append(errs, fmt.Sprintf("tasks[%d]: %v", i, err))
+			}
+		}
+		for _, dataID := range outputIDs {
+			outputs[dataID] = true
+		}
+	}
+	return errs, warnings
+}
+
+func (h *jobService) findDomainDataOwner(ctx context.Context, dataID string, parties []*kusciaapi.Party) string {
+	for _, party := range parties {
+		if _, err := h.kusciaClient.KusciaV1alpha1().DomainDatas(party.DomainId).Get(ctx, dataID, metav1.GetOptions{}); err == nil {
+			return party.DomainId
+		}
+	}
+	return ""
+}
+
+// dryRunDomainIDs returns the initiator and parties of tasks without duplicates.
+func dryRunDomainIDs(request *kusciaapi.CreateJobRequest) []string {
+	seen := map[string]bool{request.Initiator: true}
+	domainIDs := []string{request.Initiator}
+	for _, task := range request.Tasks {
+		for _, party := range task.Parties {
+			if !seen[party.DomainId] {
+				seen[party.DomainId] = true
+				domainIDs = append(domainIDs, party.DomainId)
+			}
+		}
+	}
+	return domainIDs
+}
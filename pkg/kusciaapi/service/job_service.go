@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,15 +33,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
+	"github.com/secretflow/kuscia/pkg/interconn/kuscia/metadata"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/proxy"
 	"github.com/secretflow/kuscia/pkg/kusciaapi/utils"
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
+	"github.com/secretflow/kuscia/pkg/utils/approval"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 	"github.com/secretflow/kuscia/pkg/utils/resources"
+	"github.com/secretflow/kuscia/pkg/utils/tenant"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
 	consts "github.com/secretflow/kuscia/pkg/web/constants"
 	utils2 "github.com/secretflow/kuscia/pkg/web/utils"
 	pberrorcode "github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
@@ -53,14 +63,22 @@ type IJobService interface {
 	DeleteJob(ctx context.Context, request *kusciaapi.DeleteJobRequest) *kusciaapi.DeleteJobResponse
 	WatchJob(ctx context.Context, request *kusciaapi.WatchJobRequest, event chan<- *kusciaapi.WatchJobEventResponse) error
 	ApproveJob(ctx context.Context, request *kusciaapi.ApproveJobRequest) *kusciaapi.ApproveJobResponse
+	ListPendingApprovals(ctx context.Context, request *kusciaapi.ListPendingApprovalsRequest) *kusciaapi.ListPendingApprovalsResponse
 	SuspendJob(ctx context.Context, request *kusciaapi.SuspendJobRequest) *kusciaapi.SuspendJobResponse
 	RestartJob(ctx context.Context, request *kusciaapi.RestartJobRequest) *kusciaapi.RestartJobResponse
 	CancelJob(ctx context.Context, request *kusciaapi.CancelJobRequest) *kusciaapi.CancelJobResponse
+	QueryJobResourceUsage(ctx context.Context, request *kusciaapi.QueryJobResourceUsageRequest) *kusciaapi.QueryJobResourceUsageResponse
+	QueryJobHistory(ctx context.Context, request *kusciaapi.QueryJobHistoryRequest) *kusciaapi.QueryJobHistoryResponse
 }
 
 type jobService struct {
 	Initiator    string
 	kusciaClient kusciaclientset.Interface
+	kubeClient   kubernetes.Interface
+	// partnerMetadata queries the apps advertised by partners to validate jobs.
+	partnerMetadata *metadata.Client
+	// jobHistory archives finished jobs, nil means no history is kept.
+	jobHistory jobhistory.Store
 }
 
 func NewJobService(config *config.KusciaAPIConfig) IJobService {
@@ -72,13 +90,19 @@ func NewJobService(config *config.KusciaAPIConfig) IJobService {
 		}
 	default:
 		return &jobService{
-			Initiator:    config.Initiator,
-			kusciaClient: config.KusciaClient,
+			Initiator:       config.Initiator,
+			kusciaClient:    config.KusciaClient,
+			kubeClient:      config.KubeClient,
+			partnerMetadata: metadata.NewClient(config.DomainID, metadata.DefaultCacheTTL),
+			jobHistory:      config.JobHistory,
 		}
 	}
 }
 
 func (h *jobService) CreateJob(ctx context.Context, request *kusciaapi.CreateJobRequest) *kusciaapi.CreateJobResponse {
+	if request.DryRun {
+		return h.dryRunCreateJob(ctx, request)
+	}
 	// do validate
 	if err := validateCreateJobRequest(request, h.Initiator); err != nil {
 		return &kusciaapi.CreateJobResponse{
@@ -91,6 +115,45 @@ func (h *jobService) CreateJob(ctx context.Context, request *kusciaapi.CreateJob
 			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
 		}
 	}
+	if err := h.validateAppImageChannels(ctx, request.Tasks); err != nil {
+		return &kusciaapi.CreateJobResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+	if err := h.validateTaskInputConfigs(ctx, request.Tasks); err != nil {
+		return &kusciaapi.CreateJobResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+	if err := h.validatePartnerApps(ctx, request.Tasks); err != nil {
+		return &kusciaapi.CreateJobResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+	kusciaJob, err := buildKusciaJob(ctx, request)
+	if err != nil {
+		return &kusciaapi.CreateJobResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	// create kuscia job
+	_, err = h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Create(ctx, kusciaJob, metav1.CreateOptions{})
+	if err != nil {
+		return &kusciaapi.CreateJobResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrCreateJob, err.Error()),
+		}
+	}
+	return &kusciaapi.CreateJobResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.CreateJobResponseData{
+			JobId: request.JobId,
+		},
+	}
+}
+
+// buildKusciaJob converts the request to kuscia job.
+func buildKusciaJob(ctx context.Context, request *kusciaapi.CreateJobRequest) (*v1alpha1.KusciaJob, error) {
 	// convert createJobRequest to kuscia job
 	tasks := request.Tasks
 	kusciaTasks := make([]v1alpha1.KusciaTaskTemplate, len(tasks))
@@ -106,18 +169,14 @@ func (h *jobService) CreateJob(ctx context.Context, request *kusciaapi.CreateJob
 					if q, err := k8sresource.ParseQuantity(party.Resources.Cpu); err == nil {
 						limitResource[corev1.ResourceCPU] = q
 					} else {
-						return &kusciaapi.CreateJobResponse{
-							Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, fmt.Sprintf("parse input cpu resource failed: %v", err.Error())),
-						}
+						return nil, fmt.Errorf("parse input cpu resource failed: %v", err.Error())
 					}
 				}
 				if party.Resources.Memory != "" {
 					if q, err := k8sresource.ParseQuantity(party.Resources.Memory); err == nil {
 						limitResource[corev1.ResourceMemory] = q
 					} else {
-						return &kusciaapi.CreateJobResponse{
-							Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, fmt.Sprintf("parse input memory resource failed: %v", err.Error())),
-						}
+						return nil, fmt.Errorf("parse input memory resource failed: %v", err.Error())
 					}
 				}
 				resource = &corev1.ResourceRequirements{
@@ -130,14 +189,10 @@ func (h *jobService) CreateJob(ctx context.Context, request *kusciaapi.CreateJob
 			if len(party.BandwidthLimits) > 0 {
 				for _, bw := range party.BandwidthLimits {
 					if bw.LimitKbps <= 0 {
-						return &kusciaapi.CreateJobResponse{
-							Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, "bandwidth limit kbps can not be zero or negative"),
-						}
+						return nil, fmt.Errorf("bandwidth limit kbps can not be zero or negative")
 					}
 					if bw.DestinationId == "" {
-						return &kusciaapi.CreateJobResponse{
-							Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, "bandwidth limit destination id can not be empty"),
-						}
+						return nil, fmt.Errorf("bandwidth limit destination id can not be empty")
 					}
 					bandwidthLimits = append(bandwidthLimits, v1alpha1.BandwidthLimit{
 						DestinationID: bw.DestinationId,
@@ -146,10 +201,19 @@ func (h *jobService) CreateJob(ctx context.Context, request *kusciaapi.CreateJob
 				}
 			}
 
+			var replicas *int32
+			if party.Replicas < 0 {
+				return nil, fmt.Errorf("replicas of party %s can not be negative", party.DomainId)
+			} else if party.Replicas > 0 {
+				r := party.Replicas
+				replicas = &r
+			}
+
 			kusciaParties[j] = v1alpha1.Party{
 				DomainID:       party.DomainId,
 				Role:           party.Role,
 				Resources:      resource,
+				Replicas:       replicas,
 				BandwidthLimit: bandwidthLimits,
 			}
 		}
@@ -159,6 +223,7 @@ func (h *jobService) CreateJob(ctx context.Context, request *kusciaapi.CreateJob
 			Alias:           task.Alias,
 			Dependencies:    task.Dependencies,
 			AppImage:        task.AppImage,
+			AppImageChannel: task.AppImageChannel,
 			TaskInputConfig: task.TaskInputConfig,
 			Parties:         kusciaParties,
 			Priority:        int(task.Priority),
@@ -180,30 +245,24 @@ func (h *jobService) CreateJob(ctx context.Context, request *kusciaapi.CreateJob
 
 	kusciaJob := &v1alpha1.KusciaJob{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   request.JobId,
-			Labels: labels,
+			Name:        request.JobId,
+			Labels:      labels,
+			Annotations: map[string]string{},
 		},
 		Spec: v1alpha1.KusciaJobSpec{
 			Initiator:      request.Initiator,
 			MaxParallelism: utils.IntValue(request.MaxParallelism),
+			Priority:       request.Priority,
 			ScheduleMode:   v1alpha1.KusciaJobScheduleModeBestEffort,
 			Tasks:          kusciaTasks,
 		},
 	}
 
-	// create kuscia job
-	_, err := h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Create(ctx, kusciaJob, metav1.CreateOptions{})
-	if err != nil {
-		return &kusciaapi.CreateJobResponse{
-			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrCreateJob, err.Error()),
-		}
-	}
-	return &kusciaapi.CreateJobResponse{
-		Status: utils2.BuildSuccessResponseStatus(),
-		Data: &kusciaapi.CreateJobResponseData{
-			JobId: request.JobId,
-		},
-	}
+	// tasks and pods of the job join the trace of this request
+	tracing.InjectAnnotations(ctx, kusciaJob.Annotations)
+	// the job belongs to the tenant of request
+	tenant.SetLabel(ctx, kusciaJob)
+	return kusciaJob, nil
 }
 
 func buildScheduleConfigForKusciaTask(sc *kusciaapi.ScheduleConfig) *v1alpha1.ScheduleConfig {
@@ -274,6 +333,9 @@ func (h *jobService) QueryJob(ctx context.Context, request *kusciaapi.QueryJobRe
 				Role:            party.Role,
 				BandwidthLimits: bandwidthLimits,
 			}
+			if party.Replicas != nil {
+				parties[j].Replicas = *party.Replicas
+			}
 		}
 
 		// build task config
@@ -282,6 +344,7 @@ func (h *jobService) QueryJob(ctx context.Context, request *kusciaapi.QueryJobRe
 			TaskId:          task.TaskID,
 			Parties:         parties,
 			AppImage:        task.AppImage,
+			AppImageChannel: task.AppImageChannel,
 			Dependencies:    task.Dependencies,
 			TaskInputConfig: task.TaskInputConfig,
 			Priority:        int32(task.Priority),
@@ -304,6 +367,7 @@ func (h *jobService) QueryJob(ctx context.Context, request *kusciaapi.QueryJobRe
 			Tasks:          taskConfigs,
 			Status:         jobStatus,
 			CustomFields:   customFields,
+			Priority:       kusciaJobSpec.Priority,
 		},
 	}
 	return jobResponse
@@ -460,11 +524,17 @@ func (h *jobService) ApproveJob(ctx context.Context, request *kusciaapi.ApproveJ
 		// domain just approval itself party not all self cluster parties
 		domainIDs = append(domainIDs, domainID)
 	}
+	// decisions are immutable, the parties which have decided can't approve or reject the job again
 	for _, v := range domainIDs {
-		if job.Status.ApproveStatus == nil {
-			job.Status.ApproveStatus = make(map[string]v1alpha1.JobApprovePhase)
+		if approval.Decided(job, v) {
+			return &kusciaapi.ApproveJobResponse{
+				Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrJobApprovalDecided,
+					fmt.Sprintf("domain %s has %s job %s", v, job.Status.ApproveStatus[v], jobID)),
+			}
 		}
-		job.Status.ApproveStatus[v] = approveStatus
+	}
+	for _, v := range domainIDs {
+		_ = approval.Record(job, v, approveStatus, request.Reason, fmt.Sprintf("kusciaapi/%s", domainID))
 	}
 	// update kuscia job approve status
 	_, err = h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).UpdateStatus(ctx, job, metav1.UpdateOptions{})
@@ -481,6 +551,68 @@ func (h *jobService) ApproveJob(ctx context.Context, request *kusciaapi.ApproveJ
 	}
 }
 
+func (h *jobService) ListPendingApprovals(ctx context.Context, request *kusciaapi.ListPendingApprovalsRequest) *kusciaapi.ListPendingApprovalsResponse {
+	role, domainID := GetRoleAndDomainFromCtx(ctx)
+	if len(domainID) == 0 {
+		return &kusciaapi.ListPendingApprovalsResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, "source domain header must be set"),
+		}
+	}
+	jobs, err := h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return &kusciaapi.ListPendingApprovalsResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrListPendingApprovals, err.Error()),
+		}
+	}
+
+	approvals := make([]*kusciaapi.PendingApproval, 0)
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Status.Phase != v1alpha1.KusciaJobAwaitingApproval {
+			continue
+		}
+		if request.Initiator != "" && job.Spec.Initiator != request.Initiator {
+			continue
+		}
+		// same as ApproveJob, master approves all self cluster parties, and domain approves itself party
+		var domainIDs []string
+		if role == consts.AuthRoleMaster {
+			if selfParties, ok := job.Annotations[common.InterConnSelfPartyAnnotationKey]; ok {
+				domainIDs = annotationToDomainList(selfParties)
+			}
+		} else if slices.Contains(jobPartyDomainIDs(job), domainID) {
+			domainIDs = []string{domainID}
+		}
+		pending := approval.Pending(job, domainIDs)
+		if len(pending) == 0 {
+			continue
+		}
+		var appImages []string
+		for _, task := range job.Spec.Tasks {
+			if !slices.Contains(appImages, task.AppImage) {
+				appImages = append(appImages, task.AppImage)
+			}
+		}
+		approvals = append(approvals, &kusciaapi.PendingApproval{
+			JobId:      job.Name,
+			Initiator:  job.Spec.Initiator,
+			DomainIds:  pending,
+			AppImages:  appImages,
+			CreateTime: utils.TimeRfc3339String(&job.CreationTimestamp),
+		})
+	}
+	sort.Slice(approvals, func(i, j int) bool {
+		return approvals[i].CreateTime < approvals[j].CreateTime ||
+			(approvals[i].CreateTime == approvals[j].CreateTime && approvals[i].JobId < approvals[j].JobId)
+	})
+	return &kusciaapi.ListPendingApprovalsResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.ListPendingApprovalsResponseData{
+			Approvals: approvals,
+		},
+	}
+}
+
 func (h *jobService) SuspendJob(ctx context.Context, request *kusciaapi.SuspendJobRequest) *kusciaapi.SuspendJobResponse {
 	// do validate
 	jobID := request.JobId
@@ -628,6 +760,201 @@ func (h *jobService) CancelJob(ctx context.Context, request *kusciaapi.CancelJob
 	}
 }
 
+func (h *jobService) QueryJobResourceUsage(ctx context.Context, request *kusciaapi.QueryJobResourceUsageRequest) *kusciaapi.QueryJobResourceUsageResponse {
+	// do validate
+	jobID := request.JobId
+	if jobID == "" {
+		return &kusciaapi.QueryJobResourceUsageResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, "job id can not be empty"),
+		}
+	}
+	job, err := h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(ctx, jobID, metav1.GetOptions{})
+	if err != nil {
+		return &kusciaapi.QueryJobResourceUsageResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrQueryJobResourceUsage, err.Error()),
+		}
+	}
+	// auth pre handler
+	if err = h.authHandlerJobRetrieve(ctx, job); err != nil {
+		return &kusciaapi.QueryJobResourceUsageResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
+
+	// the usage of a party is only visible to the party itself when requested by a domain
+	role, requestDomainID := GetRoleAndDomainFromCtx(ctx)
+	var domainIDs []string
+	if role == consts.AuthRoleDomain {
+		domainIDs = []string{requestDomainID}
+	} else {
+		domainIDs = jobPartyDomainIDs(job)
+	}
+
+	total := &accounting.Usage{}
+	var latest time.Time
+	var parties []*kusciaapi.PartyResourceUsage
+	for _, domainID := range domainIDs {
+		jobUsage, err := accounting.LoadJobUsage(ctx, h.kubeClient, domainID, jobID)
+		if err != nil {
+			return &kusciaapi.QueryJobResourceUsageResponse{
+				Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrQueryJobResourceUsage, err.Error()),
+			}
+		}
+		// the party is not accounted by this cluster or its tasks haven't run
+		if jobUsage == nil {
+			continue
+		}
+
+		party := &kusciaapi.PartyResourceUsage{DomainId: domainID}
+		partyTotal := jobUsage.Total()
+		party.Usage = buildResourceUsage(partyTotal, jobUsage.UpdateTime)
+		for taskID, usage := range jobUsage.Tasks {
+			party.Tasks = append(party.Tasks, &kusciaapi.TaskResourceUsage{
+				TaskId: taskID,
+				Usage:  buildResourceUsage(usage, jobUsage.UpdateTime),
+			})
+		}
+		parties = append(parties, party)
+		total.Add(partyTotal)
+		if jobUsage.UpdateTime.After(latest) {
+			latest = jobUsage.UpdateTime
+		}
+	}
+
+	return &kusciaapi.QueryJobResourceUsageResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.QueryJobResourceUsageResponseData{
+			JobId:   jobID,
+			Usage:   buildResourceUsage(total, latest),
+			Parties: parties,
+		},
+	}
+}
+
+func buildResourceUsage(usage *accounting.Usage, updateTime time.Time) *kusciaapi.ResourceUsage {
+	result := &kusciaapi.ResourceUsage{
+		CpuSeconds:    usage.CPUSeconds,
+		MemoryGbHours: usage.MemoryGBHours,
+		SentBytes:     usage.SentBytes,
+		ReceivedBytes: usage.ReceivedBytes,
+	}
+	if !updateTime.IsZero() {
+		result.UpdateTime = updateTime.Format(time.RFC3339)
+	}
+	return result
+}
+
+func (h *jobService) QueryJobHistory(ctx context.Context, request *kusciaapi.QueryJobHistoryRequest) *kusciaapi.QueryJobHistoryResponse {
+	if h.jobHistory == nil {
+		return &kusciaapi.QueryJobHistoryResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrQueryJobHistory, "job history is not enabled"),
+		}
+	}
+	query, err := buildJobHistoryQuery(request)
+	if err != nil {
+		return &kusciaapi.QueryJobHistoryResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+	// a domain could only query the jobs it participates in
+	role, domainID := GetRoleAndDomainFromCtx(ctx)
+	if role == consts.AuthRoleDomain {
+		query.Party = domainID
+	}
+
+	records, err := h.jobHistory.Query(ctx, query)
+	if err != nil {
+		return &kusciaapi.QueryJobHistoryResponse{
+			Status: utils2.BuildErrorResponseStatus(pberrorcode.ErrorCode_KusciaAPIErrQueryJobHistory, err.Error()),
+		}
+	}
+	jobs := make([]*kusciaapi.JobHistory, 0, len(records))
+	for _, record := range records {
+		jobs = append(jobs, buildJobHistory(record))
+	}
+	return &kusciaapi.QueryJobHistoryResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+		Data: &kusciaapi.QueryJobHistoryResponseData{
+			Jobs: jobs,
+		},
+	}
+}
+
+func buildJobHistoryQuery(request *kusciaapi.QueryJobHistoryRequest) (*jobhistory.Query, error) {
+	if request.Limit < 0 {
+		return nil, fmt.Errorf("limit can not be negative")
+	}
+	query := &jobhistory.Query{
+		Initiator: request.Initiator,
+		Phase:     request.State,
+		Limit:     int(request.Limit),
+	}
+	var err error
+	if request.StartTime != "" {
+		if query.StartTime, err = time.Parse(time.RFC3339, request.StartTime); err != nil {
+			return nil, fmt.Errorf("start time %q is not in RFC3339 format", request.StartTime)
+		}
+	}
+	if request.EndTime != "" {
+		if query.EndTime, err = time.Parse(time.RFC3339, request.EndTime); err != nil {
+			return nil, fmt.Errorf("end time %q is not in RFC3339 format", request.EndTime)
+		}
+	}
+	if !query.StartTime.IsZero() && !query.EndTime.IsZero() && query.EndTime.Before(query.StartTime) {
+		return nil, fmt.Errorf("end time can not be before start time")
+	}
+	return query, nil
+}
+
+func buildJobHistory(record *jobhistory.Record) *kusciaapi.JobHistory {
+	job := &kusciaapi.JobHistory{
+		JobId:      record.JobID,
+		Initiator:  record.Initiator,
+		State:      getJobState(v1alpha1.KusciaJobPhase(record.Phase)),
+		Reason:     record.Reason,
+		SpecHash:   record.SpecHash,
+		DomainIds:  record.Parties,
+		CreateTime: record.CreationTime.Format(time.RFC3339),
+		StartTime:  formatHistoryTime(record.StartTime),
+		EndTime:    formatHistoryTime(record.CompletionTime),
+		DeleteTime: formatHistoryTime(record.DeletionTime),
+	}
+	for _, task := range record.Tasks {
+		job.Tasks = append(job.Tasks, &kusciaapi.TaskHistory{
+			TaskId:    task.TaskID,
+			Alias:     task.Alias,
+			State:     getTaskState(v1alpha1.KusciaTaskPhase(task.Phase)),
+			StartTime: formatHistoryTime(task.StartTime),
+			EndTime:   formatHistoryTime(task.CompletionTime),
+		})
+	}
+	if record.ResourceUsage != nil {
+		job.Usage = buildResourceUsage(record.ResourceUsage, time.Time{})
+	}
+	return job
+}
+
+func formatHistoryTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func jobPartyDomainIDs(job *v1alpha1.KusciaJob) []string {
+	var domainIDs []string
+	seen := map[string]bool{}
+	for _, task := range job.Spec.Tasks {
+		for _, p := range task.Parties {
+			if !seen[p.DomainID] {
+				seen[p.DomainID] = true
+				domainIDs = append(domainIDs, p.DomainID)
+			}
+		}
+	}
+	return domainIDs
+}
+
 func (h *jobService) setJobStage(job *v1alpha1.KusciaJob, stage v1alpha1.JobStage, domain string) {
 	if job.Labels == nil {
 		job.Labels = make(map[string]string)
@@ -777,8 +1104,8 @@ loop:
 					}
 				}
 				task, _ := event.Object.(*v1alpha1.KusciaTask)
-				// only update running task progress
-				if task.Status.Phase == v1alpha1.TaskRunning && task.Status.Progress > 0 && task.Status.Progress < 1 {
+				// only update running task progress and metrics
+				if task.Status.Phase == v1alpha1.TaskRunning && ((task.Status.Progress > 0 && task.Status.Progress < 1) || len(task.Status.Metrics) > 0) {
 					// get job via task owner ref
 					ownerRef := metav1.GetControllerOf(task)
 					if ownerRef == nil { // ignore task
@@ -848,6 +1175,7 @@ func (h *jobService) buildJobStatus(ctx context.Context, kusciaJob *v1alpha1.Kus
 		Tasks:             make([]*kusciaapi.TaskStatus, 0),
 		StageStatusList:   make([]*kusciaapi.PartyStageStatus, 0),
 		ApproveStatusList: make([]*kusciaapi.PartyApproveStatus, 0),
+		QueuePosition:     kusciaJobStatus.QueuePosition,
 	}
 
 	for k, v := range kusciaJobStatus.StageStatus {
@@ -858,10 +1186,18 @@ func (h *jobService) buildJobStatus(ctx context.Context, kusciaJob *v1alpha1.Kus
 	}
 
 	for k, v := range kusciaJobStatus.ApproveStatus {
-		statusDetail.ApproveStatusList = append(statusDetail.ApproveStatusList, &kusciaapi.PartyApproveStatus{
+		approveStatus := &kusciaapi.PartyApproveStatus{
 			DomainId: k,
 			State:    string(v),
-		})
+		}
+		for _, r := range kusciaJobStatus.ApprovalRecords {
+			if r.DomainID == k {
+				approveStatus.Reason = r.Reason
+				approveStatus.Operator = r.Operator
+				approveStatus.ApproveTime = utils.TimeRfc3339String(&r.Time)
+			}
+		}
+		statusDetail.ApproveStatusList = append(statusDetail.ApproveStatusList, approveStatus)
 	}
 
 	// build task status
@@ -884,12 +1220,19 @@ func (h *jobService) buildJobStatus(ctx context.Context, kusciaJob *v1alpha1.Kus
 				ts.StartTime = utils.TimeRfc3339String(taskStatus.StartTime)
 				ts.EndTime = utils.TimeRfc3339String(taskStatus.CompletionTime)
 				ts.Progress = taskStatus.Progress
+				ts.Metrics = taskStatus.Metrics
 				partyTaskStatus := make(map[string]v1alpha1.KusciaTaskPhase)
+				partyPods := make(map[string][]*kusciaapi.PartyPodStatus)
+				partyErrMsg := make(map[string][]string)
 				for _, ps := range taskStatus.PartyTaskStatus {
 					partyTaskStatus[ps.DomainID] = ps.Phase
+					partyPods[ps.DomainID] = append(partyPods[ps.DomainID], buildPartyPodStatuses(ps.Pods)...)
+					// parties in other clusters only report their pods by party task status
+					if _, ok := partyErrMsg[ps.DomainID]; !ok {
+						partyErrMsg[ps.DomainID] = nil
+					}
 				}
 
-				partyErrMsg := make(map[string][]string)
 				for _, podStatus := range taskStatus.PodStatuses {
 					msg := ""
 					if podStatus.Message != "" {
@@ -921,6 +1264,7 @@ func (h *jobService) buildJobStatus(ctx context.Context, kusciaJob *v1alpha1.Kus
 						State:     getTaskState(partyTaskStatus[partyID]),
 						ErrMsg:    strings.Join(partyErrMsg[partyID], ","),
 						Endpoints: partyEndpoints[partyID],
+						Pods:      partyPods[partyID],
 					})
 				}
 			}
@@ -954,11 +1298,17 @@ func (h *jobService) authHandlerJobCreate(ctx context.Context, request *kusciaap
 
 func (h *jobService) authHandlerJobDelete(ctx context.Context, jobID string) error {
 	role, domainID := GetRoleAndDomainFromCtx(ctx)
-	if role == consts.AuthRoleDomain {
+	if role == consts.AuthRoleDomain || tenant.FromContext(ctx) != "" {
 		kusciaJob, err := h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(ctx, jobID, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
+		if err := tenant.Check(ctx, kusciaJob); err != nil {
+			return err
+		}
+		if role != consts.AuthRoleDomain {
+			return nil
+		}
 		if domainID == kusciaJob.Spec.Initiator {
 			return nil
 		}
@@ -975,6 +1325,9 @@ func (h *jobService) authHandlerJobDelete(ctx context.Context, jobID string) err
 }
 
 func (h *jobService) authHandlerJobRetrieve(ctx context.Context, kusciaJob *v1alpha1.KusciaJob) error {
+	if err := tenant.Check(ctx, kusciaJob); err != nil {
+		return err
+	}
 	role, domainID := GetRoleAndDomainFromCtx(ctx)
 	if domainID == kusciaJob.Spec.Initiator {
 		return nil
@@ -993,6 +1346,9 @@ func (h *jobService) authHandlerJobRetrieve(ctx context.Context, kusciaJob *v1al
 }
 
 func (h *jobService) authHandlerJobWatch(ctx context.Context, kusciaJob *v1alpha1.KusciaJob) bool {
+	if tenant.Check(ctx, kusciaJob) != nil {
+		return false
+	}
 	role, domainID := GetRoleAndDomainFromCtx(ctx)
 	if domainID == kusciaJob.Spec.Initiator {
 		return true
@@ -1011,6 +1367,9 @@ func (h *jobService) authHandlerJobWatch(ctx context.Context, kusciaJob *v1alpha
 }
 
 func (h *jobService) authHandlerJob(ctx context.Context, kusciaJob *v1alpha1.KusciaJob) error {
+	if err := tenant.Check(ctx, kusciaJob); err != nil {
+		return err
+	}
 	role, domainID := GetRoleAndDomainFromCtx(ctx)
 	if domainID == kusciaJob.Spec.Initiator {
 		return nil
@@ -1070,6 +1429,105 @@ func validateCreateJobRequest(request *kusciaapi.CreateJobRequest, domainID stri
 	return nil
 }
 
+// validateAppImageChannels checks the channels pinned by tasks exist, so the job won't fail when tasks are created.
+func (h *jobService) validateAppImageChannels(ctx context.Context, tasks []*kusciaapi.Task) error {
+	for i, task := range tasks {
+		if task.AppImageChannel == "" {
+			continue
+		}
+		appImage, err := h.kusciaClient.KusciaV1alpha1().AppImages().Get(ctx, task.AppImage, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get app image %s of tasks[%d] failed, %v", task.AppImage, i, err)
+		}
+		if _, err := resources.SelectAppImageInfo(appImage, task.AppImageChannel); err != nil {
+			return fmt.Errorf("tasks[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// validateTaskInputConfigs checks the task input config of tasks against the task config schemas registered in their
+// app images, so typos in task input config fail the request instead of the tasks. App images which don't exist in
+// this cluster are skipped.
+func (h *jobService) validateTaskInputConfigs(ctx context.Context, tasks []*kusciaapi.Task) error {
+	for i, task := range tasks {
+		appImage, err := h.kusciaClient.KusciaV1alpha1().AppImages().Get(ctx, task.AppImage, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("get app image %s of tasks[%d] failed, %v", task.AppImage, i, err)
+		}
+		violations, err := resources.ValidateTaskInputConfig(appImage, task.AppImageChannel, task.TaskInputConfig)
+		if err != nil {
+			nlog.Warnf("Skip validating task input config of tasks[%d], %v", i, err)
+			continue
+		}
+		if len(violations) > 0 {
+			return fmt.Errorf("tasks[%d]: task input config doesn't match the schema of app image %s: %s", i,
+				task.AppImage, strings.Join(violations, "; "))
+		}
+	}
+	return nil
+}
+
+// validatePartnerApps checks the partners support the apps of tasks by the metadata they advertise. Partners which
+// don't advertise metadata or can't be reached are skipped, the apps are checked again when tasks are created.
+func (h *jobService) validatePartnerApps(ctx context.Context, tasks []*kusciaapi.Task) error {
+	if h.partnerMetadata == nil {
+		return nil
+	}
+
+	advertised := map[string]*metadata.PartnerMetadata{}
+	for i, task := range tasks {
+		for _, party := range task.Parties {
+			md, ok := advertised[party.DomainId]
+			if !ok {
+				md = h.queryPartnerMetadata(ctx, party.DomainId)
+				advertised[party.DomainId] = md
+			}
+			if md == nil {
+				continue
+			}
+
+			app := md.FindApp(task.AppImage)
+			if app == nil {
+				return fmt.Errorf("tasks[%d]: partner %s doesn't support app image %s", i, party.DomainId, task.AppImage)
+			}
+			if task.AppImageChannel != "" && !slices.Contains(app.Channels, task.AppImageChannel) {
+				return fmt.Errorf("tasks[%d]: app image %s of partner %s doesn't have channel %s", i, task.AppImage,
+					party.DomainId, task.AppImageChannel)
+			}
+		}
+	}
+	return nil
+}
+
+// queryPartnerMetadata returns the metadata advertised by the master of partner domain, or nil if the domain isn't a
+// partner interconnected with kuscia protocol or its metadata is unavailable.
+func (h *jobService) queryPartnerMetadata(ctx context.Context, domainID string) *metadata.PartnerMetadata {
+	domain, err := h.kusciaClient.KusciaV1alpha1().Domains().Get(ctx, domainID, metav1.GetOptions{})
+	if err != nil || domain.Spec.Role != v1alpha1.Partner {
+		return nil
+	}
+	if len(domain.Spec.InterConnProtocols) > 0 && domain.Spec.InterConnProtocols[0] != v1alpha1.InterConnKuscia {
+		return nil
+	}
+
+	host := domainID
+	if domain.Spec.MasterDomain != "" {
+		host = domain.Spec.MasterDomain
+	}
+	md, err := h.partnerMetadata.Get(ctx, host)
+	if err != nil {
+		if !errors.Is(err, metadata.ErrNotAdvertised) {
+			nlog.Warnf("Skip validating apps of partner %s, %v", domainID, err)
+		}
+		return nil
+	}
+	return md
+}
+
 func validateInitiator(domainID, initiator string, tasks []*kusciaapi.Task) error {
 	if initiator == "" {
 		return fmt.Errorf("initiator can not be empty")
@@ -1094,7 +1552,7 @@ func validateBatchQueryJobStatusRequest(request *kusciaapi.BatchQueryJobStatusRe
 
 func getJobState(jobPhase v1alpha1.KusciaJobPhase) string {
 	switch jobPhase {
-	case "", v1alpha1.KusciaJobPending:
+	case "", v1alpha1.KusciaJobPreflightCheck, v1alpha1.KusciaJobPending:
 		return kusciaapi.JobState_Pending.String()
 	case v1alpha1.KusciaJobRunning:
 		return kusciaapi.JobState_Running.String()
@@ -1130,6 +1588,21 @@ func getTaskState(taskPhase v1alpha1.KusciaTaskPhase) string {
 	}
 }
 
+func buildPartyPodStatuses(pods []v1alpha1.PartyPodStatus) []*kusciaapi.PartyPodStatus {
+	var result []*kusciaapi.PartyPodStatus
+	for _, pod := range pods {
+		result = append(result, &kusciaapi.PartyPodStatus{
+			Name:          pod.Name,
+			Phase:         string(pod.Phase),
+			Ready:         pod.Ready,
+			RestartCount:  pod.RestartCount,
+			LastEvent:     pod.LastEvent,
+			LastEventTime: utils.TimeRfc3339String(pod.LastEventTime),
+		})
+	}
+	return result
+}
+
 func jobCustomField2Label(name string) string {
 	return fmt.Sprintf("%s%s", common.JobCustomFieldsLabelPrefix, name)
 }
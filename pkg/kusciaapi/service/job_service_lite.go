@@ -202,3 +202,43 @@ func (h *jobServiceLite) WatchJob(ctx context.Context, request *kusciaapi.WatchJ
 	// request the master api
 	return h.kusciaAPIClient.WatchJob(ctx, request, eventCh)
 }
+
+func (h *jobServiceLite) QueryJobResourceUsage(ctx context.Context, request *kusciaapi.QueryJobResourceUsageRequest) *kusciaapi.QueryJobResourceUsageResponse {
+	// do validate
+	jobID := request.JobId
+	if jobID == "" {
+		return &kusciaapi.QueryJobResourceUsageResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, "job id can not be empty"),
+		}
+	}
+	// request the master api
+	resp, err := h.kusciaAPIClient.QueryJobResourceUsage(ctx, request)
+	if err != nil {
+		return &kusciaapi.QueryJobResourceUsageResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
+
+func (h *jobServiceLite) ListPendingApprovals(ctx context.Context, request *kusciaapi.ListPendingApprovalsRequest) *kusciaapi.ListPendingApprovalsResponse {
+	// request the master api
+	resp, err := h.kusciaAPIClient.ListPendingApprovals(ctx, request)
+	if err != nil {
+		return &kusciaapi.ListPendingApprovalsResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
+
+func (h *jobServiceLite) QueryJobHistory(ctx context.Context, request *kusciaapi.QueryJobHistoryRequest) *kusciaapi.QueryJobHistoryResponse {
+	// request the master api
+	resp, err := h.kusciaAPIClient.QueryJobHistory(ctx, request)
+	if err != nil {
+		return &kusciaapi.QueryJobHistoryResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
@@ -17,12 +17,25 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/secretflow/kuscia/pkg/common"
 	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	gwutils "github.com/secretflow/kuscia/pkg/gateway/utils"
+	"github.com/secretflow/kuscia/pkg/interconn/kuscia/metadata"
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
+	"github.com/secretflow/kuscia/pkg/utils/jobhistory"
 	consts "github.com/secretflow/kuscia/pkg/web/constants"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
 	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
@@ -116,6 +129,219 @@ func TestCancelJob(t *testing.T) {
 	assert.Equal(t, res.Data.JobId, kusciaAPIJS.jobID)
 }
 
+func TestQueryJobResourceUsage(t *testing.T) {
+	ctx := context.Background()
+	usage := accounting.NewJobUsage(kusciaAPIJS.jobID)
+	usage.Task("task1").Add(&accounting.Usage{CPUSeconds: 60, MemoryGBHours: 0.5, SentBytes: 100, ReceivedBytes: 200})
+	usage.UpdateTime = time.Now()
+	err := accounting.SaveJobUsages(ctx, kubeClient, "alice", map[string]*accounting.JobUsage{kusciaAPIJS.jobID: usage})
+	assert.NilError(t, err)
+
+	res := kusciaAPIJS.QueryJobResourceUsage(ctx, &kusciaapi.QueryJobResourceUsageRequest{
+		JobId: kusciaAPIJS.jobID,
+	})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+	assert.Equal(t, len(res.Data.Parties), 1)
+	assert.Equal(t, res.Data.Parties[0].DomainId, "alice")
+	assert.Equal(t, res.Data.Usage.CpuSeconds, float64(60))
+	assert.Equal(t, res.Data.Usage.ReceivedBytes, int64(200))
+
+	// the usage of other parties is invisible to a domain
+	ctx = context.WithValue(ctx, consts.AuthRole, consts.AuthRoleDomain)
+	ctx = context.WithValue(ctx, consts.SourceDomainKey, "bob")
+	res = kusciaAPIJS.QueryJobResourceUsage(ctx, &kusciaapi.QueryJobResourceUsageRequest{
+		JobId: kusciaAPIJS.jobID,
+	})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+	assert.Equal(t, len(res.Data.Parties), 0)
+}
+
+func TestQueryJobHistory(t *testing.T) {
+	ctx := context.Background()
+	js := &jobService{}
+	res := js.QueryJobHistory(ctx, &kusciaapi.QueryJobHistoryRequest{})
+	assert.Equal(t, res.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrQueryJobHistory))
+
+	store, err := jobhistory.New(ctx, t.TempDir())
+	assert.NilError(t, err)
+	defer store.Close()
+	js.jobHistory = store
+	completion := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NilError(t, store.Put(ctx, &jobhistory.Record{
+		JobID: "history-1", Initiator: "alice", Phase: string(v1alpha1.KusciaJobSucceeded),
+		Parties: []string{"alice", "bob"}, CompletionTime: &completion,
+		Tasks:         []jobhistory.TaskRecord{{TaskID: "task-1", Phase: string(v1alpha1.TaskSucceeded)}},
+		ResourceUsage: &accounting.Usage{CPUSeconds: 60},
+	}))
+	assert.NilError(t, store.Put(ctx, &jobhistory.Record{
+		JobID: "history-2", Initiator: "carol", Phase: string(v1alpha1.KusciaJobFailed),
+		Parties: []string{"carol"}, CompletionTime: &completion,
+	}))
+
+	ctx = context.WithValue(ctx, consts.AuthRole, consts.AuthRoleMaster)
+	res = js.QueryJobHistory(ctx, &kusciaapi.QueryJobHistoryRequest{State: kusciaapi.JobState_Succeeded.String()})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+	assert.Equal(t, len(res.Data.Jobs), 1)
+	assert.Equal(t, res.Data.Jobs[0].JobId, "history-1")
+	assert.Equal(t, res.Data.Jobs[0].EndTime, "2024-01-01T00:00:00Z")
+	assert.Equal(t, res.Data.Jobs[0].Tasks[0].State, kusciaapi.JobState_Succeeded.String())
+	assert.Equal(t, res.Data.Jobs[0].Usage.CpuSeconds, float64(60))
+
+	res = js.QueryJobHistory(ctx, &kusciaapi.QueryJobHistoryRequest{StartTime: "2024-01-02T00:00:00Z"})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+	assert.Equal(t, len(res.Data.Jobs), 0)
+
+	res = js.QueryJobHistory(ctx, &kusciaapi.QueryJobHistoryRequest{StartTime: "2024-01-02"})
+	assert.Equal(t, res.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrRequestValidate))
+
+	// a domain only sees the jobs it participates in
+	ctx = context.WithValue(ctx, consts.AuthRole, consts.AuthRoleDomain)
+	ctx = context.WithValue(ctx, consts.SourceDomainKey, "carol")
+	res = js.QueryJobHistory(ctx, &kusciaapi.QueryJobHistoryRequest{})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+	assert.Equal(t, len(res.Data.Jobs), 1)
+	assert.Equal(t, res.Data.Jobs[0].JobId, "history-2")
+}
+
+func TestApproveJob(t *testing.T) {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, consts.AuthRole, consts.AuthRoleMaster)
+	ctx = context.WithValue(ctx, consts.SourceDomainKey, "bob")
+	job := &v1alpha1.KusciaJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "approve-job",
+			Namespace:   common.KusciaCrossDomain,
+			Annotations: map[string]string{common.InterConnSelfPartyAnnotationKey: "bob"},
+		},
+		Spec: v1alpha1.KusciaJobSpec{
+			Initiator: "alice",
+			Tasks: []v1alpha1.KusciaTaskTemplate{{
+				TaskID:   "approve-job-task1",
+				AppImage: "mockImageName",
+				Parties:  []v1alpha1.Party{{DomainID: "alice"}, {DomainID: "bob"}},
+			}},
+		},
+		Status: v1alpha1.KusciaJobStatus{Phase: v1alpha1.KusciaJobAwaitingApproval},
+	}
+	_, err := kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Create(ctx, job, metav1.CreateOptions{})
+	assert.NilError(t, err)
+
+	pending := kusciaAPIJS.ListPendingApprovals(ctx, &kusciaapi.ListPendingApprovalsRequest{Initiator: "alice"})
+	assert.Equal(t, pending.Status.Code, kusciaAPISuccessStatusCode)
+	assert.Equal(t, len(pending.Data.Approvals), 1)
+	assert.DeepEqual(t, pending.Data.Approvals[0].DomainIds, []string{"bob"})
+	assert.DeepEqual(t, pending.Data.Approvals[0].AppImages, []string{"mockImageName"})
+
+	res := kusciaAPIJS.ApproveJob(ctx, &kusciaapi.ApproveJobRequest{
+		JobId:  "approve-job",
+		Result: kusciaapi.ApproveResult_APPROVE_RESULT_ACCEPT,
+		Reason: "trusted partner",
+	})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+	// approval decision can't be changed
+	res = kusciaAPIJS.ApproveJob(ctx, &kusciaapi.ApproveJobRequest{
+		JobId:  "approve-job",
+		Result: kusciaapi.ApproveResult_APPROVE_RESULT_REJECT,
+	})
+	assert.Equal(t, res.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrJobApprovalDecided))
+
+	kj, err := kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(ctx, "approve-job", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, kj.Status.ApproveStatus["bob"], v1alpha1.JobAccepted)
+	assert.Equal(t, len(kj.Status.ApprovalRecords), 1)
+	assert.Equal(t, kj.Status.ApprovalRecords[0].Reason, "trusted partner")
+	assert.Equal(t, kj.Status.ApprovalRecords[0].Operator, "kusciaapi/bob")
+
+	pending = kusciaAPIJS.ListPendingApprovals(ctx, &kusciaapi.ListPendingApprovalsRequest{})
+	assert.Equal(t, pending.Status.Code, kusciaAPISuccessStatusCode)
+	assert.Equal(t, len(pending.Data.Approvals), 0)
+}
+
+func TestCreateJobWithAppImageChannel(t *testing.T) {
+	ctx := context.Background()
+	tasks := []*kusciaapi.Task{{
+		TaskId:          "channel-job-task1",
+		Alias:           "task1",
+		TaskInputConfig: "{}",
+		AppImage:        "mockImageName",
+		AppImageChannel: v1alpha1.AppImageChannelBeta,
+		Parties:         []*kusciaapi.Party{{DomainId: "alice"}, {DomainId: "bob"}},
+	}}
+	res := kusciaAPIJS.CreateJob(ctx, &kusciaapi.CreateJobRequest{JobId: "channel-job", Initiator: "alice", Tasks: tasks})
+	assert.Equal(t, res.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrRequestValidate))
+
+	appImage, err := kusciaClient.KusciaV1alpha1().AppImages().Get(ctx, "mockImageName", metav1.GetOptions{})
+	assert.NilError(t, err)
+	appImage.Spec.Channels = []v1alpha1.AppImageChannel{{
+		Name:  v1alpha1.AppImageChannelBeta,
+		Image: v1alpha1.AppImageInfo{Name: "mockImage", Tag: "beta"},
+	}}
+	_, err = kusciaClient.KusciaV1alpha1().AppImages().Update(ctx, appImage, metav1.UpdateOptions{})
+	assert.NilError(t, err)
+
+	res = kusciaAPIJS.CreateJob(ctx, &kusciaapi.CreateJobRequest{JobId: "channel-job", Initiator: "alice", Tasks: tasks})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+	queryRes := kusciaAPIJS.QueryJob(ctx, &kusciaapi.QueryJobRequest{JobId: "channel-job"})
+	assert.Equal(t, queryRes.Data.Tasks[0].AppImageChannel, v1alpha1.AppImageChannelBeta)
+}
+
+func TestCreateJobWithPartyReplicas(t *testing.T) {
+	ctx := context.Background()
+	tasks := []*kusciaapi.Task{{
+		TaskId:          "replicas-job-task1",
+		Alias:           "task1",
+		TaskInputConfig: "{}",
+		AppImage:        "mockImageName",
+		Parties: []*kusciaapi.Party{
+			{DomainId: "alice", Replicas: 2, Resources: &kusciaapi.JobResource{Memory: "4Gi"}},
+			{DomainId: "bob"},
+		},
+	}}
+	res := kusciaAPIJS.CreateJob(ctx, &kusciaapi.CreateJobRequest{JobId: "replicas-job", Initiator: "alice", Tasks: tasks})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+
+	kusciaJob, err := kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(ctx, "replicas-job", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, *kusciaJob.Spec.Tasks[0].Parties[0].Replicas, int32(2))
+	assert.Assert(t, kusciaJob.Spec.Tasks[0].Parties[1].Replicas == nil)
+
+	queryRes := kusciaAPIJS.QueryJob(ctx, &kusciaapi.QueryJobRequest{JobId: "replicas-job"})
+	assert.Equal(t, queryRes.Data.Tasks[0].Parties[0].Replicas, int32(2))
+
+	tasks[0].TaskId = "replicas-job-task2"
+	tasks[0].Parties[0].Replicas = -1
+	res = kusciaAPIJS.CreateJob(ctx, &kusciaapi.CreateJobRequest{JobId: "replicas-job-2", Initiator: "alice", Tasks: tasks})
+	assert.Assert(t, res.Status.Code != kusciaAPISuccessStatusCode)
+}
+
+func TestCreateJobWithTaskConfigSchema(t *testing.T) {
+	ctx := context.Background()
+	_, err := kusciaClient.KusciaV1alpha1().AppImages().Create(ctx, &v1alpha1.AppImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "schema-image"},
+		Spec: v1alpha1.AppImageSpec{
+			Image:            v1alpha1.AppImageInfo{Name: "mockImage", Tag: "latest"},
+			TaskConfigSchema: `{"type":"object","required":["sf_input_ids"],"properties":{"sf_input_ids":{"type":"array"}}}`,
+		},
+	}, metav1.CreateOptions{})
+	assert.NilError(t, err)
+
+	tasks := []*kusciaapi.Task{{
+		TaskId:          "schema-job-task1",
+		Alias:           "task1",
+		TaskInputConfig: `{"sf_input_ids":"alice-table"}`,
+		AppImage:        "schema-image",
+		Parties:         []*kusciaapi.Party{{DomainId: "alice"}, {DomainId: "bob"}},
+	}}
+	res := kusciaAPIJS.CreateJob(ctx, &kusciaapi.CreateJobRequest{JobId: "schema-job", Initiator: "alice", Tasks: tasks})
+	assert.Equal(t, res.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrRequestValidate))
+	assert.Equal(t, res.Status.Message, "tasks[0]: task input config doesn't match the schema of app image schema-image: "+
+		"sf_input_ids: must be of type array, got string")
+
+	tasks[0].TaskInputConfig = `{"sf_input_ids":["alice-table"]}`
+	res = kusciaAPIJS.CreateJob(ctx, &kusciaapi.CreateJobRequest{JobId: "schema-job", Initiator: "alice", Tasks: tasks})
+	assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+}
+
 func TestBatchQueryJob(t *testing.T) {
 	batchResponse := kusciaAPIJS.BatchQueryJobStatus(context.Background(), &kusciaapi.BatchQueryJobStatusRequest{
 		JobIds: []string{kusciaAPIJS.jobID},
@@ -213,3 +439,129 @@ func TestBuildScheduleConfigForKusciaAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePartnerApps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := json.Marshal(&metadata.PartnerMetadata{
+			DomainID: "bob",
+			Apps:     []metadata.AppInfo{{Name: "secretflow", Channels: []string{v1alpha1.AppImageChannelStable}}},
+		})
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	internalServer := gwutils.InternalServer
+	gwutils.InternalServer = server.URL
+	defer func() { gwutils.InternalServer = internalServer }()
+
+	h := &jobService{
+		kusciaClient: kusciafake.NewSimpleClientset(&v1alpha1.Domain{
+			ObjectMeta: metav1.ObjectMeta{Name: "bob"},
+			Spec:       v1alpha1.DomainSpec{Role: v1alpha1.Partner},
+		}),
+		partnerMetadata: metadata.NewClient("alice", time.Minute),
+	}
+	parties := []*kusciaapi.Party{{DomainId: "alice"}, {DomainId: "bob"}}
+
+	err := h.validatePartnerApps(context.Background(), []*kusciaapi.Task{{AppImage: "secretflow", Parties: parties}})
+	assert.NilError(t, err)
+	err = h.validatePartnerApps(context.Background(), []*kusciaapi.Task{{AppImage: "scql", Parties: parties}})
+	assert.ErrorContains(t, err, "partner bob doesn't support app image scql")
+	err = h.validatePartnerApps(context.Background(), []*kusciaapi.Task{{AppImage: "secretflow", AppImageChannel: "beta", Parties: parties}})
+	assert.ErrorContains(t, err, "doesn't have channel beta")
+}
+
+func TestJobTenant(t *testing.T) {
+	makeJob := func(name, tenant string) *v1alpha1.KusciaJob {
+		job := &v1alpha1.KusciaJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: common.KusciaCrossDomain, Labels: map[string]string{}},
+			Spec:       v1alpha1.KusciaJobSpec{Initiator: "alice"},
+		}
+		if tenant != "" {
+			job.Labels[common.LabelTenant] = tenant
+		}
+		return job
+	}
+	h := &jobService{kusciaClient: kusciafake.NewSimpleClientset(makeJob("job-a", "team-a"), makeJob("job-shared", ""))}
+	tenantA := context.WithValue(context.Background(), consts.TenantKey, "team-a")
+
+	job, err := h.kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(tenantA, "job-shared", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.ErrorContains(t, h.authHandlerJob(tenantA, job), "doesn't belong to tenant team-a")
+	assert.Equal(t, h.authHandlerJobWatch(tenantA, job), false)
+	// the domain accesses the jobs of all tenants
+	assert.NilError(t, h.authHandlerJob(context.Background(), job))
+
+	deleteRes := h.DeleteJob(tenantA, &kusciaapi.DeleteJobRequest{JobId: "job-shared"})
+	assert.Equal(t, deleteRes.Status.Code, int32(errorcode.ErrorCode_KusciaAPIErrAuthFailed))
+	deleteRes = h.DeleteJob(tenantA, &kusciaapi.DeleteJobRequest{JobId: "job-a"})
+	assert.Equal(t, deleteRes.Data.JobId, "job-a")
+}
+
+func TestDryRunCreateJob(t *testing.T) {
+	ctx := context.Background()
+	readyRoute := func(name string) *v1alpha1.ClusterDomainRoute {
+		return &v1alpha1.ClusterDomainRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1alpha1.ClusterDomainRouteStatus{Conditions: []v1alpha1.ClusterDomainRouteCondition{{
+				Type:   v1alpha1.ClusterDomainRouteReady,
+				Status: corev1.ConditionTrue,
+			}}},
+		}
+	}
+	kusciaClient := kusciafake.NewSimpleClientset(
+		makeMockAppImage("mockImageName"),
+		&v1alpha1.Domain{ObjectMeta: metav1.ObjectMeta{Name: "alice"}},
+		&v1alpha1.Domain{ObjectMeta: metav1.ObjectMeta{Name: "bob"}},
+		readyRoute("alice-bob"),
+		&v1alpha1.DomainData{ObjectMeta: metav1.ObjectMeta{Name: "bob-table", Namespace: "bob"}},
+	)
+	h := &jobService{kusciaClient: kusciaClient}
+	request := &kusciaapi.CreateJobRequest{
+		JobId:     "dry-run-job",
+		Initiator: "alice",
+		DryRun:    true,
+		Tasks: []*kusciaapi.Task{{
+			Alias:           "task1",
+			AppImage:        "mockImageName",
+			TaskInputConfig: `{"sf_input_ids":["bob-table"],"sf_output_ids":["psi-output"]}`,
+			Parties:         []*kusciaapi.Party{{DomainId: "alice"}, {DomainId: "bob"}, {DomainId: "carol"}},
+		}},
+	}
+	failedChecks := func(res *kusciaapi.CreateJobResponse) map[string][]string {
+		assert.Equal(t, res.Status.Code, kusciaAPISuccessStatusCode)
+		failed := map[string][]string{}
+		for _, check := range res.Data.DryRunReport.Checks {
+			if !check.Passed {
+				failed[check.Name] = check.Errors
+			}
+		}
+		return failed
+	}
+
+	res := h.CreateJob(ctx, request)
+	assert.Equal(t, res.Data.DryRunReport.Valid, false)
+	failed := failedChecks(res)
+	assert.DeepEqual(t, failed[dryRunCheckParties], []string{"domain carol does not exist"})
+	assert.Equal(t, len(failed[dryRunCheckRoutes]), 5)
+	assert.ErrorContains(t, fmt.Errorf("%v", failed[dryRunCheckDomainDataGrants]), "domaindata bob-table is not granted to alice, no grant")
+	_, err := kusciaClient.KusciaV1alpha1().KusciaJobs(common.KusciaCrossDomain).Get(ctx, request.JobId, metav1.GetOptions{})
+	assert.Equal(t, apierrors.IsNotFound(err), true)
+
+	request.Tasks[0].Parties = request.Tasks[0].Parties[:2]
+	_, err = kusciaClient.KusciaV1alpha1().ClusterDomainRoutes().Create(ctx, readyRoute("bob-alice"), metav1.CreateOptions{})
+	assert.NilError(t, err)
+	_, err = kusciaClient.KusciaV1alpha1().DomainDataGrants("bob").Create(ctx, &v1alpha1.DomainDataGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob-table-alice", Namespace: "bob"},
+		Spec:       v1alpha1.DomainDataGrantSpec{Author: "bob", DomainDataID: "bob-table", GrantDomain: "alice"},
+	}, metav1.CreateOptions{})
+	assert.NilError(t, err)
+	res = h.CreateJob(ctx, request)
+	assert.DeepEqual(t, failedChecks(res), map[string][]string{})
+	assert.Equal(t, res.Data.DryRunReport.Valid, true)
+
+	request.JobId = "Invalid_ID"
+	res = h.CreateJob(ctx, request)
+	assert.Equal(t, len(res.Data.DryRunReport.Checks), 1)
+	assert.Equal(t, res.Data.DryRunReport.Checks[0].Name, dryRunCheckRequest)
+	assert.Equal(t, res.Data.DryRunReport.Valid, false)
+}
@@ -0,0 +1,340 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/resources"
+	utils2 "github.com/secretflow/kuscia/pkg/web/utils"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/errorcode"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+// servingRevisionName returns the kuscia deployment name of serving revision.
+func servingRevisionName(servingID, revision string) string {
+	if revision == "" {
+		return servingID
+	}
+	return servingID + "-" + revision
+}
+
+// servingRevisionOf returns the revision of traffic target, empty target is the serving itself.
+func servingRevisionOf(servingID, target string) string {
+	if target == "" || target == servingID {
+		return ""
+	}
+	return strings.TrimPrefix(target, servingID+"-")
+}
+
+func (s *servingService) CreateServingRevision(ctx context.Context, request *kusciaapi.CreateServingRevisionRequest) *kusciaapi.CreateServingRevisionResponse {
+	if err := validateCreateServingRevisionRequest(request); err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	kd, err := s.getServing(ctx, request.ServingId)
+	if err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrCreateServingRevision, err.Error()),
+		}
+	}
+
+	revisionName := servingRevisionName(request.ServingId, request.Revision)
+	createRequest := &kusciaapi.CreateServingRequest{
+		ServingId:          revisionName,
+		ServingInputConfig: request.ServingInputConfig,
+		Initiator:          kd.Spec.Initiator,
+		Parties:            request.Parties,
+	}
+	if err := authenticateServingRequest(ctx, request.Parties); err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrAuthFailed, err.Error()),
+		}
+	}
+	if err := s.checkIfExist(ctx, common.KusciaCrossDomain, createRequest); err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrCreateServingRevision, err.Error()),
+		}
+	}
+
+	revision, err := s.buildServingRevision(ctx, kd, request.Revision, createRequest)
+	if err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrCreateServingRevision, err.Error()),
+		}
+	}
+
+	if _, err = s.kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain).Create(ctx, revision, metav1.CreateOptions{}); err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrCreateServingRevision, err.Error()),
+		}
+	}
+	nlog.Infof("Serving revision %s of serving %s is created", request.Revision, request.ServingId)
+	return &kusciaapi.CreateServingRevisionResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+	}
+}
+
+func validateCreateServingRevisionRequest(request *kusciaapi.CreateServingRevisionRequest) error {
+	if err := validateServingID(request.ServingId); err != nil {
+		return err
+	}
+	if request.Revision == "" {
+		return fmt.Errorf("revision can not be empty")
+	}
+	if err := resources.ValidateK8sName(servingRevisionName(request.ServingId, request.Revision), "serving_id-revision"); err != nil {
+		return err
+	}
+	for i, party := range request.Parties {
+		if err := validateServingParty(party, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getServing returns the kuscia deployment of serving, serving revisions are not regarded as servings here.
+func (s *servingService) getServing(ctx context.Context, servingID string) (*v1alpha1.KusciaDeployment, error) {
+	kd, err := s.kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain).Get(ctx, servingID, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !selfAsParticipant(ctx, kd) {
+		return nil, fmt.Errorf("serving not found")
+	}
+	if owner := kd.Labels[common.LabelServingID]; owner != "" {
+		return nil, fmt.Errorf("%s is a revision of serving %s", servingID, owner)
+	}
+	return kd, nil
+}
+
+// buildServingRevision builds the kuscia deployment of revision, the parties and input config of serving are used if
+// they are not given.
+func (s *servingService) buildServingRevision(ctx context.Context, kd *v1alpha1.KusciaDeployment, revision string,
+	request *kusciaapi.CreateServingRequest) (*v1alpha1.KusciaDeployment, error) {
+	var parties []v1alpha1.KusciaDeploymentParty
+	if len(request.Parties) == 0 {
+		for _, party := range kd.Spec.Parties {
+			parties = append(parties, *party.DeepCopy())
+		}
+	} else {
+		built, err := s.buildKusciaDeployment(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		parties = built.Spec.Parties
+		if err := checkSameServingParties(kd.Spec.Parties, parties); err != nil {
+			return nil, err
+		}
+	}
+	// services of revision must not conflict with services of serving
+	for i := range parties {
+		if parties[i].ServiceNamePrefix != "" {
+			parties[i].ServiceNamePrefix = parties[i].ServiceNamePrefix + "-" + revision
+		}
+	}
+
+	inputConfig := request.ServingInputConfig
+	if inputConfig == "" {
+		inputConfig = kd.Spec.InputConfig
+	}
+	return &v1alpha1.KusciaDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: common.KusciaCrossDomain,
+			Name:      request.ServingId,
+			Labels: map[string]string{
+				common.LabelKusciaDeploymentAppType: string(common.ServingApp),
+				common.LabelServingID:               kd.Name,
+			},
+			Annotations: map[string]string{
+				common.ServingRevisionAnnotationKey: revision,
+			},
+		},
+		Spec: v1alpha1.KusciaDeploymentSpec{
+			Initiator:   kd.Spec.Initiator,
+			InputConfig: inputConfig,
+			Parties:     parties,
+		},
+	}, nil
+}
+
+// checkSameServingParties checks that the revision has the same parties as serving, so every service of serving
+// has pods of the revision to switch to.
+func checkSameServingParties(servingParties, revisionParties []v1alpha1.KusciaDeploymentParty) error {
+	key := func(p v1alpha1.KusciaDeploymentParty) string { return p.DomainID + "/" + p.Role }
+	expected := make(map[string]bool, len(servingParties))
+	for _, p := range servingParties {
+		expected[key(p)] = true
+	}
+	if len(revisionParties) != len(expected) {
+		return fmt.Errorf("parties of revision should be the same as parties of serving")
+	}
+	for _, p := range revisionParties {
+		if !expected[key(p)] {
+			return fmt.Errorf("party %s with role %q isn't a party of serving", p.DomainID, p.Role)
+		}
+	}
+	return nil
+}
+
+func (s *servingService) SwitchServingTraffic(ctx context.Context, request *kusciaapi.SwitchServingTrafficRequest) *kusciaapi.SwitchServingTrafficResponse {
+	if err := validateServingID(request.ServingId); err != nil {
+		return &kusciaapi.SwitchServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	if err := s.switchServingTraffic(ctx, request.ServingId, request.Revision, true); err != nil {
+		return &kusciaapi.SwitchServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrSwitchServingTraffic, err.Error()),
+		}
+	}
+	return &kusciaapi.SwitchServingTrafficResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+	}
+}
+
+func (s *servingService) RollbackServingTraffic(ctx context.Context, request *kusciaapi.RollbackServingTrafficRequest) *kusciaapi.RollbackServingTrafficResponse {
+	if err := validateServingID(request.ServingId); err != nil {
+		return &kusciaapi.RollbackServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	kd, err := s.getServing(ctx, request.ServingId)
+	if err != nil {
+		return &kusciaapi.RollbackServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrSwitchServingTraffic, err.Error()),
+		}
+	}
+	previous, ok := kd.Annotations[common.ServingPreviousRevisionAnnotationKey]
+	if !ok {
+		return &kusciaapi.RollbackServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrSwitchServingTraffic,
+				fmt.Sprintf("traffic of serving %s has never been switched", request.ServingId)),
+		}
+	}
+
+	// rollback is used when the current revision misbehaves, so the previous revision isn't required to be healthy
+	if err := s.switchServingTraffic(ctx, request.ServingId, previous, false); err != nil {
+		return &kusciaapi.RollbackServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrSwitchServingTraffic, err.Error()),
+		}
+	}
+	return &kusciaapi.RollbackServingTrafficResponse{
+		Status: utils2.BuildSuccessResponseStatus(),
+	}
+}
+
+// switchServingTraffic sets the traffic target of serving, then the kuscia deployment controller switches all services
+// of serving to the pods of revision.
+func (s *servingService) switchServingTraffic(ctx context.Context, servingID, revision string, checkHealth bool) error {
+	kd, err := s.getServing(ctx, servingID)
+	if err != nil {
+		return err
+	}
+
+	target := servingRevisionName(servingID, revision)
+	targetKd := kd
+	if revision != "" {
+		targetKd, err = s.kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain).Get(ctx, target, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get revision %s of serving %s failed, %v", revision, servingID, err)
+		}
+		if targetKd.Labels[common.LabelServingID] != servingID {
+			return fmt.Errorf("%s isn't a revision of serving %s", target, servingID)
+		}
+		if err := checkSameServingParties(kd.Spec.Parties, targetKd.Spec.Parties); err != nil {
+			return err
+		}
+	}
+	if checkHealth && !isServingHealthy(targetKd) {
+		return fmt.Errorf("revision %q of serving %s isn't healthy, state: %s, available parties: %d/%d", revision, servingID,
+			getServingState(targetKd.Status.Phase), targetKd.Status.AvailableParties, targetKd.Status.TotalParties)
+	}
+
+	current := servingRevisionOf(servingID, kd.Spec.TrafficTarget)
+	if current == revision {
+		return nil
+	}
+
+	kdCopy := kd.DeepCopy()
+	if revision == "" {
+		kdCopy.Spec.TrafficTarget = ""
+	} else {
+		kdCopy.Spec.TrafficTarget = target
+	}
+	if kdCopy.Annotations == nil {
+		kdCopy.Annotations = map[string]string{}
+	}
+	kdCopy.Annotations[common.ServingPreviousRevisionAnnotationKey] = current
+	if _, err := s.kusciaClient.KusciaV1alpha1().KusciaDeployments(kdCopy.Namespace).Update(ctx, kdCopy, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	nlog.Infof("Traffic of serving %s is switched from revision %q to %q", servingID, current, revision)
+	return nil
+}
+
+func isServingHealthy(kd *v1alpha1.KusciaDeployment) bool {
+	return kd.Status.Phase == v1alpha1.KusciaDeploymentPhaseAvailable && kd.Status.TotalParties > 0 &&
+		kd.Status.AvailableParties == kd.Status.TotalParties
+}
+
+// listServingRevisions returns revisions of serving sorted by name.
+func (s *servingService) listServingRevisions(ctx context.Context, servingID string) ([]string, error) {
+	selector := labels.SelectorFromSet(labels.Set{common.LabelServingID: servingID})
+	kds, err := s.kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]string, 0, len(kds.Items))
+	for _, kd := range kds.Items {
+		revisions = append(revisions, kd.Annotations[common.ServingRevisionAnnotationKey])
+	}
+	sort.Strings(revisions)
+	return revisions, nil
+}
+
+// checkServingRevisionDeletable refuses to delete a revision receiving traffic of serving.
+func (s *servingService) checkServingRevisionDeletable(ctx context.Context, kd *v1alpha1.KusciaDeployment) error {
+	servingID := kd.Labels[common.LabelServingID]
+	if servingID == "" {
+		return nil
+	}
+	serving, err := s.kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain).Get(ctx, servingID, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if serving.Spec.TrafficTarget == kd.Name {
+		return fmt.Errorf("revision %s is receiving traffic of serving %s, switch traffic before deleting it",
+			kd.Annotations[common.ServingRevisionAnnotationKey], servingID)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	kusciafake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func TestServingRevisionTraffic(t *testing.T) {
+	ctx := context.Background()
+	serving := &v1alpha1.KusciaDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "serving", Namespace: common.KusciaCrossDomain},
+		Spec: v1alpha1.KusciaDeploymentSpec{
+			Initiator:   "alice",
+			InputConfig: "v1",
+			Parties: []v1alpha1.KusciaDeploymentParty{
+				{DomainID: "alice", AppImageRef: "sf", ServiceNamePrefix: "model"},
+			},
+		},
+		Status: v1alpha1.KusciaDeploymentStatus{Phase: v1alpha1.KusciaDeploymentPhaseAvailable, TotalParties: 1, AvailableParties: 1},
+	}
+	kusciaClient := kusciafake.NewSimpleClientset(serving)
+	s := &servingService{kubeClient: kubefake.NewSimpleClientset(), kusciaClient: kusciaClient}
+	kds := kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain)
+
+	createResp := s.CreateServingRevision(ctx, &kusciaapi.CreateServingRevisionRequest{
+		ServingId:          "serving",
+		Revision:           "v2",
+		ServingInputConfig: "v2",
+	})
+	assert.Equal(t, int32(0), createResp.Status.Code)
+	revision, err := kds.Get(ctx, "serving-v2", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "serving", revision.Labels[common.LabelServingID])
+	assert.Equal(t, "v2", revision.Spec.InputConfig)
+	assert.Equal(t, "model-v2", revision.Spec.Parties[0].ServiceNamePrefix)
+
+	// revision of revision isn't allowed
+	createResp = s.CreateServingRevision(ctx, &kusciaapi.CreateServingRevisionRequest{ServingId: "serving-v2", Revision: "v3"})
+	assert.NotEqual(t, int32(0), createResp.Status.Code)
+
+	// revision isn't available yet
+	switchResp := s.SwitchServingTraffic(ctx, &kusciaapi.SwitchServingTrafficRequest{ServingId: "serving", Revision: "v2"})
+	assert.NotEqual(t, int32(0), switchResp.Status.Code)
+
+	revision.Status = v1alpha1.KusciaDeploymentStatus{Phase: v1alpha1.KusciaDeploymentPhaseAvailable, TotalParties: 1, AvailableParties: 1}
+	_, err = kds.Update(ctx, revision, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	switchResp = s.SwitchServingTraffic(ctx, &kusciaapi.SwitchServingTrafficRequest{ServingId: "serving", Revision: "v2"})
+	assert.Equal(t, int32(0), switchResp.Status.Code)
+	got, err := kds.Get(ctx, "serving", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "serving-v2", got.Spec.TrafficTarget)
+	assert.Equal(t, "", got.Annotations[common.ServingPreviousRevisionAnnotationKey])
+
+	assert.Equal(t, "v2", servingRevisionOf("serving", got.Spec.TrafficTarget))
+	revisions, err := s.listServingRevisions(ctx, "serving")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v2"}, revisions)
+
+	// the active revision can't be deleted
+	deleteResp := s.DeleteServing(ctx, &kusciaapi.DeleteServingRequest{ServingId: "serving-v2"})
+	assert.NotEqual(t, int32(0), deleteResp.Status.Code)
+
+	rollbackResp := s.RollbackServingTraffic(ctx, &kusciaapi.RollbackServingTrafficRequest{ServingId: "serving"})
+	assert.Equal(t, int32(0), rollbackResp.Status.Code)
+	got, err = kds.Get(ctx, "serving", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", got.Spec.TrafficTarget)
+	assert.Equal(t, "v2", got.Annotations[common.ServingPreviousRevisionAnnotationKey])
+
+	deleteResp = s.DeleteServing(ctx, &kusciaapi.DeleteServingRequest{ServingId: "serving-v2"})
+	assert.Equal(t, int32(0), deleteResp.Status.Code)
+}
+
+func TestCheckSameServingParties(t *testing.T) {
+	serving := []v1alpha1.KusciaDeploymentParty{{DomainID: "alice"}, {DomainID: "bob", Role: "server"}}
+	assert.NoError(t, checkSameServingParties(serving, []v1alpha1.KusciaDeploymentParty{{DomainID: "bob", Role: "server"}, {DomainID: "alice"}}))
+	assert.Error(t, checkSameServingParties(serving, []v1alpha1.KusciaDeploymentParty{{DomainID: "alice"}}))
+	assert.Error(t, checkSameServingParties(serving, []v1alpha1.KusciaDeploymentParty{{DomainID: "alice"}, {DomainID: "bob"}}))
+}
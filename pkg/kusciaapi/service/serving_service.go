@@ -57,6 +57,9 @@ type IServingService interface {
 	BatchQueryServingStatus(ctx context.Context, request *kusciaapi.BatchQueryServingStatusRequest) *kusciaapi.BatchQueryServingStatusResponse
 	UpdateServing(ctx context.Context, request *kusciaapi.UpdateServingRequest) *kusciaapi.UpdateServingResponse
 	DeleteServing(ctx context.Context, request *kusciaapi.DeleteServingRequest) *kusciaapi.DeleteServingResponse
+	CreateServingRevision(ctx context.Context, request *kusciaapi.CreateServingRevisionRequest) *kusciaapi.CreateServingRevisionResponse
+	SwitchServingTraffic(ctx context.Context, request *kusciaapi.SwitchServingTrafficRequest) *kusciaapi.SwitchServingTrafficResponse
+	RollbackServingTraffic(ctx context.Context, request *kusciaapi.RollbackServingTrafficRequest) *kusciaapi.RollbackServingTrafficResponse
 }
 
 type servingService struct {
@@ -584,6 +587,13 @@ func (s *servingService) QueryServing(ctx context.Context, request *kusciaapi.Qu
 		}
 	}
 
+	revisions, err := s.listServingRevisions(ctx, servingID)
+	if err != nil {
+		return &kusciaapi.QueryServingResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrQueryServing, err.Error()),
+		}
+	}
+
 	return &kusciaapi.QueryServingResponse{
 		Status: utils2.BuildSuccessResponseStatus(),
 		Data: &kusciaapi.QueryServingResponseData{
@@ -591,6 +601,9 @@ func (s *servingService) QueryServing(ctx context.Context, request *kusciaapi.Qu
 			Initiator:          kd.Spec.Initiator,
 			Parties:            parties,
 			Status:             status,
+			ActiveRevision:     servingRevisionOf(servingID, kd.Spec.TrafficTarget),
+			PreviousRevision:   kd.Annotations[common.ServingPreviousRevisionAnnotationKey],
+			Revisions:          revisions,
 		},
 	}
 }
@@ -1039,12 +1052,29 @@ func (s *servingService) DeleteServing(ctx context.Context, request *kusciaapi.D
 		}
 	}
 
+	if err = s.checkServingRevisionDeletable(ctx, kd); err != nil {
+		return &kusciaapi.DeleteServingResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrDeleteServing, err.Error()),
+		}
+	}
+
 	if err = s.kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain).Delete(ctx, request.ServingId, metav1.DeleteOptions{}); err != nil {
 		return &kusciaapi.DeleteServingResponse{
 			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrDeleteServing, err.Error()),
 		}
 	}
 
+	// revisions are deleted with the serving
+	if kd.Labels[common.LabelServingID] == "" {
+		selector := labels.SelectorFromSet(labels.Set{common.LabelServingID: kd.Name})
+		if err = s.kusciaClient.KusciaV1alpha1().KusciaDeployments(common.KusciaCrossDomain).DeleteCollection(ctx,
+			metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: selector.String()}); err != nil {
+			return &kusciaapi.DeleteServingResponse{
+				Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrDeleteServing, err.Error()),
+			}
+		}
+	}
+
 	return &kusciaapi.DeleteServingResponse{
 		Status: utils2.BuildSuccessResponseStatus(),
 	}
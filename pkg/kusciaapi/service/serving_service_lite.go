@@ -125,3 +125,57 @@ func (s *servingServiceLite) DeleteServing(ctx context.Context, request *kusciaa
 	}
 	return resp
 }
+
+func (s *servingServiceLite) CreateServingRevision(ctx context.Context, request *kusciaapi.CreateServingRevisionRequest) *kusciaapi.CreateServingRevisionResponse {
+	// do validate
+	if err := validateCreateServingRevisionRequest(request); err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	// request the master api
+	resp, err := s.kusciaAPIClient.CreateServingRevision(ctx, request)
+	if err != nil {
+		return &kusciaapi.CreateServingRevisionResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
+
+func (s *servingServiceLite) SwitchServingTraffic(ctx context.Context, request *kusciaapi.SwitchServingTrafficRequest) *kusciaapi.SwitchServingTrafficResponse {
+	// do validate
+	if err := validateServingID(request.ServingId); err != nil {
+		return &kusciaapi.SwitchServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	// request the master api
+	resp, err := s.kusciaAPIClient.SwitchServingTraffic(ctx, request)
+	if err != nil {
+		return &kusciaapi.SwitchServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
+
+func (s *servingServiceLite) RollbackServingTraffic(ctx context.Context, request *kusciaapi.RollbackServingTrafficRequest) *kusciaapi.RollbackServingTrafficResponse {
+	// do validate
+	if err := validateServingID(request.ServingId); err != nil {
+		return &kusciaapi.RollbackServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestValidate, err.Error()),
+		}
+	}
+
+	// request the master api
+	resp, err := s.kusciaAPIClient.RollbackServingTraffic(ctx, request)
+	if err != nil {
+		return &kusciaapi.RollbackServingTrafficResponse{
+			Status: utils2.BuildErrorResponseStatus(errorcode.ErrorCode_KusciaAPIErrRequestMasterFailed, err.Error()),
+		}
+	}
+	return resp
+}
@@ -17,6 +17,9 @@ package utils
 import (
 	"fmt"
 	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/secretflow/kuscia/pkg/kusciaapi/config"
 )
@@ -29,3 +32,35 @@ func ReadToken(tokenConfig config.TokenConfig) (string, error) {
 	}
 	return string(data), nil
 }
+
+// ReadTenantTokens reads the tokens of tenants, and returns the map from token to tenant name.
+func ReadTenantTokens(tenants []config.TenantConfig, domainToken string) (map[string]string, error) {
+	tenantTokens := map[string]string{}
+	names := map[string]bool{}
+	for _, tenant := range tenants {
+		if errs := validation.IsDNS1123Label(tenant.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid tenant name %q, %s", tenant.Name, strings.Join(errs, ","))
+		}
+		if names[tenant.Name] {
+			return nil, fmt.Errorf("tenant %s is duplicated", tenant.Name)
+		}
+		names[tenant.Name] = true
+
+		token, err := ReadToken(config.TokenConfig{TokenFile: tenant.TokenFile})
+		if err != nil {
+			return nil, fmt.Errorf("read token of tenant %s failed, %v", tenant.Name, err)
+		}
+		token = strings.TrimSpace(token)
+		if token == "" {
+			return nil, fmt.Errorf("token of tenant %s is empty", tenant.Name)
+		}
+		if token == domainToken {
+			return nil, fmt.Errorf("token of tenant %s must be different from the token of domain", tenant.Name)
+		}
+		if other, ok := tenantTokens[token]; ok {
+			return nil, fmt.Errorf("tenant %s and %s use the same token", other, tenant.Name)
+		}
+		tenantTokens[token] = tenant.Name
+	}
+	return tenantTokens, nil
+}
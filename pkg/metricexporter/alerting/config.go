@@ -0,0 +1,220 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alerting evaluates threshold rules on the metrics of kuscia and sends notifications by webhook, DingTalk or
+// email, so that lite nodes can alert without a Prometheus stack.
+package alerting
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultInterval        = 30 * time.Second
+	defaultNotifierTimeout = 10 * time.Second
+
+	AggregationSum   = "sum"
+	AggregationAvg   = "avg"
+	AggregationMax   = "max"
+	AggregationMin   = "min"
+	AggregationCount = "count"
+)
+
+// Config is the alerting section in config file.
+type Config struct {
+	// Interval is the period of evaluating rules, default 30s.
+	Interval  time.Duration    `yaml:"interval,omitempty"`
+	Rules     []RuleConfig     `yaml:"rules,omitempty"`
+	Notifiers []NotifierConfig `yaml:"notifiers,omitempty"`
+}
+
+// RuleConfig fires an alert when the value of metric compared with threshold holds for a duration.
+type RuleConfig struct {
+	Name string `yaml:"name"`
+	// Metric is the name of the metric, e.g. domain_cert_expiring.
+	Metric string `yaml:"metric"`
+	// Labels selects the series whose labels equal to them.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// IncreaseOver takes the increase of the metric in the window as the value, which suits counters.
+	IncreaseOver time.Duration `yaml:"increaseOver,omitempty"`
+	// DivideBy divides the value by the metric of the same labels, e.g. avail bytes of file systems by size bytes.
+	DivideBy string `yaml:"divideBy,omitempty"`
+	// Aggregation is sum, avg, max, min or count of the series, every series is evaluated alone if it's empty.
+	Aggregation string `yaml:"aggregation,omitempty"`
+	// Op is one of >, >=, <, <=, == and !=.
+	Op        string  `yaml:"op"`
+	Threshold float64 `yaml:"threshold"`
+	// For is how long the condition holds before the alert fires.
+	For time.Duration `yaml:"for,omitempty"`
+	// RepeatInterval resends a firing alert periodically, it's sent only once if it's 0.
+	RepeatInterval time.Duration `yaml:"repeatInterval,omitempty"`
+	Severity       string        `yaml:"severity,omitempty"`
+	Summary        string        `yaml:"summary,omitempty"`
+	// Notifiers are the names of notifiers to send the alert, all notifiers are used if it's empty.
+	Notifiers []string `yaml:"notifiers,omitempty"`
+}
+
+// NotifierConfig is a notifier, exactly one of webhook, dingtalk and email is set.
+type NotifierConfig struct {
+	Name     string          `yaml:"name"`
+	Webhook  *WebhookConfig  `yaml:"webhook,omitempty"`
+	DingTalk *DingTalkConfig `yaml:"dingtalk,omitempty"`
+	Email    *EmailConfig    `yaml:"email,omitempty"`
+}
+
+// WebhookConfig posts alerts in json to the url.
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Timeout of the request, default 10s.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// DingTalkConfig sends alerts as markdown messages by a DingTalk robot.
+type DingTalkConfig struct {
+	// URL is the webhook of the robot, e.g. https://oapi.dingtalk.com/robot/send?access_token=xxx.
+	URL string `yaml:"url"`
+	// Secret signs the requests if the robot is secured by signature.
+	Secret    string   `yaml:"secret,omitempty"`
+	AtMobiles []string `yaml:"atMobiles,omitempty"`
+	// Timeout of the request, default 10s.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// EmailConfig sends alerts by SMTP, STARTTLS is used if the server supports it.
+type EmailConfig struct {
+	SMTPHost string   `yaml:"smtpHost"`
+	SMTPPort int      `yaml:"smtpPort,omitempty"`
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+}
+
+// Enabled returns whether any rule is configured.
+func (c *Config) Enabled() bool {
+	return c != nil && len(c.Rules) > 0
+}
+
+func (c *Config) interval() time.Duration {
+	if c.Interval <= 0 {
+		return defaultInterval
+	}
+	return c.Interval
+}
+
+func CheckConfig(c *Config) error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Interval < 0 {
+		return fmt.Errorf("alerting interval can't be negative")
+	}
+
+	notifiers := make(map[string]bool, len(c.Notifiers))
+	for i := range c.Notifiers {
+		n := &c.Notifiers[i]
+		if n.Name == "" {
+			return fmt.Errorf("alerting notifiers[%d] has no name", i)
+		}
+		if notifiers[n.Name] {
+			return fmt.Errorf("alerting notifier %s is duplicated", n.Name)
+		}
+		notifiers[n.Name] = true
+		if err := checkNotifier(n); err != nil {
+			return fmt.Errorf("alerting notifier %s is invalid, %v", n.Name, err)
+		}
+	}
+	if len(notifiers) == 0 {
+		return fmt.Errorf("alerting rules are configured but no notifier is configured")
+	}
+
+	rules := make(map[string]bool, len(c.Rules))
+	for i := range c.Rules {
+		r := &c.Rules[i]
+		if r.Name == "" {
+			return fmt.Errorf("alerting rules[%d] has no name", i)
+		}
+		if rules[r.Name] {
+			return fmt.Errorf("alerting rule %s is duplicated", r.Name)
+		}
+		rules[r.Name] = true
+		if err := checkRule(r, notifiers); err != nil {
+			return fmt.Errorf("alerting rule %s is invalid, %v", r.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkRule(r *RuleConfig, notifiers map[string]bool) error {
+	if r.Metric == "" {
+		return fmt.Errorf("metric is empty")
+	}
+	if _, ok := comparators[r.Op]; !ok {
+		return fmt.Errorf("unknown op %q", r.Op)
+	}
+	switch r.Aggregation {
+	case "", AggregationSum, AggregationAvg, AggregationMax, AggregationMin, AggregationCount:
+	default:
+		return fmt.Errorf("unknown aggregation %q", r.Aggregation)
+	}
+	if r.IncreaseOver < 0 || r.For < 0 || r.RepeatInterval < 0 {
+		return fmt.Errorf("increaseOver, for and repeatInterval can't be negative")
+	}
+	for _, name := range r.Notifiers {
+		if !notifiers[name] {
+			return fmt.Errorf("notifier %s is not configured", name)
+		}
+	}
+	return nil
+}
+
+func checkNotifier(n *NotifierConfig) error {
+	count := 0
+	if n.Webhook != nil {
+		count++
+		if err := checkURL(n.Webhook.URL); err != nil {
+			return err
+		}
+	}
+	if n.DingTalk != nil {
+		count++
+		if err := checkURL(n.DingTalk.URL); err != nil {
+			return err
+		}
+	}
+	if n.Email != nil {
+		count++
+		if n.Email.SMTPHost == "" || n.Email.From == "" || len(n.Email.To) == 0 {
+			return fmt.Errorf("smtpHost, from and to of email are required")
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("exactly one of webhook, dingtalk and email should be set")
+	}
+	return nil
+}
+
+func checkURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url %q, %v", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid url %q, only http and https are supported", rawURL)
+	}
+	return nil
+}
@@ -0,0 +1,418 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+var comparators = map[string]func(value, threshold float64) bool{
+	">":  func(v, t float64) bool { return v > t },
+	">=": func(v, t float64) bool { return v >= t },
+	"<":  func(v, t float64) bool { return v < t },
+	"<=": func(v, t float64) bool { return v <= t },
+	"==": func(v, t float64) bool { return v == t },
+	"!=": func(v, t float64) bool { return v != t },
+}
+
+// Alert is a notification of a rule firing or resolved.
+type Alert struct {
+	Rule      string            `json:"rule"`
+	Status    string            `json:"status"`
+	Severity  string            `json:"severity,omitempty"`
+	Summary   string            `json:"summary,omitempty"`
+	Domain    string            `json:"domain"`
+	Metric    string            `json:"metric"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Value     float64           `json:"value"`
+	Op        string            `json:"op"`
+	Threshold float64           `json:"threshold"`
+	StartsAt  time.Time         `json:"startsAt"`
+	EndsAt    *time.Time        `json:"endsAt,omitempty"`
+}
+
+func (a *Alert) String() string {
+	var labels []string
+	for k, v := range a.Labels {
+		labels = append(labels, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(labels)
+	s := fmt.Sprintf("[%s] %s of domain %s: %s{%s} = %g %s %g", strings.ToUpper(a.Status), a.Rule, a.Domain, a.Metric,
+		strings.Join(labels, ","), a.Value, a.Op, a.Threshold)
+	if a.Summary != "" {
+		s += ", " + a.Summary
+	}
+	return s
+}
+
+// series is a sample of a metric.
+type series struct {
+	key    string
+	labels map[string]string
+	value  float64
+}
+
+type sample struct {
+	time  time.Time
+	value float64
+}
+
+type alertState struct {
+	activeSince time.Time
+	firing      bool
+	lastSent    time.Time
+	labels      map[string]string
+	value       float64
+}
+
+// Engine evaluates the rules periodically, and notifies when alerts fire or resolve.
+type Engine struct {
+	config    *Config
+	domainID  string
+	sources   []Source
+	notifiers map[string]Notifier
+
+	// history is the samples of metrics in the windows of increaseOver, indexed by rule and series.
+	history map[string][]sample
+	// states is the pending and firing alerts, indexed by rule and series.
+	states map[string]*alertState
+
+	// now is replaced in test.
+	now func() time.Time
+}
+
+func NewEngine(config *Config, domainID string, sources []Source) (*Engine, error) {
+	if err := CheckConfig(config); err != nil {
+		return nil, err
+	}
+	notifiers := make(map[string]Notifier, len(config.Notifiers))
+	for i := range config.Notifiers {
+		notifiers[config.Notifiers[i].Name] = NewNotifier(&config.Notifiers[i])
+	}
+	return &Engine{
+		config:    config,
+		domainID:  domainID,
+		sources:   sources,
+		notifiers: notifiers,
+		history:   make(map[string][]sample),
+		states:    make(map[string]*alertState),
+		now:       time.Now,
+	}, nil
+}
+
+// Run evaluates the rules until ctx is done.
+func (e *Engine) Run(ctx context.Context) {
+	nlog.Infof("Start to evaluate %d alerting rules every %v", len(e.config.Rules), e.config.interval())
+	ticker := time.NewTicker(e.config.interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate evaluates the rules once and sends the notifications.
+func (e *Engine) Evaluate(ctx context.Context) {
+	now := e.now()
+	index, complete := e.gather()
+
+	var alerts []*Alert
+	seen := make(map[string]bool)
+	for i := range e.config.Rules {
+		rule := &e.config.Rules[i]
+		for _, s := range e.ruleValues(rule, index, now) {
+			stateKey := rule.Name + "|" + s.key
+			seen[stateKey] = true
+			state := e.states[stateKey]
+			if !comparators[rule.Op](s.value, rule.Threshold) {
+				if state != nil && state.firing {
+					state.value = s.value
+					alerts = append(alerts, e.makeAlert(rule, state, StatusResolved, now))
+				}
+				delete(e.states, stateKey)
+				continue
+			}
+
+			if state == nil {
+				state = &alertState{activeSince: now}
+				e.states[stateKey] = state
+			}
+			state.labels, state.value = s.labels, s.value
+			if now.Sub(state.activeSince) < rule.For {
+				continue
+			}
+			if !state.firing || (rule.RepeatInterval > 0 && now.Sub(state.lastSent) >= rule.RepeatInterval) {
+				state.firing = true
+				state.lastSent = now
+				alerts = append(alerts, e.makeAlert(rule, state, StatusFiring, now))
+			}
+		}
+	}
+
+	// series may be missing because a source failed, keep their alerts until all sources are gathered
+	if complete {
+		for stateKey, state := range e.states {
+			if seen[stateKey] {
+				continue
+			}
+			name, _, _ := strings.Cut(stateKey, "|")
+			if rule := e.ruleOf(name); rule != nil && state.firing {
+				alerts = append(alerts, e.makeAlert(rule, state, StatusResolved, now))
+			}
+			delete(e.states, stateKey)
+		}
+	}
+	e.notify(ctx, alerts)
+}
+
+func (e *Engine) ruleOf(name string) *RuleConfig {
+	for i := range e.config.Rules {
+		if e.config.Rules[i].Name == name {
+			return &e.config.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (e *Engine) makeAlert(rule *RuleConfig, state *alertState, status string, now time.Time) *Alert {
+	alert := &Alert{
+		Rule:      rule.Name,
+		Status:    status,
+		Severity:  rule.Severity,
+		Summary:   rule.Summary,
+		Domain:    e.domainID,
+		Metric:    rule.Metric,
+		Labels:    state.labels,
+		Value:     state.value,
+		Op:        rule.Op,
+		Threshold: rule.Threshold,
+		StartsAt:  state.activeSince,
+	}
+	if status == StatusResolved {
+		endsAt := now
+		alert.EndsAt = &endsAt
+	}
+	return alert
+}
+
+// gather returns series indexed by metric name, and whether all sources are gathered.
+func (e *Engine) gather() (map[string][]*series, bool) {
+	index := make(map[string][]*series)
+	complete := true
+	for _, source := range e.sources {
+		families, err := source.Gather()
+		if err != nil {
+			nlog.Warnf("Fail to gather metrics from %s for alerting, err: %v", source.Name(), err)
+			complete = false
+		}
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				value, ok := metricValue(metric)
+				if !ok {
+					continue
+				}
+				labels := make(map[string]string, len(metric.GetLabel()))
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				index[family.GetName()] = append(index[family.GetName()], &series{key: seriesKey(labels), labels: labels, value: value})
+			}
+		}
+	}
+	return index, complete
+}
+
+// ruleValues returns the values of rule, one value without labels if the series are aggregated.
+func (e *Engine) ruleValues(rule *RuleConfig, index map[string][]*series, now time.Time) []*series {
+	var divisors map[string]float64
+	if rule.DivideBy != "" {
+		divisors = make(map[string]float64)
+		for _, s := range index[rule.DivideBy] {
+			divisors[s.key] = s.value
+		}
+	}
+
+	if rule.IncreaseOver > 0 {
+		e.pruneHistory(rule, now)
+	}
+	var values []*series
+	for _, s := range index[rule.Metric] {
+		if !matchLabels(s.labels, rule.Labels) {
+			continue
+		}
+		value := s.value
+		if rule.IncreaseOver > 0 {
+			var ok bool
+			if value, ok = e.increase(rule, s, now); !ok {
+				continue
+			}
+		}
+		if divisors != nil {
+			divisor, ok := divisors[s.key]
+			if !ok || divisor == 0 {
+				continue
+			}
+			value /= divisor
+		}
+		values = append(values, &series{key: s.key, labels: s.labels, value: value})
+	}
+
+	if rule.Aggregation == "" {
+		return values
+	}
+	if len(values) == 0 && rule.Aggregation != AggregationCount {
+		return nil
+	}
+	return []*series{{key: "", labels: rule.Labels, value: aggregate(rule.Aggregation, values)}}
+}
+
+// increase records the sample and returns the increase in the window, a decrease is taken as a counter reset.
+func (e *Engine) increase(rule *RuleConfig, s *series, now time.Time) (float64, bool) {
+	historyKey := rule.Name + "|" + s.key
+	samples := append(e.history[historyKey], sample{time: now, value: s.value})
+	e.history[historyKey] = samples
+	if len(samples) < 2 {
+		return 0, false
+	}
+	var increase float64
+	for i := 1; i < len(samples); i++ {
+		if delta := samples[i].value - samples[i-1].value; delta >= 0 {
+			increase += delta
+		} else {
+			increase += samples[i].value
+		}
+	}
+	return increase, true
+}
+
+// pruneHistory drops samples out of the window and series not updated in the window.
+func (e *Engine) pruneHistory(rule *RuleConfig, now time.Time) {
+	start := now.Add(-rule.IncreaseOver)
+	prefix := rule.Name + "|"
+	for historyKey, samples := range e.history {
+		if !strings.HasPrefix(historyKey, prefix) {
+			continue
+		}
+		i := 0
+		for i < len(samples) && samples[i].time.Before(start) {
+			i++
+		}
+		if i == len(samples) {
+			delete(e.history, historyKey)
+			continue
+		}
+		e.history[historyKey] = samples[i:]
+	}
+}
+
+func (e *Engine) notify(ctx context.Context, alerts []*Alert) {
+	if len(alerts) == 0 {
+		return
+	}
+	grouped := make(map[string][]*Alert)
+	for _, alert := range alerts {
+		nlog.Infof("Alert %s", alert)
+		names := e.ruleOf(alert.Rule).Notifiers
+		if len(names) == 0 {
+			for name := range e.notifiers {
+				names = append(names, name)
+			}
+		}
+		for _, name := range names {
+			grouped[name] = append(grouped[name], alert)
+		}
+	}
+	for name, alerts := range grouped {
+		notifier := e.notifiers[name]
+		if err := notifier.Notify(ctx, alerts); err != nil {
+			nlog.Warnf("Fail to send %d alerts by notifier %s, err: %v", len(alerts), name, err)
+			notifications.WithLabelValues(name, resultFailed).Inc()
+			continue
+		}
+		notifications.WithLabelValues(name, resultSucceeded).Inc()
+	}
+}
+
+func metricValue(metric *dto.Metric) (float64, bool) {
+	switch {
+	case metric.Gauge != nil:
+		return metric.Gauge.GetValue(), true
+	case metric.Counter != nil:
+		return metric.Counter.GetValue(), true
+	case metric.Untyped != nil:
+		return metric.Untyped.GetValue(), true
+	}
+	return 0, false
+}
+
+func matchLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func seriesKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func aggregate(aggregation string, values []*series) float64 {
+	if aggregation == AggregationCount {
+		return float64(len(values))
+	}
+	result := values[0].value
+	for _, s := range values[1:] {
+		switch aggregation {
+		case AggregationSum, AggregationAvg:
+			result += s.value
+		case AggregationMax:
+			if s.value > result {
+				result = s.value
+			}
+		case AggregationMin:
+			if s.value < result {
+				result = s.value
+			}
+		}
+	}
+	if aggregation == AggregationAvg {
+		result /= float64(len(values))
+	}
+	return result
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNotifier struct {
+	alerts []*Alert
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, alerts []*Alert) error {
+	n.alerts = append(n.alerts, alerts...)
+	return nil
+}
+
+func (n *fakeNotifier) statuses() []string {
+	var statuses []string
+	for _, alert := range n.alerts {
+		statuses = append(statuses, alert.Rule+"/"+alert.Status)
+	}
+	n.alerts = nil
+	return statuses
+}
+
+var testNotifiers = []NotifierConfig{{Name: "ops", Webhook: &WebhookConfig{URL: "http://127.0.0.1/alerts"}}}
+
+func makeEngine(t *testing.T, rules []RuleConfig, reg *prometheus.Registry) (*Engine, *fakeNotifier, *time.Time) {
+	e, err := NewEngine(&Config{Rules: rules, Notifiers: testNotifiers}, "alice", []Source{NewGathererSource("test", reg)})
+	assert.NoError(t, err)
+	notifier := &fakeNotifier{}
+	e.notifiers["ops"] = notifier
+	now := time.Now()
+	e.now = func() time.Time { return now }
+	return e, notifier, &now
+}
+
+func TestEngineThreshold(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	errorRate := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "kuscia_network_upstream_error_rate"}, []string{"dst_domain"})
+	reg.MustRegister(errorRate)
+	errorRate.WithLabelValues("bob").Set(0.8)
+	errorRate.WithLabelValues("carol").Set(0)
+
+	e, notifier, now := makeEngine(t, []RuleConfig{{
+		Name: "route-down", Metric: "kuscia_network_upstream_error_rate", Op: ">", Threshold: 0.5, For: time.Minute,
+		RepeatInterval: time.Hour, Severity: "critical",
+	}}, reg)
+	ctx := context.Background()
+
+	// pending until the condition holds for a minute
+	e.Evaluate(ctx)
+	assert.Empty(t, notifier.statuses())
+	*now = now.Add(time.Minute)
+	e.Evaluate(ctx)
+	alert := notifier.alerts[0]
+	assert.Equal(t, []string{"route-down/firing"}, notifier.statuses())
+	assert.Equal(t, map[string]string{"dst_domain": "bob"}, alert.Labels)
+	assert.Equal(t, `[FIRING] route-down of domain alice: kuscia_network_upstream_error_rate{dst_domain="bob"} = 0.8 > 0.5`, alert.String())
+
+	// firing alerts are not sent again until the repeat interval
+	*now = now.Add(time.Minute)
+	e.Evaluate(ctx)
+	assert.Empty(t, notifier.statuses())
+	*now = now.Add(time.Hour)
+	e.Evaluate(ctx)
+	assert.Equal(t, []string{"route-down/firing"}, notifier.statuses())
+
+	errorRate.WithLabelValues("bob").Set(0.1)
+	e.Evaluate(ctx)
+	assert.Equal(t, StatusResolved, notifier.alerts[0].Status)
+	assert.Equal(t, *now, *notifier.alerts[0].EndsAt)
+	assert.Equal(t, []string{"route-down/resolved"}, notifier.statuses())
+	assert.Empty(t, e.states)
+}
+
+func TestEngineIncreaseAndAggregation(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	jobResults := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "kuscia_job_result_stats"}, []string{"result"})
+	avail := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "node_filesystem_avail_bytes"}, []string{"mountpoint"})
+	size := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "node_filesystem_size_bytes"}, []string{"mountpoint"})
+	reg.MustRegister(jobResults, avail, size)
+	jobResults.WithLabelValues("failed").Add(10)
+	avail.WithLabelValues("/").Set(5)
+	size.WithLabelValues("/").Set(100)
+	avail.WithLabelValues("/home").Set(50)
+	size.WithLabelValues("/home").Set(100)
+
+	e, notifier, now := makeEngine(t, []RuleConfig{
+		{
+			Name: "job-failure-spike", Metric: "kuscia_job_result_stats", Labels: map[string]string{"result": "failed"},
+			IncreaseOver: 10 * time.Minute, Op: ">=", Threshold: 3,
+		},
+		{
+			Name: "disk-pressure", Metric: "node_filesystem_avail_bytes", DivideBy: "node_filesystem_size_bytes",
+			Aggregation: AggregationMin, Op: "<", Threshold: 0.1,
+		},
+	}, reg)
+	ctx := context.Background()
+
+	// the increase is unknown in the first evaluation
+	e.Evaluate(ctx)
+	assert.Equal(t, []string{"disk-pressure/firing"}, notifier.statuses())
+
+	*now = now.Add(5 * time.Minute)
+	jobResults.WithLabelValues("failed").Add(2)
+	e.Evaluate(ctx)
+	assert.Empty(t, notifier.statuses())
+
+	*now = now.Add(4 * time.Minute)
+	jobResults.WithLabelValues("failed").Add(1)
+	e.Evaluate(ctx)
+	assert.Equal(t, 3.0, notifier.alerts[0].Value)
+	assert.Equal(t, []string{"job-failure-spike/firing"}, notifier.statuses())
+
+	// failures out of the window are not counted
+	*now = now.Add(2 * time.Minute)
+	avail.WithLabelValues("/").Set(50)
+	e.Evaluate(ctx)
+	assert.ElementsMatch(t, []string{"job-failure-spike/resolved", "disk-pressure/resolved"}, notifier.statuses())
+}
+
+func TestEngineSourceFailure(t *testing.T) {
+	up := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "# TYPE domain_cert_expiring gauge")
+		fmt.Fprintln(w, `domain_cert_expiring{domain="alice"} 1`)
+	}))
+	defer server.Close()
+
+	e, err := NewEngine(&Config{
+		Rules:     []RuleConfig{{Name: "cert-expiring", Metric: "domain_cert_expiring", Op: "==", Threshold: 1}},
+		Notifiers: testNotifiers,
+	}, "alice", []Source{NewURLSource("gateway", server.URL)})
+	assert.NoError(t, err)
+	notifier := &fakeNotifier{}
+	e.notifiers["ops"] = notifier
+
+	e.Evaluate(context.Background())
+	assert.Equal(t, []string{"cert-expiring/firing"}, notifier.statuses())
+	// alerts are kept while the source is down
+	up = false
+	e.Evaluate(context.Background())
+	assert.Empty(t, notifier.statuses())
+	assert.Len(t, e.states, 1)
+}
+
+func TestCheckConfig(t *testing.T) {
+	rule := RuleConfig{Name: "r", Metric: "m", Op: ">"}
+	tests := []struct {
+		config *Config
+		err    string
+	}{
+		{config: nil},
+		{config: &Config{Notifiers: testNotifiers}},
+		{config: &Config{Rules: []RuleConfig{rule}, Notifiers: testNotifiers}},
+		{config: &Config{Rules: []RuleConfig{rule}}, err: "no notifier is configured"},
+		{config: &Config{Rules: []RuleConfig{{Name: "r", Metric: "m", Op: "=>"}}, Notifiers: testNotifiers}, err: `unknown op "=>"`},
+		{config: &Config{Rules: []RuleConfig{{Name: "r", Metric: "m", Op: ">", Aggregation: "p99"}}, Notifiers: testNotifiers},
+			err: `unknown aggregation "p99"`},
+		{config: &Config{Rules: []RuleConfig{{Name: "r", Metric: "m", Op: ">", Notifiers: []string{"sms"}}}, Notifiers: testNotifiers},
+			err: "notifier sms is not configured"},
+		{config: &Config{Rules: []RuleConfig{rule, rule}, Notifiers: testNotifiers}, err: "alerting rule r is duplicated"},
+		{config: &Config{Rules: []RuleConfig{rule}, Notifiers: []NotifierConfig{{Name: "ops"}}},
+			err: "exactly one of webhook, dingtalk and email should be set"},
+		{config: &Config{Rules: []RuleConfig{rule}, Notifiers: []NotifierConfig{{Name: "ops", Email: &EmailConfig{SMTPHost: "smtp"}}}},
+			err: "smtpHost, from and to of email are required"},
+		{config: &Config{Rules: []RuleConfig{rule}, Notifiers: []NotifierConfig{{Name: "ops", DingTalk: &DingTalkConfig{URL: "ftp://x"}}}},
+			err: "only http and https are supported"},
+	}
+	for i, tt := range tests {
+		err := CheckConfig(tt.config)
+		if tt.err == "" {
+			assert.NoError(t, err, i)
+		} else {
+			assert.ErrorContains(t, err, tt.err, i)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	defaultSMTPPort = 25
+
+	resultSucceeded = "succeeded"
+	resultFailed    = "failed"
+)
+
+var notifications = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "kuscia_alerting_notifications_total",
+		Help: "Notifications of alerts sent by notifiers",
+	},
+	[]string{"notifier", "result"},
+)
+
+// Notifier sends alerts.
+type Notifier interface {
+	Notify(ctx context.Context, alerts []*Alert) error
+}
+
+func NewNotifier(config *NotifierConfig) Notifier {
+	switch {
+	case config.Webhook != nil:
+		return &webhookNotifier{config: config.Webhook, client: &http.Client{Timeout: timeoutOrDefault(config.Webhook.Timeout)}}
+	case config.DingTalk != nil:
+		return &dingTalkNotifier{config: config.DingTalk, client: &http.Client{Timeout: timeoutOrDefault(config.DingTalk.Timeout)},
+			now: time.Now}
+	default:
+		return &emailNotifier{config: config.Email, sendMail: smtp.SendMail}
+	}
+}
+
+func timeoutOrDefault(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return defaultNotifierTimeout
+	}
+	return timeout
+}
+
+type webhookNotifier struct {
+	config *WebhookConfig
+	client *http.Client
+}
+
+type webhookMessage struct {
+	Alerts []*Alert `json:"alerts"`
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, alerts []*Alert) error {
+	body, err := json.Marshal(&webhookMessage{Alerts: alerts})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.client, n.config.URL, n.config.Headers, body, nil)
+}
+
+type dingTalkNotifier struct {
+	config *DingTalkConfig
+	client *http.Client
+	// now is replaced in test.
+	now func() time.Time
+}
+
+type dingTalkMessage struct {
+	MsgType  string `json:"msgtype"`
+	Markdown struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	} `json:"markdown"`
+	At struct {
+		AtMobiles []string `json:"atMobiles,omitempty"`
+	} `json:"at"`
+}
+
+type dingTalkResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+func (n *dingTalkNotifier) Notify(ctx context.Context, alerts []*Alert) error {
+	msg := &dingTalkMessage{MsgType: "markdown"}
+	msg.Markdown.Title = messageTitle(alerts)
+	lines := []string{"### " + msg.Markdown.Title}
+	for _, alert := range alerts {
+		lines = append(lines, "- "+alert.String())
+	}
+	for _, mobile := range n.config.AtMobiles {
+		lines = append(lines, "@"+mobile)
+	}
+	msg.Markdown.Text = strings.Join(lines, "\n")
+	msg.At.AtMobiles = n.config.AtMobiles
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	target := n.config.URL
+	if n.config.Secret != "" {
+		target, err = signDingTalkURL(target, n.config.Secret, n.now())
+		if err != nil {
+			return err
+		}
+	}
+	resp := &dingTalkResponse{}
+	if err := postJSON(ctx, n.client, target, nil, body, resp); err != nil {
+		return err
+	}
+	if resp.ErrCode != 0 {
+		return fmt.Errorf("dingtalk error %d, %s", resp.ErrCode, resp.ErrMsg)
+	}
+	return nil
+}
+
+// signDingTalkURL appends the timestamp and the signature required by robots secured by signature.
+func signDingTalkURL(rawURL, secret string, now time.Time) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	timestamp := strconv.FormatInt(now.UnixMilli(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	query := u.Query()
+	query.Set("timestamp", timestamp)
+	query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+type emailNotifier struct {
+	config *EmailConfig
+	// sendMail is replaced in test.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (n *emailNotifier) Notify(ctx context.Context, alerts []*Alert) error {
+	port := n.config.SMTPPort
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.SMTPHost)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", messageTitle(alerts))
+	msg.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n")
+	for _, alert := range alerts {
+		msg.WriteString(alert.String() + "\r\n")
+	}
+	return n.sendMail(net.JoinHostPort(n.config.SMTPHost, strconv.Itoa(port)), auth, n.config.From, n.config.To, msg.Bytes())
+}
+
+// messageTitle summarizes alerts, e.g. [kuscia alice] 2 firing, 1 resolved.
+func messageTitle(alerts []*Alert) string {
+	var firing, resolved int
+	for _, alert := range alerts {
+		if alert.Status == StatusFiring {
+			firing++
+		} else {
+			resolved++
+		}
+	}
+	var parts []string
+	if firing > 0 {
+		parts = append(parts, fmt.Sprintf("%d firing", firing))
+	}
+	if resolved > 0 {
+		parts = append(parts, fmt.Sprintf("%d resolved", resolved))
+	}
+	return fmt.Sprintf("[kuscia %s] %s", alerts[0].Domain, strings.Join(parts, ", "))
+}
+
+func postJSON(ctx context.Context, client *http.Client, target string, headers map[string]string, body []byte,
+	out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("response status code [%d], detail -> %s", resp.StatusCode, string(respBody))
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("invalid response %q, %v", string(respBody), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testAlerts = []*Alert{
+	{Rule: "route-down", Status: StatusFiring, Domain: "alice", Metric: "kuscia_network_upstream_error_rate", Value: 0.8, Op: ">", Threshold: 0.5},
+	{Rule: "disk-pressure", Status: StatusResolved, Domain: "alice", Metric: "node_filesystem_avail_bytes", Value: 0.5, Op: "<", Threshold: 0.1},
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received webhookMessage
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	n := NewNotifier(&NotifierConfig{Name: "ops", Webhook: &WebhookConfig{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer t"}}})
+	assert.NoError(t, n.Notify(context.Background(), testAlerts))
+	assert.Equal(t, "Bearer t", token)
+	assert.Len(t, received.Alerts, 2)
+	assert.Equal(t, "route-down", received.Alerts[0].Rule)
+
+	n = NewNotifier(&NotifierConfig{Name: "ops", Webhook: &WebhookConfig{URL: server.URL + "/missing"}})
+	server.Config.Handler = http.NotFoundHandler()
+	assert.ErrorContains(t, n.Notify(context.Background(), testAlerts), "response status code [404]")
+}
+
+func TestDingTalkNotifier(t *testing.T) {
+	var received dingTalkMessage
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if r.URL.Query().Get("access_token") != "token" {
+			io.WriteString(w, `{"errcode":300001,"errmsg":"token is not exist"}`)
+			return
+		}
+		io.WriteString(w, `{"errcode":0,"errmsg":"ok"}`)
+	}))
+	defer server.Close()
+
+	n := NewNotifier(&NotifierConfig{Name: "ding", DingTalk: &DingTalkConfig{URL: server.URL + "?access_token=token",
+		Secret: "SEC000", AtMobiles: []string{"13800000000"}}}).(*dingTalkNotifier)
+	n.now = func() time.Time { return time.UnixMilli(1700000000000) }
+	assert.NoError(t, n.Notify(context.Background(), testAlerts))
+	assert.Equal(t, "access_token=token&sign=ltBBey5eZrWKh1cPzFIdz3v3xpkc4Tjx4lLsPSHqdtA%3D&timestamp=1700000000000", query)
+	assert.Equal(t, "markdown", received.MsgType)
+	assert.Equal(t, "[kuscia alice] 1 firing, 1 resolved", received.Markdown.Title)
+	assert.Contains(t, received.Markdown.Text, "- [RESOLVED] disk-pressure of domain alice")
+	assert.Equal(t, []string{"13800000000"}, received.At.AtMobiles)
+
+	n.config.URL = server.URL + "?access_token=invalid"
+	assert.EqualError(t, n.Notify(context.Background(), testAlerts), "dingtalk error 300001, token is not exist")
+}
+
+func TestEmailNotifier(t *testing.T) {
+	n := NewNotifier(&NotifierConfig{Name: "mail", Email: &EmailConfig{SMTPHost: "smtp.example.com", Username: "ops",
+		Password: "pass", From: "kuscia@example.com", To: []string{"a@example.com", "b@example.com"}}}).(*emailNotifier)
+	var addr string
+	var msg string
+	n.sendMail = func(a string, auth smtp.Auth, from string, to []string, m []byte) error {
+		addr, msg = a, string(m)
+		assert.NotNil(t, auth)
+		assert.Equal(t, "kuscia@example.com", from)
+		assert.Len(t, to, 2)
+		return nil
+	}
+	assert.NoError(t, n.Notify(context.Background(), testAlerts))
+	assert.Equal(t, "smtp.example.com:25", addr)
+	assert.True(t, strings.HasPrefix(msg, "From: kuscia@example.com\r\nTo: a@example.com, b@example.com\r\n"+
+		"Subject: [kuscia alice] 1 firing, 1 resolved\r\n"), msg)
+	assert.Contains(t, msg, "[FIRING] route-down of domain alice: kuscia_network_upstream_error_rate{} = 0.8 > 0.5\r\n")
+}
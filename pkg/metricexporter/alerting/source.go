@@ -0,0 +1,87 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alerting
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const scrapeTimeout = 5 * time.Second
+
+// Source provides metric families to evaluate rules on.
+type Source interface {
+	Name() string
+	Gather() ([]*dto.MetricFamily, error)
+}
+
+type gathererSource struct {
+	name     string
+	gatherer prometheus.Gatherer
+}
+
+// NewGathererSource gathers metrics registered in the process, e.g. prometheus.DefaultGatherer.
+func NewGathererSource(name string, gatherer prometheus.Gatherer) Source {
+	return &gathererSource{name: name, gatherer: gatherer}
+}
+
+func (s *gathererSource) Name() string {
+	return s.name
+}
+
+func (s *gathererSource) Gather() ([]*dto.MetricFamily, error) {
+	return s.gatherer.Gather()
+}
+
+type urlSource struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+// NewURLSource scrapes metrics in the prometheus text format from url, e.g. node exporter.
+func NewURLSource(name, url string) Source {
+	return &urlSource{name: name, url: url, client: &http.Client{Timeout: scrapeTimeout}}
+}
+
+func (s *urlSource) Name() string {
+	return s.name
+}
+
+func (s *urlSource) Gather() ([]*dto.MetricFamily, error) {
+	resp, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response status code [%d]", resp.StatusCode)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*dto.MetricFamily, 0, len(families))
+	for _, family := range families {
+		result = append(result, family)
+	}
+	return result, nil
+}
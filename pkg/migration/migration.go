@@ -0,0 +1,94 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migration upgrades the kuscia config file and kuscia resources written by former versions, so that stale
+// config keys and resource fields don't break kuscia after upgrade.
+package migration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/client-go/dynamic"
+)
+
+// Step is a versioned migration of config file, kuscia resources or both.
+type Step struct {
+	// ID identifies the step in migration records, it must be unique and never changed.
+	ID          string
+	Description string
+	// Config migrates the config document in place and returns the changes. Config migrations aren't recorded, they
+	// are applied every time the config file is loaded, so it must be idempotent.
+	Config func(doc *yaml.Node) ([]string, error)
+	// Resources migrates kuscia resources and returns the changes, nothing is written if dryRun is true. It's applied
+	// once and recorded, but it may be interrupted before recording, so it must be idempotent too.
+	Resources func(ctx context.Context, client dynamic.Interface, dryRun bool) ([]string, error)
+}
+
+// Change is a change made or to be made by a step.
+type Change struct {
+	Step        string
+	Description string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("[%s] %s", c.Step, c.Description)
+}
+
+// MigrateConfig applies config migrations of all steps to content of config file, content is returned as it is if
+// there is nothing to change.
+func MigrateConfig(content []byte) ([]byte, []Change, error) {
+	return migrateConfig(content, steps)
+}
+
+func migrateConfig(content []byte, steps []Step) ([]byte, []Change, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(content, doc); err != nil {
+		return nil, nil, err
+	}
+	// empty config file
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return content, nil, nil
+	}
+
+	var changes []Change
+	for _, step := range steps {
+		if step.Config == nil {
+			continue
+		}
+		descriptions, err := step.Config(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("migrate config by step %s failed, %v", step.ID, err)
+		}
+		for _, d := range descriptions {
+			changes = append(changes, Change{Step: step.ID, Description: d})
+		}
+	}
+	if len(changes) == 0 {
+		return content, nil, nil
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), changes, nil
+}
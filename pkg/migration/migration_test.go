@@ -0,0 +1,95 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func renameStep(from, to string) Step {
+	return Step{
+		ID: "rename",
+		Config: func(doc *yaml.Node) ([]string, error) {
+			renamed, err := RenameKey(doc, from, to)
+			if err != nil || !renamed {
+				return nil, err
+			}
+			return []string{"rename " + from + " to " + to}, nil
+		},
+	}
+}
+
+func TestMigrateConfigRenameKey(t *testing.T) {
+	content := []byte(`mode: lite
+# registry of images
+agent:
+  runtime: runc
+`)
+	migrated, changes, err := migrateConfig(content, []Step{renameStep("agent.runtime", "agent.provider.runtime")})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Step: "rename", Description: "rename agent.runtime to agent.provider.runtime"}}, changes)
+	assert.Equal(t, `mode: lite
+# registry of images
+agent:
+  provider:
+    runtime: runc
+`, string(migrated))
+
+	// migrated config isn't changed again
+	again, changes, err := migrateConfig(migrated, []Step{renameStep("agent.runtime", "agent.provider.runtime")})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, migrated, again)
+
+	_, _, err = migrateConfig([]byte("a: 1\nb: 2\n"), []Step{renameStep("a", "b")})
+	assert.Error(t, err)
+}
+
+func TestMigrateConfigEmpty(t *testing.T) {
+	migrated, changes, err := migrateConfig([]byte(""), steps)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, migrated)
+}
+
+func TestMigrateRegistryEnv(t *testing.T) {
+	t.Setenv(envRegistryEndpoint, "registry.example.com/secretflow")
+	t.Setenv(envRegistryUsername, "alice")
+	t.Setenv(envRegistryPassword, "")
+
+	migrated, changes, err := MigrateConfig([]byte("mode: autonomy\nimage:\n"))
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "0001-registry-env", changes[0].Step)
+	assert.Equal(t, `mode: autonomy
+image:
+  registries:
+    - name: default
+      endpoint: registry.example.com/secretflow
+      username: alice
+`, string(migrated))
+
+	// configured registries take precedence
+	_, changes, err = MigrateConfig(migrated)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, changes, err = MigrateConfig([]byte("mode: master\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
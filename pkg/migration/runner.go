@@ -0,0 +1,157 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/utils/kubeconfig"
+	"github.com/secretflow/kuscia/pkg/utils/meta"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// RecordNamespace and RecordName locate the config map recording applied resource migrations, its data is indexed
+	// by step id.
+	RecordNamespace = "kube-system"
+	RecordName      = "kuscia-migration"
+)
+
+// Record is an applied resource migration.
+type Record struct {
+	AppliedAt     time.Time `json:"appliedAt"`
+	KusciaVersion string    `json:"kusciaVersion,omitempty"`
+}
+
+// Report is the result of running resource migrations.
+type Report struct {
+	DryRun bool
+	// Applied are ids of steps applied in this run, or to be applied in dry run.
+	Applied []string
+	// Skipped are ids of steps applied before.
+	Skipped []string
+	Changes []Change
+}
+
+// Runner applies resource migrations in order and records the applied ones.
+type Runner struct {
+	steps         []Step
+	dynamicClient dynamic.Interface
+	kubeClient    kubernetes.Interface
+}
+
+func NewRunner(clients *kubeconfig.KubeClients) (*Runner, error) {
+	dynamicClient, err := dynamic.NewForConfig(clients.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("create dynamic client failed, %v", err)
+	}
+	return newRunner(steps, dynamicClient, clients.KubeClient), nil
+}
+
+func newRunner(steps []Step, dynamicClient dynamic.Interface, kubeClient kubernetes.Interface) *Runner {
+	return &Runner{
+		steps:         steps,
+		dynamicClient: dynamicClient,
+		kubeClient:    kubeClient,
+	}
+}
+
+// Run applies resource migrations which aren't recorded, nothing is written in dry run.
+func (r *Runner) Run(ctx context.Context, dryRun bool) (*Report, error) {
+	records, err := r.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{DryRun: dryRun}
+	for _, step := range r.steps {
+		if step.Resources == nil {
+			continue
+		}
+		if _, ok := records[step.ID]; ok {
+			report.Skipped = append(report.Skipped, step.ID)
+			continue
+		}
+		descriptions, err := step.Resources(ctx, r.dynamicClient, dryRun)
+		if err != nil {
+			return report, fmt.Errorf("migrate resources by step %s failed, %v", step.ID, err)
+		}
+		for _, d := range descriptions {
+			report.Changes = append(report.Changes, Change{Step: step.ID, Description: d})
+		}
+		report.Applied = append(report.Applied, step.ID)
+		if dryRun {
+			continue
+		}
+		if err := r.record(ctx, step.ID); err != nil {
+			return report, err
+		}
+		nlog.Infof("Resource migration %s is applied with %d changes", step.ID, len(descriptions))
+	}
+	return report, nil
+}
+
+// Records returns the applied resource migrations indexed by step id.
+func (r *Runner) Records(ctx context.Context) (map[string]Record, error) {
+	cm, err := r.kubeClient.CoreV1().ConfigMaps(RecordNamespace).Get(ctx, RecordName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return map[string]Record{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get migration records failed, %v", err)
+	}
+	records := make(map[string]Record, len(cm.Data))
+	for id, data := range cm.Data {
+		record := Record{}
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return nil, fmt.Errorf("parse migration record %s failed, %v", id, err)
+		}
+		records[id] = record
+	}
+	return records, nil
+}
+
+func (r *Runner) record(ctx context.Context, id string) error {
+	data, err := json.Marshal(Record{AppliedAt: time.Now().UTC(), KusciaVersion: meta.KusciaVersionString()})
+	if err != nil {
+		return err
+	}
+	client := r.kubeClient.CoreV1().ConfigMaps(RecordNamespace)
+	cm, err := client.Get(ctx, RecordName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: RecordName, Namespace: RecordNamespace},
+			Data:       map[string]string{id: string(data)},
+		}, metav1.CreateOptions{})
+	} else if err == nil {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[id] = string(data)
+		_, err = client.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("record migration %s failed, %v", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+func newDomainRoute(kind, namespace, name string, ports ...interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": v1alpha1.SchemeGroupVersion.String(),
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec": map[string]interface{}{
+			"endpoint": map[string]interface{}{"host": "bob.example.com", "ports": ports},
+		},
+	}}
+	return obj
+}
+
+func port(name, protocol string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "protocol": protocol, "port": int64(443)}
+}
+
+func TestRunnerDomainRouteTLSProtocol(t *testing.T) {
+	ctx := context.Background()
+	cdrGVR := v1alpha1.SchemeGroupVersion.WithResource("clusterdomainroutes")
+	drGVR := v1alpha1.SchemeGroupVersion.WithResource("domainroutes")
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			cdrGVR: "ClusterDomainRouteList",
+			drGVR:  "DomainRouteList",
+		},
+		newDomainRoute("ClusterDomainRoute", "", "alice-bob", port("http", "HTTPS"), port("grpc", "GRPC")),
+		newDomainRoute("DomainRoute", "alice", "bob-alice", port("http", "http")),
+		newDomainRoute("DomainRoute", "alice", "carol-alice", port("http", "HTTP")))
+	kubeClient := kubefake.NewSimpleClientset()
+	runner := newRunner(steps, dynamicClient, kubeClient)
+
+	report, err := runner.Run(ctx, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0002-domainroute-tls-protocol"}, report.Applied)
+	assert.Len(t, report.Changes, 2)
+	cdr, err := dynamicClient.Resource(cdrGVR).Get(ctx, "alice-bob", metav1.GetOptions{})
+	assert.NoError(t, err)
+	ports, _, _ := unstructured.NestedSlice(cdr.Object, "spec", "endpoint", "ports")
+	assert.Equal(t, "HTTPS", ports[0].(map[string]interface{})["protocol"])
+	records, err := runner.Records(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+
+	report, err = runner.Run(ctx, false)
+	assert.NoError(t, err)
+	assert.Len(t, report.Changes, 2)
+	cdr, err = dynamicClient.Resource(cdrGVR).Get(ctx, "alice-bob", metav1.GetOptions{})
+	assert.NoError(t, err)
+	ports, _, _ = unstructured.NestedSlice(cdr.Object, "spec", "endpoint", "ports")
+	assert.Equal(t, map[string]interface{}{"name": "http", "protocol": "HTTP", "isTLS": true, "port": int64(443)}, ports[0])
+	assert.Equal(t, "GRPC", ports[1].(map[string]interface{})["protocol"])
+	dr, err := dynamicClient.Resource(drGVR).Namespace("alice").Get(ctx, "bob-alice", metav1.GetOptions{})
+	assert.NoError(t, err)
+	ports, _, _ = unstructured.NestedSlice(dr.Object, "spec", "endpoint", "ports")
+	assert.Equal(t, "HTTP", ports[0].(map[string]interface{})["protocol"])
+	records, err = runner.Records(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, records, "0002-domainroute-tls-protocol")
+
+	// applied migrations are skipped
+	report, err = runner.Run(ctx, false)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Applied)
+	assert.Equal(t, []string{"0002-domainroute-tls-protocol"}, report.Skipped)
+}
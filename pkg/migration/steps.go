@@ -0,0 +1,164 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+const (
+	envRegistryEndpoint = "REGISTRY_ENDPOINT"
+	envRegistryUsername = "REGISTRY_USERNAME"
+	envRegistryPassword = "REGISTRY_PASSWORD"
+)
+
+// steps are applied in order, they must be appended only, never change or remove a released one.
+var steps = []Step{
+	{
+		ID:          "0001-registry-env",
+		Description: "move deprecated registry environment variables into image.registries of config file",
+		Config:      migrateRegistryEnv,
+	},
+	{
+		ID:          "0002-domainroute-tls-protocol",
+		Description: "convert HTTPS and GRPCS ports of DomainRoute and ClusterDomainRoute into HTTP and GRPC ports with isTLS",
+		Resources:   migrateDomainRouteTLSProtocol,
+	},
+}
+
+// Steps returns all migration steps in order.
+func Steps() []Step {
+	return steps
+}
+
+// migrateRegistryEnv moves the registry set by environment variables of former versions into image.registries, the
+// environment variables are ignored if registries are configured.
+func migrateRegistryEnv(doc *yaml.Node) ([]string, error) {
+	endpoint := os.Getenv(envRegistryEndpoint)
+	root := rootMapping(doc)
+	if endpoint == "" || root == nil {
+		return nil, nil
+	}
+	// master doesn't pull images
+	if mode := lookup(root, []string{"mode"}); mode != nil && strings.EqualFold(mode.Value, common.RunModeMaster) {
+		return nil, nil
+	}
+	if registries := lookup(root, []string{"image", "registries"}); registries != nil && len(registries.Content) > 0 {
+		return nil, nil
+	}
+
+	image := ensureMapping(root, []string{"image"})
+	if idx := mappingIndex(image, "registries"); idx >= 0 {
+		image.Content = append(image.Content[:idx], image.Content[idx+2:]...)
+	}
+	registry := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	registry.Content = append(registry.Content, scalarNode("name"), scalarNode("default"), scalarNode("endpoint"), scalarNode(endpoint))
+	if username := os.Getenv(envRegistryUsername); username != "" {
+		registry.Content = append(registry.Content, scalarNode("username"), scalarNode(username))
+	}
+	if password := os.Getenv(envRegistryPassword); password != "" {
+		registry.Content = append(registry.Content, scalarNode("password"), scalarNode(password))
+	}
+	image.Content = append(image.Content, scalarNode("registries"),
+		&yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{registry}})
+	return []string{fmt.Sprintf("add registry %s of environment variable %s to image.registries", endpoint, envRegistryEndpoint)}, nil
+}
+
+// migrateDomainRouteTLSProtocol converts ports created by former KusciaAPI, which stored HTTPS and GRPCS or lower case
+// protocols, into the protocols gateway supports.
+func migrateDomainRouteTLSProtocol(ctx context.Context, client dynamic.Interface, dryRun bool) ([]string, error) {
+	var changes []string
+	for _, resource := range []string{"clusterdomainroutes", "domainroutes"} {
+		gvr := v1alpha1.SchemeGroupVersion.WithResource(resource)
+		list, err := client.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("list %s failed, %v", resource, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			converted, err := convertPortProtocols(obj)
+			if err != nil {
+				return nil, fmt.Errorf("convert ports of %s %s failed, %v", resource, objectKey(obj), err)
+			}
+			if len(converted) == 0 {
+				continue
+			}
+			if !dryRun {
+				if err := updateObject(ctx, client, gvr, obj); err != nil {
+					return nil, err
+				}
+			}
+			changes = append(changes, fmt.Sprintf("convert protocol of %s %s ports %s", resource, objectKey(obj),
+				strings.Join(converted, ",")))
+		}
+	}
+	return changes, nil
+}
+
+// convertPortProtocols converts spec.endpoint.ports of obj in place and returns names of the converted ports.
+func convertPortProtocols(obj *unstructured.Unstructured) ([]string, error) {
+	ports, found, err := unstructured.NestedSlice(obj.Object, "spec", "endpoint", "ports")
+	if err != nil || !found {
+		return nil, err
+	}
+	var converted []string
+	for _, p := range ports {
+		port, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		protocol, _ := port["protocol"].(string)
+		upper := strings.ToUpper(protocol)
+		if upper == "HTTPS" || upper == "GRPCS" {
+			upper = strings.TrimSuffix(upper, "S")
+			port["isTLS"] = true
+		}
+		if upper == protocol {
+			continue
+		}
+		port["protocol"] = upper
+		converted = append(converted, fmt.Sprint(port["name"]))
+	}
+	if len(converted) == 0 {
+		return nil, nil
+	}
+	return converted, unstructured.SetNestedSlice(obj.Object, ports, "spec", "endpoint", "ports")
+}
+
+func updateObject(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+	if _, err := client.Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("update %s %s failed, %v", gvr.Resource, objectKey(obj), err)
+	}
+	return nil
+}
+
+func objectKey(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
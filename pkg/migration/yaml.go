@@ -0,0 +1,104 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RenameKey moves the value of key from to key to in config document, keys are dot separated paths like
+// agent.provider.runtime. It returns false if from doesn't exist, and fails if both keys exist.
+func RenameKey(doc *yaml.Node, from, to string) (bool, error) {
+	root := rootMapping(doc)
+	if root == nil {
+		return false, nil
+	}
+	fromPath, toPath := strings.Split(from, "."), strings.Split(to, ".")
+	parent := lookup(root, fromPath[:len(fromPath)-1])
+	idx := mappingIndex(parent, fromPath[len(fromPath)-1])
+	if idx < 0 {
+		return false, nil
+	}
+	if lookup(root, toPath) != nil {
+		return false, fmt.Errorf("both %s and %s are set, please remove %s", from, to, from)
+	}
+
+	key, value := parent.Content[idx], parent.Content[idx+1]
+	parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+	target := ensureMapping(root, toPath[:len(toPath)-1])
+	key.Value = toPath[len(toPath)-1]
+	target.Content = append(target.Content, key, value)
+	return true, nil
+}
+
+// rootMapping returns the top level mapping of config document, or nil if the document isn't a mapping.
+func rootMapping(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+	if doc.Kind != yaml.MappingNode {
+		return nil
+	}
+	return doc
+}
+
+// lookup returns the value at path in mapping m, or nil if it doesn't exist.
+func lookup(m *yaml.Node, path []string) *yaml.Node {
+	for _, key := range path {
+		idx := mappingIndex(m, key)
+		if idx < 0 {
+			return nil
+		}
+		m = m.Content[idx+1]
+	}
+	return m
+}
+
+// ensureMapping returns the mapping at path in mapping m, missing mappings are created.
+func ensureMapping(m *yaml.Node, path []string) *yaml.Node {
+	for _, key := range path {
+		idx := mappingIndex(m, key)
+		if idx < 0 {
+			m.Content = append(m.Content, scalarNode(key), &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+			idx = len(m.Content) - 2
+		}
+		m = m.Content[idx+1]
+		if m.Kind != yaml.MappingNode {
+			// a null value like "image:" is replaced by an empty mapping
+			*m = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+	}
+	return m
+}
+
+// mappingIndex returns the index of key node in mapping m, its value is at index+1. It returns -1 if key doesn't exist.
+func mappingIndex(m *yaml.Node, key string) int {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
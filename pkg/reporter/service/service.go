@@ -16,6 +16,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/secretflow/kuscia/pkg/common"
@@ -78,16 +79,36 @@ func (r *reportService) ReportProgress(ctx context.Context, request *reporter.Re
 				Message: "Task progress should be a valid percentage number"},
 		}
 	}
-	if request.Progress <= kt.Status.Progress {
+	// check request.CustomFields are valid metrics
+	if err := validateMetrics(request.CustomFields); err != nil {
+		nlog.Errorf("Invalid metrics report, taskID: %s, %v", taskID, err)
+		return &reporter.CommonReportResponse{
+			Status: &pbv1alpha.Status{
+				Code:    int32(pberrorcode.ErrorCode_ReporterErrRequestInvalidate),
+				Message: err.Error()},
+		}
+	}
+	metrics, metricsChanged := mergeMetrics(kt.Status.Metrics, request.CustomFields)
+	if len(metrics) > maxMetrics {
+		return &reporter.CommonReportResponse{
+			Status: &pbv1alpha.Status{
+				Code:    int32(pberrorcode.ErrorCode_ReporterErrRequestInvalidate),
+				Message: fmt.Sprintf("Task metrics should not exceed %d keys", maxMetrics)},
+		}
+	}
+	if request.Progress <= kt.Status.Progress && !metricsChanged {
 		return &reporter.CommonReportResponse{
 			Status: &pbv1alpha.Status{
 				Code:    int32(pberrorcode.ErrorCode_SUCCESS),
 				Message: "ignored"},
 		}
 	}
-	// update: cacheProgress is invalid or reqProgress > cacheProgress
+	// update: cacheProgress is invalid or reqProgress > cacheProgress, or metrics changed
 	newKT := kt.DeepCopy()
-	newKT.Status.Progress = request.Progress
+	if request.Progress > kt.Status.Progress {
+		newKT.Status.Progress = request.Progress
+	}
+	newKT.Status.Metrics = metrics
 
 	if kt, err := r.kusciaClient.KusciaV1alpha1().KusciaTasks(common.KusciaCrossDomain).UpdateStatus(ctx, newKT, metav1.UpdateOptions{}); err != nil {
 		nlog.Infof("Failed to update Kuscia Task Status Progress %v %e", kt, err)
@@ -105,6 +126,61 @@ func (r *reportService) ReportProgress(ctx context.Context, request *reporter.Re
 	}
 }
 
+const (
+	// maxMetrics is the max number of metrics kept in the status of a task.
+	maxMetrics = 32
+	// maxMetricKeyLength is the max length of a metric key.
+	maxMetricKeyLength = 63
+	// maxMetricValueLength is the max length of a metric value.
+	maxMetricValueLength = 256
+)
+
+func validateMetrics(metrics map[string]string) error {
+	if len(metrics) > maxMetrics {
+		return fmt.Errorf("Task metrics should not exceed %d keys", maxMetrics)
+	}
+	for k, v := range metrics {
+		if k == "" || len(k) > maxMetricKeyLength {
+			return fmt.Errorf("Task metric key %q should be 1-%d characters", k, maxMetricKeyLength)
+		}
+		if len(v) > maxMetricValueLength {
+			return fmt.Errorf("Task metric %q value should not exceed %d characters", k, maxMetricValueLength)
+		}
+	}
+	return nil
+}
+
+// mergeMetrics merges the reported metrics into the current ones, a reported metric with empty value removes the
+// metric. It returns the merged metrics and whether they are different from the current ones.
+func mergeMetrics(current, reported map[string]string) (map[string]string, bool) {
+	if len(reported) == 0 {
+		return current, false
+	}
+	merged := make(map[string]string, len(current)+len(reported))
+	for k, v := range current {
+		merged[k] = v
+	}
+	changed := false
+	for k, v := range reported {
+		old, ok := merged[k]
+		if v == "" {
+			if ok {
+				delete(merged, k)
+				changed = true
+			}
+			continue
+		}
+		if !ok || old != v {
+			merged[k] = v
+			changed = true
+		}
+	}
+	if len(merged) == 0 {
+		merged = nil
+	}
+	return merged, changed
+}
+
 type IHealthService interface {
 	HealthCheck(ctx context.Context, request *reporter.HealthRequest) *reporter.CommonReportResponse
 }
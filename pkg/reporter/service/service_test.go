@@ -17,6 +17,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/secretflow/kuscia/pkg/common"
@@ -159,6 +160,67 @@ func TestReportService_TaskBadProgress(t *testing.T) {
 	assert.Equal(t, resp.Status.Message, "ignored")
 }
 
+func TestReportService_Metrics(t *testing.T) {
+
+	kubeClient := kubefake.NewSimpleClientset()
+	kusciaClient := kusciafake.NewSimpleClientset()
+
+	taskID := "mockTask1"
+	task := &v1alpha1.KusciaTask{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      taskID,
+			Namespace: common.KusciaCrossDomain,
+		},
+	}
+	kusciaClient.KusciaV1alpha1().KusciaTasks(common.KusciaCrossDomain).Create(context.Background(), task, v1.CreateOptions{})
+
+	// prepare reporter service
+	reporterConfig := config.NewDefaultReporterConfig("")
+	reporterConfig.KubeClient = kubeClient
+	reporterConfig.KusciaClient = kusciaClient
+	rs := NewReportService(reporterConfig)
+	resp := rs.ReportProgress(context.Background(),
+		&reporter.ReportProgressRequest{Progress: 0.5, CustomFields: map[string]string{"epoch": "1", "loss": "0.7"}},
+		taskID,
+	)
+	assert.Equal(t, resp.Status.Code, int32(pberrorcode.ErrorCode_SUCCESS))
+
+	// progress is not increased but metrics are updated
+	resp = rs.ReportProgress(context.Background(),
+		&reporter.ReportProgressRequest{Progress: 0.4, CustomFields: map[string]string{"epoch": "2", "loss": ""}},
+		taskID,
+	)
+	assert.Equal(t, resp.Status.Code, int32(pberrorcode.ErrorCode_SUCCESS))
+	assert.Equal(t, resp.Status.Message, "success")
+	kt, err := kusciaClient.KusciaV1alpha1().KusciaTasks(common.KusciaCrossDomain).Get(context.Background(), taskID, v1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, kt.Status.Progress, float32(0.5))
+	assert.DeepEqual(t, kt.Status.Metrics, map[string]string{"epoch": "2"})
+
+	// same metrics are ignored
+	resp = rs.ReportProgress(context.Background(),
+		&reporter.ReportProgressRequest{Progress: 0.5, CustomFields: map[string]string{"epoch": "2"}},
+		taskID,
+	)
+	assert.Equal(t, resp.Status.Message, "ignored")
+
+	resp = rs.ReportProgress(context.Background(),
+		&reporter.ReportProgressRequest{Progress: 0.6, CustomFields: map[string]string{"": "1"}},
+		taskID,
+	)
+	assert.Equal(t, resp.Status.Code, int32(pberrorcode.ErrorCode_ReporterErrRequestInvalidate))
+
+	tooMany := map[string]string{}
+	for i := 0; i < maxMetrics; i++ {
+		tooMany[fmt.Sprintf("m%d", i)] = "1"
+	}
+	resp = rs.ReportProgress(context.Background(),
+		&reporter.ReportProgressRequest{Progress: 0.6, CustomFields: tooMany},
+		taskID,
+	)
+	assert.Equal(t, resp.Status.Code, int32(pberrorcode.ErrorCode_ReporterErrRequestInvalidate))
+}
+
 func TestReportService_Health(t *testing.T) {
 
 	kubeClient := kubefake.NewSimpleClientset()
@@ -45,6 +45,8 @@ import (
 const (
 	// defaultWaitTime is 60s if ResourceReservedSeconds is not specified.
 	defaultWaitTime = 30 * time.Second
+	// DefaultGangSchedulingTimeout is used if GangSchedulingTimeoutSeconds is not specified.
+	DefaultGangSchedulingTimeout = 120 * time.Second
 
 	retryInterval      = 200 * time.Millisecond
 	checkRetryInterval = 500 * time.Millisecond
@@ -76,6 +78,7 @@ type Manager interface {
 	CalculateAssignedPods(*kusciaapisv1alpha1.TaskResource, *corev1.Pod) int
 	ActivateSiblings(*corev1.Pod, *framework.CycleState)
 	GetTaskResource(*corev1.Pod) (string, *kusciaapisv1alpha1.TaskResource, bool)
+	FailTimeoutTaskResource(*kusciaapisv1alpha1.TaskResource, *corev1.Pod, time.Duration, string) (string, bool)
 }
 
 // TaskResourceManager defines the scheduling operation called.
@@ -265,6 +268,41 @@ func (trMgr *TaskResourceManager) Unreserve(ctx context.Context, tr *kusciaapisv
 	}()
 }
 
+// FailTimeoutTaskResource patches the task resource to failed if its pods can't be scheduled within timeout since it
+// began reserving. The failed task resource makes the pods waiting in Permit and PreBind release their reserved
+// resources, and the task resource group controller retries or fails the task resource group. It returns the failure
+// message and whether the task resource is timeout.
+func (trMgr *TaskResourceManager) FailTimeoutTaskResource(tr *kusciaapisv1alpha1.TaskResource, pod *corev1.Pod, timeout time.Duration, unschedulableReason string) (string, bool) {
+	if tr.Status.Phase != kusciaapisv1alpha1.TaskResourcePhaseReserving {
+		return "", false
+	}
+
+	if timeout <= 0 {
+		timeout = DefaultGangSchedulingTimeout
+	}
+	startTime := tr.CreationTimestamp.Time
+	if tr.Status.LastTransitionTime != nil {
+		startTime = tr.Status.LastTransitionTime.Time
+	}
+	if time.Since(startTime) < timeout {
+		return "", false
+	}
+
+	msg := fmt.Sprintf("gang scheduling of task resource %v/%v timed out after %v, pod %v/%v can't be scheduled",
+		tr.Namespace, tr.Name, timeout, pod.Namespace, pod.Name)
+	if unschedulableReason != "" {
+		msg = fmt.Sprintf("%s: %s", msg, unschedulableReason)
+	}
+	if siblingInfo := trMgr.buildSiblingStatusInfo(tr); siblingInfo != "" {
+		msg = fmt.Sprintf("%s, %s", msg, siblingInfo)
+	}
+
+	if err := trMgr.patchTaskResource(kusciaapisv1alpha1.TaskResourcePhaseFailed, kusciaapisv1alpha1.TaskResourceCondFailed, msg, tr); err != nil {
+		nlog.Warnf("Failed to patch timeout task resource %v/%v to failed, %v", tr.Namespace, tr.Name, err)
+	}
+	return msg, true
+}
+
 // Permit permits a pod to run, if the minReservedPods match, it would send a signal to chan.
 func (trMgr *TaskResourceManager) Permit(ctx context.Context, pod *corev1.Pod) Status {
 	_, tr, exist := trMgr.GetTaskResource(pod)
@@ -18,6 +18,7 @@ package core
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -564,3 +565,82 @@ func TestGetScheduledPodsCount(t *testing.T) {
 		})
 	}
 }
+
+func TestFailTimeoutTaskResource(t *testing.T) {
+	creationTime := time.Now().Add(-time.Minute)
+	reservingTr := util.MakeTaskResource("ns1", "tr", 2, &creationTime)
+	reservingTr.Status.Phase = kusciaapisv1alpha1.TaskResourcePhaseReserving
+	reservedTr := reservingTr.DeepCopy()
+	reservedTr.Name = "tr-reserved"
+	reservedTr.Status.Phase = kusciaapisv1alpha1.TaskResourcePhaseReserved
+	retriedTr := reservingTr.DeepCopy()
+	retriedTr.Name = "tr-retried"
+	retriedTr.Status.LastTransitionTime = &metav1.Time{Time: time.Now()}
+
+	ctx := context.Background()
+	cs := kusciaclientsetfake.NewSimpleClientset(reservingTr, reservedTr, retriedTr)
+	trInformerFactory := kusciainformers.NewSharedInformerFactory(cs, 0)
+	trInformer := trInformerFactory.Kuscia().V1alpha1().TaskResources()
+	fakeClient := clientsetfake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	podInformer := informerFactory.Core().V1().Pods()
+	nsInformer := informerFactory.Core().V1().Namespaces()
+
+	timeout := 10 * time.Second
+	trMgr := NewTaskResourceManager(cs, nil, trInformer, podInformer, nsInformer, &timeout)
+	pod := st.MakePod().Name("pod1").Namespace("ns1").UID("pod1").Obj()
+
+	tests := []struct {
+		name            string
+		tr              *kusciaapisv1alpha1.TaskResource
+		timeout         time.Duration
+		expectedTimeout bool
+	}{
+		{
+			name:            "reserving task resource is timeout",
+			tr:              reservingTr,
+			timeout:         30 * time.Second,
+			expectedTimeout: true,
+		},
+		{
+			name:            "reserving task resource is not timeout",
+			tr:              reservingTr,
+			timeout:         2 * time.Minute,
+			expectedTimeout: false,
+		},
+		{
+			name:            "reserved task resource is never timeout",
+			tr:              reservedTr,
+			timeout:         30 * time.Second,
+			expectedTimeout: false,
+		},
+		{
+			name:            "retried task resource is timed from last transition",
+			tr:              retriedTr,
+			timeout:         30 * time.Second,
+			expectedTimeout: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, isTimeout := trMgr.FailTimeoutTaskResource(tt.tr, pod, tt.timeout, "1 Insufficient cpu")
+			if isTimeout != tt.expectedTimeout {
+				t.Fatalf("expected timeout %v, got %v", tt.expectedTimeout, isTimeout)
+			}
+			if !isTimeout {
+				return
+			}
+			if !strings.Contains(msg, "1 Insufficient cpu") {
+				t.Errorf("expected message contains unschedulable reason, got %v", msg)
+			}
+			latestTr, err := cs.KusciaV1alpha1().TaskResources(tt.tr.Namespace).Get(ctx, tt.tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if latestTr.Status.Phase != kusciaapisv1alpha1.TaskResourcePhaseFailed {
+				t.Errorf("expected phase %v, got %v", kusciaapisv1alpha1.TaskResourcePhaseFailed, latestTr.Status.Phase)
+			}
+		})
+	}
+}
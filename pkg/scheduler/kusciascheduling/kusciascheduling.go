@@ -20,17 +20,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
+	"github.com/secretflow/kuscia/pkg/common"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciaclientset "github.com/secretflow/kuscia/pkg/crd/clientset/versioned"
 	kusciainformer "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
 	"github.com/secretflow/kuscia/pkg/scheduler/kusciascheduling/core"
+	"github.com/secretflow/kuscia/pkg/scheduler/reservation"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
@@ -39,9 +44,13 @@ type KusciaScheduling struct {
 	frameworkHandler        framework.Handle
 	trMgr                   core.Manager
 	resourceReservedSeconds *time.Duration
+	gangSchedulingTimeout   time.Duration
+	reservations            *reservation.Ledger
+	dataDiskFreeThreshold   resource.Quantity
 }
 
 var _ framework.PreFilterPlugin = &KusciaScheduling{}
+var _ framework.FilterPlugin = &KusciaScheduling{}
 var _ framework.PostFilterPlugin = &KusciaScheduling{}
 var _ framework.ReservePlugin = &KusciaScheduling{}
 var _ framework.PermitPlugin = &KusciaScheduling{}
@@ -52,6 +61,9 @@ var _ framework.EnqueueExtensions = &KusciaScheduling{}
 const (
 	// Name is the name of the plugin used in Registry and configurations.
 	Name = "KusciaScheduling"
+
+	// defaultDataDiskFreeThreshold is used if DataDiskFreeThreshold is not specified.
+	defaultDataDiskFreeThreshold = "1Gi"
 )
 
 // New initializes and returns a new KusciaScheduling plugin.
@@ -62,7 +74,8 @@ func New(obj runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		return nil, err
 	}
 
-	nlog.Infof("%v plugin args: ResourceReservedSeconds=%d", Name, args.ResourceReservedSeconds)
+	nlog.Infof("%v plugin args: ResourceReservedSeconds=%d, GangSchedulingTimeoutSeconds=%d, DataDiskFreeThreshold=%s", Name,
+		args.ResourceReservedSeconds, args.GangSchedulingTimeoutSeconds, args.DataDiskFreeThreshold)
 
 	kubeConfig := *handle.KubeConfig()
 	kubeConfig.ContentType = "application/json"
@@ -77,12 +90,25 @@ func New(obj runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	if args != nil && args.ResourceReservedSeconds > 0 {
 		timeout = time.Duration(args.ResourceReservedSeconds)
 	}
+	gangSchedulingTimeout := core.DefaultGangSchedulingTimeout
+	if args != nil && args.GangSchedulingTimeoutSeconds > 0 {
+		gangSchedulingTimeout = time.Duration(args.GangSchedulingTimeoutSeconds) * time.Second
+	}
+	dataDiskFreeThreshold := resource.MustParse(defaultDataDiskFreeThreshold)
+	if args != nil && args.DataDiskFreeThreshold != "" {
+		if dataDiskFreeThreshold, err = resource.ParseQuantity(args.DataDiskFreeThreshold); err != nil {
+			return nil, fmt.Errorf("invalid dataDiskFreeThreshold %q, %v", args.DataDiskFreeThreshold, err)
+		}
+	}
 
 	trMgr := core.NewTaskResourceManager(kusciaClient, handle.SnapshotSharedLister(), trInformer, podInformer, nsInformer, &timeout)
 	ks := &KusciaScheduling{
 		frameworkHandler:        handle,
 		trMgr:                   trMgr,
 		resourceReservedSeconds: &timeout,
+		gangSchedulingTimeout:   gangSchedulingTimeout,
+		reservations:            reservation.Default(),
+		dataDiskFreeThreshold:   dataDiskFreeThreshold,
 	}
 
 	ctx := context.Background()
@@ -121,6 +147,7 @@ func (cs *KusciaScheduling) EventsToRegister() []framework.ClusterEvent {
 	// https://git.k8s.io/kubernetes/pkg/scheduler/eventhandlers.go#L403-L410
 	return []framework.ClusterEvent{
 		{Resource: framework.Pod, ActionType: framework.Add},
+		{Resource: framework.Node, ActionType: framework.UpdateNodeCondition | framework.UpdateNodeAllocatable},
 	}
 }
 
@@ -132,13 +159,77 @@ func (cs *KusciaScheduling) Name() string {
 // PreFilter performs the following validations.
 // 1. Whether the TaskResourceGroup that the Pod belongs to is on the deny list.
 // 2. Whether the total number of pods in a TaskResourceGroup is less than its `minReservedMember`.
+// 3. Whether the resources of the domain are reserved by other tasks.
 func (cs *KusciaScheduling) PreFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod) (*framework.PreFilterResult, *framework.Status) {
 	if err := cs.trMgr.PreFilter(ctx, pod); err != nil {
 		return nil, framework.NewStatus(framework.UnschedulableAndUnresolvable, err.Error())
 	}
+	if err := cs.checkReservation(pod); err != nil {
+		return nil, framework.NewStatus(framework.Unschedulable, err.Error())
+	}
 	return nil, framework.NewStatus(framework.Success, "")
 }
 
+// checkReservation keeps the resources reserved by other tasks from the pod, the pods of the task holding the
+// reservation are not limited.
+func (cs *KusciaScheduling) checkReservation(pod *v1.Pod) error {
+	if cs.reservations == nil {
+		return nil
+	}
+	reserved := cs.reservations.ReservedByOthers(pod.Namespace, pod.Annotations[common.TaskResourceGroupAnnotationKey])
+	if len(reserved) == 0 {
+		return nil
+	}
+
+	nodeInfos, err := cs.frameworkHandler.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return err
+	}
+	var nodes []*v1.Node
+	var pods []*v1.Pod
+	for _, nodeInfo := range nodeInfos {
+		if nodeInfo.Node() == nil {
+			continue
+		}
+		nodes = append(nodes, nodeInfo.Node())
+		for _, podInfo := range nodeInfo.Pods {
+			pods = append(pods, podInfo.Pod)
+		}
+	}
+	free, ok := reservation.FreeResources(pod.Namespace, nodes, pods)
+	if !ok {
+		return nil
+	}
+	if insufficient := reservation.Insufficient(reservation.PodRequests(pod), reservation.Subtract(free, reserved)); len(insufficient) > 0 {
+		return fmt.Errorf("insufficient %s of domain %s, resources are reserved by other tasks", strings.Join(insufficient, ","), pod.Namespace)
+	}
+	return nil
+}
+
+// Filter rejects nodes whose data disk is under pressure or has less free space than the threshold, so tasks are not
+// scheduled to nodes where they would fail for the disk full in running.
+func (cs *KusciaScheduling) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeDiskPressure && cond.Status == v1.ConditionTrue {
+			return framework.NewStatus(framework.UnschedulableAndUnresolvable, "node(s) had data disk pressure")
+		}
+	}
+
+	if cs.dataDiskFreeThreshold.IsZero() {
+		return nil
+	}
+	if free, ok := node.Status.Allocatable[common.ResourceDataDisk]; ok && free.Cmp(cs.dataDiskFreeThreshold) < 0 {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable,
+			fmt.Sprintf("node(s) had data disk free space less than %s", cs.dataDiskFreeThreshold.String()))
+	}
+	return nil
+}
+
 // PostFilter is used to reject a group of pods if a pod does not pass PreFilter or Filter.
 func (cs *KusciaScheduling) PostFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod,
 	filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
@@ -160,20 +251,53 @@ func (cs *KusciaScheduling) PostFilter(ctx context.Context, state *framework.Cyc
 		return &framework.PostFilterResult{}, framework.NewStatus(framework.Success)
 	}
 
+	// If the whole party set can't be scheduled in time, fail the task resource, so the pods of other parties don't
+	// hold resources forever, and the task resource group could retry or fail.
+	rejectMsg := "optimistic rejection in PostFilter"
+	msg, timeout := cs.trMgr.FailTimeoutTaskResource(tr, pod, cs.gangSchedulingTimeout, unschedulableReason(filteredNodeStatusMap))
+	if timeout {
+		nlog.Warnf("PostFilter: %s", msg)
+		cs.frameworkHandler.EventRecorder().Eventf(pod, nil, v1.EventTypeWarning, "GangSchedulingTimeout", "Scheduling", msg)
+		rejectMsg = msg
+	}
+
 	// It's based on an implicit assumption: if the nth Pod failed,
 	// it's inferrable other Pods belonging to the same TaskResource would be very likely to fail.
 	cs.frameworkHandler.IterateOverWaitingPods(func(waitingPod framework.WaitingPod) {
 		trName, _ := core.GetTaskResourceName(waitingPod.GetPod())
 		if trName == tr.Name && waitingPod.GetPod().Namespace == pod.Namespace {
 			nlog.Infof("PostFilter rejects the waiting pod %s/%s under task resource %v", pod.Namespace, pod.Name, tr.Name)
-			waitingPod.Reject(cs.Name(), "optimistic rejection in PostFilter")
+			waitingPod.Reject(cs.Name(), rejectMsg)
 		}
 	})
 
+	if timeout {
+		return &framework.PostFilterResult{}, framework.NewStatus(framework.Unschedulable, msg)
+	}
 	return &framework.PostFilterResult{}, framework.NewStatus(framework.Unschedulable,
 		fmt.Sprintf("reject the pod %v even after PostFilter", pod.Name))
 }
 
+// unschedulableReason summarizes the reasons why nodes are filtered, e.g. "2 Insufficient cpu, 1 node(s) had taint".
+func unschedulableReason(filteredNodeStatusMap framework.NodeToStatusMap) string {
+	reasonCount := map[string]int{}
+	for _, status := range filteredNodeStatusMap {
+		if status == nil || status.IsSuccess() {
+			continue
+		}
+		for _, reason := range status.Reasons() {
+			reasonCount[reason]++
+		}
+	}
+
+	reasons := make([]string, 0, len(reasonCount))
+	for reason, count := range reasonCount {
+		reasons = append(reasons, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(reasons)
+	return strings.Join(reasons, ", ")
+}
+
 // PreFilterExtensions returns a PreFilterExtensions interface if the plugin implements one.
 func (cs *KusciaScheduling) PreFilterExtensions() framework.PreFilterExtensions {
 	return nil
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
@@ -33,6 +34,7 @@ import (
 	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
 	st "k8s.io/kubernetes/pkg/scheduler/testing"
 
+	"github.com/secretflow/kuscia/pkg/common"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 	kusciaclientsetfake "github.com/secretflow/kuscia/pkg/crd/clientset/versioned/fake"
 	kusciainformers "github.com/secretflow/kuscia/pkg/crd/informers/externalversions"
@@ -566,3 +568,64 @@ func TestUnreserve(t *testing.T) {
 		})
 	}
 }
+
+func TestUnschedulableReason(t *testing.T) {
+	nodeStatusMap := framework.NodeToStatusMap{
+		"node1": framework.NewStatus(framework.Unschedulable, "Insufficient cpu"),
+		"node2": framework.NewStatus(framework.Unschedulable, "Insufficient cpu", "Insufficient memory"),
+		"node3": framework.NewStatus(framework.Success, ""),
+	}
+	got := unschedulableReason(nodeStatusMap)
+	want := "1 Insufficient memory, 2 Insufficient cpu"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	cs := &KusciaScheduling{dataDiskFreeThreshold: resource.MustParse("1Gi")}
+	pod := st.MakePod().Name("pod1").Namespace("ns1").Obj()
+
+	tests := []struct {
+		name         string
+		node         *corev1.Node
+		expectedCode framework.Code
+	}{
+		{
+			name:         "node has enough free data disk",
+			node:         st.MakeNode().Name("node1").Capacity(map[corev1.ResourceName]string{common.ResourceDataDisk: "10Gi"}).Obj(),
+			expectedCode: framework.Success,
+		},
+		{
+			name:         "node has less free data disk than threshold",
+			node:         st.MakeNode().Name("node1").Capacity(map[corev1.ResourceName]string{common.ResourceDataDisk: "512Mi"}).Obj(),
+			expectedCode: framework.UnschedulableAndUnresolvable,
+		},
+		{
+			name:         "node does not report data disk",
+			node:         st.MakeNode().Name("node1").Obj(),
+			expectedCode: framework.Success,
+		},
+		{
+			name: "node has disk pressure",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+				}},
+			},
+			expectedCode: framework.UnschedulableAndUnresolvable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(tt.node)
+			status := cs.Filter(context.Background(), framework.NewCycleState(), pod, nodeInfo)
+			if status.Code() != tt.expectedCode {
+				t.Errorf("expected %v, got %v", tt.expectedCode, status.Code())
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reservation reserves resources of domains for tasks whose pods are not created yet. The task controller
+// reserves resources for all local parties of a task at once before creating pods, and the kuscia scheduler keeps the
+// reserved resources from pods of other tasks, until the reservation is released or expired.
+package reservation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+// DefaultWindow is how long a reservation is kept if it isn't released.
+const DefaultWindow = 60 * time.Second
+
+// FreeFunc returns the free resources of a namespace, ok is false if the namespace has no nodes known, in which case
+// the namespace isn't reserved.
+type FreeFunc func(namespace string) (free corev1.ResourceList, ok bool)
+
+type reservation struct {
+	requests   map[string]corev1.ResourceList
+	expireTime time.Time
+}
+
+// Ledger records resources reserved by owners, the owner is the name of kuscia task.
+type Ledger struct {
+	mu           sync.Mutex
+	reservations map[string]*reservation
+	now          func() time.Time
+}
+
+func NewLedger() *Ledger {
+	return &Ledger{
+		reservations: map[string]*reservation{},
+		now:          time.Now,
+	}
+}
+
+var defaultLedger = NewLedger()
+
+// Default returns the ledger shared by the task controller and the scheduler in the same process.
+func Default() *Ledger {
+	return defaultLedger
+}
+
+// Reserve reserves requests of all namespaces for owner. It is all-or-nothing: if any namespace doesn't have enough
+// free resources besides those reserved by others, nothing is reserved. Reserving again renews the reservation.
+func (l *Ledger) Reserve(owner string, requests map[string]corev1.ResourceList, free FreeFunc, window time.Duration) error {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+
+	reserved := map[string]corev1.ResourceList{}
+	namespaces := make([]string, 0, len(requests))
+	for ns := range requests {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		nsFree, ok := free(ns)
+		if !ok {
+			continue
+		}
+		available := Subtract(nsFree, l.reservedByOthers(ns, owner))
+		if insufficient := Insufficient(requests[ns], available); len(insufficient) > 0 {
+			return fmt.Errorf("domain %s has insufficient %s to reserve", ns, strings.Join(insufficient, ","))
+		}
+		reserved[ns] = requests[ns].DeepCopy()
+	}
+
+	l.reservations[owner] = &reservation{requests: reserved, expireTime: l.now().Add(window)}
+	return nil
+}
+
+// Release releases resources reserved by owner.
+func (l *Ledger) Release(owner string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.reservations, owner)
+}
+
+// ReservedByOthers returns resources of namespace reserved by owners except owner.
+func (l *Ledger) ReservedByOthers(namespace, owner string) corev1.ResourceList {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	return l.reservedByOthers(namespace, owner)
+}
+
+func (l *Ledger) reservedByOthers(namespace, owner string) corev1.ResourceList {
+	reserved := corev1.ResourceList{}
+	for o, r := range l.reservations {
+		if o == owner {
+			continue
+		}
+		Add(reserved, r.requests[namespace])
+	}
+	return reserved
+}
+
+func (l *Ledger) expire() {
+	now := l.now()
+	for owner, r := range l.reservations {
+		if now.After(r.expireTime) {
+			delete(l.reservations, owner)
+		}
+	}
+}
+
+// ContainerRequests returns requests of a container, the limit is used if the request of a resource isn't set, the
+// same as kubernetes defaulting.
+func ContainerRequests(resources corev1.ResourceRequirements) corev1.ResourceList {
+	requests := resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = corev1.ResourceList{}
+	}
+	for name, limit := range resources.Limits {
+		if _, ok := requests[name]; !ok {
+			requests[name] = limit.DeepCopy()
+		}
+	}
+	return requests
+}
+
+// PodRequests returns the sum of requests of containers in pod.
+func PodRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		Add(requests, ContainerRequests(c.Resources))
+	}
+	return requests
+}
+
+// FreeResources returns the allocatable of nodes in namespace minus requests of the pods running on them. ok is false if
+// there is no node in namespace.
+func FreeResources(namespace string, nodes []*corev1.Node, pods []*corev1.Pod) (corev1.ResourceList, bool) {
+	free := corev1.ResourceList{}
+	nodeNames := map[string]bool{}
+	for _, node := range nodes {
+		if node.Labels[common.LabelNodeNamespace] != namespace || node.Spec.Unschedulable {
+			continue
+		}
+		nodeNames[node.Name] = true
+		Add(free, node.Status.Allocatable)
+	}
+	if len(nodeNames) == 0 {
+		return nil, false
+	}
+
+	for _, pod := range pods {
+		if !nodeNames[pod.Spec.NodeName] || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		free = Subtract(free, PodRequests(pod))
+	}
+	return free, true
+}
+
+// Subtract returns a minus b.
+func Subtract(a, b corev1.ResourceList) corev1.ResourceList {
+	result := a.DeepCopy()
+	if result == nil {
+		result = corev1.ResourceList{}
+	}
+	for name, quantity := range b {
+		q := result[name]
+		q.Sub(quantity)
+		result[name] = q
+	}
+	return result
+}
+
+// Insufficient returns the names of resources whose requests are more than available.
+func Insufficient(requests, available corev1.ResourceList) []string {
+	var names []string
+	for name, quantity := range requests {
+		if quantity.IsZero() {
+			continue
+		}
+		if q := available[name]; quantity.Cmp(q) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add adds other to list.
+func Add(list, other corev1.ResourceList) {
+	for name, quantity := range other {
+		q := list[name]
+		q.Add(quantity)
+		list[name] = q
+	}
+}
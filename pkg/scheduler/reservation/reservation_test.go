@@ -0,0 +1,105 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func resourceList(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestLedgerReserve(t *testing.T) {
+	free := func(namespace string) (corev1.ResourceList, bool) {
+		if namespace == "alice" || namespace == "bob" {
+			return resourceList("4", "8Gi"), true
+		}
+		return nil, false
+	}
+
+	now := time.Now()
+	l := NewLedger()
+	l.now = func() time.Time { return now }
+
+	assert.NoError(t, l.Reserve("task-1", map[string]corev1.ResourceList{
+		"alice": resourceList("3", "4Gi"),
+		"bob":   resourceList("1", "1Gi"),
+		"carol": resourceList("100", "100Gi"),
+	}, free, time.Minute))
+	assert.True(t, resourceList("3", "4Gi")[corev1.ResourceCPU].Equal(l.ReservedByOthers("alice", "task-2")[corev1.ResourceCPU]))
+	assert.Empty(t, l.ReservedByOthers("alice", "task-1"))
+
+	// all-or-nothing, bob has enough cpu but alice hasn't
+	err := l.Reserve("task-2", map[string]corev1.ResourceList{
+		"alice": resourceList("2", "1Gi"),
+		"bob":   resourceList("1", "1Gi"),
+	}, free, time.Minute)
+	assert.ErrorContains(t, err, "domain alice has insufficient cpu")
+	assert.True(t, resourceList("1", "1Gi")[corev1.ResourceCPU].Equal(l.ReservedByOthers("bob", "task-3")[corev1.ResourceCPU]))
+
+	// reserving again renews the reservation of the owner itself
+	assert.NoError(t, l.Reserve("task-1", map[string]corev1.ResourceList{"alice": resourceList("4", "8Gi")}, free, time.Minute))
+
+	l.Release("task-1")
+	assert.NoError(t, l.Reserve("task-2", map[string]corev1.ResourceList{"alice": resourceList("2", "1Gi")}, free, time.Minute))
+
+	now = now.Add(2 * time.Minute)
+	assert.Empty(t, l.ReservedByOthers("alice", "task-3"))
+}
+
+func TestFreeResources(t *testing.T) {
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{common.LabelNodeNamespace: "alice"}},
+			Status:     corev1.NodeStatus{Allocatable: resourceList("4", "8Gi")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{common.LabelNodeNamespace: "bob"}},
+			Status:     corev1.NodeStatus{Allocatable: resourceList("4", "8Gi")},
+		},
+	}
+	pods := []*corev1.Pod{
+		{
+			Spec: corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: resourceList("1", "1Gi")}},
+				{Resources: corev1.ResourceRequirements{Limits: resourceList("500m", "1Gi")}},
+			}},
+		},
+		{
+			Spec:   corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: resourceList("1", "1Gi")}}}},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
+
+	free, ok := FreeResources("alice", nodes, pods)
+	assert.True(t, ok)
+	assert.Equal(t, "2500m", free.Cpu().String())
+	assert.Equal(t, "6Gi", free.Memory().String())
+
+	_, ok = FreeResources("carol", nodes, pods)
+	assert.False(t, ok)
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sdk is the Go client of KusciaAPI and DataMesh. It wraps the grpc services with token and mTLS auth, retries
+// on transient failures, and provides helpers for batch queries and watch streams.
+package sdk
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"github.com/secretflow/kuscia/pkg/utils/network"
+	tlsutils "github.com/secretflow/kuscia/pkg/utils/tls"
+	"github.com/secretflow/kuscia/pkg/web/interceptor"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/datamesh"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+// Config is the connection config of a client.
+type Config struct {
+	// Endpoint is the grpc address, e.g. 127.0.0.1:8083 for KusciaAPI and 127.0.0.1:8071 for DataMesh.
+	Endpoint string
+	// Token is sent in Token header of every request. TokenFile is read if Token is empty, the content is used as is
+	// like the server does.
+	Token     string
+	TokenFile string
+	// CAFile, CertFile and KeyFile enable mTLS, plaintext is used if all of them are empty.
+	CAFile   string
+	CertFile string
+	KeyFile  string
+	// TLSConfig takes precedence over the tls files.
+	TLSConfig *tls.Config
+	// Retry is the retry policy of unary calls, DefaultRetryPolicy is used if it's nil.
+	Retry *RetryPolicy
+	// DialOptions are appended to the options built from the config.
+	DialOptions []grpc.DialOption
+}
+
+// KusciaAPIClient is the client of all KusciaAPI services.
+type KusciaAPIClient struct {
+	conn  *grpc.ClientConn
+	retry RetryPolicy
+
+	AppImage         kusciaapi.AppImageServiceClient
+	Certificate      kusciaapi.CertificateServiceClient
+	Config           kusciaapi.ConfigServiceClient
+	Domain           kusciaapi.DomainServiceClient
+	DomainRoute      kusciaapi.DomainRouteServiceClient
+	DomainData       kusciaapi.DomainDataServiceClient
+	DomainDataGrant  kusciaapi.DomainDataGrantServiceClient
+	DomainDataSource kusciaapi.DomainDataSourceServiceClient
+	Health           kusciaapi.HealthServiceClient
+	Job              kusciaapi.JobServiceClient
+	Log              kusciaapi.LogServiceClient
+	Serving          kusciaapi.ServingServiceClient
+}
+
+// NewKusciaAPIClient connects to KusciaAPI. The connection is established lazily, so it doesn't fail if the server
+// isn't ready yet.
+func NewKusciaAPIClient(config *Config) (*KusciaAPIClient, error) {
+	conn, retry, err := dial(config)
+	if err != nil {
+		return nil, err
+	}
+	return &KusciaAPIClient{
+		conn:             conn,
+		retry:            retry,
+		AppImage:         kusciaapi.NewAppImageServiceClient(conn),
+		Certificate:      kusciaapi.NewCertificateServiceClient(conn),
+		Config:           kusciaapi.NewConfigServiceClient(conn),
+		Domain:           kusciaapi.NewDomainServiceClient(conn),
+		DomainRoute:      kusciaapi.NewDomainRouteServiceClient(conn),
+		DomainData:       kusciaapi.NewDomainDataServiceClient(conn),
+		DomainDataGrant:  kusciaapi.NewDomainDataGrantServiceClient(conn),
+		DomainDataSource: kusciaapi.NewDomainDataSourceServiceClient(conn),
+		Health:           kusciaapi.NewHealthServiceClient(conn),
+		Job:              kusciaapi.NewJobServiceClient(conn),
+		Log:              kusciaapi.NewLogServiceClient(conn),
+		Serving:          kusciaapi.NewServingServiceClient(conn),
+	}, nil
+}
+
+// Close closes the connection.
+func (c *KusciaAPIClient) Close() error {
+	return c.conn.Close()
+}
+
+// DataMeshClient is the client of all DataMesh services.
+type DataMeshClient struct {
+	conn *grpc.ClientConn
+
+	DomainData       datamesh.DomainDataServiceClient
+	DomainDataGrant  datamesh.DomainDataGrantServiceClient
+	DomainDataSource datamesh.DomainDataSourceServiceClient
+}
+
+// NewDataMeshClient connects to DataMesh.
+func NewDataMeshClient(config *Config) (*DataMeshClient, error) {
+	conn, _, err := dial(config)
+	if err != nil {
+		return nil, err
+	}
+	return &DataMeshClient{
+		conn:             conn,
+		DomainData:       datamesh.NewDomainDataServiceClient(conn),
+		DomainDataGrant:  datamesh.NewDomainDataGrantServiceClient(conn),
+		DomainDataSource: datamesh.NewDomainDataSourceServiceClient(conn),
+	}, nil
+}
+
+// Close closes the connection.
+func (c *DataMeshClient) Close() error {
+	return c.conn.Close()
+}
+
+func dial(config *Config) (*grpc.ClientConn, RetryPolicy, error) {
+	if config == nil || config.Endpoint == "" {
+		return nil, RetryPolicy{}, fmt.Errorf("endpoint can't be empty")
+	}
+	retry := DefaultRetryPolicy
+	if config.Retry != nil {
+		retry = *config.Retry
+	}
+
+	tlsConfig := config.TLSConfig
+	if tlsConfig == nil && (config.CAFile != "" || config.CertFile != "" || config.KeyFile != "") {
+		var err error
+		if tlsConfig, err = tlsutils.BuildClientTLSConfigViaPath(config.CAFile, config.CertFile, config.KeyFile); err != nil {
+			return nil, retry, err
+		}
+	}
+	dialOpts := network.BuildGrpcOptions(tlsConfig)
+
+	unary := []grpc.UnaryClientInterceptor{retryUnaryInterceptor(retry)}
+	var stream []grpc.StreamClientInterceptor
+	token := config.Token
+	if token == "" && config.TokenFile != "" {
+		data, err := os.ReadFile(config.TokenFile)
+		if err != nil {
+			return nil, retry, fmt.Errorf("read token file failed, %v", err)
+		}
+		token = string(data)
+	}
+	if token != "" {
+		// the token interceptor is the innermost, so every retry carries the token once
+		unary = append(unary, interceptor.GrpcClientTokenInterceptor(token))
+		stream = append(stream, interceptor.GrpcClientStreamTokenInterceptor(token))
+	}
+	dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(unary...), grpc.WithChainStreamInterceptor(stream...))
+	dialOpts = append(dialOpts, config.DialOptions...)
+
+	conn, err := grpc.Dial(config.Endpoint, dialOpts...)
+	if err != nil {
+		return nil, retry, fmt.Errorf("dial %s failed, %v", config.Endpoint, err)
+	}
+	return conn, retry, nil
+}
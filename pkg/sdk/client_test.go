@@ -0,0 +1,173 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/secretflow/kuscia/pkg/web/interceptor"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+const testToken = "test-token"
+
+type fakeJobService struct {
+	kusciaapi.UnimplementedJobServiceServer
+	unavailable int
+	batches     [][]string
+	watches     int
+}
+
+func (s *fakeJobService) QueryJob(ctx context.Context, req *kusciaapi.QueryJobRequest) (*kusciaapi.QueryJobResponse, error) {
+	if s.unavailable > 0 {
+		s.unavailable--
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	if req.JobId == "" {
+		return &kusciaapi.QueryJobResponse{Status: &v1alpha1.Status{Code: 11100, Message: "job id is empty"}}, nil
+	}
+	return &kusciaapi.QueryJobResponse{Status: &v1alpha1.Status{}, Data: &kusciaapi.QueryJobResponseData{JobId: req.JobId}}, nil
+}
+
+func (s *fakeJobService) BatchQueryJobStatus(ctx context.Context, req *kusciaapi.BatchQueryJobStatusRequest) (*kusciaapi.BatchQueryJobStatusResponse, error) {
+	s.batches = append(s.batches, req.JobIds)
+	resp := &kusciaapi.BatchQueryJobStatusResponse{Status: &v1alpha1.Status{}, Data: &kusciaapi.BatchQueryJobStatusResponseData{}}
+	for _, id := range req.JobIds {
+		resp.Data.Jobs = append(resp.Data.Jobs, &kusciaapi.JobStatus{JobId: id})
+	}
+	return resp, nil
+}
+
+func (s *fakeJobService) WatchJob(req *kusciaapi.WatchJobRequest, stream kusciaapi.JobService_WatchJobServer) error {
+	s.watches++
+	if err := stream.Send(&kusciaapi.WatchJobEventResponse{Type: kusciaapi.EventType_HEARTBEAT}); err != nil {
+		return err
+	}
+	return stream.Send(&kusciaapi.WatchJobEventResponse{
+		Type:   kusciaapi.EventType_MODIFIED,
+		Object: &kusciaapi.JobStatus{JobId: "job-" + string(rune('0'+s.watches))},
+	})
+}
+
+func startServer(t *testing.T, svc *fakeJobService) *KusciaAPIClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.GrpcServerTokenInterceptor(testToken)),
+		grpc.StreamInterceptor(interceptor.GrpcStreamServerTokenInterceptor(testToken)),
+	)
+	kusciaapi.RegisterJobServiceServer(server, svc)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	client, err := NewKusciaAPIClient(&Config{
+		Endpoint: lis.Addr().String(),
+		Token:    testToken,
+		Retry:    &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Codes: []codes.Code{codes.Unavailable}},
+	})
+	assert.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestNewKusciaAPIClient_EmptyEndpoint(t *testing.T) {
+	_, err := NewKusciaAPIClient(&Config{})
+	assert.Error(t, err)
+}
+
+func TestCallWithRetryAndStatus(t *testing.T) {
+	svc := &fakeJobService{unavailable: 2}
+	client := startServer(t, svc)
+	ctx := context.Background()
+
+	resp, err := Check(client.Job.QueryJob(ctx, &kusciaapi.QueryJobRequest{JobId: "job-1"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "job-1", resp.Data.JobId)
+
+	_, err = Check(client.Job.QueryJob(ctx, &kusciaapi.QueryJobRequest{}))
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, int32(11100), statusErr.Code)
+
+	svc.unavailable = 3
+	_, err = client.Job.QueryJob(ctx, &kusciaapi.QueryJobRequest{JobId: "job-1"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 0, svc.unavailable)
+}
+
+func TestCallWithWrongToken(t *testing.T) {
+	client := startServer(t, &fakeJobService{})
+	other, err := NewKusciaAPIClient(&Config{Endpoint: client.conn.Target(), Token: "wrong"})
+	assert.NoError(t, err)
+	defer other.Close()
+
+	_, err = other.Job.QueryJob(context.Background(), &kusciaapi.QueryJobRequest{JobId: "job-1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestJobStatusIterator(t *testing.T) {
+	svc := &fakeJobService{}
+	client := startServer(t, svc)
+
+	it := client.JobStatuses([]string{"a", "b", "c", "d", "e"}, 2)
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().JobId)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, ids)
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, svc.batches)
+}
+
+func TestWatchJobs(t *testing.T) {
+	svc := &fakeJobService{}
+	client := startServer(t, svc)
+
+	stop := errors.New("stop")
+	var ids []string
+	err := client.WatchJobs(context.Background(), 10, func(event *kusciaapi.WatchJobEventResponse) error {
+		assert.NotEqual(t, kusciaapi.EventType_HEARTBEAT, event.Type)
+		ids = append(ids, event.Object.JobId)
+		if len(ids) == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, []string{"job-1", "job-2"}, ids)
+	assert.Equal(t, 2, svc.watches)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, client.WatchJobs(ctx, 10, func(*kusciaapi.WatchJobEventResponse) error { return nil }))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 300*time.Millisecond, p.backoff(3))
+	assert.Equal(t, 300*time.Millisecond, p.backoff(10))
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+const defaultBatchSize = 100
+
+// JobStatusIterator queries the status of jobs batch by batch, so a large number of jobs doesn't end up in one huge
+// request. Use it like:
+//
+//	it := client.JobStatuses(jobIDs, 0)
+//	for it.Next(ctx) {
+//		status := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type JobStatusIterator struct {
+	client    *KusciaAPIClient
+	jobIDs    []string
+	batchSize int
+	batch     []*kusciaapi.JobStatus
+	current   *kusciaapi.JobStatus
+	err       error
+}
+
+// JobStatuses returns an iterator of the status of jobIDs, batchSize defaults to 100 if it's not positive.
+func (c *KusciaAPIClient) JobStatuses(jobIDs []string, batchSize int) *JobStatusIterator {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return &JobStatusIterator{client: c, jobIDs: jobIDs, batchSize: batchSize}
+}
+
+// Next moves to the next job status, it returns false when all jobs are iterated or an error occurs.
+func (it *JobStatusIterator) Next(ctx context.Context) bool {
+	for len(it.batch) == 0 {
+		if it.err != nil || len(it.jobIDs) == 0 {
+			it.current = nil
+			return false
+		}
+		n := it.batchSize
+		if n > len(it.jobIDs) {
+			n = len(it.jobIDs)
+		}
+		resp, err := Check(it.client.Job.BatchQueryJobStatus(ctx, &kusciaapi.BatchQueryJobStatusRequest{JobIds: it.jobIDs[:n]}))
+		if err != nil {
+			it.err = err
+			continue
+		}
+		it.jobIDs = it.jobIDs[n:]
+		it.batch = resp.GetData().GetJobs()
+	}
+	it.current, it.batch = it.batch[0], it.batch[1:]
+	return true
+}
+
+// Value returns the current job status.
+func (it *JobStatusIterator) Value() *kusciaapi.JobStatus {
+	return it.current
+}
+
+// Err returns the error stopped the iteration.
+func (it *JobStatusIterator) Err() error {
+	return it.err
+}
+
+// ForEachDomainData lists domain data of request and calls fn for each of them until fn returns an error.
+func (c *KusciaAPIClient) ForEachDomainData(ctx context.Context, request *kusciaapi.ListDomainDataRequest,
+	fn func(*kusciaapi.DomainData) error) error {
+	resp, err := Check(c.DomainData.ListDomainData(ctx, request))
+	if err != nil {
+		return err
+	}
+	for _, data := range resp.GetData().GetDomaindataList() {
+		if err := fn(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
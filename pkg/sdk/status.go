@@ -0,0 +1,49 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1"
+)
+
+// StatusError is the error reported in the status of a response.
+type StatusError struct {
+	Code    int32
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("code: %d, message: %s", e.Code, e.Message)
+}
+
+// Response is implemented by all responses of KusciaAPI and DataMesh.
+type Response interface {
+	GetStatus() *v1alpha1.Status
+}
+
+// Check turns a non-zero status of response into StatusError, so a call can be checked in one place, e.g.
+//
+//	resp, err := sdk.Check(client.Job.QueryJob(ctx, req))
+func Check[T Response](resp T, err error) (T, error) {
+	if err != nil {
+		return resp, err
+	}
+	if s := resp.GetStatus(); s != nil && s.Code != 0 {
+		return resp, &StatusError{Code: s.Code, Message: s.Message}
+	}
+	return resp, nil
+}
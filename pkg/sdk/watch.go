@@ -0,0 +1,87 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+// WatchJobs calls fn for every job event except heartbeats. The server closes the stream after timeoutSeconds, the
+// stream is re-established with the backoff of retry policy when it ends or fails, until ctx is done or fn returns an
+// error. The error of fn is returned as is, and nil is returned when ctx is done.
+func (c *KusciaAPIClient) WatchJobs(ctx context.Context, timeoutSeconds int64, fn func(*kusciaapi.WatchJobEventResponse) error) error {
+	attempt := 0
+	for {
+		received, err := c.watchJobsOnce(ctx, timeoutSeconds, fn)
+		var fnErr *watchHandlerError
+		if errors.As(err, &fnErr) {
+			return fnErr.err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if received {
+			attempt = 0
+		}
+		attempt++
+		if err != nil {
+			nlog.Warnf("Watch job failed, attempt %d, rewatch later, %v", attempt, err)
+		}
+		if sleep(ctx, c.retry.backoff(attempt)) != nil {
+			return nil
+		}
+	}
+}
+
+type watchHandlerError struct {
+	err error
+}
+
+func (e *watchHandlerError) Error() string {
+	return e.err.Error()
+}
+
+// watchJobsOnce consumes one stream, it reports whether any event is received, so the backoff restarts after a stream
+// works.
+func (c *KusciaAPIClient) watchJobsOnce(ctx context.Context, timeoutSeconds int64, fn func(*kusciaapi.WatchJobEventResponse) error) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.Job.WatchJob(ctx, &kusciaapi.WatchJobRequest{TimeoutSeconds: timeoutSeconds})
+	if err != nil {
+		return false, err
+	}
+	received := false
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return received, nil
+		}
+		if err != nil {
+			return received, err
+		}
+		received = true
+		if event.Type == kusciaapi.EventType_HEARTBEAT {
+			continue
+		}
+		if err := fn(event); err != nil {
+			return received, &watchHandlerError{err: err}
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package accounting aggregates resource consumption of task pods by KusciaJob and KusciaTask for billing.
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/ssexporter/netmetrics"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// metricRetention is how long a job is still exported as metrics after its usage stops changing.
+	metricRetention = time.Hour
+
+	serviceClusterPrefix = "service-"
+	statSentBytes        = "upstream_cx_rx_bytes_total"
+	statReceivedBytes    = "upstream_cx_tx_bytes_total"
+
+	directionSent     = "sent"
+	directionReceived = "received"
+
+	bytesPerGiB    = 1 << 30
+	secondsPerHour = 3600
+)
+
+type taskKey struct {
+	jobID  string
+	taskID string
+}
+
+type transferCounter struct {
+	sent, received float64
+}
+
+// Collector samples resources allocated to running task pods and bytes transferred through gateway for task services
+// of a domain, accumulates them into rollups per job and task, and exports the rollups as prometheus metrics.
+type Collector struct {
+	namespace  string
+	kubeClient kubernetes.Interface
+
+	mtx          sync.Mutex
+	usages       map[string]*JobUsage
+	lastTransfer map[string]transferCounter
+	lastSample   time.Time
+	loaded       bool
+
+	cpuDesc      *prometheus.Desc
+	memoryDesc   *prometheus.Desc
+	transferDesc *prometheus.Desc
+
+	// now and fetchEnvoyStats are replaced in test.
+	now             func() time.Time
+	fetchEnvoyStats func() (map[string]float64, error)
+}
+
+func NewCollector(namespace string, kubeClient kubernetes.Interface) *Collector {
+	labels := []string{"job_id", "task_id"}
+	return &Collector{
+		namespace:    namespace,
+		kubeClient:   kubeClient,
+		usages:       make(map[string]*JobUsage),
+		lastTransfer: make(map[string]transferCounter),
+		cpuDesc: prometheus.NewDesc("kuscia_job_cpu_seconds_total",
+			"Cpu cores allocated to task pods multiplied by running seconds", labels, nil),
+		memoryDesc: prometheus.NewDesc("kuscia_job_memory_gb_hours_total",
+			"Memory allocated to task pods in GiB multiplied by running hours", labels, nil),
+		transferDesc: prometheus.NewDesc("kuscia_job_transfer_bytes_total",
+			"Bytes transferred by task pods through gateway", append(labels, "direction"), nil),
+		now:             time.Now,
+		fetchEnvoyStats: getServiceTransferStats,
+	}
+}
+
+// Refresh samples the usage since last refresh and stores the updated rollups.
+func (c *Collector) Refresh(ctx context.Context) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+	if !c.loaded {
+		usages, err := LoadJobUsages(ctx, c.kubeClient, c.namespace)
+		if err != nil {
+			nlog.Warnf("Fail to load resource usage of domain %s, err: %v", c.namespace, err)
+			return
+		}
+		c.usages = usages
+		c.loaded = true
+		c.lastSample = now
+		// counters of envoy are accumulated before started, only take them as baseline
+		c.lastTransfer, _, _ = c.sampleTransfer(ctx)
+		return
+	}
+
+	elapsed := now.Sub(c.lastSample)
+	c.lastSample = now
+	changed := make(map[taskKey]*Usage)
+	if err := c.samplePods(ctx, elapsed, changed); err != nil {
+		nlog.Warnf("Fail to sample task pods of domain %s, err: %v", c.namespace, err)
+	}
+	if err := c.accumulateTransfer(ctx, changed); err != nil {
+		nlog.Warnf("Fail to sample transfer bytes of domain %s, err: %v", c.namespace, err)
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	for key, usage := range changed {
+		jobUsage, ok := c.usages[key.jobID]
+		if !ok {
+			jobUsage = NewJobUsage(key.jobID)
+			c.usages[key.jobID] = jobUsage
+		}
+		jobUsage.Task(key.taskID).Add(usage)
+		jobUsage.UpdateTime = now
+	}
+	if err := SaveJobUsages(ctx, c.kubeClient, c.namespace, c.usages); err != nil {
+		nlog.Warnf("Fail to store resource usage of domain %s, err: %v", c.namespace, err)
+	}
+}
+
+func (c *Collector) samplePods(ctx context.Context, elapsed time.Duration, changed map[taskKey]*Usage) error {
+	if elapsed <= 0 {
+		return nil
+	}
+	pods, err := c.kubeClient.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelTaskUID})
+	if err != nil {
+		return err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		key, ok := taskKeyOf(pod.Annotations)
+		if !ok {
+			continue
+		}
+		cpu, memory := podAllocatedResources(pod)
+		usage := changedUsage(changed, key)
+		usage.CPUSeconds += cpu * elapsed.Seconds()
+		usage.MemoryGBHours += memory / bytesPerGiB * elapsed.Seconds() / secondsPerHour
+	}
+	return nil
+}
+
+func (c *Collector) accumulateTransfer(ctx context.Context, changed map[taskKey]*Usage) error {
+	current, services, err := c.sampleTransfer(ctx)
+	if err != nil {
+		return err
+	}
+	if c.lastTransfer == nil {
+		c.lastTransfer = current
+		return nil
+	}
+	for clusterName, cur := range current {
+		key, ok := services[clusterName]
+		if !ok {
+			continue
+		}
+		last := c.lastTransfer[clusterName]
+		sent, received := cur.sent-last.sent, cur.received-last.received
+		// counters are reset if the cluster was recreated
+		if sent < 0 || received < 0 {
+			sent, received = cur.sent, cur.received
+		}
+		if sent == 0 && received == 0 {
+			continue
+		}
+		usage := changedUsage(changed, key)
+		usage.SentBytes += int64(sent)
+		usage.ReceivedBytes += int64(received)
+	}
+	c.lastTransfer = current
+	return nil
+}
+
+// sampleTransfer returns cumulative bytes of clusters of task services and the task each cluster belongs to.
+func (c *Collector) sampleTransfer(ctx context.Context) (map[string]transferCounter, map[string]taskKey, error) {
+	services, err := c.kubeClient.CoreV1().Services(c.namespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelTaskUID})
+	if err != nil {
+		return nil, nil, err
+	}
+	stats, err := c.fetchEnvoyStats()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterTasks := make(map[string]taskKey, len(services.Items))
+	for _, svc := range services.Items {
+		if key, ok := taskKeyOf(svc.Annotations); ok {
+			clusterTasks[serviceClusterPrefix+svc.Name] = key
+		}
+	}
+
+	counters := make(map[string]transferCounter)
+	for name, value := range stats {
+		clusterName, stat, ok := netmetrics.SplitClusterStat(name)
+		if !ok || !strings.HasPrefix(clusterName, serviceClusterPrefix) {
+			continue
+		}
+		counter := counters[clusterName]
+		switch stat {
+		case statSentBytes:
+			counter.sent = value
+		case statReceivedBytes:
+			counter.received = value
+		default:
+			continue
+		}
+		counters[clusterName] = counter
+	}
+	return counters, clusterTasks, nil
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.cpuDesc
+	ch <- c.memoryDesc
+	ch <- c.transferDesc
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+	for jobID, jobUsage := range c.usages {
+		if now.Sub(jobUsage.UpdateTime) > metricRetention {
+			continue
+		}
+		for taskID, usage := range jobUsage.Tasks {
+			ch <- prometheus.MustNewConstMetric(c.cpuDesc, prometheus.CounterValue, usage.CPUSeconds, jobID, taskID)
+			ch <- prometheus.MustNewConstMetric(c.memoryDesc, prometheus.CounterValue, usage.MemoryGBHours, jobID, taskID)
+			ch <- prometheus.MustNewConstMetric(c.transferDesc, prometheus.CounterValue, float64(usage.SentBytes),
+				jobID, taskID, directionSent)
+			ch <- prometheus.MustNewConstMetric(c.transferDesc, prometheus.CounterValue, float64(usage.ReceivedBytes),
+				jobID, taskID, directionReceived)
+		}
+	}
+}
+
+func taskKeyOf(annotations map[string]string) (taskKey, bool) {
+	key := taskKey{
+		jobID:  annotations[common.JobIDAnnotationKey],
+		taskID: annotations[common.TaskIDAnnotationKey],
+	}
+	return key, key.jobID != "" && key.taskID != ""
+}
+
+func changedUsage(changed map[taskKey]*Usage, key taskKey) *Usage {
+	usage, ok := changed[key]
+	if !ok {
+		usage = &Usage{}
+		changed[key] = usage
+	}
+	return usage
+}
+
+// podAllocatedResources returns cpu cores and memory bytes allocated to pod, limits are used if requests are not set.
+func podAllocatedResources(pod *corev1.Pod) (float64, float64) {
+	var cpu, memory float64
+	for _, container := range pod.Spec.Containers {
+		if q, ok := container.Resources.Requests[corev1.ResourceCPU]; ok && !q.IsZero() {
+			cpu += q.AsApproximateFloat64()
+		} else if q, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+			cpu += q.AsApproximateFloat64()
+		}
+		if q, ok := container.Resources.Requests[corev1.ResourceMemory]; ok && !q.IsZero() {
+			memory += q.AsApproximateFloat64()
+		} else if q, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			memory += q.AsApproximateFloat64()
+		}
+	}
+	return cpu, memory
+}
+
+func getServiceTransferStats() (map[string]float64, error) {
+	return netmetrics.FetchEnvoyStats(fmt.Sprintf(`^cluster\.%s.*\.(%s|%s)$`, serviceClusterPrefix, statSentBytes,
+		statReceivedBytes))
+}
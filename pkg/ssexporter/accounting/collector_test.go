@@ -0,0 +1,148 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accounting
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func makeTaskObjectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "alice",
+		Labels:    map[string]string{common.LabelTaskUID: "uid"},
+		Annotations: map[string]string{
+			common.JobIDAnnotationKey:  "job-1",
+			common.TaskIDAnnotationKey: "task-1",
+		},
+	}
+}
+
+func makeTaskPod(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: makeTaskObjectMeta(name),
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestCollectorRefresh(t *testing.T) {
+	t.Parallel()
+	kubeClient := kubefake.NewSimpleClientset(
+		makeTaskPod("pod-running", corev1.PodRunning),
+		makeTaskPod("pod-succeeded", corev1.PodSucceeded),
+		&corev1.Service{ObjectMeta: makeTaskObjectMeta("svc-1")},
+	)
+	c := NewCollector("alice", kubeClient)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	stats := map[string]float64{
+		"cluster.service-svc-1.upstream_cx_rx_bytes_total": 100,
+		"cluster.service-svc-1.upstream_cx_tx_bytes_total": 200,
+		"cluster.service-other.upstream_cx_rx_bytes_total": 300,
+	}
+	c.fetchEnvoyStats = func() (map[string]float64, error) { return stats, nil }
+
+	// the first refresh only takes baseline
+	ctx := context.Background()
+	c.Refresh(ctx)
+	usage, err := LoadJobUsage(ctx, kubeClient, "alice", "job-1")
+	assert.NoError(t, err)
+	assert.Nil(t, usage)
+
+	now = now.Add(time.Hour)
+	stats = map[string]float64{
+		"cluster.service-svc-1.upstream_cx_rx_bytes_total": 150,
+		"cluster.service-svc-1.upstream_cx_tx_bytes_total": 500,
+		"cluster.service-other.upstream_cx_rx_bytes_total": 900,
+	}
+	c.Refresh(ctx)
+
+	usage, err = LoadJobUsage(ctx, kubeClient, "alice", "job-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, usage)
+	task := usage.Tasks["task-1"]
+	assert.NotNil(t, task)
+	assert.InDelta(t, 7200, task.CPUSeconds, 0.001)
+	assert.InDelta(t, 1, task.MemoryGBHours, 0.001)
+	assert.Equal(t, int64(50), task.SentBytes)
+	assert.Equal(t, int64(300), task.ReceivedBytes)
+
+	expected := `
+# HELP kuscia_job_cpu_seconds_total Cpu cores allocated to task pods multiplied by running seconds
+# TYPE kuscia_job_cpu_seconds_total counter
+kuscia_job_cpu_seconds_total{job_id="job-1",task_id="task-1"} 7200
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected), "kuscia_job_cpu_seconds_total"))
+
+	// a restarted collector continues from the stored rollups
+	restarted := NewCollector("alice", kubeClient)
+	restarted.now = c.now
+	restarted.fetchEnvoyStats = c.fetchEnvoyStats
+	restarted.Refresh(ctx)
+	now = now.Add(time.Hour)
+	restarted.Refresh(ctx)
+	usage, err = LoadJobUsage(ctx, kubeClient, "alice", "job-1")
+	assert.NoError(t, err)
+	assert.InDelta(t, 14400, usage.Tasks["task-1"].CPUSeconds, 0.001)
+	assert.Equal(t, int64(50), usage.Tasks["task-1"].SentBytes)
+}
+
+func TestSaveJobUsagesRetention(t *testing.T) {
+	t.Parallel()
+	kubeClient := kubefake.NewSimpleClientset()
+	usages := make(map[string]*JobUsage)
+	now := time.Now()
+	for i := 0; i <= maxStoredJobs; i++ {
+		usage := NewJobUsage(string(rune('a'+i%26)) + strings.Repeat("x", i/26))
+		usage.UpdateTime = now.Add(time.Duration(i) * time.Second)
+		usages[usage.JobID] = usage
+	}
+	assert.NoError(t, SaveJobUsages(context.Background(), kubeClient, "alice", usages))
+
+	loaded, err := LoadJobUsages(context.Background(), kubeClient, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, maxStoredJobs, len(loaded))
+	// the least recently updated job is dropped
+	_, ok := loaded["a"]
+	assert.False(t, ok)
+}
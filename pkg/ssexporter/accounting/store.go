@@ -0,0 +1,170 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// UsageConfigMapName is the configmap in domain namespace which stores resource usage rollups, keyed by job id.
+	UsageConfigMapName = "kuscia-resource-usage"
+
+	// maxStoredJobs bounds the rollups kept in the configmap, the least recently updated jobs are dropped first.
+	maxStoredJobs = 1000
+)
+
+// Usage is the resource consumption accounted for a task or a job.
+type Usage struct {
+	// CPUSeconds is cpu cores allocated to pods multiplied by running seconds.
+	CPUSeconds float64 `json:"cpuSeconds"`
+	// MemoryGBHours is memory allocated to pods in GiB multiplied by running hours.
+	MemoryGBHours float64 `json:"memoryGBHours"`
+	// SentBytes is the bytes sent by pods through gateway.
+	SentBytes int64 `json:"sentBytes"`
+	// ReceivedBytes is the bytes received by pods through gateway.
+	ReceivedBytes int64 `json:"receivedBytes"`
+}
+
+func (u *Usage) Add(other *Usage) {
+	u.CPUSeconds += other.CPUSeconds
+	u.MemoryGBHours += other.MemoryGBHours
+	u.SentBytes += other.SentBytes
+	u.ReceivedBytes += other.ReceivedBytes
+}
+
+// JobUsage is the rollup of a job in a domain.
+type JobUsage struct {
+	JobID      string            `json:"jobID"`
+	Tasks      map[string]*Usage `json:"tasks"`
+	UpdateTime time.Time         `json:"updateTime"`
+}
+
+func NewJobUsage(jobID string) *JobUsage {
+	return &JobUsage{
+		JobID: jobID,
+		Tasks: make(map[string]*Usage),
+	}
+}
+
+// Task returns the usage of task, creating it if it doesn't exist.
+func (j *JobUsage) Task(taskID string) *Usage {
+	usage, ok := j.Tasks[taskID]
+	if !ok {
+		usage = &Usage{}
+		j.Tasks[taskID] = usage
+	}
+	return usage
+}
+
+// Total sums the usage of all tasks.
+func (j *JobUsage) Total() *Usage {
+	total := &Usage{}
+	for _, usage := range j.Tasks {
+		total.Add(usage)
+	}
+	return total
+}
+
+// LoadJobUsages loads all rollups stored in namespace, it returns an empty map if nothing has been stored.
+func LoadJobUsages(ctx context.Context, kubeClient kubernetes.Interface, namespace string) (map[string]*JobUsage, error) {
+	usages := make(map[string]*JobUsage)
+	cm, err := kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, UsageConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return usages, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for jobID, data := range cm.Data {
+		usage := NewJobUsage(jobID)
+		if err := json.Unmarshal([]byte(data), usage); err != nil {
+			nlog.Warnf("Skip invalid resource usage of job %s in %s/%s, %v", jobID, namespace, UsageConfigMapName, err)
+			continue
+		}
+		if usage.Tasks == nil {
+			usage.Tasks = make(map[string]*Usage)
+		}
+		usages[jobID] = usage
+	}
+	return usages, nil
+}
+
+// LoadJobUsage loads the rollup of job stored in namespace, it returns nil if the job isn't accounted in namespace.
+func LoadJobUsage(ctx context.Context, kubeClient kubernetes.Interface, namespace, jobID string) (*JobUsage, error) {
+	usages, err := LoadJobUsages(ctx, kubeClient, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return usages[jobID], nil
+}
+
+// SaveJobUsages stores rollups into namespace, overwriting what has been stored.
+func SaveJobUsages(ctx context.Context, kubeClient kubernetes.Interface, namespace string, usages map[string]*JobUsage) error {
+	data := make(map[string]string, len(usages))
+	for _, usage := range retainedJobUsages(usages) {
+		content, err := json.Marshal(usage)
+		if err != nil {
+			return err
+		}
+		data[usage.JobID] = string(content)
+	}
+
+	cms := kubeClient.CoreV1().ConfigMaps(namespace)
+	cm, err := cms.Get(ctx, UsageConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = cms.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      UsageConfigMapName,
+				Namespace: namespace,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	cm = cm.DeepCopy()
+	cm.Data = data
+	_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
+
+func retainedJobUsages(usages map[string]*JobUsage) []*JobUsage {
+	list := make([]*JobUsage, 0, len(usages))
+	for _, usage := range usages {
+		list = append(list, usage)
+	}
+	if len(list) <= maxStoredJobs {
+		return list
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].UpdateTime.After(list[j].UpdateTime)
+	})
+	return list[:maxStoredJobs]
+}
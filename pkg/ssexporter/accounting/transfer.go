@@ -0,0 +1,199 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/ssexporter/netmetrics"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// upstream of route clusters is the gateway of the partner
+	statRouteSentBytes     = "upstream_cx_tx_bytes_total"
+	statRouteReceivedBytes = "upstream_cx_rx_bytes_total"
+)
+
+// TransferCollector samples bytes transferred through envoy clusters of DomainRoutes from a domain, accumulates them
+// into daily rollups per partner, and exports the bytes transferred since started as prometheus metrics. Requests
+// initiated by a partner are accounted by the DomainRoute from the partner.
+type TransferCollector struct {
+	namespace  string
+	kubeClient kubernetes.Interface
+
+	mtx          sync.Mutex
+	days         map[string]DailyTransfer
+	totals       map[string]*Transfer
+	lastTransfer map[string]transferCounter
+	loaded       bool
+
+	transferDesc *prometheus.Desc
+
+	// now and fetchEnvoyStats are replaced in test.
+	now             func() time.Time
+	fetchEnvoyStats func(namespace string) (map[string]float64, error)
+}
+
+func NewTransferCollector(namespace string, kubeClient kubernetes.Interface) *TransferCollector {
+	return &TransferCollector{
+		namespace:    namespace,
+		kubeClient:   kubeClient,
+		days:         make(map[string]DailyTransfer),
+		totals:       make(map[string]*Transfer),
+		lastTransfer: make(map[string]transferCounter),
+		transferDesc: prometheus.NewDesc("kuscia_domainroute_transfer_bytes_total",
+			"Bytes transferred through the DomainRoute since started", []string{"src_domain", "dst_domain", "direction"}, nil),
+		now:             time.Now,
+		fetchEnvoyStats: getRouteTransferStats,
+	}
+}
+
+// Refresh samples the bytes transferred since last refresh and stores the updated rollups, the bytes are accounted
+// to the day of the refresh in UTC.
+func (c *TransferCollector) Refresh(ctx context.Context) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if !c.loaded {
+		days, err := LoadDailyTransfers(ctx, c.kubeClient, c.namespace)
+		if err != nil {
+			nlog.Warnf("Fail to load transfer of domain %s, err: %v", c.namespace, err)
+			return
+		}
+		c.days = days
+		c.loaded = true
+		// counters of envoy are accumulated before started, only take them as baseline
+		c.lastTransfer, _ = c.sampleTransfer()
+		return
+	}
+
+	current, err := c.sampleTransfer()
+	if err != nil {
+		nlog.Warnf("Fail to sample transfer bytes of domain routes of %s, err: %v", c.namespace, err)
+		return
+	}
+	changed := make(map[string]*Transfer)
+	for clusterName, cur := range current {
+		key, ok := netmetrics.ParseClusterName(c.namespace, clusterName)
+		if !ok {
+			continue
+		}
+		last := c.lastTransfer[clusterName]
+		sent, received := cur.sent-last.sent, cur.received-last.received
+		// counters are reset if the cluster was recreated
+		if sent < 0 || received < 0 {
+			sent, received = cur.sent, cur.received
+		}
+		if sent == 0 && received == 0 {
+			continue
+		}
+		transfer, ok := changed[key.Destination]
+		if !ok {
+			transfer = &Transfer{}
+			changed[key.Destination] = transfer
+		}
+		transfer.SentBytes += int64(sent)
+		transfer.ReceivedBytes += int64(received)
+	}
+	c.lastTransfer = current
+	if len(changed) == 0 {
+		return
+	}
+
+	date := c.now().UTC().Format(TransferDateLayout)
+	day, ok := c.days[date]
+	if !ok {
+		day = DailyTransfer{}
+		c.days[date] = day
+	}
+	for partner, transfer := range changed {
+		day.Partner(partner).Add(transfer)
+		total, ok := c.totals[partner]
+		if !ok {
+			total = &Transfer{}
+			c.totals[partner] = total
+		}
+		total.Add(transfer)
+	}
+	c.pruneDays()
+	if err := SaveDailyTransfers(ctx, c.kubeClient, c.namespace, c.days); err != nil {
+		nlog.Warnf("Fail to store transfer of domain %s, err: %v", c.namespace, err)
+	}
+}
+
+// sampleTransfer returns cumulative bytes of clusters of DomainRoutes from the domain.
+func (c *TransferCollector) sampleTransfer() (map[string]transferCounter, error) {
+	stats, err := c.fetchEnvoyStats(c.namespace)
+	if err != nil {
+		return nil, err
+	}
+	counters := make(map[string]transferCounter)
+	for name, value := range stats {
+		clusterName, stat, ok := netmetrics.SplitClusterStat(name)
+		if !ok {
+			continue
+		}
+		counter := counters[clusterName]
+		switch stat {
+		case statRouteSentBytes:
+			counter.sent = value
+		case statRouteReceivedBytes:
+			counter.received = value
+		default:
+			continue
+		}
+		counters[clusterName] = counter
+	}
+	return counters, nil
+}
+
+func (c *TransferCollector) pruneDays() {
+	if len(c.days) <= maxStoredDays {
+		return
+	}
+	retained := make(map[string]DailyTransfer, maxStoredDays)
+	for _, date := range retainedDates(c.days) {
+		retained[date] = c.days[date]
+	}
+	c.days = retained
+}
+
+func (c *TransferCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.transferDesc
+}
+
+func (c *TransferCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for partner, total := range c.totals {
+		ch <- prometheus.MustNewConstMetric(c.transferDesc, prometheus.CounterValue, float64(total.SentBytes),
+			c.namespace, partner, directionSent)
+		ch <- prometheus.MustNewConstMetric(c.transferDesc, prometheus.CounterValue, float64(total.ReceivedBytes),
+			c.namespace, partner, directionReceived)
+	}
+}
+
+func getRouteTransferStats(namespace string) (map[string]float64, error) {
+	return netmetrics.FetchEnvoyStats(fmt.Sprintf(`^cluster\.%s-to-.*\.(%s|%s)$`, namespace, statRouteSentBytes,
+		statRouteReceivedBytes))
+}
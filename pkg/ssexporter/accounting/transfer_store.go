@@ -0,0 +1,135 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accounting
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// TransferConfigMapName is the configmap in domain namespace which stores daily rollups of bytes transferred
+	// through DomainRoutes, keyed by date.
+	TransferConfigMapName = "kuscia-transfer-usage"
+
+	// TransferDateLayout formats the day of a rollup in UTC.
+	TransferDateLayout = "2006-01-02"
+
+	// maxStoredDays bounds the rollups kept in the configmap, the earliest days are dropped first.
+	maxStoredDays = 180
+)
+
+// Transfer is the bytes exchanged with a partner through the DomainRoute to it.
+type Transfer struct {
+	// SentBytes is the bytes sent to the partner.
+	SentBytes int64 `json:"sentBytes"`
+	// ReceivedBytes is the bytes received from the partner.
+	ReceivedBytes int64 `json:"receivedBytes"`
+}
+
+func (t *Transfer) Add(other *Transfer) {
+	t.SentBytes += other.SentBytes
+	t.ReceivedBytes += other.ReceivedBytes
+}
+
+// DailyTransfer is the rollup of a day, keyed by the destination domain of DomainRoutes.
+type DailyTransfer map[string]*Transfer
+
+// Partner returns the transfer with partner, creating it if it doesn't exist.
+func (d DailyTransfer) Partner(partner string) *Transfer {
+	transfer, ok := d[partner]
+	if !ok {
+		transfer = &Transfer{}
+		d[partner] = transfer
+	}
+	return transfer
+}
+
+// LoadDailyTransfers loads all daily rollups stored in namespace keyed by date, it returns an empty map if nothing
+// has been stored.
+func LoadDailyTransfers(ctx context.Context, kubeClient kubernetes.Interface, namespace string) (map[string]DailyTransfer, error) {
+	days := make(map[string]DailyTransfer)
+	cm, err := kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, TransferConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return days, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for date, data := range cm.Data {
+		day := DailyTransfer{}
+		if err := json.Unmarshal([]byte(data), &day); err != nil {
+			nlog.Warnf("Skip invalid transfer of %s in %s/%s, %v", date, namespace, TransferConfigMapName, err)
+			continue
+		}
+		days[date] = day
+	}
+	return days, nil
+}
+
+// SaveDailyTransfers stores daily rollups into namespace, overwriting what has been stored.
+func SaveDailyTransfers(ctx context.Context, kubeClient kubernetes.Interface, namespace string, days map[string]DailyTransfer) error {
+	data := make(map[string]string, len(days))
+	for _, date := range retainedDates(days) {
+		content, err := json.Marshal(days[date])
+		if err != nil {
+			return err
+		}
+		data[date] = string(content)
+	}
+
+	cms := kubeClient.CoreV1().ConfigMaps(namespace)
+	cm, err := cms.Get(ctx, TransferConfigMapName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = cms.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TransferConfigMapName,
+				Namespace: namespace,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	cm = cm.DeepCopy()
+	cm.Data = data
+	_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
+
+// retainedDates returns the latest maxStoredDays dates, dates formatted as TransferDateLayout sort by time.
+func retainedDates(days map[string]DailyTransfer) []string {
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	if len(dates) > maxStoredDays {
+		dates = dates[len(dates)-maxStoredDays:]
+	}
+	return dates
+}
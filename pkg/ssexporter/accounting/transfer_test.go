@@ -0,0 +1,109 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTransferCollectorRefresh(t *testing.T) {
+	t.Parallel()
+	kubeClient := kubefake.NewSimpleClientset()
+	c := NewTransferCollector("alice", kubeClient)
+	now := time.Date(2024, 6, 1, 23, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	stats := map[string]float64{
+		"cluster.alice-to-bob-http.upstream_cx_tx_bytes_total":  100,
+		"cluster.alice-to-bob-http.upstream_cx_rx_bytes_total":  200,
+		"cluster.alice-to-bob-grpc.upstream_cx_tx_bytes_total":  10,
+		"cluster.alice-to-carol-http.upstream_cx_tx_bytes_total": 0,
+	}
+	c.fetchEnvoyStats = func(string) (map[string]float64, error) { return stats, nil }
+
+	// the first refresh only takes baseline
+	ctx := context.Background()
+	c.Refresh(ctx)
+	days, err := LoadDailyTransfers(ctx, kubeClient, "alice")
+	assert.NoError(t, err)
+	assert.Empty(t, days)
+
+	stats = map[string]float64{
+		"cluster.alice-to-bob-http.upstream_cx_tx_bytes_total":  150,
+		"cluster.alice-to-bob-http.upstream_cx_rx_bytes_total":  500,
+		"cluster.alice-to-bob-grpc.upstream_cx_tx_bytes_total":  30,
+		"cluster.alice-to-carol-http.upstream_cx_tx_bytes_total": 0,
+	}
+	c.Refresh(ctx)
+
+	// the next day, the counters of a recreated cluster are reset
+	now = now.Add(2 * time.Hour)
+	stats["cluster.alice-to-bob-grpc.upstream_cx_tx_bytes_total"] = 5
+	stats["cluster.alice-to-carol-http.upstream_cx_tx_bytes_total"] = 40
+	c.Refresh(ctx)
+
+	days, err = LoadDailyTransfers(ctx, kubeClient, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, DailyTransfer{"bob": {SentBytes: 70, ReceivedBytes: 300}}, days["2024-06-01"])
+	assert.Equal(t, DailyTransfer{"bob": {SentBytes: 5}, "carol": {SentBytes: 40}}, days["2024-06-02"])
+
+	expected := `
+# HELP kuscia_domainroute_transfer_bytes_total Bytes transferred through the DomainRoute since started
+# TYPE kuscia_domainroute_transfer_bytes_total counter
+kuscia_domainroute_transfer_bytes_total{direction="received",dst_domain="bob",src_domain="alice"} 300
+kuscia_domainroute_transfer_bytes_total{direction="received",dst_domain="carol",src_domain="alice"} 0
+kuscia_domainroute_transfer_bytes_total{direction="sent",dst_domain="bob",src_domain="alice"} 75
+kuscia_domainroute_transfer_bytes_total{direction="sent",dst_domain="carol",src_domain="alice"} 40
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected)))
+
+	// a restarted collector continues from the stored rollups
+	restarted := NewTransferCollector("alice", kubeClient)
+	restarted.now = c.now
+	restarted.fetchEnvoyStats = c.fetchEnvoyStats
+	restarted.Refresh(ctx)
+	stats["cluster.alice-to-carol-http.upstream_cx_tx_bytes_total"] = 50
+	restarted.Refresh(ctx)
+	days, err = LoadDailyTransfers(ctx, kubeClient, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), days["2024-06-02"]["carol"].SentBytes)
+	assert.Equal(t, int64(70), days["2024-06-01"]["bob"].SentBytes)
+}
+
+func TestSaveDailyTransfersRetention(t *testing.T) {
+	t.Parallel()
+	kubeClient := kubefake.NewSimpleClientset()
+	days := make(map[string]DailyTransfer)
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= maxStoredDays; i++ {
+		date := first.AddDate(0, 0, i).Format(TransferDateLayout)
+		days[date] = DailyTransfer{"bob": {SentBytes: int64(i)}}
+	}
+	assert.NoError(t, SaveDailyTransfers(context.Background(), kubeClient, "alice", days))
+
+	loaded, err := LoadDailyTransfers(context.Background(), kubeClient, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, maxStoredDays, len(loaded))
+	// the earliest day is dropped
+	_, ok := loaded[first.Format(TransferDateLayout)]
+	assert.False(t, ok, fmt.Sprintf("%s should be dropped", first.Format(TransferDateLayout)))
+}
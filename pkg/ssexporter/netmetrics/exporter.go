@@ -0,0 +1,252 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmetrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/ssexporter/parse"
+	"github.com/secretflow/kuscia/pkg/ssexporter/ssmetrics"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	envoyStatsURL = "http://localhost:10000/stats"
+
+	statUpstreamRequests     = "upstream_rq_total"
+	statUpstream5xx          = "upstream_rq_5xx"
+	statUpstreamTimeout      = "upstream_rq_timeout"
+	statUpstreamConnectFail  = "upstream_cx_connect_fail"
+	envoyClusterStatPrefix   = "cluster."
+	envoyStatsRequestTimeout = 2 * time.Second
+)
+
+// counters is the cumulative values of a route, used to calculate the change in a period.
+type counters struct {
+	txBytes, rxBytes   float64
+	requests, failures float64
+	hasSs, hasEnvoy    bool
+}
+
+// Exporter refreshes network metrics of the local domain periodically.
+type Exporter struct {
+	localDomain string
+	period      time.Duration
+	collector   *Collector
+	last        map[RouteKey]*counters
+
+	// fetchSs and fetchEnvoyStats are replaced in test.
+	fetchSs         func() ([]map[string]string, error)
+	fetchEnvoyStats func(localDomain string) (map[string]float64, error)
+}
+
+func NewExporter(localDomain string, period time.Duration) *Exporter {
+	return &Exporter{
+		localDomain:     localDomain,
+		period:          period,
+		collector:       NewCollector(),
+		last:            make(map[RouteKey]*counters),
+		fetchSs:         ssmetrics.GetStatistic,
+		fetchEnvoyStats: getEnvoyClusterStats,
+	}
+}
+
+// SetPeriod changes the refresh period used to calculate rates, it must be called in the refreshing goroutine.
+func (e *Exporter) SetPeriod(period time.Duration) {
+	e.period = period
+}
+
+func (e *Exporter) Collector() *Collector {
+	return e.collector
+}
+
+// Refresh collects stats of routes whose envoy clusters are given as clusterName -> endpoint addresses.
+func (e *Exporter) Refresh(clusterAddresses map[string][]string) {
+	routeAddresses := make(map[RouteKey][]string)
+	for clusterName, addresses := range clusterAddresses {
+		if key, ok := ParseClusterName(e.localDomain, clusterName); ok {
+			routeAddresses[key] = append(routeAddresses[key], addresses...)
+		}
+	}
+
+	current := make(map[RouteKey]*counters, len(routeAddresses))
+	stats := make(map[RouteKey]*RouteStats, len(routeAddresses))
+	for key := range routeAddresses {
+		current[key] = &counters{}
+		stats[key] = &RouteStats{}
+	}
+
+	if ssResults, err := e.fetchSs(); err != nil {
+		nlog.Warnf("Fail to get tcp statistics, err: %v", err)
+	} else {
+		e.fillSsStats(ssResults, routeAddresses, current, stats)
+	}
+
+	if envoyStats, err := e.fetchEnvoyStats(e.localDomain); err != nil {
+		nlog.Warnf("Fail to get statistics from envoy, err: %v", err)
+	} else {
+		e.fillEnvoyStats(envoyStats, current)
+	}
+
+	seconds := e.period.Seconds()
+	for key, cur := range current {
+		last, ok := e.last[key]
+		if !ok {
+			continue
+		}
+		s := stats[key]
+		if cur.hasSs && last.hasSs && seconds > 0 {
+			s.TxBytesPerSecond = nonNegative(cur.txBytes-last.txBytes) / seconds
+			s.RxBytesPerSecond = nonNegative(cur.rxBytes-last.rxBytes) / seconds
+		}
+		if cur.hasEnvoy && last.hasEnvoy {
+			s.UpstreamRequests = nonNegative(cur.requests - last.requests)
+			s.UpstreamErrorRate = ssmetrics.Rate(nonNegative(cur.failures-last.failures), s.UpstreamRequests)
+		}
+	}
+	e.last = current
+	e.collector.Update(stats)
+}
+
+func (e *Exporter) fillSsStats(ssResults []map[string]string, routeAddresses map[RouteKey][]string,
+	current map[RouteKey]*counters, stats map[RouteKey]*RouteStats) {
+	var sourceIPs []string
+	if hostName, err := os.Hostname(); err == nil {
+		sourceIPs = parse.GetIPFromDomain(hostName)
+	}
+	if len(sourceIPs) == 0 {
+		sourceIPs = []string{"*"}
+	}
+
+	for key, addresses := range routeAddresses {
+		var connections []map[string]string
+		for _, address := range addresses {
+			for _, dstIP := range parse.GetIPFromDomain(strings.Split(address, ":")[0]) {
+				for _, srcIP := range sourceIPs {
+					connections = append(connections, ssmetrics.Filter(ssResults, srcIP, dstIP, "*", "*", "*")...)
+				}
+			}
+		}
+		if len(connections) == 0 {
+			continue
+		}
+
+		s := stats[key]
+		s.RTTMillis, _ = ssmetrics.Avg(connections, parse.MetricRTT)
+		retrans, _ := ssmetrics.Sum(connections, parse.MetricRetrans)
+		s.RetransRate = ssmetrics.Rate(retrans, float64(len(connections)))
+
+		c := current[key]
+		c.txBytes, _ = ssmetrics.Sum(connections, parse.MetricByteSent)
+		c.rxBytes, _ = ssmetrics.Sum(connections, parse.MetricBytesReceived)
+		c.hasSs = true
+	}
+}
+
+func (e *Exporter) fillEnvoyStats(envoyStats map[string]float64, current map[RouteKey]*counters) {
+	for name, value := range envoyStats {
+		clusterName, stat, ok := SplitClusterStat(name)
+		if !ok {
+			continue
+		}
+		key, ok := ParseClusterName(e.localDomain, clusterName)
+		if !ok {
+			continue
+		}
+		c, ok := current[key]
+		if !ok {
+			continue
+		}
+		switch stat {
+		case statUpstreamRequests:
+			c.requests += value
+		case statUpstream5xx, statUpstreamTimeout, statUpstreamConnectFail:
+			c.failures += value
+		default:
+			continue
+		}
+		c.hasEnvoy = true
+	}
+}
+
+// SplitClusterStat splits envoy stat name as cluster.{clusterName}.{stat}, cluster name never contains dot.
+func SplitClusterStat(name string) (string, string, bool) {
+	if !strings.HasPrefix(name, envoyClusterStatPrefix) {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(name, envoyClusterStatPrefix)
+	idx := strings.Index(rest, ".")
+	if idx <= 0 {
+		return "", "", false
+	}
+	return rest[:idx], rest[idx+1:], true
+}
+
+type envoyStatsResponse struct {
+	Stats []struct {
+		Name  string      `json:"name"`
+		Value json.Number `json:"value"`
+	} `json:"stats"`
+}
+
+func getEnvoyClusterStats(localDomain string) (map[string]float64, error) {
+	filter := fmt.Sprintf(`^cluster\.%s-to-.*\.(%s|%s|%s|%s)$`, localDomain, statUpstreamRequests, statUpstream5xx,
+		statUpstreamTimeout, statUpstreamConnectFail)
+	return FetchEnvoyStats(filter)
+}
+
+// FetchEnvoyStats gets envoy stats whose names match the regular expression filter.
+func FetchEnvoyStats(filter string) (map[string]float64, error) {
+	client := http.Client{Timeout: envoyStatsRequestTimeout}
+	resp, err := client.Get(envoyStatsURL + "?format=json&filter=" + url.QueryEscape(filter))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseEnvoyStats(body)
+}
+
+func parseEnvoyStats(body []byte) (map[string]float64, error) {
+	var resp envoyStatsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	result := make(map[string]float64, len(resp.Stats))
+	for _, stat := range resp.Stats {
+		// histograms have no numeric value, skip them
+		if value, err := stat.Value.Float64(); err == nil {
+			result[stat.Name] = value
+		}
+	}
+	return result, nil
+}
+
+func nonNegative(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
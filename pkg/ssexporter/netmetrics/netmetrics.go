@@ -0,0 +1,125 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netmetrics reports the quality of links between domains, combining tcp statistics from ss
+// and upstream statistics from envoy, labeled by source and destination domain.
+package netmetrics
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	labelSourceDomain      = "src_domain"
+	labelDestinationDomain = "dst_domain"
+	labelDirection         = "direction"
+
+	directionTx = "tx"
+	directionRx = "rx"
+)
+
+// RouteKey identifies a DomainRoute.
+type RouteKey struct {
+	Source      string
+	Destination string
+}
+
+// RouteStats is the link quality of a DomainRoute in the last period.
+type RouteStats struct {
+	// RTTMillis is the average round trip time of connections.
+	RTTMillis float64
+	// RetransRate is retransmitted segments per connection.
+	RetransRate float64
+	// TxBytesPerSecond and RxBytesPerSecond estimate the bandwidth used.
+	TxBytesPerSecond float64
+	RxBytesPerSecond float64
+	// UpstreamErrorRate is the ratio of failed requests reported by envoy, including 5xx, timeout and connect failure.
+	UpstreamErrorRate float64
+	// UpstreamRequests is the count of requests reported by envoy.
+	UpstreamRequests float64
+}
+
+// Collector exports the latest RouteStats as prometheus metrics.
+type Collector struct {
+	mu    sync.RWMutex
+	stats map[RouteKey]*RouteStats
+
+	rtt               *prometheus.Desc
+	retransRate       *prometheus.Desc
+	bandwidth         *prometheus.Desc
+	upstreamErrorRate *prometheus.Desc
+	upstreamRequests  *prometheus.Desc
+}
+
+func NewCollector() *Collector {
+	routeLabels := []string{labelSourceDomain, labelDestinationDomain}
+	return &Collector{
+		stats: make(map[RouteKey]*RouteStats),
+		rtt: prometheus.NewDesc("kuscia_network_rtt_milliseconds",
+			"Average round trip time of connections between domains", routeLabels, nil),
+		retransRate: prometheus.NewDesc("kuscia_network_retransmit_rate",
+			"Retransmitted segments per connection between domains", routeLabels, nil),
+		bandwidth: prometheus.NewDesc("kuscia_network_bandwidth_bytes_per_second",
+			"Estimated bandwidth used between domains", append(routeLabels, labelDirection), nil),
+		upstreamErrorRate: prometheus.NewDesc("kuscia_network_upstream_error_rate",
+			"Ratio of failed requests to the destination domain reported by envoy", routeLabels, nil),
+		upstreamRequests: prometheus.NewDesc("kuscia_network_upstream_requests",
+			"Requests to the destination domain in the last period reported by envoy", routeLabels, nil),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rtt
+	ch <- c.retransRate
+	ch <- c.bandwidth
+	ch <- c.upstreamErrorRate
+	ch <- c.upstreamRequests
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for key, s := range c.stats {
+		ch <- prometheus.MustNewConstMetric(c.rtt, prometheus.GaugeValue, s.RTTMillis, key.Source, key.Destination)
+		ch <- prometheus.MustNewConstMetric(c.retransRate, prometheus.GaugeValue, s.RetransRate, key.Source, key.Destination)
+		ch <- prometheus.MustNewConstMetric(c.bandwidth, prometheus.GaugeValue, s.TxBytesPerSecond, key.Source, key.Destination, directionTx)
+		ch <- prometheus.MustNewConstMetric(c.bandwidth, prometheus.GaugeValue, s.RxBytesPerSecond, key.Source, key.Destination, directionRx)
+		ch <- prometheus.MustNewConstMetric(c.upstreamErrorRate, prometheus.GaugeValue, s.UpstreamErrorRate, key.Source, key.Destination)
+		ch <- prometheus.MustNewConstMetric(c.upstreamRequests, prometheus.GaugeValue, s.UpstreamRequests, key.Source, key.Destination)
+	}
+}
+
+// Update replaces the stats, routes that no longer exist are removed.
+func (c *Collector) Update(stats map[RouteKey]*RouteStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = stats
+}
+
+// ParseClusterName gets the DomainRoute of envoy cluster named as {source}-to-{destination}-{portName}.
+func ParseClusterName(localDomain, clusterName string) (RouteKey, bool) {
+	prefix := localDomain + "-to-"
+	if !strings.HasPrefix(clusterName, prefix) {
+		return RouteKey{}, false
+	}
+	rest := strings.TrimPrefix(clusterName, prefix)
+	idx := strings.LastIndex(rest, "-")
+	if idx <= 0 {
+		return RouteKey{}, false
+	}
+	return RouteKey{Source: localDomain, Destination: rest[:idx]}, true
+}
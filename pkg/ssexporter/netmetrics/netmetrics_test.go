@@ -0,0 +1,101 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netmetrics
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClusterName(t *testing.T) {
+	key, ok := ParseClusterName("alice", "alice-to-bob-http")
+	assert.True(t, ok)
+	assert.Equal(t, RouteKey{Source: "alice", Destination: "bob"}, key)
+
+	key, ok = ParseClusterName("alice", "alice-to-bob-2-http")
+	assert.True(t, ok)
+	assert.Equal(t, "bob-2", key.Destination)
+
+	_, ok = ParseClusterName("alice", "bob-to-alice-http")
+	assert.False(t, ok)
+	_, ok = ParseClusterName("alice", "alice-to-bob")
+	assert.False(t, ok)
+	_, ok = ParseClusterName("alice", "alice-to-")
+	assert.False(t, ok)
+}
+
+func TestParseEnvoyStats(t *testing.T) {
+	body := []byte(`{"stats":[{"name":"cluster.alice-to-bob-http.upstream_rq_total","value":10},` +
+		`{"name":"cluster.alice-to-bob-http.upstream_rq_5xx","value":1},{"histograms":{"supported_quantiles":[0.5]}}]}`)
+	stats, err := parseEnvoyStats(body)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		"cluster.alice-to-bob-http.upstream_rq_total": 10,
+		"cluster.alice-to-bob-http.upstream_rq_5xx":   1,
+	}, stats)
+
+	clusterName, stat, ok := SplitClusterStat("cluster.alice-to-bob-http.health_check.attempt")
+	assert.True(t, ok)
+	assert.Equal(t, "alice-to-bob-http", clusterName)
+	assert.Equal(t, "health_check.attempt", stat)
+}
+
+func TestExporterRefresh(t *testing.T) {
+	e := NewExporter("alice", 10*time.Second)
+	round := 0.0
+	e.fetchSs = func() ([]map[string]string, error) {
+		return []map[string]string{{
+			"localAddr":         "10.0.0.1:1000",
+			"peerAddr":          "10.0.0.2:1080",
+			"rtt":               "2.5",
+			"retrans":           "1",
+			"total_connections": "1",
+			"bytes_sent":        strconv.FormatFloat(1000*round, 'f', -1, 64),
+			"bytes_received":    strconv.FormatFloat(500*round, 'f', -1, 64),
+		}}, nil
+	}
+	e.fetchEnvoyStats = func(localDomain string) (map[string]float64, error) {
+		return map[string]float64{
+			"cluster.alice-to-bob-http.upstream_rq_total":        100 * round,
+			"cluster.alice-to-bob-http.upstream_rq_5xx":          5 * round,
+			"cluster.alice-to-bob-http.upstream_cx_connect_fail": 5 * round,
+			"cluster.carol-to-bob-http.upstream_rq_total":        100,
+		}, nil
+	}
+
+	clusters := map[string][]string{"alice-to-bob-http": {"10.0.0.2:1080"}, "other": {"10.0.0.3:80"}}
+	e.Refresh(clusters)
+	round = 1
+	e.Refresh(clusters)
+
+	stats := e.collector.stats[RouteKey{Source: "alice", Destination: "bob"}]
+	assert.NotNil(t, stats)
+	assert.Equal(t, 1, len(e.collector.stats))
+	assert.Equal(t, 100.0, stats.UpstreamRequests)
+	assert.Equal(t, 0.1, stats.UpstreamErrorRate)
+
+	expected := `
+# HELP kuscia_network_upstream_error_rate Ratio of failed requests to the destination domain reported by envoy
+# TYPE kuscia_network_upstream_error_rate gauge
+kuscia_network_upstream_error_rate{dst_domain="bob",src_domain="alice"} 0.1
+`
+	assert.NoError(t, testutil.CollectAndCompare(e.Collector(), strings.NewReader(expected),
+		"kuscia_network_upstream_error_rate"))
+}
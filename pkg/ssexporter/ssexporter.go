@@ -16,16 +16,23 @@ package ssexporter
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/kubernetes"
 
 	pkgcom "github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
+	"github.com/secretflow/kuscia/pkg/ssexporter/netmetrics"
 	"github.com/secretflow/kuscia/pkg/ssexporter/parse"
 	"github.com/secretflow/kuscia/pkg/ssexporter/promexporter"
 	"github.com/secretflow/kuscia/pkg/ssexporter/ssmetrics"
+	"github.com/secretflow/kuscia/pkg/ssexporter/taskflow"
+	"github.com/secretflow/kuscia/pkg/utils/confbus"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
@@ -33,7 +40,8 @@ var (
 	ReadyChan = make(chan struct{})
 )
 
-func SsExporter(ctx context.Context, runMode pkgcom.RunModeType, domainID string, exportPeriod uint, port string) error {
+func SsExporter(ctx context.Context, runMode pkgcom.RunModeType, domainID string, exportPeriod uint, port string,
+	kubeClient kubernetes.Interface) error {
 	// read the config
 	_, AggregationMetrics := parse.LoadMetricConfig()
 	clusterAddresses, _ := parse.GetClusterAddress(domainID)
@@ -41,6 +49,26 @@ func SsExporter(ctx context.Context, runMode pkgcom.RunModeType, domainID string
 	var MetricTypes = promexporter.NewMetricTypes()
 
 	reg := promexporter.ProduceRegister()
+	netExporter := netmetrics.NewExporter(localDomainName, time.Duration(exportPeriod)*time.Second)
+	reg.MustRegister(netExporter.Collector())
+	netExporter.Refresh(clusterAddresses)
+	// task pods only run in lite and autonomy
+	var usageCollector *accounting.Collector
+	var flowCollector *taskflow.Collector
+	if runMode != pkgcom.RunModeMaster && kubeClient != nil {
+		usageCollector = accounting.NewCollector(domainID, kubeClient)
+		reg.MustRegister(usageCollector)
+		usageCollector.Refresh(ctx)
+		flowCollector = taskflow.NewCollector(domainID, kubeClient)
+		reg.MustRegister(flowCollector)
+		flowCollector.Refresh(ctx)
+	}
+	var transferCollector *accounting.TransferCollector
+	if kubeClient != nil {
+		transferCollector = accounting.NewTransferCollector(domainID, kubeClient)
+		reg.MustRegister(transferCollector)
+		transferCollector.Refresh(ctx)
+	}
 	lastClusterMetricValues, err := ssmetrics.GetSsMetricResults(runMode, localDomainName, clusterAddresses, AggregationMetrics, exportPeriod)
 	if err != nil {
 		nlog.Warnf("Fail to get ss metric results, err: %v", err)
@@ -49,8 +77,32 @@ func SsExporter(ctx context.Context, runMode pkgcom.RunModeType, domainID string
 	// export the cluster metrics
 	ticker := time.NewTicker(time.Duration(exportPeriod) * time.Second)
 	defer ticker.Stop()
+	periodCh := make(chan uint, 1)
+	confbus.Subscribe(confbus.FieldMetricUpdatePeriod, "ssexporter", func(value string) error {
+		period, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || period == 0 {
+			return fmt.Errorf("invalid metric update period %q, it should be a positive integer", value)
+		}
+		select {
+		case <-periodCh:
+		default:
+		}
+		periodCh <- uint(period)
+		return nil
+	})
 	go func(runMode pkgcom.RunModeType, reg *prometheus.Registry, MetricTypes map[string]string, exportPeriods uint, lastClusterMetricValues map[string]float64) {
-		for range ticker.C {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case period := <-periodCh:
+				exportPeriods = period
+				ticker.Reset(time.Duration(period) * time.Second)
+				netExporter.SetPeriod(time.Duration(period) * time.Second)
+				nlog.Infof("Metric update period is changed to %ds", period)
+				continue
+			case <-ticker.C:
+			}
 			// get clusterName and clusterAddress
 			clusterAddresses, _ = parse.GetClusterAddress(domainID)
 			// get cluster metrics
@@ -62,6 +114,20 @@ func SsExporter(ctx context.Context, runMode pkgcom.RunModeType, domainID string
 			lastClusterMetricValues, currentClusterMetricValues = ssmetrics.GetMetricChange(lastClusterMetricValues, currentClusterMetricValues)
 			// update cluster metrics in prometheus
 			promexporter.UpdateMetrics(reg, currentClusterMetricValues, MetricTypes)
+			// update network quality metrics of domain routes
+			netExporter.Refresh(clusterAddresses)
+			// update resource usage of jobs
+			if usageCollector != nil {
+				usageCollector.Refresh(ctx)
+			}
+			// update network traffic of tasks
+			if flowCollector != nil {
+				flowCollector.Refresh(ctx)
+			}
+			// update bytes transferred with partners
+			if transferCollector != nil {
+				transferCollector.Refresh(ctx)
+			}
 		}
 	}(runMode, reg, MetricTypes, exportPeriod, lastClusterMetricValues)
 	// export to the prometheus
@@ -0,0 +1,159 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssmetrics
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/secretflow/kuscia/pkg/ssexporter/parse"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	BackendSs   = "ss"
+	BackendEBPF = "ebpf"
+	BackendProc = "proc"
+)
+
+// Backend collects statistics of tcp connections, every connection is a map with the keys of ss metrics, e.g.
+// parse.MetricRTT and parse.SsPeerAddr. Metrics not supported by the backend are "0".
+type Backend interface {
+	Name() string
+	// Available reports why the backend can't work in this environment, it's nil if the backend can work.
+	Available() error
+	Collect() ([]map[string]string, error)
+}
+
+type ssBackend struct{}
+
+func (ssBackend) Name() string {
+	return BackendSs
+}
+
+func (ssBackend) Available() error {
+	_, err := exec.LookPath("ss")
+	return err
+}
+
+func (ssBackend) Collect() ([]map[string]string, error) {
+	return GetStatisticFromSs()
+}
+
+// AutoBackend uses the first available backend of the candidates. If the backend in use fails, the following
+// candidates are tried and the first one which succeeds is used from then on.
+type AutoBackend struct {
+	candidates []Backend
+
+	mu      sync.Mutex
+	current int
+	checked bool
+}
+
+// NewAutoBackend creates an AutoBackend which prefers the candidates in order.
+func NewAutoBackend(candidates ...Backend) *AutoBackend {
+	return &AutoBackend{candidates: candidates}
+}
+
+func (b *AutoBackend) Name() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.selectLocked(0)
+	if b.current >= len(b.candidates) {
+		return ""
+	}
+	return b.candidates[b.current].Name()
+}
+
+func (b *AutoBackend) Available() error {
+	if b.Name() == "" {
+		return errors.New("no tcp statistics backend is available")
+	}
+	return nil
+}
+
+func (b *AutoBackend) Collect() ([]map[string]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.selectLocked(0)
+
+	var errs []string
+	for b.current < len(b.candidates) {
+		backend := b.candidates[b.current]
+		stats, err := backend.Collect()
+		if err == nil {
+			return stats, nil
+		}
+		nlog.Warnf("Fail to collect tcp statistics by backend %s, err: %v", backend.Name(), err)
+		errs = append(errs, fmt.Sprintf("%s: %v", backend.Name(), err))
+		b.selectLocked(b.current + 1)
+	}
+	// start over in the next collection, the environment may have changed
+	b.checked = false
+	return nil, fmt.Errorf("all tcp statistics backends failed, %s", strings.Join(errs, "; "))
+}
+
+// selectLocked selects the first available backend starting from candidates[from].
+func (b *AutoBackend) selectLocked(from int) {
+	if b.checked && from <= b.current {
+		return
+	}
+	b.checked = true
+	for b.current = from; b.current < len(b.candidates); b.current++ {
+		backend := b.candidates[b.current]
+		err := backend.Available()
+		if err == nil {
+			nlog.Infof("Use %s to collect tcp statistics", backend.Name())
+			return
+		}
+		nlog.Infof("Tcp statistics backend %s is unavailable, %v", backend.Name(), err)
+	}
+}
+
+var (
+	defaultBackendOnce sync.Once
+	defaultBackend     Backend
+)
+
+// DefaultBackend prefers ss, which reports all metrics. Without the ss binary, e.g. in minimal containers, it uses the
+// eBPF iterator if the kernel supports it, and falls back to /proc/net/tcp, which only reports rto and retrans.
+func DefaultBackend() Backend {
+	defaultBackendOnce.Do(func() {
+		defaultBackend = NewAutoBackend(ssBackend{}, NewEBPFBackend(DefaultEBPFObjectPath), NewProcBackend(DefaultProcNetTCPPath))
+	})
+	return defaultBackend
+}
+
+// GetStatistic gets the statistics of network flows from the default backend.
+func GetStatistic() ([]map[string]string, error) {
+	return DefaultBackend().Collect()
+}
+
+// newConnectionMetrics returns the metrics of a connection with unsupported metrics set to "0".
+func newConnectionMetrics(localAddr, peerAddr string) map[string]string {
+	return map[string]string{
+		parse.MetricRto:              "0",
+		parse.MetricRTT:              "0",
+		parse.MetricByteSent:         "0",
+		parse.MetricBytesReceived:    "0",
+		parse.MetricTotalConnections: "1",
+		parse.MetricRetrans:          "0",
+		parse.SsLocalAddr:            localAddr,
+		parse.SsPeerAddr:             peerAddr,
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssmetrics
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/ssexporter/parse"
+)
+
+type fakeBackend struct {
+	name         string
+	availableErr error
+	collectErr   error
+	collected    int
+}
+
+func (b *fakeBackend) Name() string {
+	return b.name
+}
+
+func (b *fakeBackend) Available() error {
+	return b.availableErr
+}
+
+func (b *fakeBackend) Collect() ([]map[string]string, error) {
+	b.collected++
+	if b.collectErr != nil {
+		return nil, b.collectErr
+	}
+	return []map[string]string{newConnectionMetrics(b.name, b.name)}, nil
+}
+
+func TestAutoBackend(t *testing.T) {
+	ss := &fakeBackend{name: BackendSs, availableErr: errors.New("executable file not found")}
+	ebpf := &fakeBackend{name: BackendEBPF}
+	proc := &fakeBackend{name: BackendProc}
+	backend := NewAutoBackend(ss, ebpf, proc)
+
+	assert.Equal(t, BackendEBPF, backend.Name())
+	stats, err := backend.Collect()
+	assert.NoError(t, err)
+	assert.Equal(t, BackendEBPF, stats[0][parse.SsPeerAddr])
+	assert.Equal(t, 0, ss.collected)
+
+	// falls back to the next backend once the backend in use fails, and keeps using it
+	ebpf.collectErr = errors.New("iterator detached")
+	stats, err = backend.Collect()
+	assert.NoError(t, err)
+	assert.Equal(t, BackendProc, stats[0][parse.SsPeerAddr])
+	_, err = backend.Collect()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ebpf.collected)
+	assert.Equal(t, BackendProc, backend.Name())
+
+	// starts over after all backends fail
+	proc.collectErr = errors.New("permission denied")
+	_, err = backend.Collect()
+	assert.EqualError(t, err, "all tcp statistics backends failed, proc: permission denied")
+	ss.availableErr = nil
+	assert.Equal(t, BackendSs, backend.Name())
+
+	assert.Error(t, NewAutoBackend().Available())
+}
+
+func TestParseProcNetTCP(t *testing.T) {
+	addr := func(ip [4]byte, port string) string {
+		return fmt.Sprintf("%08X:%s", binary.NativeEndian.Uint32(ip[:]), port)
+	}
+	content := "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n" +
+		"   0: " + addr([4]byte{0, 0, 0, 0}, "1F90") + " " + addr([4]byte{0, 0, 0, 0}, "0000") +
+		" 0A 00000000:00000000 00:00000000 00000000     0        0 10001 1 0000000000000000 100 0 0 10 0\n" +
+		"   1: " + addr([4]byte{172, 18, 0, 3}, "B4A4") + " " + addr([4]byte{172, 18, 0, 4}, "0050") +
+		" 01 00000000:00000000 01:00000014 0000000A     0        0 10002 2 0000000000000000 41 4 30 10 -1\n" +
+		"   2: " + addr([4]byte{172, 18, 0, 3}, "B4A6") + " " + addr([4]byte{172, 18, 0, 4}, "0050") +
+		" 06 00000000:00000000 03:00001770 00000000     0        0 0 3 0000000000000000\n"
+
+	stats, err := parseProcNetTCP(content)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{
+			parse.SsLocalAddr: "172.18.0.3:46244", parse.SsPeerAddr: "172.18.0.4:80", parse.MetricRto: "410",
+			parse.MetricRetrans: "10", parse.MetricRTT: "0", parse.MetricByteSent: "0", parse.MetricBytesReceived: "0",
+			parse.MetricTotalConnections: "1",
+		},
+		newConnectionMetrics("172.18.0.3:46246", "172.18.0.4:80"),
+	}, stats)
+
+	_, err = parseProcNetTCP("header\n   0: 0300120A 0400120A:0050 01 00000000:00000000 00:00000000 00000000\n")
+	assert.Error(t, err)
+}
+
+func TestProcBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tcp")
+	backend := NewProcBackend(path)
+	assert.Error(t, backend.Available())
+
+	assert.NoError(t, os.WriteFile(path, []byte("  sl  local_address rem_address   st\n"), 0644))
+	assert.NoError(t, backend.Available())
+	stats, err := backend.Collect()
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestParseEBPFOutput(t *testing.T) {
+	stats, err := parseEBPFOutput("172.18.0.3:46244 172.18.0.4:80 1250 204000 4096 1024 3\n\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{{
+		parse.SsLocalAddr: "172.18.0.3:46244", parse.SsPeerAddr: "172.18.0.4:80", parse.MetricRTT: "1.25",
+		parse.MetricRto: "204", parse.MetricByteSent: "4096", parse.MetricBytesReceived: "1024",
+		parse.MetricRetrans: "3", parse.MetricTotalConnections: "1",
+	}}, stats)
+
+	_, err = parseEBPFOutput("172.18.0.3:46244 172.18.0.4:80 1250 204000\n")
+	assert.Error(t, err)
+	_, err = parseEBPFOutput("172.18.0.3:46244 172.18.0.4:80 1250 204000 4096 1024 -1\n")
+	assert.Error(t, err)
+}
+
+func TestParseCombinedLine(t *testing.T) {
+	metrics := parseCombinedLine("ESTAB 0 0 172.18.0.3:46244 172.18.0.4:80 " +
+		"cubic wscale:7,7 rto:204 rtt:0.2/0.1 bytes_sent:4096 bytes_received:1024 retrans:0/2")
+	assert.Equal(t, map[string]string{
+		parse.SsLocalAddr: "172.18.0.3:46244", parse.SsPeerAddr: "172.18.0.4:80", parse.MetricRTT: "0.2",
+		parse.MetricRto: "204", parse.MetricByteSent: "4096", parse.MetricBytesReceived: "1024",
+		parse.MetricRetrans: "2", parse.MetricTotalConnections: "1",
+	}, metrics)
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// tcpstats dumps the statistics of ipv4 tcp connections by a tcp iterator, one line per connection:
+//   {local ip}:{port} {peer ip}:{port} {srtt in us} {rto in us} {bytes sent} {bytes received} {total retrans}
+// It's read by the ebpf backend of ssmetrics. Build it with:
+//   bpftool btf dump file /sys/kernel/btf/vmlinux format c > vmlinux.h
+//   clang -O2 -g -target bpf -c tcpstats.bpf.c -o tcpstats.bpf.o
+
+#include "vmlinux.h"
+#include <bpf/bpf_helpers.h>
+#include <bpf/bpf_tracing.h>
+#include <bpf/bpf_endian.h>
+
+#define AF_INET 2
+#define TCP_LISTEN 10
+#define USEC_PER_SEC 1000000ULL
+
+extern unsigned int CONFIG_HZ __kconfig;
+
+char _license[] SEC("license") = "GPL";
+
+SEC("iter/tcp")
+int dump_tcp4(struct bpf_iter__tcp *ctx)
+{
+	struct sock_common *skc = ctx->sk_common;
+	struct seq_file *seq = ctx->meta->seq;
+	struct tcp_sock *tp;
+
+	if (skc == NULL || skc->skc_family != AF_INET || skc->skc_state == TCP_LISTEN)
+		return 0;
+	// time wait and request sockets have no statistics
+	tp = bpf_skc_to_tcp_sock(skc);
+	if (tp == NULL)
+		return 0;
+
+	BPF_SEQ_PRINTF(seq, "%pI4:%u %pI4:%u ", &skc->skc_rcv_saddr, skc->skc_num, &skc->skc_daddr,
+		       bpf_ntohs(skc->skc_dport));
+	BPF_SEQ_PRINTF(seq, "%u %llu %llu %llu %u\n", tp->srtt_us >> 3,
+		       (__u64)tp->inet_conn.icsk_rto * USEC_PER_SEC / CONFIG_HZ, tp->bytes_sent,
+		       tp->bytes_received, tp->total_retrans);
+	return 0;
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssmetrics
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	pkgcom "github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/ssexporter/parse"
+)
+
+const (
+	// ebpfProgramName is the tcp iterator in the object compiled from bpf/tcpstats.bpf.c.
+	ebpfProgramName = "dump_tcp4"
+)
+
+// DefaultEBPFObjectPath is where the object compiled from bpf/tcpstats.bpf.c is deployed.
+var DefaultEBPFObjectPath = filepath.Join(pkgcom.DefaultKusciaHomePath, "etc/ebpf/tcpstats.bpf.o")
+
+// parseEBPFOutput parses the output of the tcp iterator, every line is a connection formatted as
+//
+//	{local ip}:{port} {peer ip}:{port} {srtt in us} {rto in us} {bytes sent} {bytes received} {total retrans}
+func parseEBPFOutput(output string) ([]map[string]string, error) {
+	var tcpStatisticList []map[string]string
+	for i, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("unexpected output of ebpf iterator at line %d: %q", i+1, line)
+		}
+		values := make([]uint64, 0, 5)
+		for _, field := range fields[2:] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected output of ebpf iterator at line %d: %q", i+1, line)
+			}
+			values = append(values, value)
+		}
+
+		metrics := newConnectionMetrics(fields[0], fields[1])
+		metrics[parse.MetricRTT] = strconv.FormatFloat(float64(values[0])/1000, 'f', -1, 64)
+		metrics[parse.MetricRto] = strconv.FormatFloat(float64(values[1])/1000, 'f', -1, 64)
+		metrics[parse.MetricByteSent] = strconv.FormatUint(values[2], 10)
+		metrics[parse.MetricBytesReceived] = strconv.FormatUint(values[3], 10)
+		metrics[parse.MetricRetrans] = strconv.FormatUint(values[4], 10)
+		tcpStatisticList = append(tcpStatisticList, metrics)
+	}
+	return tcpStatisticList, nil
+}
@@ -0,0 +1,97 @@
+//go:build linux
+// +build linux
+
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssmetrics
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/link"
+)
+
+// ebpfBackend reads tcp statistics by a bpf tcp iterator, which requires linux 5.8+ with BTF and CAP_BPF.
+type ebpfBackend struct {
+	objectPath string
+
+	once    sync.Once
+	loadErr error
+	coll    *ebpf.Collection
+	iter    *link.Iter
+}
+
+func NewEBPFBackend(objectPath string) Backend {
+	return &ebpfBackend{objectPath: objectPath}
+}
+
+func (b *ebpfBackend) Name() string {
+	return BackendEBPF
+}
+
+// Available loads the iterator at the first call, the iterator stays attached until the process exits.
+func (b *ebpfBackend) Available() error {
+	b.once.Do(func() {
+		b.loadErr = b.load()
+	})
+	return b.loadErr
+}
+
+func (b *ebpfBackend) load() error {
+	if _, err := os.Stat(b.objectPath); err != nil {
+		return err
+	}
+	spec, err := ebpf.LoadCollectionSpec(b.objectPath)
+	if err != nil {
+		return fmt.Errorf("load ebpf object %s failed, %v", b.objectPath, err)
+	}
+	coll, err := ebpf.NewCollection(spec)
+	if err != nil {
+		return fmt.Errorf("load ebpf program failed, %v", err)
+	}
+	prog, ok := coll.Programs[ebpfProgramName]
+	if !ok {
+		coll.Close()
+		return fmt.Errorf("ebpf program %s not found in %s", ebpfProgramName, b.objectPath)
+	}
+	iter, err := link.AttachIter(link.IterOptions{Program: prog})
+	if err != nil {
+		coll.Close()
+		return fmt.Errorf("attach ebpf iterator failed, %v", err)
+	}
+	b.coll = coll
+	b.iter = iter
+	return nil
+}
+
+func (b *ebpfBackend) Collect() ([]map[string]string, error) {
+	if err := b.Available(); err != nil {
+		return nil, err
+	}
+	reader, err := b.iter.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return parseEBPFOutput(string(output))
+}
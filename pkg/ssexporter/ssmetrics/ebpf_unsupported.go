@@ -0,0 +1,40 @@
+//go:build !linux
+// +build !linux
+
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssmetrics
+
+import (
+	"errors"
+)
+
+type ebpfBackend struct{}
+
+func NewEBPFBackend(objectPath string) Backend {
+	return ebpfBackend{}
+}
+
+func (ebpfBackend) Name() string {
+	return BackendEBPF
+}
+
+func (ebpfBackend) Available() error {
+	return errors.New("ebpf is only supported on linux")
+}
+
+func (b ebpfBackend) Collect() ([]map[string]string, error) {
+	return nil, b.Available()
+}
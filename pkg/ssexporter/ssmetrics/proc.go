@@ -0,0 +1,122 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssmetrics
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/secretflow/kuscia/pkg/ssexporter/parse"
+)
+
+const (
+	DefaultProcNetTCPPath = "/proc/net/tcp"
+
+	// procTCPListen is the state of listening sockets, which are excluded like ss does without -a.
+	procTCPListen = "0A"
+	// procUserHZ is the unit of clock_t in /proc, which is 100 on all architectures supported by linux.
+	procUserHZ = 100
+)
+
+// procBackend parses /proc/net/tcp, it only reports rto and the retransmits not yet recovered, rtt and bytes are "0".
+type procBackend struct {
+	path string
+}
+
+func NewProcBackend(path string) Backend {
+	return &procBackend{path: path}
+}
+
+func (b *procBackend) Name() string {
+	return BackendProc
+}
+
+func (b *procBackend) Available() error {
+	_, err := os.Stat(b.path)
+	return err
+}
+
+func (b *procBackend) Collect() ([]map[string]string, error) {
+	content, err := os.ReadFile(b.path)
+	if err != nil {
+		return nil, err
+	}
+	return parseProcNetTCP(string(content))
+}
+
+// parseProcNetTCP parses the lines formatted by tcp4_seq_show, e.g.
+//
+//	sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ...
+//	 0: 0300120A:B4A4 0400120A:0050 01 00000000:00000000 02:000000A3 00000000     0        0 35891 2 ... 20 4 30 10 -1
+//
+// The fields after the socket pointer are rto and ato in clock_t, quick ack, cwnd and ssthresh.
+func parseProcNetTCP(content string) ([]map[string]string, error) {
+	lines := strings.Split(content, "\n")
+	var tcpStatisticList []map[string]string
+	// the first line is the header
+	for i := 1; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+		if len(fields) < 7 {
+			continue
+		}
+		if fields[3] == procTCPListen {
+			continue
+		}
+		localAddr, err := parseProcAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid local address at line %d, %v", i+1, err)
+		}
+		peerAddr, err := parseProcAddr(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote address at line %d, %v", i+1, err)
+		}
+
+		metrics := newConnectionMetrics(localAddr, peerAddr)
+		if retrans, err := strconv.ParseUint(fields[6], 16, 32); err == nil {
+			metrics[parse.MetricRetrans] = strconv.FormatUint(retrans, 10)
+		}
+		// sockets in TIME_WAIT have no rto
+		if len(fields) > 12 {
+			if rto, err := strconv.ParseUint(fields[12], 10, 64); err == nil {
+				metrics[parse.MetricRto] = strconv.FormatUint(rto*1000/procUserHZ, 10)
+			}
+		}
+		tcpStatisticList = append(tcpStatisticList, metrics)
+	}
+	return tcpStatisticList, nil
+}
+
+// parseProcAddr parses the address formatted as {ip}:{port} in hex, the ip is in the byte order of the host.
+func parseProcAddr(addr string) (string, error) {
+	ipHex, portHex, ok := strings.Cut(addr, ":")
+	if !ok || len(ipHex) != 8 {
+		return "", fmt.Errorf("unexpected address %q", addr)
+	}
+	ipValue, err := strconv.ParseUint(ipHex, 16, 32)
+	if err != nil {
+		return "", fmt.Errorf("unexpected address %q", addr)
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", fmt.Errorf("unexpected address %q", addr)
+	}
+	ip := make(net.IP, net.IPv4len)
+	binary.NativeEndian.PutUint32(ip, uint32(ipValue))
+	return fmt.Sprintf("%s:%d", ip.String(), port), nil
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package ssmetrics collect metrics from ss
+// Package ssmetrics collect metrics of tcp connections from ss, or ebpf and /proc/net/tcp if ss is unavailable
 package ssmetrics
 
 import (
@@ -58,15 +58,7 @@ func parseCombinedLine(line string) map[string]string {
 	if len(fields) < 5 {
 		return nil
 	}
-	ssMetrics := make(map[string]string)
-	ssMetrics[parse.MetricRto] = "0"
-	ssMetrics[parse.MetricRTT] = "0"
-	ssMetrics[parse.MetricByteSent] = "0"
-	ssMetrics[parse.MetricBytesReceived] = "0"
-	ssMetrics[parse.MetricTotalConnections] = "1"
-	ssMetrics[parse.MetricRetrans] = "0"
-	ssMetrics[parse.SsLocalAddr] = fields[3]
-	ssMetrics[parse.SsPeerAddr] = fields[4]
+	ssMetrics := newConnectionMetrics(fields[3], fields[4])
 
 	for idx := 6; idx < len(fields); idx++ {
 		field := fields[idx]
@@ -227,11 +219,11 @@ func AggregateStatistics(localDomainName string, clusterResults map[string]float
 
 // GetSsMetricResults Get the results of ss statistics after filtering
 func GetSsMetricResults(runMode pkgcom.RunModeType, localDomainName string, clusterAddresses map[string][]string, AggregationMetrics map[string]string, MonitorPeriods uint) (map[string]float64, error) {
-	// get the statistics from SS
+	// get the statistics from the backend available in the environment
 	ssResults := make(map[string]float64)
-	ssMetrics, err := GetStatisticFromSs()
+	ssMetrics, err := GetStatistic()
 	if err != nil {
-		nlog.Warnf("Fail to get statistics from ss, err: %v", err)
+		nlog.Warnf("Fail to get tcp statistics, err: %v", err)
 		return ssResults, err
 	}
 	// get the source/destination IP from domain names
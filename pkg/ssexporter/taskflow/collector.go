@@ -0,0 +1,183 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package taskflow attributes network traffic to KusciaTasks. It locates the processes of task pods by the container
+// ids in their cgroups, and reads the interface counters of the network namespaces of the pods.
+package taskflow
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// metricRetention is how long a task is still exported as metrics after its traffic stops changing.
+	metricRetention = time.Hour
+
+	defaultProcRoot = "/proc"
+
+	directionTx = "tx"
+	directionRx = "rx"
+)
+
+type taskKey struct {
+	jobID  string
+	taskID string
+}
+
+type taskFlow struct {
+	counters   netCounters
+	updateTime time.Time
+}
+
+// Collector exports bytes and packets transferred by task pods of a domain, summed by KusciaTask. Pods sharing the
+// network namespace of the exporter, e.g. pods of runp, can't be told apart and are skipped.
+type Collector struct {
+	namespace  string
+	kubeClient kubernetes.Interface
+	procRoot   string
+
+	mtx      sync.Mutex
+	lastPods map[types.UID]netCounters
+	tasks    map[taskKey]*taskFlow
+
+	bytesDesc   *prometheus.Desc
+	packetsDesc *prometheus.Desc
+
+	// now is replaced in test.
+	now func() time.Time
+}
+
+func NewCollector(namespace string, kubeClient kubernetes.Interface) *Collector {
+	labels := []string{"job_id", "task_id", "direction"}
+	return &Collector{
+		namespace:  namespace,
+		kubeClient: kubeClient,
+		procRoot:   defaultProcRoot,
+		lastPods:   make(map[types.UID]netCounters),
+		tasks:      make(map[taskKey]*taskFlow),
+		bytesDesc: prometheus.NewDesc("kuscia_task_network_bytes_total",
+			"Bytes transferred by the network interfaces of task pods", labels, nil),
+		packetsDesc: prometheus.NewDesc("kuscia_task_network_packets_total",
+			"Packets transferred by the network interfaces of task pods", labels, nil),
+		now: time.Now,
+	}
+}
+
+// Refresh samples the interface counters of running task pods and accumulates the change since last refresh.
+func (c *Collector) Refresh(ctx context.Context) {
+	pods, err := c.kubeClient.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelTaskUID})
+	if err != nil {
+		nlog.Warnf("Fail to list task pods of domain %s, err: %v", c.namespace, err)
+		return
+	}
+
+	podTasks := make(map[types.UID]taskKey)
+	containerPods := make(map[string]types.UID)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		key, ok := taskKeyOf(pod.Annotations)
+		if !ok {
+			continue
+		}
+		podTasks[pod.UID] = key
+		for _, status := range pod.Status.ContainerStatuses {
+			if id := trimContainerID(status.ContainerID); id != "" {
+				containerPods[id] = pod.UID
+			}
+		}
+	}
+
+	current, err := sampleNetCounters(c.procRoot, containerPods)
+	if err != nil {
+		nlog.Warnf("Fail to sample network counters of task pods, err: %v", err)
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	now := c.now()
+	for uid, cur := range current {
+		delta := cur.sub(c.lastPods[uid])
+		if delta.isZero() {
+			continue
+		}
+		key := podTasks[uid]
+		flow, ok := c.tasks[key]
+		if !ok {
+			flow = &taskFlow{}
+			c.tasks[key] = flow
+		}
+		flow.counters = flow.counters.add(delta)
+		flow.updateTime = now
+	}
+	// pods not running any more are forgotten, their traffic stays in the task
+	c.lastPods = current
+	for key, flow := range c.tasks {
+		if now.Sub(flow.updateTime) > metricRetention {
+			delete(c.tasks, key)
+		}
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.bytesDesc
+	ch <- c.packetsDesc
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for key, flow := range c.tasks {
+		counters := flow.counters
+		ch <- prometheus.MustNewConstMetric(c.bytesDesc, prometheus.CounterValue, float64(counters.txBytes),
+			key.jobID, key.taskID, directionTx)
+		ch <- prometheus.MustNewConstMetric(c.bytesDesc, prometheus.CounterValue, float64(counters.rxBytes),
+			key.jobID, key.taskID, directionRx)
+		ch <- prometheus.MustNewConstMetric(c.packetsDesc, prometheus.CounterValue, float64(counters.txPackets),
+			key.jobID, key.taskID, directionTx)
+		ch <- prometheus.MustNewConstMetric(c.packetsDesc, prometheus.CounterValue, float64(counters.rxPackets),
+			key.jobID, key.taskID, directionRx)
+	}
+}
+
+func taskKeyOf(annotations map[string]string) (taskKey, bool) {
+	key := taskKey{
+		jobID:  annotations[common.JobIDAnnotationKey],
+		taskID: annotations[common.TaskIDAnnotationKey],
+	}
+	return key, key.jobID != "" && key.taskID != ""
+}
+
+// trimContainerID trims the runtime of container id formatted as {runtime}://{id}.
+func trimContainerID(containerID string) string {
+	if idx := strings.Index(containerID, "://"); idx >= 0 {
+		return containerID[idx+3:]
+	}
+	return containerID
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+const netDevHeader = "Inter-|   Receive                                                |  Transmit\n" +
+	" face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed\n"
+
+func netDevLine(name string, rxBytes, rxPackets, txBytes, txPackets int) string {
+	return fmt.Sprintf("%6s: %d %d 0 0 0 0 0 0 %d %d 0 0 0 0 0 0\n", name, rxBytes, rxPackets, txBytes, txPackets)
+}
+
+// writeProcess fakes /proc/{pid} of a process in the cgroup and the network namespace.
+func writeProcess(t *testing.T, procRoot, pid, cgroup, netNs, netDev string) {
+	dir := filepath.Join(procRoot, pid)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "ns"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "net"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "cgroup"), []byte(cgroup), 0644))
+	assert.NoError(t, os.Symlink(netNs, filepath.Join(dir, "ns", "net")))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "net", "dev"), []byte(netDev), 0644))
+}
+
+func makeTaskPod(name, taskID string, containerIDs ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "alice",
+			UID:       types.UID(name + "-uid"),
+			Labels:    map[string]string{common.LabelTaskUID: "uid"},
+			Annotations: map[string]string{
+				common.JobIDAnnotationKey:  "job-1",
+				common.TaskIDAnnotationKey: taskID,
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for _, id := range containerIDs {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{ContainerID: "containerd://" + id})
+	}
+	return pod
+}
+
+func TestCollectorRefresh(t *testing.T) {
+	procRoot := t.TempDir()
+	writeProcess(t, procRoot, "self", "0::/", "net:[1]", "")
+	writeProcess(t, procRoot, "100", "0::/k8s.io/aaa111\n", "net:[2]",
+		netDevHeader+netDevLine("lo", 999, 9, 999, 9)+netDevLine("eth0", 1000, 10, 2000, 20))
+	// a process of the second container of the same pod
+	writeProcess(t, procRoot, "101", "0::/k8s.io/aaa222\n", "net:[2]", netDevHeader+netDevLine("eth0", 1000, 10, 2000, 20))
+	writeProcess(t, procRoot, "200", "0::/system.slice/cri-containerd-bbb111.scope\n", "net:[3]",
+		netDevHeader+netDevLine("eth0", 500, 5, 100, 1))
+	// runp pods share the network namespace of the exporter
+	writeProcess(t, procRoot, "300", "0::/k8s.io/ccc111\n", "net:[1]", netDevHeader+netDevLine("eth0", 7, 7, 7, 7))
+
+	podA := makeTaskPod("pod-a", "task-1", "aaa111", "aaa222")
+	podB := makeTaskPod("pod-b", "task-1", "bbb111")
+	kubeClient := kubefake.NewSimpleClientset(podA, podB, makeTaskPod("pod-c", "task-2", "ccc111"))
+	c := NewCollector("alice", kubeClient)
+	c.procRoot = procRoot
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Refresh(context.Background())
+	expected := `
+# HELP kuscia_task_network_bytes_total Bytes transferred by the network interfaces of task pods
+# TYPE kuscia_task_network_bytes_total counter
+kuscia_task_network_bytes_total{direction="rx",job_id="job-1",task_id="task-1"} 1500
+kuscia_task_network_bytes_total{direction="tx",job_id="job-1",task_id="task-1"} 2100
+# HELP kuscia_task_network_packets_total Packets transferred by the network interfaces of task pods
+# TYPE kuscia_task_network_packets_total counter
+kuscia_task_network_packets_total{direction="rx",job_id="job-1",task_id="task-1"} 15
+kuscia_task_network_packets_total{direction="tx",job_id="job-1",task_id="task-1"} 21
+`
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected)))
+
+	// traffic of pods which stopped stays in the task
+	assert.NoError(t, os.WriteFile(filepath.Join(procRoot, "100", "net", "dev"),
+		[]byte(netDevHeader+netDevLine("eth0", 1500, 15, 2000, 20)), 0644))
+	podB.Status.Phase = corev1.PodSucceeded
+	_, err := kubeClient.CoreV1().Pods("alice").UpdateStatus(context.Background(), podB, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	c.Refresh(context.Background())
+	assert.Equal(t, netCounters{rxBytes: 2000, rxPackets: 20, txBytes: 2100, txPackets: 21}, c.tasks[taskKey{"job-1", "task-1"}].counters)
+	assert.Equal(t, []types.UID{podA.UID}, func() []types.UID {
+		var uids []types.UID
+		for uid := range c.lastPods {
+			uids = append(uids, uid)
+		}
+		return uids
+	}())
+
+	// tasks without traffic for a long time are not exported
+	now = now.Add(2 * time.Hour)
+	c.Refresh(context.Background())
+	assert.Equal(t, 0, testutil.CollectAndCount(c))
+}
+
+func TestParseNetDev(t *testing.T) {
+	counters, err := parseNetDev(netDevHeader + netDevLine("lo", 1, 1, 1, 1) + netDevLine("eth0", 10, 2, 20, 3) +
+		netDevLine("eth1", 5, 1, 5, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, netCounters{rxBytes: 15, rxPackets: 3, txBytes: 25, txPackets: 4}, counters)
+
+	_, err = parseNetDev(netDevHeader + "  eth0: 10 2 0\n")
+	assert.Error(t, err)
+
+	// counters are reset
+	assert.Equal(t, netCounters{rxBytes: 1}, netCounters{rxBytes: 1}.sub(netCounters{rxBytes: 5}))
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const loopbackInterface = "lo"
+
+// netCounters is the cumulative counters of network interfaces.
+type netCounters struct {
+	rxBytes, rxPackets uint64
+	txBytes, txPackets uint64
+}
+
+func (n netCounters) add(o netCounters) netCounters {
+	return netCounters{
+		rxBytes:   n.rxBytes + o.rxBytes,
+		rxPackets: n.rxPackets + o.rxPackets,
+		txBytes:   n.txBytes + o.txBytes,
+		txPackets: n.txPackets + o.txPackets,
+	}
+}
+
+// sub returns the change from last, counters are reset if the interfaces were recreated.
+func (n netCounters) sub(last netCounters) netCounters {
+	if n.rxBytes < last.rxBytes || n.rxPackets < last.rxPackets || n.txBytes < last.txBytes ||
+		n.txPackets < last.txPackets {
+		return n
+	}
+	return netCounters{
+		rxBytes:   n.rxBytes - last.rxBytes,
+		rxPackets: n.rxPackets - last.rxPackets,
+		txBytes:   n.txBytes - last.txBytes,
+		txPackets: n.txPackets - last.txPackets,
+	}
+}
+
+func (n netCounters) isZero() bool {
+	return n == netCounters{}
+}
+
+// sampleNetCounters returns the interface counters of the network namespaces of pods whose containers are given as
+// container id -> pod uid.
+func sampleNetCounters(procRoot string, containerPods map[string]types.UID) (map[types.UID]netCounters, error) {
+	result := make(map[types.UID]netCounters)
+	if len(containerPods) == 0 {
+		return result, nil
+	}
+	selfNetNs, err := os.Readlink(filepath.Join(procRoot, "self", "ns", "net"))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		pidDir := filepath.Join(procRoot, entry.Name())
+		// processes may exit during the scan, skip them
+		cgroup, err := os.ReadFile(filepath.Join(pidDir, "cgroup"))
+		if err != nil {
+			continue
+		}
+		uid, ok := podOfCgroup(string(cgroup), containerPods)
+		if !ok {
+			continue
+		}
+		if _, sampled := result[uid]; sampled {
+			continue
+		}
+		netNs, err := os.Readlink(filepath.Join(pidDir, "ns", "net"))
+		if err != nil || netNs == selfNetNs {
+			continue
+		}
+		netDev, err := os.ReadFile(filepath.Join(pidDir, "net", "dev"))
+		if err != nil {
+			continue
+		}
+		counters, err := parseNetDev(string(netDev))
+		if err != nil {
+			return nil, fmt.Errorf("parse net dev of process %s failed, %v", entry.Name(), err)
+		}
+		result[uid] = counters
+	}
+	return result, nil
+}
+
+// podOfCgroup finds the pod whose container id is in the cgroup path, which is /k8s.io/{id} for cgroupfs and
+// cri-containerd-{id}.scope for systemd.
+func podOfCgroup(cgroup string, containerPods map[string]types.UID) (types.UID, bool) {
+	for _, line := range strings.Split(cgroup, "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		for id, uid := range containerPods {
+			if strings.Contains(parts[2], id) {
+				return uid, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseNetDev sums the counters of interfaces except loopback in the format of /proc/net/dev:
+//
+//	Inter-|   Receive                                                |  Transmit
+//	 face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+//	  eth0:    1000      10    0    0    0     0          0         0     2000      20    0    0    0     0       0          0
+func parseNetDev(content string) (netCounters, error) {
+	var counters netCounters
+	for _, line := range strings.Split(content, "\n") {
+		name, stats, ok := strings.Cut(line, ":")
+		if !ok || strings.Contains(name, "|") {
+			continue
+		}
+		if strings.TrimSpace(name) == loopbackInterface {
+			continue
+		}
+		fields := strings.Fields(stats)
+		if len(fields) < 10 {
+			return counters, fmt.Errorf("unexpected line %q", line)
+		}
+		values := make([]uint64, 0, 4)
+		for _, idx := range []int{0, 1, 8, 9} {
+			value, err := strconv.ParseUint(fields[idx], 10, 64)
+			if err != nil {
+				return counters, fmt.Errorf("unexpected line %q", line)
+			}
+			values = append(values, value)
+		}
+		counters = counters.add(netCounters{rxBytes: values[0], rxPackets: values[1], txBytes: values[2], txPackets: values[3]})
+	}
+	return counters, nil
+}
@@ -26,6 +26,25 @@ const (
 	PtpSourceNodeID = "x-ptp-source-node-id"
 	PtpTraceID      = "x-ptp-trace-id"
 	PtpTopicID      = "x-ptp-topic"
+
+	// PtpAckMode is set to "true" by pop request to keep the message in flight until it's acked.
+	PtpAckMode = "x-ptp-ack-mode"
+	// PtpMessageID is the delivery id of message popped in ack mode, used by ack or nack request.
+	PtpMessageID = "x-ptp-message-id"
+	// PtpNack is set to "true" by grpc release request to nack the message of PtpMessageID.
+	PtpNack = "x-ptp-nack"
+	// PtpStreamOp is the operation of inbound or outbound in transport stream.
+	PtpStreamOp = "x-ptp-stream-op"
+)
+
+const (
+	StreamOpPush        = "push"
+	StreamOpSubscribe   = "subscribe"
+	StreamOpUnsubscribe = "unsubscribe"
+	StreamOpAck         = "ack"
+	StreamOpNack        = "nack"
+	// StreamOpMessage is the operation of outbound delivering message of subscribed topic.
+	StreamOpMessage = "message"
 )
 
 type Outbound ptp.TransportOutbound
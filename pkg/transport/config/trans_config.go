@@ -25,6 +25,8 @@ import (
 type TransConfig struct {
 	MsqConfig  *msq.Config   `yaml:"msqConfig,omitempty"`
 	HTTPConfig *ServerConfig `yaml:"httpConfig,omitempty"`
+	// GrpcConfig enables grpc server sharing the same queues with http server if set.
+	GrpcConfig *GrpcConfig `yaml:"grpcConfig,omitempty"`
 }
 
 func LoadTransConfig(configPath string) (*TransConfig, error) {
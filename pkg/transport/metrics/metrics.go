@@ -0,0 +1,35 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	QuotaSessionBytes = "session_bytes"
+	QuotaTopicBytes   = "topic_bytes"
+	QuotaSessionRate  = "session_rate"
+	QuotaTopicRate    = "topic_rate"
+)
+
+var (
+	// QuotaRejections record the count of messages rejected because the quota is exceeded.
+	QuotaRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuscia_transport_quota_rejections_count",
+		Help: "Counts number of messages rejected by transport quota",
+	}, []string{"quota"})
+)
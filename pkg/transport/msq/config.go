@@ -14,10 +14,14 @@
 
 package msq
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	cleanStep            = 100
+	requeueInterval      = time.Second
 	sidPqInitialCapacity = 5
 
 	minTotalByteSizeLimit      = 1024 * 1024 * 100
@@ -34,18 +38,67 @@ const (
 
 	minCleanIntervalSeconds = 30
 	maxCleanIntervalSeconds = 600
+
+	minAckTimeoutSeconds = 1
+	maxAckTimeoutSeconds = 3600
+
+	defaultAckTimeoutSeconds     = 30
+	defaultMaxDeliveryCount      = 5
+	defaultDeadLetterTopicSuffix = ".dlq"
+
+	minDiskSpillSegmentByteSize = 1024 * 1024
+	minFsyncIntervalMillis      = 10
+	maxFsyncIntervalMillis      = 10000
+)
+
+const (
+	// FsyncPolicyAlways syncs segment file after every write, messages are never lost but throughput is low.
+	FsyncPolicyAlways = "always"
+	// FsyncPolicyInterval syncs segment file periodically, messages written in the last interval may be lost on crash.
+	FsyncPolicyInterval = "interval"
+	// FsyncPolicyNever leaves syncing to the operating system.
+	FsyncPolicyNever = "never"
 )
 
 type Config struct {
 	TotalByteSizeLimit      uint64 `yaml:"totalByteSizeLimit,omitempty"`
 	PerSessionByteSizeLimit uint64 `yaml:"perSessionByteSizeLimit,omitempty"`
 	TopicQueueCapacity      int    `yaml:"topicQueueCapacity,omitempty"`
+	// PerTopicByteSizeLimit limits the queued bytes of a topic, 0 means only limited by session.
+	PerTopicByteSizeLimit uint64 `yaml:"perTopicByteSizeLimit,omitempty"`
+
+	// PerSessionMessageRate and PerTopicMessageRate limit messages pushed per second, 0 means unlimited.
+	PerSessionMessageRate float64 `yaml:"perSessionMessageRate,omitempty"`
+	PerTopicMessageRate   float64 `yaml:"perTopicMessageRate,omitempty"`
+	// MessageRateBurst is the max messages allowed to push at once, default to the rate.
+	MessageRateBurst int `yaml:"messageRateBurst,omitempty"`
 
 	DeadSessionIDExpireSeconds int64 `yaml:"deadSessionIDExpireSeconds,omitempty"`
 	SessionExpireSeconds       int64 `yaml:"sessionExpireSeconds,omitempty"`
 	NormalizeActiveSeconds     int64 `yaml:"normalizeActiveSeconds,omitempty"`
 
 	CleanIntervalSeconds int64 `yaml:"cleanIntervalSeconds,omitempty"`
+
+	// AckTimeoutSeconds is how long a message popped in ack mode stays invisible before it's redelivered.
+	AckTimeoutSeconds int64 `yaml:"ackTimeoutSeconds,omitempty"`
+	// MaxDeliveryCount is how many times a message is delivered before moving to dead letter topic.
+	MaxDeliveryCount int `yaml:"maxDeliveryCount,omitempty"`
+	// DeadLetterTopicSuffix is appended to the topic name to build the dead letter topic.
+	DeadLetterTopicSuffix string `yaml:"deadLetterTopicSuffix,omitempty"`
+
+	DiskSpill *DiskSpillConfig `yaml:"diskSpill,omitempty"`
+}
+
+// DiskSpillConfig configures the disk backed queue. When enabled, all messages are persisted to
+// segment files so that they survive restarts, and message content is kept on disk only when the
+// memory buffer is full.
+type DiskSpillConfig struct {
+	Enable              bool   `yaml:"enable,omitempty"`
+	Path                string `yaml:"path,omitempty"`
+	MaxByteSize         uint64 `yaml:"maxByteSize,omitempty"`
+	SegmentByteSize     uint64 `yaml:"segmentByteSize,omitempty"`
+	FsyncPolicy         string `yaml:"fsyncPolicy,omitempty"`
+	FsyncIntervalMillis int64  `yaml:"fsyncIntervalMillis,omitempty"`
 }
 
 func DefaultMsgConfig() *Config {
@@ -57,6 +110,9 @@ func DefaultMsgConfig() *Config {
 		SessionExpireSeconds:       600,
 		NormalizeActiveSeconds:     4,
 		CleanIntervalSeconds:       120,
+		AckTimeoutSeconds:          defaultAckTimeoutSeconds,
+		MaxDeliveryCount:           defaultMaxDeliveryCount,
+		DeadLetterTopicSuffix:      defaultDeadLetterTopicSuffix,
 	}
 }
 
@@ -76,10 +132,65 @@ func (c *Config) Check() error {
 			c.PerSessionByteSizeLimit, minPerSessionByteSizeLimit)
 	}
 
+	if c.PerTopicByteSizeLimit > c.PerSessionByteSizeLimit {
+		return fmt.Errorf("PerTopicByteSizeLimit(%d) of msq should less than PerSessionByteSizeLimit(%d)",
+			c.PerTopicByteSizeLimit, c.PerSessionByteSizeLimit)
+	}
+
+	if c.PerSessionMessageRate < 0 || c.PerTopicMessageRate < 0 || c.MessageRateBurst < 0 {
+		return fmt.Errorf("message rate limit of msq should not be negative")
+	}
+
 	adjustInt64(&c.DeadSessionIDExpireSeconds, minDeadSessionIDExpireSeconds, maxDeadSessionIDExpireSeconds)
 	adjustInt64(&c.SessionExpireSeconds, minSessionExpireSeconds, maxSessionExpireSeconds)
 	adjustInt64(&c.NormalizeActiveSeconds, minNormalizeActiveSeconds, maxNormalizeActiveSeconds)
 	adjustInt64(&c.CleanIntervalSeconds, minCleanIntervalSeconds, maxCleanIntervalSeconds)
+	adjustInt64(&c.AckTimeoutSeconds, minAckTimeoutSeconds, maxAckTimeoutSeconds)
+	if c.MaxDeliveryCount <= 0 {
+		c.MaxDeliveryCount = defaultMaxDeliveryCount
+	}
+	if c.DeadLetterTopicSuffix == "" {
+		c.DeadLetterTopicSuffix = defaultDeadLetterTopicSuffix
+	}
+
+	if c.DiskSpill != nil && c.DiskSpill.Enable {
+		return c.DiskSpill.Check()
+	}
+	return nil
+}
+
+func (c *DiskSpillConfig) Check() error {
+	if c.Path == "" {
+		return fmt.Errorf("path of msq disk spill should not be empty")
+	}
+
+	if c.SegmentByteSize == 0 {
+		c.SegmentByteSize = 1024 * 1024 * 64
+	}
+	if c.MaxByteSize == 0 {
+		c.MaxByteSize = 1024 * 1024 * 1024 * 32
+	}
+	if c.SegmentByteSize < minDiskSpillSegmentByteSize {
+		return fmt.Errorf("SegmentByteSize(%d) of msq disk spill should greater than %d", c.SegmentByteSize,
+			minDiskSpillSegmentByteSize)
+	}
+	if c.SegmentByteSize > c.MaxByteSize {
+		return fmt.Errorf("SegmentByteSize(%d) of msq disk spill should less than MaxByteSize(%d)",
+			c.SegmentByteSize, c.MaxByteSize)
+	}
+
+	switch c.FsyncPolicy {
+	case "":
+		c.FsyncPolicy = FsyncPolicyInterval
+	case FsyncPolicyAlways, FsyncPolicyInterval, FsyncPolicyNever:
+	default:
+		return fmt.Errorf("unsupported fsyncPolicy(%s) of msq disk spill, should be one of %s, %s, %s",
+			c.FsyncPolicy, FsyncPolicyAlways, FsyncPolicyInterval, FsyncPolicyNever)
+	}
+	if c.FsyncIntervalMillis == 0 {
+		c.FsyncIntervalMillis = 1000
+	}
+	adjustInt64(&c.FsyncIntervalMillis, minFsyncIntervalMillis, maxFsyncIntervalMillis)
 	return nil
 }
 
@@ -0,0 +1,468 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msq
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	recordPush           byte = 1
+	recordAck            byte = 2
+	recordReleaseTopic   byte = 3
+	recordReleaseSession byte = 4
+
+	// record layout: | body length(4) | crc32 of body(4) | body |
+	// body layout:   | type(1) | seq(8) | sid length(2) | sid | topic length(2) | topic | payload |
+	recordHeaderSize = 8
+	segmentFileExt   = ".seg"
+)
+
+var errDiskStoreFull = errors.New("disk store is full")
+
+// diskLocation is where the payload of a pushed message is stored.
+type diskLocation struct {
+	seg    *segment
+	seq    uint64
+	offset int64
+	length uint32
+}
+
+type segment struct {
+	baseSeq uint64
+	path    string
+	file    *os.File
+	size    int64
+	// live is the count of pushed messages which are not acked or released yet.
+	live int
+}
+
+type diskRecord struct {
+	typ     byte
+	seq     uint64
+	sid     string
+	topic   string
+	payload []byte
+}
+
+// pendingMessage is a message recovered from disk which hasn't been consumed before restart.
+type pendingMessage struct {
+	sid   string
+	topic string
+	loc   *diskLocation
+}
+
+// diskStore is an append only log split into segment files. Pushed messages are appended to the active
+// segment, consuming a message appends an ack record. A segment file is removed once all messages in it and
+// in the segments before it are consumed, so that the log can always be replayed from the oldest segment.
+type diskStore struct {
+	mu       sync.Mutex
+	config   *DiskSpillConfig
+	segments []*segment
+	seq      uint64
+	size     int64
+	dirty    bool
+	stopCh   chan struct{}
+}
+
+func openDiskStore(config *DiskSpillConfig) (*diskStore, []*pendingMessage, error) {
+	if err := os.MkdirAll(config.Path, 0755); err != nil {
+		return nil, nil, err
+	}
+
+	ds := &diskStore{
+		config: config,
+		stopCh: make(chan struct{}),
+	}
+	pending, err := ds.recover()
+	if err != nil {
+		ds.close()
+		return nil, nil, err
+	}
+	if len(ds.segments) == 0 {
+		if err := ds.rollSegment(); err != nil {
+			ds.close()
+			return nil, nil, err
+		}
+	}
+
+	if config.FsyncPolicy == FsyncPolicyInterval {
+		go ds.syncLoop(time.Duration(config.FsyncIntervalMillis) * time.Millisecond)
+	}
+	nlog.Infof("Open msq disk store at %s, %d segments, %d messages recovered", config.Path, len(ds.segments), len(pending))
+	return ds, pending, nil
+}
+
+func (ds *diskStore) appendPush(sid, topic string, payload []byte) (*diskLocation, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	seq := ds.seq + 1
+	rec := &diskRecord{typ: recordPush, seq: seq, sid: sid, topic: topic, payload: payload}
+	seg, offset, err := ds.append(rec, true)
+	if err != nil {
+		return nil, err
+	}
+	ds.seq = seq
+	seg.live++
+	return &diskLocation{
+		seg:    seg,
+		seq:    seq,
+		offset: offset + int64(recordHeaderSize+1+8+2+len(sid)+2+len(topic)),
+		length: uint32(len(payload)),
+	}, nil
+}
+
+// read returns the payload of a pushed message.
+func (ds *diskStore) read(loc *diskLocation) ([]byte, error) {
+	buf := make([]byte, loc.length)
+	if _, err := loc.seg.file.ReadAt(buf, loc.offset); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// ack marks the message is consumed.
+func (ds *diskStore) ack(loc *diskLocation) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if _, _, err := ds.append(&diskRecord{typ: recordAck, seq: loc.seq}, false); err != nil {
+		nlog.Warnf("Failed to append ack record of message(seq=%d), %v", loc.seq, err)
+	}
+	ds.release(loc)
+}
+
+// discard decreases the live count of message, the caller should append a release record before.
+func (ds *diskStore) discard(loc *diskLocation) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.release(loc)
+}
+
+func (ds *diskStore) releaseTopic(sid, topic string) {
+	ds.appendRelease(&diskRecord{typ: recordReleaseTopic, sid: sid, topic: topic})
+}
+
+func (ds *diskStore) releaseSession(sid string) {
+	ds.appendRelease(&diskRecord{typ: recordReleaseSession, sid: sid})
+}
+
+func (ds *diskStore) appendRelease(rec *diskRecord) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if _, _, err := ds.append(rec, false); err != nil {
+		nlog.Warnf("Failed to append release record of session(%s) topic(%s), %v", rec.sid, rec.topic, err)
+	}
+}
+
+func (ds *diskStore) close() {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	select {
+	case <-ds.stopCh:
+		return
+	default:
+		close(ds.stopCh)
+	}
+	for _, seg := range ds.segments {
+		if seg.file != nil {
+			_ = seg.file.Sync()
+			_ = seg.file.Close()
+		}
+	}
+}
+
+// release is not thread safe.
+func (ds *diskStore) release(loc *diskLocation) {
+	loc.seg.live--
+	ds.removeConsumedSegments()
+}
+
+// append is not thread safe. Push records are limited by max byte size, other records are always appended
+// because they help to free disk space.
+func (ds *diskStore) append(rec *diskRecord, limited bool) (*segment, int64, error) {
+	data := encodeRecord(rec)
+	if limited && ds.size+int64(len(data)) > int64(ds.config.MaxByteSize) {
+		return nil, 0, errDiskStoreFull
+	}
+
+	seg := ds.segments[len(ds.segments)-1]
+	if seg.size > 0 && seg.size+int64(len(data)) > int64(ds.config.SegmentByteSize) {
+		if err := ds.rollSegment(); err != nil {
+			return nil, 0, err
+		}
+		seg = ds.segments[len(ds.segments)-1]
+	}
+
+	offset := seg.size
+	if _, err := seg.file.Write(data); err != nil {
+		return nil, 0, err
+	}
+	seg.size += int64(len(data))
+	ds.size += int64(len(data))
+
+	switch ds.config.FsyncPolicy {
+	case FsyncPolicyAlways:
+		if err := seg.file.Sync(); err != nil {
+			return nil, 0, err
+		}
+	case FsyncPolicyInterval:
+		ds.dirty = true
+	}
+	return seg, offset, nil
+}
+
+// rollSegment is not thread safe.
+func (ds *diskStore) rollSegment() error {
+	if len(ds.segments) > 0 {
+		if err := ds.segments[len(ds.segments)-1].file.Sync(); err != nil {
+			return err
+		}
+	}
+
+	// segments are named by the first sequence they may contain, make sure the name is unique
+	// even if the last segment has no push record.
+	baseSeq := ds.seq + 1
+	if n := len(ds.segments); n > 0 && ds.segments[n-1].baseSeq >= baseSeq {
+		baseSeq = ds.segments[n-1].baseSeq + 1
+	}
+	path := filepath.Join(ds.config.Path, fmt.Sprintf("%020d%s", baseSeq, segmentFileExt))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	ds.segments = append(ds.segments, &segment{baseSeq: baseSeq, path: path, file: file})
+	return nil
+}
+
+// removeConsumedSegments is not thread safe, the active segment is never removed.
+func (ds *diskStore) removeConsumedSegments() {
+	for len(ds.segments) > 1 && ds.segments[0].live <= 0 {
+		seg := ds.segments[0]
+		_ = seg.file.Close()
+		if err := os.Remove(seg.path); err != nil {
+			nlog.Warnf("Failed to remove msq segment %s, %v", seg.path, err)
+		}
+		ds.size -= seg.size
+		ds.segments = ds.segments[1:]
+	}
+}
+
+func (ds *diskStore) syncLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ds.stopCh:
+			return
+		case <-ticker.C:
+			ds.mu.Lock()
+			if ds.dirty {
+				if err := ds.segments[len(ds.segments)-1].file.Sync(); err != nil {
+					nlog.Warnf("Failed to sync msq segment, %v", err)
+				}
+				ds.dirty = false
+			}
+			ds.mu.Unlock()
+		}
+	}
+}
+
+// recover replays all segments and returns messages which are not consumed, ordered by push sequence.
+func (ds *diskStore) recover() ([]*pendingMessage, error) {
+	entries, err := os.ReadDir(ds.config.Path)
+	if err != nil {
+		return nil, err
+	}
+	var baseSeqs []uint64
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), segmentFileExt) {
+			continue
+		}
+		baseSeq, err := strconv.ParseUint(strings.TrimSuffix(e.Name(), segmentFileExt), 10, 64)
+		if err != nil {
+			nlog.Warnf("Skip unknown file %s in msq disk store", e.Name())
+			continue
+		}
+		baseSeqs = append(baseSeqs, baseSeq)
+	}
+	sort.Slice(baseSeqs, func(i, j int) bool { return baseSeqs[i] < baseSeqs[j] })
+
+	pending := make(map[uint64]*pendingMessage)
+	for i, baseSeq := range baseSeqs {
+		path := filepath.Join(ds.config.Path, fmt.Sprintf("%020d%s", baseSeq, segmentFileExt))
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		seg := &segment{baseSeq: baseSeq, path: path, file: file}
+		ds.segments = append(ds.segments, seg)
+
+		validSize, err := ds.replaySegment(seg, pending)
+		if err != nil {
+			return nil, err
+		}
+		if validSize < seg.size {
+			if i != len(baseSeqs)-1 {
+				return nil, fmt.Errorf("msq segment %s is corrupted at offset %d", path, validSize)
+			}
+			// the tail of last segment may be partially written before crash
+			nlog.Warnf("Truncate msq segment %s from %d to %d bytes", path, seg.size, validSize)
+			if err := file.Truncate(validSize); err != nil {
+				return nil, err
+			}
+			seg.size = validSize
+		}
+		ds.size += seg.size
+	}
+
+	result := make([]*pendingMessage, 0, len(pending))
+	for _, msg := range pending {
+		msg.loc.seg.live++
+		result = append(result, msg)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].loc.seq < result[j].loc.seq })
+	ds.removeConsumedSegments()
+	return result, nil
+}
+
+func (ds *diskStore) replaySegment(seg *segment, pending map[uint64]*pendingMessage) (int64, error) {
+	info, err := seg.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	seg.size = info.Size()
+
+	reader := bufio.NewReader(io.NewSectionReader(seg.file, 0, seg.size))
+	var offset int64
+	for {
+		rec, n, err := decodeRecord(reader)
+		if err != nil {
+			if err != io.EOF {
+				nlog.Warnf("Invalid record in msq segment %s at offset %d, %v", seg.path, offset, err)
+			}
+			return offset, nil
+		}
+
+		switch rec.typ {
+		case recordPush:
+			pending[rec.seq] = &pendingMessage{
+				sid:   rec.sid,
+				topic: rec.topic,
+				loc: &diskLocation{
+					seg:    seg,
+					seq:    rec.seq,
+					offset: offset + int64(n-len(rec.payload)),
+					length: uint32(len(rec.payload)),
+				},
+			}
+			if rec.seq > ds.seq {
+				ds.seq = rec.seq
+			}
+		case recordAck:
+			delete(pending, rec.seq)
+		case recordReleaseTopic:
+			for seq, msg := range pending {
+				if msg.sid == rec.sid && msg.topic == rec.topic {
+					delete(pending, seq)
+				}
+			}
+		case recordReleaseSession:
+			for seq, msg := range pending {
+				if msg.sid == rec.sid {
+					delete(pending, seq)
+				}
+			}
+		}
+		offset += int64(n)
+	}
+}
+
+func encodeRecord(rec *diskRecord) []byte {
+	bodyLen := 1 + 8 + 2 + len(rec.sid) + 2 + len(rec.topic) + len(rec.payload)
+	data := make([]byte, recordHeaderSize+bodyLen)
+	body := data[recordHeaderSize:]
+	body[0] = rec.typ
+	binary.BigEndian.PutUint64(body[1:], rec.seq)
+	pos := 9
+	binary.BigEndian.PutUint16(body[pos:], uint16(len(rec.sid)))
+	pos += 2
+	pos += copy(body[pos:], rec.sid)
+	binary.BigEndian.PutUint16(body[pos:], uint16(len(rec.topic)))
+	pos += 2
+	pos += copy(body[pos:], rec.topic)
+	copy(body[pos:], rec.payload)
+
+	binary.BigEndian.PutUint32(data, uint32(bodyLen))
+	binary.BigEndian.PutUint32(data[4:], crc32.ChecksumIEEE(body))
+	return data
+}
+
+// decodeRecord returns the record and the total bytes it takes.
+func decodeRecord(reader io.Reader) (*diskRecord, int, error) {
+	header := make([]byte, recordHeaderSize)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, 0, errors.New("incomplete record header")
+		}
+		return nil, 0, err
+	}
+	bodyLen := binary.BigEndian.Uint32(header)
+	if bodyLen < 1+8+2+2 {
+		return nil, 0, fmt.Errorf("invalid record length %d", bodyLen)
+	}
+	body := make([]byte, bodyLen)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return nil, 0, errors.New("incomplete record body")
+	}
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, 0, errors.New("record checksum mismatch")
+	}
+
+	rec := &diskRecord{typ: body[0], seq: binary.BigEndian.Uint64(body[1:])}
+	pos := 9
+	sidLen := int(binary.BigEndian.Uint16(body[pos:]))
+	pos += 2
+	if pos+sidLen+2 > len(body) {
+		return nil, 0, errors.New("invalid record sid length")
+	}
+	rec.sid = string(body[pos : pos+sidLen])
+	pos += sidLen
+	topicLen := int(binary.BigEndian.Uint16(body[pos:]))
+	pos += 2
+	if pos+topicLen > len(body) {
+		return nil, 0, errors.New("invalid record topic length")
+	}
+	rec.topic = string(body[pos : pos+topicLen])
+	pos += topicLen
+	rec.payload = body[pos:]
+	return rec, recordHeaderSize + int(bodyLen), nil
+}
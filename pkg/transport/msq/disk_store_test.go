@@ -0,0 +1,207 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msq
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDiskSessionManager(t *testing.T, path string) *SessionManager {
+	config := &Config{
+		TotalByteSizeLimit:         1024,
+		PerSessionByteSizeLimit:    512,
+		TopicQueueCapacity:         5,
+		DeadSessionIDExpireSeconds: 6,
+		SessionExpireSeconds:       4,
+		CleanIntervalSeconds:       2,
+		NormalizeActiveSeconds:     1,
+		DiskSpill: &DiskSpillConfig{
+			Enable:          true,
+			Path:            path,
+			MaxByteSize:     1024 * 1024,
+			SegmentByteSize: 2048,
+			FsyncPolicy:     FsyncPolicyAlways,
+		},
+	}
+	sm, err := OpenSessionManager(config)
+	assert.NoError(t, err)
+	return sm
+}
+
+func segmentCount(t *testing.T, path string) int {
+	files, err := filepath.Glob(filepath.Join(path, "*"+segmentFileExt))
+	assert.NoError(t, err)
+	return len(files)
+}
+
+func TestDiskSpillConfigCheck(t *testing.T) {
+	t.Parallel()
+	config := &DiskSpillConfig{Enable: true}
+	assert.Error(t, config.Check())
+
+	config.Path = t.TempDir()
+	assert.NoError(t, config.Check())
+	assert.Equal(t, FsyncPolicyInterval, config.FsyncPolicy)
+	assert.Equal(t, int64(1000), config.FsyncIntervalMillis)
+
+	config.FsyncPolicy = "sometimes"
+	assert.Error(t, config.Check())
+}
+
+func TestSessionManagerSpillWhenBufferFull(t *testing.T) {
+	t.Parallel()
+	sm := newTestDiskSessionManager(t, t.TempDir())
+	defer sm.Close()
+
+	// pushes beyond session buffer don't fail, content is spilled to disk
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, sm.Push("session1", "topic", NewMessageByStr(string(rune('a'+i))+string(make([]byte, 255))), time.Millisecond*100))
+	}
+	assert.Equal(t, uint64(512), sm.memControl.totalByteSize)
+
+	for i := 0; i < 4; i++ {
+		msg, err := sm.Pop("session1", "topic", time.Millisecond*100)
+		assert.Nil(t, err)
+		assert.NotNil(t, msg)
+		assert.Equal(t, 256, len(msg.Content))
+		assert.Equal(t, byte('a'+i), msg.Content[0])
+	}
+	assert.Equal(t, uint64(0), sm.memControl.totalByteSize)
+}
+
+func TestSessionManagerRecoverFromDisk(t *testing.T) {
+	t.Parallel()
+	path := t.TempDir()
+	sm := newTestDiskSessionManager(t, path)
+	assert.Nil(t, sm.Push("session1", "topic1", NewMessageByStr("hello"), time.Millisecond*100))
+	assert.Nil(t, sm.Push("session1", "topic1", NewMessageByStr("world"), time.Millisecond*100))
+	assert.Nil(t, sm.Push("session1", "topic2", NewMessageByStr("released"), time.Millisecond*100))
+	assert.Nil(t, sm.Push("session2", "topic", NewMessageByStr("released"), time.Millisecond*100))
+
+	msg, err := sm.Pop("session1", "topic1", time.Millisecond*100)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(msg.Content))
+	sm.ReleaseTopic("session1", "topic2")
+	sm.ReleaseSession("session2")
+	sm.Close()
+
+	sm = newTestDiskSessionManager(t, path)
+	defer sm.Close()
+	msg, err = sm.Pop("session1", "topic1", time.Millisecond*100)
+	assert.Nil(t, err)
+	assert.Equal(t, "world", string(msg.Content))
+
+	msg, err = sm.Peek("session1", "topic2")
+	assert.Nil(t, err)
+	assert.Nil(t, msg)
+	msg, err = sm.Peek("session2", "topic")
+	assert.Nil(t, err)
+	assert.Nil(t, msg)
+}
+
+func TestDiskStoreTruncateTornTail(t *testing.T) {
+	t.Parallel()
+	path := t.TempDir()
+	sm := newTestDiskSessionManager(t, path)
+	assert.Nil(t, sm.Push("session1", "topic", NewMessageByStr("hello"), time.Millisecond*100))
+	sm.Close()
+
+	// simulate a record partially written before crash
+	files, _ := filepath.Glob(filepath.Join(path, "*"+segmentFileExt))
+	assert.Equal(t, 1, len(files))
+	f, err := os.OpenFile(files[0], os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	_, _ = f.Write([]byte{0, 0, 1})
+	_ = f.Close()
+
+	sm = newTestDiskSessionManager(t, path)
+	defer sm.Close()
+	msg, err := sm.Peek("session1", "topic")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(msg.Content))
+	assert.Nil(t, sm.Push("session1", "topic", NewMessageByStr("world"), time.Millisecond*100))
+	msg, err = sm.Peek("session1", "topic")
+	assert.Nil(t, err)
+	assert.Equal(t, "world", string(msg.Content))
+}
+
+func TestDiskStoreRemoveConsumedSegments(t *testing.T) {
+	t.Parallel()
+	path := t.TempDir()
+	sm := newTestDiskSessionManager(t, path)
+	defer sm.Close()
+
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, sm.Push("session1", "topic", NewMessageByRandomStr(200), time.Millisecond*100))
+	}
+	assert.True(t, segmentCount(t, path) > 1)
+
+	for i := 0; i < 20; i++ {
+		msg, err := sm.Pop("session1", "topic", time.Millisecond*100)
+		assert.Nil(t, err)
+		assert.NotNil(t, msg)
+	}
+	assert.Equal(t, 1, segmentCount(t, path))
+}
+
+func TestDiskStoreFull(t *testing.T) {
+	t.Parallel()
+	ds, pending, err := openDiskStore(&DiskSpillConfig{
+		Path:            t.TempDir(),
+		MaxByteSize:     1024,
+		SegmentByteSize: 1024,
+		FsyncPolicy:     FsyncPolicyNever,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	defer ds.close()
+
+	loc, err := ds.appendPush("session", "topic", make([]byte, 512))
+	assert.NoError(t, err)
+	_, err = ds.appendPush("session", "topic", make([]byte, 512))
+	assert.Equal(t, errDiskStoreFull, err)
+
+	content, err := ds.read(loc)
+	assert.NoError(t, err)
+	assert.Equal(t, 512, len(content))
+}
+
+func TestSessionManagerRedeliverUnackedAfterRestart(t *testing.T) {
+	t.Parallel()
+	path := t.TempDir()
+	sm := newTestDiskSessionManager(t, path)
+	assert.Nil(t, sm.Push("session1", "topic", NewMessageByStr("hello"), time.Millisecond*100))
+	assert.Nil(t, sm.Push("session1", "topic", NewMessageByStr("world"), time.Millisecond*100))
+
+	msg, id, err := sm.PopWithAck("session1", "topic", time.Millisecond*100)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(msg.Content))
+	assert.Nil(t, sm.Ack("session1", id))
+	msg, _, err = sm.PopWithAck("session1", "topic", time.Millisecond*100)
+	assert.Nil(t, err)
+	assert.Equal(t, "world", string(msg.Content))
+	sm.Close()
+
+	sm = newTestDiskSessionManager(t, path)
+	defer sm.Close()
+	msg, err = sm.Peek("session1", "topic")
+	assert.Nil(t, err)
+	assert.Equal(t, "world", string(msg.Content))
+}
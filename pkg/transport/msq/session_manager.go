@@ -16,6 +16,7 @@ package msq
 
 import (
 	"container/heap"
+	"fmt"
 	"sync"
 	"time"
 
@@ -38,6 +39,7 @@ type SessionManager struct {
 	deadSessionIDs   *DeadSessionID
 	activeSessionIDs SessionIDPQ
 	memControl       *MemControl
+	disk             *diskStore
 }
 
 func NewSessionManager(config *Config) *SessionManager {
@@ -50,6 +52,37 @@ func NewSessionManager(config *Config) *SessionManager {
 	}
 }
 
+// OpenSessionManager creates session manager and restores messages persisted by disk store if disk spill is enabled.
+func OpenSessionManager(config *Config) (*SessionManager, error) {
+	sm := NewSessionManager(config)
+	if config.DiskSpill == nil || !config.DiskSpill.Enable {
+		return sm, nil
+	}
+
+	disk, pending, err := openDiskStore(config.DiskSpill)
+	if err != nil {
+		return nil, fmt.Errorf("open msq disk store failed, %v", err)
+	}
+	sm.disk = disk
+	for _, msg := range pending {
+		sq, transErr := sm.createSessionQueue(msg.sid)
+		if transErr != nil {
+			disk.close()
+			return nil, fmt.Errorf("restore session(%s) failed, %s", msg.sid, transErr.Error())
+		}
+		// restored message is kept on disk until it's consumed
+		sq.restore(msg.topic, &Message{loc: msg.loc})
+	}
+	return sm, nil
+}
+
+// Close flushes and closes the disk store.
+func (s *SessionManager) Close() {
+	if s.disk != nil {
+		s.disk.close()
+	}
+}
+
 func (s *SessionManager) StartCleanLoop(stopCh <-chan struct{}) {
 	round := 0
 	cleanFn := func() {
@@ -63,6 +96,7 @@ func (s *SessionManager) StartCleanLoop(stopCh <-chan struct{}) {
 
 	cleanInterval := time.Duration((s.config.CleanIntervalSeconds*1000)>>1) * time.Millisecond
 	go wait.Until(cleanFn, cleanInterval, stopCh)
+	go wait.Until(s.requeueExpiredMessages, requeueInterval, stopCh)
 }
 
 func (s *SessionManager) Push(sid, topic string, message *Message, timeout time.Duration) *transerr.TransError {
@@ -71,6 +105,14 @@ func (s *SessionManager) Push(sid, topic string, message *Message, timeout time.
 		return err
 	}
 
+	if err = sq.AllowRate(topic); err != nil {
+		return err
+	}
+
+	if s.disk != nil {
+		return s.pushToDisk(sid, topic, sq, message)
+	}
+
 	ok, leftTime := s.memControl.Prefetch(message.ByteSize(), timeout)
 	if !ok {
 		nlog.Warnf("All session queue total buffer(len=%d) size can't fit message(len=%d)", s.memControl.totalByteSizeLimit, message.ByteSize())
@@ -93,6 +135,7 @@ func (s *SessionManager) Pop(sid, topic string, timeout time.Duration) (*Message
 	message, err := sq.Pop(topic, timeout)
 	if message != nil {
 		s.memControl.Release(message.ByteSize())
+		return s.ackFromDisk(message)
 	}
 	return message, err
 }
@@ -106,16 +149,78 @@ func (s *SessionManager) Peek(sid, topic string) (*Message, *transerr.TransError
 	message, err := sq.Peek(topic)
 	if message != nil {
 		s.memControl.Release(message.ByteSize())
+		return s.ackFromDisk(message)
 	}
 	return message, err
 }
 
+// PopWithAck pops message in ack mode, the returned delivery id is used to ack or nack the message.
+func (s *SessionManager) PopWithAck(sid, topic string, timeout time.Duration) (*Message, uint64, *transerr.TransError) {
+	sq, err := s.GetOrCreateSession(sid, true)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	message, deliveryID, err := sq.PopWithAck(topic, timeout)
+	if err != nil || message == nil {
+		return nil, 0, err
+	}
+
+	// the message in flight is still held by queue, return a copy to the caller
+	content := message.Content
+	if message.spilled() {
+		var readErr error
+		if content, readErr = s.disk.read(message.loc); readErr != nil {
+			nlog.Errorf("Read spilled message(seq=%d) failed, %v", message.loc.seq, readErr)
+			_ = sq.Nack(deliveryID)
+			return nil, 0, transerr.NewTransError(transerr.ServerError)
+		}
+	}
+	return NewMessage(content), deliveryID, nil
+}
+
+// Ack confirms the message is consumed, and releases the buffer it takes.
+func (s *SessionManager) Ack(sid string, deliveryID uint64) *transerr.TransError {
+	sq, err := s.GetSession(sid, true)
+	if err != nil {
+		return err
+	}
+	if sq == nil {
+		return transerr.NewTransError(transerr.NotFound)
+	}
+
+	message, err := sq.Ack(deliveryID)
+	if err != nil {
+		return err
+	}
+	s.memControl.Release(message.ByteSize())
+	if message.loc != nil {
+		s.disk.ack(message.loc)
+	}
+	return nil
+}
+
+// Nack returns the message to topic and makes it available for redelivery.
+func (s *SessionManager) Nack(sid string, deliveryID uint64) *transerr.TransError {
+	sq, err := s.GetSession(sid, true)
+	if err != nil {
+		return err
+	}
+	if sq == nil {
+		return transerr.NewTransError(transerr.NotFound)
+	}
+	return sq.Nack(deliveryID)
+}
+
 func (s *SessionManager) ReleaseTopic(sid, topic string) {
 	sq, _ := s.GetSession(sid, false)
 	if sq == nil {
 		return
 	}
 
+	if s.disk != nil {
+		s.disk.releaseTopic(sid, topic)
+	}
 	if topicByteSize := sq.ReleaseTopic(topic); topicByteSize > 0 {
 		s.memControl.Release(topicByteSize)
 	}
@@ -130,6 +235,9 @@ func (s *SessionManager) ReleaseSession(sid string) {
 	// delete session from session map before release session
 	s.deleteSessionQueue(sid)
 
+	if s.disk != nil {
+		s.disk.releaseSession(sid)
+	}
 	if sessionByteSize := sq.ReleaseSession(); sessionByteSize > 0 {
 		s.memControl.Release(sessionByteSize)
 	}
@@ -165,6 +273,7 @@ func (s *SessionManager) cleanInactiveSession() {
 	currentTimestamp := s.normalizedNowTimestamp()
 	expireDuration := s.config.SessionExpireSeconds / s.config.NormalizeActiveSeconds
 	inactiveQueues := make([]*SessionQueue, 0)
+	inactiveSids := make([]string, 0)
 
 	s.Lock()
 	count := min(s.activeSessionIDs.Len(), cleanStep)
@@ -180,11 +289,17 @@ func (s *SessionManager) cleanInactiveSession() {
 		if session != nil {
 			s.deadSessionIDs.Push(item.sid)
 			inactiveQueues = append(inactiveQueues, session.Queue)
+			inactiveSids = append(inactiveSids, item.sid)
 		}
 		delete(s.sessions, item.sid)
 	}
 	s.Unlock()
 
+	if s.disk != nil {
+		for _, sid := range inactiveSids {
+			s.disk.releaseSession(sid)
+		}
+	}
 	for _, sq := range inactiveQueues {
 		if sessionByteSize := sq.ReleaseSession(); sessionByteSize > 0 {
 			s.memControl.Release(sessionByteSize)
@@ -192,6 +307,22 @@ func (s *SessionManager) cleanInactiveSession() {
 	}
 }
 
+func (s *SessionManager) requeueExpiredMessages() {
+	s.RLock()
+	queues := make([]*SessionQueue, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		queues = append(queues, session.Queue)
+	}
+	s.RUnlock()
+
+	now := time.Now()
+	for _, sq := range queues {
+		if count := sq.RequeueExpired(now); count > 0 {
+			nlog.Infof("Redeliver %d messages which are not acked in time", count)
+		}
+	}
+}
+
 func (s *SessionManager) getSessionAndVerifyRefresh(sid string, refresh bool) (*SessionQueue, bool) {
 	s.RLock()
 	defer s.RUnlock()
@@ -221,6 +352,9 @@ func (s *SessionManager) createSessionQueue(sid string) (*SessionQueue, *transer
 	}
 
 	sessionQueue := NewSessionQueue(s.config)
+	if s.disk != nil {
+		sessionQueue.discard = s.discardFromDisk
+	}
 	SessionIDItem := NewSessionIDItem(sid, s.normalizedNowTimestamp())
 	session := &Session{
 		Queue:      sessionQueue,
@@ -248,3 +382,57 @@ func (s *SessionManager) deleteSessionQueue(sid string) {
 func (s *SessionManager) normalizedNowTimestamp() int64 {
 	return time.Now().Unix() / s.config.NormalizeActiveSeconds
 }
+
+// pushToDisk persists the message before pushing it to session queue, the push never waits for
+// buffer. If memory buffer is full, the content is spilled and read back from disk when popped.
+func (s *SessionManager) pushToDisk(sid, topic string, sq *SessionQueue, message *Message) *transerr.TransError {
+	loc, err := s.disk.appendPush(sid, topic, message.Content)
+	if err != nil {
+		nlog.Warnf("Persist message(len=%d) of session(%s) topic(%s) failed, %v", message.ByteSize(), sid, topic, err)
+		return transerr.NewTransError(transerr.BufferOverflow)
+	}
+	message.loc = loc
+
+	if !s.memControl.TryPrefetch(message.ByteSize()) {
+		message.spill()
+	}
+	byteSize := message.ByteSize()
+	spilled, transErr := sq.PushOrSpill(topic, message)
+	if transErr != nil {
+		s.memControl.Release(byteSize)
+		s.disk.ack(loc)
+		return transErr
+	}
+	if spilled > 0 {
+		s.memControl.Release(spilled)
+	}
+	return nil
+}
+
+// ackFromDisk loads content of spilled message and marks the message consumed.
+func (s *SessionManager) ackFromDisk(message *Message) (*Message, *transerr.TransError) {
+	if message.loc == nil {
+		return message, nil
+	}
+
+	loc := message.loc
+	defer s.disk.ack(loc)
+	if message.spilled() {
+		content, err := s.disk.read(loc)
+		if err != nil {
+			nlog.Errorf("Read spilled message(seq=%d) failed, %v", loc.seq, err)
+			return nil, transerr.NewTransError(transerr.ServerError)
+		}
+		message.Content = content
+	}
+	message.loc = nil
+	return message, nil
+}
+
+func (s *SessionManager) discardFromDisk(messages []*Message) {
+	for _, message := range messages {
+		if message.loc != nil {
+			s.disk.discard(message.loc)
+		}
+	}
+}
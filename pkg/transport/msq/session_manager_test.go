@@ -293,3 +293,87 @@ func TestSessionReleasedDuringWait(t *testing.T) {
 	assert.True(t, processTime > time.Second && processTime < time.Second*2)
 	assert.NotNil(t, err)
 }
+
+func TestSessionManagerAckAndRedeliver(t *testing.T) {
+	t.Parallel()
+	sm := NewTestSessionManager()
+
+	assert.Nil(t, sm.Push("session1", "topic", NewMessageByStr("hello"), time.Millisecond*100))
+	msg, id1, err := sm.PopWithAck("session1", "topic", time.Millisecond*100)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(msg.Content))
+	// in flight message still takes the buffer
+	assert.Equal(t, uint64(5), sm.memControl.totalByteSize)
+
+	msg, _ = sm.Peek("session1", "topic")
+	assert.Nil(t, msg)
+
+	// nack makes message available immediately
+	assert.Nil(t, sm.Nack("session1", id1))
+	msg, id2, err := sm.PopWithAck("session1", "topic", time.Millisecond*100)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(msg.Content))
+	assert.NotEqual(t, id1, id2)
+	assert.NotNil(t, sm.Ack("session1", id1))
+
+	assert.Nil(t, sm.Ack("session1", id2))
+	assert.Equal(t, uint64(0), sm.memControl.totalByteSize)
+	assert.NotNil(t, sm.Ack("session1", id2))
+}
+
+func TestSessionManagerDeadLetter(t *testing.T) {
+	t.Parallel()
+	sm := NewTestSessionManager()
+	sq, err := sm.GetOrCreateSession("session1", false)
+	assert.Nil(t, err)
+	sq.maxDeliveries = 2
+	sq.ackTimeout = time.Millisecond * 10
+
+	assert.Nil(t, sm.Push("session1", "topic", NewMessageByStr("hello"), time.Millisecond*100))
+	for i := 0; i < 2; i++ {
+		msg, _, err := sm.PopWithAck("session1", "topic", time.Millisecond*100)
+		assert.Nil(t, err)
+		assert.NotNil(t, msg)
+		time.Sleep(time.Millisecond * 20)
+		sm.requeueExpiredMessages()
+	}
+
+	msg, _ := sm.Peek("session1", "topic")
+	assert.Nil(t, msg)
+	msg, _ = sm.Peek("session1", "topic.dlq")
+	assert.NotNil(t, msg)
+	assert.Equal(t, "hello", string(msg.Content))
+	assert.Equal(t, uint64(0), sm.memControl.totalByteSize)
+}
+
+func TestSessionManagerTopicByteSizeQuota(t *testing.T) {
+	t.Parallel()
+	sm := NewTestSessionManager()
+	sm.config.PerTopicByteSizeLimit = 256
+
+	assert.NotNil(t, sm.Push("session1", "topic1", NewMessageByRandomStr(257), time.Millisecond*100))
+	assert.Nil(t, sm.Push("session1", "topic1", NewMessageByRandomStr(256), time.Millisecond*100))
+	// topic is full, but other topics of the session are not affected
+	assert.NotNil(t, sm.Push("session1", "topic1", NewMessageByRandomStr(1), time.Millisecond*100))
+	assert.Nil(t, sm.Push("session1", "topic2", NewMessageByRandomStr(256), time.Millisecond*100))
+	assert.Equal(t, uint64(512), sm.memControl.totalByteSize)
+}
+
+func TestSessionManagerMessageRateQuota(t *testing.T) {
+	t.Parallel()
+	sm := NewTestSessionManager()
+	sm.config.PerSessionMessageRate = 3
+	sm.config.PerTopicMessageRate = 2
+
+	assert.Nil(t, sm.Push("session1", "topic1", NewMessageByStr("1"), time.Millisecond*100))
+	assert.Nil(t, sm.Push("session1", "topic1", NewMessageByStr("2"), time.Millisecond*100))
+	err := sm.Push("session1", "topic1", NewMessageByStr("3"), time.Millisecond*100)
+	assert.NotNil(t, err)
+	assert.Equal(t, "E0000000701", err.Error())
+
+	// the rejected message doesn't take session quota
+	assert.Nil(t, sm.Push("session1", "topic2", NewMessageByStr("4"), time.Millisecond*100))
+	assert.NotNil(t, sm.Push("session1", "topic3", NewMessageByStr("5"), time.Millisecond*100))
+	// other sessions are not affected
+	assert.Nil(t, sm.Push("session2", "topic1", NewMessageByStr("6"), time.Millisecond*100))
+}
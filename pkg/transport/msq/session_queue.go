@@ -15,11 +15,15 @@
 package msq
 
 import (
+	"math"
+	"strings"
 	"sync"
 	"time"
 
 	"gitlab.com/jonas.jasas/condchan"
+	"golang.org/x/time/rate"
 
+	"github.com/secretflow/kuscia/pkg/transport/metrics"
 	"github.com/secretflow/kuscia/pkg/transport/transerr"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
@@ -31,11 +35,26 @@ type SessionQueue struct {
 
 	released bool
 
+	topicByteSizeLimit uint64
+	sessionLimiter     *rate.Limiter
+	topicRate          float64
+	rateBurst          int
+
 	mtx      sync.Mutex
 	notFull  *condchan.CondChan
 	notEmpty *condchan.CondChan
 
 	topics map[string]*Topic
+
+	// inflight holds messages popped in ack mode but not acked yet, keyed by delivery id.
+	inflight       map[uint64]*inflightMessage
+	nextDeliveryID uint64
+	ackTimeout     time.Duration
+	maxDeliveries  int
+	deadLetter     string
+
+	// discard is called with messages dropped by releasing topic or session.
+	discard func([]*Message)
 }
 
 func NewSessionQueue(config *Config) *SessionQueue {
@@ -44,8 +63,25 @@ func NewSessionQueue(config *Config) *SessionQueue {
 		ByteSize:           0,
 		topicQueueCapacity: config.TopicQueueCapacity,
 		released:           false,
+		topicByteSizeLimit: config.PerTopicByteSizeLimit,
+		sessionLimiter:     newRateLimiter(config.PerSessionMessageRate, config.MessageRateBurst),
+		topicRate:          config.PerTopicMessageRate,
+		rateBurst:          config.MessageRateBurst,
 		mtx:                sync.Mutex{},
 		topics:             make(map[string]*Topic),
+		inflight:           make(map[uint64]*inflightMessage),
+		ackTimeout:         time.Duration(config.AckTimeoutSeconds) * time.Second,
+		maxDeliveries:      config.MaxDeliveryCount,
+		deadLetter:         config.DeadLetterTopicSuffix,
+	}
+	if sq.ackTimeout <= 0 {
+		sq.ackTimeout = defaultAckTimeoutSeconds * time.Second
+	}
+	if sq.maxDeliveries <= 0 {
+		sq.maxDeliveries = defaultMaxDeliveryCount
+	}
+	if sq.deadLetter == "" {
+		sq.deadLetter = defaultDeadLetterTopicSuffix
 	}
 
 	sq.notEmpty = condchan.New(&sq.mtx)
@@ -102,11 +138,24 @@ func (s *SessionQueue) ReleaseTopic(topic string) uint64 {
 		return 0
 	}
 
-	s.ByteSize -= topicQueue.ByteSize
+	byteSize := topicQueue.ByteSize
+	discarded := topicQueue.queue
+	for id, m := range s.inflight {
+		if m.topic == topic {
+			byteSize += m.message.ByteSize()
+			discarded = append(discarded, m.message)
+			delete(s.inflight, id)
+		}
+	}
+
+	s.ByteSize -= byteSize
 	delete(s.topics, topic)
+	if s.discard != nil {
+		s.discard(discarded)
+	}
 
 	s.notFull.Signal()
-	return topicQueue.ByteSize
+	return byteSize
 }
 
 func (s *SessionQueue) ReleaseSession() uint64 {
@@ -114,7 +163,16 @@ func (s *SessionQueue) ReleaseSession() uint64 {
 	defer s.mtx.Unlock()
 
 	s.released = true
+	if s.discard != nil {
+		for _, topicQueue := range s.topics {
+			s.discard(topicQueue.queue)
+		}
+		for _, m := range s.inflight {
+			s.discard([]*Message{m.message})
+		}
+	}
 	s.topics = nil
+	s.inflight = nil
 	byteSize := s.ByteSize
 	s.ByteSize = 0
 
@@ -123,6 +181,169 @@ func (s *SessionQueue) ReleaseSession() uint64 {
 	return byteSize
 }
 
+type inflightMessage struct {
+	topic    string
+	message  *Message
+	deadline time.Time
+}
+
+// PopWithAck pops message and keeps it in flight until it's acked. The message is redelivered
+// if it's not acked before ack timeout.
+func (s *SessionQueue) PopWithAck(topic string, timeout time.Duration) (*Message, uint64, *transerr.TransError) {
+	checkFn := func() bool {
+		return s.availableToPop(topic)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	available, err := s.waitUntil(checkFn, s.notEmpty, timeout)
+	if err != nil || !available {
+		return nil, 0, err
+	}
+
+	// message in flight still takes the buffer, so only pop it from topic
+	message := s.getTopic(topic).Pop()
+	message.deliveries++
+	s.nextDeliveryID++
+	s.inflight[s.nextDeliveryID] = &inflightMessage{
+		topic:    topic,
+		message:  message,
+		deadline: time.Now().Add(s.ackTimeout),
+	}
+	return message, s.nextDeliveryID, nil
+}
+
+// Ack removes the message from flight and returns it.
+func (s *SessionQueue) Ack(deliveryID uint64) (*Message, *transerr.TransError) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.released {
+		return nil, transerr.NewTransError(transerr.SessionReleased)
+	}
+	m, ok := s.inflight[deliveryID]
+	if !ok {
+		return nil, transerr.NewTransError(transerr.NotFound)
+	}
+	delete(s.inflight, deliveryID)
+	s.ByteSize -= m.message.ByteSize()
+
+	s.notFull.Signal()
+	return m.message, nil
+}
+
+// Nack puts the message back to topic for redelivery immediately.
+func (s *SessionQueue) Nack(deliveryID uint64) *transerr.TransError {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.released {
+		return transerr.NewTransError(transerr.SessionReleased)
+	}
+	m, ok := s.inflight[deliveryID]
+	if !ok {
+		return transerr.NewTransError(transerr.NotFound)
+	}
+	delete(s.inflight, deliveryID)
+	s.redeliver(m)
+
+	s.notEmpty.Signal()
+	return nil
+}
+
+// RequeueExpired redelivers messages which are not acked before deadline, returns the count of them.
+func (s *SessionQueue) RequeueExpired(now time.Time) int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	count := 0
+	for id, m := range s.inflight {
+		if now.Before(m.deadline) {
+			continue
+		}
+		delete(s.inflight, id)
+		s.redeliver(m)
+		count++
+	}
+	if count > 0 {
+		s.notEmpty.Signal()
+	}
+	return count
+}
+
+// redeliver is not thread safe, the message exceeding max deliveries is moved to dead letter topic.
+func (s *SessionQueue) redeliver(m *inflightMessage) {
+	if m.message.deliveries >= s.maxDeliveries && !strings.HasSuffix(m.topic, s.deadLetter) {
+		deadLetterTopic := m.topic + s.deadLetter
+		nlog.Warnf("Message of topic(%s) is not acked after %d deliveries, move to topic(%s)", m.topic,
+			m.message.deliveries, deadLetterTopic)
+		m.message.deliveries = 0
+		s.getTopic(deadLetterTopic).Push(m.message)
+		return
+	}
+	s.getTopic(m.topic).PushFront(m.message)
+}
+
+// PushOrSpill pushes the persisted message without waiting, the content is spilled to disk if
+// the session buffer is full. Returns the byte size released by spilling.
+func (s *SessionQueue) PushOrSpill(topic string, message *Message) (uint64, *transerr.TransError) {
+	s.mtx.Lock()
+	if s.released {
+		s.mtx.Unlock()
+		return 0, transerr.NewTransError(transerr.SessionReleased)
+	}
+
+	var spilled uint64
+	if !s.availableToPush(topic, message) {
+		spilled = message.spill()
+	}
+	s.innerPush(topic, message)
+	s.mtx.Unlock()
+
+	s.notEmpty.Signal()
+	return spilled, nil
+}
+
+// restore pushes the message recovered from disk regardless of buffer limit.
+func (s *SessionQueue) restore(topic string, message *Message) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.innerPush(topic, message)
+}
+
+// AllowRate checks message rate quota of session and topic, a token is taken only if both allow.
+func (s *SessionQueue) AllowRate(topic string) *transerr.TransError {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.released {
+		return transerr.NewTransError(transerr.SessionReleased)
+	}
+	if s.sessionLimiter != nil && s.sessionLimiter.Tokens() < 1 {
+		metrics.QuotaRejections.WithLabelValues(metrics.QuotaSessionRate).Inc()
+		return transerr.NewTransError(transerr.RateLimited)
+	}
+	topicLimiter := s.getTopic(topic).limiter
+	if topicLimiter != nil && !topicLimiter.Allow() {
+		metrics.QuotaRejections.WithLabelValues(metrics.QuotaTopicRate).Inc()
+		return transerr.NewTransError(transerr.RateLimited)
+	}
+	if s.sessionLimiter != nil {
+		s.sessionLimiter.Allow()
+	}
+	return nil
+}
+
+func newRateLimiter(r float64, burst int) *rate.Limiter {
+	if r <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(r))
+	}
+	return rate.NewLimiter(rate.Limit(r), burst)
+}
+
 // getTopic not thread safe
 func (s *SessionQueue) getTopic(topic string) *Topic {
 	topicQueue, exists := s.topics[topic]
@@ -131,6 +352,7 @@ func (s *SessionQueue) getTopic(topic string) *Topic {
 	}
 
 	topicQueue = NewTopicQueue(topic, s.topicQueueCapacity)
+	topicQueue.limiter = newRateLimiter(s.topicRate, s.rateBurst)
 	s.topics[topic] = topicQueue
 	return topicQueue
 }
@@ -181,8 +403,14 @@ func (s *SessionQueue) tryPush(topic string, message *Message, timeout time.Dura
 			topic, message.ByteSize(), s.ByteSizeLimit)
 		return transerr.NewTransError(transerr.BufferOverflow)
 	}
+	if s.topicByteSizeLimit > 0 && message.ByteSize() > s.topicByteSizeLimit {
+		nlog.Warnf("Session queue topic(%s) new message len(%d) max than topic buffer size(%d)",
+			topic, message.ByteSize(), s.topicByteSizeLimit)
+		metrics.QuotaRejections.WithLabelValues(metrics.QuotaTopicBytes).Inc()
+		return transerr.NewTransError(transerr.BufferOverflow)
+	}
 	checkFn := func() bool {
-		return s.availableToPush(message)
+		return s.availableToPush(topic, message)
 	}
 
 	s.mtx.Lock()
@@ -193,6 +421,11 @@ func (s *SessionQueue) tryPush(topic string, message *Message, timeout time.Dura
 	}
 	if !available {
 		nlog.Infof("Not found available buffer for topic(%s), len(%d)", topic, message.ByteSize())
+		if s.ByteSize+message.ByteSize() > s.ByteSizeLimit {
+			metrics.QuotaRejections.WithLabelValues(metrics.QuotaSessionBytes).Inc()
+		} else {
+			metrics.QuotaRejections.WithLabelValues(metrics.QuotaTopicBytes).Inc()
+		}
 		return transerr.NewTransError(transerr.BufferOverflow)
 	}
 	s.innerPush(topic, message)
@@ -214,8 +447,11 @@ func (s *SessionQueue) tryPop(topic string, timeout time.Duration) (*Message, *t
 	return s.innerPop(topic), nil
 }
 
-func (s *SessionQueue) availableToPush(message *Message) bool {
-	return s.ByteSize+message.ByteSize() <= s.ByteSizeLimit
+func (s *SessionQueue) availableToPush(topic string, message *Message) bool {
+	if s.ByteSize+message.ByteSize() > s.ByteSizeLimit {
+		return false
+	}
+	return s.topicByteSizeLimit == 0 || s.getTopic(topic).ByteSize+message.ByteSize() <= s.topicByteSizeLimit
 }
 
 func (s *SessionQueue) availableToPop(topic string) bool {
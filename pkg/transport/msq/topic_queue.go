@@ -14,13 +14,22 @@
 
 package msq
 
+import "golang.org/x/time/rate"
+
 type Message struct {
 	Content []byte
+
+	// deliveries is how many times the message has been popped in ack mode.
+	deliveries int
+	// loc is set if the message is persisted by disk store, Content is nil when the message is spilled.
+	loc *diskLocation
 }
 
 type Topic struct {
 	ByteSize uint64
 	queue    []*Message
+
+	limiter *rate.Limiter
 }
 
 func NewMessage(msg []byte) *Message {
@@ -41,6 +50,12 @@ func (t *Topic) Push(message *Message) {
 	t.queue = append(t.queue, message)
 }
 
+// PushFront puts the message back to the head of queue, used to redeliver message.
+func (t *Topic) PushFront(message *Message) {
+	t.ByteSize += message.ByteSize()
+	t.queue = append([]*Message{message}, t.queue...)
+}
+
 func (t *Topic) Pop() *Message {
 	if len(t.queue) == 0 {
 		return nil
@@ -58,6 +73,18 @@ func (t *Topic) Len() int {
 	return len(t.queue)
 }
 
+// ByteSize returns the memory size taken by the message, it is 0 for spilled message.
 func (m *Message) ByteSize() uint64 {
 	return uint64(len(m.Content))
 }
+
+func (m *Message) spilled() bool {
+	return m.loc != nil && m.Content == nil
+}
+
+// spill drops the content kept in memory and returns the released byte size.
+func (m *Message) spill() uint64 {
+	byteSize := m.ByteSize()
+	m.Content = nil
+	return byteSize
+}
@@ -78,6 +78,10 @@ func (s *Server) Start(ctx context.Context) error {
 
 	pb.RegisterPrivateTransferProtocolServer(gs, s)
 	pb.RegisterPrivateTransferTransportServer(gs, s)
+	go func() {
+		<-ctx.Done()
+		gs.Stop()
+	}()
 	err = gs.Serve(limitedListener)
 	if err != nil {
 		return err
@@ -104,6 +108,16 @@ func (s *Server) Pop(ctx context.Context, inbound *pb.PopInbound) (*pb.Transport
 		return codec.BuildTransportOutboundByErr(err), nil
 	}
 
+	if ackMode, _ := getParamFromCtx(ctx, codec.PtpAckMode); ackMode == "true" {
+		msg, deliveryID, err := s.sm.PopWithAck(params.sid, params.topic, getTimeout(ctx, inbound))
+		if err != nil || msg == nil {
+			return codec.BuildTransportOutboundByErr(err), nil
+		}
+		outbound := codec.BuildTransportOutboundByPayload(msg.Content)
+		outbound.Metadata = map[string]string{codec.PtpMessageID: strconv.FormatUint(deliveryID, 10)}
+		return outbound, nil
+	}
+
 	msg, err := s.sm.Pop(params.sid, params.topic, getTimeout(ctx, inbound))
 	if err != nil || msg == nil {
 		return codec.BuildTransportOutboundByErr(err), nil
@@ -133,6 +147,19 @@ func (s *Server) Release(ctx context.Context, inbound *pb.ReleaseInbound) (*pb.T
 		return codec.BuildTransportOutboundByErr(transerr.NewTransError(transerr.InvalidRequest)), nil
 	}
 
+	// release with message id acks or nacks the message popped in ack mode
+	if messageID, _ := getParamFromCtx(ctx, codec.PtpMessageID); len(messageID) != 0 {
+		deliveryID, err := strconv.ParseUint(messageID, 10, 64)
+		if err != nil {
+			nlog.Warnf("Invalid %s: %s", codec.PtpMessageID, messageID)
+			return codec.BuildTransportOutboundByErr(transerr.NewTransError(transerr.InvalidRequest)), nil
+		}
+		if nack, _ := getParamFromCtx(ctx, codec.PtpNack); nack == "true" {
+			return codec.BuildTransportOutboundByErr(s.sm.Nack(sid, deliveryID)), nil
+		}
+		return codec.BuildTransportOutboundByErr(s.sm.Ack(sid, deliveryID)), nil
+	}
+
 	topic := getTopic(ctx, inbound)
 
 	if len(topic) != 0 {
@@ -503,3 +503,61 @@ func TestLoadOverrideGrpcTransConfig(t *testing.T) {
 
 	assert.Equal(t, string(popOut.Payload), "123456789")
 }
+
+func TestTransportStream(t *testing.T) {
+	dial, err := grpc.Dial(testServer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer dial.Close()
+
+	md := metadata.New(map[string]string{
+		codec.PtpSessionID:    "session-stream",
+		codec.PtpSourceNodeID: "node0",
+		codec.PtpTargetNodeID: "node0",
+	})
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), md))
+	defer cancel()
+	stream, err := pb.NewPrivateTransferProtocolClient(dial).Transport(ctx)
+	assert.NoError(t, err)
+
+	recv := func(op string) *pb.Outbound {
+		out, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, op, out.Metadata[codec.PtpStreamOp])
+		return out
+	}
+
+	assert.NoError(t, stream.Send(&pb.Inbound{Metadata: map[string]string{
+		codec.PtpStreamOp: codec.StreamOpSubscribe,
+		codec.PtpTopicID:  "topic1",
+		codec.PtpAckMode:  "true",
+	}}))
+	assert.Equal(t, string(transerr.Success), recv(codec.StreamOpSubscribe).Code)
+
+	assert.NoError(t, stream.Send(&pb.Inbound{
+		Metadata: map[string]string{codec.PtpTopicID: "topic1"},
+		Payload:  NewStr("hello"),
+	}))
+	// push result and message delivery may arrive in any order
+	var message *pb.Outbound
+	for i := 0; i < 2; i++ {
+		out, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, string(transerr.Success), out.Code)
+		if out.Metadata[codec.PtpStreamOp] == codec.StreamOpMessage {
+			message = out
+		}
+	}
+	assert.NotNil(t, message)
+	assert.Equal(t, "hello", string(message.Payload))
+	assert.Equal(t, "topic1", message.Metadata[codec.PtpTopicID])
+
+	assert.NoError(t, stream.Send(&pb.Inbound{Metadata: map[string]string{
+		codec.PtpStreamOp:  codec.StreamOpAck,
+		codec.PtpMessageID: message.Metadata[codec.PtpMessageID],
+	}}))
+	assert.Equal(t, string(transerr.Success), recv(codec.StreamOpAck).Code)
+
+	assert.NoError(t, stream.Send(&pb.Inbound{Metadata: map[string]string{codec.PtpStreamOp: "unknown"}}))
+	assert.Equal(t, string(transerr.InvalidRequest), recv("unknown").Code)
+	assert.NoError(t, stream.CloseSend())
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/secretflow/kuscia/pkg/transport/codec"
+	"github.com/secretflow/kuscia/pkg/transport/msq"
+	pb "github.com/secretflow/kuscia/pkg/transport/proto/mesh"
+	"github.com/secretflow/kuscia/pkg/transport/transerr"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// subscribePollTimeout bounds how long a subscription waits for message before checking it's canceled.
+	subscribePollTimeout = time.Second
+)
+
+// transportStream serves a bidirectional transport stream. Each inbound carries an operation in metadata:
+// push sends payload to the topic, subscribe starts to deliver messages of the topic to the client,
+// unsubscribe stops it, ack and nack confirm a message delivered in ack mode.
+type transportStream struct {
+	sm     *msq.SessionManager
+	stream pb.PrivateTransferProtocol_TransportServer
+
+	sid        string
+	sourceNode string
+	targetNode string
+
+	sendMtx       sync.Mutex
+	mtx           sync.Mutex
+	subscriptions map[string]context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+func (s *Server) Transport(stream pb.PrivateTransferProtocol_TransportServer) error {
+	ctx := stream.Context()
+	sid, _ := getParamFromCtx(ctx, codec.PtpSessionID)
+	if len(sid) == 0 {
+		nlog.Warnf("Empty session-id of transport stream")
+		return stream.Send(codec.BuildInvokeOutboundByErr(transerr.NewTransError(transerr.InvalidRequest)))
+	}
+	sourceNode, _ := getParamFromCtx(ctx, codec.PtpSourceNodeID)
+	targetNode, _ := getParamFromCtx(ctx, codec.PtpTargetNodeID)
+
+	ts := &transportStream{
+		sm:            s.sm,
+		stream:        stream,
+		sid:           sid,
+		sourceNode:    sourceNode,
+		targetNode:    targetNode,
+		subscriptions: make(map[string]context.CancelFunc),
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		ts.wg.Wait()
+	}()
+
+	for {
+		inbound, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ts.handle(ctx, inbound); err != nil {
+			return err
+		}
+	}
+}
+
+func (ts *transportStream) handle(ctx context.Context, inbound *pb.Inbound) error {
+	op := inbound.GetMetadata()[codec.PtpStreamOp]
+	topic := inbound.GetMetadata()[codec.PtpTopicID]
+	if op == "" {
+		op = codec.StreamOpPush
+	}
+	switch op {
+	case codec.StreamOpPush:
+		return ts.send(op, topic, ts.push(topic, inbound))
+	case codec.StreamOpSubscribe:
+		return ts.send(op, topic, ts.subscribe(ctx, topic, inbound.GetMetadata()[codec.PtpAckMode] == "true"))
+	case codec.StreamOpUnsubscribe:
+		ts.unsubscribe(topic)
+		return ts.send(op, topic, nil)
+	case codec.StreamOpAck, codec.StreamOpNack:
+		return ts.send(op, topic, ts.ack(op, inbound.GetMetadata()[codec.PtpMessageID]))
+	default:
+		nlog.Warnf("Unknown transport stream operation: %s", op)
+		return ts.send(op, topic, transerr.NewTransError(transerr.InvalidRequest))
+	}
+}
+
+func (ts *transportStream) push(topic string, inbound *pb.Inbound) *transerr.TransError {
+	if len(topic) == 0 || len(ts.sourceNode) == 0 || len(inbound.GetPayload()) == 0 {
+		nlog.Warnf("Empty topic or %s or payload of stream push", codec.PtpSourceNodeID)
+		return transerr.NewTransError(transerr.InvalidRequest)
+	}
+	fullTopic := fmt.Sprintf("%s-%s", ts.sourceNode, topic)
+	return ts.sm.Push(ts.sid, fullTopic, msq.NewMessage(inbound.GetPayload()), getTimeout(ts.stream.Context(), inbound))
+}
+
+func (ts *transportStream) subscribe(ctx context.Context, topic string, ackMode bool) *transerr.TransError {
+	if len(topic) == 0 || len(ts.targetNode) == 0 {
+		nlog.Warnf("Empty topic or %s of stream subscribe", codec.PtpTargetNodeID)
+		return transerr.NewTransError(transerr.InvalidRequest)
+	}
+
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	if _, ok := ts.subscriptions[topic]; ok {
+		return nil
+	}
+	subCtx, cancel := context.WithCancel(ctx)
+	ts.subscriptions[topic] = cancel
+
+	ts.wg.Add(1)
+	go func() {
+		defer ts.wg.Done()
+		ts.deliver(subCtx, topic, ackMode)
+	}()
+	return nil
+}
+
+func (ts *transportStream) unsubscribe(topic string) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+	if cancel, ok := ts.subscriptions[topic]; ok {
+		cancel()
+		delete(ts.subscriptions, topic)
+	}
+}
+
+func (ts *transportStream) ack(op, messageID string) *transerr.TransError {
+	deliveryID, err := strconv.ParseUint(messageID, 10, 64)
+	if err != nil {
+		nlog.Warnf("Invalid %s: %s", codec.PtpMessageID, messageID)
+		return transerr.NewTransError(transerr.InvalidRequest)
+	}
+	if op == codec.StreamOpNack {
+		return ts.sm.Nack(ts.sid, deliveryID)
+	}
+	return ts.sm.Ack(ts.sid, deliveryID)
+}
+
+// deliver pops messages of topic and sends them to client until the subscription is canceled.
+func (ts *transportStream) deliver(ctx context.Context, topic string, ackMode bool) {
+	fullTopic := fmt.Sprintf("%s-%s", ts.targetNode, topic)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		var (
+			msg        *msq.Message
+			deliveryID uint64
+			err        *transerr.TransError
+		)
+		if ackMode {
+			msg, deliveryID, err = ts.sm.PopWithAck(ts.sid, fullTopic, subscribePollTimeout)
+		} else {
+			msg, err = ts.sm.Pop(ts.sid, fullTopic, subscribePollTimeout)
+		}
+		if err != nil {
+			// the subscription ends if session is released
+			_ = ts.send(codec.StreamOpMessage, topic, err)
+			ts.unsubscribe(topic)
+			return
+		}
+		if msg == nil {
+			continue
+		}
+
+		var metadata map[string]string
+		if ackMode {
+			metadata = map[string]string{codec.PtpMessageID: strconv.FormatUint(deliveryID, 10)}
+		}
+		outbound := codec.BuildInvokeOutboundByPayload(msg.Content)
+		if sendErr := ts.sendOutbound(codec.StreamOpMessage, topic, metadata, outbound); sendErr != nil {
+			nlog.Warnf("Send message of topic(%s) to stream failed, %v", topic, sendErr)
+			if ackMode {
+				_ = ts.sm.Nack(ts.sid, deliveryID)
+			}
+			return
+		}
+	}
+}
+
+func (ts *transportStream) send(op, topic string, err *transerr.TransError) error {
+	return ts.sendOutbound(op, topic, nil, codec.BuildInvokeOutboundByErr(err))
+}
+
+func (ts *transportStream) sendOutbound(op, topic string, metadata map[string]string, outbound *pb.Outbound) error {
+	outbound.Metadata = map[string]string{
+		codec.PtpStreamOp: op,
+		codec.PtpTopicID:  topic,
+	}
+	for k, v := range metadata {
+		outbound.Metadata[k] = v
+	}
+
+	ts.sendMtx.Lock()
+	defer ts.sendMtx.Unlock()
+	return ts.stream.Send(outbound)
+}
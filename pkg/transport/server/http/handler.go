@@ -35,7 +35,7 @@ type ReqParams struct {
 
 func (s *Server) generateHandler(method Method) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		outbound := s.factory[method](r)
+		outbound := s.factory[method](w, r)
 		body, err := s.codec.Marshal(outbound)
 		if err != nil {
 			nlog.Warnf("Marshal outbound fail :%v", outbound)
@@ -61,10 +61,12 @@ func (s *Server) registerInnerHandler() {
 		pop:     s.handlePop,
 		peek:    s.handlePeek,
 		release: s.handleRelease,
+		ack:     s.handleAck,
+		nack:    s.handleNack,
 	}
 }
 
-func (s *Server) handleInvoke(r *http.Request) *codec.Outbound {
+func (s *Server) handleInvoke(_ http.ResponseWriter, r *http.Request) *codec.Outbound {
 	params, err := getReqParams(r, true)
 	if err != nil {
 		return codec.BuildOutboundByErr(err)
@@ -79,12 +81,21 @@ func (s *Server) handleInvoke(r *http.Request) *codec.Outbound {
 	return codec.BuildOutboundByErr(err)
 }
 
-func (s *Server) handlePop(r *http.Request) *codec.Outbound {
+func (s *Server) handlePop(w http.ResponseWriter, r *http.Request) *codec.Outbound {
 	params, err := getReqParams(r, false)
 	if err != nil {
 		return codec.BuildOutboundByErr(err)
 	}
 
+	if r.Header.Get(codec.PtpAckMode) == "true" {
+		msg, deliveryID, err := s.sm.PopWithAck(params.sid, params.topic, getTimeout(r))
+		if err != nil || msg == nil {
+			return codec.BuildOutboundByErr(err)
+		}
+		w.Header().Set(codec.PtpMessageID, strconv.FormatUint(deliveryID, 10))
+		return codec.BuildOutboundByPayload(msg.Content)
+	}
+
 	msg, err := s.sm.Pop(params.sid, params.topic, getTimeout(r))
 	if err != nil || msg == nil {
 		return codec.BuildOutboundByErr(err)
@@ -93,7 +104,7 @@ func (s *Server) handlePop(r *http.Request) *codec.Outbound {
 	return codec.BuildOutboundByPayload(msg.Content)
 }
 
-func (s *Server) handlePeek(r *http.Request) *codec.Outbound {
+func (s *Server) handlePeek(_ http.ResponseWriter, r *http.Request) *codec.Outbound {
 	params, err := getReqParams(r, false)
 	if err != nil {
 		return codec.BuildOutboundByErr(err)
@@ -107,7 +118,7 @@ func (s *Server) handlePeek(r *http.Request) *codec.Outbound {
 	return codec.BuildOutboundByPayload(msg.Content)
 }
 
-func (s *Server) handleRelease(r *http.Request) *codec.Outbound {
+func (s *Server) handleRelease(_ http.ResponseWriter, r *http.Request) *codec.Outbound {
 	sid := r.Header.Get(codec.PtpSessionID)
 	if len(sid) == 0 {
 		nlog.Warnf("Empty session-id")
@@ -126,6 +137,22 @@ func (s *Server) handleRelease(r *http.Request) *codec.Outbound {
 	return codec.BuildOutboundByErr(nil)
 }
 
+func (s *Server) handleAck(_ http.ResponseWriter, r *http.Request) *codec.Outbound {
+	sid, deliveryID, err := getAckParams(r)
+	if err != nil {
+		return codec.BuildOutboundByErr(err)
+	}
+	return codec.BuildOutboundByErr(s.sm.Ack(sid, deliveryID))
+}
+
+func (s *Server) handleNack(_ http.ResponseWriter, r *http.Request) *codec.Outbound {
+	sid, deliveryID, err := getAckParams(r)
+	if err != nil {
+		return codec.BuildOutboundByErr(err)
+	}
+	return codec.BuildOutboundByErr(s.sm.Nack(sid, deliveryID))
+}
+
 func (s *Server) readMessage(r *http.Request) (*msq.Message, *transerr.TransError) {
 	if r.ContentLength > s.svrConfig.ReqBodyMaxSize {
 		return nil, transerr.NewTransError(transerr.BodyTooLarge)
@@ -173,6 +200,16 @@ func getReqParams(r *http.Request, isPush bool) (*ReqParams, *transerr.TransErro
 	}, nil
 }
 
+func getAckParams(r *http.Request) (string, uint64, *transerr.TransError) {
+	sid := r.Header.Get(codec.PtpSessionID)
+	deliveryID, err := strconv.ParseUint(r.Header.Get(codec.PtpMessageID), 10, 64)
+	if len(sid) == 0 || err != nil {
+		nlog.Warnf("Empty session-id or invalid %s", codec.PtpMessageID)
+		return "", 0, transerr.NewTransError(transerr.InvalidRequest)
+	}
+	return sid, deliveryID, nil
+}
+
 func getTimeout(r *http.Request) time.Duration {
 	val := r.URL.Query().Get(common.ParamTimeout)
 	if len(val) == 0 {
@@ -23,6 +23,7 @@ import (
 	"github.com/secretflow/kuscia/pkg/transport/codec"
 	"github.com/secretflow/kuscia/pkg/transport/config"
 	"github.com/secretflow/kuscia/pkg/transport/msq"
+	"github.com/secretflow/kuscia/pkg/transport/server/grpc"
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
 )
 
@@ -33,9 +34,11 @@ const (
 	pop     Method = "pop"
 	peek    Method = "peek"
 	release Method = "release"
+	ack     Method = "ack"
+	nack    Method = "nack"
 )
 
-type TransHandler func(r *http.Request) *codec.Outbound
+type TransHandler func(w http.ResponseWriter, r *http.Request) *codec.Outbound
 
 type Server struct {
 	svrConfig *config.ServerConfig
@@ -62,6 +65,8 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc("/v1/interconn/chan/pop", s.generateHandler(pop))
 	mux.HandleFunc("/v1/interconn/chan/peek", s.generateHandler(peek))
 	mux.HandleFunc("/v1/interconn/chan/release", s.generateHandler(release))
+	mux.HandleFunc("/v1/interconn/chan/ack", s.generateHandler(ack))
+	mux.HandleFunc("/v1/interconn/chan/nack", s.generateHandler(nack))
 
 	sr := &http.Server{
 		Addr:           fmt.Sprintf("127.0.0.1:%d", s.svrConfig.Port),
@@ -96,7 +101,21 @@ func Run(ctx context.Context, configFile string) error {
 		return err
 	}
 
-	sessionManager := msq.NewSessionManager(transConfig.MsqConfig)
+	sessionManager, err := msq.OpenSessionManager(transConfig.MsqConfig)
+	if err != nil {
+		return err
+	}
+	defer sessionManager.Close()
+
+	if transConfig.GrpcConfig != nil {
+		grpcServer := grpc.NewServer(transConfig.GrpcConfig, sessionManager)
+		go func() {
+			if err := grpcServer.Start(ctx); err != nil {
+				nlog.Errorf("Transport grpc server exit with error: %v", err)
+			}
+		}()
+	}
+
 	server := NewServer(transConfig.HTTPConfig, sessionManager)
 	return server.Start(ctx)
 }
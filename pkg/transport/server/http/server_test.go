@@ -245,6 +245,41 @@ func TestPushWait(t *testing.T) {
 	assert.Less(t, processTime, time.Millisecond*2500)   // 2.5s
 }
 
+func TestPopWithAck(t *testing.T) {
+	server.sm.Push("session10", "node0-topic1", &msq.Message{Content: NewStr("hello")}, time.Second)
+
+	popReq, _ := http.NewRequest("POST", generatePath(pop), bytes.NewBuffer(nil))
+	popReq.Header.Set(codec.PtpTopicID, "topic1")
+	popReq.Header.Set(codec.PtpSessionID, "session10")
+	popReq.Header.Set(codec.PtpTargetNodeID, "node0")
+	popReq.Header.Set(codec.PtpAckMode, "true")
+	resp, err := http.DefaultClient.Do(popReq)
+	assert.NoError(t, err)
+	messageID := resp.Header.Get(codec.PtpMessageID)
+	assert.NotEmpty(t, messageID)
+	body, _ := io.ReadAll(resp.Body)
+	outbound, _ := server.codec.UnMarshal(body)
+	assert.Equal(t, "hello", string(outbound.Payload))
+
+	nackReq, _ := http.NewRequest("POST", generatePath(nack), bytes.NewBuffer(nil))
+	nackReq.Header.Set(codec.PtpSessionID, "session10")
+	nackReq.Header.Set(codec.PtpMessageID, messageID)
+	verifyResponse(t, nackReq, transerr.Success)
+
+	// nacked message is redelivered
+	msg, _, transErr := server.sm.PopWithAck("session10", "node0-topic1", time.Second)
+	assert.Nil(t, transErr)
+	assert.Equal(t, "hello", string(msg.Content))
+
+	ackReq, _ := http.NewRequest("POST", generatePath(ack), bytes.NewBuffer(nil))
+	ackReq.Header.Set(codec.PtpSessionID, "session10")
+	ackReq.Header.Set(codec.PtpMessageID, messageID)
+	verifyResponse(t, ackReq, transerr.NotFound)
+
+	ackReq.Header.Del(codec.PtpMessageID)
+	verifyResponse(t, ackReq, transerr.InvalidRequest)
+}
+
 func TestBadRequestParam(t *testing.T) {
 	pushReq, _ := http.NewRequest("POST", generatePath(invoke), bytes.NewBuffer(NewStr("123456789")))
 	pushReq.Header.Set(codec.PtpSessionID, "session9")
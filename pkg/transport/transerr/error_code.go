@@ -63,6 +63,7 @@ const (
 	DomainNotInNetwork         ErrorCode = "E0000000618"
 	AddressUnreachable         ErrorCode = "E0000000619"
 	BufferOverflow             ErrorCode = "E0000000700"
+	RateLimited                ErrorCode = "E0000000701"
 )
 
 var errInfoMap = map[ErrorCode]string{
@@ -90,4 +91,5 @@ var errInfoMap = map[ErrorCode]string{
 	DomainNotInNetwork:         "节点或机构未组网",
 	AddressUnreachable:         "地址非法或无法访问",
 	BufferOverflow:             "缓冲区已满",
+	RateLimited:                "发送速率超过限制",
 }
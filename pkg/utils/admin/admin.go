@@ -0,0 +1,330 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin serves the authenticated admin endpoints of a kuscia process, including pprof, runtime stats, status
+// pages registered by modules and runtime toggles like log level.
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/confbus"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/queue"
+)
+
+const (
+	DefaultAddress = "127.0.0.1"
+	DefaultPort    = 9094
+
+	StatusPath    = "/status"
+	RuntimePath   = "/runtime"
+	RuntimeGCPath = "/runtime/gc"
+
+	// statusTimeout bounds the time of a single status func.
+	statusTimeout = 5 * time.Second
+	tokenFileName = "admin.token"
+)
+
+// Config is the admin server of kuscia process, which listens on 127.0.0.1:9094 by default. Requests must carry the
+// token in TokenFile as "Authorization: Bearer <token>", a random token is generated into the file if it's missing.
+type Config struct {
+	Disable   bool   `yaml:"disable,omitempty"`
+	Address   string `yaml:"address,omitempty"`
+	Port      int    `yaml:"port,omitempty"`
+	TokenFile string `yaml:"tokenFile,omitempty"`
+}
+
+func (c *Config) withDefaults(rootDir string) Config {
+	conf := *c
+	if conf.Address == "" {
+		conf.Address = DefaultAddress
+	}
+	if conf.Port == 0 {
+		conf.Port = DefaultPort
+	}
+	if conf.TokenFile == "" {
+		conf.TokenFile = filepath.Join(rootDir, common.CertPrefix, tokenFileName)
+	}
+	return conf
+}
+
+func (c *Config) Check() error {
+	if c == nil || c.Disable {
+		return nil
+	}
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("admin port %d is invalid", c.Port)
+	}
+	if c.Address != "" && net.ParseIP(c.Address) == nil {
+		return fmt.Errorf("admin address %q is not an ip", c.Address)
+	}
+	return nil
+}
+
+// StatusFunc returns the status of a module, which is rendered as json.
+type StatusFunc func(ctx context.Context) (interface{}, error)
+
+// Registry holds status funcs registered by modules.
+type Registry struct {
+	mtx   sync.RWMutex
+	funcs map[string]StatusFunc
+}
+
+func NewRegistry() *Registry {
+	return &Registry{funcs: make(map[string]StatusFunc)}
+}
+
+var defaultRegistry = NewRegistry()
+
+func init() {
+	RegisterStatus("workqueues", func(ctx context.Context) (interface{}, error) {
+		return queue.Stats(), nil
+	})
+}
+
+// RegisterStatus registers status func of module to default registry, a func registered again replaces the old one.
+func RegisterStatus(name string, fn StatusFunc) {
+	defaultRegistry.RegisterStatus(name, fn)
+}
+
+// UnregisterStatus removes status func of module from default registry.
+func UnregisterStatus(name string) {
+	defaultRegistry.UnregisterStatus(name)
+}
+
+func (r *Registry) RegisterStatus(name string, fn StatusFunc) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.funcs[name] = fn
+}
+
+func (r *Registry) UnregisterStatus(name string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	delete(r.funcs, name)
+}
+
+// Names returns the sorted names of registered modules.
+func (r *Registry) Names() []string {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	names := make([]string, 0, len(r.funcs))
+	for name := range r.funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Status calls status func of module, found is false if the module isn't registered.
+func (r *Registry) Status(ctx context.Context, name string) (status interface{}, found bool, err error) {
+	r.mtx.RLock()
+	fn, ok := r.funcs[name]
+	r.mtx.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, statusTimeout)
+	defer cancel()
+	status, err = fn(ctx)
+	return status, true, err
+}
+
+// RuntimeStats is the runtime stats of process.
+type RuntimeStats struct {
+	GoVersion     string  `json:"goVersion"`
+	NumCPU        int     `json:"numCPU"`
+	GOMAXPROCS    int     `json:"gomaxprocs"`
+	NumGoroutine  int     `json:"numGoroutine"`
+	HeapAlloc     uint64  `json:"heapAlloc"`
+	HeapInuse     uint64  `json:"heapInuse"`
+	HeapIdle      uint64  `json:"heapIdle"`
+	HeapReleased  uint64  `json:"heapReleased"`
+	HeapObjects   uint64  `json:"heapObjects"`
+	Sys           uint64  `json:"sys"`
+	NumGC         uint32  `json:"numGC"`
+	PauseTotalNs  uint64  `json:"pauseTotalNs"`
+	LastGC        string  `json:"lastGC,omitempty"`
+	GCCPUFraction float64 `json:"gcCPUFraction"`
+}
+
+func readRuntimeStats() *RuntimeStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	stats := &RuntimeStats{
+		GoVersion:     runtime.Version(),
+		NumCPU:        runtime.NumCPU(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		NumGoroutine:  runtime.NumGoroutine(),
+		HeapAlloc:     m.HeapAlloc,
+		HeapInuse:     m.HeapInuse,
+		HeapIdle:      m.HeapIdle,
+		HeapReleased:  m.HeapReleased,
+		HeapObjects:   m.HeapObjects,
+		Sys:           m.Sys,
+		NumGC:         m.NumGC,
+		PauseTotalNs:  m.PauseTotalNs,
+		GCCPUFraction: m.GCCPUFraction,
+	}
+	if m.LastGC > 0 {
+		stats.LastGC = time.Unix(0, int64(m.LastGC)).Format(time.RFC3339)
+	}
+	return stats
+}
+
+// NewHandler returns the admin handler of default registry, which rejects requests without token.
+func NewHandler(token string) http.Handler {
+	return defaultRegistry.NewHandler(token)
+}
+
+func (r *Registry) NewHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc(RuntimePath, func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, http.StatusOK, readRuntimeStats())
+	})
+	mux.HandleFunc(RuntimeGCPath, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		nlog.Infof("Admin triggered gc from %s", req.RemoteAddr)
+		runtime.GC()
+		debug.FreeOSMemory()
+		writeJSON(w, http.StatusOK, readRuntimeStats())
+	})
+	mux.HandleFunc(StatusPath, func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, http.StatusOK, map[string][]string{"modules": r.Names()})
+	})
+	mux.HandleFunc(StatusPath+"/", r.serveStatus)
+	nlog.InstallLevelHandler(mux)
+	confbus.InstallHandler(mux)
+	return withToken(token, mux)
+}
+
+func (r *Registry) serveStatus(w http.ResponseWriter, req *http.Request) {
+	name := strings.TrimPrefix(req.URL.Path, StatusPath+"/")
+	status, found, err := r.Status(req.Context(), name)
+	if !found {
+		http.Error(w, fmt.Sprintf("module %q not found", name), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("get status of %q failed, %v", name, err), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func withToken(token string, next http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get("Authorization")), expected) != 1 {
+			nlog.Warnf("Rejected admin request %s %s from %s", req.Method, req.URL.Path, req.RemoteAddr)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// LoadOrCreateToken reads the token in file, or writes a random token to it with mode 0600 if it's missing.
+func LoadOrCreateToken(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err == nil {
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("admin token file %s is empty", file)
+		}
+		return token, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(file, []byte(token), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Start serves the admin handler of default registry until ctx is done, it does nothing if the server is disabled.
+func Start(ctx context.Context, conf Config, rootDir string) error {
+	if conf.Disable {
+		nlog.Info("Admin server is disabled")
+		return nil
+	}
+	conf = conf.withDefaults(rootDir)
+	token, err := LoadOrCreateToken(conf.TokenFile)
+	if err != nil {
+		return fmt.Errorf("load admin token failed, %v", err)
+	}
+	httpServer := &http.Server{
+		Addr:              net.JoinHostPort(conf.Address, fmt.Sprint(conf.Port)),
+		Handler:           NewHandler(token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	nlog.Infof("Admin server listens on %s, token file is %s", httpServer.Addr, conf.TokenFile)
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			nlog.Errorf("Start admin server fail: %v", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = httpServer.Close()
+	}()
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func doRequest(h http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandlerRequiresToken(t *testing.T) {
+	h := NewRegistry().NewHandler("secret")
+	assert.Equal(t, http.StatusUnauthorized, doRequest(h, http.MethodGet, RuntimePath, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, doRequest(h, http.MethodGet, RuntimePath, "wrong").Code)
+	assert.Equal(t, http.StatusOK, doRequest(h, http.MethodGet, RuntimePath, "secret").Code)
+	assert.Equal(t, http.StatusOK, doRequest(h, http.MethodGet, "/debug/pprof/", "secret").Code)
+}
+
+func TestStatus(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterStatus("gateway", func(ctx context.Context) (interface{}, error) {
+		return map[string]int{"routes": 2}, nil
+	})
+	r.RegisterStatus("agent", func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("not ready")
+	})
+	h := r.NewHandler("secret")
+
+	w := doRequest(h, http.MethodGet, StatusPath, "secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"modules":["agent","gateway"]}`, w.Body.String())
+
+	w = doRequest(h, http.MethodGet, StatusPath+"/gateway", "secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"routes":2}`, w.Body.String())
+
+	assert.Equal(t, http.StatusInternalServerError, doRequest(h, http.MethodGet, StatusPath+"/agent", "secret").Code)
+	assert.Equal(t, http.StatusNotFound, doRequest(h, http.MethodGet, StatusPath+"/none", "secret").Code)
+
+	r.UnregisterStatus("gateway")
+	assert.Equal(t, http.StatusNotFound, doRequest(h, http.MethodGet, StatusPath+"/gateway", "secret").Code)
+}
+
+func TestRuntimeGC(t *testing.T) {
+	h := NewRegistry().NewHandler("secret")
+	assert.Equal(t, http.StatusMethodNotAllowed, doRequest(h, http.MethodGet, RuntimeGCPath, "secret").Code)
+
+	w := doRequest(h, http.MethodPost, RuntimeGCPath, "secret")
+	assert.Equal(t, http.StatusOK, w.Code)
+	stats := &RuntimeStats{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), stats))
+	assert.True(t, stats.NumGC > 0)
+	assert.True(t, stats.NumGoroutine > 0)
+}
+
+func TestLoadOrCreateToken(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "certs", tokenFileName)
+	token, err := LoadOrCreateToken(file)
+	assert.NoError(t, err)
+	assert.Len(t, token, 64)
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := LoadOrCreateToken(file)
+	assert.NoError(t, err)
+	assert.Equal(t, token, loaded)
+
+	assert.NoError(t, os.WriteFile(file, []byte("\n"), 0600))
+	_, err = LoadOrCreateToken(file)
+	assert.Error(t, err)
+}
+
+func TestCheckConfig(t *testing.T) {
+	assert.NoError(t, (*Config)(nil).Check())
+	assert.NoError(t, (&Config{}).Check())
+	assert.NoError(t, (&Config{Disable: true, Port: -1}).Check())
+	assert.Error(t, (&Config{Port: 70000}).Check())
+	assert.Error(t, (&Config{Address: "localhost"}).Check())
+
+	conf := (&Config{}).withDefaults("/home/kuscia")
+	assert.Equal(t, DefaultAddress, conf.Address)
+	assert.Equal(t, DefaultPort, conf.Port)
+	assert.Equal(t, "/home/kuscia/var/certs/admin.token", conf.TokenFile)
+}
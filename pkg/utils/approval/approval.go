@@ -0,0 +1,124 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package approval implements the approval decisions of cross-domain jobs, including the auto-approve policies and
+// the audit records of decisions.
+package approval
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+// Policy approves jobs initiated by partners automatically when workload approval is enabled.
+type Policy struct {
+	Name string `yaml:"name"`
+	// Initiators matches the initiator of job, empty matches all initiators.
+	Initiators []string `yaml:"initiators,omitempty"`
+	// AppImages matches the app images of job, every task of the job must use one of them. Empty matches all app images.
+	AppImages []string `yaml:"appImages,omitempty"`
+}
+
+// CheckPolicies validates policies in config.
+func CheckPolicies(policies []Policy) error {
+	names := map[string]bool{}
+	for i, p := range policies {
+		if p.Name == "" {
+			return fmt.Errorf("name of workload approve policy[%d] is empty", i)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("workload approve policy %s is duplicated", p.Name)
+		}
+		names[p.Name] = true
+	}
+	return nil
+}
+
+// Match returns the first policy matching job, or nil if no policy matches.
+func Match(policies []Policy, job *v1alpha1.KusciaJob) *Policy {
+	for i := range policies {
+		if policies[i].match(job) {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+func (p *Policy) match(job *v1alpha1.KusciaJob) bool {
+	if len(p.Initiators) > 0 && !contains(p.Initiators, job.Spec.Initiator) {
+		return false
+	}
+	if len(p.AppImages) > 0 {
+		for _, task := range job.Spec.Tasks {
+			if !contains(p.AppImages, task.AppImage) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyOperator returns the operator of decisions made by policy.
+func PolicyOperator(name string) string {
+	return "policy/" + name
+}
+
+// Decided returns whether domain has made the approval decision of job.
+func Decided(job *v1alpha1.KusciaJob, domainID string) bool {
+	_, ok := job.Status.ApproveStatus[domainID]
+	return ok
+}
+
+// Record sets the approval decision of domain and appends an audit record of it. Decisions are immutable, so it returns
+// an error if the domain has decided.
+func Record(job *v1alpha1.KusciaJob, domainID string, result v1alpha1.JobApprovePhase, reason, operator string) error {
+	if Decided(job, domainID) {
+		return fmt.Errorf("domain %s has decided %s on job %s", domainID, job.Status.ApproveStatus[domainID], job.Name)
+	}
+	if job.Status.ApproveStatus == nil {
+		job.Status.ApproveStatus = make(map[string]v1alpha1.JobApprovePhase)
+	}
+	job.Status.ApproveStatus[domainID] = result
+	job.Status.ApprovalRecords = append(job.Status.ApprovalRecords, v1alpha1.JobApprovalRecord{
+		DomainID: domainID,
+		Result:   result,
+		Reason:   reason,
+		Operator: operator,
+		Time:     metav1.Now().Rfc3339Copy(),
+	})
+	return nil
+}
+
+// Pending returns the parties of domainIDs which haven't decided on job, the initiator never needs approval.
+func Pending(job *v1alpha1.KusciaJob, domainIDs []string) []string {
+	var pending []string
+	for _, d := range domainIDs {
+		if d != job.Spec.Initiator && !Decided(job, d) {
+			pending = append(pending, d)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+func makeJob() *v1alpha1.KusciaJob {
+	return &v1alpha1.KusciaJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-1"},
+		Spec: v1alpha1.KusciaJobSpec{
+			Initiator: "alice",
+			Tasks: []v1alpha1.KusciaTaskTemplate{
+				{AppImage: "secretflow"},
+				{AppImage: "psi"},
+			},
+		},
+	}
+}
+
+func TestCheckPolicies(t *testing.T) {
+	assert.NoError(t, CheckPolicies(nil))
+	assert.NoError(t, CheckPolicies([]Policy{{Name: "a"}, {Name: "b"}}))
+	assert.Error(t, CheckPolicies([]Policy{{Initiators: []string{"alice"}}}))
+	assert.Error(t, CheckPolicies([]Policy{{Name: "a"}, {Name: "a"}}))
+}
+
+func TestMatch(t *testing.T) {
+	job := makeJob()
+	assert.Nil(t, Match(nil, job))
+	assert.Nil(t, Match([]Policy{{Name: "a", Initiators: []string{"bob"}}}, job))
+	// every task must use an allowed app image
+	assert.Nil(t, Match([]Policy{{Name: "a", AppImages: []string{"secretflow"}}}, job))
+
+	policies := []Policy{
+		{Name: "a", Initiators: []string{"bob"}},
+		{Name: "b", Initiators: []string{"alice"}, AppImages: []string{"secretflow", "psi"}},
+		{Name: "c"},
+	}
+	assert.Equal(t, "b", Match(policies, job).Name)
+}
+
+func TestRecord(t *testing.T) {
+	job := makeJob()
+	assert.Equal(t, []string{"bob", "carol"}, Pending(job, []string{"alice", "bob", "carol"}))
+
+	assert.NoError(t, Record(job, "bob", v1alpha1.JobAccepted, "ok", "kusciaapi/bob"))
+	assert.True(t, Decided(job, "bob"))
+	assert.Error(t, Record(job, "bob", v1alpha1.JobRejected, "", "kusciaapi/bob"))
+	assert.Equal(t, v1alpha1.JobAccepted, job.Status.ApproveStatus["bob"])
+	assert.Len(t, job.Status.ApprovalRecords, 1)
+	assert.Equal(t, []string{"carol"}, Pending(job, []string{"alice", "bob", "carol"}))
+}
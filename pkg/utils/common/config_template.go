@@ -0,0 +1,213 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/appconfig"
+)
+
+const (
+	configTemplateEnvDomainID      = "KUSCIA_DOMAIN_ID"
+	configTemplateEnvInputConfig   = "TASK_INPUT_CONFIG"
+	configTemplateEnvClusterDefine = "TASK_CLUSTER_DEFINE"
+)
+
+var configTemplateReg = regexp.MustCompile(`\{\{?\{\.([^{}]+)\}\}?\}`)
+
+// ConfigTemplateOptions are the options of RenderConfigTemplate.
+type ConfigTemplateOptions struct {
+	// Strict makes rendering fail on undefined variables, instead of rendering them as empty values.
+	Strict bool
+	// LookupSecret returns the value of key stored in confmanager, it's called by template function secret.
+	LookupSecret func(key string) (string, error)
+}
+
+// translateConfigTemplate replaces {{{.pattern}}} of config template with {{kuscia "pattern"}}, and returns the keys
+// referenced by the template.
+func translateConfigTemplate(templateContent string) (string, []string) {
+	var keys []string
+	seen := make(map[string]struct{})
+	result := configTemplateReg.ReplaceAllStringFunc(templateContent, func(match string) string {
+		subMatch := configTemplateReg.FindStringSubmatch(match)
+		if len(subMatch) > 1 {
+			key := strings.Split(subMatch[1], ".")[0]
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+			// replace {{{pattern}}} --> {{kuscia "pattern"}}
+			if strings.HasPrefix(subMatch[0], "{{{") {
+				reg := regexp.MustCompile(`\[(.*?)\]`)
+				subMatch[1] = reg.ReplaceAllStringFunc(subMatch[1], func(match string) string {
+					result := reg.FindStringSubmatch(match)
+					if len(result) > 1 {
+						// replace [v1.v2] to [v1#v2]
+						return strings.ReplaceAll(match, ".", "#")
+					}
+					return match
+				})
+				return "{{kuscia " + "\"" + subMatch[1] + "\"" + "}}"
+			}
+		}
+		return match
+	})
+	return result, keys
+}
+
+// ConfigTemplateKeys returns the keys referenced by config template, e.g. TASK_ID of {{.TASK_ID}}.
+func ConfigTemplateKeys(templateContent string) []string {
+	_, keys := translateConfigTemplate(templateContent)
+	return keys
+}
+
+// RenderConfigTemplate renders config template of app image with data. Besides {{.KEY}}, the template supports
+// {{{.KEY.field}}} to query a field of the json value of KEY, and the functions:
+//
+//   - kuscia "KEY.field": the same as {{{.KEY.field}}}.
+//   - env "KEY": the value of KEY, the key can be computed, e.g. env (printf "%s_ENDPOINT" partyName).
+//   - input "field": a field of the task input config.
+//   - partyName, partyRole: the name and role of the party rendering the template.
+//   - secret "KEY": the value of KEY stored in confmanager.
+func RenderConfigTemplate(templateContent string, data map[string]string, opts ConfigTemplateOptions) (string, error) {
+	configResult, _ := translateConfigTemplate(templateContent)
+
+	structData := BuildStructMap(data)
+	undefined := func(format string, args ...interface{}) (string, error) {
+		if opts.Strict {
+			return "", fmt.Errorf(format, args...)
+		}
+		return "", nil
+	}
+	queryValue := func(value any, query string) (string, error) {
+		result := QueryByFields(value, query)
+		if result == nil {
+			return undefined("%q is undefined", query)
+		}
+		if s, ok := result.(string); ok {
+			return s, nil
+		}
+		output, _ := json.Marshal(result)
+		return string(output), nil
+	}
+
+	selfParty := func() (*appconfig.Party, error) {
+		clusterDefine := &appconfig.ClusterDefine{}
+		if err := protojson.Unmarshal([]byte(data[configTemplateEnvClusterDefine]), clusterDefine); err != nil {
+			return nil, fmt.Errorf("parse %s failed, %v", configTemplateEnvClusterDefine, err)
+		}
+		idx := int(clusterDefine.SelfPartyIdx)
+		if idx < 0 || idx >= len(clusterDefine.Parties) {
+			return nil, fmt.Errorf("self party index %d of %s is out of range", idx, configTemplateEnvClusterDefine)
+		}
+		return clusterDefine.Parties[idx], nil
+	}
+
+	funcs := template.FuncMap{
+		"kuscia": func(query string) (string, error) {
+			return queryValue(structData, query)
+		},
+		"env": func(key string) (string, error) {
+			value, ok := data[strings.ToUpper(key)]
+			if !ok {
+				return undefined("env %q is undefined", key)
+			}
+			return strings.Trim(strconv.Quote(value), "\""), nil
+		},
+		"input": func(query string) (string, error) {
+			return queryValue(structData[configTemplateEnvInputConfig], query)
+		},
+		"partyName": func() (string, error) {
+			if _, ok := data[configTemplateEnvClusterDefine]; !ok {
+				if domainID, ok := data[configTemplateEnvDomainID]; ok {
+					return domainID, nil
+				}
+				return undefined("party name is undefined")
+			}
+			party, err := selfParty()
+			if err != nil {
+				return "", err
+			}
+			return party.Name, nil
+		},
+		"partyRole": func() (string, error) {
+			if _, ok := data[configTemplateEnvClusterDefine]; !ok {
+				return undefined("party role is undefined")
+			}
+			party, err := selfParty()
+			if err != nil {
+				return "", err
+			}
+			return party.Role, nil
+		},
+		"secret": func(key string) (string, error) {
+			if opts.LookupSecret == nil {
+				return "", fmt.Errorf("secret %q can't be looked up, confmanager is unavailable", key)
+			}
+			return opts.LookupSecret(key)
+		},
+	}
+
+	missingKeyOption := "missingkey=zero"
+	if opts.Strict {
+		missingKeyOption = "missingkey=error"
+	}
+	tmpl, err := template.New("config-template").Option(missingKeyOption).Funcs(funcs).Parse(configResult)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse config template, detail-> %v", err)
+	}
+
+	quoteData := make(map[string]string)
+	for k, v := range data {
+		quoteData[k] = strings.Trim(strconv.Quote(v), "\"")
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, quoteData); err != nil {
+		return "", fmt.Errorf("failed to execute config template, detail-> %v", err)
+	}
+
+	return buf.String(), nil
+}
+
+// BuildStructMap converts the json values of map to structured values, so fields of them can be queried.
+func BuildStructMap(value map[string]string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range value {
+		result[k] = v
+
+		// try json
+		var payload interface{}
+		if err := json.Unmarshal([]byte(v), &payload); err == nil {
+			switch reflect.TypeOf(payload).Kind() {
+			case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
+				result[k] = payload
+			}
+
+		}
+	}
+
+	return result
+}
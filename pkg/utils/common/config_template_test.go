@@ -0,0 +1,123 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderConfigTemplate_Functions(t *testing.T) {
+	t.Parallel()
+	data := map[string]string{
+		"KUSCIA_DOMAIN_ID":    "alice",
+		"TASK_INPUT_CONFIG":   `{"sf_datasource_config": {"alice": {"id": "default-data-source"}}}`,
+		"TASK_CLUSTER_DEFINE": `{"parties": [{"name": "alice", "role": "host"}, {"name": "bob", "role": "guest"}], "selfPartyIdx": 1}`,
+		"BOB_ENDPOINT":        "bob.svc:8080",
+	}
+	opts := ConfigTemplateOptions{
+		LookupSecret: func(key string) (string, error) {
+			if key == "db-password" {
+				return "123456", nil
+			}
+			return "", fmt.Errorf("secret %s not found", key)
+		},
+	}
+
+	got, err := RenderConfigTemplate(`{{partyName}}/{{partyRole}} {{env (printf "%s_endpoint" partyName)}} `+
+		`{{input "sf_datasource_config.alice.id"}} {{secret "db-password"}}`, data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "bob/guest bob.svc:8080 default-data-source 123456", got)
+
+	_, err = RenderConfigTemplate(`{{secret "unknown"}}`, data, opts)
+	assert.Error(t, err)
+
+	// undefined variables are rendered as empty values unless in strict mode
+	tmpl := `{{.UNKNOWN}}{{env "UNKNOWN"}}{{input "unknown"}}`
+	got, err = RenderConfigTemplate(tmpl, data, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "", got)
+
+	opts.Strict = true
+	for _, tmpl := range []string{`{{.UNKNOWN}}`, `{{env "UNKNOWN"}}`, `{{input "unknown"}}`, `{{{.TASK_INPUT_CONFIG.unknown}}}`} {
+		_, err = RenderConfigTemplate(tmpl, data, opts)
+		assert.Error(t, err, tmpl)
+	}
+}
+
+func TestRenderConfigTemplate_PartyNameWithoutClusterDefine(t *testing.T) {
+	t.Parallel()
+	got, err := RenderConfigTemplate(`{{partyName}}`, map[string]string{"KUSCIA_DOMAIN_ID": "alice"}, ConfigTemplateOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", got)
+
+	_, err = RenderConfigTemplate(`{{partyRole}}`, map[string]string{}, ConfigTemplateOptions{Strict: true})
+	assert.Error(t, err)
+}
+
+func TestMergeDataMaps_WithJsonMap(t *testing.T) {
+	t.Parallel()
+	dst := BuildStructMap(map[string]string{
+		"KEY1": "{\"xyz\":1}",
+		"KEY2": "124",
+	})
+
+	assert.Len(t, dst, 2)
+	assert.Contains(t, dst, "KEY1")
+	assert.Contains(t, dst, "KEY2")
+
+	assert.Contains(t, dst["KEY1"], "xyz")
+	assert.Equal(t, dst["KEY1"].(map[string]interface{})["xyz"], float64(1))
+}
+
+func TestBuildStructMap_WithJsonArray(t *testing.T) {
+	t.Parallel()
+	dst := BuildStructMap(map[string]string{
+		"KEY1": "[1,2,3]",
+		"KEY2": "[\"1\"]",
+	})
+
+	assert.Len(t, dst, 2)
+	assert.Contains(t, dst, "KEY1")
+	assert.Contains(t, dst, "KEY2")
+
+	assert.Len(t, dst["KEY1"], 3)
+	assert.Len(t, dst["KEY2"], 1)
+	assert.Len(t, dst["KEY2"], 1)
+	assert.Equal(t, dst["KEY1"], []interface{}{float64(1), float64(2), float64(3)})
+	assert.Equal(t, dst["KEY2"], []interface{}{"1"})
+}
+
+func TestBuildStructMap_WithJsonMapArray(t *testing.T) {
+	t.Parallel()
+	dst := BuildStructMap(map[string]string{
+		"KEY3": "[{\"xyz\":\"1\"}]",
+		"KEY4": "{\"xyz\":[\"2\"]}",
+	})
+
+	assert.Len(t, dst, 2)
+	assert.Contains(t, dst, "KEY3")
+	assert.Contains(t, dst, "KEY4")
+
+	assert.Len(t, dst["KEY3"], 1)
+	assert.NotNil(t, dst["KEY3"].([]interface{})[0])
+	assert.Contains(t, dst["KEY3"].([]interface{})[0], "xyz")
+	assert.Contains(t, dst["KEY3"].([]interface{})[0].(map[string]interface{})["xyz"], "1")
+
+	assert.Contains(t, dst["KEY4"], "xyz")
+	assert.Equal(t, dst["KEY4"].(map[string]interface{})["xyz"], []interface{}{"2"})
+}
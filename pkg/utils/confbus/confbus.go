@@ -0,0 +1,201 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package confbus distributes hot-reloadable config fields to the subsystems subscribing them, and serves the applied
+// config version as /config.
+package confbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	ConfigPath = "/config"
+
+	// Hot-reloadable fields of kuscia.yaml.
+	FieldLogLevel           = "logLevel"
+	FieldMetricUpdatePeriod = "metricUpdatePeriod"
+)
+
+// Handler applies the new value of a field, the value is the string form of the config field.
+type Handler func(value string) error
+
+type subscriber struct {
+	subsystem string
+	fn        Handler
+}
+
+// Status is the config applied, Version increases on every reload.
+type Status struct {
+	Version    int64             `json:"version"`
+	Hash       string            `json:"hash"`
+	UpdateTime string            `json:"updateTime"`
+	Applied    map[string]string `json:"applied"`
+	// RestartRequired are fields changed in config file which only take effect after restart.
+	RestartRequired []string `json:"restartRequired,omitempty"`
+	// Errors are failures of subsystems applying hot-reloadable fields.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Bus holds the current values of hot-reloadable fields and their subscribers.
+type Bus struct {
+	mtx         sync.RWMutex
+	subscribers map[string][]*subscriber
+	status      Status
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[string][]*subscriber),
+		status:      Status{Applied: make(map[string]string)},
+	}
+}
+
+var defaultBus = NewBus()
+
+// Init sets the values loaded at startup to default bus.
+func Init(hash string, values map[string]string) {
+	defaultBus.Init(hash, values)
+}
+
+// Subscribe registers handler of field to default bus.
+func Subscribe(field, subsystem string, fn Handler) {
+	defaultBus.Subscribe(field, subsystem, fn)
+}
+
+// Publish applies changes of config file to default bus.
+func Publish(hash string, values map[string]string, restartRequired []string) Status {
+	return defaultBus.Publish(hash, values, restartRequired)
+}
+
+// GetStatus returns the status of default bus.
+func GetStatus() Status {
+	return defaultBus.GetStatus()
+}
+
+// InstallHandler installs /config of default bus to mux.
+func InstallHandler(mux *http.ServeMux) {
+	defaultBus.InstallHandler(mux)
+}
+
+func (b *Bus) Init(hash string, values map[string]string) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.status = Status{
+		Version:    1,
+		Hash:       hash,
+		UpdateTime: time.Now().Format(time.RFC3339),
+		Applied:    copyValues(values),
+	}
+}
+
+// Subscribe registers handler of field. If the field has been reloaded, the handler is called with the current value
+// at once, so subsystems created after a reload won't miss it.
+func (b *Bus) Subscribe(field, subsystem string, fn Handler) {
+	b.mtx.Lock()
+	b.subscribers[field] = append(b.subscribers[field], &subscriber{subsystem: subsystem, fn: fn})
+	value, reloaded := b.status.Applied[field], b.status.Version > 1
+	b.mtx.Unlock()
+
+	if reloaded {
+		if err := fn(value); err != nil {
+			nlog.Warnf("Subsystem %s apply %s=%s failed, %v", subsystem, field, value, err)
+		}
+	}
+}
+
+// Publish calls subscribers of fields whose values are changed. A field keeps its old value if any subscriber fails
+// to apply it.
+func (b *Bus) Publish(hash string, values map[string]string, restartRequired []string) Status {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	applied := copyValues(b.status.Applied)
+	var errs []string
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		value := values[field]
+		if old, ok := applied[field]; ok && old == value {
+			continue
+		}
+		failed := false
+		for _, s := range b.subscribers[field] {
+			if err := s.fn(value); err != nil {
+				failed = true
+				errs = append(errs, fmt.Sprintf("%s: apply %s=%s failed, %v", s.subsystem, field, value, err))
+			}
+		}
+		if !failed {
+			nlog.Infof("Config %s is reloaded to %s", field, value)
+			applied[field] = value
+		}
+	}
+
+	sort.Strings(restartRequired)
+	b.status = Status{
+		Version:         b.status.Version + 1,
+		Hash:            hash,
+		UpdateTime:      time.Now().Format(time.RFC3339),
+		Applied:         applied,
+		RestartRequired: restartRequired,
+		Errors:          errs,
+	}
+	return b.copyStatus()
+}
+
+func (b *Bus) GetStatus() Status {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.copyStatus()
+}
+
+func (b *Bus) copyStatus() Status {
+	status := b.status
+	status.Applied = copyValues(b.status.Applied)
+	status.RestartRequired = append([]string(nil), b.status.RestartRequired...)
+	status.Errors = append([]string(nil), b.status.Errors...)
+	return status
+}
+
+// InstallHandler installs /config to mux.
+func (b *Bus) InstallHandler(mux *http.ServeMux) {
+	mux.HandleFunc(ConfigPath, func(w http.ResponseWriter, req *http.Request) {
+		body, err := json.Marshal(b.GetStatus())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+}
+
+func copyValues(values map[string]string) map[string]string {
+	c := make(map[string]string, len(values))
+	for k, v := range values {
+		c[k] = v
+	}
+	return c
+}
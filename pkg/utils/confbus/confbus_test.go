@@ -0,0 +1,84 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confbus
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusPublish(t *testing.T) {
+	t.Parallel()
+	b := NewBus()
+	b.Init("h1", map[string]string{FieldLogLevel: "INFO", FieldMetricUpdatePeriod: "5"})
+
+	var levels []string
+	b.Subscribe(FieldLogLevel, "log", func(value string) error {
+		levels = append(levels, value)
+		return nil
+	})
+	b.Subscribe(FieldMetricUpdatePeriod, "ssexporter", func(value string) error {
+		if value == "0" {
+			return errors.New("invalid period")
+		}
+		return nil
+	})
+	assert.Empty(t, levels)
+
+	status := b.Publish("h2", map[string]string{FieldLogLevel: "DEBUG", FieldMetricUpdatePeriod: "0"}, []string{"protocol"})
+	assert.Equal(t, int64(2), status.Version)
+	assert.Equal(t, "h2", status.Hash)
+	assert.Equal(t, []string{"DEBUG"}, levels)
+	assert.Equal(t, "DEBUG", status.Applied[FieldLogLevel])
+	assert.Equal(t, "5", status.Applied[FieldMetricUpdatePeriod])
+	assert.Equal(t, []string{"protocol"}, status.RestartRequired)
+	assert.Equal(t, 1, len(status.Errors))
+
+	// unchanged fields aren't applied again
+	status = b.Publish("h3", map[string]string{FieldLogLevel: "DEBUG", FieldMetricUpdatePeriod: "10"}, nil)
+	assert.Equal(t, int64(3), status.Version)
+	assert.Equal(t, []string{"DEBUG"}, levels)
+	assert.Equal(t, "10", status.Applied[FieldMetricUpdatePeriod])
+	assert.Empty(t, status.Errors)
+
+	// subscribers registered after reload get the current value
+	var late string
+	b.Subscribe(FieldLogLevel, "late", func(value string) error {
+		late = value
+		return nil
+	})
+	assert.Equal(t, "DEBUG", late)
+}
+
+func TestBusHandler(t *testing.T) {
+	t.Parallel()
+	b := NewBus()
+	b.Init("h1", map[string]string{FieldLogLevel: "INFO"})
+	mux := http.NewServeMux()
+	b.InstallHandler(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ConfigPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	status := Status{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, int64(1), status.Version)
+	assert.Equal(t, "INFO", status.Applied[FieldLogLevel])
+}
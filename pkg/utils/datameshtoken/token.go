@@ -0,0 +1,77 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datameshtoken mints and verifies the tokens which authenticate task pods to the DataMesh Flight server.
+// The agent signs a token for every task pod with the domain key, DataMesh verifies it with the same key and
+// checks that the pod it was issued to is still running.
+package datameshtoken
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+// Claims identifies the task pod which a token is issued to.
+type Claims struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	PodUID    string `json:"podUID"`
+	TaskID    string `json:"taskID"`
+	jwt.RegisteredClaims
+}
+
+// NewClaims returns the claims of the task pod, the pod must be created by a KusciaTask.
+func NewClaims(pod *corev1.Pod) (*Claims, error) {
+	taskID := pod.Annotations[common.TaskIDAnnotationKey]
+	if taskID == "" {
+		return nil, fmt.Errorf("pod %s/%s doesn't belong to any task", pod.Namespace, pod.Name)
+	}
+	return &Claims{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		PodUID:    string(pod.UID),
+		TaskID:    taskID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   pod.Namespace,
+			Subject:  taskID,
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}, nil
+}
+
+// Sign signs the claims with the domain key.
+func Sign(key *rsa.PrivateKey, claims *Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+}
+
+// Parse verifies the token with the public key of the domain and returns its claims.
+func Parse(key *rsa.PublicKey, token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	if claims.Namespace == "" || claims.Pod == "" || claims.TaskID == "" {
+		return nil, fmt.Errorf("token doesn't identify a task pod")
+	}
+	return claims, nil
+}
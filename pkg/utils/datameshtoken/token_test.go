@@ -0,0 +1,62 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datameshtoken
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+func TestSignAndParse(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "task-0",
+			Namespace:   "alice",
+			UID:         "uid-0",
+			Annotations: map[string]string{common.TaskIDAnnotationKey: "task"},
+		},
+	}
+	claims, err := NewClaims(pod)
+	assert.NoError(t, err)
+	token, err := Sign(key, claims)
+	assert.NoError(t, err)
+
+	got, err := Parse(&key.PublicKey, token)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", got.Namespace)
+	assert.Equal(t, "task-0", got.Pod)
+	assert.Equal(t, "uid-0", got.PodUID)
+	assert.Equal(t, "task", got.TaskID)
+
+	_, err = Parse(&otherKey.PublicKey, token)
+	assert.Error(t, err)
+	_, err = Parse(&key.PublicKey, token+"x")
+	assert.Error(t, err)
+
+	_, err = NewClaims(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "alice"}})
+	assert.Error(t, err)
+}
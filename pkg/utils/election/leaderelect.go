@@ -89,6 +89,8 @@ func buildLeaderElector(options *Options, onStartedLeading func(context.Context)
 		},
 		WatchDog: options.HealthChecker,
 		Name:     options.Name,
+		// release the lease on exit, so a standby replica takes over without waiting for the lease to expire
+		ReleaseOnCancel: true,
 	}
 
 	leaderElector, err := leaderelection.NewLeaderElector(lec)
@@ -121,6 +123,7 @@ func (e *k8sElector) MyIdentity() string {
 
 func (e *k8sElector) onNewLeader(identity string) {
 	e.leaderID.Store(identity)
+	RecordNewLeader(e.options.Name, identity)
 	if e.options.OnNewLeader != nil {
 		e.options.OnNewLeader(identity)
 	}
@@ -128,6 +131,7 @@ func (e *k8sElector) onNewLeader(identity string) {
 
 func (e *k8sElector) onStartedLeading(ctx context.Context) {
 	e.isLeader.Store(true)
+	RecordLeading(e.options.Name, true)
 	if e.options.OnStartedLeading != nil {
 		e.options.OnStartedLeading(ctx)
 	}
@@ -135,6 +139,7 @@ func (e *k8sElector) onStartedLeading(ctx context.Context) {
 
 func (e *k8sElector) onStoppedLeading() {
 	e.isLeader.Store(false)
+	RecordLeading(e.options.Name, false)
 	if e.options.OnStoppedLeading != nil {
 		e.options.OnStoppedLeading()
 	}
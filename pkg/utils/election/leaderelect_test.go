@@ -19,7 +19,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/secretflow/kuscia/pkg/utils/signals"
@@ -58,3 +60,26 @@ func Test_k8sElector_Run(t *testing.T) {
 	assert.Equal(t, startedLeadingCh, 1)
 	assert.Equal(t, stoppedLeadingCh, 1)
 }
+
+func TestWithConfig(t *testing.T) {
+	options := defaultOptions()
+	WithConfig(Config{LeaseDuration: 6 * time.Second, RetryPeriod: time.Second})(options)
+	assert.Equal(t, 6*time.Second, options.LeaseDuration)
+	assert.Equal(t, 5*time.Second, options.RenewDuration)
+	assert.Equal(t, time.Second, options.RetryPeriod)
+}
+
+func Test_k8sElector_ReleaseOnExit(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	ctx, cancel := context.WithCancel(context.Background())
+	elector := NewElector(kubeClient, "test-release", WithOnStartedLeading(func(ctx context.Context) {
+		cancel()
+	}))
+	elector.Run(ctx)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(LeaderStatus.WithLabelValues("test-release")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(LeaderTransitions.WithLabelValues("test-release")))
+	lease, err := kubeClient.CoordinationV1().Leases("kube-system").Get(context.Background(), "test-release", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, *lease.Spec.HolderIdentity)
+}
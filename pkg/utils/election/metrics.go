@@ -0,0 +1,51 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package election
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+var (
+	// LeaderStatus records whether this replica is the leader of a lease.
+	LeaderStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kuscia_leader_election_is_leader",
+		Help: "Whether this replica is the leader of the lease, 1 means leader",
+	}, []string{"name"})
+
+	// LeaderTransitions records the count of leader changes observed by this replica.
+	LeaderTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kuscia_leader_election_transitions_total",
+		Help: "Counts number of leader changes of the lease observed by this replica",
+	}, []string{"name"})
+)
+
+// RecordLeading records whether this replica is leading the lease name.
+func RecordLeading(name string, leading bool) {
+	if leading {
+		LeaderStatus.WithLabelValues(name).Set(1)
+	} else {
+		LeaderStatus.WithLabelValues(name).Set(0)
+	}
+}
+
+// RecordNewLeader records a new leader of the lease name is observed.
+func RecordNewLeader(name, identity string) {
+	nlog.Infof("Leader of %s is changed to %s", name, identity)
+	LeaderTransitions.WithLabelValues(name).Inc()
+}
@@ -0,0 +1,239 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package healthz aggregates the dependency checks registered by kuscia subsystems, and serves them as /healthz and
+// /readyz with per-check details in json.
+package healthz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/readyz"
+)
+
+const (
+	HealthzPath = "/healthz"
+	ReadyzPath  = "/readyz"
+
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+
+	// checkTimeout bounds the time of a single check.
+	checkTimeout = 5 * time.Second
+)
+
+// CheckFunc returns nil if the dependency is healthy.
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	subsystem string
+	name      string
+	liveness  bool
+	fn        CheckFunc
+}
+
+// CheckResult is the result of a check.
+type CheckResult struct {
+	Subsystem      string `json:"subsystem"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	Error          string `json:"error,omitempty"`
+	DurationMillis int64  `json:"durationMillis"`
+}
+
+// Report is the aggregated result of checks, Status is ok only if all checks are ok.
+type Report struct {
+	Status string         `json:"status"`
+	Checks []*CheckResult `json:"checks"`
+}
+
+// Registry holds checks registered by subsystems. Liveness checks are included in readiness.
+type Registry struct {
+	mtx    sync.RWMutex
+	checks map[string]*check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]*check)}
+}
+
+var defaultRegistry = NewRegistry()
+
+// AddLivenessCheck registers a check to default registry which fails /healthz and /readyz.
+func AddLivenessCheck(subsystem, name string, fn CheckFunc) {
+	defaultRegistry.AddLivenessCheck(subsystem, name, fn)
+}
+
+// AddReadinessCheck registers a check to default registry which only fails /readyz.
+func AddReadinessCheck(subsystem, name string, fn CheckFunc) {
+	defaultRegistry.AddReadinessCheck(subsystem, name, fn)
+}
+
+// RemoveChecks removes all checks of subsystem from default registry.
+func RemoveChecks(subsystem string) {
+	defaultRegistry.RemoveChecks(subsystem)
+}
+
+// InstallHandler installs /healthz and /readyz of default registry to mux.
+func InstallHandler(mux *http.ServeMux) {
+	defaultRegistry.InstallHandler(mux)
+}
+
+func (r *Registry) AddLivenessCheck(subsystem, name string, fn CheckFunc) {
+	r.add(&check{subsystem: subsystem, name: name, liveness: true, fn: fn})
+}
+
+func (r *Registry) AddReadinessCheck(subsystem, name string, fn CheckFunc) {
+	r.add(&check{subsystem: subsystem, name: name, fn: fn})
+}
+
+func (r *Registry) add(c *check) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	// a check registered again replaces the old one, as module may be recreated
+	r.checks[c.subsystem+"/"+c.name] = c
+}
+
+func (r *Registry) RemoveChecks(subsystem string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for key, c := range r.checks {
+		if c.subsystem == subsystem {
+			delete(r.checks, key)
+		}
+	}
+}
+
+// Check runs checks concurrently. Only liveness checks are run if readiness is false, checks of other subsystems
+// are skipped if subsystem isn't empty.
+func (r *Registry) Check(ctx context.Context, readiness bool, subsystem string) *Report {
+	r.mtx.RLock()
+	var checks []*check
+	for _, c := range r.checks {
+		if (readiness || c.liveness) && (subsystem == "" || c.subsystem == subsystem) {
+			checks = append(checks, c)
+		}
+	}
+	r.mtx.RUnlock()
+
+	report := &Report{
+		Status: StatusOK,
+		Checks: make([]*CheckResult, len(checks)),
+	}
+	wg := sync.WaitGroup{}
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			report.Checks[i] = runCheck(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusFailed
+		}
+	}
+	sort.Slice(report.Checks, func(i, j int) bool {
+		if report.Checks[i].Subsystem != report.Checks[j].Subsystem {
+			return report.Checks[i].Subsystem < report.Checks[j].Subsystem
+		}
+		return report.Checks[i].Name < report.Checks[j].Name
+	})
+	return report
+}
+
+func runCheck(ctx context.Context, c *check) (result *CheckResult) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result = &CheckResult{
+		Subsystem: c.subsystem,
+		Name:      c.name,
+		Status:    StatusOK,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			nlog.Errorf("Health check %s/%s panic: %v", c.subsystem, c.name, r)
+			result.Status = StatusFailed
+			result.Error = "check panic"
+		}
+		result.DurationMillis = time.Since(start).Milliseconds()
+	}()
+
+	if err := c.fn(ctx); err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// InstallHandler installs /healthz and /readyz to mux, the subsystem query parameter limits checks to a subsystem.
+func (r *Registry) InstallHandler(mux *http.ServeMux) {
+	mux.HandleFunc(HealthzPath, func(w http.ResponseWriter, req *http.Request) {
+		r.serve(w, req, false)
+	})
+	mux.HandleFunc(ReadyzPath, func(w http.ResponseWriter, req *http.Request) {
+		r.serve(w, req, true)
+	})
+}
+
+func (r *Registry) serve(w http.ResponseWriter, req *http.Request, readiness bool) {
+	report := r.Check(req.Context(), readiness, req.URL.Query().Get("subsystem"))
+	body, err := json.Marshal(report)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if report.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	_, _ = w.Write(body)
+}
+
+// ReadyZCheck adapts readyz.ReadyZ to CheckFunc.
+func ReadyZCheck(rdz readyz.ReadyZ) CheckFunc {
+	return rdz.IsReady
+}
+
+// KubeAPICheck checks the readyz of kube apiserver, which also covers the datastore of apiserver.
+func KubeAPICheck(kubeClient kubernetes.Interface, path string) CheckFunc {
+	return func(ctx context.Context) error {
+		if kubeClient == nil {
+			return errors.New("kube client is not initialized")
+		}
+		restClient := kubeClient.Discovery().RESTClient()
+		if restClient == nil {
+			// fake clients have no rest client
+			_, err := kubeClient.Discovery().ServerVersion()
+			return err
+		}
+		return restClient.Get().AbsPath(path).Do(ctx).Error()
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegistryCheck(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.AddLivenessCheck("agent", "running", func(ctx context.Context) error { return nil })
+	r.AddReadinessCheck("gateway", "envoy-admin", func(ctx context.Context) error { return errors.New("connection refused") })
+	r.AddReadinessCheck("kusciaapi", "ready", func(ctx context.Context) error { panic("unexpected") })
+
+	report := r.Check(context.Background(), false, "")
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, 1, len(report.Checks))
+
+	report = r.Check(context.Background(), true, "")
+	assert.Equal(t, StatusFailed, report.Status)
+	assert.Equal(t, 3, len(report.Checks))
+	assert.Equal(t, "agent", report.Checks[0].Subsystem)
+	assert.Equal(t, "connection refused", report.Checks[1].Error)
+	assert.Equal(t, StatusFailed, report.Checks[2].Status)
+
+	report = r.Check(context.Background(), true, "agent")
+	assert.Equal(t, StatusOK, report.Status)
+
+	r.RemoveChecks("gateway")
+	r.RemoveChecks("kusciaapi")
+	report = r.Check(context.Background(), true, "")
+	assert.Equal(t, StatusOK, report.Status)
+}
+
+func TestRegistryHandler(t *testing.T) {
+	t.Parallel()
+	r := NewRegistry()
+	r.AddLivenessCheck("agent", "running", func(ctx context.Context) error { return nil })
+	r.AddReadinessCheck("gateway", "envoy-admin", func(ctx context.Context) error { return errors.New("not ready") })
+	mux := http.NewServeMux()
+	r.InstallHandler(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, HealthzPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReadyzPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	report := &Report{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), report))
+	assert.Equal(t, StatusFailed, report.Status)
+	assert.Equal(t, 2, len(report.Checks))
+}
+
+func TestKubeAPICheck(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, KubeAPICheck(kubefake.NewSimpleClientset(), "/readyz")(context.Background()))
+	assert.Error(t, KubeAPICheck(nil, "/readyz")(context.Background()))
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagepolicy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of signature layer which holds the base64 encoded signature.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// maxSignaturePayloadSize limits the size of a signature payload read from registry.
+	maxSignaturePayloadSize = 1 << 20
+)
+
+// cosignPayload is the simple signing payload signed by cosign.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// cosignSignatureTag returns the tag cosign stores the signatures of digest in, i.e. sha256-<hex>.sig.
+func cosignSignatureTag(digest name.Digest) (name.Tag, error) {
+	hash, err := parseDigest(digest)
+	if err != nil {
+		return name.Tag{}, err
+	}
+	return digest.Context().Tag(fmt.Sprintf("%s-%s.sig", hash.Algorithm, hash.Hex)), nil
+}
+
+func (v *Verifier) verifyCosign(digest name.Digest, opts []remote.Option) error {
+	tag, err := cosignSignatureTag(digest)
+	if err != nil {
+		return err
+	}
+	sigImage, err := remote.Image(tag, opts...)
+	if err != nil {
+		if isNotFound(err) {
+			return &RejectedError{Image: digest.Name(), Reason: "no cosign signature found"}
+		}
+		return fmt.Errorf("get cosign signatures of image %s failed, %v", digest.Name(), err)
+	}
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return fmt.Errorf("get cosign signatures of image %s failed, %v", digest.Name(), err)
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		blob, err := remote.Layer(digest.Context().Digest(layer.Digest.String()), opts...)
+		if err != nil {
+			return fmt.Errorf("get cosign signature payload of image %s failed, %v", digest.Name(), err)
+		}
+		payload, err := readBlob(blob.Compressed)
+		if err != nil {
+			return fmt.Errorf("read cosign signature payload of image %s failed, %v", digest.Name(), err)
+		}
+		if !v.verifyCosignSignature(payload, signature) {
+			continue
+		}
+		p := &cosignPayload{}
+		if err = json.Unmarshal(payload, p); err != nil {
+			continue
+		}
+		if p.Critical.Image.DockerManifestDigest == digest.DigestStr() {
+			return nil
+		}
+	}
+	return &RejectedError{Image: digest.Name(), Reason: "no cosign signature is signed by trusted keys for the image digest"}
+}
+
+func (v *Verifier) verifyCosignSignature(payload, signature []byte) bool {
+	hashed := sha256.Sum256(payload)
+	for _, key := range v.cosignKeys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hashed[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hashed[:], signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, payload, signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func readBlob(open func() (io.ReadCloser, error)) ([]byte, error) {
+	rc, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxSignaturePayloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSignaturePayloadSize {
+		return nil, fmt.Errorf("payload exceeds %d bytes", maxSignaturePayloadSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imagepolicy admits the images of tasks by resolving their tags to digests and verifying their cosign or
+// notation signatures against the trust roots in config.
+package imagepolicy
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	defaultTimeout  = 30 * time.Second
+	defaultCacheTTL = 10 * time.Minute
+)
+
+// Config is the image admission policy in config file.
+type Config struct {
+	// PinDigest resolves the image tag to digest, and pods of task run the image by digest.
+	PinDigest bool `yaml:"pinDigest,omitempty"`
+	// Cosign verifies cosign signatures of images.
+	Cosign *CosignConfig `yaml:"cosign,omitempty"`
+	// Notation verifies notation signatures of images.
+	Notation *NotationConfig `yaml:"notation,omitempty"`
+	// Insecure allows to access registries by plain http.
+	Insecure bool `yaml:"insecure,omitempty"`
+	// Timeout of resolving and verifying an image, default 30s.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// CacheTTL is how long the result of an image is cached, default 10m.
+	CacheTTL time.Duration `yaml:"cacheTTL,omitempty"`
+}
+
+// CosignConfig is the trust roots of cosign signatures.
+type CosignConfig struct {
+	// PublicKeys are the PEM files of public keys, an image signed by any of them is trusted.
+	PublicKeys []string `yaml:"publicKeys"`
+}
+
+// NotationConfig is the trust roots of notation signatures.
+type NotationConfig struct {
+	// TrustedCAs are the PEM files of CA certificates, an image signed by a certificate issued by any of them is
+	// trusted.
+	TrustedCAs []string `yaml:"trustedCAs"`
+}
+
+// RejectedError is returned when an image is unsigned or its signature doesn't match, the task must not run it.
+type RejectedError struct {
+	Image  string
+	Reason string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("image %s is rejected, %s", e.Image, e.Reason)
+}
+
+// IsRejected returns whether the image is rejected by policy rather than failed to be verified temporarily.
+func IsRejected(err error) bool {
+	var rejected *RejectedError
+	return errors.As(err, &rejected)
+}
+
+type cacheEntry struct {
+	pinned  string
+	err     error
+	expires time.Time
+}
+
+// Verifier resolves and verifies images by the policy.
+type Verifier struct {
+	pinDigest   bool
+	cosignKeys  []crypto.PublicKey
+	notationCAs *x509.CertPool
+	nameOpts    []name.Option
+	remoteOpts  []remote.Option
+	timeout     time.Duration
+	cacheTTL    time.Duration
+	now         func() time.Time
+
+	mtx   sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// Build builds the verifier of config, it returns nil if neither pinning nor signature verification is enabled.
+func Build(config *Config) (*Verifier, error) {
+	if config == nil || (!config.PinDigest && config.Cosign == nil && config.Notation == nil) {
+		return nil, nil
+	}
+
+	v := &Verifier{
+		pinDigest:  config.PinDigest,
+		remoteOpts: []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithUserAgent("kuscia")},
+		timeout:    config.Timeout,
+		cacheTTL:   config.CacheTTL,
+		now:        time.Now,
+		cache:      map[string]cacheEntry{},
+	}
+	if v.timeout <= 0 {
+		v.timeout = defaultTimeout
+	}
+	if v.cacheTTL <= 0 {
+		v.cacheTTL = defaultCacheTTL
+	}
+	if config.Insecure {
+		v.nameOpts = append(v.nameOpts, name.Insecure)
+	}
+
+	if config.Cosign != nil {
+		if len(config.Cosign.PublicKeys) == 0 {
+			return nil, fmt.Errorf("publicKeys of image policy cosign is empty")
+		}
+		for _, file := range config.Cosign.PublicKeys {
+			key, err := loadPublicKey(file)
+			if err != nil {
+				return nil, err
+			}
+			v.cosignKeys = append(v.cosignKeys, key)
+		}
+	}
+	if config.Notation != nil {
+		if len(config.Notation.TrustedCAs) == 0 {
+			return nil, fmt.Errorf("trustedCAs of image policy notation is empty")
+		}
+		v.notationCAs = x509.NewCertPool()
+		for _, file := range config.Notation.TrustedCAs {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("read notation trusted CA %s failed, %v", file, err)
+			}
+			if !v.notationCAs.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("no certificate found in notation trusted CA %s", file)
+			}
+		}
+	}
+	return v, nil
+}
+
+func loadPublicKey(file string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read cosign public key %s failed, %v", file, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("cosign public key %s is not in PEM format", file)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse cosign public key %s failed, %v", file, err)
+	}
+	return key, nil
+}
+
+// Admit resolves the image to digest and verifies its signatures. It returns the image the pods should run, which is
+// the image by digest if pinning is enabled, otherwise the image itself. The returned error is a *RejectedError if
+// the image is not allowed to run.
+func (v *Verifier) Admit(ctx context.Context, image string) (string, error) {
+	v.mtx.Lock()
+	entry, ok := v.cache[image]
+	v.mtx.Unlock()
+	if ok && v.now().Before(entry.expires) {
+		return entry.pinned, entry.err
+	}
+
+	pinned, err := v.admit(ctx, image)
+	if err != nil && !IsRejected(err) {
+		// don't cache transient errors, e.g. the registry is unreachable.
+		return "", err
+	}
+	v.mtx.Lock()
+	v.cache[image] = cacheEntry{pinned: pinned, err: err, expires: v.now().Add(v.cacheTTL)}
+	v.mtx.Unlock()
+	return pinned, err
+}
+
+func (v *Verifier) admit(ctx context.Context, image string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	ref, err := name.ParseReference(image, v.nameOpts...)
+	if err != nil {
+		return "", &RejectedError{Image: image, Reason: fmt.Sprintf("invalid image reference, %v", err)}
+	}
+	opts := append([]remote.Option{remote.WithContext(ctx)}, v.remoteOpts...)
+	desc, err := remote.Head(ref, opts...)
+	if err != nil {
+		if isNotFound(err) {
+			return "", &RejectedError{Image: image, Reason: "image is not found in registry"}
+		}
+		return "", fmt.Errorf("resolve digest of image %s failed, %v", image, err)
+	}
+	digest := ref.Context().Digest(desc.Digest.String())
+
+	if len(v.cosignKeys) > 0 || v.notationCAs != nil {
+		if err = v.verify(digest, opts); err != nil {
+			return "", err
+		}
+		nlog.Infof("Image %s (%s) is verified", image, desc.Digest)
+	}
+
+	if v.pinDigest {
+		return digest.Name(), nil
+	}
+	return image, nil
+}
+
+// verify passes if the image is signed by any trust root in config.
+func (v *Verifier) verify(digest name.Digest, opts []remote.Option) error {
+	var reasons []string
+	if len(v.cosignKeys) > 0 {
+		err := v.verifyCosign(digest, opts)
+		if err == nil {
+			return nil
+		}
+		if !IsRejected(err) {
+			return err
+		}
+		reasons = append(reasons, err.(*RejectedError).Reason)
+	}
+	if v.notationCAs != nil {
+		err := v.verifyNotation(digest, opts)
+		if err == nil {
+			return nil
+		}
+		if !IsRejected(err) {
+			return err
+		}
+		reasons = append(reasons, err.(*RejectedError).Reason)
+	}
+	return &RejectedError{Image: digest.Name(), Reason: strings.Join(reasons, "; ")}
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,258 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagepolicy
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegistry(t *testing.T) string {
+	server := httptest.NewServer(registry.New(registry.WithReferrersSupport(true)))
+	t.Cleanup(server.Close)
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func pushImage(t *testing.T, image string) name.Digest {
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	ref, err := name.ParseReference(image, name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return ref.Context().Digest(digest.String())
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	file := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return file
+}
+
+func newCosignKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return key, writePEM(t, "PUBLIC KEY", der)
+}
+
+func cosignSign(t *testing.T, key *ecdsa.PrivateKey, digest name.Digest, signedDigest string) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},`+
+		`"type":"cosign container image signature"},"optional":null}`, digest.Context().Name(), signedDigest))
+	hashed := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed[:])
+	require.NoError(t, err)
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+		Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+	})
+	require.NoError(t, err)
+	tag, err := cosignSignatureTag(digest)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(tag, img))
+}
+
+func newCA(t *testing.T, commonName string) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func notationSign(t *testing.T, ca *x509.Certificate, caKey *rsa.PrivateKey, digest name.Digest) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	leaf, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	subject, err := remote.Head(digest)
+	require.NoError(t, err)
+	payload, err := json.Marshal(notationPayload{TargetArtifact: *subject})
+	require.NoError(t, err)
+	protected := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"PS256","cty":"application/vnd.cncf.notary.payload.v1+json"}`))
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	hashed := sha256.Sum256([]byte(protected + "." + encodedPayload))
+	signature, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, hashed[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	require.NoError(t, err)
+	envelope := jwsEnvelope{Payload: encodedPayload, Protected: protected, Signature: base64.RawURLEncoding.EncodeToString(signature)}
+	envelope.Header.CertChain = [][]byte{leaf}
+	data, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{Layer: static.NewLayer(data, notationJWSMediaType)})
+	require.NoError(t, err)
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, notationArtifactType)
+	img = mutate.Subject(img, *subject).(v1.Image)
+	sigDigest, err := img.Digest()
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(digest.Context().Digest(sigDigest.String()), img))
+}
+
+func TestBuild(t *testing.T) {
+	v, err := Build(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = Build(&Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = Build(&Config{Cosign: &CosignConfig{}})
+	assert.Error(t, err)
+
+	_, err = Build(&Config{Notation: &NotationConfig{TrustedCAs: []string{"not-exist.pem"}}})
+	assert.Error(t, err)
+}
+
+func TestAdmitPinDigest(t *testing.T) {
+	host := newRegistry(t)
+	digest := pushImage(t, host+"/secretflow/app:v1")
+
+	v, err := Build(&Config{PinDigest: true, Insecure: true})
+	require.NoError(t, err)
+	pinned, err := v.Admit(context.Background(), host+"/secretflow/app:v1")
+	require.NoError(t, err)
+	assert.Equal(t, digest.Name(), pinned)
+
+	_, err = v.Admit(context.Background(), host+"/secretflow/app:not-exist")
+	assert.True(t, IsRejected(err))
+}
+
+func TestAdmitCosign(t *testing.T) {
+	host := newRegistry(t)
+	key, keyFile := newCosignKey(t)
+	otherKey, _ := newCosignKey(t)
+
+	signed := pushImage(t, host+"/secretflow/app:signed")
+	cosignSign(t, key, signed, signed.DigestStr())
+	pushImage(t, host+"/secretflow/app:unsigned")
+	untrusted := pushImage(t, host+"/secretflow/app:untrusted")
+	cosignSign(t, otherKey, untrusted, untrusted.DigestStr())
+	tampered := pushImage(t, host+"/secretflow/app:tampered")
+	cosignSign(t, key, tampered, signed.DigestStr())
+
+	v, err := Build(&Config{Cosign: &CosignConfig{PublicKeys: []string{keyFile}}, Insecure: true})
+	require.NoError(t, err)
+
+	image, err := v.Admit(context.Background(), host+"/secretflow/app:signed")
+	assert.NoError(t, err)
+	assert.Equal(t, host+"/secretflow/app:signed", image)
+
+	for _, tag := range []string{"unsigned", "untrusted", "tampered"} {
+		_, err = v.Admit(context.Background(), host+"/secretflow/app:"+tag)
+		assert.True(t, IsRejected(err), tag)
+	}
+}
+
+func TestAdmitNotation(t *testing.T) {
+	host := newRegistry(t)
+	ca, caKey := newCA(t, "trusted")
+	otherCA, otherCAKey := newCA(t, "untrusted")
+
+	signed := pushImage(t, host+"/secretflow/app:signed")
+	notationSign(t, ca, caKey, signed)
+	untrusted := pushImage(t, host+"/secretflow/app:untrusted")
+	notationSign(t, otherCA, otherCAKey, untrusted)
+	pushImage(t, host+"/secretflow/app:unsigned")
+
+	caFile := writePEM(t, "CERTIFICATE", ca.Raw)
+	v, err := Build(&Config{PinDigest: true, Notation: &NotationConfig{TrustedCAs: []string{caFile}}, Insecure: true})
+	require.NoError(t, err)
+
+	image, err := v.Admit(context.Background(), host+"/secretflow/app:signed")
+	assert.NoError(t, err)
+	assert.Equal(t, signed.Name(), image)
+
+	for _, tag := range []string{"unsigned", "untrusted"} {
+		_, err = v.Admit(context.Background(), host+"/secretflow/app:"+tag)
+		assert.True(t, IsRejected(err), tag)
+	}
+}
+
+func TestAdmitCache(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	host := strings.TrimPrefix(server.URL, "http://")
+	digest := pushImage(t, host+"/secretflow/app:v1")
+
+	v, err := Build(&Config{PinDigest: true, Insecure: true, CacheTTL: time.Minute})
+	require.NoError(t, err)
+	_, err = v.Admit(context.Background(), host+"/secretflow/app:v1")
+	require.NoError(t, err)
+
+	server.Close()
+	pinned, err := v.Admit(context.Background(), host+"/secretflow/app:v1")
+	assert.NoError(t, err)
+	assert.Equal(t, digest.Name(), pinned)
+
+	v.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = v.Admit(context.Background(), host+"/secretflow/app:v1")
+	assert.Error(t, err)
+	assert.False(t, IsRejected(err))
+}
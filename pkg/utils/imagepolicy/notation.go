@@ -0,0 +1,208 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagepolicy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	// notationArtifactType is the artifact type of notation signature manifests referring to the image.
+	notationArtifactType = "application/vnd.cncf.notary.signature"
+	// notationJWSMediaType is the media type of notation signature envelope in JWS format.
+	notationJWSMediaType = "application/jose+json"
+)
+
+// jwsEnvelope is the flattened JWS JSON serialization used by notation.
+type jwsEnvelope struct {
+	Payload   string `json:"payload"`
+	Protected string `json:"protected"`
+	Header    struct {
+		CertChain [][]byte `json:"x5c"`
+	} `json:"header"`
+	Signature string `json:"signature"`
+}
+
+type jwsProtectedHeader struct {
+	Algorithm string `json:"alg"`
+}
+
+type notationPayload struct {
+	TargetArtifact v1.Descriptor `json:"targetArtifact"`
+}
+
+func parseDigest(digest name.Digest) (v1.Hash, error) {
+	hash, err := v1.NewHash(digest.DigestStr())
+	if err != nil {
+		return v1.Hash{}, &RejectedError{Image: digest.Name(), Reason: fmt.Sprintf("invalid digest, %v", err)}
+	}
+	return hash, nil
+}
+
+func (v *Verifier) verifyNotation(digest name.Digest, opts []remote.Option) error {
+	index, err := remote.Referrers(digest, append(opts, remote.WithFilter("artifactType", notationArtifactType))...)
+	if err != nil {
+		return fmt.Errorf("get referrers of image %s failed, %v", digest.Name(), err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return fmt.Errorf("get referrers of image %s failed, %v", digest.Name(), err)
+	}
+
+	found := false
+	for _, desc := range manifest.Manifests {
+		if desc.ArtifactType != notationArtifactType {
+			continue
+		}
+		found = true
+		sigImage, err := remote.Image(digest.Context().Digest(desc.Digest.String()), opts...)
+		if err != nil {
+			return fmt.Errorf("get notation signature of image %s failed, %v", digest.Name(), err)
+		}
+		sigManifest, err := sigImage.Manifest()
+		if err != nil {
+			return fmt.Errorf("get notation signature of image %s failed, %v", digest.Name(), err)
+		}
+		for _, layer := range sigManifest.Layers {
+			if layer.MediaType != notationJWSMediaType {
+				continue
+			}
+			blob, err := remote.Layer(digest.Context().Digest(layer.Digest.String()), opts...)
+			if err != nil {
+				return fmt.Errorf("get notation signature envelope of image %s failed, %v", digest.Name(), err)
+			}
+			envelope, err := readBlob(blob.Compressed)
+			if err != nil {
+				return fmt.Errorf("read notation signature envelope of image %s failed, %v", digest.Name(), err)
+			}
+			if v.verifyNotationEnvelope(envelope, digest.DigestStr()) == nil {
+				return nil
+			}
+		}
+	}
+	if !found {
+		return &RejectedError{Image: digest.Name(), Reason: "no notation signature found"}
+	}
+	return &RejectedError{Image: digest.Name(), Reason: "no notation signature is signed by trusted CAs for the image digest"}
+}
+
+// verifyNotationEnvelope verifies the JWS envelope is signed by a certificate chained to the trusted CAs, and the
+// signed payload targets the digest.
+func (v *Verifier) verifyNotationEnvelope(data []byte, digest string) error {
+	envelope := &jwsEnvelope{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return err
+	}
+	if len(envelope.Header.CertChain) == 0 {
+		return fmt.Errorf("certificate chain is empty")
+	}
+	certs := make([]*x509.Certificate, 0, len(envelope.Header.CertChain))
+	for _, der := range envelope.Header.CertChain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         v.notationCAs,
+		Intermediates: intermediates,
+		CurrentTime:   v.now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return err
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(envelope.Protected)
+	if err != nil {
+		return err
+	}
+	header := &jwsProtectedHeader{}
+	if err = json.Unmarshal(protected, header); err != nil {
+		return err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(envelope.Signature)
+	if err != nil {
+		return err
+	}
+	if err = verifyJWS(certs[0].PublicKey, header.Algorithm, []byte(envelope.Protected+"."+envelope.Payload), signature); err != nil {
+		return err
+	}
+
+	payloadData, err := base64.RawURLEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return err
+	}
+	payload := &notationPayload{}
+	if err = json.Unmarshal(payloadData, payload); err != nil {
+		return err
+	}
+	if payload.TargetArtifact.Digest.String() != digest {
+		return fmt.Errorf("signed digest %s doesn't match %s", payload.TargetArtifact.Digest, digest)
+	}
+	return nil
+}
+
+func verifyJWS(key crypto.PublicKey, alg string, signingInput, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "PS256", "ES256":
+		hash = crypto.SHA256
+	case "PS384", "ES384":
+		hash = crypto.SHA384
+	case "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write(signingInput)
+	hashed := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if alg[0] != 'P' {
+			return fmt.Errorf("algorithm %s doesn't match RSA key", alg)
+		}
+		return rsa.VerifyPSS(k, hash, hashed, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case *ecdsa.PublicKey:
+		if alg[0] != 'E' || len(signature)%2 != 0 {
+			return fmt.Errorf("algorithm %s doesn't match ECDSA key", alg)
+		}
+		r := new(big.Int).SetBytes(signature[:len(signature)/2])
+		s := new(big.Int).SetBytes(signature[len(signature)/2:])
+		if !ecdsa.Verify(k, hashed, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jobhistory archives compact summary records of finished jobs, so that jobs can still be queried after they
+// are garbage collected.
+package jobhistory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
+)
+
+// TaskRecord is the summary of a task of job.
+type TaskRecord struct {
+	TaskID         string     `json:"taskID"`
+	Alias          string     `json:"alias,omitempty"`
+	Phase          string     `json:"phase,omitempty"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+}
+
+// Record is the summary of a finished job.
+type Record struct {
+	JobID     string `json:"jobID"`
+	Initiator string `json:"initiator"`
+	Phase     string `json:"phase"`
+	Reason    string `json:"reason,omitempty"`
+	// SpecHash is the sha256 of job spec, jobs submitted with the same spec have the same hash.
+	SpecHash       string       `json:"specHash"`
+	Parties        []string     `json:"parties,omitempty"`
+	Tasks          []TaskRecord `json:"tasks,omitempty"`
+	CreationTime   time.Time    `json:"creationTime"`
+	StartTime      *time.Time   `json:"startTime,omitempty"`
+	CompletionTime *time.Time   `json:"completionTime,omitempty"`
+	// DeletionTime is set when the job is garbage collected.
+	DeletionTime *time.Time `json:"deletionTime,omitempty"`
+	// ResourceUsage is the usage of parties accounted by this cluster.
+	ResourceUsage *accounting.Usage `json:"resourceUsage,omitempty"`
+}
+
+// NewRecord summarizes the job and its tasks, tasks not found keep the phase in job status only.
+func NewRecord(job *v1alpha1.KusciaJob, tasks []*v1alpha1.KusciaTask) *Record {
+	record := &Record{
+		JobID:          job.Name,
+		Initiator:      job.Spec.Initiator,
+		Phase:          string(job.Status.Phase),
+		Reason:         job.Status.Reason,
+		SpecHash:       specHash(&job.Spec),
+		CreationTime:   job.CreationTimestamp.Time,
+		StartTime:      toTime(job.Status.StartTime),
+		CompletionTime: toTime(job.Status.CompletionTime),
+	}
+
+	taskByID := make(map[string]*v1alpha1.KusciaTask, len(tasks))
+	for _, task := range tasks {
+		taskByID[task.Name] = task
+	}
+	parties := map[string]bool{}
+	for _, template := range job.Spec.Tasks {
+		for _, party := range template.Parties {
+			parties[party.DomainID] = true
+		}
+		taskRecord := TaskRecord{
+			TaskID: template.TaskID,
+			Alias:  template.Alias,
+			Phase:  string(job.Status.TaskStatus[template.TaskID]),
+		}
+		if task, ok := taskByID[template.TaskID]; ok {
+			if task.Status.Phase != "" {
+				taskRecord.Phase = string(task.Status.Phase)
+			}
+			taskRecord.StartTime = toTime(task.Status.StartTime)
+			taskRecord.CompletionTime = toTime(task.Status.CompletionTime)
+		}
+		record.Tasks = append(record.Tasks, taskRecord)
+	}
+	for party := range parties {
+		record.Parties = append(record.Parties, party)
+	}
+	sort.Strings(record.Parties)
+	return record
+}
+
+func specHash(spec *v1alpha1.KusciaJobSpec) string {
+	data, _ := json.Marshal(spec)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func toTime(t *metav1.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
+
+// Query filters records, empty fields match all records.
+type Query struct {
+	// StartTime and EndTime bound the completion time of jobs.
+	StartTime time.Time
+	EndTime   time.Time
+	Initiator string
+	Phase     string
+	// Party only matches jobs the domain participates in.
+	Party string
+	// Limit is the max count of records returned, the latest completed ones first.
+	Limit int
+}
+
+// Store keeps the records of finished jobs, records are unique by job id.
+type Store interface {
+	// Put creates or replaces the record of job.
+	Put(ctx context.Context, record *Record) error
+	Query(ctx context.Context, query *Query) ([]*Record, error)
+	Close() error
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobhistory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+func TestNewRecord(t *testing.T) {
+	now := time.Now()
+	job := &v1alpha1.KusciaJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-1", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+		Spec: v1alpha1.KusciaJobSpec{
+			Initiator: "alice",
+			Tasks: []v1alpha1.KusciaTaskTemplate{
+				{TaskID: "task-1", Alias: "a", Parties: []v1alpha1.Party{{DomainID: "bob"}, {DomainID: "alice"}}},
+				{TaskID: "task-2", Alias: "b", Parties: []v1alpha1.Party{{DomainID: "alice"}}},
+			},
+		},
+		Status: v1alpha1.KusciaJobStatus{
+			Phase:          v1alpha1.KusciaJobFailed,
+			TaskStatus:     map[string]v1alpha1.KusciaTaskPhase{"task-1": v1alpha1.TaskRunning, "task-2": v1alpha1.TaskFailed},
+			CompletionTime: &metav1.Time{Time: now},
+		},
+	}
+	task := &v1alpha1.KusciaTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-1"},
+		Status: v1alpha1.KusciaTaskStatus{
+			Phase:     v1alpha1.TaskSucceeded,
+			StartTime: &metav1.Time{Time: now.Add(-time.Minute)},
+		},
+	}
+
+	record := NewRecord(job, []*v1alpha1.KusciaTask{task})
+	assert.Equal(t, "job-1", record.JobID)
+	assert.Equal(t, "alice", record.Initiator)
+	assert.Equal(t, string(v1alpha1.KusciaJobFailed), record.Phase)
+	assert.Equal(t, []string{"alice", "bob"}, record.Parties)
+	assert.Nil(t, record.StartTime)
+	assert.True(t, record.CompletionTime.Equal(now))
+	assert.Equal(t, []string{string(v1alpha1.TaskSucceeded), string(v1alpha1.TaskFailed)},
+		[]string{record.Tasks[0].Phase, record.Tasks[1].Phase})
+	assert.NotNil(t, record.Tasks[0].StartTime)
+	assert.Nil(t, record.Tasks[1].StartTime)
+	assert.Len(t, record.SpecHash, 64)
+
+	job.Spec.Tasks[0].AppImage = "another"
+	assert.NotEqual(t, record.SpecHash, NewRecord(job, nil).SpecHash)
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobhistory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// register sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	// DBFileName is the SQLite database in the job history directory.
+	DBFileName = "jobhistory.db"
+
+	historyTable = "kuscia_job_history"
+
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+)
+
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// New opens the SQLite store under dir, the directory and table are created if they don't exist.
+func New(ctx context.Context, dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create job history directory %s, %v", dir, err)
+	}
+	// busy timeout avoids failures when another process holds the write lock.
+	dsn := "file:" + filepath.Join(dir, DBFileName) + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open job history store failed, %v", err)
+	}
+	// sqlite allows only one writer, serialize writes in process instead of failing with SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS ` + historyTable + ` (
+			job_id TEXT NOT NULL PRIMARY KEY,
+			initiator TEXT NOT NULL,
+			phase TEXT NOT NULL,
+			parties TEXT NOT NULL,
+			completion_time INTEGER NOT NULL,
+			record BLOB NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_completion_time ON ` + historyTable + ` (completion_time)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("init job history store failed, %v", err)
+		}
+	}
+	nlog.Infof("Job history store is ready, path: %s", filepath.Join(dir, DBFileName))
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Put(ctx context.Context, record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	var completionTime int64
+	if record.CompletionTime != nil {
+		completionTime = record.CompletionTime.Unix()
+	}
+	// parties are wrapped by commas, so that a party is matched by LIKE '%,party,%'
+	parties := "," + strings.Join(record.Parties, ",") + ","
+	_, err = s.db.ExecContext(ctx, "INSERT INTO "+historyTable+
+		" (job_id, initiator, phase, parties, completion_time, record) VALUES (?, ?, ?, ?, ?, ?) "+
+		"ON CONFLICT(job_id) DO UPDATE SET initiator = excluded.initiator, phase = excluded.phase, "+
+		"parties = excluded.parties, completion_time = excluded.completion_time, record = excluded.record",
+		record.JobID, record.Initiator, record.Phase, parties, completionTime, data)
+	return err
+}
+
+func (s *sqliteStore) Query(ctx context.Context, query *Query) ([]*Record, error) {
+	var conditions []string
+	var args []interface{}
+	if !query.StartTime.IsZero() {
+		conditions = append(conditions, "completion_time >= ?")
+		args = append(args, query.StartTime.Unix())
+	}
+	if !query.EndTime.IsZero() {
+		conditions = append(conditions, "completion_time <= ?")
+		args = append(args, query.EndTime.Unix())
+	}
+	if query.Initiator != "" {
+		conditions = append(conditions, "initiator = ?")
+		args = append(args, query.Initiator)
+	}
+	if query.Phase != "" {
+		conditions = append(conditions, "phase = ?")
+		args = append(args, query.Phase)
+	}
+	if query.Party != "" {
+		conditions = append(conditions, "parties LIKE ?")
+		args = append(args, "%,"+query.Party+",%")
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	} else if limit > maxQueryLimit {
+		limit = maxQueryLimit
+	}
+
+	stmt := "SELECT record FROM " + historyTable
+	if len(conditions) > 0 {
+		stmt += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	stmt += " ORDER BY completion_time DESC, job_id LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*Record
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		record := &Record{}
+		if err := json.Unmarshal(data, record); err != nil {
+			nlog.Warnf("Skip invalid job history record, %v", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobhistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/secretflow/kuscia/pkg/ssexporter/accounting"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := New(ctx, dir)
+	require.NoError(t, err)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newRecord := func(jobID, initiator, phase string, parties []string, completion time.Time) *Record {
+		return &Record{JobID: jobID, Initiator: initiator, Phase: phase, Parties: parties, CompletionTime: &completion}
+	}
+	assert.NoError(t, s.Put(ctx, newRecord("job-1", "alice", "Succeeded", []string{"alice", "bob"}, base)))
+	assert.NoError(t, s.Put(ctx, newRecord("job-2", "bob", "Failed", []string{"bob", "carol"}, base.Add(time.Hour))))
+	assert.NoError(t, s.Put(ctx, newRecord("job-3", "alice", "Failed", []string{"alice"}, base.Add(2*time.Hour))))
+
+	jobIDs := func(query *Query) []string {
+		records, err := s.Query(ctx, query)
+		require.NoError(t, err)
+		var ids []string
+		for _, r := range records {
+			ids = append(ids, r.JobID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"job-3", "job-2", "job-1"}, jobIDs(&Query{}))
+	assert.Equal(t, []string{"job-3", "job-1"}, jobIDs(&Query{Initiator: "alice"}))
+	assert.Equal(t, []string{"job-3", "job-2"}, jobIDs(&Query{Phase: "Failed"}))
+	assert.Equal(t, []string{"job-2", "job-1"}, jobIDs(&Query{Party: "bob"}))
+	assert.Equal(t, []string{"job-2"}, jobIDs(&Query{StartTime: base.Add(time.Minute), EndTime: base.Add(time.Hour)}))
+	assert.Equal(t, []string{"job-3"}, jobIDs(&Query{Limit: 1}))
+
+	// records are replaced by job id
+	deletion := base.Add(3 * time.Hour)
+	record := newRecord("job-1", "alice", "Succeeded", []string{"alice", "bob"}, base)
+	record.DeletionTime = &deletion
+	record.ResourceUsage = &accounting.Usage{CPUSeconds: 10}
+	assert.NoError(t, s.Put(ctx, record))
+	require.NoError(t, s.Close())
+
+	s, err = New(ctx, dir)
+	require.NoError(t, err)
+	defer s.Close()
+	records, err := s.Query(ctx, &Query{Initiator: "alice", Phase: "Succeeded"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.True(t, records[0].DeletionTime.Equal(deletion))
+	assert.Equal(t, 10.0, records[0].ResourceUsage.CPUSeconds)
+}
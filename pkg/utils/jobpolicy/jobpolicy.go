@@ -0,0 +1,187 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jobpolicy validates jobs against org-specific policies before any task of the job is created. A policy is
+// either an external HTTP webhook or a Go plugin registered by Register.
+package jobpolicy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+const (
+	// TypeWebhook sends the job to an external HTTP policy service.
+	TypeWebhook = "webhook"
+	// TypeRules checks the app images and resources of job by the rules in config.
+	TypeRules = "rules"
+
+	// FailurePolicyFail denies the job if the policy can't be evaluated, e.g. the webhook is unreachable.
+	FailurePolicyFail = "Fail"
+	// FailurePolicyIgnore allows the job if the policy can't be evaluated.
+	FailurePolicyIgnore = "Ignore"
+
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// Config is a job validation policy in config file.
+type Config struct {
+	Name string `yaml:"name"`
+	// Type is webhook, rules or the type of a registered plugin.
+	Type string `yaml:"type"`
+	// URL is the endpoint of webhook.
+	URL string `yaml:"url,omitempty"`
+	// Timeout of calling webhook, default 10s.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// FailurePolicy is Fail or Ignore, default Fail.
+	FailurePolicy string `yaml:"failurePolicy,omitempty"`
+	// Config is the custom config of plugin.
+	Config yaml.Node `yaml:"config,omitempty"`
+}
+
+// Decode decodes the custom config of plugin into out.
+func (c *Config) Decode(out interface{}) error {
+	if c.Config.IsZero() {
+		return nil
+	}
+	return c.Config.Decode(out)
+}
+
+// Validator inspects the spec of job, and returns a non-nil error with the reason if the job is denied.
+type Validator interface {
+	Validate(ctx context.Context, job *v1alpha1.KusciaJob) error
+}
+
+// Factory builds a validator of plugin from config.
+type Factory func(config *Config) (Validator, error)
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = map[string]Factory{}
+)
+
+// Register registers the factory of a plugin type, it's usually called in init of the plugin package.
+func Register(pluginType string, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	factories[pluginType] = factory
+}
+
+func getFactory(pluginType string) Factory {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+	return factories[pluginType]
+}
+
+// Error is returned when a job is denied by policy.
+type Error struct {
+	Policy string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("denied by policy %s: %s", e.Policy, e.Reason)
+}
+
+type policy struct {
+	name          string
+	failurePolicy string
+	validator     Validator
+}
+
+// Chain validates jobs by policies in order, the first denial stops the validation.
+type Chain struct {
+	policies []policy
+}
+
+// Build builds the policies in config.
+func Build(configs []Config) (*Chain, error) {
+	chain := &Chain{}
+	names := map[string]bool{}
+	for i := range configs {
+		config := &configs[i]
+		if config.Name == "" {
+			return nil, fmt.Errorf("name of job validation policy[%d] is empty", i)
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("job validation policy %s is duplicated", config.Name)
+		}
+		names[config.Name] = true
+
+		failurePolicy := config.FailurePolicy
+		switch failurePolicy {
+		case "":
+			failurePolicy = FailurePolicyFail
+		case FailurePolicyFail, FailurePolicyIgnore:
+		default:
+			return nil, fmt.Errorf("failurePolicy of job validation policy %s must be %s or %s", config.Name,
+				FailurePolicyFail, FailurePolicyIgnore)
+		}
+
+		var validator Validator
+		var err error
+		if config.Type == TypeWebhook {
+			validator, err = newWebhookValidator(config)
+		} else if factory := getFactory(config.Type); factory != nil {
+			validator, err = factory(config)
+		} else {
+			err = fmt.Errorf("type %q is not registered", config.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("build job validation policy %s failed, %v", config.Name, err)
+		}
+		chain.policies = append(chain.policies, policy{name: config.Name, failurePolicy: failurePolicy, validator: validator})
+	}
+	return chain, nil
+}
+
+// Validate returns *Error if job is denied by any policy.
+func (c *Chain) Validate(ctx context.Context, job *v1alpha1.KusciaJob) error {
+	if c == nil {
+		return nil
+	}
+	for _, p := range c.policies {
+		err := p.validator.Validate(ctx, job)
+		if err == nil {
+			continue
+		}
+		if _, denied := err.(*denial); !denied && p.failurePolicy == FailurePolicyIgnore {
+			continue
+		}
+		return &Error{Policy: p.name, Reason: err.Error()}
+	}
+	return nil
+}
+
+// denial is the error of validator which denies the job explicitly, unlike errors evaluating the policy it's never
+// ignored by failure policy.
+type denial struct {
+	reason string
+}
+
+func (d *denial) Error() string {
+	return d.reason
+}
+
+// Deny returns the error which denies job with reason. Plugins should return it for jobs violating policy, other
+// errors are treated as failures of evaluating the policy.
+func Deny(format string, args ...interface{}) error {
+	return &denial{reason: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,243 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+func parseConfigs(t *testing.T, content string) []Config {
+	var configs []Config
+	assert.NoError(t, yaml.Unmarshal([]byte(content), &configs))
+	return configs
+}
+
+func makeJob() *v1alpha1.KusciaJob {
+	return &v1alpha1.KusciaJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-1"},
+		Spec: v1alpha1.KusciaJobSpec{
+			Initiator: "alice",
+			Tasks: []v1alpha1.KusciaTaskTemplate{
+				{
+					Alias:    "task-1",
+					AppImage: "secretflow-image",
+					Parties: []v1alpha1.Party{
+						{
+							DomainID: "alice",
+							Resources: &corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("2"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+							},
+						},
+						{DomainID: "bob"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "empty name",
+			content: "- type: rules",
+			wantErr: "name of job validation policy[0] is empty",
+		},
+		{
+			name:    "duplicated name",
+			content: "- {name: p, type: rules}\n- {name: p, type: rules}",
+			wantErr: "job validation policy p is duplicated",
+		},
+		{
+			name:    "invalid failure policy",
+			content: "- {name: p, type: rules, failurePolicy: Retry}",
+			wantErr: "failurePolicy of job validation policy p must be Fail or Ignore",
+		},
+		{
+			name:    "unknown type",
+			content: "- {name: p, type: opa}",
+			wantErr: `type "opa" is not registered`,
+		},
+		{
+			name:    "invalid webhook url",
+			content: "- {name: p, type: webhook, url: 'ftp://example.com'}",
+			wantErr: "invalid webhook url",
+		},
+		{
+			name:    "invalid max cpu",
+			content: "- {name: p, type: rules, config: {maxCPU: abc}}",
+			wantErr: "invalid max cpu",
+		},
+		{
+			name:    "valid",
+			content: "- {name: p1, type: rules}\n- {name: p2, type: webhook, url: 'http://127.0.0.1:8080/validate', failurePolicy: Ignore}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := Build(parseConfigs(t, tt.content))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, chain)
+		})
+	}
+}
+
+func TestNilChain(t *testing.T) {
+	var chain *Chain
+	assert.NoError(t, chain.Validate(context.Background(), makeJob()))
+}
+
+func TestRulesValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:   "no rules",
+			config: "{}",
+		},
+		{
+			name:   "approved app image",
+			config: "{appImages: [secretflow-image]}",
+		},
+		{
+			name:    "unapproved app image",
+			config:  "{appImages: [other-image]}",
+			wantErr: "denied by policy rules: app image secretflow-image of task task-1 is not approved",
+		},
+		{
+			name:    "cpu exceeds limit",
+			config:  "{maxCPU: '1'}",
+			wantErr: "denied by policy rules: cpu 2 of party alice in task task-1 exceeds the limit 1",
+		},
+		{
+			name:   "memory within limit",
+			config: "{maxMemory: 8Gi}",
+		},
+		{
+			name:   "tasks within limit",
+			config: "{maxTasks: 1}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := Build(parseConfigs(t, "- {name: rules, type: rules, config: "+tt.config+"}"))
+			assert.NoError(t, err)
+			err = chain.Validate(context.Background(), makeJob())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				var policyErr *Error
+				assert.True(t, errors.As(err, &policyErr))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	chain, err := Build(parseConfigs(t, "- {name: rules, type: rules, config: {maxTasks: 1}}"))
+	assert.NoError(t, err)
+	job := makeJob()
+	job.Spec.Tasks = append(job.Spec.Tasks, job.Spec.Tasks[0])
+	assert.EqualError(t, chain.Validate(context.Background(), job),
+		"denied by policy rules: job has 2 tasks, exceeds the limit 1")
+}
+
+func TestWebhookValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &WebhookRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.Job == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp := &WebhookResponse{Allowed: req.Job.Spec.Initiator == "alice"}
+		if !resp.Allowed {
+			resp.Reason = "initiator is not allowed"
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	chain, err := Build(parseConfigs(t, "- {name: org, type: webhook, url: '"+server.URL+"'}"))
+	assert.NoError(t, err)
+
+	job := makeJob()
+	assert.NoError(t, chain.Validate(context.Background(), job))
+
+	job.Spec.Initiator = "bob"
+	assert.EqualError(t, chain.Validate(context.Background(), job), "denied by policy org: initiator is not allowed")
+}
+
+func TestWebhookFailurePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	chain, err := Build(parseConfigs(t, "- {name: org, type: webhook, url: '"+server.URL+"'}"))
+	assert.NoError(t, err)
+	assert.ErrorContains(t, chain.Validate(context.Background(), makeJob()), "webhook returns status code 500")
+
+	chain, err = Build(parseConfigs(t, "- {name: org, type: webhook, url: '"+server.URL+"', failurePolicy: Ignore}"))
+	assert.NoError(t, err)
+	assert.NoError(t, chain.Validate(context.Background(), makeJob()))
+}
+
+type denyAllValidator struct {
+	Reason string `yaml:"reason"`
+}
+
+func (v *denyAllValidator) Validate(ctx context.Context, job *v1alpha1.KusciaJob) error {
+	return Deny("%s", v.Reason)
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	Register("deny-all", func(config *Config) (Validator, error) {
+		v := &denyAllValidator{}
+		if err := config.Decode(v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	})
+
+	// explicit denials are never ignored by failure policy
+	chain, err := Build(parseConfigs(t, "- {name: custom, type: deny-all, failurePolicy: Ignore, config: {reason: maintenance}}"))
+	assert.NoError(t, err)
+	assert.EqualError(t, chain.Validate(context.Background(), makeJob()), "denied by policy custom: maintenance")
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobpolicy
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+func init() {
+	Register(TypeRules, newRulesValidator)
+}
+
+// RulesConfig is the config of rules policy.
+type RulesConfig struct {
+	// AppImages are the approved app images, empty approves all app images.
+	AppImages []string `yaml:"appImages,omitempty"`
+	// MaxCPU limits the cpu requested by each party of tasks, empty means unlimited.
+	MaxCPU string `yaml:"maxCPU,omitempty"`
+	// MaxMemory limits the memory requested by each party of tasks, empty means unlimited.
+	MaxMemory string `yaml:"maxMemory,omitempty"`
+	// MaxTasks limits the number of tasks of job, 0 means unlimited.
+	MaxTasks int `yaml:"maxTasks,omitempty"`
+}
+
+type rulesValidator struct {
+	appImages map[string]bool
+	limits    corev1.ResourceList
+	maxTasks  int
+}
+
+func newRulesValidator(config *Config) (Validator, error) {
+	rules := &RulesConfig{}
+	if err := config.Decode(rules); err != nil {
+		return nil, err
+	}
+
+	v := &rulesValidator{limits: corev1.ResourceList{}, maxTasks: rules.MaxTasks}
+	if len(rules.AppImages) > 0 {
+		v.appImages = map[string]bool{}
+		for _, appImage := range rules.AppImages {
+			v.appImages[appImage] = true
+		}
+	}
+	for name, value := range map[corev1.ResourceName]string{corev1.ResourceCPU: rules.MaxCPU, corev1.ResourceMemory: rules.MaxMemory} {
+		if value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max %s %q, %v", name, value, err)
+		}
+		v.limits[name] = q
+	}
+	return v, nil
+}
+
+func (v *rulesValidator) Validate(ctx context.Context, job *v1alpha1.KusciaJob) error {
+	if v.maxTasks > 0 && len(job.Spec.Tasks) > v.maxTasks {
+		return Deny("job has %d tasks, exceeds the limit %d", len(job.Spec.Tasks), v.maxTasks)
+	}
+
+	for _, task := range job.Spec.Tasks {
+		if v.appImages != nil && !v.appImages[task.AppImage] {
+			return Deny("app image %s of task %s is not approved", task.AppImage, task.Alias)
+		}
+		for _, party := range task.Parties {
+			if party.Resources == nil {
+				continue
+			}
+			for name, limit := range v.limits {
+				for _, list := range []corev1.ResourceList{party.Resources.Requests, party.Resources.Limits} {
+					if q, ok := list[name]; ok && q.Cmp(limit) > 0 {
+						return Deny("%s %s of party %s in task %s exceeds the limit %s", name, q.String(),
+							party.DomainID, task.Alias, limit.String())
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+const maxWebhookResponseSize = 1 << 20
+
+// WebhookRequest is posted to the webhook as json.
+type WebhookRequest struct {
+	Job *v1alpha1.KusciaJob `json:"job"`
+}
+
+// WebhookResponse is the json response of webhook.
+type WebhookResponse struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type webhookValidator struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookValidator(config *Config) (Validator, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook url %q", config.URL)
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &webhookValidator{url: config.URL, client: &http.Client{Timeout: timeout}}, nil
+}
+
+func (v *webhookValidator) Validate(ctx context.Context, job *v1alpha1.KusciaJob) error {
+	body, err := json.Marshal(&WebhookRequest{Job: job})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call webhook failed, %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseSize))
+	if err != nil {
+		return fmt.Errorf("read webhook response failed, %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook returns status code %d, %s", resp.StatusCode, respBody)
+	}
+
+	result := &WebhookResponse{}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("parse webhook response failed, %v", err)
+	}
+	if !result.Allowed {
+		if result.Reason == "" {
+			result.Reason = "no reason is given"
+		}
+		return Deny("%s", result.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jobqueue limits the jobs running at the same time. Jobs exceeding the limits wait in a queue ordered by
+// priority and creation time, and are dispatched when running jobs finish.
+package jobqueue
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+// dispatchTTL bounds how long a dispatched job is counted as running before it's observed running.
+const dispatchTTL = 5 * time.Minute
+
+// Config is the limits of job queue in config file, 0 means unlimited.
+type Config struct {
+	// MaxRunningJobs limits the running jobs of all domains.
+	MaxRunningJobs int `yaml:"maxRunningJobs,omitempty"`
+	// MaxRunningJobsPerDomain limits the running jobs which a domain participates in.
+	MaxRunningJobsPerDomain int `yaml:"maxRunningJobsPerDomain,omitempty"`
+}
+
+// Enabled returns whether any limit is set.
+func (c *Config) Enabled() bool {
+	return c != nil && (c.MaxRunningJobs > 0 || c.MaxRunningJobsPerDomain > 0)
+}
+
+func CheckConfig(c *Config) error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxRunningJobs < 0 || c.MaxRunningJobsPerDomain < 0 {
+		return fmt.Errorf("job queue maxRunningJobs and maxRunningJobsPerDomain can't be negative")
+	}
+	return nil
+}
+
+// Queue dispatches queued jobs when the running jobs are under the limits. It's safe for concurrent use.
+type Queue struct {
+	config Config
+
+	lock sync.Mutex
+	// dispatched are the jobs allowed to run but not observed running yet, they are counted as running.
+	dispatched map[string]time.Time
+	now        func() time.Time
+}
+
+// New returns a queue, or nil if no limit is set.
+func New(config *Config) *Queue {
+	if !config.Enabled() {
+		return nil
+	}
+	return &Queue{
+		config:     *config,
+		dispatched: map[string]time.Time{},
+		now:        time.Now,
+	}
+}
+
+// Dispatch decides whether the job can run now. jobs are all the jobs known to the controller, running jobs are
+// counted against the limits, and the jobs for which queued returns true are waiting in the queue. If the job
+// has to wait, its 1-based position in the queue is returned.
+func (q *Queue) Dispatch(job *v1alpha1.KusciaJob, jobs []*v1alpha1.KusciaJob, queued func(*v1alpha1.KusciaJob) bool) (bool, int) {
+	if q == nil {
+		return true, 0
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.now()
+	total := 0
+	perDomain := map[string]int{}
+	var waiting []*v1alpha1.KusciaJob
+	for _, j := range jobs {
+		if j.Name == job.Name {
+			continue
+		}
+		if q.isRunning(j, now) {
+			total++
+			for _, d := range Domains(j) {
+				perDomain[d]++
+			}
+			continue
+		}
+		if j.Status.Phase == v1alpha1.KusciaJobPending && queued(j) {
+			waiting = append(waiting, j)
+		}
+	}
+	if _, ok := q.dispatched[job.Name]; ok {
+		return true, 0
+	}
+	waiting = append(waiting, job)
+	sortQueue(waiting)
+
+	// Jobs blocked by the limit of a domain don't block the jobs of other domains behind them.
+	position := 0
+	for _, j := range waiting {
+		domains := Domains(j)
+		if q.fits(total, perDomain, domains) {
+			if j.Name == job.Name {
+				q.dispatched[job.Name] = now
+				return true, 0
+			}
+			total++
+			for _, d := range domains {
+				perDomain[d]++
+			}
+			continue
+		}
+		position++
+		if j.Name == job.Name {
+			break
+		}
+	}
+	return false, position
+}
+
+// isRunning returns whether the job occupies the running slots, and forgets the dispatched jobs observed running.
+func (q *Queue) isRunning(job *v1alpha1.KusciaJob, now time.Time) bool {
+	if job.Status.Phase == v1alpha1.KusciaJobRunning {
+		delete(q.dispatched, job.Name)
+		return true
+	}
+	if t, ok := q.dispatched[job.Name]; ok {
+		if job.Status.Phase == v1alpha1.KusciaJobPending && now.Sub(t) < dispatchTTL {
+			return true
+		}
+		delete(q.dispatched, job.Name)
+	}
+	return false
+}
+
+func (q *Queue) fits(total int, perDomain map[string]int, domains []string) bool {
+	if q.config.MaxRunningJobs > 0 && total >= q.config.MaxRunningJobs {
+		return false
+	}
+	if q.config.MaxRunningJobsPerDomain > 0 {
+		for _, d := range domains {
+			if perDomain[d] >= q.config.MaxRunningJobsPerDomain {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sortQueue orders jobs by priority descending, then by creation time and name.
+func sortQueue(jobs []*v1alpha1.KusciaJob) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].Spec.Priority != jobs[j].Spec.Priority {
+			return jobs[i].Spec.Priority > jobs[j].Spec.Priority
+		}
+		if !jobs[i].CreationTimestamp.Equal(&jobs[j].CreationTimestamp) {
+			return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+		}
+		return jobs[i].Name < jobs[j].Name
+	})
+}
+
+// Domains returns the distinct domains participating in the job.
+func Domains(job *v1alpha1.KusciaJob) []string {
+	seen := map[string]bool{}
+	var domains []string
+	for _, t := range job.Spec.Tasks {
+		for _, p := range t.Parties {
+			if !seen[p.DomainID] {
+				seen[p.DomainID] = true
+				domains = append(domains, p.DomainID)
+			}
+		}
+	}
+	return domains
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+var base = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func makeJob(name string, phase v1alpha1.KusciaJobPhase, created int, priority int32, domains ...string) *v1alpha1.KusciaJob {
+	var parties []v1alpha1.Party
+	for _, d := range domains {
+		parties = append(parties, v1alpha1.Party{DomainID: d})
+	}
+	return &v1alpha1.KusciaJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(base.Add(time.Duration(created) * time.Second)),
+		},
+		Spec: v1alpha1.KusciaJobSpec{
+			Priority: priority,
+			Tasks:    []v1alpha1.KusciaTaskTemplate{{Parties: parties}},
+		},
+		Status: v1alpha1.KusciaJobStatus{Phase: phase},
+	}
+}
+
+func all(*v1alpha1.KusciaJob) bool { return true }
+
+func TestNew(t *testing.T) {
+	assert.Nil(t, New(&Config{}))
+	assert.Nil(t, New(nil))
+	assert.NotNil(t, New(&Config{MaxRunningJobs: 1}))
+
+	var q *Queue
+	ok, position := q.Dispatch(makeJob("job", v1alpha1.KusciaJobPending, 0, 0, "alice"), nil, all)
+	assert.True(t, ok)
+	assert.Equal(t, 0, position)
+
+	assert.Error(t, CheckConfig(&Config{MaxRunningJobsPerDomain: -1}))
+	assert.NoError(t, CheckConfig(&Config{MaxRunningJobs: 3}))
+}
+
+func TestDispatchGlobalLimit(t *testing.T) {
+	q := New(&Config{MaxRunningJobs: 1})
+	running := makeJob("running", v1alpha1.KusciaJobRunning, 0, 0, "alice")
+	first := makeJob("first", v1alpha1.KusciaJobPending, 1, 0, "alice")
+	second := makeJob("second", v1alpha1.KusciaJobPending, 2, 0, "bob")
+	urgent := makeJob("urgent", v1alpha1.KusciaJobPending, 3, 10, "carol")
+	jobs := []*v1alpha1.KusciaJob{running, first, second, urgent}
+
+	ok, position := q.Dispatch(second, jobs, all)
+	assert.False(t, ok)
+	assert.Equal(t, 3, position)
+	ok, position = q.Dispatch(urgent, jobs, all)
+	assert.False(t, ok)
+	assert.Equal(t, 1, position)
+
+	// urgent takes the free slot first, so first is the next one
+	running.Status.Phase = v1alpha1.KusciaJobSucceeded
+	ok, position = q.Dispatch(first, jobs, all)
+	assert.False(t, ok)
+	assert.Equal(t, 1, position)
+	ok, _ = q.Dispatch(urgent, jobs, all)
+	assert.True(t, ok)
+
+	// the dispatched job takes the slot until it's observed running
+	ok, position = q.Dispatch(first, jobs, all)
+	assert.False(t, ok)
+	assert.Equal(t, 1, position)
+	ok, _ = q.Dispatch(urgent, jobs, all)
+	assert.True(t, ok)
+}
+
+func TestDispatchPerDomainLimit(t *testing.T) {
+	q := New(&Config{MaxRunningJobsPerDomain: 1})
+	running := makeJob("running", v1alpha1.KusciaJobRunning, 0, 0, "alice", "bob")
+	blocked := makeJob("blocked", v1alpha1.KusciaJobPending, 1, 0, "alice", "carol")
+	other := makeJob("other", v1alpha1.KusciaJobPending, 2, 0, "carol", "dave")
+	ignored := makeJob("ignored", v1alpha1.KusciaJobPending, 0, 0, "carol")
+	jobs := []*v1alpha1.KusciaJob{running, blocked, other, ignored}
+	queued := func(j *v1alpha1.KusciaJob) bool { return j.Name != "ignored" }
+
+	ok, position := q.Dispatch(blocked, jobs, queued)
+	assert.False(t, ok)
+	assert.Equal(t, 1, position)
+
+	// the job blocked by alice doesn't block the job of carol behind it
+	ok, _ = q.Dispatch(other, jobs, queued)
+	assert.True(t, ok)
+
+	// other never becomes running, its slot of carol is released after dispatchTTL
+	q.now = func() time.Time { return time.Now().Add(dispatchTTL) }
+	running.Status.Phase = v1alpha1.KusciaJobFailed
+	ok, _ = q.Dispatch(blocked, jobs, queued)
+	assert.True(t, ok)
+}
+
+func TestDomains(t *testing.T) {
+	job := makeJob("job", v1alpha1.KusciaJobPending, 0, 0, "alice", "bob")
+	job.Spec.Tasks = append(job.Spec.Tasks, v1alpha1.KusciaTaskTemplate{Parties: []v1alpha1.Party{{DomainID: "bob"}, {DomainID: "carol"}}})
+	assert.Equal(t, []string{"alice", "bob", "carol"}, Domains(job))
+}
@@ -0,0 +1,321 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema validates json documents against a subset of JSON Schema, which covers the keywords used to
+// describe the task input config of apps: type, properties, required, additionalProperties, items, enum, minimum,
+// maximum, minLength, maxLength, pattern, minItems and maxItems. Other keywords are ignored.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeBoolean = "boolean"
+	TypeNull    = "null"
+)
+
+// Schema is a parsed JSON Schema.
+type Schema struct {
+	Type                 schemaTypes           `json:"type,omitempty"`
+	Properties           map[string]*Schema    `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	AdditionalProperties *additionalProperties `json:"additionalProperties,omitempty"`
+	Items                *Schema               `json:"items,omitempty"`
+	Enum                 []interface{}         `json:"enum,omitempty"`
+	Minimum              *float64              `json:"minimum,omitempty"`
+	Maximum              *float64              `json:"maximum,omitempty"`
+	MinLength            *int                  `json:"minLength,omitempty"`
+	MaxLength            *int                  `json:"maxLength,omitempty"`
+	Pattern              string                `json:"pattern,omitempty"`
+	MinItems             *int                  `json:"minItems,omitempty"`
+	MaxItems             *int                  `json:"maxItems,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes is the type keyword, which is either a type or a list of types.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var typ string
+	if err := json.Unmarshal(data, &typ); err == nil {
+		*t = schemaTypes{typ}
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings")
+	}
+	*t = types
+	return nil
+}
+
+// additionalProperties is either a boolean or a schema of the properties not listed in properties.
+type additionalProperties struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// FieldError is a violation of the schema by a field of the document.
+type FieldError struct {
+	// Field is the path of the field, e.g. sf_node_eval_param.attrs[0].s, it's empty for the document itself.
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Parse parses the schema, it fails if the schema is malformed, e.g. an unknown type or an invalid pattern.
+func Parse(data string) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal([]byte(data), s); err != nil {
+		return nil, fmt.Errorf("invalid json schema, %v", err)
+	}
+	if err := s.compile(""); err != nil {
+		return nil, fmt.Errorf("invalid json schema, %v", err)
+	}
+	return s, nil
+}
+
+func (s *Schema) compile(path string) error {
+	for _, typ := range s.Type {
+		switch typ {
+		case TypeObject, TypeArray, TypeString, TypeNumber, TypeInteger, TypeBoolean, TypeNull:
+		default:
+			return fmt.Errorf("unknown type %q of %s", typ, displayPath(path))
+		}
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern of %s, %v", displayPath(path), err)
+		}
+		s.pattern = pattern
+	}
+	for name, property := range s.Properties {
+		if property == nil {
+			return fmt.Errorf("schema of %s can not be null", displayPath(joinField(path, name)))
+		}
+		if err := property.compile(joinField(path, name)); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		if err := s.AdditionalProperties.Schema.compile(joinField(path, "*")); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(path + "[*]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateJSON validates the json document against the schema, it returns all violations ordered by field.
+func (s *Schema) ValidateJSON(data string) []*FieldError {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(data)))
+	if err := decoder.Decode(&value); err != nil {
+		return []*FieldError{{Message: fmt.Sprintf("invalid json, %v", err)}}
+	}
+	return s.Validate(value)
+}
+
+// Validate validates the value decoded by encoding/json against the schema.
+func (s *Schema) Validate(value interface{}) []*FieldError {
+	var errs []*FieldError
+	s.validate("", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *[]*FieldError) {
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, &FieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !s.matchType(value) {
+		fail("must be of type %s, got %s", strings.Join(s.Type, " or "), typeOf(value))
+		return
+	}
+	if len(s.Enum) > 0 && !s.inEnum(value) {
+		fail("must be one of %s, got %s", formatValues(s.Enum), formatValue(value))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(path, v, errs)
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("must have at least %d items, got %d", *s.MinItems, len(v))
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("must have at most %d items, got %d", *s.MaxItems, len(v))
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	case string:
+		length := len([]rune(v))
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("length must be at least %d, got %d", *s.MinLength, length)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("length must be at most %d, got %d", *s.MaxLength, length)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match pattern %q, got %q", s.Pattern, v)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("must be greater than or equal to %v, got %v", *s.Minimum, v)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("must be less than or equal to %v, got %v", *s.Maximum, v)
+		}
+	}
+}
+
+func (s *Schema) validateObject(path string, value map[string]interface{}, errs *[]*FieldError) {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			*errs = append(*errs, &FieldError{Field: joinField(path, name), Message: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property, ok := s.Properties[name]; ok {
+			property.validate(joinField(path, name), value[name], errs)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if !s.AdditionalProperties.Allowed {
+			*errs = append(*errs, &FieldError{Field: joinField(path, name), Message: "is not a known field"})
+			continue
+		}
+		if s.AdditionalProperties.Schema != nil {
+			s.AdditionalProperties.Schema.validate(joinField(path, name), value[name], errs)
+		}
+	}
+}
+
+func (s *Schema) matchType(value interface{}) bool {
+	actual := typeOf(value)
+	for _, typ := range s.Type {
+		if typ == actual {
+			return true
+		}
+		if typ == TypeNumber && actual == TypeInteger {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) inEnum(value interface{}) bool {
+	for _, candidate := range s.Enum {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the most specific type of value, integral numbers are integer.
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return TypeObject
+	case []interface{}:
+		return TypeArray
+	case string:
+		return TypeString
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return TypeInteger
+		}
+		return TypeNumber
+	case bool:
+		return TypeBoolean
+	case nil:
+		return TypeNull
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func formatValues(values []interface{}) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		formatted = append(formatted, formatValue(value))
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "root"
+	}
+	return path
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+  "type": "object",
+  "required": ["sf_node_eval_param"],
+  "additionalProperties": false,
+  "properties": {
+    "sf_input_ids": {"type": "array", "items": {"type": "string", "minLength": 1}, "minItems": 1},
+    "sf_output_uris": {"type": "array", "items": {"type": "string", "pattern": "^[a-z-]+\\.csv$"}},
+    "sf_node_eval_param": {
+      "type": "object",
+      "required": ["domain", "name"],
+      "properties": {
+        "domain": {"type": "string"},
+        "name": {"enum": ["psi", "train_test_split"]},
+        "version": {"type": "string", "maxLength": 8},
+        "attrs": {"type": "array", "maxItems": 2, "items": {"type": "object", "additionalProperties": {"type": ["number", "boolean"], "minimum": 0, "maximum": 1}}}
+      }
+    }
+  }
+}`
+
+func TestValidateJSON(t *testing.T) {
+	t.Parallel()
+	schema, err := Parse(testSchema)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		document string
+		errors   []string
+	}{
+		{
+			name:     "valid",
+			document: `{"sf_input_ids":["alice-table"],"sf_node_eval_param":{"domain":"data_prep","name":"psi","attrs":[{"f":0.75,"b":true}]}}`,
+		},
+		{
+			name:     "invalid json",
+			document: `{"sf_input_ids":`,
+			errors:   []string{"invalid json, unexpected EOF"},
+		},
+		{
+			name:     "root type",
+			document: `[]`,
+			errors:   []string{"must be of type object, got array"},
+		},
+		{
+			name:     "field errors",
+			document: `{"sf_input_ids":"alice-table","sf_output_uri":["a.csv"],"sf_node_eval_param":{"name":"psii","version":"0.0.5-beta1","attrs":[{"f":2},{"i":"1"},{}]}}`,
+			errors: []string{
+				"sf_input_ids: must be of type array, got string",
+				"sf_node_eval_param.domain: is required",
+				"sf_node_eval_param.attrs: must have at most 2 items, got 3",
+				"sf_node_eval_param.attrs[0].f: must be less than or equal to 1, got 2",
+				"sf_node_eval_param.attrs[1].i: must be of type number or boolean, got string",
+				"sf_node_eval_param.name: must be one of [\"psi\", \"train_test_split\"], got \"psii\"",
+				"sf_node_eval_param.version: length must be at most 8, got 11",
+				"sf_output_uri: is not a known field",
+			},
+		},
+		{
+			name:     "array items",
+			document: `{"sf_input_ids":[""],"sf_output_uris":["psi-output.csv","PSI.txt"],"sf_node_eval_param":{"domain":"data_prep","name":"psi"}}`,
+			errors: []string{
+				"sf_input_ids[0]: length must be at least 1, got 0",
+				"sf_output_uris[1]: must match pattern \"^[a-z-]+\\\\.csv$\", got \"PSI.txt\"",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errors []string
+			for _, err := range schema.ValidateJSON(tt.document) {
+				errors = append(errors, err.Error())
+			}
+			assert.Equal(t, tt.errors, errors)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{schema: `{"type":"integer","minimum":1}`},
+		{schema: `{"type":"object","additionalProperties":true}`},
+		{schema: `{"type":"obj"}`, err: `invalid json schema, unknown type "obj" of root`},
+		{schema: `{"properties":{"a":{"items":{"pattern":"("}}}}`, err: "invalid json schema, invalid pattern of a[*]"},
+		{schema: `{"type":1}`, err: "invalid json schema, type must be a string or an array of strings"},
+		{schema: `not json`, err: "invalid json schema"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.schema)
+		if tt.err == "" {
+			assert.NoError(t, err, tt.schema)
+		} else {
+			assert.ErrorContains(t, err, tt.err, tt.schema)
+		}
+	}
+}
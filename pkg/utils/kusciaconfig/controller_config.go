@@ -0,0 +1,80 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kusciaconfig
+
+import (
+	"fmt"
+	"time"
+)
+
+// MinResyncPeriod avoids re-delivering all resources from informers too frequently.
+const MinResyncPeriod = 10 * time.Second
+
+// ControllerTuning defines the concurrency of a controller.
+type ControllerTuning struct {
+	// Workers is the count of goroutines reconciling the queues of controller.
+	Workers int `yaml:"workers,omitempty"`
+	// ResyncPeriod is the period of informers of controller re-delivering all resources.
+	ResyncPeriod time.Duration `yaml:"resyncPeriod,omitempty"`
+}
+
+// ControllersConfig defines the concurrency of controllers, Overrides is keyed by controller name, e.g. kusciajob.
+type ControllersConfig struct {
+	// Workers is the default count of workers of controllers.
+	Workers int `yaml:"workers,omitempty"`
+	// Overrides tunes workers and resync period of a single controller.
+	Overrides map[string]ControllerTuning `yaml:"overrides,omitempty"`
+}
+
+// GetWorkers returns the workers of controller, or defaultWorkers if neither the override nor the default is set.
+func (c ControllersConfig) GetWorkers(name string, defaultWorkers int) int {
+	if t, ok := c.Overrides[name]; ok && t.Workers > 0 {
+		return t.Workers
+	}
+	if c.Workers > 0 {
+		return c.Workers
+	}
+	return defaultWorkers
+}
+
+// GetResyncPeriod returns the resync period of controller, 0 means the default of the controller.
+func (c ControllersConfig) GetResyncPeriod(name string) time.Duration {
+	return c.Overrides[name].ResyncPeriod
+}
+
+func CheckControllersConfig(config *ControllersConfig, names []string) error {
+	if config.Workers < 0 {
+		return fmt.Errorf("controllers workers can't be negative")
+	}
+	known := map[string]bool{}
+	for _, name := range names {
+		known[name] = true
+	}
+	for name, t := range config.Overrides {
+		if !known[name] {
+			return fmt.Errorf("unknown controller %q in controllers overrides, valid controllers are %v", name, names)
+		}
+		if t.Workers < 0 {
+			return fmt.Errorf("workers of controller %q can't be negative", name)
+		}
+		if t.ResyncPeriod < 0 {
+			return fmt.Errorf("resyncPeriod of controller %q can't be negative", name)
+		}
+		if t.ResyncPeriod > 0 && t.ResyncPeriod < MinResyncPeriod {
+			return fmt.Errorf("resyncPeriod %v of controller %q is too short, must be at least %v", t.ResyncPeriod, name, MinResyncPeriod)
+		}
+	}
+	return nil
+}
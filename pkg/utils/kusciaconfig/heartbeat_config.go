@@ -0,0 +1,63 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kusciaconfig
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultHeartbeatInterval      = 15 * time.Second
+	DefaultHeartbeatMissThreshold = 4
+)
+
+// HeartbeatConfig defines the liveness protocol between lite domains and master. Gateways of lite domains report
+// heartbeats to master every interval, and master marks a domain unreachable after missThreshold heartbeats are missed.
+type HeartbeatConfig struct {
+	// Interval is the period of heartbeats reported by gateways, default 15s.
+	Interval time.Duration `yaml:"interval,omitempty"`
+	// MissThreshold is the count of missed heartbeats before a domain is unreachable, default 4.
+	MissThreshold int `yaml:"missThreshold,omitempty"`
+}
+
+// GetInterval returns the heartbeat interval, or the default one if it's not set.
+func (c HeartbeatConfig) GetInterval() time.Duration {
+	if c.Interval <= 0 {
+		return DefaultHeartbeatInterval
+	}
+	return c.Interval
+}
+
+// GetMissThreshold returns the miss threshold, or the default one if it's not set.
+func (c HeartbeatConfig) GetMissThreshold() int {
+	if c.MissThreshold <= 0 {
+		return DefaultHeartbeatMissThreshold
+	}
+	return c.MissThreshold
+}
+
+func CheckHeartbeatConfig(config *HeartbeatConfig) error {
+	if config.Interval < 0 {
+		return fmt.Errorf("heartbeat interval can't be negative")
+	}
+	if config.Interval > 0 && config.Interval < time.Second {
+		return fmt.Errorf("heartbeat interval %v is too short, must be at least 1s", config.Interval)
+	}
+	if config.MissThreshold < 0 {
+		return fmt.Errorf("heartbeat missThreshold can't be negative")
+	}
+	return nil
+}
@@ -16,6 +16,8 @@ package kusciaconfig
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 )
 
 type ServiceConfig struct {
@@ -37,6 +39,76 @@ type MasterConfig struct {
 	KusciaAPI         *ServiceConfig   `yaml:"kusciaAPI,omitempty"`
 	Reporter          *ServiceConfig   `yaml:"reporter,omitempty"`
 	APIWhitelist      []string         `yaml:"apiWhitelist,omitempty"`
+	Sync              MasterSyncConfig `yaml:"sync,omitempty"`
+	// StandbyEndpoints are the endpoints of standby masters of the same organization, e.g. masters in other
+	// regions sharing the same datastore. Lite nodes fail over to them in order when the active master is down.
+	StandbyEndpoints []string `yaml:"standbyEndpoints,omitempty"`
+}
+
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// MasterSyncConfig defines how a lite node syncs resources from master over the tunnel.
+type MasterSyncConfig struct {
+	// Compression is the content encoding requested for responses from master, gzip or zstd. Empty means no compression.
+	Compression string `yaml:"compression,omitempty"`
+	// BandwidthLimitKbps is the bandwidth budget of the traffic between lite and master, 0 means unlimited.
+	BandwidthLimitKbps int64 `yaml:"bandwidthLimitKbps,omitempty"`
+	// ResyncPeriod is the resync period of informers watching resources from master.
+	ResyncPeriod time.Duration `yaml:"resyncPeriod,omitempty"`
+	// WatchIdleTimeout bounds how long a watch stream to master may be idle before it is reset.
+	// It should be longer than the watch timeout of informers, otherwise informers re-list resources after the reset.
+	WatchIdleTimeout time.Duration `yaml:"watchIdleTimeout,omitempty"`
+}
+
+func CheckMasterSyncConfig(config *MasterSyncConfig) error {
+	switch config.Compression {
+	case "", CompressionGzip, CompressionZstd:
+	default:
+		return fmt.Errorf("master sync compression %q is not supported, must be %s or %s", config.Compression, CompressionGzip, CompressionZstd)
+	}
+
+	if config.BandwidthLimitKbps < 0 {
+		return fmt.Errorf("master sync bandwidthLimitKbps can't be negative")
+	}
+
+	if config.ResyncPeriod < 0 || config.WatchIdleTimeout < 0 {
+		return fmt.Errorf("master sync resyncPeriod and watchIdleTimeout can't be negative")
+	}
+	return nil
+}
+
+// CheckStandbyEndpoints checks the standby masters use the same scheme as the active one, because lite nodes
+// reach all of them with the same upstream cluster.
+func CheckStandbyEndpoints(config *MasterConfig) error {
+	if len(config.StandbyEndpoints) == 0 {
+		return nil
+	}
+	if config.Endpoint == "" {
+		return fmt.Errorf("master standbyEndpoints must be used with endpoint")
+	}
+
+	active, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid master endpoint %q, detail-> %v", config.Endpoint, err)
+	}
+	seen := map[string]bool{config.Endpoint: true}
+	for _, endpoint := range config.StandbyEndpoints {
+		standby, err := url.Parse(endpoint)
+		if err != nil || standby.Host == "" {
+			return fmt.Errorf("invalid master standby endpoint %q", endpoint)
+		}
+		if standby.Scheme != active.Scheme {
+			return fmt.Errorf("master standby endpoint %q must use the same scheme as %q", endpoint, config.Endpoint)
+		}
+		if seen[endpoint] {
+			return fmt.Errorf("master endpoint %q is duplicated", endpoint)
+		}
+		seen[endpoint] = true
+	}
+	return nil
 }
 
 func (m *MasterConfig) IsMaster() bool {
@@ -77,6 +149,14 @@ func CheckMasterConfig(config *MasterConfig) error {
 		}
 	}
 
+	if err := CheckMasterSyncConfig(&config.Sync); err != nil {
+		return err
+	}
+
+	if err := CheckStandbyEndpoints(config); err != nil {
+		return err
+	}
+
 	if config.KusciaStorage != nil {
 		return CheckServiceConfig(config.KusciaStorage, "kusciastorage")
 	}
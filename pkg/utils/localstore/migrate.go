@@ -0,0 +1,124 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const schemaTable = "kuscia_schema_version"
+
+// migration upgrades the schema from version-1 to version. Statements differ between drivers, so both are given.
+type migration struct {
+	version int
+	sqlite  []string
+	mysql   []string
+}
+
+// migrations must be appended only, never change a released one.
+var migrations = []migration{
+	{
+		version: 1,
+		sqlite: []string{
+			`CREATE TABLE IF NOT EXISTS ` + stateTable + ` (
+				kind TEXT NOT NULL,
+				name TEXT NOT NULL,
+				value BLOB NOT NULL,
+				updated_at INTEGER NOT NULL,
+				PRIMARY KEY (kind, name)
+			)`,
+		},
+		mysql: []string{
+			`CREATE TABLE IF NOT EXISTS ` + stateTable + ` (
+				kind VARCHAR(32) NOT NULL,
+				name VARCHAR(255) NOT NULL,
+				value LONGBLOB NOT NULL,
+				updated_at BIGINT NOT NULL,
+				PRIMARY KEY (kind, name)
+			) DEFAULT CHARSET=utf8mb4`,
+		},
+	},
+}
+
+// migrate applies migrations newer than the version recorded in schema table, each one in a transaction. MySQL commits
+// DDL implicitly, so the statements of a migration must be idempotent.
+func migrate(ctx context.Context, db *sql.DB, driver string) error {
+	return migrateTo(ctx, db, driver, migrations)
+}
+
+func migrateTo(ctx context.Context, db *sql.DB, driver string, migrations []migration) error {
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+schemaTable+" (version INTEGER NOT NULL)"); err != nil {
+		return fmt.Errorf("create schema version table failed, %v", err)
+	}
+	current, err := schemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].version
+	}
+	if current > latest {
+		return fmt.Errorf("schema version %d of local store is newer than %d supported, downgrade isn't supported", current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		stmts := m.sqlite
+		if driver == driverMysql {
+			stmts = m.mysql
+		}
+		if err := applyMigration(ctx, db, m.version, stmts); err != nil {
+			return fmt.Errorf("migrate local store to version %d failed, %v", m.version, err)
+		}
+		nlog.Infof("Local store is migrated to schema version %d", m.version)
+	}
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sql.DB, version int, stmts []string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+schemaTable); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO "+schemaTable+" (version) VALUES (?)", version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func schemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, "SELECT MAX(version) FROM "+schemaTable).Scan(&version); err != nil {
+		return 0, fmt.Errorf("query schema version failed, %v", err)
+	}
+	return int(version.Int64), nil
+}
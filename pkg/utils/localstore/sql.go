@@ -0,0 +1,113 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	// register sqlite3 driver
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const stateTable = "kuscia_local_state"
+
+type sqlStore struct {
+	db     *sql.DB
+	driver string
+}
+
+func openSQLStore(ctx context.Context, driver, dsn string) (*sqlStore, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open local store failed, %v", err)
+	}
+	if driver == driverSqlite {
+		// sqlite allows only one writer, serialize writes in process instead of failing with SQLITE_BUSY.
+		db.SetMaxOpenConns(1)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := db.PingContext(pingCtx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping local store failed, %v", err)
+	}
+
+	s := &sqlStore{db: db, driver: driver}
+	if err := migrate(ctx, db, driver); err != nil {
+		db.Close()
+		return nil, err
+	}
+	nlog.Infof("Local store is ready, driver: %s", driver)
+	return s, nil
+}
+
+func (s *sqlStore) Get(ctx context.Context, kind, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM "+stateTable+" WHERE kind = ? AND name = ?", kind, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (s *sqlStore) Put(ctx context.Context, kind, key string, value []byte) error {
+	stmt := "INSERT INTO " + stateTable + " (kind, name, value, updated_at) VALUES (?, ?, ?, ?) "
+	if s.driver == driverMysql {
+		stmt += "ON DUPLICATE KEY UPDATE value = VALUES(value), updated_at = VALUES(updated_at)"
+	} else {
+		stmt += "ON CONFLICT(kind, name) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at"
+	}
+	_, err := s.db.ExecContext(ctx, stmt, kind, key, value, time.Now().Unix())
+	return err
+}
+
+func (s *sqlStore) Delete(ctx context.Context, kind, key string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM "+stateTable+" WHERE kind = ? AND name = ?", kind, key)
+	return err
+}
+
+func (s *sqlStore) List(ctx context.Context, kind string) (map[string][]byte, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT name, value FROM "+stateTable+" WHERE kind = ?", kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := map[string][]byte{}
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
+
+func (s *sqlStore) SchemaVersion(ctx context.Context) (int, error) {
+	return schemaVersion(ctx, s.db)
+}
+
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
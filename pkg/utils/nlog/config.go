@@ -14,10 +14,31 @@
 
 package nlog
 
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	// Stable field names of logs in json format.
+	FieldModule  = "module"
+	FieldDomain  = "domain"
+	FieldJobID   = "job_id"
+	FieldTraceID = "trace_id"
+)
+
 type LogConfig struct {
 	LogLevel string
 	LogPath  string
 
+	// LogFormat is text or json, it defaults to text.
+	LogFormat string
+
+	// ModuleLevels overrides LogLevel of modules. The module of a log is the package path of its caller under pkg,
+	// e.g. gateway/controller, and the level of gateway applies to all packages under it.
+	ModuleLevels map[string]string
+
+	// Fields are added to every log in json format, e.g. domain.
+	Fields map[string]string
+
 	// MaxFileSizeMB is the maximum size in megabytes of the log file before it gets
 	// rotated. It defaults to 100 megabytes.
 	MaxFileSizeMB int
@@ -0,0 +1,40 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import "context"
+
+type fieldsKey struct{}
+
+// FieldsWriter is implemented by writers supporting structured fields.
+type FieldsWriter interface {
+	WithFields(keysAndValues ...string) LogWriter
+}
+
+// ContextWithFields returns a context carrying log fields, e.g. ContextWithFields(ctx, FieldJobID, jobID). Loggers
+// returned by WithCtx(ctx) add them to every log if the writer supports fields.
+func ContextWithFields(ctx context.Context, keysAndValues ...string) context.Context {
+	fields := append(append([]string(nil), FieldsFromContext(ctx)...), keysAndValues...)
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// FieldsFromContext returns the log fields carried by ctx.
+func FieldsFromContext(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]string)
+	return fields
+}
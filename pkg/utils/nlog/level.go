@@ -0,0 +1,76 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// LevelPath is where log levels are served and changed.
+const LevelPath = "/loglevel"
+
+// LevelController is implemented by writers whose levels can be changed at runtime.
+type LevelController interface {
+	ChangeLogLevel(level string) error
+	// SetModuleLevel overrides the level of module, an empty level removes the override.
+	SetModuleLevel(module, level string) error
+	Levels() LevelStatus
+}
+
+// LevelStatus is the global level and the overrides of modules.
+type LevelStatus struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules,omitempty"`
+}
+
+// InstallLevelHandler installs LevelPath of default logger to mux. GET returns the levels, PUT with query
+// level=DEBUG changes the global level, and with module=gateway/controller&level=DEBUG changes the level of a module.
+func InstallLevelHandler(mux *http.ServeMux) {
+	mux.HandleFunc(LevelPath, func(w http.ResponseWriter, r *http.Request) {
+		controller, ok := defaultLogger.logWriter.(LevelController)
+		if !ok {
+			http.Error(w, "log writer doesn't support changing level", http.StatusNotImplemented)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			module, level := r.URL.Query().Get("module"), r.URL.Query().Get("level")
+			var err error
+			if module == "" {
+				err = controller.ChangeLogLevel(level)
+			} else {
+				err = controller.SetModuleLevel(module, level)
+			}
+			if err != nil {
+				http.Error(w, fmt.Sprintf("change log level failed, %v", err), http.StatusBadRequest)
+				return
+			}
+			Infof("Log level of module [%s] is changed to [%s]", module, level)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := json.Marshal(controller.Levels())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+}
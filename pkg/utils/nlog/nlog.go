@@ -30,6 +30,11 @@ func (n *NLog) WithCtx(ctx context.Context) *NLog {
 	if ctx == nil {
 		ret.ctx = context.Background()
 	}
+	if fields := FieldsFromContext(ret.ctx); len(fields) > 0 {
+		if w, ok := n.logWriter.(FieldsWriter); ok {
+			ret.logWriter = w.WithFields(fields...)
+		}
+	}
 	return ret
 }
 
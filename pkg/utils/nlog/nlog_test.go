@@ -16,7 +16,10 @@ package nlog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -116,3 +119,52 @@ func TestDefaultLogger(t *testing.T) {
 	ctx := context.Background()
 	logTest(ctx, log)
 }
+
+type fakeLevelWriter struct {
+	defaultLogWriter
+	status LevelStatus
+}
+
+func (w *fakeLevelWriter) ChangeLogLevel(level string) error {
+	w.status.Level = level
+	return nil
+}
+
+func (w *fakeLevelWriter) SetModuleLevel(module, level string) error {
+	if w.status.Modules == nil {
+		w.status.Modules = map[string]string{}
+	}
+	w.status.Modules[module] = level
+	return nil
+}
+
+func (w *fakeLevelWriter) Levels() LevelStatus {
+	return w.status
+}
+
+func TestInstallLevelHandler(t *testing.T) {
+	old := defaultLogger
+	defer func() { defaultLogger = old }()
+	writer := &fakeLevelWriter{status: LevelStatus{Level: "INFO"}}
+	Setup(SetWriter(writer))
+	mux := http.NewServeMux()
+	InstallLevelHandler(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, LevelPath+"?module=gateway&level=DEBUG", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, LevelPath+"?level=WARN", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, LevelPath, nil))
+	status := LevelStatus{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, LevelStatus{Level: "WARN", Modules: map[string]string{"gateway": "DEBUG"}}, status)
+
+	Setup()
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, LevelPath, nil))
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zlogwriter
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+// moduleLevels is the global level and the level overrides of modules.
+type moduleLevels struct {
+	global zap.AtomicLevel
+
+	mtx     sync.RWMutex
+	modules map[string]zapcore.Level
+}
+
+func newModuleLevels(global zap.AtomicLevel) *moduleLevels {
+	return &moduleLevels{global: global, modules: map[string]zapcore.Level{}}
+}
+
+func (m *moduleLevels) set(module, level string) error {
+	module = strings.Trim(module, "/")
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if level == "" {
+		delete(m.modules, module)
+		return nil
+	}
+	var l zapcore.Level
+	if err := l.Set(level); err != nil {
+		return err
+	}
+	m.modules[module] = l
+	return nil
+}
+
+// enabled reports whether a log of level may be written by any module, the exact check is done when the caller is
+// known.
+func (m *moduleLevels) enabled(level zapcore.Level) bool {
+	if m.global.Enabled(level) {
+		return true
+	}
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	for _, l := range m.modules {
+		if l.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// levelOf returns the level of the longest module matching module.
+func (m *moduleLevels) levelOf(module string) zapcore.Level {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	level, matched := m.global.Level(), -1
+	for name, l := range m.modules {
+		if (module == name || strings.HasPrefix(module, name+"/")) && len(name) > matched {
+			level, matched = l, len(name)
+		}
+	}
+	return level
+}
+
+func (m *moduleLevels) status() nlog.LevelStatus {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	status := nlog.LevelStatus{Level: m.global.Level().CapitalString()}
+	if len(m.modules) > 0 {
+		status.Modules = make(map[string]string, len(m.modules))
+		for name, l := range m.modules {
+			status.Modules[name] = l.CapitalString()
+		}
+	}
+	return status
+}
+
+// moduleCore filters logs by the level of the module of their callers, and adds the module field in json format.
+type moduleCore struct {
+	zapcore.Core
+	levels      *moduleLevels
+	moduleField bool
+}
+
+func (c *moduleCore) Enabled(level zapcore.Level) bool {
+	return c.levels.enabled(level)
+}
+
+func (c *moduleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &moduleCore{Core: c.Core.With(fields), levels: c.levels, moduleField: c.moduleField}
+}
+
+func (c *moduleCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write is called after the caller is filled into entry.
+func (c *moduleCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	module := ""
+	if entry.Caller.Defined {
+		module = moduleOf(entry.Caller.File)
+	}
+	if !c.levels.levelOf(module).Enabled(entry.Level) {
+		return nil
+	}
+	if c.moduleField && module != "" {
+		fields = append(fields, zap.String(nlog.FieldModule, module))
+	}
+	return c.Core.Write(entry, fields)
+}
+
+// moduleOf returns the package path of file under pkg, e.g. gateway/controller for .../pkg/gateway/controller/x.go,
+// files under cmd keep the cmd prefix.
+func moduleOf(file string) string {
+	segments := strings.Split(path.Dir(filepath.ToSlash(file)), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] == "pkg" {
+			return strings.Join(segments[i+1:], "/")
+		}
+	}
+	for i, segment := range segments {
+		if segment == "cmd" {
+			return strings.Join(segments[i:], "/")
+		}
+	}
+	return segments[len(segments)-1]
+}
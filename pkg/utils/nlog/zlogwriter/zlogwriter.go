@@ -17,7 +17,10 @@ package zlogwriter
 import (
 	"bytes"
 	"flag"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/secretflow/kuscia/pkg/utils/nlog"
@@ -31,6 +34,9 @@ import (
 type Writer struct {
 	*zap.SugaredLogger
 	atomicLevel zap.AtomicLevel
+	levels      *moduleLevels
+	// fields are only written in json format, the text format stays unchanged.
+	jsonFormat bool
 }
 
 type WriterWrapper struct {
@@ -46,6 +52,7 @@ func InstallFlags(flagset *flag.FlagSet) *nlog.LogConfig {
 	c := &nlog.LogConfig{}
 	flagset.StringVar(&c.LogLevel, "log.level", "INFO", "Logs of this level or above will be output")
 	flagset.StringVar(&c.LogPath, "log.path", "", "Also output logs to this file, empty means only output to stdout")
+	flagset.StringVar(&c.LogFormat, "log.format", nlog.LogFormatText, "Log format, choose from text and json")
 	flagset.IntVar(&c.MaxFileSizeMB, "log.file_size", 512, "Maximum size in megabytes of the log file before it gets rotated")
 	flagset.IntVar(&c.MaxFiles, "log.max_files", 10, "Maximum number of old log files to retain")
 	return c
@@ -60,6 +67,7 @@ func InstallPFlags(flagset *pflag.FlagSet) *nlog.LogConfig {
 	c := &nlog.LogConfig{}
 	flagset.StringVar(&c.LogLevel, "log.level", "INFO", "Logs of this level or above will be output")
 	flagset.StringVar(&c.LogPath, "log.path", "", "Also output logs to this file, empty means only output to stdout")
+	flagset.StringVar(&c.LogFormat, "log.format", nlog.LogFormatText, "Log format, choose from text and json")
 	flagset.IntVar(&c.MaxFileSizeMB, "log.file_size", 512, "Maximum size in megabytes of the log file before it gets rotated")
 	flagset.IntVar(&c.MaxFiles, "log.max_files", 10, "Maximum number of old log files to retain")
 	return c
@@ -73,8 +81,17 @@ func New(config *nlog.LogConfig) (nlog.LogWriter, error) {
 		}
 	}
 	atomicLevel := zap.NewAtomicLevel()
+	if err := changeLogLevel(atomicLevel, config.LogLevel); err != nil {
+		return nil, err
+	}
+	levels := newModuleLevels(atomicLevel)
+	for module, level := range config.ModuleLevels {
+		if err := levels.set(module, level); err != nil {
+			return nil, err
+		}
+	}
 
-	log, err := newZapLogger(config, &zapcore.EncoderConfig{
+	encoderConfig := zapcore.EncoderConfig{
 		ConsoleSeparator: " ",
 
 		LevelKey:   "Level",
@@ -87,21 +104,37 @@ func New(config *nlog.LogConfig) (nlog.LogWriter, error) {
 		EncodeTime: func(time time.Time, encoder zapcore.PrimitiveArrayEncoder) {
 			encoder.AppendString(time.Format("2006-01-02 15:04:05.000"))
 		},
-	}, atomicLevel)
-	if err != nil {
-		return nil, err
+	}
+	var encoder zapcore.Encoder
+	jsonFormat := false
+	switch strings.ToLower(config.LogFormat) {
+	case "", nlog.LogFormatText:
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	case nlog.LogFormatJSON:
+		encoderConfig.LevelKey = "level"
+		encoderConfig.TimeKey = "time"
+		encoderConfig.MessageKey = "msg"
+		encoderConfig.CallerKey = "caller"
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+		jsonFormat = true
+	default:
+		return nil, fmt.Errorf("unknown log format %q, choose from text and json", config.LogFormat)
 	}
 
+	log := newZapLogger(config, encoder, levels, jsonFormat)
 	writer := &Writer{
-		log,
-		atomicLevel,
+		SugaredLogger: log,
+		atomicLevel:   atomicLevel,
+		levels:        levels,
+		jsonFormat:    jsonFormat,
 	}
 
 	return writer, nil
 }
 
 // newZapLogger creates a *zap.SugaredLogger object.
-func newZapLogger(config *nlog.LogConfig, encoderConfig *zapcore.EncoderConfig, atomicLevel zap.AtomicLevel) (*zap.SugaredLogger, error) {
+func newZapLogger(config *nlog.LogConfig, encoder zapcore.Encoder, levels *moduleLevels, jsonFormat bool) *zap.SugaredLogger {
 	syncer := zapcore.AddSync(os.Stdout)
 	if config.LogPath != "" {
 		syncer = zapcore.NewMultiWriteSyncer(syncer, zapcore.AddSync(&lumberjack.Logger{
@@ -113,14 +146,27 @@ func newZapLogger(config *nlog.LogConfig, encoderConfig *zapcore.EncoderConfig,
 		}))
 	}
 
-	if err := changeLogLevel(atomicLevel, config.LogLevel); err != nil {
-		return nil, err
+	// levels are checked by module core, the inner core accepts all
+	var core zapcore.Core = &moduleCore{
+		Core:        zapcore.NewCore(encoder, syncer, zapcore.DebugLevel),
+		levels:      levels,
+		moduleField: jsonFormat,
+	}
+	if jsonFormat && len(config.Fields) > 0 {
+		keys := make([]string, 0, len(config.Fields))
+		for k := range config.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fields := make([]zap.Field, 0, len(keys))
+		for _, k := range keys {
+			fields = append(fields, zap.String(k, config.Fields[k]))
+		}
+		core = core.With(fields)
 	}
-
-	core := zapcore.NewCore(zapcore.NewConsoleEncoder(*encoderConfig), syncer, atomicLevel)
 	zap.ReplaceGlobals(zap.New(core).WithOptions(zap.AddCaller()))
 
-	return zap.L().WithOptions(zap.AddCallerSkip(1)).Sugar(), nil
+	return zap.L().WithOptions(zap.AddCallerSkip(1)).Sugar()
 }
 
 func changeLogLevel(atomicLevel zap.AtomicLevel, newLevel string) error {
@@ -138,6 +184,33 @@ func (w *Writer) ChangeLogLevel(newLevel string) error {
 	return changeLogLevel(w.atomicLevel, newLevel)
 }
 
+// SetModuleLevel overrides the log level of module on the fly, an empty level removes the override.
+func (w *Writer) SetModuleLevel(module, level string) error {
+	return w.levels.set(module, level)
+}
+
+// Levels returns the global level and the overrides of modules.
+func (w *Writer) Levels() nlog.LevelStatus {
+	return w.levels.status()
+}
+
+// WithFields returns a writer adding fields to every log, fields are only visible in json format.
+func (w *Writer) WithFields(keysAndValues ...string) nlog.LogWriter {
+	if !w.jsonFormat {
+		return w
+	}
+	args := make([]interface{}, 0, len(keysAndValues))
+	for _, kv := range keysAndValues {
+		args = append(args, kv)
+	}
+	return &Writer{
+		SugaredLogger: w.SugaredLogger.With(args...),
+		atomicLevel:   w.atomicLevel,
+		levels:        w.levels,
+		jsonFormat:    w.jsonFormat,
+	}
+}
+
 // Flush flushes any buffered log entries.
 func (w *Writer) Flush() error {
 	return w.Sync()
@@ -16,7 +16,10 @@ package zlogwriter
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,3 +49,54 @@ func TestNewNLogWithNilContext(t *testing.T) {
 	assert.True(t, log != nil)
 	logTest(ctx, log)
 }
+
+func TestJSONFormatAndModuleLevels(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "json.log")
+	writer, err := New(&nlog.LogConfig{
+		LogPath:      logPath,
+		LogLevel:     "INFO",
+		LogFormat:    nlog.LogFormatJSON,
+		ModuleLevels: map[string]string{"utils/nlog": "WARN"},
+		Fields:       map[string]string{nlog.FieldDomain: "alice"},
+	})
+	assert.NoError(t, err)
+	log := nlog.NewNLog(nlog.SetWriter(writer))
+	ctx := nlog.ContextWithFields(context.Background(), nlog.FieldJobID, "job-1")
+
+	// the caller is in module utils/nlog/zlogwriter, which is warn level
+	log.WithCtx(ctx).Info("hidden info")
+	log.WithCtx(ctx).Warn("shown warn")
+	controller := writer.(nlog.LevelController)
+	assert.NoError(t, controller.SetModuleLevel("utils/nlog/zlogwriter", "DEBUG"))
+	log.Debug("shown debug")
+	assert.Equal(t, nlog.LevelStatus{Level: "INFO", Modules: map[string]string{"utils/nlog": "WARN", "utils/nlog/zlogwriter": "DEBUG"}},
+		controller.Levels())
+	assert.Error(t, controller.SetModuleLevel("gateway", "LOUD"))
+	_ = writer.Sync()
+
+	content, err := os.ReadFile(logPath)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2)
+	entry := map[string]string{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "shown warn", entry["msg"])
+	assert.Equal(t, "utils/nlog/zlogwriter", entry[nlog.FieldModule])
+	assert.Equal(t, "alice", entry[nlog.FieldDomain])
+	assert.Equal(t, "job-1", entry[nlog.FieldJobID])
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "shown debug", entry["msg"])
+}
+
+func TestNewWithUnknownFormat(t *testing.T) {
+	_, err := New(&nlog.LogConfig{LogFormat: "xml"})
+	assert.Error(t, err)
+}
+
+func TestModuleOf(t *testing.T) {
+	assert.Equal(t, "gateway/controller", moduleOf("/root/module/pkg/gateway/controller/admin.go"))
+	assert.Equal(t, "controllers/kusciajob", moduleOf("github.com/secretflow/kuscia/pkg/controllers/kusciajob/controller.go"))
+	assert.Equal(t, "cmd/kuscia/modules", moduleOf("/root/module/cmd/kuscia/modules/runtime.go"))
+	assert.Equal(t, "main", moduleOf("/tmp/main/main.go"))
+}
@@ -372,3 +372,30 @@ func Move(oldPath, newPath string) error {
 
 	return os.Rename(oldPath, newPath)
 }
+
+// DirSize returns the total size of the regular files under the directory, symlinks are not followed.
+// The files removed during the walk are ignored, it returns 0 if the directory doesn't exist.
+func DirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
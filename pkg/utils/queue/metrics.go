@@ -0,0 +1,111 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	// workqueue metrics provider of k8s, it's imported here so that named workqueues always report to legacyregistry
+	// no matter which package is initialized first, as client-go only accepts the first provider.
+	_ "k8s.io/component-base/metrics/prometheus/workqueue"
+)
+
+const workqueueMetricPrefix = "workqueue_"
+
+// QueueStats is the snapshot of a named workqueue.
+type QueueStats struct {
+	Name                           string  `json:"name"`
+	Depth                          int64   `json:"depth"`
+	Adds                           int64   `json:"adds"`
+	Retries                        int64   `json:"retries"`
+	UnfinishedWorkSeconds          float64 `json:"unfinishedWorkSeconds"`
+	LongestRunningProcessorSeconds float64 `json:"longestRunningProcessorSeconds"`
+}
+
+// Gatherer gathers depth, adds, latency, work duration and retries metrics of named workqueues, labeled by queue name.
+// Queues with empty name aren't tracked by client-go.
+var Gatherer prometheus.Gatherer = prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+	mfs, err := legacyregistry.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	var ret []*dto.MetricFamily
+	for _, mf := range mfs {
+		if strings.HasPrefix(mf.GetName(), workqueueMetricPrefix) {
+			ret = append(ret, mf)
+		}
+	}
+	return ret, nil
+})
+
+// MetricsHandler serves metrics of the default prometheus registry together with metrics of workqueues.
+func MetricsHandler() http.Handler {
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.Gatherers{prometheus.DefaultGatherer, Gatherer}, promhttp.HandlerOpts{}))
+}
+
+// Stats returns the snapshot of named workqueues created in this process, sorted by name. Queues of the same name
+// share their metrics.
+func Stats() []QueueStats {
+	mfs, err := Gatherer.Gather()
+	if err != nil {
+		return nil
+	}
+	queues := map[string]*QueueStats{}
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			name := queueName(m)
+			s, ok := queues[name]
+			if !ok {
+				s = &QueueStats{Name: name}
+				queues[name] = s
+			}
+			switch mf.GetName() {
+			case workqueueMetricPrefix + "depth":
+				s.Depth = int64(m.GetGauge().GetValue())
+			case workqueueMetricPrefix + "adds_total":
+				s.Adds = int64(m.GetCounter().GetValue())
+			case workqueueMetricPrefix + "retries_total":
+				s.Retries = int64(m.GetCounter().GetValue())
+			case workqueueMetricPrefix + "unfinished_work_seconds":
+				s.UnfinishedWorkSeconds = m.GetGauge().GetValue()
+			case workqueueMetricPrefix + "longest_running_processor_seconds":
+				s.LongestRunningProcessorSeconds = m.GetGauge().GetValue()
+			}
+		}
+	}
+	stats := make([]QueueStats, 0, len(queues))
+	for _, s := range queues {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+func queueName(m *dto.Metric) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == "name" {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func findStats(name string) *QueueStats {
+	for _, s := range Stats() {
+		if s.Name == name {
+			return &s
+		}
+	}
+	return nil
+}
+
+func TestStats(t *testing.T) {
+	q := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "stats-test")
+	defer q.ShutDown()
+	q.Add("a")
+	q.Add("b")
+	q.Add("a") // drop duplicate
+
+	s := findStats("stats-test")
+	assert.NotNil(t, s)
+	assert.Equal(t, int64(2), s.Depth)
+	assert.Equal(t, int64(2), s.Adds)
+
+	item, _ := q.Get()
+	q.AddRateLimited(item)
+	q.Done(item)
+	s = findStats("stats-test")
+	assert.Equal(t, int64(1), s.Depth)
+	assert.Equal(t, int64(1), s.Retries)
+}
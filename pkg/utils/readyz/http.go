@@ -41,7 +41,7 @@ func NewHTTPReadyZ(uri string, code int, body HTTPResponseBodyChcker) ReadyZ {
 
 func (hr *HTTPReadyZ) IsReady(ctx context.Context) error {
 	cl := http.Client{}
-	req, err := http.NewRequest(http.MethodGet, hr.uri, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hr.uri, nil)
 	if err != nil {
 		return fmt.Errorf("NewRequest error:%s", err.Error())
 	}
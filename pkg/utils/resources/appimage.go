@@ -17,9 +17,27 @@ package resources
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/secretflow/kuscia/pkg/common"
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	utilscommon "github.com/secretflow/kuscia/pkg/utils/common"
+	"github.com/secretflow/kuscia/pkg/utils/jsonschema"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/appconfig"
+)
+
+const (
+	// DryRunTaskID is the task id of the hypothetical task rendered by DryRunAppImage.
+	DryRunTaskID = "dry-run"
+
+	dryRunPortBase           = 20000
+	dryRunConfigTemplateName = "config-template"
 )
 
 // SelectDeployTemplate selects a matching template according to the role.
@@ -57,3 +75,341 @@ func SelectDeployTemplate(templates []kusciaapisv1alpha1.DeployTemplate, role st
 
 	return nil, fmt.Errorf("not found deploy template for role %q", role)
 }
+
+// SelectAppImageInfo returns the image of channel, or the image of appImage if channel is empty.
+func SelectAppImageInfo(appImage *kusciaapisv1alpha1.AppImage, channel string) (*kusciaapisv1alpha1.AppImageInfo, error) {
+	if channel == "" {
+		return &appImage.Spec.Image, nil
+	}
+	for i := range appImage.Spec.Channels {
+		if appImage.Spec.Channels[i].Name == channel {
+			return &appImage.Spec.Channels[i].Image, nil
+		}
+	}
+	return nil, fmt.Errorf("channel %q not found in appImage %q", channel, appImage.Name)
+}
+
+// SelectTaskConfigSchema returns the task config schema of channel, or the schema of appImage if channel is empty or
+// doesn't have its own schema. It's empty if no schema is registered.
+func SelectTaskConfigSchema(appImage *kusciaapisv1alpha1.AppImage, channel string) string {
+	for i := range appImage.Spec.Channels {
+		if channel != "" && appImage.Spec.Channels[i].Name == channel && appImage.Spec.Channels[i].TaskConfigSchema != "" {
+			return appImage.Spec.Channels[i].TaskConfigSchema
+		}
+	}
+	return appImage.Spec.TaskConfigSchema
+}
+
+// ValidateTaskInputConfig validates taskInputConfig against the task config schema selected by channel, and returns
+// the violations of fields. Nothing is validated if no schema is registered, an error is returned if the schema is
+// malformed.
+func ValidateTaskInputConfig(appImage *kusciaapisv1alpha1.AppImage, channel, taskInputConfig string) ([]string, error) {
+	content := SelectTaskConfigSchema(appImage, channel)
+	if content == "" {
+		return nil, nil
+	}
+	schema, err := jsonschema.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("task config schema of appImage %q is malformed, %v", appImage.Name, err)
+	}
+	var violations []string
+	for _, fieldErr := range schema.ValidateJSON(taskInputConfig) {
+		violations = append(violations, fieldErr.Error())
+	}
+	return violations, nil
+}
+
+// AppImageDryRun is the result of rendering the deploy template of an app image for a hypothetical task party.
+type AppImageDryRun struct {
+	DeployTemplate string
+	Image          string
+	PodSpec        *corev1.PodSpec
+	// ConfigFiles are the rendered config templates, keyed by the name of config template.
+	ConfigFiles map[string]string
+	// Errors would fail the task at runtime, Warnings may not.
+	Errors   []string
+	Warnings []string
+}
+
+func (r *AppImageDryRun) errorf(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+func (r *AppImageDryRun) warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// DryRunAppImage renders the pod spec and config templates of appImage for a party of role in domainID, the same way
+// as the task controller and agent do, with ports allocated from a fake range. Nothing is created, problems of the
+// deploy template which would only fail the task at runtime are reported in the result.
+func DryRunAppImage(appImage *kusciaapisv1alpha1.AppImage, role, channel, domainID, taskInputConfig string) *AppImageDryRun {
+	result := &AppImageDryRun{ConfigFiles: map[string]string{}}
+
+	if imageInfo, err := SelectAppImageInfo(appImage, channel); err != nil {
+		result.errorf("%v", err)
+	} else if imageInfo.Name == "" {
+		result.errorf("image name can not be empty")
+	} else {
+		result.Image = fmt.Sprintf("%s:%s", imageInfo.Name, imageInfo.Tag)
+	}
+
+	if taskInputConfig != "" {
+		violations, err := ValidateTaskInputConfig(appImage, channel, taskInputConfig)
+		if err != nil {
+			result.warnf("%v", err)
+		}
+		for _, violation := range violations {
+			result.errorf("invalid taskInputConfig, %s", violation)
+		}
+	}
+
+	template, err := SelectDeployTemplate(appImage.Spec.DeployTemplates, role)
+	if err != nil {
+		result.errorf("%v", err)
+		return result
+	}
+	result.DeployTemplate = template.Name
+	if template.Replicas != nil && *template.Replicas < 0 {
+		result.errorf("replicas of deploy template %q can not be less than 0", template.Name)
+	}
+	if len(template.Spec.Containers) == 0 {
+		result.errorf("containers of deploy template %q can not be empty", template.Name)
+	}
+
+	ports, allocatedPorts := dryRunAllocatePorts(result, template.Spec.Containers)
+	dryRunConfigTemplates(result, appImage.Spec.ConfigTemplates, template.Spec.Containers, allocatedPorts, role, domainID, taskInputConfig)
+
+	restartPolicy := corev1.RestartPolicyNever
+	switch template.Spec.RestartPolicy {
+	case "":
+	case corev1.RestartPolicyAlways, corev1.RestartPolicyOnFailure, corev1.RestartPolicyNever:
+		restartPolicy = template.Spec.RestartPolicy
+	default:
+		result.errorf("restart policy %q is not supported", template.Spec.RestartPolicy)
+	}
+	podSpec := &corev1.PodSpec{RestartPolicy: restartPolicy}
+
+	containerNames, portNames := map[string]bool{}, map[string]bool{}
+	needConfigTemplateVolume := false
+	for _, ctr := range template.Spec.Containers {
+		if ctr.Name == "" {
+			result.errorf("container name can not be empty")
+		} else if containerNames[ctr.Name] {
+			result.errorf("container %q is duplicated", ctr.Name)
+		}
+		containerNames[ctr.Name] = true
+
+		resCtr := corev1.Container{
+			Name:            ctr.Name,
+			Image:           result.Image,
+			Command:         ctr.Command,
+			Args:            ctr.Args,
+			WorkingDir:      ctr.WorkingDir,
+			Env:             ctr.Env,
+			EnvFrom:         ctr.EnvFrom,
+			Resources:       ctr.Resources,
+			LivenessProbe:   ctr.LivenessProbe,
+			ReadinessProbe:  ctr.ReadinessProbe,
+			StartupProbe:    ctr.StartupProbe,
+			ImagePullPolicy: ctr.ImagePullPolicy,
+			SecurityContext: ctr.SecurityContext,
+		}
+		switch ctr.ImagePullPolicy {
+		case "":
+			resCtr.ImagePullPolicy = corev1.PullIfNotPresent
+		case corev1.PullAlways, corev1.PullNever, corev1.PullIfNotPresent:
+		default:
+			result.errorf("image pull policy %q of container %q is not supported", ctr.ImagePullPolicy, ctr.Name)
+		}
+
+		for _, port := range ctr.Ports {
+			if p, ok := ports[port.Name]; ok && !portNames[port.Name] {
+				portNames[port.Name] = true
+				resCtr.Ports = append(resCtr.Ports, corev1.ContainerPort{Name: port.Name, ContainerPort: p.Port, Protocol: corev1.ProtocolTCP})
+			}
+		}
+		for _, p := range allocatedPorts.Ports {
+			resCtr.Env = append(resCtr.Env, corev1.EnvVar{
+				Name:  strings.ToUpper(strings.ReplaceAll(fmt.Sprintf(common.EnvPortNumber, p.Name), "-", "_")),
+				Value: strconv.Itoa(int(p.Port)),
+			})
+		}
+
+		if ctr.MetricProbe != nil && ctr.MetricProbe.Path != "" && ctr.MetricProbe.Port != "" {
+			if _, ok := ports[ctr.MetricProbe.Port]; !ok {
+				result.errorf("metric port %q of container %q is not declared in ports", ctr.MetricProbe.Port, ctr.Name)
+			}
+		}
+		dryRunProbePort(result, ctr.Name, "liveness", ctr.LivenessProbe, ports)
+		dryRunProbePort(result, ctr.Name, "readiness", ctr.ReadinessProbe, ports)
+		dryRunProbePort(result, ctr.Name, "startup", ctr.StartupProbe, ports)
+		dryRunResources(result, ctr.Name, ctr.Resources)
+
+		for _, vm := range ctr.ConfigVolumeMounts {
+			if vm.MountPath == "" {
+				result.errorf("mount path of config volume of container %q can not be empty", ctr.Name)
+			}
+			if _, ok := appImage.Spec.ConfigTemplates[vm.SubPath]; !ok {
+				result.errorf("sub path %q of config volume of container %q is not found in config templates", vm.SubPath, ctr.Name)
+			}
+			needConfigTemplateVolume = true
+			resCtr.VolumeMounts = append(resCtr.VolumeMounts, corev1.VolumeMount{
+				Name:      dryRunConfigTemplateName,
+				MountPath: vm.MountPath,
+				SubPath:   vm.SubPath,
+			})
+		}
+
+		podSpec.Containers = append(podSpec.Containers, resCtr)
+	}
+
+	if needConfigTemplateVolume {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: dryRunConfigTemplateName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-configtemplate", DryRunTaskID)},
+				},
+			},
+		})
+	}
+	result.PodSpec = podSpec
+	return result
+}
+
+func dryRunAllocatePorts(result *AppImageDryRun, containers []kusciaapisv1alpha1.Container) (map[string]*appconfig.Port, *appconfig.AllocatedPorts) {
+	ports := map[string]*appconfig.Port{}
+	allocatedPorts := &appconfig.AllocatedPorts{}
+	for _, ctr := range containers {
+		for _, port := range ctr.Ports {
+			if port.Name == "" {
+				result.errorf("port name of container %q can not be empty", ctr.Name)
+				continue
+			}
+			if _, ok := ports[port.Name]; ok {
+				result.errorf("port %q of container %q is duplicated", port.Name, ctr.Name)
+				continue
+			}
+
+			protocol := port.Protocol
+			switch protocol {
+			case "":
+				protocol = kusciaapisv1alpha1.ProtocolHTTP
+			case kusciaapisv1alpha1.ProtocolHTTP, kusciaapisv1alpha1.ProtocolGRPC:
+			default:
+				result.errorf("protocol %q of port %q is not supported", port.Protocol, port.Name)
+			}
+			scope := port.Scope
+			switch scope {
+			case "":
+				scope = kusciaapisv1alpha1.ScopeLocal
+			case kusciaapisv1alpha1.ScopeCluster, kusciaapisv1alpha1.ScopeDomain, kusciaapisv1alpha1.ScopeLocal:
+			default:
+				result.errorf("scope %q of port %q is not supported", port.Scope, port.Name)
+			}
+
+			p := &appconfig.Port{
+				Name:     port.Name,
+				Port:     int32(dryRunPortBase + len(allocatedPorts.Ports)),
+				Scope:    string(scope),
+				Protocol: string(protocol),
+			}
+			ports[port.Name] = p
+			allocatedPorts.Ports = append(allocatedPorts.Ports, p)
+		}
+	}
+	return ports, allocatedPorts
+}
+
+func dryRunConfigTemplates(result *AppImageDryRun, configTemplates map[string]string, containers []kusciaapisv1alpha1.Container,
+	allocatedPorts *appconfig.AllocatedPorts, role, domainID, taskInputConfig string) {
+	var services []*appconfig.Service
+	for _, p := range allocatedPorts.Ports {
+		if p.Scope != string(kusciaapisv1alpha1.ScopeLocal) {
+			services = append(services, &appconfig.Service{
+				PortName:  p.Name,
+				Endpoints: []string{fmt.Sprintf("%s-0-%s.%s.svc", DryRunTaskID, p.Name, domainID)},
+			})
+		}
+	}
+	clusterDefine := &appconfig.ClusterDefine{
+		Parties: []*appconfig.Party{{Name: domainID, Role: role, Services: services}},
+	}
+	protoJSONOptions := protojson.MarshalOptions{EmitUnpopulated: true}
+	clusterDefineJSON, _ := protoJSONOptions.Marshal(clusterDefine)
+	allocatedPortsJSON, _ := protoJSONOptions.Marshal(allocatedPorts)
+
+	// the agent renders config templates with env of containers and configs generated by task controller
+	data := map[string]string{}
+	for _, ctr := range containers {
+		for _, env := range ctr.Env {
+			if env.ValueFrom == nil {
+				data[strings.ToUpper(env.Name)] = env.Value
+			}
+		}
+	}
+	data[common.EnvDomainID] = domainID
+	data[common.EnvTaskID] = DryRunTaskID
+	data[common.EnvTaskInputConfig] = taskInputConfig
+	data[common.EnvTaskClusterDefine] = string(clusterDefineJSON)
+	data[common.EnvAllocatedPorts] = string(allocatedPortsJSON)
+
+	names := make([]string, 0, len(configTemplates))
+	for name := range configTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, key := range utilscommon.ConfigTemplateKeys(configTemplates[name]) {
+			if _, ok := data[key]; !ok {
+				result.warnf("key %q of config template %q is unknown in dry-run, it must be provided by confmanager at runtime", key, name)
+			}
+		}
+		content, err := utilscommon.RenderConfigTemplate(configTemplates[name], data, utilscommon.ConfigTemplateOptions{
+			LookupSecret: func(key string) (string, error) {
+				result.warnf("secret %q of config template %q is looked up from confmanager at runtime", key, name)
+				return "", nil
+			},
+		})
+		if err != nil {
+			result.errorf("render config template %q failed, %v", name, err)
+			continue
+		}
+		result.ConfigFiles[name] = content
+	}
+}
+
+func dryRunProbePort(result *AppImageDryRun, container, probeType string, probe *corev1.Probe, ports map[string]*appconfig.Port) {
+	if probe == nil {
+		return
+	}
+	var port *intstr.IntOrString
+	switch {
+	case probe.HTTPGet != nil:
+		port = &probe.HTTPGet.Port
+	case probe.TCPSocket != nil:
+		port = &probe.TCPSocket.Port
+	}
+	if port != nil && port.Type == intstr.String {
+		if _, ok := ports[port.StrVal]; !ok {
+			result.errorf("%s probe port %q of container %q is not declared in ports", probeType, port.StrVal, container)
+		}
+	}
+}
+
+func dryRunResources(result *AppImageDryRun, container string, resources corev1.ResourceRequirements) {
+	if len(resources.Limits) == 0 {
+		result.warnf("container %q has no resource limits", container)
+	}
+	names := make([]string, 0, len(resources.Requests))
+	for name := range resources.Requests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		request := resources.Requests[corev1.ResourceName(name)]
+		if limit, ok := resources.Limits[corev1.ResourceName(name)]; ok && request.Cmp(limit) > 0 {
+			result.errorf("%s request %s of container %q is greater than limit %s", name, request.String(), container, limit.String())
+		}
+	}
+}
@@ -19,6 +19,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
 )
@@ -135,3 +138,117 @@ func Test_selectDeployTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectAppImageInfo(t *testing.T) {
+	appImage := &kusciaapisv1alpha1.AppImage{
+		Spec: kusciaapisv1alpha1.AppImageSpec{
+			Image: kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "1.6.0"},
+			Channels: []kusciaapisv1alpha1.AppImageChannel{
+				{Name: kusciaapisv1alpha1.AppImageChannelStable, Image: kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "1.6.1"}},
+				{Name: kusciaapisv1alpha1.AppImageChannelBeta, Image: kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "1.7.0b0"}},
+			},
+		},
+	}
+
+	info, err := SelectAppImageInfo(appImage, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.6.0", info.Tag)
+	info, err = SelectAppImageInfo(appImage, kusciaapisv1alpha1.AppImageChannelBeta)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.7.0b0", info.Tag)
+	_, err = SelectAppImageInfo(appImage, "nightly")
+	assert.Error(t, err)
+}
+
+func TestValidateTaskInputConfig(t *testing.T) {
+	appImage := &kusciaapisv1alpha1.AppImage{
+		Spec: kusciaapisv1alpha1.AppImageSpec{
+			Image:            kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "1.6.0"},
+			TaskConfigSchema: `{"type":"object","required":["sf_input_ids"]}`,
+			Channels: []kusciaapisv1alpha1.AppImageChannel{
+				{Name: kusciaapisv1alpha1.AppImageChannelStable, Image: kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "1.6.1"}},
+				{Name: kusciaapisv1alpha1.AppImageChannelBeta, Image: kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "1.7.0b0"},
+					TaskConfigSchema: `{"type":"object","properties":{"sf_input_ids":{"type":"array"}}}`},
+			},
+		},
+	}
+
+	violations, err := ValidateTaskInputConfig(appImage, "", `{"sf_output_ids":["out"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sf_input_ids: is required"}, violations)
+	// the channel without schema falls back to the schema of app image
+	violations, err = ValidateTaskInputConfig(appImage, kusciaapisv1alpha1.AppImageChannelStable, `{"sf_input_ids":[]}`)
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+	violations, err = ValidateTaskInputConfig(appImage, kusciaapisv1alpha1.AppImageChannelBeta, `{"sf_input_ids":"in"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sf_input_ids: must be of type array, got string"}, violations)
+
+	appImage.Spec.TaskConfigSchema = `{"type":"map"}`
+	_, err = ValidateTaskInputConfig(appImage, "", `{}`)
+	assert.Error(t, err)
+	appImage.Spec.TaskConfigSchema = ""
+	violations, err = ValidateTaskInputConfig(appImage, "", `not json`)
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func makeTestDryRunAppImage() *kusciaapisv1alpha1.AppImage {
+	return &kusciaapisv1alpha1.AppImage{
+		Spec: kusciaapisv1alpha1.AppImageSpec{
+			Image: kusciaapisv1alpha1.AppImageInfo{Name: "secretflow", Tag: "1.0.0"},
+			ConfigTemplates: map[string]string{
+				"task-config.conf": `{"task_id": "{{.TASK_ID}}", "port": "{{{.ALLOCATED_PORTS.ports[name=global].port}}}"}`,
+			},
+			DeployTemplates: []kusciaapisv1alpha1.DeployTemplate{{
+				Name: "secretflow",
+				Spec: kusciaapisv1alpha1.PodSpec{
+					Containers: []kusciaapisv1alpha1.Container{{
+						Name:               "secretflow",
+						Ports:              []kusciaapisv1alpha1.ContainerPort{{Name: "global", Scope: kusciaapisv1alpha1.ScopeCluster}},
+						ConfigVolumeMounts: []kusciaapisv1alpha1.ConfigVolumeMount{{MountPath: "/work/task-config.conf", SubPath: "task-config.conf"}},
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("2")},
+						},
+					}},
+				},
+			}},
+		},
+	}
+}
+
+func TestDryRunAppImage(t *testing.T) {
+	result := DryRunAppImage(makeTestDryRunAppImage(), "", "", "alice", "{}")
+	assert.Empty(t, result.Errors)
+	assert.Empty(t, result.Warnings)
+	assert.Equal(t, "secretflow:1.0.0", result.Image)
+	assert.Equal(t, `{"task_id": "dry-run", "port": "20000"}`, result.ConfigFiles["task-config.conf"])
+	assert.Len(t, result.PodSpec.Containers, 1)
+	assert.Equal(t, int32(20000), result.PodSpec.Containers[0].Ports[0].ContainerPort)
+	assert.Len(t, result.PodSpec.Volumes, 1)
+}
+
+func TestDryRunAppImage_Errors(t *testing.T) {
+	appImage := makeTestDryRunAppImage()
+	appImage.Spec.ConfigTemplates["task-config.conf"] = "{{.TASK_ID"
+	ctr := &appImage.Spec.DeployTemplates[0].Spec.Containers[0]
+	ctr.Ports = append(ctr.Ports, kusciaapisv1alpha1.ContainerPort{Name: "global"})
+	ctr.ConfigVolumeMounts = append(ctr.ConfigVolumeMounts, kusciaapisv1alpha1.ConfigVolumeMount{MountPath: "/work/a", SubPath: "missing"})
+	ctr.ReadinessProbe = &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("health")}}}
+	ctr.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("4")}
+
+	result := DryRunAppImage(appImage, "", "beta", "alice", "{}")
+	assert.Equal(t, []string{
+		`channel "beta" not found in appImage ""`,
+		`port "global" of container "secretflow" is duplicated`,
+		`render config template "task-config.conf" failed, failed to parse config template, detail-> template: config-template:1: unclosed action`,
+		`readiness probe port "health" of container "secretflow" is not declared in ports`,
+		`cpu request 4 of container "secretflow" is greater than limit 2`,
+		`sub path "missing" of config volume of container "secretflow" is not found in config templates`,
+	}, result.Errors)
+
+	appImage.Spec.DeployTemplates[0].Role = "client"
+	result = DryRunAppImage(appImage, "server", "", "alice", "{}")
+	assert.NotEmpty(t, result.Errors)
+	assert.Nil(t, result.PodSpec)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+)
+
+// DomainCertHash returns the sha256 of cert, base64 encoded.
+func DomainCertHash(cert string) string {
+	sum := sha256.Sum256([]byte(cert))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// DomainKeyRotation returns the key rotation of domain, nil if there is no rotation or the rotation is recorded for a
+// cert which has been replaced.
+func DomainKeyRotation(domain *kusciaapisv1alpha1.Domain) *kusciaapisv1alpha1.DomainKeyRotationStatus {
+	if domain.Status == nil || domain.Status.KeyRotation == nil || domain.Status.KeyRotation.PublicKey == "" {
+		return nil
+	}
+	if domain.Status.KeyRotation.CertHash != DomainCertHash(domain.Spec.Cert) {
+		return nil
+	}
+	return domain.Status.KeyRotation
+}
+
+// DomainPublicKey returns the RSA public key of domain for routes, which is the rotated key if there is a key rotation,
+// otherwise the key in cert. The key is in PKCS1 pem format and base64 encoded.
+func DomainPublicKey(domain *kusciaapisv1alpha1.Domain) (string, error) {
+	if rotation := DomainKeyRotation(domain); rotation != nil {
+		return rotation.PublicKey, nil
+	}
+	certPem, err := base64.StdEncoding.DecodeString(domain.Spec.Cert)
+	if err != nil {
+		return "", err
+	}
+	certData, _ := pem.Decode(certPem)
+	if certData == nil {
+		return "", fmt.Errorf("pem decode cert of domain %s fail", domain.Name)
+	}
+	cert, err := x509.ParseCertificate(certData.Bytes)
+	if err != nil {
+		return "", err
+	}
+	rsaPub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("public key in cert of domain %s is not rsa key", domain.Name)
+	}
+	block := &pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(rsaPub),
+	}
+	return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(block)), nil
+}
+
+// KeyRotationSignData returns the data signed by the previous key of domain in key rotation.
+func KeyRotationSignData(domainID, publicKey string, startTime int64) string {
+	return fmt.Sprintf("%s/%s/%d", domainID, publicKey, startTime)
+}
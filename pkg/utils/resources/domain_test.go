@@ -0,0 +1,72 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kusciaapisv1alpha1 "github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/utils/tls"
+)
+
+func TestDomainKeyRotation(t *testing.T) {
+	domain := &kusciaapisv1alpha1.Domain{
+		Spec: kusciaapisv1alpha1.DomainSpec{Cert: "cert"},
+	}
+	assert.Nil(t, DomainKeyRotation(domain))
+
+	domain.Status = &kusciaapisv1alpha1.DomainStatus{
+		KeyRotation: &kusciaapisv1alpha1.DomainKeyRotationStatus{
+			PublicKey: "key",
+			CertHash:  DomainCertHash("cert"),
+		},
+	}
+	assert.Equal(t, "key", DomainKeyRotation(domain).PublicKey)
+
+	// the rotation is discarded once the cert is replaced
+	domain.Spec.Cert = "new-cert"
+	assert.Nil(t, DomainKeyRotation(domain))
+}
+
+func TestDomainPublicKey(t *testing.T) {
+	key, certBytes, err := tls.CreateCA("alice")
+	assert.NoError(t, err)
+	cert := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}))
+	domain := &kusciaapisv1alpha1.Domain{
+		Spec: kusciaapisv1alpha1.DomainSpec{Cert: cert},
+	}
+	pub, err := DomainPublicKey(domain)
+	assert.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(tls.EncodePKCS1PublicKey(key)), pub)
+
+	domain.Status = &kusciaapisv1alpha1.DomainStatus{
+		KeyRotation: &kusciaapisv1alpha1.DomainKeyRotationStatus{
+			PublicKey: "rotated-key",
+			CertHash:  DomainCertHash(cert),
+		},
+	}
+	pub, err = DomainPublicKey(domain)
+	assert.NoError(t, err)
+	assert.Equal(t, "rotated-key", pub)
+
+	domain.Status = nil
+	domain.Spec.Cert = "invalid"
+	_, err = DomainPublicKey(domain)
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -138,3 +139,50 @@ func ExtractDomainDataGrantStatus(p *kusciaapisv1alpha1.DomainDataGrant) *kuscia
 	pp.Status = p.Status
 	return pp
 }
+
+// TaskInputDomainDataIDs returns the domaindata read and written by a task, from sf_input_ids and sf_output_ids of
+// the task input config.
+func TaskInputDomainDataIDs(taskInputConfig string) (inputIDs, outputIDs []string, err error) {
+	if taskInputConfig == "" {
+		return nil, nil, nil
+	}
+	inputConfig := struct {
+		InputIDs  []string `json:"sf_input_ids"`
+		OutputIDs []string `json:"sf_output_ids"`
+	}{}
+	if err := json.Unmarshal([]byte(taskInputConfig), &inputConfig); err != nil {
+		return nil, nil, err
+	}
+	return inputConfig.InputIDs, inputConfig.OutputIDs, nil
+}
+
+// CheckDomainDataGranted checks there's a usable grant of domaindata for grantDomain in grants of the owner, a grant
+// is unusable if it's unavailable, expired, used up or limited to another initiator.
+func CheckDomainDataGranted(grants []kusciaapisv1alpha1.DomainDataGrant, dataID, grantDomain string, now time.Time) error {
+	reason := "no grant"
+	for _, grant := range grants {
+		if grant.Spec.DomainDataID != dataID || grant.Spec.GrantDomain != grantDomain {
+			continue
+		}
+		if grant.Status.Phase == kusciaapisv1alpha1.GrantUnavailable {
+			reason = fmt.Sprintf("grant %s is unavailable", grant.Name)
+			continue
+		}
+		if limit := grant.Spec.Limit; limit != nil {
+			if limit.ExpirationTime != nil && limit.ExpirationTime.Time.Before(now) {
+				reason = fmt.Sprintf("grant %s is expired", grant.Name)
+				continue
+			}
+			if limit.UseCount > 0 && len(grant.Status.UseRecords) >= limit.UseCount {
+				reason = fmt.Sprintf("grant %s is used up", grant.Name)
+				continue
+			}
+			if limit.Initiator != "" && limit.Initiator != grantDomain {
+				reason = fmt.Sprintf("grant %s is limited to initiator %s", grant.Name, limit.Initiator)
+				continue
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("domaindata %s is not granted to %s, %s", dataID, grantDomain, reason)
+}
@@ -17,6 +17,9 @@ package resources
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -121,3 +124,38 @@ func TestPatchDomainDataGrantStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckDomainDataGranted(t *testing.T) {
+	now := time.Now()
+	grant := func(name string, limit *kusciaapisv1alpha1.GrantLimit, uses int) kusciaapisv1alpha1.DomainDataGrant {
+		g := kusciaapisv1alpha1.DomainDataGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kusciaapisv1alpha1.DomainDataGrantSpec{DomainDataID: "data", GrantDomain: "alice", Limit: limit},
+		}
+		g.Status.UseRecords = make([]kusciaapisv1alpha1.UseRecord, uses)
+		return g
+	}
+	expired := metav1.NewTime(now.Add(-time.Hour))
+
+	assert.ErrorContains(t, CheckDomainDataGranted(nil, "data", "alice", now), "no grant")
+	assert.ErrorContains(t, CheckDomainDataGranted([]kusciaapisv1alpha1.DomainDataGrant{
+		grant("g1", &kusciaapisv1alpha1.GrantLimit{ExpirationTime: &expired}, 0),
+	}, "data", "alice", now), "grant g1 is expired")
+	assert.ErrorContains(t, CheckDomainDataGranted([]kusciaapisv1alpha1.DomainDataGrant{
+		grant("g1", &kusciaapisv1alpha1.GrantLimit{UseCount: 1}, 1),
+	}, "data", "alice", now), "grant g1 is used up")
+	assert.NoError(t, CheckDomainDataGranted([]kusciaapisv1alpha1.DomainDataGrant{
+		grant("g1", &kusciaapisv1alpha1.GrantLimit{UseCount: 1}, 1),
+		grant("g2", &kusciaapisv1alpha1.GrantLimit{UseCount: 2}, 1),
+	}, "data", "alice", now))
+	assert.ErrorContains(t, CheckDomainDataGranted([]kusciaapisv1alpha1.DomainDataGrant{grant("g1", nil, 0)}, "data", "bob", now), "no grant")
+}
+
+func TestTaskInputDomainDataIDs(t *testing.T) {
+	inputIDs, outputIDs, err := TaskInputDomainDataIDs(`{"sf_input_ids":["a","b"],"sf_output_ids":["c"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, inputIDs)
+	assert.Equal(t, []string{"c"}, outputIDs)
+	_, _, err = TaskInputDomainDataIDs("meta://secretflow-1/task-input-config")
+	assert.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant scopes the jobs and data inside a domain to tenants. The tenant of request is set to the context by
+// interceptors, and resources of the tenant are marked by the label kuscia.secretflow/tenant.
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+// FromContext returns the tenant of request, empty means the request isn't scoped to any tenant.
+func FromContext(ctx context.Context) string {
+	if tenant, ok := ctx.Value(constants.TenantKey).(string); ok {
+		return tenant
+	}
+	return ""
+}
+
+// Check returns error if the request is scoped to a tenant and the resource doesn't belong to the tenant.
+func Check(ctx context.Context, obj metav1.Object) error {
+	tenant := FromContext(ctx)
+	if tenant == "" || obj.GetLabels()[common.LabelTenant] == tenant {
+		return nil
+	}
+	return fmt.Errorf("%s doesn't belong to tenant %s", obj.GetName(), tenant)
+}
+
+// SetLabel marks the resource created by the request with its tenant.
+func SetLabel(ctx context.Context, obj metav1.Object) {
+	tenant := FromContext(ctx)
+	if tenant == "" {
+		return
+	}
+	objLabels := obj.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	objLabels[common.LabelTenant] = tenant
+	obj.SetLabels(objLabels)
+}
+
+// LabelSelector adds the tenant of request to the label selector of list requests.
+func LabelSelector(ctx context.Context, selector string) (string, error) {
+	tenant := FromContext(ctx)
+	if tenant == "" {
+		return selector, nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return "", err
+	}
+	requirement, err := labels.NewRequirement(common.LabelTenant, selection.Equals, []string{tenant})
+	if err != nil {
+		return "", err
+	}
+	return parsed.Add(*requirement).String(), nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/crd/apis/kuscia/v1alpha1"
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+func TestTenant(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.TenantKey, "team-a")
+	assert.Equal(t, "team-a", FromContext(ctx))
+	assert.Equal(t, "", FromContext(context.Background()))
+
+	job := &v1alpha1.KusciaJob{ObjectMeta: metav1.ObjectMeta{Name: "job-1"}}
+	assert.NoError(t, Check(context.Background(), job))
+	assert.EqualError(t, Check(ctx, job), "job-1 doesn't belong to tenant team-a")
+
+	SetLabel(context.Background(), job)
+	assert.Nil(t, job.Labels)
+	SetLabel(ctx, job)
+	assert.Equal(t, "team-a", job.Labels[common.LabelTenant])
+	assert.NoError(t, Check(ctx, job))
+}
+
+func TestLabelSelector(t *testing.T) {
+	selector, err := LabelSelector(context.Background(), "a=b")
+	assert.NoError(t, err)
+	assert.Equal(t, "a=b", selector)
+
+	ctx := context.WithValue(context.Background(), constants.TenantKey, "team-a")
+	selector, err = LabelSelector(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, common.LabelTenant+"=team-a", selector)
+
+	selector, err = LabelSelector(ctx, "a=b")
+	assert.NoError(t, err)
+	assert.Equal(t, "a=b,"+common.LabelTenant+"=team-a", selector)
+}
@@ -501,3 +501,13 @@ func SignWithRSA(key *rsa.PrivateKey, data string) (string, error) {
 	}
 	return base64.StdEncoding.EncodeToString(sigBytes), nil
 }
+
+func VerifyWithRSA(pub *rsa.PublicKey, data, signature string) error {
+	sigBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+	h := sha256.New()
+	h.Write([]byte(data))
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, h.Sum(nil), sigBytes)
+}
@@ -118,3 +118,13 @@ func TestParsePKCS8PrivateKey(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, token, string(plaintext))
 }
+
+func TestSignAndVerifyWithRSA(t *testing.T) {
+	priKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	signature, err := SignWithRSA(priKey, "kuscia123")
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyWithRSA(&priKey.PublicKey, "kuscia123", signature))
+	assert.Error(t, VerifyWithRSA(&priKey.PublicKey, "kuscia1234", signature))
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing exports spans of kuscia modules via OTLP and propagates the W3C trace context of a job through
+// the annotations of its tasks and pods, so that the time of a job spent in scheduling, WAN and engines can be told
+// apart in one trace.
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/secretflow/kuscia/pkg/common"
+	"github.com/secretflow/kuscia/pkg/utils/meta"
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+)
+
+const (
+	instrumentationName = "github.com/secretflow/kuscia"
+
+	// ShutdownTimeout bounds the time of flushing spans when the process exits.
+	ShutdownTimeout = 5 * time.Second
+
+	// EnvTraceParent is the env of task containers holding the trace context of the task, engines may use it as the
+	// parent of their spans.
+	EnvTraceParent = "TRACEPARENT"
+
+	// AttrDomain and the attributes below are set on the spans of kuscia objects.
+	AttrDomain = attribute.Key("kuscia.domain")
+	AttrJobID  = attribute.Key("kuscia.job_id")
+	AttrTaskID = attribute.Key("kuscia.task_id")
+	AttrPod    = attribute.Key("kuscia.pod")
+	AttrPhase  = attribute.Key("kuscia.phase")
+)
+
+// Config is the OTLP exporter of spans, tracing is disabled if the endpoint is empty.
+type Config struct {
+	// Endpoint is the address of the OTLP gRPC collector, e.g. otel-collector:4317.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Insecure disables TLS to the collector.
+	Insecure bool `yaml:"insecure,omitempty"`
+	// Headers are sent with every export request, e.g. the token of the collector.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// SampleRatio is the fraction of new traces that are sampled, default 1. Spans of a propagated trace follow
+	// the sampling decision of the parent.
+	SampleRatio float64 `yaml:"sampleRatio,omitempty"`
+}
+
+var (
+	propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	enabled    atomic.Bool
+	// sampler decides whether the traces derived from job ids are sampled.
+	sampler atomic.Value
+)
+
+func init() {
+	otel.SetTextMapPropagator(propagator)
+}
+
+// Init installs the global tracer provider exporting spans of service to the collector of conf. It returns a
+// function flushing the pending spans, which should be called before the process exits.
+func Init(ctx context.Context, service, domainID string, conf Config) (func(context.Context) error, error) {
+	if conf.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if conf.SampleRatio < 0 || conf.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sampleRatio %v should be in [0, 1]", conf.SampleRatio)
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(conf.Endpoint), otlptracegrpc.WithHeaders(conf.Headers)}
+	if conf.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})))
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create otlp trace exporter failed, %v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(service),
+		semconv.ServiceVersion(meta.KusciaVersionString()),
+		AttrDomain.String(domainID)))
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := conf.SampleRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+	ratioSampler := sdktrace.TraceIDRatioBased(ratio)
+	sampler.Store(ratioSampler)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(ratioSampler)))
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		nlog.Warnf("Tracing error: %v", err)
+	}))
+	enabled.Store(true)
+	nlog.Infof("Tracing is enabled, spans are exported to %s with sample ratio %v", conf.Endpoint, ratio)
+
+	return func(ctx context.Context) error {
+		enabled.Store(false)
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// Enabled reports whether spans are exported.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Tracer returns the tracer of kuscia, its spans are non-recording if tracing isn't enabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts an internal span of Tracer.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span if any and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// annotationCarrier stores the trace context in annotations of kuscia objects.
+type annotationCarrier map[string]string
+
+var annotationKeys = map[string]string{
+	"traceparent": common.TraceParentAnnotationKey,
+	"tracestate":  common.TraceStateAnnotationKey,
+}
+
+func (c annotationCarrier) Get(key string) string {
+	if k, ok := annotationKeys[key]; ok {
+		return c[k]
+	}
+	return ""
+}
+
+func (c annotationCarrier) Set(key, value string) {
+	if k, ok := annotationKeys[key]; ok && value != "" {
+		c[k] = value
+	}
+}
+
+func (c annotationCarrier) Keys() []string {
+	return []string{"traceparent", "tracestate"}
+}
+
+// HasContext reports whether annotations carry a trace context.
+func HasContext(annotations map[string]string) bool {
+	return annotations[common.TraceParentAnnotationKey] != ""
+}
+
+// InjectAnnotations writes the trace context of ctx into annotations, which must not be nil.
+func InjectAnnotations(ctx context.Context, annotations map[string]string) {
+	propagation.TraceContext{}.Inject(ctx, annotationCarrier(annotations))
+}
+
+// CopyAnnotations copies the trace context of src into dst, which must not be nil.
+func CopyAnnotations(dst, src map[string]string) {
+	for _, k := range annotationKeys {
+		if v := src[k]; v != "" {
+			dst[k] = v
+		}
+	}
+}
+
+// ContextFromAnnotations returns ctx carrying the trace context of annotations. A job without trace context, e.g.
+// one created by kubectl, gets a trace derived from its id, so that all parties and objects of the job still share
+// one trace. ctx is returned as is if neither is present.
+func ContextFromAnnotations(ctx context.Context, annotations map[string]string, jobID string) context.Context {
+	if HasContext(annotations) {
+		return propagation.TraceContext{}.Extract(ctx, annotationCarrier(annotations))
+	}
+	if jobID == "" {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, jobSpanContext(jobID))
+}
+
+func jobSpanContext(jobID string) trace.SpanContext {
+	sum := sha256.Sum256([]byte(jobID))
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	copy(traceID[:], sum[:16])
+	copy(spanID[:], sum[16:24])
+	var flags trace.TraceFlags
+	if s, ok := sampler.Load().(sdktrace.Sampler); !ok ||
+		s.ShouldSample(sdktrace.SamplingParameters{TraceID: traceID}).Decision == sdktrace.RecordAndSample {
+		flags = trace.FlagsSampled
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		Remote:     true,
+	})
+}
+
+// StartWithAnnotations starts a span in the trace of annotations. It returns a no-op span if annotations don't carry a
+// trace context, so that objects not belonging to jobs don't produce spans.
+func StartWithAnnotations(ctx context.Context, name string, annotations map[string]string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !HasContext(annotations) {
+		return ctx, noop.Span{}
+	}
+	return Start(propagation.TraceContext{}.Extract(ctx, annotationCarrier(annotations)), name, attrs...)
+}
+
+// TraceParent returns the W3C traceparent of annotations, or the one derived from jobID, or empty.
+func TraceParent(annotations map[string]string, jobID string) string {
+	ctx := ContextFromAnnotations(context.Background(), annotations, jobID)
+	carrier := annotationCarrier{}
+	InjectAnnotations(ctx, carrier)
+	return carrier[common.TraceParentAnnotationKey]
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/secretflow/kuscia/pkg/common"
+)
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestAnnotationsRoundTrip(t *testing.T) {
+	src := map[string]string{common.TraceParentAnnotationKey: traceParent}
+	ctx := ContextFromAnnotations(context.Background(), src, "job-1")
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.True(t, sc.IsRemote())
+
+	dst := map[string]string{}
+	InjectAnnotations(ctx, dst)
+	assert.Equal(t, traceParent, dst[common.TraceParentAnnotationKey])
+
+	copied := map[string]string{"a": "b"}
+	CopyAnnotations(copied, src)
+	assert.Equal(t, map[string]string{"a": "b", common.TraceParentAnnotationKey: traceParent}, copied)
+}
+
+func TestContextDerivedFromJobID(t *testing.T) {
+	ctx1 := ContextFromAnnotations(context.Background(), nil, "job-1")
+	ctx2 := ContextFromAnnotations(context.Background(), map[string]string{}, "job-1")
+	ctx3 := ContextFromAnnotations(context.Background(), nil, "job-2")
+	sc1, sc2, sc3 := trace.SpanContextFromContext(ctx1), trace.SpanContextFromContext(ctx2), trace.SpanContextFromContext(ctx3)
+	assert.True(t, sc1.IsValid())
+	assert.True(t, sc1.IsSampled())
+	assert.Equal(t, sc1.TraceID(), sc2.TraceID())
+	assert.NotEqual(t, sc1.TraceID(), sc3.TraceID())
+	assert.Equal(t, TraceParent(nil, "job-1"), TraceParent(map[string]string{}, "job-1"))
+
+	ctx := ContextFromAnnotations(context.Background(), nil, "")
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.Empty(t, TraceParent(nil, ""))
+}
+
+func TestStartWithAnnotations(t *testing.T) {
+	_, span := StartWithAnnotations(context.Background(), "test", nil)
+	assert.False(t, span.SpanContext().IsValid())
+	span.End()
+
+	annotations := map[string]string{common.TraceParentAnnotationKey: traceParent}
+	ctx, span := StartWithAnnotations(context.Background(), "test", annotations)
+	defer End(span, nil)
+	// spans are non-recording without Init, but the trace is still propagated
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(ctx).TraceID().String())
+}
+
+func TestInit(t *testing.T) {
+	shutdown, err := Init(context.Background(), "kuscia", "alice", Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.False(t, Enabled())
+
+	_, err = Init(context.Background(), "kuscia", "alice", Config{Endpoint: "127.0.0.1:4317", SampleRatio: 2})
+	assert.Error(t, err)
+}
@@ -21,6 +21,7 @@ const (
 	HealthAPI              = "/healthZ"
 	TokenHeader            = "Token"
 	XForwardHostHeader     = "x-forward-host"
+	RequestIDHeader        = "X-Request-ID"
 	ContentTypeHeader      = "Content-Type"
 	HTTPDefaultContentType = "application/json"
 	SourceDomainHeader     = "Kuscia-Source"
@@ -28,4 +29,8 @@ const (
 	AuthRole               = "AuthRole"
 	AuthRoleMaster         = "master"
 	AuthRoleDomain         = "domain"
+	AuthRoleTenant         = "tenant"
+	TenantHeader           = "Kuscia-Tenant"
+	TenantKey              = "tenant"
+	DataMeshTokenHeader    = "Kuscia-Datamesh-Token"
 )
@@ -36,3 +36,8 @@ type Bean interface {
 	Init(e ConfBeanRegistry) error
 	Start(ctx context.Context, e ConfBeanRegistry) error
 }
+
+// Drainer is implemented by beans which can stop accepting new requests and wait for the in-flight ones to finish.
+type Drainer interface {
+	Drain(ctx context.Context) error
+}
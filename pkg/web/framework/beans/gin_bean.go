@@ -18,8 +18,10 @@ import (
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -43,6 +45,9 @@ type GinBean struct {
 	LogPath string `name:"logpath" usage:"Gin Log path"`
 	GinBeanConfig
 	*gin.Engine
+
+	serverMtx sync.Mutex
+	server    *http.Server
 }
 
 func (b *GinBean) Validate(errs *errorcode.Errs) {
@@ -119,6 +124,9 @@ func (b *GinBean) Start(ctx context.Context, e framework.ConfBeanRegistry) error
 		MaxHeaderBytes: *b.MaxHeaderBytes,
 		IdleTimeout:    time.Duration(*b.IdleTimeout) * time.Second,
 	}
+	b.serverMtx.Lock()
+	b.server = s
+	b.serverMtx.Unlock()
 
 	// init server tls config
 	if b.TLSServerConfig != nil {
@@ -129,11 +137,30 @@ func (b *GinBean) Start(ctx context.Context, e framework.ConfBeanRegistry) error
 			return err
 		}
 		nlog.Infof("https server started on %s", addr)
-		return s.ListenAndServeTLS("", "")
+		return ignoreServerClosed(s.ListenAndServeTLS("", ""))
 	}
 
 	logs.GetLogger().Infof("http server started %s", addr)
-	return s.ListenAndServe()
+	return ignoreServerClosed(s.ListenAndServe())
+}
+
+// ignoreServerClosed treats server closed by Drain as a normal exit.
+func ignoreServerClosed(err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Drain stops accepting new connections, and waits for in-flight requests to finish.
+func (b *GinBean) Drain(ctx context.Context) error {
+	b.serverMtx.Lock()
+	s := b.server
+	b.serverMtx.Unlock()
+	if s == nil {
+		return nil
+	}
+	return s.Shutdown(ctx)
 }
 
 type GinBeanConfig struct {
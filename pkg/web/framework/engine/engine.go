@@ -20,6 +20,7 @@ import (
 	"os"
 	"reflect"
 	"runtime/debug"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -240,6 +241,29 @@ func (e *Engine) Run(ctx context.Context) error {
 	}
 }
 
+// Drain drains all beans implementing framework.Drainer at the same time, it returns when they are drained or ctx is
+// done.
+func (e *Engine) Drain(ctx context.Context) error {
+	beanName, beanList := e.beans.listBeans()
+	errs := make([]error, len(beanList))
+	wg := sync.WaitGroup{}
+	for i, b := range beanList {
+		d, ok := b.(framework.Drainer)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, d framework.Drainer) {
+			defer wg.Done()
+			if err := d.Drain(ctx); err != nil {
+				errs[i] = fmt.Errorf("drain bean %s failed, %v", beanName[i], err)
+			}
+		}(i, d)
+	}
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
+}
+
 func (e *Engine) runCmd(ctx context.Context, cmd *cobra.Command, args []string) error {
 	if len(args) != 0 {
 		logs.GetLogger().Info("arguments are not supported")
@@ -14,7 +14,11 @@
 
 package router
 
-import "github.com/gin-gonic/gin"
+import (
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
 
 type GroupsRouters []*GroupRouters
 
@@ -30,4 +34,8 @@ type Router struct {
 	HTTPMethod   string
 	RelativePath string
 	Handlers     []gin.HandlerFunc
+	// RequestType and ResponseType are the proto messages of a route served by a proto handler, they're used to
+	// generate the openapi document. Routes without them are left out of the document.
+	RequestType  reflect.Type
+	ResponseType reflect.Type
 }
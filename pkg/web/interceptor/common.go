@@ -92,6 +92,7 @@ type loggerContext struct {
 	Protocol     string
 	XForwardHost string
 	ContextType  string
+	RequestID    string
 	Duration     time.Duration
 
 	Errs []error
@@ -107,24 +108,26 @@ type loggerContext struct {
 func printfLoggerContext(logger nlog.NLog, loggerContext *loggerContext) {
 
 	if len(loggerContext.Errs) > 0 {
-		logger.Errorf("[%s] [%s] Duration: %s, StatusCode: %d, ForwardHost: [%s], ContextType: [%s], Request: %s, Response: %s, Error: %v",
+		logger.Errorf("[%s] [%s] Duration: %s, StatusCode: %d, ForwardHost: [%s], RequestID: [%s], ContextType: [%s], Request: %s, Response: %s, Error: %v",
 			loggerContext.Protocol,
 			loggerContext.RequestPath,
 			loggerContext.Duration,
 			loggerContext.StatusCode,
 			loggerContext.XForwardHost,
+			loggerContext.RequestID,
 			loggerContext.ContextType,
 			cutSlice(loggerContext.RequestBody, maxSizeBytes),
 			cutSlice(loggerContext.ResponseBody, maxSizeBytes),
 			loggerContext.Errs)
 	} else {
-		logger.Infof("[%s] [%s %s] Duration: %s, StatusCode: %d, ForwardHost: [%s], ContextType: [%s], Request: %s, Response: %s",
+		logger.Infof("[%s] [%s %s] Duration: %s, StatusCode: %d, ForwardHost: [%s], RequestID: [%s], ContextType: [%s], Request: %s, Response: %s",
 			loggerContext.Protocol,
 			loggerContext.RequestMethod,
 			loggerContext.RequestPath,
 			loggerContext.Duration,
 			loggerContext.StatusCode,
 			loggerContext.XForwardHost,
+			loggerContext.RequestID,
 			loggerContext.ContextType,
 			cutSlice(loggerContext.RequestBody, maxSizeBytes),
 			cutSlice(loggerContext.ResponseBody, maxSizeBytes),
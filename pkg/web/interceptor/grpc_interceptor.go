@@ -52,10 +52,11 @@ func GrpcServerLoggingInterceptor(logger nlog.NLog) grpc.UnaryServerInterceptor
 				statusCode = respStatus.Code()
 			}
 
-			forwardHostsStr := ""
+			forwardHostsStr, requestID := "", ""
 			if md, ok := metadata.FromIncomingContext(ctx); ok {
 				forwardHosts := md.Get(constants.XForwardHostHeader)
 				forwardHostsStr = strings.Join(forwardHosts, ",")
+				requestID = strings.Join(md.Get(constants.RequestIDHeader), ",")
 			} else {
 				logger.Warnf("[%s] Get metadata from incoming context failed", protocolGRPC)
 			}
@@ -65,6 +66,7 @@ func GrpcServerLoggingInterceptor(logger nlog.NLog) grpc.UnaryServerInterceptor
 				RequestMethod: protocolGRPC,
 				RequestPath:   info.FullMethod,
 				XForwardHost:  forwardHostsStr,
+				RequestID:     requestID,
 				StatusCode:    int(statusCode),
 
 				Errs:         errors,
@@ -191,6 +193,13 @@ func GrpcClientTokenInterceptor(tokenData string) grpc.UnaryClientInterceptor {
 	}
 }
 
+func GrpcClientStreamTokenInterceptor(tokenData string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(constants.TokenHeader), tokenData)
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
 // UnaryRecoverInterceptor returns a new unary server interceptors that recovers from panics.
 func UnaryRecoverInterceptor(errorCode pberrorcode.ErrorCode) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
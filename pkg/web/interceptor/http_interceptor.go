@@ -94,6 +94,7 @@ func HTTPServerLoggingInterceptor(logger nlog.NLog) func(c *gin.Context) {
 				RequestMethod: r.Method,
 				RequestPath:   r.URL.RequestURI(),
 				XForwardHost:  r.Header.Get(constants.XForwardHostHeader),
+				RequestID:     r.Header.Get(constants.RequestIDHeader),
 				ContextType:   c.Writer.Header().Get(constants.ContentTypeHeader),
 				StatusCode:    c.Writer.Status(),
 				Duration:      duration,
@@ -0,0 +1,207 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/secretflow/kuscia/pkg/utils/nlog"
+	"github.com/secretflow/kuscia/pkg/utils/tracing"
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+// DefaultMaxBodyBytes is the default limit of request body of http servers.
+const DefaultMaxBodyBytes int64 = 32 << 20
+
+type requestIDKey struct{}
+
+// HTTPMiddlewareConfig is the config of the standard middleware chain of http servers.
+type HTTPMiddlewareConfig struct {
+	// MaxBodyBytes limits the size of request body, DefaultMaxBodyBytes is used if it's zero, and a negative value
+	// disables the limit.
+	MaxBodyBytes int64
+}
+
+// UseHTTPStandardInterceptors applies recovery, request id, tracing and body limit to all routes of engine. Timeouts are set per
+// route with HTTPTimeoutInterceptor, because streaming routes can't have one. The context of gin falls back to the
+// request context, so the deadline and request id reach handlers using gin.Context as context.Context.
+func UseHTTPStandardInterceptors(engine *gin.Engine, config HTTPMiddlewareConfig) {
+	engine.ContextWithFallback = true
+	engine.Use(HTTPRecoveryInterceptor(), HTTPRequestIDInterceptor(), HTTPTracingInterceptor(),
+		HTTPBodyLimitInterceptor(maxBodyBytes(config)))
+}
+
+// HTTPStandardHandler wraps a net/http handler with recovery, request id, tracing and body limit.
+func HTTPStandardHandler(handler http.Handler, config HTTPMiddlewareConfig) http.Handler {
+	limit := maxBodyBytes(config)
+	handler = otelhttp.NewHandler(handler, "", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + r.URL.Path
+	}))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, requestID := withRequestID(r)
+		w.Header().Set(constants.RequestIDHeader, requestID)
+		defer func() {
+			if err := recover(); err != nil {
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+				nlog.Errorf("[%s %s] Recovered from panic, request_id: %s, %v, stack: %s", r.Method, r.URL.Path, requestID, err, debug.Stack())
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		if limit > 0 {
+			if r.ContentLength > limit {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// HTTPRecoveryInterceptor recovers from panics of handlers, it logs the stack and responds 500 instead of crashing the
+// server.
+func HTTPRecoveryInterceptor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if err := recover(); err != nil {
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+				nlog.Errorf("[%s %s] Recovered from panic, request_id: %s, %v, stack: %s", c.Request.Method, c.Request.URL.Path,
+					RequestIDFromContext(c.Request.Context()), err, debug.Stack())
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+		c.Next()
+	}
+}
+
+// HTTPRequestIDInterceptor takes X-Request-ID of request or generates one, the id is set to the response header and the
+// request context.
+func HTTPRequestIDInterceptor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var requestID string
+		c.Request, requestID = withRequestID(c.Request)
+		c.Header(constants.RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// HTTPTracingInterceptor starts a server span for each request, which continues the trace of the traceparent header
+// if any. The span is in the request context, so it's the parent of the spans started by handlers.
+func HTTPTracingInterceptor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		ctx, span := tracing.Tracer().Start(ctx, c.Request.Method+" "+route, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPMethod(c.Request.Method), semconv.HTTPRoute(route)))
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// HTTPBodyLimitInterceptor rejects requests whose body is larger than limit with 413.
+func HTTPBodyLimitInterceptor(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit > 0 {
+			if c.Request.ContentLength > limit {
+				c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+				return
+			}
+			if c.Request.Body != nil {
+				c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+			}
+		}
+		c.Next()
+	}
+}
+
+// HTTPTimeoutInterceptor sets a deadline to the request context, handlers are expected to stop when it's exceeded. 504 is
+// responded if the handler doesn't write any response within the timeout.
+func HTTPTimeoutInterceptor(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			nlog.Warnf("[%s %s] Request timeout after %s, request_id: %s", c.Request.Method, c.Request.URL.Path, timeout,
+				RequestIDFromContext(ctx))
+			c.AbortWithStatus(http.StatusGatewayTimeout)
+		}
+	}
+}
+
+// RequestIDFromContext returns the request id set by HTTPRequestIDInterceptor or HTTPStandardHandler.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func withRequestID(r *http.Request) (*http.Request, string) {
+	requestID := r.Header.Get(constants.RequestIDHeader)
+	if requestID == "" {
+		requestID = newRequestID()
+		r.Header.Set(constants.RequestIDHeader, requestID)
+	}
+	ctx := nlog.ContextWithFields(context.WithValue(r.Context(), requestIDKey{}, requestID), nlog.FieldTraceID, requestID)
+	return r.WithContext(ctx), requestID
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+func maxBodyBytes(config HTTPMiddlewareConfig) int64 {
+	if config.MaxBodyBytes == 0 {
+		return DefaultMaxBodyBytes
+	}
+	return config.MaxBodyBytes
+}
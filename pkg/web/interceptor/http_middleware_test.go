@@ -0,0 +1,117 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+func newTestEngine(config HTTPMiddlewareConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	UseHTTPStandardInterceptors(engine, config)
+	engine.POST("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	engine.POST("/echo", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(http.StatusOK, RequestIDFromContext(c)+":"+string(body))
+	})
+	engine.POST("/trace", func(c *gin.Context) {
+		c.String(http.StatusOK, trace.SpanContextFromContext(c).TraceID().String())
+	})
+	engine.POST("/slow", HTTPTimeoutInterceptor(10*time.Millisecond), func(c *gin.Context) {
+		<-c.Done()
+	})
+	return engine
+}
+
+func serve(engine http.Handler, path, body string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestHTTPStandardInterceptors(t *testing.T) {
+	engine := newTestEngine(HTTPMiddlewareConfig{MaxBodyBytes: 8})
+
+	w := serve(engine, "/panic", "", nil)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotEmpty(t, w.Header().Get(constants.RequestIDHeader))
+
+	w = serve(engine, "/echo", "hello", map[string]string{constants.RequestIDHeader: "req-1"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "req-1:hello", w.Body.String())
+	assert.Equal(t, "req-1", w.Header().Get(constants.RequestIDHeader))
+
+	w = serve(engine, "/echo", "hello world", nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = serve(engine, "/slow", "", nil)
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+}
+
+func TestHTTPStandardHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		_, _ = w.Write([]byte(RequestIDFromContext(r.Context()) + ":" + string(body)))
+	})
+	handler := HTTPStandardHandler(mux, HTTPMiddlewareConfig{MaxBodyBytes: 8})
+
+	w := serve(handler, "/panic", "", nil)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = serve(handler, "/echo", "hi", map[string]string{constants.RequestIDHeader: "req-2"})
+	assert.Equal(t, "req-2:hi", w.Body.String())
+
+	w = serve(handler, "/echo", "hello world", nil)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestHTTPTracingInterceptor(t *testing.T) {
+	engine := newTestEngine(HTTPMiddlewareConfig{})
+	w := serve(engine, "/trace", "", map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Body.String())
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+// tenantTokenCheck accepts the token of domain or any tenant, and returns the tenant of token, empty for the domain.
+func tenantTokenCheck(src []string, target string, tenantTokens map[string]string) (string, error) {
+	if err := tokenCheck(src, target); err == nil {
+		return "", nil
+	}
+	for _, s := range src {
+		if tenant, ok := tenantTokens[s]; ok {
+			return tenant, nil
+		}
+	}
+	return "", status.Errorf(codes.Unauthenticated, "s unauthorized")
+}
+
+// HTTPTenantTokenAuthInterceptor authenticates requests by the token of domain or tenants, the tenant is set to the
+// context of requests with tenant token.
+func HTTPTenantTokenAuthInterceptor(tokenData string, tenantTokens map[string]string) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		tenant, err := tenantTokenCheck([]string{c.GetHeader(constants.TokenHeader)}, tokenData, tenantTokens)
+		if err != nil {
+			c.AbortWithError(http.StatusUnauthorized, err)
+			return
+		}
+		if tenant != "" {
+			c.Set(constants.TenantKey, tenant)
+		}
+		c.Next()
+	}
+}
+
+// HTTPTenantHeaderInterceptor sets the tenant in Kuscia-Tenant header to the context, it's only used by the servers
+// which are reachable inside the domain.
+func HTTPTenantHeaderInterceptor() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if tenant := c.GetHeader(constants.TenantHeader); tenant != "" {
+			c.Set(constants.TenantKey, tenant)
+		}
+		c.Next()
+	}
+}
+
+func GrpcServerTenantTokenInterceptor(tokenData string, tenantTokens map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		tokens := metadata.ValueFromIncomingContext(ctx, strings.ToLower(constants.TokenHeader))
+		tenant, err := tenantTokenCheck(tokens, tokenData, tenantTokens)
+		if err != nil {
+			return nil, err
+		}
+		if tenant != "" {
+			ctx = context.WithValue(ctx, constants.TenantKey, tenant)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func GrpcStreamServerTenantTokenInterceptor(tokenData string, tenantTokens map[string]string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tokens := metadata.ValueFromIncomingContext(ss.Context(), strings.ToLower(constants.TokenHeader))
+		tenant, err := tenantTokenCheck(tokens, tokenData, tenantTokens)
+		if err != nil {
+			return err
+		}
+		if tenant != "" {
+			ss = &tenantServerStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), constants.TenantKey, tenant)}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// GrpcServerTenantHeaderInterceptor is the grpc version of HTTPTenantHeaderInterceptor.
+func GrpcServerTenantHeaderInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		if tenants := metadata.ValueFromIncomingContext(ctx, strings.ToLower(constants.TenantHeader)); len(tenants) > 0 && tenants[0] != "" {
+			ctx = context.WithValue(ctx, constants.TenantKey, tenants[0])
+		}
+		return handler(ctx, req)
+	}
+}
+
+type tenantServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/secretflow/kuscia/pkg/web/constants"
+)
+
+func TestHTTPTenantTokenAuthInterceptor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(HTTPTenantTokenAuthInterceptor("domain-token", map[string]string{"tenant-token": "team-a"}))
+	engine.POST("/tenant", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(constants.TenantKey))
+	})
+
+	resp := serve(engine, "/tenant", "", map[string]string{constants.TokenHeader: "domain-token"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "", resp.Body.String())
+
+	resp = serve(engine, "/tenant", "", map[string]string{constants.TokenHeader: "tenant-token"})
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "team-a", resp.Body.String())
+
+	resp = serve(engine, "/tenant", "", map[string]string{constants.TokenHeader: "unknown"})
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestGrpcServerTenantTokenInterceptor(t *testing.T) {
+	interceptor := GrpcServerTenantTokenInterceptor("domain-token", map[string]string{"tenant-token": "team-a"})
+	handler := func(ctx context.Context, req any) (any, error) {
+		return ctx.Value(constants.TenantKey), nil
+	}
+	call := func(token string) (any, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(constants.TokenHeader, token))
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	}
+
+	tenant, err := call("domain-token")
+	assert.NoError(t, err)
+	assert.Nil(t, tenant)
+
+	tenant, err = call("tenant-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", tenant)
+
+	_, err = call("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi generates the openapi v3 document of http servers from their routes. The schemas are built from the
+// descriptors of proto messages compiled in, so the document is always in sync with the proto definitions.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/secretflow/kuscia/pkg/web/framework/router"
+)
+
+const (
+	Version = "3.0.3"
+	// Path is where the document is served.
+	Path = "/api/openapi.json"
+
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+// Info describes the api of the document.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Document is the openapi v3 document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+	Security   []map[string][]any   `json:"security,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type PathItem struct {
+	Get  *Operation `json:"get,omitempty"`
+	Post *Operation `json:"post,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	OperationID string               `json:"operationId,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Options controls how the routes are described.
+type Options struct {
+	Info Info
+	// TokenHeader is the header of the token auth, no security requirement is declared if it's empty.
+	TokenHeader string
+	// UseProtoNames should be the same as the json marshal options of the server.
+	UseProtoNames bool
+}
+
+// Generate returns the document of routes served by proto handlers.
+func Generate(options Options, groups []*router.GroupRouters) *Document {
+	g := &generator{
+		useProtoNames: options.UseProtoNames,
+		schemas:       map[string]*Schema{},
+	}
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       options.Info,
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: g.schemas},
+	}
+	if options.TokenHeader != "" {
+		doc.Components.SecuritySchemes = map[string]*SecurityScheme{
+			"token": {Type: "apiKey", In: "header", Name: options.TokenHeader},
+		}
+		doc.Security = []map[string][]any{{"token": {}}}
+	}
+
+	for _, group := range groups {
+		for _, route := range group.Routes {
+			if route.RequestType == nil || route.ResponseType == nil {
+				continue
+			}
+			p := "/" + strings.Trim(path.Join(group.Group, route.RelativePath), "/")
+			item := doc.Paths[p]
+			if item == nil {
+				item = &PathItem{}
+				doc.Paths[p] = item
+			}
+			op := &Operation{
+				OperationID: operationID(p),
+				Responses: map[string]*Response{
+					"200": {
+						Description: "OK",
+						Content:     jsonContent(g.typeSchema(route.ResponseType)),
+					},
+				},
+			}
+			if tag := strings.Trim(group.Group, "/"); tag != "" {
+				op.Tags = []string{tag}
+			}
+			switch route.HTTPMethod {
+			case http.MethodGet:
+				item.Get = op
+			default:
+				op.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.typeSchema(route.RequestType))}
+				item.Post = op
+			}
+		}
+	}
+	return doc
+}
+
+// Handler serves the document of groups, the document is generated on the first request.
+func Handler(options Options, groups []*router.GroupRouters) gin.HandlerFunc {
+	var once sync.Once
+	var body []byte
+	var err error
+	return func(c *gin.Context) {
+		once.Do(func() {
+			body, err = json.Marshal(Generate(options, groups))
+		})
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Data(http.StatusOK, "application/json", body)
+	}
+}
+
+func jsonContent(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: schema}}
+}
+
+// operationID turns /api/v1/job/status/batchQuery into job.status.batchQuery.
+func operationID(p string) string {
+	p = strings.TrimPrefix(p, "/api/v1/")
+	return strings.ReplaceAll(strings.Trim(p, "/"), "/", ".")
+}
+
+type generator struct {
+	useProtoNames bool
+	schemas       map[string]*Schema
+}
+
+// typeSchema returns the schema of a route type, which may be a proto message or a raw body such as AnyStringProto.
+func (g *generator) typeSchema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	msg, ok := reflect.New(t).Interface().(proto.Message)
+	if !ok || msg.ProtoReflect() == nil {
+		return &Schema{Type: "object"}
+	}
+	return g.messageSchema(msg.ProtoReflect().Descriptor())
+}
+
+func (g *generator) messageSchema(md protoreflect.MessageDescriptor) *Schema {
+	if s, ok := wellKnownSchema(md.FullName()); ok {
+		return s
+	}
+	name := string(md.FullName())
+	if _, ok := g.schemas[name]; !ok {
+		// register before walking fields, so recursive messages end with a ref
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		g.schemas[name] = schema
+		fields := md.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			fieldName := fd.JSONName()
+			if g.useProtoNames {
+				fieldName = string(fd.Name())
+			}
+			schema.Properties[fieldName] = g.fieldSchema(fd)
+		}
+	}
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+func (g *generator) fieldSchema(fd protoreflect.FieldDescriptor) *Schema {
+	if fd.IsMap() {
+		return &Schema{Type: "object", AdditionalProperties: g.singularSchema(fd.MapValue())}
+	}
+	if fd.IsList() {
+		return &Schema{Type: "array", Items: g.singularSchema(fd)}
+	}
+	return g.singularSchema(fd)
+}
+
+// singularSchema follows the json mapping of proto3, 64-bit integers are encoded as strings.
+func (g *generator) singularSchema(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: "string", Format: "uint64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		enum := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			enum = append(enum, string(values.Get(i).Name()))
+		}
+		return &Schema{Type: "string", Enum: enum}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.messageSchema(fd.Message())
+	}
+	return &Schema{}
+}
+
+func wellKnownSchema(name protoreflect.FullName) (*Schema, bool) {
+	switch name {
+	case "google.protobuf.Any", "google.protobuf.Struct":
+		return &Schema{Type: "object"}, true
+	case "google.protobuf.Value":
+		return &Schema{}, true
+	case "google.protobuf.ListValue":
+		return &Schema{Type: "array", Items: &Schema{}}, true
+	case "google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}, true
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return &Schema{Type: "string"}, true
+	case "google.protobuf.Empty":
+		return &Schema{Type: "object"}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/secretflow/kuscia/pkg/web/api"
+	"github.com/secretflow/kuscia/pkg/web/framework/router"
+	"github.com/secretflow/kuscia/proto/api/v1alpha1/kusciaapi"
+)
+
+func testGroups() []*router.GroupRouters {
+	return []*router.GroupRouters{
+		{
+			Group: "api/v1/job",
+			Routes: []*router.Router{
+				{
+					HTTPMethod:   http.MethodPost,
+					RelativePath: "status/batchQuery",
+					RequestType:  reflect.TypeOf(kusciaapi.BatchQueryJobStatusRequest{}),
+					ResponseType: reflect.TypeOf(kusciaapi.BatchQueryJobStatusResponse{}),
+				},
+				{
+					HTTPMethod:   http.MethodPost,
+					RelativePath: "raw",
+					RequestType:  reflect.TypeOf(api.AnyStringProto{}),
+					ResponseType: reflect.TypeOf(api.AnyStringProto{}),
+				},
+				{
+					HTTPMethod:   http.MethodPost,
+					RelativePath: "undescribed",
+				},
+			},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	doc := Generate(Options{Info: Info{Title: "test", Version: "v1"}, TokenHeader: "Token", UseProtoNames: true}, testGroups())
+
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Len(t, doc.Paths, 2)
+	op := doc.Paths["/api/v1/job/status/batchQuery"].Post
+	assert.Equal(t, "job.status.batchQuery", op.OperationID)
+	assert.Equal(t, []string{"api/v1/job"}, op.Tags)
+	assert.Equal(t, "#/components/schemas/kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryJobStatusRequest",
+		op.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "object", doc.Paths["/api/v1/job/raw"].Post.RequestBody.Content["application/json"].Schema.Type)
+
+	req := doc.Components.Schemas["kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryJobStatusRequest"]
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, req.Properties["job_ids"])
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		doc.Components.Schemas["kuscia.proto.api.v1alpha1.RequestHeader"].Properties["custom_headers"])
+	assert.Equal(t, "apiKey", doc.Components.SecuritySchemes["token"].Type)
+
+	// every ref resolves to a schema
+	body, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	for name, schema := range doc.Components.Schemas {
+		for field, prop := range schema.Properties {
+			for prop.Items != nil {
+				prop = prop.Items
+			}
+			if prop.Ref != "" {
+				_, ok := doc.Components.Schemas[prop.Ref[len(schemaRefPrefix):]]
+				assert.True(t, ok, "%s.%s refers to missing %s", name, field, prop.Ref)
+			}
+		}
+	}
+	assert.Contains(t, string(body), `"openapi":"3.0.3"`)
+}
+
+func TestGenerate_JSONNames(t *testing.T) {
+	doc := Generate(Options{}, testGroups())
+	req := doc.Components.Schemas["kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryJobStatusRequest"]
+	assert.Contains(t, req.Properties, "jobIds")
+	assert.Nil(t, doc.Components.SecuritySchemes)
+}
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET(Path, Handler(Options{}, testGroups()))
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	doc := &Document{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), doc))
+	assert.Contains(t, doc.Paths, "/api/v1/job/status/batchQuery")
+}
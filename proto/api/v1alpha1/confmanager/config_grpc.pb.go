@@ -33,11 +33,14 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ConfigService_CreateConfig_FullMethodName     = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/CreateConfig"
-	ConfigService_QueryConfig_FullMethodName      = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/QueryConfig"
-	ConfigService_UpdateConfig_FullMethodName     = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/UpdateConfig"
-	ConfigService_DeleteConfig_FullMethodName     = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/DeleteConfig"
-	ConfigService_BatchQueryConfig_FullMethodName = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/BatchQueryConfig"
+	ConfigService_CreateConfig_FullMethodName       = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/CreateConfig"
+	ConfigService_QueryConfig_FullMethodName        = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/QueryConfig"
+	ConfigService_UpdateConfig_FullMethodName       = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/UpdateConfig"
+	ConfigService_DeleteConfig_FullMethodName       = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/DeleteConfig"
+	ConfigService_BatchQueryConfig_FullMethodName   = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/BatchQueryConfig"
+	ConfigService_WatchConfig_FullMethodName        = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/WatchConfig"
+	ConfigService_ListConfigVersions_FullMethodName = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/ListConfigVersions"
+	ConfigService_RollbackConfig_FullMethodName     = "/kuscia.proto.api.v1alpha1.confmanager.ConfigService/RollbackConfig"
 )
 
 // ConfigServiceClient is the client API for ConfigService service.
@@ -49,6 +52,9 @@ type ConfigServiceClient interface {
 	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error)
 	DeleteConfig(ctx context.Context, in *DeleteConfigRequest, opts ...grpc.CallOption) (*DeleteConfigResponse, error)
 	BatchQueryConfig(ctx context.Context, in *BatchQueryConfigRequest, opts ...grpc.CallOption) (*BatchQueryConfigResponse, error)
+	WatchConfig(ctx context.Context, in *WatchConfigRequest, opts ...grpc.CallOption) (ConfigService_WatchConfigClient, error)
+	ListConfigVersions(ctx context.Context, in *ListConfigVersionsRequest, opts ...grpc.CallOption) (*ListConfigVersionsResponse, error)
+	RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigResponse, error)
 }
 
 type configServiceClient struct {
@@ -104,6 +110,56 @@ func (c *configServiceClient) BatchQueryConfig(ctx context.Context, in *BatchQue
 	return out, nil
 }
 
+func (c *configServiceClient) WatchConfig(ctx context.Context, in *WatchConfigRequest, opts ...grpc.CallOption) (ConfigService_WatchConfigClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConfigService_ServiceDesc.Streams[0], ConfigService_WatchConfig_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &configServiceWatchConfigClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConfigService_WatchConfigClient interface {
+	Recv() (*WatchConfigEventResponse, error)
+	grpc.ClientStream
+}
+
+type configServiceWatchConfigClient struct {
+	grpc.ClientStream
+}
+
+func (x *configServiceWatchConfigClient) Recv() (*WatchConfigEventResponse, error) {
+	m := new(WatchConfigEventResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *configServiceClient) ListConfigVersions(ctx context.Context, in *ListConfigVersionsRequest, opts ...grpc.CallOption) (*ListConfigVersionsResponse, error) {
+	out := new(ListConfigVersionsResponse)
+	err := c.cc.Invoke(ctx, ConfigService_ListConfigVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configServiceClient) RollbackConfig(ctx context.Context, in *RollbackConfigRequest, opts ...grpc.CallOption) (*RollbackConfigResponse, error) {
+	out := new(RollbackConfigResponse)
+	err := c.cc.Invoke(ctx, ConfigService_RollbackConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigServiceServer is the server API for ConfigService service.
 // All implementations must embed UnimplementedConfigServiceServer
 // for forward compatibility
@@ -113,6 +169,9 @@ type ConfigServiceServer interface {
 	UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error)
 	DeleteConfig(context.Context, *DeleteConfigRequest) (*DeleteConfigResponse, error)
 	BatchQueryConfig(context.Context, *BatchQueryConfigRequest) (*BatchQueryConfigResponse, error)
+	WatchConfig(*WatchConfigRequest, ConfigService_WatchConfigServer) error
+	ListConfigVersions(context.Context, *ListConfigVersionsRequest) (*ListConfigVersionsResponse, error)
+	RollbackConfig(context.Context, *RollbackConfigRequest) (*RollbackConfigResponse, error)
 	mustEmbedUnimplementedConfigServiceServer()
 }
 
@@ -135,6 +194,15 @@ func (UnimplementedConfigServiceServer) DeleteConfig(context.Context, *DeleteCon
 func (UnimplementedConfigServiceServer) BatchQueryConfig(context.Context, *BatchQueryConfigRequest) (*BatchQueryConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchQueryConfig not implemented")
 }
+func (UnimplementedConfigServiceServer) WatchConfig(*WatchConfigRequest, ConfigService_WatchConfigServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchConfig not implemented")
+}
+func (UnimplementedConfigServiceServer) ListConfigVersions(context.Context, *ListConfigVersionsRequest) (*ListConfigVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfigVersions not implemented")
+}
+func (UnimplementedConfigServiceServer) RollbackConfig(context.Context, *RollbackConfigRequest) (*RollbackConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackConfig not implemented")
+}
 func (UnimplementedConfigServiceServer) mustEmbedUnimplementedConfigServiceServer() {}
 
 // UnsafeConfigServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -238,6 +306,63 @@ func _ConfigService_BatchQueryConfig_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigService_WatchConfig_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchConfigRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigServiceServer).WatchConfig(m, &configServiceWatchConfigServer{stream})
+}
+
+type ConfigService_WatchConfigServer interface {
+	Send(*WatchConfigEventResponse) error
+	grpc.ServerStream
+}
+
+type configServiceWatchConfigServer struct {
+	grpc.ServerStream
+}
+
+func (x *configServiceWatchConfigServer) Send(m *WatchConfigEventResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ConfigService_ListConfigVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).ListConfigVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_ListConfigVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).ListConfigVersions(ctx, req.(*ListConfigVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigService_RollbackConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).RollbackConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_RollbackConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).RollbackConfig(ctx, req.(*RollbackConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConfigService_ServiceDesc is the grpc.ServiceDesc for ConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,7 +390,21 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchQueryConfig",
 			Handler:    _ConfigService_BatchQueryConfig_Handler,
 		},
+		{
+			MethodName: "ListConfigVersions",
+			Handler:    _ConfigService_ListConfigVersions_Handler,
+		},
+		{
+			MethodName: "RollbackConfig",
+			Handler:    _ConfigService_RollbackConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchConfig",
+			Handler:       _ConfigService_WatchConfig_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "kuscia/proto/api/v1alpha1/confmanager/config.proto",
 }
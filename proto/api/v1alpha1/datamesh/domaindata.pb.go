@@ -50,9 +50,8 @@ type CreateDomainDataRequest struct {
 	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
 	// The relative_uri is relative to the datasource URI, The datasourceURI appends relative_uri is the domaindataURI.
 	// e.g. the relative_uri is "train/table.csv"
-	//
-	//	the URI of datasource is "/home/data"
-	//	the URI of domaindata is "/home/data/train/table.csv"
+	//      the URI of datasource is "/home/data"
+	//      the URI of domaindata is "/home/data/train/table.csv"
 	RelativeUri string `protobuf:"bytes,5,opt,name=relative_uri,json=relativeUri,proto3" json:"relative_uri,omitempty"`
 	// Optional, server would use default datasource if datasource_id is empty.
 	// The datasource is where the domain is stored.
@@ -298,9 +297,8 @@ type UpdateDomainDataRequest struct {
 	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
 	// The relative_uri is relative to the datasource URI, The datasourceURI appends relative_uri is the domaindataURI.
 	// e.g. the relative_uri is "train/table.csv"
-	//
-	//	the URI of datasource is "/home/data"
-	//	the URI of domaindata is "/home/data/train/table.csv"
+	//      the URI of datasource is "/home/data"
+	//      the URI of domaindata is "/home/data/train/table.csv"
 	RelativeUri string `protobuf:"bytes,5,opt,name=relative_uri,json=relativeUri,proto3" json:"relative_uri,omitempty"`
 	// The datasource is where the domain is stored.
 	DatasourceId string `protobuf:"bytes,6,opt,name=datasource_id,json=datasourceId,proto3" json:"datasource_id,omitempty"`
@@ -577,6 +575,433 @@ func (x *DeleteDomainDataResponse) GetStatus() *v1alpha1.Status {
 	return nil
 }
 
+// call DoAction with type ActionVerifyDomainDataRequest, re-check the integrity of the stored domaindata
+type VerifyDomainDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header       *v1alpha1.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	DomaindataId string                  `protobuf:"bytes,2,opt,name=domaindata_id,json=domaindataId,proto3" json:"domaindata_id,omitempty"`
+}
+
+func (x *VerifyDomainDataRequest) Reset() {
+	*x = VerifyDomainDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyDomainDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainDataRequest) ProtoMessage() {}
+
+func (x *VerifyDomainDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainDataRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainDataRequest) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VerifyDomainDataRequest) GetHeader() *v1alpha1.RequestHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *VerifyDomainDataRequest) GetDomaindataId() string {
+	if x != nil {
+		return x.DomaindataId
+	}
+	return ""
+}
+
+type VerifyDomainDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *v1alpha1.Status        `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *VerifyDomainDataResult `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *VerifyDomainDataResponse) Reset() {
+	*x = VerifyDomainDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyDomainDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainDataResponse) ProtoMessage() {}
+
+func (x *VerifyDomainDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainDataResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDomainDataResponse) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VerifyDomainDataResponse) GetStatus() *v1alpha1.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *VerifyDomainDataResponse) GetData() *VerifyDomainDataResult {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type VerifyDomainDataResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// checksum recorded when the domaindata was written, e.g. "sha256:<hex>", empty if not recorded
+	ExpectedChecksum string `protobuf:"bytes,1,opt,name=expected_checksum,json=expectedChecksum,proto3" json:"expected_checksum,omitempty"`
+	// checksum of the domaindata currently stored in the datasource
+	ActualChecksum string `protobuf:"bytes,2,opt,name=actual_checksum,json=actualChecksum,proto3" json:"actual_checksum,omitempty"`
+	// verified is true if the expected checksum is recorded and equals to the actual checksum
+	Verified bool `protobuf:"varint,3,opt,name=verified,proto3" json:"verified,omitempty"`
+}
+
+func (x *VerifyDomainDataResult) Reset() {
+	*x = VerifyDomainDataResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyDomainDataResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainDataResult) ProtoMessage() {}
+
+func (x *VerifyDomainDataResult) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainDataResult.ProtoReflect.Descriptor instead.
+func (*VerifyDomainDataResult) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyDomainDataResult) GetExpectedChecksum() string {
+	if x != nil {
+		return x.ExpectedChecksum
+	}
+	return ""
+}
+
+func (x *VerifyDomainDataResult) GetActualChecksum() string {
+	if x != nil {
+		return x.ActualChecksum
+	}
+	return ""
+}
+
+func (x *VerifyDomainDataResult) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+// call DoAction with type ActionSampleDomainDataRequest, sample the table domaindata into a new domaindata
+type SampleDomainDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *v1alpha1.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// id of the source domaindata, only table is supported
+	DomaindataId string `protobuf:"bytes,2,opt,name=domaindata_id,json=domaindataId,proto3" json:"domaindata_id,omitempty"`
+	// sample fraction in (0, 1], one of fraction and rows must be set
+	Fraction float64 `protobuf:"fixed64,3,opt,name=fraction,proto3" json:"fraction,omitempty"`
+	// number of sampled rows
+	Rows int64 `protobuf:"varint,4,opt,name=rows,proto3" json:"rows,omitempty"`
+	// seed of the random sampling, the same seed samples the same rows from the same data
+	Seed int64 `protobuf:"varint,5,opt,name=seed,proto3" json:"seed,omitempty"`
+	// Optional, sample each distinct value of the column in proportion
+	StratifyColumn string `protobuf:"bytes,6,opt,name=stratify_column,json=stratifyColumn,proto3" json:"stratify_column,omitempty"`
+	// Optional, id of the sampled domaindata, generated by server if empty
+	OutputDomaindataId string `protobuf:"bytes,7,opt,name=output_domaindata_id,json=outputDomaindataId,proto3" json:"output_domaindata_id,omitempty"`
+	// Optional, name of the sampled domaindata
+	OutputName string `protobuf:"bytes,8,opt,name=output_name,json=outputName,proto3" json:"output_name,omitempty"`
+	// relative_uri of the sampled domaindata
+	OutputRelativeUri string `protobuf:"bytes,9,opt,name=output_relative_uri,json=outputRelativeUri,proto3" json:"output_relative_uri,omitempty"`
+	// Optional, datasource of the sampled domaindata, default is the datasource of the source domaindata
+	OutputDatasourceId string `protobuf:"bytes,10,opt,name=output_datasource_id,json=outputDatasourceId,proto3" json:"output_datasource_id,omitempty"`
+}
+
+func (x *SampleDomainDataRequest) Reset() {
+	*x = SampleDomainDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SampleDomainDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SampleDomainDataRequest) ProtoMessage() {}
+
+func (x *SampleDomainDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SampleDomainDataRequest.ProtoReflect.Descriptor instead.
+func (*SampleDomainDataRequest) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SampleDomainDataRequest) GetHeader() *v1alpha1.RequestHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *SampleDomainDataRequest) GetDomaindataId() string {
+	if x != nil {
+		return x.DomaindataId
+	}
+	return ""
+}
+
+func (x *SampleDomainDataRequest) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+func (x *SampleDomainDataRequest) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *SampleDomainDataRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *SampleDomainDataRequest) GetStratifyColumn() string {
+	if x != nil {
+		return x.StratifyColumn
+	}
+	return ""
+}
+
+func (x *SampleDomainDataRequest) GetOutputDomaindataId() string {
+	if x != nil {
+		return x.OutputDomaindataId
+	}
+	return ""
+}
+
+func (x *SampleDomainDataRequest) GetOutputName() string {
+	if x != nil {
+		return x.OutputName
+	}
+	return ""
+}
+
+func (x *SampleDomainDataRequest) GetOutputRelativeUri() string {
+	if x != nil {
+		return x.OutputRelativeUri
+	}
+	return ""
+}
+
+func (x *SampleDomainDataRequest) GetOutputDatasourceId() string {
+	if x != nil {
+		return x.OutputDatasourceId
+	}
+	return ""
+}
+
+type SampleDomainDataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *v1alpha1.Status        `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *SampleDomainDataResult `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *SampleDomainDataResponse) Reset() {
+	*x = SampleDomainDataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SampleDomainDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SampleDomainDataResponse) ProtoMessage() {}
+
+func (x *SampleDomainDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SampleDomainDataResponse.ProtoReflect.Descriptor instead.
+func (*SampleDomainDataResponse) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SampleDomainDataResponse) GetStatus() *v1alpha1.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *SampleDomainDataResponse) GetData() *SampleDomainDataResult {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SampleDomainDataResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the sampled domaindata
+	DomaindataId string `protobuf:"bytes,1,opt,name=domaindata_id,json=domaindataId,proto3" json:"domaindata_id,omitempty"`
+	// number of rows of the source domaindata
+	TotalRows int64 `protobuf:"varint,2,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	// number of sampled rows
+	SampledRows int64 `protobuf:"varint,3,opt,name=sampled_rows,json=sampledRows,proto3" json:"sampled_rows,omitempty"`
+}
+
+func (x *SampleDomainDataResult) Reset() {
+	*x = SampleDomainDataResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SampleDomainDataResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SampleDomainDataResult) ProtoMessage() {}
+
+func (x *SampleDomainDataResult) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SampleDomainDataResult.ProtoReflect.Descriptor instead.
+func (*SampleDomainDataResult) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SampleDomainDataResult) GetDomaindataId() string {
+	if x != nil {
+		return x.DomaindataId
+	}
+	return ""
+}
+
+func (x *SampleDomainDataResult) GetTotalRows() int64 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *SampleDomainDataResult) GetSampledRows() int64 {
+	if x != nil {
+		return x.SampledRows
+	}
+	return 0
+}
+
 type QueryDomainDataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -589,7 +1014,7 @@ type QueryDomainDataRequest struct {
 func (x *QueryDomainDataRequest) Reset() {
 	*x = QueryDomainDataRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[7]
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -602,7 +1027,7 @@ func (x *QueryDomainDataRequest) String() string {
 func (*QueryDomainDataRequest) ProtoMessage() {}
 
 func (x *QueryDomainDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[7]
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +1040,7 @@ func (x *QueryDomainDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDomainDataRequest.ProtoReflect.Descriptor instead.
 func (*QueryDomainDataRequest) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{7}
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *QueryDomainDataRequest) GetHeader() *v1alpha1.RequestHeader {
@@ -644,7 +1069,7 @@ type QueryDomainDataResponse struct {
 func (x *QueryDomainDataResponse) Reset() {
 	*x = QueryDomainDataResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[8]
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -657,7 +1082,7 @@ func (x *QueryDomainDataResponse) String() string {
 func (*QueryDomainDataResponse) ProtoMessage() {}
 
 func (x *QueryDomainDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[8]
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +1095,7 @@ func (x *QueryDomainDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDomainDataResponse.ProtoReflect.Descriptor instead.
 func (*QueryDomainDataResponse) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{8}
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *QueryDomainDataResponse) GetStatus() *v1alpha1.Status {
@@ -700,9 +1125,8 @@ type DomainData struct {
 	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	// The relative_uri is relative to the datasource URI, The datasourceURI appends relative_uri is the domaindataURI.
 	// e.g. the relative_uri is "train/table.csv"
-	//
-	//	the URI of datasource is "/home/data"
-	//	the URI of domaindata is "/home/data/train/table.csv"
+	//      the URI of datasource is "/home/data"
+	//      the URI of domaindata is "/home/data/train/table.csv"
 	RelativeUri string `protobuf:"bytes,4,opt,name=relative_uri,json=relativeUri,proto3" json:"relative_uri,omitempty"`
 	// datasource_id is the identity of the domaindatasource, the domaindatasource that storage the domaindata file.
 	DatasourceId string `protobuf:"bytes,5,opt,name=datasource_id,json=datasourceId,proto3" json:"datasource_id,omitempty"`
@@ -725,7 +1149,7 @@ type DomainData struct {
 func (x *DomainData) Reset() {
 	*x = DomainData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[9]
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -738,7 +1162,7 @@ func (x *DomainData) String() string {
 func (*DomainData) ProtoMessage() {}
 
 func (x *DomainData) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[9]
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,7 +1175,7 @@ func (x *DomainData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DomainData.ProtoReflect.Descriptor instead.
 func (*DomainData) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{9}
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DomainData) GetDomaindataId() string {
@@ -956,105 +1380,177 @@ var file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDesc = []byte{
 	0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x7f, 0x0a,
-	0x16, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x80, 0x01,
+	0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64,
+	0x22, 0xa5, 0x01, 0x0a, 0x18, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x8a, 0x01, 0x0a, 0x16, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d,
+	0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x63, 0x74, 0x75, 0x61,
+	0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x22, 0xa2, 0x03, 0x0a, 0x17, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x66, 0x79, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x75, 0x72, 0x69, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x55, 0x72, 0x69, 0x12, 0x30, 0x0a, 0x14, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0xa5, 0x01, 0x0a, 0x18, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x22, 0x98,
-	0x01, 0x0a, 0x17, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
-	0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73,
+	0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x4e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x7f, 0x0a, 0x16, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x0d,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x6f, 0x77, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x5f, 0x72, 0x6f, 0x77, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x52,
+	0x6f, 0x77, 0x73, 0x22, 0x7f, 0x0a, 0x16, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a,
+	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61,
+	0x74, 0x61, 0x49, 0x64, 0x22, 0x98, 0x01, 0x0a, 0x17, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x42, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0xbd, 0x04, 0x0a, 0x0a, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74,
+	0x61, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x55, 0x72, 0x69, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x64, 0x12, 0x5e, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x70, 0x61,
+	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52,
+	0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64,
+	0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72,
+	0x12, 0x46, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0a, 0x66, 0x69,
+	0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32,
+	0xd0, 0x04, 0x0a, 0x11, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x2e, 0x6b, 0x75, 0x73,
 	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x42, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
-	0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xbd, 0x04, 0x0a, 0x0a, 0x44, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76,
-	0x65, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c,
-	0x61, 0x74, 0x69, 0x76, 0x65, 0x55, 0x72, 0x69, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x5e, 0x0a,
-	0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x42, 0x0a,
-	0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x61, 0x72,
-	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x3f, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44,
-	0x61, 0x74, 0x61, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
-	0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x46, 0x0a, 0x0b, 0x66, 0x69,
-	0x6c, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x25, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74,
-	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xd0, 0x04, 0x0a, 0x11, 0x44, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x8d, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x44, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8a, 0x01, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x3c, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x8a, 0x01, 0x0a, 0x0f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a,
-	0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c,
-	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d,
-	0x65, 0x73, 0x68, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a,
-	0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
-	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c,
-	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d,
-	0x65, 0x73, 0x68, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x5c, 0x0a, 0x20,
-	0x6f, 0x72, 0x67, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68,
-	0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x5c, 0x0a, 0x20, 0x6f, 0x72, 0x67, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1069,7 +1565,7 @@ func file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescGZIP() []by
 	return file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDescData
 }
 
-var file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_goTypes = []interface{}{
 	(*CreateDomainDataRequest)(nil),      // 0: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest
 	(*CreateDomainDataResponse)(nil),     // 1: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataResponse
@@ -1078,54 +1574,66 @@ var file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_goTypes = []interfa
 	(*UpdateDomainDataResponse)(nil),     // 4: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataResponse
 	(*DeleteDomainDataRequest)(nil),      // 5: kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataRequest
 	(*DeleteDomainDataResponse)(nil),     // 6: kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataResponse
-	(*QueryDomainDataRequest)(nil),       // 7: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataRequest
-	(*QueryDomainDataResponse)(nil),      // 8: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse
-	(*DomainData)(nil),                   // 9: kuscia.proto.api.v1alpha1.datamesh.DomainData
-	nil,                                  // 10: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.AttributesEntry
-	nil,                                  // 11: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.AttributesEntry
-	nil,                                  // 12: kuscia.proto.api.v1alpha1.datamesh.DomainData.AttributesEntry
-	(*v1alpha1.RequestHeader)(nil),       // 13: kuscia.proto.api.v1alpha1.RequestHeader
-	(*v1alpha1.Partition)(nil),           // 14: kuscia.proto.api.v1alpha1.Partition
-	(*v1alpha1.DataColumn)(nil),          // 15: kuscia.proto.api.v1alpha1.DataColumn
-	(v1alpha1.FileFormat)(0),             // 16: kuscia.proto.api.v1alpha1.FileFormat
-	(*v1alpha1.Status)(nil),              // 17: kuscia.proto.api.v1alpha1.Status
+	(*VerifyDomainDataRequest)(nil),      // 7: kuscia.proto.api.v1alpha1.datamesh.VerifyDomainDataRequest
+	(*VerifyDomainDataResponse)(nil),     // 8: kuscia.proto.api.v1alpha1.datamesh.VerifyDomainDataResponse
+	(*VerifyDomainDataResult)(nil),       // 9: kuscia.proto.api.v1alpha1.datamesh.VerifyDomainDataResult
+	(*SampleDomainDataRequest)(nil),      // 10: kuscia.proto.api.v1alpha1.datamesh.SampleDomainDataRequest
+	(*SampleDomainDataResponse)(nil),     // 11: kuscia.proto.api.v1alpha1.datamesh.SampleDomainDataResponse
+	(*SampleDomainDataResult)(nil),       // 12: kuscia.proto.api.v1alpha1.datamesh.SampleDomainDataResult
+	(*QueryDomainDataRequest)(nil),       // 13: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataRequest
+	(*QueryDomainDataResponse)(nil),      // 14: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse
+	(*DomainData)(nil),                   // 15: kuscia.proto.api.v1alpha1.datamesh.DomainData
+	nil,                                  // 16: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.AttributesEntry
+	nil,                                  // 17: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.AttributesEntry
+	nil,                                  // 18: kuscia.proto.api.v1alpha1.datamesh.DomainData.AttributesEntry
+	(*v1alpha1.RequestHeader)(nil),       // 19: kuscia.proto.api.v1alpha1.RequestHeader
+	(*v1alpha1.Partition)(nil),           // 20: kuscia.proto.api.v1alpha1.Partition
+	(*v1alpha1.DataColumn)(nil),          // 21: kuscia.proto.api.v1alpha1.DataColumn
+	(v1alpha1.FileFormat)(0),             // 22: kuscia.proto.api.v1alpha1.FileFormat
+	(*v1alpha1.Status)(nil),              // 23: kuscia.proto.api.v1alpha1.Status
 }
 var file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_depIdxs = []int32{
-	13, // 0: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	10, // 1: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.attributes:type_name -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.AttributesEntry
-	14, // 2: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.partition:type_name -> kuscia.proto.api.v1alpha1.Partition
-	15, // 3: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.columns:type_name -> kuscia.proto.api.v1alpha1.DataColumn
-	16, // 4: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.file_format:type_name -> kuscia.proto.api.v1alpha1.FileFormat
-	17, // 5: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	19, // 0: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	16, // 1: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.attributes:type_name -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.AttributesEntry
+	20, // 2: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.partition:type_name -> kuscia.proto.api.v1alpha1.Partition
+	21, // 3: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.columns:type_name -> kuscia.proto.api.v1alpha1.DataColumn
+	22, // 4: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest.file_format:type_name -> kuscia.proto.api.v1alpha1.FileFormat
+	23, // 5: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
 	2,  // 6: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataResponse.data:type_name -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataResponseData
-	13, // 7: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	11, // 8: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.attributes:type_name -> kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.AttributesEntry
-	14, // 9: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.partition:type_name -> kuscia.proto.api.v1alpha1.Partition
-	15, // 10: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.columns:type_name -> kuscia.proto.api.v1alpha1.DataColumn
-	16, // 11: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.file_format:type_name -> kuscia.proto.api.v1alpha1.FileFormat
-	17, // 12: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	13, // 13: kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	17, // 14: kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	13, // 15: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	17, // 16: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	9,  // 17: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse.data:type_name -> kuscia.proto.api.v1alpha1.datamesh.DomainData
-	12, // 18: kuscia.proto.api.v1alpha1.datamesh.DomainData.attributes:type_name -> kuscia.proto.api.v1alpha1.datamesh.DomainData.AttributesEntry
-	14, // 19: kuscia.proto.api.v1alpha1.datamesh.DomainData.partition:type_name -> kuscia.proto.api.v1alpha1.Partition
-	15, // 20: kuscia.proto.api.v1alpha1.datamesh.DomainData.columns:type_name -> kuscia.proto.api.v1alpha1.DataColumn
-	16, // 21: kuscia.proto.api.v1alpha1.datamesh.DomainData.file_format:type_name -> kuscia.proto.api.v1alpha1.FileFormat
-	0,  // 22: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.CreateDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest
-	7,  // 23: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.QueryDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataRequest
-	3,  // 24: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.UpdateDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest
-	5,  // 25: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.DeleteDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataRequest
-	1,  // 26: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.CreateDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataResponse
-	8,  // 27: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.QueryDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse
-	4,  // 28: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.UpdateDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataResponse
-	6,  // 29: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.DeleteDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataResponse
-	26, // [26:30] is the sub-list for method output_type
-	22, // [22:26] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	19, // 7: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	17, // 8: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.attributes:type_name -> kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.AttributesEntry
+	20, // 9: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.partition:type_name -> kuscia.proto.api.v1alpha1.Partition
+	21, // 10: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.columns:type_name -> kuscia.proto.api.v1alpha1.DataColumn
+	22, // 11: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest.file_format:type_name -> kuscia.proto.api.v1alpha1.FileFormat
+	23, // 12: kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	19, // 13: kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	23, // 14: kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	19, // 15: kuscia.proto.api.v1alpha1.datamesh.VerifyDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	23, // 16: kuscia.proto.api.v1alpha1.datamesh.VerifyDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	9,  // 17: kuscia.proto.api.v1alpha1.datamesh.VerifyDomainDataResponse.data:type_name -> kuscia.proto.api.v1alpha1.datamesh.VerifyDomainDataResult
+	19, // 18: kuscia.proto.api.v1alpha1.datamesh.SampleDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	23, // 19: kuscia.proto.api.v1alpha1.datamesh.SampleDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	12, // 20: kuscia.proto.api.v1alpha1.datamesh.SampleDomainDataResponse.data:type_name -> kuscia.proto.api.v1alpha1.datamesh.SampleDomainDataResult
+	19, // 21: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	23, // 22: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	15, // 23: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse.data:type_name -> kuscia.proto.api.v1alpha1.datamesh.DomainData
+	18, // 24: kuscia.proto.api.v1alpha1.datamesh.DomainData.attributes:type_name -> kuscia.proto.api.v1alpha1.datamesh.DomainData.AttributesEntry
+	20, // 25: kuscia.proto.api.v1alpha1.datamesh.DomainData.partition:type_name -> kuscia.proto.api.v1alpha1.Partition
+	21, // 26: kuscia.proto.api.v1alpha1.datamesh.DomainData.columns:type_name -> kuscia.proto.api.v1alpha1.DataColumn
+	22, // 27: kuscia.proto.api.v1alpha1.datamesh.DomainData.file_format:type_name -> kuscia.proto.api.v1alpha1.FileFormat
+	0,  // 28: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.CreateDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataRequest
+	13, // 29: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.QueryDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataRequest
+	3,  // 30: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.UpdateDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataRequest
+	5,  // 31: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.DeleteDomainData:input_type -> kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataRequest
+	1,  // 32: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.CreateDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataResponse
+	14, // 33: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.QueryDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataResponse
+	4,  // 34: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.UpdateDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.UpdateDomainDataResponse
+	6,  // 35: kuscia.proto.api.v1alpha1.datamesh.DomainDataService.DeleteDomainData:output_type -> kuscia.proto.api.v1alpha1.datamesh.DeleteDomainDataResponse
+	32, // [32:36] is the sub-list for method output_type
+	28, // [28:32] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_init() }
@@ -1219,7 +1727,7 @@ func file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryDomainDataRequest); i {
+			switch v := v.(*VerifyDomainDataRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1231,7 +1739,7 @@ func file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryDomainDataResponse); i {
+			switch v := v.(*VerifyDomainDataResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1243,6 +1751,78 @@ func file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyDomainDataResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SampleDomainDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SampleDomainDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SampleDomainDataResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDomainDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDomainDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DomainData); i {
 			case 0:
 				return &v.state
@@ -1261,7 +1841,7 @@ func file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kuscia_proto_api_v1alpha1_datamesh_domaindata_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,847 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.8
+// source: kuscia/proto/api/v1alpha1/datamesh/domaindatacopy.proto
+
+package datamesh
+
+import (
+	v1alpha1 "github.com/secretflow/kuscia/proto/api/v1alpha1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DomainDataCopyData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DomaindatacopyId   string `protobuf:"bytes,1,opt,name=domaindatacopy_id,json=domaindatacopyId,proto3" json:"domaindatacopy_id,omitempty"`
+	SourceDomain       string `protobuf:"bytes,2,opt,name=source_domain,json=sourceDomain,proto3" json:"source_domain,omitempty"`
+	SourceDomaindataId string `protobuf:"bytes,3,opt,name=source_domaindata_id,json=sourceDomaindataId,proto3" json:"source_domaindata_id,omitempty"`
+	// id of the destination domaindata
+	DomaindataId string                `protobuf:"bytes,4,opt,name=domaindata_id,json=domaindataId,proto3" json:"domaindata_id,omitempty"`
+	DatasourceId string                `protobuf:"bytes,5,opt,name=datasource_id,json=datasourceId,proto3" json:"datasource_id,omitempty"`
+	RelativeUri  string                `protobuf:"bytes,6,opt,name=relative_uri,json=relativeUri,proto3" json:"relative_uri,omitempty"`
+	Status       *DomainDataCopyStatus `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *DomainDataCopyData) Reset() {
+	*x = DomainDataCopyData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainDataCopyData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainDataCopyData) ProtoMessage() {}
+
+func (x *DomainDataCopyData) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainDataCopyData.ProtoReflect.Descriptor instead.
+func (*DomainDataCopyData) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DomainDataCopyData) GetDomaindatacopyId() string {
+	if x != nil {
+		return x.DomaindatacopyId
+	}
+	return ""
+}
+
+func (x *DomainDataCopyData) GetSourceDomain() string {
+	if x != nil {
+		return x.SourceDomain
+	}
+	return ""
+}
+
+func (x *DomainDataCopyData) GetSourceDomaindataId() string {
+	if x != nil {
+		return x.SourceDomaindataId
+	}
+	return ""
+}
+
+func (x *DomainDataCopyData) GetDomaindataId() string {
+	if x != nil {
+		return x.DomaindataId
+	}
+	return ""
+}
+
+func (x *DomainDataCopyData) GetDatasourceId() string {
+	if x != nil {
+		return x.DatasourceId
+	}
+	return ""
+}
+
+func (x *DomainDataCopyData) GetRelativeUri() string {
+	if x != nil {
+		return x.RelativeUri
+	}
+	return ""
+}
+
+func (x *DomainDataCopyData) GetStatus() *DomainDataCopyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type DomainDataCopyStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Pending, Running, Succeeded or Failed
+	Phase          string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	Message        string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CopiedBytes    int64  `protobuf:"varint,3,opt,name=copied_bytes,json=copiedBytes,proto3" json:"copied_bytes,omitempty"`
+	TotalBytes     int64  `protobuf:"varint,4,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	Checksum       string `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	StartTime      string `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	CompletionTime string `protobuf:"bytes,7,opt,name=completion_time,json=completionTime,proto3" json:"completion_time,omitempty"`
+}
+
+func (x *DomainDataCopyStatus) Reset() {
+	*x = DomainDataCopyStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainDataCopyStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainDataCopyStatus) ProtoMessage() {}
+
+func (x *DomainDataCopyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainDataCopyStatus.ProtoReflect.Descriptor instead.
+func (*DomainDataCopyStatus) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DomainDataCopyStatus) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *DomainDataCopyStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DomainDataCopyStatus) GetCopiedBytes() int64 {
+	if x != nil {
+		return x.CopiedBytes
+	}
+	return 0
+}
+
+func (x *DomainDataCopyStatus) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *DomainDataCopyStatus) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *DomainDataCopyStatus) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *DomainDataCopyStatus) GetCompletionTime() string {
+	if x != nil {
+		return x.CompletionTime
+	}
+	return ""
+}
+
+type CreateDomainDataCopyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header             *v1alpha1.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	DomaindatacopyId   string                  `protobuf:"bytes,2,opt,name=domaindatacopy_id,json=domaindatacopyId,proto3" json:"domaindatacopy_id,omitempty"`
+	SourceDomain       string                  `protobuf:"bytes,3,opt,name=source_domain,json=sourceDomain,proto3" json:"source_domain,omitempty"`
+	SourceDomaindataId string                  `protobuf:"bytes,4,opt,name=source_domaindata_id,json=sourceDomaindataId,proto3" json:"source_domaindata_id,omitempty"`
+	// id of the destination domaindata, default is generated
+	DomaindataId string `protobuf:"bytes,5,opt,name=domaindata_id,json=domaindataId,proto3" json:"domaindata_id,omitempty"`
+	// datasource of the destination domaindata, default is default-data-source
+	DatasourceId string `protobuf:"bytes,6,opt,name=datasource_id,json=datasourceId,proto3" json:"datasource_id,omitempty"`
+	// uri of the copied data relative to the datasource, default is generated
+	RelativeUri string `protobuf:"bytes,7,opt,name=relative_uri,json=relativeUri,proto3" json:"relative_uri,omitempty"`
+	ChunkSize   int64  `protobuf:"varint,8,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+}
+
+func (x *CreateDomainDataCopyRequest) Reset() {
+	*x = CreateDomainDataCopyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDomainDataCopyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDomainDataCopyRequest) ProtoMessage() {}
+
+func (x *CreateDomainDataCopyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDomainDataCopyRequest.ProtoReflect.Descriptor instead.
+func (*CreateDomainDataCopyRequest) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateDomainDataCopyRequest) GetHeader() *v1alpha1.RequestHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CreateDomainDataCopyRequest) GetDomaindatacopyId() string {
+	if x != nil {
+		return x.DomaindatacopyId
+	}
+	return ""
+}
+
+func (x *CreateDomainDataCopyRequest) GetSourceDomain() string {
+	if x != nil {
+		return x.SourceDomain
+	}
+	return ""
+}
+
+func (x *CreateDomainDataCopyRequest) GetSourceDomaindataId() string {
+	if x != nil {
+		return x.SourceDomaindataId
+	}
+	return ""
+}
+
+func (x *CreateDomainDataCopyRequest) GetDomaindataId() string {
+	if x != nil {
+		return x.DomaindataId
+	}
+	return ""
+}
+
+func (x *CreateDomainDataCopyRequest) GetDatasourceId() string {
+	if x != nil {
+		return x.DatasourceId
+	}
+	return ""
+}
+
+func (x *CreateDomainDataCopyRequest) GetRelativeUri() string {
+	if x != nil {
+		return x.RelativeUri
+	}
+	return ""
+}
+
+func (x *CreateDomainDataCopyRequest) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+type CreateDomainDataCopyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *v1alpha1.Status                  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *CreateDomainDataCopyResponseData `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *CreateDomainDataCopyResponse) Reset() {
+	*x = CreateDomainDataCopyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDomainDataCopyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDomainDataCopyResponse) ProtoMessage() {}
+
+func (x *CreateDomainDataCopyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDomainDataCopyResponse.ProtoReflect.Descriptor instead.
+func (*CreateDomainDataCopyResponse) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateDomainDataCopyResponse) GetStatus() *v1alpha1.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *CreateDomainDataCopyResponse) GetData() *CreateDomainDataCopyResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CreateDomainDataCopyResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DomaindatacopyId string `protobuf:"bytes,1,opt,name=domaindatacopy_id,json=domaindatacopyId,proto3" json:"domaindatacopy_id,omitempty"`
+	DomaindataId     string `protobuf:"bytes,2,opt,name=domaindata_id,json=domaindataId,proto3" json:"domaindata_id,omitempty"`
+}
+
+func (x *CreateDomainDataCopyResponseData) Reset() {
+	*x = CreateDomainDataCopyResponseData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDomainDataCopyResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDomainDataCopyResponseData) ProtoMessage() {}
+
+func (x *CreateDomainDataCopyResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDomainDataCopyResponseData.ProtoReflect.Descriptor instead.
+func (*CreateDomainDataCopyResponseData) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateDomainDataCopyResponseData) GetDomaindatacopyId() string {
+	if x != nil {
+		return x.DomaindatacopyId
+	}
+	return ""
+}
+
+func (x *CreateDomainDataCopyResponseData) GetDomaindataId() string {
+	if x != nil {
+		return x.DomaindataId
+	}
+	return ""
+}
+
+type QueryDomainDataCopyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header           *v1alpha1.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	DomaindatacopyId string                  `protobuf:"bytes,2,opt,name=domaindatacopy_id,json=domaindatacopyId,proto3" json:"domaindatacopy_id,omitempty"`
+}
+
+func (x *QueryDomainDataCopyRequest) Reset() {
+	*x = QueryDomainDataCopyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDomainDataCopyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDomainDataCopyRequest) ProtoMessage() {}
+
+func (x *QueryDomainDataCopyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDomainDataCopyRequest.ProtoReflect.Descriptor instead.
+func (*QueryDomainDataCopyRequest) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueryDomainDataCopyRequest) GetHeader() *v1alpha1.RequestHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *QueryDomainDataCopyRequest) GetDomaindatacopyId() string {
+	if x != nil {
+		return x.DomaindatacopyId
+	}
+	return ""
+}
+
+type QueryDomainDataCopyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *v1alpha1.Status    `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *DomainDataCopyData `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *QueryDomainDataCopyResponse) Reset() {
+	*x = QueryDomainDataCopyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDomainDataCopyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDomainDataCopyResponse) ProtoMessage() {}
+
+func (x *QueryDomainDataCopyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDomainDataCopyResponse.ProtoReflect.Descriptor instead.
+func (*QueryDomainDataCopyResponse) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QueryDomainDataCopyResponse) GetStatus() *v1alpha1.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *QueryDomainDataCopyResponse) GetData() *DomainDataCopyData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto protoreflect.FileDescriptor
+
+var file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDesc = []byte{
+	0x0a, 0x37, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x64, 0x61, 0x74, 0x61,
+	0x6d, 0x65, 0x73, 0x68, 0x2f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x63,
+	0x6f, 0x70, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x22, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x1a, 0x26, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd7, 0x02, 0x0a, 0x12, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x0a, 0x11,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x70, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64,
+	0x61, 0x74, 0x61, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x30,
+	0x0a, 0x14, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64,
+	0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x55, 0x72, 0x69, 0x12, 0x50, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65,
+	0x73, 0x68, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70,
+	0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0xee, 0x01, 0x0a, 0x14, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f,
+	0x70, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x70, 0x69,
+	0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x22, 0xef, 0x02, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61,
+	0x63, 0x6f, 0x70, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x12, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x75, 0x72, 0x69,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x55, 0x72, 0x69, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69,
+	0x7a, 0x65, 0x22, 0xb3, 0x01, 0x0a, 0x1c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x58,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x74, 0x0a, 0x20, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x0a, 0x11,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x70, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64,
+	0x61, 0x74, 0x61, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x49, 0x64, 0x22, 0x8b,
+	0x01, 0x0a, 0x1a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a,
+	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x2b, 0x0a, 0x11, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x70,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x6f, 0x70, 0x79, 0x49, 0x64, 0x22, 0xa4, 0x01, 0x0a,
+	0x1b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4a, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x32, 0xcc, 0x02, 0x0a, 0x15, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x99, 0x01,
+	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x12, 0x3f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x40, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x96, 0x01, 0x0a, 0x13, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70,
+	0x79, 0x12, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x3f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x5c, 0x0a, 0x20, 0x6f, 0x72, 0x67, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x6d, 0x65, 0x73, 0x68, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x6d, 0x65, 0x73, 0x68,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescOnce sync.Once
+	file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescData = file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDesc
+)
+
+func file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescGZIP() []byte {
+	file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescOnce.Do(func() {
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescData = protoimpl.X.CompressGZIP(file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescData)
+	})
+	return file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDescData
+}
+
+var file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_goTypes = []interface{}{
+	(*DomainDataCopyData)(nil),               // 0: kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyData
+	(*DomainDataCopyStatus)(nil),             // 1: kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyStatus
+	(*CreateDomainDataCopyRequest)(nil),      // 2: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyRequest
+	(*CreateDomainDataCopyResponse)(nil),     // 3: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyResponse
+	(*CreateDomainDataCopyResponseData)(nil), // 4: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyResponseData
+	(*QueryDomainDataCopyRequest)(nil),       // 5: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataCopyRequest
+	(*QueryDomainDataCopyResponse)(nil),      // 6: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataCopyResponse
+	(*v1alpha1.RequestHeader)(nil),           // 7: kuscia.proto.api.v1alpha1.RequestHeader
+	(*v1alpha1.Status)(nil),                  // 8: kuscia.proto.api.v1alpha1.Status
+}
+var file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_depIdxs = []int32{
+	1, // 0: kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyData.status:type_name -> kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyStatus
+	7, // 1: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	8, // 2: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	4, // 3: kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyResponse.data:type_name -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyResponseData
+	7, // 4: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataCopyRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	8, // 5: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataCopyResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	0, // 6: kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataCopyResponse.data:type_name -> kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyData
+	2, // 7: kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyService.CreateDomainDataCopy:input_type -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyRequest
+	5, // 8: kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyService.QueryDomainDataCopy:input_type -> kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataCopyRequest
+	3, // 9: kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyService.CreateDomainDataCopy:output_type -> kuscia.proto.api.v1alpha1.datamesh.CreateDomainDataCopyResponse
+	6, // 10: kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyService.QueryDomainDataCopy:output_type -> kuscia.proto.api.v1alpha1.datamesh.QueryDomainDataCopyResponse
+	9, // [9:11] is the sub-list for method output_type
+	7, // [7:9] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_init() }
+func file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_init() {
+	if File_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainDataCopyData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainDataCopyStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDomainDataCopyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDomainDataCopyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDomainDataCopyResponseData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDomainDataCopyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDomainDataCopyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_goTypes,
+		DependencyIndexes: file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_depIdxs,
+		MessageInfos:      file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_msgTypes,
+	}.Build()
+	File_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto = out.File
+	file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_rawDesc = nil
+	file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_goTypes = nil
+	file_kuscia_proto_api_v1alpha1_datamesh_domaindatacopy_proto_depIdxs = nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Ant Group Co., Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.8
+// source: kuscia/proto/api/v1alpha1/datamesh/domaindatacopy.proto
+
+package datamesh
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	DomainDataCopyService_CreateDomainDataCopy_FullMethodName = "/kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyService/CreateDomainDataCopy"
+	DomainDataCopyService_QueryDomainDataCopy_FullMethodName  = "/kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyService/QueryDomainDataCopy"
+)
+
+// DomainDataCopyServiceClient is the client API for DomainDataCopyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DomainDataCopyServiceClient interface {
+	CreateDomainDataCopy(ctx context.Context, in *CreateDomainDataCopyRequest, opts ...grpc.CallOption) (*CreateDomainDataCopyResponse, error)
+	QueryDomainDataCopy(ctx context.Context, in *QueryDomainDataCopyRequest, opts ...grpc.CallOption) (*QueryDomainDataCopyResponse, error)
+}
+
+type domainDataCopyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDomainDataCopyServiceClient(cc grpc.ClientConnInterface) DomainDataCopyServiceClient {
+	return &domainDataCopyServiceClient{cc}
+}
+
+func (c *domainDataCopyServiceClient) CreateDomainDataCopy(ctx context.Context, in *CreateDomainDataCopyRequest, opts ...grpc.CallOption) (*CreateDomainDataCopyResponse, error) {
+	out := new(CreateDomainDataCopyResponse)
+	err := c.cc.Invoke(ctx, DomainDataCopyService_CreateDomainDataCopy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *domainDataCopyServiceClient) QueryDomainDataCopy(ctx context.Context, in *QueryDomainDataCopyRequest, opts ...grpc.CallOption) (*QueryDomainDataCopyResponse, error) {
+	out := new(QueryDomainDataCopyResponse)
+	err := c.cc.Invoke(ctx, DomainDataCopyService_QueryDomainDataCopy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DomainDataCopyServiceServer is the server API for DomainDataCopyService service.
+// All implementations must embed UnimplementedDomainDataCopyServiceServer
+// for forward compatibility
+type DomainDataCopyServiceServer interface {
+	CreateDomainDataCopy(context.Context, *CreateDomainDataCopyRequest) (*CreateDomainDataCopyResponse, error)
+	QueryDomainDataCopy(context.Context, *QueryDomainDataCopyRequest) (*QueryDomainDataCopyResponse, error)
+	mustEmbedUnimplementedDomainDataCopyServiceServer()
+}
+
+// UnimplementedDomainDataCopyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDomainDataCopyServiceServer struct {
+}
+
+func (UnimplementedDomainDataCopyServiceServer) CreateDomainDataCopy(context.Context, *CreateDomainDataCopyRequest) (*CreateDomainDataCopyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDomainDataCopy not implemented")
+}
+func (UnimplementedDomainDataCopyServiceServer) QueryDomainDataCopy(context.Context, *QueryDomainDataCopyRequest) (*QueryDomainDataCopyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDomainDataCopy not implemented")
+}
+func (UnimplementedDomainDataCopyServiceServer) mustEmbedUnimplementedDomainDataCopyServiceServer() {}
+
+// UnsafeDomainDataCopyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DomainDataCopyServiceServer will
+// result in compilation errors.
+type UnsafeDomainDataCopyServiceServer interface {
+	mustEmbedUnimplementedDomainDataCopyServiceServer()
+}
+
+func RegisterDomainDataCopyServiceServer(s grpc.ServiceRegistrar, srv DomainDataCopyServiceServer) {
+	s.RegisterService(&DomainDataCopyService_ServiceDesc, srv)
+}
+
+func _DomainDataCopyService_CreateDomainDataCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDomainDataCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainDataCopyServiceServer).CreateDomainDataCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainDataCopyService_CreateDomainDataCopy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainDataCopyServiceServer).CreateDomainDataCopy(ctx, req.(*CreateDomainDataCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DomainDataCopyService_QueryDomainDataCopy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDomainDataCopyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainDataCopyServiceServer).QueryDomainDataCopy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainDataCopyService_QueryDomainDataCopy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainDataCopyServiceServer).QueryDomainDataCopy(ctx, req.(*QueryDomainDataCopyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DomainDataCopyService_ServiceDesc is the grpc.ServiceDesc for DomainDataCopyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DomainDataCopyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kuscia.proto.api.v1alpha1.datamesh.DomainDataCopyService",
+	HandlerType: (*DomainDataCopyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateDomainDataCopy",
+			Handler:    _DomainDataCopyService_CreateDomainDataCopy_Handler,
+		},
+		{
+			MethodName: "QueryDomainDataCopy",
+			Handler:    _DomainDataCopyService_QueryDomainDataCopy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kuscia/proto/api/v1alpha1/datamesh/domaindatacopy.proto",
+}
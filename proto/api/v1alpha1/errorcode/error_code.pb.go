@@ -56,6 +56,10 @@ const (
 	ErrorCode_KusciaAPIErrCancelJob                        ErrorCode = 11209
 	ErrorCode_KusciaAPIErrSuspendNotRunningJob             ErrorCode = 11210
 	ErrorCode_KusciaAPIErrRestartNotSuspendedOrFailedJob   ErrorCode = 11211
+	ErrorCode_KusciaAPIErrQueryJobResourceUsage            ErrorCode = 11212
+	ErrorCode_KusciaAPIErrListPendingApprovals             ErrorCode = 11213
+	ErrorCode_KusciaAPIErrJobApprovalDecided               ErrorCode = 11214
+	ErrorCode_KusciaAPIErrQueryJobHistory                  ErrorCode = 11215
 	ErrorCode_KusciaAPIErrCreateDomain                     ErrorCode = 11300
 	ErrorCode_KusciaAPIErrQueryDomain                      ErrorCode = 11301
 	ErrorCode_KusciaAPIErrQueryDomainStatus                ErrorCode = 11302
@@ -63,12 +67,14 @@ const (
 	ErrorCode_KusciaAPIErrDeleteDomain                     ErrorCode = 11304
 	ErrorCode_KusciaAPIErrDomainNotExists                  ErrorCode = 11305
 	ErrorCode_KusciaAPIErrDomainExists                     ErrorCode = 11306
+	ErrorCode_KusciaAPIErrQueryDomainStatusDetail          ErrorCode = 11307
 	ErrorCode_KusciaAPIErrCreateDomainRoute                ErrorCode = 11400
 	ErrorCode_KusciaAPIErrQueryDomainRoute                 ErrorCode = 11401
 	ErrorCode_KusciaAPIErrQueryDomainRouteStatus           ErrorCode = 11402
 	ErrorCode_KusciaAPIErrDeleteDomainRoute                ErrorCode = 11403
 	ErrorCode_KusciaAPIErrDomainRouteNotExists             ErrorCode = 11404
 	ErrorCode_KusciaAPIErrDomainRouteExists                ErrorCode = 11405
+	ErrorCode_KusciaAPIErrQueryDomainRouteTransfer         ErrorCode = 11406
 	ErrorCode_KusciaAPIErrCreateDomainDataFailed           ErrorCode = 11500
 	ErrorCode_KusciaAPIErrDeleteDomainDataFailed           ErrorCode = 11501
 	ErrorCode_KusciaAPIErrGetDomainDataFailed              ErrorCode = 11502
@@ -82,6 +88,8 @@ const (
 	ErrorCode_KusciaAPIErrQueryServingStatus               ErrorCode = 11602
 	ErrorCode_KusciaAPIErrUpdateServing                    ErrorCode = 11603
 	ErrorCode_KusciaAPIErrDeleteServing                    ErrorCode = 11604
+	ErrorCode_KusciaAPIErrCreateServingRevision            ErrorCode = 11605
+	ErrorCode_KusciaAPIErrSwitchServingTraffic             ErrorCode = 11606
 	ErrorCode_KusciaAPIErrCreateDomainDataGrant            ErrorCode = 11700
 	ErrorCode_KusciaAPIErrUpdateDomainDataGrant            ErrorCode = 11701
 	ErrorCode_KusciaAPIErrQueryDomainDataGrant             ErrorCode = 11702
@@ -97,6 +105,7 @@ const (
 	ErrorCode_KusciaAPIErrDomainDataSourceNotExists        ErrorCode = 11806
 	ErrorCode_KusciaAPIErrDomainDataSourceInfoEncodeFailed ErrorCode = 11807
 	ErrorCode_KusciaAPIErrListDomainDataSource             ErrorCode = 11808
+	ErrorCode_KusciaAPIErrTestDomainDataSource             ErrorCode = 11809
 	ErrorCode_KusciaAPIErrCreateConfig                     ErrorCode = 11900
 	ErrorCode_KusciaAPIErrQueryConfig                      ErrorCode = 11901
 	ErrorCode_KusciaAPIErrUpdateConfig                     ErrorCode = 11902
@@ -109,8 +118,13 @@ const (
 	ErrorCode_KusciaAPIErrBatchQueryAppImage               ErrorCode = 13104
 	ErrorCode_KusciaAPIErrAppImageNotExists                ErrorCode = 13105
 	ErrorCode_KusciaAPIErrAppImageExists                   ErrorCode = 13106
+	ErrorCode_KusciaAPIErrValidateAppImage                 ErrorCode = 13107
 	ErrorCode_KusciaAPIErrQueryLog                         ErrorCode = 13200
 	ErrorCode_KusciaAPIErrQueryPodNode                     ErrorCode = 13201
+	ErrorCode_KusciaAPIErrCreateBackup                     ErrorCode = 13300
+	ErrorCode_KusciaAPIErrListBackup                       ErrorCode = 13301
+	ErrorCode_KusciaAPIErrListCertificate                  ErrorCode = 13400
+	ErrorCode_KusciaAPIErrRotateCertificate                ErrorCode = 13401
 	// data mesh
 	ErrorCode_DataMeshErrRequestInvalidate                 ErrorCode = 12100
 	ErrorCode_DataMeshErrForUnexpected                     ErrorCode = 12101
@@ -137,18 +151,34 @@ const (
 	ErrorCode_DataMeshErrDeleteDomainDataGrant             ErrorCode = 12403
 	ErrorCode_DataMeshErrDomainDataGrantExists             ErrorCode = 12404
 	ErrorCode_DataMeshErrDomainDataGrantNotExists          ErrorCode = 12405
+	ErrorCode_DataMeshErrCreateDomainDataCopy              ErrorCode = 12500
+	ErrorCode_DataMeshErrQueryDomainDataCopy               ErrorCode = 12501
 	// conf manager
-	ErrorCode_ConfManagerErrRequestInvalidate ErrorCode = 2000
-	ErrorCode_ConfManagerErrForUnexpected     ErrorCode = 2001
-	ErrorCode_ConfManagerErrCreateConfig      ErrorCode = 2102
-	ErrorCode_ConfManagerErrQueryConfig       ErrorCode = 2103
-	ErrorCode_ConfManagerErrUpdateConfig      ErrorCode = 2104
-	ErrorCode_ConfManagerErrDeleteConfig      ErrorCode = 2105
-	ErrorCode_ConfManagerErrBatchQueryConfig  ErrorCode = 2106
-	ErrorCode_ConfManagerErrGenerateKeyCerts  ErrorCode = 2200
+	ErrorCode_ConfManagerErrRequestInvalidate  ErrorCode = 2000
+	ErrorCode_ConfManagerErrForUnexpected      ErrorCode = 2001
+	ErrorCode_ConfManagerErrPermissionDenied   ErrorCode = 2002
+	ErrorCode_ConfManagerErrCreateConfig       ErrorCode = 2102
+	ErrorCode_ConfManagerErrQueryConfig        ErrorCode = 2103
+	ErrorCode_ConfManagerErrUpdateConfig       ErrorCode = 2104
+	ErrorCode_ConfManagerErrDeleteConfig       ErrorCode = 2105
+	ErrorCode_ConfManagerErrBatchQueryConfig   ErrorCode = 2106
+	ErrorCode_ConfManagerErrWatchConfig        ErrorCode = 2107
+	ErrorCode_ConfManagerErrListConfigVersions ErrorCode = 2108
+	ErrorCode_ConfManagerErrRollbackConfig     ErrorCode = 2109
+	ErrorCode_ConfManagerErrGenerateKeyCerts   ErrorCode = 2200
 	// reporter
 	ErrorCode_ReporterErrRequestInvalidate ErrorCode = 3000
 	ErrorCode_ReporterErrForUnexptected    ErrorCode = 3001
+	// interconn, errors returned by the platforms of other parties
+	ErrorCode_InterConnErrForUnexpected     ErrorCode = 14000
+	ErrorCode_InterConnErrPartyUnreachable  ErrorCode = 14001
+	ErrorCode_InterConnErrPartyTimeout      ErrorCode = 14002
+	ErrorCode_InterConnErrAuthFailed        ErrorCode = 14003
+	ErrorCode_InterConnErrRequestInvalidate ErrorCode = 14004
+	ErrorCode_InterConnErrResourceNotExists ErrorCode = 14005
+	ErrorCode_InterConnErrResourceExists    ErrorCode = 14006
+	ErrorCode_InterConnErrPartyInternal     ErrorCode = 14007
+	ErrorCode_InterConnErrPartyNotSupport   ErrorCode = 14008
 )
 
 // Enum value maps for ErrorCode.
@@ -172,6 +202,10 @@ var (
 		11209: "KusciaAPIErrCancelJob",
 		11210: "KusciaAPIErrSuspendNotRunningJob",
 		11211: "KusciaAPIErrRestartNotSuspendedOrFailedJob",
+		11212: "KusciaAPIErrQueryJobResourceUsage",
+		11213: "KusciaAPIErrListPendingApprovals",
+		11214: "KusciaAPIErrJobApprovalDecided",
+		11215: "KusciaAPIErrQueryJobHistory",
 		11300: "KusciaAPIErrCreateDomain",
 		11301: "KusciaAPIErrQueryDomain",
 		11302: "KusciaAPIErrQueryDomainStatus",
@@ -179,12 +213,14 @@ var (
 		11304: "KusciaAPIErrDeleteDomain",
 		11305: "KusciaAPIErrDomainNotExists",
 		11306: "KusciaAPIErrDomainExists",
+		11307: "KusciaAPIErrQueryDomainStatusDetail",
 		11400: "KusciaAPIErrCreateDomainRoute",
 		11401: "KusciaAPIErrQueryDomainRoute",
 		11402: "KusciaAPIErrQueryDomainRouteStatus",
 		11403: "KusciaAPIErrDeleteDomainRoute",
 		11404: "KusciaAPIErrDomainRouteNotExists",
 		11405: "KusciaAPIErrDomainRouteExists",
+		11406: "KusciaAPIErrQueryDomainRouteTransfer",
 		11500: "KusciaAPIErrCreateDomainDataFailed",
 		11501: "KusciaAPIErrDeleteDomainDataFailed",
 		11502: "KusciaAPIErrGetDomainDataFailed",
@@ -198,6 +234,8 @@ var (
 		11602: "KusciaAPIErrQueryServingStatus",
 		11603: "KusciaAPIErrUpdateServing",
 		11604: "KusciaAPIErrDeleteServing",
+		11605: "KusciaAPIErrCreateServingRevision",
+		11606: "KusciaAPIErrSwitchServingTraffic",
 		11700: "KusciaAPIErrCreateDomainDataGrant",
 		11701: "KusciaAPIErrUpdateDomainDataGrant",
 		11702: "KusciaAPIErrQueryDomainDataGrant",
@@ -213,6 +251,7 @@ var (
 		11806: "KusciaAPIErrDomainDataSourceNotExists",
 		11807: "KusciaAPIErrDomainDataSourceInfoEncodeFailed",
 		11808: "KusciaAPIErrListDomainDataSource",
+		11809: "KusciaAPIErrTestDomainDataSource",
 		11900: "KusciaAPIErrCreateConfig",
 		11901: "KusciaAPIErrQueryConfig",
 		11902: "KusciaAPIErrUpdateConfig",
@@ -225,8 +264,13 @@ var (
 		13104: "KusciaAPIErrBatchQueryAppImage",
 		13105: "KusciaAPIErrAppImageNotExists",
 		13106: "KusciaAPIErrAppImageExists",
+		13107: "KusciaAPIErrValidateAppImage",
 		13200: "KusciaAPIErrQueryLog",
 		13201: "KusciaAPIErrQueryPodNode",
+		13300: "KusciaAPIErrCreateBackup",
+		13301: "KusciaAPIErrListBackup",
+		13400: "KusciaAPIErrListCertificate",
+		13401: "KusciaAPIErrRotateCertificate",
 		12100: "DataMeshErrRequestInvalidate",
 		12101: "DataMeshErrForUnexpected",
 		12200: "DataMeshErrCreateDomainData",
@@ -252,16 +296,31 @@ var (
 		12403: "DataMeshErrDeleteDomainDataGrant",
 		12404: "DataMeshErrDomainDataGrantExists",
 		12405: "DataMeshErrDomainDataGrantNotExists",
+		12500: "DataMeshErrCreateDomainDataCopy",
+		12501: "DataMeshErrQueryDomainDataCopy",
 		2000:  "ConfManagerErrRequestInvalidate",
 		2001:  "ConfManagerErrForUnexpected",
+		2002:  "ConfManagerErrPermissionDenied",
 		2102:  "ConfManagerErrCreateConfig",
 		2103:  "ConfManagerErrQueryConfig",
 		2104:  "ConfManagerErrUpdateConfig",
 		2105:  "ConfManagerErrDeleteConfig",
 		2106:  "ConfManagerErrBatchQueryConfig",
+		2107:  "ConfManagerErrWatchConfig",
+		2108:  "ConfManagerErrListConfigVersions",
+		2109:  "ConfManagerErrRollbackConfig",
 		2200:  "ConfManagerErrGenerateKeyCerts",
 		3000:  "ReporterErrRequestInvalidate",
 		3001:  "ReporterErrForUnexptected",
+		14000: "InterConnErrForUnexpected",
+		14001: "InterConnErrPartyUnreachable",
+		14002: "InterConnErrPartyTimeout",
+		14003: "InterConnErrAuthFailed",
+		14004: "InterConnErrRequestInvalidate",
+		14005: "InterConnErrResourceNotExists",
+		14006: "InterConnErrResourceExists",
+		14007: "InterConnErrPartyInternal",
+		14008: "InterConnErrPartyNotSupport",
 	}
 	ErrorCode_value = map[string]int32{
 		"SUCCESS":                                      0,
@@ -282,6 +341,10 @@ var (
 		"KusciaAPIErrCancelJob":                        11209,
 		"KusciaAPIErrSuspendNotRunningJob":             11210,
 		"KusciaAPIErrRestartNotSuspendedOrFailedJob":   11211,
+		"KusciaAPIErrQueryJobResourceUsage":            11212,
+		"KusciaAPIErrListPendingApprovals":             11213,
+		"KusciaAPIErrJobApprovalDecided":               11214,
+		"KusciaAPIErrQueryJobHistory":                  11215,
 		"KusciaAPIErrCreateDomain":                     11300,
 		"KusciaAPIErrQueryDomain":                      11301,
 		"KusciaAPIErrQueryDomainStatus":                11302,
@@ -289,12 +352,14 @@ var (
 		"KusciaAPIErrDeleteDomain":                     11304,
 		"KusciaAPIErrDomainNotExists":                  11305,
 		"KusciaAPIErrDomainExists":                     11306,
+		"KusciaAPIErrQueryDomainStatusDetail":          11307,
 		"KusciaAPIErrCreateDomainRoute":                11400,
 		"KusciaAPIErrQueryDomainRoute":                 11401,
 		"KusciaAPIErrQueryDomainRouteStatus":           11402,
 		"KusciaAPIErrDeleteDomainRoute":                11403,
 		"KusciaAPIErrDomainRouteNotExists":             11404,
 		"KusciaAPIErrDomainRouteExists":                11405,
+		"KusciaAPIErrQueryDomainRouteTransfer":         11406,
 		"KusciaAPIErrCreateDomainDataFailed":           11500,
 		"KusciaAPIErrDeleteDomainDataFailed":           11501,
 		"KusciaAPIErrGetDomainDataFailed":              11502,
@@ -308,6 +373,8 @@ var (
 		"KusciaAPIErrQueryServingStatus":               11602,
 		"KusciaAPIErrUpdateServing":                    11603,
 		"KusciaAPIErrDeleteServing":                    11604,
+		"KusciaAPIErrCreateServingRevision":            11605,
+		"KusciaAPIErrSwitchServingTraffic":             11606,
 		"KusciaAPIErrCreateDomainDataGrant":            11700,
 		"KusciaAPIErrUpdateDomainDataGrant":            11701,
 		"KusciaAPIErrQueryDomainDataGrant":             11702,
@@ -323,6 +390,7 @@ var (
 		"KusciaAPIErrDomainDataSourceNotExists":        11806,
 		"KusciaAPIErrDomainDataSourceInfoEncodeFailed": 11807,
 		"KusciaAPIErrListDomainDataSource":             11808,
+		"KusciaAPIErrTestDomainDataSource":             11809,
 		"KusciaAPIErrCreateConfig":                     11900,
 		"KusciaAPIErrQueryConfig":                      11901,
 		"KusciaAPIErrUpdateConfig":                     11902,
@@ -335,8 +403,13 @@ var (
 		"KusciaAPIErrBatchQueryAppImage":               13104,
 		"KusciaAPIErrAppImageNotExists":                13105,
 		"KusciaAPIErrAppImageExists":                   13106,
+		"KusciaAPIErrValidateAppImage":                 13107,
 		"KusciaAPIErrQueryLog":                         13200,
 		"KusciaAPIErrQueryPodNode":                     13201,
+		"KusciaAPIErrCreateBackup":                     13300,
+		"KusciaAPIErrListBackup":                       13301,
+		"KusciaAPIErrListCertificate":                  13400,
+		"KusciaAPIErrRotateCertificate":                13401,
 		"DataMeshErrRequestInvalidate":                 12100,
 		"DataMeshErrForUnexpected":                     12101,
 		"DataMeshErrCreateDomainData":                  12200,
@@ -362,16 +435,31 @@ var (
 		"DataMeshErrDeleteDomainDataGrant":             12403,
 		"DataMeshErrDomainDataGrantExists":             12404,
 		"DataMeshErrDomainDataGrantNotExists":          12405,
+		"DataMeshErrCreateDomainDataCopy":              12500,
+		"DataMeshErrQueryDomainDataCopy":               12501,
 		"ConfManagerErrRequestInvalidate":              2000,
 		"ConfManagerErrForUnexpected":                  2001,
+		"ConfManagerErrPermissionDenied":               2002,
 		"ConfManagerErrCreateConfig":                   2102,
 		"ConfManagerErrQueryConfig":                    2103,
 		"ConfManagerErrUpdateConfig":                   2104,
 		"ConfManagerErrDeleteConfig":                   2105,
 		"ConfManagerErrBatchQueryConfig":               2106,
+		"ConfManagerErrWatchConfig":                    2107,
+		"ConfManagerErrListConfigVersions":             2108,
+		"ConfManagerErrRollbackConfig":                 2109,
 		"ConfManagerErrGenerateKeyCerts":               2200,
 		"ReporterErrRequestInvalidate":                 3000,
 		"ReporterErrForUnexptected":                    3001,
+		"InterConnErrForUnexpected":                    14000,
+		"InterConnErrPartyUnreachable":                 14001,
+		"InterConnErrPartyTimeout":                     14002,
+		"InterConnErrAuthFailed":                       14003,
+		"InterConnErrRequestInvalidate":                14004,
+		"InterConnErrResourceNotExists":                14005,
+		"InterConnErrResourceExists":                   14006,
+		"InterConnErrPartyInternal":                    14007,
+		"InterConnErrPartyNotSupport":                  14008,
 	}
 )
 
@@ -402,6 +490,91 @@ func (ErrorCode) EnumDescriptor() ([]byte, []int) {
 	return file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDescGZIP(), []int{0}
 }
 
+// ErrorDetail is the machine-readable detail attached to Status.details of a failed response.
+type ErrorDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The stable string form of the error code, i.e. the name of ErrorCode.
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The numeric error code, same as Status.code.
+	Code int32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	// The localized description of the error.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The localized remediation hint of the error.
+	Solution string `protobuf:"bytes,4,opt,name=solution,proto3" json:"solution,omitempty"`
+	// The language of description and solution, e.g. en-US, zh-CN.
+	Language string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErrorDetail) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ErrorDetail) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetSolution() string {
+	if x != nil {
+		return x.Solution
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 var File_kuscia_proto_api_v1alpha1_errorcode_error_code_proto protoreflect.FileDescriptor
 
 var file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDesc = []byte{
@@ -410,100 +583,129 @@ var file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDesc = []byte{
 	0x72, 0x63, 0x6f, 0x64, 0x65, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2a, 0xeb, 0x1e, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12,
-	0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1b,
-	0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x10, 0xdc, 0x56, 0x12, 0x1e,
-	0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x46, 0x6f,
-	0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0xdd, 0x56, 0x12, 0x1b,
-	0x0a, 0x16, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x41, 0x75,
-	0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xde, 0x56, 0x12, 0x24, 0x0a, 0x1f, 0x4b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xdf,
-	0x56, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
-	0x72, 0x4c, 0x69, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4e, 0x6f, 0x74, 0x53, 0x75, 0x70, 0x70, 0x6f,
-	0x72, 0x74, 0x10, 0xe0, 0x56, 0x12, 0x24, 0x0a, 0x1f, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
-	0x50, 0x49, 0x45, 0x72, 0x72, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x41, 0x50, 0x49, 0x4e, 0x6f,
-	0x74, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x10, 0xe1, 0x56, 0x12, 0x1a, 0x0a, 0x15, 0x4b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x4a, 0x6f, 0x62, 0x10, 0xc1, 0x57, 0x12, 0x19, 0x0a, 0x14, 0x4b, 0x75, 0x73, 0x63, 0x69,
-	0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x10,
-	0xc2, 0x57, 0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
-	0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x10, 0xc3, 0x57, 0x12, 0x1a, 0x0a, 0x15, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
-	0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x10, 0xc4, 0x57, 0x12,
-	0x18, 0x0a, 0x13, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x53,
-	0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x10, 0xc5, 0x57, 0x12, 0x1b, 0x0a, 0x16, 0x4b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65,
-	0x4a, 0x6f, 0x62, 0x10, 0xc6, 0x57, 0x12, 0x1b, 0x0a, 0x16, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x4a, 0x6f, 0x62,
-	0x10, 0xc7, 0x57, 0x12, 0x1b, 0x0a, 0x16, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
-	0x45, 0x72, 0x72, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x10, 0xc8, 0x57,
-	0x12, 0x1a, 0x0a, 0x15, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x10, 0xc9, 0x57, 0x12, 0x25, 0x0a, 0x20,
+	0x31, 0x22, 0x93, 0x01, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x2a, 0xf5, 0x26, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53,
+	0x10, 0x00, 0x12, 0x20, 0x0a, 0x1b, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
+	0x72, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x10, 0xdc, 0x56, 0x12, 0x1e, 0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
+	0x49, 0x45, 0x72, 0x72, 0x46, 0x6f, 0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x10, 0xdd, 0x56, 0x12, 0x1b, 0x0a, 0x16, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
+	0x49, 0x45, 0x72, 0x72, 0x41, 0x75, 0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xde,
+	0x56, 0x12, 0x24, 0x0a, 0x1f, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x46, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x10, 0xdf, 0x56, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4c, 0x69, 0x74, 0x65, 0x41, 0x50, 0x49, 0x4e, 0x6f,
+	0x74, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x10, 0xe0, 0x56, 0x12, 0x24, 0x0a, 0x1f, 0x4b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x41, 0x50, 0x49, 0x4e, 0x6f, 0x74, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x10, 0xe1,
+	0x56, 0x12, 0x1a, 0x0a, 0x15, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
+	0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x10, 0xc1, 0x57, 0x12, 0x19, 0x0a,
+	0x14, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x4a, 0x6f, 0x62, 0x10, 0xc2, 0x57, 0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0xc3, 0x57, 0x12, 0x1a, 0x0a, 0x15, 0x4b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a,
+	0x6f, 0x62, 0x10, 0xc4, 0x57, 0x12, 0x18, 0x0a, 0x13, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
+	0x50, 0x49, 0x45, 0x72, 0x72, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x10, 0xc5, 0x57, 0x12,
+	0x1b, 0x0a, 0x16, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x4a, 0x6f, 0x62, 0x10, 0xc6, 0x57, 0x12, 0x1b, 0x0a, 0x16,
 	0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x53, 0x75, 0x73, 0x70,
-	0x65, 0x6e, 0x64, 0x4e, 0x6f, 0x74, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62,
-	0x10, 0xca, 0x57, 0x12, 0x2f, 0x0a, 0x2a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
-	0x45, 0x72, 0x72, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4e, 0x6f, 0x74, 0x53, 0x75, 0x73,
-	0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4f, 0x72, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4a, 0x6f,
-	0x62, 0x10, 0xcb, 0x57, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
-	0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x10, 0xa4, 0x58, 0x12, 0x1c, 0x0a, 0x17, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
-	0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x10, 0xa5,
-	0x58, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
-	0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x10, 0xa6, 0x58, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
-	0x50, 0x49, 0x45, 0x72, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x10, 0xa7, 0x58, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
-	0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x10, 0xa8, 0x58, 0x12, 0x20, 0x0a, 0x1b, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
-	0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73,
-	0x74, 0x73, 0x10, 0xa9, 0x58, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
-	0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x69, 0x73, 0x74,
-	0x73, 0x10, 0xaa, 0x58, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
+	0x65, 0x6e, 0x64, 0x4a, 0x6f, 0x62, 0x10, 0xc7, 0x57, 0x12, 0x1b, 0x0a, 0x16, 0x4b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x4a, 0x6f, 0x62, 0x10, 0xc8, 0x57, 0x12, 0x1a, 0x0a, 0x15, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x10,
+	0xc9, 0x57, 0x12, 0x25, 0x0a, 0x20, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
+	0x72, 0x72, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x4e, 0x6f, 0x74, 0x52, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x10, 0xca, 0x57, 0x12, 0x2f, 0x0a, 0x2a, 0x4b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x4e, 0x6f, 0x74, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x4f, 0x72, 0x46, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x10, 0xcb, 0x57, 0x12, 0x26, 0x0a, 0x21, 0x4b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x55, 0x73, 0x61, 0x67, 0x65, 0x10,
+	0xcc, 0x57, 0x12, 0x25, 0x0a, 0x20, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
+	0x72, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x10, 0xcd, 0x57, 0x12, 0x23, 0x0a, 0x1e, 0x4b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4a, 0x6f, 0x62, 0x41, 0x70, 0x70, 0x72,
+	0x6f, 0x76, 0x61, 0x6c, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x10, 0xce, 0x57, 0x12, 0x20,
+	0x0a, 0x1b, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x10, 0xcf, 0x57,
+	0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x10, 0xa4, 0x58, 0x12,
+	0x1c, 0x0a, 0x17, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x10, 0xa5, 0x58, 0x12, 0x22, 0x0a,
+	0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0xa6,
+	0x58, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
+	0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x10, 0xa7, 0x58,
+	0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x10, 0xa8, 0x58, 0x12,
+	0x20, 0x0a, 0x1b, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xa9,
+	0x58, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
+	0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xaa, 0x58,
+	0x12, 0x28, 0x0a, 0x23, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x10, 0xab, 0x58, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x10, 0x88, 0x59, 0x12, 0x21,
+	0x0a, 0x1c, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x10, 0x89,
+	0x59, 0x12, 0x27, 0x0a, 0x22, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
+	0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0x8a, 0x59, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x10, 0x8b, 0x59, 0x12, 0x25,
+	0x0a, 0x20, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x10, 0x8c, 0x59, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
+	0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x8d, 0x59, 0x12, 0x29, 0x0a, 0x24, 0x4b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x10, 0x8e, 0x59, 0x12, 0x27, 0x0a, 0x22, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
 	0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x52, 0x6f, 0x75, 0x74, 0x65, 0x10, 0x88, 0x59, 0x12, 0x21, 0x0a, 0x1c, 0x4b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x10, 0x89, 0x59, 0x12, 0x27, 0x0a, 0x22, 0x4b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x10, 0x8a, 0x59, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
-	0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x52, 0x6f, 0x75, 0x74, 0x65, 0x10, 0x8b, 0x59, 0x12, 0x25, 0x0a, 0x20, 0x4b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f,
-	0x75, 0x74, 0x65, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x8c, 0x59, 0x12,
-	0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
-	0x10, 0x8d, 0x59, 0x12, 0x27, 0x0a, 0x22, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
-	0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
-	0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xec, 0x59, 0x12, 0x27, 0x0a, 0x22,
-	0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c,
-	0x65, 0x64, 0x10, 0xed, 0x59, 0x12, 0x24, 0x0a, 0x1f, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
-	0x50, 0x49, 0x45, 0x72, 0x72, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
-	0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xee, 0x59, 0x12, 0x25, 0x0a, 0x20, 0x4b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10,
-	0xef, 0x59, 0x12, 0x26, 0x0a, 0x21, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
-	0x72, 0x72, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xf0, 0x59, 0x12, 0x26, 0x0a, 0x21, 0x4b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x50, 0x61, 0x74, 0x63, 0x68, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10,
-	0xf1, 0x59, 0x12, 0x24, 0x0a, 0x1f, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
-	0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x74, 0x45,
-	0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xf2, 0x59, 0x12, 0x21, 0x0a, 0x1c, 0x4b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
-	0x74, 0x61, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xf3, 0x59, 0x12, 0x1e, 0x0a, 0x19, 0x4b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd0, 0x5a, 0x12, 0x1d, 0x0a, 0x18, 0x4b,
+	0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xec, 0x59, 0x12, 0x27, 0x0a,
+	0x22, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x10, 0xed, 0x59, 0x12, 0x24, 0x0a, 0x1f, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xee, 0x59, 0x12, 0x25, 0x0a, 0x20,
+	0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x10, 0xef, 0x59, 0x12, 0x26, 0x0a, 0x21, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
+	0x45, 0x72, 0x72, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xf0, 0x59, 0x12, 0x26, 0x0a, 0x21, 0x4b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x50, 0x61, 0x74, 0x63, 0x68,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x10, 0xf1, 0x59, 0x12, 0x24, 0x0a, 0x1f, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
+	0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x4e, 0x6f, 0x74,
+	0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xf2, 0x59, 0x12, 0x21, 0x0a, 0x1c, 0x4b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xf3, 0x59, 0x12, 0x1e, 0x0a, 0x19,
+	0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd0, 0x5a, 0x12, 0x1d, 0x0a, 0x18,
+	0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd1, 0x5a, 0x12, 0x23, 0x0a, 0x1e, 0x4b,
 	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd1, 0x5a, 0x12, 0x23, 0x0a, 0x1e, 0x4b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0xd2, 0x5a, 0x12,
-	0x1e, 0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd3, 0x5a, 0x12,
-	0x1e, 0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd4, 0x5a, 0x12,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x10, 0xd2, 0x5a,
+	0x12, 0x1e, 0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd3, 0x5a,
+	0x12, 0x1e, 0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x10, 0xd4, 0x5a,
+	0x12, 0x26, 0x0a, 0x21, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0xd5, 0x5a, 0x12, 0x25, 0x0a, 0x20, 0x4b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x10, 0xd6, 0x5a, 0x12,
 	0x26, 0x0a, 0x21, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43,
 	0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47,
 	0x72, 0x61, 0x6e, 0x74, 0x10, 0xb4, 0x5b, 0x12, 0x26, 0x0a, 0x21, 0x4b, 0x75, 0x73, 0x63, 0x69,
@@ -543,120 +745,165 @@ var file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDesc = []byte{
 	0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x9f, 0x5c, 0x12, 0x25, 0x0a, 0x20, 0x4b, 0x75, 0x73,
 	0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d,
 	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x10, 0xa0, 0x5c,
+	0x12, 0x25, 0x0a, 0x20, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
+	0x54, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x10, 0xa1, 0x5c, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x10, 0xfc, 0x5c, 0x12, 0x1c, 0x0a, 0x17, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x10, 0xfd, 0x5c, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50,
+	0x49, 0x45, 0x72, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x10, 0xfe, 0x5c, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49,
+	0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10,
+	0xff, 0x5c, 0x12, 0x21, 0x0a, 0x1c, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
+	0x72, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x10, 0x80, 0x5d, 0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
+	0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x10, 0xac, 0x66, 0x12, 0x1e, 0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x10, 0xad, 0x66, 0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x10, 0xae, 0x66, 0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0xaf, 0x66, 0x12, 0x23, 0x0a, 0x1e, 0x4b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0xb0, 0x66, 0x12, 0x22, 0x0a,
+	0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x41, 0x70, 0x70,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xb1,
+	0x66, 0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
+	0x72, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10,
+	0xb2, 0x66, 0x12, 0x21, 0x0a, 0x1c, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45,
+	0x72, 0x72, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x10, 0xb3, 0x66, 0x12, 0x19, 0x0a, 0x14, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41,
+	0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x10, 0x90, 0x67,
 	0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xfc, 0x5c, 0x12,
-	0x1c, 0x0a, 0x17, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xfd, 0x5c, 0x12, 0x1d, 0x0a,
-	0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xfe, 0x5c, 0x12, 0x1d, 0x0a, 0x18,
-	0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xff, 0x5c, 0x12, 0x21, 0x0a, 0x1c, 0x4b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0x80, 0x5d, 0x12, 0x1f,
-	0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0xac, 0x66, 0x12,
-	0x1e, 0x0a, 0x19, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0xad, 0x66, 0x12,
-	0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0xae, 0x66,
-	0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x10, 0xaf,
-	0x66, 0x12, 0x23, 0x0a, 0x1e, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72,
-	0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x10, 0xb0, 0x66, 0x12, 0x22, 0x0a, 0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x4e, 0x6f,
-	0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xb1, 0x66, 0x12, 0x1f, 0x0a, 0x1a, 0x4b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xb2, 0x66, 0x12, 0x19, 0x0a, 0x14, 0x4b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x4c, 0x6f, 0x67, 0x10, 0x90, 0x67, 0x12, 0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x6f, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x10, 0x91, 0x67, 0x12, 0x21, 0x0a, 0x1c, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73,
-	0x68, 0x45, 0x72, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c,
-	0x69, 0x64, 0x61, 0x74, 0x65, 0x10, 0xc4, 0x5e, 0x12, 0x1d, 0x0a, 0x18, 0x44, 0x61, 0x74, 0x61,
-	0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x46, 0x6f, 0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65,
-	0x63, 0x74, 0x65, 0x64, 0x10, 0xc5, 0x5e, 0x12, 0x20, 0x0a, 0x1b, 0x44, 0x61, 0x74, 0x61, 0x4d,
-	0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x10, 0xa8, 0x5f, 0x12, 0x1f, 0x0a, 0x1a, 0x44, 0x61, 0x74,
-	0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x10, 0xa9, 0x5f, 0x12, 0x2b, 0x0a, 0x26, 0x44, 0x61,
-	0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x72, 0x6f, 0x6d, 0x4b, 0x75, 0x62, 0x65, 0x46, 0x61,
-	0x69, 0x6c, 0x65, 0x64, 0x10, 0xaa, 0x5f, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d,
-	0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xab, 0x5f, 0x12, 0x25,
-	0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x50, 0x61, 0x74,
-	0x63, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c,
-	0x65, 0x64, 0x10, 0xac, 0x5f, 0x12, 0x26, 0x0a, 0x21, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x6f, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x10, 0x91, 0x67, 0x12,
+	0x1d, 0x0a, 0x18, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x10, 0xf4, 0x67, 0x12, 0x1b,
+	0x0a, 0x16, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x10, 0xf5, 0x67, 0x12, 0x20, 0x0a, 0x1b, 0x4b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x10, 0xd8, 0x68, 0x12, 0x22, 0x0a,
+	0x1d, 0x4b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x41, 0x50, 0x49, 0x45, 0x72, 0x72, 0x52, 0x6f, 0x74,
+	0x61, 0x74, 0x65, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x10, 0xd9,
+	0x68, 0x12, 0x21, 0x0a, 0x1c, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x10, 0xc4, 0x5e, 0x12, 0x1d, 0x0a, 0x18, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68,
+	0x45, 0x72, 0x72, 0x46, 0x6f, 0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x10, 0xc5, 0x5e, 0x12, 0x20, 0x0a, 0x1b, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45,
+	0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x10, 0xa8, 0x5f, 0x12, 0x1f, 0x0a, 0x1a, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73,
+	0x68, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x10, 0xa9, 0x5f, 0x12, 0x2b, 0x0a, 0x26, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65,
+	0x73, 0x68, 0x45, 0x72, 0x72, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x46, 0x72, 0x6f, 0x6d, 0x4b, 0x75, 0x62, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x10, 0xaa, 0x5f, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45,
+	0x72, 0x72, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
+	0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xab, 0x5f, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61,
+	0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x50, 0x61, 0x74, 0x63, 0x68, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xac,
+	0x5f, 0x12, 0x26, 0x0a, 0x21, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xad, 0x5f, 0x12, 0x26, 0x0a, 0x21, 0x44, 0x61, 0x74,
+	0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x10, 0x8c,
+	0x60, 0x12, 0x2b, 0x0a, 0x26, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72,
+	0x50, 0x61, 0x72, 0x73, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x8d, 0x60, 0x12, 0x25,
+	0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x10, 0x8e, 0x60, 0x12, 0x2c, 0x0a, 0x27, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73,
 	0x68, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x44, 0x61, 0x74, 0x61, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0xad, 0x5f, 0x12, 0x26, 0x0a,
-	0x21, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x10, 0x8c, 0x60, 0x12, 0x2b, 0x0a, 0x26, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73,
-	0x68, 0x45, 0x72, 0x72, 0x50, 0x61, 0x72, 0x73, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10,
-	0x8d, 0x60, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72,
-	0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x10, 0x8e, 0x60, 0x12, 0x2c, 0x0a, 0x27, 0x44, 0x61, 0x74,
-	0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f,
+	0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x10, 0x8f, 0x60, 0x12, 0x26, 0x0a, 0x21, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45,
+	0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x90, 0x60, 0x12, 0x29, 0x0a, 0x24, 0x44,
+	0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x10, 0x91, 0x60, 0x12, 0x31, 0x0a, 0x2c, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65,
+	0x73, 0x68, 0x45, 0x72, 0x72, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x4b, 0x75, 0x62, 0x65,
+	0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x92, 0x60, 0x12, 0x30, 0x0a, 0x2b, 0x44, 0x61, 0x74,
+	0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x93, 0x60, 0x12, 0x30, 0x0a, 0x2b, 0x44,
+	0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x94, 0x60, 0x12, 0x2b, 0x0a,
+	0x26, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x95, 0x60, 0x12, 0x2b, 0x0a, 0x26, 0x44, 0x61,
+	0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x50, 0x61, 0x74, 0x63, 0x68, 0x44, 0x6f,
 	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x61,
-	0x69, 0x6c, 0x65, 0x64, 0x10, 0x8f, 0x60, 0x12, 0x26, 0x0a, 0x21, 0x44, 0x61, 0x74, 0x61, 0x4d,
-	0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x90, 0x60, 0x12,
-	0x29, 0x0a, 0x24, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f,
-	0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0x91, 0x60, 0x12, 0x31, 0x0a, 0x2c, 0x44, 0x61,
-	0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x72, 0x6f, 0x6d,
-	0x4b, 0x75, 0x62, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x92, 0x60, 0x12, 0x30, 0x0a,
-	0x2b, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x65, 0x63, 0x6f,
-	0x64, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x93, 0x60, 0x12,
-	0x30, 0x0a, 0x2b, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x45, 0x6e,
-	0x63, 0x6f, 0x64, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x94,
-	0x60, 0x12, 0x2b, 0x0a, 0x26, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72,
-	0x4d, 0x65, 0x72, 0x67, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x95, 0x60, 0x12, 0x2b,
-	0x0a, 0x26, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x50, 0x61, 0x74,
-	0x63, 0x68, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x96, 0x60, 0x12, 0x25, 0x0a, 0x20, 0x44,
-	0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x69, 0x6c, 0x65, 0x64, 0x10, 0x96, 0x60, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d,
+	0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x10, 0xf0, 0x60, 0x12, 0x25,
+	0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47, 0x72, 0x61,
+	0x6e, 0x74, 0x10, 0xf1, 0x60, 0x12, 0x24, 0x0a, 0x1f, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73,
+	0x68, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x10, 0xf2, 0x60, 0x12, 0x25, 0x0a, 0x20, 0x44,
+	0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
 	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x10,
-	0xf0, 0x60, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72,
-	0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x10, 0xf1, 0x60, 0x12, 0x24, 0x0a, 0x1f, 0x44, 0x61, 0x74,
+	0xf3, 0x60, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72,
+	0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47, 0x72, 0x61, 0x6e, 0x74,
+	0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xf4, 0x60, 0x12, 0x28, 0x0a, 0x23, 0x44, 0x61, 0x74,
+	0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x10, 0xf5, 0x60, 0x12, 0x24, 0x0a, 0x1f, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45,
+	0x72, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x10, 0xd4, 0x61, 0x12, 0x23, 0x0a, 0x1e, 0x44, 0x61, 0x74,
 	0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x10, 0xf2, 0x60, 0x12,
-	0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47, 0x72,
-	0x61, 0x6e, 0x74, 0x10, 0xf3, 0x60, 0x12, 0x25, 0x0a, 0x20, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65,
-	0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47,
-	0x72, 0x61, 0x6e, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xf4, 0x60, 0x12, 0x28, 0x0a,
-	0x23, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x68, 0x45, 0x72, 0x72, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x4e, 0x6f, 0x74, 0x45, 0x78,
-	0x69, 0x73, 0x74, 0x73, 0x10, 0xf5, 0x60, 0x12, 0x24, 0x0a, 0x1f, 0x43, 0x6f, 0x6e, 0x66, 0x4d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x10, 0xd0, 0x0f, 0x12, 0x20, 0x0a,
-	0x1b, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x46,
-	0x6f, 0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0xd1, 0x0f, 0x12,
-	0x1f, 0x0a, 0x1a, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72,
-	0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xb6, 0x10,
-	0x12, 0x1e, 0x0a, 0x19, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45,
-	0x72, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xb7, 0x10,
-	0x12, 0x1f, 0x0a, 0x1a, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45,
-	0x72, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xb8,
-	0x10, 0x12, 0x1f, 0x0a, 0x1a, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
-	0x45, 0x72, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10,
-	0xb9, 0x10, 0x12, 0x23, 0x0a, 0x1e, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x45, 0x72, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x10, 0xba, 0x10, 0x12, 0x23, 0x0a, 0x1e, 0x43, 0x6f, 0x6e, 0x66, 0x4d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x4b, 0x65, 0x79, 0x43, 0x65, 0x72, 0x74, 0x73, 0x10, 0x98, 0x11, 0x12, 0x21, 0x0a, 0x1c,
-	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x45, 0x72, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x10, 0xb8, 0x17, 0x12,
-	0x1e, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x45, 0x72, 0x72, 0x46, 0x6f,
-	0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x10, 0xb9, 0x17, 0x42,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x70, 0x79, 0x10, 0xd5, 0x61, 0x12, 0x24,
+	0x0a, 0x1f, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x10, 0xd0, 0x0f, 0x12, 0x20, 0x0a, 0x1b, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x46, 0x6f, 0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x10, 0xd1, 0x0f, 0x12, 0x23, 0x0a, 0x1e, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x10, 0xd2, 0x0f, 0x12, 0x1f, 0x0a, 0x1a, 0x43,
+	0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xb6, 0x10, 0x12, 0x1e, 0x0a, 0x19,
+	0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xb7, 0x10, 0x12, 0x1f, 0x0a, 0x1a,
+	0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xb8, 0x10, 0x12, 0x1f, 0x0a,
+	0x1a, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xb9, 0x10, 0x12, 0x23,
+	0x0a, 0x1e, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x10, 0xba, 0x10, 0x12, 0x1e, 0x0a, 0x19, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x45, 0x72, 0x72, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x10, 0xbb, 0x10, 0x12, 0x25, 0x0a, 0x20, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x45, 0x72, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x10, 0xbc, 0x10, 0x12, 0x21, 0x0a, 0x1c, 0x43, 0x6f,
+	0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x52, 0x6f, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x10, 0xbd, 0x10, 0x12, 0x23, 0x0a,
+	0x1e, 0x43, 0x6f, 0x6e, 0x66, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x45, 0x72, 0x72, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x43, 0x65, 0x72, 0x74, 0x73, 0x10,
+	0x98, 0x11, 0x12, 0x21, 0x0a, 0x1c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x45, 0x72,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x10, 0xb8, 0x17, 0x12, 0x1e, 0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x72, 0x45, 0x72, 0x72, 0x46, 0x6f, 0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x74, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x10, 0xb9, 0x17, 0x12, 0x1e, 0x0a, 0x19, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x6e, 0x45, 0x72, 0x72, 0x46, 0x6f, 0x72, 0x55, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x10, 0xb0, 0x6d, 0x12, 0x21, 0x0a, 0x1c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x6e, 0x45, 0x72, 0x72, 0x50, 0x61, 0x72, 0x74, 0x79, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63,
+	0x68, 0x61, 0x62, 0x6c, 0x65, 0x10, 0xb1, 0x6d, 0x12, 0x1d, 0x0a, 0x18, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x50, 0x61, 0x72, 0x74, 0x79, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x10, 0xb2, 0x6d, 0x12, 0x1b, 0x0a, 0x16, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x41, 0x75, 0x74, 0x68, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x10, 0xb3, 0x6d, 0x12, 0x22, 0x0a, 0x1d, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x6e, 0x45, 0x72, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x10, 0xb4, 0x6d, 0x12, 0x22, 0x0a, 0x1d, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xb5, 0x6d, 0x12, 0x1f, 0x0a, 0x1a,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x10, 0xb6, 0x6d, 0x12, 0x1e, 0x0a,
+	0x19, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x50, 0x61, 0x72,
+	0x74, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x10, 0xb7, 0x6d, 0x12, 0x20, 0x0a,
+	0x1b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x45, 0x72, 0x72, 0x50, 0x61, 0x72,
+	0x74, 0x79, 0x4e, 0x6f, 0x74, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x10, 0xb8, 0x6d, 0x42,
 	0x5e, 0x0a, 0x21, 0x6f, 0x72, 0x67, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f,
 	0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x65, 0x72, 0x72, 0x6f, 0x72,
 	0x63, 0x6f, 0x64, 0x65, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
@@ -679,8 +926,10 @@ func file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDescGZIP() []b
 }
 
 var file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_goTypes = []interface{}{
-	(ErrorCode)(0), // 0: kuscia.proto.api.v1alpha1.ErrorCode
+	(ErrorCode)(0),      // 0: kuscia.proto.api.v1alpha1.ErrorCode
+	(*ErrorDetail)(nil), // 1: kuscia.proto.api.v1alpha1.ErrorDetail
 }
 var file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -695,19 +944,34 @@ func file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_init() {
 	if File_kuscia_proto_api_v1alpha1_errorcode_error_code_proto != nil {
 		return
 	}
+	if !protoimpl.UnsafeEnabled {
+		file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   0,
+			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_goTypes,
 		DependencyIndexes: file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_depIdxs,
 		EnumInfos:         file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_enumTypes,
+		MessageInfos:      file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_msgTypes,
 	}.Build()
 	File_kuscia_proto_api_v1alpha1_errorcode_error_code_proto = out.File
 	file_kuscia_proto_api_v1alpha1_errorcode_error_code_proto_rawDesc = nil
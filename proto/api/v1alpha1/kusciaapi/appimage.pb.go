@@ -45,6 +45,11 @@ type CreateAppImageRequest struct {
 	Image           *AppImageInfo           `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
 	ConfigTemplates map[string]string       `protobuf:"bytes,4,rep,name=config_templates,json=configTemplates,proto3" json:"config_templates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	DeployTemplates []*DeployTemplate       `protobuf:"bytes,5,rep,name=deploy_templates,json=deployTemplates,proto3" json:"deploy_templates,omitempty"`
+	// the app version of image
+	Version  string             `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	Channels []*AppImageChannel `protobuf:"bytes,7,rep,name=channels,proto3" json:"channels,omitempty"`
+	// the JSON Schema of task input config of image, task input config of jobs is validated against it
+	TaskConfigSchema string `protobuf:"bytes,8,opt,name=task_config_schema,json=taskConfigSchema,proto3" json:"task_config_schema,omitempty"`
 }
 
 func (x *CreateAppImageRequest) Reset() {
@@ -114,6 +119,27 @@ func (x *CreateAppImageRequest) GetDeployTemplates() []*DeployTemplate {
 	return nil
 }
 
+func (x *CreateAppImageRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CreateAppImageRequest) GetChannels() []*AppImageChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *CreateAppImageRequest) GetTaskConfigSchema() string {
+	if x != nil {
+		return x.TaskConfigSchema
+	}
+	return ""
+}
+
 type AppImageInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -185,6 +211,79 @@ func (x *AppImageInfo) GetSign() string {
 	return ""
 }
 
+// AppImageChannel is a release channel of app image, e.g. stable or beta. Tasks pinning a channel use the image of it.
+type AppImageChannel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string        `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Image   *AppImageInfo `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	// the JSON Schema of task input config of the image of channel, task_config_schema of app image is used if it's empty
+	TaskConfigSchema string `protobuf:"bytes,4,opt,name=task_config_schema,json=taskConfigSchema,proto3" json:"task_config_schema,omitempty"`
+}
+
+func (x *AppImageChannel) Reset() {
+	*x = AppImageChannel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppImageChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppImageChannel) ProtoMessage() {}
+
+func (x *AppImageChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppImageChannel.ProtoReflect.Descriptor instead.
+func (*AppImageChannel) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AppImageChannel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AppImageChannel) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AppImageChannel) GetImage() *AppImageInfo {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *AppImageChannel) GetTaskConfigSchema() string {
+	if x != nil {
+		return x.TaskConfigSchema
+	}
+	return ""
+}
+
 type DeployTemplate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -201,7 +300,7 @@ type DeployTemplate struct {
 func (x *DeployTemplate) Reset() {
 	*x = DeployTemplate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[2]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -214,7 +313,7 @@ func (x *DeployTemplate) String() string {
 func (*DeployTemplate) ProtoMessage() {}
 
 func (x *DeployTemplate) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[2]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,7 +326,7 @@ func (x *DeployTemplate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeployTemplate.ProtoReflect.Descriptor instead.
 func (*DeployTemplate) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{2}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DeployTemplate) GetName() string {
@@ -276,7 +375,7 @@ type NetworkPolicy struct {
 func (x *NetworkPolicy) Reset() {
 	*x = NetworkPolicy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[3]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -289,7 +388,7 @@ func (x *NetworkPolicy) String() string {
 func (*NetworkPolicy) ProtoMessage() {}
 
 func (x *NetworkPolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[3]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,7 +401,7 @@ func (x *NetworkPolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetworkPolicy.ProtoReflect.Descriptor instead.
 func (*NetworkPolicy) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{3}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *NetworkPolicy) GetIngresses() []*Ingress {
@@ -324,7 +423,7 @@ type Ingress struct {
 func (x *Ingress) Reset() {
 	*x = Ingress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[4]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -337,7 +436,7 @@ func (x *Ingress) String() string {
 func (*Ingress) ProtoMessage() {}
 
 func (x *Ingress) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[4]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +449,7 @@ func (x *Ingress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Ingress.ProtoReflect.Descriptor instead.
 func (*Ingress) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{4}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Ingress) GetFrom() *IngressFrom {
@@ -378,7 +477,7 @@ type IngressFrom struct {
 func (x *IngressFrom) Reset() {
 	*x = IngressFrom{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[5]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -391,7 +490,7 @@ func (x *IngressFrom) String() string {
 func (*IngressFrom) ProtoMessage() {}
 
 func (x *IngressFrom) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[5]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +503,7 @@ func (x *IngressFrom) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IngressFrom.ProtoReflect.Descriptor instead.
 func (*IngressFrom) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{5}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *IngressFrom) GetRoles() []string {
@@ -425,7 +524,7 @@ type IngressPort struct {
 func (x *IngressPort) Reset() {
 	*x = IngressPort{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[6]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -438,7 +537,7 @@ func (x *IngressPort) String() string {
 func (*IngressPort) ProtoMessage() {}
 
 func (x *IngressPort) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[6]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +550,7 @@ func (x *IngressPort) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IngressPort.ProtoReflect.Descriptor instead.
 func (*IngressPort) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{6}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *IngressPort) GetPort() string {
@@ -484,7 +583,7 @@ type Container struct {
 func (x *Container) Reset() {
 	*x = Container{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[7]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -497,7 +596,7 @@ func (x *Container) String() string {
 func (*Container) ProtoMessage() {}
 
 func (x *Container) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[7]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,7 +609,7 @@ func (x *Container) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Container.ProtoReflect.Descriptor instead.
 func (*Container) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{7}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Container) GetName() string {
@@ -616,7 +715,7 @@ type ConfigVolumeMount struct {
 func (x *ConfigVolumeMount) Reset() {
 	*x = ConfigVolumeMount{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[8]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -629,7 +728,7 @@ func (x *ConfigVolumeMount) String() string {
 func (*ConfigVolumeMount) ProtoMessage() {}
 
 func (x *ConfigVolumeMount) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[8]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,7 +741,7 @@ func (x *ConfigVolumeMount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigVolumeMount.ProtoReflect.Descriptor instead.
 func (*ConfigVolumeMount) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{8}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ConfigVolumeMount) GetMountPath() string {
@@ -672,7 +771,7 @@ type ContainerPort struct {
 func (x *ContainerPort) Reset() {
 	*x = ContainerPort{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[9]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -685,7 +784,7 @@ func (x *ContainerPort) String() string {
 func (*ContainerPort) ProtoMessage() {}
 
 func (x *ContainerPort) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[9]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,7 +797,7 @@ func (x *ContainerPort) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContainerPort.ProtoReflect.Descriptor instead.
 func (*ContainerPort) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{9}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ContainerPort) GetName() string {
@@ -734,7 +833,7 @@ type ResourceRequirements struct {
 func (x *ResourceRequirements) Reset() {
 	*x = ResourceRequirements{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[10]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -747,7 +846,7 @@ func (x *ResourceRequirements) String() string {
 func (*ResourceRequirements) ProtoMessage() {}
 
 func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[10]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -760,7 +859,7 @@ func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceRequirements.ProtoReflect.Descriptor instead.
 func (*ResourceRequirements) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{10}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResourceRequirements) GetLimits() *ResourceSpec {
@@ -789,7 +888,7 @@ type ResourceSpec struct {
 func (x *ResourceSpec) Reset() {
 	*x = ResourceSpec{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[11]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -802,7 +901,7 @@ func (x *ResourceSpec) String() string {
 func (*ResourceSpec) ProtoMessage() {}
 
 func (x *ResourceSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[11]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,7 +914,7 @@ func (x *ResourceSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceSpec.ProtoReflect.Descriptor instead.
 func (*ResourceSpec) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{11}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ResourceSpec) GetCpu() string {
@@ -843,7 +942,7 @@ type CreateAppImageResponse struct {
 func (x *CreateAppImageResponse) Reset() {
 	*x = CreateAppImageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[12]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -856,7 +955,7 @@ func (x *CreateAppImageResponse) String() string {
 func (*CreateAppImageResponse) ProtoMessage() {}
 
 func (x *CreateAppImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[12]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -869,7 +968,7 @@ func (x *CreateAppImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAppImageResponse.ProtoReflect.Descriptor instead.
 func (*CreateAppImageResponse) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{12}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CreateAppImageResponse) GetStatus() *v1alpha1.Status {
@@ -891,7 +990,7 @@ type DeleteAppImageRequest struct {
 func (x *DeleteAppImageRequest) Reset() {
 	*x = DeleteAppImageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[13]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -904,7 +1003,7 @@ func (x *DeleteAppImageRequest) String() string {
 func (*DeleteAppImageRequest) ProtoMessage() {}
 
 func (x *DeleteAppImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[13]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -917,7 +1016,7 @@ func (x *DeleteAppImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAppImageRequest.ProtoReflect.Descriptor instead.
 func (*DeleteAppImageRequest) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{13}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteAppImageRequest) GetHeader() *v1alpha1.RequestHeader {
@@ -945,7 +1044,7 @@ type DeleteAppImageResponse struct {
 func (x *DeleteAppImageResponse) Reset() {
 	*x = DeleteAppImageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[14]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -958,7 +1057,7 @@ func (x *DeleteAppImageResponse) String() string {
 func (*DeleteAppImageResponse) ProtoMessage() {}
 
 func (x *DeleteAppImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[14]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -971,7 +1070,7 @@ func (x *DeleteAppImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAppImageResponse.ProtoReflect.Descriptor instead.
 func (*DeleteAppImageResponse) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{14}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeleteAppImageResponse) GetStatus() *v1alpha1.Status {
@@ -993,7 +1092,7 @@ type QueryAppImageRequest struct {
 func (x *QueryAppImageRequest) Reset() {
 	*x = QueryAppImageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[15]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1006,7 +1105,7 @@ func (x *QueryAppImageRequest) String() string {
 func (*QueryAppImageRequest) ProtoMessage() {}
 
 func (x *QueryAppImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[15]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1019,7 +1118,7 @@ func (x *QueryAppImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryAppImageRequest.ProtoReflect.Descriptor instead.
 func (*QueryAppImageRequest) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{15}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *QueryAppImageRequest) GetHeader() *v1alpha1.RequestHeader {
@@ -1048,7 +1147,7 @@ type BatchQueryAppImageRequest struct {
 func (x *BatchQueryAppImageRequest) Reset() {
 	*x = BatchQueryAppImageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[16]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1061,7 +1160,7 @@ func (x *BatchQueryAppImageRequest) String() string {
 func (*BatchQueryAppImageRequest) ProtoMessage() {}
 
 func (x *BatchQueryAppImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[16]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1074,7 +1173,7 @@ func (x *BatchQueryAppImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchQueryAppImageRequest.ProtoReflect.Descriptor instead.
 func (*BatchQueryAppImageRequest) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{16}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BatchQueryAppImageRequest) GetHeader() *v1alpha1.RequestHeader {
@@ -1103,7 +1202,7 @@ type QueryAppImageResponse struct {
 func (x *QueryAppImageResponse) Reset() {
 	*x = QueryAppImageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[17]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1116,7 +1215,7 @@ func (x *QueryAppImageResponse) String() string {
 func (*QueryAppImageResponse) ProtoMessage() {}
 
 func (x *QueryAppImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[17]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1129,7 +1228,7 @@ func (x *QueryAppImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryAppImageResponse.ProtoReflect.Descriptor instead.
 func (*QueryAppImageResponse) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{17}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *QueryAppImageResponse) GetStatus() *v1alpha1.Status {
@@ -1158,7 +1257,7 @@ type BatchQueryAppImageResponse struct {
 func (x *BatchQueryAppImageResponse) Reset() {
 	*x = BatchQueryAppImageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[18]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1171,7 +1270,7 @@ func (x *BatchQueryAppImageResponse) String() string {
 func (*BatchQueryAppImageResponse) ProtoMessage() {}
 
 func (x *BatchQueryAppImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[18]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,7 +1283,7 @@ func (x *BatchQueryAppImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchQueryAppImageResponse.ProtoReflect.Descriptor instead.
 func (*BatchQueryAppImageResponse) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{18}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *BatchQueryAppImageResponse) GetStatus() *v1alpha1.Status {
@@ -1206,16 +1305,19 @@ type QueryAppImageResponseData struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name            string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Image           *AppImageInfo     `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	ConfigTemplates map[string]string `protobuf:"bytes,3,rep,name=config_templates,json=configTemplates,proto3" json:"config_templates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	DeployTemplates []*DeployTemplate `protobuf:"bytes,4,rep,name=deploy_templates,json=deployTemplates,proto3" json:"deploy_templates,omitempty"`
+	Name             string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Image            *AppImageInfo      `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	ConfigTemplates  map[string]string  `protobuf:"bytes,3,rep,name=config_templates,json=configTemplates,proto3" json:"config_templates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DeployTemplates  []*DeployTemplate  `protobuf:"bytes,4,rep,name=deploy_templates,json=deployTemplates,proto3" json:"deploy_templates,omitempty"`
+	Version          string             `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Channels         []*AppImageChannel `protobuf:"bytes,6,rep,name=channels,proto3" json:"channels,omitempty"`
+	TaskConfigSchema string             `protobuf:"bytes,7,opt,name=task_config_schema,json=taskConfigSchema,proto3" json:"task_config_schema,omitempty"`
 }
 
 func (x *QueryAppImageResponseData) Reset() {
 	*x = QueryAppImageResponseData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[19]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1228,7 +1330,7 @@ func (x *QueryAppImageResponseData) String() string {
 func (*QueryAppImageResponseData) ProtoMessage() {}
 
 func (x *QueryAppImageResponseData) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[19]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1241,7 +1343,7 @@ func (x *QueryAppImageResponseData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryAppImageResponseData.ProtoReflect.Descriptor instead.
 func (*QueryAppImageResponseData) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{19}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *QueryAppImageResponseData) GetName() string {
@@ -1272,6 +1374,27 @@ func (x *QueryAppImageResponseData) GetDeployTemplates() []*DeployTemplate {
 	return nil
 }
 
+func (x *QueryAppImageResponseData) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *QueryAppImageResponseData) GetChannels() []*AppImageChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *QueryAppImageResponseData) GetTaskConfigSchema() string {
+	if x != nil {
+		return x.TaskConfigSchema
+	}
+	return ""
+}
+
 type UpdateAppImageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1282,12 +1405,16 @@ type UpdateAppImageRequest struct {
 	Image           *AppImageInfo           `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
 	ConfigTemplates map[string]string       `protobuf:"bytes,4,rep,name=config_templates,json=configTemplates,proto3" json:"config_templates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	DeployTemplates []*DeployTemplate       `protobuf:"bytes,5,rep,name=deploy_templates,json=deployTemplates,proto3" json:"deploy_templates,omitempty"`
+	// version, channels and task_config_schema are kept unchanged if they are empty
+	Version          string             `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	Channels         []*AppImageChannel `protobuf:"bytes,7,rep,name=channels,proto3" json:"channels,omitempty"`
+	TaskConfigSchema string             `protobuf:"bytes,8,opt,name=task_config_schema,json=taskConfigSchema,proto3" json:"task_config_schema,omitempty"`
 }
 
 func (x *UpdateAppImageRequest) Reset() {
 	*x = UpdateAppImageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[20]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1300,7 +1427,7 @@ func (x *UpdateAppImageRequest) String() string {
 func (*UpdateAppImageRequest) ProtoMessage() {}
 
 func (x *UpdateAppImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[20]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,7 +1440,7 @@ func (x *UpdateAppImageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAppImageRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAppImageRequest) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{20}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *UpdateAppImageRequest) GetHeader() *v1alpha1.RequestHeader {
@@ -1351,6 +1478,27 @@ func (x *UpdateAppImageRequest) GetDeployTemplates() []*DeployTemplate {
 	return nil
 }
 
+func (x *UpdateAppImageRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpdateAppImageRequest) GetChannels() []*AppImageChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *UpdateAppImageRequest) GetTaskConfigSchema() string {
+	if x != nil {
+		return x.TaskConfigSchema
+	}
+	return ""
+}
+
 type UpdateAppImageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1362,7 +1510,7 @@ type UpdateAppImageResponse struct {
 func (x *UpdateAppImageResponse) Reset() {
 	*x = UpdateAppImageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[21]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1375,7 +1523,7 @@ func (x *UpdateAppImageResponse) String() string {
 func (*UpdateAppImageResponse) ProtoMessage() {}
 
 func (x *UpdateAppImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[21]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1388,7 +1536,7 @@ func (x *UpdateAppImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAppImageResponse.ProtoReflect.Descriptor instead.
 func (*UpdateAppImageResponse) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{21}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UpdateAppImageResponse) GetStatus() *v1alpha1.Status {
@@ -1398,41 +1546,44 @@ func (x *UpdateAppImageResponse) GetStatus() *v1alpha1.Status {
 	return nil
 }
 
-// modified from k8s
-// EnvFromSource represents the source of a set of ConfigMaps
-type EnvFromSource struct {
+// ValidateAppImageRequest renders the pod of a hypothetical task party with the app image, nothing is created.
+// The app image is the existing one of name, or the inline one given by image and templates if name is empty.
+type ValidateAppImageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// An optional identifier to prepend to each key in the ConfigMap. Must be a C_IDENTIFIER.
-	// +optional
-	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	// The ConfigMap to select from
-	// +optional
-	ConfigMapRef *ConfigMapEnvSource `protobuf:"bytes,2,opt,name=config_map_ref,json=configMapRef,proto3" json:"config_map_ref,omitempty"`
-	// The Secret to select from
-	// +optional
-	SecretRef *SecretEnvSource `protobuf:"bytes,3,opt,name=secret_ref,json=secretRef,proto3" json:"secret_ref,omitempty"`
+	Header          *v1alpha1.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Name            string                  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Image           *AppImageInfo           `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	ConfigTemplates map[string]string       `protobuf:"bytes,4,rep,name=config_templates,json=configTemplates,proto3" json:"config_templates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DeployTemplates []*DeployTemplate       `protobuf:"bytes,5,rep,name=deploy_templates,json=deployTemplates,proto3" json:"deploy_templates,omitempty"`
+	// role of the party, used to select the deploy template
+	Role            string `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	AppImageChannel string `protobuf:"bytes,7,opt,name=app_image_channel,json=appImageChannel,proto3" json:"app_image_channel,omitempty"`
+	DomainId        string `protobuf:"bytes,8,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	// task_input_config is validated against task_config_schema, or the schema of the existing app image if name is set
+	TaskInputConfig  string `protobuf:"bytes,9,opt,name=task_input_config,json=taskInputConfig,proto3" json:"task_input_config,omitempty"`
+	TaskConfigSchema string `protobuf:"bytes,10,opt,name=task_config_schema,json=taskConfigSchema,proto3" json:"task_config_schema,omitempty"`
 }
 
-func (x *EnvFromSource) Reset() {
-	*x = EnvFromSource{}
+func (x *ValidateAppImageRequest) Reset() {
+	*x = ValidateAppImageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[22]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EnvFromSource) String() string {
+func (x *ValidateAppImageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnvFromSource) ProtoMessage() {}
+func (*ValidateAppImageRequest) ProtoMessage() {}
 
-func (x *EnvFromSource) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[22]
+func (x *ValidateAppImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1443,21 +1594,295 @@ func (x *EnvFromSource) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnvFromSource.ProtoReflect.Descriptor instead.
-func (*EnvFromSource) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ValidateAppImageRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAppImageRequest) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *EnvFromSource) GetPrefix() string {
+func (x *ValidateAppImageRequest) GetHeader() *v1alpha1.RequestHeader {
 	if x != nil {
-		return x.Prefix
+		return x.Header
 	}
-	return ""
+	return nil
 }
 
-func (x *EnvFromSource) GetConfigMapRef() *ConfigMapEnvSource {
+func (x *ValidateAppImageRequest) GetName() string {
 	if x != nil {
-		return x.ConfigMapRef
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ValidateAppImageRequest) GetImage() *AppImageInfo {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *ValidateAppImageRequest) GetConfigTemplates() map[string]string {
+	if x != nil {
+		return x.ConfigTemplates
+	}
+	return nil
+}
+
+func (x *ValidateAppImageRequest) GetDeployTemplates() []*DeployTemplate {
+	if x != nil {
+		return x.DeployTemplates
+	}
+	return nil
+}
+
+func (x *ValidateAppImageRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ValidateAppImageRequest) GetAppImageChannel() string {
+	if x != nil {
+		return x.AppImageChannel
+	}
+	return ""
+}
+
+func (x *ValidateAppImageRequest) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+func (x *ValidateAppImageRequest) GetTaskInputConfig() string {
+	if x != nil {
+		return x.TaskInputConfig
+	}
+	return ""
+}
+
+func (x *ValidateAppImageRequest) GetTaskConfigSchema() string {
+	if x != nil {
+		return x.TaskConfigSchema
+	}
+	return ""
+}
+
+type ValidateAppImageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *v1alpha1.Status              `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *ValidateAppImageResponseData `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ValidateAppImageResponse) Reset() {
+	*x = ValidateAppImageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateAppImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAppImageResponse) ProtoMessage() {}
+
+func (x *ValidateAppImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAppImageResponse.ProtoReflect.Descriptor instead.
+func (*ValidateAppImageResponse) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ValidateAppImageResponse) GetStatus() *v1alpha1.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ValidateAppImageResponse) GetData() *ValidateAppImageResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ValidateAppImageResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// valid is false if any error is found
+	Valid    bool     `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors   []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	Warnings []string `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// name of the deploy template selected by role
+	DeployTemplate string `protobuf:"bytes,4,opt,name=deploy_template,json=deployTemplate,proto3" json:"deploy_template,omitempty"`
+	Image          string `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	// the rendered pod spec in json
+	PodSpec string `protobuf:"bytes,6,opt,name=pod_spec,json=podSpec,proto3" json:"pod_spec,omitempty"`
+	// the rendered config templates, keyed by name of config template
+	ConfigFiles map[string]string `protobuf:"bytes,7,rep,name=config_files,json=configFiles,proto3" json:"config_files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ValidateAppImageResponseData) Reset() {
+	*x = ValidateAppImageResponseData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateAppImageResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAppImageResponseData) ProtoMessage() {}
+
+func (x *ValidateAppImageResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAppImageResponseData.ProtoReflect.Descriptor instead.
+func (*ValidateAppImageResponseData) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ValidateAppImageResponseData) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateAppImageResponseData) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ValidateAppImageResponseData) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *ValidateAppImageResponseData) GetDeployTemplate() string {
+	if x != nil {
+		return x.DeployTemplate
+	}
+	return ""
+}
+
+func (x *ValidateAppImageResponseData) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ValidateAppImageResponseData) GetPodSpec() string {
+	if x != nil {
+		return x.PodSpec
+	}
+	return ""
+}
+
+func (x *ValidateAppImageResponseData) GetConfigFiles() map[string]string {
+	if x != nil {
+		return x.ConfigFiles
+	}
+	return nil
+}
+
+// modified from k8s
+// EnvFromSource represents the source of a set of ConfigMaps
+type EnvFromSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// An optional identifier to prepend to each key in the ConfigMap. Must be a C_IDENTIFIER.
+	// +optional
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// The ConfigMap to select from
+	// +optional
+	ConfigMapRef *ConfigMapEnvSource `protobuf:"bytes,2,opt,name=config_map_ref,json=configMapRef,proto3" json:"config_map_ref,omitempty"`
+	// The Secret to select from
+	// +optional
+	SecretRef *SecretEnvSource `protobuf:"bytes,3,opt,name=secret_ref,json=secretRef,proto3" json:"secret_ref,omitempty"`
+}
+
+func (x *EnvFromSource) Reset() {
+	*x = EnvFromSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnvFromSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvFromSource) ProtoMessage() {}
+
+func (x *EnvFromSource) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvFromSource.ProtoReflect.Descriptor instead.
+func (*EnvFromSource) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EnvFromSource) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *EnvFromSource) GetConfigMapRef() *ConfigMapEnvSource {
+	if x != nil {
+		return x.ConfigMapRef
 	}
 	return nil
 }
@@ -1482,7 +1907,7 @@ type ConfigMapEnvSource struct {
 func (x *ConfigMapEnvSource) Reset() {
 	*x = ConfigMapEnvSource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[23]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1495,7 +1920,7 @@ func (x *ConfigMapEnvSource) String() string {
 func (*ConfigMapEnvSource) ProtoMessage() {}
 
 func (x *ConfigMapEnvSource) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[23]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1508,7 +1933,7 @@ func (x *ConfigMapEnvSource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfigMapEnvSource.ProtoReflect.Descriptor instead.
 func (*ConfigMapEnvSource) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{23}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ConfigMapEnvSource) GetName() string {
@@ -1531,7 +1956,7 @@ type SecretEnvSource struct {
 func (x *SecretEnvSource) Reset() {
 	*x = SecretEnvSource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[24]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1544,7 +1969,7 @@ func (x *SecretEnvSource) String() string {
 func (*SecretEnvSource) ProtoMessage() {}
 
 func (x *SecretEnvSource) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[24]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1557,7 +1982,7 @@ func (x *SecretEnvSource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretEnvSource.ProtoReflect.Descriptor instead.
 func (*SecretEnvSource) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{24}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SecretEnvSource) GetName() string {
@@ -1590,7 +2015,7 @@ type EnvVar struct {
 func (x *EnvVar) Reset() {
 	*x = EnvVar{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[25]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1603,7 +2028,7 @@ func (x *EnvVar) String() string {
 func (*EnvVar) ProtoMessage() {}
 
 func (x *EnvVar) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[25]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1616,7 +2041,7 @@ func (x *EnvVar) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnvVar.ProtoReflect.Descriptor instead.
 func (*EnvVar) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{25}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *EnvVar) GetName() string {
@@ -1692,7 +2117,7 @@ type Probe struct {
 func (x *Probe) Reset() {
 	*x = Probe{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[26]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1705,7 +2130,7 @@ func (x *Probe) String() string {
 func (*Probe) ProtoMessage() {}
 
 func (x *Probe) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[26]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1718,7 +2143,7 @@ func (x *Probe) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Probe.ProtoReflect.Descriptor instead.
 func (*Probe) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{26}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Probe) GetExec() *ExecAction {
@@ -1809,7 +2234,7 @@ type ExecAction struct {
 func (x *ExecAction) Reset() {
 	*x = ExecAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[27]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1822,7 +2247,7 @@ func (x *ExecAction) String() string {
 func (*ExecAction) ProtoMessage() {}
 
 func (x *ExecAction) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[27]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1835,7 +2260,7 @@ func (x *ExecAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecAction.ProtoReflect.Descriptor instead.
 func (*ExecAction) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{27}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ExecAction) GetCommand() []string {
@@ -1875,7 +2300,7 @@ type HTTPGetAction struct {
 func (x *HTTPGetAction) Reset() {
 	*x = HTTPGetAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[28]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1888,7 +2313,7 @@ func (x *HTTPGetAction) String() string {
 func (*HTTPGetAction) ProtoMessage() {}
 
 func (x *HTTPGetAction) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[28]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1901,7 +2326,7 @@ func (x *HTTPGetAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPGetAction.ProtoReflect.Descriptor instead.
 func (*HTTPGetAction) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{28}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *HTTPGetAction) GetPath() string {
@@ -1955,7 +2380,7 @@ type HTTPHeader struct {
 func (x *HTTPHeader) Reset() {
 	*x = HTTPHeader{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[29]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1968,7 +2393,7 @@ func (x *HTTPHeader) String() string {
 func (*HTTPHeader) ProtoMessage() {}
 
 func (x *HTTPHeader) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[29]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1981,7 +2406,7 @@ func (x *HTTPHeader) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HTTPHeader.ProtoReflect.Descriptor instead.
 func (*HTTPHeader) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{29}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *HTTPHeader) GetName() string {
@@ -2017,7 +2442,7 @@ type TCPSocketAction struct {
 func (x *TCPSocketAction) Reset() {
 	*x = TCPSocketAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[30]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2030,7 +2455,7 @@ func (x *TCPSocketAction) String() string {
 func (*TCPSocketAction) ProtoMessage() {}
 
 func (x *TCPSocketAction) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[30]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2043,7 +2468,7 @@ func (x *TCPSocketAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TCPSocketAction.ProtoReflect.Descriptor instead.
 func (*TCPSocketAction) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{30}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *TCPSocketAction) GetPort() string {
@@ -2079,7 +2504,7 @@ type GRPCAction struct {
 func (x *GRPCAction) Reset() {
 	*x = GRPCAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[31]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2092,7 +2517,7 @@ func (x *GRPCAction) String() string {
 func (*GRPCAction) ProtoMessage() {}
 
 func (x *GRPCAction) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[31]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2105,7 +2530,7 @@ func (x *GRPCAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GRPCAction.ProtoReflect.Descriptor instead.
 func (*GRPCAction) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{31}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GRPCAction) GetPort() int32 {
@@ -2133,7 +2558,7 @@ var file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDesc = []byte{
 	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x1a, 0x26, 0x6b, 0x75, 0x73, 0x63, 0x69,
 	0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x61, 0x6c,
 	0x70, 0x68, 0x61, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xd6, 0x03, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49,
+	0x6f, 0x22, 0xf0, 0x04, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49,
 	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68,
 	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75,
 	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
@@ -2158,377 +2583,503 @@ var file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDesc = []byte{
 	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
 	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d,
 	0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x1a, 0x42, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x58, 0x0a, 0x0c, 0x41, 0x70,
-	0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x73, 0x69, 0x67, 0x6e, 0x22, 0xcb, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54,
-	0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72,
-	0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72,
-	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x12, 0x4e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x73, 0x22, 0x5b, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x6f, 0x6c,
-	0x69, 0x63, 0x79, 0x12, 0x4a, 0x0a, 0x09, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x67,
-	0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22,
-	0x97, 0x01, 0x0a, 0x07, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x44, 0x0a, 0x04, 0x66,
-	0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x50, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x74, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x1a, 0x42, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x58, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x67, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x22, 0xb6,
+	0x01, 0x0a, 0x0f, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x47, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61, 0x73,
+	0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0xcb, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x4e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6b, 0x75, 0x73, 0x63,
 	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
 	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
-	0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x04, 0x66, 0x72, 0x6f,
-	0x6d, 0x12, 0x46, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x30, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f,
-	0x72, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x23, 0x0a, 0x0b, 0x49, 0x6e, 0x67,
-	0x72, 0x65, 0x73, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x21,
-	0x0a, 0x0b, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72,
-	0x74, 0x22, 0xae, 0x06, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67,
-	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44,
-	0x69, 0x72, 0x12, 0x68, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x76, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x36, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x56, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x12, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x48, 0x0a, 0x05,
-	0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6b, 0x75,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x5b, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x4a, 0x0a, 0x09, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
+	0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x07, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x44,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61,
+	0x70, 0x69, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x46, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x23, 0x0a, 0x0b,
+	0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65,
+	0x73, 0x22, 0x21, 0x0a, 0x0b, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x22, 0xae, 0x06, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x61, 0x72, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f,
+	0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69,
+	0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x68, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f,
+	0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x12, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12,
+	0x48, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f,
+	0x72, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x4d, 0x0a, 0x08, 0x65, 0x6e, 0x76,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6b, 0x75,
 	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
 	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
-	0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x52,
-	0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x4d, 0x0a, 0x08, 0x65, 0x6e, 0x76, 0x5f, 0x66, 0x72,
-	0x6f, 0x6d, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
-	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x45,
-	0x6e, 0x76, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x65, 0x6e,
-	0x76, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x3d, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x52,
-	0x03, 0x65, 0x6e, 0x76, 0x12, 0x57, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x51, 0x0a,
-	0x0e, 0x6c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x69, 0x2e, 0x45, 0x6e, 0x76, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x07, 0x65, 0x6e, 0x76, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x3d, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x72, 0x6f, 0x62,
-	0x65, 0x52, 0x0d, 0x6c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x62, 0x65,
-	0x12, 0x53, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
-	0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73,
-	0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x4f, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70,
-	0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x6e, 0x76, 0x56,
+	0x61, 0x72, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x57, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x12, 0x51, 0x0a, 0x0e, 0x6c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x50,
+	0x72, 0x6f, 0x62, 0x65, 0x52, 0x0d, 0x6c, 0x69, 0x76, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x72,
+	0x6f, 0x62, 0x65, 0x12, 0x53, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73,
+	0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b,
 	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
 	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61,
-	0x70, 0x69, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x75,
-	0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f,
-	0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x22, 0x4d, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x56, 0x6f, 0x6c, 0x75,
-	0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x5f, 0x70, 0x61,
-	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x50, 0x61, 0x74,
-	0x68, 0x22, 0x55, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f,
-	0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x12, 0x49, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x70, 0x69, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e,
+	0x65, 0x73, 0x73, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x4f, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x75, 0x70, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x0c, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x5f, 0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x4d, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x75, 0x62,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62,
+	0x50, 0x61, 0x74, 0x68, 0x22, 0x55, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x14,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x49, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12,
+	0x4d, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
 	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x4d, 0x0a, 0x08,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31,
-	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
-	0x61, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x70, 0x65,
-	0x63, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x38, 0x0a, 0x0c, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x63,
-	0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x16, 0x0a,
-	0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
-	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x53, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41,
-	0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x6d, 0x0a, 0x15, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x53, 0x0a, 0x16, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x6c,
-	0x0a, 0x14, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x53, 0x70, 0x65, 0x63, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x38,
+	0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x10,
+	0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x70, 0x75,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x53, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x6d, 0x0a,
+	0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
 	0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
 	0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x73, 0x0a, 0x19,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x53, 0x0a, 0x16,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x6c, 0x0a, 0x14, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61,
 	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61,
 	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63,
 	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
 	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65,
-	0x73, 0x22, 0xa6, 0x01, 0x0a, 0x15, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d,
-	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x52, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xab, 0x01, 0x0a, 0x1a, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x52, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x73, 0x0a, 0x19, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x15, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70,
+	0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x52, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xab, 0x01,
+	0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x52, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xb6, 0x04, 0x0a, 0x19,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x47, 0x0a,
+	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61,
+	0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x7e, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x53, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x5e, 0x0a, 0x10, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x33, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x50, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70,
-	0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x9c, 0x03, 0x0a, 0x19, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x47, 0x0a, 0x05, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
-	0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x12, 0x7e, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x65,
-	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x53, 0x2e,
-	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x73, 0x12, 0x5e, 0x0a, 0x10, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x5f, 0x74, 0x65,
-	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e,
-	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x52, 0x0f, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x73, 0x1a, 0x42, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x74, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x1a, 0x42, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xf0, 0x04, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41,
+	0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40,
+	0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x47, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x7a, 0x0a,
+	0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x5e, 0x0a, 0x10, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x74, 0x61, 0x73, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x1a, 0x42, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d,
 	0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
 	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
 	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd6, 0x03, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x47, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x12, 0x7a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x4f, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x5e, 0x0a, 0x10,
-	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x64, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x1a, 0x42, 0x0a, 0x14,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x53, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x91, 0x05, 0x0a,
+	0x17, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x47,
+	0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x61, 0x70, 0x69, 0x2e, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x7c, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x51, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x5e, 0x0a, 0x10, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x5f,
+	0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x33, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x70, 0x70,
+	0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74,
+	0x61, 0x73, 0x6b, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2c,
+	0x0a, 0x12, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x61, 0x73, 0x6b,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x1a, 0x42, 0x0a, 0x14,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x45,
 	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x53, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xdb, 0x01, 0x0a, 0x0d, 0x45, 0x6e, 0x76, 0x46, 0x72, 0x6f,
-	0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69,
-	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
-	0x5d, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x72, 0x65,
-	0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x76, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x66, 0x12, 0x53,
-	0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45,
-	0x6e, 0x76, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
-	0x52, 0x65, 0x66, 0x22, 0x28, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x61, 0x70,
-	0x45, 0x6e, 0x76, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x25, 0x0a,
-	0x0f, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x06, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xdc, 0x04, 0x0a, 0x05, 0x50, 0x72, 0x6f,
-	0x62, 0x65, 0x12, 0x43, 0x0a, 0x04, 0x65, 0x78, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x04, 0x65, 0x78, 0x65, 0x63, 0x12, 0x4d, 0x0a, 0x08, 0x68, 0x74, 0x74, 0x70, 0x5f,
-	0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
-	0x48, 0x54, 0x54, 0x50, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x68,
-	0x74, 0x74, 0x70, 0x47, 0x65, 0x74, 0x12, 0x53, 0x0a, 0x0a, 0x74, 0x63, 0x70, 0x5f, 0x73, 0x6f,
-	0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73,
+	0x22, 0xac, 0x01, 0x0a, 0x18, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x55, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0xf9, 0x02, 0x0a, 0x1c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6f, 0x64,
+	0x5f, 0x73, 0x70, 0x65, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6f, 0x64,
+	0x53, 0x70, 0x65, 0x63, 0x12, 0x75, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x52, 0x2e, 0x6b, 0x75, 0x73,
 	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
 	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
-	0x2e, 0x54, 0x43, 0x50, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x09, 0x74, 0x63, 0x70, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x43, 0x0a, 0x04, 0x67,
-	0x72, 0x70, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
-	0x47, 0x52, 0x50, 0x43, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x67, 0x72, 0x70, 0x63,
-	0x12, 0x32, 0x0a, 0x15, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x61,
-	0x79, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x13, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f,
-	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74,
-	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x0a,
-	0x0e, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f,
-	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x10, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
-	0x64, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72,
-	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x66, 0x61,
-	0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x47,
-	0x0a, 0x20, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x67, 0x72,
-	0x61, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
-	0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x1d, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
-	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x26, 0x0a, 0x0a, 0x45, 0x78, 0x65, 0x63, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22,
-	0xb7, 0x01, 0x0a, 0x0d, 0x48, 0x54, 0x54, 0x50, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x52, 0x0a, 0x0c, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
-	0x69, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x68, 0x74,
-	0x74, 0x70, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x22, 0x36, 0x0a, 0x0a, 0x48, 0x54, 0x54,
-	0x50, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x39, 0x0a, 0x0f, 0x54, 0x43, 0x50, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x22, 0x3a, 0x0a, 0x0a,
-	0x47, 0x52, 0x50, 0x43, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x32, 0xd6, 0x05, 0x0a, 0x0f, 0x41, 0x70, 0x70,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x89, 0x01, 0x0a,
-	0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12,
-	0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
+	0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xdb, 0x01, 0x0a, 0x0d,
+	0x45, 0x6e, 0x76, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x5d, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f,
+	0x6d, 0x61, 0x70, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x76,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x4d, 0x61,
+	0x70, 0x52, 0x65, 0x66, 0x12, 0x53, 0x0a, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x72,
+	0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x09,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x22, 0x28, 0x0a, 0x12, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x76, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x25, 0x0a, 0x0f, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x06, 0x45, 0x6e,
+	0x76, 0x56, 0x61, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xdc,
+	0x04, 0x0a, 0x05, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x43, 0x0a, 0x04, 0x65, 0x78, 0x65, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x78, 0x65,
+	0x63, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x65, 0x78, 0x65, 0x63, 0x12, 0x4d, 0x0a,
+	0x08, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x32, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x68, 0x74, 0x74, 0x70, 0x47, 0x65, 0x74, 0x12, 0x53, 0x0a, 0x0a,
+	0x74, 0x63, 0x70, 0x5f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x43, 0x50, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x74, 0x63, 0x70, 0x53, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x43, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
 	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x6b, 0x75,
+	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x52, 0x50, 0x43, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x04, 0x67, 0x72, 0x70, 0x63, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65,
+	0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x5f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x47, 0x0a, 0x20, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x67, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x1d,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x47, 0x72, 0x61, 0x63, 0x65,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x26, 0x0a,
+	0x0a, 0x45, 0x78, 0x65, 0x63, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0xb7, 0x01, 0x0a, 0x0d, 0x48, 0x54, 0x54, 0x50, 0x47, 0x65,
+	0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x52, 0x0a, 0x0c, 0x68,
+	0x74, 0x74, 0x70, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x0b, 0x68, 0x74, 0x74, 0x70, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x22,
+	0x36, 0x0a, 0x0a, 0x48, 0x54, 0x54, 0x50, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x39, 0x0a, 0x0f, 0x54, 0x43, 0x50, 0x53, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x22, 0x3a, 0x0a, 0x0a, 0x47, 0x52, 0x50, 0x43, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x32, 0xe8,
+	0x06, 0x0a, 0x0f, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x89, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70,
+	0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x86,
+	0x01, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x39, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x6b, 0x75,
 	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
 	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
-	0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x86, 0x01, 0x0a, 0x0d, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x39, 0x2e, 0x6b, 0x75, 0x73,
+	0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x89, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x3a, 0x2e, 0x6b, 0x75, 0x73,
 	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
 	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
-	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x89, 0x01, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x12, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x89, 0x01,
-	0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65,
-	0x12, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x6b,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x89, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70,
+	0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x3a, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41,
+	0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x95, 0x01, 0x0a, 0x12, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70,
+	0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8f, 0x01, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x2e, 0x6b,
 	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
 	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61,
-	0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x95, 0x01, 0x0a, 0x12, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65,
-	0x12, 0x3e, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x3f, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x5e, 0x0a, 0x21, 0x6f, 0x72, 0x67, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66,
-	0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
-	0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x69, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3d, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
+	0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x41, 0x70, 0x70, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x5e, 0x0a, 0x21, 0x6f, 0x72, 0x67,
+	0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x5a, 0x39,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -2543,104 +3094,123 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescGZIP() []byt
 	return file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDescData
 }
 
-var file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_goTypes = []interface{}{
-	(*CreateAppImageRequest)(nil),      // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest
-	(*AppImageInfo)(nil),               // 1: kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
-	(*DeployTemplate)(nil),             // 2: kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
-	(*NetworkPolicy)(nil),              // 3: kuscia.proto.api.v1alpha1.kusciaapi.NetworkPolicy
-	(*Ingress)(nil),                    // 4: kuscia.proto.api.v1alpha1.kusciaapi.Ingress
-	(*IngressFrom)(nil),                // 5: kuscia.proto.api.v1alpha1.kusciaapi.IngressFrom
-	(*IngressPort)(nil),                // 6: kuscia.proto.api.v1alpha1.kusciaapi.IngressPort
-	(*Container)(nil),                  // 7: kuscia.proto.api.v1alpha1.kusciaapi.Container
-	(*ConfigVolumeMount)(nil),          // 8: kuscia.proto.api.v1alpha1.kusciaapi.ConfigVolumeMount
-	(*ContainerPort)(nil),              // 9: kuscia.proto.api.v1alpha1.kusciaapi.ContainerPort
-	(*ResourceRequirements)(nil),       // 10: kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements
-	(*ResourceSpec)(nil),               // 11: kuscia.proto.api.v1alpha1.kusciaapi.ResourceSpec
-	(*CreateAppImageResponse)(nil),     // 12: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageResponse
-	(*DeleteAppImageRequest)(nil),      // 13: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageRequest
-	(*DeleteAppImageResponse)(nil),     // 14: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageResponse
-	(*QueryAppImageRequest)(nil),       // 15: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageRequest
-	(*BatchQueryAppImageRequest)(nil),  // 16: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageRequest
-	(*QueryAppImageResponse)(nil),      // 17: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse
-	(*BatchQueryAppImageResponse)(nil), // 18: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse
-	(*QueryAppImageResponseData)(nil),  // 19: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData
-	(*UpdateAppImageRequest)(nil),      // 20: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest
-	(*UpdateAppImageResponse)(nil),     // 21: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageResponse
-	(*EnvFromSource)(nil),              // 22: kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource
-	(*ConfigMapEnvSource)(nil),         // 23: kuscia.proto.api.v1alpha1.kusciaapi.ConfigMapEnvSource
-	(*SecretEnvSource)(nil),            // 24: kuscia.proto.api.v1alpha1.kusciaapi.SecretEnvSource
-	(*EnvVar)(nil),                     // 25: kuscia.proto.api.v1alpha1.kusciaapi.EnvVar
-	(*Probe)(nil),                      // 26: kuscia.proto.api.v1alpha1.kusciaapi.Probe
-	(*ExecAction)(nil),                 // 27: kuscia.proto.api.v1alpha1.kusciaapi.ExecAction
-	(*HTTPGetAction)(nil),              // 28: kuscia.proto.api.v1alpha1.kusciaapi.HTTPGetAction
-	(*HTTPHeader)(nil),                 // 29: kuscia.proto.api.v1alpha1.kusciaapi.HTTPHeader
-	(*TCPSocketAction)(nil),            // 30: kuscia.proto.api.v1alpha1.kusciaapi.TCPSocketAction
-	(*GRPCAction)(nil),                 // 31: kuscia.proto.api.v1alpha1.kusciaapi.GRPCAction
-	nil,                                // 32: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.ConfigTemplatesEntry
-	nil,                                // 33: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.ConfigTemplatesEntry
-	nil,                                // 34: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.ConfigTemplatesEntry
-	(*v1alpha1.RequestHeader)(nil),     // 35: kuscia.proto.api.v1alpha1.RequestHeader
-	(*v1alpha1.Status)(nil),            // 36: kuscia.proto.api.v1alpha1.Status
+	(*CreateAppImageRequest)(nil),        // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest
+	(*AppImageInfo)(nil),                 // 1: kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
+	(*AppImageChannel)(nil),              // 2: kuscia.proto.api.v1alpha1.kusciaapi.AppImageChannel
+	(*DeployTemplate)(nil),               // 3: kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
+	(*NetworkPolicy)(nil),                // 4: kuscia.proto.api.v1alpha1.kusciaapi.NetworkPolicy
+	(*Ingress)(nil),                      // 5: kuscia.proto.api.v1alpha1.kusciaapi.Ingress
+	(*IngressFrom)(nil),                  // 6: kuscia.proto.api.v1alpha1.kusciaapi.IngressFrom
+	(*IngressPort)(nil),                  // 7: kuscia.proto.api.v1alpha1.kusciaapi.IngressPort
+	(*Container)(nil),                    // 8: kuscia.proto.api.v1alpha1.kusciaapi.Container
+	(*ConfigVolumeMount)(nil),            // 9: kuscia.proto.api.v1alpha1.kusciaapi.ConfigVolumeMount
+	(*ContainerPort)(nil),                // 10: kuscia.proto.api.v1alpha1.kusciaapi.ContainerPort
+	(*ResourceRequirements)(nil),         // 11: kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements
+	(*ResourceSpec)(nil),                 // 12: kuscia.proto.api.v1alpha1.kusciaapi.ResourceSpec
+	(*CreateAppImageResponse)(nil),       // 13: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageResponse
+	(*DeleteAppImageRequest)(nil),        // 14: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageRequest
+	(*DeleteAppImageResponse)(nil),       // 15: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageResponse
+	(*QueryAppImageRequest)(nil),         // 16: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageRequest
+	(*BatchQueryAppImageRequest)(nil),    // 17: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageRequest
+	(*QueryAppImageResponse)(nil),        // 18: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse
+	(*BatchQueryAppImageResponse)(nil),   // 19: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse
+	(*QueryAppImageResponseData)(nil),    // 20: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData
+	(*UpdateAppImageRequest)(nil),        // 21: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest
+	(*UpdateAppImageResponse)(nil),       // 22: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageResponse
+	(*ValidateAppImageRequest)(nil),      // 23: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest
+	(*ValidateAppImageResponse)(nil),     // 24: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponse
+	(*ValidateAppImageResponseData)(nil), // 25: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponseData
+	(*EnvFromSource)(nil),                // 26: kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource
+	(*ConfigMapEnvSource)(nil),           // 27: kuscia.proto.api.v1alpha1.kusciaapi.ConfigMapEnvSource
+	(*SecretEnvSource)(nil),              // 28: kuscia.proto.api.v1alpha1.kusciaapi.SecretEnvSource
+	(*EnvVar)(nil),                       // 29: kuscia.proto.api.v1alpha1.kusciaapi.EnvVar
+	(*Probe)(nil),                        // 30: kuscia.proto.api.v1alpha1.kusciaapi.Probe
+	(*ExecAction)(nil),                   // 31: kuscia.proto.api.v1alpha1.kusciaapi.ExecAction
+	(*HTTPGetAction)(nil),                // 32: kuscia.proto.api.v1alpha1.kusciaapi.HTTPGetAction
+	(*HTTPHeader)(nil),                   // 33: kuscia.proto.api.v1alpha1.kusciaapi.HTTPHeader
+	(*TCPSocketAction)(nil),              // 34: kuscia.proto.api.v1alpha1.kusciaapi.TCPSocketAction
+	(*GRPCAction)(nil),                   // 35: kuscia.proto.api.v1alpha1.kusciaapi.GRPCAction
+	nil,                                  // 36: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.ConfigTemplatesEntry
+	nil,                                  // 37: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.ConfigTemplatesEntry
+	nil,                                  // 38: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.ConfigTemplatesEntry
+	nil,                                  // 39: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest.ConfigTemplatesEntry
+	nil,                                  // 40: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponseData.ConfigFilesEntry
+	(*v1alpha1.RequestHeader)(nil),       // 41: kuscia.proto.api.v1alpha1.RequestHeader
+	(*v1alpha1.Status)(nil),              // 42: kuscia.proto.api.v1alpha1.Status
 }
 var file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_depIdxs = []int32{
-	35, // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	41, // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
 	1,  // 1: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.image:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
-	32, // 2: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.config_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.ConfigTemplatesEntry
-	2,  // 3: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.deploy_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
-	7,  // 4: kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate.containers:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Container
-	4,  // 5: kuscia.proto.api.v1alpha1.kusciaapi.NetworkPolicy.ingresses:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Ingress
-	5,  // 6: kuscia.proto.api.v1alpha1.kusciaapi.Ingress.from:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.IngressFrom
-	6,  // 7: kuscia.proto.api.v1alpha1.kusciaapi.Ingress.ports:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.IngressPort
-	8,  // 8: kuscia.proto.api.v1alpha1.kusciaapi.Container.config_volume_mounts:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ConfigVolumeMount
-	9,  // 9: kuscia.proto.api.v1alpha1.kusciaapi.Container.ports:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ContainerPort
-	22, // 10: kuscia.proto.api.v1alpha1.kusciaapi.Container.env_from:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource
-	25, // 11: kuscia.proto.api.v1alpha1.kusciaapi.Container.env:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.EnvVar
-	10, // 12: kuscia.proto.api.v1alpha1.kusciaapi.Container.resources:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements
-	26, // 13: kuscia.proto.api.v1alpha1.kusciaapi.Container.liveness_probe:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Probe
-	26, // 14: kuscia.proto.api.v1alpha1.kusciaapi.Container.readiness_probe:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Probe
-	26, // 15: kuscia.proto.api.v1alpha1.kusciaapi.Container.startup_probe:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Probe
-	11, // 16: kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements.limits:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ResourceSpec
-	11, // 17: kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements.requests:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ResourceSpec
-	36, // 18: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	35, // 19: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	36, // 20: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	35, // 21: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	35, // 22: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	36, // 23: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	19, // 24: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData
-	36, // 25: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	19, // 26: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData
-	1,  // 27: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.image:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
-	33, // 28: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.config_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.ConfigTemplatesEntry
-	2,  // 29: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.deploy_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
-	35, // 30: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	1,  // 31: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.image:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
-	34, // 32: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.config_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.ConfigTemplatesEntry
-	2,  // 33: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.deploy_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
-	36, // 34: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	23, // 35: kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource.config_map_ref:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ConfigMapEnvSource
-	24, // 36: kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource.secret_ref:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.SecretEnvSource
-	27, // 37: kuscia.proto.api.v1alpha1.kusciaapi.Probe.exec:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ExecAction
-	28, // 38: kuscia.proto.api.v1alpha1.kusciaapi.Probe.http_get:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.HTTPGetAction
-	30, // 39: kuscia.proto.api.v1alpha1.kusciaapi.Probe.tcp_socket:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.TCPSocketAction
-	31, // 40: kuscia.proto.api.v1alpha1.kusciaapi.Probe.grpc:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.GRPCAction
-	29, // 41: kuscia.proto.api.v1alpha1.kusciaapi.HTTPGetAction.http_headers:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.HTTPHeader
-	0,  // 42: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.CreateAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest
-	15, // 43: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.QueryAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageRequest
-	20, // 44: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.UpdateAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest
-	13, // 45: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.DeleteAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageRequest
-	16, // 46: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.BatchQueryAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageRequest
-	12, // 47: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.CreateAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageResponse
-	17, // 48: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.QueryAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse
-	21, // 49: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.UpdateAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageResponse
-	14, // 50: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.DeleteAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageResponse
-	18, // 51: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.BatchQueryAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse
-	47, // [47:52] is the sub-list for method output_type
-	42, // [42:47] is the sub-list for method input_type
-	42, // [42:42] is the sub-list for extension type_name
-	42, // [42:42] is the sub-list for extension extendee
-	0,  // [0:42] is the sub-list for field type_name
+	36, // 2: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.config_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.ConfigTemplatesEntry
+	3,  // 3: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.deploy_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
+	2,  // 4: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest.channels:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageChannel
+	1,  // 5: kuscia.proto.api.v1alpha1.kusciaapi.AppImageChannel.image:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
+	8,  // 6: kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate.containers:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Container
+	5,  // 7: kuscia.proto.api.v1alpha1.kusciaapi.NetworkPolicy.ingresses:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Ingress
+	6,  // 8: kuscia.proto.api.v1alpha1.kusciaapi.Ingress.from:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.IngressFrom
+	7,  // 9: kuscia.proto.api.v1alpha1.kusciaapi.Ingress.ports:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.IngressPort
+	9,  // 10: kuscia.proto.api.v1alpha1.kusciaapi.Container.config_volume_mounts:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ConfigVolumeMount
+	10, // 11: kuscia.proto.api.v1alpha1.kusciaapi.Container.ports:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ContainerPort
+	26, // 12: kuscia.proto.api.v1alpha1.kusciaapi.Container.env_from:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource
+	29, // 13: kuscia.proto.api.v1alpha1.kusciaapi.Container.env:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.EnvVar
+	11, // 14: kuscia.proto.api.v1alpha1.kusciaapi.Container.resources:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements
+	30, // 15: kuscia.proto.api.v1alpha1.kusciaapi.Container.liveness_probe:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Probe
+	30, // 16: kuscia.proto.api.v1alpha1.kusciaapi.Container.readiness_probe:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Probe
+	30, // 17: kuscia.proto.api.v1alpha1.kusciaapi.Container.startup_probe:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Probe
+	12, // 18: kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements.limits:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ResourceSpec
+	12, // 19: kuscia.proto.api.v1alpha1.kusciaapi.ResourceRequirements.requests:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ResourceSpec
+	42, // 20: kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	41, // 21: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	42, // 22: kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	41, // 23: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	41, // 24: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	42, // 25: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	20, // 26: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData
+	42, // 27: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	20, // 28: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData
+	1,  // 29: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.image:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
+	37, // 30: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.config_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.ConfigTemplatesEntry
+	3,  // 31: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.deploy_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
+	2,  // 32: kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponseData.channels:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageChannel
+	41, // 33: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	1,  // 34: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.image:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
+	38, // 35: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.config_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.ConfigTemplatesEntry
+	3,  // 36: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.deploy_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
+	2,  // 37: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest.channels:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageChannel
+	42, // 38: kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	41, // 39: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	1,  // 40: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest.image:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AppImageInfo
+	39, // 41: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest.config_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest.ConfigTemplatesEntry
+	3,  // 42: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest.deploy_templates:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTemplate
+	42, // 43: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	25, // 44: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponseData
+	40, // 45: kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponseData.config_files:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponseData.ConfigFilesEntry
+	27, // 46: kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource.config_map_ref:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ConfigMapEnvSource
+	28, // 47: kuscia.proto.api.v1alpha1.kusciaapi.EnvFromSource.secret_ref:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.SecretEnvSource
+	31, // 48: kuscia.proto.api.v1alpha1.kusciaapi.Probe.exec:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.ExecAction
+	32, // 49: kuscia.proto.api.v1alpha1.kusciaapi.Probe.http_get:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.HTTPGetAction
+	34, // 50: kuscia.proto.api.v1alpha1.kusciaapi.Probe.tcp_socket:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.TCPSocketAction
+	35, // 51: kuscia.proto.api.v1alpha1.kusciaapi.Probe.grpc:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.GRPCAction
+	33, // 52: kuscia.proto.api.v1alpha1.kusciaapi.HTTPGetAction.http_headers:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.HTTPHeader
+	0,  // 53: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.CreateAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageRequest
+	16, // 54: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.QueryAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageRequest
+	21, // 55: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.UpdateAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageRequest
+	14, // 56: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.DeleteAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageRequest
+	17, // 57: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.BatchQueryAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageRequest
+	23, // 58: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.ValidateAppImage:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageRequest
+	13, // 59: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.CreateAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateAppImageResponse
+	18, // 60: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.QueryAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryAppImageResponse
+	22, // 61: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.UpdateAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateAppImageResponse
+	15, // 62: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.DeleteAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteAppImageResponse
+	19, // 63: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.BatchQueryAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryAppImageResponse
+	24, // 64: kuscia.proto.api.v1alpha1.kusciaapi.AppImageService.ValidateAppImage:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.ValidateAppImageResponse
+	59, // [59:65] is the sub-list for method output_type
+	53, // [53:59] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() }
@@ -2674,7 +3244,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeployTemplate); i {
+			switch v := v.(*AppImageChannel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2686,7 +3256,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NetworkPolicy); i {
+			switch v := v.(*DeployTemplate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2698,7 +3268,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Ingress); i {
+			switch v := v.(*NetworkPolicy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2710,7 +3280,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IngressFrom); i {
+			switch v := v.(*Ingress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2722,7 +3292,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IngressPort); i {
+			switch v := v.(*IngressFrom); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2734,7 +3304,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Container); i {
+			switch v := v.(*IngressPort); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2746,7 +3316,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConfigVolumeMount); i {
+			switch v := v.(*Container); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2758,7 +3328,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ContainerPort); i {
+			switch v := v.(*ConfigVolumeMount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2770,7 +3340,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourceRequirements); i {
+			switch v := v.(*ContainerPort); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2782,7 +3352,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourceSpec); i {
+			switch v := v.(*ResourceRequirements); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2794,7 +3364,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAppImageResponse); i {
+			switch v := v.(*ResourceSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2806,7 +3376,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAppImageRequest); i {
+			switch v := v.(*CreateAppImageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2818,7 +3388,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAppImageResponse); i {
+			switch v := v.(*DeleteAppImageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2830,7 +3400,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryAppImageRequest); i {
+			switch v := v.(*DeleteAppImageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2842,7 +3412,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchQueryAppImageRequest); i {
+			switch v := v.(*QueryAppImageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2854,7 +3424,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryAppImageResponse); i {
+			switch v := v.(*BatchQueryAppImageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2866,7 +3436,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchQueryAppImageResponse); i {
+			switch v := v.(*QueryAppImageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2878,7 +3448,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryAppImageResponseData); i {
+			switch v := v.(*BatchQueryAppImageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2890,7 +3460,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAppImageRequest); i {
+			switch v := v.(*QueryAppImageResponseData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2902,7 +3472,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAppImageResponse); i {
+			switch v := v.(*UpdateAppImageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2914,7 +3484,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EnvFromSource); i {
+			switch v := v.(*UpdateAppImageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2926,7 +3496,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConfigMapEnvSource); i {
+			switch v := v.(*ValidateAppImageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2938,7 +3508,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SecretEnvSource); i {
+			switch v := v.(*ValidateAppImageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2950,7 +3520,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EnvVar); i {
+			switch v := v.(*ValidateAppImageResponseData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2962,7 +3532,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Probe); i {
+			switch v := v.(*EnvFromSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2974,7 +3544,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecAction); i {
+			switch v := v.(*ConfigMapEnvSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2986,7 +3556,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HTTPGetAction); i {
+			switch v := v.(*SecretEnvSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2998,7 +3568,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HTTPHeader); i {
+			switch v := v.(*EnvVar); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3010,7 +3580,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TCPSocketAction); i {
+			switch v := v.(*Probe); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3022,6 +3592,54 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPGetAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTPHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TCPSocketAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GRPCAction); i {
 			case 0:
 				return &v.state
@@ -3040,7 +3658,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kuscia_proto_api_v1alpha1_kusciaapi_appimage_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   35,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -38,6 +38,7 @@ const (
 	AppImageService_UpdateAppImage_FullMethodName     = "/kuscia.proto.api.v1alpha1.kusciaapi.AppImageService/UpdateAppImage"
 	AppImageService_DeleteAppImage_FullMethodName     = "/kuscia.proto.api.v1alpha1.kusciaapi.AppImageService/DeleteAppImage"
 	AppImageService_BatchQueryAppImage_FullMethodName = "/kuscia.proto.api.v1alpha1.kusciaapi.AppImageService/BatchQueryAppImage"
+	AppImageService_ValidateAppImage_FullMethodName   = "/kuscia.proto.api.v1alpha1.kusciaapi.AppImageService/ValidateAppImage"
 )
 
 // AppImageServiceClient is the client API for AppImageService service.
@@ -49,6 +50,7 @@ type AppImageServiceClient interface {
 	UpdateAppImage(ctx context.Context, in *UpdateAppImageRequest, opts ...grpc.CallOption) (*UpdateAppImageResponse, error)
 	DeleteAppImage(ctx context.Context, in *DeleteAppImageRequest, opts ...grpc.CallOption) (*DeleteAppImageResponse, error)
 	BatchQueryAppImage(ctx context.Context, in *BatchQueryAppImageRequest, opts ...grpc.CallOption) (*BatchQueryAppImageResponse, error)
+	ValidateAppImage(ctx context.Context, in *ValidateAppImageRequest, opts ...grpc.CallOption) (*ValidateAppImageResponse, error)
 }
 
 type appImageServiceClient struct {
@@ -104,6 +106,15 @@ func (c *appImageServiceClient) BatchQueryAppImage(ctx context.Context, in *Batc
 	return out, nil
 }
 
+func (c *appImageServiceClient) ValidateAppImage(ctx context.Context, in *ValidateAppImageRequest, opts ...grpc.CallOption) (*ValidateAppImageResponse, error) {
+	out := new(ValidateAppImageResponse)
+	err := c.cc.Invoke(ctx, AppImageService_ValidateAppImage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AppImageServiceServer is the server API for AppImageService service.
 // All implementations must embed UnimplementedAppImageServiceServer
 // for forward compatibility
@@ -113,6 +124,7 @@ type AppImageServiceServer interface {
 	UpdateAppImage(context.Context, *UpdateAppImageRequest) (*UpdateAppImageResponse, error)
 	DeleteAppImage(context.Context, *DeleteAppImageRequest) (*DeleteAppImageResponse, error)
 	BatchQueryAppImage(context.Context, *BatchQueryAppImageRequest) (*BatchQueryAppImageResponse, error)
+	ValidateAppImage(context.Context, *ValidateAppImageRequest) (*ValidateAppImageResponse, error)
 	mustEmbedUnimplementedAppImageServiceServer()
 }
 
@@ -135,6 +147,9 @@ func (UnimplementedAppImageServiceServer) DeleteAppImage(context.Context, *Delet
 func (UnimplementedAppImageServiceServer) BatchQueryAppImage(context.Context, *BatchQueryAppImageRequest) (*BatchQueryAppImageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchQueryAppImage not implemented")
 }
+func (UnimplementedAppImageServiceServer) ValidateAppImage(context.Context, *ValidateAppImageRequest) (*ValidateAppImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAppImage not implemented")
+}
 func (UnimplementedAppImageServiceServer) mustEmbedUnimplementedAppImageServiceServer() {}
 
 // UnsafeAppImageServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -238,6 +253,24 @@ func _AppImageService_BatchQueryAppImage_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AppImageService_ValidateAppImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateAppImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppImageServiceServer).ValidateAppImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppImageService_ValidateAppImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppImageServiceServer).ValidateAppImage(ctx, req.(*ValidateAppImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AppImageService_ServiceDesc is the grpc.ServiceDesc for AppImageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +298,10 @@ var AppImageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchQueryAppImage",
 			Handler:    _AppImageService_BatchQueryAppImage_Handler,
 		},
+		{
+			MethodName: "ValidateAppImage",
+			Handler:    _AppImageService_ValidateAppImage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kuscia/proto/api/v1alpha1/kusciaapi/appimage.proto",
@@ -1047,6 +1047,632 @@ func (x *AuthCenter) GetTokenGenMethod() string {
 	return ""
 }
 
+type QueryDomainStatusDetailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header   *v1alpha1.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	DomainId string                  `protobuf:"bytes,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+}
+
+func (x *QueryDomainStatusDetailRequest) Reset() {
+	*x = QueryDomainStatusDetailRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDomainStatusDetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDomainStatusDetailRequest) ProtoMessage() {}
+
+func (x *QueryDomainStatusDetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDomainStatusDetailRequest.ProtoReflect.Descriptor instead.
+func (*QueryDomainStatusDetailRequest) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *QueryDomainStatusDetailRequest) GetHeader() *v1alpha1.RequestHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *QueryDomainStatusDetailRequest) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+type QueryDomainStatusDetailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *v1alpha1.Status    `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *DomainStatusDetail `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *QueryDomainStatusDetailResponse) Reset() {
+	*x = QueryDomainStatusDetailResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryDomainStatusDetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDomainStatusDetailResponse) ProtoMessage() {}
+
+func (x *QueryDomainStatusDetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDomainStatusDetailResponse.ProtoReflect.Descriptor instead.
+func (*QueryDomainStatusDetailResponse) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QueryDomainStatusDetailResponse) GetStatus() *v1alpha1.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *QueryDomainStatusDetailResponse) GetData() *DomainStatusDetail {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// DomainStatusDetail describes whether the components of domain are functional.
+type DomainStatusDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DomainId string `protobuf:"bytes,1,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	// Healthy if all agents and gateways are healthy, Degraded if some of them are, Unavailable if none of the agents
+	// or gateways is healthy.
+	Health    string                 `protobuf:"bytes,2,opt,name=health,proto3" json:"health,omitempty"`
+	Agents    []*DomainAgentStatus   `protobuf:"bytes,3,rep,name=agents,proto3" json:"agents,omitempty"`
+	Gateways  []*DomainGatewayStatus `protobuf:"bytes,4,rep,name=gateways,proto3" json:"gateways,omitempty"`
+	Apps      []*DomainAppStatus     `protobuf:"bytes,5,rep,name=apps,proto3" json:"apps,omitempty"`
+	Resources *DomainResourceStatus  `protobuf:"bytes,6,opt,name=resources,proto3" json:"resources,omitempty"`
+	Certs     []*DomainCertStatus    `protobuf:"bytes,7,rep,name=certs,proto3" json:"certs,omitempty"`
+	// jobs awaiting the approval of domain
+	PendingApprovals int32 `protobuf:"varint,8,opt,name=pending_approvals,json=pendingApprovals,proto3" json:"pending_approvals,omitempty"`
+}
+
+func (x *DomainStatusDetail) Reset() {
+	*x = DomainStatusDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainStatusDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainStatusDetail) ProtoMessage() {}
+
+func (x *DomainStatusDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainStatusDetail.ProtoReflect.Descriptor instead.
+func (*DomainStatusDetail) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DomainStatusDetail) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+func (x *DomainStatusDetail) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *DomainStatusDetail) GetAgents() []*DomainAgentStatus {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *DomainStatusDetail) GetGateways() []*DomainGatewayStatus {
+	if x != nil {
+		return x.Gateways
+	}
+	return nil
+}
+
+func (x *DomainStatusDetail) GetApps() []*DomainAppStatus {
+	if x != nil {
+		return x.Apps
+	}
+	return nil
+}
+
+func (x *DomainStatusDetail) GetResources() *DomainResourceStatus {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *DomainStatusDetail) GetCerts() []*DomainCertStatus {
+	if x != nil {
+		return x.Certs
+	}
+	return nil
+}
+
+func (x *DomainStatusDetail) GetPendingApprovals() int32 {
+	if x != nil {
+		return x.PendingApprovals
+	}
+	return 0
+}
+
+type DomainAgentStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Ready or NotReady
+	Status              string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Version             string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	LastHeartbeatTime   string `protobuf:"bytes,4,opt,name=last_heartbeat_time,json=lastHeartbeatTime,proto3" json:"last_heartbeat_time,omitempty"`
+	HeartbeatAgeSeconds int64  `protobuf:"varint,5,opt,name=heartbeat_age_seconds,json=heartbeatAgeSeconds,proto3" json:"heartbeat_age_seconds,omitempty"`
+}
+
+func (x *DomainAgentStatus) Reset() {
+	*x = DomainAgentStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainAgentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainAgentStatus) ProtoMessage() {}
+
+func (x *DomainAgentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainAgentStatus.ProtoReflect.Descriptor instead.
+func (*DomainAgentStatus) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DomainAgentStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DomainAgentStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DomainAgentStatus) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DomainAgentStatus) GetLastHeartbeatTime() string {
+	if x != nil {
+		return x.LastHeartbeatTime
+	}
+	return ""
+}
+
+func (x *DomainAgentStatus) GetHeartbeatAgeSeconds() int64 {
+	if x != nil {
+		return x.HeartbeatAgeSeconds
+	}
+	return 0
+}
+
+type DomainGatewayStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the gateway is ready if its heartbeat is not timed out
+	Ready               bool   `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"`
+	Version             string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Address             string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	LastHeartbeatTime   string `protobuf:"bytes,5,opt,name=last_heartbeat_time,json=lastHeartbeatTime,proto3" json:"last_heartbeat_time,omitempty"`
+	HeartbeatAgeSeconds int64  `protobuf:"varint,6,opt,name=heartbeat_age_seconds,json=heartbeatAgeSeconds,proto3" json:"heartbeat_age_seconds,omitempty"`
+}
+
+func (x *DomainGatewayStatus) Reset() {
+	*x = DomainGatewayStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainGatewayStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainGatewayStatus) ProtoMessage() {}
+
+func (x *DomainGatewayStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainGatewayStatus.ProtoReflect.Descriptor instead.
+func (*DomainGatewayStatus) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DomainGatewayStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DomainGatewayStatus) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *DomainGatewayStatus) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DomainGatewayStatus) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DomainGatewayStatus) GetLastHeartbeatTime() string {
+	if x != nil {
+		return x.LastHeartbeatTime
+	}
+	return ""
+}
+
+func (x *DomainGatewayStatus) GetHeartbeatAgeSeconds() int64 {
+	if x != nil {
+		return x.HeartbeatAgeSeconds
+	}
+	return 0
+}
+
+type DomainAppStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Replicas          int32  `protobuf:"varint,2,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	AvailableReplicas int32  `protobuf:"varint,3,opt,name=available_replicas,json=availableReplicas,proto3" json:"available_replicas,omitempty"`
+}
+
+func (x *DomainAppStatus) Reset() {
+	*x = DomainAppStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainAppStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainAppStatus) ProtoMessage() {}
+
+func (x *DomainAppStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainAppStatus.ProtoReflect.Descriptor instead.
+func (*DomainAppStatus) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DomainAppStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DomainAppStatus) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *DomainAppStatus) GetAvailableReplicas() int32 {
+	if x != nil {
+		return x.AvailableReplicas
+	}
+	return 0
+}
+
+// DomainResourceStatus is the sum of resources of nodes of domain, quantities are in the format of kubernetes.
+type DomainResourceStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CpuCapacity       string `protobuf:"bytes,1,opt,name=cpu_capacity,json=cpuCapacity,proto3" json:"cpu_capacity,omitempty"`
+	MemoryCapacity    string `protobuf:"bytes,2,opt,name=memory_capacity,json=memoryCapacity,proto3" json:"memory_capacity,omitempty"`
+	CpuAllocatable    string `protobuf:"bytes,3,opt,name=cpu_allocatable,json=cpuAllocatable,proto3" json:"cpu_allocatable,omitempty"`
+	MemoryAllocatable string `protobuf:"bytes,4,opt,name=memory_allocatable,json=memoryAllocatable,proto3" json:"memory_allocatable,omitempty"`
+	// requests of the running and pending pods of domain
+	CpuRequested    string `protobuf:"bytes,5,opt,name=cpu_requested,json=cpuRequested,proto3" json:"cpu_requested,omitempty"`
+	MemoryRequested string `protobuf:"bytes,6,opt,name=memory_requested,json=memoryRequested,proto3" json:"memory_requested,omitempty"`
+	Pods            int32  `protobuf:"varint,7,opt,name=pods,proto3" json:"pods,omitempty"`
+}
+
+func (x *DomainResourceStatus) Reset() {
+	*x = DomainResourceStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainResourceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainResourceStatus) ProtoMessage() {}
+
+func (x *DomainResourceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainResourceStatus.ProtoReflect.Descriptor instead.
+func (*DomainResourceStatus) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DomainResourceStatus) GetCpuCapacity() string {
+	if x != nil {
+		return x.CpuCapacity
+	}
+	return ""
+}
+
+func (x *DomainResourceStatus) GetMemoryCapacity() string {
+	if x != nil {
+		return x.MemoryCapacity
+	}
+	return ""
+}
+
+func (x *DomainResourceStatus) GetCpuAllocatable() string {
+	if x != nil {
+		return x.CpuAllocatable
+	}
+	return ""
+}
+
+func (x *DomainResourceStatus) GetMemoryAllocatable() string {
+	if x != nil {
+		return x.MemoryAllocatable
+	}
+	return ""
+}
+
+func (x *DomainResourceStatus) GetCpuRequested() string {
+	if x != nil {
+		return x.CpuRequested
+	}
+	return ""
+}
+
+func (x *DomainResourceStatus) GetMemoryRequested() string {
+	if x != nil {
+		return x.MemoryRequested
+	}
+	return ""
+}
+
+func (x *DomainResourceStatus) GetPods() int32 {
+	if x != nil {
+		return x.Pods
+	}
+	return 0
+}
+
+type DomainCertStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Domain for the cert of domain, or Secret for tls secrets in the namespace of domain
+	Source   string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Subject  string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	NotAfter string `protobuf:"bytes,4,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// negative if the cert has expired
+	ExpiresInSeconds int64 `protobuf:"varint,5,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"`
+}
+
+func (x *DomainCertStatus) Reset() {
+	*x = DomainCertStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainCertStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainCertStatus) ProtoMessage() {}
+
+func (x *DomainCertStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainCertStatus.ProtoReflect.Descriptor instead.
+func (*DomainCertStatus) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DomainCertStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DomainCertStatus) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *DomainCertStatus) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *DomainCertStatus) GetNotAfter() string {
+	if x != nil {
+		return x.NotAfter
+	}
+	return ""
+}
+
+func (x *DomainCertStatus) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
 var File_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto protoreflect.FileDescriptor
 
 var file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDesc = []byte{
@@ -1231,57 +1857,180 @@ var file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDesc = []byte{
 	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
 	0x28, 0x0a, 0x10, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x67, 0x65, 0x6e, 0x5f, 0x6d, 0x65, 0x74,
 	0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x47, 0x65, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x32, 0xb6, 0x05, 0x0a, 0x0d, 0x44, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x0c,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61,
-	0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
+	0x47, 0x65, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x7f, 0x0a, 0x1e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a,
+	0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x22, 0xa9, 0x01, 0x0a, 0x1f, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4b, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x8c, 0x04, 0x0a, 0x12, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1b, 0x0a,
+	0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x12, 0x4e, 0x0a, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x41,
+	0x67, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x54, 0x0a, 0x08, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x73, 0x12, 0x48, 0x0a, 0x04, 0x61, 0x70, 0x70, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x80, 0x01, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x12, 0x37, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e, 0x6b, 0x75, 0x73,
+	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x61, 0x70,
+	0x70, 0x73, 0x12, 0x57, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x4b, 0x0a, 0x05, 0x63,
+	0x65, 0x72, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x75, 0x73,
 	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
 	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
-	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x39, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x0c, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x6b, 0x75,
+	0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x43, 0x65, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x05, 0x63, 0x65, 0x72, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x10, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x70, 0x70, 0x72,
+	0x6f, 0x76, 0x61, 0x6c, 0x73, 0x22, 0xbd, 0x01, 0x0a, 0x11, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x6c, 0x61, 0x73, 0x74, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x32, 0x0a, 0x15, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x13, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x41, 0x67, 0x65, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xd7, 0x01, 0x0a, 0x13, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x68,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x68, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22,
+	0x70, 0x0a, 0x0f, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x73, 0x22, 0x9e, 0x02, 0x0a, 0x14, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x70,
+	0x75, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x70, 0x75, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x27, 0x0a,
+	0x0f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x43, 0x61,
+	0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x70, 0x75, 0x5f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x63, 0x70, 0x75, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x2d, 0x0a, 0x12, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x70, 0x75, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x70, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
+	0x64, 0x73, 0x22, 0xa3, 0x01, 0x0a, 0x10, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x43, 0x65, 0x72,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x6e, 0x6f, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6e, 0x6f, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x12, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49,
+	0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x32, 0xdd, 0x06, 0x0a, 0x0d, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x0c, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x6b, 0x75,
 	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
 	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
-	0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65,
+	0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61,
 	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x8f, 0x01, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x3c, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x3d, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x5e, 0x0a, 0x21, 0x6f, 0x72, 0x67, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
-	0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
-	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61,
-	0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x80, 0x01, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x12, 0x37, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x38, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x0c, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x38, 0x2e, 0x6b, 0x75, 0x73,
+	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x8f, 0x01, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x12, 0x3c, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x3d, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0xa4, 0x01, 0x0a, 0x17, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x43, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x44, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x5e, 0x0a, 0x21, 0x6f, 0x72, 0x67, 0x2e,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x5a, 0x39, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x6b,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1296,64 +2045,82 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescGZIP() []byte
 	return file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDescData
 }
 
-var file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_goTypes = []interface{}{
-	(*CreateDomainRequest)(nil),          // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainRequest
-	(*CreateDomainResponse)(nil),         // 1: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainResponse
-	(*DeleteDomainRequest)(nil),          // 2: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainRequest
-	(*DeleteDomainResponse)(nil),         // 3: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainResponse
-	(*QueryDomainRequest)(nil),           // 4: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainRequest
-	(*QueryDomainResponse)(nil),          // 5: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponse
-	(*QueryDomainResponseData)(nil),      // 6: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData
-	(*NodeStatus)(nil),                   // 7: kuscia.proto.api.v1alpha1.kusciaapi.NodeStatus
-	(*UpdateDomainRequest)(nil),          // 8: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainRequest
-	(*UpdateDomainResponse)(nil),         // 9: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainResponse
-	(*BatchQueryDomainRequest)(nil),      // 10: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainRequest
-	(*BatchQueryDomainResponse)(nil),     // 11: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponse
-	(*BatchQueryDomainResponseData)(nil), // 12: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponseData
-	(*Domain)(nil),                       // 13: kuscia.proto.api.v1alpha1.kusciaapi.Domain
-	(*DeployTokenStatus)(nil),            // 14: kuscia.proto.api.v1alpha1.kusciaapi.DeployTokenStatus
-	(*AuthCenter)(nil),                   // 15: kuscia.proto.api.v1alpha1.kusciaapi.AuthCenter
-	nil,                                  // 16: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.AnnotationsEntry
-	(*v1alpha1.RequestHeader)(nil),       // 17: kuscia.proto.api.v1alpha1.RequestHeader
-	(*v1alpha1.Status)(nil),              // 18: kuscia.proto.api.v1alpha1.Status
+	(*CreateDomainRequest)(nil),             // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainRequest
+	(*CreateDomainResponse)(nil),            // 1: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainResponse
+	(*DeleteDomainRequest)(nil),             // 2: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainRequest
+	(*DeleteDomainResponse)(nil),            // 3: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainResponse
+	(*QueryDomainRequest)(nil),              // 4: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainRequest
+	(*QueryDomainResponse)(nil),             // 5: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponse
+	(*QueryDomainResponseData)(nil),         // 6: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData
+	(*NodeStatus)(nil),                      // 7: kuscia.proto.api.v1alpha1.kusciaapi.NodeStatus
+	(*UpdateDomainRequest)(nil),             // 8: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainRequest
+	(*UpdateDomainResponse)(nil),            // 9: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainResponse
+	(*BatchQueryDomainRequest)(nil),         // 10: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainRequest
+	(*BatchQueryDomainResponse)(nil),        // 11: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponse
+	(*BatchQueryDomainResponseData)(nil),    // 12: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponseData
+	(*Domain)(nil),                          // 13: kuscia.proto.api.v1alpha1.kusciaapi.Domain
+	(*DeployTokenStatus)(nil),               // 14: kuscia.proto.api.v1alpha1.kusciaapi.DeployTokenStatus
+	(*AuthCenter)(nil),                      // 15: kuscia.proto.api.v1alpha1.kusciaapi.AuthCenter
+	(*QueryDomainStatusDetailRequest)(nil),  // 16: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainStatusDetailRequest
+	(*QueryDomainStatusDetailResponse)(nil), // 17: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainStatusDetailResponse
+	(*DomainStatusDetail)(nil),              // 18: kuscia.proto.api.v1alpha1.kusciaapi.DomainStatusDetail
+	(*DomainAgentStatus)(nil),               // 19: kuscia.proto.api.v1alpha1.kusciaapi.DomainAgentStatus
+	(*DomainGatewayStatus)(nil),             // 20: kuscia.proto.api.v1alpha1.kusciaapi.DomainGatewayStatus
+	(*DomainAppStatus)(nil),                 // 21: kuscia.proto.api.v1alpha1.kusciaapi.DomainAppStatus
+	(*DomainResourceStatus)(nil),            // 22: kuscia.proto.api.v1alpha1.kusciaapi.DomainResourceStatus
+	(*DomainCertStatus)(nil),                // 23: kuscia.proto.api.v1alpha1.kusciaapi.DomainCertStatus
+	nil,                                     // 24: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.AnnotationsEntry
+	(*v1alpha1.RequestHeader)(nil),          // 25: kuscia.proto.api.v1alpha1.RequestHeader
+	(*v1alpha1.Status)(nil),                 // 26: kuscia.proto.api.v1alpha1.Status
 }
 var file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_depIdxs = []int32{
-	17, // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	25, // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
 	15, // 1: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainRequest.auth_center:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AuthCenter
-	18, // 2: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	17, // 3: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	18, // 4: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	17, // 5: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	18, // 6: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	26, // 2: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	25, // 3: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 4: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	25, // 5: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 6: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
 	6,  // 7: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData
 	7,  // 8: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.node_statuses:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.NodeStatus
 	14, // 9: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.deploy_token_statuses:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DeployTokenStatus
-	16, // 10: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.annotations:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.AnnotationsEntry
+	24, // 10: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.annotations:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.AnnotationsEntry
 	15, // 11: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponseData.auth_center:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AuthCenter
-	17, // 12: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	25, // 12: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
 	15, // 13: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainRequest.auth_center:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.AuthCenter
-	18, // 14: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	17, // 15: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	18, // 16: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	26, // 14: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	25, // 15: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 16: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
 	12, // 17: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponseData
 	13, // 18: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponseData.domains:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.Domain
 	7,  // 19: kuscia.proto.api.v1alpha1.kusciaapi.Domain.node_statuses:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.NodeStatus
-	0,  // 20: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.CreateDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainRequest
-	4,  // 21: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.QueryDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainRequest
-	8,  // 22: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.UpdateDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainRequest
-	2,  // 23: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.DeleteDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainRequest
-	10, // 24: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.BatchQueryDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainRequest
-	1,  // 25: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.CreateDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainResponse
-	5,  // 26: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.QueryDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponse
-	9,  // 27: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.UpdateDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainResponse
-	3,  // 28: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.DeleteDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainResponse
-	11, // 29: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.BatchQueryDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponse
-	25, // [25:30] is the sub-list for method output_type
-	20, // [20:25] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	25, // 20: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainStatusDetailRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 21: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainStatusDetailResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	18, // 22: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainStatusDetailResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainStatusDetail
+	19, // 23: kuscia.proto.api.v1alpha1.kusciaapi.DomainStatusDetail.agents:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainAgentStatus
+	20, // 24: kuscia.proto.api.v1alpha1.kusciaapi.DomainStatusDetail.gateways:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainGatewayStatus
+	21, // 25: kuscia.proto.api.v1alpha1.kusciaapi.DomainStatusDetail.apps:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainAppStatus
+	22, // 26: kuscia.proto.api.v1alpha1.kusciaapi.DomainStatusDetail.resources:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainResourceStatus
+	23, // 27: kuscia.proto.api.v1alpha1.kusciaapi.DomainStatusDetail.certs:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainCertStatus
+	0,  // 28: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.CreateDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainRequest
+	4,  // 29: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.QueryDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainRequest
+	8,  // 30: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.UpdateDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainRequest
+	2,  // 31: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.DeleteDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainRequest
+	10, // 32: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.BatchQueryDomain:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainRequest
+	16, // 33: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.QueryDomainStatusDetail:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainStatusDetailRequest
+	1,  // 34: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.CreateDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainResponse
+	5,  // 35: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.QueryDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainResponse
+	9,  // 36: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.UpdateDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainResponse
+	3,  // 37: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.DeleteDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainResponse
+	11, // 38: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.BatchQueryDomain:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainResponse
+	17, // 39: kuscia.proto.api.v1alpha1.kusciaapi.DomainService.QueryDomainStatusDetail:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainStatusDetailResponse
+	34, // [34:40] is the sub-list for method output_type
+	28, // [28:34] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_init() }
@@ -1554,6 +2321,102 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_init() {
 				return nil
 			}
 		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDomainStatusDetailRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryDomainStatusDetailResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainStatusDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainAgentStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainGatewayStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainAppStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainResourceStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainCertStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1561,7 +2424,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kuscia_proto_api_v1alpha1_kusciaapi_domain_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -33,11 +33,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	DomainService_CreateDomain_FullMethodName     = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/CreateDomain"
-	DomainService_QueryDomain_FullMethodName      = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/QueryDomain"
-	DomainService_UpdateDomain_FullMethodName     = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/UpdateDomain"
-	DomainService_DeleteDomain_FullMethodName     = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/DeleteDomain"
-	DomainService_BatchQueryDomain_FullMethodName = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/BatchQueryDomain"
+	DomainService_CreateDomain_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/CreateDomain"
+	DomainService_QueryDomain_FullMethodName             = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/QueryDomain"
+	DomainService_UpdateDomain_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/UpdateDomain"
+	DomainService_DeleteDomain_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/DeleteDomain"
+	DomainService_BatchQueryDomain_FullMethodName        = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/BatchQueryDomain"
+	DomainService_QueryDomainStatusDetail_FullMethodName = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainService/QueryDomainStatusDetail"
 )
 
 // DomainServiceClient is the client API for DomainService service.
@@ -49,6 +50,7 @@ type DomainServiceClient interface {
 	UpdateDomain(ctx context.Context, in *UpdateDomainRequest, opts ...grpc.CallOption) (*UpdateDomainResponse, error)
 	DeleteDomain(ctx context.Context, in *DeleteDomainRequest, opts ...grpc.CallOption) (*DeleteDomainResponse, error)
 	BatchQueryDomain(ctx context.Context, in *BatchQueryDomainRequest, opts ...grpc.CallOption) (*BatchQueryDomainResponse, error)
+	QueryDomainStatusDetail(ctx context.Context, in *QueryDomainStatusDetailRequest, opts ...grpc.CallOption) (*QueryDomainStatusDetailResponse, error)
 }
 
 type domainServiceClient struct {
@@ -104,6 +106,15 @@ func (c *domainServiceClient) BatchQueryDomain(ctx context.Context, in *BatchQue
 	return out, nil
 }
 
+func (c *domainServiceClient) QueryDomainStatusDetail(ctx context.Context, in *QueryDomainStatusDetailRequest, opts ...grpc.CallOption) (*QueryDomainStatusDetailResponse, error) {
+	out := new(QueryDomainStatusDetailResponse)
+	err := c.cc.Invoke(ctx, DomainService_QueryDomainStatusDetail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DomainServiceServer is the server API for DomainService service.
 // All implementations must embed UnimplementedDomainServiceServer
 // for forward compatibility
@@ -113,6 +124,7 @@ type DomainServiceServer interface {
 	UpdateDomain(context.Context, *UpdateDomainRequest) (*UpdateDomainResponse, error)
 	DeleteDomain(context.Context, *DeleteDomainRequest) (*DeleteDomainResponse, error)
 	BatchQueryDomain(context.Context, *BatchQueryDomainRequest) (*BatchQueryDomainResponse, error)
+	QueryDomainStatusDetail(context.Context, *QueryDomainStatusDetailRequest) (*QueryDomainStatusDetailResponse, error)
 	mustEmbedUnimplementedDomainServiceServer()
 }
 
@@ -135,6 +147,9 @@ func (UnimplementedDomainServiceServer) DeleteDomain(context.Context, *DeleteDom
 func (UnimplementedDomainServiceServer) BatchQueryDomain(context.Context, *BatchQueryDomainRequest) (*BatchQueryDomainResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchQueryDomain not implemented")
 }
+func (UnimplementedDomainServiceServer) QueryDomainStatusDetail(context.Context, *QueryDomainStatusDetailRequest) (*QueryDomainStatusDetailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDomainStatusDetail not implemented")
+}
 func (UnimplementedDomainServiceServer) mustEmbedUnimplementedDomainServiceServer() {}
 
 // UnsafeDomainServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -238,6 +253,24 @@ func _DomainService_BatchQueryDomain_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DomainService_QueryDomainStatusDetail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDomainStatusDetailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainServiceServer).QueryDomainStatusDetail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainService_QueryDomainStatusDetail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainServiceServer).QueryDomainStatusDetail(ctx, req.(*QueryDomainStatusDetailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DomainService_ServiceDesc is the grpc.ServiceDesc for DomainService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +298,10 @@ var DomainService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchQueryDomain",
 			Handler:    _DomainService_BatchQueryDomain_Handler,
 		},
+		{
+			MethodName: "QueryDomainStatusDetail",
+			Handler:    _DomainService_QueryDomainStatusDetail_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kuscia/proto/api/v1alpha1/kusciaapi/domain.proto",
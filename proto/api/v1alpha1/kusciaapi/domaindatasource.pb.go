@@ -894,6 +894,289 @@ func (x *ListDomainDataSourceResponse) GetData() *DomainDataSourceList {
 	return nil
 }
 
+type TestDomainDataSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header       *v1alpha1.RequestHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	DomainId     string                  `protobuf:"bytes,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	DatasourceId string                  `protobuf:"bytes,3,opt,name=datasource_id,json=datasourceId,proto3" json:"datasource_id,omitempty"`
+}
+
+func (x *TestDomainDataSourceRequest) Reset() {
+	*x = TestDomainDataSourceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestDomainDataSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestDomainDataSourceRequest) ProtoMessage() {}
+
+func (x *TestDomainDataSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestDomainDataSourceRequest.ProtoReflect.Descriptor instead.
+func (*TestDomainDataSourceRequest) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TestDomainDataSourceRequest) GetHeader() *v1alpha1.RequestHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *TestDomainDataSourceRequest) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+func (x *TestDomainDataSourceRequest) GetDatasourceId() string {
+	if x != nil {
+		return x.DatasourceId
+	}
+	return ""
+}
+
+type TestDomainDataSourceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *v1alpha1.Status                  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   *TestDomainDataSourceResponseData `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *TestDomainDataSourceResponse) Reset() {
+	*x = TestDomainDataSourceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestDomainDataSourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestDomainDataSourceResponse) ProtoMessage() {}
+
+func (x *TestDomainDataSourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestDomainDataSourceResponse.ProtoReflect.Descriptor instead.
+func (*TestDomainDataSourceResponse) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TestDomainDataSourceResponse) GetStatus() *v1alpha1.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *TestDomainDataSourceResponse) GetData() *TestDomainDataSourceResponseData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type TestDomainDataSourceResponseData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DomainId     string `protobuf:"bytes,1,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	DatasourceId string `protobuf:"bytes,2,opt,name=datasource_id,json=datasourceId,proto3" json:"datasource_id,omitempty"`
+	Type         string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// whether all checks passed, the datasource is usable if true.
+	Passed bool `protobuf:"varint,4,opt,name=passed,proto3" json:"passed,omitempty"`
+	// checks in order of Connectivity, Authentication and Permission, the checks after a failed one are skipped.
+	Checks []*DataSourceCheck `protobuf:"bytes,5,rep,name=checks,proto3" json:"checks,omitempty"`
+}
+
+func (x *TestDomainDataSourceResponseData) Reset() {
+	*x = TestDomainDataSourceResponseData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestDomainDataSourceResponseData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestDomainDataSourceResponseData) ProtoMessage() {}
+
+func (x *TestDomainDataSourceResponseData) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestDomainDataSourceResponseData.ProtoReflect.Descriptor instead.
+func (*TestDomainDataSourceResponseData) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TestDomainDataSourceResponseData) GetDomainId() string {
+	if x != nil {
+		return x.DomainId
+	}
+	return ""
+}
+
+func (x *TestDomainDataSourceResponseData) GetDatasourceId() string {
+	if x != nil {
+		return x.DatasourceId
+	}
+	return ""
+}
+
+func (x *TestDomainDataSourceResponseData) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TestDomainDataSourceResponseData) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *TestDomainDataSourceResponseData) GetChecks() []*DataSourceCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type DataSourceCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// enum [Connectivity, Authentication, Permission]
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// enum [Passed, Failed, Skipped]
+	Result string `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	// what was checked and why it failed.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// how to fix the failure.
+	Suggestion string `protobuf:"bytes,4,opt,name=suggestion,proto3" json:"suggestion,omitempty"`
+	// time cost of the check in milliseconds.
+	ElapsedMs int64 `protobuf:"varint,5,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+}
+
+func (x *DataSourceCheck) Reset() {
+	*x = DataSourceCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataSourceCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSourceCheck) ProtoMessage() {}
+
+func (x *DataSourceCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSourceCheck.ProtoReflect.Descriptor instead.
+func (*DataSourceCheck) Descriptor() ([]byte, []int) {
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DataSourceCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DataSourceCheck) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *DataSourceCheck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DataSourceCheck) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+func (x *DataSourceCheck) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
 type DomainDataSourceList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -905,7 +1188,7 @@ type DomainDataSourceList struct {
 func (x *DomainDataSourceList) Reset() {
 	*x = DomainDataSourceList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[14]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -918,7 +1201,7 @@ func (x *DomainDataSourceList) String() string {
 func (*DomainDataSourceList) ProtoMessage() {}
 
 func (x *DomainDataSourceList) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[14]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -931,7 +1214,7 @@ func (x *DomainDataSourceList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DomainDataSourceList.ProtoReflect.Descriptor instead.
 func (*DomainDataSourceList) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{14}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *DomainDataSourceList) GetDatasourceList() []*DomainDataSource {
@@ -971,7 +1254,7 @@ type DomainDataSource struct {
 func (x *DomainDataSource) Reset() {
 	*x = DomainDataSource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[15]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -984,7 +1267,7 @@ func (x *DomainDataSource) String() string {
 func (*DomainDataSource) ProtoMessage() {}
 
 func (x *DomainDataSource) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[15]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,7 +1280,7 @@ func (x *DomainDataSource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DomainDataSource.ProtoReflect.Descriptor instead.
 func (*DomainDataSource) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{15}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DomainDataSource) GetDomainId() string {
@@ -1076,7 +1359,7 @@ type DataSourceInfo struct {
 func (x *DataSourceInfo) Reset() {
 	*x = DataSourceInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[16]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1089,7 +1372,7 @@ func (x *DataSourceInfo) String() string {
 func (*DataSourceInfo) ProtoMessage() {}
 
 func (x *DataSourceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[16]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,7 +1385,7 @@ func (x *DataSourceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataSourceInfo.ProtoReflect.Descriptor instead.
 func (*DataSourceInfo) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{16}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DataSourceInfo) GetLocalfs() *LocalDataSourceInfo {
@@ -1146,7 +1429,7 @@ type LocalDataSourceInfo struct {
 func (x *LocalDataSourceInfo) Reset() {
 	*x = LocalDataSourceInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[17]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1159,7 +1442,7 @@ func (x *LocalDataSourceInfo) String() string {
 func (*LocalDataSourceInfo) ProtoMessage() {}
 
 func (x *LocalDataSourceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[17]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1172,7 +1455,7 @@ func (x *LocalDataSourceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalDataSourceInfo.ProtoReflect.Descriptor instead.
 func (*LocalDataSourceInfo) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{17}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *LocalDataSourceInfo) GetPath() string {
@@ -1210,7 +1493,7 @@ type OssDataSourceInfo struct {
 func (x *OssDataSourceInfo) Reset() {
 	*x = OssDataSourceInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[18]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1223,7 +1506,7 @@ func (x *OssDataSourceInfo) String() string {
 func (*OssDataSourceInfo) ProtoMessage() {}
 
 func (x *OssDataSourceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[18]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1236,7 +1519,7 @@ func (x *OssDataSourceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OssDataSourceInfo.ProtoReflect.Descriptor instead.
 func (*OssDataSourceInfo) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{18}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *OssDataSourceInfo) GetEndpoint() string {
@@ -1314,7 +1597,7 @@ type DatabaseDataSourceInfo struct {
 func (x *DatabaseDataSourceInfo) Reset() {
 	*x = DatabaseDataSourceInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[19]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1327,7 +1610,7 @@ func (x *DatabaseDataSourceInfo) String() string {
 func (*DatabaseDataSourceInfo) ProtoMessage() {}
 
 func (x *DatabaseDataSourceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[19]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1340,7 +1623,7 @@ func (x *DatabaseDataSourceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatabaseDataSourceInfo.ProtoReflect.Descriptor instead.
 func (*DatabaseDataSourceInfo) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{19}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *DatabaseDataSourceInfo) GetEndpoint() string {
@@ -1386,7 +1669,7 @@ type OdpsDataSourceInfo struct {
 func (x *OdpsDataSourceInfo) Reset() {
 	*x = OdpsDataSourceInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[20]
+		mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1399,7 +1682,7 @@ func (x *OdpsDataSourceInfo) String() string {
 func (*OdpsDataSourceInfo) ProtoMessage() {}
 
 func (x *OdpsDataSourceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[20]
+	mi := &file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1412,7 +1695,7 @@ func (x *OdpsDataSourceInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OdpsDataSourceInfo.ProtoReflect.Descriptor instead.
 func (*OdpsDataSourceInfo) Descriptor() ([]byte, []int) {
-	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{20}
+	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *OdpsDataSourceInfo) GetEndpoint() string {
@@ -1614,162 +1897,217 @@ var file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDesc = []
 	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
 	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
 	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x76, 0x0a, 0x14, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x5e, 0x0a, 0x0f, 0x64,
-	0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0e, 0x64, 0x61, 0x74,
-	0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0xa1, 0x02, 0x0a, 0x10,
-	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x23, 0x0a,
-	0x0d, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x47, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x33, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x69,
-	0x6e, 0x66, 0x6f, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69,
-	0x6e, 0x66, 0x6f, 0x4b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6c, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6c, 0x79, 0x22,
-	0xd4, 0x02, 0x0a, 0x0e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x52, 0x0a, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x66, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6c,
-	0x6f, 0x63, 0x61, 0x6c, 0x66, 0x73, 0x12, 0x48, 0x0a, 0x03, 0x6f, 0x73, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x61, 0x74, 0x61, 0x22, 0xa1, 0x01, 0x0a, 0x1b, 0x54, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x73, 0x73, 0x44, 0x61, 0x74,
-	0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x6f, 0x73, 0x73,
-	0x12, 0x57, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x04, 0x6f, 0x64, 0x70,
-	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x64,
-	0x70, 0x73, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x04, 0x6f, 0x64, 0x70, 0x73, 0x22, 0x29, 0x0a, 0x13, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
-	0x68, 0x22, 0x8e, 0x02, 0x0a, 0x11, 0x4f, 0x73, 0x73, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70,
-	0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65,
-	0x66, 0x69, 0x78, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b, 0x65,
-	0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x68, 0x6f,
-	0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x76, 0x69, 0x72, 0x74, 0x75, 0x61,
-	0x6c, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x21, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a,
-	0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x12, 0x4f, 0x64, 0x70, 0x73, 0x44, 0x61,
-	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08,
-	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b, 0x65, 0x79,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x32, 0xf4, 0x07, 0x0a, 0x17, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
-	0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xa1,
-	0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x42, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0xb4, 0x01, 0x0a, 0x1c, 0x54, 0x65, 0x73, 0x74,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x59, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x45, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xde,
+	0x01, 0x0a, 0x20, 0x54, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
+	0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x49, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65,
+	0x64, 0x12, 0x4c, 0x0a, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x22,
+	0x96, 0x01, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x67,
+	0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6c, 0x61,
+	0x70, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65,
+	0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73, 0x22, 0x76, 0x0a, 0x14, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x5e, 0x0a, 0x0f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c,
+	0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x75, 0x73, 0x63,
 	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
 	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e,
-	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
-	0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x9e, 0x01, 0x0a, 0x15, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x41, 0x2e, 0x6b,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x22, 0xa1, 0x02, 0x0a, 0x10, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x47, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f,
+	0x12, 0x19, 0x0a, 0x08, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x66, 0x6f, 0x4b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6c, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6c, 0x79, 0x22, 0xd4, 0x02, 0x0a, 0x0e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x52, 0x0a, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x66, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x66, 0x73, 0x12, 0x48, 0x0a, 0x03, 0x6f,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
+	0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x4f,
+	0x73, 0x73, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x03, 0x6f, 0x73, 0x73, 0x12, 0x57, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x4b,
+	0x0a, 0x04, 0x6f, 0x64, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6b,
 	0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
 	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61,
-	0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
-	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x42, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
-	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0xa1, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f,
+	0x70, 0x69, 0x2e, 0x4f, 0x64, 0x70, 0x73, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x6f, 0x64, 0x70, 0x73, 0x22, 0x29, 0x0a, 0x13, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x8e, 0x02, 0x0a, 0x11, 0x4f, 0x73, 0x73, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b,
+	0x65, 0x79, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x69, 0x72, 0x74,
+	0x75, 0x61, 0x6c, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x76,
+	0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x12, 0x4f,
+	0x64, 0x70, 0x73, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x65,
+	0x79, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x32, 0x92, 0x09, 0x0a, 0x17, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0xa1, 0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f,
 	0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x42,
 	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70,
 	0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69,
-	0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69,
 	0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0xa1, 0x01, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x12, 0x42, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44,
+	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44,
 	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0xad, 0x01, 0x0a, 0x1a,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x46, 0x2e, 0x6b, 0x75, 0x73,
-	0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69,
-	0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x47, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b,
-	0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x9b, 0x01, 0x0a, 0x14,
-	0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x40, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x9e, 0x01, 0x0a, 0x15, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x41, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x42, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x41, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x5e, 0x0a, 0x21, 0x6f, 0x72, 0x67,
-	0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x5a, 0x39,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f,
-	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0xa1, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x42, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0xa1, 0x01, 0x0a,
+	0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
+	0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x42, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
+	0x69, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0xad, 0x01, 0x0a, 0x1a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x46, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x47, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61,
+	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x9b, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x40, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x41, 0x2e, 0x6b, 0x75,
+	0x73, 0x63, 0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
+	0x69, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x9b,
+	0x01, 0x0a, 0x14, 0x54, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74,
+	0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x40, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x65,
+	0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x41, 0x2e, 0x6b, 0x75, 0x73, 0x63,
+	0x69, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x2e,
+	0x54, 0x65, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x5e, 0x0a, 0x21,
+	0x6f, 0x72, 0x67, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70,
+	0x69, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x6b, 0x75, 0x73, 0x63, 0x69, 0x61, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1784,7 +2122,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescGZIP
 	return file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDescData
 }
 
-var file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_goTypes = []interface{}{
 	(*CreateDomainDataSourceRequest)(nil),      // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceRequest
 	(*CreateDomainDataSourceResponse)(nil),     // 1: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceResponse
@@ -1800,59 +2138,69 @@ var file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_goTypes = []
 	(*BatchQueryDomainDataSourceResponse)(nil), // 11: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceResponse
 	(*ListDomainDataSourceRequest)(nil),        // 12: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceRequest
 	(*ListDomainDataSourceResponse)(nil),       // 13: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceResponse
-	(*DomainDataSourceList)(nil),               // 14: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList
-	(*DomainDataSource)(nil),                   // 15: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource
-	(*DataSourceInfo)(nil),                     // 16: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
-	(*LocalDataSourceInfo)(nil),                // 17: kuscia.proto.api.v1alpha1.kusciaapi.LocalDataSourceInfo
-	(*OssDataSourceInfo)(nil),                  // 18: kuscia.proto.api.v1alpha1.kusciaapi.OssDataSourceInfo
-	(*DatabaseDataSourceInfo)(nil),             // 19: kuscia.proto.api.v1alpha1.kusciaapi.DatabaseDataSourceInfo
-	(*OdpsDataSourceInfo)(nil),                 // 20: kuscia.proto.api.v1alpha1.kusciaapi.OdpsDataSourceInfo
-	(*v1alpha1.RequestHeader)(nil),             // 21: kuscia.proto.api.v1alpha1.RequestHeader
-	(*v1alpha1.Status)(nil),                    // 22: kuscia.proto.api.v1alpha1.Status
+	(*TestDomainDataSourceRequest)(nil),        // 14: kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceRequest
+	(*TestDomainDataSourceResponse)(nil),       // 15: kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceResponse
+	(*TestDomainDataSourceResponseData)(nil),   // 16: kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceResponseData
+	(*DataSourceCheck)(nil),                    // 17: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceCheck
+	(*DomainDataSourceList)(nil),               // 18: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList
+	(*DomainDataSource)(nil),                   // 19: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource
+	(*DataSourceInfo)(nil),                     // 20: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
+	(*LocalDataSourceInfo)(nil),                // 21: kuscia.proto.api.v1alpha1.kusciaapi.LocalDataSourceInfo
+	(*OssDataSourceInfo)(nil),                  // 22: kuscia.proto.api.v1alpha1.kusciaapi.OssDataSourceInfo
+	(*DatabaseDataSourceInfo)(nil),             // 23: kuscia.proto.api.v1alpha1.kusciaapi.DatabaseDataSourceInfo
+	(*OdpsDataSourceInfo)(nil),                 // 24: kuscia.proto.api.v1alpha1.kusciaapi.OdpsDataSourceInfo
+	(*v1alpha1.RequestHeader)(nil),             // 25: kuscia.proto.api.v1alpha1.RequestHeader
+	(*v1alpha1.Status)(nil),                    // 26: kuscia.proto.api.v1alpha1.Status
 }
 var file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_depIdxs = []int32{
-	21, // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	16, // 1: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceRequest.info:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
-	22, // 2: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	25, // 0: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	20, // 1: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceRequest.info:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
+	26, // 2: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
 	2,  // 3: kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceResponseData
-	21, // 4: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	16, // 5: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceRequest.info:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
-	22, // 6: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	21, // 7: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	22, // 8: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	21, // 9: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	22, // 10: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	15, // 11: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource
-	21, // 12: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	25, // 4: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	20, // 5: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceRequest.info:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
+	26, // 6: kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	25, // 7: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 8: kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	25, // 9: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 10: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	19, // 11: kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource
+	25, // 12: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
 	9,  // 13: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceRequest.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceRequestData
-	22, // 14: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	14, // 15: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList
-	21, // 16: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
-	22, // 17: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
-	14, // 18: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList
-	15, // 19: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList.datasource_list:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource
-	16, // 20: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource.info:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
-	17, // 21: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.localfs:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.LocalDataSourceInfo
-	18, // 22: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.oss:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.OssDataSourceInfo
-	19, // 23: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.database:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DatabaseDataSourceInfo
-	20, // 24: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.odps:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.OdpsDataSourceInfo
-	0,  // 25: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.CreateDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceRequest
-	7,  // 26: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.QueryDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceRequest
-	3,  // 27: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.UpdateDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceRequest
-	5,  // 28: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.DeleteDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceRequest
-	10, // 29: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.BatchQueryDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceRequest
-	12, // 30: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.ListDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceRequest
-	1,  // 31: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.CreateDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceResponse
-	8,  // 32: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.QueryDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceResponse
-	4,  // 33: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.UpdateDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceResponse
-	6,  // 34: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.DeleteDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceResponse
-	11, // 35: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.BatchQueryDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceResponse
-	13, // 36: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.ListDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceResponse
-	31, // [31:37] is the sub-list for method output_type
-	25, // [25:31] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	26, // 14: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	18, // 15: kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList
+	25, // 16: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 17: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	18, // 18: kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList
+	25, // 19: kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceRequest.header:type_name -> kuscia.proto.api.v1alpha1.RequestHeader
+	26, // 20: kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceResponse.status:type_name -> kuscia.proto.api.v1alpha1.Status
+	16, // 21: kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceResponse.data:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceResponseData
+	17, // 22: kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceResponseData.checks:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DataSourceCheck
+	19, // 23: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceList.datasource_list:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource
+	20, // 24: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSource.info:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo
+	21, // 25: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.localfs:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.LocalDataSourceInfo
+	22, // 26: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.oss:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.OssDataSourceInfo
+	23, // 27: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.database:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.DatabaseDataSourceInfo
+	24, // 28: kuscia.proto.api.v1alpha1.kusciaapi.DataSourceInfo.odps:type_name -> kuscia.proto.api.v1alpha1.kusciaapi.OdpsDataSourceInfo
+	0,  // 29: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.CreateDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceRequest
+	7,  // 30: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.QueryDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceRequest
+	3,  // 31: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.UpdateDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceRequest
+	5,  // 32: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.DeleteDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceRequest
+	10, // 33: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.BatchQueryDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceRequest
+	12, // 34: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.ListDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceRequest
+	14, // 35: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.TestDomainDataSource:input_type -> kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceRequest
+	1,  // 36: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.CreateDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.CreateDomainDataSourceResponse
+	8,  // 37: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.QueryDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.QueryDomainDataSourceResponse
+	4,  // 38: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.UpdateDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.UpdateDomainDataSourceResponse
+	6,  // 39: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.DeleteDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.DeleteDomainDataSourceResponse
+	11, // 40: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.BatchQueryDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.BatchQueryDomainDataSourceResponse
+	13, // 41: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.ListDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.ListDomainDataSourceResponse
+	15, // 42: kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService.TestDomainDataSource:output_type -> kuscia.proto.api.v1alpha1.kusciaapi.TestDomainDataSourceResponse
+	36, // [36:43] is the sub-list for method output_type
+	29, // [29:36] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() }
@@ -2030,7 +2378,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainDataSourceList); i {
+			switch v := v.(*TestDomainDataSourceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2042,7 +2390,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainDataSource); i {
+			switch v := v.(*TestDomainDataSourceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2054,7 +2402,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataSourceInfo); i {
+			switch v := v.(*TestDomainDataSourceResponseData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2066,7 +2414,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LocalDataSourceInfo); i {
+			switch v := v.(*DataSourceCheck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2078,7 +2426,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OssDataSourceInfo); i {
+			switch v := v.(*DomainDataSourceList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2090,7 +2438,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DatabaseDataSourceInfo); i {
+			switch v := v.(*DomainDataSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2102,6 +2450,54 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			}
 		}
 		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataSourceInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalDataSourceInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OssDataSourceInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatabaseDataSourceInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OdpsDataSourceInfo); i {
 			case 0:
 				return &v.state
@@ -2122,7 +2518,7 @@ func file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_kuscia_proto_api_v1alpha1_kusciaapi_domaindatasource_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   21,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -39,6 +39,7 @@ const (
 	DomainDataSourceService_DeleteDomainDataSource_FullMethodName     = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService/DeleteDomainDataSource"
 	DomainDataSourceService_BatchQueryDomainDataSource_FullMethodName = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService/BatchQueryDomainDataSource"
 	DomainDataSourceService_ListDomainDataSource_FullMethodName       = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService/ListDomainDataSource"
+	DomainDataSourceService_TestDomainDataSource_FullMethodName       = "/kuscia.proto.api.v1alpha1.kusciaapi.DomainDataSourceService/TestDomainDataSource"
 )
 
 // DomainDataSourceServiceClient is the client API for DomainDataSourceService service.
@@ -51,6 +52,7 @@ type DomainDataSourceServiceClient interface {
 	DeleteDomainDataSource(ctx context.Context, in *DeleteDomainDataSourceRequest, opts ...grpc.CallOption) (*DeleteDomainDataSourceResponse, error)
 	BatchQueryDomainDataSource(ctx context.Context, in *BatchQueryDomainDataSourceRequest, opts ...grpc.CallOption) (*BatchQueryDomainDataSourceResponse, error)
 	ListDomainDataSource(ctx context.Context, in *ListDomainDataSourceRequest, opts ...grpc.CallOption) (*ListDomainDataSourceResponse, error)
+	TestDomainDataSource(ctx context.Context, in *TestDomainDataSourceRequest, opts ...grpc.CallOption) (*TestDomainDataSourceResponse, error)
 }
 
 type domainDataSourceServiceClient struct {
@@ -115,6 +117,15 @@ func (c *domainDataSourceServiceClient) ListDomainDataSource(ctx context.Context
 	return out, nil
 }
 
+func (c *domainDataSourceServiceClient) TestDomainDataSource(ctx context.Context, in *TestDomainDataSourceRequest, opts ...grpc.CallOption) (*TestDomainDataSourceResponse, error) {
+	out := new(TestDomainDataSourceResponse)
+	err := c.cc.Invoke(ctx, DomainDataSourceService_TestDomainDataSource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DomainDataSourceServiceServer is the server API for DomainDataSourceService service.
 // All implementations must embed UnimplementedDomainDataSourceServiceServer
 // for forward compatibility
@@ -125,6 +136,7 @@ type DomainDataSourceServiceServer interface {
 	DeleteDomainDataSource(context.Context, *DeleteDomainDataSourceRequest) (*DeleteDomainDataSourceResponse, error)
 	BatchQueryDomainDataSource(context.Context, *BatchQueryDomainDataSourceRequest) (*BatchQueryDomainDataSourceResponse, error)
 	ListDomainDataSource(context.Context, *ListDomainDataSourceRequest) (*ListDomainDataSourceResponse, error)
+	TestDomainDataSource(context.Context, *TestDomainDataSourceRequest) (*TestDomainDataSourceResponse, error)
 	mustEmbedUnimplementedDomainDataSourceServiceServer()
 }
 
@@ -150,6 +162,9 @@ func (UnimplementedDomainDataSourceServiceServer) BatchQueryDomainDataSource(con
 func (UnimplementedDomainDataSourceServiceServer) ListDomainDataSource(context.Context, *ListDomainDataSourceRequest) (*ListDomainDataSourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDomainDataSource not implemented")
 }
+func (UnimplementedDomainDataSourceServiceServer) TestDomainDataSource(context.Context, *TestDomainDataSourceRequest) (*TestDomainDataSourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestDomainDataSource not implemented")
+}
 func (UnimplementedDomainDataSourceServiceServer) mustEmbedUnimplementedDomainDataSourceServiceServer() {
 }
 
@@ -272,6 +287,24 @@ func _DomainDataSourceService_ListDomainDataSource_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DomainDataSourceService_TestDomainDataSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestDomainDataSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainDataSourceServiceServer).TestDomainDataSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainDataSourceService_TestDomainDataSource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainDataSourceServiceServer).TestDomainDataSource(ctx, req.(*TestDomainDataSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DomainDataSourceService_ServiceDesc is the grpc.ServiceDesc for DomainDataSourceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -303,6 +336,10 @@ var DomainDataSourceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListDomainDataSource",
 			Handler:    _DomainDataSourceService_ListDomainDataSource_Handler,
 		},
+		{
+			MethodName: "TestDomainDataSource",
+			Handler:    _DomainDataSourceService_TestDomainDataSource_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kuscia/proto/api/v1alpha1/kusciaapi/domaindatasource.proto",
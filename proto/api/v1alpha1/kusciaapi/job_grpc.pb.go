@@ -33,16 +33,19 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	JobService_CreateJob_FullMethodName           = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/CreateJob"
-	JobService_QueryJob_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/QueryJob"
-	JobService_BatchQueryJobStatus_FullMethodName = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/BatchQueryJobStatus"
-	JobService_StopJob_FullMethodName             = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/StopJob"
-	JobService_RestartJob_FullMethodName          = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/RestartJob"
-	JobService_SuspendJob_FullMethodName          = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/SuspendJob"
-	JobService_CancelJob_FullMethodName           = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/CancelJob"
-	JobService_DeleteJob_FullMethodName           = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/DeleteJob"
-	JobService_WatchJob_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/WatchJob"
-	JobService_ApproveJob_FullMethodName          = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/ApproveJob"
+	JobService_CreateJob_FullMethodName             = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/CreateJob"
+	JobService_QueryJob_FullMethodName              = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/QueryJob"
+	JobService_BatchQueryJobStatus_FullMethodName   = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/BatchQueryJobStatus"
+	JobService_StopJob_FullMethodName               = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/StopJob"
+	JobService_RestartJob_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/RestartJob"
+	JobService_SuspendJob_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/SuspendJob"
+	JobService_CancelJob_FullMethodName             = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/CancelJob"
+	JobService_DeleteJob_FullMethodName             = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/DeleteJob"
+	JobService_WatchJob_FullMethodName              = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/WatchJob"
+	JobService_ApproveJob_FullMethodName            = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/ApproveJob"
+	JobService_ListPendingApprovals_FullMethodName  = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/ListPendingApprovals"
+	JobService_QueryJobResourceUsage_FullMethodName = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/QueryJobResourceUsage"
+	JobService_QueryJobHistory_FullMethodName       = "/kuscia.proto.api.v1alpha1.kusciaapi.JobService/QueryJobHistory"
 )
 
 // JobServiceClient is the client API for JobService service.
@@ -59,6 +62,9 @@ type JobServiceClient interface {
 	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
 	WatchJob(ctx context.Context, in *WatchJobRequest, opts ...grpc.CallOption) (JobService_WatchJobClient, error)
 	ApproveJob(ctx context.Context, in *ApproveJobRequest, opts ...grpc.CallOption) (*ApproveJobResponse, error)
+	ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error)
+	QueryJobResourceUsage(ctx context.Context, in *QueryJobResourceUsageRequest, opts ...grpc.CallOption) (*QueryJobResourceUsageResponse, error)
+	QueryJobHistory(ctx context.Context, in *QueryJobHistoryRequest, opts ...grpc.CallOption) (*QueryJobHistoryResponse, error)
 }
 
 type jobServiceClient struct {
@@ -182,6 +188,33 @@ func (c *jobServiceClient) ApproveJob(ctx context.Context, in *ApproveJobRequest
 	return out, nil
 }
 
+func (c *jobServiceClient) ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error) {
+	out := new(ListPendingApprovalsResponse)
+	err := c.cc.Invoke(ctx, JobService_ListPendingApprovals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) QueryJobResourceUsage(ctx context.Context, in *QueryJobResourceUsageRequest, opts ...grpc.CallOption) (*QueryJobResourceUsageResponse, error) {
+	out := new(QueryJobResourceUsageResponse)
+	err := c.cc.Invoke(ctx, JobService_QueryJobResourceUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) QueryJobHistory(ctx context.Context, in *QueryJobHistoryRequest, opts ...grpc.CallOption) (*QueryJobHistoryResponse, error) {
+	out := new(QueryJobHistoryResponse)
+	err := c.cc.Invoke(ctx, JobService_QueryJobHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JobServiceServer is the server API for JobService service.
 // All implementations must embed UnimplementedJobServiceServer
 // for forward compatibility
@@ -196,6 +229,9 @@ type JobServiceServer interface {
 	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
 	WatchJob(*WatchJobRequest, JobService_WatchJobServer) error
 	ApproveJob(context.Context, *ApproveJobRequest) (*ApproveJobResponse, error)
+	ListPendingApprovals(context.Context, *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error)
+	QueryJobResourceUsage(context.Context, *QueryJobResourceUsageRequest) (*QueryJobResourceUsageResponse, error)
+	QueryJobHistory(context.Context, *QueryJobHistoryRequest) (*QueryJobHistoryResponse, error)
 	mustEmbedUnimplementedJobServiceServer()
 }
 
@@ -233,6 +269,15 @@ func (UnimplementedJobServiceServer) WatchJob(*WatchJobRequest, JobService_Watch
 func (UnimplementedJobServiceServer) ApproveJob(context.Context, *ApproveJobRequest) (*ApproveJobResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApproveJob not implemented")
 }
+func (UnimplementedJobServiceServer) ListPendingApprovals(context.Context, *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingApprovals not implemented")
+}
+func (UnimplementedJobServiceServer) QueryJobResourceUsage(context.Context, *QueryJobResourceUsageRequest) (*QueryJobResourceUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryJobResourceUsage not implemented")
+}
+func (UnimplementedJobServiceServer) QueryJobHistory(context.Context, *QueryJobHistoryRequest) (*QueryJobHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryJobHistory not implemented")
+}
 func (UnimplementedJobServiceServer) mustEmbedUnimplementedJobServiceServer() {}
 
 // UnsafeJobServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -429,6 +474,60 @@ func _JobService_ApproveJob_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobService_ListPendingApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListPendingApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ListPendingApprovals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListPendingApprovals(ctx, req.(*ListPendingApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_QueryJobResourceUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryJobResourceUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).QueryJobResourceUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_QueryJobResourceUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).QueryJobResourceUsage(ctx, req.(*QueryJobResourceUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_QueryJobHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryJobHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).QueryJobHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_QueryJobHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).QueryJobHistory(ctx, req.(*QueryJobHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // JobService_ServiceDesc is the grpc.ServiceDesc for JobService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -472,6 +571,18 @@ var JobService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ApproveJob",
 			Handler:    _JobService_ApproveJob_Handler,
 		},
+		{
+			MethodName: "ListPendingApprovals",
+			Handler:    _JobService_ListPendingApprovals_Handler,
+		},
+		{
+			MethodName: "QueryJobResourceUsage",
+			Handler:    _JobService_QueryJobResourceUsage_Handler,
+		},
+		{
+			MethodName: "QueryJobHistory",
+			Handler:    _JobService_QueryJobHistory_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
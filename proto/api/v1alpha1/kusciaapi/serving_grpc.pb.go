@@ -38,6 +38,9 @@ const (
 	ServingService_UpdateServing_FullMethodName           = "/kuscia.proto.api.v1alpha1.kusciaapi.ServingService/UpdateServing"
 	ServingService_DeleteServing_FullMethodName           = "/kuscia.proto.api.v1alpha1.kusciaapi.ServingService/DeleteServing"
 	ServingService_BatchQueryServingStatus_FullMethodName = "/kuscia.proto.api.v1alpha1.kusciaapi.ServingService/BatchQueryServingStatus"
+	ServingService_CreateServingRevision_FullMethodName   = "/kuscia.proto.api.v1alpha1.kusciaapi.ServingService/CreateServingRevision"
+	ServingService_SwitchServingTraffic_FullMethodName    = "/kuscia.proto.api.v1alpha1.kusciaapi.ServingService/SwitchServingTraffic"
+	ServingService_RollbackServingTraffic_FullMethodName  = "/kuscia.proto.api.v1alpha1.kusciaapi.ServingService/RollbackServingTraffic"
 )
 
 // ServingServiceClient is the client API for ServingService service.
@@ -49,6 +52,9 @@ type ServingServiceClient interface {
 	UpdateServing(ctx context.Context, in *UpdateServingRequest, opts ...grpc.CallOption) (*UpdateServingResponse, error)
 	DeleteServing(ctx context.Context, in *DeleteServingRequest, opts ...grpc.CallOption) (*DeleteServingResponse, error)
 	BatchQueryServingStatus(ctx context.Context, in *BatchQueryServingStatusRequest, opts ...grpc.CallOption) (*BatchQueryServingStatusResponse, error)
+	CreateServingRevision(ctx context.Context, in *CreateServingRevisionRequest, opts ...grpc.CallOption) (*CreateServingRevisionResponse, error)
+	SwitchServingTraffic(ctx context.Context, in *SwitchServingTrafficRequest, opts ...grpc.CallOption) (*SwitchServingTrafficResponse, error)
+	RollbackServingTraffic(ctx context.Context, in *RollbackServingTrafficRequest, opts ...grpc.CallOption) (*RollbackServingTrafficResponse, error)
 }
 
 type servingServiceClient struct {
@@ -104,6 +110,33 @@ func (c *servingServiceClient) BatchQueryServingStatus(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *servingServiceClient) CreateServingRevision(ctx context.Context, in *CreateServingRevisionRequest, opts ...grpc.CallOption) (*CreateServingRevisionResponse, error) {
+	out := new(CreateServingRevisionResponse)
+	err := c.cc.Invoke(ctx, ServingService_CreateServingRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servingServiceClient) SwitchServingTraffic(ctx context.Context, in *SwitchServingTrafficRequest, opts ...grpc.CallOption) (*SwitchServingTrafficResponse, error) {
+	out := new(SwitchServingTrafficResponse)
+	err := c.cc.Invoke(ctx, ServingService_SwitchServingTraffic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servingServiceClient) RollbackServingTraffic(ctx context.Context, in *RollbackServingTrafficRequest, opts ...grpc.CallOption) (*RollbackServingTrafficResponse, error) {
+	out := new(RollbackServingTrafficResponse)
+	err := c.cc.Invoke(ctx, ServingService_RollbackServingTraffic_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServingServiceServer is the server API for ServingService service.
 // All implementations must embed UnimplementedServingServiceServer
 // for forward compatibility
@@ -113,6 +146,9 @@ type ServingServiceServer interface {
 	UpdateServing(context.Context, *UpdateServingRequest) (*UpdateServingResponse, error)
 	DeleteServing(context.Context, *DeleteServingRequest) (*DeleteServingResponse, error)
 	BatchQueryServingStatus(context.Context, *BatchQueryServingStatusRequest) (*BatchQueryServingStatusResponse, error)
+	CreateServingRevision(context.Context, *CreateServingRevisionRequest) (*CreateServingRevisionResponse, error)
+	SwitchServingTraffic(context.Context, *SwitchServingTrafficRequest) (*SwitchServingTrafficResponse, error)
+	RollbackServingTraffic(context.Context, *RollbackServingTrafficRequest) (*RollbackServingTrafficResponse, error)
 	mustEmbedUnimplementedServingServiceServer()
 }
 
@@ -135,6 +171,15 @@ func (UnimplementedServingServiceServer) DeleteServing(context.Context, *DeleteS
 func (UnimplementedServingServiceServer) BatchQueryServingStatus(context.Context, *BatchQueryServingStatusRequest) (*BatchQueryServingStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchQueryServingStatus not implemented")
 }
+func (UnimplementedServingServiceServer) CreateServingRevision(context.Context, *CreateServingRevisionRequest) (*CreateServingRevisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServingRevision not implemented")
+}
+func (UnimplementedServingServiceServer) SwitchServingTraffic(context.Context, *SwitchServingTrafficRequest) (*SwitchServingTrafficResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchServingTraffic not implemented")
+}
+func (UnimplementedServingServiceServer) RollbackServingTraffic(context.Context, *RollbackServingTrafficRequest) (*RollbackServingTrafficResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackServingTraffic not implemented")
+}
 func (UnimplementedServingServiceServer) mustEmbedUnimplementedServingServiceServer() {}
 
 // UnsafeServingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -238,6 +283,60 @@ func _ServingService_BatchQueryServingStatus_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServingService_CreateServingRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServingRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServingServiceServer).CreateServingRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServingService_CreateServingRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServingServiceServer).CreateServingRevision(ctx, req.(*CreateServingRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServingService_SwitchServingTraffic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchServingTrafficRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServingServiceServer).SwitchServingTraffic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServingService_SwitchServingTraffic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServingServiceServer).SwitchServingTraffic(ctx, req.(*SwitchServingTrafficRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServingService_RollbackServingTraffic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackServingTrafficRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServingServiceServer).RollbackServingTraffic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServingService_RollbackServingTraffic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServingServiceServer).RollbackServingTraffic(ctx, req.(*RollbackServingTrafficRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServingService_ServiceDesc is the grpc.ServiceDesc for ServingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +364,18 @@ var ServingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BatchQueryServingStatus",
 			Handler:    _ServingService_BatchQueryServingStatus_Handler,
 		},
+		{
+			MethodName: "CreateServingRevision",
+			Handler:    _ServingService_CreateServingRevision_Handler,
+		},
+		{
+			MethodName: "SwitchServingTraffic",
+			Handler:    _ServingService_SwitchServingTraffic_Handler,
+		},
+		{
+			MethodName: "RollbackServingTraffic",
+			Handler:    _ServingService_RollbackServingTraffic_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kuscia/proto/api/v1alpha1/kusciaapi/serving.proto",